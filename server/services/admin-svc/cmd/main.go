@@ -2,21 +2,36 @@ package main
 
 import (
 	"context"
+	"crypto/ed25519"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
+	"admin-svc/internal/alert"
+	"admin-svc/internal/cron"
+	"admin-svc/internal/domain"
+	"admin-svc/internal/export"
+	admingrpc "admin-svc/internal/grpc"
 	"admin-svc/internal/handler"
 	"admin-svc/internal/middleware"
+	"admin-svc/internal/repository"
 	"admin-svc/internal/service"
+	"admin-svc/internal/webauthn"
 
 	"github.com/gin-gonic/gin"
 	consulapi "github.com/hashicorp/consul/api"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/grpc"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/grpc/interceptor"
+	adminv1 "github.com/xiaoxiao0301/listen-stream-v2/server/shared/proto/admin/v1"
+	authv1 "github.com/xiaoxiao0301/listen-stream-v2/server/shared/proto/auth/v1"
+	userv1 "github.com/xiaoxiao0301/listen-stream-v2/server/shared/proto/user/v1"
 )
 
 func main() {
@@ -44,18 +59,165 @@ func main() {
 	}
 	log.Println("Connected to Consul")
 
+	// 初始化PostgreSQL（管理员、角色、每日统计、操作日志和异常活动）
+	dsn := os.Getenv("POSTGRES_DSN")
+	if dsn == "" {
+		log.Fatal("POSTGRES_DSN is required")
+	}
+	db, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		log.Fatalf("failed to create postgres pool: %v", err)
+	}
+	defer db.Close()
+	if err := db.Ping(ctx); err != nil {
+		log.Fatalf("failed to connect to postgres: %v", err)
+	}
+	log.Println("Connected to PostgreSQL")
+
 	// 初始化服务
 	totpSvc := service.NewTOTPService("Listen Stream Admin")
 	configSvc := service.NewConfigService(consulClient, redisClient, "listen-stream/")
-	auditSvc := service.NewAuditService(redisClient)
-	statsSvc := service.NewStatsService(redisClient)
+
+	// 异常告警投递（未配置ALERT_CONFIG时没有告警通道，异常只落库和发布到Redis）
+	alertCfg, err := alert.LoadConfig(os.Getenv("ALERT_CONFIG"))
+	if err != nil {
+		log.Fatalf("failed to load alert config: %v", err)
+	}
+	alertSinks, err := alert.NewSinks(alertCfg)
+	if err != nil {
+		log.Fatalf("failed to create alert sinks: %v", err)
+	}
+	log.Printf("Alert sinks configured: %d", len(alertSinks))
+	anomalyRepo := repository.NewAnomalyRepository(db)
+	alertSvc := service.NewAlertService(anomalyRepo, repository.NewAlertDeliveryRepository(db), redisClient, alertCfg, alertSinks)
+
+	// 操作日志哈希链检查点签名（未配置时不生成检查点）
+	var checkpointKey ed25519.PrivateKey
+	if encoded := os.Getenv("AUDIT_CHECKPOINT_KEY"); encoded != "" {
+		if checkpointKey, err = service.ParseCheckpointKey(encoded); err != nil {
+			log.Fatalf("invalid AUDIT_CHECKPOINT_KEY: %v", err)
+		}
+	} else {
+		log.Println("Warning: AUDIT_CHECKPOINT_KEY not set, operation log checkpoints will not be signed")
+	}
+	// 密钥轮换前的历史公钥，用于校验旧检查点
+	trustedCheckpointKeys, err := service.ParseTrustedCheckpointKeys(os.Getenv("AUDIT_CHECKPOINT_TRUSTED_KEYS"))
+	if err != nil {
+		log.Fatalf("invalid AUDIT_CHECKPOINT_TRUSTED_KEYS: %v", err)
+	}
+	operationLogRepo := repository.NewOperationLogRepository(db)
+	chainSvc := service.NewAuditChainService(operationLogRepo, repository.NewAuditCheckpointRepository(db), checkpointKey, trustedCheckpointKeys)
+
+	auditSvc := service.NewAuditService(redisClient, operationLogRepo, anomalyRepo, alertSvc)
+	statsSvc := service.NewStatsService(redisClient, repository.NewDailyStatsRepository(db))
+	adminUserRepo := repository.NewAdminUserRepository(db)
+	rbacSvc := service.NewRBACService(repository.NewRoleRepository(db), adminUserRepo, auditSvc)
+
+	// 管理员会话和第二因素：ADMIN_REQUIRE_2FA默认开启，未配置ADMIN_WEBAUTHN_RP_ID时不支持安全密钥
+	adminJWTSecret := os.Getenv("ADMIN_JWT_SECRET")
+	if adminJWTSecret == "" {
+		log.Fatal("ADMIN_JWT_SECRET is required")
+	}
+	backupCodeKey := os.Getenv("ADMIN_BACKUP_CODE_KEY")
+	if backupCodeKey == "" {
+		log.Fatal("ADMIN_BACKUP_CODE_KEY is required")
+	}
+	sessionSvc := service.NewAdminSessionService(repository.NewAdminSessionRepository(db), adminUserRepo, adminJWTSecret, auditSvc, service.SessionConfig{
+		TTL:         mustParseDuration(getEnv("ADMIN_SESSION_TTL", "12h")),
+		IdleTimeout: mustParseDuration(getEnv("ADMIN_SESSION_IDLE_TIMEOUT", "30m")),
+	})
+	mfaConfig := service.MFAConfig{
+		Required:      os.Getenv("ADMIN_REQUIRE_2FA") != "false",
+		BackupCodeKey: []byte(backupCodeKey),
+	}
+	if rpID := os.Getenv("ADMIN_WEBAUTHN_RP_ID"); rpID != "" {
+		origins := splitEnvList("ADMIN_WEBAUTHN_ORIGINS")
+		if len(origins) == 0 {
+			origins = []string{"https://" + rpID}
+		}
+		mfaConfig.WebAuthn, err = webauthn.New(webauthn.Config{
+			RPID:    rpID,
+			RPName:  "Listen Stream Admin",
+			Origins: origins,
+		})
+		if err != nil {
+			log.Fatalf("failed to configure webauthn: %v", err)
+		}
+	}
+	if !mfaConfig.Required {
+		log.Println("Warning: ADMIN_REQUIRE_2FA=false, admins can use the API without a second factor")
+	}
+	mfaSvc := service.NewMFAService(
+		adminUserRepo, repository.NewBackupCodeRepository(db), repository.NewWebAuthnCredentialRepository(db),
+		sessionSvc, totpSvc, redisClient, auditSvc, mfaConfig,
+	)
+	adminAuthSvc := service.NewAdminAuthService(adminUserRepo, mfaSvc, sessionSvc, redisClient, auditSvc)
 	exportSvc := service.NewExportService()
+	configHistoryRepo := repository.NewConfigHistoryRepository(db)
+	featureFlagSvc := service.NewFeatureFlagService(configSvc, configHistoryRepo, auditSvc)
+	// 配置变更：CONFIG_APPROVAL_PREFIXES（逗号分隔）下的配置需要审批
+	configChangeSvc := service.NewConfigChangeService(
+		configSvc, repository.NewConfigChangeRepository(db), configHistoryRepo, auditSvc,
+		splitEnvList("CONFIG_APPROVAL_PREFIXES"),
+	)
+
+	// 连接auth-svc和user-svc（终端用户管理）
+	authConn, err := grpc.NewClient(ctx, grpc.DefaultClientConfig(getEnv("AUTH_SVC_ADDR", "localhost:9001")))
+	if err != nil {
+		log.Fatalf("failed to connect to auth-svc: %v", err)
+	}
+	defer authConn.Close()
+	userConn, err := grpc.NewClient(ctx, grpc.DefaultClientConfig(getEnv("USER_SVC_ADDR", "localhost:9003")))
+	if err != nil {
+		log.Fatalf("failed to connect to user-svc: %v", err)
+	}
+	defer userConn.Close()
+	userAdminSvc := service.NewUserAdminService(authv1.NewAuthServiceClient(authConn), userv1.NewUserServiceClient(userConn), auditSvc)
+
+	// 导出文件下载链接签名
+	signingKey := os.Getenv("EXPORT_SIGNING_KEY")
+	if signingKey == "" {
+		log.Fatal("EXPORT_SIGNING_KEY is required")
+	}
+	exportLinkSvc := service.NewExportLinkService(
+		getEnv("EXPORT_DIR", os.TempDir()),
+		getEnv("EXPORT_BASE_URL", "http://localhost:"+getEnv("HTTP_PORT", "8005")),
+		signingKey,
+	)
+
+	// 异步导出任务（文件保存到本地目录或S3兼容存储）
+	exportStorage, err := newExportStorage(exportLinkSvc)
+	if err != nil {
+		log.Fatalf("failed to create export storage: %v", err)
+	}
+	exportJobSvc := service.NewExportJobService(
+		repository.NewExportJobRepository(db), operationLogRepo, statsSvc, userAdminSvc, auditSvc, exportStorage,
+		service.ExportJobConfig{
+			Workers:   mustParseInt(getEnv("EXPORT_WORKERS", "2")),
+			TempDir:   os.Getenv("EXPORT_TMP_DIR"),
+			LinkTTL:   mustParseDuration(getEnv("EXPORT_LINK_TTL", "1h")),
+			Retention: mustParseDuration(getEnv("EXPORT_RETENTION", "168h")),
+		},
+	)
+	exportJobSvc.Start()
+	defer exportJobSvc.Stop()
+
+	// 每日统计落库、告警重试和升级、操作日志检查点、过期导出文件和管理员会话清理
+	cronManager := cron.NewCronManager(statsSvc, alertSvc, chainSvc, exportJobSvc, sessionSvc)
+	if err := cronManager.Start(); err != nil {
+		log.Fatalf("failed to start cron manager: %v", err)
+	}
+	defer cronManager.Stop()
 
 	// 初始化处理器
-	adminHandler := handler.NewAdminHandler(totpSvc, auditSvc)
-	configHandler := handler.NewConfigHandler(configSvc, auditSvc)
+	adminHandler := handler.NewAdminHandler(adminAuthSvc, mfaSvc, sessionSvc)
+	configHandler := handler.NewConfigHandler(configSvc, configChangeSvc)
+	featureFlagHandler := handler.NewFeatureFlagHandler(featureFlagSvc)
 	statsHandler := handler.NewStatsHandler(statsSvc, exportSvc)
-	auditHandler := handler.NewAuditHandler(auditSvc, exportSvc)
+	auditHandler := handler.NewAuditHandler(auditSvc, exportJobSvc, alertSvc, chainSvc)
+	exportHandler := handler.NewExportHandler(exportLinkSvc, exportJobSvc)
+	userHandler := handler.NewUserHandler(userAdminSvc)
+	roleHandler := handler.NewRoleHandler(rbacSvc)
 
 	// 创建Gin路由
 	gin.SetMode(gin.ReleaseMode)
@@ -73,46 +235,134 @@ func main() {
 		})
 	})
 
-	// API路由（需要认证）
-	api := router.Group("/api/v1")
-	api.Use(middleware.JWTAuth())
+	// 导出文件下载（链接自带签名）
+	router.GET("/exports/:filename", exportHandler.Download)
+
+	// 管理员登录（无需认证）
+	login := router.Group("/api/v1/admins/login")
+	{
+		login.POST("", adminHandler.Login)
+		login.POST("/verify", adminHandler.VerifyLogin)
+		login.POST("/webauthn/options", adminHandler.WebAuthnLoginOptions)
+	}
+
+	// 绑定第二因素和退出登录（只需要有效会话，强制2FA时未绑定的管理员登录后只能访问这些接口）
+	authed := router.Group("/api/v1", middleware.JWTAuth(sessionSvc))
+	account := authed.Group("/admins")
+	{
+		account.GET("/me/security", adminHandler.Security)
+		account.POST("/2fa/enable", adminHandler.Enable2FA)
+		account.POST("/2fa/verify", adminHandler.Verify2FA)
+		account.POST("/webauthn/register/options", adminHandler.WebAuthnRegisterOptions)
+		account.POST("/webauthn/register", adminHandler.RegisterWebAuthn)
+		account.POST("/logout", adminHandler.Logout)
+	}
+
+	// API路由（需要认证和第二因素，除个人安全设置外按权限授权）
+	perm := func(permission string) gin.HandlerFunc {
+		return middleware.RequirePermission(rbacSvc, permission)
+	}
+	api := authed.Group("", middleware.RequireSecondFactor(mfaSvc.Required()))
 	{
 		// 管理员管理
 		admins := api.Group("/admins")
 		{
-			admins.POST("/login", adminHandler.Login)
-			admins.GET("", adminHandler.ListAdmins)
-			admins.POST("/2fa/enable", adminHandler.Enable2FA)
-			admins.POST("/2fa/verify", adminHandler.Verify2FA)
+			admins.GET("", perm(domain.PermAdminView), adminHandler.ListAdmins)
+			admins.PUT("/:id/role", perm(domain.PermRoleManage), roleHandler.AssignRole)
 			admins.POST("/2fa/disable", adminHandler.Disable2FA)
+			admins.POST("/backup-codes", adminHandler.RegenerateBackupCodes)
+			admins.GET("/webauthn/credentials", adminHandler.ListWebAuthnCredentials)
+			admins.DELETE("/webauthn/credentials/:id", adminHandler.DeleteWebAuthnCredential)
+			admins.GET("/sessions", adminHandler.ListSessions)
+			admins.DELETE("/sessions/:id", adminHandler.RevokeSession)
+			admins.POST("/sessions/revoke-others", adminHandler.RevokeOtherSessions)
+			admins.GET("/:id/sessions", perm(domain.PermAdminSession), adminHandler.ListAdminSessions)
+			admins.POST("/:id/sessions/revoke", perm(domain.PermAdminSession), adminHandler.RevokeAdminSessions)
+		}
+
+		// 角色与权限
+		api.GET("/permissions", perm(domain.PermAdminView), roleHandler.ListPermissions)
+		roles := api.Group("/roles")
+		{
+			roles.GET("", perm(domain.PermAdminView), roleHandler.ListRoles)
+			roles.GET("/:name", perm(domain.PermAdminView), roleHandler.GetRole)
+			roles.POST("", perm(domain.PermRoleManage), roleHandler.CreateRole)
+			roles.PUT("/:name", perm(domain.PermRoleManage), roleHandler.UpdateRole)
+			roles.DELETE("/:name", perm(domain.PermRoleManage), roleHandler.DeleteRole)
 		}
 
 		// 配置管理
 		configs := api.Group("/configs")
 		{
-			configs.GET("", configHandler.ListConfigs)
-			configs.GET("/:key", configHandler.GetConfig)
-			configs.PUT("/:key", configHandler.UpdateConfig)
-			configs.DELETE("/:key", configHandler.DeleteConfig)
-			configs.POST("/cache/clear", configHandler.ClearConfigCache)
-			configs.GET("/:key/history", configHandler.GetConfigHistory)
+			configs.GET("", perm(domain.PermConfigView), configHandler.ListConfigs)
+			configs.GET("/history", perm(domain.PermConfigView), configHandler.ListConfigHistory)
+			configs.POST("/history/:id/rollback", perm(domain.PermConfigEdit), configHandler.RollbackConfig)
+			configs.GET("/changes", perm(domain.PermConfigView), configHandler.ListConfigChanges)
+			configs.POST("/changes", perm(domain.PermConfigEdit), configHandler.SubmitConfigChange)
+			configs.POST("/changes/preview", perm(domain.PermConfigEdit), configHandler.PreviewConfigChange)
+			configs.GET("/changes/:id", perm(domain.PermConfigView), configHandler.GetConfigChange)
+			configs.POST("/changes/:id/approve", perm(domain.PermConfigApprove), configHandler.ApproveConfigChange)
+			configs.POST("/changes/:id/reject", perm(domain.PermConfigApprove), configHandler.RejectConfigChange)
+			configs.POST("/changes/:id/cancel", perm(domain.PermConfigEdit), configHandler.CancelConfigChange)
+			configs.GET("/:key", perm(domain.PermConfigView), configHandler.GetConfig)
+			configs.PUT("/:key", perm(domain.PermConfigEdit), configHandler.UpdateConfig)
+			configs.DELETE("/:key", perm(domain.PermConfigEdit), configHandler.DeleteConfig)
+			configs.POST("/cache/clear", perm(domain.PermConfigEdit), configHandler.ClearConfigCache)
+			configs.GET("/:key/history", perm(domain.PermConfigView), configHandler.GetConfigHistory)
+		}
+
+		// 功能开关
+		flags := api.Group("/feature-flags")
+		{
+			flags.GET("", perm(domain.PermFlagView), featureFlagHandler.ListFeatureFlags)
+			flags.POST("", perm(domain.PermFlagEdit), featureFlagHandler.CreateFeatureFlag)
+			flags.GET("/:key", perm(domain.PermFlagView), featureFlagHandler.GetFeatureFlag)
+			flags.PUT("/:key", perm(domain.PermFlagEdit), featureFlagHandler.UpdateFeatureFlag)
+			flags.DELETE("/:key", perm(domain.PermFlagEdit), featureFlagHandler.DeleteFeatureFlag)
+			flags.GET("/:key/evaluate", perm(domain.PermFlagView), featureFlagHandler.EvaluateFeatureFlag)
 		}
 
 		// 统计
 		stats := api.Group("/stats")
 		{
-			stats.GET("/realtime", statsHandler.GetRealtimeStats)
-			stats.GET("/daily", statsHandler.GetDailyStats)
-			stats.GET("/daily/export", statsHandler.ExportDailyStats)
+			stats.GET("/realtime", perm(domain.PermStatsView), statsHandler.GetRealtimeStats)
+			stats.GET("/daily", perm(domain.PermStatsView), statsHandler.GetDailyStats)
+			stats.GET("/daily/export", perm(domain.PermStatsExport), statsHandler.ExportDailyStats)
+		}
+
+		// 终端用户管理
+		users := api.Group("/users")
+		{
+			users.GET("", perm(domain.PermUserView), userHandler.SearchUsers)
+			users.GET("/:id", perm(domain.PermUserView), userHandler.GetUser)
+			users.GET("/:id/activity", perm(domain.PermUserView), userHandler.GetUserActivity)
+			users.POST("/:id/disable", perm(domain.PermUserManage), userHandler.DisableUser)
+			users.POST("/:id/enable", perm(domain.PermUserManage), userHandler.EnableUser)
+			users.POST("/:id/sessions/revoke", perm(domain.PermUserManage), userHandler.RevokeSessions)
+			users.DELETE("/:id/devices/:device_id", perm(domain.PermUserManage), userHandler.RemoveDevice)
+		}
+
+		// 异步导出（只能查看自己创建的任务）
+		exports := api.Group("/exports")
+		{
+			exports.POST("/operation-logs", perm(domain.PermAuditExport), exportHandler.CreateOperationLogExport)
+			exports.POST("/daily-stats", perm(domain.PermStatsExport), exportHandler.CreateDailyStatsExport)
+			exports.POST("/users", perm(domain.PermUserExport), exportHandler.CreateUserExport)
+			exports.GET("", exportHandler.ListExportJobs)
+			exports.GET("/:id", exportHandler.GetExportJob)
 		}
 
 		// 审计日志
 		audit := api.Group("/audit")
 		{
-			audit.GET("/logs", auditHandler.ListOperationLogs)
-			audit.GET("/logs/export", auditHandler.ExportOperationLogs)
-			audit.GET("/anomalies", auditHandler.ListAnomalousActivities)
-			audit.POST("/anomalies/:id/resolve", auditHandler.ResolveAnomalousActivity)
+			audit.GET("/logs", perm(domain.PermAuditView), auditHandler.ListOperationLogs)
+			audit.GET("/logs/export", perm(domain.PermAuditExport), auditHandler.ExportOperationLogs)
+			audit.GET("/anomalies", perm(domain.PermAuditView), auditHandler.ListAnomalousActivities)
+			audit.POST("/anomalies/:id/resolve", perm(domain.PermAuditResolve), auditHandler.ResolveAnomalousActivity)
+			audit.GET("/anomalies/:id/deliveries", perm(domain.PermAuditView), auditHandler.ListAlertDeliveries)
+			audit.POST("/deliveries/:id/retry", perm(domain.PermAuditResolve), auditHandler.RetryAlertDelivery)
+			audit.GET("/chain/verify", perm(domain.PermAuditView), auditHandler.VerifyLogChain)
+			audit.GET("/chain/checkpoints", perm(domain.PermAuditView), auditHandler.ListLogCheckpoints)
 		}
 	}
 
@@ -126,6 +376,13 @@ func main() {
 		IdleTimeout:  60 * time.Second,
 	}
 
+	// 启动gRPC服务器（供内部运维工具调用）
+	grpcPort := mustParseInt(getEnv("GRPC_PORT", "9005"))
+	grpcServer, err := startGRPCServer(grpcPort, admingrpc.NewAdminServer(statsSvc, auditSvc, exportJobSvc, userAdminSvc))
+	if err != nil {
+		log.Fatalf("failed to start grpc server: %v", err)
+	}
+
 	// 服务注册到Consul
 	instanceID := fmt.Sprintf("admin-svc-%s", getHostname())
 	registration := &consulapi.AgentServiceRegistration{
@@ -173,10 +430,66 @@ func main() {
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Printf("Server forced to shutdown: %v", err)
 	}
+	if err := grpcServer.Shutdown(ctx); err != nil {
+		log.Printf("gRPC server forced to shutdown: %v", err)
+	}
 
 	log.Println("Server exited")
 }
 
+// startGRPCServer 启动AdminService gRPC服务器
+// 调用方需携带ADMIN_GRPC_TOKEN作为Bearer Token，未配置时拒绝所有请求
+func startGRPCServer(port int, adminServer *admingrpc.AdminServer) (*grpc.Server, error) {
+	token := os.Getenv("ADMIN_GRPC_TOKEN")
+	if token == "" {
+		log.Println("Warning: ADMIN_GRPC_TOKEN not set, all gRPC calls will be rejected")
+	}
+
+	config := grpc.DefaultServerConfig("admin-svc", port)
+	config.UnaryInterceptors = append(config.UnaryInterceptors,
+		interceptor.NewRecoveryInterceptor().UnaryServerInterceptor(),
+		interceptor.NewLoggingInterceptor(nil).UnaryServerInterceptor(),
+		interceptor.NewAuthInterceptor(admingrpc.TokenValidator(token)).
+			WithSkipMethods("/grpc.health.v1.Health/").
+			UnaryServerInterceptor(),
+	)
+
+	server, err := grpc.NewServer(config)
+	if err != nil {
+		return nil, fmt.Errorf("create grpc server: %w", err)
+	}
+	adminv1.RegisterAdminServiceServer(server.Server, adminServer)
+
+	go func() {
+		log.Printf("admin-svc gRPC server started on :%d", port)
+		if err := server.Serve(); err != nil {
+			log.Fatalf("grpc serve: %v", err)
+		}
+	}()
+
+	return server, nil
+}
+
+// newExportStorage 按EXPORT_STORAGE创建导出文件存储：local（默认，EXPORT_DIR）或s3
+func newExportStorage(linkSvc *service.ExportLinkService) (export.Storage, error) {
+	switch storage := getEnv("EXPORT_STORAGE", "local"); storage {
+	case "local":
+		return export.NewLocalStorage(getEnv("EXPORT_DIR", os.TempDir()), linkSvc)
+	case "s3":
+		return export.NewS3Storage(export.S3Config{
+			Endpoint:     os.Getenv("EXPORT_S3_ENDPOINT"),
+			Region:       os.Getenv("EXPORT_S3_REGION"),
+			Bucket:       os.Getenv("EXPORT_S3_BUCKET"),
+			AccessKey:    os.Getenv("EXPORT_S3_ACCESS_KEY"),
+			SecretKey:    os.Getenv("EXPORT_S3_SECRET_KEY"),
+			Prefix:       os.Getenv("EXPORT_S3_PREFIX"),
+			UsePathStyle: os.Getenv("EXPORT_S3_PATH_STYLE") == "true",
+		})
+	default:
+		return nil, fmt.Errorf("unknown EXPORT_STORAGE %q", storage)
+	}
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -184,6 +497,17 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// splitEnvList 读取逗号分隔的环境变量，忽略空项
+func splitEnvList(key string) []string {
+	var items []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func getHostname() string {
 	hostname, _ := os.Hostname()
 	if hostname == "" {
@@ -202,3 +526,11 @@ func mustParseInt(s string) int {
 	fmt.Sscanf(s, "%d", &i)
 	return i
 }
+
+func mustParseDuration(s string) time.Duration {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		log.Fatalf("invalid duration %q: %v", s, err)
+	}
+	return d
+}
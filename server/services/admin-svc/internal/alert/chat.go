@@ -0,0 +1,110 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ChatSink 聊天机器人Webhook（Slack、钉钉、飞书）
+type ChatSink struct {
+	name   string
+	format string
+	url    string
+	secret string
+	client *http.Client
+}
+
+// NewChatSink 创建聊天机器人通道，format为slack、dingtalk或feishu
+// secret为钉钉加签或飞书签名校验密钥，为空时不签名（Slack不使用）
+func NewChatSink(name, format, url, secret string, client *http.Client) *ChatSink {
+	return &ChatSink{name: name, format: format, url: url, secret: secret, client: client}
+}
+
+// Name 通道名称
+func (s *ChatSink) Name() string {
+	return s.name
+}
+
+// Send 投递告警
+func (s *ChatSink) Send(ctx context.Context, msg *Message) error {
+	text := msg.Title() + "\n" + msg.Text()
+	target := s.url
+
+	var payload map[string]interface{}
+	switch s.format {
+	case SinkSlack:
+		payload = map[string]interface{}{"text": text}
+	case SinkDingTalk:
+		payload = map[string]interface{}{
+			"msgtype": "text",
+			"text":    map[string]string{"content": text},
+		}
+		if s.secret != "" {
+			// 钉钉加签：签名放在URL参数中
+			ts := strconv.FormatInt(time.Now().UnixMilli(), 10)
+			mac := hmac.New(sha256.New, []byte(s.secret))
+			mac.Write([]byte(ts + "\n" + s.secret))
+			sign := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+			target = fmt.Sprintf("%s&timestamp=%s&sign=%s", s.url, ts, url.QueryEscape(sign))
+		}
+	case SinkFeishu:
+		payload = map[string]interface{}{
+			"msg_type": "text",
+			"content":  map[string]string{"text": text},
+		}
+		if s.secret != "" {
+			// 飞书签名：以timestamp+"\n"+secret为密钥对空串做HMAC
+			ts := strconv.FormatInt(time.Now().Unix(), 10)
+			mac := hmac.New(sha256.New, []byte(ts+"\n"+s.secret))
+			payload["timestamp"] = ts
+			payload["sign"] = base64.StdEncoding.EncodeToString(mac.Sum(nil))
+		}
+	default:
+		return fmt.Errorf("unknown chat format %q", s.format)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal chat message: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	respBody, err := postJSON(s.client, req)
+	if err != nil {
+		return err
+	}
+	if s.format == SinkSlack {
+		return nil
+	}
+
+	// 钉钉和飞书出错时仍返回200，错误码在响应体中
+	var result struct {
+		ErrCode int    `json:"errcode"` // 钉钉
+		ErrMsg  string `json:"errmsg"`
+		Code    int    `json:"code"` // 飞书
+		Msg     string `json:"msg"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return fmt.Errorf("decode %s response: %w", s.format, err)
+	}
+	if result.ErrCode != 0 {
+		return fmt.Errorf("%s error %d: %s", s.format, result.ErrCode, result.ErrMsg)
+	}
+	if result.Code != 0 {
+		return fmt.Errorf("%s error %d: %s", s.format, result.Code, result.Msg)
+	}
+	return nil
+}
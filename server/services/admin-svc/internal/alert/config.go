@@ -0,0 +1,172 @@
+package alert
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"admin-svc/internal/domain"
+)
+
+// Sink类型
+const (
+	SinkWebhook  = "webhook"  // 通用JSON Webhook（HMAC-SHA256签名）
+	SinkEmail    = "email"    // SMTP邮件
+	SinkSlack    = "slack"    // Slack Incoming Webhook
+	SinkDingTalk = "dingtalk" // 钉钉群机器人（可选加签）
+	SinkFeishu   = "feishu"   // 飞书群机器人（可选签名校验）
+)
+
+// Config 告警投递配置（JSON文件，路径由ALERT_CONFIG指定）
+// 文件内容中的${VAR}会在解析前替换为环境变量，密钥不必写在文件里
+type Config struct {
+	// DedupWindow 同一管理员的同类异常在窗口内只告警一次（异常本身仍会记录）
+	DedupWindow Duration         `json:"dedup_window"`
+	Retry       RetryConfig      `json:"retry"`
+	Escalation  EscalationConfig `json:"escalation"`
+	Sinks       []SinkConfig     `json:"sinks"`
+}
+
+// RetryConfig 投递失败重试（指数退避）
+type RetryConfig struct {
+	MaxAttempts int      `json:"max_attempts"` // 包括首次投递
+	Backoff     Duration `json:"backoff"`      // 第一次重试的等待时间，之后每次翻倍
+	MaxBackoff  Duration `json:"max_backoff"`
+}
+
+// EscalationConfig 未处理异常的升级告警
+type EscalationConfig struct {
+	After       Duration `json:"after"`        // 异常创建后多久仍未处理时第一次升级，为0时不升级
+	Interval    Duration `json:"interval"`     // 之后每次升级的间隔
+	MaxLevel    int      `json:"max_level"`    // 最多升级次数
+	MinSeverity string   `json:"min_severity"` // 只升级不低于该严重程度的异常
+	Sinks       []string `json:"sinks"`        // 升级告警发送到的通道，为空时沿用首次告警的路由
+}
+
+// SinkConfig 告警通道配置，按Type使用对应字段
+type SinkConfig struct {
+	Name        string   `json:"name"`
+	Type        string   `json:"type"`
+	MinSeverity string   `json:"min_severity"` // 只接收不低于该严重程度的告警，为空时接收全部
+	Timeout     Duration `json:"timeout"`
+
+	// webhook、slack、dingtalk、feishu
+	URL    string `json:"url,omitempty"`
+	Secret string `json:"secret,omitempty"` // webhook签名密钥，钉钉/飞书加签密钥
+
+	// email
+	SMTPAddr string   `json:"smtp_addr,omitempty"` // host:port
+	Username string   `json:"username,omitempty"`
+	Password string   `json:"password,omitempty"`
+	From     string   `json:"from,omitempty"`
+	To       []string `json:"to,omitempty"`
+}
+
+// Accepts 判断通道是否接收该严重程度的告警
+func (c *SinkConfig) Accepts(severity string) bool {
+	return c.MinSeverity == "" || domain.SeverityRank(severity) >= domain.SeverityRank(c.MinSeverity)
+}
+
+// DefaultConfig 默认配置：没有告警通道，异常只记录和发布到Redis
+func DefaultConfig() *Config {
+	return &Config{
+		DedupWindow: Duration(10 * time.Minute),
+		Retry: RetryConfig{
+			MaxAttempts: 5,
+			Backoff:     Duration(30 * time.Second),
+			MaxBackoff:  Duration(30 * time.Minute),
+		},
+		Escalation: EscalationConfig{
+			Interval:    Duration(time.Hour),
+			MaxLevel:    3,
+			MinSeverity: domain.SeverityHigh,
+		},
+	}
+}
+
+// LoadConfig 读取告警配置，path为空时返回默认配置
+func LoadConfig(path string) (*Config, error) {
+	cfg := DefaultConfig()
+	if path == "" {
+		return cfg, nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read alert config: %w", err)
+	}
+	if err := json.Unmarshal([]byte(os.ExpandEnv(string(raw))), cfg); err != nil {
+		return nil, fmt.Errorf("parse alert config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Validate 校验配置
+func (c *Config) Validate() error {
+	if c.Retry.MaxAttempts < 1 {
+		return fmt.Errorf("alert config: retry.max_attempts must be at least 1")
+	}
+
+	names := make(map[string]bool, len(c.Sinks))
+	for i, s := range c.Sinks {
+		if s.Name == "" {
+			return fmt.Errorf("alert config: sinks[%d] has no name", i)
+		}
+		if names[s.Name] {
+			return fmt.Errorf("alert config: duplicate sink %q", s.Name)
+		}
+		names[s.Name] = true
+
+		if s.MinSeverity != "" && domain.SeverityRank(s.MinSeverity) == 0 {
+			return fmt.Errorf("alert config: sink %q has unknown min_severity %q", s.Name, s.MinSeverity)
+		}
+		switch s.Type {
+		case SinkWebhook, SinkSlack, SinkDingTalk, SinkFeishu:
+			if s.URL == "" {
+				return fmt.Errorf("alert config: sink %q requires url", s.Name)
+			}
+		case SinkEmail:
+			if s.SMTPAddr == "" || s.From == "" || len(s.To) == 0 {
+				return fmt.Errorf("alert config: sink %q requires smtp_addr, from and to", s.Name)
+			}
+		default:
+			return fmt.Errorf("alert config: sink %q has unknown type %q", s.Name, s.Type)
+		}
+	}
+
+	if c.Escalation.MinSeverity != "" && domain.SeverityRank(c.Escalation.MinSeverity) == 0 {
+		return fmt.Errorf("alert config: escalation has unknown min_severity %q", c.Escalation.MinSeverity)
+	}
+	for _, name := range c.Escalation.Sinks {
+		if !names[name] {
+			return fmt.Errorf("alert config: escalation sink %q is not defined", name)
+		}
+	}
+	return nil
+}
+
+// Duration 支持"30s"、"10m"格式的时长
+type Duration time.Duration
+
+// UnmarshalJSON 解析时长字符串
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("duration must be a string like \"30s\": %w", err)
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// MarshalJSON 输出时长字符串
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
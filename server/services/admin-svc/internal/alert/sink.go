@@ -0,0 +1,74 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"admin-svc/internal/domain"
+)
+
+// defaultSinkTimeout 通道未配置超时时的单次投递超时
+const defaultSinkTimeout = 10 * time.Second
+
+// Message 一条待投递的告警
+type Message struct {
+	Kind            string                    `json:"kind"`             // alert, escalation
+	EscalationLevel int                       `json:"escalation_level"` // 首次告警为0
+	Anomaly         *domain.AnomalousActivity `json:"anomaly"`
+}
+
+// Title 告警标题
+func (m *Message) Title() string {
+	title := fmt.Sprintf("[%s] 管理后台异常活动: %s", strings.ToUpper(m.Anomaly.Severity), m.Anomaly.Type)
+	if m.Kind == domain.AlertKindEscalation {
+		title = fmt.Sprintf("[升级#%d] %s（仍未处理）", m.EscalationLevel, title)
+	}
+	return title
+}
+
+// Text 告警正文（纯文本，邮件和聊天消息共用）
+func (m *Message) Text() string {
+	a := m.Anomaly
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n", a.Description)
+	fmt.Fprintf(&b, "管理员: %s (%s)\n", a.AdminName, a.AdminID)
+	fmt.Fprintf(&b, "严重程度: %s\n", a.Severity)
+	fmt.Fprintf(&b, "发生时间: %s\n", a.CreatedAt.Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(&b, "异常ID: %s", a.ID)
+	return b.String()
+}
+
+// Sink 告警通道
+type Sink interface {
+	// Name 配置中的通道名称
+	Name() string
+	// Send 投递一条告警，返回错误时由调用方重试
+	Send(ctx context.Context, msg *Message) error
+}
+
+// NewSinks 按配置创建所有告警通道
+func NewSinks(cfg *Config) (map[string]Sink, error) {
+	sinks := make(map[string]Sink, len(cfg.Sinks))
+	for _, sc := range cfg.Sinks {
+		timeout := time.Duration(sc.Timeout)
+		if timeout <= 0 {
+			timeout = defaultSinkTimeout
+		}
+		client := &http.Client{Timeout: timeout}
+
+		switch sc.Type {
+		case SinkWebhook:
+			sinks[sc.Name] = NewWebhookSink(sc.Name, sc.URL, sc.Secret, client)
+		case SinkSlack, SinkDingTalk, SinkFeishu:
+			sinks[sc.Name] = NewChatSink(sc.Name, sc.Type, sc.URL, sc.Secret, client)
+		case SinkEmail:
+			sinks[sc.Name] = NewEmailSink(sc.Name, sc.SMTPAddr, sc.Username, sc.Password, sc.From, sc.To, timeout)
+		default:
+			return nil, fmt.Errorf("unknown sink type %q", sc.Type)
+		}
+	}
+	return sinks, nil
+}
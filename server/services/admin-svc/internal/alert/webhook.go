@@ -0,0 +1,86 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Webhook签名请求头
+// 签名 = hex(HMAC-SHA256(secret, timestamp + "." + body))，接收方应校验时间戳防止重放
+const (
+	HeaderTimestamp = "X-Alert-Timestamp"
+	HeaderSignature = "X-Alert-Signature"
+)
+
+// WebhookSink 通用JSON Webhook，请求体为Message
+type WebhookSink struct {
+	name   string
+	url    string
+	secret string
+	client *http.Client
+}
+
+// NewWebhookSink 创建Webhook通道，secret为空时不签名
+func NewWebhookSink(name, url, secret string, client *http.Client) *WebhookSink {
+	return &WebhookSink{name: name, url: url, secret: secret, client: client}
+}
+
+// Name 通道名称
+func (s *WebhookSink) Name() string {
+	return s.name
+}
+
+// Send 投递告警
+func (s *WebhookSink) Send(ctx context.Context, msg *Message) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("marshal alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.secret != "" {
+		ts := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(HeaderTimestamp, ts)
+		req.Header.Set(HeaderSignature, Sign(s.secret, ts, body))
+	}
+
+	_, err = postJSON(s.client, req)
+	return err
+}
+
+// Sign 计算Webhook签名
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// postJSON 发送请求并返回响应体（最多4KB），非2xx响应视为失败
+func postJSON(client *http.Client, req *http.Request) ([]byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("post: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, respBody)
+	}
+	return respBody, nil
+}
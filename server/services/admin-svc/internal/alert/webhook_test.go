@@ -0,0 +1,100 @@
+package alert
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"admin-svc/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// capturedRequest Webhook接收方收到的请求
+type capturedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+func newWebhookReceiver(t *testing.T, status int) (*httptest.Server, chan capturedRequest) {
+	received := make(chan capturedRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- capturedRequest{header: r.Header.Clone(), body: body}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, received
+}
+
+func testMessage() *Message {
+	return &Message{
+		Kind: domain.AlertKindAlert,
+		Anomaly: &domain.AnomalousActivity{
+			ID:        "anomaly-1",
+			Type:      domain.AnomalousTypeDataLeak,
+			Severity:  domain.SeverityCritical,
+			AdminID:   "admin-1",
+			AdminName: "root",
+			CreatedAt: time.Now(),
+		},
+	}
+}
+
+// TestWebhookSink_Signature 测试签名覆盖"时间戳.请求体"，接收方可以独立校验
+func TestWebhookSink_Signature(t *testing.T) {
+	server, received := newWebhookReceiver(t, http.StatusOK)
+	sink := NewWebhookSink("ops", server.URL, "s3cret", server.Client())
+
+	require.NoError(t, sink.Send(context.Background(), testMessage()))
+	req := <-received
+
+	ts := req.header.Get(HeaderTimestamp)
+	sec, err := strconv.ParseInt(ts, 10, 64)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), time.Unix(sec, 0), 5*time.Second)
+
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write([]byte(ts + "." + string(req.body)))
+	assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), req.header.Get(HeaderSignature))
+
+	// 篡改请求体或时间戳后签名不再匹配
+	assert.NotEqual(t, Sign("s3cret", ts, append(req.body, ' ')), req.header.Get(HeaderSignature))
+	assert.NotEqual(t, Sign("s3cret", strconv.FormatInt(sec+1, 10), req.body), req.header.Get(HeaderSignature))
+	assert.NotEqual(t, Sign("other", ts, req.body), req.header.Get(HeaderSignature))
+
+	var msg Message
+	require.NoError(t, json.Unmarshal(req.body, &msg))
+	assert.Equal(t, "anomaly-1", msg.Anomaly.ID)
+}
+
+// TestWebhookSink_Unsigned 测试未配置密钥时不发送签名头
+func TestWebhookSink_Unsigned(t *testing.T) {
+	server, received := newWebhookReceiver(t, http.StatusNoContent)
+	sink := NewWebhookSink("ops", server.URL, "", server.Client())
+
+	require.NoError(t, sink.Send(context.Background(), testMessage()))
+	req := <-received
+	assert.Empty(t, req.header.Get(HeaderTimestamp))
+	assert.Empty(t, req.header.Get(HeaderSignature))
+}
+
+// TestWebhookSink_ErrorStatus 测试非2xx响应视为投递失败
+func TestWebhookSink_ErrorStatus(t *testing.T) {
+	server, received := newWebhookReceiver(t, http.StatusBadGateway)
+	sink := NewWebhookSink("ops", server.URL, "s3cret", server.Client())
+
+	err := sink.Send(context.Background(), testMessage())
+	<-received
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "502")
+}
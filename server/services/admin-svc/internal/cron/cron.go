@@ -0,0 +1,164 @@
+package cron
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"admin-svc/internal/service"
+
+	"github.com/robfig/cron/v3"
+)
+
+// CronManager 定时任务管理器
+type CronManager struct {
+	cron       *cron.Cron
+	statsSvc   *service.StatsService
+	alertSvc   *service.AlertService
+	chainSvc   *service.AuditChainService
+	exportSvc  *service.ExportJobService
+	sessionSvc *service.AdminSessionService
+}
+
+// NewCronManager 创建定时任务管理器
+func NewCronManager(statsSvc *service.StatsService, alertSvc *service.AlertService, chainSvc *service.AuditChainService, exportSvc *service.ExportJobService, sessionSvc *service.AdminSessionService) *CronManager {
+	return &CronManager{
+		cron:       cron.New(cron.WithLocation(time.Local)),
+		statsSvc:   statsSvc,
+		alertSvc:   alertSvc,
+		chainSvc:   chainSvc,
+		exportSvc:  exportSvc,
+		sessionSvc: sessionSvc,
+	}
+}
+
+// Start 启动定时任务，并在后台补写停机期间错过的每日统计
+func (m *CronManager) Start() error {
+	// 每天00:10把前一天的统计写入数据库（留出时间让各服务写出缓冲中的事件）
+	_, err := m.cron.AddFunc("10 0 * * *", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		yesterday := time.Now().AddDate(0, 0, -1)
+		daily, err := m.statsSvc.PersistDailyStats(ctx, yesterday)
+		if err != nil {
+			log.Printf("Daily stats persist failed: %v", err)
+			return
+		}
+		log.Printf("Daily stats persisted: date=%s active_users=%d total_requests=%d",
+			daily.Date.Format("2006-01-02"), daily.ActiveUsers, daily.TotalRequests)
+	})
+	if err != nil {
+		return err
+	}
+
+	// 每分钟重试失败的告警投递，并为长时间未处理的异常发送升级告警
+	// 上一轮未执行完时跳过本轮，避免同一实例内重复投递
+	_, err = m.cron.AddJob("@every 1m", cron.NewChain(cron.SkipIfStillRunning(cron.DiscardLogger)).Then(cron.FuncJob(m.processAlerts)))
+	if err != nil {
+		return err
+	}
+
+	// 每小时为操作日志哈希链签名检查点（未配置签名密钥时跳过）
+	if pub, _ := m.chainSvc.PublicKey(); pub != "" {
+		if _, err := m.cron.AddFunc(service.CheckpointSchedule, m.createCheckpoint); err != nil {
+			return err
+		}
+	}
+
+	// 每小时删除超过保留期限的导出文件
+	if _, err := m.cron.AddFunc("30 * * * *", m.cleanupExports); err != nil {
+		return err
+	}
+
+	// 每天04:00删除早已过期或撤销的管理员会话
+	if _, err := m.cron.AddFunc("0 4 * * *", m.cleanupSessions); err != nil {
+		return err
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		backfilled, err := m.statsSvc.BackfillDailyStats(ctx)
+		if err != nil {
+			log.Printf("Daily stats backfill failed: %v", err)
+			return
+		}
+		if backfilled > 0 {
+			log.Printf("Daily stats backfilled: days=%d", backfilled)
+		}
+	}()
+
+	m.cron.Start()
+	log.Println("Cron manager started")
+	return nil
+}
+
+// processAlerts 重试到期的告警投递和升级未处理的异常
+func (m *CronManager) processAlerts() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	if processed, err := m.alertSvc.ProcessDueDeliveries(ctx); err != nil {
+		log.Printf("Alert delivery retry failed: %v", err)
+	} else if processed > 0 {
+		log.Printf("Alert deliveries retried: count=%d", processed)
+	}
+
+	if escalated, err := m.alertSvc.EscalateUnresolved(ctx); err != nil {
+		log.Printf("Anomaly escalation failed: %v", err)
+	} else if escalated > 0 {
+		log.Printf("Anomalies escalated: count=%d", escalated)
+	}
+}
+
+// createCheckpoint 为当前链头签名
+func (m *CronManager) createCheckpoint() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	cp, err := m.chainSvc.CreateCheckpoint(ctx)
+	if err != nil {
+		log.Printf("Audit checkpoint failed: %v", err)
+		return
+	}
+	if cp != nil {
+		log.Printf("Audit checkpoint created: seq=%d hash=%s", cp.Seq, cp.Hash)
+	}
+}
+
+// cleanupExports 删除过期的导出文件
+func (m *CronManager) cleanupExports() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	cleaned, err := m.exportSvc.CleanupExpired(ctx)
+	if err != nil {
+		log.Printf("Export cleanup failed: %v", err)
+	}
+	if cleaned > 0 {
+		log.Printf("Expired exports cleaned: count=%d", cleaned)
+	}
+}
+
+// cleanupSessions 删除过期的管理员会话
+func (m *CronManager) cleanupSessions() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	deleted, err := m.sessionSvc.CleanupExpired(ctx)
+	if err != nil {
+		log.Printf("Admin session cleanup failed: %v", err)
+	}
+	if deleted > 0 {
+		log.Printf("Expired admin sessions deleted: count=%d", deleted)
+	}
+}
+
+// Stop 停止定时任务，等待正在执行的任务结束
+func (m *CronManager) Stop() {
+	ctx := m.cron.Stop()
+	<-ctx.Done()
+	log.Println("Cron manager stopped")
+}
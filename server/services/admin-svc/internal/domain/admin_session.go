@@ -0,0 +1,62 @@
+package domain
+
+import "time"
+
+// AdminSession 管理员登录会话
+// 管理员JWT携带会话ID，每次请求都校验会话未撤销、未过期且未超过空闲超时，撤销后Token立即失效
+type AdminSession struct {
+	ID         string     `json:"id" db:"id"`
+	AdminID    string     `json:"admin_id" db:"admin_id"`
+	AdminName  string     `json:"admin_name" db:"admin_name"`
+	MFAMethod  string     `json:"mfa_method" db:"mfa_method"` // 登录时使用的第二因素，空表示未验证第二因素
+	IP         string     `json:"ip" db:"ip"`
+	UserAgent  string     `json:"user_agent" db:"user_agent"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	LastSeenAt time.Time  `json:"last_seen_at" db:"last_seen_at"`
+	ExpiresAt  time.Time  `json:"expires_at" db:"expires_at"` // 绝对过期时间，不随活动延长
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	RevokedBy  string     `json:"revoked_by,omitempty" db:"revoked_by"`
+
+	Current bool `json:"current" db:"-"` // 是否为发起请求的会话（仅列表时填充）
+}
+
+// Active 判断会话在now时是否有效（未撤销、未过期、空闲时间未超过idleTimeout）
+func (s *AdminSession) Active(now time.Time, idleTimeout time.Duration) bool {
+	if s.RevokedAt != nil || !now.Before(s.ExpiresAt) {
+		return false
+	}
+	return idleTimeout <= 0 || now.Sub(s.LastSeenAt) < idleTimeout
+}
+
+// MFA 第二因素类型
+const (
+	MFAMethodTOTP       = "totp"
+	MFAMethodBackupCode = "backup_code"
+	MFAMethodWebAuthn   = "webauthn"
+)
+
+// WebAuthnCredential 管理员注册的安全密钥
+type WebAuthnCredential struct {
+	ID             string     `json:"id" db:"id"` // 凭证ID（base64url）
+	AdminID        string     `json:"admin_id" db:"admin_id"`
+	Name           string     `json:"name" db:"name"`                       // 管理员起的名称，如"YubiKey 5C"
+	PublicKey      []byte     `json:"-" db:"public_key"`                    // COSE_Key（认证器数据中的凭证公钥）
+	Algorithm      int        `json:"algorithm" db:"algorithm"`             // COSE算法标识
+	SignCount      uint32     `json:"-" db:"sign_count"`                    // 签名计数，用于发现被复制的安全密钥
+	BackupEligible bool       `json:"backup_eligible" db:"backup_eligible"` // 可同步备份的凭证（通行密钥）
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	LastUsedAt     *time.Time `json:"last_used_at,omitempty" db:"last_used_at"`
+}
+
+// MFAStatus 管理员第二因素的绑定情况
+type MFAStatus struct {
+	Required             bool                  `json:"required"` // 是否强制要求第二因素
+	TOTPEnabled          bool                  `json:"totp_enabled"`
+	WebAuthnCredentials  []*WebAuthnCredential `json:"webauthn_credentials"`
+	BackupCodesRemaining int64                 `json:"backup_codes_remaining"`
+}
+
+// Enrolled 是否已绑定可用于登录的第二因素（备用恢复码不单独算作第二因素）
+func (s *MFAStatus) Enrolled() bool {
+	return s.TOTPEnabled || len(s.WebAuthnCredentials) > 0
+}
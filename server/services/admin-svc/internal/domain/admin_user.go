@@ -8,7 +8,7 @@ type AdminUser struct {
 	Username     string    `json:"username" db:"username"`
 	PasswordHash string    `json:"-" db:"password_hash"` // 不在JSON中暴露
 	Email        string    `json:"email" db:"email"`
-	Role         string    `json:"role" db:"role"` // admin, operator, viewer
+	Role         string    `json:"role" db:"role"` // 角色名，内置admin/operator/viewer或自定义角色
 	Status       string    `json:"status" db:"status"` // active, disabled
 	TOTPSecret   string    `json:"-" db:"totp_secret"` // TOTP密钥（可选）
 	TOTPEnabled  bool      `json:"totp_enabled" db:"totp_enabled"` // 是否启用2FA
@@ -18,7 +18,7 @@ type AdminUser struct {
 	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
 }
 
-// AdminRole 管理员角色常量
+// AdminRole 内置角色，权限见BuiltInRoles
 const (
 	RoleAdmin    = "admin"    // 超级管理员：所有权限
 	RoleOperator = "operator" // 运营：查看+编辑
@@ -31,22 +31,6 @@ const (
 	StatusDisabled = "disabled"
 )
 
-// HasPermission 检查是否有权限
-func (u *AdminUser) HasPermission(permission string) bool {
-	switch u.Role {
-	case RoleAdmin:
-		return true // 管理员有所有权限
-	case RoleOperator:
-		// 运营可以编辑，但不能管理用户
-		return permission != "manage_admins"
-	case RoleViewer:
-		// 只读用户只能查看
-		return permission == "view"
-	default:
-		return false
-	}
-}
-
 // UpdateLastLogin 更新最后登录信息
 func (u *AdminUser) UpdateLastLogin(ip string) {
 	now := time.Now()
@@ -0,0 +1,64 @@
+package domain
+
+import "time"
+
+// AlertDelivery 告警投递记录（每个异常 × 每个告警通道 × 每次升级一条）
+type AlertDelivery struct {
+	ID              string     `json:"id" db:"id"`
+	AnomalyID       string     `json:"anomaly_id" db:"anomaly_id"`
+	Sink            string     `json:"sink" db:"sink"`                         // 告警通道名称
+	Kind            string     `json:"kind" db:"kind"`                         // alert, escalation
+	EscalationLevel int        `json:"escalation_level" db:"escalation_level"` // 首次告警为0
+	Status          string     `json:"status" db:"status"`                     // pending, sent, failed, canceled
+	Attempts        int        `json:"attempts" db:"attempts"`
+	LastError       string     `json:"last_error,omitempty" db:"last_error"`
+	NextAttemptAt   *time.Time `json:"next_attempt_at,omitempty" db:"next_attempt_at"`
+	DeliveredAt     *time.Time `json:"delivered_at,omitempty" db:"delivered_at"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+
+	History []AlertDeliveryAttempt `json:"history,omitempty" db:"-"` // 投递历史，仅查询详情时填充
+}
+
+// AlertDeliveryAttempt 单次投递尝试（投递历史）
+type AlertDeliveryAttempt struct {
+	DeliveryID string    `json:"delivery_id" db:"delivery_id"`
+	Attempt    int       `json:"attempt" db:"attempt"`
+	Success    bool      `json:"success" db:"success"`
+	Error      string    `json:"error,omitempty" db:"error"`
+	Duration   int64     `json:"duration" db:"duration"` // 毫秒
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// AlertDeliveryKind 投递类型常量
+const (
+	AlertKindAlert      = "alert"      // 异常产生时的首次告警
+	AlertKindEscalation = "escalation" // 异常长时间未处理时的升级告警
+)
+
+// AlertDeliveryStatus 投递状态常量
+const (
+	AlertStatusPending  = "pending" // 等待投递或重试
+	AlertStatusSent     = "sent"
+	AlertStatusFailed   = "failed"   // 重试次数用尽
+	AlertStatusCanceled = "canceled" // 异常已处理，不再投递
+)
+
+// severityRanks 严重程度排序，用于按最低级别路由告警
+var severityRanks = map[string]int{
+	SeverityLow:      1,
+	SeverityMedium:   2,
+	SeverityHigh:     3,
+	SeverityCritical: 4,
+}
+
+// SeverityRank 返回严重程度的级别，未知的严重程度为0
+func SeverityRank(severity string) int {
+	return severityRanks[severity]
+}
+
+// AnomalyFilter 异常活动查询条件，空字段表示不限制
+type AnomalyFilter struct {
+	Severity string
+	Resolved *bool
+}
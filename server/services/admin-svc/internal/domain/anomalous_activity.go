@@ -4,17 +4,19 @@ import "time"
 
 // AnomalousActivity 异常活动告警实体
 type AnomalousActivity struct {
-	ID          string     `json:"id" db:"id"`
-	Type        string     `json:"type" db:"type"`                       // 异常类型
-	Severity    string     `json:"severity" db:"severity"`               // low, medium, high, critical
-	Description string     `json:"description" db:"description"`         // 异常描述
-	AdminID     string     `json:"admin_id" db:"admin_id"`               // 触发异常的管理员ID
-	AdminName   string     `json:"admin_name" db:"admin_name"`           // 管理员姓名
-	Details     string     `json:"details" db:"details"`                 // 详细信息（JSON）
-	Resolved    bool       `json:"resolved" db:"resolved"`               // 是否已处理
-	ResolvedBy  string     `json:"resolved_by,omitempty" db:"resolved_by"` // 处理人
-	ResolvedAt  *time.Time `json:"resolved_at,omitempty" db:"resolved_at"` // 处理时间
-	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	ID              string     `json:"id" db:"id"`
+	Type            string     `json:"type" db:"type"`                         // 异常类型
+	Severity        string     `json:"severity" db:"severity"`                 // low, medium, high, critical
+	Description     string     `json:"description" db:"description"`           // 异常描述
+	AdminID         string     `json:"admin_id" db:"admin_id"`                 // 触发异常的管理员ID
+	AdminName       string     `json:"admin_name" db:"admin_name"`             // 管理员姓名
+	Details         string     `json:"details" db:"details"`                   // 详细信息（JSON）
+	Resolved        bool       `json:"resolved" db:"resolved"`                 // 是否已处理
+	ResolvedBy      string     `json:"resolved_by,omitempty" db:"resolved_by"` // 处理人
+	ResolvedAt      *time.Time `json:"resolved_at,omitempty" db:"resolved_at"` // 处理时间
+	EscalationLevel int        `json:"escalation_level" db:"escalation_level"` // 未处理时已升级的次数
+	LastEscalatedAt *time.Time `json:"last_escalated_at,omitempty" db:"last_escalated_at"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
 }
 
 // AnomalousType 异常类型常量
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// GenesisHash 哈希链第一条日志的PrevHash
+var GenesisHash = strings.Repeat("0", 64)
+
+// PrepareForChain 规范化日志内容，使写入数据库再读出后计算的哈希不变：
+// 创建时间转为UTC并截断到微秒（PostgreSQL TIMESTAMP精度），详情转为规范JSON（JSONB会重排键和空白）
+func (l *OperationLog) PrepareForChain() error {
+	l.CreatedAt = l.CreatedAt.UTC().Truncate(time.Microsecond)
+	details, err := CanonicalJSON(l.Details)
+	if err != nil {
+		return fmt.Errorf("canonicalize details: %w", err)
+	}
+	l.Details = details
+	return nil
+}
+
+// ComputeChainHash 计算日志在哈希链中的哈希：hex(SHA-256(prevHash + 日志字段的JSON数组))
+// 日志需已经过PrepareForChain规范化
+func (l *OperationLog) ComputeChainHash(prevHash string) (string, error) {
+	details, err := CanonicalJSON(l.Details)
+	if err != nil {
+		return "", fmt.Errorf("canonicalize details: %w", err)
+	}
+	if details == nil {
+		details = json.RawMessage("null")
+	}
+
+	fields, err := json.Marshal([]interface{}{
+		l.Seq, l.ID, l.AdminID, l.AdminName, l.Operation, l.Resource, l.ResourceID, l.Action,
+		details, l.RequestID, l.IP, l.UserAgent, l.Status, l.ErrorMsg, l.Duration,
+		l.CreatedAt.UTC().Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	h.Write([]byte(prevHash))
+	h.Write(fields)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// CanonicalJSON 把JSON转换为规范形式（对象键按字典序、无多余空白、数字保持原样），空输入返回nil
+func CanonicalJSON(raw json.RawMessage) (json.RawMessage, error) {
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return nil, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// AuditCheckpoint 哈希链签名检查点：对某一时刻链头（最后一条日志的序号和哈希）的Ed25519签名
+// 签名密钥不在数据库中，即使整条链被重新计算也无法伪造检查点
+type AuditCheckpoint struct {
+	Seq       int64     `json:"seq" db:"seq"`
+	Hash      string    `json:"hash" db:"hash"`
+	KeyID     string    `json:"key_id" db:"key_id"`       // 签名公钥的指纹
+	Signature string    `json:"signature" db:"signature"` // base64
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// SigningPayload 检查点的签名内容
+func (c *AuditCheckpoint) SigningPayload() []byte {
+	return []byte(fmt.Sprintf("listen-stream-audit-checkpoint\n%d\n%s\n%s",
+		c.Seq, c.Hash, c.CreatedAt.UTC().Format(time.RFC3339Nano)))
+}
+
+// ChainBreak 原因常量
+const (
+	ChainBreakHashMismatch      = "hash_mismatch"       // 日志内容被修改
+	ChainBreakPrevHashMismatch  = "prev_hash_mismatch"  // 与上一条日志的链接断开（上一条被替换或重排）
+	ChainBreakMissingEntries    = "missing_entries"     // 序号不连续（日志被删除）
+	ChainBreakTruncated         = "truncated"           // 链尾的日志被删除
+	ChainBreakUnchainedEntry    = "unchained_entry"     // 存在不属于哈希链的日志（直接插入数据库）
+	ChainBreakCheckpointHash    = "checkpoint_mismatch" // 与签名检查点不一致（检查点之前的链被重新计算）
+	ChainBreakInvalidSignature  = "invalid_signature"   // 检查点签名无效
+	ChainBreakUnknownKey        = "unknown_key"         // 检查点由不受信任的密钥签名
+	ChainBreakMissingCheckpoint = "missing_checkpoint"  // 应已生成的检查点不存在（检查点被删除）
+)
+
+// ChainBreak 哈希链第一个断点
+type ChainBreak struct {
+	Seq      int64  `json:"seq"`
+	LogID    string `json:"log_id,omitempty"`
+	Reason   string `json:"reason"`
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+}
+
+// ChainVerification 哈希链校验结果
+type ChainVerification struct {
+	StartTime           time.Time   `json:"start_time"`
+	EndTime             time.Time   `json:"end_time"`
+	FirstSeq            int64       `json:"first_seq"`
+	LastSeq             int64       `json:"last_seq"`
+	EntriesChecked      int64       `json:"entries_checked"`
+	CheckpointsVerified int         `json:"checkpoints_verified"`
+	Valid               bool        `json:"valid"`
+	BrokenLink          *ChainBreak `json:"broken_link,omitempty"`
+	VerifiedAt          time.Time   `json:"verified_at"`
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCanonicalJSON 测试键顺序、空白和数字写法都会被规范化，JSONB重排后结果不变
+func TestCanonicalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"sorts keys", `{"b":1,"a":{"d":2,"c":3}}`, `{"a":{"c":3,"d":2},"b":1}`},
+		{"strips whitespace", "{ \"a\" : [1, 2,\n 3] }", `{"a":[1,2,3]}`},
+		{"keeps number literal", `{"big":12345678901234567890,"f":1.50}`, `{"big":12345678901234567890,"f":1.50}`},
+		{"keeps unicode", `{"name":"管理员"}`, `{"name":"管理员"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CanonicalJSON(json.RawMessage(tt.raw))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+
+	got, err := CanonicalJSON(json.RawMessage("  "))
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	_, err = CanonicalJSON(json.RawMessage(`{"a":`))
+	assert.Error(t, err)
+}
+
+func testChainLog() *OperationLog {
+	return &OperationLog{
+		ID:        "log-1",
+		AdminID:   "admin-1",
+		AdminName: "root",
+		Operation: OpLogin,
+		Resource:  "admin",
+		Action:    "create",
+		Details:   json.RawMessage(`{"role": "admin", "mfa": true}`),
+		IP:        "10.0.0.1",
+		Status:    StatusSuccess,
+		Duration:  12,
+		CreatedAt: time.Date(2026, 3, 2, 18, 30, 0, 123456789, time.FixedZone("CST", 8*3600)),
+		Seq:       1,
+	}
+}
+
+// TestComputeChainHash_RoundTrip 测试日志经过存储（UTC微秒时间、JSONB重排）后哈希不变
+func TestComputeChainHash_RoundTrip(t *testing.T) {
+	log := testChainLog()
+	require.NoError(t, log.PrepareForChain())
+	hash, err := log.ComputeChainHash(GenesisHash)
+	require.NoError(t, err)
+	assert.Len(t, hash, 64)
+
+	// 模拟写入数据库再读出：JSONB重排键并加空白，时间以本地时区返回
+	stored := *log
+	stored.Details = json.RawMessage(`{"mfa": true, "role": "admin"}`)
+	stored.CreatedAt = log.CreatedAt.In(time.Local)
+	storedHash, err := stored.ComputeChainHash(GenesisHash)
+	require.NoError(t, err)
+	assert.Equal(t, hash, storedHash)
+
+	// 经过JSON序列化（导出、接口返回）后也能复算
+	data, err := json.Marshal(log)
+	require.NoError(t, err)
+	var decoded OperationLog
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	decodedHash, err := decoded.ComputeChainHash(GenesisHash)
+	require.NoError(t, err)
+	assert.Equal(t, hash, decodedHash)
+}
+
+// TestComputeChainHash_DetectsChanges 测试任何字段或上一条哈希变化都会改变哈希
+func TestComputeChainHash_DetectsChanges(t *testing.T) {
+	base := testChainLog()
+	require.NoError(t, base.PrepareForChain())
+	hash, err := base.ComputeChainHash(GenesisHash)
+	require.NoError(t, err)
+
+	changes := map[string]func(l *OperationLog){
+		"seq":        func(l *OperationLog) { l.Seq = 2 },
+		"admin":      func(l *OperationLog) { l.AdminID = "admin-2" },
+		"status":     func(l *OperationLog) { l.Status = StatusFailed },
+		"details":    func(l *OperationLog) { l.Details = json.RawMessage(`{"mfa":false,"role":"admin"}`) },
+		"created_at": func(l *OperationLog) { l.CreatedAt = l.CreatedAt.Add(time.Microsecond) },
+		"duration":   func(l *OperationLog) { l.Duration = 13 },
+	}
+	for name, change := range changes {
+		t.Run(name, func(t *testing.T) {
+			modified := *base
+			change(&modified)
+			got, err := modified.ComputeChainHash(GenesisHash)
+			require.NoError(t, err)
+			assert.NotEqual(t, hash, got)
+		})
+	}
+
+	other, err := base.ComputeChainHash(hash)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, other)
+}
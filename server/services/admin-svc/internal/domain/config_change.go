@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// ConfigChange 配置变更请求
+// 一次变更可以包含多个配置项，校验通过后整体写入Consul（CAS，任一配置项在提交后被修改则整体失败）；
+// 包含敏感配置项时需要另一位管理员审批后才会写入
+type ConfigChange struct {
+	ID               string             `json:"id" db:"id"`
+	Items            []ConfigChangeItem `json:"items" db:"items"`
+	Reason           string             `json:"reason" db:"reason"`
+	Status           string             `json:"status" db:"status"` // pending, approved, applied, rejected, cancelled, failed
+	RequiresApproval bool               `json:"requires_approval" db:"requires_approval"`
+	RollbackOf       string             `json:"rollback_of,omitempty" db:"rollback_of"` // 回滚到的历史记录ID
+	CreatedBy        string             `json:"created_by" db:"created_by"`
+	CreatedByName    string             `json:"created_by_name" db:"created_by_name"`
+	ReviewedBy       string             `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewedByName   string             `json:"reviewed_by_name,omitempty" db:"reviewed_by_name"`
+	ReviewComment    string             `json:"review_comment,omitempty" db:"review_comment"`
+	Error            string             `json:"error,omitempty" db:"error"` // 写入失败的原因
+	CreatedAt        time.Time          `json:"created_at" db:"created_at"`
+	ReviewedAt       *time.Time         `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	AppliedAt        *time.Time         `json:"applied_at,omitempty" db:"applied_at"`
+	UpdatedAt        time.Time          `json:"updated_at" db:"updated_at"`
+}
+
+// ConfigChangeItem 单个配置项的变更
+type ConfigChangeItem struct {
+	Key      string  `json:"key"`
+	Delete   bool    `json:"delete,omitempty"`
+	OldValue *string `json:"old_value"` // 提交时的值，不存在时为nil
+	NewValue *string `json:"new_value"` // 删除时为nil
+	// ModifyIndex 提交时Consul的ModifyIndex（不存在时为0），写入时用于CAS
+	ModifyIndex uint64 `json:"modify_index"`
+	Sensitive   bool   `json:"sensitive"` // 需要审批
+	Secret      bool   `json:"secret"`    // 凭证类配置，展示时脱敏
+}
+
+// Masked 返回展示用的副本，凭证类配置的值被替换为掩码
+func (i ConfigChangeItem) Masked() ConfigChangeItem {
+	if i.Secret {
+		i.OldValue = maskConfigValue(i.OldValue)
+		i.NewValue = maskConfigValue(i.NewValue)
+	}
+	return i
+}
+
+// Masked 返回展示用的副本，凭证类配置的值被替换为掩码
+func (c *ConfigChange) Masked() *ConfigChange {
+	masked := *c
+	masked.Items = make([]ConfigChangeItem, len(c.Items))
+	for i, item := range c.Items {
+		masked.Items[i] = item.Masked()
+	}
+	return &masked
+}
+
+// Keys 变更涉及的配置键
+func (c *ConfigChange) Keys() []string {
+	keys := make([]string, len(c.Items))
+	for i, item := range c.Items {
+		keys[i] = item.Key
+	}
+	return keys
+}
+
+// ConfigChangeStatus 配置变更状态常量
+const (
+	ConfigChangePending   = "pending"   // 等待审批
+	ConfigChangeApproved  = "approved"  // 已审批或无需审批，正在写入Consul
+	ConfigChangeApplied   = "applied"   // 已写入Consul
+	ConfigChangeRejected  = "rejected"  // 审批拒绝
+	ConfigChangeCancelled = "cancelled" // 提交人撤回
+	ConfigChangeFailed    = "failed"    // 写入失败（校验不通过或配置已被其他变更修改）
+)
+
+// ConfigChangeFilter 配置变更查询条件，空字段表示不限制
+type ConfigChangeFilter struct {
+	Status    string
+	CreatedBy string
+}
+
+// ConfigHistory 配置变更历史，每个配置项每次写入一条
+type ConfigHistory struct {
+	ID           string    `json:"id" db:"id"`
+	ConfigKey    string    `json:"config_key" db:"config_key"`
+	Action       string    `json:"action" db:"action"` // create, update, delete
+	OldValue     *string   `json:"old_value" db:"old_value"`
+	NewValue     *string   `json:"new_value" db:"new_value"` // 删除时为nil
+	Version      int64     `json:"version" db:"version"`     // 每个配置键从1开始递增
+	ChangeID     string    `json:"change_id,omitempty" db:"change_id"`
+	AdminID      string    `json:"admin_id" db:"admin_id"`
+	AdminName    string    `json:"admin_name" db:"admin_name"`
+	Reason       string    `json:"reason" db:"reason"` // 变更原因
+	Rollbackable bool      `json:"rollbackable" db:"rollbackable"`
+	Secret       bool      `json:"secret" db:"-"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
+
+// Masked 返回展示用的副本，凭证类配置的值被替换为掩码
+func (h *ConfigHistory) Masked() *ConfigHistory {
+	masked := *h
+	if masked.Secret {
+		masked.OldValue = maskConfigValue(h.OldValue)
+		masked.NewValue = maskConfigValue(h.NewValue)
+	}
+	return &masked
+}
+
+// maskConfigValue 凭证脱敏：只保留末4位（长度不足8位时全部隐藏）
+func maskConfigValue(v *string) *string {
+	if v == nil {
+		return nil
+	}
+	masked := "******"
+	if len(*v) >= 8 {
+		masked += (*v)[len(*v)-4:]
+	}
+	return &masked
+}
+
+// NormalizeConfigKey 规范化配置键：去掉首尾空白和斜杠
+func NormalizeConfigKey(key string) string {
+	return strings.Trim(strings.TrimSpace(key), "/")
+}
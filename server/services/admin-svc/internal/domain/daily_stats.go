@@ -4,19 +4,22 @@ import "time"
 
 // DailyStats 每日统计实体（定时聚合）
 type DailyStats struct {
-	Date            time.Time `json:"date" db:"date"` // PK: 日期（YYYY-MM-DD）
-	TotalUsers      int64     `json:"total_users" db:"total_users"` // 总用户数
-	NewUsers        int64     `json:"new_users" db:"new_users"` // 新增用户数
-	ActiveUsers     int64     `json:"active_users" db:"active_users"` // 活跃用户数（当日有操作）
-	TotalRequests   int64     `json:"total_requests" db:"total_requests"` // 总请求数
-	SuccessRequests int64     `json:"success_requests" db:"success_requests"` // 成功请求数
-	FailedRequests  int64     `json:"failed_requests" db:"failed_requests"` // 失败请求数
-	ErrorRate       float64   `json:"error_rate" db:"error_rate"` // 错误率
-	AvgResponseTime int64     `json:"avg_response_time" db:"avg_response_time"` // 平均响应时间（ms）
-	TotalFavorites  int64     `json:"total_favorites" db:"total_favorites"` // 总收藏数
-	TotalPlaylists  int64     `json:"total_playlists" db:"total_playlists"` // 总歌单数
-	TotalPlays      int64     `json:"total_plays" db:"total_plays"` // 总播放次数
-	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+	Date               time.Time `json:"date" db:"date"`                                 // PK: 日期（YYYY-MM-DD）
+	TotalUsers         int64     `json:"total_users" db:"total_users"`                   // 总用户数
+	NewUsers           int64     `json:"new_users" db:"new_users"`                       // 新增用户数
+	ActiveUsers        int64     `json:"active_users" db:"active_users"`                 // 活跃用户数（当日有操作）
+	WeeklyActiveUsers  int64     `json:"weekly_active_users" db:"weekly_active_users"`   // 所在自然周截至当日的活跃用户数
+	MonthlyActiveUsers int64     `json:"monthly_active_users" db:"monthly_active_users"` // 所在自然月截至当日的活跃用户数
+	Logins             int64     `json:"logins" db:"logins"`                             // 登录次数
+	TotalRequests      int64     `json:"total_requests" db:"total_requests"`             // 总请求数
+	SuccessRequests    int64     `json:"success_requests" db:"success_requests"`         // 成功请求数
+	FailedRequests     int64     `json:"failed_requests" db:"failed_requests"`           // 失败请求数
+	ErrorRate          float64   `json:"error_rate" db:"error_rate"`                     // 错误率
+	AvgResponseTime    int64     `json:"avg_response_time" db:"avg_response_time"`       // 平均响应时间（ms）
+	TotalFavorites     int64     `json:"total_favorites" db:"total_favorites"`           // 总收藏数
+	TotalPlaylists     int64     `json:"total_playlists" db:"total_playlists"`           // 总歌单数
+	TotalPlays         int64     `json:"total_plays" db:"total_plays"`                   // 总播放次数
+	CreatedAt          time.Time `json:"created_at" db:"created_at"`
 }
 
 // CalculateErrorRate 计算错误率
@@ -0,0 +1,67 @@
+package domain
+
+import "time"
+
+// EndUser 终端用户（来自auth-svc，手机号已脱敏）
+type EndUser struct {
+	ID           string     `json:"id"`
+	Phone        string     `json:"phone"`
+	Role         string     `json:"role"`
+	Disabled     bool       `json:"disabled"`
+	TokenVersion int        `json:"token_version"`
+	CreatedAt    time.Time  `json:"created_at"`
+	LastLoginAt  *time.Time `json:"last_login_at,omitempty"` // 所有设备中最近一次登录时间
+}
+
+// EndUserDevice 终端用户的登录设备
+type EndUserDevice struct {
+	ID         string    `json:"id"`
+	Platform   string    `json:"platform"`
+	IPAddress  string    `json:"ip_address"`
+	LastActive time.Time `json:"last_active"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// EndUserUsage 终端用户的使用统计（来自user-svc）
+type EndUserUsage struct {
+	FavoriteCount int64 `json:"favorite_count"`
+	PlaylistCount int64 `json:"playlist_count"`
+	HistoryCount  int64 `json:"history_count"`
+	TotalPlayTime int64 `json:"total_play_time"` // 最近一年的收听时长（秒）
+}
+
+// EndUserDetail 终端用户详情
+type EndUserDetail struct {
+	User    *EndUser         `json:"user"`
+	Devices []*EndUserDevice `json:"devices"`
+	Usage   *EndUserUsage    `json:"usage"`
+}
+
+// 用户活动类型
+const (
+	ActivityLogin = "login" // 设备登录
+	ActivityPlay  = "play"  // 播放歌曲
+)
+
+// EndUserActivity 终端用户的近期活动（设备登录与播放记录合并，按时间倒序）
+type EndUserActivity struct {
+	Type       string    `json:"type"`
+	DeviceID   string    `json:"device_id,omitempty"`
+	Platform   string    `json:"platform,omitempty"`
+	IPAddress  string    `json:"ip_address,omitempty"`
+	SongID     string    `json:"song_id,omitempty"`
+	SongName   string    `json:"song_name,omitempty"`
+	ArtistName string    `json:"artist_name,omitempty"`
+	Duration   int       `json:"duration,omitempty"` // 播放时长（秒）
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// AdminActor 执行操作的管理员及请求信息（写入操作日志）
+type AdminActor struct {
+	AdminID   string
+	AdminName string
+	SessionID string // 发起请求的登录会话
+	RequestID string
+	IP        string
+	UserAgent string
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ExportJob 异步导出任务
+// 任务入队后由后台worker从数据库流式读取数据写入文件，完成后上传到存储并提供带签名的下载链接
+type ExportJob struct {
+	ID            string          `json:"id" db:"id"`
+	Type          string          `json:"type" db:"type"`     // operation_logs, daily_stats, users
+	Format        string          `json:"format" db:"format"` // csv, xlsx, parquet
+	Filters       json.RawMessage `json:"filters" db:"filters"`
+	Status        string          `json:"status" db:"status"`         // queued, running, succeeded, failed, expired
+	TotalRows     int64           `json:"total_rows" db:"total_rows"` // 开始导出时统计的总行数，用于计算进度
+	ProcessedRows int64           `json:"processed_rows" db:"processed_rows"`
+	Attempts      int             `json:"attempts" db:"attempts"`
+	StorageKey    string          `json:"-" db:"storage_key"`
+	FileName      string          `json:"file_name,omitempty" db:"file_name"`
+	FileSize      int64           `json:"file_size,omitempty" db:"file_size"`
+	Error         string          `json:"error,omitempty" db:"error"`
+	CreatedBy     string          `json:"created_by" db:"created_by"`
+	LeaseUntil    *time.Time      `json:"-" db:"lease_until"` // 执行中的任务定期续约，实例崩溃后租约过期由其他实例接手
+	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
+	StartedAt     *time.Time      `json:"started_at,omitempty" db:"started_at"`
+	FinishedAt    *time.Time      `json:"finished_at,omitempty" db:"finished_at"`
+	ExpiresAt     *time.Time      `json:"expires_at,omitempty" db:"expires_at"` // 导出文件的保留期限
+	UpdatedAt     time.Time       `json:"updated_at" db:"updated_at"`
+
+	DownloadURL string `json:"download_url,omitempty" db:"-"` // 仅成功的任务查询时填充
+}
+
+// Progress 导出进度（0-100），总行数未知时按状态返回0或100
+func (j *ExportJob) Progress() float64 {
+	if j.Status == ExportStatusSucceeded {
+		return 100
+	}
+	if j.TotalRows <= 0 {
+		return 0
+	}
+	progress := float64(j.ProcessedRows) / float64(j.TotalRows) * 100
+	if progress > 99 {
+		progress = 99 // 行数统计之后可能有新数据写入，完成前不显示100
+	}
+	return progress
+}
+
+// MarshalJSON 序列化时附带进度
+func (j ExportJob) MarshalJSON() ([]byte, error) {
+	type alias ExportJob
+	return json.Marshal(struct {
+		alias
+		Progress float64 `json:"progress"`
+	}{alias(j), j.Progress()})
+}
+
+// ExportType 导出数据类型常量
+const (
+	ExportTypeOperationLogs = "operation_logs"
+	ExportTypeDailyStats    = "daily_stats"
+	ExportTypeUsers         = "users"
+)
+
+// ExportFormat 导出格式常量
+const (
+	ExportFormatCSV     = "csv"
+	ExportFormatXLSX    = "xlsx"
+	ExportFormatParquet = "parquet"
+)
+
+// ExportStatus 导出任务状态常量
+const (
+	ExportStatusQueued    = "queued"
+	ExportStatusRunning   = "running"
+	ExportStatusSucceeded = "succeeded"
+	ExportStatusFailed    = "failed"
+	ExportStatusExpired   = "expired" // 文件已超过保留期限被删除
+)
+
+// ExportFilters 导出条件，按导出类型使用其中的字段，空字段表示不限制
+type ExportFilters struct {
+	StartDate string `json:"start_date,omitempty"` // YYYY-MM-DD（本地时区），包含当天
+	EndDate   string `json:"end_date,omitempty"`   // YYYY-MM-DD（本地时区），包含当天
+
+	// 操作日志
+	AdminID   string `json:"admin_id,omitempty"`
+	Operation string `json:"operation,omitempty"`
+	Resource  string `json:"resource,omitempty"`
+	Action    string `json:"action,omitempty"`
+	Status    string `json:"status,omitempty"`
+
+	// StartTime/EndTime 精确的时间范围[start, end)（gRPC导出使用），设置时优先于日期
+	StartTime *time.Time `json:"start_time,omitempty"`
+	EndTime   *time.Time `json:"end_time,omitempty"`
+
+	// 终端用户
+	PhonePrefix string `json:"phone_prefix,omitempty"`
+}
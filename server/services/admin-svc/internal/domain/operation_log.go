@@ -22,34 +22,58 @@ type OperationLog struct {
 	ErrorMsg    string          `json:"error_msg,omitempty" db:"error_msg"` // 错误信息
 	Duration    int64           `json:"duration" db:"duration"` // 执行时长（毫秒）
 	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+	Seq         int64           `json:"seq,omitempty" db:"seq"` // 哈希链序号（从1开始连续递增）
+	PrevHash    string          `json:"prev_hash,omitempty" db:"prev_hash"` // 上一条日志的哈希
+	Hash        string          `json:"hash,omitempty" db:"hash"` // 本条日志的哈希（见ComputeChainHash）
 }
 
 // Operation 操作类型常量
 const (
-	OpLogin          = "login"
-	OpLogout         = "logout"
-	OpCreateUser     = "create_user"
-	OpUpdateUser     = "update_user"
-	OpDisableUser    = "disable_user"
-	OpUpdateConfig   = "update_config"
-	OpRollbackConfig = "rollback_config"
-	OpExportData     = "export_data"
+	OpLogin             = "login"
+	OpLogout            = "logout"
+	OpCreateUser        = "create_user"
+	OpUpdateUser        = "update_user"
+	OpDisableUser       = "disable_user"
+	OpUpdateConfig      = "update_config"
+	OpRollbackConfig    = "rollback_config"
+	OpUpdateFeatureFlag = "update_feature_flag"
+	OpExportData        = "export_data"
+	OpUpdateMFA         = "update_mfa"           // 绑定/解绑第二因素、重新生成备用恢复码
+	OpRevokeSession     = "revoke_admin_session" // 撤销管理员登录会话
+
+	// 以下操作类型参与异常检测（见AuditService.CheckAnomalousActivity）
+	OpUserManagement = "user_management" // 终端用户管理（禁用、启用等）
+	OpExport         = "export"          // 数据导出
+	OpPermission     = "permission"      // 角色和权限变更
 )
 
 // Resource 资源类型常量
 const (
-	ResourceAdminUser = "admin_user"
-	ResourceConfig    = "config"
-	ResourceStats     = "stats"
-	ResourceAuditLog  = "audit_log"
+	ResourceAdminUser   = "admin_user"
+	ResourceConfig      = "config"
+	ResourceStats       = "stats"
+	ResourceAuditLog    = "audit_log"
+	ResourceUser        = "user" // 终端用户
+	ResourceRole        = "role" // 管理员角色
+	ResourceFeatureFlag = "feature_flag"
+	ResourceSession     = "admin_session" // 管理员登录会话
 )
 
 // Action 动作常量
 const (
-	ActionCreate = "create"
-	ActionUpdate = "update"
-	ActionDelete = "delete"
-	ActionView   = "view"
+	ActionCreate         = "create"
+	ActionUpdate         = "update"
+	ActionDelete         = "delete"
+	ActionView           = "view"
+	ActionDisable        = "disable"
+	ActionEnable         = "enable"
+	ActionExport         = "export"
+	ActionRevokeSessions = "revoke_sessions"
+	ActionRemoveDevice   = "remove_device"
+	ActionAssignRole     = "assign_role"
+	ActionApprove        = "approve"
+	ActionReject         = "reject"
+	ActionCancel         = "cancel"
 )
 
 // Status 状态常量
@@ -58,6 +82,43 @@ const (
 	StatusFailed  = "failed"
 )
 
+// OperationLogFilter 操作日志查询条件，空字段和零值时间表示不限制
+type OperationLogFilter struct {
+	AdminID   string
+	Operation string
+	Action    string
+	Resource  string
+	Status    string
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// Match 判断日志是否满足查询条件（时间范围为左闭右开）
+func (f *OperationLogFilter) Match(log *OperationLog) bool {
+	if f.AdminID != "" && log.AdminID != f.AdminID {
+		return false
+	}
+	if f.Operation != "" && log.Operation != f.Operation {
+		return false
+	}
+	if f.Action != "" && log.Action != f.Action {
+		return false
+	}
+	if f.Resource != "" && log.Resource != f.Resource {
+		return false
+	}
+	if f.Status != "" && log.Status != f.Status {
+		return false
+	}
+	if !f.StartTime.IsZero() && log.CreatedAt.Before(f.StartTime) {
+		return false
+	}
+	if !f.EndTime.IsZero() && !log.CreatedAt.Before(f.EndTime) {
+		return false
+	}
+	return true
+}
+
 // OperationDetails 操作详情结构（用于序列化到Details字段）
 type OperationDetails struct {
 	Before map[string]interface{} `json:"before,omitempty"` // 操作前的值
@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"regexp"
+	"sort"
+	"time"
+)
+
+// Permission 权限，格式为"资源:动作"
+const (
+	PermStatsView   = "stats:view"
+	PermStatsExport = "stats:export"
+
+	PermConfigView    = "config:view"
+	PermConfigEdit    = "config:edit"
+	PermConfigApprove = "config:approve" // 审批敏感配置变更（不能审批自己提交的变更）
+
+	PermFlagView = "flag:view"
+	PermFlagEdit = "flag:edit" // 创建、修改、删除功能开关（包括调整灰度比例）
+
+	PermAuditView    = "audit:view"
+	PermAuditExport  = "audit:export"
+	PermAuditResolve = "audit:resolve"
+
+	PermUserView   = "user:view"
+	PermUserManage = "user:manage" // 禁用/启用、强制下线、移除设备
+	PermUserExport = "user:export"
+
+	PermAdminView = "admin:view"
+
+	// PermAdminSession 查看和撤销其他管理员的登录会话，只属于内置的超级管理员角色，不能授予自定义角色
+	PermAdminSession = "admin:session"
+
+	// PermRoleManage 管理自定义角色和为管理员分配角色，只属于内置的超级管理员角色，不能授予自定义角色
+	PermRoleManage = "role:manage"
+)
+
+// PermissionInfo 权限说明（供管理后台展示）
+type PermissionInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Grantable   bool   `json:"grantable"` // 是否可以授予自定义角色
+}
+
+// permissions 所有权限及说明
+var permissions = []PermissionInfo{
+	{PermStatsView, "查看实时和每日统计", true},
+	{PermStatsExport, "导出统计数据", true},
+	{PermConfigView, "查看配置和变更历史", true},
+	{PermConfigEdit, "提交配置变更、回滚配置和清除配置缓存", true},
+	{PermConfigApprove, "审批敏感配置变更", true},
+	{PermFlagView, "查看功能开关和求值结果", true},
+	{PermFlagEdit, "创建、修改和删除功能开关", true},
+	{PermAuditView, "查看操作日志和异常活动", true},
+	{PermAuditExport, "导出操作日志", true},
+	{PermAuditResolve, "处理异常活动", true},
+	{PermUserView, "搜索和查看终端用户", true},
+	{PermUserManage, "禁用/启用终端用户、强制下线、移除设备", true},
+	{PermUserExport, "导出终端用户列表", true},
+	{PermAdminView, "查看管理员和角色", true},
+	{PermAdminSession, "查看和撤销其他管理员的登录会话", false},
+	{PermRoleManage, "管理角色和分配角色", false},
+}
+
+// Permissions 返回所有权限
+func Permissions() []PermissionInfo {
+	result := make([]PermissionInfo, len(permissions))
+	copy(result, permissions)
+	return result
+}
+
+// IsGrantablePermission 判断权限是否存在且可以授予自定义角色
+func IsGrantablePermission(permission string) bool {
+	for _, p := range permissions {
+		if p.Name == permission {
+			return p.Grantable
+		}
+	}
+	return false
+}
+
+// Role 角色（一组扁平的权限，角色之间没有继承关系）
+type Role struct {
+	Name        string    `json:"name" db:"name"`
+	Description string    `json:"description" db:"description"`
+	Permissions []string  `json:"permissions" db:"permissions"`
+	BuiltIn     bool      `json:"built_in" db:"built_in"` // 内置角色不能修改或删除
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// HasPermission 检查角色是否拥有某个权限
+func (r *Role) HasPermission(permission string) bool {
+	for _, p := range r.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// rolePattern 角色名：小写字母开头，2-32位小写字母、数字、下划线或连字符
+var rolePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{1,31}$`)
+
+// ValidRoleName 判断角色名是否合法
+func ValidRoleName(name string) bool {
+	return rolePattern.MatchString(name)
+}
+
+// NormalizePermissions 去重并排序权限列表
+func NormalizePermissions(perms []string) []string {
+	seen := make(map[string]bool, len(perms))
+	result := make([]string, 0, len(perms))
+	for _, p := range perms {
+		if !seen[p] {
+			seen[p] = true
+			result = append(result, p)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// BuiltInRoles 返回内置角色
+// 内置角色定义在代码中不落库，新增权限时超级管理员和运营自动获得
+func BuiltInRoles() []*Role {
+	all := make([]string, 0, len(permissions))
+	var operator []string
+	for _, p := range permissions {
+		all = append(all, p.Name)
+		if p.Grantable {
+			operator = append(operator, p.Name)
+		}
+	}
+	viewer := []string{PermStatsView, PermConfigView, PermFlagView, PermAuditView, PermUserView, PermAdminView}
+
+	return []*Role{
+		{Name: RoleAdmin, Description: "超级管理员：所有权限", Permissions: NormalizePermissions(all), BuiltIn: true},
+		{Name: RoleOperator, Description: "运营：除角色管理外的所有权限", Permissions: NormalizePermissions(operator), BuiltIn: true},
+		{Name: RoleViewer, Description: "只读：仅查看", Permissions: NormalizePermissions(viewer), BuiltIn: true},
+	}
+}
@@ -0,0 +1,47 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// csvWriter CSV写入器
+type csvWriter struct {
+	w       *csv.Writer
+	columns []Column
+	record  []string
+}
+
+func newCSVWriter(w io.Writer, columns []Column) (*csvWriter, error) {
+	cw := &csvWriter{
+		w:       csv.NewWriter(w),
+		columns: columns,
+		record:  make([]string, len(columns)),
+	}
+	for i, col := range columns {
+		cw.record[i] = col.Title
+	}
+	if err := cw.w.Write(cw.record); err != nil {
+		return nil, fmt.Errorf("write header: %w", err)
+	}
+	return cw, nil
+}
+
+func (cw *csvWriter) Write(row []interface{}) error {
+	if err := checkRow(row, cw.columns); err != nil {
+		return err
+	}
+	for i, v := range row {
+		cw.record[i] = formatText(v)
+	}
+	if err := cw.w.Write(cw.record); err != nil {
+		return fmt.Errorf("write row: %w", err)
+	}
+	return nil
+}
+
+func (cw *csvWriter) Close() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
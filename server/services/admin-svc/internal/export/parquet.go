@@ -0,0 +1,431 @@
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// Parquet写入器
+// 只实现导出需要的子集：扁平的OPTIONAL列、PLAIN编码、不压缩、每个行组每列一个数据页（Data Page V1）。
+// 行数据按列缓冲，达到parquetRowGroupRows行或parquetRowGroupBytes字节时写出一个行组，内存占用有上限。
+// 文件结构见 https://parquet.apache.org/docs/file-format/ ，元数据使用Thrift Compact Protocol编码
+
+const (
+	parquetMagic         = "PAR1"
+	parquetRowGroupRows  = 50000
+	parquetRowGroupBytes = 64 << 20
+	parquetCreatedBy     = "listen-stream admin-svc"
+)
+
+// parquet.thrift中的枚举值
+const (
+	pqTypeInt64     = 2
+	pqTypeDouble    = 5
+	pqTypeByteArray = 6
+
+	pqRepetitionOptional = 1
+
+	pqConvertedUTF8            = 0
+	pqConvertedTimestampMillis = 9
+
+	pqEncodingPlain = 0
+	pqEncodingRLE   = 3
+
+	pqCodecUncompressed = 0
+
+	pqPageTypeData = 0
+)
+
+// parquetColumn 一列在当前行组中缓冲的数据
+type parquetColumn struct {
+	Column
+	defLevels []bool // 每行是否有值
+	values    bytes.Buffer
+	lastLen   int // 追加最后一个值之前values的长度
+}
+
+// parquetChunk 已写出的列块的元数据
+type parquetChunk struct {
+	numValues  int64
+	offset     int64
+	size       int64
+	columnType int32
+	key        string
+}
+
+type parquetRowGroup struct {
+	chunks   []parquetChunk
+	numRows  int64
+	byteSize int64
+}
+
+type parquetWriter struct {
+	w         *countingWriter
+	columns   []*parquetColumn
+	rows      int64 // 当前行组的行数
+	totalRows int64
+	rowGroups []parquetRowGroup
+}
+
+func newParquetWriter(w io.Writer, columns []Column) (*parquetWriter, error) {
+	pw := &parquetWriter{w: &countingWriter{w: w}}
+	for _, col := range columns {
+		pw.columns = append(pw.columns, &parquetColumn{Column: col})
+	}
+	if _, err := io.WriteString(pw.w, parquetMagic); err != nil {
+		return nil, err
+	}
+	return pw, nil
+}
+
+func (pw *parquetWriter) Write(row []interface{}) error {
+	if len(row) != len(pw.columns) {
+		return fmt.Errorf("row has %d values, expected %d", len(row), len(pw.columns))
+	}
+	for i, v := range row {
+		if err := pw.columns[i].append(v); err != nil {
+			// 回退本行已追加的值，保持各列行数一致
+			for _, col := range pw.columns[:i] {
+				col.removeLast()
+			}
+			return err
+		}
+	}
+	pw.rows++
+	pw.totalRows++
+
+	buffered := 0
+	for _, col := range pw.columns {
+		buffered += col.values.Len()
+	}
+	if pw.rows >= parquetRowGroupRows || buffered >= parquetRowGroupBytes {
+		return pw.flushRowGroup()
+	}
+	return nil
+}
+
+func (pw *parquetWriter) Close() error {
+	if pw.rows > 0 {
+		if err := pw.flushRowGroup(); err != nil {
+			return err
+		}
+	}
+
+	footer := pw.fileMetaData()
+	if _, err := pw.w.Write(footer); err != nil {
+		return err
+	}
+	var size [4]byte
+	binary.LittleEndian.PutUint32(size[:], uint32(len(footer)))
+	if _, err := pw.w.Write(size[:]); err != nil {
+		return err
+	}
+	_, err := io.WriteString(pw.w, parquetMagic)
+	return err
+}
+
+// append 按PLAIN编码追加一个值
+func (c *parquetColumn) append(v interface{}) error {
+	c.lastLen = c.values.Len()
+	if v == nil {
+		c.defLevels = append(c.defLevels, false)
+		return nil
+	}
+
+	var buf [8]byte
+	switch c.Type {
+	case String:
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("column %s: expected string, got %T", c.Key, v)
+		}
+		binary.LittleEndian.PutUint32(buf[:4], uint32(len(s)))
+		c.values.Write(buf[:4])
+		c.values.WriteString(s)
+	case Int64:
+		n, ok := v.(int64)
+		if !ok {
+			return fmt.Errorf("column %s: expected int64, got %T", c.Key, v)
+		}
+		binary.LittleEndian.PutUint64(buf[:], uint64(n))
+		c.values.Write(buf[:])
+	case Float64:
+		f, ok := v.(float64)
+		if !ok {
+			return fmt.Errorf("column %s: expected float64, got %T", c.Key, v)
+		}
+		binary.LittleEndian.PutUint64(buf[:], math.Float64bits(f))
+		c.values.Write(buf[:])
+	case Time:
+		t, ok := v.(time.Time)
+		if !ok {
+			return fmt.Errorf("column %s: expected time.Time, got %T", c.Key, v)
+		}
+		if t.IsZero() {
+			c.defLevels = append(c.defLevels, false)
+			return nil
+		}
+		binary.LittleEndian.PutUint64(buf[:], uint64(t.UnixMilli()))
+		c.values.Write(buf[:])
+	}
+	c.defLevels = append(c.defLevels, true)
+	return nil
+}
+
+// removeLast 删除最后追加的一个值
+func (c *parquetColumn) removeLast() {
+	c.defLevels = c.defLevels[:len(c.defLevels)-1]
+	c.values.Truncate(c.lastLen)
+}
+
+func (c *parquetColumn) physicalType() int32 {
+	switch c.Type {
+	case Float64:
+		return pqTypeDouble
+	case Int64, Time:
+		return pqTypeInt64
+	default:
+		return pqTypeByteArray
+	}
+}
+
+// flushRowGroup 写出当前行组：每列一个数据页，页内依次为定义级别（RLE，带4字节长度前缀）和值
+func (pw *parquetWriter) flushRowGroup() error {
+	group := parquetRowGroup{numRows: pw.rows}
+	for _, col := range pw.columns {
+		levels := encodeDefinitionLevels(col.defLevels)
+		pageSize := 4 + len(levels) + col.values.Len()
+		header := pageHeader(int32(pageSize), int32(len(col.defLevels)))
+
+		offset := pw.w.n
+		var levelLen [4]byte
+		binary.LittleEndian.PutUint32(levelLen[:], uint32(len(levels)))
+		for _, b := range [][]byte{header, levelLen[:], levels, col.values.Bytes()} {
+			if _, err := pw.w.Write(b); err != nil {
+				return err
+			}
+		}
+
+		chunk := parquetChunk{
+			numValues:  int64(len(col.defLevels)),
+			offset:     offset,
+			size:       pw.w.n - offset,
+			columnType: col.physicalType(),
+			key:        col.Key,
+		}
+		group.chunks = append(group.chunks, chunk)
+		group.byteSize += chunk.size
+
+		col.defLevels = col.defLevels[:0]
+		col.values.Reset()
+	}
+
+	pw.rowGroups = append(pw.rowGroups, group)
+	pw.rows = 0
+	return nil
+}
+
+// encodeDefinitionLevels 用RLE/Bit-Packing混合编码（位宽1）的RLE游程编码定义级别
+func encodeDefinitionLevels(levels []bool) []byte {
+	var buf bytes.Buffer
+	var tmp [binary.MaxVarintLen64]byte
+	for i := 0; i < len(levels); {
+		j := i
+		for j < len(levels) && levels[j] == levels[i] {
+			j++
+		}
+		n := binary.PutUvarint(tmp[:], uint64(j-i)<<1)
+		buf.Write(tmp[:n])
+		if levels[i] {
+			buf.WriteByte(1)
+		} else {
+			buf.WriteByte(0)
+		}
+		i = j
+	}
+	return buf.Bytes()
+}
+
+// pageHeader 编码数据页的PageHeader
+func pageHeader(size, numValues int32) []byte {
+	t := &thriftWriter{}
+	t.i32(1, pqPageTypeData)
+	t.i32(2, size)   // uncompressed_page_size
+	t.i32(3, size)   // compressed_page_size
+	t.structBegin(5) // data_page_header
+	t.i32(1, numValues)
+	t.i32(2, pqEncodingPlain)
+	t.i32(3, pqEncodingRLE)
+	t.i32(4, pqEncodingRLE)
+	t.structEnd()
+	t.stop()
+	return t.buf.Bytes()
+}
+
+// fileMetaData 编码文件尾的FileMetaData
+func (pw *parquetWriter) fileMetaData() []byte {
+	t := &thriftWriter{}
+	t.i32(1, 1) // version
+
+	t.listBegin(2, thriftStruct, len(pw.columns)+1) // schema
+	t.elemBegin()
+	t.binary(4, "schema")
+	t.i32(5, int32(len(pw.columns))) // num_children
+	t.elemEnd()
+	for _, col := range pw.columns {
+		t.elemBegin()
+		t.i32(1, col.physicalType())
+		t.i32(3, pqRepetitionOptional)
+		t.binary(4, col.Key)
+		switch col.Type {
+		case String:
+			t.i32(6, pqConvertedUTF8)
+		case Time:
+			t.i32(6, pqConvertedTimestampMillis)
+		}
+		t.elemEnd()
+	}
+
+	t.i64(3, pw.totalRows)
+
+	t.listBegin(4, thriftStruct, len(pw.rowGroups))
+	for _, group := range pw.rowGroups {
+		t.elemBegin()
+		t.listBegin(1, thriftStruct, len(group.chunks)) // columns
+		for _, chunk := range group.chunks {
+			t.elemBegin()
+			t.i64(2, chunk.offset) // file_offset
+			t.structBegin(3)       // meta_data
+			t.i32(1, chunk.columnType)
+			t.listBegin(2, thriftI32, 2) // encodings
+			t.listI32(pqEncodingPlain)
+			t.listI32(pqEncodingRLE)
+			t.listBegin(3, thriftBinary, 1) // path_in_schema
+			t.listBinary(chunk.key)
+			t.i32(4, pqCodecUncompressed)
+			t.i64(5, chunk.numValues)
+			t.i64(6, chunk.size)   // total_uncompressed_size
+			t.i64(7, chunk.size)   // total_compressed_size
+			t.i64(9, chunk.offset) // data_page_offset
+			t.structEnd()
+			t.elemEnd()
+		}
+		t.i64(2, group.byteSize)
+		t.i64(3, group.numRows)
+		t.elemEnd()
+	}
+
+	t.binary(6, parquetCreatedBy)
+	t.stop()
+	return t.buf.Bytes()
+}
+
+// Thrift Compact Protocol类型
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// thriftWriter Thrift Compact Protocol编码器（只包含Parquet元数据用到的类型）
+type thriftWriter struct {
+	buf    bytes.Buffer
+	lastID int16
+	stack  []int16
+}
+
+func (t *thriftWriter) fieldHeader(id int16, typ byte) {
+	if delta := id - t.lastID; delta > 0 && delta <= 15 {
+		t.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		t.buf.WriteByte(typ)
+		t.uvarint(uint64(uint16((id << 1) ^ (id >> 15))))
+	}
+	t.lastID = id
+}
+
+func (t *thriftWriter) uvarint(v uint64) {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], v)
+	t.buf.Write(tmp[:n])
+}
+
+func (t *thriftWriter) i32(id int16, v int32) {
+	t.fieldHeader(id, thriftI32)
+	t.listI32(v)
+}
+
+func (t *thriftWriter) i64(id int16, v int64) {
+	t.fieldHeader(id, thriftI64)
+	t.uvarint(uint64((v << 1) ^ (v >> 63)))
+}
+
+func (t *thriftWriter) binary(id int16, s string) {
+	t.fieldHeader(id, thriftBinary)
+	t.listBinary(s)
+}
+
+// listBegin 写入列表头，之后依次写入size个元素
+func (t *thriftWriter) listBegin(id int16, elemType byte, size int) {
+	t.fieldHeader(id, thriftList)
+	if size < 15 {
+		t.buf.WriteByte(byte(size)<<4 | elemType)
+	} else {
+		t.buf.WriteByte(0xF0 | elemType)
+		t.uvarint(uint64(size))
+	}
+}
+
+func (t *thriftWriter) listI32(v int32) {
+	t.uvarint(uint64(uint32((v << 1) ^ (v >> 31))))
+}
+
+func (t *thriftWriter) listBinary(s string) {
+	t.uvarint(uint64(len(s)))
+	t.buf.WriteString(s)
+}
+
+// structBegin 开始一个结构体字段
+func (t *thriftWriter) structBegin(id int16) {
+	t.fieldHeader(id, thriftStruct)
+	t.elemBegin()
+}
+
+func (t *thriftWriter) structEnd() {
+	t.elemEnd()
+}
+
+// elemBegin 开始列表中的一个结构体元素，字段ID重新计数
+func (t *thriftWriter) elemBegin() {
+	t.stack = append(t.stack, t.lastID)
+	t.lastID = 0
+}
+
+func (t *thriftWriter) elemEnd() {
+	t.stop()
+	t.lastID = t.stack[len(t.stack)-1]
+	t.stack = t.stack[:len(t.stack)-1]
+}
+
+// stop 结构体结束标记
+func (t *thriftWriter) stop() {
+	t.buf.WriteByte(0)
+}
+
+// countingWriter 记录已写入的字节数（列块在文件中的偏移）
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
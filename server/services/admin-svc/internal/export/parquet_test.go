@@ -0,0 +1,332 @@
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 测试用的Parquet读取器：按parquet.thrift和Thrift Compact Protocol规范独立解码，
+// 不复用写入器的编码函数，用于校验写出的文件能被其他实现读回
+
+// thriftStructValue 解码后的Thrift结构体，按字段ID索引
+// i32/i64解码为int64，binary为string，list为[]interface{}，struct为thriftStructValue
+type thriftStructValue map[int16]interface{}
+
+type thriftReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *thriftReader) byte() byte {
+	b := r.data[r.pos]
+	r.pos++
+	return b
+}
+
+func (r *thriftReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.data[r.pos:])
+	if n <= 0 {
+		panic(fmt.Sprintf("invalid varint at %d", r.pos))
+	}
+	r.pos += n
+	return v
+}
+
+func (r *thriftReader) zigzag() int64 {
+	v := r.uvarint()
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (r *thriftReader) value(typ byte) interface{} {
+	switch typ {
+	case 1, 2: // bool（结构体字段中值在类型里）
+		return typ == 1
+	case 3: // byte
+		return int64(int8(r.byte()))
+	case 4, 5, 6: // i16, i32, i64
+		return r.zigzag()
+	case 7: // double
+		v := math.Float64frombits(binary.LittleEndian.Uint64(r.data[r.pos:]))
+		r.pos += 8
+		return v
+	case 8: // binary
+		n := int(r.uvarint())
+		s := string(r.data[r.pos : r.pos+n])
+		r.pos += n
+		return s
+	case 9, 10: // list, set
+		header := r.byte()
+		size, elemType := int(header>>4), header&0x0F
+		if size == 15 {
+			size = int(r.uvarint())
+		}
+		list := make([]interface{}, size)
+		for i := range list {
+			if elemType == 1 || elemType == 2 {
+				list[i] = r.byte() == 1
+			} else {
+				list[i] = r.value(elemType)
+			}
+		}
+		return list
+	case 12:
+		return r.structValue()
+	default:
+		panic(fmt.Sprintf("unsupported thrift type %d at %d", typ, r.pos))
+	}
+}
+
+func (r *thriftReader) structValue() thriftStructValue {
+	s := thriftStructValue{}
+	var lastID int16
+	for {
+		header := r.byte()
+		if header == 0 {
+			return s
+		}
+		typ := header & 0x0F
+		id := lastID + int16(header>>4)
+		if header>>4 == 0 {
+			id = int16(r.zigzag())
+		}
+		s[id] = r.value(typ)
+		lastID = id
+	}
+}
+
+func (s thriftStructValue) int(id int16) int64 {
+	v, _ := s[id].(int64)
+	return v
+}
+
+func (s thriftStructValue) list(id int16) []interface{} {
+	v, _ := s[id].([]interface{})
+	return v
+}
+
+// parquetTestFile 读回的文件：元数据和按列拼接所有行组的值（null为nil，时间为毫秒时间戳）
+type parquetTestFile struct {
+	meta    thriftStructValue
+	columns map[string][]interface{}
+}
+
+func readParquet(t *testing.T, data []byte) *parquetTestFile {
+	t.Helper()
+	require.GreaterOrEqual(t, len(data), 12)
+	require.Equal(t, "PAR1", string(data[:4]))
+	require.Equal(t, "PAR1", string(data[len(data)-4:]))
+
+	footerLen := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	footerStart := len(data) - 8 - footerLen
+	footer := &thriftReader{data: data[footerStart : len(data)-8]}
+	meta := footer.structValue()
+	require.Equal(t, footerLen, footer.pos, "footer length")
+
+	file := &parquetTestFile{meta: meta, columns: make(map[string][]interface{})}
+	for _, g := range meta.list(4) {
+		group := g.(thriftStructValue)
+		var groupBytes int64
+		for _, c := range group.list(1) {
+			chunk := c.(thriftStructValue)
+			md := chunk[3].(thriftStructValue)
+			path := md.list(3)
+			require.Len(t, path, 1)
+			key := path[0].(string)
+			require.Equal(t, int64(0), md.int(4), "codec")
+
+			offset := md.int(9)
+			page := &thriftReader{data: data[offset:footerStart]}
+			header := page.structValue()
+			require.Equal(t, int64(0), header.int(1), "page type")
+			require.Equal(t, header.int(2), header.int(3), "uncompressed page size")
+			body := data[offset+int64(page.pos) : offset+int64(page.pos)+header.int(3)]
+			require.Equal(t, md.int(7), int64(page.pos)+header.int(3), "chunk size")
+			groupBytes += md.int(7)
+
+			dph := header[5].(thriftStructValue)
+			require.Equal(t, md.int(5), dph.int(1), "num values")
+			values := decodeTestPage(t, body, int(dph.int(1)), md.int(1))
+			file.columns[key] = append(file.columns[key], values...)
+		}
+		assert.Equal(t, group.int(2), groupBytes, "row group byte size")
+	}
+	return file
+}
+
+// decodeTestPage 解码Data Page V1：4字节长度前缀的定义级别（RLE/Bit-Packing混合，位宽1）和PLAIN值
+func decodeTestPage(t *testing.T, body []byte, numValues int, physicalType int64) []interface{} {
+	levelLen := int(binary.LittleEndian.Uint32(body))
+	levels := &thriftReader{data: body[4 : 4+levelLen]}
+	var defined []bool
+	for levels.pos < len(levels.data) {
+		header := levels.uvarint()
+		if header&1 == 0 { // RLE游程
+			v := levels.byte()
+			for i := uint64(0); i < header>>1; i++ {
+				defined = append(defined, v == 1)
+			}
+		} else { // Bit-Packing，每组8个值
+			for i := uint64(0); i < header>>1; i++ {
+				b := levels.byte()
+				for bit := 0; bit < 8; bit++ {
+					defined = append(defined, b&(1<<bit) != 0)
+				}
+			}
+		}
+	}
+	require.GreaterOrEqual(t, len(defined), numValues)
+	defined = defined[:numValues]
+
+	plain := body[4+levelLen:]
+	values := make([]interface{}, numValues)
+	for i, ok := range defined {
+		if !ok {
+			continue
+		}
+		switch physicalType {
+		case 2: // INT64
+			values[i] = int64(binary.LittleEndian.Uint64(plain))
+			plain = plain[8:]
+		case 5: // DOUBLE
+			values[i] = math.Float64frombits(binary.LittleEndian.Uint64(plain))
+			plain = plain[8:]
+		case 6: // BYTE_ARRAY
+			n := binary.LittleEndian.Uint32(plain)
+			values[i] = string(plain[4 : 4+n])
+			plain = plain[4+n:]
+		default:
+			t.Fatalf("unexpected physical type %d", physicalType)
+		}
+	}
+	require.Empty(t, plain, "trailing page bytes")
+	return values
+}
+
+var parquetTestColumns = []Column{
+	{Key: "id", Title: "ID", Type: String},
+	{Key: "count", Title: "次数", Type: Int64},
+	{Key: "ratio", Title: "比例", Type: Float64},
+	{Key: "created_at", Title: "创建时间", Type: Time},
+}
+
+// TestParquetWriter_RoundTrip 测试写出的文件能按规范读回：类型、空值、中文和时间戳一致
+func TestParquetWriter_RoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(FormatParquet, &buf, "", parquetTestColumns)
+	require.NoError(t, err)
+
+	createdAt := time.Date(2026, 3, 2, 10, 30, 0, 123456789, time.UTC)
+	require.NoError(t, w.Write([]interface{}{"a-1", int64(42), 0.5, createdAt}))
+	require.NoError(t, w.Write([]interface{}{"歌单", nil, nil, time.Time{}}))
+	require.NoError(t, w.Write([]interface{}{nil, int64(-1), math.Inf(1), nil}))
+	require.NoError(t, w.Write([]interface{}{"", int64(math.MinInt64), -0.25, time.UnixMilli(0).UTC()}))
+	require.NoError(t, w.Close())
+
+	file := readParquet(t, buf.Bytes())
+	assert.Equal(t, int64(1), file.meta.int(1), "version")
+	assert.Equal(t, int64(4), file.meta.int(3), "num rows")
+	assert.Len(t, file.meta.list(4), 1)
+
+	assert.Equal(t, []interface{}{"a-1", "歌单", nil, ""}, file.columns["id"])
+	assert.Equal(t, []interface{}{int64(42), nil, int64(-1), int64(math.MinInt64)}, file.columns["count"])
+	assert.Equal(t, []interface{}{0.5, nil, math.Inf(1), -0.25}, file.columns["ratio"])
+	assert.Equal(t, []interface{}{createdAt.UnixMilli(), nil, nil, int64(0)}, file.columns["created_at"])
+
+	// schema：根节点加每列一个OPTIONAL叶子，字符串和时间戳带converted_type
+	schema := file.meta.list(2)
+	require.Len(t, schema, len(parquetTestColumns)+1)
+	root := schema[0].(thriftStructValue)
+	assert.Equal(t, int64(len(parquetTestColumns)), root.int(5))
+
+	wantTypes := []struct {
+		physical  int64
+		converted interface{}
+	}{
+		{6, int64(0)}, // BYTE_ARRAY UTF8
+		{2, nil},      // INT64
+		{5, nil},      // DOUBLE
+		{2, int64(9)}, // INT64 TIMESTAMP_MILLIS
+	}
+	for i, col := range parquetTestColumns {
+		el := schema[i+1].(thriftStructValue)
+		assert.Equal(t, col.Key, el[4], "name")
+		assert.Equal(t, wantTypes[i].physical, el.int(1), col.Key)
+		assert.Equal(t, int64(1), el.int(3), "%s repetition", col.Key)
+		assert.Equal(t, wantTypes[i].converted, el[6], col.Key)
+	}
+}
+
+// TestParquetWriter_RowGroups 测试超过行数上限后分多个行组写出，读回的行数和顺序不变
+func TestParquetWriter_RowGroups(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(FormatParquet, &buf, "", parquetTestColumns)
+	require.NoError(t, err)
+
+	const rows = parquetRowGroupRows + 10
+	for i := 0; i < rows; i++ {
+		var id interface{}
+		if i%3 != 0 {
+			id = fmt.Sprintf("row-%d", i)
+		}
+		require.NoError(t, w.Write([]interface{}{id, int64(i), float64(i) / 2, time.UnixMilli(int64(i))}))
+	}
+	require.NoError(t, w.Close())
+
+	file := readParquet(t, buf.Bytes())
+	assert.Equal(t, int64(rows), file.meta.int(3))
+	groups := file.meta.list(4)
+	require.Len(t, groups, 2)
+	assert.Equal(t, int64(parquetRowGroupRows), groups[0].(thriftStructValue).int(3))
+	assert.Equal(t, int64(10), groups[1].(thriftStructValue).int(3))
+
+	require.Len(t, file.columns["count"], rows)
+	for i := 0; i < rows; i++ {
+		require.Equal(t, int64(i), file.columns["count"][i])
+		require.Equal(t, int64(i), file.columns["created_at"][i])
+		if i%3 == 0 {
+			require.Nil(t, file.columns["id"][i])
+		} else {
+			require.Equal(t, fmt.Sprintf("row-%d", i), file.columns["id"][i])
+		}
+	}
+}
+
+// TestParquetWriter_Empty 测试没有数据行时也写出合法的文件
+func TestParquetWriter_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(FormatParquet, &buf, "", parquetTestColumns)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	file := readParquet(t, buf.Bytes())
+	assert.Equal(t, int64(0), file.meta.int(3))
+	assert.Empty(t, file.meta.list(4))
+	assert.Len(t, file.meta.list(2), len(parquetTestColumns)+1)
+}
+
+// TestParquetWriter_TypeMismatch 测试值类型与列类型不一致或列数不对时报错，且不影响之后写入的行
+func TestParquetWriter_TypeMismatch(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(FormatParquet, &buf, "", parquetTestColumns)
+	require.NoError(t, err)
+
+	assert.Error(t, w.Write([]interface{}{"a", 42, nil, nil}))
+	assert.Error(t, w.Write([]interface{}{"a", nil, nil, "2026-03-02"}))
+	assert.Error(t, w.Write([]interface{}{"a"}))
+
+	// 失败的行不会留下部分列的值
+	require.NoError(t, w.Write([]interface{}{"b", int64(1), 1.5, nil}))
+	require.NoError(t, w.Close())
+	file := readParquet(t, buf.Bytes())
+	assert.Equal(t, int64(1), file.meta.int(3))
+	assert.Equal(t, []interface{}{"b"}, file.columns["id"])
+	assert.Equal(t, []interface{}{int64(1)}, file.columns["count"])
+	assert.Equal(t, []interface{}{1.5}, file.columns["ratio"])
+}
@@ -0,0 +1,112 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// S3Config S3兼容存储配置（AWS S3、MinIO、阿里云OSS、腾讯云COS等）
+type S3Config struct {
+	Endpoint     string // 为空时使用AWS S3
+	Region       string
+	Bucket       string
+	AccessKey    string
+	SecretKey    string
+	Prefix       string // 对象键前缀，如"exports/"
+	UsePathStyle bool   // MinIO等需要路径风格（endpoint/bucket/key）
+}
+
+// S3Storage S3兼容存储，下载链接为预签名URL，直接从存储下载不经过admin-svc
+type S3Storage struct {
+	client  *s3.Client
+	presign *s3.PresignClient
+	bucket  string
+	prefix  string
+}
+
+// NewS3Storage 创建S3兼容存储
+func NewS3Storage(cfg S3Config) (*S3Storage, error) {
+	if cfg.Bucket == "" {
+		return nil, errors.New("s3 bucket is required")
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+
+	opts := s3.Options{
+		Region:       cfg.Region,
+		UsePathStyle: cfg.UsePathStyle,
+	}
+	if cfg.AccessKey != "" {
+		opts.Credentials = aws.NewCredentialsCache(credentials.NewStaticCredentialsProvider(cfg.AccessKey, cfg.SecretKey, ""))
+	}
+	if cfg.Endpoint != "" {
+		opts.BaseEndpoint = aws.String(cfg.Endpoint)
+	}
+	client := s3.New(opts)
+
+	return &S3Storage{
+		client:  client,
+		presign: s3.NewPresignClient(client),
+		bucket:  cfg.Bucket,
+		prefix:  cfg.Prefix,
+	}, nil
+}
+
+// Put 上传文件（单次PutObject，文件上限5GB）
+func (s *S3Storage) Put(ctx context.Context, key, path, contentType string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("open export file: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("stat export file: %w", err)
+	}
+
+	_, err = s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(s.bucket),
+		Key:           aws.String(s.prefix + key),
+		Body:          file,
+		ContentLength: aws.Int64(info.Size()),
+		ContentType:   aws.String(contentType),
+	})
+	if err != nil {
+		return fmt.Errorf("put s3 object: %w", err)
+	}
+	return nil
+}
+
+// URL 生成预签名下载链接
+func (s *S3Storage) URL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	req, err := s.presign.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket:                     aws.String(s.bucket),
+		Key:                        aws.String(s.prefix + key),
+		ResponseContentDisposition: aws.String(fmt.Sprintf("attachment; filename=%q", key)),
+	}, s3.WithPresignExpires(ttl))
+	if err != nil {
+		return "", fmt.Errorf("presign s3 object: %w", err)
+	}
+	return req.URL, nil
+}
+
+// Delete 删除对象
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.prefix + key),
+	})
+	if err != nil {
+		return fmt.Errorf("delete s3 object: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Storage 导出文件存储
+// key为不含目录的文件名，同时用作下载时的文件名
+type Storage interface {
+	// Put 把本地文件保存到key，调用方负责删除本地文件
+	Put(ctx context.Context, key, path, contentType string) error
+	// URL 返回有效期为ttl的下载链接
+	URL(ctx context.Context, key string, ttl time.Duration) (string, error)
+	// Delete 删除文件，文件不存在时不报错
+	Delete(ctx context.Context, key string) error
+}
+
+// LinkSigner 生成本地文件的签名下载链接（service.ExportLinkService）
+type LinkSigner interface {
+	SignedURL(filename string, ttl time.Duration) string
+}
+
+// LocalStorage 本地目录存储，通过admin-svc的/exports/:filename签名链接下载
+type LocalStorage struct {
+	dir    string
+	signer LinkSigner
+}
+
+// NewLocalStorage 创建本地目录存储，目录不存在时创建
+func NewLocalStorage(dir string, signer LinkSigner) (*LocalStorage, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("create export dir: %w", err)
+	}
+	return &LocalStorage{dir: dir, signer: signer}, nil
+}
+
+// Put 把文件移动到导出目录（跨文件系统时复制）
+func (s *LocalStorage) Put(ctx context.Context, key, path, contentType string) error {
+	dst := filepath.Join(s.dir, filepath.Base(key))
+	if err := os.Rename(path, dst); err == nil {
+		return nil
+	}
+
+	src, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("open export file: %w", err)
+	}
+	defer src.Close()
+
+	tmp := dst + ".part"
+	out, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("create export file: %w", err)
+	}
+	if _, err := io.Copy(out, src); err != nil {
+		out.Close()
+		os.Remove(tmp)
+		return fmt.Errorf("copy export file: %w", err)
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("close export file: %w", err)
+	}
+	return os.Rename(tmp, dst)
+}
+
+// URL 生成签名下载链接
+func (s *LocalStorage) URL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	return s.signer.SignedURL(filepath.Base(key), ttl), nil
+}
+
+// Delete 删除文件
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	err := os.Remove(filepath.Join(s.dir, filepath.Base(key)))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("delete export file: %w", err)
+	}
+	return nil
+}
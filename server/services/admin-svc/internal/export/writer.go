@@ -0,0 +1,97 @@
+// Package export 导出文件的流式写入和存储
+// 数据逐行写入CSV/XLSX/Parquet，内存占用与总行数无关；写完的文件上传到本地目录或S3兼容存储
+package export
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// ColumnType 列的数据类型
+type ColumnType int
+
+const (
+	String ColumnType = iota
+	Int64
+	Float64
+	Time
+)
+
+// Column 导出文件的一列
+type Column struct {
+	Key   string // Parquet列名（英文）
+	Title string // CSV/XLSX表头
+	Type  ColumnType
+}
+
+// Writer 逐行写入导出文件
+// 每行的值与列一一对应：String为string、Int64为int64、Float64为float64、Time为time.Time，nil表示空值
+type Writer interface {
+	Write(row []interface{}) error
+	// Close 写出缓冲的数据和文件尾，不关闭底层的io.Writer
+	Close() error
+}
+
+// 导出格式（与domain.ExportFormat*一致）
+const (
+	FormatCSV     = "csv"
+	FormatXLSX    = "xlsx"
+	FormatParquet = "parquet"
+)
+
+// NewWriter 按格式创建写入器，sheet为XLSX的工作表名称
+func NewWriter(format string, w io.Writer, sheet string, columns []Column) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w, columns)
+	case FormatXLSX:
+		return newXLSXWriter(w, sheet, columns)
+	case FormatParquet:
+		return newParquetWriter(w, columns)
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+// ContentType 导出格式对应的MIME类型
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// formatText 把值格式化为文本（CSV/XLSX），空值为空字符串
+func formatText(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case int64:
+		return strconv.FormatInt(val, 10)
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case time.Time:
+		if val.IsZero() {
+			return ""
+		}
+		return val.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// checkRow 校验行的列数
+func checkRow(row []interface{}, columns []Column) error {
+	if len(row) != len(columns) {
+		return fmt.Errorf("row has %d values, expected %d", len(row), len(columns))
+	}
+	return nil
+}
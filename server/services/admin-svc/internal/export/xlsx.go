@@ -0,0 +1,133 @@
+package export
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// xlsxMaxRows 每个工作表最多的数据行数（Excel上限1048576行，含表头）
+const xlsxMaxRows = 1048575
+
+// xlsxWriter XLSX写入器
+// 使用excelize的StreamWriter，行数据超过缓冲后写入临时文件；超过单表行数上限时自动新建工作表
+type xlsxWriter struct {
+	w           io.Writer
+	file        *excelize.File
+	sheet       string
+	columns     []Column
+	headerStyle int
+	stream      *excelize.StreamWriter
+	sheets      int
+	rows        int // 当前工作表已写入的数据行数
+	values      []interface{}
+}
+
+func newXLSXWriter(w io.Writer, sheet string, columns []Column) (*xlsxWriter, error) {
+	f := excelize.NewFile()
+	style, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"#E0E0E0"}, Pattern: 1},
+	})
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("new style: %w", err)
+	}
+
+	xw := &xlsxWriter{
+		w:           w,
+		file:        f,
+		sheet:       sheet,
+		columns:     columns,
+		headerStyle: style,
+		values:      make([]interface{}, len(columns)),
+	}
+	if err := xw.nextSheet(); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return xw, nil
+}
+
+// nextSheet 结束当前工作表并新建一个（第一个工作表复用默认的Sheet1）
+func (xw *xlsxWriter) nextSheet() error {
+	if xw.stream != nil {
+		if err := xw.stream.Flush(); err != nil {
+			return fmt.Errorf("flush sheet: %w", err)
+		}
+	}
+
+	xw.sheets++
+	name := xw.sheet
+	if xw.sheets == 1 {
+		if err := xw.file.SetSheetName("Sheet1", name); err != nil {
+			return fmt.Errorf("rename sheet: %w", err)
+		}
+	} else {
+		name = fmt.Sprintf("%s (%d)", xw.sheet, xw.sheets)
+		if _, err := xw.file.NewSheet(name); err != nil {
+			return fmt.Errorf("new sheet: %w", err)
+		}
+	}
+
+	stream, err := xw.file.NewStreamWriter(name)
+	if err != nil {
+		return fmt.Errorf("new stream writer: %w", err)
+	}
+	if err := stream.SetColWidth(1, len(xw.columns), 15); err != nil {
+		return fmt.Errorf("set col width: %w", err)
+	}
+	header := make([]interface{}, len(xw.columns))
+	for i, col := range xw.columns {
+		header[i] = excelize.Cell{StyleID: xw.headerStyle, Value: col.Title}
+	}
+	if err := stream.SetRow("A1", header); err != nil {
+		return fmt.Errorf("write header: %w", err)
+	}
+
+	xw.stream = stream
+	xw.rows = 0
+	return nil
+}
+
+func (xw *xlsxWriter) Write(row []interface{}) error {
+	if err := checkRow(row, xw.columns); err != nil {
+		return err
+	}
+	if xw.rows >= xlsxMaxRows {
+		if err := xw.nextSheet(); err != nil {
+			return err
+		}
+	}
+
+	// 时间按RFC3339文本写入，与CSV一致
+	for i, v := range row {
+		if xw.columns[i].Type == Time || v == nil {
+			xw.values[i] = formatText(v)
+		} else {
+			xw.values[i] = v
+		}
+	}
+	xw.rows++
+	cell, err := excelize.CoordinatesToCellName(1, xw.rows+1)
+	if err != nil {
+		return err
+	}
+	if err := xw.stream.SetRow(cell, xw.values); err != nil {
+		return fmt.Errorf("write row: %w", err)
+	}
+	return nil
+}
+
+func (xw *xlsxWriter) Close() error {
+	defer xw.file.Close()
+
+	if err := xw.stream.Flush(); err != nil {
+		return fmt.Errorf("flush sheet: %w", err)
+	}
+	if _, err := xw.file.WriteTo(xw.w); err != nil {
+		return fmt.Errorf("write xlsx: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,380 @@
+package grpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"time"
+
+	"admin-svc/internal/domain"
+	"admin-svc/internal/service"
+
+	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/grpc/interceptor"
+	adminv1 "github.com/xiaoxiao0301/listen-stream-v2/server/shared/proto/admin/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// InternalCaller 通过服务Token调用gRPC接口的调用方标识
+	InternalCaller = "internal-ops"
+
+	defaultPageSize = 20
+	maxPageSize     = 100
+
+	// maxStatsDays 系统统计单次最多查询的天数
+	maxStatsDays = 31
+
+	// exportWaitTimeout 同步等待导出任务完成的最长时间（调用方未设置更短的deadline时）
+	exportWaitTimeout = 10 * time.Minute
+)
+
+// ErrTokenNotConfigured 未配置gRPC访问Token
+var ErrTokenNotConfigured = errors.New("admin grpc token not configured")
+
+// AdminServer 管理后台gRPC实现
+type AdminServer struct {
+	adminv1.UnimplementedAdminServiceServer
+	statsSvc *service.StatsService
+	auditSvc *service.AuditService
+	jobSvc   *service.ExportJobService
+	userSvc  *service.UserAdminService
+	now      func() time.Time
+}
+
+// NewAdminServer 创建管理后台gRPC服务器
+func NewAdminServer(
+	statsSvc *service.StatsService,
+	auditSvc *service.AuditService,
+	jobSvc *service.ExportJobService,
+	userSvc *service.UserAdminService,
+) *AdminServer {
+	return &AdminServer{
+		statsSvc: statsSvc,
+		auditSvc: auditSvc,
+		jobSvc:   jobSvc,
+		userSvc:  userSvc,
+		now:      time.Now,
+	}
+}
+
+// TokenValidator 校验调用方携带的服务Token
+// expected为空时拒绝所有请求，避免未配置时接口裸奔
+func TokenValidator(expected string) func(string) (string, error) {
+	return func(token string) (string, error) {
+		if expected == "" {
+			return "", ErrTokenNotConfigured
+		}
+		if subtle.ConstantTimeCompare([]byte(token), []byte(expected)) != 1 {
+			return "", errors.New("invalid token")
+		}
+		return InternalCaller, nil
+	}
+}
+
+// GetSystemStats 获取系统统计（实时指标 + 每日统计）
+func (s *AdminServer) GetSystemStats(ctx context.Context, req *adminv1.GetSystemStatsRequest) (*adminv1.GetSystemStatsResponse, error) {
+	realtime, err := s.statsSvc.GetRealtimeStats(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get realtime stats")
+	}
+	today, err := s.statsSvc.AggregateDailyStats(ctx, s.now())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get daily stats")
+	}
+
+	metrics := &adminv1.RealTimeMetrics{
+		TotalUsers:        int32(today.TotalUsers),
+		ActiveUsersToday:  int32(today.ActiveUsers),
+		TotalFavorites:    int32(today.TotalFavorites),
+		TotalPlaylists:    int32(today.TotalPlaylists),
+		TotalHistory:      int32(today.TotalPlays),
+		ActiveConnections: int32(realtime.ActiveSessions),
+		CurrentQps:        int32(realtime.RequestsPerMin / 60),
+		// 目前只采集了平均响应时间，P99暂不提供
+	}
+	if realtime.RequestsPerMin > 0 {
+		metrics.ErrorRate = float32(realtime.ErrorsPerMin) / float32(realtime.RequestsPerMin) * 100
+	}
+
+	resp := &adminv1.GetSystemStatsResponse{Realtime: metrics}
+	if req.StartTime == nil && req.EndTime == nil {
+		return resp, nil
+	}
+
+	start, end := s.statsRange(req.StartTime, req.EndTime)
+	if end.Before(start) {
+		return nil, status.Error(codes.InvalidArgument, "end_time must not be before start_time")
+	}
+	if end.Sub(start) >= maxStatsDays*24*time.Hour {
+		return nil, status.Errorf(codes.InvalidArgument, "time range must not exceed %d days", maxStatsDays)
+	}
+
+	daily, err := s.statsSvc.GetDailyStatsRange(ctx, start, end)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get daily stats")
+	}
+	for _, d := range daily {
+		resp.DailyStats = append(resp.DailyStats, &adminv1.DailyStat{
+			Date:           d.Date.Format("2006-01-02"),
+			TotalUsers:     int32(d.TotalUsers),
+			ActiveUsers:    int32(d.ActiveUsers),
+			NewUsers:       int32(d.NewUsers),
+			TotalFavorites: int32(d.TotalFavorites),
+			TotalPlaylists: int32(d.TotalPlaylists),
+			TotalHistory:   int32(d.TotalPlays),
+			ApiCalls:       int32(d.TotalRequests),
+			ErrorRate:      float32(d.ErrorRate),
+		})
+	}
+	return resp, nil
+}
+
+// GetUserInfo 获取用户详情
+func (s *AdminServer) GetUserInfo(ctx context.Context, req *adminv1.GetUserInfoRequest) (*adminv1.GetUserInfoResponse, error) {
+	if req.Identifier == "" {
+		return nil, status.Error(codes.InvalidArgument, "identifier is required")
+	}
+
+	detail, err := s.userSvc.GetUserDetail(ctx, req.Identifier)
+	if err != nil {
+		return nil, upstreamError(err, "failed to get user info")
+	}
+
+	resp := &adminv1.GetUserInfoResponse{
+		User: &adminv1.UserInfo{
+			Id:           detail.User.ID,
+			Phone:        detail.User.Phone,
+			Role:         detail.User.Role,
+			Disabled:     detail.User.Disabled,
+			TokenVersion: int32(detail.User.TokenVersion),
+			CreatedAt:    timestamppb.New(detail.User.CreatedAt),
+		},
+		Stats: &adminv1.UsageStats{
+			FavoriteCount: int32(detail.Usage.FavoriteCount),
+			PlaylistCount: int32(detail.Usage.PlaylistCount),
+			HistoryCount:  int32(detail.Usage.HistoryCount),
+			TotalPlayTime: detail.Usage.TotalPlayTime,
+		},
+	}
+	if detail.User.LastLoginAt != nil {
+		resp.User.LastLoginAt = timestamppb.New(*detail.User.LastLoginAt)
+	}
+	for _, d := range detail.Devices {
+		resp.Devices = append(resp.Devices, &adminv1.DeviceInfo{
+			Id:         d.ID,
+			Platform:   d.Platform,
+			IpAddress:  d.IPAddress,
+			LastActive: timestamppb.New(d.LastActive),
+			CreatedAt:  timestamppb.New(d.CreatedAt),
+		})
+	}
+	return resp, nil
+}
+
+// DisableUser 禁用用户
+func (s *AdminServer) DisableUser(ctx context.Context, req *adminv1.DisableUserRequest) (*adminv1.DisableUserResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if req.Reason == "" {
+		return nil, status.Error(codes.InvalidArgument, "reason is required")
+	}
+
+	revoked, err := s.userSvc.DisableUser(ctx, actorFromContext(ctx, req.AdminId), req.UserId, req.Reason)
+	if err != nil {
+		return nil, upstreamError(err, "failed to disable user")
+	}
+	return &adminv1.DisableUserResponse{Success: true, TokensRevoked: int32(revoked)}, nil
+}
+
+// EnableUser 启用用户
+func (s *AdminServer) EnableUser(ctx context.Context, req *adminv1.EnableUserRequest) (*adminv1.EnableUserResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	if err := s.userSvc.EnableUser(ctx, actorFromContext(ctx, req.AdminId), req.UserId); err != nil {
+		return nil, upstreamError(err, "failed to enable user")
+	}
+	return &adminv1.EnableUserResponse{Success: true}, nil
+}
+
+// ListOperationLogs 分页查询操作日志
+func (s *AdminServer) ListOperationLogs(ctx context.Context, req *adminv1.ListOperationLogsRequest) (*adminv1.ListOperationLogsResponse, error) {
+	page := int(req.Page)
+	if page < 1 {
+		page = 1
+	}
+	pageSize := int(req.PageSize)
+	if pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	filter := &domain.OperationLogFilter{
+		AdminID:   req.AdminId,
+		Action:    req.Action,
+		StartTime: timeOrZero(req.StartTime),
+		EndTime:   timeOrZero(req.EndTime),
+	}
+	logs, total, err := s.auditSvc.ListOperationLogs(ctx, filter, page, pageSize)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list operation logs")
+	}
+
+	resp := &adminv1.ListOperationLogsResponse{
+		Total:    int32(total),
+		Page:     int32(page),
+		PageSize: int32(pageSize),
+	}
+	for i := range logs {
+		resp.Logs = append(resp.Logs, operationLogToProto(&logs[i]))
+	}
+	return resp, nil
+}
+
+// ExportOperationLogs 导出操作日志，返回带签名的下载链接
+// 与HTTP导出相同，创建异步导出任务从PostgreSQL流式读取，等待任务完成后返回下载链接
+func (s *AdminServer) ExportOperationLogs(ctx context.Context, req *adminv1.ExportOperationLogsRequest) (*adminv1.ExportOperationLogsResponse, error) {
+	format := domain.ExportFormatCSV
+	if req.Format == adminv1.ExportFormat_EXPORT_FORMAT_EXCEL {
+		format = domain.ExportFormatXLSX
+	}
+	filters := &domain.ExportFilters{AdminID: req.AdminId}
+	if req.StartTime != nil {
+		start := req.StartTime.AsTime()
+		filters.StartTime = &start
+	}
+	if req.EndTime != nil {
+		end := req.EndTime.AsTime()
+		filters.EndTime = &end
+	}
+
+	actor := actorFromContext(ctx, "")
+	job, err := s.jobSvc.Enqueue(ctx, actor, domain.ExportTypeOperationLogs, format, filters)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidExportRequest) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, "failed to create export job")
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, exportWaitTimeout)
+	defer cancel()
+	done, err := s.jobSvc.Wait(waitCtx, actor.AdminID, job.ID)
+	if err != nil {
+		if waitCtx.Err() != nil {
+			return nil, status.Errorf(codes.DeadlineExceeded, "export job %s is still running", job.ID)
+		}
+		return nil, status.Error(codes.Internal, "failed to get export job")
+	}
+	if done.Status != domain.ExportStatusSucceeded || done.DownloadURL == "" {
+		return nil, status.Errorf(codes.Internal, "export job %s %s: %s", done.ID, done.Status, done.Error)
+	}
+
+	return &adminv1.ExportOperationLogsResponse{
+		DownloadUrl: done.DownloadURL,
+		FileSize:    done.FileSize,
+		RecordCount: int32(done.ProcessedRows),
+	}, nil
+}
+
+// statsRange 将统计时间范围对齐到自然日，缺省的一端取另一端（或今天）
+func (s *AdminServer) statsRange(startTS, endTS *timestamppb.Timestamp) (time.Time, time.Time) {
+	end := s.now()
+	if endTS != nil {
+		end = endTS.AsTime().In(time.Local)
+	}
+	start := end
+	if startTS != nil {
+		start = startTS.AsTime().In(time.Local)
+	}
+	return truncateDay(start), truncateDay(end)
+}
+
+// actorFromContext 从请求上下文构造操作人信息
+// adminID为空时使用认证拦截器解析出的调用方
+func actorFromContext(ctx context.Context, adminID string) *domain.AdminActor {
+	if adminID == "" {
+		adminID = interceptor.GetUserID(ctx)
+	}
+	actor := &domain.AdminActor{
+		AdminID:   adminID,
+		AdminName: adminID,
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("x-request-id"); len(values) > 0 {
+			actor.RequestID = values[0]
+		}
+		if values := md.Get("user-agent"); len(values) > 0 {
+			actor.UserAgent = values[0]
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		actor.IP = p.Addr.String()
+	}
+	return actor
+}
+
+// operationLogToProto 转换操作日志
+func operationLogToProto(l *domain.OperationLog) *adminv1.OperationLog {
+	pb := &adminv1.OperationLog{
+		Id:            l.ID,
+		AdminId:       l.AdminID,
+		AdminUsername: l.AdminName,
+		Action:        l.Action,
+		TargetType:    l.Resource,
+		TargetId:      l.ResourceID,
+		RequestId:     l.RequestID,
+		IpAddress:     l.IP,
+		UserAgent:     l.UserAgent,
+		Status:        l.Status,
+		ErrorMessage:  l.ErrorMsg,
+		CreatedAt:     timestamppb.New(l.CreatedAt),
+	}
+	if len(l.Details) == 0 {
+		return pb
+	}
+	details, err := domain.UnmarshalDetails(l.Details)
+	if err != nil {
+		return pb
+	}
+	if before, err := structpb.NewStruct(details.Before); err == nil && len(details.Before) > 0 {
+		pb.BeforeData = before
+	}
+	if after, err := structpb.NewStruct(details.After); err == nil && len(details.After) > 0 {
+		pb.AfterData = after
+	}
+	return pb
+}
+
+// upstreamError 透传下游服务返回的NotFound/InvalidArgument等业务错误，其余统一为Internal
+func upstreamError(err error, msg string) error {
+	if st, ok := service.UpstreamStatus(err); ok {
+		switch st.Code() {
+		case codes.NotFound, codes.InvalidArgument, codes.FailedPrecondition:
+			return status.Error(st.Code(), st.Message())
+		case codes.Unavailable, codes.DeadlineExceeded:
+			return status.Error(codes.Unavailable, msg)
+		}
+	}
+	return status.Error(codes.Internal, msg)
+}
+
+func timeOrZero(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,306 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"admin-svc/internal/domain"
+	"admin-svc/internal/service"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	adminv1 "github.com/xiaoxiao0301/listen-stream-v2/server/shared/proto/admin/v1"
+	authv1 "github.com/xiaoxiao0301/listen-stream-v2/server/shared/proto/auth/v1"
+	userv1 "github.com/xiaoxiao0301/listen-stream-v2/server/shared/proto/user/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// stubAuthClient auth-svc客户端（只实现用户查询和禁用）
+type stubAuthClient struct {
+	authv1.AuthServiceClient
+	getErr      error
+	getRequests []*authv1.GetUserRequest
+	disabled    []*authv1.DisableUserRequest
+}
+
+func (c *stubAuthClient) GetUser(ctx context.Context, req *authv1.GetUserRequest, opts ...grpc.CallOption) (*authv1.GetUserResponse, error) {
+	c.getRequests = append(c.getRequests, req)
+	if c.getErr != nil {
+		return nil, c.getErr
+	}
+	return &authv1.GetUserResponse{User: &authv1.User{
+		Id:           "user-1",
+		Phone:        "138****8000",
+		Role:         authv1.UserRole_USER_ROLE_USER,
+		TokenVersion: 2,
+		CreatedAt:    timestamppb.New(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}}, nil
+}
+
+func (c *stubAuthClient) GetUserDevices(ctx context.Context, req *authv1.GetUserDevicesRequest, opts ...grpc.CallOption) (*authv1.GetUserDevicesResponse, error) {
+	return &authv1.GetUserDevicesResponse{Devices: []*authv1.Device{{
+		Id:         "dev-1",
+		Platform:   authv1.Platform_PLATFORM_ANDROID,
+		IpAddress:  "10.0.0.1",
+		LastActive: timestamppb.New(time.Date(2026, 3, 15, 9, 0, 0, 0, time.UTC)),
+		CreatedAt:  timestamppb.New(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}}}, nil
+}
+
+func (c *stubAuthClient) DisableUser(ctx context.Context, req *authv1.DisableUserRequest, opts ...grpc.CallOption) (*authv1.DisableUserResponse, error) {
+	c.disabled = append(c.disabled, req)
+	return &authv1.DisableUserResponse{Success: true, TokensRevoked: 3}, nil
+}
+
+// stubUserClient user-svc客户端（只实现使用统计）
+type stubUserClient struct {
+	userv1.UserServiceClient
+}
+
+func (c *stubUserClient) ListFavorites(ctx context.Context, req *userv1.ListFavoritesRequest, opts ...grpc.CallOption) (*userv1.ListFavoritesResponse, error) {
+	return &userv1.ListFavoritesResponse{Total: 12}, nil
+}
+
+func (c *stubUserClient) ListPlaylists(ctx context.Context, req *userv1.ListPlaylistsRequest, opts ...grpc.CallOption) (*userv1.ListPlaylistsResponse, error) {
+	return &userv1.ListPlaylistsResponse{Playlists: []*userv1.Playlist{{Id: "p1"}}}, nil
+}
+
+func (c *stubUserClient) ListPlayHistory(ctx context.Context, req *userv1.ListPlayHistoryRequest, opts ...grpc.CallOption) (*userv1.ListPlayHistoryResponse, error) {
+	return &userv1.ListPlayHistoryResponse{Total: 40}, nil
+}
+
+func (c *stubUserClient) GetListeningStats(ctx context.Context, req *userv1.GetListeningStatsRequest, opts ...grpc.CallOption) (*userv1.GetListeningStatsResponse, error) {
+	return &userv1.GetListeningStatsResponse{Summary: &userv1.ListeningSummary{SecondsListened: 7200}}, nil
+}
+
+type adminServerTestEnv struct {
+	server *AdminServer
+	auth   *stubAuthClient
+	audit  *service.AuditService
+}
+
+func newAdminServerTestEnv(t *testing.T) *adminServerTestEnv {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	env := &adminServerTestEnv{
+		auth:  &stubAuthClient{},
+		audit: service.NewAuditService(client, nil, nil, nil),
+	}
+	env.server = NewAdminServer(
+		service.NewStatsService(client, nil),
+		env.audit,
+		nil,
+		service.NewUserAdminService(env.auth, &stubUserClient{}, env.audit),
+	)
+	env.server.now = func() time.Time { return time.Date(2026, 3, 15, 10, 0, 0, 0, time.Local) }
+	return env
+}
+
+// TestTokenValidator 测试服务Token校验，未配置Token时拒绝所有请求
+func TestTokenValidator(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		token    string
+		wantErr  bool
+	}{
+		{"not configured rejects empty token", "", "", true},
+		{"not configured rejects any token", "", "secret", true},
+		{"empty token", "secret", "", true},
+		{"wrong token", "secret", "secreT", true},
+		{"prefix of token", "secret", "secre", true},
+		{"valid token", "secret", "secret", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			caller, err := TokenValidator(tt.expected)(tt.token)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Empty(t, caller)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, InternalCaller, caller)
+		})
+	}
+
+	_, err := TokenValidator("")("")
+	assert.ErrorIs(t, err, ErrTokenNotConfigured)
+}
+
+// TestUpstreamError 测试下游业务错误透传，不可用类错误统一为Unavailable，其余为Internal
+func TestUpstreamError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode codes.Code
+		wantMsg  string
+	}{
+		{"not found", status.Error(codes.NotFound, "user not found"), codes.NotFound, "user not found"},
+		{"wrapped not found", fmt.Errorf("get user: %w", status.Error(codes.NotFound, "user not found")), codes.NotFound, "user not found"},
+		{"invalid argument", status.Error(codes.InvalidArgument, "bad phone"), codes.InvalidArgument, "bad phone"},
+		{"failed precondition", status.Error(codes.FailedPrecondition, "already disabled"), codes.FailedPrecondition, "already disabled"},
+		{"unavailable", status.Error(codes.Unavailable, "connection refused"), codes.Unavailable, "failed"},
+		{"deadline exceeded", status.Error(codes.DeadlineExceeded, "timeout"), codes.Unavailable, "failed"},
+		{"permission denied", status.Error(codes.PermissionDenied, "internal detail"), codes.Internal, "failed"},
+		{"plain error", errors.New("boom"), codes.Internal, "failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st, ok := status.FromError(upstreamError(tt.err, "failed"))
+			require.True(t, ok)
+			assert.Equal(t, tt.wantCode, st.Code())
+			assert.Equal(t, tt.wantMsg, st.Message())
+		})
+	}
+}
+
+// TestGetSystemStats_RangeValidation 测试统计时间范围校验
+func TestGetSystemStats_RangeValidation(t *testing.T) {
+	env := newAdminServerTestEnv(t)
+	ctx := context.Background()
+	day := func(d int) *timestamppb.Timestamp {
+		return timestamppb.New(time.Date(2026, 3, d, 12, 0, 0, 0, time.Local))
+	}
+
+	tests := []struct {
+		name     string
+		start    *timestamppb.Timestamp
+		end      *timestamppb.Timestamp
+		wantCode codes.Code
+		wantDays int
+	}{
+		{"end before start", day(10), day(9), codes.InvalidArgument, 0},
+		{"more than 31 days", timestamppb.New(time.Date(2026, 2, 1, 0, 0, 0, 0, time.Local)), day(4), codes.InvalidArgument, 0},
+		{"31 days", timestamppb.New(time.Date(2026, 2, 1, 0, 0, 0, 0, time.Local)), day(3), codes.OK, 31},
+		{"start only defaults end to today", day(10), nil, codes.OK, 6},
+		{"end only", nil, day(9), codes.OK, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := env.server.GetSystemStats(ctx, &adminv1.GetSystemStatsRequest{StartTime: tt.start, EndTime: tt.end})
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			if tt.wantCode == codes.OK {
+				assert.Len(t, resp.DailyStats, tt.wantDays)
+			}
+		})
+	}
+
+	resp, err := env.server.GetSystemStats(ctx, &adminv1.GetSystemStatsRequest{})
+	require.NoError(t, err)
+	assert.NotNil(t, resp.Realtime)
+	assert.Empty(t, resp.DailyStats)
+}
+
+// TestListOperationLogs_PageClamping 测试分页参数修正
+func TestListOperationLogs_PageClamping(t *testing.T) {
+	env := newAdminServerTestEnv(t)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, env.audit.LogOperation(ctx, &domain.OperationLog{
+			ID:        fmt.Sprintf("log-%d", i),
+			AdminID:   "admin-1",
+			Action:    domain.ActionUpdate,
+			Status:    domain.StatusSuccess,
+			CreatedAt: time.Now(),
+		}))
+	}
+
+	tests := []struct {
+		name         string
+		page         int32
+		pageSize     int32
+		wantPage     int32
+		wantPageSize int32
+	}{
+		{"defaults", 0, 0, 1, defaultPageSize},
+		{"negative", -3, -1, 1, defaultPageSize},
+		{"too large", 2, 1000, 2, maxPageSize},
+		{"in range", 3, 50, 3, 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := env.server.ListOperationLogs(ctx, &adminv1.ListOperationLogsRequest{Page: tt.page, PageSize: tt.pageSize})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPage, resp.Page)
+			assert.Equal(t, tt.wantPageSize, resp.PageSize)
+			assert.Equal(t, int32(3), resp.Total)
+		})
+	}
+
+	resp, err := env.server.ListOperationLogs(ctx, &adminv1.ListOperationLogsRequest{})
+	require.NoError(t, err)
+	assert.Len(t, resp.Logs, 3)
+}
+
+// TestGetUserInfo 测试按手机号或用户ID查询用户详情，以及下游错误映射
+func TestGetUserInfo(t *testing.T) {
+	env := newAdminServerTestEnv(t)
+	ctx := context.Background()
+
+	_, err := env.server.GetUserInfo(ctx, &adminv1.GetUserInfoRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Empty(t, env.auth.getRequests)
+
+	resp, err := env.server.GetUserInfo(ctx, &adminv1.GetUserInfoRequest{Identifier: "13800138000"})
+	require.NoError(t, err)
+	assert.Equal(t, "13800138000", env.auth.getRequests[0].Phone)
+	assert.Equal(t, "user-1", resp.User.Id)
+	assert.Equal(t, "user", resp.User.Role)
+	assert.Equal(t, int32(2), resp.User.TokenVersion)
+	require.NotNil(t, resp.User.LastLoginAt)
+	assert.Equal(t, time.Date(2026, 3, 15, 9, 0, 0, 0, time.UTC), resp.User.LastLoginAt.AsTime())
+	require.Len(t, resp.Devices, 1)
+	assert.Equal(t, "Android", resp.Devices[0].Platform)
+	assert.Equal(t, int32(12), resp.Stats.FavoriteCount)
+	assert.Equal(t, int32(1), resp.Stats.PlaylistCount)
+	assert.Equal(t, int32(40), resp.Stats.HistoryCount)
+	assert.Equal(t, int64(7200), resp.Stats.TotalPlayTime)
+
+	_, err = env.server.GetUserInfo(ctx, &adminv1.GetUserInfoRequest{Identifier: "user-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", env.auth.getRequests[1].UserId)
+
+	env.auth.getErr = status.Error(codes.Unavailable, "auth-svc down")
+	_, err = env.server.GetUserInfo(ctx, &adminv1.GetUserInfoRequest{Identifier: "user-1"})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+// TestDisableUser 测试禁用用户的参数校验和审计日志
+func TestDisableUser(t *testing.T) {
+	env := newAdminServerTestEnv(t)
+	ctx := context.Background()
+
+	_, err := env.server.DisableUser(ctx, &adminv1.DisableUserRequest{Reason: "spam"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = env.server.DisableUser(ctx, &adminv1.DisableUserRequest{UserId: "user-1"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Empty(t, env.auth.disabled)
+
+	resp, err := env.server.DisableUser(ctx, &adminv1.DisableUserRequest{UserId: "user-1", Reason: "spam", AdminId: "ops-bot"})
+	require.NoError(t, err)
+	assert.True(t, resp.Success)
+	assert.Equal(t, int32(3), resp.TokensRevoked)
+	require.Len(t, env.auth.disabled, 1)
+	assert.Equal(t, "spam", env.auth.disabled[0].Reason)
+
+	logs, _, err := env.audit.ListOperationLogs(ctx, nil, 1, 20)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	assert.Equal(t, "ops-bot", logs[0].AdminID)
+	assert.Equal(t, domain.ActionDisable, logs[0].Action)
+	assert.Equal(t, "user-1", logs[0].ResourceID)
+}
@@ -1,41 +1,44 @@
 package handler
 
 import (
-	"bytes"
+	"errors"
+	"io"
 	"net/http"
-	"time"
 
-	"admin-svc/internal/domain"
 	"admin-svc/internal/service"
+	"admin-svc/internal/webauthn"
 
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 )
 
-// AdminHandler 管理员处理器
+// AdminHandler 管理员登录、第二因素和会话处理器
 type AdminHandler struct {
-	totpSvc   *service.TOTPService
-	auditSvc  *service.AuditService
-	// 实际项目中需要Repository
+	authSvc    *service.AdminAuthService
+	mfaSvc     *service.MFAService
+	sessionSvc *service.AdminSessionService
 }
 
 // NewAdminHandler 创建管理员处理器
 func NewAdminHandler(
-	totpSvc *service.TOTPService,
-	auditSvc *service.AuditService,
+	authSvc *service.AdminAuthService,
+	mfaSvc *service.MFAService,
+	sessionSvc *service.AdminSessionService,
 ) *AdminHandler {
 	return &AdminHandler{
-		totpSvc:  totpSvc,
-		auditSvc: auditSvc,
+		authSvc:    authSvc,
+		mfaSvc:     mfaSvc,
+		sessionSvc: sessionSvc,
 	}
 }
 
 // Login 管理员登录
+// POST /api/v1/admins/login
+// 已绑定第二因素且未提供totp_code时返回mfa_required和mfa_token，再调用/admins/login/verify
 func (h *AdminHandler) Login(c *gin.Context) {
 	var req struct {
 		Username string `json:"username" binding:"required"`
 		Password string `json:"password" binding:"required"`
-		TOTPCode string `json:"totp_code"` // 可选，启用2FA时必须
+		TOTPCode string `json:"totp_code"` // 可选，TOTP码或备用恢复码，提供时一步完成登录
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -43,68 +46,87 @@ func (h *AdminHandler) Login(c *gin.Context) {
 		return
 	}
 
-	// TODO: 从数据库查询用户，验证密码
-	// TODO: 如果启用2FA，验证TOTP码
-	// TODO: 生成JWT Token
-	// TODO: 记录登录日志
+	result, err := h.authSvc.Login(c.Request.Context(), actorFromGin(c), req.Username, req.Password, req.TOTPCode)
+	if err != nil {
+		respondAdminAuthError(c, err, "failed to login")
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
 
-	// 记录操作日志
-	log := &domain.OperationLog{
-		ID:        uuid.New().String(),
-		AdminID:   "admin-id",
-		AdminName: req.Username,
-		Operation: domain.OpLogin,
-		Resource:  domain.ResourceAdminUser,
-		Action:    domain.ActionView,
-		RequestID: c.GetString("request_id"),
-		IP:        c.ClientIP(),
-		UserAgent: c.Request.UserAgent(),
-		Status:    domain.StatusSuccess,
-		Duration:  100,
-		CreatedAt: time.Now(),
+// VerifyLogin 登录第二步：提交TOTP码、备用恢复码或安全密钥断言
+// POST /api/v1/admins/login/verify
+func (h *AdminHandler) VerifyLogin(c *gin.Context) {
+	var req struct {
+		MFAToken string `json:"mfa_token" binding:"required"`
+		service.SecondFactor
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
 	}
-	
-	_ = h.auditSvc.LogOperation(c.Request.Context(), log)
 
-	c.JSON(http.StatusOK, gin.H{
-		"message": "login successful",
-		"token":   "jwt-token",
-	})
+	result, err := h.authSvc.VerifySecondFactor(c.Request.Context(), actorFromGin(c), req.MFAToken, &req.SecondFactor)
+	if err != nil {
+		respondAdminAuthError(c, err, "failed to verify second factor")
+		return
+	}
+	c.JSON(http.StatusOK, result)
 }
 
-// Enable2FA 启用双因素认证
-func (h *AdminHandler) Enable2FA(c *gin.Context) {
-	adminID := c.GetString("admin_id")
-	if adminID == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+// WebAuthnLoginOptions 获取登录第二步的安全密钥断言参数
+// POST /api/v1/admins/login/webauthn/options
+func (h *AdminHandler) WebAuthnLoginOptions(c *gin.Context) {
+	var req struct {
+		MFAToken string `json:"mfa_token" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
 		return
 	}
 
-	// 生成TOTP密钥
-	secret, err := h.totpSvc.GenerateSecret(adminID)
-	_ = adminID // TODO: save to database
+	options, err := h.authSvc.WebAuthnLoginOptions(c.Request.Context(), req.MFAToken)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate secret"})
+		respondAdminAuthError(c, err, "failed to create webauthn options")
 		return
 	}
+	c.JSON(http.StatusOK, options)
+}
 
-	// TODO: 保存密钥到数据库（但标记为未启用）
+// Logout 退出登录（撤销当前会话）
+// POST /api/v1/admins/logout
+func (h *AdminHandler) Logout(c *gin.Context) {
+	if err := h.sessionSvc.Logout(c.Request.Context(), actorFromGin(c)); err != nil {
+		respondAdminAuthError(c, err, "failed to logout")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "logged out"})
+}
 
-	// 生成二维码
-	var buf bytes.Buffer
-	if err := h.totpSvc.GenerateQRCode(adminID, secret, &buf); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate qr code"})
+// Security 查询当前管理员的第二因素绑定情况
+// GET /api/v1/admins/me/security
+func (h *AdminHandler) Security(c *gin.Context) {
+	status, err := h.mfaSvc.Status(c.Request.Context(), c.GetString("admin_id"))
+	if err != nil {
+		respondAdminAuthError(c, err, "failed to get security status")
 		return
 	}
+	c.JSON(http.StatusOK, status)
+}
 
-	c.JSON(http.StatusOK, gin.H{
-		"secret":           secret,
-		"provisioning_uri": h.totpSvc.GetProvisioningURI(adminID, secret),
-		"qr_code":          buf.Bytes(),
-	})
+// Enable2FA 开始绑定TOTP，返回密钥和二维码
+// POST /api/v1/admins/2fa/enable
+func (h *AdminHandler) Enable2FA(c *gin.Context) {
+	enrollment, err := h.mfaSvc.BeginTOTP(c.Request.Context(), actorFromGin(c))
+	if err != nil {
+		respondAdminAuthError(c, err, "failed to generate secret")
+		return
+	}
+	c.JSON(http.StatusOK, enrollment)
 }
 
-// Verify2FA 验证并启用双因素认证
+// Verify2FA 验证验证器生成的码并启用TOTP，首次绑定时返回备用恢复码（只返回一次）
+// POST /api/v1/admins/2fa/verify
 func (h *AdminHandler) Verify2FA(c *gin.Context) {
 	var req struct {
 		Code string `json:"code" binding:"required,len=6"`
@@ -115,49 +137,196 @@ func (h *AdminHandler) Verify2FA(c *gin.Context) {
 		return
 	}
 
-	adminID := c.GetString("admin_id")
-	_ = adminID // TODO: 用于数据库操作
+	codes, err := h.mfaSvc.ConfirmTOTP(c.Request.Context(), actorFromGin(c), req.Code)
+	if err != nil {
+		respondAdminAuthError(c, err, "failed to enable 2fa")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message":      "2fa enabled successfully",
+		"backup_codes": codes,
+	})
+}
 
-	// TODO: 从数据库获取密钥
-	secret := "user-secret"
+// Disable2FA 停用TOTP，需要密码和TOTP码（或备用恢复码）
+// POST /api/v1/admins/2fa/disable
+func (h *AdminHandler) Disable2FA(c *gin.Context) {
+	var req struct {
+		Password string `json:"password" binding:"required"`
+		Code     string `json:"code" binding:"required"`
+	}
 
-	// 验证TOTP码
-	if !h.totpSvc.Verify(req.Code, secret) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid totp code"})
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
 		return
 	}
 
-	// TODO: 更新数据库，标记2FA为启用状态
-
+	if err := h.mfaSvc.DisableTOTP(c.Request.Context(), actorFromGin(c), req.Password, req.Code); err != nil {
+		respondAdminAuthError(c, err, "failed to disable 2fa")
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{
-		"message": "2fa enabled successfully",
+		"message": "2fa disabled successfully",
 	})
 }
 
-// Disable2FA 禁用双因素认证
-func (h *AdminHandler) Disable2FA(c *gin.Context) {
+// RegenerateBackupCodes 重新生成备用恢复码，之前的全部作废
+// POST /api/v1/admins/backup-codes
+func (h *AdminHandler) RegenerateBackupCodes(c *gin.Context) {
 	var req struct {
 		Password string `json:"password" binding:"required"`
-		Code     string `json:"code" binding:"required,len=6"`
 	}
-
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
 		return
 	}
 
-	adminID := c.GetString("admin_id")
-	_ = adminID // TODO: use for database operation
+	codes, err := h.mfaSvc.RegenerateBackupCodes(c.Request.Context(), actorFromGin(c), req.Password)
+	if err != nil {
+		respondAdminAuthError(c, err, "failed to generate backup codes")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"backup_codes": codes})
+}
 
-	// TODO: 验证密码
-	// TODO: 验证TOTP码
-	// TODO: 更新数据库，禁用2FA
+// WebAuthnRegisterOptions 获取注册安全密钥的参数
+// POST /api/v1/admins/webauthn/register/options
+func (h *AdminHandler) WebAuthnRegisterOptions(c *gin.Context) {
+	options, err := h.mfaSvc.BeginWebAuthnRegistration(c.Request.Context(), actorFromGin(c))
+	if err != nil {
+		respondAdminAuthError(c, err, "failed to create webauthn options")
+		return
+	}
+	c.JSON(http.StatusOK, options)
+}
 
-	c.JSON(http.StatusOK, gin.H{
-		"message": "2fa disabled successfully",
+// RegisterWebAuthn 保存安全密钥，首次绑定第二因素时返回备用恢复码（只返回一次）
+// POST /api/v1/admins/webauthn/register
+func (h *AdminHandler) RegisterWebAuthn(c *gin.Context) {
+	var req struct {
+		Name     string                       `json:"name" binding:"max=64"`
+		Response webauthn.AttestationResponse `json:"response" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	cred, codes, err := h.mfaSvc.FinishWebAuthnRegistration(c.Request.Context(), actorFromGin(c), req.Name, req.Response)
+	if err != nil {
+		respondAdminAuthError(c, err, "failed to register security key")
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{
+		"credential":   cred,
+		"backup_codes": codes,
 	})
 }
 
+// ListWebAuthnCredentials 列出当前管理员的安全密钥
+// GET /api/v1/admins/webauthn/credentials
+func (h *AdminHandler) ListWebAuthnCredentials(c *gin.Context) {
+	status, err := h.mfaSvc.Status(c.Request.Context(), c.GetString("admin_id"))
+	if err != nil {
+		respondAdminAuthError(c, err, "failed to list security keys")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": status.WebAuthnCredentials})
+}
+
+// DeleteWebAuthnCredential 移除安全密钥，需要密码
+// DELETE /api/v1/admins/webauthn/credentials/:id
+func (h *AdminHandler) DeleteWebAuthnCredential(c *gin.Context) {
+	var req struct {
+		Password string `json:"password" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	if err := h.mfaSvc.DeleteWebAuthnCredential(c.Request.Context(), actorFromGin(c), c.Param("id"), req.Password); err != nil {
+		respondAdminAuthError(c, err, "failed to delete security key")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ListSessions 列出当前管理员的有效会话
+// GET /api/v1/admins/sessions
+func (h *AdminHandler) ListSessions(c *gin.Context) {
+	sessions, err := h.sessionSvc.List(c.Request.Context(), c.GetString("admin_id"), c.GetString("session_id"))
+	if err != nil {
+		respondAdminAuthError(c, err, "failed to list sessions")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": sessions})
+}
+
+// RevokeSession 撤销当前管理员的某个会话
+// DELETE /api/v1/admins/sessions/:id
+func (h *AdminHandler) RevokeSession(c *gin.Context) {
+	actor := actorFromGin(c)
+	if err := h.sessionSvc.Revoke(c.Request.Context(), actor, actor.AdminID, c.Param("id")); err != nil {
+		respondAdminAuthError(c, err, "failed to revoke session")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// RevokeOtherSessions 撤销当前管理员除当前会话外的所有会话
+// POST /api/v1/admins/sessions/revoke-others
+func (h *AdminHandler) RevokeOtherSessions(c *gin.Context) {
+	actor := actorFromGin(c)
+	revoked, err := h.sessionSvc.RevokeAll(c.Request.Context(), actor, actor.AdminID, actor.SessionID)
+	if err != nil {
+		respondAdminAuthError(c, err, "failed to revoke sessions")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"revoked": revoked})
+}
+
+// ListAdminSessions 列出指定管理员的有效会话
+// GET /api/v1/admins/:id/sessions
+func (h *AdminHandler) ListAdminSessions(c *gin.Context) {
+	sessions, err := h.sessionSvc.List(c.Request.Context(), c.Param("id"), c.GetString("session_id"))
+	if err != nil {
+		respondAdminAuthError(c, err, "failed to list sessions")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": sessions})
+}
+
+// RevokeAdminSessions 撤销指定管理员的会话，提供session_id时只撤销该会话，否则撤销全部
+// POST /api/v1/admins/:id/sessions/revoke
+func (h *AdminHandler) RevokeAdminSessions(c *gin.Context) {
+	var req struct {
+		SessionID string `json:"session_id"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) { // 请求体可以为空
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	actor := actorFromGin(c)
+	adminID := c.Param("id")
+	if req.SessionID != "" {
+		if err := h.sessionSvc.Revoke(c.Request.Context(), actor, adminID, req.SessionID); err != nil {
+			respondAdminAuthError(c, err, "failed to revoke session")
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"revoked": 1})
+		return
+	}
+
+	revoked, err := h.sessionSvc.RevokeAll(c.Request.Context(), actor, adminID, "")
+	if err != nil {
+		respondAdminAuthError(c, err, "failed to revoke sessions")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"revoked": revoked})
+}
+
 // ListAdmins 列出管理员
 func (h *AdminHandler) ListAdmins(c *gin.Context) {
 	type query struct {
@@ -184,3 +353,28 @@ func (h *AdminHandler) ListAdmins(c *gin.Context) {
 		"size":   q.Size,
 	})
 }
+
+// respondAdminAuthError 把登录、第二因素和会话错误转换为HTTP响应
+func respondAdminAuthError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, service.ErrInvalidCredentials), errors.Is(err, service.ErrLoginExpired):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidMFACode), errors.Is(err, service.ErrInvalidPassword),
+		errors.Is(err, service.ErrWebAuthnChallengeExpired), errors.Is(err, webauthn.ErrInvalidResponse):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error(), "code": "invalid_second_factor"})
+	case errors.Is(err, service.ErrAdminDisabled), errors.Is(err, service.ErrLastSecondFactor):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrTooManyAttempts):
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrAdminNotFound), errors.Is(err, service.ErrSessionNotFound),
+		errors.Is(err, service.ErrCredentialNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrTOTPAlreadyEnabled), errors.Is(err, service.ErrCredentialExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrTOTPNotEnabled), errors.Is(err, service.ErrTOTPNotPending),
+		errors.Is(err, service.ErrMFANotEnrolled), errors.Is(err, service.ErrWebAuthnDisabled):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": msg})
+	}
+}
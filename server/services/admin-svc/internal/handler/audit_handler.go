@@ -1,9 +1,8 @@
 package handler
 
 import (
-	"fmt"
+	"errors"
 	"net/http"
-	"strconv"
 	"time"
 
 	"admin-svc/internal/domain"
@@ -15,13 +14,17 @@ import (
 // AuditHandler 审计日志处理器
 type AuditHandler struct {
 	auditSvc  *service.AuditService
-	exportSvc *service.ExportService
+	exportSvc *service.ExportJobService
+	alertSvc  *service.AlertService
+	chainSvc  *service.AuditChainService
 }
 
-func NewAuditHandler(auditSvc *service.AuditService, exportSvc *service.ExportService) *AuditHandler {
+func NewAuditHandler(auditSvc *service.AuditService, exportSvc *service.ExportJobService, alertSvc *service.AlertService, chainSvc *service.AuditChainService) *AuditHandler {
 	return &AuditHandler{
 		auditSvc:  auditSvc,
 		exportSvc: exportSvc,
+		alertSvc:  alertSvc,
+		chainSvc:  chainSvc,
 	}
 }
 
@@ -48,23 +51,33 @@ func (h *AuditHandler) ListOperationLogs(c *gin.Context) {
 		return
 	}
 
-	// TODO: 实际应该从数据库查询
-	// 这里返回模拟数据
-	logs := []domain.OperationLog{
-		{
-			ID:        "log-1",
-			AdminID:   "admin-1",
-			AdminName: "管理员",
-			Operation: "login",
-			Resource:  "admin_user",
-			Action:    "login",
-			Status:    "success",
-			IP:        "192.168.1.100",
-			UserAgent: "Mozilla/5.0",
-			RequestID: "req-1",
-			Duration:  50,
-			CreatedAt: time.Now().Add(-1 * time.Hour),
-		},
+	filter := &domain.OperationLogFilter{
+		AdminID:   req.AdminID,
+		Operation: req.Operation,
+		Resource:  req.Resource,
+		Status:    req.Status,
+	}
+	if req.StartDate != "" {
+		startDate, err := time.ParseInLocation("2006-01-02", req.StartDate, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid start_date format"})
+			return
+		}
+		filter.StartTime = startDate
+	}
+	if req.EndDate != "" {
+		endDate, err := time.ParseInLocation("2006-01-02", req.EndDate, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid end_date format"})
+			return
+		}
+		filter.EndTime = endDate.AddDate(0, 0, 1) // 包含结束日期当天
+	}
+
+	logs, total, err := h.auditSvc.ListOperationLogs(c.Request.Context(), filter, req.Page, req.Size)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list operation logs"})
+		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
@@ -72,119 +85,175 @@ func (h *AuditHandler) ListOperationLogs(c *gin.Context) {
 		"pagination": gin.H{
 			"page":  req.Page,
 			"size":  req.Size,
-			"total": 1,
+			"total": total,
 		},
 	})
 }
 
-// ExportOperationLogs 导出操作日志
+// ExportOperationLogs 导出操作日志（创建异步导出任务）
 // GET /api/v1/audit/logs/export
+// 返回202和排队中的任务，通过GET /api/v1/exports/:id查询进度和下载链接
 func (h *AuditHandler) ExportOperationLogs(c *gin.Context) {
-	format := c.DefaultQuery("format", "excel")
-	startDate := c.Query("start_date")
-	endDate := c.Query("end_date")
-
-	// TODO: 根据日期范围查询数据库
-	logs := []domain.OperationLog{
-		{
-			ID:        "log-1",
-			AdminID:   "admin-1",
-			AdminName: "管理员",
-			Operation: "login",
-			Resource:  "admin_user",
-			Action:    "login",
-			Status:    "success",
-			IP:        "192.168.1.100",
-			UserAgent: "Mozilla/5.0",
-			RequestID: "req-1",
-			Duration:  50,
-			CreatedAt: time.Now(),
-		},
+	var req struct {
+		Format    string `form:"format"`
+		StartDate string `form:"start_date"`
+		EndDate   string `form:"end_date"`
+		AdminID   string `form:"admin_id"`
+		Operation string `form:"operation"`
+		Resource  string `form:"resource"`
+		Action    string `form:"action"`
+		Status    string `form:"status"`
 	}
-
-	filename := fmt.Sprintf("operation_logs_%s_%s.%s", startDate, endDate, format)
-	filepath := fmt.Sprintf("/tmp/%s", filename)
-
-	// 导出
-	var err error
-	if format == "csv" {
-		err = h.exportSvc.ExportToCSV(c.Request.Context(), logs, filepath)
-	} else {
-		err = h.exportSvc.ExportToExcel(c.Request.Context(), logs, filepath)
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
+	// 兼容旧参数：format=excel即xlsx，缺省为xlsx
+	format := req.Format
+	if format == "" || format == "excel" {
+		format = domain.ExportFormatXLSX
+	}
+	job, err := h.exportSvc.Enqueue(c.Request.Context(), actorFromGin(c), domain.ExportTypeOperationLogs, format, &domain.ExportFilters{
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+		AdminID:   req.AdminID,
+		Operation: req.Operation,
+		Resource:  req.Resource,
+		Action:    req.Action,
+		Status:    req.Status,
+	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("export failed: %v", err)})
+		respondExportError(c, err, "failed to create export job")
 		return
 	}
-
-	c.FileAttachment(filepath, filename)
+	respondExportAccepted(c, job)
 }
 
 // ListAnomalousActivities 列出异常活动
 // GET /api/v1/audit/anomalies
 func (h *AuditHandler) ListAnomalousActivities(c *gin.Context) {
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	size, _ := strconv.Atoi(c.DefaultQuery("size", "20"))
-	severity := c.Query("severity")
-	resolved := c.Query("resolved")
-
-	// TODO: 实际应该从数据库查询
-	activities := []domain.AnomalousActivity{
-		{
-			ID:          "anomaly-1",
-			Type:        domain.AnomalousTypeBulkDisable,
-			Severity:    domain.SeverityHigh,
-			Description: "管理员在1小时内禁用了25个用户",
-			AdminID:     "admin-1",
-			AdminName:   "管理员",
-			Resolved:    false,
-			CreatedAt:   time.Now().Add(-2 * time.Hour),
-		},
+	var req struct {
+		Page     int    `form:"page" binding:"min=1"`
+		Size     int    `form:"size" binding:"min=1,max=100"`
+		Severity string `form:"severity" binding:"omitempty,oneof=low medium high critical"`
+		Resolved string `form:"resolved" binding:"omitempty,oneof=true false"`
+	}
+	req.Page = 1
+	req.Size = 20
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
-	// 过滤
-	var filtered []domain.AnomalousActivity
-	for _, a := range activities {
-		if severity != "" && a.Severity != severity {
-			continue
-		}
-		if resolved == "true" && !a.Resolved {
-			continue
-		}
-		if resolved == "false" && a.Resolved {
-			continue
-		}
-		filtered = append(filtered, a)
+	filter := &domain.AnomalyFilter{Severity: req.Severity}
+	if req.Resolved != "" {
+		resolved := req.Resolved == "true"
+		filter.Resolved = &resolved
+	}
+
+	activities, total, err := h.auditSvc.ListAnomalies(c.Request.Context(), filter, req.Page, req.Size)
+	if err != nil {
+		respondAnomalyError(c, err, "failed to list anomalies")
+		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"data": filtered,
+		"data": activities,
 		"pagination": gin.H{
-			"page":  page,
-			"size":  size,
-			"total": len(filtered),
+			"page":  req.Page,
+			"size":  req.Size,
+			"total": total,
 		},
 	})
 }
 
-// ResolveAnomalousActivity 标记异常为已处理
+// ResolveAnomalousActivity 标记异常为已处理（停止升级告警）
 // POST /api/v1/audit/anomalies/:id/resolve
 func (h *AuditHandler) ResolveAnomalousActivity(c *gin.Context) {
-	id := c.Param("id")
-
-	// 获取当前管理员ID（从JWT中解析）
-	// TODO: 实际应该从JWT中获取
-	adminID := "admin-1"
-
-	// TODO: 实际应该更新数据库
-	activity := &domain.AnomalousActivity{
-		ID: id,
+	activity, err := h.auditSvc.ResolveAnomaly(c.Request.Context(), c.Param("id"), c.GetString("admin_id"))
+	if err != nil {
+		respondAnomalyError(c, err, "failed to resolve anomaly")
+		return
 	}
-	activity.Resolve(adminID)
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "异常活动已标记为已处理",
 		"data":    activity,
 	})
 }
+
+// ListAlertDeliveries 列出异常的告警投递记录和投递历史
+// GET /api/v1/audit/anomalies/:id/deliveries
+func (h *AuditHandler) ListAlertDeliveries(c *gin.Context) {
+	deliveries, err := h.alertSvc.ListDeliveries(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondAnomalyError(c, err, "failed to list alert deliveries")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": deliveries})
+}
+
+// RetryAlertDelivery 手动重试最终失败的告警投递
+// POST /api/v1/audit/deliveries/:id/retry
+func (h *AuditHandler) RetryAlertDelivery(c *gin.Context) {
+	delivery, err := h.alertSvc.RetryDelivery(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondAnomalyError(c, err, "failed to retry alert delivery")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": delivery})
+}
+
+// VerifyLogChain 校验日期范围内操作日志的哈希链，返回第一个断点
+// GET /api/v1/audit/chain/verify?start_date=2026-03-01&end_date=2026-03-07
+func (h *AuditHandler) VerifyLogChain(c *gin.Context) {
+	startDate, endDate, ok := parseStatsRange(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.chainSvc.Verify(c.Request.Context(), startDate, endDate.AddDate(0, 0, 1))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to verify log chain"})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// ListLogCheckpoints 列出最近的哈希链签名检查点和签名公钥
+// GET /api/v1/audit/chain/checkpoints?limit=20
+func (h *AuditHandler) ListLogCheckpoints(c *gin.Context) {
+	var req struct {
+		Limit int `form:"limit" binding:"min=1,max=500"`
+	}
+	req.Limit = 20
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	checkpoints, err := h.chainSvc.ListCheckpoints(c.Request.Context(), req.Limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list checkpoints"})
+		return
+	}
+	publicKey, keyID := h.chainSvc.PublicKey()
+	c.JSON(http.StatusOK, gin.H{
+		"data":       checkpoints,
+		"public_key": publicKey,
+		"key_id":     keyID,
+	})
+}
+
+// respondAnomalyError 把异常活动和告警投递错误转换为HTTP响应
+func respondAnomalyError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, service.ErrAnomalyNotFound), errors.Is(err, service.ErrDeliveryNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrAnomalyResolved), errors.Is(err, service.ErrDeliveryNotRetryable):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": msg})
+	}
+}
@@ -1,10 +1,12 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
+	"admin-svc/internal/domain"
 	"admin-svc/internal/service"
 
 	"github.com/gin-gonic/gin"
@@ -13,20 +15,33 @@ import (
 // ConfigHandler 配置管理处理器
 type ConfigHandler struct {
 	configSvc *service.ConfigService
-	auditSvc  *service.AuditService
+	changeSvc *service.ConfigChangeService
 }
 
 // NewConfigHandler 创建配置处理器
 func NewConfigHandler(
 	configSvc *service.ConfigService,
-	auditSvc *service.AuditService,
+	changeSvc *service.ConfigChangeService,
 ) *ConfigHandler {
 	return &ConfigHandler{
 		configSvc: configSvc,
-		auditSvc:  auditSvc,
+		changeSvc: changeSvc,
 	}
 }
 
+// configItemRequest 变更请求中的单个配置项
+type configItemRequest struct {
+	Key    string `json:"key" binding:"required"`
+	Value  string `json:"value"`
+	Delete bool   `json:"delete"`
+}
+
+// pageQuery 分页参数
+type pageQuery struct {
+	Page int `form:"page" binding:"min=1"`
+	Size int `form:"size" binding:"min=1,max=100"`
+}
+
 // GetConfig 获取配置
 func (h *ConfigHandler) GetConfig(c *gin.Context) {
 	key := c.Param("key")
@@ -60,83 +75,150 @@ func (h *ConfigHandler) ListConfigs(c *gin.Context) {
 	})
 }
 
-// UpdateConfig 更新配置
+// UpdateConfig 更新单个配置（提交只包含一个配置项的变更，敏感配置需要审批）
+// PUT /api/v1/configs/:key
 func (h *ConfigHandler) UpdateConfig(c *gin.Context) {
-	key := c.Param("key")
-	if key == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "config key is required"})
-		return
-	}
-
 	var req struct {
 		Value  string `json:"value" binding:"required"`
 		Reason string `json:"reason"`
 	}
-
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
 		return
 	}
 
-	// 获取旧值
-	oldConfig, _ := h.configSvc.GetWithVersion(c.Request.Context(), key)
-	oldValue := ""
-	if oldConfig != nil {
-		oldValue = oldConfig.Value
+	input := service.ConfigItemInput{Key: c.Param("key"), Value: req.Value}
+	change, err := h.changeSvc.Submit(c.Request.Context(), actorFromGin(c), []service.ConfigItemInput{input}, req.Reason)
+	respondConfigChange(c, change, err, "failed to update config")
+}
+
+// DeleteConfig 删除单个配置（敏感配置需要审批）
+// DELETE /api/v1/configs/:key?reason=xxx
+func (h *ConfigHandler) DeleteConfig(c *gin.Context) {
+	input := service.ConfigItemInput{Key: c.Param("key"), Delete: true}
+	change, err := h.changeSvc.Submit(c.Request.Context(), actorFromGin(c), []service.ConfigItemInput{input}, c.Query("reason"))
+	respondConfigChange(c, change, err, "failed to delete config")
+}
+
+// PreviewConfigChange 校验变更并返回差异（dry-run，不写入）
+// POST /api/v1/configs/changes/preview
+func (h *ConfigHandler) PreviewConfigChange(c *gin.Context) {
+	var req struct {
+		Items []configItemRequest `json:"items" binding:"required,min=1,dive"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
 	}
 
-	// 更新配置
-	if err := h.configSvc.Set(c.Request.Context(), key, req.Value); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("failed to update config: %v", err)})
+	preview, err := h.changeSvc.Preview(c.Request.Context(), configItemInputs(req.Items))
+	if err != nil {
+		respondConfigError(c, err, "failed to preview config change")
 		return
 	}
+	c.JSON(http.StatusOK, preview)
+}
 
-	// TODO: 保存历史记录到数据库
-	adminID := c.GetString("admin_id")
-	adminName := c.GetString("admin_name")
-	
-	history := h.configSvc.CreateHistory(
-		key, oldValue, req.Value,
-		adminID, adminName, req.Reason,
-	)
-	_ = history // TODO: 保存到数据库
+// SubmitConfigChange 提交配置变更（可包含多个配置项，整体原子写入）
+// POST /api/v1/configs/changes
+func (h *ConfigHandler) SubmitConfigChange(c *gin.Context) {
+	var req struct {
+		Items  []configItemRequest `json:"items" binding:"required,min=1,dive"`
+		Reason string              `json:"reason" binding:"required,max=500"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"message": "config updated successfully",
-		"key":     key,
-		"value":   req.Value,
-	})
+	change, err := h.changeSvc.Submit(c.Request.Context(), actorFromGin(c), configItemInputs(req.Items), req.Reason)
+	respondConfigChange(c, change, err, "failed to submit config change")
 }
 
-// DeleteConfig 删除配置
-func (h *ConfigHandler) DeleteConfig(c *gin.Context) {
-	key := c.Param("key")
-	if key == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "config key is required"})
+// ListConfigChanges 分页查询配置变更
+// GET /api/v1/configs/changes?status=pending&mine=true&page=1&size=20
+func (h *ConfigHandler) ListConfigChanges(c *gin.Context) {
+	req := pageQuery{Page: 1, Size: 20}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	if err := h.configSvc.Delete(c.Request.Context(), key); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("failed to delete config: %v", err)})
+	filter := &domain.ConfigChangeFilter{Status: c.Query("status")}
+	if c.Query("mine") == "true" {
+		filter.CreatedBy = c.GetString("admin_id")
+	}
+	changes, total, err := h.changeSvc.List(c.Request.Context(), filter, req.Page, req.Size)
+	if err != nil {
+		respondConfigError(c, err, "failed to list config changes")
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "config deleted successfully",
+		"data": changes,
+		"pagination": gin.H{
+			"page":  req.Page,
+			"size":  req.Size,
+			"total": total,
+		},
 	})
 }
 
-// GetConfigHistory 获取配置历史
+// GetConfigChange 获取配置变更
+// GET /api/v1/configs/changes/:id
+func (h *ConfigHandler) GetConfigChange(c *gin.Context) {
+	change, err := h.changeSvc.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondConfigError(c, err, "failed to get config change")
+		return
+	}
+	c.JSON(http.StatusOK, change)
+}
+
+// ApproveConfigChange 审批通过并写入配置
+// POST /api/v1/configs/changes/:id/approve
+func (h *ConfigHandler) ApproveConfigChange(c *gin.Context) {
+	change, err := h.changeSvc.Approve(c.Request.Context(), actorFromGin(c), c.Param("id"), reviewComment(c))
+	respondConfigChange(c, change, err, "failed to approve config change")
+}
+
+// RejectConfigChange 拒绝配置变更
+// POST /api/v1/configs/changes/:id/reject
+func (h *ConfigHandler) RejectConfigChange(c *gin.Context) {
+	change, err := h.changeSvc.Reject(c.Request.Context(), actorFromGin(c), c.Param("id"), reviewComment(c))
+	respondConfigChange(c, change, err, "failed to reject config change")
+}
+
+// CancelConfigChange 撤回自己提交的配置变更
+// POST /api/v1/configs/changes/:id/cancel
+func (h *ConfigHandler) CancelConfigChange(c *gin.Context) {
+	change, err := h.changeSvc.Cancel(c.Request.Context(), actorFromGin(c), c.Param("id"))
+	respondConfigChange(c, change, err, "failed to cancel config change")
+}
+
+// GetConfigHistory 获取单个配置的变更历史
+// GET /api/v1/configs/:key/history
 func (h *ConfigHandler) GetConfigHistory(c *gin.Context) {
-	key := c.Param("key")
+	h.listHistory(c, c.Param("key"))
+}
 
-	// TODO: 从数据库查询历史记录 (key)
-	_ = key
+// ListConfigHistory 获取配置变更历史，key为空时返回所有配置
+// GET /api/v1/configs/history?key=api/qq_music/base_url
+func (h *ConfigHandler) ListConfigHistory(c *gin.Context) {
+	h.listHistory(c, c.Query("key"))
+}
 
-	c.JSON(http.StatusOK, gin.H{
-		"histories": []interface{}{},
-		"count":     0,
-	})
+// RollbackConfig 回滚到某条历史记录写入后的值
+// POST /api/v1/configs/history/:id/rollback
+func (h *ConfigHandler) RollbackConfig(c *gin.Context) {
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	// 请求体可选
+	_ = c.ShouldBindJSON(&req)
+
+	change, err := h.changeSvc.Rollback(c.Request.Context(), actorFromGin(c), c.Param("id"), req.Reason)
+	respondConfigChange(c, change, err, "failed to rollback config")
 }
 
 // ClearConfigCache 清除配置缓存
@@ -151,3 +233,78 @@ func (h *ConfigHandler) ClearConfigCache(c *gin.Context) {
 		"cleared_at": time.Now(),
 	})
 }
+
+func (h *ConfigHandler) listHistory(c *gin.Context, key string) {
+	req := pageQuery{Page: 1, Size: 20}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	histories, total, err := h.changeSvc.ListHistory(c.Request.Context(), key, req.Page, req.Size)
+	if err != nil {
+		respondConfigError(c, err, "failed to list config history")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"histories": histories,
+		"count":     len(histories),
+		"pagination": gin.H{
+			"page":  req.Page,
+			"size":  req.Size,
+			"total": total,
+		},
+	})
+}
+
+// reviewComment 读取审批意见（请求体可选）
+func reviewComment(c *gin.Context) string {
+	var req struct {
+		Comment string `json:"comment"`
+	}
+	_ = c.ShouldBindJSON(&req)
+	return req.Comment
+}
+
+func configItemInputs(items []configItemRequest) []service.ConfigItemInput {
+	inputs := make([]service.ConfigItemInput, len(items))
+	for i, item := range items {
+		inputs[i] = service.ConfigItemInput{Key: item.Key, Value: item.Value, Delete: item.Delete}
+	}
+	return inputs
+}
+
+// respondConfigChange 返回变更结果：已写入200，等待审批202，写入冲突409（附带失败的变更）
+func respondConfigChange(c *gin.Context, change *domain.ConfigChange, err error, msg string) {
+	if err != nil {
+		if errors.Is(err, service.ErrConfigConflict) && change != nil {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "data": change.Masked()})
+			return
+		}
+		respondConfigError(c, err, msg)
+		return
+	}
+
+	if change.Status == domain.ConfigChangePending {
+		c.JSON(http.StatusAccepted, gin.H{"message": "config change is waiting for approval", "data": change.Masked()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "config change " + change.Status, "data": change.Masked()})
+}
+
+// respondConfigError 把配置变更错误转换为HTTP响应
+func respondConfigError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, service.ErrConfigChangeNotFound), errors.Is(err, service.ErrConfigHistoryNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidConfigChange):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrConfigChangeNotPending), errors.Is(err, service.ErrConfigConflict):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrSelfApproval), errors.Is(err, service.ErrNotChangeOwner):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": msg})
+	}
+}
@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"admin-svc/internal/domain"
+	"admin-svc/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExportHandler 异步导出任务和导出文件下载处理器
+// 下载链接自带签名，不经过JWT认证
+type ExportHandler struct {
+	linkSvc *service.ExportLinkService
+	jobSvc  *service.ExportJobService
+}
+
+func NewExportHandler(linkSvc *service.ExportLinkService, jobSvc *service.ExportJobService) *ExportHandler {
+	return &ExportHandler{
+		linkSvc: linkSvc,
+		jobSvc:  jobSvc,
+	}
+}
+
+// createExportRequest 创建导出任务的请求体
+type createExportRequest struct {
+	Format  string               `json:"format" binding:"required,oneof=csv xlsx parquet"`
+	Filters domain.ExportFilters `json:"filters"`
+}
+
+// CreateOperationLogExport 导出操作日志
+// POST /api/v1/exports/operation-logs
+func (h *ExportHandler) CreateOperationLogExport(c *gin.Context) {
+	h.createJob(c, domain.ExportTypeOperationLogs)
+}
+
+// CreateDailyStatsExport 导出每日统计
+// POST /api/v1/exports/daily-stats
+func (h *ExportHandler) CreateDailyStatsExport(c *gin.Context) {
+	h.createJob(c, domain.ExportTypeDailyStats)
+}
+
+// CreateUserExport 导出终端用户列表
+// POST /api/v1/exports/users
+func (h *ExportHandler) CreateUserExport(c *gin.Context) {
+	h.createJob(c, domain.ExportTypeUsers)
+}
+
+func (h *ExportHandler) createJob(c *gin.Context, exportType string) {
+	var req createExportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	job, err := h.jobSvc.Enqueue(c.Request.Context(), actorFromGin(c), exportType, req.Format, &req.Filters)
+	if err != nil {
+		respondExportError(c, err, "failed to create export job")
+		return
+	}
+	respondExportAccepted(c, job)
+}
+
+// ListExportJobs 列出当前管理员创建的导出任务
+// GET /api/v1/exports
+func (h *ExportHandler) ListExportJobs(c *gin.Context) {
+	var req struct {
+		Page int `form:"page" binding:"min=1"`
+		Size int `form:"size" binding:"min=1,max=100"`
+	}
+	req.Page = 1
+	req.Size = 20
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	jobs, total, err := h.jobSvc.List(c.Request.Context(), c.GetString("admin_id"), req.Page, req.Size)
+	if err != nil {
+		respondExportError(c, err, "failed to list export jobs")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": jobs,
+		"pagination": gin.H{
+			"page":  req.Page,
+			"size":  req.Size,
+			"total": total,
+		},
+	})
+}
+
+// GetExportJob 查询导出任务的状态和进度，完成后返回下载链接
+// GET /api/v1/exports/:id
+func (h *ExportHandler) GetExportJob(c *gin.Context) {
+	job, err := h.jobSvc.Get(c.Request.Context(), c.GetString("admin_id"), c.Param("id"))
+	if err != nil {
+		respondExportError(c, err, "failed to get export job")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": job})
+}
+
+// Download 下载导出文件
+// GET /exports/:filename?expires=...&signature=...
+func (h *ExportHandler) Download(c *gin.Context) {
+	filename := filepath.Base(c.Param("filename"))
+
+	err := h.linkSvc.Verify(filename, c.Query("expires"), c.Query("signature"))
+	if errors.Is(err, service.ErrExportLinkExpired) {
+		c.JSON(http.StatusGone, gin.H{"error": "download link expired"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "invalid download link"})
+		return
+	}
+
+	path := h.linkSvc.FilePath(filename)
+	if _, err := os.Stat(path); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "file not found"})
+		return
+	}
+
+	// 导出文件可能很大，取消服务器的写超时
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+	c.FileAttachment(path, filename)
+}
+
+// respondExportAccepted 返回已入队的导出任务
+func respondExportAccepted(c *gin.Context, job *domain.ExportJob) {
+	c.Header("Location", "/api/v1/exports/"+job.ID)
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "导出任务已创建",
+		"data":    job,
+	})
+}
+
+// respondExportError 把导出任务错误转换为HTTP响应
+func respondExportError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, service.ErrInvalidExportRequest):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrExportJobNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "export job not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": msg})
+	}
+}
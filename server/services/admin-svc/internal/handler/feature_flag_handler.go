@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"admin-svc/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/featureflag"
+)
+
+// FeatureFlagHandler 功能开关处理器
+type FeatureFlagHandler struct {
+	flagSvc *service.FeatureFlagService
+}
+
+// NewFeatureFlagHandler 创建功能开关处理器
+func NewFeatureFlagHandler(flagSvc *service.FeatureFlagService) *FeatureFlagHandler {
+	return &FeatureFlagHandler{
+		flagSvc: flagSvc,
+	}
+}
+
+// featureFlagRequest 创建/修改功能开关的请求体
+type featureFlagRequest struct {
+	Description   string           `json:"description" binding:"max=255"`
+	Type          featureflag.Type `json:"type" binding:"required"`
+	Enabled       bool             `json:"enabled"`
+	Value         json.RawMessage  `json:"value" binding:"required"`
+	OffValue      json.RawMessage  `json:"off_value"`
+	Rollout       float64          `json:"rollout"`
+	AllowUsers    []string         `json:"allow_users"`
+	DenyUsers     []string         `json:"deny_users"`
+	Platforms     []string         `json:"platforms"`
+	MinAppVersion string           `json:"min_app_version"`
+	MaxAppVersion string           `json:"max_app_version"`
+	Client        bool             `json:"client"`
+	Version       uint64           `json:"version"` // 修改时传入读取到的版本号，避免覆盖他人的修改
+}
+
+func (r *featureFlagRequest) flag(key string) *featureflag.Flag {
+	return &featureflag.Flag{
+		Key:           key,
+		Description:   r.Description,
+		Type:          r.Type,
+		Enabled:       r.Enabled,
+		Value:         r.Value,
+		OffValue:      r.OffValue,
+		Rollout:       r.Rollout,
+		AllowUsers:    r.AllowUsers,
+		DenyUsers:     r.DenyUsers,
+		Platforms:     r.Platforms,
+		MinAppVersion: r.MinAppVersion,
+		MaxAppVersion: r.MaxAppVersion,
+		Client:        r.Client,
+		Version:       r.Version,
+	}
+}
+
+// ListFeatureFlags 列出所有功能开关
+// GET /api/v1/feature-flags
+func (h *FeatureFlagHandler) ListFeatureFlags(c *gin.Context) {
+	flags, err := h.flagSvc.List(c.Request.Context())
+	if err != nil {
+		respondFeatureFlagError(c, err, "failed to list feature flags")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": flags})
+}
+
+// GetFeatureFlag 获取功能开关
+// GET /api/v1/feature-flags/:key
+func (h *FeatureFlagHandler) GetFeatureFlag(c *gin.Context) {
+	flag, err := h.flagSvc.Get(c.Request.Context(), c.Param("key"))
+	if err != nil {
+		respondFeatureFlagError(c, err, "failed to get feature flag")
+		return
+	}
+	c.JSON(http.StatusOK, flag)
+}
+
+// CreateFeatureFlag 创建功能开关
+// POST /api/v1/feature-flags
+func (h *FeatureFlagHandler) CreateFeatureFlag(c *gin.Context) {
+	var req struct {
+		Key string `json:"key" binding:"required"`
+		featureFlagRequest
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	flag, err := h.flagSvc.Create(c.Request.Context(), actorFromGin(c), req.flag(req.Key))
+	if err != nil {
+		respondFeatureFlagError(c, err, "failed to create feature flag")
+		return
+	}
+	c.JSON(http.StatusCreated, flag)
+}
+
+// UpdateFeatureFlag 修改功能开关（整体替换，包括调整灰度比例和定向条件）
+// PUT /api/v1/feature-flags/:key
+func (h *FeatureFlagHandler) UpdateFeatureFlag(c *gin.Context) {
+	var req featureFlagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	flag, err := h.flagSvc.Update(c.Request.Context(), actorFromGin(c), req.flag(c.Param("key")))
+	if err != nil {
+		respondFeatureFlagError(c, err, "failed to update feature flag")
+		return
+	}
+	c.JSON(http.StatusOK, flag)
+}
+
+// DeleteFeatureFlag 删除功能开关
+// DELETE /api/v1/feature-flags/:key
+func (h *FeatureFlagHandler) DeleteFeatureFlag(c *gin.Context) {
+	if err := h.flagSvc.Delete(c.Request.Context(), actorFromGin(c), c.Param("key")); err != nil {
+		respondFeatureFlagError(c, err, "failed to delete feature flag")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "feature flag deleted"})
+}
+
+// EvaluateFeatureFlag 按用户、平台和版本求值
+// GET /api/v1/feature-flags/:key/evaluate?user_id=xxx&platform=ios&app_version=2.3.0
+func (h *FeatureFlagHandler) EvaluateFeatureFlag(c *gin.Context) {
+	ec := featureflag.Context{
+		UserID:     c.Query("user_id"),
+		Platform:   c.Query("platform"),
+		AppVersion: c.Query("app_version"),
+	}
+	result, err := h.flagSvc.Evaluate(c.Request.Context(), c.Param("key"), ec)
+	if err != nil {
+		respondFeatureFlagError(c, err, "failed to evaluate feature flag")
+		return
+	}
+	resp := gin.H{"data": result}
+	if ec.UserID != "" {
+		// 用户的灰度分桶（0-9999），小于rollout*100时命中灰度
+		resp["bucket"] = featureflag.Bucket(result.Key, ec.UserID)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// respondFeatureFlagError 把功能开关错误转换为HTTP响应
+func respondFeatureFlagError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, service.ErrFeatureFlagNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidFeatureFlag):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrFeatureFlagExists), errors.Is(err, service.ErrConfigConflict):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": msg})
+	}
+}
@@ -0,0 +1,253 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"admin-svc/internal/domain"
+	"admin-svc/internal/service"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/hashicorp/consul/api"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeConsulKV 内存Consul KV（只实现Get、List和事务CAS）
+type fakeConsulKV struct {
+	mu        sync.Mutex
+	values    map[string][]byte
+	indexes   map[string]uint64
+	lastIndex uint64
+}
+
+func (kv *fakeConsulKV) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	w.Header().Set("X-Consul-Index", strconv.FormatUint(kv.lastIndex, 10))
+	w.Header().Set("X-Consul-LastContact", "0")
+
+	switch {
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v1/kv/"):
+		key := strings.TrimPrefix(r.URL.Path, "/v1/kv/")
+		var pairs api.KVPairs
+		for k, v := range kv.values {
+			if k == key || (r.URL.Query().Has("recurse") && strings.HasPrefix(k, key)) {
+				pairs = append(pairs, &api.KVPair{Key: k, Value: v, ModifyIndex: kv.indexes[k]})
+			}
+		}
+		if len(pairs) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(pairs)
+	case r.Method == http.MethodPut && r.URL.Path == "/v1/txn":
+		var ops api.TxnOps
+		if err := json.NewDecoder(r.Body).Decode(&ops); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		for i, op := range ops {
+			if kv.indexes[op.KV.Key] != op.KV.Index {
+				w.WriteHeader(http.StatusConflict)
+				_ = json.NewEncoder(w).Encode(api.TxnResponse{Errors: api.TxnErrors{{OpIndex: i, What: "index mismatch"}}})
+				return
+			}
+		}
+		for _, op := range ops {
+			if op.KV.Verb == api.KVDeleteCAS {
+				delete(kv.values, op.KV.Key)
+				delete(kv.indexes, op.KV.Key)
+				continue
+			}
+			kv.lastIndex++
+			kv.values[op.KV.Key] = op.KV.Value
+			kv.indexes[op.KV.Key] = kv.lastIndex
+		}
+		_ = json.NewEncoder(w).Encode(api.TxnResponse{})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// memoryConfigHistoryRepository 只记录写入的配置历史
+type memoryConfigHistoryRepository struct {
+	histories []*domain.ConfigHistory
+}
+
+func (r *memoryConfigHistoryRepository) Create(ctx context.Context, h *domain.ConfigHistory) error {
+	r.histories = append(r.histories, h)
+	return nil
+}
+
+func (r *memoryConfigHistoryRepository) Get(ctx context.Context, id string) (*domain.ConfigHistory, error) {
+	return nil, nil
+}
+
+func (r *memoryConfigHistoryRepository) List(ctx context.Context, configKey string, limit, offset int) ([]*domain.ConfigHistory, error) {
+	return nil, nil
+}
+
+func (r *memoryConfigHistoryRepository) Count(ctx context.Context, configKey string) (int64, error) {
+	return 0, nil
+}
+
+type featureFlagHandlerTestEnv struct {
+	router    *gin.Engine
+	histories *memoryConfigHistoryRepository
+}
+
+func newFeatureFlagHandlerTestEnv(t *testing.T) *featureFlagHandlerTestEnv {
+	mr := miniredis.RunT(t)
+	server := httptest.NewServer(&fakeConsulKV{values: make(map[string][]byte), indexes: make(map[string]uint64)})
+	t.Cleanup(server.Close)
+	consul, err := api.NewClient(&api.Config{Address: strings.TrimPrefix(server.URL, "http://")})
+	require.NoError(t, err)
+
+	redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	env := &featureFlagHandlerTestEnv{histories: &memoryConfigHistoryRepository{}}
+	h := NewFeatureFlagHandler(service.NewFeatureFlagService(
+		service.NewConfigService(consul, redisClient, "listen-stream/"),
+		env.histories,
+		service.NewAuditService(redisClient, nil, nil, nil),
+	))
+
+	env.router = gin.New()
+	env.router.Use(func(c *gin.Context) {
+		c.Set("admin_id", "admin-1")
+		c.Set("admin_name", "root")
+	})
+	env.router.GET("/feature-flags", h.ListFeatureFlags)
+	env.router.GET("/feature-flags/:key", h.GetFeatureFlag)
+	env.router.POST("/feature-flags", h.CreateFeatureFlag)
+	env.router.PUT("/feature-flags/:key", h.UpdateFeatureFlag)
+	env.router.DELETE("/feature-flags/:key", h.DeleteFeatureFlag)
+	env.router.GET("/feature-flags/:key/evaluate", h.EvaluateFeatureFlag)
+	return env
+}
+
+func (env *featureFlagHandlerTestEnv) serve(method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	env.router.ServeHTTP(w, req)
+	return w
+}
+
+// TestCreateFeatureFlag_Validation 测试创建开关的请求校验和错误到HTTP状态码的映射
+func TestCreateFeatureFlag_Validation(t *testing.T) {
+	env := newFeatureFlagHandlerTestEnv(t)
+
+	w := env.serve(http.MethodPost, "/feature-flags", `{"key":"player.lossless","type":"bool","enabled":true,"value":true,"rollout":5}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created struct {
+		Key       string  `json:"key"`
+		Rollout   float64 `json:"rollout"`
+		UpdatedBy string  `json:"updated_by"`
+		Version   uint64  `json:"version"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, "player.lossless", created.Key)
+	assert.Equal(t, float64(5), created.Rollout)
+	assert.Equal(t, "root", created.UpdatedBy)
+	assert.NotZero(t, created.Version)
+
+	tests := []struct {
+		name string
+		body string
+		code int
+	}{
+		{"duplicate key", `{"key":"player.lossless","type":"bool","value":true}`, http.StatusConflict},
+		{"missing key", `{"type":"bool","value":true}`, http.StatusBadRequest},
+		{"missing type", `{"key":"player.eq","value":true}`, http.StatusBadRequest},
+		{"malformed body", `{"key":`, http.StatusBadRequest},
+		{"rollout below 0", `{"key":"player.eq","type":"bool","value":true,"rollout":-1}`, http.StatusUnprocessableEntity},
+		{"rollout above 100", `{"key":"player.eq","type":"bool","value":true,"rollout":100.5}`, http.StatusUnprocessableEntity},
+		{"value does not match type", `{"key":"player.eq","type":"number","value":"high"}`, http.StatusUnprocessableEntity},
+		{"invalid key", `{"key":"Player EQ","type":"bool","value":true}`, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := env.serve(http.MethodPost, "/feature-flags", tt.body)
+			assert.Equal(t, tt.code, w.Code, w.Body.String())
+		})
+	}
+
+	w = env.serve(http.MethodGet, "/feature-flags", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var list struct {
+		Data []json.RawMessage `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Len(t, list.Data, 1)
+	assert.Len(t, env.histories.histories, 1)
+}
+
+// TestUpdateFeatureFlag_VersionAndRollout 测试修改开关时的版本冲突、灰度比例校验和不存在的开关
+func TestUpdateFeatureFlag_VersionAndRollout(t *testing.T) {
+	env := newFeatureFlagHandlerTestEnv(t)
+
+	w := env.serve(http.MethodPost, "/feature-flags", `{"key":"player.lossless","type":"bool","enabled":true,"value":true,"rollout":5}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	var created struct {
+		Version uint64 `json:"version"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	version := strconv.FormatUint(created.Version, 10)
+
+	w = env.serve(http.MethodPut, "/feature-flags/player.lossless", `{"type":"bool","value":true,"rollout":20,"version":`+version+`9}`)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	w = env.serve(http.MethodPut, "/feature-flags/player.lossless", `{"type":"bool","value":true,"rollout":101,"version":`+version+`}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	w = env.serve(http.MethodPut, "/feature-flags/player.missing", `{"type":"bool","value":true,"rollout":20}`)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = env.serve(http.MethodPut, "/feature-flags/player.lossless", `{"type":"bool","enabled":true,"value":true,"rollout":20,"version":`+version+`}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var updated struct {
+		Rollout float64 `json:"rollout"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &updated))
+	assert.Equal(t, float64(20), updated.Rollout)
+	require.Len(t, env.histories.histories, 2)
+	assert.Equal(t, domain.ActionUpdate, env.histories.histories[1].Action)
+}
+
+// TestEvaluateAndDeleteFeatureFlag 测试求值返回用户分桶，删除后开关不存在
+func TestEvaluateAndDeleteFeatureFlag(t *testing.T) {
+	env := newFeatureFlagHandlerTestEnv(t)
+
+	w := env.serve(http.MethodPost, "/feature-flags", `{"key":"player.lossless","type":"bool","enabled":true,"value":true,"off_value":false,"rollout":100}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	w = env.serve(http.MethodGet, "/feature-flags/player.lossless/evaluate?user_id=user-1&platform=ios", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var evaluated struct {
+		Data struct {
+			Value json.RawMessage `json:"value"`
+		} `json:"data"`
+		Bucket *int `json:"bucket"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &evaluated))
+	assert.JSONEq(t, `true`, string(evaluated.Data.Value))
+	require.NotNil(t, evaluated.Bucket)
+
+	w = env.serve(http.MethodDelete, "/feature-flags/player.lossless", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = env.serve(http.MethodGet, "/feature-flags/player.lossless", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = env.serve(http.MethodDelete, "/feature-flags/player.lossless", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = env.serve(http.MethodGet, "/feature-flags/player.lossless/evaluate", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
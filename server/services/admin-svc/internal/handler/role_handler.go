@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"admin-svc/internal/domain"
+	"admin-svc/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RoleHandler 角色与权限处理器
+type RoleHandler struct {
+	rbacSvc *service.RBACService
+}
+
+// NewRoleHandler 创建角色与权限处理器
+func NewRoleHandler(rbacSvc *service.RBACService) *RoleHandler {
+	return &RoleHandler{
+		rbacSvc: rbacSvc,
+	}
+}
+
+// ListPermissions 列出所有权限
+// GET /api/v1/permissions
+func (h *RoleHandler) ListPermissions(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"data": domain.Permissions()})
+}
+
+// ListRoles 列出所有角色
+// GET /api/v1/roles
+func (h *RoleHandler) ListRoles(c *gin.Context) {
+	roles, err := h.rbacSvc.ListRoles(c.Request.Context())
+	if err != nil {
+		respondRoleError(c, err, "failed to list roles")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": roles})
+}
+
+// GetRole 获取角色
+// GET /api/v1/roles/:name
+func (h *RoleHandler) GetRole(c *gin.Context) {
+	role, err := h.rbacSvc.GetRole(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		respondRoleError(c, err, "failed to get role")
+		return
+	}
+	c.JSON(http.StatusOK, role)
+}
+
+// CreateRole 创建自定义角色
+// POST /api/v1/roles
+func (h *RoleHandler) CreateRole(c *gin.Context) {
+	var req struct {
+		Name        string   `json:"name" binding:"required"`
+		Description string   `json:"description" binding:"max=255"`
+		Permissions []string `json:"permissions"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	role, err := h.rbacSvc.CreateRole(c.Request.Context(), actorFromGin(c), req.Name, req.Description, req.Permissions)
+	if err != nil {
+		respondRoleError(c, err, "failed to create role")
+		return
+	}
+	c.JSON(http.StatusCreated, role)
+}
+
+// UpdateRole 修改自定义角色（权限列表整体替换）
+// PUT /api/v1/roles/:name
+func (h *RoleHandler) UpdateRole(c *gin.Context) {
+	var req struct {
+		Description string   `json:"description" binding:"max=255"`
+		Permissions []string `json:"permissions"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	role, err := h.rbacSvc.UpdateRole(c.Request.Context(), actorFromGin(c), c.Param("name"), req.Description, req.Permissions)
+	if err != nil {
+		respondRoleError(c, err, "failed to update role")
+		return
+	}
+	c.JSON(http.StatusOK, role)
+}
+
+// DeleteRole 删除自定义角色
+// DELETE /api/v1/roles/:name
+func (h *RoleHandler) DeleteRole(c *gin.Context) {
+	if err := h.rbacSvc.DeleteRole(c.Request.Context(), actorFromGin(c), c.Param("name")); err != nil {
+		respondRoleError(c, err, "failed to delete role")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "role deleted"})
+}
+
+// AssignRole 为管理员分配角色
+// PUT /api/v1/admins/:id/role
+func (h *RoleHandler) AssignRole(c *gin.Context) {
+	var req struct {
+		Role string `json:"role" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	if err := h.rbacSvc.AssignRole(c.Request.Context(), actorFromGin(c), c.Param("id"), req.Role); err != nil {
+		respondRoleError(c, err, "failed to assign role")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "role assigned"})
+}
+
+// respondRoleError 把角色管理错误转换为HTTP响应
+func respondRoleError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, service.ErrRoleNotFound), errors.Is(err, service.ErrAdminNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrRoleExists), errors.Is(err, service.ErrRoleInUse):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidRoleName), errors.Is(err, service.ErrInvalidPermission):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrBuiltInRole), errors.Is(err, service.ErrCannotChangeOwnRole):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": msg})
+	}
+}
@@ -3,8 +3,10 @@ package handler
 import (
 	"fmt"
 	"net/http"
+	"os"
 	"time"
 
+	"admin-svc/internal/domain"
 	"admin-svc/internal/service"
 
 	"github.com/gin-gonic/gin"
@@ -38,37 +40,26 @@ func (h *StatsHandler) GetRealtimeStats(c *gin.Context) {
 	c.JSON(http.StatusOK, stats)
 }
 
+// maxDailyStatsDays 每日统计单次查询/导出的最大天数
+const maxDailyStatsDays = 366
+
 // GetDailyStats 获取每日统计
+// 今天的数据从Redis实时聚合，之前的日期读取每日落库的结果
 func (h *StatsHandler) GetDailyStats(c *gin.Context) {
-	var query struct {
-		StartDate string `form:"start_date" binding:"required"`
-		EndDate   string `form:"end_date" binding:"required"`
-	}
-
-	if err := c.ShouldBindQuery(&query); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query"})
-		return
-	}
-
-	// 解析日期
-	startDate, err := time.Parse("2006-01-02", query.StartDate)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid start_date format"})
+	startDate, endDate, ok := parseStatsRange(c)
+	if !ok {
 		return
 	}
 
-	endDate, err := time.Parse("2006-01-02", query.EndDate)
+	stats, err := h.statsSvc.GetDailyStatsRange(c.Request.Context(), startDate, endDate)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid end_date format"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("failed to get stats: %v", err)})
 		return
 	}
 
-	// TODO: 从数据库查询每日统计 (startDate to endDate)
-	_, _ = startDate, endDate
-
 	c.JSON(http.StatusOK, gin.H{
-		"stats": []interface{}{},
-		"count": 0,
+		"stats": stats,
+		"count": len(stats),
 	})
 }
 
@@ -79,6 +70,49 @@ func (h *StatsHandler) ExportDailyStats(c *gin.Context) {
 		format = "excel"
 	}
 
+	startDate, endDate, ok := parseStatsRange(c)
+	if !ok {
+		return
+	}
+
+	daily, err := h.statsSvc.GetDailyStatsRange(c.Request.Context(), startDate, endDate)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("failed to get stats: %v", err)})
+		return
+	}
+	stats := make([]domain.DailyStats, 0, len(daily))
+	for _, d := range daily {
+		stats = append(stats, *d)
+	}
+
+	ext := "xlsx"
+	if format == "csv" {
+		ext = "csv"
+	}
+	filename := fmt.Sprintf("stats_%s_%s.%s", startDate.Format("2006-01-02"), endDate.Format("2006-01-02"), ext)
+	file, err := os.CreateTemp("", "stats_*."+ext)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("export failed: %v", err)})
+		return
+	}
+	file.Close()
+	defer os.Remove(file.Name())
+
+	if format == "csv" {
+		err = h.exportSvc.ExportStatsToCSV(c.Request.Context(), stats, file.Name())
+	} else {
+		err = h.exportSvc.ExportStatsToExcel(c.Request.Context(), stats, file.Name())
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("export failed: %v", err)})
+		return
+	}
+
+	c.FileAttachment(file.Name(), filename)
+}
+
+// parseStatsRange 解析start_date/end_date（YYYY-MM-DD，本地时区），失败时已写入响应
+func parseStatsRange(c *gin.Context) (time.Time, time.Time, bool) {
 	var query struct {
 		StartDate string `form:"start_date" binding:"required"`
 		EndDate   string `form:"end_date" binding:"required"`
@@ -86,22 +120,29 @@ func (h *StatsHandler) ExportDailyStats(c *gin.Context) {
 
 	if err := c.ShouldBindQuery(&query); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query"})
-		return
+		return time.Time{}, time.Time{}, false
 	}
 
-	// TODO: 从数据库查询统计数据
-	stats := []*struct{}{} // 示例
+	startDate, err := time.ParseInLocation("2006-01-02", query.StartDate, time.Local)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid start_date format"})
+		return time.Time{}, time.Time{}, false
+	}
 
-	// 导出
-	if format == "excel" {
-		c.Header("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
-		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="stats_%s_%s.xlsx"`, query.StartDate, query.EndDate))
-		// err := h.exportSvc.ExportStatsToExcel(c.Request.Context(), stats, c.Writer)
-		// TODO: 实现导出
-		_ = stats
-	} else {
-		c.Header("Content-Type", "text/csv")
-		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="stats_%s_%s.csv"`, query.StartDate, query.EndDate))
-		// TODO: 实现CSV导出
+	endDate, err := time.ParseInLocation("2006-01-02", query.EndDate, time.Local)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid end_date format"})
+		return time.Time{}, time.Time{}, false
+	}
+
+	if endDate.Before(startDate) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "end_date must not be before start_date"})
+		return time.Time{}, time.Time{}, false
+	}
+	if endDate.Sub(startDate) >= maxDailyStatsDays*24*time.Hour {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("date range must not exceed %d days", maxDailyStatsDays)})
+		return time.Time{}, time.Time{}, false
 	}
+
+	return startDate, endDate, true
 }
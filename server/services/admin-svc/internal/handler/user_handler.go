@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"admin-svc/internal/domain"
+	"admin-svc/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+)
+
+// phonePrefixPattern 手机号搜索前缀（3-11位数字）
+var phonePrefixPattern = regexp.MustCompile(`^\d{3,11}$`)
+
+// UserHandler 终端用户管理处理器
+type UserHandler struct {
+	userSvc *service.UserAdminService
+}
+
+// NewUserHandler 创建终端用户管理处理器
+func NewUserHandler(userSvc *service.UserAdminService) *UserHandler {
+	return &UserHandler{
+		userSvc: userSvc,
+	}
+}
+
+// SearchUsers 按手机号前缀搜索用户
+// GET /api/v1/users?phone=138&page=1&size=20
+func (h *UserHandler) SearchUsers(c *gin.Context) {
+	phone := c.Query("phone")
+	if !phonePrefixPattern.MatchString(phone) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "phone must be 3-11 digits"})
+		return
+	}
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(c.DefaultQuery("size", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 || size > 100 {
+		size = 20
+	}
+
+	users, total, err := h.userSvc.SearchUsers(c.Request.Context(), phone, page, size)
+	if err != nil {
+		respondUserError(c, err, "failed to search users")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": users,
+		"pagination": gin.H{
+			"page":  page,
+			"size":  size,
+			"total": total,
+		},
+	})
+}
+
+// GetUser 获取用户详情（账号、设备、收藏/歌单/播放统计）
+// GET /api/v1/users/:id
+func (h *UserHandler) GetUser(c *gin.Context) {
+	detail, err := h.userSvc.GetUserDetail(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondUserError(c, err, "failed to get user")
+		return
+	}
+
+	c.JSON(http.StatusOK, detail)
+}
+
+// GetUserActivity 获取用户近期活动
+// GET /api/v1/users/:id/activity?limit=20
+func (h *UserHandler) GetUserActivity(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	activities, err := h.userSvc.GetRecentActivity(c.Request.Context(), c.Param("id"), limit)
+	if err != nil {
+		respondUserError(c, err, "failed to get user activity")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  activities,
+		"count": len(activities),
+	})
+}
+
+// DisableUser 禁用用户
+// POST /api/v1/users/:id/disable
+func (h *UserHandler) DisableUser(c *gin.Context) {
+	var req struct {
+		Reason string `json:"reason" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "reason is required"})
+		return
+	}
+
+	revoked, err := h.userSvc.DisableUser(c.Request.Context(), actorFromGin(c), c.Param("id"), req.Reason)
+	if err != nil {
+		respondUserError(c, err, "failed to disable user")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":        "用户已禁用",
+		"tokens_revoked": revoked,
+	})
+}
+
+// EnableUser 启用用户
+// POST /api/v1/users/:id/enable
+func (h *UserHandler) EnableUser(c *gin.Context) {
+	if err := h.userSvc.EnableUser(c.Request.Context(), actorFromGin(c), c.Param("id")); err != nil {
+		respondUserError(c, err, "failed to enable user")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "用户已启用"})
+}
+
+// RevokeSessions 强制用户所有设备下线
+// POST /api/v1/users/:id/sessions/revoke
+func (h *UserHandler) RevokeSessions(c *gin.Context) {
+	var req struct {
+		Reason string `json:"reason" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "reason is required"})
+		return
+	}
+
+	revoked, err := h.userSvc.RevokeSessions(c.Request.Context(), actorFromGin(c), c.Param("id"), req.Reason)
+	if err != nil {
+		respondUserError(c, err, "failed to revoke sessions")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":        "用户会话已全部撤销",
+		"tokens_revoked": revoked,
+	})
+}
+
+// RemoveDevice 移除用户的登录设备
+// DELETE /api/v1/users/:id/devices/:device_id
+func (h *UserHandler) RemoveDevice(c *gin.Context) {
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	// 请求体可选
+	_ = c.ShouldBindJSON(&req)
+
+	err := h.userSvc.RemoveDevice(c.Request.Context(), actorFromGin(c), c.Param("id"), c.Param("device_id"), req.Reason)
+	if err != nil {
+		respondUserError(c, err, "failed to remove device")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "设备已移除"})
+}
+
+// actorFromGin 从请求上下文获取当前管理员信息
+func actorFromGin(c *gin.Context) *domain.AdminActor {
+	return &domain.AdminActor{
+		AdminID:   c.GetString("admin_id"),
+		AdminName: c.GetString("admin_name"),
+		SessionID: c.GetString("session_id"),
+		RequestID: c.GetString("request_id"),
+		IP:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+}
+
+// respondUserError 把下游服务错误转换为HTTP响应
+func respondUserError(c *gin.Context, err error, msg string) {
+	if errors.Is(err, service.ErrDeviceNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "device not found"})
+		return
+	}
+	if st, ok := service.UpstreamStatus(err); ok {
+		switch st.Code() {
+		case codes.NotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": st.Message()})
+			return
+		case codes.InvalidArgument:
+			c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+			return
+		case codes.Unavailable, codes.DeadlineExceeded:
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": msg})
+			return
+		}
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": msg})
+}
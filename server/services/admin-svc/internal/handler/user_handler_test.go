@@ -0,0 +1,231 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"admin-svc/internal/service"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authv1 "github.com/xiaoxiao0301/listen-stream-v2/server/shared/proto/auth/v1"
+	userv1 "github.com/xiaoxiao0301/listen-stream-v2/server/shared/proto/user/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// stubAuthClient auth-svc客户端，GetUser返回固定错误或固定用户
+type stubAuthClient struct {
+	authv1.AuthServiceClient
+	getErr      error
+	getRequests []*authv1.GetUserRequest
+	searches    []*authv1.SearchUsersRequest
+	disabled    []string
+}
+
+func (c *stubAuthClient) GetUser(ctx context.Context, req *authv1.GetUserRequest, opts ...grpc.CallOption) (*authv1.GetUserResponse, error) {
+	c.getRequests = append(c.getRequests, req)
+	if c.getErr != nil {
+		return nil, c.getErr
+	}
+	return &authv1.GetUserResponse{User: &authv1.User{Id: "user-1", Phone: "138****8000", CreatedAt: timestamppb.Now()}}, nil
+}
+
+func (c *stubAuthClient) SearchUsers(ctx context.Context, req *authv1.SearchUsersRequest, opts ...grpc.CallOption) (*authv1.SearchUsersResponse, error) {
+	c.searches = append(c.searches, req)
+	return &authv1.SearchUsersResponse{
+		Users: []*authv1.User{{Id: "user-1", Phone: "138****8000", CreatedAt: timestamppb.Now()}},
+		Total: 41,
+	}, nil
+}
+
+func (c *stubAuthClient) GetUserDevices(ctx context.Context, req *authv1.GetUserDevicesRequest, opts ...grpc.CallOption) (*authv1.GetUserDevicesResponse, error) {
+	return &authv1.GetUserDevicesResponse{Devices: []*authv1.Device{
+		{Id: "dev-1", Platform: authv1.Platform_PLATFORM_IOS, LastActive: timestamppb.Now(), CreatedAt: timestamppb.Now()},
+	}}, nil
+}
+
+func (c *stubAuthClient) DisableUser(ctx context.Context, req *authv1.DisableUserRequest, opts ...grpc.CallOption) (*authv1.DisableUserResponse, error) {
+	c.disabled = append(c.disabled, req.UserId)
+	return &authv1.DisableUserResponse{Success: true, TokensRevoked: 1}, nil
+}
+
+// stubUserClient user-svc客户端，记录播放记录的分页参数
+type stubUserClient struct {
+	userv1.UserServiceClient
+	historyPageSizes []int32
+}
+
+func (c *stubUserClient) ListFavorites(ctx context.Context, req *userv1.ListFavoritesRequest, opts ...grpc.CallOption) (*userv1.ListFavoritesResponse, error) {
+	return &userv1.ListFavoritesResponse{}, nil
+}
+
+func (c *stubUserClient) ListPlaylists(ctx context.Context, req *userv1.ListPlaylistsRequest, opts ...grpc.CallOption) (*userv1.ListPlaylistsResponse, error) {
+	return &userv1.ListPlaylistsResponse{}, nil
+}
+
+func (c *stubUserClient) ListPlayHistory(ctx context.Context, req *userv1.ListPlayHistoryRequest, opts ...grpc.CallOption) (*userv1.ListPlayHistoryResponse, error) {
+	c.historyPageSizes = append(c.historyPageSizes, req.PageSize)
+	return &userv1.ListPlayHistoryResponse{}, nil
+}
+
+func (c *stubUserClient) GetListeningStats(ctx context.Context, req *userv1.GetListeningStatsRequest, opts ...grpc.CallOption) (*userv1.GetListeningStatsResponse, error) {
+	return &userv1.GetListeningStatsResponse{}, nil
+}
+
+type userHandlerTestEnv struct {
+	router *gin.Engine
+	auth   *stubAuthClient
+	users  *stubUserClient
+	audit  *service.AuditService
+}
+
+func newUserHandlerTestEnv(t *testing.T) *userHandlerTestEnv {
+	mr := miniredis.RunT(t)
+	env := &userHandlerTestEnv{
+		auth:  &stubAuthClient{},
+		users: &stubUserClient{},
+		audit: service.NewAuditService(redis.NewClient(&redis.Options{Addr: mr.Addr()}), nil, nil, nil),
+	}
+	h := NewUserHandler(service.NewUserAdminService(env.auth, env.users, env.audit))
+
+	env.router = gin.New()
+	env.router.Use(func(c *gin.Context) {
+		c.Set("admin_id", "admin-1")
+		c.Set("admin_name", "root")
+	})
+	env.router.GET("/users", h.SearchUsers)
+	env.router.GET("/users/:id", h.GetUser)
+	env.router.GET("/users/:id/activity", h.GetUserActivity)
+	env.router.POST("/users/:id/disable", h.DisableUser)
+	env.router.DELETE("/users/:id/devices/:device_id", h.RemoveDevice)
+	return env
+}
+
+func (env *userHandlerTestEnv) serve(method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	env.router.ServeHTTP(w, req)
+	return w
+}
+
+// TestGetUser_UpstreamErrors 测试按手机号或用户ID查询，以及下游错误到HTTP状态码的映射
+func TestGetUser_UpstreamErrors(t *testing.T) {
+	env := newUserHandlerTestEnv(t)
+
+	w := env.serve(http.MethodGet, "/users/13800138000", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = env.serve(http.MethodGet, "/users/user-1", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	require.Len(t, env.auth.getRequests, 2)
+	assert.Equal(t, "13800138000", env.auth.getRequests[0].Phone)
+	assert.Equal(t, "user-1", env.auth.getRequests[1].UserId)
+
+	tests := []struct {
+		err      error
+		wantCode int
+	}{
+		{status.Error(codes.NotFound, "user not found"), http.StatusNotFound},
+		{status.Error(codes.InvalidArgument, "bad id"), http.StatusBadRequest},
+		{status.Error(codes.Unavailable, "down"), http.StatusServiceUnavailable},
+		{status.Error(codes.DeadlineExceeded, "slow"), http.StatusServiceUnavailable},
+		{status.Error(codes.Internal, "secret detail"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		env.auth.getErr = tt.err
+		w := env.serve(http.MethodGet, "/users/user-1", "")
+		assert.Equal(t, tt.wantCode, w.Code, tt.err.Error())
+		assert.NotContains(t, w.Body.String(), "secret detail")
+	}
+}
+
+// TestUserHandler_Validation 测试请求参数校验和分页修正
+func TestUserHandler_Validation(t *testing.T) {
+	env := newUserHandlerTestEnv(t)
+
+	for _, phone := range []string{"", "13", "138abc", "138001380001"} {
+		w := env.serve(http.MethodGet, "/users?phone="+phone, "")
+		assert.Equal(t, http.StatusBadRequest, w.Code, phone)
+	}
+
+	for _, tt := range []struct {
+		query string
+		want  int32
+	}{{"", 20}, {"?limit=5", 5}, {"?limit=0", 20}, {"?limit=500", 20}} {
+		w := env.serve(http.MethodGet, "/users/user-1/activity"+tt.query, "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, tt.want, env.users.historyPageSizes[len(env.users.historyPageSizes)-1], tt.query)
+	}
+
+	w := env.serve(http.MethodPost, "/users/user-1/disable", `{}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Empty(t, env.auth.disabled)
+
+	w = env.serve(http.MethodDelete, "/users/user-1/devices/dev-missing", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+// TestDisableUser_RecordsActor 测试禁用用户时审计日志记录当前管理员
+func TestDisableUser_RecordsActor(t *testing.T) {
+	env := newUserHandlerTestEnv(t)
+
+	w := env.serve(http.MethodPost, "/users/user-1/disable", `{"reason":"spam"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, float64(1), body["tokens_revoked"])
+	assert.Equal(t, []string{"user-1"}, env.auth.disabled)
+
+	logs, _, err := env.audit.ListOperationLogs(context.Background(), nil, 1, 20)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	assert.Equal(t, "admin-1", logs[0].AdminID)
+	assert.Equal(t, "root", logs[0].AdminName)
+	assert.Equal(t, "user-1", logs[0].ResourceID)
+}
+
+// TestSearchUsers 测试按手机号前缀搜索和分页参数修正
+func TestSearchUsers(t *testing.T) {
+	env := newUserHandlerTestEnv(t)
+
+	w := env.serve(http.MethodGet, "/users?phone=138&page=3&size=10", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var body struct {
+		Data []struct {
+			ID    string `json:"id"`
+			Phone string `json:"phone"`
+		} `json:"data"`
+		Pagination struct {
+			Page  int `json:"page"`
+			Size  int `json:"size"`
+			Total int `json:"total"`
+		} `json:"pagination"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Len(t, body.Data, 1)
+	assert.Equal(t, "138****8000", body.Data[0].Phone)
+	assert.Equal(t, 3, body.Pagination.Page)
+	assert.Equal(t, 10, body.Pagination.Size)
+	assert.Equal(t, 41, body.Pagination.Total)
+	assert.Equal(t, &authv1.SearchUsersRequest{PhonePrefix: "138", Page: 3, PageSize: 10}, env.auth.searches[0])
+
+	w = env.serve(http.MethodGet, "/users?phone=138&page=0&size=500", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, &authv1.SearchUsersRequest{PhonePrefix: "138", Page: 1, PageSize: 20}, env.auth.searches[1])
+}
@@ -1,6 +1,12 @@
 package middleware
 
 import (
+	"context"
+	"log"
+	"strings"
+
+	"admin-svc/internal/domain"
+
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
@@ -38,48 +44,81 @@ func CORS() gin.HandlerFunc {
 	}
 }
 
-// JWTAuth 中间件 - JWT验证（简化版）
-func JWTAuth() gin.HandlerFunc {
+// SessionAuthenticator 校验管理员Token对应的登录会话，Token或会话无效时返回nil
+type SessionAuthenticator interface {
+	Authenticate(ctx context.Context, token string) (*domain.AdminSession, error)
+}
+
+// JWTAuth 中间件 - 验证管理员Token及其会话，会话撤销、过期或空闲超时后立即失效
+func JWTAuth(auth SessionAuthenticator) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		token := c.GetHeader("Authorization")
+		token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
 		if token == "" {
 			c.JSON(401, gin.H{"error": "unauthorized"})
 			c.Abort()
 			return
 		}
 
-		// TODO: 验证JWT Token
-		// TODO: 提取admin_id和admin_name
-		
-		// 临时设置（实际应该从Token解析）
-		c.Set("admin_id", "admin-123")
-		c.Set("admin_name", "admin")
+		session, err := auth.Authenticate(c.Request.Context(), token)
+		if err != nil {
+			log.Printf("Session authentication failed: %v", err)
+			c.JSON(500, gin.H{"error": "authentication failed"})
+			c.Abort()
+			return
+		}
+		if session == nil {
+			c.JSON(401, gin.H{"error": "unauthorized"})
+			c.Abort()
+			return
+		}
+
+		c.Set("admin_id", session.AdminID)
+		c.Set("admin_name", session.AdminName)
+		c.Set("session_id", session.ID)
+		c.Set("mfa_method", session.MFAMethod)
 
 		c.Next()
 	}
 }
 
-// RequireRole 中间件 - 角色验证
-func RequireRole(allowedRoles ...string) gin.HandlerFunc {
+// RequireSecondFactor 中间件 - 强制2FA时，未验证第二因素的会话只能访问绑定第二因素的接口（需在JWTAuth之后使用）
+func RequireSecondFactor(required bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		role := c.GetString("admin_role")
-		if role == "" {
-			c.JSON(403, gin.H{"error": "forbidden"})
+		if required && c.GetString("mfa_method") == "" {
+			c.JSON(403, gin.H{"error": "two-factor authentication enrollment required", "code": "mfa_enrollment_required"})
 			c.Abort()
 			return
 		}
 
-		// 检查角色是否在允许列表中
-		allowed := false
-		for _, r := range allowedRoles {
-			if role == r {
-				allowed = true
-				break
-			}
+		c.Next()
+	}
+}
+
+// PermissionChecker 检查管理员是否拥有某个权限
+type PermissionChecker interface {
+	HasPermission(ctx context.Context, adminID, permission string) (bool, error)
+}
+
+// RequirePermission 中间件 - 权限验证（需在JWTAuth之后使用）
+// 每次请求都按管理员当前的角色检查，角色变更无需重新登录即可生效
+func RequirePermission(checker PermissionChecker, permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		adminID := c.GetString("admin_id")
+		if adminID == "" {
+			c.JSON(401, gin.H{"error": "unauthorized"})
+			c.Abort()
+			return
 		}
 
+		allowed, err := checker.HasPermission(c.Request.Context(), adminID, permission)
+		if err != nil {
+			log.Printf("Permission check failed for admin %s: %v", adminID, err)
+			c.JSON(500, gin.H{"error": "permission check failed"})
+			c.Abort()
+			return
+		}
 		if !allowed {
-			c.JSON(403, gin.H{"error": "insufficient permissions"})
+			c.JSON(403, gin.H{"error": "insufficient permissions", "permission": permission})
 			c.Abort()
 			return
 		}
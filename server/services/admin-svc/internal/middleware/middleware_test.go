@@ -0,0 +1,199 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"admin-svc/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// stubPermissionChecker 按管理员ID返回固定权限
+type stubPermissionChecker struct {
+	permissions map[string][]string
+	err         error
+	calls       int
+}
+
+func (c *stubPermissionChecker) HasPermission(ctx context.Context, adminID, permission string) (bool, error) {
+	c.calls++
+	if c.err != nil {
+		return false, c.err
+	}
+	for _, p := range c.permissions[adminID] {
+		if p == permission {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// serveWithPermission 以adminID身份请求受permission保护的接口
+func serveWithPermission(checker PermissionChecker, adminID, permission string) *httptest.ResponseRecorder {
+	router := gin.New()
+	router.GET("/test", func(c *gin.Context) {
+		if adminID != "" {
+			c.Set("admin_id", adminID)
+		}
+		c.Next()
+	}, RequirePermission(checker, permission), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test", nil))
+	return w
+}
+
+// TestRequirePermission tests the RequirePermission middleware
+func TestRequirePermission(t *testing.T) {
+	checker := &stubPermissionChecker{permissions: map[string][]string{
+		"admin-1": {"config:view", "config:edit"},
+		"admin-2": {"config:view"},
+	}}
+
+	t.Run("allows admin with permission", func(t *testing.T) {
+		w := serveWithPermission(checker, "admin-1", "config:edit")
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("rejects admin without permission", func(t *testing.T) {
+		w := serveWithPermission(checker, "admin-2", "config:edit")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "config:edit")
+	})
+
+	t.Run("rejects unauthenticated request without checking", func(t *testing.T) {
+		calls := checker.calls
+		w := serveWithPermission(checker, "", "config:view")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Equal(t, calls, checker.calls)
+	})
+
+	t.Run("fails closed when check errors", func(t *testing.T) {
+		failing := &stubPermissionChecker{err: errors.New("database unavailable")}
+		w := serveWithPermission(failing, "admin-1", "config:view")
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+// stubSessionAuthenticator 按Token返回固定会话
+type stubSessionAuthenticator struct {
+	sessions map[string]*domain.AdminSession
+	err      error
+	calls    int
+}
+
+func (a *stubSessionAuthenticator) Authenticate(ctx context.Context, token string) (*domain.AdminSession, error) {
+	a.calls++
+	if a.err != nil {
+		return nil, a.err
+	}
+	return a.sessions[token], nil
+}
+
+// serveWithSession 携带authorization请求经过JWTAuth和RequireSecondFactor的接口，返回处理函数看到的管理员
+func serveWithSession(auth SessionAuthenticator, requireMFA bool, authorization string) (*httptest.ResponseRecorder, gin.H) {
+	var seen gin.H
+	router := gin.New()
+	router.GET("/test", JWTAuth(auth), RequireSecondFactor(requireMFA), func(c *gin.Context) {
+		seen = gin.H{
+			"admin_id":   c.GetString("admin_id"),
+			"admin_name": c.GetString("admin_name"),
+			"session_id": c.GetString("session_id"),
+			"mfa_method": c.GetString("mfa_method"),
+		}
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w, seen
+}
+
+// TestJWTAuth tests the JWTAuth middleware
+func TestJWTAuth(t *testing.T) {
+	auth := &stubSessionAuthenticator{sessions: map[string]*domain.AdminSession{
+		"verified": {ID: "session-1", AdminID: "admin-1", AdminName: "root", MFAMethod: "totp"},
+	}}
+
+	t.Run("sets admin from session", func(t *testing.T) {
+		w, seen := serveWithSession(auth, false, "Bearer verified")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, gin.H{
+			"admin_id":   "admin-1",
+			"admin_name": "root",
+			"session_id": "session-1",
+			"mfa_method": "totp",
+		}, seen)
+	})
+
+	t.Run("rejects missing token without checking", func(t *testing.T) {
+		calls := auth.calls
+		w, seen := serveWithSession(auth, false, "")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Nil(t, seen)
+		assert.Equal(t, calls, auth.calls)
+	})
+
+	t.Run("rejects invalid or revoked session", func(t *testing.T) {
+		w, seen := serveWithSession(auth, false, "Bearer revoked")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Nil(t, seen)
+	})
+
+	t.Run("fails closed when session lookup errors", func(t *testing.T) {
+		failing := &stubSessionAuthenticator{err: errors.New("database unavailable")}
+		w, seen := serveWithSession(failing, false, "Bearer verified")
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Nil(t, seen)
+	})
+}
+
+// TestRequireSecondFactor tests the RequireSecondFactor middleware
+func TestRequireSecondFactor(t *testing.T) {
+	auth := &stubSessionAuthenticator{sessions: map[string]*domain.AdminSession{
+		"verified":   {ID: "session-1", AdminID: "admin-1", MFAMethod: "webauthn"},
+		"unverified": {ID: "session-2", AdminID: "admin-2"},
+	}}
+
+	t.Run("allows verified session", func(t *testing.T) {
+		w, _ := serveWithSession(auth, true, "Bearer verified")
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("rejects unverified session when required", func(t *testing.T) {
+		w, seen := serveWithSession(auth, true, "Bearer unverified")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "mfa_enrollment_required")
+		assert.Nil(t, seen)
+	})
+
+	t.Run("allows unverified session when not required", func(t *testing.T) {
+		w, _ := serveWithSession(auth, false, "Bearer unverified")
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("rejects request that skipped JWTAuth", func(t *testing.T) {
+		router := gin.New()
+		router.GET("/test", RequireSecondFactor(true), func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test", nil))
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"admin-svc/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AdminSessionRepository 管理员登录会话仓储
+type AdminSessionRepository interface {
+	Create(ctx context.Context, session *domain.AdminSession) error
+	// Get 获取会话，不存在时返回nil
+	Get(ctx context.Context, id string) (*domain.AdminSession, error)
+	// ListActive 按创建时间倒序返回管理员未撤销且未过期的会话（不判断空闲超时）
+	ListActive(ctx context.Context, adminID string, now time.Time) ([]*domain.AdminSession, error)
+	// Touch 更新最后活动时间
+	Touch(ctx context.Context, id string, at time.Time) error
+	// SetMFAMethod 记录会话已验证的第二因素（登录后绑定第二因素时使用，已验证的会话不变）
+	SetMFAMethod(ctx context.Context, id, method string) error
+	// Revoke 撤销管理员的某个会话，返回false表示会话不存在、不属于该管理员或已撤销
+	Revoke(ctx context.Context, adminID, id, revokedBy string, at time.Time) (bool, error)
+	// RevokeAll 撤销管理员除exceptID外的所有会话（exceptID为空时全部撤销），返回撤销数量
+	RevokeAll(ctx context.Context, adminID, exceptID, revokedBy string, at time.Time) (int64, error)
+	// DeleteExpired 删除在before之前过期或撤销的会话
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+// AdminSessionRepositoryImpl 管理员会话仓储实现（SQL与queries/admin_session.sql保持一致）
+type AdminSessionRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewAdminSessionRepository 创建管理员会话仓储
+func NewAdminSessionRepository(db *pgxpool.Pool) AdminSessionRepository {
+	return &AdminSessionRepositoryImpl{db: db}
+}
+
+const (
+	adminSessionColumns = `
+		id, admin_id, admin_name, COALESCE(mfa_method, ''), ip, COALESCE(user_agent, ''),
+		created_at, last_seen_at, expires_at, revoked_at, COALESCE(revoked_by, '')
+	`
+	createAdminSessionQuery = `
+		INSERT INTO admin_sessions (
+			id, admin_id, admin_name, mfa_method, ip, user_agent, created_at, last_seen_at, expires_at
+		) VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7, $8, $9)
+	`
+	getAdminSessionQuery         = `SELECT ` + adminSessionColumns + ` FROM admin_sessions WHERE id = $1`
+	listActiveAdminSessionsQuery = `
+		SELECT ` + adminSessionColumns + `
+		FROM admin_sessions
+		WHERE admin_id = $1 AND revoked_at IS NULL AND expires_at > $2
+		ORDER BY created_at DESC
+	`
+	touchAdminSessionQuery        = `UPDATE admin_sessions SET last_seen_at = $1 WHERE id = $2`
+	setAdminSessionMFAMethodQuery = `UPDATE admin_sessions SET mfa_method = COALESCE(mfa_method, $1) WHERE id = $2`
+	revokeAdminSessionQuery       = `
+		UPDATE admin_sessions
+		SET revoked_at = $1, revoked_by = $2
+		WHERE id = $3 AND admin_id = $4 AND revoked_at IS NULL
+	`
+	revokeAdminSessionsQuery = `
+		UPDATE admin_sessions
+		SET revoked_at = $1, revoked_by = $2
+		WHERE admin_id = $3 AND id <> $4 AND revoked_at IS NULL AND expires_at > $1
+	`
+	deleteExpiredAdminSessionsQuery = `
+		DELETE FROM admin_sessions
+		WHERE expires_at < $1 OR revoked_at < $1
+	`
+)
+
+// Create 创建会话
+func (r *AdminSessionRepositoryImpl) Create(ctx context.Context, s *domain.AdminSession) error {
+	_, err := r.db.Exec(ctx, createAdminSessionQuery,
+		s.ID, s.AdminID, s.AdminName, s.MFAMethod, s.IP, s.UserAgent, s.CreatedAt, s.LastSeenAt, s.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("create admin session: %w", err)
+	}
+	return nil
+}
+
+// Get 获取会话
+func (r *AdminSessionRepositoryImpl) Get(ctx context.Context, id string) (*domain.AdminSession, error) {
+	session, err := scanAdminSession(r.db.QueryRow(ctx, getAdminSessionQuery, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get admin session: %w", err)
+	}
+	return session, nil
+}
+
+// ListActive 查询管理员的有效会话
+func (r *AdminSessionRepositoryImpl) ListActive(ctx context.Context, adminID string, now time.Time) ([]*domain.AdminSession, error) {
+	rows, err := r.db.Query(ctx, listActiveAdminSessionsQuery, adminID, now)
+	if err != nil {
+		return nil, fmt.Errorf("list admin sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*domain.AdminSession
+	for rows.Next() {
+		session, err := scanAdminSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan admin session: %w", err)
+		}
+		result = append(result, session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list admin sessions: %w", err)
+	}
+	return result, nil
+}
+
+// Touch 更新最后活动时间
+func (r *AdminSessionRepositoryImpl) Touch(ctx context.Context, id string, at time.Time) error {
+	if _, err := r.db.Exec(ctx, touchAdminSessionQuery, at, id); err != nil {
+		return fmt.Errorf("touch admin session: %w", err)
+	}
+	return nil
+}
+
+// SetMFAMethod 记录会话已验证的第二因素
+func (r *AdminSessionRepositoryImpl) SetMFAMethod(ctx context.Context, id, method string) error {
+	if _, err := r.db.Exec(ctx, setAdminSessionMFAMethodQuery, method, id); err != nil {
+		return fmt.Errorf("set admin session mfa method: %w", err)
+	}
+	return nil
+}
+
+// Revoke 撤销会话
+func (r *AdminSessionRepositoryImpl) Revoke(ctx context.Context, adminID, id, revokedBy string, at time.Time) (bool, error) {
+	tag, err := r.db.Exec(ctx, revokeAdminSessionQuery, at, revokedBy, id, adminID)
+	if err != nil {
+		return false, fmt.Errorf("revoke admin session: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// RevokeAll 撤销管理员的其他会话
+func (r *AdminSessionRepositoryImpl) RevokeAll(ctx context.Context, adminID, exceptID, revokedBy string, at time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, revokeAdminSessionsQuery, at, revokedBy, adminID, exceptID)
+	if err != nil {
+		return 0, fmt.Errorf("revoke admin sessions: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// DeleteExpired 删除早已失效的会话
+func (r *AdminSessionRepositoryImpl) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, deleteExpiredAdminSessionsQuery, before)
+	if err != nil {
+		return 0, fmt.Errorf("delete expired admin sessions: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+func scanAdminSession(row pgx.Row) (*domain.AdminSession, error) {
+	s := &domain.AdminSession{}
+	if err := row.Scan(
+		&s.ID, &s.AdminID, &s.AdminName, &s.MFAMethod, &s.IP, &s.UserAgent,
+		&s.CreatedAt, &s.LastSeenAt, &s.ExpiresAt, &s.RevokedAt, &s.RevokedBy,
+	); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
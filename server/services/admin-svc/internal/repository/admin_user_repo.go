@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"admin-svc/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AdminUserRepository 管理员仓储
+type AdminUserRepository interface {
+	// GetByID 获取管理员，不存在时返回nil
+	GetByID(ctx context.Context, id string) (*domain.AdminUser, error)
+	// GetByUsername 按用户名获取管理员，不存在时返回nil
+	GetByUsername(ctx context.Context, username string) (*domain.AdminUser, error)
+	// UpdateTOTP 保存TOTP密钥和启用状态（secret为空时清除密钥）
+	UpdateTOTP(ctx context.Context, id, secret string, enabled bool, updatedAt time.Time) error
+	// UpdateLastLogin 记录最后登录时间和IP
+	UpdateLastLogin(ctx context.Context, id string, at time.Time, ip string) error
+	// UpdateRole 修改管理员的角色
+	UpdateRole(ctx context.Context, id, role string, updatedAt time.Time) error
+	// CountByRole 统计使用某个角色的管理员数
+	CountByRole(ctx context.Context, role string) (int64, error)
+}
+
+// AdminUserRepositoryImpl 管理员仓储实现（SQL与queries/admin_user.sql保持一致）
+type AdminUserRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewAdminUserRepository 创建管理员仓储
+func NewAdminUserRepository(db *pgxpool.Pool) AdminUserRepository {
+	return &AdminUserRepositoryImpl{db: db}
+}
+
+const (
+	adminUserColumns = `
+		id, username, password_hash, email, role, status,
+		COALESCE(totp_secret, ''), totp_enabled, last_login_at, COALESCE(last_login_ip, ''),
+		created_at, updated_at
+	`
+	getAdminUserQuery           = `SELECT ` + adminUserColumns + ` FROM admin_users WHERE id = $1`
+	getAdminUserByUsernameQuery = `SELECT ` + adminUserColumns + ` FROM admin_users WHERE username = $1`
+	updateAdminUserRoleQuery    = `UPDATE admin_users SET role = $1, updated_at = $2 WHERE id = $3`
+	countAdminUsersByRoleQuery  = `SELECT COUNT(*) FROM admin_users WHERE role = $1`
+	updateAdminUserTOTPQuery    = `
+		UPDATE admin_users
+		SET totp_secret = NULLIF($1, ''), totp_enabled = $2, updated_at = $3
+		WHERE id = $4
+	`
+	updateAdminUserLastLoginQuery = `
+		UPDATE admin_users
+		SET last_login_at = $1, last_login_ip = $2, updated_at = $3
+		WHERE id = $4
+	`
+)
+
+// GetByID 获取管理员
+func (r *AdminUserRepositoryImpl) GetByID(ctx context.Context, id string) (*domain.AdminUser, error) {
+	user, err := scanAdminUser(r.db.QueryRow(ctx, getAdminUserQuery, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get admin user: %w", err)
+	}
+	return user, nil
+}
+
+// GetByUsername 按用户名获取管理员
+func (r *AdminUserRepositoryImpl) GetByUsername(ctx context.Context, username string) (*domain.AdminUser, error) {
+	user, err := scanAdminUser(r.db.QueryRow(ctx, getAdminUserByUsernameQuery, username))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get admin user by username: %w", err)
+	}
+	return user, nil
+}
+
+// UpdateTOTP 保存TOTP密钥和启用状态
+func (r *AdminUserRepositoryImpl) UpdateTOTP(ctx context.Context, id, secret string, enabled bool, updatedAt time.Time) error {
+	if _, err := r.db.Exec(ctx, updateAdminUserTOTPQuery, secret, enabled, updatedAt, id); err != nil {
+		return fmt.Errorf("update admin user totp: %w", err)
+	}
+	return nil
+}
+
+// UpdateLastLogin 记录最后登录时间和IP
+func (r *AdminUserRepositoryImpl) UpdateLastLogin(ctx context.Context, id string, at time.Time, ip string) error {
+	if _, err := r.db.Exec(ctx, updateAdminUserLastLoginQuery, at, ip, at, id); err != nil {
+		return fmt.Errorf("update admin user last login: %w", err)
+	}
+	return nil
+}
+
+// UpdateRole 修改管理员的角色
+func (r *AdminUserRepositoryImpl) UpdateRole(ctx context.Context, id, role string, updatedAt time.Time) error {
+	if _, err := r.db.Exec(ctx, updateAdminUserRoleQuery, role, updatedAt, id); err != nil {
+		return fmt.Errorf("update admin user role: %w", err)
+	}
+	return nil
+}
+
+// CountByRole 统计使用某个角色的管理员数
+func (r *AdminUserRepositoryImpl) CountByRole(ctx context.Context, role string) (int64, error) {
+	var count int64
+	if err := r.db.QueryRow(ctx, countAdminUsersByRoleQuery, role).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count admin users by role: %w", err)
+	}
+	return count, nil
+}
+
+func scanAdminUser(row pgx.Row) (*domain.AdminUser, error) {
+	user := &domain.AdminUser{}
+	if err := row.Scan(
+		&user.ID, &user.Username, &user.PasswordHash, &user.Email, &user.Role, &user.Status,
+		&user.TOTPSecret, &user.TOTPEnabled, &user.LastLoginAt, &user.LastLoginIP,
+		&user.CreatedAt, &user.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"admin-svc/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AlertDeliveryRepository 告警投递仓储
+type AlertDeliveryRepository interface {
+	Create(ctx context.Context, delivery *domain.AlertDelivery) error
+	// Get 获取投递记录，不存在时返回nil
+	Get(ctx context.Context, id string) (*domain.AlertDelivery, error)
+	// ListByAnomaly 按创建时间升序返回某个异常的所有投递记录
+	ListByAnomaly(ctx context.Context, anomalyID string) ([]*domain.AlertDelivery, error)
+	// ListAttempts 按尝试次序返回某次投递的历史
+	ListAttempts(ctx context.Context, deliveryID string) ([]domain.AlertDeliveryAttempt, error)
+	// ClaimDue 领取到期的待投递记录，并把下次投递时间推迟到leaseUntil，避免多个实例重复投递
+	ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*domain.AlertDelivery, error)
+	// Update 更新投递状态
+	Update(ctx context.Context, delivery *domain.AlertDelivery) error
+	// RecordAttempt 在同一事务中更新投递状态并追加一条投递历史
+	RecordAttempt(ctx context.Context, delivery *domain.AlertDelivery, attempt *domain.AlertDeliveryAttempt) error
+	// CancelPending 取消某个异常所有待投递的记录，返回取消的条数
+	CancelPending(ctx context.Context, anomalyID string, at time.Time) (int64, error)
+}
+
+// AlertDeliveryRepositoryImpl 告警投递仓储实现（SQL与queries/alert_delivery.sql保持一致）
+type AlertDeliveryRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewAlertDeliveryRepository 创建告警投递仓储
+func NewAlertDeliveryRepository(db *pgxpool.Pool) AlertDeliveryRepository {
+	return &AlertDeliveryRepositoryImpl{db: db}
+}
+
+const (
+	alertDeliveryColumns = `
+		id, anomaly_id, sink, kind, escalation_level, status, attempts,
+		COALESCE(last_error, ''), next_attempt_at, delivered_at, created_at, updated_at
+	`
+	createAlertDeliveryQuery = `
+		INSERT INTO alert_deliveries (
+			id, anomaly_id, sink, kind, escalation_level, status, attempts,
+			last_error, next_attempt_at, delivered_at, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), $9, $10, $11, $12)
+	`
+	getAlertDeliveryQuery    = `SELECT ` + alertDeliveryColumns + ` FROM alert_deliveries WHERE id = $1`
+	listAlertDeliveriesQuery = `
+		SELECT ` + alertDeliveryColumns + `
+		FROM alert_deliveries
+		WHERE anomaly_id = $1
+		ORDER BY created_at, sink
+	`
+	listAlertDeliveryAttemptsQuery = `
+		SELECT delivery_id, attempt, success, COALESCE(error, ''), duration, created_at
+		FROM alert_delivery_attempts
+		WHERE delivery_id = $1
+		ORDER BY attempt
+	`
+	claimDueAlertDeliveriesQuery = `
+		UPDATE alert_deliveries
+		SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM alert_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + alertDeliveryColumns
+	updateAlertDeliveryQuery = `
+		UPDATE alert_deliveries
+		SET status = $1, attempts = $2, last_error = NULLIF($3, ''),
+			next_attempt_at = $4, delivered_at = $5, updated_at = $6
+		WHERE id = $7
+	`
+	createAlertDeliveryAttemptQuery = `
+		INSERT INTO alert_delivery_attempts (delivery_id, attempt, success, error, duration, created_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6)
+	`
+	cancelPendingAlertDeliveriesQuery = `
+		UPDATE alert_deliveries
+		SET status = 'canceled', next_attempt_at = NULL, updated_at = $1
+		WHERE anomaly_id = $2 AND status = 'pending'
+	`
+)
+
+// Create 创建投递记录
+func (r *AlertDeliveryRepositoryImpl) Create(ctx context.Context, d *domain.AlertDelivery) error {
+	_, err := r.db.Exec(ctx, createAlertDeliveryQuery,
+		d.ID, d.AnomalyID, d.Sink, d.Kind, d.EscalationLevel, d.Status, d.Attempts,
+		d.LastError, d.NextAttemptAt, d.DeliveredAt, d.CreatedAt, d.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("create alert delivery: %w", err)
+	}
+	return nil
+}
+
+// Get 获取投递记录
+func (r *AlertDeliveryRepositoryImpl) Get(ctx context.Context, id string) (*domain.AlertDelivery, error) {
+	d, err := scanAlertDelivery(r.db.QueryRow(ctx, getAlertDeliveryQuery, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get alert delivery: %w", err)
+	}
+	return d, nil
+}
+
+// ListByAnomaly 查询异常的投递记录
+func (r *AlertDeliveryRepositoryImpl) ListByAnomaly(ctx context.Context, anomalyID string) ([]*domain.AlertDelivery, error) {
+	rows, err := r.db.Query(ctx, listAlertDeliveriesQuery, anomalyID)
+	if err != nil {
+		return nil, fmt.Errorf("list alert deliveries: %w", err)
+	}
+	return collectAlertDeliveries(rows)
+}
+
+// ListAttempts 查询投递历史
+func (r *AlertDeliveryRepositoryImpl) ListAttempts(ctx context.Context, deliveryID string) ([]domain.AlertDeliveryAttempt, error) {
+	rows, err := r.db.Query(ctx, listAlertDeliveryAttemptsQuery, deliveryID)
+	if err != nil {
+		return nil, fmt.Errorf("list alert delivery attempts: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.AlertDeliveryAttempt
+	for rows.Next() {
+		var a domain.AlertDeliveryAttempt
+		if err := rows.Scan(&a.DeliveryID, &a.Attempt, &a.Success, &a.Error, &a.Duration, &a.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan alert delivery attempt: %w", err)
+		}
+		result = append(result, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list alert delivery attempts: %w", err)
+	}
+	return result, nil
+}
+
+// ClaimDue 领取到期的待投递记录
+func (r *AlertDeliveryRepositoryImpl) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*domain.AlertDelivery, error) {
+	rows, err := r.db.Query(ctx, claimDueAlertDeliveriesQuery, now, leaseUntil, limit)
+	if err != nil {
+		return nil, fmt.Errorf("claim due alert deliveries: %w", err)
+	}
+	return collectAlertDeliveries(rows)
+}
+
+// Update 更新投递状态
+func (r *AlertDeliveryRepositoryImpl) Update(ctx context.Context, d *domain.AlertDelivery) error {
+	if err := updateAlertDelivery(ctx, r.db, d); err != nil {
+		return fmt.Errorf("update alert delivery: %w", err)
+	}
+	return nil
+}
+
+// RecordAttempt 更新投递状态并追加投递历史
+func (r *AlertDeliveryRepositoryImpl) RecordAttempt(ctx context.Context, d *domain.AlertDelivery, attempt *domain.AlertDeliveryAttempt) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := updateAlertDelivery(ctx, tx, d); err != nil {
+		return fmt.Errorf("update alert delivery: %w", err)
+	}
+	_, err = tx.Exec(ctx, createAlertDeliveryAttemptQuery,
+		attempt.DeliveryID, attempt.Attempt, attempt.Success, attempt.Error, attempt.Duration, attempt.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("create alert delivery attempt: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
+// CancelPending 取消待投递的记录
+func (r *AlertDeliveryRepositoryImpl) CancelPending(ctx context.Context, anomalyID string, at time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, cancelPendingAlertDeliveriesQuery, at, anomalyID)
+	if err != nil {
+		return 0, fmt.Errorf("cancel pending alert deliveries: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// execer 连接池和事务的公共部分
+type execer interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+func updateAlertDelivery(ctx context.Context, db execer, d *domain.AlertDelivery) error {
+	_, err := db.Exec(ctx, updateAlertDeliveryQuery,
+		d.Status, d.Attempts, d.LastError, d.NextAttemptAt, d.DeliveredAt, d.UpdatedAt, d.ID,
+	)
+	return err
+}
+
+func collectAlertDeliveries(rows pgx.Rows) ([]*domain.AlertDelivery, error) {
+	defer rows.Close()
+
+	var result []*domain.AlertDelivery
+	for rows.Next() {
+		d, err := scanAlertDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan alert delivery: %w", err)
+		}
+		result = append(result, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list alert deliveries: %w", err)
+	}
+	return result, nil
+}
+
+func scanAlertDelivery(row pgx.Row) (*domain.AlertDelivery, error) {
+	d := &domain.AlertDelivery{}
+	if err := row.Scan(
+		&d.ID, &d.AnomalyID, &d.Sink, &d.Kind, &d.EscalationLevel, &d.Status, &d.Attempts,
+		&d.LastError, &d.NextAttemptAt, &d.DeliveredAt, &d.CreatedAt, &d.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"admin-svc/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AnomalyRepository 异常活动仓储
+type AnomalyRepository interface {
+	Create(ctx context.Context, anomaly *domain.AnomalousActivity) error
+	// Get 获取异常活动，不存在时返回nil
+	Get(ctx context.Context, id string) (*domain.AnomalousActivity, error)
+	// List 按创建时间倒序分页查询
+	List(ctx context.Context, filter *domain.AnomalyFilter, limit, offset int) ([]*domain.AnomalousActivity, error)
+	Count(ctx context.Context, filter *domain.AnomalyFilter) (int64, error)
+	// Resolve 把未处理的异常标记为已处理，异常不存在或已处理时返回nil
+	Resolve(ctx context.Context, id, resolvedBy string, resolvedAt time.Time) (*domain.AnomalousActivity, error)
+	// ListEscalationDue 返回需要升级的未处理异常：
+	// 从未升级且创建时间早于firstBefore，或已升级但上次升级早于nextBefore，且升级次数小于maxLevel、严重程度在severities中
+	ListEscalationDue(ctx context.Context, firstBefore, nextBefore time.Time, maxLevel int, severities []string, limit int) ([]*domain.AnomalousActivity, error)
+	// MarkEscalated 把升级次数从fromLevel加1（条件更新，多实例下只有一个成功），返回是否更新成功
+	MarkEscalated(ctx context.Context, id string, fromLevel int, at time.Time) (bool, error)
+}
+
+// AnomalyRepositoryImpl 异常活动仓储实现（SQL与queries/anomalous_activity.sql保持一致）
+type AnomalyRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewAnomalyRepository 创建异常活动仓储
+func NewAnomalyRepository(db *pgxpool.Pool) AnomalyRepository {
+	return &AnomalyRepositoryImpl{db: db}
+}
+
+const (
+	anomalyColumns = `
+		id, type, severity, description, admin_id, admin_name,
+		COALESCE(details, ''), resolved, COALESCE(resolved_by, ''), resolved_at,
+		escalation_level, last_escalated_at, created_at
+	`
+	createAnomalyQuery = `
+		INSERT INTO anomalous_activities (
+			id, type, severity, description, admin_id, admin_name,
+			details, resolved, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	getAnomalyQuery = `SELECT ` + anomalyColumns + ` FROM anomalous_activities WHERE id = $1`
+	// 过滤条件为NULL时不限制
+	anomalyFilterClause = `
+		WHERE severity = COALESCE($1, severity)
+			AND resolved = COALESCE($2, resolved)
+	`
+	listAnomaliesQuery = `
+		SELECT ` + anomalyColumns + `
+		FROM anomalous_activities
+		` + anomalyFilterClause + `
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4
+	`
+	countAnomaliesQuery = `SELECT COUNT(*) FROM anomalous_activities ` + anomalyFilterClause
+	resolveAnomalyQuery = `
+		UPDATE anomalous_activities
+		SET resolved = true, resolved_by = $1, resolved_at = $2
+		WHERE id = $3 AND resolved = false
+		RETURNING ` + anomalyColumns
+	listEscalationDueQuery = `
+		SELECT ` + anomalyColumns + `
+		FROM anomalous_activities
+		WHERE resolved = false
+			AND escalation_level < $3
+			AND severity = ANY($4)
+			AND ((escalation_level = 0 AND created_at <= $1)
+				OR (escalation_level > 0 AND last_escalated_at <= $2))
+		ORDER BY created_at
+		LIMIT $5
+	`
+	markAnomalyEscalatedQuery = `
+		UPDATE anomalous_activities
+		SET escalation_level = escalation_level + 1, last_escalated_at = $1
+		WHERE id = $2 AND escalation_level = $3 AND resolved = false
+	`
+)
+
+// Create 创建异常活动
+func (r *AnomalyRepositoryImpl) Create(ctx context.Context, a *domain.AnomalousActivity) error {
+	_, err := r.db.Exec(ctx, createAnomalyQuery,
+		a.ID, a.Type, a.Severity, a.Description, a.AdminID, a.AdminName,
+		a.Details, a.Resolved, a.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("create anomaly: %w", err)
+	}
+	return nil
+}
+
+// Get 获取异常活动
+func (r *AnomalyRepositoryImpl) Get(ctx context.Context, id string) (*domain.AnomalousActivity, error) {
+	a, err := scanAnomaly(r.db.QueryRow(ctx, getAnomalyQuery, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get anomaly: %w", err)
+	}
+	return a, nil
+}
+
+// List 分页查询异常活动
+func (r *AnomalyRepositoryImpl) List(ctx context.Context, filter *domain.AnomalyFilter, limit, offset int) ([]*domain.AnomalousActivity, error) {
+	severity, resolved := anomalyFilterArgs(filter)
+	rows, err := r.db.Query(ctx, listAnomaliesQuery, severity, resolved, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("list anomalies: %w", err)
+	}
+	return collectAnomalies(rows)
+}
+
+// Count 统计满足条件的异常活动数
+func (r *AnomalyRepositoryImpl) Count(ctx context.Context, filter *domain.AnomalyFilter) (int64, error) {
+	severity, resolved := anomalyFilterArgs(filter)
+	var count int64
+	if err := r.db.QueryRow(ctx, countAnomaliesQuery, severity, resolved).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count anomalies: %w", err)
+	}
+	return count, nil
+}
+
+// Resolve 标记异常为已处理
+func (r *AnomalyRepositoryImpl) Resolve(ctx context.Context, id, resolvedBy string, resolvedAt time.Time) (*domain.AnomalousActivity, error) {
+	a, err := scanAnomaly(r.db.QueryRow(ctx, resolveAnomalyQuery, resolvedBy, resolvedAt, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("resolve anomaly: %w", err)
+	}
+	return a, nil
+}
+
+// ListEscalationDue 查询需要升级的未处理异常
+func (r *AnomalyRepositoryImpl) ListEscalationDue(ctx context.Context, firstBefore, nextBefore time.Time, maxLevel int, severities []string, limit int) ([]*domain.AnomalousActivity, error) {
+	rows, err := r.db.Query(ctx, listEscalationDueQuery, firstBefore, nextBefore, maxLevel, severities, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list escalation due anomalies: %w", err)
+	}
+	return collectAnomalies(rows)
+}
+
+// MarkEscalated 记录一次升级
+func (r *AnomalyRepositoryImpl) MarkEscalated(ctx context.Context, id string, fromLevel int, at time.Time) (bool, error) {
+	tag, err := r.db.Exec(ctx, markAnomalyEscalatedQuery, at, id, fromLevel)
+	if err != nil {
+		return false, fmt.Errorf("mark anomaly escalated: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// anomalyFilterArgs 把查询条件转换为SQL参数，空条件为NULL
+func anomalyFilterArgs(filter *domain.AnomalyFilter) (*string, *bool) {
+	if filter == nil {
+		return nil, nil
+	}
+	var severity *string
+	if filter.Severity != "" {
+		severity = &filter.Severity
+	}
+	return severity, filter.Resolved
+}
+
+func collectAnomalies(rows pgx.Rows) ([]*domain.AnomalousActivity, error) {
+	defer rows.Close()
+
+	var result []*domain.AnomalousActivity
+	for rows.Next() {
+		a, err := scanAnomaly(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan anomaly: %w", err)
+		}
+		result = append(result, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list anomalies: %w", err)
+	}
+	return result, nil
+}
+
+func scanAnomaly(row pgx.Row) (*domain.AnomalousActivity, error) {
+	a := &domain.AnomalousActivity{}
+	if err := row.Scan(
+		&a.ID, &a.Type, &a.Severity, &a.Description, &a.AdminID, &a.AdminName,
+		&a.Details, &a.Resolved, &a.ResolvedBy, &a.ResolvedAt,
+		&a.EscalationLevel, &a.LastEscalatedAt, &a.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
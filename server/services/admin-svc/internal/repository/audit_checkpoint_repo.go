@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"admin-svc/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AuditCheckpointRepository 操作日志签名检查点仓储
+type AuditCheckpointRepository interface {
+	Create(ctx context.Context, checkpoint *domain.AuditCheckpoint) error
+	// Latest 返回序号最大的检查点，没有时返回nil
+	Latest(ctx context.Context) (*domain.AuditCheckpoint, error)
+	// ListRange 按序号升序返回[fromSeq, toSeq]内的检查点
+	ListRange(ctx context.Context, fromSeq, toSeq int64) ([]*domain.AuditCheckpoint, error)
+	// ListRecent 按序号倒序返回最近的检查点
+	ListRecent(ctx context.Context, limit int) ([]*domain.AuditCheckpoint, error)
+}
+
+// AuditCheckpointRepositoryImpl 检查点仓储实现（SQL与queries/operation_log_checkpoint.sql保持一致）
+type AuditCheckpointRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewAuditCheckpointRepository 创建检查点仓储
+func NewAuditCheckpointRepository(db *pgxpool.Pool) AuditCheckpointRepository {
+	return &AuditCheckpointRepositoryImpl{db: db}
+}
+
+const (
+	checkpointColumns     = `seq, hash, key_id, signature, created_at`
+	createCheckpointQuery = `
+		INSERT INTO operation_log_checkpoints (` + checkpointColumns + `)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (seq) DO NOTHING
+	`
+	latestCheckpointQuery = `SELECT ` + checkpointColumns + ` FROM operation_log_checkpoints ORDER BY seq DESC LIMIT 1`
+	listCheckpointsQuery  = `
+		SELECT ` + checkpointColumns + `
+		FROM operation_log_checkpoints
+		WHERE seq >= $1 AND seq <= $2
+		ORDER BY seq
+	`
+	listRecentCheckpointsQuery = `SELECT ` + checkpointColumns + ` FROM operation_log_checkpoints ORDER BY seq DESC LIMIT $1`
+)
+
+// Create 创建检查点（同一序号已有检查点时忽略，多实例同时签名不会冲突）
+func (r *AuditCheckpointRepositoryImpl) Create(ctx context.Context, cp *domain.AuditCheckpoint) error {
+	_, err := r.db.Exec(ctx, createCheckpointQuery, cp.Seq, cp.Hash, cp.KeyID, cp.Signature, cp.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("create checkpoint: %w", err)
+	}
+	return nil
+}
+
+// Latest 获取最新的检查点
+func (r *AuditCheckpointRepositoryImpl) Latest(ctx context.Context) (*domain.AuditCheckpoint, error) {
+	cp, err := scanCheckpoint(r.db.QueryRow(ctx, latestCheckpointQuery))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get latest checkpoint: %w", err)
+	}
+	return cp, nil
+}
+
+// ListRange 按序号范围查询检查点
+func (r *AuditCheckpointRepositoryImpl) ListRange(ctx context.Context, fromSeq, toSeq int64) ([]*domain.AuditCheckpoint, error) {
+	rows, err := r.db.Query(ctx, listCheckpointsQuery, fromSeq, toSeq)
+	if err != nil {
+		return nil, fmt.Errorf("list checkpoints: %w", err)
+	}
+	return collectCheckpoints(rows)
+}
+
+// ListRecent 查询最近的检查点
+func (r *AuditCheckpointRepositoryImpl) ListRecent(ctx context.Context, limit int) ([]*domain.AuditCheckpoint, error) {
+	rows, err := r.db.Query(ctx, listRecentCheckpointsQuery, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list checkpoints: %w", err)
+	}
+	return collectCheckpoints(rows)
+}
+
+func collectCheckpoints(rows pgx.Rows) ([]*domain.AuditCheckpoint, error) {
+	defer rows.Close()
+
+	var result []*domain.AuditCheckpoint
+	for rows.Next() {
+		cp, err := scanCheckpoint(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan checkpoint: %w", err)
+		}
+		result = append(result, cp)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list checkpoints: %w", err)
+	}
+	return result, nil
+}
+
+func scanCheckpoint(row pgx.Row) (*domain.AuditCheckpoint, error) {
+	cp := &domain.AuditCheckpoint{}
+	if err := row.Scan(&cp.Seq, &cp.Hash, &cp.KeyID, &cp.Signature, &cp.CreatedAt); err != nil {
+		return nil, err
+	}
+	return cp, nil
+}
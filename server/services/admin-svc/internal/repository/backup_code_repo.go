@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// BackupCodeRepository 备用恢复码仓储（只保存码的HMAC）
+type BackupCodeRepository interface {
+	// Replace 删除管理员现有的恢复码并保存新的一组
+	Replace(ctx context.Context, adminID string, hashes []string, at time.Time) error
+	// Consume 把未使用的恢复码标记为已使用，返回false表示不存在或已使用（并发使用同一个码时只有一个成功）
+	Consume(ctx context.Context, adminID, hash string, at time.Time) (bool, error)
+	// CountUnused 统计未使用的恢复码
+	CountUnused(ctx context.Context, adminID string) (int64, error)
+	// DeleteAll 删除管理员所有恢复码
+	DeleteAll(ctx context.Context, adminID string) error
+}
+
+// BackupCodeRepositoryImpl 备用恢复码仓储实现（SQL与queries/admin_backup_code.sql保持一致）
+type BackupCodeRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewBackupCodeRepository 创建备用恢复码仓储
+func NewBackupCodeRepository(db *pgxpool.Pool) BackupCodeRepository {
+	return &BackupCodeRepositoryImpl{db: db}
+}
+
+const (
+	deleteBackupCodesQuery = `DELETE FROM admin_backup_codes WHERE admin_id = $1`
+	createBackupCodeQuery  = `
+		INSERT INTO admin_backup_codes (admin_id, code_hash, created_at)
+		VALUES ($1, $2, $3)
+	`
+	consumeBackupCodeQuery = `
+		UPDATE admin_backup_codes
+		SET used_at = $1
+		WHERE admin_id = $2 AND code_hash = $3 AND used_at IS NULL
+	`
+	countUnusedBackupCodesQuery = `SELECT COUNT(*) FROM admin_backup_codes WHERE admin_id = $1 AND used_at IS NULL`
+)
+
+// Replace 替换恢复码
+func (r *BackupCodeRepositoryImpl) Replace(ctx context.Context, adminID string, hashes []string, at time.Time) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, deleteBackupCodesQuery, adminID); err != nil {
+		return fmt.Errorf("delete backup codes: %w", err)
+	}
+	for _, hash := range hashes {
+		if _, err := tx.Exec(ctx, createBackupCodeQuery, adminID, hash, at); err != nil {
+			return fmt.Errorf("create backup code: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
+// Consume 使用恢复码
+func (r *BackupCodeRepositoryImpl) Consume(ctx context.Context, adminID, hash string, at time.Time) (bool, error) {
+	tag, err := r.db.Exec(ctx, consumeBackupCodeQuery, at, adminID, hash)
+	if err != nil {
+		return false, fmt.Errorf("consume backup code: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// CountUnused 统计未使用的恢复码
+func (r *BackupCodeRepositoryImpl) CountUnused(ctx context.Context, adminID string) (int64, error) {
+	var count int64
+	if err := r.db.QueryRow(ctx, countUnusedBackupCodesQuery, adminID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count backup codes: %w", err)
+	}
+	return count, nil
+}
+
+// DeleteAll 删除所有恢复码
+func (r *BackupCodeRepositoryImpl) DeleteAll(ctx context.Context, adminID string) error {
+	if _, err := r.db.Exec(ctx, deleteBackupCodesQuery, adminID); err != nil {
+		return fmt.Errorf("delete backup codes: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"admin-svc/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ConfigChangeRepository 配置变更请求仓储
+type ConfigChangeRepository interface {
+	Create(ctx context.Context, change *domain.ConfigChange) error
+	// Get 获取变更请求，不存在时返回nil
+	Get(ctx context.Context, id string) (*domain.ConfigChange, error)
+	// List 按创建时间倒序分页查询
+	List(ctx context.Context, filter *domain.ConfigChangeFilter, limit, offset int) ([]*domain.ConfigChange, error)
+	Count(ctx context.Context, filter *domain.ConfigChangeFilter) (int64, error)
+	// Transition 把状态为from的变更请求更新为change中的状态和审批信息（条件更新，并发审批时只有一个成功），
+	// 返回是否更新成功
+	Transition(ctx context.Context, change *domain.ConfigChange, from string) (bool, error)
+}
+
+// ConfigChangeRepositoryImpl 配置变更请求仓储实现（SQL与queries/config_change.sql保持一致）
+type ConfigChangeRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewConfigChangeRepository 创建配置变更请求仓储
+func NewConfigChangeRepository(db *pgxpool.Pool) ConfigChangeRepository {
+	return &ConfigChangeRepositoryImpl{db: db}
+}
+
+const (
+	configChangeColumns = `
+		id, items, reason, status, requires_approval, COALESCE(rollback_of, ''),
+		created_by, created_by_name, COALESCE(reviewed_by, ''), COALESCE(reviewed_by_name, ''),
+		COALESCE(review_comment, ''), COALESCE(error, ''),
+		created_at, reviewed_at, applied_at, updated_at
+	`
+	createConfigChangeQuery = `
+		INSERT INTO config_changes (
+			id, items, reason, status, requires_approval, rollback_of,
+			created_by, created_by_name, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, $8, $9, $10)
+	`
+	getConfigChangeQuery = `SELECT ` + configChangeColumns + ` FROM config_changes WHERE id = $1`
+	// 过滤条件为NULL时不限制
+	configChangeFilterClause = `
+		WHERE status = COALESCE($1, status)
+			AND created_by = COALESCE($2, created_by)
+	`
+	listConfigChangesQuery = `
+		SELECT ` + configChangeColumns + `
+		FROM config_changes
+		` + configChangeFilterClause + `
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4
+	`
+	countConfigChangesQuery     = `SELECT COUNT(*) FROM config_changes ` + configChangeFilterClause
+	transitionConfigChangeQuery = `
+		UPDATE config_changes
+		SET status = $1, reviewed_by = NULLIF($2, ''), reviewed_by_name = NULLIF($3, ''),
+			review_comment = NULLIF($4, ''), error = NULLIF($5, ''),
+			reviewed_at = $6, applied_at = $7, updated_at = $8
+		WHERE id = $9 AND status = $10
+	`
+)
+
+// Create 创建变更请求
+func (r *ConfigChangeRepositoryImpl) Create(ctx context.Context, c *domain.ConfigChange) error {
+	items, err := json.Marshal(c.Items)
+	if err != nil {
+		return fmt.Errorf("marshal config change items: %w", err)
+	}
+	_, err = r.db.Exec(ctx, createConfigChangeQuery,
+		c.ID, items, c.Reason, c.Status, c.RequiresApproval, c.RollbackOf,
+		c.CreatedBy, c.CreatedByName, c.CreatedAt, c.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("create config change: %w", err)
+	}
+	return nil
+}
+
+// Get 获取变更请求
+func (r *ConfigChangeRepositoryImpl) Get(ctx context.Context, id string) (*domain.ConfigChange, error) {
+	c, err := scanConfigChange(r.db.QueryRow(ctx, getConfigChangeQuery, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get config change: %w", err)
+	}
+	return c, nil
+}
+
+// List 分页查询变更请求
+func (r *ConfigChangeRepositoryImpl) List(ctx context.Context, filter *domain.ConfigChangeFilter, limit, offset int) ([]*domain.ConfigChange, error) {
+	status, createdBy := configChangeFilterArgs(filter)
+	rows, err := r.db.Query(ctx, listConfigChangesQuery, status, createdBy, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("list config changes: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*domain.ConfigChange
+	for rows.Next() {
+		c, err := scanConfigChange(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan config change: %w", err)
+		}
+		result = append(result, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list config changes: %w", err)
+	}
+	return result, nil
+}
+
+// Count 统计满足条件的变更请求数
+func (r *ConfigChangeRepositoryImpl) Count(ctx context.Context, filter *domain.ConfigChangeFilter) (int64, error) {
+	status, createdBy := configChangeFilterArgs(filter)
+	var count int64
+	if err := r.db.QueryRow(ctx, countConfigChangesQuery, status, createdBy).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count config changes: %w", err)
+	}
+	return count, nil
+}
+
+// Transition 条件更新状态
+func (r *ConfigChangeRepositoryImpl) Transition(ctx context.Context, c *domain.ConfigChange, from string) (bool, error) {
+	tag, err := r.db.Exec(ctx, transitionConfigChangeQuery,
+		c.Status, c.ReviewedBy, c.ReviewedByName, c.ReviewComment, c.Error,
+		c.ReviewedAt, c.AppliedAt, c.UpdatedAt,
+		c.ID, from,
+	)
+	if err != nil {
+		return false, fmt.Errorf("update config change: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// configChangeFilterArgs 把查询条件转换为SQL参数，空条件为NULL
+func configChangeFilterArgs(filter *domain.ConfigChangeFilter) (*string, *string) {
+	if filter == nil {
+		return nil, nil
+	}
+	return nullIfEmpty(filter.Status), nullIfEmpty(filter.CreatedBy)
+}
+
+func scanConfigChange(row pgx.Row) (*domain.ConfigChange, error) {
+	c := &domain.ConfigChange{}
+	var items []byte
+	if err := row.Scan(
+		&c.ID, &items, &c.Reason, &c.Status, &c.RequiresApproval, &c.RollbackOf,
+		&c.CreatedBy, &c.CreatedByName, &c.ReviewedBy, &c.ReviewedByName,
+		&c.ReviewComment, &c.Error,
+		&c.CreatedAt, &c.ReviewedAt, &c.AppliedAt, &c.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(items, &c.Items); err != nil {
+		return nil, fmt.Errorf("unmarshal config change items: %w", err)
+	}
+	return c, nil
+}
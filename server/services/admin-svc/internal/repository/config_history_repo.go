@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"admin-svc/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ConfigHistoryRepository 配置变更历史仓储
+type ConfigHistoryRepository interface {
+	// Create 写入历史记录，版本号为该配置键已有的最大版本号加1（写回history.Version）
+	Create(ctx context.Context, history *domain.ConfigHistory) error
+	// Get 获取历史记录，不存在时返回nil
+	Get(ctx context.Context, id string) (*domain.ConfigHistory, error)
+	// List 按时间倒序分页查询，configKey为空时查询所有配置键
+	List(ctx context.Context, configKey string, limit, offset int) ([]*domain.ConfigHistory, error)
+	Count(ctx context.Context, configKey string) (int64, error)
+}
+
+// ConfigHistoryRepositoryImpl 配置变更历史仓储实现（SQL与queries/config_history.sql保持一致）
+type ConfigHistoryRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewConfigHistoryRepository 创建配置变更历史仓储
+func NewConfigHistoryRepository(db *pgxpool.Pool) ConfigHistoryRepository {
+	return &ConfigHistoryRepositoryImpl{db: db}
+}
+
+const (
+	configHistoryColumns = `
+		id, config_key, action, old_value, new_value, version, COALESCE(change_id, ''),
+		admin_id, admin_name, COALESCE(reason, ''), rollbackable, created_at
+	`
+	// 同一配置键的写入由Consul CAS串行化，不会并发计算版本号
+	createConfigHistoryQuery = `
+		INSERT INTO config_histories (
+			id, config_key, action, old_value, new_value, version, change_id,
+			admin_id, admin_name, reason, rollbackable, created_at
+		)
+		SELECT $1, $2, $3, $4, $5, COALESCE(MAX(version), 0) + 1, NULLIF($6, ''), $7, $8, $9, $10, $11
+		FROM config_histories
+		WHERE config_key = $2
+		RETURNING version
+	`
+	getConfigHistoryQuery = `SELECT ` + configHistoryColumns + ` FROM config_histories WHERE id = $1`
+	// 配置键为NULL时不限制
+	listConfigHistoriesQuery = `
+		SELECT ` + configHistoryColumns + `
+		FROM config_histories
+		WHERE config_key = COALESCE($1, config_key)
+		ORDER BY created_at DESC, version DESC
+		LIMIT $2 OFFSET $3
+	`
+	countConfigHistoriesQuery = `SELECT COUNT(*) FROM config_histories WHERE config_key = COALESCE($1, config_key)`
+)
+
+// Create 写入历史记录
+func (r *ConfigHistoryRepositoryImpl) Create(ctx context.Context, h *domain.ConfigHistory) error {
+	err := r.db.QueryRow(ctx, createConfigHistoryQuery,
+		h.ID, h.ConfigKey, h.Action, h.OldValue, h.NewValue, h.ChangeID,
+		h.AdminID, h.AdminName, h.Reason, h.Rollbackable, h.CreatedAt,
+	).Scan(&h.Version)
+	if err != nil {
+		return fmt.Errorf("create config history: %w", err)
+	}
+	return nil
+}
+
+// Get 获取历史记录
+func (r *ConfigHistoryRepositoryImpl) Get(ctx context.Context, id string) (*domain.ConfigHistory, error) {
+	h, err := scanConfigHistory(r.db.QueryRow(ctx, getConfigHistoryQuery, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get config history: %w", err)
+	}
+	return h, nil
+}
+
+// List 分页查询历史记录
+func (r *ConfigHistoryRepositoryImpl) List(ctx context.Context, configKey string, limit, offset int) ([]*domain.ConfigHistory, error) {
+	rows, err := r.db.Query(ctx, listConfigHistoriesQuery, nullIfEmpty(configKey), limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("list config histories: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*domain.ConfigHistory
+	for rows.Next() {
+		h, err := scanConfigHistory(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan config history: %w", err)
+		}
+		result = append(result, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list config histories: %w", err)
+	}
+	return result, nil
+}
+
+// Count 统计历史记录数
+func (r *ConfigHistoryRepositoryImpl) Count(ctx context.Context, configKey string) (int64, error) {
+	var count int64
+	if err := r.db.QueryRow(ctx, countConfigHistoriesQuery, nullIfEmpty(configKey)).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count config histories: %w", err)
+	}
+	return count, nil
+}
+
+func scanConfigHistory(row pgx.Row) (*domain.ConfigHistory, error) {
+	h := &domain.ConfigHistory{}
+	if err := row.Scan(
+		&h.ID, &h.ConfigKey, &h.Action, &h.OldValue, &h.NewValue, &h.Version, &h.ChangeID,
+		&h.AdminID, &h.AdminName, &h.Reason, &h.Rollbackable, &h.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// nullIfEmpty 空字符串转换为NULL参数
+func nullIfEmpty(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return &v
+}
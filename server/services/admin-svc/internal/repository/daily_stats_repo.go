@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"admin-svc/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DailyStatsRepository 每日统计仓储
+type DailyStatsRepository interface {
+	// Upsert 写入某日统计，已存在时覆盖
+	Upsert(ctx context.Context, stats *domain.DailyStats) error
+	// ListRange 按日期升序返回[start, end]范围内已落库的统计
+	ListRange(ctx context.Context, start, end time.Time) ([]*domain.DailyStats, error)
+}
+
+// DailyStatsRepositoryImpl 每日统计仓储实现（SQL与queries/daily_stats.sql保持一致）
+type DailyStatsRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewDailyStatsRepository 创建每日统计仓储
+func NewDailyStatsRepository(db *pgxpool.Pool) DailyStatsRepository {
+	return &DailyStatsRepositoryImpl{db: db}
+}
+
+const (
+	dailyStatsColumns = `
+		date, total_users, new_users, active_users,
+		weekly_active_users, monthly_active_users, logins,
+		total_requests, success_requests, failed_requests, error_rate,
+		avg_response_time, total_favorites, total_playlists, total_plays, created_at
+	`
+	upsertDailyStatsQuery = `
+		INSERT INTO daily_stats (` + dailyStatsColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+		ON CONFLICT (date) DO UPDATE SET
+			total_users = EXCLUDED.total_users,
+			new_users = EXCLUDED.new_users,
+			active_users = EXCLUDED.active_users,
+			weekly_active_users = EXCLUDED.weekly_active_users,
+			monthly_active_users = EXCLUDED.monthly_active_users,
+			logins = EXCLUDED.logins,
+			total_requests = EXCLUDED.total_requests,
+			success_requests = EXCLUDED.success_requests,
+			failed_requests = EXCLUDED.failed_requests,
+			error_rate = EXCLUDED.error_rate,
+			avg_response_time = EXCLUDED.avg_response_time,
+			total_favorites = EXCLUDED.total_favorites,
+			total_playlists = EXCLUDED.total_playlists,
+			total_plays = EXCLUDED.total_plays
+	`
+	listDailyStatsQuery = `
+		SELECT ` + dailyStatsColumns + `
+		FROM daily_stats
+		WHERE date >= $1 AND date <= $2
+		ORDER BY date
+	`
+)
+
+// Upsert 写入某日统计
+func (r *DailyStatsRepositoryImpl) Upsert(ctx context.Context, stats *domain.DailyStats) error {
+	_, err := r.db.Exec(ctx, upsertDailyStatsQuery,
+		stats.Date, stats.TotalUsers, stats.NewUsers, stats.ActiveUsers,
+		stats.WeeklyActiveUsers, stats.MonthlyActiveUsers, stats.Logins,
+		stats.TotalRequests, stats.SuccessRequests, stats.FailedRequests, stats.ErrorRate,
+		stats.AvgResponseTime, stats.TotalFavorites, stats.TotalPlaylists, stats.TotalPlays, stats.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("upsert daily stats: %w", err)
+	}
+	return nil
+}
+
+// ListRange 查询日期范围内的统计
+func (r *DailyStatsRepositoryImpl) ListRange(ctx context.Context, start, end time.Time) ([]*domain.DailyStats, error) {
+	rows, err := r.db.Query(ctx, listDailyStatsQuery, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("list daily stats: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*domain.DailyStats
+	for rows.Next() {
+		stats := &domain.DailyStats{}
+		if err := rows.Scan(
+			&stats.Date, &stats.TotalUsers, &stats.NewUsers, &stats.ActiveUsers,
+			&stats.WeeklyActiveUsers, &stats.MonthlyActiveUsers, &stats.Logins,
+			&stats.TotalRequests, &stats.SuccessRequests, &stats.FailedRequests, &stats.ErrorRate,
+			&stats.AvgResponseTime, &stats.TotalFavorites, &stats.TotalPlaylists, &stats.TotalPlays, &stats.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan daily stats: %w", err)
+		}
+		result = append(result, stats)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list daily stats: %w", err)
+	}
+	return result, nil
+}
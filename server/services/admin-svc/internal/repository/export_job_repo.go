@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"admin-svc/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ExportJobRepository 异步导出任务仓储
+type ExportJobRepository interface {
+	Create(ctx context.Context, job *domain.ExportJob) error
+	// Get 获取任务，不存在时返回nil
+	Get(ctx context.Context, id string) (*domain.ExportJob, error)
+	// ListByCreator 按创建时间倒序返回某个管理员创建的任务
+	ListByCreator(ctx context.Context, createdBy string, limit, offset int) ([]*domain.ExportJob, error)
+	CountByCreator(ctx context.Context, createdBy string) (int64, error)
+	// ClaimNext 领取最早的排队任务或租约已过期的执行中任务（执行的实例已崩溃），
+	// 状态改为running、租约设为leaseUntil、尝试次数加1，没有可领取的任务时返回nil
+	ClaimNext(ctx context.Context, now, leaseUntil time.Time) (*domain.ExportJob, error)
+	// UpdateProgress 更新执行进度并续约，返回false表示任务已不属于本次执行（租约过期后被其他实例领取）
+	UpdateProgress(ctx context.Context, job *domain.ExportJob) (bool, error)
+	// Finish 记录执行结果（成功或失败），同样只在本次执行仍持有任务时生效
+	Finish(ctx context.Context, job *domain.ExportJob) (bool, error)
+	// ListExpired 返回文件保留期限早于now的成功任务
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]*domain.ExportJob, error)
+	// MarkExpired 把任务标记为文件已删除
+	MarkExpired(ctx context.Context, id string, at time.Time) error
+}
+
+// ExportJobRepositoryImpl 导出任务仓储实现（SQL与queries/export_job.sql保持一致）
+type ExportJobRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewExportJobRepository 创建导出任务仓储
+func NewExportJobRepository(db *pgxpool.Pool) ExportJobRepository {
+	return &ExportJobRepositoryImpl{db: db}
+}
+
+const (
+	exportJobColumns = `
+		id, type, format, filters, status, total_rows, processed_rows, attempts,
+		COALESCE(storage_key, ''), COALESCE(file_name, ''), file_size, COALESCE(error, ''),
+		created_by, lease_until, created_at, started_at, finished_at, expires_at, updated_at
+	`
+	createExportJobQuery = `
+		INSERT INTO export_jobs (id, type, format, filters, status, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	getExportJobQuery   = `SELECT ` + exportJobColumns + ` FROM export_jobs WHERE id = $1`
+	listExportJobsQuery = `
+		SELECT ` + exportJobColumns + `
+		FROM export_jobs
+		WHERE created_by = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+	countExportJobsQuery = `SELECT COUNT(*) FROM export_jobs WHERE created_by = $1`
+	claimExportJobQuery  = `
+		UPDATE export_jobs
+		SET status = 'running', lease_until = $2, attempts = attempts + 1,
+			started_at = COALESCE(started_at, $1), updated_at = $1
+		WHERE id = (
+			SELECT id FROM export_jobs
+			WHERE status = 'queued' OR (status = 'running' AND lease_until < $1)
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + exportJobColumns
+	// 以attempts区分同一任务的不同次执行
+	updateExportJobProgressQuery = `
+		UPDATE export_jobs
+		SET total_rows = $1, processed_rows = $2, lease_until = $3, updated_at = $4
+		WHERE id = $5 AND status = 'running' AND attempts = $6
+	`
+	finishExportJobQuery = `
+		UPDATE export_jobs
+		SET status = $1, total_rows = $2, processed_rows = $3, storage_key = NULLIF($4, ''),
+			file_name = NULLIF($5, ''), file_size = $6, error = NULLIF($7, ''),
+			lease_until = NULL, finished_at = $8, expires_at = $9, updated_at = $10
+		WHERE id = $11 AND status = 'running' AND attempts = $12
+	`
+	listExpiredExportJobsQuery = `
+		SELECT ` + exportJobColumns + `
+		FROM export_jobs
+		WHERE status = 'succeeded' AND expires_at < $1
+		ORDER BY expires_at
+		LIMIT $2
+	`
+	markExportJobExpiredQuery = `
+		UPDATE export_jobs
+		SET status = 'expired', updated_at = $1
+		WHERE id = $2 AND status = 'succeeded'
+	`
+)
+
+// Create 创建任务
+func (r *ExportJobRepositoryImpl) Create(ctx context.Context, job *domain.ExportJob) error {
+	_, err := r.db.Exec(ctx, createExportJobQuery,
+		job.ID, job.Type, job.Format, job.Filters, job.Status, job.CreatedBy, job.CreatedAt, job.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("create export job: %w", err)
+	}
+	return nil
+}
+
+// Get 获取任务
+func (r *ExportJobRepositoryImpl) Get(ctx context.Context, id string) (*domain.ExportJob, error) {
+	job, err := scanExportJob(r.db.QueryRow(ctx, getExportJobQuery, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get export job: %w", err)
+	}
+	return job, nil
+}
+
+// ListByCreator 查询管理员创建的任务
+func (r *ExportJobRepositoryImpl) ListByCreator(ctx context.Context, createdBy string, limit, offset int) ([]*domain.ExportJob, error) {
+	rows, err := r.db.Query(ctx, listExportJobsQuery, createdBy, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("list export jobs: %w", err)
+	}
+	return collectExportJobs(rows)
+}
+
+// CountByCreator 统计管理员创建的任务数
+func (r *ExportJobRepositoryImpl) CountByCreator(ctx context.Context, createdBy string) (int64, error) {
+	var count int64
+	if err := r.db.QueryRow(ctx, countExportJobsQuery, createdBy).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count export jobs: %w", err)
+	}
+	return count, nil
+}
+
+// ClaimNext 领取任务（SKIP LOCKED，多个实例同时领取时不会拿到同一个任务）
+func (r *ExportJobRepositoryImpl) ClaimNext(ctx context.Context, now, leaseUntil time.Time) (*domain.ExportJob, error) {
+	job, err := scanExportJob(r.db.QueryRow(ctx, claimExportJobQuery, now, leaseUntil))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("claim export job: %w", err)
+	}
+	return job, nil
+}
+
+// UpdateProgress 更新进度并续约
+func (r *ExportJobRepositoryImpl) UpdateProgress(ctx context.Context, job *domain.ExportJob) (bool, error) {
+	tag, err := r.db.Exec(ctx, updateExportJobProgressQuery,
+		job.TotalRows, job.ProcessedRows, job.LeaseUntil, job.UpdatedAt, job.ID, job.Attempts,
+	)
+	if err != nil {
+		return false, fmt.Errorf("update export job progress: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// Finish 记录执行结果
+func (r *ExportJobRepositoryImpl) Finish(ctx context.Context, job *domain.ExportJob) (bool, error) {
+	tag, err := r.db.Exec(ctx, finishExportJobQuery,
+		job.Status, job.TotalRows, job.ProcessedRows, job.StorageKey,
+		job.FileName, job.FileSize, job.Error,
+		job.FinishedAt, job.ExpiresAt, job.UpdatedAt,
+		job.ID, job.Attempts,
+	)
+	if err != nil {
+		return false, fmt.Errorf("finish export job: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// ListExpired 查询文件已过保留期限的任务
+func (r *ExportJobRepositoryImpl) ListExpired(ctx context.Context, now time.Time, limit int) ([]*domain.ExportJob, error) {
+	rows, err := r.db.Query(ctx, listExpiredExportJobsQuery, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list expired export jobs: %w", err)
+	}
+	return collectExportJobs(rows)
+}
+
+// MarkExpired 标记文件已删除
+func (r *ExportJobRepositoryImpl) MarkExpired(ctx context.Context, id string, at time.Time) error {
+	if _, err := r.db.Exec(ctx, markExportJobExpiredQuery, at, id); err != nil {
+		return fmt.Errorf("mark export job expired: %w", err)
+	}
+	return nil
+}
+
+func collectExportJobs(rows pgx.Rows) ([]*domain.ExportJob, error) {
+	defer rows.Close()
+
+	var result []*domain.ExportJob
+	for rows.Next() {
+		job, err := scanExportJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan export job: %w", err)
+		}
+		result = append(result, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list export jobs: %w", err)
+	}
+	return result, nil
+}
+
+func scanExportJob(row pgx.Row) (*domain.ExportJob, error) {
+	job := &domain.ExportJob{}
+	if err := row.Scan(
+		&job.ID, &job.Type, &job.Format, &job.Filters, &job.Status, &job.TotalRows, &job.ProcessedRows, &job.Attempts,
+		&job.StorageKey, &job.FileName, &job.FileSize, &job.Error,
+		&job.CreatedBy, &job.LeaseUntil, &job.CreatedAt, &job.StartedAt, &job.FinishedAt, &job.ExpiresAt, &job.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
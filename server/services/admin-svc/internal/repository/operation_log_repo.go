@@ -0,0 +1,311 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"admin-svc/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// OperationLogRepository 操作日志仓储（哈希链）
+type OperationLogRepository interface {
+	// Append 把日志追加到哈希链末尾，填充Seq、PrevHash和Hash
+	Append(ctx context.Context, log *domain.OperationLog) error
+	// Head 返回链头（最后一条日志的序号和哈希），空链返回0和GenesisHash
+	Head(ctx context.Context) (int64, string, error)
+	// SeqBounds 返回创建时间早于start的最大序号和不早于end的最小序号，没有时为0
+	// 用于把时间范围换算为序号范围，范围内的日志全部被删除时也能发现断点
+	SeqBounds(ctx context.Context, start, end time.Time) (int64, int64, error)
+	// GetBySeq 按序号获取日志，不存在时返回nil
+	GetBySeq(ctx context.Context, seq int64) (*domain.OperationLog, error)
+	// ListBySeq 按序号升序返回[fromSeq, toSeq]内的日志，最多limit条
+	ListBySeq(ctx context.Context, fromSeq, toSeq int64, limit int) ([]*domain.OperationLog, error)
+	// FindUnchained 返回创建时间在[start, end)内第一条不属于哈希链的日志，没有时返回nil
+	FindUnchained(ctx context.Context, start, end time.Time) (*domain.OperationLog, error)
+	// Count 统计满足条件的日志数
+	Count(ctx context.Context, filter *domain.OperationLogFilter) (int64, error)
+	// List 按创建时间降序分页查询满足条件的日志
+	List(ctx context.Context, filter *domain.OperationLogFilter, limit, offset int) ([]*domain.OperationLog, error)
+	// Stream 按创建时间升序逐条读取满足条件的日志并交给fn处理，fn返回错误时停止
+	// 结果不会整体加载到内存，用于导出大量日志
+	Stream(ctx context.Context, filter *domain.OperationLogFilter, fn func(*domain.OperationLog) error) error
+}
+
+// OperationLogRepositoryImpl 操作日志仓储实现（SQL与queries/operation_log.sql保持一致）
+type OperationLogRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewOperationLogRepository 创建操作日志仓储
+func NewOperationLogRepository(db *pgxpool.Pool) OperationLogRepository {
+	return &OperationLogRepositoryImpl{db: db}
+}
+
+const (
+	operationLogColumns = `
+		id, admin_id, admin_name, operation, resource, COALESCE(resource_id, ''),
+		action, details, COALESCE(request_id, ''), ip, COALESCE(user_agent, ''), status,
+		COALESCE(error_msg, ''), duration, created_at,
+		COALESCE(seq, 0), COALESCE(prev_hash, ''), COALESCE(hash, '')
+	`
+	lockChainHeadQuery      = `SELECT last_seq, last_hash FROM operation_log_chain_head WHERE id = 1 FOR UPDATE`
+	getChainHeadQuery       = `SELECT last_seq, last_hash FROM operation_log_chain_head WHERE id = 1`
+	updateChainHeadQuery    = `UPDATE operation_log_chain_head SET last_seq = $1, last_hash = $2, updated_at = $3 WHERE id = 1`
+	appendOperationLogQuery = `
+		INSERT INTO operation_logs (
+			id, admin_id, admin_name, operation, resource, resource_id,
+			action, details, request_id, ip, user_agent, status, error_msg, duration, created_at,
+			seq, prev_hash, hash
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+	`
+	operationLogSeqBoundsQuery = `
+		SELECT
+			COALESCE((SELECT MAX(seq) FROM operation_logs WHERE created_at < $1), 0),
+			COALESCE((SELECT MIN(seq) FROM operation_logs WHERE created_at >= $2), 0)
+	`
+	getOperationLogBySeqQuery   = `SELECT ` + operationLogColumns + ` FROM operation_logs WHERE seq = $1`
+	listOperationLogsBySeqQuery = `
+		SELECT ` + operationLogColumns + `
+		FROM operation_logs
+		WHERE seq >= $1 AND seq <= $2
+		ORDER BY seq
+		LIMIT $3
+	`
+	findUnchainedOperationLogQuery = `
+		SELECT ` + operationLogColumns + `
+		FROM operation_logs
+		WHERE created_at >= $1 AND created_at < $2
+			AND (seq IS NULL OR hash IS NULL OR prev_hash IS NULL)
+		ORDER BY created_at
+		LIMIT 1
+	`
+	// 过滤条件为NULL时不限制，时间范围左闭右开
+	operationLogFilterClause = `
+		WHERE admin_id = COALESCE($1, admin_id)
+			AND operation = COALESCE($2, operation)
+			AND resource = COALESCE($3, resource)
+			AND action = COALESCE($4, action)
+			AND status = COALESCE($5, status)
+			AND created_at >= COALESCE($6, created_at)
+			AND ($7::timestamp IS NULL OR created_at < $7)
+	`
+	countOperationLogsQuery = `SELECT COUNT(*) FROM operation_logs ` + operationLogFilterClause
+	listOperationLogsQuery  = `
+		SELECT ` + operationLogColumns + `
+		FROM operation_logs
+		` + operationLogFilterClause + `
+		ORDER BY created_at DESC, seq DESC
+		LIMIT $8 OFFSET $9
+	`
+	streamOperationLogsQuery = `
+		SELECT ` + operationLogColumns + `
+		FROM operation_logs
+		` + operationLogFilterClause + `
+		ORDER BY created_at, seq
+	`
+)
+
+// Append 追加日志
+// 在事务中锁住链头行，多个实例并发写入时按加锁顺序排队，保证序号连续
+func (r *OperationLogRepositoryImpl) Append(ctx context.Context, log *domain.OperationLog) error {
+	if err := log.PrepareForChain(); err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var lastSeq int64
+	var lastHash string
+	if err := tx.QueryRow(ctx, lockChainHeadQuery).Scan(&lastSeq, &lastHash); err != nil {
+		return fmt.Errorf("lock chain head: %w", err)
+	}
+
+	log.Seq = lastSeq + 1
+	log.PrevHash = lastHash
+	if log.Hash, err = log.ComputeChainHash(lastHash); err != nil {
+		return fmt.Errorf("compute chain hash: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, appendOperationLogQuery,
+		log.ID, log.AdminID, log.AdminName, log.Operation, log.Resource, log.ResourceID,
+		log.Action, log.Details, log.RequestID, log.IP, log.UserAgent, log.Status, log.ErrorMsg, log.Duration, log.CreatedAt,
+		log.Seq, log.PrevHash, log.Hash,
+	)
+	if err != nil {
+		return fmt.Errorf("insert operation log: %w", err)
+	}
+	if _, err := tx.Exec(ctx, updateChainHeadQuery, log.Seq, log.Hash, time.Now()); err != nil {
+		return fmt.Errorf("update chain head: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
+// Head 获取链头
+func (r *OperationLogRepositoryImpl) Head(ctx context.Context) (int64, string, error) {
+	var seq int64
+	var hash string
+	if err := r.db.QueryRow(ctx, getChainHeadQuery).Scan(&seq, &hash); err != nil {
+		return 0, "", fmt.Errorf("get chain head: %w", err)
+	}
+	return seq, hash, nil
+}
+
+// SeqBounds 查询时间范围两侧的序号
+func (r *OperationLogRepositoryImpl) SeqBounds(ctx context.Context, start, end time.Time) (int64, int64, error) {
+	var before, after int64
+	if err := r.db.QueryRow(ctx, operationLogSeqBoundsQuery, start.UTC(), end.UTC()).Scan(&before, &after); err != nil {
+		return 0, 0, fmt.Errorf("get operation log seq bounds: %w", err)
+	}
+	return before, after, nil
+}
+
+// GetBySeq 按序号获取日志
+func (r *OperationLogRepositoryImpl) GetBySeq(ctx context.Context, seq int64) (*domain.OperationLog, error) {
+	log, err := scanOperationLog(r.db.QueryRow(ctx, getOperationLogBySeqQuery, seq))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get operation log: %w", err)
+	}
+	return log, nil
+}
+
+// ListBySeq 按序号范围查询日志
+func (r *OperationLogRepositoryImpl) ListBySeq(ctx context.Context, fromSeq, toSeq int64, limit int) ([]*domain.OperationLog, error) {
+	rows, err := r.db.Query(ctx, listOperationLogsBySeqQuery, fromSeq, toSeq, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list operation logs: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*domain.OperationLog
+	for rows.Next() {
+		log, err := scanOperationLog(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan operation log: %w", err)
+		}
+		result = append(result, log)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list operation logs: %w", err)
+	}
+	return result, nil
+}
+
+// FindUnchained 查询不属于哈希链的日志
+func (r *OperationLogRepositoryImpl) FindUnchained(ctx context.Context, start, end time.Time) (*domain.OperationLog, error) {
+	log, err := scanOperationLog(r.db.QueryRow(ctx, findUnchainedOperationLogQuery, start.UTC(), end.UTC()))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find unchained operation log: %w", err)
+	}
+	return log, nil
+}
+
+// Count 统计日志数
+func (r *OperationLogRepositoryImpl) Count(ctx context.Context, filter *domain.OperationLogFilter) (int64, error) {
+	var count int64
+	if err := r.db.QueryRow(ctx, countOperationLogsQuery, operationLogFilterArgs(filter)...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count operation logs: %w", err)
+	}
+	return count, nil
+}
+
+// List 分页查询日志
+func (r *OperationLogRepositoryImpl) List(ctx context.Context, filter *domain.OperationLogFilter, limit, offset int) ([]*domain.OperationLog, error) {
+	args := append(operationLogFilterArgs(filter), limit, offset)
+	rows, err := r.db.Query(ctx, listOperationLogsQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list operation logs: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*domain.OperationLog
+	for rows.Next() {
+		log, err := scanOperationLog(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan operation log: %w", err)
+		}
+		result = append(result, log)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list operation logs: %w", err)
+	}
+	return result, nil
+}
+
+// Stream 流式读取日志（pgx按需从连接读取结果行）
+func (r *OperationLogRepositoryImpl) Stream(ctx context.Context, filter *domain.OperationLogFilter, fn func(*domain.OperationLog) error) error {
+	rows, err := r.db.Query(ctx, streamOperationLogsQuery, operationLogFilterArgs(filter)...)
+	if err != nil {
+		return fmt.Errorf("stream operation logs: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		log, err := scanOperationLog(rows)
+		if err != nil {
+			return fmt.Errorf("scan operation log: %w", err)
+		}
+		if err := fn(log); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("stream operation logs: %w", err)
+	}
+	return nil
+}
+
+// operationLogFilterArgs 把查询条件转换为SQL参数，空条件为NULL
+func operationLogFilterArgs(filter *domain.OperationLogFilter) []interface{} {
+	if filter == nil {
+		filter = &domain.OperationLogFilter{}
+	}
+	str := func(v string) *string {
+		if v == "" {
+			return nil
+		}
+		return &v
+	}
+	ts := func(t time.Time) *time.Time {
+		if t.IsZero() {
+			return nil
+		}
+		utc := t.UTC()
+		return &utc
+	}
+	return []interface{}{
+		str(filter.AdminID), str(filter.Operation), str(filter.Resource), str(filter.Action), str(filter.Status),
+		ts(filter.StartTime), ts(filter.EndTime),
+	}
+}
+
+func scanOperationLog(row pgx.Row) (*domain.OperationLog, error) {
+	log := &domain.OperationLog{}
+	if err := row.Scan(
+		&log.ID, &log.AdminID, &log.AdminName, &log.Operation, &log.Resource, &log.ResourceID,
+		&log.Action, &log.Details, &log.RequestID, &log.IP, &log.UserAgent, &log.Status,
+		&log.ErrorMsg, &log.Duration, &log.CreatedAt,
+		&log.Seq, &log.PrevHash, &log.Hash,
+	); err != nil {
+		return nil, err
+	}
+	return log, nil
+}
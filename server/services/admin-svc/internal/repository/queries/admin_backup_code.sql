@@ -0,0 +1,20 @@
+-- name: DeleteAdminBackupCodes :exec
+DELETE FROM admin_backup_codes
+WHERE admin_id = $1;
+
+-- name: CreateAdminBackupCode :exec
+INSERT INTO admin_backup_codes (
+    admin_id, code_hash, created_at
+) VALUES (
+    $1, $2, $3
+);
+
+-- 条件更新：同一个码并发使用时只有一个成功
+-- name: ConsumeAdminBackupCode :execrows
+UPDATE admin_backup_codes
+SET used_at = $1
+WHERE admin_id = $2 AND code_hash = $3 AND used_at IS NULL;
+
+-- name: CountUnusedAdminBackupCodes :one
+SELECT COUNT(*) FROM admin_backup_codes
+WHERE admin_id = $1 AND used_at IS NULL;
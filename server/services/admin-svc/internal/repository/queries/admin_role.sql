@@ -0,0 +1,23 @@
+-- name: CreateAdminRole :exec
+INSERT INTO admin_roles (
+    name, description, permissions, created_at, updated_at
+) VALUES (
+    $1, $2, $3, $4, $5
+);
+
+-- name: GetAdminRole :one
+SELECT * FROM admin_roles
+WHERE name = $1 LIMIT 1;
+
+-- name: ListAdminRoles :many
+SELECT * FROM admin_roles
+ORDER BY name;
+
+-- name: UpdateAdminRole :exec
+UPDATE admin_roles
+SET description = $1, permissions = $2, updated_at = $3
+WHERE name = $4;
+
+-- name: DeleteAdminRole :exec
+DELETE FROM admin_roles
+WHERE name = $1;
@@ -0,0 +1,40 @@
+-- name: CreateAdminSession :exec
+INSERT INTO admin_sessions (
+    id, admin_id, admin_name, mfa_method, ip, user_agent, created_at, last_seen_at, expires_at
+) VALUES (
+    $1, $2, $3, NULLIF($4, ''), $5, $6, $7, $8, $9
+);
+
+-- name: GetAdminSession :one
+SELECT * FROM admin_sessions
+WHERE id = $1 LIMIT 1;
+
+-- name: ListActiveAdminSessions :many
+SELECT * FROM admin_sessions
+WHERE admin_id = $1 AND revoked_at IS NULL AND expires_at > $2
+ORDER BY created_at DESC;
+
+-- name: TouchAdminSession :exec
+UPDATE admin_sessions
+SET last_seen_at = $1
+WHERE id = $2;
+
+-- name: SetAdminSessionMFAMethod :exec
+UPDATE admin_sessions
+SET mfa_method = COALESCE(mfa_method, $1)
+WHERE id = $2;
+
+-- name: RevokeAdminSession :execrows
+UPDATE admin_sessions
+SET revoked_at = $1, revoked_by = $2
+WHERE id = $3 AND admin_id = $4 AND revoked_at IS NULL;
+
+-- 撤销管理员除$4外的所有有效会话
+-- name: RevokeAdminSessions :execrows
+UPDATE admin_sessions
+SET revoked_at = $1, revoked_by = $2
+WHERE admin_id = $3 AND id <> $4 AND revoked_at IS NULL AND expires_at > $1;
+
+-- name: DeleteExpiredAdminSessions :execrows
+DELETE FROM admin_sessions
+WHERE expires_at < $1 OR revoked_at < $1;
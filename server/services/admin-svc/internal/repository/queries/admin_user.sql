@@ -49,3 +49,17 @@ WHERE id = $1;
 -- name: CountAdminUsers :one
 SELECT COUNT(*) FROM admin_users
 WHERE status = COALESCE(sqlc.narg('status'), status);
+
+-- name: UpdateAdminUserRole :exec
+UPDATE admin_users
+SET role = $1, updated_at = $2
+WHERE id = $3;
+
+-- name: CountAdminUsersByRole :one
+SELECT COUNT(*) FROM admin_users
+WHERE role = $1;
+
+-- name: UpdateAdminUserTOTP :exec
+UPDATE admin_users
+SET totp_secret = NULLIF($1, ''), totp_enabled = $2, updated_at = $3
+WHERE id = $4;
@@ -0,0 +1,24 @@
+-- name: CreateAdminWebAuthnCredential :exec
+INSERT INTO admin_webauthn_credentials (
+    id, admin_id, name, public_key, algorithm, sign_count, backup_eligible, created_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+);
+
+-- name: GetAdminWebAuthnCredential :one
+SELECT * FROM admin_webauthn_credentials
+WHERE id = $1 LIMIT 1;
+
+-- name: ListAdminWebAuthnCredentials :many
+SELECT * FROM admin_webauthn_credentials
+WHERE admin_id = $1
+ORDER BY created_at;
+
+-- name: UpdateAdminWebAuthnSignCount :exec
+UPDATE admin_webauthn_credentials
+SET sign_count = $1, last_used_at = $2
+WHERE id = $3;
+
+-- name: DeleteAdminWebAuthnCredential :execrows
+DELETE FROM admin_webauthn_credentials
+WHERE admin_id = $1 AND id = $2;
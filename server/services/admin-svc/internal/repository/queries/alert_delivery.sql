@@ -0,0 +1,51 @@
+-- name: CreateAlertDelivery :exec
+INSERT INTO alert_deliveries (
+    id, anomaly_id, sink, kind, escalation_level, status, attempts,
+    last_error, next_attempt_at, delivered_at, created_at, updated_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+);
+
+-- name: GetAlertDelivery :one
+SELECT * FROM alert_deliveries
+WHERE id = $1 LIMIT 1;
+
+-- name: ListAlertDeliveriesByAnomaly :many
+SELECT * FROM alert_deliveries
+WHERE anomaly_id = $1
+ORDER BY created_at, sink;
+
+-- name: ClaimDueAlertDeliveries :many
+UPDATE alert_deliveries
+SET next_attempt_at = $2
+WHERE id IN (
+    SELECT id FROM alert_deliveries
+    WHERE status = 'pending' AND next_attempt_at <= $1
+    ORDER BY next_attempt_at
+    LIMIT $3
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: UpdateAlertDelivery :exec
+UPDATE alert_deliveries
+SET status = $1, attempts = $2, last_error = $3,
+    next_attempt_at = $4, delivered_at = $5, updated_at = $6
+WHERE id = $7;
+
+-- name: CancelPendingAlertDeliveries :execrows
+UPDATE alert_deliveries
+SET status = 'canceled', next_attempt_at = NULL, updated_at = $1
+WHERE anomaly_id = $2 AND status = 'pending';
+
+-- name: CreateAlertDeliveryAttempt :exec
+INSERT INTO alert_delivery_attempts (
+    delivery_id, attempt, success, error, duration, created_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+);
+
+-- name: ListAlertDeliveryAttempts :many
+SELECT * FROM alert_delivery_attempts
+WHERE delivery_id = $1
+ORDER BY attempt;
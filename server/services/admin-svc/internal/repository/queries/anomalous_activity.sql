@@ -33,9 +33,24 @@ WHERE
 -- name: ResolveAnomalousActivity :one
 UPDATE anomalous_activities
 SET resolved = true, resolved_by = $1, resolved_at = $2
-WHERE id = $3
+WHERE id = $3 AND resolved = false
 RETURNING *;
 
+-- name: ListEscalationDueAnomalousActivities :many
+SELECT * FROM anomalous_activities
+WHERE resolved = false
+    AND escalation_level < $3
+    AND severity = ANY($4::text[])
+    AND ((escalation_level = 0 AND created_at <= $1)
+        OR (escalation_level > 0 AND last_escalated_at <= $2))
+ORDER BY created_at
+LIMIT $5;
+
+-- name: MarkAnomalousActivityEscalated :execrows
+UPDATE anomalous_activities
+SET escalation_level = escalation_level + 1, last_escalated_at = $1
+WHERE id = $2 AND escalation_level = $3 AND resolved = false;
+
 -- name: DeleteOldAnomalousActivities :exec
 DELETE FROM anomalous_activities
 WHERE created_at < $1 AND resolved = true;
@@ -0,0 +1,31 @@
+-- name: CreateConfigChange :exec
+INSERT INTO config_changes (
+    id, items, reason, status, requires_approval, rollback_of,
+    created_by, created_by_name, created_at, updated_at
+) VALUES (
+    $1, $2, $3, $4, $5, NULLIF($6, ''), $7, $8, $9, $10
+);
+
+-- name: GetConfigChange :one
+SELECT * FROM config_changes
+WHERE id = $1 LIMIT 1;
+
+-- name: ListConfigChanges :many
+SELECT * FROM config_changes
+WHERE status = COALESCE(sqlc.narg('status'), status)
+  AND created_by = COALESCE(sqlc.narg('created_by'), created_by)
+ORDER BY created_at DESC
+LIMIT $1 OFFSET $2;
+
+-- name: CountConfigChanges :one
+SELECT COUNT(*) FROM config_changes
+WHERE status = COALESCE(sqlc.narg('status'), status)
+  AND created_by = COALESCE(sqlc.narg('created_by'), created_by);
+
+-- 条件更新：只有状态仍为from时才更新，并发审批时只有一个成功
+-- name: TransitionConfigChange :execrows
+UPDATE config_changes
+SET status = $1, reviewed_by = NULLIF($2, ''), reviewed_by_name = NULLIF($3, ''),
+    review_comment = NULLIF($4, ''), error = NULLIF($5, ''),
+    reviewed_at = $6, applied_at = $7, updated_at = $8
+WHERE id = $9 AND status = $10;
@@ -1,10 +1,13 @@
+-- 版本号为该配置键已有的最大版本号加1
 -- name: CreateConfigHistory :one
 INSERT INTO config_histories (
-    id, config_key, old_value, new_value, version,
+    id, config_key, action, old_value, new_value, version, change_id,
     admin_id, admin_name, reason, rollbackable, created_at
-) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
-) RETURNING *;
+)
+SELECT $1, $2, $3, $4, $5, COALESCE(MAX(version), 0) + 1, NULLIF($6, ''), $7, $8, $9, $10, $11
+FROM config_histories
+WHERE config_key = $2
+RETURNING version;
 
 -- name: GetConfigHistory :one
 SELECT * FROM config_histories
@@ -13,9 +16,13 @@ WHERE id = $1 LIMIT 1;
 -- name: ListConfigHistories :many
 SELECT * FROM config_histories
 WHERE config_key = COALESCE(sqlc.narg('config_key'), config_key)
-ORDER BY created_at DESC
+ORDER BY created_at DESC, version DESC
 LIMIT $1 OFFSET $2;
 
+-- name: CountConfigHistories :one
+SELECT COUNT(*) FROM config_histories
+WHERE config_key = COALESCE(sqlc.narg('config_key'), config_key);
+
 -- name: GetLatestConfigVersion :one
 SELECT * FROM config_histories
 WHERE config_key = $1
@@ -1,15 +1,19 @@
 -- name: UpsertDailyStats :one
 INSERT INTO daily_stats (
     date, total_users, new_users, active_users,
+    weekly_active_users, monthly_active_users, logins,
     total_requests, success_requests, failed_requests, error_rate,
     avg_response_time, total_favorites, total_playlists, total_plays, created_at
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16
 )
 ON CONFLICT (date) DO UPDATE SET
     total_users = EXCLUDED.total_users,
     new_users = EXCLUDED.new_users,
     active_users = EXCLUDED.active_users,
+    weekly_active_users = EXCLUDED.weekly_active_users,
+    monthly_active_users = EXCLUDED.monthly_active_users,
+    logins = EXCLUDED.logins,
     total_requests = EXCLUDED.total_requests,
     success_requests = EXCLUDED.success_requests,
     failed_requests = EXCLUDED.failed_requests,
@@ -0,0 +1,57 @@
+-- name: CreateExportJob :exec
+INSERT INTO export_jobs (
+    id, type, format, filters, status, created_by, created_at, updated_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+);
+
+-- name: GetExportJob :one
+SELECT * FROM export_jobs
+WHERE id = $1 LIMIT 1;
+
+-- name: ListExportJobsByCreator :many
+SELECT * FROM export_jobs
+WHERE created_by = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CountExportJobsByCreator :one
+SELECT COUNT(*) FROM export_jobs
+WHERE created_by = $1;
+
+-- 排队中的任务，或租约已过期的执行中任务（执行的实例已崩溃）
+-- name: ClaimExportJob :one
+UPDATE export_jobs
+SET status = 'running', lease_until = $2, attempts = attempts + 1,
+    started_at = COALESCE(started_at, $1), updated_at = $1
+WHERE id = (
+    SELECT id FROM export_jobs
+    WHERE status = 'queued' OR (status = 'running' AND lease_until < $1)
+    ORDER BY created_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: UpdateExportJobProgress :execrows
+UPDATE export_jobs
+SET total_rows = $1, processed_rows = $2, lease_until = $3, updated_at = $4
+WHERE id = $5 AND status = 'running' AND attempts = $6;
+
+-- name: FinishExportJob :execrows
+UPDATE export_jobs
+SET status = $1, total_rows = $2, processed_rows = $3, storage_key = $4,
+    file_name = $5, file_size = $6, error = $7,
+    lease_until = NULL, finished_at = $8, expires_at = $9, updated_at = $10
+WHERE id = $11 AND status = 'running' AND attempts = $12;
+
+-- name: ListExpiredExportJobs :many
+SELECT * FROM export_jobs
+WHERE status = 'succeeded' AND expires_at < $1
+ORDER BY expires_at
+LIMIT $2;
+
+-- name: MarkExportJobExpired :exec
+UPDATE export_jobs
+SET status = 'expired', updated_at = $1
+WHERE id = $2 AND status = 'succeeded';
@@ -1,10 +1,47 @@
--- name: CreateOperationLog :one
+-- name: LockOperationLogChainHead :one
+SELECT last_seq, last_hash FROM operation_log_chain_head
+WHERE id = 1
+FOR UPDATE;
+
+-- name: GetOperationLogChainHead :one
+SELECT last_seq, last_hash FROM operation_log_chain_head
+WHERE id = 1;
+
+-- name: UpdateOperationLogChainHead :exec
+UPDATE operation_log_chain_head
+SET last_seq = $1, last_hash = $2, updated_at = $3
+WHERE id = 1;
+
+-- name: CreateOperationLog :exec
 INSERT INTO operation_logs (
     id, admin_id, admin_name, operation, resource, resource_id,
-    action, details, request_id, ip, user_agent, status, error_msg, duration, created_at
+    action, details, request_id, ip, user_agent, status, error_msg, duration, created_at,
+    seq, prev_hash, hash
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
-) RETURNING *;
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18
+);
+
+-- name: GetOperationLogSeqBounds :one
+SELECT
+    COALESCE((SELECT MAX(seq) FROM operation_logs WHERE created_at < $1), 0)::bigint AS before_seq,
+    COALESCE((SELECT MIN(seq) FROM operation_logs WHERE created_at >= $2), 0)::bigint AS after_seq;
+
+-- name: GetOperationLogBySeq :one
+SELECT * FROM operation_logs
+WHERE seq = $1 LIMIT 1;
+
+-- name: ListOperationLogsBySeq :many
+SELECT * FROM operation_logs
+WHERE seq >= $1 AND seq <= $2
+ORDER BY seq
+LIMIT $3;
+
+-- name: FindUnchainedOperationLog :one
+SELECT * FROM operation_logs
+WHERE created_at >= $1 AND created_at < $2
+    AND (seq IS NULL OR hash IS NULL OR prev_hash IS NULL)
+ORDER BY created_at
+LIMIT 1;
 
 -- name: GetOperationLog :one
 SELECT * FROM operation_logs
@@ -12,14 +49,15 @@ WHERE id = $1 LIMIT 1;
 
 -- name: ListOperationLogs :many
 SELECT * FROM operation_logs
-WHERE 
+WHERE
     admin_id = COALESCE(sqlc.narg('admin_id'), admin_id)
     AND operation = COALESCE(sqlc.narg('operation'), operation)
     AND resource = COALESCE(sqlc.narg('resource'), resource)
+    AND action = COALESCE(sqlc.narg('action'), action)
     AND status = COALESCE(sqlc.narg('status'), status)
-    AND created_at >= COALESCE(sqlc.narg('start_date'), created_at)
-    AND created_at <= COALESCE(sqlc.narg('end_date'), created_at)
-ORDER BY created_at DESC
+    AND created_at >= COALESCE(sqlc.narg('start_time'), created_at)
+    AND (sqlc.narg('end_time')::timestamp IS NULL OR created_at < sqlc.narg('end_time'))
+ORDER BY created_at DESC, seq DESC
 LIMIT $1 OFFSET $2;
 
 -- name: CountOperationLogs :one
@@ -32,11 +70,36 @@ WHERE
     AND created_at >= COALESCE(sqlc.narg('start_date'), created_at)
     AND created_at <= COALESCE(sqlc.narg('end_date'), created_at);
 
+-- name: CountOperationLogsForExport :one
+SELECT COUNT(*) FROM operation_logs
+WHERE
+    admin_id = COALESCE(sqlc.narg('admin_id'), admin_id)
+    AND operation = COALESCE(sqlc.narg('operation'), operation)
+    AND resource = COALESCE(sqlc.narg('resource'), resource)
+    AND action = COALESCE(sqlc.narg('action'), action)
+    AND status = COALESCE(sqlc.narg('status'), status)
+    AND created_at >= COALESCE(sqlc.narg('start_time'), created_at)
+    AND (sqlc.narg('end_time')::timestamp IS NULL OR created_at < sqlc.narg('end_time'));
+
+-- 导出时逐行读取，不分页
+-- name: StreamOperationLogs :many
+SELECT * FROM operation_logs
+WHERE
+    admin_id = COALESCE(sqlc.narg('admin_id'), admin_id)
+    AND operation = COALESCE(sqlc.narg('operation'), operation)
+    AND resource = COALESCE(sqlc.narg('resource'), resource)
+    AND action = COALESCE(sqlc.narg('action'), action)
+    AND status = COALESCE(sqlc.narg('status'), status)
+    AND created_at >= COALESCE(sqlc.narg('start_time'), created_at)
+    AND (sqlc.narg('end_time')::timestamp IS NULL OR created_at < sqlc.narg('end_time'))
+ORDER BY created_at, seq;
+
 -- name: ListOperationLogsByRequestID :many
 SELECT * FROM operation_logs
 WHERE request_id = $1
 ORDER BY created_at ASC;
 
+-- 删除日志会使哈希链在删除点断开，校验时报告missing_entries
 -- name: DeleteOldOperationLogs :exec
 DELETE FROM operation_logs
 WHERE created_at < $1;
@@ -0,0 +1,22 @@
+-- name: CreateOperationLogCheckpoint :exec
+INSERT INTO operation_log_checkpoints (
+    seq, hash, key_id, signature, created_at
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (seq) DO NOTHING;
+
+-- name: GetLatestOperationLogCheckpoint :one
+SELECT * FROM operation_log_checkpoints
+ORDER BY seq DESC
+LIMIT 1;
+
+-- name: ListOperationLogCheckpoints :many
+SELECT * FROM operation_log_checkpoints
+WHERE seq >= $1 AND seq <= $2
+ORDER BY seq;
+
+-- name: ListRecentOperationLogCheckpoints :many
+SELECT * FROM operation_log_checkpoints
+ORDER BY seq DESC
+LIMIT $1;
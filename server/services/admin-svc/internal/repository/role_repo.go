@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"admin-svc/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// RoleRepository 自定义角色仓储（内置角色不落库）
+type RoleRepository interface {
+	// Get 获取角色，不存在时返回nil
+	Get(ctx context.Context, name string) (*domain.Role, error)
+	// List 按名称升序返回所有自定义角色
+	List(ctx context.Context) ([]*domain.Role, error)
+	Create(ctx context.Context, role *domain.Role) error
+	Update(ctx context.Context, role *domain.Role) error
+	Delete(ctx context.Context, name string) error
+}
+
+// RoleRepositoryImpl 自定义角色仓储实现（SQL与queries/admin_role.sql保持一致）
+type RoleRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewRoleRepository 创建自定义角色仓储
+func NewRoleRepository(db *pgxpool.Pool) RoleRepository {
+	return &RoleRepositoryImpl{db: db}
+}
+
+const (
+	roleColumns     = `name, description, permissions, created_at, updated_at`
+	getRoleQuery    = `SELECT ` + roleColumns + ` FROM admin_roles WHERE name = $1`
+	listRolesQuery  = `SELECT ` + roleColumns + ` FROM admin_roles ORDER BY name`
+	createRoleQuery = `
+		INSERT INTO admin_roles (` + roleColumns + `)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	updateRoleQuery = `
+		UPDATE admin_roles
+		SET description = $1, permissions = $2, updated_at = $3
+		WHERE name = $4
+	`
+	deleteRoleQuery = `DELETE FROM admin_roles WHERE name = $1`
+)
+
+// Get 获取角色
+func (r *RoleRepositoryImpl) Get(ctx context.Context, name string) (*domain.Role, error) {
+	role, err := scanRole(r.db.QueryRow(ctx, getRoleQuery, name))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get role: %w", err)
+	}
+	return role, nil
+}
+
+// List 列出所有自定义角色
+func (r *RoleRepositoryImpl) List(ctx context.Context) ([]*domain.Role, error) {
+	rows, err := r.db.Query(ctx, listRolesQuery)
+	if err != nil {
+		return nil, fmt.Errorf("list roles: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*domain.Role
+	for rows.Next() {
+		role, err := scanRole(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan role: %w", err)
+		}
+		result = append(result, role)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list roles: %w", err)
+	}
+	return result, nil
+}
+
+// Create 创建角色
+func (r *RoleRepositoryImpl) Create(ctx context.Context, role *domain.Role) error {
+	_, err := r.db.Exec(ctx, createRoleQuery, role.Name, role.Description, role.Permissions, role.CreatedAt, role.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("create role: %w", err)
+	}
+	return nil
+}
+
+// Update 更新角色的说明和权限
+func (r *RoleRepositoryImpl) Update(ctx context.Context, role *domain.Role) error {
+	_, err := r.db.Exec(ctx, updateRoleQuery, role.Description, role.Permissions, role.UpdatedAt, role.Name)
+	if err != nil {
+		return fmt.Errorf("update role: %w", err)
+	}
+	return nil
+}
+
+// Delete 删除角色
+func (r *RoleRepositoryImpl) Delete(ctx context.Context, name string) error {
+	if _, err := r.db.Exec(ctx, deleteRoleQuery, name); err != nil {
+		return fmt.Errorf("delete role: %w", err)
+	}
+	return nil
+}
+
+func scanRole(row pgx.Row) (*domain.Role, error) {
+	role := &domain.Role{}
+	if err := row.Scan(&role.Name, &role.Description, &role.Permissions, &role.CreatedAt, &role.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
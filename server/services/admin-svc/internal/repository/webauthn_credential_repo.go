@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"admin-svc/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// WebAuthnCredentialRepository 管理员安全密钥仓储
+type WebAuthnCredentialRepository interface {
+	Create(ctx context.Context, cred *domain.WebAuthnCredential) error
+	// Get 获取凭证，不存在时返回nil
+	Get(ctx context.Context, id string) (*domain.WebAuthnCredential, error)
+	// ListByAdmin 按注册时间返回管理员的所有凭证
+	ListByAdmin(ctx context.Context, adminID string) ([]*domain.WebAuthnCredential, error)
+	// UpdateSignCount 登录成功后保存新的签名计数
+	UpdateSignCount(ctx context.Context, id string, signCount uint32, usedAt time.Time) error
+	// Delete 删除管理员的凭证，返回false表示不存在
+	Delete(ctx context.Context, adminID, id string) (bool, error)
+}
+
+// WebAuthnCredentialRepositoryImpl 安全密钥仓储实现（SQL与queries/admin_webauthn_credential.sql保持一致）
+type WebAuthnCredentialRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewWebAuthnCredentialRepository 创建安全密钥仓储
+func NewWebAuthnCredentialRepository(db *pgxpool.Pool) WebAuthnCredentialRepository {
+	return &WebAuthnCredentialRepositoryImpl{db: db}
+}
+
+const (
+	webAuthnCredentialColumns = `
+		id, admin_id, name, public_key, algorithm, sign_count, backup_eligible, created_at, last_used_at
+	`
+	createWebAuthnCredentialQuery = `
+		INSERT INTO admin_webauthn_credentials (id, admin_id, name, public_key, algorithm, sign_count, backup_eligible, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	getWebAuthnCredentialQuery   = `SELECT ` + webAuthnCredentialColumns + ` FROM admin_webauthn_credentials WHERE id = $1`
+	listWebAuthnCredentialsQuery = `
+		SELECT ` + webAuthnCredentialColumns + `
+		FROM admin_webauthn_credentials
+		WHERE admin_id = $1
+		ORDER BY created_at
+	`
+	updateWebAuthnSignCountQuery = `
+		UPDATE admin_webauthn_credentials
+		SET sign_count = $1, last_used_at = $2
+		WHERE id = $3
+	`
+	deleteWebAuthnCredentialQuery = `DELETE FROM admin_webauthn_credentials WHERE admin_id = $1 AND id = $2`
+)
+
+// Create 保存凭证
+func (r *WebAuthnCredentialRepositoryImpl) Create(ctx context.Context, c *domain.WebAuthnCredential) error {
+	_, err := r.db.Exec(ctx, createWebAuthnCredentialQuery,
+		c.ID, c.AdminID, c.Name, c.PublicKey, c.Algorithm, int64(c.SignCount), c.BackupEligible, c.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("create webauthn credential: %w", err)
+	}
+	return nil
+}
+
+// Get 获取凭证
+func (r *WebAuthnCredentialRepositoryImpl) Get(ctx context.Context, id string) (*domain.WebAuthnCredential, error) {
+	cred, err := scanWebAuthnCredential(r.db.QueryRow(ctx, getWebAuthnCredentialQuery, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get webauthn credential: %w", err)
+	}
+	return cred, nil
+}
+
+// ListByAdmin 查询管理员的凭证
+func (r *WebAuthnCredentialRepositoryImpl) ListByAdmin(ctx context.Context, adminID string) ([]*domain.WebAuthnCredential, error) {
+	rows, err := r.db.Query(ctx, listWebAuthnCredentialsQuery, adminID)
+	if err != nil {
+		return nil, fmt.Errorf("list webauthn credentials: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*domain.WebAuthnCredential
+	for rows.Next() {
+		cred, err := scanWebAuthnCredential(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan webauthn credential: %w", err)
+		}
+		result = append(result, cred)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list webauthn credentials: %w", err)
+	}
+	return result, nil
+}
+
+// UpdateSignCount 保存签名计数
+func (r *WebAuthnCredentialRepositoryImpl) UpdateSignCount(ctx context.Context, id string, signCount uint32, usedAt time.Time) error {
+	if _, err := r.db.Exec(ctx, updateWebAuthnSignCountQuery, int64(signCount), usedAt, id); err != nil {
+		return fmt.Errorf("update webauthn sign count: %w", err)
+	}
+	return nil
+}
+
+// Delete 删除凭证
+func (r *WebAuthnCredentialRepositoryImpl) Delete(ctx context.Context, adminID, id string) (bool, error) {
+	tag, err := r.db.Exec(ctx, deleteWebAuthnCredentialQuery, adminID, id)
+	if err != nil {
+		return false, fmt.Errorf("delete webauthn credential: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func scanWebAuthnCredential(row pgx.Row) (*domain.WebAuthnCredential, error) {
+	c := &domain.WebAuthnCredential{}
+	var signCount int64
+	if err := row.Scan(
+		&c.ID, &c.AdminID, &c.Name, &c.PublicKey, &c.Algorithm, &signCount, &c.BackupEligible, &c.CreatedAt, &c.LastUsedAt,
+	); err != nil {
+		return nil, err
+	}
+	c.SignCount = uint32(signCount)
+	return c, nil
+}
@@ -0,0 +1,319 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"admin-svc/internal/domain"
+	"admin-svc/internal/repository"
+	"admin-svc/internal/webauthn"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// maxLoginFailures 同一用户名在loginFailureWindow内允许的密码错误次数
+	maxLoginFailures   = 5
+	loginFailureWindow = 15 * time.Minute
+	// pendingLoginTTL 密码验证通过后，完成第二因素验证的期限
+	pendingLoginTTL = 5 * time.Minute
+	// maxSecondFactorAttempts 一次登录允许的第二因素错误次数，超过后需要重新输入密码
+	maxSecondFactorAttempts = 5
+	// maxMFAFailures 同一管理员在loginFailureWindow内允许的第二因素错误次数（所有登录累计）
+	// 密码已泄露时，攻击者不能通过反复登录绕过单次登录的次数限制
+	maxMFAFailures = 5
+)
+
+var (
+	// ErrInvalidCredentials 用户名或密码错误（不区分用户是否存在）
+	ErrInvalidCredentials = errors.New("invalid username or password")
+	// ErrAdminDisabled 管理员已禁用
+	ErrAdminDisabled = errors.New("admin is disabled")
+	// ErrTooManyAttempts 失败次数过多，暂时锁定
+	ErrTooManyAttempts = errors.New("too many failed attempts")
+	// ErrLoginExpired 第二因素验证的登录令牌不存在或已过期
+	ErrLoginExpired = errors.New("login expired, sign in again")
+)
+
+// LoginResult 登录结果
+// MFARequired为true时密码已验证，需要用MFAToken调用第二步验证；否则Token为会话Token
+type LoginResult struct {
+	Token     string               `json:"token,omitempty"`
+	ExpiresAt *time.Time           `json:"expires_at,omitempty"`
+	Session   *domain.AdminSession `json:"session,omitempty"`
+
+	MFARequired bool     `json:"mfa_required"`
+	MFAToken    string   `json:"mfa_token,omitempty"`
+	MFAMethods  []string `json:"mfa_methods,omitempty"`
+
+	// MFAEnrollmentRequired 强制2FA但尚未绑定，会话只能访问绑定第二因素的接口
+	MFAEnrollmentRequired bool `json:"mfa_enrollment_required"`
+}
+
+// SecondFactor 登录第二步提交的验证信息
+type SecondFactor struct {
+	Method    string                     `json:"method"` // totp, backup_code, webauthn；为空时按code格式判断
+	Code      string                     `json:"code"`
+	Assertion webauthn.AssertionResponse `json:"assertion"`
+}
+
+// AdminAuthService 管理员登录
+// 已绑定第二因素的管理员分两步登录：密码验证通过后返回短期的MFAToken，再提交TOTP码、备用恢复码或安全密钥断言
+type AdminAuthService struct {
+	admins   repository.AdminUserRepository
+	mfa      *MFAService
+	sessions *AdminSessionService
+	redis    *redis.Client
+	audit    *AuditService
+	now      func() time.Time
+}
+
+// NewAdminAuthService 创建管理员登录服务
+func NewAdminAuthService(
+	admins repository.AdminUserRepository, mfa *MFAService, sessions *AdminSessionService,
+	redisClient *redis.Client, audit *AuditService,
+) *AdminAuthService {
+	return &AdminAuthService{
+		admins:   admins,
+		mfa:      mfa,
+		sessions: sessions,
+		redis:    redisClient,
+		audit:    audit,
+		now:      time.Now,
+	}
+}
+
+// Login 验证用户名和密码，client提供请求的IP和UA
+// 已绑定第二因素时：totpCode不为空则直接验证（TOTP码或备用恢复码），否则返回MFAToken
+func (s *AdminAuthService) Login(ctx context.Context, client *domain.AdminActor, username, password, totpCode string) (result *LoginResult, err error) {
+	start := s.now()
+	var admin *domain.AdminUser
+	method := ""
+	defer func() {
+		if result != nil && result.MFARequired {
+			return // 只记录最终结果
+		}
+		s.logLogin(ctx, client, admin, username, method, start, err)
+	}()
+
+	if err := s.checkFailures(ctx, loginFailureKey(username), maxLoginFailures); err != nil {
+		return nil, err
+	}
+
+	admin, err = s.admins.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if admin == nil || !checkPassword(admin, password) {
+		s.recordFailure(ctx, loginFailureKey(username))
+		return nil, ErrInvalidCredentials
+	}
+	if admin.Status != domain.StatusActive {
+		return nil, ErrAdminDisabled
+	}
+	// 密码错误次数在整个登录成功后才清零，第二因素失败不能重置密码的次数限制
+	if err := s.checkFailures(ctx, mfaFailureKey(admin.ID), maxMFAFailures); err != nil {
+		return nil, err
+	}
+
+	methods, err := s.mfa.Methods(ctx, admin)
+	if err != nil {
+		return nil, err
+	}
+	if len(methods) == 0 {
+		result, err = s.issueSession(ctx, admin, "", client)
+		if err != nil {
+			return nil, err
+		}
+		result.MFAEnrollmentRequired = s.mfa.Required()
+		return result, nil
+	}
+
+	if totpCode != "" {
+		method, err = s.mfa.VerifyCode(ctx, admin, "", totpCode)
+		if errors.Is(err, ErrInvalidMFACode) {
+			s.recordFailure(ctx, mfaFailureKey(admin.ID))
+		}
+		if err != nil {
+			return nil, err
+		}
+		return s.issueSession(ctx, admin, method, client)
+	}
+
+	mfaToken := uuid.New().String()
+	key := pendingLoginKey(mfaToken)
+	pipe := s.redis.TxPipeline()
+	pipe.HSet(ctx, key, "admin_id", admin.ID, "attempts", 0)
+	pipe.Expire(ctx, key, pendingLoginTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("save pending login: %w", err)
+	}
+	return &LoginResult{MFARequired: true, MFAToken: mfaToken, MFAMethods: methods}, nil
+}
+
+// VerifySecondFactor 登录第二步：验证第二因素并创建会话
+func (s *AdminAuthService) VerifySecondFactor(ctx context.Context, client *domain.AdminActor, mfaToken string, factor *SecondFactor) (result *LoginResult, err error) {
+	start := s.now()
+	var admin *domain.AdminUser
+	method := factor.Method
+	defer func() {
+		name := ""
+		if admin != nil {
+			name = admin.Username
+		}
+		s.logLogin(ctx, client, admin, name, method, start, err)
+	}()
+
+	admin, err = s.pendingAdmin(ctx, mfaToken)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkFailures(ctx, mfaFailureKey(admin.ID), maxMFAFailures); err != nil {
+		s.redis.Del(ctx, pendingLoginKey(mfaToken))
+		return nil, err
+	}
+
+	if method == domain.MFAMethodWebAuthn || (method == "" && len(factor.Assertion) > 0) {
+		method = domain.MFAMethodWebAuthn
+		err = s.mfa.VerifyWebAuthn(ctx, admin, mfaToken, factor.Assertion)
+	} else {
+		method, err = s.mfa.VerifyCode(ctx, admin, method, factor.Code)
+	}
+	if errors.Is(err, ErrInvalidMFACode) || errors.Is(err, ErrWebAuthnChallengeExpired) {
+		s.recordFailure(ctx, mfaFailureKey(admin.ID))
+		attempts, incrErr := s.redis.HIncrBy(ctx, pendingLoginKey(mfaToken), "attempts", 1).Result()
+		if incrErr == nil && attempts >= maxSecondFactorAttempts {
+			s.redis.Del(ctx, pendingLoginKey(mfaToken))
+		}
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.redis.Del(ctx, pendingLoginKey(mfaToken))
+	return s.issueSession(ctx, admin, method, client)
+}
+
+// WebAuthnLoginOptions 生成登录第二步的安全密钥断言参数
+func (s *AdminAuthService) WebAuthnLoginOptions(ctx context.Context, mfaToken string) (*webauthn.RequestOptions, error) {
+	admin, err := s.pendingAdmin(ctx, mfaToken)
+	if err != nil {
+		return nil, err
+	}
+	return s.mfa.BeginWebAuthnLogin(ctx, admin, mfaToken)
+}
+
+// pendingAdmin 根据MFAToken取出密码已验证的管理员
+func (s *AdminAuthService) pendingAdmin(ctx context.Context, mfaToken string) (*domain.AdminUser, error) {
+	if mfaToken == "" {
+		return nil, ErrLoginExpired
+	}
+	adminID, err := s.redis.HGet(ctx, pendingLoginKey(mfaToken), "admin_id").Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrLoginExpired
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get pending login: %w", err)
+	}
+
+	admin, err := s.admins.GetByID(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	if admin == nil {
+		return nil, ErrLoginExpired
+	}
+	if admin.Status != domain.StatusActive {
+		return nil, ErrAdminDisabled
+	}
+	return admin, nil
+}
+
+// issueSession 创建会话、清零失败次数并更新最后登录信息
+func (s *AdminAuthService) issueSession(ctx context.Context, admin *domain.AdminUser, method string, client *domain.AdminActor) (*LoginResult, error) {
+	token, session, err := s.sessions.Create(ctx, admin, method, client)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.redis.Del(ctx, loginFailureKey(admin.Username), mfaFailureKey(admin.ID)).Err(); err != nil {
+		log.Printf("Failed to reset login failures of admin %s: %v", admin.ID, err)
+	}
+	if err := s.admins.UpdateLastLogin(ctx, admin.ID, session.CreatedAt, client.IP); err != nil {
+		log.Printf("Failed to update last login of admin %s: %v", admin.ID, err)
+	}
+	return &LoginResult{Token: token, ExpiresAt: &session.ExpiresAt, Session: session}, nil
+}
+
+// checkFailures 失败次数达到上限时返回ErrTooManyAttempts
+func (s *AdminAuthService) checkFailures(ctx context.Context, key string, max int64) error {
+	failures, err := s.redis.Get(ctx, key).Int64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return fmt.Errorf("get login failures: %w", err)
+	}
+	if failures >= max {
+		return ErrTooManyAttempts
+	}
+	return nil
+}
+
+// recordFailure 累计密码或第二因素错误次数
+func (s *AdminAuthService) recordFailure(ctx context.Context, key string) {
+	pipe := s.redis.TxPipeline()
+	pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, loginFailureWindow)
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("Failed to record login failure: %v", err)
+	}
+}
+
+// logLogin 记录登录结果（失败记录也用于检测连续登录失败）
+func (s *AdminAuthService) logLogin(ctx context.Context, client *domain.AdminActor, admin *domain.AdminUser, username, method string, start time.Time, opErr error) {
+	raw, err := domain.MarshalDetails(&domain.OperationDetails{Extra: map[string]interface{}{"mfa_method": method}})
+	if err != nil {
+		log.Printf("Failed to marshal operation details: %v", err)
+	}
+
+	entry := &domain.OperationLog{
+		ID:        uuid.New().String(),
+		AdminName: username,
+		Operation: domain.OpLogin,
+		Resource:  domain.ResourceAdminUser,
+		Action:    domain.ActionCreate,
+		Details:   raw,
+		RequestID: client.RequestID,
+		IP:        client.IP,
+		UserAgent: client.UserAgent,
+		Status:    domain.StatusSuccess,
+		Duration:  s.now().Sub(start).Milliseconds(),
+		CreatedAt: s.now(),
+	}
+	if admin != nil {
+		entry.AdminID = admin.ID
+		entry.ResourceID = admin.ID
+	}
+	if opErr != nil {
+		entry.Status = domain.StatusFailed
+		entry.ErrorMsg = opErr.Error()
+	}
+
+	if err := s.audit.LogOperation(ctx, entry); err != nil {
+		log.Printf("Failed to log login of %s: %v", username, err)
+	}
+}
+
+func loginFailureKey(username string) string {
+	return "admin:login:failures:" + username
+}
+
+func mfaFailureKey(adminID string) string {
+	return "admin:mfa:failures:" + adminID
+}
+
+func pendingLoginKey(mfaToken string) string {
+	return "admin:login:pending:" + mfaToken
+}
@@ -0,0 +1,368 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"admin-svc/internal/domain"
+	"admin-svc/internal/repository"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/pquerna/otp/totp"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/crypto"
+)
+
+func (r *memoryAdminRepository) GetByUsername(ctx context.Context, username string) (*domain.AdminUser, error) {
+	for _, admin := range r.admins {
+		if admin.Username == username {
+			copied := *admin
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryAdminRepository) UpdateTOTP(ctx context.Context, id, secret string, enabled bool, updatedAt time.Time) error {
+	r.admins[id].TOTPSecret = secret
+	r.admins[id].TOTPEnabled = enabled
+	r.admins[id].UpdatedAt = updatedAt
+	return nil
+}
+
+func (r *memoryAdminRepository) UpdateLastLogin(ctx context.Context, id string, at time.Time, ip string) error {
+	r.admins[id].LastLoginAt = &at
+	r.admins[id].LastLoginIP = ip
+	return nil
+}
+
+// memoryBackupCodeRepository 内存备用恢复码仓储（用于测试）
+type memoryBackupCodeRepository struct {
+	codes map[string]map[string]bool // adminID -> hash -> 已使用
+}
+
+func (r *memoryBackupCodeRepository) Replace(ctx context.Context, adminID string, hashes []string, at time.Time) error {
+	r.codes[adminID] = make(map[string]bool, len(hashes))
+	for _, hash := range hashes {
+		r.codes[adminID][hash] = false
+	}
+	return nil
+}
+
+func (r *memoryBackupCodeRepository) Consume(ctx context.Context, adminID, hash string, at time.Time) (bool, error) {
+	used, ok := r.codes[adminID][hash]
+	if !ok || used {
+		return false, nil
+	}
+	r.codes[adminID][hash] = true
+	return true, nil
+}
+
+func (r *memoryBackupCodeRepository) CountUnused(ctx context.Context, adminID string) (int64, error) {
+	var count int64
+	for _, used := range r.codes[adminID] {
+		if !used {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *memoryBackupCodeRepository) DeleteAll(ctx context.Context, adminID string) error {
+	delete(r.codes, adminID)
+	return nil
+}
+
+// memoryWebAuthnCredentialRepository 内存安全密钥仓储（只实现登录用到的方法）
+type memoryWebAuthnCredentialRepository struct {
+	repository.WebAuthnCredentialRepository
+	creds []*domain.WebAuthnCredential
+}
+
+func (r *memoryWebAuthnCredentialRepository) ListByAdmin(ctx context.Context, adminID string) ([]*domain.WebAuthnCredential, error) {
+	var result []*domain.WebAuthnCredential
+	for _, cred := range r.creds {
+		if cred.AdminID == adminID {
+			copied := *cred
+			result = append(result, &copied)
+		}
+	}
+	return result, nil
+}
+
+type authTestEnv struct {
+	auth        *AdminAuthService
+	mfa         *MFAService
+	sessions    *AdminSessionService
+	admins      *memoryAdminRepository
+	backupCodes *memoryBackupCodeRepository
+	credentials *memoryWebAuthnCredentialRepository
+	redis       *miniredis.Miniredis
+	secret      string
+	client      *domain.AdminActor
+}
+
+func newAuthTestEnv(t *testing.T) *authTestEnv {
+	mr := miniredis.RunT(t)
+	redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	audit := NewAuditService(redisClient, nil, nil, nil)
+
+	hash, err := crypto.HashPassword("correct horse")
+	require.NoError(t, err)
+	secret, err := NewTOTPService("Listen Stream Admin").GenerateSecret("root")
+	require.NoError(t, err)
+
+	env := &authTestEnv{
+		admins: &memoryAdminRepository{admins: map[string]*domain.AdminUser{
+			"admin-1": {
+				ID: "admin-1", Username: "root", PasswordHash: hash, Role: domain.RoleAdmin, Status: domain.StatusActive,
+				TOTPSecret: secret, TOTPEnabled: true,
+			},
+			"admin-2": {ID: "admin-2", Username: "fresh", PasswordHash: hash, Role: domain.RoleViewer, Status: domain.StatusActive},
+		}},
+		backupCodes: &memoryBackupCodeRepository{codes: make(map[string]map[string]bool)},
+		credentials: &memoryWebAuthnCredentialRepository{},
+		redis:       mr,
+		secret:      secret,
+		client:      &domain.AdminActor{IP: "203.0.113.7", UserAgent: "test"},
+	}
+	env.sessions = NewAdminSessionService(newMemorySessionRepository(), env.admins, "test-secret", audit, SessionConfig{})
+	env.mfa = NewMFAService(
+		env.admins, env.backupCodes, env.credentials, env.sessions,
+		NewTOTPService("Listen Stream Admin"), redisClient, audit,
+		MFAConfig{Required: true, BackupCodeKey: []byte("backup-code-key")},
+	)
+	env.auth = NewAdminAuthService(env.admins, env.mfa, env.sessions, redisClient, audit)
+	return env
+}
+
+func (env *authTestEnv) currentCode(t *testing.T) string {
+	code, err := totp.GenerateCode(env.secret, time.Now())
+	require.NoError(t, err)
+	return code
+}
+
+// TestVerifyCode_TOTPReplay 测试同一个TOTP码只能使用一次
+func TestVerifyCode_TOTPReplay(t *testing.T) {
+	env := newAuthTestEnv(t)
+	ctx := context.Background()
+	admin := env.admins.admins["admin-1"]
+	code := env.currentCode(t)
+
+	method, err := env.mfa.VerifyCode(ctx, admin, "", code)
+	require.NoError(t, err)
+	assert.Equal(t, domain.MFAMethodTOTP, method)
+
+	_, err = env.mfa.VerifyCode(ctx, admin, domain.MFAMethodTOTP, code)
+	assert.ErrorIs(t, err, ErrInvalidMFACode)
+
+	// 锁定只针对这个管理员
+	env.admins.admins["admin-2"].TOTPSecret = env.secret
+	env.admins.admins["admin-2"].TOTPEnabled = true
+	_, err = env.mfa.VerifyCode(ctx, env.admins.admins["admin-2"], "", code)
+	assert.NoError(t, err)
+}
+
+// TestVerifyCode_TOTPRequiresEnabled 测试未启用TOTP时不接受TOTP码
+func TestVerifyCode_TOTPRequiresEnabled(t *testing.T) {
+	env := newAuthTestEnv(t)
+	admin := env.admins.admins["admin-1"]
+	admin.TOTPEnabled = false
+
+	_, err := env.mfa.VerifyCode(context.Background(), admin, "", env.currentCode(t))
+	assert.ErrorIs(t, err, ErrInvalidMFACode)
+}
+
+// TestVerifyCode_BackupCodeSingleUse 测试备用恢复码只能使用一次，且不能跨管理员使用
+func TestVerifyCode_BackupCodeSingleUse(t *testing.T) {
+	env := newAuthTestEnv(t)
+	ctx := context.Background()
+	admin := env.admins.admins["admin-1"]
+
+	codes, err := env.mfa.generateBackupCodes(ctx, admin.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, codes)
+
+	_, err = env.mfa.VerifyCode(ctx, env.admins.admins["admin-2"], domain.MFAMethodBackupCode, codes[0])
+	assert.ErrorIs(t, err, ErrInvalidMFACode)
+
+	method, err := env.mfa.VerifyCode(ctx, admin, "", codes[0])
+	require.NoError(t, err)
+	assert.Equal(t, domain.MFAMethodBackupCode, method)
+
+	_, err = env.mfa.VerifyCode(ctx, admin, "", codes[0])
+	assert.ErrorIs(t, err, ErrInvalidMFACode)
+
+	remaining, err := env.backupCodes.CountUnused(ctx, admin.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(codes)-1), remaining)
+
+	// 重新生成后旧的恢复码全部作废
+	_, err = env.mfa.generateBackupCodes(ctx, admin.ID)
+	require.NoError(t, err)
+	_, err = env.mfa.VerifyCode(ctx, admin, "", codes[1])
+	assert.ErrorIs(t, err, ErrInvalidMFACode)
+}
+
+// TestLogin_PasswordLockout 测试密码错误次数达到上限后锁定，正确的密码也无法登录
+func TestLogin_PasswordLockout(t *testing.T) {
+	env := newAuthTestEnv(t)
+	ctx := context.Background()
+
+	for i := 0; i < maxLoginFailures; i++ {
+		_, err := env.auth.Login(ctx, env.client, "root", "wrong", "")
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	}
+	_, err := env.auth.Login(ctx, env.client, "root", "correct horse", "")
+	assert.ErrorIs(t, err, ErrTooManyAttempts)
+
+	// 不存在的用户名同样计数
+	for i := 0; i < maxLoginFailures; i++ {
+		_, err := env.auth.Login(ctx, env.client, "nobody", "wrong", "")
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	}
+	_, err = env.auth.Login(ctx, env.client, "nobody", "wrong", "")
+	assert.ErrorIs(t, err, ErrTooManyAttempts)
+
+	env.redis.FastForward(loginFailureWindow)
+	result, err := env.auth.Login(ctx, env.client, "root", "correct horse", "")
+	require.NoError(t, err)
+	assert.True(t, result.MFARequired)
+}
+
+// TestLogin_PasswordFailuresKeptUntilSecondFactor 测试只通过密码验证不会清零密码错误次数
+func TestLogin_PasswordFailuresKeptUntilSecondFactor(t *testing.T) {
+	env := newAuthTestEnv(t)
+	ctx := context.Background()
+
+	for i := 0; i < maxLoginFailures-1; i++ {
+		_, err := env.auth.Login(ctx, env.client, "root", "wrong", "")
+		require.ErrorIs(t, err, ErrInvalidCredentials)
+	}
+	result, err := env.auth.Login(ctx, env.client, "root", "correct horse", "")
+	require.NoError(t, err)
+	require.True(t, result.MFARequired)
+
+	_, err = env.auth.Login(ctx, env.client, "root", "wrong", "")
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+	_, err = env.auth.Login(ctx, env.client, "root", "correct horse", "")
+	assert.ErrorIs(t, err, ErrTooManyAttempts)
+}
+
+// TestLogin_SuccessResetsFailures 测试完整登录成功后清零密码和第二因素的错误次数
+func TestLogin_SuccessResetsFailures(t *testing.T) {
+	env := newAuthTestEnv(t)
+	ctx := context.Background()
+
+	_, err := env.auth.Login(ctx, env.client, "root", "wrong", "")
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+	result, err := env.auth.Login(ctx, env.client, "root", "correct horse", "")
+	require.NoError(t, err)
+	_, err = env.auth.VerifySecondFactor(ctx, env.client, result.MFAToken, &SecondFactor{Code: "000000"})
+	require.ErrorIs(t, err, ErrInvalidMFACode)
+	assert.True(t, env.redis.Exists(loginFailureKey("root")))
+	assert.True(t, env.redis.Exists(mfaFailureKey("admin-1")))
+
+	result, err = env.auth.VerifySecondFactor(ctx, env.client, result.MFAToken, &SecondFactor{Code: env.currentCode(t)})
+	require.NoError(t, err)
+	assert.NotEmpty(t, result.Token)
+	assert.Equal(t, domain.MFAMethodTOTP, result.Session.MFAMethod)
+	assert.False(t, env.redis.Exists(loginFailureKey("root")))
+	assert.False(t, env.redis.Exists(mfaFailureKey("admin-1")))
+	assert.Equal(t, "203.0.113.7", env.admins.admins["admin-1"].LastLoginIP)
+}
+
+// TestVerifySecondFactor_LockoutAcrossLogins 测试第二因素错误次数按管理员累计，重新登录不能绕过
+func TestVerifySecondFactor_LockoutAcrossLogins(t *testing.T) {
+	env := newAuthTestEnv(t)
+	ctx := context.Background()
+
+	for i := 0; i < maxMFAFailures; i++ {
+		result, err := env.auth.Login(ctx, env.client, "root", "correct horse", "")
+		require.NoError(t, err)
+		require.True(t, result.MFARequired)
+		_, err = env.auth.VerifySecondFactor(ctx, env.client, result.MFAToken, &SecondFactor{Code: "000000"})
+		require.ErrorIs(t, err, ErrInvalidMFACode)
+	}
+
+	_, err := env.auth.Login(ctx, env.client, "root", "correct horse", "")
+	assert.ErrorIs(t, err, ErrTooManyAttempts)
+	_, err = env.auth.Login(ctx, env.client, "root", "correct horse", env.currentCode(t))
+	assert.ErrorIs(t, err, ErrTooManyAttempts)
+
+	env.redis.FastForward(loginFailureWindow)
+	result, err := env.auth.Login(ctx, env.client, "root", "correct horse", env.currentCode(t))
+	require.NoError(t, err)
+	assert.NotEmpty(t, result.Token)
+}
+
+// TestVerifySecondFactor_PendingLoginLocked 测试锁定后已经拿到的MFAToken也不能继续尝试
+func TestVerifySecondFactor_PendingLoginLocked(t *testing.T) {
+	env := newAuthTestEnv(t)
+	ctx := context.Background()
+
+	pending, err := env.auth.Login(ctx, env.client, "root", "correct horse", "")
+	require.NoError(t, err)
+	for i := 0; i < maxMFAFailures; i++ {
+		_, err := env.auth.Login(ctx, env.client, "root", "correct horse", "000000")
+		require.ErrorIs(t, err, ErrInvalidMFACode)
+	}
+
+	_, err = env.auth.VerifySecondFactor(ctx, env.client, pending.MFAToken, &SecondFactor{Code: env.currentCode(t)})
+	assert.ErrorIs(t, err, ErrTooManyAttempts)
+	_, err = env.auth.VerifySecondFactor(ctx, env.client, pending.MFAToken, &SecondFactor{Code: env.currentCode(t)})
+	assert.ErrorIs(t, err, ErrLoginExpired)
+}
+
+// TestVerifySecondFactor_AttemptsPerLogin 测试一次登录的第二因素错误次数用完后需要重新输入密码
+func TestVerifySecondFactor_AttemptsPerLogin(t *testing.T) {
+	env := newAuthTestEnv(t)
+	ctx := context.Background()
+
+	result, err := env.auth.Login(ctx, env.client, "root", "correct horse", "")
+	require.NoError(t, err)
+	for i := 0; i < maxSecondFactorAttempts; i++ {
+		_, err := env.auth.VerifySecondFactor(ctx, env.client, result.MFAToken, &SecondFactor{Method: domain.MFAMethodBackupCode, Code: "nope"})
+		require.ErrorIs(t, err, ErrInvalidMFACode)
+	}
+	_, err = env.auth.VerifySecondFactor(ctx, env.client, result.MFAToken, &SecondFactor{Code: env.currentCode(t)})
+	assert.ErrorIs(t, err, ErrLoginExpired)
+}
+
+// TestLogin_EnrollmentRequired 测试未绑定第二因素时登录成功但会话需要先绑定
+func TestLogin_EnrollmentRequired(t *testing.T) {
+	env := newAuthTestEnv(t)
+
+	result, err := env.auth.Login(context.Background(), env.client, "fresh", "correct horse", "")
+	require.NoError(t, err)
+	assert.False(t, result.MFARequired)
+	assert.True(t, result.MFAEnrollmentRequired)
+	assert.Empty(t, result.Session.MFAMethod)
+}
+
+// TestLogin_SecurityKeyWithoutRelyingParty 测试只绑定了安全密钥但未配置WebAuthn依赖方时拒绝登录
+func TestLogin_SecurityKeyWithoutRelyingParty(t *testing.T) {
+	env := newAuthTestEnv(t)
+	env.credentials.creds = []*domain.WebAuthnCredential{{ID: "cred-1", AdminID: "admin-2"}}
+	ctx := context.Background()
+
+	result, err := env.auth.Login(ctx, env.client, "fresh", "correct horse", "")
+	assert.ErrorIs(t, err, ErrWebAuthnDisabled)
+	assert.Nil(t, result)
+
+	// 仍可使用备用恢复码完成第二步
+	codes, err := env.mfa.generateBackupCodes(ctx, "admin-2")
+	require.NoError(t, err)
+	result, err = env.auth.Login(ctx, env.client, "fresh", "correct horse", "")
+	require.NoError(t, err)
+	assert.True(t, result.MFARequired)
+	assert.Equal(t, []string{domain.MFAMethodBackupCode}, result.MFAMethods)
+	assert.Nil(t, result.Session)
+
+	result, err = env.auth.Login(ctx, env.client, "fresh", "correct horse", codes[0])
+	require.NoError(t, err)
+	assert.Equal(t, domain.MFAMethodBackupCode, result.Session.MFAMethod)
+}
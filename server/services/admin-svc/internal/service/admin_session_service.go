@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"admin-svc/internal/domain"
+	"admin-svc/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/jwt"
+)
+
+const (
+	// sessionTouchInterval 最后活动时间的更新间隔，避免每个请求都写数据库（空闲超时因此有最多1分钟的误差）
+	sessionTouchInterval = time.Minute
+	// sessionRetention 过期或撤销的会话保留多久后删除
+	sessionRetention = 7 * 24 * time.Hour
+)
+
+// ErrSessionNotFound 会话不存在、不属于该管理员或已撤销
+var ErrSessionNotFound = errors.New("session not found")
+
+// SessionConfig 管理员会话配置
+type SessionConfig struct {
+	TTL         time.Duration // 会话绝对有效期（同时是JWT有效期），不随活动延长
+	IdleTimeout time.Duration // 空闲超时，超过这么久没有请求的会话失效；为0时不限制
+}
+
+// AdminSessionService 管理员登录会话
+// 管理员JWT的device_id字段存放会话ID，每次请求都按会话校验，撤销、过期或空闲超时后Token立即失效
+type AdminSessionService struct {
+	sessions repository.AdminSessionRepository
+	admins   repository.AdminUserRepository
+	tokens   *jwt.Manager
+	audit    *AuditService
+	config   SessionConfig
+	now      func() time.Time
+}
+
+// NewAdminSessionService 创建管理员会话服务，secret为JWT签名密钥
+func NewAdminSessionService(
+	sessions repository.AdminSessionRepository, admins repository.AdminUserRepository,
+	secret string, audit *AuditService, config SessionConfig,
+) *AdminSessionService {
+	if config.TTL <= 0 {
+		config.TTL = 12 * time.Hour
+	}
+	return &AdminSessionService{
+		sessions: sessions,
+		admins:   admins,
+		tokens: jwt.NewManager(&jwt.Config{
+			Secret:      secret,
+			Issuer:      "admin-svc",
+			TokenExpiry: config.TTL,
+		}),
+		audit:  audit,
+		config: config,
+		now:    time.Now,
+	}
+}
+
+// Create 为管理员创建会话并签发Token，mfaMethod为登录时验证的第二因素（未验证时为空）
+func (s *AdminSessionService) Create(ctx context.Context, admin *domain.AdminUser, mfaMethod string, client *domain.AdminActor) (string, *domain.AdminSession, error) {
+	now := s.now()
+	session := &domain.AdminSession{
+		ID:         uuid.New().String(),
+		AdminID:    admin.ID,
+		AdminName:  admin.Username,
+		MFAMethod:  mfaMethod,
+		IP:         client.IP,
+		UserAgent:  client.UserAgent,
+		CreatedAt:  now,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(s.config.TTL),
+	}
+	if err := s.sessions.Create(ctx, session); err != nil {
+		return "", nil, err
+	}
+
+	token, err := s.tokens.GenerateToken(admin.ID, session.ID, 0, "")
+	if err != nil {
+		return "", nil, fmt.Errorf("generate admin token: %w", err)
+	}
+	return token, session, nil
+}
+
+// Authenticate 校验Token和对应的会话，Token无效、会话已失效或管理员已禁用时返回nil
+// 实现middleware.SessionAuthenticator
+func (s *AdminSessionService) Authenticate(ctx context.Context, token string) (*domain.AdminSession, error) {
+	claims, err := s.tokens.ValidateToken(token)
+	if err != nil {
+		return nil, nil
+	}
+	session, err := s.sessions.Get(ctx, claims.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	if session == nil || session.AdminID != claims.UserID || !session.Active(now, s.config.IdleTimeout) {
+		return nil, nil
+	}
+
+	admin, err := s.admins.GetByID(ctx, session.AdminID)
+	if err != nil {
+		return nil, err
+	}
+	if admin == nil || admin.Status != domain.StatusActive {
+		return nil, nil
+	}
+	session.AdminName = admin.Username
+
+	if now.Sub(session.LastSeenAt) >= sessionTouchInterval {
+		if err := s.sessions.Touch(ctx, session.ID, now); err != nil {
+			log.Printf("Failed to touch admin session %s: %v", session.ID, err)
+		}
+	}
+	return session, nil
+}
+
+// MarkMFA 登录后绑定了第二因素时，把当前会话标记为已验证（已验证的会话不受影响）
+func (s *AdminSessionService) MarkMFA(ctx context.Context, sessionID, method string) error {
+	if sessionID == "" {
+		return nil
+	}
+	return s.sessions.SetMFAMethod(ctx, sessionID, method)
+}
+
+// List 列出管理员的有效会话，currentID对应的会话标记为当前会话
+func (s *AdminSessionService) List(ctx context.Context, adminID, currentID string) ([]*domain.AdminSession, error) {
+	now := s.now()
+	sessions, err := s.sessions.ListActive(ctx, adminID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*domain.AdminSession, 0, len(sessions))
+	for _, session := range sessions {
+		if !session.Active(now, s.config.IdleTimeout) {
+			continue
+		}
+		session.Current = session.ID == currentID
+		result = append(result, session)
+	}
+	return result, nil
+}
+
+// Revoke 撤销管理员的某个会话（管理员撤销自己的其他设备，或超级管理员远程撤销）
+func (s *AdminSessionService) Revoke(ctx context.Context, actor *domain.AdminActor, adminID, sessionID string) (err error) {
+	start := s.now()
+	defer func() {
+		s.audit.Record(ctx, actor, domain.OpRevokeSession, domain.ResourceSession, sessionID, domain.ActionDelete,
+			&domain.OperationDetails{Extra: map[string]interface{}{"admin_id": adminID}}, start, err)
+	}()
+
+	ok, err := s.sessions.Revoke(ctx, adminID, sessionID, actor.AdminID, s.now())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrSessionNotFound
+	}
+	return nil
+}
+
+// RevokeAll 撤销管理员除exceptID外的所有会话（exceptID为空时全部撤销），返回撤销数量
+func (s *AdminSessionService) RevokeAll(ctx context.Context, actor *domain.AdminActor, adminID, exceptID string) (revoked int64, err error) {
+	start := s.now()
+	defer func() {
+		s.audit.Record(ctx, actor, domain.OpRevokeSession, domain.ResourceSession, adminID, domain.ActionRevokeSessions,
+			&domain.OperationDetails{Extra: map[string]interface{}{"revoked": revoked, "kept_session": exceptID}}, start, err)
+	}()
+
+	admin, err := s.admins.GetByID(ctx, adminID)
+	if err != nil {
+		return 0, err
+	}
+	if admin == nil {
+		return 0, ErrAdminNotFound
+	}
+	return s.sessions.RevokeAll(ctx, adminID, exceptID, actor.AdminID, s.now())
+}
+
+// Logout 撤销当前会话
+func (s *AdminSessionService) Logout(ctx context.Context, actor *domain.AdminActor) (err error) {
+	start := s.now()
+	defer func() {
+		s.audit.Record(ctx, actor, domain.OpLogout, domain.ResourceSession, actor.SessionID, domain.ActionDelete,
+			&domain.OperationDetails{}, start, err)
+	}()
+
+	if _, err := s.sessions.Revoke(ctx, actor.AdminID, actor.SessionID, actor.AdminID, s.now()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// CleanupExpired 删除过期或撤销超过保留期限的会话
+func (s *AdminSessionService) CleanupExpired(ctx context.Context) (int64, error) {
+	return s.sessions.DeleteExpired(ctx, s.now().Add(-sessionRetention))
+}
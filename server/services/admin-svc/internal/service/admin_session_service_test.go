@@ -0,0 +1,290 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"admin-svc/internal/domain"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memorySessionRepository 内存管理员会话仓储（用于测试）
+type memorySessionRepository struct {
+	sessions map[string]*domain.AdminSession
+	touches  int
+}
+
+func newMemorySessionRepository() *memorySessionRepository {
+	return &memorySessionRepository{sessions: make(map[string]*domain.AdminSession)}
+}
+
+func (r *memorySessionRepository) Create(ctx context.Context, session *domain.AdminSession) error {
+	copied := *session
+	r.sessions[session.ID] = &copied
+	return nil
+}
+
+func (r *memorySessionRepository) Get(ctx context.Context, id string) (*domain.AdminSession, error) {
+	session, ok := r.sessions[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *session
+	return &copied, nil
+}
+
+func (r *memorySessionRepository) ListActive(ctx context.Context, adminID string, now time.Time) ([]*domain.AdminSession, error) {
+	var result []*domain.AdminSession
+	for _, session := range r.sessions {
+		if session.AdminID == adminID && session.RevokedAt == nil && now.Before(session.ExpiresAt) {
+			copied := *session
+			result = append(result, &copied)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.After(result[j].CreatedAt) })
+	return result, nil
+}
+
+func (r *memorySessionRepository) Touch(ctx context.Context, id string, at time.Time) error {
+	r.touches++
+	r.sessions[id].LastSeenAt = at
+	return nil
+}
+
+func (r *memorySessionRepository) SetMFAMethod(ctx context.Context, id, method string) error {
+	if r.sessions[id].MFAMethod == "" {
+		r.sessions[id].MFAMethod = method
+	}
+	return nil
+}
+
+func (r *memorySessionRepository) Revoke(ctx context.Context, adminID, id, revokedBy string, at time.Time) (bool, error) {
+	session, ok := r.sessions[id]
+	if !ok || session.AdminID != adminID || session.RevokedAt != nil {
+		return false, nil
+	}
+	session.RevokedAt = &at
+	session.RevokedBy = revokedBy
+	return true, nil
+}
+
+func (r *memorySessionRepository) RevokeAll(ctx context.Context, adminID, exceptID, revokedBy string, at time.Time) (int64, error) {
+	var revoked int64
+	for _, session := range r.sessions {
+		if session.AdminID == adminID && session.ID != exceptID && session.RevokedAt == nil {
+			session.RevokedAt = &at
+			session.RevokedBy = revokedBy
+			revoked++
+		}
+	}
+	return revoked, nil
+}
+
+func (r *memorySessionRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	var deleted int64
+	for id, session := range r.sessions {
+		if session.ExpiresAt.Before(before) || (session.RevokedAt != nil && session.RevokedAt.Before(before)) {
+			delete(r.sessions, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+type sessionTestEnv struct {
+	service  *AdminSessionService
+	sessions *memorySessionRepository
+	admins   *memoryAdminRepository
+	now      time.Time
+}
+
+func newSessionTestEnv(t *testing.T) *sessionTestEnv {
+	mr := miniredis.RunT(t)
+	env := &sessionTestEnv{
+		sessions: newMemorySessionRepository(),
+		admins: &memoryAdminRepository{admins: map[string]*domain.AdminUser{
+			"admin-1": {ID: "admin-1", Username: "root", Role: domain.RoleAdmin, Status: domain.StatusActive},
+			"admin-2": {ID: "admin-2", Username: "ops", Role: domain.RoleOperator, Status: domain.StatusActive},
+		}},
+		now: time.Now(),
+	}
+	audit := NewAuditService(redis.NewClient(&redis.Options{Addr: mr.Addr()}), nil, nil, nil)
+	env.service = NewAdminSessionService(env.sessions, env.admins, "test-secret", audit, SessionConfig{
+		TTL:         12 * time.Hour,
+		IdleTimeout: 30 * time.Minute,
+	})
+	env.service.now = func() time.Time { return env.now }
+	return env
+}
+
+func (env *sessionTestEnv) login(t *testing.T, adminID, mfaMethod string) (string, *domain.AdminSession) {
+	token, session, err := env.service.Create(context.Background(), env.admins.admins[adminID], mfaMethod, &domain.AdminActor{IP: "203.0.113.7"})
+	require.NoError(t, err)
+	return token, session
+}
+
+// TestAuthenticate_ValidSession 测试有效Token返回会话
+func TestAuthenticate_ValidSession(t *testing.T) {
+	env := newSessionTestEnv(t)
+	token, created := env.login(t, "admin-1", domain.MFAMethodTOTP)
+
+	session, err := env.service.Authenticate(context.Background(), token)
+	require.NoError(t, err)
+	require.NotNil(t, session)
+	assert.Equal(t, created.ID, session.ID)
+	assert.Equal(t, "root", session.AdminName)
+	assert.Equal(t, domain.MFAMethodTOTP, session.MFAMethod)
+
+	session, err = env.service.Authenticate(context.Background(), "not-a-token")
+	require.NoError(t, err)
+	assert.Nil(t, session)
+}
+
+// TestAuthenticate_Revoked 测试撤销后Token立即失效，其他会话不受影响
+func TestAuthenticate_Revoked(t *testing.T) {
+	env := newSessionTestEnv(t)
+	ctx := context.Background()
+	token, session := env.login(t, "admin-1", domain.MFAMethodTOTP)
+	other, _ := env.login(t, "admin-1", domain.MFAMethodTOTP)
+
+	err := env.service.Revoke(ctx, &domain.AdminActor{AdminID: "admin-1", AdminName: "root"}, "admin-1", session.ID)
+	require.NoError(t, err)
+
+	got, err := env.service.Authenticate(ctx, token)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+	got, err = env.service.Authenticate(ctx, other)
+	require.NoError(t, err)
+	assert.NotNil(t, got)
+
+	// 重复撤销或撤销其他管理员的会话
+	err = env.service.Revoke(ctx, &domain.AdminActor{AdminID: "admin-1"}, "admin-1", session.ID)
+	assert.ErrorIs(t, err, ErrSessionNotFound)
+	err = env.service.Revoke(ctx, &domain.AdminActor{AdminID: "admin-2"}, "admin-2", got.ID)
+	assert.ErrorIs(t, err, ErrSessionNotFound)
+}
+
+// TestAuthenticate_RevokeAll 测试撤销其他设备的会话时保留当前会话
+func TestAuthenticate_RevokeAll(t *testing.T) {
+	env := newSessionTestEnv(t)
+	ctx := context.Background()
+	current, session := env.login(t, "admin-1", domain.MFAMethodTOTP)
+	other, _ := env.login(t, "admin-1", domain.MFAMethodTOTP)
+	another, _ := env.login(t, "admin-2", domain.MFAMethodTOTP)
+
+	revoked, err := env.service.RevokeAll(ctx, &domain.AdminActor{AdminID: "admin-1"}, "admin-1", session.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), revoked)
+
+	for token, active := range map[string]bool{current: true, other: false, another: true} {
+		got, err := env.service.Authenticate(ctx, token)
+		require.NoError(t, err)
+		assert.Equal(t, active, got != nil)
+	}
+}
+
+// TestAuthenticate_Logout 测试退出登录后Token失效
+func TestAuthenticate_Logout(t *testing.T) {
+	env := newSessionTestEnv(t)
+	ctx := context.Background()
+	token, session := env.login(t, "admin-1", domain.MFAMethodTOTP)
+
+	require.NoError(t, env.service.Logout(ctx, &domain.AdminActor{AdminID: "admin-1", SessionID: session.ID}))
+	got, err := env.service.Authenticate(ctx, token)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+// TestAuthenticate_IdleTimeout 测试空闲超过限制后会话失效，有活动的会话继续有效
+func TestAuthenticate_IdleTimeout(t *testing.T) {
+	env := newSessionTestEnv(t)
+	ctx := context.Background()
+	active, _ := env.login(t, "admin-1", domain.MFAMethodTOTP)
+	idle, _ := env.login(t, "admin-1", domain.MFAMethodTOTP)
+
+	for i := 0; i < 3; i++ {
+		env.now = env.now.Add(20 * time.Minute)
+		got, err := env.service.Authenticate(ctx, active)
+		require.NoError(t, err)
+		require.NotNil(t, got, "request %d", i)
+	}
+	assert.Equal(t, 3, env.sessions.touches)
+
+	got, err := env.service.Authenticate(ctx, idle)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	env.now = env.now.Add(30 * time.Minute)
+	got, err = env.service.Authenticate(ctx, active)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	sessions, err := env.service.List(ctx, "admin-1", "")
+	require.NoError(t, err)
+	assert.Empty(t, sessions)
+}
+
+// TestAuthenticate_TouchInterval 测试最后活动时间按间隔更新，不是每个请求都写
+func TestAuthenticate_TouchInterval(t *testing.T) {
+	env := newSessionTestEnv(t)
+	token, _ := env.login(t, "admin-1", domain.MFAMethodTOTP)
+
+	for i := 0; i < 5; i++ {
+		env.now = env.now.Add(10 * time.Second)
+		_, err := env.service.Authenticate(context.Background(), token)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 0, env.sessions.touches)
+
+	env.now = env.now.Add(sessionTouchInterval)
+	_, err := env.service.Authenticate(context.Background(), token)
+	require.NoError(t, err)
+	assert.Equal(t, 1, env.sessions.touches)
+}
+
+// TestAuthenticate_Expired 测试会话到达绝对有效期后失效，活动不会延长
+func TestAuthenticate_Expired(t *testing.T) {
+	env := newSessionTestEnv(t)
+	token, session := env.login(t, "admin-1", domain.MFAMethodTOTP)
+
+	env.now = session.ExpiresAt.Add(-time.Minute)
+	env.sessions.sessions[session.ID].LastSeenAt = env.now
+	got, err := env.service.Authenticate(context.Background(), token)
+	require.NoError(t, err)
+	assert.NotNil(t, got)
+
+	env.now = session.ExpiresAt
+	env.sessions.sessions[session.ID].LastSeenAt = env.now
+	got, err = env.service.Authenticate(context.Background(), token)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+// TestAuthenticate_DisabledAdmin 测试管理员被禁用后已有会话立即失效
+func TestAuthenticate_DisabledAdmin(t *testing.T) {
+	env := newSessionTestEnv(t)
+	token, _ := env.login(t, "admin-1", domain.MFAMethodTOTP)
+
+	env.admins.admins["admin-1"].Status = domain.StatusDisabled
+	got, err := env.service.Authenticate(context.Background(), token)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+// TestAuthenticate_SessionOwner 测试Token中的管理员与会话不一致时拒绝
+func TestAuthenticate_SessionOwner(t *testing.T) {
+	env := newSessionTestEnv(t)
+	_, session := env.login(t, "admin-2", domain.MFAMethodTOTP)
+
+	forged, err := env.service.tokens.GenerateToken("admin-1", session.ID, 0, "")
+	require.NoError(t, err)
+	got, err := env.service.Authenticate(context.Background(), forged)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
@@ -0,0 +1,326 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"admin-svc/internal/alert"
+	"admin-svc/internal/domain"
+	"admin-svc/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// alertDedupKeyPrefix 告警去重键前缀，完整键为alert:dedup:{type}:{admin_id}
+	alertDedupKeyPrefix = "alert:dedup:"
+	// alertDeliveryLease 定时任务领取投递后的租约，超时未完成（实例崩溃）时由其他实例重新投递
+	alertDeliveryLease = 5 * time.Minute
+	// alertBatchSize 定时任务每次处理的投递或升级数量
+	alertBatchSize = 100
+)
+
+var (
+	// ErrDeliveryNotFound 投递记录不存在
+	ErrDeliveryNotFound = errors.New("alert delivery not found")
+	// ErrDeliveryNotRetryable 只有最终失败的投递可以手动重试
+	ErrDeliveryNotRetryable = errors.New("only failed deliveries can be retried")
+)
+
+// AlertService 异常告警投递
+// 按严重程度把异常路由到配置的告警通道，同类异常在去重窗口内只告警一次；
+// 每个通道一条投递记录，失败后按指数退避重试并保留每次尝试的历史；
+// 异常长时间未处理时按配置升级告警，处理后取消未完成的投递
+type AlertService struct {
+	anomalies  repository.AnomalyRepository
+	deliveries repository.AlertDeliveryRepository
+	redis      *redis.Client
+	cfg        *alert.Config
+	sinks      map[string]alert.Sink
+	now        func() time.Time
+}
+
+// NewAlertService 创建告警投递服务
+func NewAlertService(
+	anomalies repository.AnomalyRepository,
+	deliveries repository.AlertDeliveryRepository,
+	redis *redis.Client,
+	cfg *alert.Config,
+	sinks map[string]alert.Sink,
+) *AlertService {
+	return &AlertService{
+		anomalies:  anomalies,
+		deliveries: deliveries,
+		redis:      redis,
+		cfg:        cfg,
+		sinks:      sinks,
+		now:        time.Now,
+	}
+}
+
+// Notify 为新产生的异常投递告警（异常需已落库）
+// 去重窗口内重复的异常不再告警；单个通道投递失败不影响其他通道，失败的投递由定时任务重试
+func (s *AlertService) Notify(ctx context.Context, anomaly *domain.AnomalousActivity) error {
+	sinks := s.route(anomaly.Severity)
+	if len(sinks) == 0 {
+		return nil
+	}
+
+	if window := time.Duration(s.cfg.DedupWindow); window > 0 {
+		key := fmt.Sprintf("%s%s:%s", alertDedupKeyPrefix, anomaly.Type, anomaly.AdminID)
+		first, err := s.redis.SetNX(ctx, key, anomaly.ID, window).Result()
+		if err != nil {
+			// Redis不可用时宁可重复告警也不漏报
+			log.Printf("Alert dedup check failed, sending anyway: %v", err)
+		} else if !first {
+			log.Printf("Alert suppressed by dedup window: anomaly=%s type=%s admin=%s", anomaly.ID, anomaly.Type, anomaly.AdminID)
+			return nil
+		}
+	}
+
+	return s.dispatch(ctx, anomaly, domain.AlertKindAlert, 0, sinks)
+}
+
+// CancelPending 取消异常所有未完成的投递（异常已处理）
+func (s *AlertService) CancelPending(ctx context.Context, anomalyID string) error {
+	canceled, err := s.deliveries.CancelPending(ctx, anomalyID, s.now())
+	if err != nil {
+		return err
+	}
+	if canceled > 0 {
+		log.Printf("Canceled %d pending alert deliveries for resolved anomaly %s", canceled, anomalyID)
+	}
+	return nil
+}
+
+// ProcessDueDeliveries 重试到期的投递，返回处理的条数
+func (s *AlertService) ProcessDueDeliveries(ctx context.Context) (int, error) {
+	now := s.now()
+	due, err := s.deliveries.ClaimDue(ctx, now, now.Add(alertDeliveryLease), alertBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, d := range due {
+		anomaly, err := s.anomalies.Get(ctx, d.AnomalyID)
+		if err != nil {
+			return 0, err
+		}
+		if anomaly == nil || anomaly.Resolved {
+			d.Status = domain.AlertStatusCanceled
+			d.NextAttemptAt = nil
+			d.UpdatedAt = s.now()
+			if err := s.deliveries.Update(ctx, d); err != nil {
+				return 0, err
+			}
+			continue
+		}
+		if err := s.attempt(ctx, d, anomaly); err != nil {
+			log.Printf("Alert delivery %s to %s failed (attempt %d): %v", d.ID, d.Sink, d.Attempts, err)
+		}
+	}
+	return len(due), nil
+}
+
+// EscalateUnresolved 为长时间未处理的异常发送升级告警，返回升级的异常数
+func (s *AlertService) EscalateUnresolved(ctx context.Context) (int, error) {
+	esc := s.cfg.Escalation
+	if esc.After <= 0 || esc.MaxLevel <= 0 {
+		return 0, nil
+	}
+
+	var severities []string
+	for _, severity := range []string{domain.SeverityLow, domain.SeverityMedium, domain.SeverityHigh, domain.SeverityCritical} {
+		if esc.MinSeverity == "" || domain.SeverityRank(severity) >= domain.SeverityRank(esc.MinSeverity) {
+			severities = append(severities, severity)
+		}
+	}
+
+	now := s.now()
+	// 未配置间隔时只升级一次
+	nextBefore := time.Time{}
+	if esc.Interval > 0 {
+		nextBefore = now.Add(-time.Duration(esc.Interval))
+	}
+	due, err := s.anomalies.ListEscalationDue(ctx, now.Add(-time.Duration(esc.After)), nextBefore, esc.MaxLevel, severities, alertBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	escalated := 0
+	for _, anomaly := range due {
+		ok, err := s.anomalies.MarkEscalated(ctx, anomaly.ID, anomaly.EscalationLevel, now)
+		if err != nil {
+			return escalated, err
+		}
+		if !ok {
+			continue // 其他实例已升级或刚被处理
+		}
+		escalated++
+
+		sinks := esc.Sinks
+		if len(sinks) == 0 {
+			sinks = s.route(anomaly.Severity)
+		}
+		if err := s.dispatch(ctx, anomaly, domain.AlertKindEscalation, anomaly.EscalationLevel+1, sinks); err != nil {
+			return escalated, err
+		}
+	}
+	return escalated, nil
+}
+
+// ListDeliveries 列出异常的所有投递记录（含投递历史）
+func (s *AlertService) ListDeliveries(ctx context.Context, anomalyID string) ([]*domain.AlertDelivery, error) {
+	anomaly, err := s.anomalies.Get(ctx, anomalyID)
+	if err != nil {
+		return nil, err
+	}
+	if anomaly == nil {
+		return nil, ErrAnomalyNotFound
+	}
+
+	deliveries, err := s.deliveries.ListByAnomaly(ctx, anomalyID)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range deliveries {
+		if d.History, err = s.deliveries.ListAttempts(ctx, d.ID); err != nil {
+			return nil, err
+		}
+	}
+	return deliveries, nil
+}
+
+// RetryDelivery 手动重试一条最终失败的投递（立即投递一次），返回投递后的状态
+func (s *AlertService) RetryDelivery(ctx context.Context, id string) (*domain.AlertDelivery, error) {
+	d, err := s.deliveries.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, ErrDeliveryNotFound
+	}
+	if d.Status != domain.AlertStatusFailed {
+		return nil, ErrDeliveryNotRetryable
+	}
+	anomaly, err := s.anomalies.Get(ctx, d.AnomalyID)
+	if err != nil {
+		return nil, err
+	}
+	if anomaly == nil {
+		return nil, ErrAnomalyNotFound
+	}
+	if anomaly.Resolved {
+		return nil, ErrAnomalyResolved
+	}
+
+	if err := s.attempt(ctx, d, anomaly); err != nil {
+		log.Printf("Manual retry of alert delivery %s to %s failed: %v", d.ID, d.Sink, err)
+	}
+	if d.History, err = s.deliveries.ListAttempts(ctx, d.ID); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// route 返回接收该严重程度告警的通道名称
+func (s *AlertService) route(severity string) []string {
+	var names []string
+	for i := range s.cfg.Sinks {
+		if s.cfg.Sinks[i].Accepts(severity) {
+			names = append(names, s.cfg.Sinks[i].Name)
+		}
+	}
+	return names
+}
+
+// dispatch 为每个通道创建投递记录并立即投递一次
+func (s *AlertService) dispatch(ctx context.Context, anomaly *domain.AnomalousActivity, kind string, level int, sinks []string) error {
+	var errs []error
+	for _, sink := range sinks {
+		now := s.now()
+		d := &domain.AlertDelivery{
+			ID:              uuid.New().String(),
+			AnomalyID:       anomaly.ID,
+			Sink:            sink,
+			Kind:            kind,
+			EscalationLevel: level,
+			Status:          domain.AlertStatusPending,
+			NextAttemptAt:   &now,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		}
+		if err := s.deliveries.Create(ctx, d); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := s.attempt(ctx, d, anomaly); err != nil {
+			log.Printf("Alert delivery %s to %s failed (attempt %d): %v", d.ID, d.Sink, d.Attempts, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// attempt 投递一次并记录结果，返回投递错误
+// 记录结果失败时只打日志：投递记录仍为pending，租约到期后会再次投递
+func (s *AlertService) attempt(ctx context.Context, d *domain.AlertDelivery, anomaly *domain.AnomalousActivity) error {
+	start := s.now()
+	var sendErr error
+	if sink, ok := s.sinks[d.Sink]; ok {
+		sendErr = sink.Send(ctx, &alert.Message{Kind: d.Kind, EscalationLevel: d.EscalationLevel, Anomaly: anomaly})
+	} else {
+		sendErr = fmt.Errorf("sink %q is not configured", d.Sink)
+	}
+
+	now := s.now()
+	d.Attempts++
+	d.UpdatedAt = now
+	record := &domain.AlertDeliveryAttempt{
+		DeliveryID: d.ID,
+		Attempt:    d.Attempts,
+		Success:    sendErr == nil,
+		Duration:   now.Sub(start).Milliseconds(),
+		CreatedAt:  now,
+	}
+
+	switch {
+	case sendErr == nil:
+		d.Status = domain.AlertStatusSent
+		d.LastError = ""
+		d.NextAttemptAt = nil
+		d.DeliveredAt = &now
+	case d.Attempts >= s.cfg.Retry.MaxAttempts:
+		d.Status = domain.AlertStatusFailed
+		d.LastError = sendErr.Error()
+		d.NextAttemptAt = nil
+		record.Error = sendErr.Error()
+	default:
+		next := now.Add(s.backoff(d.Attempts))
+		d.Status = domain.AlertStatusPending
+		d.LastError = sendErr.Error()
+		d.NextAttemptAt = &next
+		record.Error = sendErr.Error()
+	}
+
+	if err := s.deliveries.RecordAttempt(ctx, d, record); err != nil {
+		log.Printf("Failed to record alert delivery attempt %s#%d: %v", d.ID, d.Attempts, err)
+	}
+	return sendErr
+}
+
+// backoff 第n次失败后的重试等待时间（指数退避）
+func (s *AlertService) backoff(attempts int) time.Duration {
+	wait := time.Duration(s.cfg.Retry.Backoff)
+	maxWait := time.Duration(s.cfg.Retry.MaxBackoff)
+	for i := 1; i < attempts; i++ {
+		wait *= 2
+		if maxWait > 0 && wait >= maxWait {
+			return maxWait
+		}
+	}
+	return wait
+}
@@ -0,0 +1,495 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"admin-svc/internal/alert"
+	"admin-svc/internal/domain"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryAnomalyRepository 内存异常活动仓储（用于测试）
+type memoryAnomalyRepository struct {
+	anomalies map[string]*domain.AnomalousActivity
+}
+
+func (r *memoryAnomalyRepository) Create(ctx context.Context, anomaly *domain.AnomalousActivity) error {
+	copied := *anomaly
+	r.anomalies[anomaly.ID] = &copied
+	return nil
+}
+
+func (r *memoryAnomalyRepository) Get(ctx context.Context, id string) (*domain.AnomalousActivity, error) {
+	anomaly, ok := r.anomalies[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *anomaly
+	return &copied, nil
+}
+
+func (r *memoryAnomalyRepository) List(ctx context.Context, filter *domain.AnomalyFilter, limit, offset int) ([]*domain.AnomalousActivity, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (r *memoryAnomalyRepository) Count(ctx context.Context, filter *domain.AnomalyFilter) (int64, error) {
+	return 0, errors.New("not implemented")
+}
+
+func (r *memoryAnomalyRepository) Resolve(ctx context.Context, id, resolvedBy string, resolvedAt time.Time) (*domain.AnomalousActivity, error) {
+	anomaly, ok := r.anomalies[id]
+	if !ok || anomaly.Resolved {
+		return nil, nil
+	}
+	anomaly.Resolve(resolvedBy)
+	copied := *anomaly
+	return &copied, nil
+}
+
+func (r *memoryAnomalyRepository) ListEscalationDue(ctx context.Context, firstBefore, nextBefore time.Time, maxLevel int, severities []string, limit int) ([]*domain.AnomalousActivity, error) {
+	var due []*domain.AnomalousActivity
+	for _, a := range r.anomalies {
+		if a.Resolved || a.EscalationLevel >= maxLevel || !containsString(severities, a.Severity) {
+			continue
+		}
+		first := a.EscalationLevel == 0 && a.CreatedAt.Before(firstBefore)
+		next := a.EscalationLevel > 0 && a.LastEscalatedAt != nil && a.LastEscalatedAt.Before(nextBefore)
+		if first || next {
+			copied := *a
+			due = append(due, &copied)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].ID < due[j].ID })
+	return due, nil
+}
+
+func (r *memoryAnomalyRepository) MarkEscalated(ctx context.Context, id string, fromLevel int, at time.Time) (bool, error) {
+	anomaly, ok := r.anomalies[id]
+	if !ok || anomaly.Resolved || anomaly.EscalationLevel != fromLevel {
+		return false, nil
+	}
+	anomaly.EscalationLevel++
+	anomaly.LastEscalatedAt = &at
+	return true, nil
+}
+
+// memoryAlertDeliveryRepository 内存告警投递仓储（用于测试）
+type memoryAlertDeliveryRepository struct {
+	deliveries map[string]*domain.AlertDelivery
+	attempts   map[string][]domain.AlertDeliveryAttempt
+}
+
+func (r *memoryAlertDeliveryRepository) Create(ctx context.Context, d *domain.AlertDelivery) error {
+	copied := *d
+	r.deliveries[d.ID] = &copied
+	return nil
+}
+
+func (r *memoryAlertDeliveryRepository) Get(ctx context.Context, id string) (*domain.AlertDelivery, error) {
+	d, ok := r.deliveries[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *d
+	return &copied, nil
+}
+
+func (r *memoryAlertDeliveryRepository) ListByAnomaly(ctx context.Context, anomalyID string) ([]*domain.AlertDelivery, error) {
+	var result []*domain.AlertDelivery
+	for _, d := range r.deliveries {
+		if d.AnomalyID == anomalyID {
+			copied := *d
+			result = append(result, &copied)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].EscalationLevel != result[j].EscalationLevel {
+			return result[i].EscalationLevel < result[j].EscalationLevel
+		}
+		return result[i].Sink < result[j].Sink
+	})
+	return result, nil
+}
+
+func (r *memoryAlertDeliveryRepository) ListAttempts(ctx context.Context, deliveryID string) ([]domain.AlertDeliveryAttempt, error) {
+	return r.attempts[deliveryID], nil
+}
+
+func (r *memoryAlertDeliveryRepository) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*domain.AlertDelivery, error) {
+	var due []*domain.AlertDelivery
+	for _, d := range r.deliveries {
+		if d.Status == domain.AlertStatusPending && d.NextAttemptAt != nil && !d.NextAttemptAt.After(now) {
+			lease := leaseUntil
+			d.NextAttemptAt = &lease
+			copied := *d
+			due = append(due, &copied)
+		}
+	}
+	return due, nil
+}
+
+func (r *memoryAlertDeliveryRepository) Update(ctx context.Context, d *domain.AlertDelivery) error {
+	copied := *d
+	r.deliveries[d.ID] = &copied
+	return nil
+}
+
+func (r *memoryAlertDeliveryRepository) RecordAttempt(ctx context.Context, d *domain.AlertDelivery, attempt *domain.AlertDeliveryAttempt) error {
+	copied := *d
+	r.deliveries[d.ID] = &copied
+	r.attempts[d.ID] = append(r.attempts[d.ID], *attempt)
+	return nil
+}
+
+func (r *memoryAlertDeliveryRepository) CancelPending(ctx context.Context, anomalyID string, at time.Time) (int64, error) {
+	var canceled int64
+	for _, d := range r.deliveries {
+		if d.AnomalyID == anomalyID && d.Status == domain.AlertStatusPending {
+			d.Status = domain.AlertStatusCanceled
+			d.NextAttemptAt = nil
+			d.UpdatedAt = at
+			canceled++
+		}
+	}
+	return canceled, nil
+}
+
+// stubSink 前failures次投递失败，之后成功
+type stubSink struct {
+	name     string
+	failures int
+	sent     []*alert.Message
+	calls    int
+}
+
+func (s *stubSink) Name() string {
+	return s.name
+}
+
+func (s *stubSink) Send(ctx context.Context, msg *alert.Message) error {
+	s.calls++
+	if s.calls <= s.failures {
+		return errors.New("connection refused")
+	}
+	s.sent = append(s.sent, msg)
+	return nil
+}
+
+type alertTestEnv struct {
+	service    *AlertService
+	anomalies  *memoryAnomalyRepository
+	deliveries *memoryAlertDeliveryRepository
+	redis      *miniredis.Miniredis
+	sinks      map[string]*stubSink
+	now        time.Time
+}
+
+func newAlertTestEnv(t *testing.T) *alertTestEnv {
+	cfg := alert.DefaultConfig()
+	cfg.Retry = alert.RetryConfig{
+		MaxAttempts: 4,
+		Backoff:     alert.Duration(30 * time.Second),
+		MaxBackoff:  alert.Duration(time.Minute),
+	}
+	cfg.Escalation = alert.EscalationConfig{
+		After:       alert.Duration(time.Hour),
+		Interval:    alert.Duration(30 * time.Minute),
+		MaxLevel:    2,
+		MinSeverity: domain.SeverityHigh,
+		Sinks:       []string{"oncall"},
+	}
+	cfg.Sinks = []alert.SinkConfig{
+		{Name: "ops", Type: alert.SinkWebhook, URL: "http://ops.invalid"},
+		{Name: "oncall", Type: alert.SinkWebhook, URL: "http://oncall.invalid", MinSeverity: domain.SeverityCritical},
+	}
+	require.NoError(t, cfg.Validate())
+
+	mr := miniredis.RunT(t)
+	env := &alertTestEnv{
+		anomalies:  &memoryAnomalyRepository{anomalies: make(map[string]*domain.AnomalousActivity)},
+		deliveries: &memoryAlertDeliveryRepository{deliveries: make(map[string]*domain.AlertDelivery), attempts: make(map[string][]domain.AlertDeliveryAttempt)},
+		redis:      mr,
+		sinks:      map[string]*stubSink{"ops": {name: "ops"}, "oncall": {name: "oncall"}},
+		now:        time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC),
+	}
+	sinks := make(map[string]alert.Sink, len(env.sinks))
+	for name, sink := range env.sinks {
+		sinks[name] = sink
+	}
+	env.service = NewAlertService(env.anomalies, env.deliveries, redis.NewClient(&redis.Options{Addr: mr.Addr()}), cfg, sinks)
+	env.service.now = func() time.Time { return env.now }
+	return env
+}
+
+// newAnomaly 创建已落库的异常
+func (env *alertTestEnv) newAnomaly(t *testing.T, id, anomalyType, adminID, severity string) *domain.AnomalousActivity {
+	anomaly := &domain.AnomalousActivity{
+		ID:        id,
+		Type:      anomalyType,
+		Severity:  severity,
+		AdminID:   adminID,
+		AdminName: adminID,
+		CreatedAt: env.now,
+	}
+	require.NoError(t, env.anomalies.Create(context.Background(), anomaly))
+	return anomaly
+}
+
+// deliveriesOf 返回异常的投递记录（按升级次数和通道排序）
+func (env *alertTestEnv) deliveriesOf(t *testing.T, anomalyID string) []*domain.AlertDelivery {
+	deliveries, err := env.deliveries.ListByAnomaly(context.Background(), anomalyID)
+	require.NoError(t, err)
+	return deliveries
+}
+
+// TestNotify_RoutesBySeverity 测试按通道的最低严重程度路由告警
+func TestNotify_RoutesBySeverity(t *testing.T) {
+	env := newAlertTestEnv(t)
+	ctx := context.Background()
+
+	require.NoError(t, env.service.Notify(ctx, env.newAnomaly(t, "a1", domain.AnomalousSensitiveOp, "admin-1", domain.SeverityMedium)))
+	require.NoError(t, env.service.Notify(ctx, env.newAnomaly(t, "a2", domain.AnomalousTypeDataLeak, "admin-1", domain.SeverityCritical)))
+
+	assert.Len(t, env.sinks["ops"].sent, 2)
+	require.Len(t, env.sinks["oncall"].sent, 1)
+	assert.Equal(t, "a2", env.sinks["oncall"].sent[0].Anomaly.ID)
+
+	for _, d := range env.deliveriesOf(t, "a2") {
+		assert.Equal(t, domain.AlertStatusSent, d.Status)
+		assert.Equal(t, 1, d.Attempts)
+		assert.Nil(t, d.NextAttemptAt)
+	}
+}
+
+// TestNotify_DedupWindow 测试同一管理员的同类异常在去重窗口内只告警一次，窗口过期后重新告警
+func TestNotify_DedupWindow(t *testing.T) {
+	env := newAlertTestEnv(t)
+	ctx := context.Background()
+	window := time.Duration(env.service.cfg.DedupWindow)
+
+	require.NoError(t, env.service.Notify(ctx, env.newAnomaly(t, "a1", domain.AnomalousSensitiveOp, "admin-1", domain.SeverityMedium)))
+	require.NoError(t, env.service.Notify(ctx, env.newAnomaly(t, "a2", domain.AnomalousSensitiveOp, "admin-1", domain.SeverityMedium)))
+	// 其他管理员或其他类型不受影响
+	require.NoError(t, env.service.Notify(ctx, env.newAnomaly(t, "a3", domain.AnomalousSensitiveOp, "admin-2", domain.SeverityMedium)))
+	require.NoError(t, env.service.Notify(ctx, env.newAnomaly(t, "a4", domain.AnomalousTypeLoginFailure, "admin-1", domain.SeverityMedium)))
+
+	assert.Len(t, env.deliveriesOf(t, "a1"), 1)
+	assert.Empty(t, env.deliveriesOf(t, "a2"))
+	assert.Len(t, env.deliveriesOf(t, "a3"), 1)
+	assert.Len(t, env.deliveriesOf(t, "a4"), 1)
+
+	key := alertDedupKeyPrefix + domain.AnomalousSensitiveOp + ":admin-1"
+	assert.Equal(t, window, env.redis.TTL(key))
+
+	env.redis.FastForward(window - time.Second)
+	require.NoError(t, env.service.Notify(ctx, env.newAnomaly(t, "a5", domain.AnomalousSensitiveOp, "admin-1", domain.SeverityMedium)))
+	assert.Empty(t, env.deliveriesOf(t, "a5"))
+
+	env.redis.FastForward(time.Second)
+	require.NoError(t, env.service.Notify(ctx, env.newAnomaly(t, "a6", domain.AnomalousSensitiveOp, "admin-1", domain.SeverityMedium)))
+	assert.Len(t, env.deliveriesOf(t, "a6"), 1)
+}
+
+// TestNotify_DedupUnavailable 测试Redis不可用时仍然告警（宁可重复也不漏报）
+func TestNotify_DedupUnavailable(t *testing.T) {
+	env := newAlertTestEnv(t)
+	ctx := context.Background()
+	env.service.redis = redis.NewClient(&redis.Options{Addr: env.redis.Addr(), MaxRetries: -1})
+	env.redis.Close()
+
+	require.NoError(t, env.service.Notify(ctx, env.newAnomaly(t, "a1", domain.AnomalousSensitiveOp, "admin-1", domain.SeverityMedium)))
+	require.NoError(t, env.service.Notify(ctx, env.newAnomaly(t, "a2", domain.AnomalousSensitiveOp, "admin-1", domain.SeverityMedium)))
+	assert.Len(t, env.sinks["ops"].sent, 2)
+}
+
+// TestProcessDueDeliveries_RetriesWithBackoff 测试投递失败后按指数退避重试并记录每次尝试
+func TestProcessDueDeliveries_RetriesWithBackoff(t *testing.T) {
+	env := newAlertTestEnv(t)
+	ctx := context.Background()
+	env.sinks["ops"].failures = 2
+
+	require.NoError(t, env.service.Notify(ctx, env.newAnomaly(t, "a1", domain.AnomalousSensitiveOp, "admin-1", domain.SeverityMedium)))
+	deliveries := env.deliveriesOf(t, "a1")
+	require.Len(t, deliveries, 1)
+	d := deliveries[0]
+	assert.Equal(t, domain.AlertStatusPending, d.Status)
+	assert.Equal(t, "connection refused", d.LastError)
+	assert.Equal(t, env.now.Add(30*time.Second), *d.NextAttemptAt)
+
+	// 未到重试时间
+	processed, err := env.service.ProcessDueDeliveries(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, processed)
+
+	env.now = env.now.Add(30 * time.Second)
+	processed, err = env.service.ProcessDueDeliveries(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	d = env.deliveriesOf(t, "a1")[0]
+	assert.Equal(t, 2, d.Attempts)
+	assert.Equal(t, env.now.Add(time.Minute), *d.NextAttemptAt)
+
+	env.now = env.now.Add(time.Minute)
+	_, err = env.service.ProcessDueDeliveries(ctx)
+	require.NoError(t, err)
+	d = env.deliveriesOf(t, "a1")[0]
+	assert.Equal(t, domain.AlertStatusSent, d.Status)
+	assert.Equal(t, 3, d.Attempts)
+	assert.Empty(t, d.LastError)
+	require.NotNil(t, d.DeliveredAt)
+
+	history, err := env.service.ListDeliveries(ctx, "a1")
+	require.NoError(t, err)
+	require.Len(t, history[0].History, 3)
+	assert.False(t, history[0].History[0].Success)
+	assert.False(t, history[0].History[1].Success)
+	assert.True(t, history[0].History[2].Success)
+}
+
+// TestProcessDueDeliveries_GivesUp 测试重试次数用尽后标记为失败，手动重试成功后标记为已发送
+func TestProcessDueDeliveries_GivesUp(t *testing.T) {
+	env := newAlertTestEnv(t)
+	ctx := context.Background()
+	env.sinks["ops"].failures = 4
+
+	require.NoError(t, env.service.Notify(ctx, env.newAnomaly(t, "a1", domain.AnomalousSensitiveOp, "admin-1", domain.SeverityMedium)))
+	for i := 0; i < 3; i++ {
+		env.now = env.now.Add(time.Minute)
+		_, err := env.service.ProcessDueDeliveries(ctx)
+		require.NoError(t, err)
+	}
+
+	d := env.deliveriesOf(t, "a1")[0]
+	assert.Equal(t, domain.AlertStatusFailed, d.Status)
+	assert.Equal(t, 4, d.Attempts)
+	assert.Nil(t, d.NextAttemptAt)
+
+	env.now = env.now.Add(time.Hour)
+	processed, err := env.service.ProcessDueDeliveries(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, processed)
+
+	retried, err := env.service.RetryDelivery(ctx, d.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.AlertStatusSent, retried.Status)
+	assert.Len(t, retried.History, 5)
+
+	_, err = env.service.RetryDelivery(ctx, d.ID)
+	assert.ErrorIs(t, err, ErrDeliveryNotRetryable)
+}
+
+// TestProcessDueDeliveries_CancelsResolved 测试异常处理后不再重试投递
+func TestProcessDueDeliveries_CancelsResolved(t *testing.T) {
+	env := newAlertTestEnv(t)
+	ctx := context.Background()
+	env.sinks["ops"].failures = 1
+
+	require.NoError(t, env.service.Notify(ctx, env.newAnomaly(t, "a1", domain.AnomalousSensitiveOp, "admin-1", domain.SeverityMedium)))
+	_, err := env.anomalies.Resolve(ctx, "a1", "admin-2", env.now)
+	require.NoError(t, err)
+
+	env.now = env.now.Add(time.Minute)
+	_, err = env.service.ProcessDueDeliveries(ctx)
+	require.NoError(t, err)
+
+	d := env.deliveriesOf(t, "a1")[0]
+	assert.Equal(t, domain.AlertStatusCanceled, d.Status)
+	assert.Equal(t, 1, d.Attempts)
+	assert.Empty(t, env.sinks["ops"].sent)
+}
+
+// TestBackoff 测试退避时间每次翻倍且不超过上限
+func TestBackoff(t *testing.T) {
+	env := newAlertTestEnv(t)
+
+	assert.Equal(t, 30*time.Second, env.service.backoff(1))
+	assert.Equal(t, time.Minute, env.service.backoff(2))
+	assert.Equal(t, time.Minute, env.service.backoff(3))
+	assert.Equal(t, time.Minute, env.service.backoff(10))
+}
+
+// TestEscalateUnresolved 测试未处理的高危异常按间隔升级到升级通道，达到最大次数或处理后不再升级
+func TestEscalateUnresolved(t *testing.T) {
+	env := newAlertTestEnv(t)
+	ctx := context.Background()
+
+	env.newAnomaly(t, "high", domain.AnomalousTypeBulkDisable, "admin-1", domain.SeverityHigh)
+	env.newAnomaly(t, "medium", domain.AnomalousSensitiveOp, "admin-1", domain.SeverityMedium)
+	env.newAnomaly(t, "resolved", domain.AnomalousTypeDataLeak, "admin-2", domain.SeverityCritical)
+	_, err := env.anomalies.Resolve(ctx, "resolved", "admin-3", env.now)
+	require.NoError(t, err)
+
+	// 创建后未满1小时不升级
+	env.now = env.now.Add(59 * time.Minute)
+	escalated, err := env.service.EscalateUnresolved(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, escalated)
+
+	env.now = env.now.Add(2 * time.Minute)
+	escalated, err = env.service.EscalateUnresolved(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, escalated)
+
+	deliveries := env.deliveriesOf(t, "high")
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, "oncall", deliveries[0].Sink)
+	assert.Equal(t, domain.AlertKindEscalation, deliveries[0].Kind)
+	assert.Equal(t, 1, deliveries[0].EscalationLevel)
+	require.Len(t, env.sinks["oncall"].sent, 1)
+	assert.Equal(t, 1, env.sinks["oncall"].sent[0].EscalationLevel)
+	assert.Contains(t, env.sinks["oncall"].sent[0].Title(), "升级#1")
+
+	// 间隔内不重复升级
+	env.now = env.now.Add(29 * time.Minute)
+	escalated, err = env.service.EscalateUnresolved(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, escalated)
+
+	env.now = env.now.Add(2 * time.Minute)
+	escalated, err = env.service.EscalateUnresolved(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, escalated)
+	assert.Len(t, env.deliveriesOf(t, "high"), 2)
+
+	// 达到最大升级次数
+	env.now = env.now.Add(time.Hour)
+	escalated, err = env.service.EscalateUnresolved(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, escalated)
+	assert.Equal(t, 2, env.anomalies.anomalies["high"].EscalationLevel)
+	assert.Empty(t, env.deliveriesOf(t, "medium"))
+	assert.Empty(t, env.deliveriesOf(t, "resolved"))
+}
+
+// TestCancelPending 测试处理异常时取消未完成的投递，已发送的投递不受影响
+func TestCancelPending(t *testing.T) {
+	env := newAlertTestEnv(t)
+	ctx := context.Background()
+	env.sinks["oncall"].failures = 1
+
+	require.NoError(t, env.service.Notify(ctx, env.newAnomaly(t, "a1", domain.AnomalousTypeDataLeak, "admin-1", domain.SeverityCritical)))
+	require.NoError(t, env.service.CancelPending(ctx, "a1"))
+
+	statuses := map[string]string{}
+	for _, d := range env.deliveriesOf(t, "a1") {
+		statuses[d.Sink] = d.Status
+	}
+	assert.Equal(t, map[string]string{"ops": domain.AlertStatusSent, "oncall": domain.AlertStatusCanceled}, statuses)
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
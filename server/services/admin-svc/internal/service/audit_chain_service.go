@@ -0,0 +1,364 @@
+package service
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"admin-svc/internal/domain"
+	"admin-svc/internal/repository"
+
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	// chainVerifyBatchSize 校验哈希链时每批读取的日志数
+	chainVerifyBatchSize = 1000
+
+	// CheckpointSchedule 检查点定时任务的cron表达式（每小时第5分钟，本地时间）
+	// 校验时据此判断哪些日志应该已被检查点覆盖
+	CheckpointSchedule = "5 * * * *"
+	// checkpointGrace 定时任务触发后生成检查点的最长耗时
+	checkpointGrace = 5 * time.Minute
+)
+
+// checkpointSchedule 解析后的检查点定时任务计划
+var checkpointSchedule = func() cron.Schedule {
+	schedule, err := cron.ParseStandard(CheckpointSchedule)
+	if err != nil {
+		panic(err)
+	}
+	return schedule
+}()
+
+// ErrCheckpointSigningDisabled 未配置检查点签名密钥
+var ErrCheckpointSigningDisabled = errors.New("audit checkpoint signing disabled")
+
+// AuditChainService 操作日志哈希链的检查点签名和校验
+// 每条日志的哈希包含上一条日志的哈希，修改、删除或插入日志都会使之后的链接断开；
+// 检查点用数据库之外的Ed25519私钥签名链头，防止有数据库权限的人重新计算整条链
+type AuditChainService struct {
+	logs        repository.OperationLogRepository
+	checkpoints repository.AuditCheckpointRepository
+	signingKey  ed25519.PrivateKey
+	keyID       string
+	publicKeys  map[string]ed25519.PublicKey // 按指纹索引的可信公钥：当前签名公钥和轮换前的历史公钥
+	now         func() time.Time
+}
+
+// NewAuditChainService 创建哈希链服务
+// signingKey为nil时不生成检查点；trustedKeys为密钥轮换前的历史公钥，用于校验旧检查点
+// 校验时无法用可信公钥验证的检查点视为断点
+func NewAuditChainService(logs repository.OperationLogRepository, checkpoints repository.AuditCheckpointRepository, signingKey ed25519.PrivateKey, trustedKeys []ed25519.PublicKey) *AuditChainService {
+	s := &AuditChainService{
+		logs:        logs,
+		checkpoints: checkpoints,
+		signingKey:  signingKey,
+		publicKeys:  make(map[string]ed25519.PublicKey, len(trustedKeys)+1),
+		now:         time.Now,
+	}
+	for _, pub := range trustedKeys {
+		s.publicKeys[checkpointKeyID(pub)] = pub
+	}
+	if signingKey != nil {
+		pub := signingKey.Public().(ed25519.PublicKey)
+		s.keyID = checkpointKeyID(pub)
+		s.publicKeys[s.keyID] = pub
+	}
+	return s
+}
+
+// ParseCheckpointKey 解析base64编码的Ed25519私钥（32字节种子或64字节完整私钥）
+func ParseCheckpointKey(encoded string) (ed25519.PrivateKey, error) {
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decode checkpoint key: %w", err)
+	}
+	switch len(raw) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(raw), nil
+	case ed25519.PrivateKeySize:
+		return ed25519.PrivateKey(raw), nil
+	default:
+		return nil, fmt.Errorf("checkpoint key must be %d or %d bytes, got %d", ed25519.SeedSize, ed25519.PrivateKeySize, len(raw))
+	}
+}
+
+// ParseTrustedCheckpointKeys 解析逗号分隔的base64编码Ed25519公钥（密钥轮换前的历史公钥）
+func ParseTrustedCheckpointKeys(encoded string) ([]ed25519.PublicKey, error) {
+	var keys []ed25519.PublicKey
+	for _, item := range strings.Split(encoded, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		raw, err := base64.StdEncoding.DecodeString(item)
+		if err != nil {
+			return nil, fmt.Errorf("decode trusted checkpoint key: %w", err)
+		}
+		if len(raw) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("trusted checkpoint key must be %d bytes, got %d", ed25519.PublicKeySize, len(raw))
+		}
+		keys = append(keys, ed25519.PublicKey(raw))
+	}
+	return keys, nil
+}
+
+// PublicKey 返回检查点签名公钥（base64）和指纹，未配置签名密钥时为空
+// 审计方应在系统之外保存公钥，用于独立校验检查点
+func (s *AuditChainService) PublicKey() (string, string) {
+	if s.signingKey == nil {
+		return "", ""
+	}
+	return base64.StdEncoding.EncodeToString(s.signingKey.Public().(ed25519.PublicKey)), s.keyID
+}
+
+// CreateCheckpoint 为当前链头签名生成检查点，链头没有变化时返回nil
+func (s *AuditChainService) CreateCheckpoint(ctx context.Context) (*domain.AuditCheckpoint, error) {
+	if s.signingKey == nil {
+		return nil, ErrCheckpointSigningDisabled
+	}
+
+	seq, hash, err := s.logs.Head(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if seq == 0 {
+		return nil, nil
+	}
+	latest, err := s.checkpoints.Latest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil && latest.Seq >= seq {
+		return nil, nil
+	}
+
+	cp := &domain.AuditCheckpoint{
+		Seq:       seq,
+		Hash:      hash,
+		KeyID:     s.keyID,
+		CreatedAt: s.now().UTC().Truncate(time.Microsecond),
+	}
+	cp.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(s.signingKey, cp.SigningPayload()))
+	if err := s.checkpoints.Create(ctx, cp); err != nil {
+		return nil, err
+	}
+	return cp, nil
+}
+
+// ListCheckpoints 列出最近的检查点
+func (s *AuditChainService) ListCheckpoints(ctx context.Context, limit int) ([]*domain.AuditCheckpoint, error) {
+	checkpoints, err := s.checkpoints.ListRecent(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	if checkpoints == nil {
+		checkpoints = []*domain.AuditCheckpoint{}
+	}
+	return checkpoints, nil
+}
+
+// Verify 校验创建时间在[start, end)内的日志，返回第一个断点
+// 时间范围先换算为序号范围（范围前最后一条之后到范围后第一条之前），范围内的日志全部被删除也能发现；
+// 生成检查点时，在最近一次检查点定时任务之前写入的日志必须被有效的检查点覆盖，检查点被删除也能发现
+func (s *AuditChainService) Verify(ctx context.Context, start, end time.Time) (*domain.ChainVerification, error) {
+	result := &domain.ChainVerification{
+		StartTime:  start,
+		EndTime:    end,
+		Valid:      true,
+		VerifiedAt: s.now(),
+	}
+	fail := func(b *domain.ChainBreak) (*domain.ChainVerification, error) {
+		result.Valid = false
+		result.BrokenLink = b
+		return result, nil
+	}
+
+	// 1. 直接插入数据库、没有链信息的日志
+	unchained, err := s.logs.FindUnchained(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if unchained != nil {
+		return fail(&domain.ChainBreak{LogID: unchained.ID, Reason: domain.ChainBreakUnchainedEntry})
+	}
+
+	// 2. 换算序号范围
+	headSeq, headHash, err := s.logs.Head(ctx)
+	if err != nil {
+		return nil, err
+	}
+	before, after, err := s.logs.SeqBounds(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+	first, last := before+1, headSeq
+	if after > 0 {
+		last = after - 1
+	}
+	result.FirstSeq, result.LastSeq = first, last
+	reachesHead := after == 0
+
+	// 3. 范围前一条日志的哈希
+	prevHash := domain.GenesisHash
+	if first > 1 {
+		prev, err := s.logs.GetBySeq(ctx, first-1)
+		if err != nil {
+			return nil, err
+		}
+		if prev == nil {
+			return fail(&domain.ChainBreak{Seq: first - 1, Reason: domain.ChainBreakMissingEntries})
+		}
+		prevHash = prev.Hash
+	}
+
+	checkpoints, err := s.checkpoints.ListRange(ctx, first, last)
+	if err != nil {
+		return nil, err
+	}
+	bySeq := make(map[int64]*domain.AuditCheckpoint, len(checkpoints))
+	for _, cp := range checkpoints {
+		bySeq[cp.Seq] = cp
+	}
+
+	// 4. 逐条校验，同时记录应被检查点覆盖的最大序号和已被有效检查点覆盖的最大序号
+	checkpointDue := lastCheckpointRun(s.now().Add(-checkpointGrace))
+	var mustCover, covered int64
+	expected := first
+	for expected <= last {
+		batch, err := s.logs.ListBySeq(ctx, expected, last, chainVerifyBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		for _, entry := range batch {
+			if entry.Seq != expected {
+				return fail(&domain.ChainBreak{
+					Seq:      expected,
+					Reason:   domain.ChainBreakMissingEntries,
+					Expected: strconv.FormatInt(expected, 10),
+					Actual:   strconv.FormatInt(entry.Seq, 10),
+				})
+			}
+			if entry.PrevHash != prevHash {
+				return fail(&domain.ChainBreak{Seq: entry.Seq, LogID: entry.ID, Reason: domain.ChainBreakPrevHashMismatch, Expected: prevHash, Actual: entry.PrevHash})
+			}
+			hash, err := entry.ComputeChainHash(prevHash)
+			if err != nil {
+				return nil, err
+			}
+			if hash != entry.Hash {
+				return fail(&domain.ChainBreak{Seq: entry.Seq, LogID: entry.ID, Reason: domain.ChainBreakHashMismatch, Expected: hash, Actual: entry.Hash})
+			}
+
+			if cp, ok := bySeq[entry.Seq]; ok {
+				if b := s.verifyCheckpoint(cp, hash); b != nil {
+					b.LogID = entry.ID
+					return fail(b)
+				}
+				result.CheckpointsVerified++
+				covered = entry.Seq
+			}
+			if entry.CreatedAt.Before(checkpointDue) {
+				mustCover = entry.Seq
+			}
+
+			prevHash = hash
+			expected++
+			result.EntriesChecked++
+		}
+	}
+
+	// 5. 链尾
+	if expected <= last {
+		reason := domain.ChainBreakMissingEntries
+		if reachesHead {
+			reason = domain.ChainBreakTruncated
+		}
+		return fail(&domain.ChainBreak{Seq: expected, Reason: reason})
+	}
+	if reachesHead && prevHash != headHash {
+		return fail(&domain.ChainBreak{Seq: headSeq, Reason: domain.ChainBreakHashMismatch, Expected: headHash, Actual: prevHash})
+	}
+	latest, err := s.checkpoints.Latest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// 链头被回退时，之前签过的检查点序号会超过链头
+	if reachesHead && latest != nil && latest.Seq > headSeq {
+		return fail(&domain.ChainBreak{Seq: headSeq + 1, Reason: domain.ChainBreakTruncated, Expected: strconv.FormatInt(latest.Seq, 10), Actual: strconv.FormatInt(headSeq, 10)})
+	}
+
+	// 6. 检查点覆盖：范围之后的最新检查点也覆盖范围内的日志，需校验其签名和对应日志的哈希
+	if s.signingKey == nil || mustCover <= covered {
+		return result, nil
+	}
+	if latest != nil && latest.Seq > last {
+		entry, err := s.logs.GetBySeq(ctx, latest.Seq)
+		if err != nil {
+			return nil, err
+		}
+		if entry == nil {
+			return fail(&domain.ChainBreak{Seq: latest.Seq, Reason: domain.ChainBreakMissingEntries})
+		}
+		if b := s.verifyCheckpoint(latest, entry.Hash); b != nil {
+			b.LogID = entry.ID
+			return fail(b)
+		}
+		result.CheckpointsVerified++
+		covered = latest.Seq
+	}
+	if mustCover > covered {
+		return fail(&domain.ChainBreak{
+			Seq:      covered + 1,
+			Reason:   domain.ChainBreakMissingCheckpoint,
+			Expected: strconv.FormatInt(mustCover, 10),
+			Actual:   strconv.FormatInt(covered, 10),
+		})
+	}
+	return result, nil
+}
+
+// verifyCheckpoint 用可信公钥校验检查点签名，并校验签名的哈希与链上的哈希一致
+func (s *AuditChainService) verifyCheckpoint(cp *domain.AuditCheckpoint, hash string) *domain.ChainBreak {
+	pub, ok := s.publicKeys[cp.KeyID]
+	if !ok {
+		return &domain.ChainBreak{Seq: cp.Seq, Reason: domain.ChainBreakUnknownKey, Actual: cp.KeyID}
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(cp.Signature)
+	if err != nil || !ed25519.Verify(pub, cp.SigningPayload(), sig) {
+		return &domain.ChainBreak{Seq: cp.Seq, Reason: domain.ChainBreakInvalidSignature}
+	}
+	if cp.Hash != hash {
+		return &domain.ChainBreak{Seq: cp.Seq, Reason: domain.ChainBreakCheckpointHash, Expected: cp.Hash, Actual: hash}
+	}
+	return nil
+}
+
+// lastCheckpointRun 返回不晚于t的最近一次检查点定时任务触发时间
+func lastCheckpointRun(t time.Time) time.Time {
+	t = t.In(time.Local)
+	var last time.Time
+	for next := checkpointSchedule.Next(t.Add(-24 * time.Hour)); !next.After(t); next = checkpointSchedule.Next(next) {
+		last = next
+	}
+	return last
+}
+
+// checkpointKeyID 公钥指纹：SHA-256的前8字节
+func checkpointKeyID(pub ed25519.PublicKey) string {
+	sum := sha256.Sum256(pub)
+	return hex.EncodeToString(sum[:8])
+}
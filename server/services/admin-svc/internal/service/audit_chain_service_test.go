@@ -0,0 +1,439 @@
+package service
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"admin-svc/internal/domain"
+	"admin-svc/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryOperationLogRepository 内存操作日志仓储，Append与数据库实现一致地维护链头
+type memoryOperationLogRepository struct {
+	repository.OperationLogRepository
+	logs     map[int64]*domain.OperationLog
+	unseq    []*domain.OperationLog // 直接插入、不属于哈希链的日志
+	headSeq  int64
+	headHash string
+}
+
+func newMemoryOperationLogRepository() *memoryOperationLogRepository {
+	return &memoryOperationLogRepository{logs: make(map[int64]*domain.OperationLog), headHash: domain.GenesisHash}
+}
+
+func (r *memoryOperationLogRepository) Append(ctx context.Context, log *domain.OperationLog) error {
+	if err := log.PrepareForChain(); err != nil {
+		return err
+	}
+	log.Seq = r.headSeq + 1
+	log.PrevHash = r.headHash
+	hash, err := log.ComputeChainHash(log.PrevHash)
+	if err != nil {
+		return err
+	}
+	log.Hash = hash
+	copied := *log
+	r.logs[log.Seq] = &copied
+	r.headSeq, r.headHash = log.Seq, hash
+	return nil
+}
+
+func (r *memoryOperationLogRepository) Head(ctx context.Context) (int64, string, error) {
+	return r.headSeq, r.headHash, nil
+}
+
+func (r *memoryOperationLogRepository) SeqBounds(ctx context.Context, start, end time.Time) (int64, int64, error) {
+	var before, after int64
+	for seq, log := range r.logs {
+		if log.CreatedAt.Before(start) && seq > before {
+			before = seq
+		}
+		if !log.CreatedAt.Before(end) && (after == 0 || seq < after) {
+			after = seq
+		}
+	}
+	return before, after, nil
+}
+
+func (r *memoryOperationLogRepository) GetBySeq(ctx context.Context, seq int64) (*domain.OperationLog, error) {
+	log, ok := r.logs[seq]
+	if !ok {
+		return nil, nil
+	}
+	copied := *log
+	return &copied, nil
+}
+
+func (r *memoryOperationLogRepository) ListBySeq(ctx context.Context, fromSeq, toSeq int64, limit int) ([]*domain.OperationLog, error) {
+	var logs []*domain.OperationLog
+	for seq, log := range r.logs {
+		if seq >= fromSeq && seq <= toSeq {
+			copied := *log
+			logs = append(logs, &copied)
+		}
+	}
+	sort.Slice(logs, func(i, j int) bool { return logs[i].Seq < logs[j].Seq })
+	if len(logs) > limit {
+		logs = logs[:limit]
+	}
+	return logs, nil
+}
+
+func (r *memoryOperationLogRepository) FindUnchained(ctx context.Context, start, end time.Time) (*domain.OperationLog, error) {
+	for _, log := range r.unseq {
+		if !log.CreatedAt.Before(start) && log.CreatedAt.Before(end) {
+			return log, nil
+		}
+	}
+	return nil, nil
+}
+
+// sorted 返回满足条件的哈希链日志，按创建时间和序号升序
+func (r *memoryOperationLogRepository) sorted(filter *domain.OperationLogFilter) []*domain.OperationLog {
+	var logs []*domain.OperationLog
+	for _, log := range r.logs {
+		if filter == nil || filter.Match(log) {
+			copied := *log
+			logs = append(logs, &copied)
+		}
+	}
+	sort.Slice(logs, func(i, j int) bool {
+		if !logs[i].CreatedAt.Equal(logs[j].CreatedAt) {
+			return logs[i].CreatedAt.Before(logs[j].CreatedAt)
+		}
+		return logs[i].Seq < logs[j].Seq
+	})
+	return logs
+}
+
+func (r *memoryOperationLogRepository) Count(ctx context.Context, filter *domain.OperationLogFilter) (int64, error) {
+	return int64(len(r.sorted(filter))), nil
+}
+
+func (r *memoryOperationLogRepository) List(ctx context.Context, filter *domain.OperationLogFilter, limit, offset int) ([]*domain.OperationLog, error) {
+	logs := r.sorted(filter)
+	for i, j := 0, len(logs)-1; i < j; i, j = i+1, j-1 {
+		logs[i], logs[j] = logs[j], logs[i]
+	}
+	if offset >= len(logs) {
+		return nil, nil
+	}
+	logs = logs[offset:]
+	if len(logs) > limit {
+		logs = logs[:limit]
+	}
+	return logs, nil
+}
+
+func (r *memoryOperationLogRepository) Stream(ctx context.Context, filter *domain.OperationLogFilter, fn func(*domain.OperationLog) error) error {
+	for _, log := range r.sorted(filter) {
+		if err := fn(log); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rechain 从seq开始重新计算哈希链（模拟有数据库权限的人修改日志后重算整条链）
+func (r *memoryOperationLogRepository) rechain(seq int64) {
+	prevHash := domain.GenesisHash
+	if prev, ok := r.logs[seq-1]; ok {
+		prevHash = prev.Hash
+	}
+	for ; seq <= r.headSeq; seq++ {
+		log := r.logs[seq]
+		log.PrevHash = prevHash
+		log.Hash, _ = log.ComputeChainHash(prevHash)
+		prevHash = log.Hash
+	}
+	r.headHash = prevHash
+}
+
+// memoryCheckpointRepository 内存检查点仓储
+type memoryCheckpointRepository struct {
+	checkpoints map[int64]*domain.AuditCheckpoint
+}
+
+func (r *memoryCheckpointRepository) Create(ctx context.Context, cp *domain.AuditCheckpoint) error {
+	copied := *cp
+	r.checkpoints[cp.Seq] = &copied
+	return nil
+}
+
+func (r *memoryCheckpointRepository) Latest(ctx context.Context) (*domain.AuditCheckpoint, error) {
+	var latest *domain.AuditCheckpoint
+	for _, cp := range r.checkpoints {
+		if latest == nil || cp.Seq > latest.Seq {
+			latest = cp
+		}
+	}
+	if latest == nil {
+		return nil, nil
+	}
+	copied := *latest
+	return &copied, nil
+}
+
+func (r *memoryCheckpointRepository) ListRange(ctx context.Context, fromSeq, toSeq int64) ([]*domain.AuditCheckpoint, error) {
+	var checkpoints []*domain.AuditCheckpoint
+	for seq, cp := range r.checkpoints {
+		if seq >= fromSeq && seq <= toSeq {
+			copied := *cp
+			checkpoints = append(checkpoints, &copied)
+		}
+	}
+	sort.Slice(checkpoints, func(i, j int) bool { return checkpoints[i].Seq < checkpoints[j].Seq })
+	return checkpoints, nil
+}
+
+func (r *memoryCheckpointRepository) ListRecent(ctx context.Context, limit int) ([]*domain.AuditCheckpoint, error) {
+	checkpoints, _ := r.ListRange(context.Background(), 0, 1<<62)
+	sort.Slice(checkpoints, func(i, j int) bool { return checkpoints[i].Seq > checkpoints[j].Seq })
+	if len(checkpoints) > limit {
+		checkpoints = checkpoints[:limit]
+	}
+	return checkpoints, nil
+}
+
+type auditChainTestEnv struct {
+	service     *AuditChainService
+	logs        *memoryOperationLogRepository
+	checkpoints *memoryCheckpointRepository
+	key         ed25519.PrivateKey
+	now         time.Time
+}
+
+// newAuditChainTestEnv 创建测试环境：9:00到9:50每10分钟一条日志，10:05（定时任务时间）生成检查点
+func newAuditChainTestEnv(t *testing.T, trustedKeys ...ed25519.PublicKey) *auditChainTestEnv {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	env := &auditChainTestEnv{
+		logs:        newMemoryOperationLogRepository(),
+		checkpoints: &memoryCheckpointRepository{checkpoints: make(map[int64]*domain.AuditCheckpoint)},
+		key:         key,
+		now:         time.Date(2026, 3, 2, 10, 5, 0, 0, time.Local),
+	}
+	env.service = NewAuditChainService(env.logs, env.checkpoints, key, trustedKeys)
+	env.service.now = func() time.Time { return env.now }
+
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)
+	for i := 0; i < 6; i++ {
+		env.appendLog(t, start.Add(time.Duration(i)*10*time.Minute))
+	}
+	_, err = env.service.CreateCheckpoint(context.Background())
+	require.NoError(t, err)
+	env.now = time.Date(2026, 3, 2, 10, 30, 0, 0, time.Local)
+	return env
+}
+
+func (env *auditChainTestEnv) appendLog(t *testing.T, createdAt time.Time) {
+	seq := env.logs.headSeq + 1
+	require.NoError(t, env.logs.Append(context.Background(), &domain.OperationLog{
+		ID:        fmt.Sprintf("log-%d", seq),
+		AdminID:   "admin-1",
+		AdminName: "root",
+		Operation: domain.OpLogin,
+		Resource:  "admin",
+		Action:    "create",
+		Details:   []byte(fmt.Sprintf(`{"n": %d}`, seq)),
+		Status:    domain.StatusSuccess,
+		CreatedAt: createdAt,
+	}))
+}
+
+// verifyAll 校验全部日志
+func (env *auditChainTestEnv) verifyAll(t *testing.T) *domain.ChainVerification {
+	result, err := env.service.Verify(context.Background(), time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local), env.now)
+	require.NoError(t, err)
+	return result
+}
+
+func requireBreak(t *testing.T, result *domain.ChainVerification, reason string, seq int64) {
+	t.Helper()
+	require.False(t, result.Valid)
+	require.NotNil(t, result.BrokenLink)
+	assert.Equal(t, reason, result.BrokenLink.Reason)
+	assert.Equal(t, seq, result.BrokenLink.Seq)
+}
+
+// TestVerify_ValidChain 测试未被篡改的链校验通过
+func TestVerify_ValidChain(t *testing.T) {
+	env := newAuditChainTestEnv(t)
+	env.appendLog(t, env.now.Add(-10*time.Minute))
+
+	result := env.verifyAll(t)
+	assert.True(t, result.Valid, "%+v", result.BrokenLink)
+	assert.Equal(t, int64(7), result.EntriesChecked)
+	assert.Equal(t, 1, result.CheckpointsVerified)
+
+	// 只校验检查点之前的一段时，也用范围之后的最新检查点确认覆盖
+	result, err := env.service.Verify(context.Background(),
+		time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local), time.Date(2026, 3, 2, 9, 30, 0, 0, time.Local))
+	require.NoError(t, err)
+	assert.True(t, result.Valid, "%+v", result.BrokenLink)
+	assert.Equal(t, int64(1), result.FirstSeq)
+	assert.Equal(t, int64(3), result.LastSeq)
+}
+
+// TestVerify_DetectsTampering 测试修改、删除、插入和截断日志都能发现
+func TestVerify_DetectsTampering(t *testing.T) {
+	t.Run("modified entry", func(t *testing.T) {
+		env := newAuditChainTestEnv(t)
+		env.logs.logs[3].Status = domain.StatusFailed
+		requireBreak(t, env.verifyAll(t), domain.ChainBreakHashMismatch, 3)
+	})
+
+	t.Run("deleted entry", func(t *testing.T) {
+		env := newAuditChainTestEnv(t)
+		delete(env.logs.logs, 2)
+		requireBreak(t, env.verifyAll(t), domain.ChainBreakMissingEntries, 2)
+	})
+
+	t.Run("replaced entry", func(t *testing.T) {
+		env := newAuditChainTestEnv(t)
+		env.logs.logs[4].PrevHash = env.logs.logs[2].Hash
+		requireBreak(t, env.verifyAll(t), domain.ChainBreakPrevHashMismatch, 4)
+	})
+
+	t.Run("unchained entry", func(t *testing.T) {
+		env := newAuditChainTestEnv(t)
+		env.logs.unseq = append(env.logs.unseq, &domain.OperationLog{ID: "forged", CreatedAt: env.now.Add(-time.Hour)})
+		result := env.verifyAll(t)
+		require.False(t, result.Valid)
+		assert.Equal(t, domain.ChainBreakUnchainedEntry, result.BrokenLink.Reason)
+		assert.Equal(t, "forged", result.BrokenLink.LogID)
+	})
+
+	t.Run("truncated tail", func(t *testing.T) {
+		env := newAuditChainTestEnv(t)
+		delete(env.logs.logs, 6)
+		requireBreak(t, env.verifyAll(t), domain.ChainBreakTruncated, 6)
+	})
+
+	t.Run("truncated tail and head rolled back", func(t *testing.T) {
+		env := newAuditChainTestEnv(t)
+		delete(env.logs.logs, 6)
+		env.logs.headSeq, env.logs.headHash = 5, env.logs.logs[5].Hash
+		requireBreak(t, env.verifyAll(t), domain.ChainBreakTruncated, 6)
+	})
+
+	t.Run("chain recomputed", func(t *testing.T) {
+		env := newAuditChainTestEnv(t)
+		env.logs.logs[2].AdminName = "someone-else"
+		env.logs.rechain(2)
+		requireBreak(t, env.verifyAll(t), domain.ChainBreakCheckpointHash, 6)
+	})
+}
+
+// TestVerify_CheckpointKeys 测试检查点必须由当前密钥或配置的历史公钥签名
+func TestVerify_CheckpointKeys(t *testing.T) {
+	t.Run("forged signature", func(t *testing.T) {
+		env := newAuditChainTestEnv(t)
+		env.logs.logs[2].AdminName = "someone-else"
+		env.logs.rechain(2)
+		cp := env.checkpoints.checkpoints[6]
+		cp.Hash = env.logs.logs[6].Hash
+		requireBreak(t, env.verifyAll(t), domain.ChainBreakInvalidSignature, 6)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		env := newAuditChainTestEnv(t)
+		env.logs.logs[2].AdminName = "someone-else"
+		env.logs.rechain(2)
+
+		// 用攻击者自己的密钥重新签名检查点
+		pub, forger, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		cp := env.checkpoints.checkpoints[6]
+		cp.Hash = env.logs.logs[6].Hash
+		cp.KeyID = checkpointKeyID(pub)
+		cp.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(forger, cp.SigningPayload()))
+
+		result := env.verifyAll(t)
+		requireBreak(t, result, domain.ChainBreakUnknownKey, 6)
+		assert.Equal(t, cp.KeyID, result.BrokenLink.Actual)
+	})
+
+	t.Run("trusted historical key", func(t *testing.T) {
+		env := newAuditChainTestEnv(t)
+		oldPub := env.key.Public().(ed25519.PublicKey)
+
+		// 密钥轮换：新服务只信任旧公钥，不再持有旧私钥
+		_, newKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		rotated := NewAuditChainService(env.logs, env.checkpoints, newKey, []ed25519.PublicKey{oldPub})
+		rotated.now = env.service.now
+		result, err := rotated.Verify(context.Background(), time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local), env.now)
+		require.NoError(t, err)
+		assert.True(t, result.Valid, "%+v", result.BrokenLink)
+		assert.Equal(t, 1, result.CheckpointsVerified)
+
+		// 没有配置旧公钥时旧检查点不可信
+		untrusted := NewAuditChainService(env.logs, env.checkpoints, newKey, nil)
+		untrusted.now = env.service.now
+		result, err = untrusted.Verify(context.Background(), time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local), env.now)
+		require.NoError(t, err)
+		requireBreak(t, result, domain.ChainBreakUnknownKey, 6)
+	})
+}
+
+// TestVerify_MissingCheckpoint 测试定时任务之前写入的日志没有检查点覆盖时视为检查点被删除
+func TestVerify_MissingCheckpoint(t *testing.T) {
+	env := newAuditChainTestEnv(t)
+	delete(env.checkpoints.checkpoints, 6)
+
+	result := env.verifyAll(t)
+	requireBreak(t, result, domain.ChainBreakMissingCheckpoint, 1)
+	assert.Equal(t, "6", result.BrokenLink.Expected)
+
+	// 删除检查点后重新计算链也只会报告检查点缺失
+	env.logs.logs[2].AdminName = "someone-else"
+	env.logs.rechain(2)
+	requireBreak(t, env.verifyAll(t), domain.ChainBreakMissingCheckpoint, 1)
+
+	// 定时任务触发前写入、还未到下次任务的日志不要求检查点
+	env = newAuditChainTestEnv(t)
+	env.appendLog(t, env.now.Add(-10*time.Minute))
+	result = env.verifyAll(t)
+	assert.True(t, result.Valid, "%+v", result.BrokenLink)
+
+	// 下次任务（11:05）之后仍没有检查点
+	env.now = time.Date(2026, 3, 2, 11, 30, 0, 0, time.Local)
+	requireBreak(t, env.verifyAll(t), domain.ChainBreakMissingCheckpoint, 7)
+
+	// 未配置签名密钥时不生成检查点，也不要求检查点
+	unsigned := NewAuditChainService(env.logs, &memoryCheckpointRepository{checkpoints: make(map[int64]*domain.AuditCheckpoint)}, nil, nil)
+	unsigned.now = env.service.now
+	result, err := unsigned.Verify(context.Background(), time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local), env.now)
+	require.NoError(t, err)
+	assert.True(t, result.Valid, "%+v", result.BrokenLink)
+}
+
+// TestParseTrustedCheckpointKeys 测试历史公钥配置解析
+func TestParseTrustedCheckpointKeys(t *testing.T) {
+	pub1, _, _ := ed25519.GenerateKey(rand.Reader)
+	pub2, _, _ := ed25519.GenerateKey(rand.Reader)
+
+	keys, err := ParseTrustedCheckpointKeys(base64.StdEncoding.EncodeToString(pub1) + ", " + base64.StdEncoding.EncodeToString(pub2) + ",")
+	require.NoError(t, err)
+	assert.Equal(t, []ed25519.PublicKey{pub1, pub2}, keys)
+
+	keys, err = ParseTrustedCheckpointKeys("")
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+
+	_, err = ParseTrustedCheckpointKeys(base64.StdEncoding.EncodeToString([]byte("short")))
+	assert.Error(t, err)
+	_, err = ParseTrustedCheckpointKeys("not base64!")
+	assert.Error(t, err)
+}
@@ -3,33 +3,120 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"time"
 
 	"admin-svc/internal/domain"
+	"admin-svc/internal/repository"
 
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 )
 
+var (
+	// ErrAnomalyNotFound 异常活动不存在
+	ErrAnomalyNotFound = errors.New("anomaly not found")
+	// ErrAnomalyResolved 异常活动已处理
+	ErrAnomalyResolved = errors.New("anomaly already resolved")
+	// ErrAnomalyPersistenceDisabled 未配置异常活动仓储
+	ErrAnomalyPersistenceDisabled = errors.New("anomaly persistence disabled")
+)
+
+// AnomalyNotifier 异常告警投递（由AlertService实现）
+type AnomalyNotifier interface {
+	// Notify 为新产生的异常投递告警
+	Notify(ctx context.Context, anomaly *domain.AnomalousActivity) error
+	// CancelPending 异常处理后取消未完成的投递
+	CancelPending(ctx context.Context, anomalyID string) error
+}
+
 // AuditService 操作审计服务
 type AuditService struct {
-	redis *redis.Client
+	redis     *redis.Client
+	logs      repository.OperationLogRepository
+	anomalies repository.AnomalyRepository
+	notifier  AnomalyNotifier
 }
 
-func NewAuditService(redis *redis.Client) *AuditService {
-	return &AuditService{redis: redis}
+// NewAuditService 创建审计服务
+// logs为nil时操作日志只保存在Redis（最近1000条），不进入哈希链，仅用于测试和本地开发；
+// anomalies为nil时异常活动只发布到Redis频道，不落库也无法查询和处理；notifier为nil时不投递告警
+func NewAuditService(redis *redis.Client, logs repository.OperationLogRepository, anomalies repository.AnomalyRepository, notifier AnomalyNotifier) *AuditService {
+	return &AuditService{
+		redis:     redis,
+		logs:      logs,
+		anomalies: anomalies,
+		notifier:  notifier,
+	}
 }
 
 // LogOperation 记录操作日志
+// 追加到PostgreSQL哈希链（失败时返回错误）；未配置日志仓储时写入Redis（最近1000条）
 func (s *AuditService) LogOperation(ctx context.Context, log *domain.OperationLog) error {
-	// TODO: 实际应该写入数据库
-	// 这里简化实现，仅记录到Redis用于审计
+	if s.logs != nil {
+		if err := s.logs.Append(ctx, log); err != nil {
+			return fmt.Errorf("append log: %w", err)
+		}
+	} else if err := s.pushRecentLog(ctx, log); err != nil {
+		return err
+	}
+
+	// 异步检查异常活动（不随请求结束而取消）
+	go s.CheckAnomalousActivity(context.WithoutCancel(ctx), log)
+
+	return nil
+}
+
+// Record 记录一次管理操作（无论成功失败），opErr不为nil时记为失败
+// 写日志失败只打日志，不影响操作结果
+func (s *AuditService) Record(
+	ctx context.Context, actor *domain.AdminActor, operation, resource, resourceID, action string,
+	details *domain.OperationDetails, start time.Time, opErr error,
+) {
+	var raw json.RawMessage
+	if details != nil {
+		var err error
+		if raw, err = domain.MarshalDetails(details); err != nil {
+			log.Printf("Failed to marshal operation details: %v", err)
+		}
+	}
+
+	now := time.Now()
+	entry := &domain.OperationLog{
+		ID:         uuid.New().String(),
+		AdminID:    actor.AdminID,
+		AdminName:  actor.AdminName,
+		Operation:  operation,
+		Resource:   resource,
+		ResourceID: resourceID,
+		Action:     action,
+		Details:    raw,
+		RequestID:  actor.RequestID,
+		IP:         actor.IP,
+		UserAgent:  actor.UserAgent,
+		Status:     domain.StatusSuccess,
+		Duration:   now.Sub(start).Milliseconds(),
+		CreatedAt:  now,
+	}
+	if opErr != nil {
+		entry.Status = domain.StatusFailed
+		entry.ErrorMsg = opErr.Error()
+	}
+
+	if err := s.LogOperation(ctx, entry); err != nil {
+		log.Printf("Failed to log %s %s operation on %s %s: %v", operation, action, resource, resourceID, err)
+	}
+}
+
+// pushRecentLog 写入Redis列表（最近1000条）
+func (s *AuditService) pushRecentLog(ctx context.Context, log *domain.OperationLog) error {
 	logJSON, err := json.Marshal(log)
 	if err != nil {
 		return fmt.Errorf("marshal log: %w", err)
 	}
 
-	// 存储到Redis列表（最近1000条）
 	key := "audit:logs"
 	if err := s.redis.LPush(ctx, key, logJSON).Err(); err != nil {
 		return fmt.Errorf("lpush log: %w", err)
@@ -39,11 +126,63 @@ func (s *AuditService) LogOperation(ctx context.Context, log *domain.OperationLo
 	if err := s.redis.LTrim(ctx, key, 0, 999).Err(); err != nil {
 		return fmt.Errorf("ltrim log: %w", err)
 	}
+	return nil
+}
 
-	// 异步检查异常活动
-	go s.CheckAnomalousActivity(ctx, log)
+// ListOperationLogs 分页查询操作日志，返回当前页和满足条件的总数
+// 配置了日志仓储时从PostgreSQL分页查询，否则只能查询Redis中最近1000条
+func (s *AuditService) ListOperationLogs(ctx context.Context, filter *domain.OperationLogFilter, page, pageSize int) ([]domain.OperationLog, int, error) {
+	if s.logs != nil {
+		total, err := s.logs.Count(ctx, filter)
+		if err != nil {
+			return nil, 0, err
+		}
+		items, err := s.logs.List(ctx, filter, pageSize, (page-1)*pageSize)
+		if err != nil {
+			return nil, 0, err
+		}
+		logs := make([]domain.OperationLog, 0, len(items))
+		for _, item := range items {
+			logs = append(logs, *item)
+		}
+		return logs, int(total), nil
+	}
 
-	return nil
+	logs, err := s.recentLogs(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total := len(logs)
+	start := (page - 1) * pageSize
+	if start >= total {
+		return []domain.OperationLog{}, total, nil
+	}
+	end := start + pageSize
+	if end > total {
+		end = total
+	}
+	return logs[start:end], total, nil
+}
+
+// recentLogs 从Redis读取最近的日志并过滤，最新的在前
+func (s *AuditService) recentLogs(ctx context.Context, filter *domain.OperationLogFilter) ([]domain.OperationLog, error) {
+	items, err := s.redis.LRange(ctx, "audit:logs", 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("lrange logs: %w", err)
+	}
+
+	logs := make([]domain.OperationLog, 0, len(items))
+	for _, item := range items {
+		var log domain.OperationLog
+		if err := json.Unmarshal([]byte(item), &log); err != nil {
+			continue // 跳过无法解析的记录
+		}
+		if filter == nil || filter.Match(&log) {
+			logs = append(logs, log)
+		}
+	}
+	return logs, nil
 }
 
 // CheckAnomalousActivity 检查异常活动
@@ -54,11 +193,11 @@ func (s *AuditService) CheckAnomalousActivity(ctx context.Context, log *domain.O
 		count, err := s.countRecentOperations(ctx, log.AdminID, "user_management:disable", 1*time.Hour)
 		if err == nil && count >= 20 {
 			return s.createAnomaly(
+				ctx,
+				log,
 				domain.AnomalousTypeBulkDisable,
 				domain.SeverityHigh,
 				fmt.Sprintf("管理员 %s 在1小时内禁用了 %d 个用户", log.AdminName, count),
-				log.AdminID,
-				log.AdminName,
 			), nil
 		}
 	}
@@ -66,6 +205,8 @@ func (s *AuditService) CheckAnomalousActivity(ctx context.Context, log *domain.O
 	// 2. 检查敏感操作（非工作时间）
 	if s.isSensitiveOperation(log.Operation) && !s.isWorkingHours(log.CreatedAt) {
 		return s.createAnomaly(
+			ctx,
+			log,
 			domain.AnomalousSensitiveOp,
 			domain.SeverityMedium,
 			fmt.Sprintf("管理员 %s 在非工作时间(%s)执行敏感操作: %s %s",
@@ -74,21 +215,19 @@ func (s *AuditService) CheckAnomalousActivity(ctx context.Context, log *domain.O
 				log.Operation,
 				log.Action,
 			),
-			log.AdminID,
-			log.AdminName,
 		), nil
 	}
 
 	// 3. 检查连续登录失败
-	if log.Operation == "login" && log.Status == "failure" {
+	if log.Operation == domain.OpLogin && log.Status == domain.StatusFailed {
 		count, err := s.countRecentOperations(ctx, log.AdminID, "login:failure", 10*time.Minute)
 		if err == nil && count >= 5 {
 			return s.createAnomaly(
+				ctx,
+				log,
 				domain.AnomalousTypeLoginFailure,
 				domain.SeverityMedium,
 				fmt.Sprintf("管理员 %s 在10分钟内连续登录失败 %d 次", log.AdminName, count),
-				log.AdminID,
-				log.AdminName,
 			), nil
 		}
 	}
@@ -98,11 +237,11 @@ func (s *AuditService) CheckAnomalousActivity(ctx context.Context, log *domain.O
 		count, err := s.countRecentOperations(ctx, log.AdminID, "export", 1*time.Hour)
 		if err == nil && count >= 10 {
 			return s.createAnomaly(
+				ctx,
+				log,
 				domain.AnomalousTypeDataLeak,
 				domain.SeverityCritical,
 				fmt.Sprintf("管理员 %s 在1小时内导出数据 %d 次", log.AdminName, count),
-				log.AdminID,
-				log.AdminName,
 			), nil
 		}
 	}
@@ -142,37 +281,48 @@ func (s *AuditService) countRecentOperations(ctx context.Context, adminID, opera
 	return count, nil
 }
 
-// createAnomaly 创建异常活动记录
+// createAnomaly 创建异常活动记录并发送告警
+// 落库或告警失败只打日志，不影响触发异常的操作
 func (s *AuditService) createAnomaly(
-	anomalyType, severity, description, adminID, adminName string,
+	ctx context.Context, trigger *domain.OperationLog, anomalyType, severity, description string,
 ) *domain.AnomalousActivity {
+	details, _ := json.Marshal(trigger)
 	anomaly := &domain.AnomalousActivity{
-		ID:          fmt.Sprintf("anomaly-%d", time.Now().UnixNano()),
+		ID:          uuid.New().String(),
 		Type:        anomalyType,
 		Severity:    severity,
 		Description: description,
-		AdminID:     adminID,
-		AdminName:   adminName,
+		AdminID:     trigger.AdminID,
+		AdminName:   trigger.AdminName,
+		Details:     string(details),
 		Resolved:    false,
 		CreatedAt:   time.Now(),
 	}
 
-	// TODO: 实际应该写入数据库
-	// 这里简化实现，发布到Redis频道用于告警
-	ctx := context.Background()
-	anomalyJSON, _ := json.Marshal(anomaly)
-	s.redis.Publish(ctx, "admin:alerts", anomalyJSON)
+	if s.anomalies != nil {
+		if err := s.anomalies.Create(ctx, anomaly); err != nil {
+			log.Printf("Failed to persist anomaly %s: %v", anomaly.ID, err)
+			// 未落库的异常无法记录投递，只发布到Redis
+			s.publishAlert(ctx, anomaly)
+			return anomaly
+		}
+	}
 
+	if err := s.SendAlert(ctx, anomaly); err != nil {
+		log.Printf("Failed to send alert for anomaly %s: %v", anomaly.ID, err)
+	}
 	return anomaly
 }
 
 // isSensitiveOperation 判断是否为敏感操作
 func (s *AuditService) isSensitiveOperation(operation string) bool {
 	sensitiveOps := map[string]bool{
-		"user_management": true,
-		"config_update":   true,
-		"permission":      true,
-		"export":          true,
+		"user_management":          true,
+		domain.OpUpdateConfig:      true,
+		domain.OpRollbackConfig:    true,
+		domain.OpUpdateFeatureFlag: true,
+		"permission":               true,
+		"export":                   true,
 	}
 	return sensitiveOps[operation]
 }
@@ -189,13 +339,75 @@ func (s *AuditService) isWorkingHours(t time.Time) bool {
 	return hour >= 8 && hour < 22
 }
 
-// SendAlert 发送告警（可以集成到钉钉、Slack等）
+// SendAlert 发送告警：发布到Redis频道admin:alerts，并通过告警通道投递（alert需已落库）
 func (s *AuditService) SendAlert(ctx context.Context, alert *domain.AnomalousActivity) error {
-	// 发布到Redis频道
+	s.publishAlert(ctx, alert)
+	if s.notifier == nil || s.anomalies == nil {
+		return nil
+	}
+	return s.notifier.Notify(ctx, alert)
+}
+
+// publishAlert 发布到Redis频道（供管理后台实时展示）
+func (s *AuditService) publishAlert(ctx context.Context, alert *domain.AnomalousActivity) {
 	alertJSON, err := json.Marshal(alert)
 	if err != nil {
-		return err
+		return
+	}
+	if err := s.redis.Publish(ctx, "admin:alerts", alertJSON).Err(); err != nil {
+		log.Printf("Failed to publish alert %s: %v", alert.ID, err)
+	}
+}
+
+// ListAnomalies 分页查询异常活动，返回当前页和满足条件的总数
+func (s *AuditService) ListAnomalies(ctx context.Context, filter *domain.AnomalyFilter, page, pageSize int) ([]*domain.AnomalousActivity, int64, error) {
+	if s.anomalies == nil {
+		return nil, 0, ErrAnomalyPersistenceDisabled
+	}
+
+	total, err := s.anomalies.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	items, err := s.anomalies.List(ctx, filter, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	if items == nil {
+		items = []*domain.AnomalousActivity{}
+	}
+	return items, total, nil
+}
+
+// ResolveAnomaly 标记异常为已处理，停止升级并取消未完成的告警投递
+func (s *AuditService) ResolveAnomaly(ctx context.Context, id, adminID string) (*domain.AnomalousActivity, error) {
+	if s.anomalies == nil {
+		return nil, ErrAnomalyPersistenceDisabled
 	}
 
-	return s.redis.Publish(ctx, "admin:alerts", alertJSON).Err()
+	existing, err := s.anomalies.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, ErrAnomalyNotFound
+	}
+	if existing.Resolved {
+		return nil, ErrAnomalyResolved
+	}
+
+	resolved, err := s.anomalies.Resolve(ctx, id, adminID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if resolved == nil {
+		return nil, ErrAnomalyResolved // 并发处理
+	}
+
+	if s.notifier != nil {
+		if err := s.notifier.CancelPending(ctx, id); err != nil {
+			log.Printf("Failed to cancel pending alerts for anomaly %s: %v", id, err)
+		}
+	}
+	return resolved, nil
 }
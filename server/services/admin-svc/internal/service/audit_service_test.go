@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"admin-svc/internal/domain"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListOperationLogs_PagesPastRedisWindowFromRepository(t *testing.T) {
+	mr := miniredis.RunT(t)
+	logs := newMemoryOperationLogRepository()
+	audit := NewAuditService(redis.NewClient(&redis.Options{Addr: mr.Addr()}), logs, nil, nil)
+	ctx := context.Background()
+
+	base := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	for i := 0; i < 1200; i++ {
+		adminID := "admin-1"
+		if i%2 == 1 {
+			adminID = "admin-2"
+		}
+		require.NoError(t, logs.Append(ctx, &domain.OperationLog{
+			ID:        fmt.Sprintf("log-%04d", i),
+			AdminID:   adminID,
+			Operation: domain.OpLogin,
+			Resource:  "admin_user",
+			Action:    "login",
+			Status:    domain.StatusSuccess,
+			CreatedAt: base.Add(time.Duration(i) * time.Second),
+		}))
+	}
+
+	page, total, err := audit.ListOperationLogs(ctx, nil, 60, 20)
+	require.NoError(t, err)
+	assert.Equal(t, 1200, total)
+	require.Len(t, page, 20)
+	assert.Equal(t, "log-0019", page[0].ID)
+	assert.Equal(t, "log-0000", page[19].ID, "oldest log must be reachable beyond the last 1000 entries")
+
+	page, total, err = audit.ListOperationLogs(ctx, &domain.OperationLogFilter{AdminID: "admin-2"}, 1, 5)
+	require.NoError(t, err)
+	assert.Equal(t, 600, total)
+	require.Len(t, page, 5)
+	assert.Equal(t, "log-1199", page[0].ID)
+}
+
+func TestLogOperation_WritesChainOnly(t *testing.T) {
+	mr := miniredis.RunT(t)
+	logs := newMemoryOperationLogRepository()
+	audit := NewAuditService(redis.NewClient(&redis.Options{Addr: mr.Addr()}), logs, nil, nil)
+	ctx := context.Background()
+
+	require.NoError(t, audit.LogOperation(ctx, &domain.OperationLog{
+		ID:        "log-1",
+		AdminID:   "admin-1",
+		Operation: domain.OpLogin,
+		Resource:  "admin_user",
+		Action:    "login",
+		Status:    domain.StatusSuccess,
+		CreatedAt: time.Now(),
+	}))
+
+	assert.False(t, mr.Exists("audit:logs"), "logs must not be duplicated into the capped Redis list")
+	page, total, err := audit.ListOperationLogs(ctx, nil, 1, 20)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, page, 1)
+	assert.Equal(t, int64(1), page[0].Seq)
+}
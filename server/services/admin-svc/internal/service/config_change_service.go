@@ -0,0 +1,602 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"admin-svc/internal/domain"
+	"admin-svc/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/config"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/featureflag"
+)
+
+// maxConfigChangeItems 单次变更最多包含的配置项（Consul事务最多64个操作）
+const maxConfigChangeItems = 64
+
+// DefaultSensitiveConfigPrefixes 默认需要审批的配置键前缀：JWT/AES密钥、第三方API地址和密钥、短信凭证
+var DefaultSensitiveConfigPrefixes = []string{"common/", "api/", "sms/"}
+
+var (
+	// ErrConfigChangeNotFound 配置变更请求不存在
+	ErrConfigChangeNotFound = errors.New("config change not found")
+	// ErrConfigHistoryNotFound 配置历史记录不存在
+	ErrConfigHistoryNotFound = errors.New("config history not found")
+	// ErrInvalidConfigChange 变更内容不合法（校验不通过），使用%w包装具体原因
+	ErrInvalidConfigChange = errors.New("invalid config change")
+	// ErrConfigChangeNotPending 变更请求已被审批、拒绝或撤回
+	ErrConfigChangeNotPending = errors.New("config change is not pending")
+	// ErrSelfApproval 不能审批自己提交的变更
+	ErrSelfApproval = errors.New("cannot review own config change")
+	// ErrNotChangeOwner 只有提交人可以撤回变更
+	ErrNotChangeOwner = errors.New("only the submitter can cancel a config change")
+)
+
+// ConfigItemInput 提交的单个配置项变更
+type ConfigItemInput struct {
+	Key    string
+	Value  string
+	Delete bool
+}
+
+// ConfigChangePreview 变更预览（dry-run），不写入任何数据
+type ConfigChangePreview struct {
+	Items            []domain.ConfigChangeItem `json:"items"` // 凭证类配置已脱敏
+	RequiresApproval bool                      `json:"requires_approval"`
+	Valid            bool                      `json:"valid"`
+	Problems         []string                  `json:"problems,omitempty"` // 校验不通过的原因
+	Warnings         []string                  `json:"warnings,omitempty"` // 变更前就存在的问题，不阻止提交
+}
+
+// configStore 变更流程读取和原子写入配置的方式（由ConfigService实现）
+type configStore interface {
+	List(ctx context.Context, prefix string) ([]*ConfigItem, error)
+	ApplyCAS(ctx context.Context, writes []ConfigWrite) error
+}
+
+// ConfigChangeService 配置变更流程：校验 → 预览 → 审批（敏感配置） → CAS原子写入 → 历史记录，支持回滚到任意历史版本
+type ConfigChangeService struct {
+	configs           configStore
+	changes           repository.ConfigChangeRepository
+	histories         repository.ConfigHistoryRepository
+	audit             *AuditService
+	validator         *config.Validator
+	sensitivePrefixes []string
+	now               func() time.Time
+}
+
+// NewConfigChangeService 创建配置变更服务
+// sensitivePrefixes为空时使用DefaultSensitiveConfigPrefixes
+func NewConfigChangeService(
+	configs *ConfigService,
+	changes repository.ConfigChangeRepository,
+	histories repository.ConfigHistoryRepository,
+	audit *AuditService,
+	sensitivePrefixes []string,
+) *ConfigChangeService {
+	if len(sensitivePrefixes) == 0 {
+		sensitivePrefixes = DefaultSensitiveConfigPrefixes
+	}
+	return &ConfigChangeService{
+		configs:           configs,
+		changes:           changes,
+		histories:         histories,
+		audit:             audit,
+		validator:         config.NewValidator(),
+		sensitivePrefixes: sensitivePrefixes,
+		now:               time.Now,
+	}
+}
+
+// Preview 校验变更并返回与当前配置的差异，不写入任何数据
+func (s *ConfigChangeService) Preview(ctx context.Context, inputs []ConfigItemInput) (*ConfigChangePreview, error) {
+	current, err := s.snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.buildItems(current, inputs)
+	if err != nil {
+		return nil, err
+	}
+	problems, warnings := s.validate(current, items)
+
+	preview := &ConfigChangePreview{
+		Items:            make([]domain.ConfigChangeItem, len(items)),
+		RequiresApproval: requiresApproval(items),
+		Valid:            len(problems) == 0,
+		Problems:         problems,
+		Warnings:         warnings,
+	}
+	for i, item := range items {
+		preview.Items[i] = item.Masked()
+	}
+	return preview, nil
+}
+
+// Submit 提交变更：校验不通过时返回ErrInvalidConfigChange；
+// 包含敏感配置时等待另一位管理员审批，否则立即写入（写入冲突时返回ErrConfigConflict和失败的变更）
+func (s *ConfigChangeService) Submit(ctx context.Context, actor *domain.AdminActor, inputs []ConfigItemInput, reason string) (*domain.ConfigChange, error) {
+	return s.submit(ctx, actor, inputs, reason, "")
+}
+
+// Rollback 把配置回滚到某条历史记录写入后的值（该次变更是删除时删除配置）
+// 回滚按普通变更处理：需要通过校验，包含敏感配置时同样等待另一位管理员审批
+func (s *ConfigChangeService) Rollback(ctx context.Context, actor *domain.AdminActor, historyID, reason string) (*domain.ConfigChange, error) {
+	history, err := s.histories.Get(ctx, historyID)
+	if err != nil {
+		return nil, err
+	}
+	if history == nil {
+		return nil, ErrConfigHistoryNotFound
+	}
+	if !history.Rollbackable {
+		return nil, fmt.Errorf("%w: history %s is not rollbackable", ErrInvalidConfigChange, historyID)
+	}
+
+	input := ConfigItemInput{Key: history.ConfigKey, Delete: history.NewValue == nil}
+	if history.NewValue != nil {
+		input.Value = *history.NewValue
+	}
+	if reason == "" {
+		reason = fmt.Sprintf("rollback %s to version %d", history.ConfigKey, history.Version)
+	}
+	return s.submit(ctx, actor, []ConfigItemInput{input}, reason, history.ID)
+}
+
+// Approve 审批通过并写入Consul，提交人不能审批自己的变更
+// 写入前按当前配置重新校验；配置在提交后被修改过时变更失败（ErrConfigConflict），需要重新提交
+func (s *ConfigChangeService) Approve(ctx context.Context, actor *domain.AdminActor, id, comment string) (change *domain.ConfigChange, err error) {
+	start := s.now()
+	defer func() {
+		s.logConfigOperation(ctx, actor, change, domain.ActionApprove, start, err)
+	}()
+
+	change, err = s.reviewable(ctx, actor, id)
+	if err != nil {
+		return change, err
+	}
+
+	now := s.now()
+	change.Status = domain.ConfigChangeApproved
+	change.ReviewedBy = actor.AdminID
+	change.ReviewedByName = actor.AdminName
+	change.ReviewComment = comment
+	change.ReviewedAt = &now
+	change.UpdatedAt = now
+	ok, err := s.changes.Transition(ctx, change, domain.ConfigChangePending)
+	if err != nil {
+		return change, err
+	}
+	if !ok {
+		return change, ErrConfigChangeNotPending
+	}
+
+	return s.apply(ctx, actor, change)
+}
+
+// Reject 拒绝变更，提交人不能拒绝自己的变更（应使用Cancel撤回）
+func (s *ConfigChangeService) Reject(ctx context.Context, actor *domain.AdminActor, id, comment string) (change *domain.ConfigChange, err error) {
+	start := s.now()
+	defer func() {
+		s.logConfigOperation(ctx, actor, change, domain.ActionReject, start, err)
+	}()
+
+	change, err = s.reviewable(ctx, actor, id)
+	if err != nil {
+		return change, err
+	}
+
+	now := s.now()
+	change.Status = domain.ConfigChangeRejected
+	change.ReviewedBy = actor.AdminID
+	change.ReviewedByName = actor.AdminName
+	change.ReviewComment = comment
+	change.ReviewedAt = &now
+	change.UpdatedAt = now
+	return s.transition(ctx, change, domain.ConfigChangePending)
+}
+
+// Cancel 提交人撤回等待审批的变更
+func (s *ConfigChangeService) Cancel(ctx context.Context, actor *domain.AdminActor, id string) (change *domain.ConfigChange, err error) {
+	start := s.now()
+	defer func() {
+		s.logConfigOperation(ctx, actor, change, domain.ActionCancel, start, err)
+	}()
+
+	change, err = s.changes.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if change == nil {
+		return nil, ErrConfigChangeNotFound
+	}
+	if change.CreatedBy != actor.AdminID {
+		return change, ErrNotChangeOwner
+	}
+	if change.Status != domain.ConfigChangePending {
+		return change, ErrConfigChangeNotPending
+	}
+
+	change.Status = domain.ConfigChangeCancelled
+	change.UpdatedAt = s.now()
+	return s.transition(ctx, change, domain.ConfigChangePending)
+}
+
+// Get 获取变更请求（凭证类配置已脱敏）
+func (s *ConfigChangeService) Get(ctx context.Context, id string) (*domain.ConfigChange, error) {
+	change, err := s.changes.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if change == nil {
+		return nil, ErrConfigChangeNotFound
+	}
+	return change.Masked(), nil
+}
+
+// List 分页查询变更请求（凭证类配置已脱敏）
+func (s *ConfigChangeService) List(ctx context.Context, filter *domain.ConfigChangeFilter, page, pageSize int) ([]*domain.ConfigChange, int64, error) {
+	total, err := s.changes.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	changes, err := s.changes.List(ctx, filter, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i, change := range changes {
+		changes[i] = change.Masked()
+	}
+	return changes, total, nil
+}
+
+// ListHistory 分页查询配置历史，key为空时查询所有配置（凭证类配置已脱敏）
+func (s *ConfigChangeService) ListHistory(ctx context.Context, key string, page, pageSize int) ([]*domain.ConfigHistory, int64, error) {
+	key = domain.NormalizeConfigKey(key)
+	total, err := s.histories.Count(ctx, key)
+	if err != nil {
+		return nil, 0, err
+	}
+	histories, err := s.histories.List(ctx, key, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i, h := range histories {
+		h.Secret = isSecretConfigKey(h.ConfigKey)
+		histories[i] = h.Masked()
+	}
+	return histories, total, nil
+}
+
+// submit 校验并保存变更，无需审批时立即写入
+func (s *ConfigChangeService) submit(ctx context.Context, actor *domain.AdminActor, inputs []ConfigItemInput, reason, rollbackOf string) (change *domain.ConfigChange, err error) {
+	start := s.now()
+	defer func() {
+		s.logConfigOperation(ctx, actor, change, domain.ActionCreate, start, err)
+	}()
+
+	current, err := s.snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.buildItems(current, inputs)
+	if err != nil {
+		return nil, err
+	}
+	if problems, _ := s.validate(current, items); len(problems) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidConfigChange, strings.Join(problems, "; "))
+	}
+
+	now := s.now()
+	change = &domain.ConfigChange{
+		ID:               uuid.New().String(),
+		Items:            items,
+		Reason:           reason,
+		Status:           domain.ConfigChangePending,
+		RequiresApproval: requiresApproval(items),
+		RollbackOf:       rollbackOf,
+		CreatedBy:        actor.AdminID,
+		CreatedByName:    actor.AdminName,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	if !change.RequiresApproval {
+		change.Status = domain.ConfigChangeApproved
+	}
+	if err := s.changes.Create(ctx, change); err != nil {
+		return nil, err
+	}
+	if change.RequiresApproval {
+		return change, nil
+	}
+	return s.apply(ctx, actor, change)
+}
+
+// apply 把已审批的变更写入Consul并记录历史
+func (s *ConfigChangeService) apply(ctx context.Context, actor *domain.AdminActor, change *domain.ConfigChange) (*domain.ConfigChange, error) {
+	applyErr := s.recheck(ctx, change)
+	if applyErr == nil {
+		writes := make([]ConfigWrite, len(change.Items))
+		for i, item := range change.Items {
+			writes[i] = ConfigWrite{Key: item.Key, Value: item.NewValue, Index: item.ModifyIndex}
+		}
+		applyErr = s.configs.ApplyCAS(ctx, writes)
+	}
+
+	now := s.now()
+	change.UpdatedAt = now
+	if applyErr != nil {
+		change.Status = domain.ConfigChangeFailed
+		change.Error = applyErr.Error()
+	} else {
+		change.Status = domain.ConfigChangeApplied
+		change.AppliedAt = &now
+	}
+	if _, err := s.changes.Transition(ctx, change, domain.ConfigChangeApproved); err != nil {
+		// 配置已写入Consul时以写入结果为准，状态更新失败只记录日志
+		log.Printf("Failed to record result of config change %s: %v", change.ID, err)
+	}
+	if applyErr != nil {
+		return change, applyErr
+	}
+
+	for _, item := range change.Items {
+		history := &domain.ConfigHistory{
+			ID:           uuid.New().String(),
+			ConfigKey:    item.Key,
+			Action:       itemAction(item),
+			OldValue:     item.OldValue,
+			NewValue:     item.NewValue,
+			ChangeID:     change.ID,
+			AdminID:      change.CreatedBy,
+			AdminName:    change.CreatedByName,
+			Reason:       change.Reason,
+			Rollbackable: true,
+			CreatedAt:    now,
+		}
+		if err := s.histories.Create(ctx, history); err != nil {
+			log.Printf("Failed to save config history for %s (change %s): %v", item.Key, change.ID, err)
+		}
+	}
+	return change, nil
+}
+
+// recheck 写入前按当前配置重新校验：配置在提交后被修改过时返回ErrConfigConflict，
+// 其他配置变化导致整体校验不通过时返回ErrInvalidConfigChange
+func (s *ConfigChangeService) recheck(ctx context.Context, change *domain.ConfigChange) error {
+	current, err := s.snapshot(ctx)
+	if err != nil {
+		return err
+	}
+	for _, item := range change.Items {
+		var index uint64
+		if existing, ok := current[item.Key]; ok {
+			index = existing.Version
+		}
+		if index != item.ModifyIndex {
+			return fmt.Errorf("%w: %s", ErrConfigConflict, item.Key)
+		}
+	}
+	if problems, _ := s.validate(current, change.Items); len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidConfigChange, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// reviewable 获取等待审批且不是由actor提交的变更
+func (s *ConfigChangeService) reviewable(ctx context.Context, actor *domain.AdminActor, id string) (*domain.ConfigChange, error) {
+	change, err := s.changes.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if change == nil {
+		return nil, ErrConfigChangeNotFound
+	}
+	if change.Status != domain.ConfigChangePending {
+		return change, ErrConfigChangeNotPending
+	}
+	if change.CreatedBy == actor.AdminID {
+		return change, ErrSelfApproval
+	}
+	return change, nil
+}
+
+// transition 条件更新状态，状态已被其他请求修改时返回ErrConfigChangeNotPending
+func (s *ConfigChangeService) transition(ctx context.Context, change *domain.ConfigChange, from string) (*domain.ConfigChange, error) {
+	ok, err := s.changes.Transition(ctx, change, from)
+	if err != nil {
+		return change, err
+	}
+	if !ok {
+		return change, ErrConfigChangeNotPending
+	}
+	return change, nil
+}
+
+// snapshot 读取当前所有配置，键为去掉前缀的配置键
+func (s *ConfigChangeService) snapshot(ctx context.Context) (map[string]*ConfigItem, error) {
+	items, err := s.configs.List(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	current := make(map[string]*ConfigItem, len(items))
+	for _, item := range items {
+		if item.Key != "" && !strings.HasSuffix(item.Key, "/") {
+			current[item.Key] = item
+		}
+	}
+	return current, nil
+}
+
+// buildItems 规范化输入并记录每个配置项的当前值和ModifyIndex
+func (s *ConfigChangeService) buildItems(current map[string]*ConfigItem, inputs []ConfigItemInput) ([]domain.ConfigChangeItem, error) {
+	if len(inputs) == 0 {
+		return nil, fmt.Errorf("%w: no items", ErrInvalidConfigChange)
+	}
+	if len(inputs) > maxConfigChangeItems {
+		return nil, fmt.Errorf("%w: at most %d items per change", ErrInvalidConfigChange, maxConfigChangeItems)
+	}
+
+	seen := make(map[string]bool, len(inputs))
+	items := make([]domain.ConfigChangeItem, 0, len(inputs))
+	for _, in := range inputs {
+		key := domain.NormalizeConfigKey(in.Key)
+		if key == "" {
+			return nil, fmt.Errorf("%w: empty key", ErrInvalidConfigChange)
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("%w: duplicate key %s", ErrInvalidConfigChange, key)
+		}
+		if strings.HasPrefix(key, featureflag.KeyPrefix) {
+			return nil, fmt.Errorf("%w: %s is managed by the feature flag API", ErrInvalidConfigChange, key)
+		}
+		seen[key] = true
+
+		item := domain.ConfigChangeItem{
+			Key:       key,
+			Delete:    in.Delete,
+			Sensitive: s.isSensitive(key),
+			Secret:    isSecretConfigKey(key),
+		}
+		if existing, ok := current[key]; ok {
+			value := existing.Value
+			item.OldValue = &value
+			item.ModifyIndex = existing.Version
+		}
+		if !in.Delete {
+			value := in.Value
+			item.NewValue = &value
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// validate 校验变更：单个配置项的格式，以及变更后的业务配置整体是否合法
+// 变更前就存在的整体校验问题作为warnings返回，不阻止提交
+func (s *ConfigChangeService) validate(current map[string]*ConfigItem, items []domain.ConfigChangeItem) (problems, warnings []string) {
+	touchesBusiness := false
+	for _, item := range items {
+		switch {
+		case item.Delete && item.OldValue == nil:
+			problems = append(problems, fmt.Sprintf("%s does not exist", item.Key))
+		case item.Delete:
+		case item.OldValue != nil && *item.OldValue == *item.NewValue:
+			problems = append(problems, fmt.Sprintf("%s is unchanged", item.Key))
+		default:
+			if err := config.ValidateBusinessKey(item.Key, *item.NewValue); err != nil {
+				problems = append(problems, err.Error())
+			}
+		}
+		if config.IsBusinessKey(item.Key) {
+			touchesBusiness = true
+		}
+	}
+	if len(problems) > 0 || !touchesBusiness {
+		return problems, nil
+	}
+
+	before := make(map[string]string, len(current))
+	for key, item := range current {
+		before[key] = item.Value
+	}
+	after := make(map[string]string, len(before))
+	for key, value := range before {
+		after[key] = value
+	}
+	for _, item := range items {
+		if item.Delete {
+			delete(after, item.Key)
+		} else {
+			after[item.Key] = *item.NewValue
+		}
+	}
+
+	afterErr := s.validateBusiness(after)
+	if afterErr == nil {
+		return nil, nil
+	}
+	beforeErr := s.validateBusiness(before)
+	if beforeErr != nil && beforeErr.Error() == afterErr.Error() {
+		return nil, []string{"business config is already invalid: " + beforeErr.Error()}
+	}
+	return []string{"business config would be invalid: " + afterErr.Error()}, nil
+}
+
+// validateBusiness 按各服务加载配置的方式解析并校验业务配置
+func (s *ConfigChangeService) validateBusiness(values map[string]string) error {
+	cfg, err := config.ParseBusinessConfig(values)
+	if err != nil {
+		return err
+	}
+	return s.validator.ValidateBusiness(cfg)
+}
+
+// isSensitive 判断配置项是否需要审批
+func (s *ConfigChangeService) isSensitive(key string) bool {
+	for _, prefix := range s.sensitivePrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// logConfigOperation 记录配置变更操作，详情中的敏感配置值已脱敏
+func (s *ConfigChangeService) logConfigOperation(ctx context.Context, actor *domain.AdminActor, change *domain.ConfigChange, action string, start time.Time, opErr error) {
+	operation := domain.OpUpdateConfig
+	details := &domain.OperationDetails{}
+	resourceID := ""
+	if change != nil {
+		resourceID = change.ID
+		if change.RollbackOf != "" {
+			operation = domain.OpRollbackConfig
+		}
+		details.Before = make(map[string]interface{}, len(change.Items))
+		details.After = make(map[string]interface{}, len(change.Items))
+		for _, item := range change.Items {
+			masked := item.Masked()
+			details.Before[item.Key] = masked.OldValue
+			details.After[item.Key] = masked.NewValue
+		}
+		details.Reason = change.Reason
+		details.Extra = map[string]interface{}{"status": change.Status}
+		if change.RollbackOf != "" {
+			details.Extra["rollback_of"] = change.RollbackOf
+		}
+	}
+	s.audit.Record(ctx, actor, operation, domain.ResourceConfig, resourceID, action, details, start, opErr)
+}
+
+// requiresApproval 包含敏感配置项时需要审批
+func requiresApproval(items []domain.ConfigChangeItem) bool {
+	for _, item := range items {
+		if item.Sensitive {
+			return true
+		}
+	}
+	return false
+}
+
+// isSecretConfigKey 判断配置项是否为凭证（展示时脱敏）
+func isSecretConfigKey(key string) bool {
+	spec, ok := config.LookupBusinessKey(key)
+	return ok && spec.Secret
+}
+
+// itemAction 历史记录的动作类型
+func itemAction(item domain.ConfigChangeItem) string {
+	switch {
+	case item.Delete:
+		return domain.ActionDelete
+	case item.OldValue == nil:
+		return domain.ActionCreate
+	default:
+		return domain.ActionUpdate
+	}
+}
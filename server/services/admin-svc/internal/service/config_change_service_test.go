@@ -0,0 +1,403 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"admin-svc/internal/domain"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryConfigStore 内存配置存储，ModifyIndex与Consul一样全局递增
+type memoryConfigStore struct {
+	values      map[string]string
+	indexes     map[string]uint64
+	lastIndex   uint64
+	beforeApply func() // 在CAS检查之前调用，用于模拟并发写入
+}
+
+func newMemoryConfigStore(values map[string]string) *memoryConfigStore {
+	s := &memoryConfigStore{values: make(map[string]string), indexes: make(map[string]uint64)}
+	for key, value := range values {
+		s.set(key, value)
+	}
+	return s
+}
+
+func (s *memoryConfigStore) set(key, value string) {
+	s.lastIndex++
+	s.values[key] = value
+	s.indexes[key] = s.lastIndex
+}
+
+func (s *memoryConfigStore) List(ctx context.Context, prefix string) ([]*ConfigItem, error) {
+	var items []*ConfigItem
+	for key, value := range s.values {
+		if strings.HasPrefix(key, prefix) {
+			items = append(items, &ConfigItem{Key: key, Value: value, Version: s.indexes[key]})
+		}
+	}
+	return items, nil
+}
+
+func (s *memoryConfigStore) ApplyCAS(ctx context.Context, writes []ConfigWrite) error {
+	if s.beforeApply != nil {
+		s.beforeApply()
+	}
+	for _, w := range writes {
+		if s.indexes[w.Key] != w.Index {
+			return fmt.Errorf("%w: %s", ErrConfigConflict, w.Key)
+		}
+	}
+	for _, w := range writes {
+		if w.Value == nil {
+			delete(s.values, w.Key)
+			delete(s.indexes, w.Key)
+		} else {
+			s.set(w.Key, *w.Value)
+		}
+	}
+	return nil
+}
+
+// memoryConfigChangeRepository 内存配置变更仓储
+type memoryConfigChangeRepository struct {
+	changes          map[string]*domain.ConfigChange
+	beforeTransition func(id string) // 在条件更新之前调用，用于模拟并发审批
+}
+
+func copyConfigChange(c *domain.ConfigChange) *domain.ConfigChange {
+	copied := *c
+	copied.Items = append([]domain.ConfigChangeItem(nil), c.Items...)
+	return &copied
+}
+
+func (r *memoryConfigChangeRepository) Create(ctx context.Context, change *domain.ConfigChange) error {
+	r.changes[change.ID] = copyConfigChange(change)
+	return nil
+}
+
+func (r *memoryConfigChangeRepository) Get(ctx context.Context, id string) (*domain.ConfigChange, error) {
+	change, ok := r.changes[id]
+	if !ok {
+		return nil, nil
+	}
+	return copyConfigChange(change), nil
+}
+
+func (r *memoryConfigChangeRepository) List(ctx context.Context, filter *domain.ConfigChangeFilter, limit, offset int) ([]*domain.ConfigChange, error) {
+	var result []*domain.ConfigChange
+	for _, change := range r.changes {
+		if filter != nil && filter.Status != "" && change.Status != filter.Status {
+			continue
+		}
+		result = append(result, copyConfigChange(change))
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.After(result[j].CreatedAt) })
+	if offset >= len(result) {
+		return nil, nil
+	}
+	result = result[offset:]
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (r *memoryConfigChangeRepository) Count(ctx context.Context, filter *domain.ConfigChangeFilter) (int64, error) {
+	changes, err := r.List(ctx, filter, len(r.changes), 0)
+	return int64(len(changes)), err
+}
+
+func (r *memoryConfigChangeRepository) Transition(ctx context.Context, change *domain.ConfigChange, from string) (bool, error) {
+	if r.beforeTransition != nil {
+		r.beforeTransition(change.ID)
+	}
+	stored, ok := r.changes[change.ID]
+	if !ok || stored.Status != from {
+		return false, nil
+	}
+	r.changes[change.ID] = copyConfigChange(change)
+	return true, nil
+}
+
+// memoryConfigHistoryRepository 内存配置历史仓储
+type memoryConfigHistoryRepository struct {
+	histories []*domain.ConfigHistory
+}
+
+func (r *memoryConfigHistoryRepository) Create(ctx context.Context, h *domain.ConfigHistory) error {
+	var version int64
+	for _, existing := range r.histories {
+		if existing.ConfigKey == h.ConfigKey && existing.Version > version {
+			version = existing.Version
+		}
+	}
+	copied := *h
+	copied.Version = version + 1
+	r.histories = append(r.histories, &copied)
+	return nil
+}
+
+func (r *memoryConfigHistoryRepository) Get(ctx context.Context, id string) (*domain.ConfigHistory, error) {
+	for _, h := range r.histories {
+		if h.ID == id {
+			copied := *h
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryConfigHistoryRepository) List(ctx context.Context, configKey string, limit, offset int) ([]*domain.ConfigHistory, error) {
+	var result []*domain.ConfigHistory
+	for i := len(r.histories) - 1; i >= 0; i-- {
+		if configKey == "" || r.histories[i].ConfigKey == configKey {
+			copied := *r.histories[i]
+			result = append(result, &copied)
+		}
+	}
+	if offset >= len(result) {
+		return nil, nil
+	}
+	result = result[offset:]
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (r *memoryConfigHistoryRepository) Count(ctx context.Context, configKey string) (int64, error) {
+	histories, err := r.List(ctx, configKey, len(r.histories), 0)
+	return int64(len(histories)), err
+}
+
+const testJWTSecret = "0123456789abcdef0123456789abcdef"
+
+type configChangeTestEnv struct {
+	service   *ConfigChangeService
+	store     *memoryConfigStore
+	changes   *memoryConfigChangeRepository
+	histories *memoryConfigHistoryRepository
+	audit     *AuditService
+	submitter *domain.AdminActor
+	reviewer  *domain.AdminActor
+}
+
+func newConfigChangeTestEnv(t *testing.T) *configChangeTestEnv {
+	mr := miniredis.RunT(t)
+	env := &configChangeTestEnv{
+		store: newMemoryConfigStore(map[string]string{
+			"common/jwt_secret":  testJWTSecret,
+			"common/jwt_version": "1",
+			"ops/banner":         "hello",
+		}),
+		changes:   &memoryConfigChangeRepository{changes: make(map[string]*domain.ConfigChange)},
+		histories: &memoryConfigHistoryRepository{},
+		audit:     NewAuditService(redis.NewClient(&redis.Options{Addr: mr.Addr()}), nil, nil, nil),
+		submitter: &domain.AdminActor{AdminID: "admin-1", AdminName: "alice"},
+		reviewer:  &domain.AdminActor{AdminID: "admin-2", AdminName: "bob"},
+	}
+	env.service = NewConfigChangeService(nil, env.changes, env.histories, env.audit, nil)
+	env.service.configs = env.store
+	return env
+}
+
+// TestConfigChange_SelfApprovalRejected 测试提交人不能审批或拒绝自己的变更
+func TestConfigChange_SelfApprovalRejected(t *testing.T) {
+	env := newConfigChangeTestEnv(t)
+	ctx := context.Background()
+
+	change, err := env.service.Submit(ctx, env.submitter, []ConfigItemInput{{Key: "common/jwt_version", Value: "2"}}, "rotate")
+	require.NoError(t, err)
+	require.True(t, change.RequiresApproval)
+	require.Equal(t, domain.ConfigChangePending, change.Status)
+
+	_, err = env.service.Approve(ctx, env.submitter, change.ID, "lgtm")
+	assert.ErrorIs(t, err, ErrSelfApproval)
+	_, err = env.service.Reject(ctx, env.submitter, change.ID, "nope")
+	assert.ErrorIs(t, err, ErrSelfApproval)
+
+	assert.Equal(t, "1", env.store.values["common/jwt_version"])
+	assert.Equal(t, domain.ConfigChangePending, env.changes.changes[change.ID].Status)
+
+	approved, err := env.service.Approve(ctx, env.reviewer, change.ID, "lgtm")
+	require.NoError(t, err)
+	assert.Equal(t, domain.ConfigChangeApplied, approved.Status)
+	assert.Equal(t, "2", env.store.values["common/jwt_version"])
+}
+
+// TestConfigChange_ApproveLosesRace 测试审批时状态已被并发修改，不写入配置
+func TestConfigChange_ApproveLosesRace(t *testing.T) {
+	env := newConfigChangeTestEnv(t)
+	ctx := context.Background()
+
+	change, err := env.service.Submit(ctx, env.submitter, []ConfigItemInput{{Key: "common/jwt_version", Value: "2"}}, "rotate")
+	require.NoError(t, err)
+
+	// 另一位管理员在本次审批读取之后、条件更新之前拒绝了变更
+	env.changes.beforeTransition = func(id string) {
+		env.changes.beforeTransition = nil
+		env.changes.changes[id].Status = domain.ConfigChangeRejected
+	}
+
+	_, err = env.service.Approve(ctx, env.reviewer, change.ID, "lgtm")
+	assert.ErrorIs(t, err, ErrConfigChangeNotPending)
+	assert.Equal(t, domain.ConfigChangeRejected, env.changes.changes[change.ID].Status)
+	assert.Equal(t, "1", env.store.values["common/jwt_version"])
+	assert.Empty(t, env.histories.histories)
+}
+
+// TestConfigChange_ConflictMarksFailed 测试配置在提交后被修改时变更失败，不写入也不记录历史
+func TestConfigChange_ConflictMarksFailed(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(env *configChangeTestEnv)
+	}{
+		{
+			name: "modified before approval",
+			modify: func(env *configChangeTestEnv) {
+				env.store.set("common/jwt_version", "3")
+			},
+		},
+		{
+			name: "modified during apply",
+			modify: func(env *configChangeTestEnv) {
+				env.store.beforeApply = func() {
+					env.store.beforeApply = nil
+					env.store.set("common/jwt_version", "3")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newConfigChangeTestEnv(t)
+			ctx := context.Background()
+
+			change, err := env.service.Submit(ctx, env.submitter, []ConfigItemInput{{Key: "common/jwt_version", Value: "2"}}, "rotate")
+			require.NoError(t, err)
+			tt.modify(env)
+
+			failed, err := env.service.Approve(ctx, env.reviewer, change.ID, "lgtm")
+			assert.ErrorIs(t, err, ErrConfigConflict)
+			require.NotNil(t, failed)
+			assert.Equal(t, domain.ConfigChangeFailed, failed.Status)
+
+			stored := env.changes.changes[change.ID]
+			assert.Equal(t, domain.ConfigChangeFailed, stored.Status)
+			assert.Contains(t, stored.Error, "common/jwt_version")
+			assert.Equal(t, "3", env.store.values["common/jwt_version"])
+			assert.Empty(t, env.histories.histories)
+		})
+	}
+}
+
+// TestConfigChange_RollbackSensitiveRequiresApproval 测试回滚敏感配置同样需要审批，普通配置立即回滚
+func TestConfigChange_RollbackSensitiveRequiresApproval(t *testing.T) {
+	env := newConfigChangeTestEnv(t)
+	ctx := context.Background()
+
+	change, err := env.service.Submit(ctx, env.submitter, []ConfigItemInput{{Key: "common/jwt_version", Value: "2"}}, "rotate")
+	require.NoError(t, err)
+	_, err = env.service.Approve(ctx, env.reviewer, change.ID, "lgtm")
+	require.NoError(t, err)
+	require.Len(t, env.histories.histories, 1)
+
+	// 回滚到变更前的值：手工补一条初始版本的历史
+	initial := "1"
+	require.NoError(t, env.histories.Create(ctx, &domain.ConfigHistory{
+		ID: "history-initial", ConfigKey: "common/jwt_version", Action: domain.ActionCreate,
+		NewValue: &initial, Rollbackable: true, CreatedAt: time.Now(),
+	}))
+
+	rollback, err := env.service.Rollback(ctx, env.submitter, "history-initial", "")
+	require.NoError(t, err)
+	assert.True(t, rollback.RequiresApproval)
+	assert.Equal(t, domain.ConfigChangePending, rollback.Status)
+	assert.Equal(t, "history-initial", rollback.RollbackOf)
+	assert.Equal(t, "2", env.store.values["common/jwt_version"], "sensitive rollback must wait for approval")
+
+	_, err = env.service.Approve(ctx, env.submitter, rollback.ID, "")
+	assert.ErrorIs(t, err, ErrSelfApproval)
+
+	applied, err := env.service.Approve(ctx, env.reviewer, rollback.ID, "ok")
+	require.NoError(t, err)
+	assert.Equal(t, domain.ConfigChangeApplied, applied.Status)
+	assert.Equal(t, "1", env.store.values["common/jwt_version"])
+
+	// 非敏感配置的回滚立即写入
+	banner, err := env.service.Submit(ctx, env.submitter, []ConfigItemInput{{Key: "ops/banner", Value: "maintenance"}}, "notice")
+	require.NoError(t, err)
+	require.Equal(t, domain.ConfigChangeApplied, banner.Status)
+	bannerHistory := env.histories.histories[len(env.histories.histories)-1]
+	require.NoError(t, env.histories.Create(ctx, &domain.ConfigHistory{
+		ID: "history-banner", ConfigKey: "ops/banner", Action: domain.ActionCreate,
+		NewValue: bannerHistory.OldValue, Rollbackable: true, CreatedAt: time.Now(),
+	}))
+	restored, err := env.service.Rollback(ctx, env.submitter, "history-banner", "")
+	require.NoError(t, err)
+	assert.False(t, restored.RequiresApproval)
+	assert.Equal(t, domain.ConfigChangeApplied, restored.Status)
+	assert.Equal(t, "hello", env.store.values["ops/banner"])
+}
+
+// TestConfigChange_MasksSecrets 测试查询变更请求、历史和操作日志时凭证已脱敏
+func TestConfigChange_MasksSecrets(t *testing.T) {
+	env := newConfigChangeTestEnv(t)
+	ctx := context.Background()
+	newSecret := "fedcba9876543210fedcba9876543210"
+
+	change, err := env.service.Submit(ctx, env.submitter, []ConfigItemInput{{Key: "common/jwt_secret", Value: newSecret}}, "rotate")
+	require.NoError(t, err)
+
+	assertMasked := func(t *testing.T, oldValue, newValue *string) {
+		t.Helper()
+		require.NotNil(t, oldValue)
+		require.NotNil(t, newValue)
+		assert.Equal(t, "******cdef", *oldValue)
+		assert.Equal(t, "******3210", *newValue)
+	}
+
+	got, err := env.service.Get(ctx, change.ID)
+	require.NoError(t, err)
+	require.Len(t, got.Items, 1)
+	assertMasked(t, got.Items[0].OldValue, got.Items[0].NewValue)
+
+	list, total, err := env.service.List(ctx, nil, 1, 20)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, list, 1)
+	assertMasked(t, list[0].Items[0].OldValue, list[0].Items[0].NewValue)
+
+	// 仓储中保存的是明文，写入时使用
+	assert.Equal(t, newSecret, *env.changes.changes[change.ID].Items[0].NewValue)
+
+	_, err = env.service.Approve(ctx, env.reviewer, change.ID, "lgtm")
+	require.NoError(t, err)
+	assert.Equal(t, newSecret, env.store.values["common/jwt_secret"])
+
+	histories, total, err := env.service.ListHistory(ctx, "common/jwt_secret", 1, 20)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, histories, 1)
+	assert.True(t, histories[0].Secret)
+	assertMasked(t, histories[0].OldValue, histories[0].NewValue)
+
+	logs, _, err := env.audit.ListOperationLogs(ctx, nil, 1, 20)
+	require.NoError(t, err)
+	require.NotEmpty(t, logs)
+	for _, l := range logs {
+		assert.NotContains(t, string(l.Details), newSecret)
+		assert.NotContains(t, string(l.Details), testJWTSecret)
+	}
+}
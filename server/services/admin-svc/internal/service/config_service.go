@@ -3,10 +3,10 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/hashicorp/consul/api"
 	"github.com/redis/go-redis/v9"
 )
@@ -20,6 +20,13 @@ type ConfigService struct {
 	cacheTTL     time.Duration
 }
 
+var (
+	// ErrConfigNotFound 配置不存在
+	ErrConfigNotFound = errors.New("config key not found")
+	// ErrConfigConflict 配置在读取之后被其他变更修改（CAS失败）
+	ErrConfigConflict = errors.New("config was modified by another change")
+)
+
 // NewConfigService 创建配置服务
 func NewConfigService(
 	consulClient *api.Client,
@@ -45,20 +52,6 @@ type ConfigItem struct {
 	UpdatedBy   string    `json:"updated_by"`
 }
 
-// ConfigHistory 配置变更历史（存储在PostgreSQL）
-type ConfigHistory struct {
-	ID           string    `json:"id" db:"id"`
-	ConfigKey    string    `json:"config_key" db:"config_key"`
-	OldValue     string    `json:"old_value" db:"old_value"`
-	NewValue     string    `json:"new_value" db:"new_value"`
-	Version      int64     `json:"version" db:"version"`
-	AdminID      string    `json:"admin_id" db:"admin_id"`
-	AdminName    string    `json:"admin_name" db:"admin_name"`
-	Reason       string    `json:"reason" db:"reason"` // 变更原因
-	Rollbackable bool      `json:"rollbackable" db:"rollbackable"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
-}
-
 // Get 获取配置（带缓存）
 func (s *ConfigService) Get(ctx context.Context, key string) (string, error) {
 	// 1. 尝试从Redis缓存读取
@@ -86,32 +79,60 @@ func (s *ConfigService) Get(ctx context.Context, key string) (string, error) {
 	return value, nil
 }
 
-// Set 设置配置（写入Consul + 清除缓存）
-func (s *ConfigService) Set(ctx context.Context, key, value string) error {
-	fullKey := s.configPrefix + key
+// ConfigWrite 原子写入中的单个配置项
+type ConfigWrite struct {
+	Key   string
+	Value *string // nil表示删除
+	Index uint64  // 读取时的ModifyIndex，0表示要求配置不存在
+}
+
+// ApplyCAS 在一个Consul事务中写入多个配置项（写入Consul + 清除缓存 + 发布变更通知）
+// 任一配置项的ModifyIndex与读取时不同则整体不写入，返回ErrConfigConflict
+func (s *ConfigService) ApplyCAS(ctx context.Context, writes []ConfigWrite) error {
+	ops := make(api.KVTxnOps, 0, len(writes))
+	for _, w := range writes {
+		op := &api.KVTxnOp{Key: s.configPrefix + w.Key, Index: w.Index}
+		if w.Value == nil {
+			op.Verb = api.KVDeleteCAS
+		} else {
+			op.Verb = api.KVCAS
+			op.Value = []byte(*w.Value)
+		}
+		ops = append(ops, op)
+	}
 
 	// 1. 写入Consul
-	p := &api.KVPair{
-		Key:   fullKey,
-		Value: []byte(value),
+	ok, resp, _, err := s.consulClient.KV().Txn(ops, (&api.QueryOptions{}).WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("consul txn: %w", err)
 	}
-	if _, err := s.consulClient.KV().Put(p, nil); err != nil {
-		return fmt.Errorf("consul put %s: %w", fullKey, err)
+	if !ok {
+		if resp != nil && len(resp.Errors) > 0 {
+			return fmt.Errorf("%w: %s", ErrConfigConflict, resp.Errors[0].What)
+		}
+		return ErrConfigConflict
 	}
 
-	// 2. 清除Redis缓存
-	cacheKey := s.cachePrefix + key
-	_ = s.redisClient.Del(ctx, cacheKey).Err()
+	// 2. 清除Redis缓存并发布变更通知
+	for _, w := range writes {
+		s.notifyChange(ctx, w.Key, w.Value == nil)
+	}
+	return nil
+}
+
+// notifyChange 清除缓存并发布变更通知（Redis Pub/Sub）
+func (s *ConfigService) notifyChange(ctx context.Context, key string, deleted bool) {
+	_ = s.redisClient.Del(ctx, s.cachePrefix+key).Err()
 
-	// 3. 发布变更通知（Redis Pub/Sub）
 	notification := map[string]interface{}{
 		"key":       key,
 		"timestamp": time.Now().Unix(),
 	}
+	if deleted {
+		notification["deleted"] = true
+	}
 	data, _ := json.Marshal(notification)
 	_ = s.redisClient.Publish(ctx, "config:change", data).Err()
-
-	return nil
 }
 
 // GetWithVersion 获取配置及版本号
@@ -122,7 +143,7 @@ func (s *ConfigService) GetWithVersion(ctx context.Context, key string) (*Config
 		return nil, fmt.Errorf("consul get %s: %w", fullKey, err)
 	}
 	if pair == nil {
-		return nil, fmt.Errorf("config key not found: %s", key)
+		return nil, fmt.Errorf("%w: %s", ErrConfigNotFound, key)
 	}
 
 	return &ConfigItem{
@@ -156,50 +177,6 @@ func (s *ConfigService) List(ctx context.Context, prefix string) ([]*ConfigItem,
 	return items, nil
 }
 
-// Delete 删除配置
-func (s *ConfigService) Delete(ctx context.Context, key string) error {
-	fullKey := s.configPrefix + key
-
-	// 1. 从Consul删除
-	if _, err := s.consulClient.KV().Delete(fullKey, nil); err != nil {
-		return fmt.Errorf("consul delete %s: %w", fullKey, err)
-	}
-
-	// 2. 清除Redis缓存
-	cacheKey := s.cachePrefix + key
-	_ = s.redisClient.Del(ctx, cacheKey).Err()
-
-	// 3. 发布变更通知
-	notification := map[string]interface{}{
-		"key":       key,
-		"deleted":   true,
-		"timestamp": time.Now().Unix(),
-	}
-	data, _ := json.Marshal(notification)
-	_ = s.redisClient.Publish(ctx, "config:change", data).Err()
-
-	return nil
-}
-
-// SaveHistory 保存配置变更历史（由Repository层实际写入PostgreSQL）
-func (s *ConfigService) CreateHistory(
-	configKey, oldValue, newValue string,
-	adminID, adminName, reason string,
-) *ConfigHistory {
-	return &ConfigHistory{
-		ID:           uuid.New().String(),
-		ConfigKey:    configKey,
-		OldValue:     oldValue,
-		NewValue:     newValue,
-		Version:      time.Now().Unix(),
-		AdminID:      adminID,
-		AdminName:    adminName,
-		Reason:       reason,
-		Rollbackable: true,
-		CreatedAt:    time.Now(),
-	}
-}
-
 // WatchChanges 监听配置变更（阻塞）
 func (s *ConfigService) WatchChanges(ctx context.Context, callback func(key, value string)) error {
 	pubsub := s.redisClient.Subscribe(ctx, "config:change")
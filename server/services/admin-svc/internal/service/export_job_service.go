@@ -0,0 +1,709 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"admin-svc/internal/domain"
+	"admin-svc/internal/export"
+	"admin-svc/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// exportJobLease 任务租约，执行中每次更新进度时续约；实例崩溃后租约过期，任务由其他实例重新执行
+	exportJobLease = 2 * time.Minute
+	// exportProgressInterval 更新进度（并续约）的间隔
+	exportProgressInterval = 5 * time.Second
+	// exportWaitInterval 等待任务完成时查询任务状态的间隔
+	exportWaitInterval = time.Second
+	// exportPollInterval worker没有任务时轮询数据库的间隔（本实例入队的任务会立即唤醒worker）
+	exportPollInterval = 10 * time.Second
+	// maxExportAttempts 任务最多执行的次数（实例在执行中崩溃会重新执行）
+	maxExportAttempts = 3
+	// exportUserPageSize 导出终端用户时每次向auth-svc请求的条数（auth-svc上限100）
+	exportUserPageSize = 100
+	// exportCleanupBatch 每次清理的过期任务数
+	exportCleanupBatch = 100
+	// maxExportStatsDays 每日统计单次最多导出的天数，与统计接口一致
+	maxExportStatsDays = 366
+)
+
+var (
+	// ErrExportJobNotFound 导出任务不存在（或不属于当前管理员）
+	ErrExportJobNotFound = errors.New("export job not found")
+	// ErrInvalidExportRequest 导出类型、格式或条件无效
+	ErrInvalidExportRequest = errors.New("invalid export request")
+
+	// errExportJobLost 租约过期，任务已被其他实例领取
+	errExportJobLost = errors.New("export job lease lost")
+
+	exportPhonePrefixPattern = regexp.MustCompile(`^\d{3,11}$`)
+)
+
+// ExportJobConfig 导出任务配置
+type ExportJobConfig struct {
+	Workers   int           // 每个实例并发执行的任务数
+	TempDir   string        // 生成文件的临时目录
+	LinkTTL   time.Duration // 下载链接有效期
+	Retention time.Duration // 导出文件保留时长，过期后删除
+}
+
+// ExportJobService 异步导出任务
+// 导出请求只创建任务并立即返回，后台worker从数据库（或下游服务）流式读取数据写入临时文件，
+// 完成后上传到存储；任务状态和进度保存在数据库，多个实例通过SKIP LOCKED领取任务
+type ExportJobService struct {
+	jobs    repository.ExportJobRepository
+	logs    repository.OperationLogRepository
+	stats   *StatsService
+	users   *UserAdminService
+	audit   *AuditService
+	storage export.Storage
+	cfg     ExportJobConfig
+	now     func() time.Time
+
+	wake   chan struct{}
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewExportJobService 创建导出任务服务
+func NewExportJobService(
+	jobs repository.ExportJobRepository,
+	logs repository.OperationLogRepository,
+	stats *StatsService,
+	users *UserAdminService,
+	audit *AuditService,
+	storage export.Storage,
+	cfg ExportJobConfig,
+) *ExportJobService {
+	if cfg.Workers <= 0 {
+		cfg.Workers = 1
+	}
+	return &ExportJobService{
+		jobs:    jobs,
+		logs:    logs,
+		stats:   stats,
+		users:   users,
+		audit:   audit,
+		storage: storage,
+		cfg:     cfg,
+		now:     time.Now,
+		wake:    make(chan struct{}, cfg.Workers),
+	}
+}
+
+// Enqueue 创建导出任务，返回排队中的任务
+func (s *ExportJobService) Enqueue(ctx context.Context, actor *domain.AdminActor, exportType, format string, filters *domain.ExportFilters) (*domain.ExportJob, error) {
+	if filters == nil {
+		filters = &domain.ExportFilters{}
+	}
+	src, err := s.source(exportType)
+	if err != nil {
+		return nil, err
+	}
+	switch format {
+	case domain.ExportFormatCSV, domain.ExportFormatXLSX, domain.ExportFormatParquet:
+	default:
+		return nil, fmt.Errorf("%w: unsupported format %q", ErrInvalidExportRequest, format)
+	}
+	if err := src.validate(filters); err != nil {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(filters)
+	if err != nil {
+		return nil, fmt.Errorf("marshal filters: %w", err)
+	}
+	now := s.now()
+	job := &domain.ExportJob{
+		ID:        uuid.New().String(),
+		Type:      exportType,
+		Format:    format,
+		Filters:   raw,
+		Status:    domain.ExportStatusQueued,
+		CreatedBy: actor.AdminID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.jobs.Create(ctx, job); err != nil {
+		return nil, err
+	}
+	s.logExport(ctx, actor, job, src.resource)
+
+	// 唤醒空闲的worker，worker都在忙时等待轮询
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return job, nil
+}
+
+// Get 获取当前管理员创建的任务，成功的任务附带下载链接
+func (s *ExportJobService) Get(ctx context.Context, adminID, id string) (*domain.ExportJob, error) {
+	job, err := s.jobs.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil || job.CreatedBy != adminID {
+		return nil, ErrExportJobNotFound
+	}
+	s.attachURL(ctx, job)
+	return job, nil
+}
+
+// Wait 等待任务结束（成功、失败或过期），返回最终状态；ctx结束时返回ctx的错误
+func (s *ExportJobService) Wait(ctx context.Context, adminID, id string) (*domain.ExportJob, error) {
+	ticker := time.NewTicker(exportWaitInterval)
+	defer ticker.Stop()
+	for {
+		job, err := s.Get(ctx, adminID, id)
+		if err != nil {
+			return nil, err
+		}
+		switch job.Status {
+		case domain.ExportStatusSucceeded, domain.ExportStatusFailed, domain.ExportStatusExpired:
+			return job, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// List 分页列出当前管理员创建的任务
+func (s *ExportJobService) List(ctx context.Context, adminID string, page, pageSize int) ([]*domain.ExportJob, int64, error) {
+	total, err := s.jobs.CountByCreator(ctx, adminID)
+	if err != nil {
+		return nil, 0, err
+	}
+	jobs, err := s.jobs.ListByCreator(ctx, adminID, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	if jobs == nil {
+		jobs = []*domain.ExportJob{}
+	}
+	for _, job := range jobs {
+		s.attachURL(ctx, job)
+	}
+	return jobs, total, nil
+}
+
+// Start 启动后台worker
+func (s *ExportJobService) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	for i := 0; i < s.cfg.Workers; i++ {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.worker(ctx)
+		}()
+	}
+	log.Printf("Export workers started: %d", s.cfg.Workers)
+}
+
+// Stop 停止worker并等待退出
+// 正在执行的任务被中断，租约过期后由其他实例（或重启后的本实例）重新执行
+func (s *ExportJobService) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.wg.Wait()
+	log.Println("Export workers stopped")
+}
+
+// CleanupExpired 删除超过保留期限的导出文件，返回清理的任务数
+func (s *ExportJobService) CleanupExpired(ctx context.Context) (int, error) {
+	expired, err := s.jobs.ListExpired(ctx, s.now(), exportCleanupBatch)
+	if err != nil {
+		return 0, err
+	}
+	for i, job := range expired {
+		if err := s.storage.Delete(ctx, job.StorageKey); err != nil {
+			return i, err
+		}
+		if err := s.jobs.MarkExpired(ctx, job.ID, s.now()); err != nil {
+			return i, err
+		}
+	}
+	return len(expired), nil
+}
+
+func (s *ExportJobService) worker(ctx context.Context) {
+	for {
+		processed, err := s.processNext(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("Export worker error: %v", err)
+		}
+		if processed {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+		case <-time.After(exportPollInterval):
+		}
+	}
+}
+
+// processNext 领取并执行一个任务，没有可执行的任务时返回false
+func (s *ExportJobService) processNext(ctx context.Context) (bool, error) {
+	now := s.now()
+	job, err := s.jobs.ClaimNext(ctx, now, now.Add(exportJobLease))
+	if err != nil || job == nil {
+		return false, err
+	}
+
+	if job.Attempts > maxExportAttempts {
+		s.finish(ctx, job, fmt.Errorf("export interrupted %d times, giving up", maxExportAttempts))
+		return true, nil
+	}
+
+	start := s.now()
+	err = s.run(ctx, job)
+	switch {
+	case errors.Is(err, errExportJobLost):
+		log.Printf("Export job %s was taken over by another instance", job.ID)
+	case ctx.Err() != nil:
+		log.Printf("Export job %s interrupted by shutdown, will be retried after lease expires", job.ID)
+	default:
+		s.finish(ctx, job, err)
+		if err != nil {
+			log.Printf("Export job %s failed after %s: %v", job.ID, s.now().Sub(start), err)
+		} else {
+			log.Printf("Export job %s succeeded: type=%s format=%s rows=%d size=%d duration=%s",
+				job.ID, job.Type, job.Format, job.ProcessedRows, job.FileSize, s.now().Sub(start))
+		}
+	}
+	return true, nil
+}
+
+// run 生成导出文件并上传到存储
+func (s *ExportJobService) run(ctx context.Context, job *domain.ExportJob) error {
+	src, err := s.source(job.Type)
+	if err != nil {
+		return err
+	}
+	var filters domain.ExportFilters
+	if err := json.Unmarshal(job.Filters, &filters); err != nil {
+		return fmt.Errorf("unmarshal filters: %w", err)
+	}
+
+	job.ProcessedRows = 0
+	if job.TotalRows, err = src.count(ctx, &filters); err != nil {
+		return err
+	}
+	if err := s.heartbeat(ctx, job); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.cfg.TempDir, "export-*."+job.Format)
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	buf := bufio.NewWriterSize(tmp, 256<<10)
+	writer, err := export.NewWriter(job.Format, buf, src.sheet, src.columns)
+	if err != nil {
+		return err
+	}
+
+	lastProgress := s.now()
+	err = src.stream(ctx, &filters, func(row []interface{}) error {
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+		job.ProcessedRows++
+		if s.now().Sub(lastProgress) >= exportProgressInterval {
+			lastProgress = s.now()
+			return s.heartbeat(ctx, job)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	if err := buf.Flush(); err != nil {
+		return fmt.Errorf("flush export file: %w", err)
+	}
+	info, err := tmp.Stat()
+	if err != nil {
+		return fmt.Errorf("stat export file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close export file: %w", err)
+	}
+
+	// 上传前再续约一次，确认任务仍属于本次执行
+	if err := s.heartbeat(ctx, job); err != nil {
+		return err
+	}
+	key := fmt.Sprintf("%s_%s_%s.%s", job.Type, job.CreatedAt.Format("20060102150405"), job.ID[:8], job.Format)
+	if err := s.storage.Put(ctx, key, tmp.Name(), export.ContentType(job.Format)); err != nil {
+		return err
+	}
+
+	job.StorageKey = key
+	job.FileName = key
+	job.FileSize = info.Size()
+	return nil
+}
+
+// heartbeat 更新进度并续约
+func (s *ExportJobService) heartbeat(ctx context.Context, job *domain.ExportJob) error {
+	leaseUntil := s.now().Add(exportJobLease)
+	job.LeaseUntil = &leaseUntil
+	job.UpdatedAt = s.now()
+	ok, err := s.jobs.UpdateProgress(ctx, job)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errExportJobLost
+	}
+	return nil
+}
+
+// finish 记录任务结果，runErr为nil表示成功
+func (s *ExportJobService) finish(ctx context.Context, job *domain.ExportJob, runErr error) {
+	now := s.now()
+	job.FinishedAt = &now
+	job.UpdatedAt = now
+	job.LeaseUntil = nil
+	if runErr == nil {
+		expiresAt := now.Add(s.cfg.Retention)
+		job.Status = domain.ExportStatusSucceeded
+		job.ExpiresAt = &expiresAt
+		job.Error = ""
+	} else {
+		job.Status = domain.ExportStatusFailed
+		job.Error = runErr.Error()
+	}
+
+	ok, err := s.jobs.Finish(ctx, job)
+	if err != nil {
+		log.Printf("Failed to record export job %s result: %v", job.ID, err)
+		return
+	}
+	if !ok && runErr == nil {
+		// 租约已过期并被其他实例领取，本次生成的文件作废
+		log.Printf("Export job %s finished after losing its lease, discarding file", job.ID)
+		if err := s.storage.Delete(ctx, job.StorageKey); err != nil {
+			log.Printf("Failed to delete orphaned export file %s: %v", job.StorageKey, err)
+		}
+	}
+}
+
+// attachURL 为成功且未过期的任务生成下载链接
+func (s *ExportJobService) attachURL(ctx context.Context, job *domain.ExportJob) {
+	if job.Status != domain.ExportStatusSucceeded || job.StorageKey == "" {
+		return
+	}
+	ttl := s.cfg.LinkTTL
+	if job.ExpiresAt != nil {
+		if remaining := job.ExpiresAt.Sub(s.now()); remaining <= 0 {
+			return
+		} else if remaining < ttl {
+			ttl = remaining
+		}
+	}
+	url, err := s.storage.URL(ctx, job.StorageKey, ttl)
+	if err != nil {
+		log.Printf("Failed to sign download url for export job %s: %v", job.ID, err)
+		return
+	}
+	job.DownloadURL = url
+}
+
+// logExport 记录导出操作（导出操作参与异常检测）
+func (s *ExportJobService) logExport(ctx context.Context, actor *domain.AdminActor, job *domain.ExportJob, resource string) {
+	raw, _ := domain.MarshalDetails(&domain.OperationDetails{
+		Extra: map[string]interface{}{"type": job.Type, "format": job.Format, "filters": job.Filters},
+	})
+	entry := &domain.OperationLog{
+		ID:         uuid.New().String(),
+		AdminID:    actor.AdminID,
+		AdminName:  actor.AdminName,
+		Operation:  domain.OpExport,
+		Resource:   resource,
+		ResourceID: job.ID,
+		Action:     domain.ActionExport,
+		Details:    raw,
+		RequestID:  actor.RequestID,
+		IP:         actor.IP,
+		UserAgent:  actor.UserAgent,
+		Status:     domain.StatusSuccess,
+		CreatedAt:  s.now(),
+	}
+	if err := s.audit.LogOperation(ctx, entry); err != nil {
+		log.Printf("Failed to log export job %s: %v", job.ID, err)
+	}
+}
+
+// exportSource 一种导出类型的数据来源
+type exportSource struct {
+	resource string // 操作日志中的资源类型
+	sheet    string // XLSX工作表名称
+	columns  []export.Column
+	validate func(f *domain.ExportFilters) error
+	// count 估算总行数（用于显示进度）
+	count func(ctx context.Context, f *domain.ExportFilters) (int64, error)
+	// stream 逐行读取数据
+	stream func(ctx context.Context, f *domain.ExportFilters, emit func(row []interface{}) error) error
+}
+
+// source 返回导出类型对应的数据来源
+func (s *ExportJobService) source(exportType string) (*exportSource, error) {
+	switch exportType {
+	case domain.ExportTypeOperationLogs:
+		return s.operationLogSource(), nil
+	case domain.ExportTypeDailyStats:
+		return s.dailyStatsSource(), nil
+	case domain.ExportTypeUsers:
+		return s.userSource(), nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported type %q", ErrInvalidExportRequest, exportType)
+	}
+}
+
+// operationLogSource 操作日志：从PostgreSQL按创建时间顺序流式读取
+func (s *ExportJobService) operationLogSource() *exportSource {
+	toFilter := func(f *domain.ExportFilters) (*domain.OperationLogFilter, error) {
+		start, end, err := parseExportDates(f, false)
+		if err != nil {
+			return nil, err
+		}
+		if f.StartTime != nil {
+			start = *f.StartTime
+		}
+		if f.EndTime != nil {
+			end = *f.EndTime
+		}
+		if !start.IsZero() && !end.IsZero() && !end.After(start) {
+			return nil, fmt.Errorf("%w: end_time must be after start_time", ErrInvalidExportRequest)
+		}
+		return &domain.OperationLogFilter{
+			AdminID:   f.AdminID,
+			Operation: f.Operation,
+			Resource:  f.Resource,
+			Action:    f.Action,
+			Status:    f.Status,
+			StartTime: start,
+			EndTime:   end,
+		}, nil
+	}
+
+	return &exportSource{
+		resource: domain.ResourceAuditLog,
+		sheet:    "操作日志",
+		columns: []export.Column{
+			{Key: "id", Title: "ID", Type: export.String},
+			{Key: "seq", Title: "序号", Type: export.Int64},
+			{Key: "admin_id", Title: "管理员ID", Type: export.String},
+			{Key: "admin_name", Title: "管理员姓名", Type: export.String},
+			{Key: "operation", Title: "操作", Type: export.String},
+			{Key: "resource", Title: "资源", Type: export.String},
+			{Key: "resource_id", Title: "资源ID", Type: export.String},
+			{Key: "action", Title: "动作", Type: export.String},
+			{Key: "status", Title: "状态", Type: export.String},
+			{Key: "ip", Title: "IP地址", Type: export.String},
+			{Key: "user_agent", Title: "User Agent", Type: export.String},
+			{Key: "request_id", Title: "Request ID", Type: export.String},
+			{Key: "duration_ms", Title: "耗时(ms)", Type: export.Int64},
+			{Key: "error_msg", Title: "错误信息", Type: export.String},
+			{Key: "details", Title: "详情", Type: export.String},
+			{Key: "created_at", Title: "创建时间", Type: export.Time},
+		},
+		validate: func(f *domain.ExportFilters) error {
+			_, err := toFilter(f)
+			return err
+		},
+		count: func(ctx context.Context, f *domain.ExportFilters) (int64, error) {
+			filter, err := toFilter(f)
+			if err != nil {
+				return 0, err
+			}
+			return s.logs.Count(ctx, filter)
+		},
+		stream: func(ctx context.Context, f *domain.ExportFilters, emit func([]interface{}) error) error {
+			filter, err := toFilter(f)
+			if err != nil {
+				return err
+			}
+			return s.logs.Stream(ctx, filter, func(l *domain.OperationLog) error {
+				return emit([]interface{}{
+					l.ID, l.Seq, l.AdminID, l.AdminName, l.Operation, l.Resource, l.ResourceID,
+					l.Action, l.Status, l.IP, l.UserAgent, l.RequestID, l.Duration, l.ErrorMsg,
+					string(l.Details), l.CreatedAt,
+				})
+			})
+		},
+	}
+}
+
+// dailyStatsSource 每日统计：与统计接口相同，今天的数据来自Redis实时聚合
+func (s *ExportJobService) dailyStatsSource() *exportSource {
+	dates := func(f *domain.ExportFilters) (time.Time, time.Time, error) {
+		start, end, err := parseExportDates(f, true)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		if end.After(start.AddDate(0, 0, maxExportStatsDays)) {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: date range must not exceed %d days", ErrInvalidExportRequest, maxExportStatsDays)
+		}
+		return start, end.AddDate(0, 0, -1), nil
+	}
+
+	return &exportSource{
+		resource: domain.ResourceStats,
+		sheet:    "每日统计",
+		columns: []export.Column{
+			{Key: "date", Title: "日期", Type: export.String},
+			{Key: "total_users", Title: "总用户数", Type: export.Int64},
+			{Key: "new_users", Title: "新增用户", Type: export.Int64},
+			{Key: "active_users", Title: "活跃用户", Type: export.Int64},
+			{Key: "total_requests", Title: "总请求数", Type: export.Int64},
+			{Key: "success_requests", Title: "成功请求", Type: export.Int64},
+			{Key: "failed_requests", Title: "失败请求", Type: export.Int64},
+			{Key: "error_rate", Title: "错误率(%)", Type: export.Float64},
+			{Key: "avg_response_time_ms", Title: "平均响应时间(ms)", Type: export.Int64},
+			{Key: "total_favorites", Title: "总收藏数", Type: export.Int64},
+			{Key: "total_playlists", Title: "总歌单数", Type: export.Int64},
+			{Key: "total_plays", Title: "总播放次数", Type: export.Int64},
+			{Key: "logins", Title: "登录次数", Type: export.Int64},
+			{Key: "weekly_active_users", Title: "周活跃用户", Type: export.Int64},
+			{Key: "monthly_active_users", Title: "月活跃用户", Type: export.Int64},
+		},
+		validate: func(f *domain.ExportFilters) error {
+			_, _, err := dates(f)
+			return err
+		},
+		count: func(ctx context.Context, f *domain.ExportFilters) (int64, error) {
+			start, end, err := dates(f)
+			if err != nil {
+				return 0, err
+			}
+			return int64(math.Round(end.Sub(start).Hours()/24)) + 1, nil
+		},
+		stream: func(ctx context.Context, f *domain.ExportFilters, emit func([]interface{}) error) error {
+			start, end, err := dates(f)
+			if err != nil {
+				return err
+			}
+			daily, err := s.stats.GetDailyStatsRange(ctx, start, end)
+			if err != nil {
+				return err
+			}
+			for _, d := range daily {
+				err := emit([]interface{}{
+					d.Date.Format("2006-01-02"), d.TotalUsers, d.NewUsers, d.ActiveUsers,
+					d.TotalRequests, d.SuccessRequests, d.FailedRequests, math.Round(d.ErrorRate*100) / 100,
+					d.AvgResponseTime, d.TotalFavorites, d.TotalPlaylists, d.TotalPlays,
+					d.Logins, d.WeeklyActiveUsers, d.MonthlyActiveUsers,
+				})
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// userSource 终端用户：按手机号前缀分页从auth-svc读取（手机号已脱敏）
+func (s *ExportJobService) userSource() *exportSource {
+	return &exportSource{
+		resource: domain.ResourceUser,
+		sheet:    "终端用户",
+		columns: []export.Column{
+			{Key: "id", Title: "用户ID", Type: export.String},
+			{Key: "phone", Title: "手机号", Type: export.String},
+			{Key: "role", Title: "角色", Type: export.String},
+			{Key: "disabled", Title: "已禁用", Type: export.String},
+			{Key: "token_version", Title: "Token版本", Type: export.Int64},
+			{Key: "created_at", Title: "注册时间", Type: export.Time},
+			{Key: "last_login_at", Title: "最近登录时间", Type: export.Time},
+		},
+		validate: func(f *domain.ExportFilters) error {
+			if !exportPhonePrefixPattern.MatchString(f.PhonePrefix) {
+				return fmt.Errorf("%w: phone_prefix must be 3-11 digits", ErrInvalidExportRequest)
+			}
+			return nil
+		},
+		count: func(ctx context.Context, f *domain.ExportFilters) (int64, error) {
+			_, total, err := s.users.SearchUsers(ctx, f.PhonePrefix, 1, 1)
+			return int64(total), err
+		},
+		stream: func(ctx context.Context, f *domain.ExportFilters, emit func([]interface{}) error) error {
+			for page := 1; ; page++ {
+				users, _, err := s.users.SearchUsers(ctx, f.PhonePrefix, page, exportUserPageSize)
+				if err != nil {
+					return err
+				}
+				for _, u := range users {
+					var lastLogin interface{}
+					if u.LastLoginAt != nil {
+						lastLogin = *u.LastLoginAt
+					}
+					err := emit([]interface{}{
+						u.ID, u.Phone, u.Role, strconv.FormatBool(u.Disabled), int64(u.TokenVersion), u.CreatedAt, lastLogin,
+					})
+					if err != nil {
+						return err
+					}
+				}
+				if len(users) < exportUserPageSize {
+					return nil
+				}
+			}
+		},
+	}
+}
+
+// parseExportDates 解析start_date/end_date（YYYY-MM-DD，本地时区，包含两端），返回[start, end)
+// 未指定的一端为零值（不限制），required为true时两端都必须指定
+func parseExportDates(f *domain.ExportFilters, required bool) (time.Time, time.Time, error) {
+	if required && (f.StartDate == "" || f.EndDate == "") {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: start_date and end_date are required", ErrInvalidExportRequest)
+	}
+
+	var start, end time.Time
+	var err error
+	if f.StartDate != "" {
+		if start, err = time.ParseInLocation("2006-01-02", f.StartDate, time.Local); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: invalid start_date format", ErrInvalidExportRequest)
+		}
+	}
+	if f.EndDate != "" {
+		if end, err = time.ParseInLocation("2006-01-02", f.EndDate, time.Local); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: invalid end_date format", ErrInvalidExportRequest)
+		}
+		end = end.AddDate(0, 0, 1)
+	}
+	if !start.IsZero() && !end.IsZero() && !end.After(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: end_date must not be before start_date", ErrInvalidExportRequest)
+	}
+	return start, end, nil
+}
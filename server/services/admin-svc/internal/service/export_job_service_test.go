@@ -0,0 +1,425 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"admin-svc/internal/domain"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationLogSource_PreciseTimeRange(t *testing.T) {
+	svc := NewExportJobService(nil, newMemoryOperationLogRepository(), nil, nil, nil, nil, ExportJobConfig{})
+	src := svc.operationLogSource()
+
+	start := time.Date(2026, 3, 2, 10, 30, 0, 0, time.UTC)
+	end := start.Add(90 * time.Minute)
+	base := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	for _, offset := range []time.Duration{10 * time.Hour, 11 * time.Hour, 12 * time.Hour} {
+		require.NoError(t, svc.logs.Append(context.Background(), &domain.OperationLog{
+			ID:        offset.String(),
+			AdminID:   "admin-1",
+			Operation: domain.OpLogin,
+			Resource:  "admin_user",
+			Action:    "login",
+			Status:    domain.StatusSuccess,
+			CreatedAt: base.Add(offset),
+		}))
+	}
+
+	// 精确时间范围优先于日期
+	filters := &domain.ExportFilters{StartDate: "2026-03-01", EndDate: "2026-03-03", StartTime: &start, EndTime: &end}
+	count, err := src.count(context.Background(), filters)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	_, err = svc.Enqueue(context.Background(), &domain.AdminActor{AdminID: "admin-1"}, domain.ExportTypeOperationLogs, domain.ExportFormatCSV,
+		&domain.ExportFilters{StartTime: &end, EndTime: &start})
+	assert.ErrorIs(t, err, ErrInvalidExportRequest)
+}
+
+// memoryExportJobRepository 内存导出任务仓储，领取、续约和完成的条件与queries/export_job.sql一致
+type memoryExportJobRepository struct {
+	mu   sync.Mutex
+	jobs map[string]*domain.ExportJob
+}
+
+func newMemoryExportJobRepository() *memoryExportJobRepository {
+	return &memoryExportJobRepository{jobs: make(map[string]*domain.ExportJob)}
+}
+
+func (r *memoryExportJobRepository) Create(ctx context.Context, job *domain.ExportJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	copied := *job
+	r.jobs[job.ID] = &copied
+	return nil
+}
+
+func (r *memoryExportJobRepository) Get(ctx context.Context, id string) (*domain.ExportJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.jobs[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *job
+	return &copied, nil
+}
+
+func (r *memoryExportJobRepository) ListByCreator(ctx context.Context, createdBy string, limit, offset int) ([]*domain.ExportJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var result []*domain.ExportJob
+	for _, job := range r.jobs {
+		if job.CreatedBy == createdBy {
+			copied := *job
+			result = append(result, &copied)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.After(result[j].CreatedAt) })
+	if offset >= len(result) {
+		return nil, nil
+	}
+	result = result[offset:]
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (r *memoryExportJobRepository) CountByCreator(ctx context.Context, createdBy string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var count int64
+	for _, job := range r.jobs {
+		if job.CreatedBy == createdBy {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *memoryExportJobRepository) ClaimNext(ctx context.Context, now, leaseUntil time.Time) (*domain.ExportJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var next *domain.ExportJob
+	for _, job := range r.jobs {
+		claimable := job.Status == domain.ExportStatusQueued ||
+			(job.Status == domain.ExportStatusRunning && job.LeaseUntil != nil && job.LeaseUntil.Before(now))
+		if claimable && (next == nil || job.CreatedAt.Before(next.CreatedAt)) {
+			next = job
+		}
+	}
+	if next == nil {
+		return nil, nil
+	}
+	next.Status = domain.ExportStatusRunning
+	next.LeaseUntil = &leaseUntil
+	next.Attempts++
+	if next.StartedAt == nil {
+		next.StartedAt = &now
+	}
+	next.UpdatedAt = now
+	copied := *next
+	return &copied, nil
+}
+
+// owned 任务仍处于本次执行（状态为running且尝试次数一致）
+func (r *memoryExportJobRepository) owned(job *domain.ExportJob) (*domain.ExportJob, bool) {
+	stored, ok := r.jobs[job.ID]
+	if !ok || stored.Status != domain.ExportStatusRunning || stored.Attempts != job.Attempts {
+		return nil, false
+	}
+	return stored, true
+}
+
+func (r *memoryExportJobRepository) UpdateProgress(ctx context.Context, job *domain.ExportJob) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.owned(job)
+	if !ok {
+		return false, nil
+	}
+	stored.TotalRows, stored.ProcessedRows = job.TotalRows, job.ProcessedRows
+	stored.LeaseUntil, stored.UpdatedAt = job.LeaseUntil, job.UpdatedAt
+	return true, nil
+}
+
+func (r *memoryExportJobRepository) Finish(ctx context.Context, job *domain.ExportJob) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.owned(job); !ok {
+		return false, nil
+	}
+	copied := *job
+	copied.LeaseUntil = nil
+	r.jobs[job.ID] = &copied
+	return true, nil
+}
+
+func (r *memoryExportJobRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*domain.ExportJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var result []*domain.ExportJob
+	for _, job := range r.jobs {
+		if job.Status == domain.ExportStatusSucceeded && job.ExpiresAt != nil && job.ExpiresAt.Before(now) {
+			copied := *job
+			result = append(result, &copied)
+		}
+	}
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (r *memoryExportJobRepository) MarkExpired(ctx context.Context, id string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if job, ok := r.jobs[id]; ok && job.Status == domain.ExportStatusSucceeded {
+		job.Status = domain.ExportStatusExpired
+		job.UpdatedAt = at
+	}
+	return nil
+}
+
+// memoryExportStorage 内存导出文件存储
+type memoryExportStorage struct {
+	mu     sync.Mutex
+	files  map[string][]byte
+	putErr error
+}
+
+func newMemoryExportStorage() *memoryExportStorage {
+	return &memoryExportStorage{files: make(map[string][]byte)}
+}
+
+func (s *memoryExportStorage) Put(ctx context.Context, key, path, contentType string) error {
+	if s.putErr != nil {
+		return s.putErr
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.files[key] = data
+	return nil
+}
+
+func (s *memoryExportStorage) URL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	return "https://exports.test/" + key, nil
+}
+
+func (s *memoryExportStorage) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.files, key)
+	return nil
+}
+
+type exportJobFixture struct {
+	svc     *ExportJobService
+	jobs    *memoryExportJobRepository
+	storage *memoryExportStorage
+	now     time.Time
+}
+
+func newExportJobFixture(t *testing.T) *exportJobFixture {
+	mr := miniredis.RunT(t)
+	logs := newMemoryOperationLogRepository()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, logs.Append(context.Background(), &domain.OperationLog{
+			ID:        fmt.Sprintf("log-%d", i),
+			AdminID:   "admin-1",
+			Operation: domain.OpLogin,
+			Resource:  "admin_user",
+			Action:    "login",
+			Status:    domain.StatusSuccess,
+			CreatedAt: time.Date(2026, 3, 2, 10, i, 0, 0, time.UTC),
+		}))
+	}
+
+	f := &exportJobFixture{
+		jobs:    newMemoryExportJobRepository(),
+		storage: newMemoryExportStorage(),
+		now:     time.Date(2026, 3, 3, 9, 0, 0, 0, time.UTC),
+	}
+	audit := NewAuditService(redis.NewClient(&redis.Options{Addr: mr.Addr()}), nil, nil, nil)
+	f.svc = NewExportJobService(f.jobs, logs, nil, nil, audit, f.storage, ExportJobConfig{
+		TempDir:   t.TempDir(),
+		LinkTTL:   time.Hour,
+		Retention: 24 * time.Hour,
+	})
+	f.svc.now = func() time.Time { return f.now }
+	return f
+}
+
+func (f *exportJobFixture) enqueue(t *testing.T, adminID string) *domain.ExportJob {
+	job, err := f.svc.Enqueue(context.Background(), &domain.AdminActor{AdminID: adminID}, domain.ExportTypeOperationLogs, domain.ExportFormatCSV, nil)
+	require.NoError(t, err)
+	return job
+}
+
+func TestExportJobService_ReclaimsJobAfterLeaseExpires(t *testing.T) {
+	f := newExportJobFixture(t)
+	ctx := context.Background()
+	job := f.enqueue(t, "admin-1")
+
+	// 另一个实例领取后崩溃，租约未过期前不能被再次领取
+	crashed, err := f.jobs.ClaimNext(ctx, f.now, f.now.Add(exportJobLease))
+	require.NoError(t, err)
+	require.Equal(t, job.ID, crashed.ID)
+	processed, err := f.svc.processNext(ctx)
+	require.NoError(t, err)
+	assert.False(t, processed)
+
+	// 租约过期后由本实例重新执行
+	f.now = f.now.Add(exportJobLease + time.Second)
+	processed, err = f.svc.processNext(ctx)
+	require.NoError(t, err)
+	assert.True(t, processed)
+
+	got, err := f.svc.Get(ctx, "admin-1", job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ExportStatusSucceeded, got.Status)
+	assert.Equal(t, 2, got.Attempts)
+	assert.Equal(t, int64(3), got.ProcessedRows)
+	assert.Equal(t, "https://exports.test/"+got.FileName, got.DownloadURL)
+	assert.Contains(t, f.storage.files, got.FileName)
+
+	// 崩溃的执行恢复后不能再续约或覆盖结果
+	assert.ErrorIs(t, f.svc.heartbeat(ctx, crashed), errExportJobLost)
+	crashed.StorageKey = "orphan.csv"
+	f.storage.files["orphan.csv"] = []byte("stale")
+	f.svc.finish(ctx, crashed, nil)
+	assert.NotContains(t, f.storage.files, "orphan.csv")
+	got, err = f.svc.Get(ctx, "admin-1", job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, got.Attempts)
+	assert.NotEqual(t, "orphan.csv", got.StorageKey)
+}
+
+func TestExportJobService_GivesUpAfterMaxAttempts(t *testing.T) {
+	f := newExportJobFixture(t)
+	ctx := context.Background()
+	job := f.enqueue(t, "admin-1")
+
+	// 每次执行都在租约内崩溃
+	for i := 0; i < maxExportAttempts; i++ {
+		_, err := f.jobs.ClaimNext(ctx, f.now, f.now.Add(exportJobLease))
+		require.NoError(t, err)
+		f.now = f.now.Add(exportJobLease + time.Second)
+	}
+
+	processed, err := f.svc.processNext(ctx)
+	require.NoError(t, err)
+	assert.True(t, processed)
+
+	got, err := f.svc.Get(ctx, "admin-1", job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ExportStatusFailed, got.Status)
+	assert.Contains(t, got.Error, "giving up")
+	assert.Empty(t, got.DownloadURL)
+	assert.Empty(t, f.storage.files)
+
+	// 失败的任务不会再被领取
+	processed, err = f.svc.processNext(ctx)
+	require.NoError(t, err)
+	assert.False(t, processed)
+}
+
+func TestExportJobService_RecordsRunFailure(t *testing.T) {
+	f := newExportJobFixture(t)
+	ctx := context.Background()
+	f.storage.putErr = errors.New("bucket unavailable")
+	job := f.enqueue(t, "admin-1")
+
+	processed, err := f.svc.processNext(ctx)
+	require.NoError(t, err)
+	assert.True(t, processed)
+
+	got, err := f.svc.Get(ctx, "admin-1", job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ExportStatusFailed, got.Status)
+	assert.Equal(t, "bucket unavailable", got.Error)
+	assert.Equal(t, 1, got.Attempts)
+	assert.Nil(t, got.ExpiresAt)
+	assert.NotNil(t, got.FinishedAt)
+	assert.Empty(t, got.DownloadURL)
+
+	// Wait立即返回已结束的任务
+	waited, err := f.svc.Wait(ctx, "admin-1", job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ExportStatusFailed, waited.Status)
+}
+
+func TestExportJobService_ExpiresFilesAfterRetention(t *testing.T) {
+	f := newExportJobFixture(t)
+	ctx := context.Background()
+	job := f.enqueue(t, "admin-1")
+	_, err := f.svc.processNext(ctx)
+	require.NoError(t, err)
+	require.Len(t, f.storage.files, 1)
+
+	f.now = f.now.Add(25 * time.Hour)
+	cleaned, err := f.svc.CleanupExpired(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, cleaned)
+	assert.Empty(t, f.storage.files)
+
+	got, err := f.svc.Get(ctx, "admin-1", job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ExportStatusExpired, got.Status)
+	assert.Empty(t, got.DownloadURL)
+}
+
+func TestExportJobService_ScopesJobsToCreator(t *testing.T) {
+	f := newExportJobFixture(t)
+	ctx := context.Background()
+
+	var mine []*domain.ExportJob
+	for i := 0; i < 3; i++ {
+		mine = append(mine, f.enqueue(t, "admin-1"))
+		f.now = f.now.Add(time.Minute)
+	}
+	other := f.enqueue(t, "admin-2")
+
+	_, err := f.svc.Get(ctx, "admin-1", other.ID)
+	assert.ErrorIs(t, err, ErrExportJobNotFound)
+	_, err = f.svc.Get(ctx, "admin-2", mine[0].ID)
+	assert.ErrorIs(t, err, ErrExportJobNotFound)
+	_, err = f.svc.Get(ctx, "admin-1", "missing")
+	assert.ErrorIs(t, err, ErrExportJobNotFound)
+
+	jobs, total, err := f.svc.List(ctx, "admin-1", 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	require.Len(t, jobs, 2)
+	assert.Equal(t, mine[2].ID, jobs[0].ID, "newest first")
+	assert.Equal(t, mine[1].ID, jobs[1].ID)
+
+	jobs, total, err = f.svc.List(ctx, "admin-1", 2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, mine[0].ID, jobs[0].ID)
+
+	jobs, total, err = f.svc.List(ctx, "admin-3", 1, 20)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+	assert.NotNil(t, jobs)
+	assert.Empty(t, jobs)
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrInvalidExportLink 下载链接签名无效
+	ErrInvalidExportLink = errors.New("invalid export link")
+	// ErrExportLinkExpired 下载链接已过期
+	ErrExportLinkExpired = errors.New("export link expired")
+)
+
+// ExportLinkService 导出文件的下载链接签名服务
+// 链接形如 {baseURL}/exports/{filename}?expires=...&signature=...，签名为HMAC-SHA256(filename|expires)
+type ExportLinkService struct {
+	dir     string
+	baseURL string
+	key     []byte
+	now     func() time.Time
+}
+
+// NewExportLinkService 创建下载链接签名服务
+// key必须在多个实例和重启之间保持一致，否则其他实例签发的链接无法校验
+func NewExportLinkService(dir, baseURL, key string) *ExportLinkService {
+	return &ExportLinkService{
+		dir:     dir,
+		baseURL: baseURL,
+		key:     []byte(key),
+		now:     time.Now,
+	}
+}
+
+// FilePath 导出文件在本地的存储路径
+func (s *ExportLinkService) FilePath(filename string) string {
+	return filepath.Join(s.dir, filepath.Base(filename))
+}
+
+// SignedURL 生成有效期为ttl的下载链接
+func (s *ExportLinkService) SignedURL(filename string, ttl time.Duration) string {
+	expires := strconv.FormatInt(s.now().Add(ttl).Unix(), 10)
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", s.sign(filename, expires))
+	return fmt.Sprintf("%s/exports/%s?%s", s.baseURL, url.PathEscape(filename), query.Encode())
+}
+
+// Verify 校验下载链接的签名和有效期
+func (s *ExportLinkService) Verify(filename, expires, signature string) error {
+	expected, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, s.mac(filename, expires)) {
+		return ErrInvalidExportLink
+	}
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrInvalidExportLink
+	}
+	if s.now().Unix() > expiresAt {
+		return ErrExportLinkExpired
+	}
+	return nil
+}
+
+func (s *ExportLinkService) sign(filename, expires string) string {
+	return hex.EncodeToString(s.mac(filename, expires))
+}
+
+func (s *ExportLinkService) mac(filename, expires string) []byte {
+	h := hmac.New(sha256.New, s.key)
+	h.Write([]byte(filename + "|" + expires))
+	return h.Sum(nil)
+}
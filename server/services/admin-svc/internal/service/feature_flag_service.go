@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"admin-svc/internal/domain"
+	"admin-svc/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/featureflag"
+)
+
+var (
+	// ErrFeatureFlagNotFound 功能开关不存在
+	ErrFeatureFlagNotFound = errors.New("feature flag not found")
+	// ErrFeatureFlagExists 功能开关已存在
+	ErrFeatureFlagExists = errors.New("feature flag already exists")
+	// ErrInvalidFeatureFlag 功能开关定义不合法
+	ErrInvalidFeatureFlag = errors.New("invalid feature flag")
+)
+
+// FeatureFlagService 功能开关管理
+// 开关定义以JSON存储在Consul KV的flags/目录下，各服务通过shared/pkg/featureflag监听并在本地求值，
+// 修改后几秒内全部生效；写入使用CAS，并发修改同一个开关时后提交的返回ErrConfigConflict；
+// 每次修改同时记录到配置历史，与其他配置的变更一起查询
+type FeatureFlagService struct {
+	configs   *ConfigService
+	histories repository.ConfigHistoryRepository
+	audit     *AuditService
+	now       func() time.Time
+}
+
+// NewFeatureFlagService 创建功能开关服务
+func NewFeatureFlagService(configs *ConfigService, histories repository.ConfigHistoryRepository, audit *AuditService) *FeatureFlagService {
+	return &FeatureFlagService{
+		configs:   configs,
+		histories: histories,
+		audit:     audit,
+		now:       time.Now,
+	}
+}
+
+// List 列出所有功能开关（按key排序），无法解析的开关跳过并记录日志
+func (s *FeatureFlagService) List(ctx context.Context) ([]*featureflag.Flag, error) {
+	items, err := s.configs.List(ctx, featureflag.KeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	flags := make([]*featureflag.Flag, 0, len(items))
+	for _, item := range items {
+		key := strings.TrimPrefix(item.Key, featureflag.KeyPrefix)
+		if key == "" {
+			continue
+		}
+		flag, err := featureflag.DecodeFlag(key, []byte(item.Value), item.Version)
+		if err != nil {
+			log.Printf("Skipping malformed feature flag %s: %v", key, err)
+			continue
+		}
+		flags = append(flags, flag)
+	}
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Key < flags[j].Key })
+	return flags, nil
+}
+
+// Get 获取功能开关
+func (s *FeatureFlagService) Get(ctx context.Context, key string) (*featureflag.Flag, error) {
+	if !featureflag.ValidKey(key) {
+		return nil, ErrFeatureFlagNotFound
+	}
+	item, err := s.configs.GetWithVersion(ctx, featureflag.KeyPrefix+key)
+	if errors.Is(err, ErrConfigNotFound) {
+		return nil, ErrFeatureFlagNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	flag, err := featureflag.DecodeFlag(key, []byte(item.Value), item.Version)
+	if err != nil {
+		return nil, fmt.Errorf("decode feature flag %s: %w", key, err)
+	}
+	return flag, nil
+}
+
+// Create 创建功能开关，key已存在时返回ErrFeatureFlagExists
+func (s *FeatureFlagService) Create(ctx context.Context, actor *domain.AdminActor, flag *featureflag.Flag) (created *featureflag.Flag, err error) {
+	start := s.now()
+	defer func() {
+		s.logFlagOperation(ctx, actor, flag.Key, domain.ActionCreate, nil, created, start, err)
+	}()
+
+	if err := s.prepare(actor, flag); err != nil {
+		return nil, err
+	}
+	// ModifyIndex为0表示要求开关不存在
+	value, err := s.write(ctx, flag, 0)
+	if err != nil {
+		if errors.Is(err, ErrConfigConflict) {
+			return nil, ErrFeatureFlagExists
+		}
+		return nil, err
+	}
+	s.recordHistory(ctx, actor, flag.Key, domain.ActionCreate, nil, &value)
+	return s.Get(ctx, flag.Key)
+}
+
+// Update 修改功能开关（整体替换定义）
+// flag.Version为读取时的版本号，与当前版本不同时返回ErrConfigConflict；为0时覆盖当前版本
+func (s *FeatureFlagService) Update(ctx context.Context, actor *domain.AdminActor, flag *featureflag.Flag) (updated *featureflag.Flag, err error) {
+	start := s.now()
+	var before *featureflag.Flag
+	defer func() {
+		s.logFlagOperation(ctx, actor, flag.Key, domain.ActionUpdate, before, updated, start, err)
+	}()
+
+	before, err = s.Get(ctx, flag.Key)
+	if err != nil {
+		return nil, err
+	}
+	if flag.Version != 0 && flag.Version != before.Version {
+		return nil, ErrConfigConflict
+	}
+	if err := s.prepare(actor, flag); err != nil {
+		return nil, err
+	}
+	value, err := s.write(ctx, flag, before.Version)
+	if err != nil {
+		return nil, err
+	}
+	s.recordHistory(ctx, actor, flag.Key, domain.ActionUpdate, encodedFlag(before), &value)
+	return s.Get(ctx, flag.Key)
+}
+
+// Delete 删除功能开关，各服务随后对该开关返回调用方提供的默认值
+func (s *FeatureFlagService) Delete(ctx context.Context, actor *domain.AdminActor, key string) (err error) {
+	start := s.now()
+	var before *featureflag.Flag
+	defer func() {
+		s.logFlagOperation(ctx, actor, key, domain.ActionDelete, before, nil, start, err)
+	}()
+
+	before, err = s.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	if err := s.configs.ApplyCAS(ctx, []ConfigWrite{{Key: featureflag.KeyPrefix + key, Index: before.Version}}); err != nil {
+		return err
+	}
+	s.recordHistory(ctx, actor, key, domain.ActionDelete, encodedFlag(before), nil)
+	return nil
+}
+
+// Evaluate 按给定的用户、平台和版本求值（与各服务的求值结果一致），用于排查某个用户为什么命中或没有命中
+func (s *FeatureFlagService) Evaluate(ctx context.Context, key string, ec featureflag.Context) (*featureflag.Evaluation, error) {
+	flag, err := s.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	result := featureflag.Evaluate(flag, ec)
+	return &result, nil
+}
+
+// prepare 规范化并校验开关定义，记录修改人
+func (s *FeatureFlagService) prepare(actor *domain.AdminActor, flag *featureflag.Flag) error {
+	flag.Normalize()
+	if err := flag.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidFeatureFlag, err)
+	}
+	flag.UpdatedBy = actor.AdminName
+	flag.UpdatedAt = s.now()
+	return nil
+}
+
+// write 以CAS方式写入Consul（版本号由Consul的ModifyIndex表示，不写入JSON），返回写入的值
+func (s *FeatureFlagService) write(ctx context.Context, flag *featureflag.Flag, index uint64) (string, error) {
+	value := encodedFlag(flag)
+	if value == nil {
+		return "", fmt.Errorf("marshal feature flag %s", flag.Key)
+	}
+	if err := s.configs.ApplyCAS(ctx, []ConfigWrite{{Key: featureflag.KeyPrefix + flag.Key, Value: value, Index: index}}); err != nil {
+		return "", err
+	}
+	return *value, nil
+}
+
+// encodedFlag 开关在Consul中存储的JSON，无法编码时返回nil
+func encodedFlag(flag *featureflag.Flag) *string {
+	stored := *flag
+	stored.Version = 0
+	data, err := json.Marshal(&stored)
+	if err != nil {
+		return nil
+	}
+	value := string(data)
+	return &value
+}
+
+// recordHistory 把开关的修改记录到配置历史，写入失败只记录日志
+// 开关不能通过配置回滚恢复（会绕过开关定义的校验），需要重新编辑开关
+func (s *FeatureFlagService) recordHistory(ctx context.Context, actor *domain.AdminActor, key, action string, oldValue, newValue *string) {
+	history := &domain.ConfigHistory{
+		ID:           uuid.New().String(),
+		ConfigKey:    featureflag.KeyPrefix + key,
+		Action:       action,
+		OldValue:     oldValue,
+		NewValue:     newValue,
+		AdminID:      actor.AdminID,
+		AdminName:    actor.AdminName,
+		Reason:       "feature flag " + action,
+		Rollbackable: false,
+		CreatedAt:    s.now(),
+	}
+	if err := s.histories.Create(ctx, history); err != nil {
+		log.Printf("Failed to save config history for feature flag %s: %v", key, err)
+	}
+}
+
+// logFlagOperation 记录功能开关操作，详情为变更前后的开关快照
+func (s *FeatureFlagService) logFlagOperation(ctx context.Context, actor *domain.AdminActor, key, action string, before, after *featureflag.Flag, start time.Time, opErr error) {
+	details := &domain.OperationDetails{Before: flagSnapshot(before), After: flagSnapshot(after)}
+	s.audit.Record(ctx, actor, domain.OpUpdateFeatureFlag, domain.ResourceFeatureFlag, key, action, details, start, opErr)
+}
+
+// flagSnapshot 审计日志中记录的开关定义
+func flagSnapshot(flag *featureflag.Flag) map[string]interface{} {
+	if flag == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"type":            flag.Type,
+		"enabled":         flag.Enabled,
+		"value":           flag.Value,
+		"off_value":       flag.OffValue,
+		"rollout":         flag.Rollout,
+		"allow_users":     flag.AllowUsers,
+		"deny_users":      flag.DenyUsers,
+		"platforms":       flag.Platforms,
+		"min_app_version": flag.MinAppVersion,
+		"max_app_version": flag.MaxAppVersion,
+		"client":          flag.Client,
+	}
+}
@@ -0,0 +1,386 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"admin-svc/internal/domain"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/hashicorp/consul/api"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/featureflag"
+)
+
+// fakeConsulKV 内存Consul KV（只实现Get、List和事务CAS），ModifyIndex全局递增
+type fakeConsulKV struct {
+	mu        sync.Mutex
+	values    map[string][]byte
+	indexes   map[string]uint64
+	lastIndex uint64
+}
+
+func newFakeConsulKV(t *testing.T) (*fakeConsulKV, *api.Client) {
+	kv := &fakeConsulKV{values: make(map[string][]byte), indexes: make(map[string]uint64)}
+	server := httptest.NewServer(kv)
+	t.Cleanup(server.Close)
+	client, err := api.NewClient(&api.Config{Address: strings.TrimPrefix(server.URL, "http://")})
+	require.NoError(t, err)
+	return kv, client
+}
+
+func (kv *fakeConsulKV) set(key, value string) {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	kv.lastIndex++
+	kv.values[key] = []byte(value)
+	kv.indexes[key] = kv.lastIndex
+}
+
+func (kv *fakeConsulKV) get(key string) (string, bool) {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	value, ok := kv.values[key]
+	return string(value), ok
+}
+
+func (kv *fakeConsulKV) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	w.Header().Set("X-Consul-Index", strconv.FormatUint(kv.lastIndex, 10))
+	w.Header().Set("X-Consul-LastContact", "0")
+	w.Header().Set("X-Consul-KnownLeader", "true")
+
+	switch {
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v1/kv/"):
+		key := strings.TrimPrefix(r.URL.Path, "/v1/kv/")
+		var pairs api.KVPairs
+		for k, v := range kv.values {
+			if k == key || (r.URL.Query().Has("recurse") && strings.HasPrefix(k, key)) {
+				pairs = append(pairs, &api.KVPair{Key: k, Value: v, ModifyIndex: kv.indexes[k]})
+			}
+		}
+		if len(pairs) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		sort.Slice(pairs, func(i, j int) bool { return pairs[i].Key < pairs[j].Key })
+		_ = json.NewEncoder(w).Encode(pairs)
+	case r.Method == http.MethodPut && r.URL.Path == "/v1/txn":
+		var ops api.TxnOps
+		if err := json.NewDecoder(r.Body).Decode(&ops); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		for i, op := range ops {
+			if kv.indexes[op.KV.Key] != op.KV.Index {
+				w.WriteHeader(http.StatusConflict)
+				_ = json.NewEncoder(w).Encode(api.TxnResponse{Errors: api.TxnErrors{{OpIndex: i, What: "index mismatch for " + op.KV.Key}}})
+				return
+			}
+		}
+		for _, op := range ops {
+			if op.KV.Verb == api.KVDeleteCAS {
+				delete(kv.values, op.KV.Key)
+				delete(kv.indexes, op.KV.Key)
+				continue
+			}
+			kv.lastIndex++
+			kv.values[op.KV.Key] = op.KV.Value
+			kv.indexes[op.KV.Key] = kv.lastIndex
+		}
+		_ = json.NewEncoder(w).Encode(api.TxnResponse{})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+const testConfigPrefix = "listen-stream/"
+
+type featureFlagTestEnv struct {
+	service   *FeatureFlagService
+	kv        *fakeConsulKV
+	redis     *redis.Client
+	logs      *memoryOperationLogRepository
+	histories *memoryConfigHistoryRepository
+	actor     *domain.AdminActor
+}
+
+func newFeatureFlagTestEnv(t *testing.T) *featureFlagTestEnv {
+	mr := miniredis.RunT(t)
+	kv, consul := newFakeConsulKV(t)
+	env := &featureFlagTestEnv{
+		kv:        kv,
+		redis:     redis.NewClient(&redis.Options{Addr: mr.Addr()}),
+		logs:      newMemoryOperationLogRepository(),
+		histories: &memoryConfigHistoryRepository{},
+		actor:     &domain.AdminActor{AdminID: "admin-1", AdminName: "alice"},
+	}
+	configs := NewConfigService(consul, env.redis, testConfigPrefix)
+	audit := NewAuditService(env.redis, env.logs, nil, nil)
+	env.service = NewFeatureFlagService(configs, env.histories, audit)
+	return env
+}
+
+func newTestFlag(key string, rollout float64) *featureflag.Flag {
+	return &featureflag.Flag{
+		Key:     key,
+		Type:    featureflag.TypeBool,
+		Enabled: true,
+		Value:   json.RawMessage(`true`),
+		Rollout: rollout,
+	}
+}
+
+// TestFeatureFlag_CreateValidation 测试创建时校验开关定义（包括灰度比例范围），校验失败不写入但记录失败的操作日志
+func TestFeatureFlag_CreateValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(f *featureflag.Flag)
+	}{
+		{"invalid key", func(f *featureflag.Flag) { f.Key = "Bad Key" }},
+		{"invalid type", func(f *featureflag.Flag) { f.Type = "list" }},
+		{"value does not match type", func(f *featureflag.Flag) { f.Value = json.RawMessage(`"yes"`) }},
+		{"missing value", func(f *featureflag.Flag) { f.Value = nil }},
+		{"negative rollout", func(f *featureflag.Flag) { f.Rollout = -0.01 }},
+		{"rollout above 100", func(f *featureflag.Flag) { f.Rollout = 100.01 }},
+		{"user in allow and deny lists", func(f *featureflag.Flag) {
+			f.AllowUsers = []string{"user-1"}
+			f.DenyUsers = []string{"user-1"}
+		}},
+		{"min version above max version", func(f *featureflag.Flag) {
+			f.MinAppVersion = "2.0.0"
+			f.MaxAppVersion = "1.9.9"
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newFeatureFlagTestEnv(t)
+			flag := newTestFlag("player.lossless", 5)
+			tt.mutate(flag)
+
+			_, err := env.service.Create(context.Background(), env.actor, flag)
+			assert.ErrorIs(t, err, ErrInvalidFeatureFlag)
+			assert.Empty(t, env.kv.values)
+			assert.Empty(t, env.histories.histories)
+
+			logs := env.logs.sorted(nil)
+			require.Len(t, logs, 1)
+			assert.Equal(t, domain.OpUpdateFeatureFlag, logs[0].Operation)
+			assert.Equal(t, domain.ActionCreate, logs[0].Action)
+			assert.Equal(t, domain.StatusFailed, logs[0].Status)
+		})
+	}
+}
+
+// TestFeatureFlag_RolloutBounds 测试灰度比例的边界值0和100以及小数比例可以保存
+func TestFeatureFlag_RolloutBounds(t *testing.T) {
+	env := newFeatureFlagTestEnv(t)
+	ctx := context.Background()
+
+	for i, rollout := range []float64{0, 0.01, 5, 100} {
+		key := "rollout.case-" + strconv.Itoa(i)
+		created, err := env.service.Create(ctx, env.actor, newTestFlag(key, rollout))
+		require.NoError(t, err, "rollout %v", rollout)
+		assert.Equal(t, rollout, created.Rollout)
+	}
+
+	flags, err := env.service.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, flags, 4)
+}
+
+// TestFeatureFlag_CreateRecordsAuditAndHistory 测试创建成功时写入Consul、操作日志和配置历史，key已存在时返回ErrFeatureFlagExists
+func TestFeatureFlag_CreateRecordsAuditAndHistory(t *testing.T) {
+	env := newFeatureFlagTestEnv(t)
+	ctx := context.Background()
+
+	created, err := env.service.Create(ctx, env.actor, newTestFlag("player.lossless", 5))
+	require.NoError(t, err)
+	assert.NotZero(t, created.Version)
+	assert.Equal(t, "alice", created.UpdatedBy)
+
+	stored, ok := env.kv.get(testConfigPrefix + "flags/player.lossless")
+	require.True(t, ok)
+	assert.NotContains(t, stored, `"version"`, "version is the Consul ModifyIndex and must not be stored")
+
+	require.Len(t, env.histories.histories, 1)
+	history := env.histories.histories[0]
+	assert.Equal(t, "flags/player.lossless", history.ConfigKey)
+	assert.Equal(t, domain.ActionCreate, history.Action)
+	assert.Nil(t, history.OldValue)
+	require.NotNil(t, history.NewValue)
+	assert.JSONEq(t, stored, *history.NewValue)
+	assert.Equal(t, "admin-1", history.AdminID)
+	assert.False(t, history.Rollbackable)
+
+	logs := env.logs.sorted(nil)
+	require.Len(t, logs, 1)
+	assert.Equal(t, domain.ResourceFeatureFlag, logs[0].Resource)
+	assert.Equal(t, "player.lossless", logs[0].ResourceID)
+	assert.Equal(t, domain.StatusSuccess, logs[0].Status)
+	details, err := domain.UnmarshalDetails(logs[0].Details)
+	require.NoError(t, err)
+	assert.Nil(t, details.Before)
+	assert.Equal(t, float64(5), details.After["rollout"])
+
+	_, err = env.service.Create(ctx, env.actor, newTestFlag("player.lossless", 10))
+	assert.ErrorIs(t, err, ErrFeatureFlagExists)
+	assert.Len(t, env.histories.histories, 1)
+}
+
+// TestFeatureFlag_UpdateValidation 测试修改时的版本冲突、校验失败和开关不存在
+func TestFeatureFlag_UpdateValidation(t *testing.T) {
+	env := newFeatureFlagTestEnv(t)
+	ctx := context.Background()
+
+	created, err := env.service.Create(ctx, env.actor, newTestFlag("player.lossless", 5))
+	require.NoError(t, err)
+
+	stale := newTestFlag("player.lossless", 20)
+	stale.Version = created.Version + 100
+	_, err = env.service.Update(ctx, env.actor, stale)
+	assert.ErrorIs(t, err, ErrConfigConflict)
+
+	invalid := newTestFlag("player.lossless", 150)
+	invalid.Version = created.Version
+	_, err = env.service.Update(ctx, env.actor, invalid)
+	assert.ErrorIs(t, err, ErrInvalidFeatureFlag)
+
+	_, err = env.service.Update(ctx, env.actor, newTestFlag("player.missing", 20))
+	assert.ErrorIs(t, err, ErrFeatureFlagNotFound)
+
+	current, err := env.service.Get(ctx, "player.lossless")
+	require.NoError(t, err)
+	assert.Equal(t, float64(5), current.Rollout)
+	assert.Equal(t, created.Version, current.Version)
+	assert.Len(t, env.histories.histories, 1, "failed updates must not be recorded as config changes")
+
+	failed := 0
+	for _, log := range env.logs.sorted(nil) {
+		if log.Status == domain.StatusFailed {
+			failed++
+		}
+	}
+	assert.Equal(t, 3, failed)
+}
+
+// TestFeatureFlag_UpdateAndDeleteRecordHistory 测试修改和删除记录变更前后的定义
+func TestFeatureFlag_UpdateAndDeleteRecordHistory(t *testing.T) {
+	env := newFeatureFlagTestEnv(t)
+	ctx := context.Background()
+
+	created, err := env.service.Create(ctx, env.actor, newTestFlag("player.lossless", 5))
+	require.NoError(t, err)
+	before, _ := env.kv.get(testConfigPrefix + "flags/player.lossless")
+
+	update := newTestFlag("player.lossless", 20)
+	update.Version = created.Version
+	updated, err := env.service.Update(ctx, env.actor, update)
+	require.NoError(t, err)
+	assert.Equal(t, float64(20), updated.Rollout)
+	assert.Greater(t, updated.Version, created.Version)
+
+	require.NoError(t, env.service.Delete(ctx, env.actor, "player.lossless"))
+	_, err = env.service.Get(ctx, "player.lossless")
+	assert.ErrorIs(t, err, ErrFeatureFlagNotFound)
+	assert.ErrorIs(t, env.service.Delete(ctx, env.actor, "player.lossless"), ErrFeatureFlagNotFound)
+
+	require.Len(t, env.histories.histories, 3)
+	updateHistory := env.histories.histories[1]
+	assert.Equal(t, domain.ActionUpdate, updateHistory.Action)
+	require.NotNil(t, updateHistory.OldValue)
+	assert.JSONEq(t, before, *updateHistory.OldValue)
+	assert.Contains(t, *updateHistory.NewValue, `"rollout":20`)
+	assert.Equal(t, int64(2), updateHistory.Version)
+
+	deleteHistory := env.histories.histories[2]
+	assert.Equal(t, domain.ActionDelete, deleteHistory.Action)
+	assert.Contains(t, *deleteHistory.OldValue, `"rollout":20`)
+	assert.Nil(t, deleteHistory.NewValue)
+
+	logs := env.logs.sorted(nil)
+	require.Len(t, logs, 4)
+	details, err := domain.UnmarshalDetails(logs[1].Details)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ActionUpdate, logs[1].Action)
+	assert.Equal(t, float64(5), details.Before["rollout"])
+	assert.Equal(t, float64(20), details.After["rollout"])
+	assert.Equal(t, domain.ActionDelete, logs[2].Action)
+	assert.Equal(t, domain.StatusFailed, logs[3].Status)
+}
+
+// TestFeatureFlag_WritesInvalidateCache 测试写入后清除配置缓存并发布变更通知
+func TestFeatureFlag_WritesInvalidateCache(t *testing.T) {
+	env := newFeatureFlagTestEnv(t)
+	ctx := context.Background()
+	cacheKey := "config:cache:flags/player.lossless"
+
+	sub := env.redis.Subscribe(ctx, "config:change")
+	defer sub.Close()
+	_, err := sub.Receive(ctx)
+	require.NoError(t, err)
+	notification := func() map[string]interface{} {
+		msg, err := sub.ReceiveTimeout(ctx, time.Second)
+		require.NoError(t, err)
+		var payload map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(msg.(*redis.Message).Payload), &payload))
+		return payload
+	}
+
+	require.NoError(t, env.redis.Set(ctx, cacheKey, "stale", time.Minute).Err())
+	created, err := env.service.Create(ctx, env.actor, newTestFlag("player.lossless", 5))
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), env.redis.Exists(ctx, cacheKey).Val())
+	assert.Equal(t, "flags/player.lossless", notification()["key"])
+
+	require.NoError(t, env.redis.Set(ctx, cacheKey, "stale", time.Minute).Err())
+	update := newTestFlag("player.lossless", 20)
+	update.Version = created.Version
+	_, err = env.service.Update(ctx, env.actor, update)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), env.redis.Exists(ctx, cacheKey).Val())
+	assert.Equal(t, "flags/player.lossless", notification()["key"])
+
+	require.NoError(t, env.redis.Set(ctx, cacheKey, "stale", time.Minute).Err())
+	require.NoError(t, env.service.Delete(ctx, env.actor, "player.lossless"))
+	assert.Equal(t, int64(0), env.redis.Exists(ctx, cacheKey).Val())
+	payload := notification()
+	assert.Equal(t, "flags/player.lossless", payload["key"])
+	assert.Equal(t, true, payload["deleted"])
+
+	// 校验失败的写入不清除缓存
+	require.NoError(t, env.redis.Set(ctx, cacheKey, "cached", time.Minute).Err())
+	_, err = env.service.Create(ctx, env.actor, newTestFlag("player.lossless", 101))
+	assert.ErrorIs(t, err, ErrInvalidFeatureFlag)
+	assert.Equal(t, "cached", env.redis.Get(ctx, cacheKey).Val())
+}
+
+// TestFeatureFlag_ListSkipsMalformed 测试列表跳过无法解析的开关
+func TestFeatureFlag_ListSkipsMalformed(t *testing.T) {
+	env := newFeatureFlagTestEnv(t)
+	ctx := context.Background()
+
+	_, err := env.service.Create(ctx, env.actor, newTestFlag("player.lossless", 5))
+	require.NoError(t, err)
+	env.kv.set(testConfigPrefix+"flags/broken", "{not json")
+
+	flags, err := env.service.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, flags, 1)
+	assert.Equal(t, "player.lossless", flags[0].Key)
+
+	result, err := env.service.Evaluate(ctx, "player.lossless", featureflag.Context{UserID: "user-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "player.lossless", result.Key)
+}
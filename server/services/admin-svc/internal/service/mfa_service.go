@@ -0,0 +1,650 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"admin-svc/internal/domain"
+	"admin-svc/internal/repository"
+	"admin-svc/internal/webauthn"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/crypto"
+)
+
+const (
+	// webAuthnChallengeTTL 安全密钥注册和登录挑战的有效期
+	webAuthnChallengeTTL = 5 * time.Minute
+	// totpReplayTTL TOTP码使用后的锁定时间，覆盖验证时允许的前后时间窗口
+	totpReplayTTL = 90 * time.Second
+)
+
+var (
+	// ErrInvalidMFACode 验证码、备用恢复码或安全密钥验证失败
+	ErrInvalidMFACode = errors.New("invalid verification code")
+	// ErrInvalidPassword 密码错误（修改第二因素前需要重新输入密码）
+	ErrInvalidPassword = errors.New("invalid password")
+	// ErrTOTPAlreadyEnabled 已启用TOTP，重新绑定前需要先停用
+	ErrTOTPAlreadyEnabled = errors.New("totp is already enabled")
+	// ErrTOTPNotEnabled 未启用TOTP
+	ErrTOTPNotEnabled = errors.New("totp is not enabled")
+	// ErrTOTPNotPending 没有待验证的TOTP密钥（需要先调用启用接口）
+	ErrTOTPNotPending = errors.New("totp enrollment not started")
+	// ErrMFANotEnrolled 尚未绑定任何第二因素
+	ErrMFANotEnrolled = errors.New("no second factor enrolled")
+	// ErrLastSecondFactor 强制2FA时不能移除最后一个第二因素
+	ErrLastSecondFactor = errors.New("cannot remove the last second factor while 2fa is required")
+	// ErrWebAuthnDisabled 未配置WebAuthn依赖方
+	ErrWebAuthnDisabled = errors.New("webauthn is not configured")
+	// ErrWebAuthnChallengeExpired 挑战不存在或已过期
+	ErrWebAuthnChallengeExpired = errors.New("webauthn challenge expired")
+	// ErrCredentialNotFound 安全密钥不存在
+	ErrCredentialNotFound = errors.New("webauthn credential not found")
+	// ErrCredentialExists 安全密钥已经注册过
+	ErrCredentialExists = errors.New("webauthn credential already registered")
+)
+
+// MFAConfig 第二因素配置
+type MFAConfig struct {
+	Required      bool                   // 强制所有管理员绑定第二因素，未绑定时登录后只能访问绑定接口
+	BackupCodeKey []byte                 // 备用恢复码HMAC密钥，数据库泄露时无法离线穷举恢复码
+	WebAuthn      *webauthn.RelyingParty // 为nil时不支持安全密钥
+}
+
+// TOTPEnrollment 开始绑定TOTP时返回的密钥和二维码
+type TOTPEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+	QRCode          []byte `json:"qr_code"` // PNG
+}
+
+// MFAService 管理员第二因素：TOTP、WebAuthn安全密钥和备用恢复码
+// 绑定TOTP或第一个安全密钥时生成一组备用恢复码（只返回一次，数据库只保存HMAC），每个恢复码只能使用一次
+type MFAService struct {
+	admins      repository.AdminUserRepository
+	backupCodes repository.BackupCodeRepository
+	credentials repository.WebAuthnCredentialRepository
+	sessions    *AdminSessionService
+	totp        *TOTPService
+	redis       *redis.Client
+	audit       *AuditService
+	config      MFAConfig
+	now         func() time.Time
+}
+
+// NewMFAService 创建第二因素服务
+func NewMFAService(
+	admins repository.AdminUserRepository, backupCodes repository.BackupCodeRepository,
+	credentials repository.WebAuthnCredentialRepository, sessions *AdminSessionService,
+	totp *TOTPService, redisClient *redis.Client, audit *AuditService, config MFAConfig,
+) *MFAService {
+	return &MFAService{
+		admins:      admins,
+		backupCodes: backupCodes,
+		credentials: credentials,
+		sessions:    sessions,
+		totp:        totp,
+		redis:       redisClient,
+		audit:       audit,
+		config:      config,
+		now:         time.Now,
+	}
+}
+
+// Required 是否强制要求第二因素
+func (s *MFAService) Required() bool {
+	return s.config.Required
+}
+
+// Status 查询管理员的第二因素绑定情况
+func (s *MFAService) Status(ctx context.Context, adminID string) (*domain.MFAStatus, error) {
+	admin, err := s.getAdmin(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	creds, err := s.credentials.ListByAdmin(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	remaining, err := s.backupCodes.CountUnused(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	if creds == nil {
+		creds = []*domain.WebAuthnCredential{}
+	}
+	return &domain.MFAStatus{
+		Required:             s.config.Required,
+		TOTPEnabled:          admin.TOTPEnabled,
+		WebAuthnCredentials:  creds,
+		BackupCodesRemaining: remaining,
+	}, nil
+}
+
+// BeginTOTP 生成新的TOTP密钥（未启用状态），管理员用验证器扫码后调用ConfirmTOTP启用
+func (s *MFAService) BeginTOTP(ctx context.Context, actor *domain.AdminActor) (*TOTPEnrollment, error) {
+	admin, err := s.getAdmin(ctx, actor.AdminID)
+	if err != nil {
+		return nil, err
+	}
+	if admin.TOTPEnabled {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+
+	secret, err := s.totp.GenerateSecret(admin.Username)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.admins.UpdateTOTP(ctx, admin.ID, secret, false, s.now()); err != nil {
+		return nil, err
+	}
+
+	var qr bytes.Buffer
+	if err := s.totp.GenerateQRCode(admin.Username, secret, &qr); err != nil {
+		return nil, fmt.Errorf("generate qr code: %w", err)
+	}
+	return &TOTPEnrollment{
+		Secret:          secret,
+		ProvisioningURI: s.totp.GetProvisioningURI(admin.Username, secret),
+		QRCode:          qr.Bytes(),
+	}, nil
+}
+
+// ConfirmTOTP 验证验证器生成的第一个码并启用TOTP，当前会话随之标记为已验证第二因素
+// 管理员还没有备用恢复码时返回新生成的一组
+func (s *MFAService) ConfirmTOTP(ctx context.Context, actor *domain.AdminActor, code string) (backupCodes []string, err error) {
+	start := s.now()
+	defer func() {
+		s.logMFAOperation(ctx, actor, domain.ActionEnable, domain.MFAMethodTOTP, nil, start, err)
+	}()
+
+	admin, err := s.getAdmin(ctx, actor.AdminID)
+	if err != nil {
+		return nil, err
+	}
+	if admin.TOTPEnabled {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+	if admin.TOTPSecret == "" {
+		return nil, ErrTOTPNotPending
+	}
+	ok, err := s.checkTOTP(ctx, admin.ID, admin.TOTPSecret, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+
+	if err := s.admins.UpdateTOTP(ctx, admin.ID, admin.TOTPSecret, true, s.now()); err != nil {
+		return nil, err
+	}
+	return s.enrolled(ctx, actor, domain.MFAMethodTOTP)
+}
+
+// DisableTOTP 停用TOTP，需要密码和当前的TOTP码（或备用恢复码）
+func (s *MFAService) DisableTOTP(ctx context.Context, actor *domain.AdminActor, password, code string) (err error) {
+	start := s.now()
+	defer func() {
+		s.logMFAOperation(ctx, actor, domain.ActionDisable, domain.MFAMethodTOTP, nil, start, err)
+	}()
+
+	admin, err := s.getAdmin(ctx, actor.AdminID)
+	if err != nil {
+		return err
+	}
+	if !checkPassword(admin, password) {
+		return ErrInvalidPassword
+	}
+	if !admin.TOTPEnabled {
+		return ErrTOTPNotEnabled
+	}
+	if _, err := s.VerifyCode(ctx, admin, "", code); err != nil {
+		return err
+	}
+
+	creds, err := s.credentials.ListByAdmin(ctx, admin.ID)
+	if err != nil {
+		return err
+	}
+	if s.config.Required && len(creds) == 0 {
+		return ErrLastSecondFactor
+	}
+	if err := s.admins.UpdateTOTP(ctx, admin.ID, "", false, s.now()); err != nil {
+		return err
+	}
+	if len(creds) == 0 {
+		// 没有第二因素后备用恢复码也没有意义，重新绑定时生成新的一组
+		return s.backupCodes.DeleteAll(ctx, admin.ID)
+	}
+	return nil
+}
+
+// RegenerateBackupCodes 重新生成备用恢复码（之前的全部作废），需要密码
+func (s *MFAService) RegenerateBackupCodes(ctx context.Context, actor *domain.AdminActor, password string) (codes []string, err error) {
+	start := s.now()
+	defer func() {
+		s.logMFAOperation(ctx, actor, domain.ActionCreate, domain.MFAMethodBackupCode, map[string]interface{}{"count": len(codes)}, start, err)
+	}()
+
+	admin, err := s.getAdmin(ctx, actor.AdminID)
+	if err != nil {
+		return nil, err
+	}
+	if !checkPassword(admin, password) {
+		return nil, ErrInvalidPassword
+	}
+	enrolled, err := s.hasSecondFactor(ctx, admin)
+	if err != nil {
+		return nil, err
+	}
+	if !enrolled {
+		return nil, ErrMFANotEnrolled
+	}
+	return s.generateBackupCodes(ctx, admin.ID)
+}
+
+// BeginWebAuthnRegistration 生成注册安全密钥的参数（传给navigator.credentials.create()）
+func (s *MFAService) BeginWebAuthnRegistration(ctx context.Context, actor *domain.AdminActor) (*webauthn.CreationOptions, error) {
+	if s.config.WebAuthn == nil {
+		return nil, ErrWebAuthnDisabled
+	}
+	admin, err := s.getAdmin(ctx, actor.AdminID)
+	if err != nil {
+		return nil, err
+	}
+	creds, err := s.credentials.ListByAdmin(ctx, admin.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, session, err := s.config.WebAuthn.BeginRegistration(webAuthnUser(admin, creds))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.saveSession(ctx, registrationChallengeKey(admin.ID), session); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
+// FinishWebAuthnRegistration 校验浏览器返回的注册数据并保存安全密钥，当前会话随之标记为已验证第二因素
+// 管理员还没有备用恢复码时返回新生成的一组
+func (s *MFAService) FinishWebAuthnRegistration(
+	ctx context.Context, actor *domain.AdminActor, name string, resp webauthn.AttestationResponse,
+) (cred *domain.WebAuthnCredential, backupCodes []string, err error) {
+	start := s.now()
+	defer func() {
+		s.logMFAOperation(ctx, actor, domain.ActionCreate, domain.MFAMethodWebAuthn, map[string]interface{}{"name": name}, start, err)
+	}()
+
+	if s.config.WebAuthn == nil {
+		return nil, nil, ErrWebAuthnDisabled
+	}
+	session, err := s.takeSession(ctx, registrationChallengeKey(actor.AdminID))
+	if err != nil {
+		return nil, nil, err
+	}
+	admin, err := s.getAdmin(ctx, actor.AdminID)
+	if err != nil {
+		return nil, nil, err
+	}
+	creds, err := s.credentials.ListByAdmin(ctx, admin.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	verified, err := s.config.WebAuthn.FinishRegistration(webAuthnUser(admin, creds), session, resp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	id := base64.RawURLEncoding.EncodeToString(verified.ID)
+	existing, err := s.credentials.Get(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if existing != nil {
+		return nil, nil, ErrCredentialExists
+	}
+	if name = strings.TrimSpace(name); name == "" {
+		name = "Security key"
+	}
+	cred = &domain.WebAuthnCredential{
+		ID:             id,
+		AdminID:        actor.AdminID,
+		Name:           name,
+		PublicKey:      verified.PublicKey,
+		Algorithm:      verified.Algorithm,
+		SignCount:      verified.SignCount,
+		BackupEligible: verified.BackupEligible,
+		CreatedAt:      s.now(),
+	}
+	if err := s.credentials.Create(ctx, cred); err != nil {
+		return nil, nil, err
+	}
+
+	backupCodes, err = s.enrolled(ctx, actor, domain.MFAMethodWebAuthn)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cred, backupCodes, nil
+}
+
+// DeleteWebAuthnCredential 移除安全密钥，需要密码
+func (s *MFAService) DeleteWebAuthnCredential(ctx context.Context, actor *domain.AdminActor, id, password string) (err error) {
+	start := s.now()
+	defer func() {
+		s.logMFAOperation(ctx, actor, domain.ActionDelete, domain.MFAMethodWebAuthn, map[string]interface{}{"credential_id": id}, start, err)
+	}()
+
+	admin, err := s.getAdmin(ctx, actor.AdminID)
+	if err != nil {
+		return err
+	}
+	if !checkPassword(admin, password) {
+		return ErrInvalidPassword
+	}
+	creds, err := s.credentials.ListByAdmin(ctx, admin.ID)
+	if err != nil {
+		return err
+	}
+	remaining := len(creds) - 1
+	if s.config.Required && !admin.TOTPEnabled && remaining < 1 {
+		return ErrLastSecondFactor
+	}
+
+	ok, err := s.credentials.Delete(ctx, admin.ID, id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrCredentialNotFound
+	}
+	if !admin.TOTPEnabled && remaining < 1 {
+		return s.backupCodes.DeleteAll(ctx, admin.ID)
+	}
+	return nil
+}
+
+// Methods 返回管理员登录时可以使用的第二因素，为空表示尚未绑定
+// 已绑定的安全密钥无论是否配置了WebAuthn依赖方都计入，未配置时只能使用其他方式，
+// 没有其他方式可用时返回ErrWebAuthnDisabled（拒绝登录，不能退化为只校验密码）
+func (s *MFAService) Methods(ctx context.Context, admin *domain.AdminUser) ([]string, error) {
+	var methods []string
+	if admin.TOTPEnabled {
+		methods = append(methods, domain.MFAMethodTOTP)
+	}
+	creds, err := s.credentials.ListByAdmin(ctx, admin.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(creds) > 0 && s.config.WebAuthn != nil {
+		methods = append(methods, domain.MFAMethodWebAuthn)
+	}
+	if len(methods) > 0 || len(creds) > 0 {
+		remaining, err := s.backupCodes.CountUnused(ctx, admin.ID)
+		if err != nil {
+			return nil, err
+		}
+		if remaining > 0 {
+			methods = append(methods, domain.MFAMethodBackupCode)
+		}
+	}
+	if len(methods) == 0 && len(creds) > 0 {
+		return nil, ErrWebAuthnDisabled
+	}
+	return methods, nil
+}
+
+// VerifyCode 校验TOTP码或备用恢复码，返回实际使用的方式
+// method为空时按格式判断：6位数字为TOTP码，其他为备用恢复码
+func (s *MFAService) VerifyCode(ctx context.Context, admin *domain.AdminUser, method, code string) (string, error) {
+	code = normalizeCode(code)
+	if method == "" {
+		method = domain.MFAMethodBackupCode
+		if len(code) == 6 {
+			method = domain.MFAMethodTOTP
+		}
+	}
+
+	switch method {
+	case domain.MFAMethodTOTP:
+		if !admin.TOTPEnabled {
+			return "", ErrInvalidMFACode
+		}
+		ok, err := s.checkTOTP(ctx, admin.ID, admin.TOTPSecret, code)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			return "", ErrInvalidMFACode
+		}
+	case domain.MFAMethodBackupCode:
+		if code == "" {
+			return "", ErrInvalidMFACode
+		}
+		ok, err := s.backupCodes.Consume(ctx, admin.ID, s.hashBackupCode(admin.ID, code), s.now())
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			return "", ErrInvalidMFACode
+		}
+	default:
+		return "", ErrInvalidMFACode
+	}
+	return method, nil
+}
+
+// BeginWebAuthnLogin 生成登录断言参数（传给navigator.credentials.get()），loginID标识这次登录
+func (s *MFAService) BeginWebAuthnLogin(ctx context.Context, admin *domain.AdminUser, loginID string) (*webauthn.RequestOptions, error) {
+	if s.config.WebAuthn == nil {
+		return nil, ErrWebAuthnDisabled
+	}
+	creds, err := s.credentials.ListByAdmin(ctx, admin.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(creds) == 0 {
+		return nil, ErrCredentialNotFound
+	}
+	opts, session, err := s.config.WebAuthn.BeginLogin(webAuthnUser(admin, creds))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.saveSession(ctx, loginChallengeKey(loginID), session); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
+// VerifyWebAuthn 校验登录断言，挑战只能使用一次
+func (s *MFAService) VerifyWebAuthn(ctx context.Context, admin *domain.AdminUser, loginID string, resp webauthn.AssertionResponse) error {
+	if s.config.WebAuthn == nil {
+		return ErrWebAuthnDisabled
+	}
+	if len(resp) == 0 {
+		return ErrInvalidMFACode
+	}
+	session, err := s.takeSession(ctx, loginChallengeKey(loginID))
+	if err != nil {
+		return err
+	}
+	creds, err := s.credentials.ListByAdmin(ctx, admin.ID)
+	if err != nil {
+		return err
+	}
+
+	used, err := s.config.WebAuthn.FinishLogin(webAuthnUser(admin, creds), session, resp)
+	if errors.Is(err, webauthn.ErrSignCountRegression) {
+		log.Printf("WebAuthn credential of admin %s reported a non-increasing signature counter, possible cloned key", admin.ID)
+		return ErrInvalidMFACode
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidMFACode, err)
+	}
+	return s.credentials.UpdateSignCount(ctx, base64.RawURLEncoding.EncodeToString(used.ID), used.SignCount, s.now())
+}
+
+// enrolled 绑定第二因素后标记当前会话，并在没有备用恢复码时生成一组
+func (s *MFAService) enrolled(ctx context.Context, actor *domain.AdminActor, method string) ([]string, error) {
+	if err := s.sessions.MarkMFA(ctx, actor.SessionID, method); err != nil {
+		return nil, err
+	}
+	remaining, err := s.backupCodes.CountUnused(ctx, actor.AdminID)
+	if err != nil {
+		return nil, err
+	}
+	if remaining > 0 {
+		return nil, nil
+	}
+	return s.generateBackupCodes(ctx, actor.AdminID)
+}
+
+// generateBackupCodes 生成并保存一组新的备用恢复码，返回明文（只返回这一次）
+func (s *MFAService) generateBackupCodes(ctx context.Context, adminID string) ([]string, error) {
+	codes, err := s.totp.GenerateBackupCodes()
+	if err != nil {
+		return nil, err
+	}
+	hashes := make([]string, len(codes))
+	for i, code := range codes {
+		hashes[i] = s.hashBackupCode(adminID, code)
+	}
+	if err := s.backupCodes.Replace(ctx, adminID, hashes, s.now()); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// hashBackupCode 计算备用恢复码的HMAC（绑定管理员ID，相同的码在不同管理员下哈希不同）
+func (s *MFAService) hashBackupCode(adminID, code string) string {
+	mac := hmac.New(sha256.New, s.config.BackupCodeKey)
+	mac.Write([]byte(adminID + ":" + normalizeCode(code)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// checkTOTP 校验TOTP码，同一个码在有效期内只能使用一次（防止被截获的码重放）
+func (s *MFAService) checkTOTP(ctx context.Context, adminID, secret, code string) (bool, error) {
+	if secret == "" || !s.totp.Verify(code, secret) {
+		return false, nil
+	}
+	fresh, err := s.redis.SetNX(ctx, fmt.Sprintf("admin:totp:used:%s:%s", adminID, code), 1, totpReplayTTL).Result()
+	if err != nil {
+		return false, fmt.Errorf("record totp code: %w", err)
+	}
+	return fresh, nil
+}
+
+// hasSecondFactor 判断管理员是否绑定了TOTP或安全密钥
+func (s *MFAService) hasSecondFactor(ctx context.Context, admin *domain.AdminUser) (bool, error) {
+	if admin.TOTPEnabled {
+		return true, nil
+	}
+	creds, err := s.credentials.ListByAdmin(ctx, admin.ID)
+	if err != nil {
+		return false, err
+	}
+	return len(creds) > 0, nil
+}
+
+// saveSession 把挑战和参数保存到Redis
+func (s *MFAService) saveSession(ctx context.Context, key string, session *webauthn.Session) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("marshal webauthn session: %w", err)
+	}
+	if err := s.redis.Set(ctx, key, data, webAuthnChallengeTTL).Err(); err != nil {
+		return fmt.Errorf("save webauthn challenge: %w", err)
+	}
+	return nil
+}
+
+// takeSession 取出并删除挑战，每个挑战只能使用一次
+func (s *MFAService) takeSession(ctx context.Context, key string) (*webauthn.Session, error) {
+	data, err := s.redis.GetDel(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrWebAuthnChallengeExpired
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get webauthn challenge: %w", err)
+	}
+	var session webauthn.Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("unmarshal webauthn session: %w", err)
+	}
+	return &session, nil
+}
+
+func (s *MFAService) getAdmin(ctx context.Context, adminID string) (*domain.AdminUser, error) {
+	admin, err := s.admins.GetByID(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	if admin == nil {
+		return nil, ErrAdminNotFound
+	}
+	return admin, nil
+}
+
+// logMFAOperation 记录第二因素变更，详情中附带使用的方式
+func (s *MFAService) logMFAOperation(ctx context.Context, actor *domain.AdminActor, action, method string, extra map[string]interface{}, start time.Time, opErr error) {
+	if extra == nil {
+		extra = map[string]interface{}{}
+	}
+	extra["method"] = method
+	s.audit.Record(ctx, actor, domain.OpUpdateMFA, domain.ResourceAdminUser, actor.AdminID, action,
+		&domain.OperationDetails{Extra: extra}, start, opErr)
+}
+
+// checkPassword 校验管理员密码（Argon2id）
+func checkPassword(admin *domain.AdminUser, password string) bool {
+	ok, err := crypto.VerifyPassword(password, admin.PasswordHash)
+	if err != nil {
+		log.Printf("Failed to verify password of admin %s: %v", admin.ID, err)
+		return false
+	}
+	return ok
+}
+
+// normalizeCode 去掉验证码中的空格和连字符
+func normalizeCode(code string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(code))
+}
+
+// webAuthnUser 把管理员和已注册的安全密钥转换为依赖方使用的用户
+func webAuthnUser(admin *domain.AdminUser, creds []*domain.WebAuthnCredential) *webauthn.User {
+	user := &webauthn.User{ID: []byte(admin.ID), Name: admin.Username}
+	for _, cred := range creds {
+		id, err := base64.RawURLEncoding.DecodeString(cred.ID)
+		if err != nil {
+			continue
+		}
+		user.Credentials = append(user.Credentials, webauthn.Credential{
+			ID:             id,
+			PublicKey:      cred.PublicKey,
+			Algorithm:      cred.Algorithm,
+			SignCount:      cred.SignCount,
+			BackupEligible: cred.BackupEligible,
+		})
+	}
+	return user
+}
+
+func registrationChallengeKey(adminID string) string {
+	return "admin:webauthn:register:" + adminID
+}
+
+func loginChallengeKey(loginID string) string {
+	return "admin:webauthn:login:" + loginID
+}
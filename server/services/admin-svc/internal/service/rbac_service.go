@@ -0,0 +1,303 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"admin-svc/internal/domain"
+	"admin-svc/internal/repository"
+)
+
+// roleCacheTTL 自定义角色的本地缓存时间，其他实例修改角色后最多延迟这么久生效
+const roleCacheTTL = 30 * time.Second
+
+var (
+	// ErrRoleNotFound 角色不存在
+	ErrRoleNotFound = errors.New("role not found")
+	// ErrRoleExists 角色名已被使用（包括内置角色名）
+	ErrRoleExists = errors.New("role already exists")
+	// ErrBuiltInRole 内置角色不能修改或删除
+	ErrBuiltInRole = errors.New("built-in roles cannot be modified")
+	// ErrRoleInUse 仍有管理员使用的角色不能删除
+	ErrRoleInUse = errors.New("role is assigned to admins")
+	// ErrInvalidRoleName 角色名不合法
+	ErrInvalidRoleName = errors.New("invalid role name")
+	// ErrInvalidPermission 权限不存在或不能授予自定义角色
+	ErrInvalidPermission = errors.New("invalid permission")
+	// ErrAdminNotFound 管理员不存在
+	ErrAdminNotFound = errors.New("admin not found")
+	// ErrCannotChangeOwnRole 不能修改自己的角色
+	ErrCannotChangeOwnRole = errors.New("cannot change own role")
+)
+
+// RBACService 基于权限的访问控制
+// 内置角色定义在代码中，自定义角色存储在PostgreSQL；每个管理员只有一个角色，角色之间没有继承
+type RBACService struct {
+	roles  repository.RoleRepository
+	admins repository.AdminUserRepository
+	audit  *AuditService
+	now    func() time.Time
+
+	mu          sync.RWMutex
+	customRoles map[string]*domain.Role
+	loadedAt    time.Time
+}
+
+// NewRBACService 创建访问控制服务
+func NewRBACService(roles repository.RoleRepository, admins repository.AdminUserRepository, audit *AuditService) *RBACService {
+	return &RBACService{
+		roles:  roles,
+		admins: admins,
+		audit:  audit,
+		now:    time.Now,
+	}
+}
+
+// HasPermission 检查管理员是否拥有某个权限
+// 管理员不存在、已禁用或角色已不存在时返回false
+func (s *RBACService) HasPermission(ctx context.Context, adminID, permission string) (bool, error) {
+	admin, err := s.admins.GetByID(ctx, adminID)
+	if err != nil {
+		return false, err
+	}
+	if admin == nil || admin.Status != domain.StatusActive {
+		return false, nil
+	}
+
+	role, err := s.lookupRole(ctx, admin.Role)
+	if err != nil {
+		return false, err
+	}
+	return role != nil && role.HasPermission(permission), nil
+}
+
+// ListRoles 列出所有角色（内置角色在前）
+func (s *RBACService) ListRoles(ctx context.Context) ([]*domain.Role, error) {
+	custom, err := s.roles.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return append(domain.BuiltInRoles(), custom...), nil
+}
+
+// GetRole 获取角色
+func (s *RBACService) GetRole(ctx context.Context, name string) (*domain.Role, error) {
+	if role := builtInRole(name); role != nil {
+		return role, nil
+	}
+	role, err := s.roles.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, ErrRoleNotFound
+	}
+	return role, nil
+}
+
+// CreateRole 创建自定义角色
+func (s *RBACService) CreateRole(ctx context.Context, actor *domain.AdminActor, name, description string, permissions []string) (role *domain.Role, err error) {
+	start := s.now()
+	defer func() {
+		details := &domain.OperationDetails{After: roleSnapshot(role)}
+		s.audit.Record(ctx, actor, domain.OpPermission, domain.ResourceRole, name, domain.ActionCreate, details, start, err)
+	}()
+
+	if !domain.ValidRoleName(name) {
+		return nil, ErrInvalidRoleName
+	}
+	perms, err := validatePermissions(permissions)
+	if err != nil {
+		return nil, err
+	}
+	if builtInRole(name) != nil {
+		return nil, ErrRoleExists
+	}
+	existing, err := s.roles.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrRoleExists
+	}
+
+	now := s.now()
+	created := &domain.Role{
+		Name:        name,
+		Description: description,
+		Permissions: perms,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.roles.Create(ctx, created); err != nil {
+		return nil, err
+	}
+	s.invalidateRoles()
+	return created, nil
+}
+
+// UpdateRole 修改自定义角色的说明和权限（整体替换权限列表）
+func (s *RBACService) UpdateRole(ctx context.Context, actor *domain.AdminActor, name, description string, permissions []string) (role *domain.Role, err error) {
+	start := s.now()
+	var before *domain.Role
+	defer func() {
+		details := &domain.OperationDetails{Before: roleSnapshot(before), After: roleSnapshot(role)}
+		s.audit.Record(ctx, actor, domain.OpPermission, domain.ResourceRole, name, domain.ActionUpdate, details, start, err)
+	}()
+
+	if builtInRole(name) != nil {
+		return nil, ErrBuiltInRole
+	}
+	perms, err := validatePermissions(permissions)
+	if err != nil {
+		return nil, err
+	}
+	before, err = s.roles.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if before == nil {
+		return nil, ErrRoleNotFound
+	}
+
+	updated := *before
+	updated.Description = description
+	updated.Permissions = perms
+	updated.UpdatedAt = s.now()
+	if err := s.roles.Update(ctx, &updated); err != nil {
+		return nil, err
+	}
+	s.invalidateRoles()
+	return &updated, nil
+}
+
+// DeleteRole 删除自定义角色，仍有管理员使用时拒绝删除
+func (s *RBACService) DeleteRole(ctx context.Context, actor *domain.AdminActor, name string) (err error) {
+	start := s.now()
+	var before *domain.Role
+	defer func() {
+		details := &domain.OperationDetails{Before: roleSnapshot(before)}
+		s.audit.Record(ctx, actor, domain.OpPermission, domain.ResourceRole, name, domain.ActionDelete, details, start, err)
+	}()
+
+	if builtInRole(name) != nil {
+		return ErrBuiltInRole
+	}
+	before, err = s.roles.Get(ctx, name)
+	if err != nil {
+		return err
+	}
+	if before == nil {
+		return ErrRoleNotFound
+	}
+	count, err := s.admins.CountByRole(ctx, name)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: %d admins", ErrRoleInUse, count)
+	}
+
+	if err := s.roles.Delete(ctx, name); err != nil {
+		return err
+	}
+	s.invalidateRoles()
+	return nil
+}
+
+// AssignRole 为管理员分配角色，不能修改自己的角色
+func (s *RBACService) AssignRole(ctx context.Context, actor *domain.AdminActor, adminID, roleName string) (err error) {
+	start := s.now()
+	details := &domain.OperationDetails{After: map[string]interface{}{"role": roleName}}
+	defer func() {
+		s.audit.Record(ctx, actor, domain.OpPermission, domain.ResourceAdminUser, adminID, domain.ActionAssignRole, details, start, err)
+	}()
+
+	if adminID == actor.AdminID {
+		return ErrCannotChangeOwnRole
+	}
+	if _, err := s.GetRole(ctx, roleName); err != nil {
+		return err
+	}
+	admin, err := s.admins.GetByID(ctx, adminID)
+	if err != nil {
+		return err
+	}
+	if admin == nil {
+		return ErrAdminNotFound
+	}
+	details.Before = map[string]interface{}{"role": admin.Role}
+
+	return s.admins.UpdateRole(ctx, adminID, roleName, s.now())
+}
+
+// lookupRole 查找角色（自定义角色走本地缓存），不存在时返回nil
+func (s *RBACService) lookupRole(ctx context.Context, name string) (*domain.Role, error) {
+	if role := builtInRole(name); role != nil {
+		return role, nil
+	}
+
+	s.mu.RLock()
+	fresh := s.customRoles != nil && s.now().Sub(s.loadedAt) < roleCacheTTL
+	role := s.customRoles[name]
+	s.mu.RUnlock()
+	if fresh {
+		return role, nil
+	}
+
+	roles, err := s.roles.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*domain.Role, len(roles))
+	for _, r := range roles {
+		byName[r.Name] = r
+	}
+
+	s.mu.Lock()
+	s.customRoles = byName
+	s.loadedAt = s.now()
+	s.mu.Unlock()
+	return byName[name], nil
+}
+
+// invalidateRoles 角色变更后清空本地缓存
+func (s *RBACService) invalidateRoles() {
+	s.mu.Lock()
+	s.customRoles = nil
+	s.mu.Unlock()
+}
+
+// builtInRole 返回同名的内置角色，不是内置角色时返回nil
+func builtInRole(name string) *domain.Role {
+	for _, role := range domain.BuiltInRoles() {
+		if role.Name == name {
+			return role
+		}
+	}
+	return nil
+}
+
+// validatePermissions 校验并规范化自定义角色的权限列表
+func validatePermissions(permissions []string) ([]string, error) {
+	for _, p := range permissions {
+		if !domain.IsGrantablePermission(p) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidPermission, p)
+		}
+	}
+	return domain.NormalizePermissions(permissions), nil
+}
+
+// roleSnapshot 角色的审计快照
+func roleSnapshot(role *domain.Role) map[string]interface{} {
+	if role == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"description": role.Description,
+		"permissions": role.Permissions,
+	}
+}
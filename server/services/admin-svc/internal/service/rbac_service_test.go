@@ -0,0 +1,264 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"admin-svc/internal/domain"
+	"admin-svc/internal/repository"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryRoleRepository 内存自定义角色仓储（用于测试）
+type memoryRoleRepository struct {
+	roles     map[string]*domain.Role
+	listCalls int
+}
+
+func (r *memoryRoleRepository) Get(ctx context.Context, name string) (*domain.Role, error) {
+	role, ok := r.roles[name]
+	if !ok {
+		return nil, nil
+	}
+	copied := *role
+	return &copied, nil
+}
+
+func (r *memoryRoleRepository) List(ctx context.Context) ([]*domain.Role, error) {
+	r.listCalls++
+	roles := make([]*domain.Role, 0, len(r.roles))
+	for _, role := range r.roles {
+		copied := *role
+		roles = append(roles, &copied)
+	}
+	return roles, nil
+}
+
+func (r *memoryRoleRepository) Create(ctx context.Context, role *domain.Role) error {
+	copied := *role
+	r.roles[role.Name] = &copied
+	return nil
+}
+
+func (r *memoryRoleRepository) Update(ctx context.Context, role *domain.Role) error {
+	copied := *role
+	r.roles[role.Name] = &copied
+	return nil
+}
+
+func (r *memoryRoleRepository) Delete(ctx context.Context, name string) error {
+	delete(r.roles, name)
+	return nil
+}
+
+// memoryAdminRepository 内存管理员仓储（只实现访问控制用到的方法）
+type memoryAdminRepository struct {
+	repository.AdminUserRepository
+	admins map[string]*domain.AdminUser
+}
+
+func (r *memoryAdminRepository) GetByID(ctx context.Context, id string) (*domain.AdminUser, error) {
+	admin, ok := r.admins[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *admin
+	return &copied, nil
+}
+
+func (r *memoryAdminRepository) UpdateRole(ctx context.Context, id, role string, updatedAt time.Time) error {
+	r.admins[id].Role = role
+	r.admins[id].UpdatedAt = updatedAt
+	return nil
+}
+
+func (r *memoryAdminRepository) CountByRole(ctx context.Context, role string) (int64, error) {
+	var count int64
+	for _, admin := range r.admins {
+		if admin.Role == role {
+			count++
+		}
+	}
+	return count, nil
+}
+
+type rbacTestEnv struct {
+	service *RBACService
+	roles   *memoryRoleRepository
+	admins  *memoryAdminRepository
+	audit   *AuditService
+	now     time.Time
+}
+
+func newRBACTestEnv(t *testing.T) *rbacTestEnv {
+	mr := miniredis.RunT(t)
+	env := &rbacTestEnv{
+		roles: &memoryRoleRepository{roles: make(map[string]*domain.Role)},
+		admins: &memoryAdminRepository{admins: map[string]*domain.AdminUser{
+			"admin-1":    {ID: "admin-1", Username: "root", Role: domain.RoleAdmin, Status: domain.StatusActive},
+			"operator-1": {ID: "operator-1", Username: "ops", Role: domain.RoleOperator, Status: domain.StatusActive},
+			"viewer-1":   {ID: "viewer-1", Username: "guest", Role: domain.RoleViewer, Status: domain.StatusActive},
+			"disabled-1": {ID: "disabled-1", Username: "gone", Role: domain.RoleAdmin, Status: domain.StatusDisabled},
+		}},
+		audit: NewAuditService(redis.NewClient(&redis.Options{Addr: mr.Addr()}), nil, nil, nil),
+		now:   time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC),
+	}
+	env.service = NewRBACService(env.roles, env.admins, env.audit)
+	env.service.now = func() time.Time { return env.now }
+	return env
+}
+
+var rootActor = &domain.AdminActor{AdminID: "admin-1", AdminName: "root"}
+
+// TestHasPermission_BuiltInRoles 测试内置角色的权限矩阵
+func TestHasPermission_BuiltInRoles(t *testing.T) {
+	env := newRBACTestEnv(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		adminID    string
+		permission string
+		want       bool
+	}{
+		{"admin-1", domain.PermRoleManage, true},
+		{"admin-1", domain.PermAdminSession, true},
+		{"admin-1", domain.PermConfigApprove, true},
+		{"operator-1", domain.PermConfigEdit, true},
+		{"operator-1", domain.PermUserManage, true},
+		{"operator-1", domain.PermRoleManage, false},
+		{"operator-1", domain.PermAdminSession, false},
+		{"viewer-1", domain.PermStatsView, true},
+		{"viewer-1", domain.PermAdminView, true},
+		{"viewer-1", domain.PermConfigEdit, false},
+		{"viewer-1", domain.PermAuditExport, false},
+		{"disabled-1", domain.PermStatsView, false},
+		{"missing", domain.PermStatsView, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.adminID+"/"+tt.permission, func(t *testing.T) {
+			allowed, err := env.service.HasPermission(ctx, tt.adminID, tt.permission)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, allowed)
+		})
+	}
+}
+
+// TestCreateRole_RejectsSuperAdminPermissions 测试角色管理和会话管理权限不能授予自定义角色
+func TestCreateRole_RejectsSuperAdminPermissions(t *testing.T) {
+	env := newRBACTestEnv(t)
+	ctx := context.Background()
+
+	for _, permission := range []string{domain.PermRoleManage, domain.PermAdminSession, "stats:delete"} {
+		_, err := env.service.CreateRole(ctx, rootActor, "support", "", []string{domain.PermUserView, permission})
+		assert.ErrorIs(t, err, ErrInvalidPermission, permission)
+	}
+	assert.Empty(t, env.roles.roles)
+
+	_, err := env.service.CreateRole(ctx, rootActor, domain.RoleViewer, "", []string{domain.PermUserView})
+	assert.ErrorIs(t, err, ErrRoleExists)
+	_, err = env.service.CreateRole(ctx, rootActor, "Support Team", "", []string{domain.PermUserView})
+	assert.ErrorIs(t, err, ErrInvalidRoleName)
+
+	role, err := env.service.CreateRole(ctx, rootActor, "support", "客服", []string{domain.PermUserView, domain.PermUserManage, domain.PermUserView})
+	require.NoError(t, err)
+	assert.Equal(t, []string{domain.PermUserManage, domain.PermUserView}, role.Permissions)
+
+	_, err = env.service.UpdateRole(ctx, rootActor, "support", "客服", []string{domain.PermRoleManage})
+	assert.ErrorIs(t, err, ErrInvalidPermission)
+	_, err = env.service.UpdateRole(ctx, rootActor, domain.RoleOperator, "", []string{domain.PermUserView})
+	assert.ErrorIs(t, err, ErrBuiltInRole)
+}
+
+// TestAssignRole_CannotChangeOwnRole 测试不能修改自己的角色，且拒绝的操作也记录审计日志
+func TestAssignRole_CannotChangeOwnRole(t *testing.T) {
+	env := newRBACTestEnv(t)
+	ctx := context.Background()
+
+	err := env.service.AssignRole(ctx, rootActor, "admin-1", domain.RoleViewer)
+	assert.ErrorIs(t, err, ErrCannotChangeOwnRole)
+	assert.Equal(t, domain.RoleAdmin, env.admins.admins["admin-1"].Role)
+
+	logs, _, err := env.audit.ListOperationLogs(ctx, nil, 1, 20)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	assert.Equal(t, domain.ActionAssignRole, logs[0].Action)
+	assert.Equal(t, domain.StatusFailed, logs[0].Status)
+
+	assert.ErrorIs(t, env.service.AssignRole(ctx, rootActor, "operator-1", "missing"), ErrRoleNotFound)
+	assert.ErrorIs(t, env.service.AssignRole(ctx, rootActor, "missing", domain.RoleViewer), ErrAdminNotFound)
+
+	require.NoError(t, env.service.AssignRole(ctx, rootActor, "operator-1", domain.RoleViewer))
+	allowed, err := env.service.HasPermission(ctx, "operator-1", domain.PermConfigEdit)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+}
+
+// TestHasPermission_CustomRoleCache 测试自定义角色缓存：本实例修改后立即生效，其他实例修改后最多延迟roleCacheTTL
+func TestHasPermission_CustomRoleCache(t *testing.T) {
+	env := newRBACTestEnv(t)
+	ctx := context.Background()
+
+	_, err := env.service.CreateRole(ctx, rootActor, "support", "", []string{domain.PermUserView})
+	require.NoError(t, err)
+	require.NoError(t, env.service.AssignRole(ctx, rootActor, "viewer-1", "support"))
+
+	allowed, err := env.service.HasPermission(ctx, "viewer-1", domain.PermUserView)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+	allowed, err = env.service.HasPermission(ctx, "viewer-1", domain.PermStatsView)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+	assert.Equal(t, 1, env.roles.listCalls)
+
+	// 本实例修改角色后清空缓存
+	_, err = env.service.UpdateRole(ctx, rootActor, "support", "", []string{domain.PermStatsView})
+	require.NoError(t, err)
+	allowed, err = env.service.HasPermission(ctx, "viewer-1", domain.PermUserView)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+	assert.Equal(t, 2, env.roles.listCalls)
+
+	// 其他实例直接修改数据库，缓存过期前仍使用旧权限
+	env.roles.roles["support"].Permissions = []string{domain.PermUserView}
+	env.now = env.now.Add(roleCacheTTL - time.Second)
+	allowed, err = env.service.HasPermission(ctx, "viewer-1", domain.PermUserView)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	env.now = env.now.Add(time.Second)
+	allowed, err = env.service.HasPermission(ctx, "viewer-1", domain.PermUserView)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+	assert.Equal(t, 3, env.roles.listCalls)
+}
+
+// TestDeleteRole 测试仍有管理员使用的角色不能删除，删除后角色立即失效
+func TestDeleteRole(t *testing.T) {
+	env := newRBACTestEnv(t)
+	ctx := context.Background()
+
+	_, err := env.service.CreateRole(ctx, rootActor, "support", "", []string{domain.PermUserView})
+	require.NoError(t, err)
+	require.NoError(t, env.service.AssignRole(ctx, rootActor, "viewer-1", "support"))
+
+	assert.ErrorIs(t, env.service.DeleteRole(ctx, rootActor, "support"), ErrRoleInUse)
+	assert.ErrorIs(t, env.service.DeleteRole(ctx, rootActor, domain.RoleViewer), ErrBuiltInRole)
+
+	allowed, err := env.service.HasPermission(ctx, "viewer-1", domain.PermUserView)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	// 模拟角色被删除后仍有管理员引用（例如其他实例并发分配）
+	env.admins.admins["viewer-1"].Role = domain.RoleViewer
+	require.NoError(t, env.service.DeleteRole(ctx, rootActor, "support"))
+	env.admins.admins["viewer-1"].Role = "support"
+
+	allowed, err = env.service.HasPermission(ctx, "viewer-1", domain.PermUserView)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+}
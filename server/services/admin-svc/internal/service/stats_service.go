@@ -2,228 +2,247 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"admin-svc/internal/domain"
+	"admin-svc/internal/repository"
 
 	"github.com/redis/go-redis/v9"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/stats"
 )
 
+const (
+	// RedisStatsDays Redis中可读取的每日计数天数（含今天），更早的日期只能从数据库读取
+	RedisStatsDays = 7
+
+	// onlineWindowMinutes 最近N分钟内有请求的用户视为在线
+	onlineWindowMinutes = 5
+
+	dateLayout = "2006-01-02"
+)
+
+// ErrStatsPersistenceDisabled 未配置数据库时无法持久化每日统计
+var ErrStatsPersistenceDisabled = errors.New("daily stats persistence is not configured")
+
 // StatsService 数据统计服务（聚合+实时）
+// 计数由各服务通过shared/pkg/stats写入Redis，这里只负责读取、聚合和落库
 type StatsService struct {
 	redis *redis.Client
+	repo  repository.DailyStatsRepository
+	now   func() time.Time
 }
 
 // NewStatsService 创建统计服务
-func NewStatsService(redis *redis.Client) *StatsService {
+// repo为nil时每日统计不落库，只能查询Redis中最近7天的数据
+func NewStatsService(redis *redis.Client, repo repository.DailyStatsRepository) *StatsService {
 	return &StatsService{
 		redis: redis,
+		repo:  repo,
+		now:   time.Now,
 	}
 }
 
 // GetRealtimeStats 获取实时统计
+// 每分钟指标取最近一个完整的分钟，在线用户为最近5分钟内有请求的用户数（HyperLogLog估算）
 func (s *StatsService) GetRealtimeStats(ctx context.Context) (*domain.RealtimeStats, error) {
+	now := s.now()
+	lastMinute := now.Truncate(time.Minute).Add(-time.Minute)
+
+	onlineKeys := make([]string, 0, onlineWindowMinutes)
+	for i := 0; i < onlineWindowMinutes; i++ {
+		onlineKeys = append(onlineKeys, stats.MinuteKey(now.Add(-time.Duration(i)*time.Minute), stats.MetricActiveUsers))
+	}
+
 	pipe := s.redis.Pipeline()
-	
-	onlineUsersCmd := pipe.Get(ctx, "stats:realtime:online_users")
+	onlineUsersCmd := pipe.PFCount(ctx, onlineKeys...)
 	activeSessionsCmd := pipe.Get(ctx, "stats:realtime:active_sessions")
-	requestsPerMinCmd := pipe.Get(ctx, "stats:realtime:requests_per_min")
-	errorsPerMinCmd := pipe.Get(ctx, "stats:realtime:errors_per_min")
-	avgResponseTimeCmd := pipe.Get(ctx, "stats:realtime:avg_response_time")
-	
+	requestsCmd := pipe.Get(ctx, stats.MinuteKey(lastMinute, stats.MetricRequests))
+	errorsCmd := pipe.Get(ctx, stats.MinuteKey(lastMinute, stats.MetricFailedRequests))
+	responseTimeCmd := pipe.Get(ctx, stats.MinuteKey(lastMinute, stats.MetricResponseTimeSum))
+
 	_, err := pipe.Exec(ctx)
 	if err != nil && err != redis.Nil {
 		return nil, fmt.Errorf("get realtime stats: %w", err)
 	}
-	
-	stats := &domain.RealtimeStats{
-		Timestamp: time.Now(),
-	}
-	
-	if val, err := onlineUsersCmd.Int64(); err == nil {
-		stats.OnlineUsers = val
-	}
-	if val, err := activeSessionsCmd.Int64(); err == nil {
-		stats.ActiveSessions = val
-	}
-	if val, err := requestsPerMinCmd.Int64(); err == nil {
-		stats.RequestsPerMin = val
-	}
-	if val, err := errorsPerMinCmd.Int64(); err == nil {
-		stats.ErrorsPerMin = val
+
+	result := &domain.RealtimeStats{
+		OnlineUsers:    onlineUsersCmd.Val(),
+		ActiveSessions: counterValue(activeSessionsCmd),
+		RequestsPerMin: counterValue(requestsCmd),
+		ErrorsPerMin:   counterValue(errorsCmd),
+		Timestamp:      now,
 	}
-	if val, err := avgResponseTimeCmd.Int64(); err == nil {
-		stats.AvgResponseTime = val
+	if result.RequestsPerMin > 0 {
+		result.AvgResponseTime = counterValue(responseTimeCmd) / result.RequestsPerMin
 	}
-	
-	return stats, nil
-}
 
-// UpdateRealtimeStats 更新实时统计
-func (s *StatsService) UpdateRealtimeStats(ctx context.Context, key string, value int64) error {
-	fullKey := fmt.Sprintf("stats:realtime:%s", key)
-	return s.redis.Set(ctx, fullKey, value, 5*time.Minute).Err()
+	return result, nil
 }
 
-// IncrementCounter 增加计数器
-func (s *StatsService) IncrementCounter(ctx context.Context, key string) error {
-	fullKey := fmt.Sprintf("stats:realtime:%s", key)
-	return s.redis.Incr(ctx, fullKey).Err()
-}
-
-// RecordResponseTime 记录响应时间（使用滑动窗口计算平均值）
-func (s *StatsService) RecordResponseTime(ctx context.Context, duration int64) error {
-	key := "stats:realtime:response_times"
-	now := time.Now().Unix()
-	
-	// 添加到Sorted Set
-	err := s.redis.ZAdd(ctx, key, redis.Z{
-		Score:  float64(now),
-		Member: duration,
-	}).Err()
-	if err != nil {
-		return err
-	}
-	
-	// 清理1分钟之前的数据
-	minScore := now - 60
-	_ = s.redis.ZRemRangeByScore(ctx, key, "0", fmt.Sprintf("%d", minScore)).Err()
-	
-	// 计算平均值
-	return s.calculateAvgResponseTime(ctx)
-}
+// AggregateDailyStats 从Redis聚合某日统计
+// 只对最近7天有效；总用户数由当前总数减去之后每天的新增用户倒推
+func (s *StatsService) AggregateDailyStats(ctx context.Context, date time.Time) (*domain.DailyStats, error) {
+	day := startOfDay(date)
+	today := startOfDay(s.now())
 
-// calculateAvgResponseTime 计算平均响应时间
-func (s *StatsService) calculateAvgResponseTime(ctx context.Context) error {
-	key := "stats:realtime:response_times"
-	
-	// 获取所有响应时间
-	members, err := s.redis.ZRange(ctx, key, 0, -1).Result()
-	if err != nil {
-		return err
-	}
-	
-	if len(members) == 0 {
-		return s.redis.Set(ctx, "stats:realtime:avg_response_time", 0, 5*time.Minute).Err()
-	}
-	
-	var sum int64
-	for _, member := range members {
-		var duration int64
-		fmt.Sscanf(member, "%d", &duration)
-		sum += duration
-	}
-	
-	avg := sum / int64(len(members))
-	return s.redis.Set(ctx, "stats:realtime:avg_response_time", avg, 5*time.Minute).Err()
-}
+	counters := []string{
+		stats.MetricNewUsers, stats.MetricLogins,
+		stats.MetricRequests, stats.MetricSuccessRequests, stats.MetricFailedRequests, stats.MetricResponseTimeSum,
+		stats.MetricFavorites, stats.MetricPlaylists, stats.MetricPlays,
+	}
 
-// AggregateDailyStats 聚合每日统计（由Cron任务调用）
-// 这个方法返回需要聚合的数据，实际写入由Repository完成
-func (s *StatsService) AggregateDailyStats(ctx context.Context, date time.Time) (*domain.DailyStats, error) {
-	stats := &domain.DailyStats{
-		Date:      date,
-		CreatedAt: time.Now(),
-	}
-	
-	// 从Redis获取今日统计数据
-	dateKey := date.Format("2006-01-02")
-	
 	pipe := s.redis.Pipeline()
-	
-	totalUsersCmd := pipe.Get(ctx, fmt.Sprintf("daily:%s:total_users", dateKey))
-	newUsersCmd := pipe.Get(ctx, fmt.Sprintf("daily:%s:new_users", dateKey))
-	activeUsersCmd := pipe.Get(ctx, fmt.Sprintf("daily:%s:active_users", dateKey))
-	totalRequestsCmd := pipe.Get(ctx, fmt.Sprintf("daily:%s:total_requests", dateKey))
-	successRequestsCmd := pipe.Get(ctx, fmt.Sprintf("daily:%s:success_requests", dateKey))
-	failedRequestsCmd := pipe.Get(ctx, fmt.Sprintf("daily:%s:failed_requests", dateKey))
-	totalFavoritesCmd := pipe.Get(ctx, fmt.Sprintf("daily:%s:total_favorites", dateKey))
-	totalPlaylistsCmd := pipe.Get(ctx, fmt.Sprintf("daily:%s:total_playlists", dateKey))
-	totalPlaysCmd := pipe.Get(ctx, fmt.Sprintf("daily:%s:total_plays", dateKey))
-	
-	// 响应时间需要从Sorted Set计算
-	avgResponseTimeKey := fmt.Sprintf("daily:%s:response_times", dateKey)
-	
+	counterCmds := make(map[string]*redis.StringCmd, len(counters))
+	for _, metric := range counters {
+		counterCmds[metric] = pipe.Get(ctx, stats.DailyKey(day, metric))
+	}
+	activeUsersCmd := pipe.PFCount(ctx, stats.DailyActiveUsersKey(day))
+	weeklyActiveUsersCmd := pipe.PFCount(ctx, stats.WeeklyActiveUsersKey(day))
+	monthlyActiveUsersCmd := pipe.PFCount(ctx, stats.MonthlyActiveUsersKey(day))
+	totalUsersCmd := pipe.Get(ctx, stats.TotalUsersKey)
+	var laterNewUsersCmds []*redis.StringCmd
+	for d := day.AddDate(0, 0, 1); !d.After(today); d = d.AddDate(0, 0, 1) {
+		laterNewUsersCmds = append(laterNewUsersCmds, pipe.Get(ctx, stats.DailyKey(d, stats.MetricNewUsers)))
+	}
+
 	_, err := pipe.Exec(ctx)
 	if err != nil && err != redis.Nil {
 		return nil, fmt.Errorf("aggregate daily stats: %w", err)
 	}
-	
-	// 读取数据
-	if val, err := totalUsersCmd.Int64(); err == nil {
-		stats.TotalUsers = val
-	}
-	if val, err := newUsersCmd.Int64(); err == nil {
-		stats.NewUsers = val
-	}
-	if val, err := activeUsersCmd.Int64(); err == nil {
-		stats.ActiveUsers = val
-	}
-	if val, err := totalRequestsCmd.Int64(); err == nil {
-		stats.TotalRequests = val
-	}
-	if val, err := successRequestsCmd.Int64(); err == nil {
-		stats.SuccessRequests = val
+
+	result := &domain.DailyStats{
+		Date:               day,
+		NewUsers:           counterValue(counterCmds[stats.MetricNewUsers]),
+		ActiveUsers:        activeUsersCmd.Val(),
+		WeeklyActiveUsers:  weeklyActiveUsersCmd.Val(),
+		MonthlyActiveUsers: monthlyActiveUsersCmd.Val(),
+		Logins:             counterValue(counterCmds[stats.MetricLogins]),
+		TotalRequests:      counterValue(counterCmds[stats.MetricRequests]),
+		SuccessRequests:    counterValue(counterCmds[stats.MetricSuccessRequests]),
+		FailedRequests:     counterValue(counterCmds[stats.MetricFailedRequests]),
+		TotalFavorites:     counterValue(counterCmds[stats.MetricFavorites]),
+		TotalPlaylists:     counterValue(counterCmds[stats.MetricPlaylists]),
+		TotalPlays:         counterValue(counterCmds[stats.MetricPlays]),
+		CreatedAt:          s.now(),
 	}
-	if val, err := failedRequestsCmd.Int64(); err == nil {
-		stats.FailedRequests = val
+
+	result.TotalUsers = counterValue(totalUsersCmd)
+	for _, cmd := range laterNewUsersCmds {
+		result.TotalUsers -= counterValue(cmd)
 	}
-	if val, err := totalFavoritesCmd.Int64(); err == nil {
-		stats.TotalFavorites = val
+	if result.TotalUsers < 0 {
+		result.TotalUsers = 0
 	}
-	if val, err := totalPlaylistsCmd.Int64(); err == nil {
-		stats.TotalPlaylists = val
+
+	if result.TotalRequests > 0 {
+		result.AvgResponseTime = counterValue(counterCmds[stats.MetricResponseTimeSum]) / result.TotalRequests
 	}
-	if val, err := totalPlaysCmd.Int64(); err == nil {
-		stats.TotalPlays = val
+	result.CalculateErrorRate()
+
+	return result, nil
+}
+
+// GetDailyStatsRange 按天返回[start, end]范围内的统计，按日期升序
+// 已落库的日期读数据库，今天和尚未落库的最近7天实时聚合Redis，其余日期返回零值
+func (s *StatsService) GetDailyStatsRange(ctx context.Context, start, end time.Time) ([]*domain.DailyStats, error) {
+	start, end = startOfDay(start), startOfDay(end)
+	today := startOfDay(s.now())
+	redisFrom := today.AddDate(0, 0, -(RedisStatsDays - 1))
+
+	persisted := make(map[string]*domain.DailyStats)
+	if s.repo != nil && start.Before(today) {
+		last := end
+		if !last.Before(today) {
+			last = today.AddDate(0, 0, -1)
+		}
+		rows, err := s.repo.ListRange(ctx, start, last)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			persisted[row.Date.Format(dateLayout)] = row
+		}
 	}
-	
-	// 计算平均响应时间
-	responseTimeMembers, err := s.redis.ZRange(ctx, avgResponseTimeKey, 0, -1).Result()
-	if err == nil && len(responseTimeMembers) > 0 {
-		var sum int64
-		for _, member := range responseTimeMembers {
-			var duration int64
-			fmt.Sscanf(member, "%d", &duration)
-			sum += duration
+
+	var result []*domain.DailyStats
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		if row, ok := persisted[day.Format(dateLayout)]; ok {
+			row.Date = day
+			result = append(result, row)
+			continue
 		}
-		stats.AvgResponseTime = sum / int64(len(responseTimeMembers))
+		if day.Before(redisFrom) || day.After(today) {
+			result = append(result, &domain.DailyStats{Date: day})
+			continue
+		}
+		daily, err := s.AggregateDailyStats(ctx, day)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, daily)
 	}
-	
-	// 计算错误率
-	stats.CalculateErrorRate()
-	
-	return stats, nil
+	return result, nil
 }
 
-// IncrementDailyCounter 增加每日计数器
-func (s *StatsService) IncrementDailyCounter(ctx context.Context, date time.Time, metric string) error {
-	dateKey := date.Format("2006-01-02")
-	key := fmt.Sprintf("daily:%s:%s", dateKey, metric)
-	
-	err := s.redis.Incr(ctx, key).Err()
+// PersistDailyStats 聚合某日统计并写入数据库，重复执行会覆盖之前的结果
+func (s *StatsService) PersistDailyStats(ctx context.Context, date time.Time) (*domain.DailyStats, error) {
+	if s.repo == nil {
+		return nil, ErrStatsPersistenceDisabled
+	}
+
+	daily, err := s.AggregateDailyStats(ctx, date)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if err := s.repo.Upsert(ctx, daily); err != nil {
+		return nil, err
 	}
-	
-	// 设置过期时间（保留7天）
-	return s.redis.Expire(ctx, key, 7*24*time.Hour).Err()
+	return daily, nil
 }
 
-// RecordDailyResponseTime 记录每日响应时间
-func (s *StatsService) RecordDailyResponseTime(ctx context.Context, date time.Time, duration int64) error {
-	dateKey := date.Format("2006-01-02")
-	key := fmt.Sprintf("daily:%s:response_times", dateKey)
-	
-	err := s.redis.ZAdd(ctx, key, redis.Z{
-		Score:  float64(time.Now().Unix()),
-		Member: duration,
-	}).Err()
+// BackfillDailyStats 补写Redis中仍有数据但尚未落库的日期（不含今天），返回补写的天数
+// 用于服务停机错过夜间任务的情况，已落库的日期不会被覆盖
+func (s *StatsService) BackfillDailyStats(ctx context.Context) (int, error) {
+	if s.repo == nil {
+		return 0, ErrStatsPersistenceDisabled
+	}
+
+	today := startOfDay(s.now())
+	start := today.AddDate(0, 0, -(RedisStatsDays - 1))
+	end := today.AddDate(0, 0, -1)
+
+	rows, err := s.repo.ListRange(ctx, start, end)
 	if err != nil {
-		return err
+		return 0, err
+	}
+	persisted := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		persisted[row.Date.Format(dateLayout)] = true
+	}
+
+	backfilled := 0
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		if persisted[day.Format(dateLayout)] {
+			continue
+		}
+		if _, err := s.PersistDailyStats(ctx, day); err != nil {
+			return backfilled, fmt.Errorf("backfill %s: %w", day.Format(dateLayout), err)
+		}
+		backfilled++
 	}
-	
-	// 设置过期时间
-	return s.redis.Expire(ctx, key, 7*24*time.Hour).Err()
+	return backfilled, nil
+}
+
+// counterValue 读取计数器，不存在的key视为0
+func counterValue(cmd *redis.StringCmd) int64 {
+	val, _ := cmd.Int64()
+	return val
+}
+
+// startOfDay 返回t所在自然日（本地时区）的零点
+func startOfDay(t time.Time) time.Time {
+	t = t.In(time.Local)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
 }
@@ -0,0 +1,339 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"admin-svc/internal/domain"
+
+	authv1 "github.com/xiaoxiao0301/listen-stream-v2/server/shared/proto/auth/v1"
+	userv1 "github.com/xiaoxiao0301/listen-stream-v2/server/shared/proto/user/v1"
+	"google.golang.org/grpc/status"
+)
+
+// ErrDeviceNotFound 设备不存在或不属于该用户
+var ErrDeviceNotFound = errors.New("device not found")
+
+// phonePattern 手机号格式，用于区分按手机号还是按用户ID查询
+var phonePattern = regexp.MustCompile(`^1\d{10}$`)
+
+// UserAdminService 终端用户管理服务
+// 账号信息和禁用状态由auth-svc管理，使用统计来自user-svc
+type UserAdminService struct {
+	auth  authv1.AuthServiceClient
+	users userv1.UserServiceClient
+	audit *AuditService
+	now   func() time.Time
+}
+
+// NewUserAdminService 创建终端用户管理服务
+func NewUserAdminService(auth authv1.AuthServiceClient, users userv1.UserServiceClient, audit *AuditService) *UserAdminService {
+	return &UserAdminService{
+		auth:  auth,
+		users: users,
+		audit: audit,
+		now:   time.Now,
+	}
+}
+
+// GetUserDetail 根据用户ID或手机号获取用户详情（账号、设备、使用统计）
+func (s *UserAdminService) GetUserDetail(ctx context.Context, identifier string) (*domain.EndUserDetail, error) {
+	req := &authv1.GetUserRequest{UserId: identifier}
+	if phonePattern.MatchString(identifier) {
+		req = &authv1.GetUserRequest{Phone: identifier}
+	}
+	userResp, err := s.auth.GetUser(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+	user := endUserFromProto(userResp.User)
+
+	devices, err := s.listDevices(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, device := range devices {
+		if user.LastLoginAt == nil || device.LastActive.After(*user.LastLoginAt) {
+			lastActive := device.LastActive
+			user.LastLoginAt = &lastActive
+		}
+	}
+
+	usage, err := s.getUsage(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.EndUserDetail{
+		User:    user,
+		Devices: devices,
+		Usage:   usage,
+	}, nil
+}
+
+// SearchUsers 按手机号前缀搜索用户（返回的手机号已脱敏）
+func (s *UserAdminService) SearchUsers(ctx context.Context, phonePrefix string, page, pageSize int) ([]*domain.EndUser, int, error) {
+	resp, err := s.auth.SearchUsers(ctx, &authv1.SearchUsersRequest{
+		PhonePrefix: phonePrefix,
+		Page:        int32(page),
+		PageSize:    int32(pageSize),
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("search users: %w", err)
+	}
+
+	users := make([]*domain.EndUser, 0, len(resp.Users))
+	for _, u := range resp.Users {
+		users = append(users, endUserFromProto(u))
+	}
+	return users, int(resp.Total), nil
+}
+
+// GetRecentActivity 获取用户近期活动（设备登录 + 播放记录），最多返回limit条
+func (s *UserAdminService) GetRecentActivity(ctx context.Context, userID string, limit int) ([]*domain.EndUserActivity, error) {
+	devices, err := s.listDevices(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	history, err := s.users.ListPlayHistory(ctx, &userv1.ListPlayHistoryRequest{UserId: userID, Page: 1, PageSize: int32(limit)})
+	if err != nil {
+		return nil, fmt.Errorf("list play history: %w", err)
+	}
+
+	activities := make([]*domain.EndUserActivity, 0, len(devices)+len(history.History))
+	for _, d := range devices {
+		activities = append(activities, &domain.EndUserActivity{
+			Type:       domain.ActivityLogin,
+			DeviceID:   d.ID,
+			Platform:   d.Platform,
+			IPAddress:  d.IPAddress,
+			OccurredAt: d.LastActive,
+		})
+	}
+	for _, h := range history.History {
+		activities = append(activities, &domain.EndUserActivity{
+			Type:       domain.ActivityPlay,
+			SongID:     h.SongId,
+			SongName:   h.SongName,
+			ArtistName: h.ArtistName,
+			Duration:   int(h.Duration),
+			OccurredAt: h.PlayedAt.AsTime(),
+		})
+	}
+
+	sort.Slice(activities, func(i, j int) bool {
+		return activities[i].OccurredAt.After(activities[j].OccurredAt)
+	})
+	if len(activities) > limit {
+		activities = activities[:limit]
+	}
+	return activities, nil
+}
+
+// DisableUser 禁用用户并撤销其全部Token，返回失效的设备会话数
+func (s *UserAdminService) DisableUser(ctx context.Context, actor *domain.AdminActor, userID, reason string) (int, error) {
+	start := s.now()
+	before, err := s.auth.GetUser(ctx, &authv1.GetUserRequest{UserId: userID})
+	if err != nil {
+		return 0, fmt.Errorf("get user: %w", err)
+	}
+
+	resp, err := s.auth.DisableUser(ctx, &authv1.DisableUserRequest{UserId: userID, Reason: reason})
+	details := &domain.OperationDetails{
+		Before: map[string]interface{}{"disabled": before.User.Disabled},
+		Reason: reason,
+	}
+	if err == nil {
+		details.After = map[string]interface{}{"disabled": true, "tokens_revoked": resp.TokensRevoked}
+	}
+	s.audit.Record(ctx, actor, domain.OpUserManagement, domain.ResourceUser, userID, domain.ActionDisable, details, start, err)
+	if err != nil {
+		return 0, fmt.Errorf("disable user: %w", err)
+	}
+	return int(resp.TokensRevoked), nil
+}
+
+// EnableUser 重新启用被禁用的用户
+func (s *UserAdminService) EnableUser(ctx context.Context, actor *domain.AdminActor, userID string) error {
+	start := s.now()
+	before, err := s.auth.GetUser(ctx, &authv1.GetUserRequest{UserId: userID})
+	if err != nil {
+		return fmt.Errorf("get user: %w", err)
+	}
+
+	_, err = s.auth.EnableUser(ctx, &authv1.EnableUserRequest{UserId: userID})
+	details := &domain.OperationDetails{
+		Before: map[string]interface{}{"disabled": before.User.Disabled},
+	}
+	if err == nil {
+		details.After = map[string]interface{}{"disabled": false}
+	}
+	s.audit.Record(ctx, actor, domain.OpUserManagement, domain.ResourceUser, userID, domain.ActionEnable, details, start, err)
+	if err != nil {
+		return fmt.Errorf("enable user: %w", err)
+	}
+	return nil
+}
+
+// RevokeSessions 撤销用户全部会话（强制所有设备下线，不禁用账号），返回失效的设备会话数
+func (s *UserAdminService) RevokeSessions(ctx context.Context, actor *domain.AdminActor, userID, reason string) (int, error) {
+	start := s.now()
+	before, err := s.auth.GetUser(ctx, &authv1.GetUserRequest{UserId: userID})
+	if err != nil {
+		return 0, fmt.Errorf("get user: %w", err)
+	}
+
+	resp, err := s.auth.RevokeUserSessions(ctx, &authv1.RevokeUserSessionsRequest{UserId: userID, Reason: reason})
+	details := &domain.OperationDetails{
+		Before: map[string]interface{}{"token_version": before.User.TokenVersion},
+		Reason: reason,
+	}
+	if err == nil {
+		details.After = map[string]interface{}{"token_version": before.User.TokenVersion + 1, "tokens_revoked": resp.TokensRevoked}
+	}
+	s.audit.Record(ctx, actor, domain.OpUserManagement, domain.ResourceUser, userID, domain.ActionRevokeSessions, details, start, err)
+	if err != nil {
+		return 0, fmt.Errorf("revoke sessions: %w", err)
+	}
+	return int(resp.TokensRevoked), nil
+}
+
+// RemoveDevice 移除用户的登录设备（该用户所有设备上的Token同时失效）
+func (s *UserAdminService) RemoveDevice(ctx context.Context, actor *domain.AdminActor, userID, deviceID, reason string) error {
+	start := s.now()
+	devices, err := s.listDevices(ctx, userID)
+	if err != nil {
+		return err
+	}
+	var device *domain.EndUserDevice
+	for _, d := range devices {
+		if d.ID == deviceID {
+			device = d
+			break
+		}
+	}
+	if device == nil {
+		return ErrDeviceNotFound
+	}
+
+	_, err = s.auth.RevokeDevice(ctx, &authv1.RevokeDeviceRequest{UserId: userID, DeviceId: deviceID, Reason: reason})
+	details := &domain.OperationDetails{
+		Before: map[string]interface{}{
+			"device_id":   device.ID,
+			"platform":    device.Platform,
+			"ip_address":  device.IPAddress,
+			"last_active": device.LastActive,
+			"devices":     len(devices),
+		},
+		Reason: reason,
+	}
+	if err == nil {
+		details.After = map[string]interface{}{"devices": len(devices) - 1}
+	}
+	s.audit.Record(ctx, actor, domain.OpUserManagement, domain.ResourceUser, userID, domain.ActionRemoveDevice, details, start, err)
+	if err != nil {
+		return fmt.Errorf("remove device: %w", err)
+	}
+	return nil
+}
+
+// listDevices 获取用户的登录设备
+func (s *UserAdminService) listDevices(ctx context.Context, userID string) ([]*domain.EndUserDevice, error) {
+	resp, err := s.auth.GetUserDevices(ctx, &authv1.GetUserDevicesRequest{UserId: userID})
+	if err != nil {
+		return nil, fmt.Errorf("get user devices: %w", err)
+	}
+	devices := make([]*domain.EndUserDevice, 0, len(resp.Devices))
+	for _, d := range resp.Devices {
+		devices = append(devices, &domain.EndUserDevice{
+			ID:         d.Id,
+			Platform:   platformName(d.Platform),
+			IPAddress:  d.IpAddress,
+			LastActive: d.LastActive.AsTime(),
+			CreatedAt:  d.CreatedAt.AsTime(),
+		})
+	}
+	return devices, nil
+}
+
+// getUsage 从user-svc汇总用户的使用统计
+func (s *UserAdminService) getUsage(ctx context.Context, userID string) (*domain.EndUserUsage, error) {
+	favorites, err := s.users.ListFavorites(ctx, &userv1.ListFavoritesRequest{UserId: userID, Page: 1, PageSize: 1})
+	if err != nil {
+		return nil, fmt.Errorf("count favorites: %w", err)
+	}
+	playlists, err := s.users.ListPlaylists(ctx, &userv1.ListPlaylistsRequest{UserId: userID})
+	if err != nil {
+		return nil, fmt.Errorf("count playlists: %w", err)
+	}
+	history, err := s.users.ListPlayHistory(ctx, &userv1.ListPlayHistoryRequest{UserId: userID, Page: 1, PageSize: 1})
+	if err != nil {
+		return nil, fmt.Errorf("count play history: %w", err)
+	}
+
+	// 听歌统计单次最多查询366天，这里取最近一年
+	today := s.now()
+	stats, err := s.users.GetListeningStats(ctx, &userv1.GetListeningStatsRequest{
+		UserId:   userID,
+		FromDate: today.AddDate(0, 0, -364).Format("2006-01-02"),
+		ToDate:   today.Format("2006-01-02"),
+		TopLimit: 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get listening stats: %w", err)
+	}
+
+	return &domain.EndUserUsage{
+		FavoriteCount: int64(favorites.Total),
+		PlaylistCount: int64(len(playlists.Playlists)),
+		HistoryCount:  int64(history.Total),
+		TotalPlayTime: stats.GetSummary().GetSecondsListened(),
+	}, nil
+}
+
+// UpstreamStatus 提取下游gRPC调用返回的状态（错误可能被多层包装）
+func UpstreamStatus(err error) (*status.Status, bool) {
+	var se interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &se) {
+		return se.GRPCStatus(), true
+	}
+	return nil, false
+}
+
+// endUserFromProto 转换auth-svc的用户
+func endUserFromProto(user *authv1.User) *domain.EndUser {
+	role := "user"
+	if user.Role == authv1.UserRole_USER_ROLE_ADMIN || user.Role == authv1.UserRole_USER_ROLE_SUPER_ADMIN {
+		role = "admin"
+	}
+	return &domain.EndUser{
+		ID:           user.Id,
+		Phone:        user.Phone,
+		Role:         role,
+		Disabled:     user.Disabled,
+		TokenVersion: int(user.TokenVersion),
+		CreatedAt:    user.CreatedAt.AsTime(),
+	}
+}
+
+// platformName 设备平台名称
+func platformName(platform authv1.Platform) string {
+	switch platform {
+	case authv1.Platform_PLATFORM_IOS:
+		return "iOS"
+	case authv1.Platform_PLATFORM_ANDROID:
+		return "Android"
+	case authv1.Platform_PLATFORM_WEB:
+		return "Web"
+	case authv1.Platform_PLATFORM_DESKTOP:
+		return "Desktop"
+	case authv1.Platform_PLATFORM_TV:
+		return "TV"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,293 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"admin-svc/internal/domain"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authv1 "github.com/xiaoxiao0301/listen-stream-v2/server/shared/proto/auth/v1"
+	userv1 "github.com/xiaoxiao0301/listen-stream-v2/server/shared/proto/user/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeAuthClient auth-svc客户端（只实现用户管理用到的方法）
+type fakeAuthClient struct {
+	authv1.AuthServiceClient
+	users       map[string]*authv1.User
+	devices     map[string][]*authv1.Device
+	getRequests []*authv1.GetUserRequest
+	mutateErr   error
+}
+
+func (c *fakeAuthClient) GetUser(ctx context.Context, req *authv1.GetUserRequest, opts ...grpc.CallOption) (*authv1.GetUserResponse, error) {
+	c.getRequests = append(c.getRequests, req)
+	for _, u := range c.users {
+		if (req.UserId != "" && u.Id == req.UserId) || (req.Phone != "" && u.Phone == req.Phone) {
+			// 与真实gRPC调用一样返回副本
+			return &authv1.GetUserResponse{User: proto.Clone(u).(*authv1.User)}, nil
+		}
+	}
+	return nil, status.Error(codes.NotFound, "user not found")
+}
+
+func (c *fakeAuthClient) GetUserDevices(ctx context.Context, req *authv1.GetUserDevicesRequest, opts ...grpc.CallOption) (*authv1.GetUserDevicesResponse, error) {
+	return &authv1.GetUserDevicesResponse{Devices: c.devices[req.UserId]}, nil
+}
+
+func (c *fakeAuthClient) DisableUser(ctx context.Context, req *authv1.DisableUserRequest, opts ...grpc.CallOption) (*authv1.DisableUserResponse, error) {
+	if c.mutateErr != nil {
+		return nil, c.mutateErr
+	}
+	c.users[req.UserId].Disabled = true
+	return &authv1.DisableUserResponse{Success: true, TokensRevoked: int32(len(c.devices[req.UserId]))}, nil
+}
+
+func (c *fakeAuthClient) EnableUser(ctx context.Context, req *authv1.EnableUserRequest, opts ...grpc.CallOption) (*authv1.EnableUserResponse, error) {
+	if c.mutateErr != nil {
+		return nil, c.mutateErr
+	}
+	c.users[req.UserId].Disabled = false
+	return &authv1.EnableUserResponse{Success: true}, nil
+}
+
+func (c *fakeAuthClient) RevokeUserSessions(ctx context.Context, req *authv1.RevokeUserSessionsRequest, opts ...grpc.CallOption) (*authv1.RevokeUserSessionsResponse, error) {
+	if c.mutateErr != nil {
+		return nil, c.mutateErr
+	}
+	c.users[req.UserId].TokenVersion++
+	return &authv1.RevokeUserSessionsResponse{Success: true, TokensRevoked: int32(len(c.devices[req.UserId]))}, nil
+}
+
+func (c *fakeAuthClient) RevokeDevice(ctx context.Context, req *authv1.RevokeDeviceRequest, opts ...grpc.CallOption) (*authv1.RevokeDeviceResponse, error) {
+	if c.mutateErr != nil {
+		return nil, c.mutateErr
+	}
+	return &authv1.RevokeDeviceResponse{Success: true}, nil
+}
+
+// fakeUserClient user-svc客户端（只实现使用统计和播放记录）
+type fakeUserClient struct {
+	userv1.UserServiceClient
+	history []*userv1.PlayHistory
+}
+
+func (c *fakeUserClient) ListFavorites(ctx context.Context, req *userv1.ListFavoritesRequest, opts ...grpc.CallOption) (*userv1.ListFavoritesResponse, error) {
+	return &userv1.ListFavoritesResponse{Total: 7}, nil
+}
+
+func (c *fakeUserClient) ListPlaylists(ctx context.Context, req *userv1.ListPlaylistsRequest, opts ...grpc.CallOption) (*userv1.ListPlaylistsResponse, error) {
+	return &userv1.ListPlaylistsResponse{Playlists: []*userv1.Playlist{{Id: "p1"}, {Id: "p2"}}}, nil
+}
+
+func (c *fakeUserClient) ListPlayHistory(ctx context.Context, req *userv1.ListPlayHistoryRequest, opts ...grpc.CallOption) (*userv1.ListPlayHistoryResponse, error) {
+	history := c.history
+	if int(req.PageSize) < len(history) {
+		history = history[:req.PageSize]
+	}
+	return &userv1.ListPlayHistoryResponse{History: history, Total: int32(len(c.history))}, nil
+}
+
+func (c *fakeUserClient) GetListeningStats(ctx context.Context, req *userv1.GetListeningStatsRequest, opts ...grpc.CallOption) (*userv1.GetListeningStatsResponse, error) {
+	return &userv1.GetListeningStatsResponse{Summary: &userv1.ListeningSummary{SecondsListened: 3600}}, nil
+}
+
+var consoleActor = &domain.AdminActor{AdminID: "admin-1", AdminName: "root"}
+
+type userAdminTestEnv struct {
+	service *UserAdminService
+	auth    *fakeAuthClient
+	users   *fakeUserClient
+	audit   *AuditService
+	base    time.Time
+}
+
+func newUserAdminTestEnv(t *testing.T) *userAdminTestEnv {
+	mr := miniredis.RunT(t)
+	base := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	at := func(minutes int) *timestamppb.Timestamp {
+		return timestamppb.New(base.Add(time.Duration(minutes) * time.Minute))
+	}
+	env := &userAdminTestEnv{
+		auth: &fakeAuthClient{
+			users: map[string]*authv1.User{
+				"user-1": {Id: "user-1", Phone: "13800138000", TokenVersion: 3, CreatedAt: at(-1000)},
+			},
+			devices: map[string][]*authv1.Device{
+				"user-1": {
+					{Id: "dev-ios", Platform: authv1.Platform_PLATFORM_IOS, IpAddress: "10.0.0.1", LastActive: at(-30), CreatedAt: at(-900)},
+					{Id: "dev-web", Platform: authv1.Platform_PLATFORM_WEB, IpAddress: "10.0.0.2", LastActive: at(-5), CreatedAt: at(-800)},
+				},
+			},
+		},
+		users: &fakeUserClient{
+			history: []*userv1.PlayHistory{
+				{SongId: "s1", SongName: "one", Duration: 200, PlayedAt: at(-1)},
+				{SongId: "s2", SongName: "two", Duration: 180, PlayedAt: at(-10)},
+				{SongId: "s3", SongName: "three", Duration: 240, PlayedAt: at(-40)},
+			},
+		},
+		audit: NewAuditService(redis.NewClient(&redis.Options{Addr: mr.Addr()}), nil, nil, nil),
+		base:  base,
+	}
+	env.service = NewUserAdminService(env.auth, env.users, env.audit)
+	env.service.now = func() time.Time { return base }
+	return env
+}
+
+// lastDetails 返回最近一条操作日志及其变更详情
+func (env *userAdminTestEnv) lastDetails(t *testing.T) (domain.OperationLog, *domain.OperationDetails) {
+	t.Helper()
+	logs, _, err := env.audit.ListOperationLogs(context.Background(), nil, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	details, err := domain.UnmarshalDetails(logs[0].Details)
+	require.NoError(t, err)
+	return logs[0], details
+}
+
+// TestGetUserDetail_PhoneOrID 测试11位手机号按手机号查询，其余按用户ID查询
+func TestGetUserDetail_PhoneOrID(t *testing.T) {
+	env := newUserAdminTestEnv(t)
+	ctx := context.Background()
+
+	detail, err := env.service.GetUserDetail(ctx, "13800138000")
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", detail.User.ID)
+	assert.Equal(t, &authv1.GetUserRequest{Phone: "13800138000"}, env.auth.getRequests[0])
+
+	detail, err = env.service.GetUserDetail(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", detail.User.ID)
+	assert.Equal(t, &authv1.GetUserRequest{UserId: "user-1"}, env.auth.getRequests[1])
+
+	// 位数不对的数字按用户ID查询
+	_, err = env.service.GetUserDetail(ctx, "1380013800")
+	st, ok := UpstreamStatus(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.NotFound, st.Code())
+	assert.Equal(t, &authv1.GetUserRequest{UserId: "1380013800"}, env.auth.getRequests[2])
+
+	require.Len(t, detail.Devices, 2)
+	assert.Equal(t, "iOS", detail.Devices[0].Platform)
+	require.NotNil(t, detail.User.LastLoginAt)
+	assert.Equal(t, env.base.Add(-5*time.Minute), detail.User.LastLoginAt.UTC())
+	assert.Equal(t, &domain.EndUserUsage{FavoriteCount: 7, PlaylistCount: 2, HistoryCount: 3, TotalPlayTime: 3600}, detail.Usage)
+}
+
+// TestGetRecentActivity_MergeSortTruncate 测试设备登录和播放记录按时间倒序合并并截断
+func TestGetRecentActivity_MergeSortTruncate(t *testing.T) {
+	env := newUserAdminTestEnv(t)
+	ctx := context.Background()
+
+	activities, err := env.service.GetRecentActivity(ctx, "user-1", 10)
+	require.NoError(t, err)
+	require.Len(t, activities, 5)
+
+	var order []string
+	for i, a := range activities {
+		if i > 0 {
+			assert.False(t, a.OccurredAt.After(activities[i-1].OccurredAt))
+		}
+		order = append(order, fmt.Sprintf("%s:%s%s", a.Type, a.DeviceID, a.SongID))
+	}
+	assert.Equal(t, []string{
+		domain.ActivityPlay + ":s1",
+		domain.ActivityLogin + ":dev-web",
+		domain.ActivityPlay + ":s2",
+		domain.ActivityLogin + ":dev-ios",
+		domain.ActivityPlay + ":s3",
+	}, order)
+	assert.Equal(t, "Web", activities[1].Platform)
+	assert.Equal(t, 200, activities[0].Duration)
+
+	activities, err = env.service.GetRecentActivity(ctx, "user-1", 3)
+	require.NoError(t, err)
+	require.Len(t, activities, 3)
+	assert.Equal(t, "s1", activities[0].SongID)
+	assert.Equal(t, "dev-web", activities[1].DeviceID)
+	assert.Equal(t, "s2", activities[2].SongID)
+}
+
+// TestDisableEnableUser_AuditDetails 测试禁用和启用用户的审计日志记录变更前后状态
+func TestDisableEnableUser_AuditDetails(t *testing.T) {
+	env := newUserAdminTestEnv(t)
+	ctx := context.Background()
+
+	revoked, err := env.service.DisableUser(ctx, consoleActor, "user-1", "spam")
+	require.NoError(t, err)
+	assert.Equal(t, 2, revoked)
+
+	log, details := env.lastDetails(t)
+	assert.Equal(t, domain.ActionDisable, log.Action)
+	assert.Equal(t, domain.StatusSuccess, log.Status)
+	assert.Equal(t, "user-1", log.ResourceID)
+	assert.Equal(t, "spam", details.Reason)
+	assert.Equal(t, map[string]interface{}{"disabled": false}, details.Before)
+	assert.Equal(t, map[string]interface{}{"disabled": true, "tokens_revoked": float64(2)}, details.After)
+
+	require.NoError(t, env.service.EnableUser(ctx, consoleActor, "user-1"))
+	log, details = env.lastDetails(t)
+	assert.Equal(t, domain.ActionEnable, log.Action)
+	assert.Equal(t, map[string]interface{}{"disabled": true}, details.Before)
+	assert.Equal(t, map[string]interface{}{"disabled": false}, details.After)
+
+	// 下游失败时只记录变更前状态
+	env.auth.mutateErr = status.Error(codes.Unavailable, "auth-svc down")
+	_, err = env.service.DisableUser(ctx, consoleActor, "user-1", "spam")
+	require.Error(t, err)
+	log, details = env.lastDetails(t)
+	assert.Equal(t, domain.ActionDisable, log.Action)
+	assert.Equal(t, domain.StatusFailed, log.Status)
+	assert.Equal(t, map[string]interface{}{"disabled": false}, details.Before)
+	assert.Nil(t, details.After)
+}
+
+// TestRevokeSessions_AuditDetails 测试撤销会话的审计日志记录Token版本变化
+func TestRevokeSessions_AuditDetails(t *testing.T) {
+	env := newUserAdminTestEnv(t)
+	ctx := context.Background()
+
+	revoked, err := env.service.RevokeSessions(ctx, consoleActor, "user-1", "stolen phone")
+	require.NoError(t, err)
+	assert.Equal(t, 2, revoked)
+
+	log, details := env.lastDetails(t)
+	assert.Equal(t, domain.ActionRevokeSessions, log.Action)
+	assert.Equal(t, "stolen phone", details.Reason)
+	assert.Equal(t, map[string]interface{}{"token_version": float64(3)}, details.Before)
+	assert.Equal(t, map[string]interface{}{"token_version": float64(4), "tokens_revoked": float64(2)}, details.After)
+	assert.False(t, env.auth.users["user-1"].Disabled)
+}
+
+// TestRemoveDevice_AuditDetails 测试移除设备的审计日志记录被移除的设备，不属于该用户的设备直接拒绝
+func TestRemoveDevice_AuditDetails(t *testing.T) {
+	env := newUserAdminTestEnv(t)
+	ctx := context.Background()
+
+	err := env.service.RemoveDevice(ctx, consoleActor, "user-1", "dev-other", "")
+	assert.ErrorIs(t, err, ErrDeviceNotFound)
+	logs, _, err := env.audit.ListOperationLogs(ctx, nil, 1, 20)
+	require.NoError(t, err)
+	assert.Empty(t, logs)
+
+	require.NoError(t, env.service.RemoveDevice(ctx, consoleActor, "user-1", "dev-ios", "lost"))
+	log, details := env.lastDetails(t)
+	assert.Equal(t, domain.ActionRemoveDevice, log.Action)
+	assert.Equal(t, "lost", details.Reason)
+	assert.Equal(t, "dev-ios", details.Before["device_id"])
+	assert.Equal(t, "iOS", details.Before["platform"])
+	assert.Equal(t, "10.0.0.1", details.Before["ip_address"])
+	assert.Equal(t, float64(2), details.Before["devices"])
+	assert.Equal(t, map[string]interface{}{"devices": float64(1)}, details.After)
+}
@@ -0,0 +1,206 @@
+// Package webauthn 管理员安全密钥（WebAuthn/FIDO2）的注册和断言校验
+//
+// 基于go-webauthn：注册时解析CBOR编码的attestationObject，按证明格式（none、packed、
+// tpm、android-key、fido-u2f、apple）校验证明，公钥取自认证器数据中的COSE密钥；
+// 登录时用保存的COSE公钥校验签名，并检查备份资格标志和签名计数。
+// 浏览器返回的凭证按WebAuthn标准的JSON格式提交（PublicKeyCredential.toJSON()）。
+package webauthn
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/protocol/webauthncbor"
+	"github.com/go-webauthn/webauthn/protocol/webauthncose"
+	gowebauthn "github.com/go-webauthn/webauthn/webauthn"
+)
+
+var (
+	// ErrInvalidResponse 浏览器返回的凭证数据不合法或与挑战不匹配
+	ErrInvalidResponse = errors.New("invalid webauthn response")
+	// ErrSignCountRegression 签名计数没有增加，安全密钥可能被复制
+	ErrSignCountRegression = errors.New("webauthn signature counter did not increase")
+)
+
+type (
+	// CreationOptions navigator.credentials.create()的publicKey参数
+	CreationOptions = protocol.PublicKeyCredentialCreationOptions
+	// RequestOptions navigator.credentials.get()的publicKey参数
+	RequestOptions = protocol.PublicKeyCredentialRequestOptions
+	// AttestationResponse 注册安全密钥时浏览器返回的PublicKeyCredential（JSON）
+	AttestationResponse = json.RawMessage
+	// AssertionResponse 使用安全密钥登录时浏览器返回的PublicKeyCredential（JSON）
+	AssertionResponse = json.RawMessage
+	// Session 一次注册或登录的挑战和参数，在Begin和Finish之间保存
+	Session = gowebauthn.SessionData
+)
+
+// Config 依赖方配置
+type Config struct {
+	RPID    string        // 依赖方ID，即管理后台域名（如admin.example.com）
+	RPName  string        // 安全密钥提示中显示的名称
+	Origins []string      // 允许的页面来源（如https://admin.example.com）
+	Timeout time.Duration // 浏览器等待用户操作安全密钥的时间，为0时使用默认值
+}
+
+// Credential 已注册的凭证
+type Credential struct {
+	ID             []byte
+	PublicKey      []byte // COSE_Key（认证器数据中的凭证公钥）
+	Algorithm      int    // COSE算法标识
+	SignCount      uint32
+	BackupEligible bool // 凭证是否可以同步备份（通行密钥），登录时必须与认证器数据一致
+}
+
+// User 安全密钥所属的管理员
+type User struct {
+	ID          []byte
+	Name        string
+	Credentials []Credential
+}
+
+// RelyingParty 依赖方，负责生成参数和校验浏览器返回的凭证
+type RelyingParty struct {
+	rp *gowebauthn.WebAuthn
+}
+
+// New 创建依赖方
+func New(c Config) (*RelyingParty, error) {
+	cfg := &gowebauthn.Config{
+		RPID:          c.RPID,
+		RPDisplayName: c.RPName,
+		RPOrigins:     c.Origins,
+		// 要求安全密钥提供证明，按格式校验证明签名（不校验厂商证书链）
+		AttestationPreference: protocol.PreferDirectAttestation,
+		// 密码已经是第一因素，安全密钥只要求用户在场，PIN/指纹可选
+		AuthenticatorSelection: protocol.AuthenticatorSelection{
+			ResidentKey:      protocol.ResidentKeyRequirementDiscouraged,
+			UserVerification: protocol.VerificationPreferred,
+		},
+	}
+	if c.Timeout > 0 {
+		timeout := gowebauthn.TimeoutConfig{Enforce: true, Timeout: c.Timeout, TimeoutUVD: c.Timeout}
+		cfg.Timeouts = gowebauthn.TimeoutsConfig{Login: timeout, Registration: timeout}
+	}
+	rp, err := gowebauthn.New(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("create webauthn relying party: %w", err)
+	}
+	return &RelyingParty{rp: rp}, nil
+}
+
+// BeginRegistration 生成注册参数，已注册的凭证不能重复注册
+func (r *RelyingParty) BeginRegistration(user *User) (*CreationOptions, *Session, error) {
+	creation, session, err := r.rp.BeginRegistration(webAuthnUser{user},
+		gowebauthn.WithExclusions(gowebauthn.Credentials(user.credentials()).CredentialDescriptors()),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("begin webauthn registration: %w", err)
+	}
+	return &creation.Response, session, nil
+}
+
+// FinishRegistration 校验注册响应（挑战、来源、依赖方、证明），返回新凭证
+func (r *RelyingParty) FinishRegistration(user *User, session *Session, resp AttestationResponse) (*Credential, error) {
+	parsed, err := protocol.ParseCredentialCreationResponseBytes(resp)
+	if err != nil {
+		return nil, invalidResponse(err)
+	}
+	cred, err := r.rp.CreateCredential(webAuthnUser{user}, *session, parsed)
+	if err != nil {
+		return nil, invalidResponse(err)
+	}
+
+	var key webauthncose.PublicKeyData
+	if err := webauthncbor.Unmarshal(cred.PublicKey, &key); err != nil {
+		return nil, invalidResponse(err)
+	}
+	return &Credential{
+		ID:             cred.ID,
+		PublicKey:      cred.PublicKey,
+		Algorithm:      int(key.Algorithm),
+		SignCount:      cred.Authenticator.SignCount,
+		BackupEligible: cred.Flags.BackupEligible,
+	}, nil
+}
+
+// BeginLogin 生成登录断言参数，只允许使用管理员已注册的凭证
+func (r *RelyingParty) BeginLogin(user *User) (*RequestOptions, *Session, error) {
+	assertion, session, err := r.rp.BeginLogin(webAuthnUser{user})
+	if err != nil {
+		return nil, nil, fmt.Errorf("begin webauthn login: %w", err)
+	}
+	return &assertion.Response, session, nil
+}
+
+// FinishLogin 校验登录断言，返回使用的凭证（SignCount为新的签名计数）
+// 安全密钥和已保存的计数都不为0且计数没有增加时返回ErrSignCountRegression
+func (r *RelyingParty) FinishLogin(user *User, session *Session, resp AssertionResponse) (*Credential, error) {
+	parsed, err := protocol.ParseCredentialRequestResponseBytes(resp)
+	if err != nil {
+		return nil, invalidResponse(err)
+	}
+	cred, err := r.rp.ValidateLogin(webAuthnUser{user}, *session, parsed)
+	if err != nil {
+		return nil, invalidResponse(err)
+	}
+	if cred.Authenticator.CloneWarning {
+		return nil, ErrSignCountRegression
+	}
+
+	for _, c := range user.Credentials {
+		if string(c.ID) == string(cred.ID) {
+			c.SignCount = cred.Authenticator.SignCount
+			return &c, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: unknown credential", ErrInvalidResponse)
+}
+
+// invalidResponse 把go-webauthn的错误包装为ErrInvalidResponse，保留详细原因
+func invalidResponse(err error) error {
+	var perr *protocol.Error
+	if errors.As(err, &perr) && perr.DevInfo != "" {
+		return fmt.Errorf("%w: %s: %s", ErrInvalidResponse, perr.Details, perr.DevInfo)
+	}
+	return fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+}
+
+func (u *User) credentials() []gowebauthn.Credential {
+	creds := make([]gowebauthn.Credential, 0, len(u.Credentials))
+	for _, c := range u.Credentials {
+		creds = append(creds, gowebauthn.Credential{
+			ID:        c.ID,
+			PublicKey: c.PublicKey,
+			Flags:     gowebauthn.CredentialFlags{BackupEligible: c.BackupEligible},
+			Authenticator: gowebauthn.Authenticator{
+				SignCount: c.SignCount,
+			},
+		})
+	}
+	return creds
+}
+
+// webAuthnUser 实现go-webauthn的User接口
+type webAuthnUser struct {
+	*User
+}
+
+func (u webAuthnUser) WebAuthnID() []byte {
+	return u.ID
+}
+
+func (u webAuthnUser) WebAuthnName() string {
+	return u.Name
+}
+
+func (u webAuthnUser) WebAuthnDisplayName() string {
+	return u.Name
+}
+
+func (u webAuthnUser) WebAuthnCredentials() []gowebauthn.Credential {
+	return u.credentials()
+}
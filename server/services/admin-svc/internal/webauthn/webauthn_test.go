@@ -0,0 +1,317 @@
+package webauthn
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-webauthn/webauthn/protocol/webauthncbor"
+	"github.com/go-webauthn/webauthn/protocol/webauthncose"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testRPID   = "admin.example.com"
+	testOrigin = "https://admin.example.com"
+)
+
+// 认证器数据标志位
+const (
+	flagUserPresent  = 0x01
+	flagBackupElig   = 0x08
+	flagAttestedData = 0x40
+)
+
+// softAuthenticator 软件实现的安全密钥（ES256），按WebAuthn规范生成注册和断言响应
+type softAuthenticator struct {
+	id        []byte
+	key       *ecdsa.PrivateKey
+	signCount uint32
+	flags     byte
+}
+
+func newSoftAuthenticator(t *testing.T) *softAuthenticator {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	id := make([]byte, 16)
+	_, err = rand.Read(id)
+	require.NoError(t, err)
+	return &softAuthenticator{id: id, key: key, flags: flagUserPresent}
+}
+
+// coseKey 返回凭证公钥的COSE_Key编码
+func (a *softAuthenticator) coseKey(t *testing.T) []byte {
+	x, y := make([]byte, 32), make([]byte, 32)
+	a.key.X.FillBytes(x)
+	a.key.Y.FillBytes(y)
+	raw, err := webauthncbor.Marshal(webauthncose.EC2PublicKeyData{
+		PublicKeyData: webauthncose.PublicKeyData{KeyType: int64(webauthncose.EllipticKey), Algorithm: int64(webauthncose.AlgES256)},
+		Curve:         int64(webauthncose.P256),
+		XCoord:        x,
+		YCoord:        y,
+	})
+	require.NoError(t, err)
+	return raw
+}
+
+// authData 构造认证器数据：rpIdHash(32) | flags(1) | signCount(4) | [aaguid(16) | credIdLen(2) | credId | 公钥]
+func (a *softAuthenticator) authData(rpID string, flags byte, attested []byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(rpID))
+	data := append([]byte{}, rpIDHash[:]...)
+	data = append(data, flags)
+	data = binary.BigEndian.AppendUint32(data, a.signCount)
+	if attested != nil {
+		data = append(data, make([]byte, 16)...)
+		data = binary.BigEndian.AppendUint16(data, uint16(len(a.id)))
+		data = append(data, a.id...)
+		data = append(data, attested...)
+	}
+	return data
+}
+
+func (a *softAuthenticator) sign(t *testing.T, key *ecdsa.PrivateKey, authData, clientDataJSON []byte) []byte {
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	digest := sha256.Sum256(append(append([]byte{}, authData...), clientDataHash[:]...))
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	require.NoError(t, err)
+	return sig
+}
+
+// attestation 对注册参数生成响应，format为none或packed（自证明，signer为nil时用凭证私钥签名）
+func (a *softAuthenticator) attestation(t *testing.T, opts *CreationOptions, origin, format string, signer *ecdsa.PrivateKey) AttestationResponse {
+	clientDataJSON := clientData(t, "webauthn.create", opts.Challenge, origin)
+	authData := a.authData(opts.RelyingParty.ID, a.flags|flagAttestedData, a.coseKey(t))
+
+	attStmt := map[string]interface{}{}
+	if format == "packed" {
+		if signer == nil {
+			signer = a.key
+		}
+		attStmt["alg"] = int64(webauthncose.AlgES256)
+		attStmt["sig"] = a.sign(t, signer, authData, clientDataJSON)
+	}
+	attestationObject, err := webauthncbor.Marshal(map[string]interface{}{
+		"fmt":      format,
+		"attStmt":  attStmt,
+		"authData": authData,
+	})
+	require.NoError(t, err)
+
+	return credentialJSON(t, a.id, map[string]string{
+		"clientDataJSON":    b64(clientDataJSON),
+		"attestationObject": b64(attestationObject),
+	})
+}
+
+// assertion 对登录参数生成断言，每次签名计数加一
+func (a *softAuthenticator) assertion(t *testing.T, opts *RequestOptions, origin string) AssertionResponse {
+	a.signCount++
+	clientDataJSON := clientData(t, "webauthn.get", opts.Challenge, origin)
+	authData := a.authData(opts.RelyingPartyID, a.flags, nil)
+	return credentialJSON(t, a.id, map[string]string{
+		"clientDataJSON":    b64(clientDataJSON),
+		"authenticatorData": b64(authData),
+		"signature":         b64(a.sign(t, a.key, authData, clientDataJSON)),
+	})
+}
+
+func clientData(t *testing.T, typ string, challenge []byte, origin string) []byte {
+	raw, err := json.Marshal(map[string]string{"type": typ, "challenge": b64(challenge), "origin": origin})
+	require.NoError(t, err)
+	return raw
+}
+
+func credentialJSON(t *testing.T, id []byte, response map[string]string) json.RawMessage {
+	raw, err := json.Marshal(map[string]interface{}{
+		"id":       b64(id),
+		"rawId":    b64(id),
+		"type":     "public-key",
+		"response": response,
+	})
+	require.NoError(t, err)
+	return raw
+}
+
+func b64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func newTestRelyingParty(t *testing.T) *RelyingParty {
+	rp, err := New(Config{RPID: testRPID, RPName: "Listen Stream Admin", Origins: []string{testOrigin}})
+	require.NoError(t, err)
+	return rp
+}
+
+// register 注册安全密钥并返回保存的凭证
+func register(t *testing.T, rp *RelyingParty, user *User, key *softAuthenticator, format string) *Credential {
+	opts, session, err := rp.BeginRegistration(user)
+	require.NoError(t, err)
+	cred, err := rp.FinishRegistration(user, session, key.attestation(t, opts, testOrigin, format, nil))
+	require.NoError(t, err)
+	return cred
+}
+
+// TestRegistration 测试none和packed自证明注册，公钥取自认证器数据
+func TestRegistration(t *testing.T) {
+	for _, format := range []string{"none", "packed"} {
+		t.Run(format, func(t *testing.T) {
+			rp := newTestRelyingParty(t)
+			key := newSoftAuthenticator(t)
+			user := &User{ID: []byte("admin-1"), Name: "root"}
+
+			cred := register(t, rp, user, key, format)
+			assert.Equal(t, key.id, cred.ID)
+			assert.Equal(t, key.coseKey(t), cred.PublicKey)
+			assert.Equal(t, int(webauthncose.AlgES256), cred.Algorithm)
+			assert.False(t, cred.BackupEligible)
+		})
+	}
+}
+
+// TestRegistration_Options 测试注册参数排除已注册的凭证
+func TestRegistration_Options(t *testing.T) {
+	rp := newTestRelyingParty(t)
+	existing := newSoftAuthenticator(t)
+	user := &User{ID: []byte("admin-1"), Name: "root", Credentials: []Credential{{ID: existing.id}}}
+
+	opts, session, err := rp.BeginRegistration(user)
+	require.NoError(t, err)
+	assert.Equal(t, testRPID, opts.RelyingParty.ID)
+	require.Len(t, opts.CredentialExcludeList, 1)
+	assert.Equal(t, existing.id, []byte(opts.CredentialExcludeList[0].CredentialID))
+	assert.Equal(t, []byte("admin-1"), session.UserID)
+	assert.Equal(t, b64(opts.Challenge), session.Challenge)
+}
+
+// TestRegistration_Rejected 测试注册响应不合法时拒绝
+func TestRegistration_Rejected(t *testing.T) {
+	rp := newTestRelyingParty(t)
+	user := &User{ID: []byte("admin-1"), Name: "root"}
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		respond func(key *softAuthenticator, opts *CreationOptions) AttestationResponse
+	}{
+		{"packed signed by another key", func(key *softAuthenticator, opts *CreationOptions) AttestationResponse {
+			return key.attestation(t, opts, testOrigin, "packed", other)
+		}},
+		{"wrong origin", func(key *softAuthenticator, opts *CreationOptions) AttestationResponse {
+			return key.attestation(t, opts, "https://evil.example.com", "none", nil)
+		}},
+		{"wrong rp id", func(key *softAuthenticator, opts *CreationOptions) AttestationResponse {
+			opts.RelyingParty.ID = "evil.example.com"
+			return key.attestation(t, opts, testOrigin, "none", nil)
+		}},
+		{"wrong challenge", func(key *softAuthenticator, opts *CreationOptions) AttestationResponse {
+			opts.Challenge = []byte("not the challenge")
+			return key.attestation(t, opts, testOrigin, "none", nil)
+		}},
+		{"user not present", func(key *softAuthenticator, opts *CreationOptions) AttestationResponse {
+			key.flags = 0
+			return key.attestation(t, opts, testOrigin, "none", nil)
+		}},
+		{"malformed", func(key *softAuthenticator, opts *CreationOptions) AttestationResponse {
+			return AttestationResponse(`{"id":"abc"}`)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, session, err := rp.BeginRegistration(user)
+			require.NoError(t, err)
+			_, err = rp.FinishRegistration(user, session, tt.respond(newSoftAuthenticator(t), opts))
+			assert.ErrorIs(t, err, ErrInvalidResponse)
+		})
+	}
+}
+
+// TestLogin 测试用注册的凭证登录并返回新的签名计数
+func TestLogin(t *testing.T) {
+	rp := newTestRelyingParty(t)
+	key := newSoftAuthenticator(t)
+	user := &User{ID: []byte("admin-1"), Name: "root"}
+	user.Credentials = []Credential{*register(t, rp, user, key, "none")}
+
+	for want := uint32(1); want <= 2; want++ {
+		opts, session, err := rp.BeginLogin(user)
+		require.NoError(t, err)
+		require.Len(t, opts.AllowedCredentials, 1)
+
+		cred, err := rp.FinishLogin(user, session, key.assertion(t, opts, testOrigin))
+		require.NoError(t, err)
+		assert.Equal(t, key.id, cred.ID)
+		assert.Equal(t, want, cred.SignCount)
+		user.Credentials[0].SignCount = cred.SignCount
+	}
+}
+
+// TestLogin_Rejected 测试断言不合法时拒绝
+func TestLogin_Rejected(t *testing.T) {
+	rp := newTestRelyingParty(t)
+	key := newSoftAuthenticator(t)
+	user := &User{ID: []byte("admin-1"), Name: "root"}
+	user.Credentials = []Credential{*register(t, rp, user, key, "none")}
+
+	t.Run("wrong origin", func(t *testing.T) {
+		opts, session, err := rp.BeginLogin(user)
+		require.NoError(t, err)
+		_, err = rp.FinishLogin(user, session, key.assertion(t, opts, "https://evil.example.com"))
+		assert.ErrorIs(t, err, ErrInvalidResponse)
+	})
+
+	t.Run("unregistered key", func(t *testing.T) {
+		opts, session, err := rp.BeginLogin(user)
+		require.NoError(t, err)
+		_, err = rp.FinishLogin(user, session, newSoftAuthenticator(t).assertion(t, opts, testOrigin))
+		assert.ErrorIs(t, err, ErrInvalidResponse)
+	})
+
+	t.Run("same credential id with another key", func(t *testing.T) {
+		forged := newSoftAuthenticator(t)
+		forged.id = key.id
+		opts, session, err := rp.BeginLogin(user)
+		require.NoError(t, err)
+		_, err = rp.FinishLogin(user, session, forged.assertion(t, opts, testOrigin))
+		assert.ErrorIs(t, err, ErrInvalidResponse)
+	})
+
+	t.Run("other user's session", func(t *testing.T) {
+		opts, _, err := rp.BeginLogin(user)
+		require.NoError(t, err)
+		otherUser := &User{ID: []byte("admin-2"), Name: "ops", Credentials: user.Credentials}
+		_, session, err := rp.BeginLogin(otherUser)
+		require.NoError(t, err)
+		_, err = rp.FinishLogin(user, session, key.assertion(t, opts, testOrigin))
+		assert.ErrorIs(t, err, ErrInvalidResponse)
+	})
+
+	t.Run("backup eligibility changed", func(t *testing.T) {
+		key.flags = flagUserPresent | flagBackupElig
+		defer func() { key.flags = flagUserPresent }()
+		opts, session, err := rp.BeginLogin(user)
+		require.NoError(t, err)
+		_, err = rp.FinishLogin(user, session, key.assertion(t, opts, testOrigin))
+		assert.ErrorIs(t, err, ErrInvalidResponse)
+	})
+}
+
+// TestLogin_SignCountRegression 测试签名计数没有增加时判断为被复制的安全密钥
+func TestLogin_SignCountRegression(t *testing.T) {
+	rp := newTestRelyingParty(t)
+	key := newSoftAuthenticator(t)
+	user := &User{ID: []byte("admin-1"), Name: "root"}
+	user.Credentials = []Credential{*register(t, rp, user, key, "none")}
+	user.Credentials[0].SignCount = 10
+
+	opts, session, err := rp.BeginLogin(user)
+	require.NoError(t, err)
+	_, err = rp.FinishLogin(user, session, key.assertion(t, opts, testOrigin))
+	assert.ErrorIs(t, err, ErrSignCountRegression)
+}
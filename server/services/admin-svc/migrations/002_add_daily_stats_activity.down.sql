@@ -0,0 +1,6 @@
+-- 002_add_daily_stats_activity.down.sql
+
+ALTER TABLE daily_stats
+    DROP COLUMN IF EXISTS monthly_active_users,
+    DROP COLUMN IF EXISTS weekly_active_users,
+    DROP COLUMN IF EXISTS logins;
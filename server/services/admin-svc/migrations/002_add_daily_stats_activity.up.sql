@@ -0,0 +1,7 @@
+-- 002_add_daily_stats_activity.up.sql
+
+-- 每日统计增加登录次数和周/月活跃用户数
+ALTER TABLE daily_stats
+    ADD COLUMN IF NOT EXISTS logins BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS weekly_active_users BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS monthly_active_users BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,5 @@
+-- 003_create_admin_roles.down.sql
+
+DROP INDEX IF EXISTS idx_admin_users_role;
+ALTER TABLE admin_users ALTER COLUMN role TYPE VARCHAR(20);
+DROP TABLE IF EXISTS admin_roles;
@@ -0,0 +1,15 @@
+-- 003_create_admin_roles.up.sql
+
+-- 自定义角色表（内置角色admin/operator/viewer定义在代码中，不落库）
+CREATE TABLE IF NOT EXISTS admin_roles (
+    name VARCHAR(32) PRIMARY KEY,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    permissions TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- 自定义角色名最长32位
+ALTER TABLE admin_users ALTER COLUMN role TYPE VARCHAR(32);
+
+CREATE INDEX IF NOT EXISTS idx_admin_users_role ON admin_users(role);
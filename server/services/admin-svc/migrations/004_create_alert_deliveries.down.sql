@@ -0,0 +1,8 @@
+-- 004_create_alert_deliveries.down.sql
+
+DROP INDEX IF EXISTS idx_anomalous_activities_unresolved;
+DROP TABLE IF EXISTS alert_delivery_attempts;
+DROP TABLE IF EXISTS alert_deliveries;
+ALTER TABLE anomalous_activities
+    DROP COLUMN IF EXISTS last_escalated_at,
+    DROP COLUMN IF EXISTS escalation_level;
@@ -0,0 +1,37 @@
+-- 004_create_alert_deliveries.up.sql
+
+-- 异常未处理时的升级状态
+ALTER TABLE anomalous_activities
+    ADD COLUMN IF NOT EXISTS escalation_level INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS last_escalated_at TIMESTAMP;
+
+-- 告警投递表（每个异常 × 每个告警通道 × 每次升级一条）
+CREATE TABLE IF NOT EXISTS alert_deliveries (
+    id VARCHAR(36) PRIMARY KEY,
+    anomaly_id VARCHAR(36) NOT NULL REFERENCES anomalous_activities(id) ON DELETE CASCADE,
+    sink VARCHAR(64) NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    escalation_level INT NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP,
+    delivered_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_alert_deliveries_anomaly_id ON alert_deliveries(anomaly_id);
+CREATE INDEX idx_alert_deliveries_due ON alert_deliveries(next_attempt_at) WHERE status = 'pending';
+
+-- 告警投递历史（每次尝试一条）
+CREATE TABLE IF NOT EXISTS alert_delivery_attempts (
+    delivery_id VARCHAR(36) NOT NULL REFERENCES alert_deliveries(id) ON DELETE CASCADE,
+    attempt INT NOT NULL,
+    success BOOLEAN NOT NULL,
+    error TEXT,
+    duration BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (delivery_id, attempt)
+);
+
+CREATE INDEX IF NOT EXISTS idx_anomalous_activities_unresolved ON anomalous_activities(created_at) WHERE resolved = false;
@@ -0,0 +1,10 @@
+-- 005_add_operation_log_chain.down.sql
+
+DROP TABLE IF EXISTS operation_log_checkpoints;
+DROP TABLE IF EXISTS operation_log_chain_head;
+ALTER TABLE operation_logs ALTER COLUMN request_id TYPE VARCHAR(36);
+DROP INDEX IF EXISTS idx_operation_logs_seq;
+ALTER TABLE operation_logs
+    DROP COLUMN IF EXISTS hash,
+    DROP COLUMN IF EXISTS prev_hash,
+    DROP COLUMN IF EXISTS seq;
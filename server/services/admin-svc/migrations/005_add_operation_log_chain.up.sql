@@ -0,0 +1,31 @@
+-- 005_add_operation_log_chain.up.sql
+
+-- 操作日志哈希链：seq连续递增，hash = SHA-256(prev_hash + 日志内容)
+ALTER TABLE operation_logs
+    ADD COLUMN IF NOT EXISTS seq BIGINT,
+    ADD COLUMN IF NOT EXISTS prev_hash CHAR(64),
+    ADD COLUMN IF NOT EXISTS hash CHAR(64);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_operation_logs_seq ON operation_logs(seq);
+
+-- 请求ID来自X-Request-ID请求头，长度不受控
+ALTER TABLE operation_logs ALTER COLUMN request_id TYPE TEXT;
+
+-- 链头（单行），写日志时行锁串行化，保证多实例下序号连续
+CREATE TABLE IF NOT EXISTS operation_log_chain_head (
+    id SMALLINT PRIMARY KEY CHECK (id = 1),
+    last_seq BIGINT NOT NULL,
+    last_hash CHAR(64) NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+INSERT INTO operation_log_chain_head (id, last_seq, last_hash)
+VALUES (1, 0, '0000000000000000000000000000000000000000000000000000000000000000')
+ON CONFLICT (id) DO NOTHING;
+
+-- 签名检查点（Ed25519，私钥不在数据库中）
+CREATE TABLE IF NOT EXISTS operation_log_checkpoints (
+    seq BIGINT PRIMARY KEY,
+    hash CHAR(64) NOT NULL,
+    key_id VARCHAR(16) NOT NULL,
+    signature TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,3 @@
+-- 006_create_export_jobs.down.sql
+
+DROP TABLE IF EXISTS export_jobs;
@@ -0,0 +1,29 @@
+-- 006_create_export_jobs.up.sql
+
+-- 异步导出任务
+CREATE TABLE IF NOT EXISTS export_jobs (
+    id VARCHAR(36) PRIMARY KEY,
+    type VARCHAR(32) NOT NULL,
+    format VARCHAR(16) NOT NULL,
+    filters JSONB NOT NULL DEFAULT '{}',
+    status VARCHAR(20) NOT NULL,
+    total_rows BIGINT NOT NULL DEFAULT 0,
+    processed_rows BIGINT NOT NULL DEFAULT 0,
+    attempts INT NOT NULL DEFAULT 0,
+    storage_key TEXT,
+    file_name TEXT,
+    file_size BIGINT NOT NULL DEFAULT 0,
+    error TEXT,
+    created_by VARCHAR(36) NOT NULL,
+    lease_until TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP,
+    finished_at TIMESTAMP,
+    expires_at TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_export_jobs_created_by ON export_jobs(created_by, created_at DESC);
+-- worker领取任务：排队中的任务和租约过期的执行中任务
+CREATE INDEX idx_export_jobs_claimable ON export_jobs(created_at) WHERE status IN ('queued', 'running');
+-- 清理过期文件
+CREATE INDEX idx_export_jobs_expires_at ON export_jobs(expires_at) WHERE status = 'succeeded';
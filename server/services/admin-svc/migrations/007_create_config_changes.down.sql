@@ -0,0 +1,7 @@
+-- 007_create_config_changes.down.sql
+
+DROP INDEX IF EXISTS idx_config_histories_key_version;
+ALTER TABLE config_histories
+    DROP COLUMN IF EXISTS change_id,
+    DROP COLUMN IF EXISTS action;
+DROP TABLE IF EXISTS config_changes;
@@ -0,0 +1,29 @@
+-- 007_create_config_changes.up.sql
+
+-- 配置变更请求（校验、审批后原子写入Consul）
+CREATE TABLE IF NOT EXISTS config_changes (
+    id VARCHAR(36) PRIMARY KEY,
+    items JSONB NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL,
+    requires_approval BOOLEAN NOT NULL DEFAULT FALSE,
+    rollback_of VARCHAR(36),
+    created_by VARCHAR(36) NOT NULL,
+    created_by_name VARCHAR(50) NOT NULL,
+    reviewed_by VARCHAR(36),
+    reviewed_by_name VARCHAR(50),
+    review_comment TEXT,
+    error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    reviewed_at TIMESTAMP,
+    applied_at TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_config_changes_status ON config_changes(status, created_at DESC);
+CREATE INDEX idx_config_changes_created_by ON config_changes(created_by, created_at DESC);
+
+-- 历史记录关联变更请求；版本号改为每个配置键从1递增
+ALTER TABLE config_histories
+    ADD COLUMN IF NOT EXISTS action VARCHAR(10) NOT NULL DEFAULT 'update',
+    ADD COLUMN IF NOT EXISTS change_id VARCHAR(36);
+CREATE INDEX IF NOT EXISTS idx_config_histories_key_version ON config_histories(config_key, version DESC);
@@ -0,0 +1,5 @@
+-- 008_create_admin_sessions.down.sql
+
+DROP TABLE IF EXISTS admin_webauthn_credentials;
+DROP TABLE IF EXISTS admin_backup_codes;
+DROP TABLE IF EXISTS admin_sessions;
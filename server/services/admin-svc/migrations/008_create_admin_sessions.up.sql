@@ -0,0 +1,41 @@
+-- 008_create_admin_sessions.up.sql
+
+-- 管理员登录会话（JWT携带会话ID，撤销后Token立即失效）
+CREATE TABLE IF NOT EXISTS admin_sessions (
+    id VARCHAR(36) PRIMARY KEY,
+    admin_id VARCHAR(36) NOT NULL REFERENCES admin_users(id) ON DELETE CASCADE,
+    admin_name VARCHAR(50) NOT NULL,
+    mfa_method VARCHAR(20),
+    ip VARCHAR(45) NOT NULL,
+    user_agent TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP,
+    revoked_by VARCHAR(36)
+);
+CREATE INDEX idx_admin_sessions_admin_id ON admin_sessions(admin_id, created_at DESC) WHERE revoked_at IS NULL;
+CREATE INDEX idx_admin_sessions_expires_at ON admin_sessions(expires_at);
+
+-- 备用恢复码（只保存HMAC，每个码只能使用一次）
+CREATE TABLE IF NOT EXISTS admin_backup_codes (
+    admin_id VARCHAR(36) NOT NULL REFERENCES admin_users(id) ON DELETE CASCADE,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (admin_id, code_hash)
+);
+
+-- WebAuthn安全密钥
+CREATE TABLE IF NOT EXISTS admin_webauthn_credentials (
+    id VARCHAR(255) PRIMARY KEY,
+    admin_id VARCHAR(36) NOT NULL REFERENCES admin_users(id) ON DELETE CASCADE,
+    name VARCHAR(64) NOT NULL,
+    public_key BYTEA NOT NULL,
+    algorithm INT NOT NULL,
+    sign_count BIGINT NOT NULL DEFAULT 0,
+    backup_eligible BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMP
+);
+CREATE INDEX idx_admin_webauthn_credentials_admin_id ON admin_webauthn_credentials(admin_id);
@@ -16,14 +16,22 @@ import (
 	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/handler"
 	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/middleware"
 	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/repository"
+	accountservice "github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/service/account"
 	deviceservice "github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/service/device"
 	jwtservice "github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/service/jwt"
+	qrloginservice "github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/service/qrlogin"
+	riskservice "github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/service/risk"
 	smsservice "github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/service/sms"
+	totpservice "github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/service/totp"
 	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/consul"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/crypto"
 	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/db"
 	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/grpc"
 	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/logger"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/stats"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/syncevent"
 	authv1 "github.com/xiaoxiao0301/listen-stream-v2/server/shared/proto/auth/v1"
+	userv1 "github.com/xiaoxiao0301/listen-stream-v2/server/shared/proto/user/v1"
 )
 
 const (
@@ -78,31 +86,44 @@ func main() {
 	// Initialize repositories
 	userRepo := repository.NewUserRepository(database)
 	deviceRepo := repository.NewDeviceRepository(database)
-	smsRepo := repository.NewSMSRepository(database)
-
-	// Initialize SMS service with Fallback chain
-	smsProviders := []smsservice.Provider{
-		smsservice.NewAliyunProvider(smsservice.AliyunConfig{
-			AccessKeyID:     getEnv("ALIYUN_ACCESS_KEY_ID", ""),
-			AccessKeySecret: getEnv("ALIYUN_ACCESS_KEY_SECRET", ""),
-			SignName:        getEnv("ALIYUN_SMS_SIGN_NAME", ""),
-			TemplateCode:    getEnv("ALIYUN_SMS_TEMPLATE_CODE", ""),
-		}),
-		smsservice.NewTencentProvider(smsservice.TencentConfig{
-			SecretID:     getEnv("TENCENT_SECRET_ID", ""),
-			SecretKey:    getEnv("TENCENT_SECRET_KEY", ""),
-			SDKAppID:     getEnv("TENCENT_SMS_SDK_APP_ID", ""),
-			SignName:     getEnv("TENCENT_SMS_SIGN_NAME", ""),
-			TemplateID:   getEnv("TENCENT_SMS_TEMPLATE_ID", ""),
-		}),
-		smsservice.NewTwilioProvider(smsservice.TwilioConfig{
-			AccountSID: getEnv("TWILIO_ACCOUNT_SID", ""),
-			AuthToken:  getEnv("TWILIO_AUTH_TOKEN", ""),
-			FromNumber: getEnv("TWILIO_FROM_NUMBER", ""),
-		}),
+
+	// Initialize SMS service with Fallback chain, adaptive routing and abuse limits
+	smsConfig := smsservice.NewConfig()
+	smsConfig.FallbackEnabled = getEnvBool("SMS_FALLBACK_ENABLED", true)
+	smsConfig.Aliyun.AccessKeyID = getEnv("ALIYUN_ACCESS_KEY_ID", "")
+	smsConfig.Aliyun.AccessKeySecret = getEnv("ALIYUN_ACCESS_KEY_SECRET", "")
+	smsConfig.Aliyun.SignName = getEnv("ALIYUN_SMS_SIGN_NAME", "")
+	smsConfig.Aliyun.TemplateCode = getEnv("ALIYUN_SMS_TEMPLATE_CODE", "")
+	smsConfig.Aliyun.Enabled = smsConfig.Aliyun.AccessKeyID != ""
+	smsConfig.Tencent.SecretID = getEnv("TENCENT_SECRET_ID", "")
+	smsConfig.Tencent.SecretKey = getEnv("TENCENT_SECRET_KEY", "")
+	smsConfig.Tencent.AppID = getEnv("TENCENT_SMS_SDK_APP_ID", "")
+	smsConfig.Tencent.SignName = getEnv("TENCENT_SMS_SIGN_NAME", "")
+	smsConfig.Tencent.TemplateID = getEnv("TENCENT_SMS_TEMPLATE_ID", "")
+	smsConfig.Tencent.Enabled = smsConfig.Tencent.SecretID != ""
+	smsConfig.Twilio.AccountSID = getEnv("TWILIO_ACCOUNT_SID", "")
+	smsConfig.Twilio.AuthToken = getEnv("TWILIO_AUTH_TOKEN", "")
+	smsConfig.Twilio.FromNumber = getEnv("TWILIO_FROM_NUMBER", "")
+	smsConfig.Twilio.Enabled = smsConfig.Twilio.AccountSID != ""
+
+	// Abuse limits (per IP / device / number prefix / phone, and step-up codes per phone) are counted in Redis;
+	// requests over the challenge threshold must pass the captcha, or are rejected when no captcha is configured
+	smsConfig.Abuse.Enabled = getEnvBool("SMS_ABUSE_LIMIT_ENABLED", true)
+	smsConfig.Captcha.VerifyURL = getEnv("SMS_CAPTCHA_VERIFY_URL", "")
+	smsConfig.Captcha.Secret = getEnv("SMS_CAPTCHA_SECRET", "")
+	if smsConfig.Abuse.Enabled && smsConfig.Captcha.VerifyURL == "" {
+		log.Warn("SMS_CAPTCHA_VERIFY_URL is not set, SMS requests over the challenge threshold will be rejected")
 	}
 
-	smsServiceInstance := smsservice.NewService(smsProviders, smsRepo, redisClient, log)
+	smsServiceInstance := smsservice.NewService(
+		smsConfig,
+		repository.NewSMSVerificationRepository(database),
+		repository.NewSMSRecordRepository(database),
+		repository.NewSMSLimitRepository(redisClient),
+	)
+
+	// Refresh SMS provider health from sms_records for adaptive routing
+	go smsServiceInstance.RunHealthRefresh(ctx, time.Minute)
 
 	// Initialize JWT service
 	jwtServiceInstance := jwtservice.NewService(jwtservice.Config{
@@ -115,10 +136,103 @@ func main() {
 	// Initialize device service
 	deviceServiceInstance := deviceservice.NewService(deviceRepo, redisClient, log)
 
+	// Initialize TOTP authenticator (independent second factor for risk step-up; secrets are stored encrypted)
+	totpKey, err := crypto.KeyFromHex(os.Getenv("TOTP_ENCRYPTION_KEY"))
+	if err != nil {
+		log.Fatal("TOTP_ENCRYPTION_KEY must be a hex-encoded 32-byte key", logger.String("error", err.Error()))
+	}
+	totpCipher, err := crypto.NewAES256Cipher(totpKey)
+	if err != nil {
+		log.Fatal("TOTP_ENCRYPTION_KEY must be a hex-encoded 32-byte key", logger.String("error", err.Error()))
+	}
+	totpServiceInstance := totpservice.NewTOTPService(
+		getEnv("TOTP_ISSUER", totpservice.DefaultIssuer),
+		repository.NewUserTOTPRepository(database),
+		repository.NewTOTPUsedCodeRepository(redisClient),
+		userRepo,
+		totpCipher,
+	)
+	totpHandler := handler.NewTOTPHandler(totpServiceInstance, jwtServiceInstance)
+
+	// Initialize login risk service (step-up verification / blocking on suspicious logins)
+	var riskServiceInstance riskservice.RiskService
+	if getEnvBool("RISK_ENABLED", true) {
+		riskConfig := riskservice.NewConfig()
+		riskConfig.StepUpThreshold = getEnvInt("RISK_STEP_UP_THRESHOLD", riskConfig.StepUpThreshold)
+		riskConfig.BlockThreshold = getEnvInt("RISK_BLOCK_THRESHOLD", riskConfig.BlockThreshold)
+
+		geoResolver := riskservice.NewNoopGeoResolver()
+		if path := getEnv("RISK_GEO_TABLE", ""); path != "" {
+			geoResolver, err = riskservice.LoadGeoTable(path)
+			if err != nil {
+				log.Fatal("Failed to load geo table", logger.String("error", err.Error()))
+			}
+		}
+
+		riskServiceInstance = riskservice.NewRiskService(
+			riskConfig,
+			repository.NewLoginRiskRepository(database),
+			repository.NewStepUpChallengeRepository(redisClient),
+			deviceRepo,
+			deviceServiceInstance,
+			geoResolver,
+			smsServiceInstance,
+			totpServiceInstance,
+			syncevent.NewRedisPublisher(redisClient, serviceName),
+		)
+	}
+
+	// Business metrics for admin-svc dashboards
+	statsEmitter := stats.NewRedisEmitter(redisClient, stats.DefaultConfig())
+	defer statsEmitter.Close()
+	if total, err := userRepo.Count(ctx); err != nil {
+		log.Warn("Failed to count users for stats", logger.String("error", err.Error()))
+	} else if err := stats.SetTotalUsers(ctx, redisClient, total); err != nil {
+		log.Warn("Failed to seed total users", logger.String("error", err.Error()))
+	}
+
 	// Initialize handlers
-	loginHandler := handler.NewLoginHandler(smsServiceInstance, jwtServiceInstance, deviceServiceInstance, userRepo, log)
+	loginHandler := handler.NewLoginHandler(smsServiceInstance, jwtServiceInstance, deviceServiceInstance, riskServiceInstance, userRepo, statsEmitter)
 	deviceHandler := handler.NewDeviceHandler(deviceServiceInstance, jwtServiceInstance, log)
 
+	// Initialize QR login (tickets are kept in Redis)
+	qrTicketRepo := repository.NewQRLoginTicketRepository(redisClient)
+	qrLoginServiceInstance := qrloginservice.NewQRLoginService(qrTicketRepo, userRepo, deviceServiceInstance, jwtServiceInstance, riskServiceInstance)
+	qrLoginHandler := handler.NewQRLoginHandler(qrLoginServiceInstance, jwtServiceInstance, statsEmitter)
+
+	// Initialize account deletion / data export
+	// Erasure steps run in order: disable the account first, delete the user row last
+	userSvcConn, err := grpc.NewClient(ctx, grpc.DefaultClientConfig(getEnv("USER_SVC_ADDR", "localhost:9003")))
+	if err != nil {
+		log.Fatal("Failed to connect to user-svc", logger.String("error", err.Error()))
+	}
+	defer userSvcConn.Close()
+
+	userContent := accountservice.NewUserContentParticipant(userv1.NewUserServiceClient(userSvcConn))
+	syncData := accountservice.NewSyncDataParticipant(getEnv("SYNC_SVC_URL", "http://localhost:8004"), getEnv("INTERNAL_API_TOKEN", ""))
+
+	accountConfig := accountservice.NewConfig()
+	accountConfig.GracePeriod = time.Duration(getEnvInt("ACCOUNT_DELETION_GRACE_DAYS", 30)) * 24 * time.Hour
+	accountServiceInstance := accountservice.NewAccountService(
+		accountConfig,
+		repository.NewAccountDeletionRepository(database),
+		[]accountservice.ErasureStep{
+			accountservice.NewRevokeAccessStep(userRepo, jwtServiceInstance),
+			userContent,
+			syncData,
+			accountservice.NewAuthDataStep(userRepo, repository.NewSMSVerificationRepository(database), repository.NewSMSRecordRepository(database)),
+		},
+		[]accountservice.ExportSource{
+			accountservice.NewAuthExportSource(userRepo, deviceRepo, repository.NewLoginRiskRepository(database)),
+			userContent,
+			syncData,
+		},
+	)
+	accountHandler := handler.NewAccountHandler(accountServiceInstance, jwtServiceInstance)
+
+	// Process account deletions whose grace period has ended
+	go accountServiceInstance.Run(ctx, time.Minute)
+
 	// Initialize gRPC server implementation
 	authServer := authgrpc.NewAuthServer(jwtServiceInstance, deviceServiceInstance, userRepo, log)
 
@@ -148,7 +262,7 @@ func main() {
 	}
 
 	// Start HTTP server
-	httpServer := startHTTPServer(log, httpPort, loginHandler, deviceHandler, database, redisClient)
+	httpServer := startHTTPServer(log, httpPort, loginHandler, deviceHandler, qrLoginHandler, totpHandler, accountHandler, database, redisClient)
 
 	// Start gRPC server
 	grpcServer, err := startGRPCServer(log, grpcPort, authServer)
@@ -189,7 +303,7 @@ func main() {
 }
 
 // startHTTPServer starts the HTTP server for client-facing APIs
-func startHTTPServer(log logger.Logger, port int, loginHandler *handler.LoginHandler, deviceHandler *handler.DeviceHandler, database *sql.DB, redisClient *redis.Client) *http.Server {
+func startHTTPServer(log logger.Logger, port int, loginHandler *handler.LoginHandler, deviceHandler *handler.DeviceHandler, qrLoginHandler *handler.QRLoginHandler, totpHandler *handler.TOTPHandler, accountHandler *handler.AccountHandler, database *sql.DB, redisClient *redis.Client) *http.Server {
 	// Create Gin router
 	router := gin.New()
 
@@ -212,6 +326,8 @@ func startHTTPServer(log logger.Logger, port int, loginHandler *handler.LoginHan
 			if loginHandler != nil {
 				auth.POST("/send-code", wrapHandler(loginHandler.SendVerificationCode))
 				auth.POST("/verify-login", wrapHandler(loginHandler.VerifyLogin))
+				auth.POST("/step-up/verify", wrapHandler(loginHandler.VerifyStepUp))
+				auth.POST("/step-up/resend", wrapHandler(loginHandler.ResendStepUpCode))
 			} else {
 				// Placeholder endpoints
 				auth.POST("/send-code", func(c *gin.Context) {
@@ -223,6 +339,36 @@ func startHTTPServer(log logger.Logger, port int, loginHandler *handler.LoginHan
 			}
 		}
 
+		// QR login endpoints
+		if qrLoginHandler != nil {
+			qr := auth.Group("/qr")
+			{
+				qr.POST("/tickets", wrapHandler(qrLoginHandler.CreateTicket))                // new device, no auth, limited per IP
+				qr.GET("/tickets/poll", wrapHandler(qrLoginHandler.PollTicket))              // new device, long-poll with X-Poll-Token header
+				qr.POST("/tickets/cancel", wrapHandler(qrLoginHandler.CancelTicketByDevice)) // new device, with poll_token
+				qr.POST("/scan", wrapHandler(qrLoginHandler.ScanTicket))                     // signed-in device
+				qr.POST("/confirm", wrapHandler(qrLoginHandler.ConfirmTicket))               // signed-in device (scanner only)
+				qr.POST("/cancel", wrapHandler(qrLoginHandler.CancelTicket))                 // signed-in device (scanner only)
+			}
+		}
+
+		// Account endpoints (deletion with grace period, personal data export)
+		account := v1.Group("/account")
+		if accountHandler != nil {
+			account.POST("/deletion", wrapHandler(accountHandler.RequestDeletion))
+			account.GET("/deletion", wrapHandler(accountHandler.GetDeletion))
+			account.DELETE("/deletion", wrapHandler(accountHandler.CancelDeletion))
+			account.GET("/export", wrapHandler(accountHandler.ExportData))
+		}
+
+		// Authenticator (TOTP) enrollment, used as the step-up factor on suspicious logins
+		if totpHandler != nil {
+			account.GET("/totp", wrapHandler(totpHandler.GetStatus))
+			account.POST("/totp/setup", wrapHandler(totpHandler.Setup))
+			account.POST("/totp/confirm", wrapHandler(totpHandler.Confirm))
+			account.DELETE("/totp", wrapHandler(totpHandler.Disable))
+		}
+
 		// Device endpoints
 		devices := v1.Group("/devices")
 		{
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// AccountDeletionGracePeriod 注销冷静期（期间可撤销）
+	AccountDeletionGracePeriod = 30 * 24 * time.Hour
+	// accountDeletionMaxBackoff 删除步骤失败后的最大重试间隔
+	accountDeletionMaxBackoff = 6 * time.Hour
+)
+
+// AccountDeletionStatus 账号注销状态
+type AccountDeletionStatus string
+
+const (
+	AccountDeletionStatusPending    AccountDeletionStatus = "pending"    // 冷静期内，可撤销
+	AccountDeletionStatusProcessing AccountDeletionStatus = "processing" // 正在删除各服务数据，不可撤销
+	AccountDeletionStatusCompleted  AccountDeletionStatus = "completed"  // 已完成
+	AccountDeletionStatusCancelled  AccountDeletionStatus = "cancelled"  // 用户已撤销
+)
+
+// AccountDeletion 账号注销请求
+// 冷静期结束后按顺序执行各服务的删除步骤，每个步骤可重复执行，完成的步骤记录在CompletedSteps中，
+// 失败时按指数退避重试，直到全部步骤完成
+type AccountDeletion struct {
+	ID             string                // UUID
+	UserID         string                // 用户ID
+	Status         AccountDeletionStatus // 状态
+	CompletedSteps []string              // 已完成的删除步骤
+	Attempts       int                   // 失败次数
+	LastError      string                // 最近一次失败原因
+	RequestedAt    time.Time             // 申请时间
+	ScheduledAt    time.Time             // 计划执行时间（冷静期结束）
+	NextAttemptAt  time.Time             // 下次执行时间
+	CancelledAt    *time.Time            // 撤销时间
+	CompletedAt    *time.Time            // 完成时间
+	UpdatedAt      time.Time             // 更新时间
+}
+
+// NewAccountDeletion 创建账号注销请求
+func NewAccountDeletion(userID string, gracePeriod time.Duration) *AccountDeletion {
+	now := time.Now()
+	scheduledAt := now.Add(gracePeriod)
+	return &AccountDeletion{
+		ID:             uuid.New().String(),
+		UserID:         userID,
+		Status:         AccountDeletionStatusPending,
+		CompletedSteps: []string{},
+		RequestedAt:    now,
+		ScheduledAt:    scheduledAt,
+		NextAttemptAt:  scheduledAt,
+		UpdatedAt:      now,
+	}
+}
+
+// IsActive 是否尚未结束（冷静期内或删除中）
+func (d *AccountDeletion) IsActive() bool {
+	return d.Status == AccountDeletionStatusPending || d.Status == AccountDeletionStatusProcessing
+}
+
+// CanCancel 是否可撤销（删除开始前）
+func (d *AccountDeletion) CanCancel() bool {
+	return d.Status == AccountDeletionStatusPending
+}
+
+// IsStepDone 删除步骤是否已完成
+func (d *AccountDeletion) IsStepDone(step string) bool {
+	for _, s := range d.CompletedSteps {
+		if s == step {
+			return true
+		}
+	}
+	return false
+}
+
+// MarkStepDone 记录删除步骤完成
+func (d *AccountDeletion) MarkStepDone(step string) {
+	if !d.IsStepDone(step) {
+		d.CompletedSteps = append(d.CompletedSteps, step)
+	}
+	d.UpdatedAt = time.Now()
+}
+
+// RecordFailure 记录删除失败，按指数退避安排重试（1分钟起，最长6小时）
+func (d *AccountDeletion) RecordFailure(err error, now time.Time) {
+	d.Attempts++
+	d.LastError = err.Error()
+
+	backoff := time.Minute
+	for i := 1; i < d.Attempts && backoff < accountDeletionMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > accountDeletionMaxBackoff {
+		backoff = accountDeletionMaxBackoff
+	}
+
+	d.NextAttemptAt = now.Add(backoff)
+	d.UpdatedAt = now
+}
+
+// Complete 标记注销完成
+func (d *AccountDeletion) Complete(now time.Time) {
+	d.Status = AccountDeletionStatusCompleted
+	d.LastError = ""
+	d.CompletedAt = &now
+	d.UpdatedAt = now
+}
@@ -34,6 +34,44 @@ var (
 	ErrSMSCodeInvalid       = errors.New("SMS verification code is invalid")
 	ErrSMSTooFrequent       = errors.New("SMS sent too frequently, please wait")
 	ErrSMSSendFailed        = errors.New("failed to send SMS")
+	ErrSMSLimitExceeded     = errors.New("SMS send limit exceeded")
+	ErrCaptchaRequired      = errors.New("captcha verification required")
+	ErrCaptchaInvalid       = errors.New("captcha verification failed")
+)
+
+// QR登录相关错误
+var (
+	ErrQRTicketNotFound       = errors.New("QR login ticket not found")
+	ErrQRTicketExpired        = errors.New("QR login ticket expired")
+	ErrQRTicketAlreadyScanned = errors.New("QR login ticket already scanned by another user")
+	ErrQRTicketInvalidState   = errors.New("QR login ticket is in an invalid state for this operation")
+	ErrQRTicketUserMismatch   = errors.New("QR login ticket belongs to another user")
+	ErrQRTicketPollToken      = errors.New("invalid QR login poll token")
+	ErrQRTicketRateLimited    = errors.New("too many QR login tickets, please try again later")
+)
+
+// 登录风控相关错误
+var (
+	ErrLoginBlocked              = errors.New("login blocked by risk policy")
+	ErrStepUpChallengeNotFound   = errors.New("step-up challenge not found")
+	ErrStepUpChallengeExpired    = errors.New("step-up challenge expired")
+	ErrStepUpMethodUnavailable   = errors.New("step-up verification method unavailable")
+	ErrStepUpInvalidCode         = errors.New("invalid step-up verification code")
+	ErrStepUpTooManyAttempts     = errors.New("too many step-up verification attempts")
+	ErrLoginRiskDecisionNotFound = errors.New("login risk decision not found")
+)
+
+// TOTP相关错误
+var (
+	ErrTOTPNotEnrolled     = errors.New("TOTP authenticator not enrolled")
+	ErrTOTPAlreadyEnrolled = errors.New("TOTP authenticator already enrolled")
+	ErrTOTPInvalidCode     = errors.New("invalid TOTP code")
+)
+
+// 账号注销相关错误
+var (
+	ErrAccountDeletionNotFound       = errors.New("account deletion request not found")
+	ErrAccountDeletionNotCancellable = errors.New("account deletion can no longer be cancelled")
 )
 
 // SMSRecord 相关错误
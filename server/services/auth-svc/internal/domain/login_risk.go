@@ -0,0 +1,188 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// StepUpChallengeTTL 二次验证挑战有效期（5分钟）
+	StepUpChallengeTTL = 5 * time.Minute
+	// StepUpMaxAttempts 二次验证最大尝试次数
+	StepUpMaxAttempts = 5
+	// StepUpResendInterval 二次验证短信重发间隔
+	StepUpResendInterval = 60 * time.Second
+)
+
+// LoginRiskAction 风控决策动作
+type LoginRiskAction string
+
+const (
+	LoginRiskActionAllow  LoginRiskAction = "allow"   // 放行
+	LoginRiskActionStepUp LoginRiskAction = "step_up" // 需要二次验证
+	LoginRiskActionBlock  LoginRiskAction = "block"   // 拒绝登录
+)
+
+// LoginRiskOutcome 风控决策最终结果
+type LoginRiskOutcome string
+
+const (
+	LoginRiskOutcomeAllowed       LoginRiskOutcome = "allowed"         // 直接放行
+	LoginRiskOutcomeStepUpPending LoginRiskOutcome = "step_up_pending" // 等待二次验证
+	LoginRiskOutcomeStepUpPassed  LoginRiskOutcome = "step_up_passed"  // 二次验证通过
+	LoginRiskOutcomeStepUpFailed  LoginRiskOutcome = "step_up_failed"  // 二次验证失败（次数耗尽）
+	LoginRiskOutcomeBlocked       LoginRiskOutcome = "blocked"         // 直接拒绝
+)
+
+// IsSuccessful 该结果是否代表一次成功登录（用于构建登录历史）
+func (o LoginRiskOutcome) IsSuccessful() bool {
+	return o == LoginRiskOutcomeAllowed || o == LoginRiskOutcomeStepUpPassed
+}
+
+// LoginRiskDecision 登录风控决策记录
+type LoginRiskDecision struct {
+	ID          string           // UUID
+	UserID      string           // 用户ID
+	DeviceID    string           // 客户端设备标识
+	Fingerprint string           // 设备指纹
+	ClientIP    string           // 客户端IP
+	Country     string           // IP所属国家/地区（解析失败为空）
+	City        string           // IP所属城市
+	Latitude    *float64         // 纬度（解析失败为nil）
+	Longitude   *float64         // 经度
+	Score       int              // 风险分
+	Action      LoginRiskAction  // 决策动作
+	Reasons     []string         // 命中的策略
+	Outcome     LoginRiskOutcome // 最终结果
+	CreatedAt   time.Time        // 创建时间
+	ResolvedAt  *time.Time       // 最终结果确定时间
+}
+
+// NewLoginRiskDecision 创建风控决策记录
+func NewLoginRiskDecision(userID, deviceID, fingerprint, clientIP string) *LoginRiskDecision {
+	return &LoginRiskDecision{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		DeviceID:    deviceID,
+		Fingerprint: fingerprint,
+		ClientIP:    clientIP,
+		Action:      LoginRiskActionAllow,
+		Outcome:     LoginRiskOutcomeAllowed,
+		Reasons:     []string{},
+		CreatedAt:   time.Now(),
+	}
+}
+
+// HasLocation 是否有地理位置信息
+func (d *LoginRiskDecision) HasLocation() bool {
+	return d.Latitude != nil && d.Longitude != nil
+}
+
+// StepUpMethod 二次验证方式
+type StepUpMethod string
+
+const (
+	StepUpMethodSMS  StepUpMethod = "sms"
+	StepUpMethodTOTP StepUpMethod = "totp"
+)
+
+// StepUpChallenge 登录二次验证挑战
+// 风控要求二次验证时创建，保存待完成登录的设备信息，仅保存在Redis中
+type StepUpChallenge struct {
+	ID         string         `json:"id"`
+	UserID     string         `json:"user_id"`
+	DecisionID string         `json:"decision_id"` // 对应的风控决策
+	Phone      string         `json:"phone"`
+	Methods    []StepUpMethod `json:"methods"`             // 可用的验证方式
+	CodeHash   string         `json:"code_hash,omitempty"` // 短信验证码哈希
+	CodeSentAt *time.Time     `json:"code_sent_at,omitempty"`
+	Attempts   int            `json:"attempts"`
+
+	// 待完成登录的设备信息
+	DeviceName string `json:"device_name"`
+	DeviceID   string `json:"device_id"`
+	Platform   string `json:"platform"`
+	OSVersion  string `json:"os_version"`
+	AppVersion string `json:"app_version"`
+	ClientIP   string `json:"client_ip"`
+
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// NewStepUpChallenge 创建二次验证挑战
+func NewStepUpChallenge(userID, decisionID, phone string, methods []StepUpMethod) *StepUpChallenge {
+	now := time.Now()
+	return &StepUpChallenge{
+		ID:         uuid.New().String(),
+		UserID:     userID,
+		DecisionID: decisionID,
+		Phone:      phone,
+		Methods:    methods,
+		CreatedAt:  now,
+		ExpiresAt:  now.Add(StepUpChallengeTTL),
+	}
+}
+
+// IsExpired 检查挑战是否过期
+func (c *StepUpChallenge) IsExpired() bool {
+	return time.Now().After(c.ExpiresAt)
+}
+
+// SupportsMethod 是否支持该验证方式
+func (c *StepUpChallenge) SupportsMethod(method StepUpMethod) bool {
+	for _, m := range c.Methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// IssueSMSCode 生成新的短信验证码（仅保存哈希），返回明文用于发送
+func (c *StepUpChallenge) IssueSMSCode() (string, error) {
+	if !c.SupportsMethod(StepUpMethodSMS) {
+		return "", ErrStepUpMethodUnavailable
+	}
+	if c.CodeSentAt != nil && time.Since(*c.CodeSentAt) < StepUpResendInterval {
+		return "", ErrSMSTooFrequent
+	}
+
+	code, err := generateSMSCode()
+	if err != nil {
+		return "", fmt.Errorf("generate step-up code: %w", err)
+	}
+
+	now := time.Now()
+	c.CodeHash = hashStepUpCode(c.ID, code)
+	c.CodeSentAt = &now
+	return code, nil
+}
+
+// VerifySMSCode 校验短信验证码（常量时间比较）
+func (c *StepUpChallenge) VerifySMSCode(code string) bool {
+	if c.CodeHash == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(c.CodeHash), []byte(hashStepUpCode(c.ID, code))) == 1
+}
+
+// RecordFailure 记录一次失败尝试，次数耗尽时返回ErrStepUpTooManyAttempts
+func (c *StepUpChallenge) RecordFailure() error {
+	c.Attempts++
+	if c.Attempts >= StepUpMaxAttempts {
+		return ErrStepUpTooManyAttempts
+	}
+	return nil
+}
+
+// hashStepUpCode 验证码哈希（以挑战ID加盐）
+func hashStepUpCode(challengeID, code string) string {
+	sum := sha256.Sum256([]byte(challengeID + ":" + code))
+	return hex.EncodeToString(sum[:])
+}
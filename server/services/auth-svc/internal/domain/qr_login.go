@@ -0,0 +1,230 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// QRLoginTicketTTL 二维码登录票据有效期（2分钟）
+	QRLoginTicketTTL = 2 * time.Minute
+	// QRLoginPickupTTL 确认后新设备领取Token的时间窗口（1分钟）
+	QRLoginPickupTTL = time.Minute
+	// qrLoginPollTokenBytes 轮询令牌随机字节数
+	qrLoginPollTokenBytes = 32
+)
+
+// QRLoginStatus 二维码登录票据状态
+type QRLoginStatus string
+
+const (
+	QRLoginStatusPending   QRLoginStatus = "pending"   // 等待扫码
+	QRLoginStatusScanned   QRLoginStatus = "scanned"   // 已扫码，等待确认
+	QRLoginStatusConfirmed QRLoginStatus = "confirmed" // 已确认，Token已签发
+	QRLoginStatusCancelled QRLoginStatus = "cancelled" // 已取消（扫码端拒绝或新设备取消）
+	QRLoginStatusExpired   QRLoginStatus = "expired"   // 已过期
+)
+
+// QRLoginTicket 二维码登录票据
+// 新设备（TV/桌面端）申请票据并渲染为二维码，已登录的手机扫码确认后，
+// 新设备通过轮询领取Token对。票据仅保存在Redis中，Token在领取时才签发，不写入票据。
+type QRLoginTicket struct {
+	ID        string        `json:"id"`         // 票据ID（二维码内容）
+	PollToken string        `json:"poll_token"` // 轮询令牌（仅新设备持有，不出现在二维码中）
+	Status    QRLoginStatus `json:"status"`     // 票据状态
+
+	// 新设备信息（确认后用于注册设备）
+	DeviceName string `json:"device_name"`
+	DeviceID   string `json:"device_id"`
+	Platform   string `json:"platform"`
+	OSVersion  string `json:"os_version"`
+	AppVersion string `json:"app_version"`
+	ClientIP   string `json:"client_ip"`
+
+	// 扫码/确认信息
+	UserID       string     `json:"user_id,omitempty"`       // 扫码用户ID
+	ScannedAt    *time.Time `json:"scanned_at,omitempty"`    // 扫码时间
+	ConfirmingAt *time.Time `json:"confirming_at,omitempty"` // 开始确认时间（确认期间其他确认请求被拒绝）
+	ConfirmedAt  *time.Time `json:"confirmed_at,omitempty"`  // 确认时间
+
+	// 确认结果（领取时为该设备签发Token，领取后票据即被删除）
+	RegisteredDeviceID string `json:"registered_device_id,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"` // 创建时间
+	ExpiresAt time.Time `json:"expires_at"` // 过期时间
+}
+
+// NewQRLoginTicket 创建二维码登录票据
+func NewQRLoginTicket(deviceName, deviceID, platform, osVersion, appVersion, clientIP string) (*QRLoginTicket, error) {
+	pollToken, err := generatePollToken()
+	if err != nil {
+		return nil, fmt.Errorf("generate poll token: %w", err)
+	}
+
+	now := time.Now()
+	return &QRLoginTicket{
+		ID:         uuid.New().String(),
+		PollToken:  pollToken,
+		Status:     QRLoginStatusPending,
+		DeviceName: deviceName,
+		DeviceID:   deviceID,
+		Platform:   platform,
+		OSVersion:  osVersion,
+		AppVersion: appVersion,
+		ClientIP:   clientIP,
+		CreatedAt:  now,
+		ExpiresAt:  now.Add(QRLoginTicketTTL),
+	}, nil
+}
+
+// generatePollToken 生成随机轮询令牌
+func generatePollToken() (string, error) {
+	bytes := make([]byte, qrLoginPollTokenBytes)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+// Validate 验证票据中的设备信息
+func (t *QRLoginTicket) Validate() error {
+	if t.DeviceID == "" {
+		return ErrInvalidDeviceID
+	}
+	if t.DeviceName == "" {
+		return ErrInvalidDeviceName
+	}
+	if !isValidPlatform(t.Platform) {
+		return ErrInvalidPlatform
+	}
+	return nil
+}
+
+// IsExpired 检查票据是否过期
+func (t *QRLoginTicket) IsExpired() bool {
+	return time.Now().After(t.ExpiresAt)
+}
+
+// VerifyPollToken 校验轮询令牌（常量时间比较）
+func (t *QRLoginTicket) VerifyPollToken(pollToken string) bool {
+	return subtle.ConstantTimeCompare([]byte(t.PollToken), []byte(pollToken)) == 1
+}
+
+// MarkScanned 标记票据已被扫码
+// 同一用户重复扫码是幂等的；其他用户扫码已被扫过的票据会被拒绝
+func (t *QRLoginTicket) MarkScanned(userID string) error {
+	if t.IsExpired() {
+		return ErrQRTicketExpired
+	}
+
+	switch t.Status {
+	case QRLoginStatusPending:
+		now := time.Now()
+		t.Status = QRLoginStatusScanned
+		t.UserID = userID
+		t.ScannedAt = &now
+		return nil
+	case QRLoginStatusScanned:
+		if t.UserID == userID {
+			return nil
+		}
+		return ErrQRTicketAlreadyScanned
+	default:
+		return ErrQRTicketInvalidState
+	}
+}
+
+// CheckConfirmable 检查票据是否可由该用户确认
+func (t *QRLoginTicket) CheckConfirmable(userID string) error {
+	if t.IsExpired() {
+		return ErrQRTicketExpired
+	}
+	if t.Status != QRLoginStatusScanned {
+		return ErrQRTicketInvalidState
+	}
+	if t.UserID != userID {
+		return ErrQRTicketUserMismatch
+	}
+	return nil
+}
+
+// ClaimConfirm 开始确认（必须由扫码用户确认）
+// 在注册设备前原子地占用票据，并发确认时只有一个请求能继续
+func (t *QRLoginTicket) ClaimConfirm(userID string) error {
+	if err := t.CheckConfirmable(userID); err != nil {
+		return err
+	}
+	if t.ConfirmingAt != nil {
+		return ErrQRTicketInvalidState
+	}
+
+	now := time.Now()
+	t.ConfirmingAt = &now
+	return nil
+}
+
+// ReleaseConfirm 确认失败（如设备数量超限）时释放占用，票据回到已扫码状态
+func (t *QRLoginTicket) ReleaseConfirm(userID string) error {
+	if t.Status != QRLoginStatusScanned || t.UserID != userID {
+		return ErrQRTicketInvalidState
+	}
+	t.ConfirmingAt = nil
+	return nil
+}
+
+// Confirm 完成确认并记录为新设备注册的设备ID（必须先调用ClaimConfirm）
+// 占用后票据已归该用户所有，不再检查过期，避免设备已注册但票据无法确认
+func (t *QRLoginTicket) Confirm(userID, deviceID string) error {
+	if t.Status != QRLoginStatusScanned || t.ConfirmingAt == nil {
+		return ErrQRTicketInvalidState
+	}
+	if t.UserID != userID {
+		return ErrQRTicketUserMismatch
+	}
+
+	now := time.Now()
+	t.Status = QRLoginStatusConfirmed
+	t.ConfirmedAt = &now
+	t.ExpiresAt = now.Add(QRLoginPickupTTL)
+	t.RegisteredDeviceID = deviceID
+	return nil
+}
+
+// Cancel 取消登录（扫码用户拒绝）
+// 只有扫码用户可以拒绝，未扫码的票据只能由持有轮询令牌的新设备取消（CancelByDevice）
+func (t *QRLoginTicket) Cancel(userID string) error {
+	if t.Status != QRLoginStatusScanned {
+		return ErrQRTicketInvalidState
+	}
+	if t.UserID != userID {
+		return ErrQRTicketUserMismatch
+	}
+
+	t.Status = QRLoginStatusCancelled
+	return nil
+}
+
+// CancelByDevice 取消登录（新设备放弃扫码登录，需要轮询令牌）
+func (t *QRLoginTicket) CancelByDevice(pollToken string) error {
+	if !t.VerifyPollToken(pollToken) {
+		return ErrQRTicketPollToken
+	}
+	if t.Status != QRLoginStatusPending && t.Status != QRLoginStatusScanned {
+		return ErrQRTicketInvalidState
+	}
+
+	t.Status = QRLoginStatusCancelled
+	return nil
+}
+
+// IsFinal 票据是否已处于终态（轮询方无需继续等待）
+func (t *QRLoginTicket) IsFinal() bool {
+	return t.Status == QRLoginStatusConfirmed ||
+		t.Status == QRLoginStatusCancelled ||
+		t.Status == QRLoginStatusExpired
+}
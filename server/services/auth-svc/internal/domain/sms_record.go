@@ -20,9 +20,18 @@ type SMSRecord struct {
 	Provider  string    // 短信提供商：aliyun/tencent/twilio
 	Success   bool      // 发送是否成功
 	ErrorMsg  string    // 错误信息（如果失败）
+	LatencyMs int64     // 本次调用提供商的耗时（毫秒）
 	CreatedAt time.Time // 创建时间
 }
 
+// SMSProviderHealth 提供商发送情况汇总（用于健康评分）
+type SMSProviderHealth struct {
+	Provider     string // 提供商
+	Total        int64  // 发送次数
+	Success      int64  // 成功次数
+	AvgLatencyMs int64  // 平均耗时（毫秒）
+}
+
 // NewSMSRecord 创建新的短信发送记录
 func NewSMSRecord(phone, provider string, success bool, errorMsg string) *SMSRecord {
 	return &SMSRecord{
@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// UserTOTP 用户绑定的TOTP身份验证器
+// Secret为加密后的密钥；EnabledAt为nil表示已生成密钥但尚未用验证码确认绑定
+type UserTOTP struct {
+	UserID    string     // 用户ID
+	Secret    string     // 加密后的TOTP密钥
+	EnabledAt *time.Time // 确认绑定时间
+	CreatedAt time.Time  // 创建时间
+	UpdatedAt time.Time  // 更新时间
+}
+
+// NewUserTOTP 创建待确认的TOTP绑定
+func NewUserTOTP(userID, encryptedSecret string) *UserTOTP {
+	now := time.Now()
+	return &UserTOTP{
+		UserID:    userID,
+		Secret:    encryptedSecret,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// IsEnabled 是否已确认绑定
+func (t *UserTOTP) IsEnabled() bool {
+	return t.EnabledAt != nil
+}
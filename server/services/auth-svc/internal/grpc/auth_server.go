@@ -2,32 +2,48 @@ package grpc
 
 import (
 	"context"
+	"errors"
+	"regexp"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/crypto"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/logger"
 	authv1 "github.com/xiaoxiao0301/listen-stream-v2/server/shared/proto/auth/v1"
 	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/domain"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/repository"
 	deviceservice "github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/service/device"
 	jwtservice "github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/service/jwt"
 )
 
+// phonePrefixPattern 手机号搜索前缀（至少3位避免全表扫描式的模糊查询）
+var phonePrefixPattern = regexp.MustCompile(`^\d{3,11}$`)
+
 // AuthServer 认证服务gRPC实现
 type AuthServer struct {
 	authv1.UnimplementedAuthServiceServer
 	jwtService    jwtservice.JWTService
 	deviceService deviceservice.DeviceService
+	userRepo      repository.UserRepository
+	masker        *crypto.DataMasker
+	log           logger.Logger
 }
 
 // NewAuthServer 创建认证服务gRPC服务器
 func NewAuthServer(
 	jwtService jwtservice.JWTService,
 	deviceService deviceservice.DeviceService,
+	userRepo repository.UserRepository,
+	log logger.Logger,
 ) *AuthServer {
 	return &AuthServer{
 		jwtService:    jwtService,
 		deviceService: deviceService,
+		userRepo:      userRepo,
+		masker:        crypto.NewDataMasker(),
+		log:           log,
 	}
 }
 
@@ -138,6 +154,9 @@ func (s *AuthServer) RevokeDevice(ctx context.Context, req *authv1.RevokeDeviceR
 
 	// 删除设备
 	err := s.deviceService.RemoveDevice(ctx, req.UserId, req.DeviceId)
+	if errors.Is(err, domain.ErrDeviceNotFound) {
+		return nil, status.Error(codes.NotFound, "device not found")
+	}
 	if err != nil {
 		return nil, status.Error(codes.Internal, "failed to remove device")
 	}
@@ -204,6 +223,176 @@ func (s *AuthServer) ValidateTokenVersion(ctx context.Context, req *authv1.Valid
 	}, nil
 }
 
+// GetUser 根据用户ID或手机号查询用户（管理后台使用，手机号脱敏返回）
+func (s *AuthServer) GetUser(ctx context.Context, req *authv1.GetUserRequest) (*authv1.GetUserResponse, error) {
+	if (req.UserId == "") == (req.Phone == "") {
+		return nil, status.Error(codes.InvalidArgument, "exactly one of user_id and phone is required")
+	}
+
+	var (
+		user *domain.User
+		err  error
+	)
+	if req.UserId != "" {
+		user, err = s.userRepo.GetByID(ctx, req.UserId)
+	} else {
+		user, err = s.userRepo.GetByPhone(ctx, req.Phone)
+	}
+	if err != nil {
+		return nil, userError(err, "failed to get user")
+	}
+
+	return &authv1.GetUserResponse{
+		User: s.userToProto(user),
+	}, nil
+}
+
+// DisableUser 禁用用户并撤销其全部Token
+// 已禁用的用户再次禁用仍会撤销Token，保证幂等
+func (s *AuthServer) DisableUser(ctx context.Context, req *authv1.DisableUserRequest) (*authv1.DisableUserResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	if _, err := s.userRepo.GetByID(ctx, req.UserId); err != nil {
+		return nil, userError(err, "failed to get user")
+	}
+
+	if err := s.userRepo.UpdateActive(ctx, req.UserId, false); err != nil {
+		return nil, status.Error(codes.Internal, "failed to disable user")
+	}
+
+	// 递增TokenVersion使所有设备上的Token失效
+	if err := s.jwtService.RevokeUserTokens(ctx, req.UserId); err != nil {
+		return nil, status.Error(codes.Internal, "failed to revoke tokens")
+	}
+
+	// 设备数仅用于返回撤销的会话数，查询失败不影响禁用结果
+	var revoked int32
+	if devices, err := s.deviceService.ListDevices(ctx, req.UserId); err == nil {
+		revoked = int32(len(devices))
+	}
+
+	s.log.Info("User disabled",
+		logger.String("user_id", req.UserId),
+		logger.String("reason", req.Reason),
+		logger.Int("tokens_revoked", int(revoked)),
+	)
+
+	return &authv1.DisableUserResponse{
+		Success:       true,
+		TokensRevoked: revoked,
+	}, nil
+}
+
+// EnableUser 重新启用被禁用的用户（之前的Token已失效，需要重新登录）
+func (s *AuthServer) EnableUser(ctx context.Context, req *authv1.EnableUserRequest) (*authv1.EnableUserResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	if _, err := s.userRepo.GetByID(ctx, req.UserId); err != nil {
+		return nil, userError(err, "failed to get user")
+	}
+
+	if err := s.userRepo.UpdateActive(ctx, req.UserId, true); err != nil {
+		return nil, status.Error(codes.Internal, "failed to enable user")
+	}
+
+	s.log.Info("User enabled", logger.String("user_id", req.UserId))
+
+	return &authv1.EnableUserResponse{Success: true}, nil
+}
+
+// SearchUsers 按手机号前缀分页搜索用户（管理后台使用，手机号脱敏返回）
+func (s *AuthServer) SearchUsers(ctx context.Context, req *authv1.SearchUsersRequest) (*authv1.SearchUsersResponse, error) {
+	if !phonePrefixPattern.MatchString(req.PhonePrefix) {
+		return nil, status.Error(codes.InvalidArgument, "phone_prefix must be 3-11 digits")
+	}
+
+	page := int(req.Page)
+	if page < 1 {
+		page = 1
+	}
+	pageSize := int(req.PageSize)
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	total, err := s.userRepo.CountByPhonePrefix(ctx, req.PhonePrefix)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to count users")
+	}
+	users, err := s.userRepo.SearchByPhonePrefix(ctx, req.PhonePrefix, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to search users")
+	}
+
+	protoUsers := make([]*authv1.User, 0, len(users))
+	for _, user := range users {
+		protoUsers = append(protoUsers, s.userToProto(user))
+	}
+
+	return &authv1.SearchUsersResponse{
+		Users: protoUsers,
+		Total: int32(total),
+	}, nil
+}
+
+// RevokeUserSessions 撤销用户全部Token，强制所有设备重新登录（不禁用账号）
+func (s *AuthServer) RevokeUserSessions(ctx context.Context, req *authv1.RevokeUserSessionsRequest) (*authv1.RevokeUserSessionsResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	if _, err := s.userRepo.GetByID(ctx, req.UserId); err != nil {
+		return nil, userError(err, "failed to get user")
+	}
+
+	if err := s.jwtService.RevokeUserTokens(ctx, req.UserId); err != nil {
+		return nil, status.Error(codes.Internal, "failed to revoke tokens")
+	}
+
+	var revoked int32
+	if devices, err := s.deviceService.ListDevices(ctx, req.UserId); err == nil {
+		revoked = int32(len(devices))
+	}
+
+	s.log.Info("User sessions revoked",
+		logger.String("user_id", req.UserId),
+		logger.String("reason", req.Reason),
+		logger.Int("tokens_revoked", int(revoked)),
+	)
+
+	return &authv1.RevokeUserSessionsResponse{
+		Success:       true,
+		TokensRevoked: revoked,
+	}, nil
+}
+
+// userToProto 转换用户为proto消息（手机号脱敏）
+func (s *AuthServer) userToProto(user *domain.User) *authv1.User {
+	return &authv1.User{
+		Id:           user.ID,
+		Phone:        s.masker.MaskPhone(user.Phone),
+		Role:         authv1.UserRole_USER_ROLE_USER,
+		Disabled:     !user.IsActive,
+		TokenVersion: int32(user.TokenVersion),
+		CreatedAt:    timestamppb.New(user.CreatedAt),
+	}
+}
+
+// userError 把用户查询错误转换为gRPC错误
+func userError(err error, msg string) error {
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return status.Error(codes.NotFound, "user not found")
+	}
+	return status.Error(codes.Internal, msg)
+}
+
 // getErrorCode 根据错误类型返回对应的错误码
 func getErrorCode(err error) authv1.ErrorCode {
 	switch err {
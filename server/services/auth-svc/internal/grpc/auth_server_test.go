@@ -2,12 +2,16 @@ package grpc
 
 import (
 	"context"
+	"io"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/logger"
 	authv1 "github.com/xiaoxiao0301/listen-stream-v2/server/shared/proto/auth/v1"
 	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/domain"
 	deviceservice "github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/service/device"
@@ -100,13 +104,78 @@ func (m *MockDeviceService) RemoveInactiveDevices(ctx context.Context, days int)
 	return args.Get(0).(int), args.Error(1)
 }
 
+// MockUserRepository 用户仓储Mock
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) Create(ctx context.Context, user *domain.User) error {
+	return m.Called(ctx, user).Error(0)
+}
+
+func (m *MockUserRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetByPhone(ctx context.Context, phone string) (*domain.User, error) {
+	args := m.Called(ctx, phone)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateTokenVersion(ctx context.Context, id string, version int) error {
+	return m.Called(ctx, id, version).Error(0)
+}
+
+func (m *MockUserRepository) UpdateActive(ctx context.Context, id string, isActive bool) error {
+	return m.Called(ctx, id, isActive).Error(0)
+}
+
+func (m *MockUserRepository) Delete(ctx context.Context, id string) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *MockUserRepository) List(ctx context.Context, limit, offset int) ([]*domain.User, error) {
+	args := m.Called(ctx, limit, offset)
+	return args.Get(0).([]*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) Count(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserRepository) CountActive(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserRepository) SearchByPhonePrefix(ctx context.Context, prefix string, limit, offset int) ([]*domain.User, error) {
+	args := m.Called(ctx, prefix, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) CountByPhonePrefix(ctx context.Context, prefix string) (int64, error) {
+	args := m.Called(ctx, prefix)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 // TestVerifyToken_Success 测试Token验证成功
 func TestVerifyToken_Success(t *testing.T) {
 	ctx := context.Background()
 	mockJWT := new(MockJWTService)
 	mockDevice := new(MockDeviceService)
 
-	server := NewAuthServer(mockJWT, mockDevice)
+	server := NewAuthServer(mockJWT, mockDevice, nil, nil)
 
 	req := &authv1.VerifyTokenRequest{
 		AccessToken: "valid-token",
@@ -138,7 +207,7 @@ func TestVerifyToken_InvalidToken(t *testing.T) {
 	mockJWT := new(MockJWTService)
 	mockDevice := new(MockDeviceService)
 
-	server := NewAuthServer(mockJWT, mockDevice)
+	server := NewAuthServer(mockJWT, mockDevice, nil, nil)
 
 	req := &authv1.VerifyTokenRequest{
 		AccessToken: "invalid-token",
@@ -162,7 +231,7 @@ func TestRefreshToken_Success(t *testing.T) {
 	mockJWT := new(MockJWTService)
 	mockDevice := new(MockDeviceService)
 
-	server := NewAuthServer(mockJWT, mockDevice)
+	server := NewAuthServer(mockJWT, mockDevice, nil, nil)
 
 	req := &authv1.RefreshTokenRequest{
 		RefreshToken: "refresh-token",
@@ -194,7 +263,7 @@ func TestRevokeToken_Success(t *testing.T) {
 	mockJWT := new(MockJWTService)
 	mockDevice := new(MockDeviceService)
 
-	server := NewAuthServer(mockJWT, mockDevice)
+	server := NewAuthServer(mockJWT, mockDevice, nil, nil)
 
 	req := &authv1.RevokeTokenRequest{
 		AccessToken: "token-to-revoke",
@@ -224,7 +293,7 @@ func TestRevokeDevice_Success(t *testing.T) {
 	mockJWT := new(MockJWTService)
 	mockDevice := new(MockDeviceService)
 
-	server := NewAuthServer(mockJWT, mockDevice)
+	server := NewAuthServer(mockJWT, mockDevice, nil, nil)
 
 	req := &authv1.RevokeDeviceRequest{
 		UserId:   "user-123",
@@ -251,7 +320,7 @@ func TestGetUserDevices_Success(t *testing.T) {
 	mockJWT := new(MockJWTService)
 	mockDevice := new(MockDeviceService)
 
-	server := NewAuthServer(mockJWT, mockDevice)
+	server := NewAuthServer(mockJWT, mockDevice, nil, nil)
 
 	req := &authv1.GetUserDevicesRequest{
 		UserId: "user-123",
@@ -292,3 +361,72 @@ func TestGetUserDevices_Success(t *testing.T) {
 
 	mockDevice.AssertExpectations(t)
 }
+
+// TestGetUser 测试按手机号查询用户（手机号脱敏）和用户不存在
+func TestGetUser(t *testing.T) {
+	ctx := context.Background()
+	mockUsers := new(MockUserRepository)
+	server := NewAuthServer(new(MockJWTService), new(MockDeviceService), mockUsers, logger.New(&logger.Config{Output: io.Discard}))
+
+	user := &domain.User{ID: "user-123", Phone: "13812345678", TokenVersion: 2, IsActive: false, CreatedAt: time.Now()}
+	mockUsers.On("GetByPhone", ctx, "13812345678").Return(user, nil)
+	mockUsers.On("GetByID", ctx, "missing").Return(nil, domain.ErrUserNotFound)
+
+	resp, err := server.GetUser(ctx, &authv1.GetUserRequest{Phone: "13812345678"})
+	assert.NoError(t, err)
+	assert.Equal(t, "user-123", resp.User.Id)
+	assert.Equal(t, "138******78", resp.User.Phone)
+	assert.True(t, resp.User.Disabled)
+	assert.Equal(t, int32(2), resp.User.TokenVersion)
+
+	_, err = server.GetUser(ctx, &authv1.GetUserRequest{UserId: "missing"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = server.GetUser(ctx, &authv1.GetUserRequest{UserId: "user-123", Phone: "13812345678"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+// TestDisableUser_Success 测试禁用用户并撤销Token
+func TestDisableUser_Success(t *testing.T) {
+	ctx := context.Background()
+	mockJWT := new(MockJWTService)
+	mockDevice := new(MockDeviceService)
+	mockUsers := new(MockUserRepository)
+	server := NewAuthServer(mockJWT, mockDevice, mockUsers, logger.New(&logger.Config{Output: io.Discard}))
+
+	mockUsers.On("GetByID", ctx, "user-123").Return(&domain.User{ID: "user-123", IsActive: true}, nil)
+	mockUsers.On("UpdateActive", ctx, "user-123", false).Return(nil)
+	mockJWT.On("RevokeUserTokens", ctx, "user-123").Return(nil)
+	mockDevice.On("ListDevices", ctx, "user-123").Return([]*domain.Device{{ID: "device-1"}, {ID: "device-2"}}, nil)
+
+	resp, err := server.DisableUser(ctx, &authv1.DisableUserRequest{UserId: "user-123", Reason: "spam"})
+
+	assert.NoError(t, err)
+	assert.True(t, resp.Success)
+	assert.Equal(t, int32(2), resp.TokensRevoked)
+
+	mockUsers.AssertExpectations(t)
+	mockJWT.AssertExpectations(t)
+}
+
+// TestSearchUsers 测试按手机号前缀搜索用户
+func TestSearchUsers(t *testing.T) {
+	ctx := context.Background()
+	mockUsers := new(MockUserRepository)
+	server := NewAuthServer(new(MockJWTService), new(MockDeviceService), mockUsers, logger.New(&logger.Config{Output: io.Discard}))
+
+	users := []*domain.User{{ID: "user-123", Phone: "13812345678", IsActive: true, CreatedAt: time.Now()}}
+	mockUsers.On("CountByPhonePrefix", ctx, "1381").Return(int64(21), nil)
+	mockUsers.On("SearchByPhonePrefix", ctx, "1381", 20, 20).Return(users, nil)
+
+	resp, err := server.SearchUsers(ctx, &authv1.SearchUsersRequest{PhonePrefix: "1381", Page: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(21), resp.Total)
+	assert.Len(t, resp.Users, 1)
+	assert.Equal(t, "138******78", resp.Users[0].Phone)
+
+	_, err = server.SearchUsers(ctx, &authv1.SearchUsersRequest{PhonePrefix: "13"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = server.SearchUsers(ctx, &authv1.SearchUsersRequest{PhonePrefix: "138%"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/domain"
+	accountservice "github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/service/account"
+	jwtservice "github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/service/jwt"
+)
+
+// AccountHandler 账号注销与个人数据导出处理器
+type AccountHandler struct {
+	accountService accountservice.AccountService
+	jwtService     jwtservice.JWTService
+}
+
+// NewAccountHandler 创建账号处理器
+func NewAccountHandler(
+	accountService accountservice.AccountService,
+	jwtService jwtservice.JWTService,
+) *AccountHandler {
+	return &AccountHandler{
+		accountService: accountService,
+		jwtService:     jwtService,
+	}
+}
+
+// AccountDeletionResponse 注销申请响应
+type AccountDeletionResponse struct {
+	Success     bool   `json:"success"`
+	ID          string `json:"id"`
+	Status      string `json:"status"`
+	RequestedAt int64  `json:"requested_at"` // Unix timestamp
+	ScheduledAt int64  `json:"scheduled_at"` // 冷静期结束时间，之前可撤销
+	CancelledAt int64  `json:"cancelled_at,omitempty"`
+	CompletedAt int64  `json:"completed_at,omitempty"`
+}
+
+// RequestDeletion 申请注销账号（进入冷静期）
+func (h *AccountHandler) RequestDeletion(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.authenticate(w, r, http.MethodPost)
+	if !ok {
+		return
+	}
+
+	deletion, err := h.accountService.RequestDeletion(r.Context(), claims.UserID)
+	if err != nil {
+		respondAccountDeletionError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusAccepted, newAccountDeletionResponse(deletion))
+}
+
+// GetDeletion 查询注销申请状态
+func (h *AccountHandler) GetDeletion(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.authenticate(w, r, http.MethodGet)
+	if !ok {
+		return
+	}
+
+	deletion, err := h.accountService.GetDeletion(r.Context(), claims.UserID)
+	if err != nil {
+		respondAccountDeletionError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, newAccountDeletionResponse(deletion))
+}
+
+// CancelDeletion 撤销注销申请（仅冷静期内）
+func (h *AccountHandler) CancelDeletion(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.authenticate(w, r, http.MethodDelete)
+	if !ok {
+		return
+	}
+
+	deletion, err := h.accountService.CancelDeletion(r.Context(), claims.UserID)
+	if err != nil {
+		respondAccountDeletionError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, newAccountDeletionResponse(deletion))
+}
+
+// ExportData 下载个人数据（zip归档）
+func (h *AccountHandler) ExportData(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.authenticate(w, r, http.MethodGet)
+	if !ok {
+		return
+	}
+
+	archive, err := h.accountService.ExportData(r.Context(), claims.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			respondError(w, http.StatusNotFound, "user not found")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "failed to export account data")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", archive.Filename))
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	// 数据已全部取回，写出失败只可能是连接中断，客户端会收到不完整的zip
+	if _, err := archive.WriteTo(w); err != nil {
+		log.Printf("Error writing data export for user %s: %v", claims.UserID, err)
+	}
+}
+
+// authenticate 校验请求方法和Token
+func (h *AccountHandler) authenticate(w http.ResponseWriter, r *http.Request, method string) (*jwtservice.TokenClaims, bool) {
+	if r.Method != method {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return nil, false
+	}
+
+	token := extractToken(r)
+	if token == "" {
+		respondError(w, http.StatusUnauthorized, "missing authorization token")
+		return nil, false
+	}
+
+	claims, err := h.jwtService.ValidateAccessToken(r.Context(), token, "")
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "invalid token")
+		return nil, false
+	}
+	return claims, true
+}
+
+// newAccountDeletionResponse 转换注销申请响应
+func newAccountDeletionResponse(deletion *domain.AccountDeletion) AccountDeletionResponse {
+	resp := AccountDeletionResponse{
+		Success:     true,
+		ID:          deletion.ID,
+		Status:      string(deletion.Status),
+		RequestedAt: deletion.RequestedAt.Unix(),
+		ScheduledAt: deletion.ScheduledAt.Unix(),
+	}
+	if deletion.CancelledAt != nil {
+		resp.CancelledAt = deletion.CancelledAt.Unix()
+	}
+	if deletion.CompletedAt != nil {
+		resp.CompletedAt = deletion.CompletedAt.Unix()
+	}
+	return resp
+}
+
+// respondAccountDeletionError 将注销错误映射为HTTP状态码
+func respondAccountDeletionError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrAccountDeletionNotFound):
+		respondError(w, http.StatusNotFound, "no account deletion request")
+	case errors.Is(err, domain.ErrAccountDeletionNotCancellable):
+		respondError(w, http.StatusConflict, "account deletion can no longer be cancelled")
+	default:
+		respondError(w, http.StatusInternalServerError, "failed to process account deletion")
+	}
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"time"
 
@@ -9,7 +10,9 @@ import (
 	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/repository"
 	deviceservice "github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/service/device"
 	jwtservice "github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/service/jwt"
+	riskservice "github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/service/risk"
 	smsservice "github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/service/sms"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/stats"
 )
 
 // LoginHandler 登录处理器
@@ -17,27 +20,39 @@ type LoginHandler struct {
 	smsService    *smsservice.Service
 	jwtService    jwtservice.JWTService
 	deviceService deviceservice.DeviceService
+	riskService   riskservice.RiskService
 	userRepo      repository.UserRepository
+	stats         stats.Emitter
 }
 
 // NewLoginHandler 创建登录处理器
+// riskService为nil时不做登录风控，statsEmitter为nil时不上报登录/注册统计
 func NewLoginHandler(
 	smsService *smsservice.Service,
 	jwtService jwtservice.JWTService,
 	deviceService deviceservice.DeviceService,
+	riskService riskservice.RiskService,
 	userRepo repository.UserRepository,
+	statsEmitter stats.Emitter,
 ) *LoginHandler {
+	if statsEmitter == nil {
+		statsEmitter = stats.NoopEmitter{}
+	}
 	return &LoginHandler{
 		smsService:    smsService,
 		jwtService:    jwtService,
 		deviceService: deviceService,
+		riskService:   riskService,
 		userRepo:      userRepo,
+		stats:         statsEmitter,
 	}
 }
 
 // SendVerificationCodeRequest 发送验证码请求
 type SendVerificationCodeRequest struct {
-	Phone string `json:"phone"`
+	Phone             string `json:"phone"`
+	DeviceFingerprint string `json:"device_fingerprint,omitempty"`
+	CaptchaToken      string `json:"captcha_token,omitempty"` // 返回captcha_required后需要提交
 }
 
 // SendVerificationCodeResponse 发送验证码响应
@@ -68,6 +83,24 @@ type VerifyLoginResponse struct {
 	ExpiresAt    int64  `json:"expires_at,omitempty"` // Unix timestamp
 	TokenType    string `json:"token_type,omitempty"`
 	UserID       string `json:"user_id,omitempty"`
+
+	// 风控要求二次验证时设置
+	StepUpRequired     bool     `json:"step_up_required,omitempty"`
+	ChallengeID        string   `json:"challenge_id,omitempty"`
+	StepUpMethods      []string `json:"step_up_methods,omitempty"`
+	ChallengeExpiresAt int64    `json:"challenge_expires_at,omitempty"` // Unix timestamp
+}
+
+// VerifyStepUpRequest 二次验证请求
+type VerifyStepUpRequest struct {
+	ChallengeID string `json:"challenge_id"`
+	Method      string `json:"method"` // sms | totp，为空时默认sms
+	Code        string `json:"code"`
+}
+
+// ResendStepUpCodeRequest 重发二次验证短信请求
+type ResendStepUpCodeRequest struct {
+	ChallengeID string `json:"challenge_id"`
 }
 
 // SendVerificationCode 发送验证码
@@ -90,9 +123,14 @@ func (h *LoginHandler) SendVerificationCode(w http.ResponseWriter, r *http.Reque
 	}
 
 	// 发送短信验证码
-	result, err := h.smsService.SendVerificationCode(r.Context(), req.Phone)
+	result, err := h.smsService.SendVerificationCode(r.Context(), &smsservice.SendCodeRequest{
+		Phone:             req.Phone,
+		ClientIP:          getClientIP(r),
+		DeviceFingerprint: req.DeviceFingerprint,
+		CaptchaToken:      req.CaptchaToken,
+	})
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "failed to send verification code")
+		respondSendCodeError(w, err)
 		return
 	}
 
@@ -156,6 +194,7 @@ func (h *LoginHandler) VerifyLogin(w http.ResponseWriter, r *http.Request) {
 				respondError(w, http.StatusInternalServerError, "failed to create user")
 				return
 			}
+			h.stats.Emit(stats.Event{Type: stats.EventNewUser, UserID: user.ID})
 		} else {
 			respondError(w, http.StatusInternalServerError, "failed to get user")
 			return
@@ -171,8 +210,40 @@ func (h *LoginHandler) VerifyLogin(w http.ResponseWriter, r *http.Request) {
 	// 获取客户端IP
 	clientIP := getClientIP(r)
 
-	// 注册或更新设备
-	deviceReq := &deviceservice.RegisterDeviceRequest{
+	// 登录风控
+	if h.riskService != nil {
+		assessment, err := h.riskService.EvaluateLogin(r.Context(), &riskservice.EvaluateLoginRequest{
+			User:       user,
+			DeviceName: req.DeviceName,
+			DeviceID:   req.DeviceID,
+			Platform:   req.Platform,
+			OSVersion:  req.OSVersion,
+			AppVersion: req.AppVersion,
+			ClientIP:   clientIP,
+		})
+		if errors.Is(err, domain.ErrSMSLimitExceeded) {
+			respondError(w, http.StatusTooManyRequests, "SMS send limit exceeded, please try again later")
+			return
+		}
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "failed to evaluate login risk")
+			return
+		}
+
+		switch assessment.Action() {
+		case domain.LoginRiskActionBlock:
+			respondError(w, http.StatusForbidden, "login blocked due to suspicious activity")
+			return
+		case domain.LoginRiskActionStepUp:
+			respondStepUpRequired(w, assessment.Challenge)
+			return
+		}
+	}
+
+	// TODO: 删除已使用的验证码
+	// redis.Del(ctx, "sms:code:"+req.Phone)
+
+	h.completeLogin(w, r, &deviceservice.RegisterDeviceRequest{
 		UserID:     user.ID,
 		DeviceName: req.DeviceName,
 		DeviceID:   req.DeviceID,
@@ -180,22 +251,100 @@ func (h *LoginHandler) VerifyLogin(w http.ResponseWriter, r *http.Request) {
 		OSVersion:  req.OSVersion,
 		AppVersion: req.AppVersion,
 		ClientIP:   clientIP,
+	})
+}
+
+// VerifyStepUp 完成二次验证并登录
+func (h *LoginHandler) VerifyStepUp(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if h.riskService == nil {
+		respondError(w, http.StatusNotFound, "step-up verification is not enabled")
+		return
+	}
+
+	var req VerifyStepUpRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.ChallengeID == "" || req.Code == "" {
+		respondError(w, http.StatusBadRequest, "challenge_id and code are required")
+		return
+	}
+
+	method := domain.StepUpMethod(req.Method)
+	if method == "" {
+		method = domain.StepUpMethodSMS
+	}
+
+	challenge, err := h.riskService.VerifyStepUp(r.Context(), req.ChallengeID, method, req.Code)
+	if err != nil {
+		respondStepUpError(w, err)
+		return
+	}
+
+	h.completeLogin(w, r, &deviceservice.RegisterDeviceRequest{
+		UserID:     challenge.UserID,
+		DeviceName: challenge.DeviceName,
+		DeviceID:   challenge.DeviceID,
+		Platform:   challenge.Platform,
+		OSVersion:  challenge.OSVersion,
+		AppVersion: challenge.AppVersion,
+		ClientIP:   getClientIP(r),
+	})
+}
+
+// ResendStepUpCode 重新发送二次验证短信
+func (h *LoginHandler) ResendStepUpCode(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if h.riskService == nil {
+		respondError(w, http.StatusNotFound, "step-up verification is not enabled")
+		return
+	}
+
+	var req ResendStepUpCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
 	}
+	if req.ChallengeID == "" {
+		respondError(w, http.StatusBadRequest, "challenge_id is required")
+		return
+	}
+
+	challenge, err := h.riskService.ResendStepUpCode(r.Context(), req.ChallengeID)
+	if err != nil {
+		respondStepUpError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, SendVerificationCodeResponse{
+		Success:   true,
+		Message:   "verification code sent",
+		ExpiresIn: int(time.Until(challenge.ExpiresAt).Seconds()),
+	})
+}
+
+// completeLogin 注册设备并签发Token
+func (h *LoginHandler) completeLogin(w http.ResponseWriter, r *http.Request, deviceReq *deviceservice.RegisterDeviceRequest) {
 	device, err := h.deviceService.RegisterDevice(r.Context(), deviceReq)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "failed to register device: "+err.Error())
 		return
 	}
 
-	// 生成Token
-	tokenPair, err := h.jwtService.GenerateTokenPair(r.Context(), user.ID, device.ID, clientIP)
+	tokenPair, err := h.jwtService.GenerateTokenPair(r.Context(), deviceReq.UserID, device.ID, deviceReq.ClientIP)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "failed to generate token")
 		return
 	}
-
-	// TODO: 删除已使用的验证码
-	// redis.Del(ctx, "sms:code:"+req.Phone)
+	h.stats.Emit(stats.Event{Type: stats.EventLogin, UserID: deviceReq.UserID})
 
 	respondJSON(w, http.StatusOK, VerifyLoginResponse{
 		Success:      true,
@@ -203,10 +352,74 @@ func (h *LoginHandler) VerifyLogin(w http.ResponseWriter, r *http.Request) {
 		RefreshToken: tokenPair.RefreshToken,
 		ExpiresAt:    tokenPair.ExpiresAt.Unix(),
 		TokenType:    "Bearer",
-		UserID:       user.ID,
+		UserID:       deviceReq.UserID,
+	})
+}
+
+// respondStepUpRequired 返回需要二次验证的响应（202，不签发Token）
+func respondStepUpRequired(w http.ResponseWriter, challenge *domain.StepUpChallenge) {
+	methods := make([]string, 0, len(challenge.Methods))
+	for _, m := range challenge.Methods {
+		methods = append(methods, string(m))
+	}
+
+	respondJSON(w, http.StatusAccepted, VerifyLoginResponse{
+		Success:            false,
+		Message:            "additional verification required",
+		StepUpRequired:     true,
+		ChallengeID:        challenge.ID,
+		StepUpMethods:      methods,
+		ChallengeExpiresAt: challenge.ExpiresAt.Unix(),
 	})
 }
 
+// respondStepUpError 将二次验证错误映射为HTTP状态码
+func respondStepUpError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrStepUpChallengeNotFound), errors.Is(err, domain.ErrStepUpChallengeExpired):
+		respondError(w, http.StatusGone, "step-up challenge expired")
+	case errors.Is(err, domain.ErrStepUpInvalidCode):
+		respondError(w, http.StatusUnauthorized, "invalid verification code")
+	case errors.Is(err, domain.ErrStepUpTooManyAttempts):
+		respondError(w, http.StatusForbidden, "too many verification attempts")
+	case errors.Is(err, domain.ErrStepUpMethodUnavailable):
+		respondError(w, http.StatusBadRequest, "verification method unavailable")
+	case errors.Is(err, domain.ErrSMSTooFrequent):
+		respondError(w, http.StatusTooManyRequests, "SMS sent too frequently, please wait")
+	case errors.Is(err, domain.ErrSMSLimitExceeded):
+		respondError(w, http.StatusTooManyRequests, "SMS send limit exceeded, please try again later")
+	case errors.Is(err, domain.ErrUserInactive):
+		respondError(w, http.StatusForbidden, "user account is disabled")
+	default:
+		respondError(w, http.StatusInternalServerError, "failed to process step-up verification")
+	}
+}
+
+// respondSendCodeError 发送验证码错误响应
+// 需要人机验证时返回captcha_required，客户端完成验证后携带captcha_token重试
+func respondSendCodeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrSMSTooFrequent):
+		respondError(w, http.StatusTooManyRequests, "SMS sent too frequently, please wait")
+	case errors.Is(err, domain.ErrSMSLimitExceeded):
+		respondError(w, http.StatusTooManyRequests, "SMS send limit exceeded, please try again later")
+	case errors.Is(err, domain.ErrCaptchaRequired):
+		respondJSON(w, http.StatusForbidden, map[string]interface{}{
+			"success":          false,
+			"error":            "captcha verification required",
+			"captcha_required": true,
+		})
+	case errors.Is(err, domain.ErrCaptchaInvalid):
+		respondJSON(w, http.StatusForbidden, map[string]interface{}{
+			"success":          false,
+			"error":            "captcha verification failed",
+			"captcha_required": true,
+		})
+	default:
+		respondError(w, http.StatusInternalServerError, "failed to send verification code")
+	}
+}
+
 // generateVerificationCode 生成6位数字验证码
 func generateVerificationCode() string {
 	// 实际应该使用随机数生成
@@ -0,0 +1,349 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/domain"
+	jwtservice "github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/service/jwt"
+	qrloginservice "github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/service/qrlogin"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/stats"
+)
+
+// QRLoginHandler 二维码登录处理器
+type QRLoginHandler struct {
+	qrLoginService qrloginservice.QRLoginService
+	jwtService     jwtservice.JWTService
+	stats          stats.Emitter
+}
+
+// NewQRLoginHandler 创建二维码登录处理器
+// statsEmitter为nil时不上报登录统计
+func NewQRLoginHandler(
+	qrLoginService qrloginservice.QRLoginService,
+	jwtService jwtservice.JWTService,
+	statsEmitter stats.Emitter,
+) *QRLoginHandler {
+	if statsEmitter == nil {
+		statsEmitter = stats.NoopEmitter{}
+	}
+	return &QRLoginHandler{
+		qrLoginService: qrLoginService,
+		jwtService:     jwtService,
+		stats:          statsEmitter,
+	}
+}
+
+// PollTokenHeader 轮询令牌请求头
+const PollTokenHeader = "X-Poll-Token"
+
+// CreateQRTicketRequest 创建二维码票据请求（新设备）
+type CreateQRTicketRequest struct {
+	DeviceID   string `json:"device_id"`
+	DeviceName string `json:"device_name"`
+	Platform   string `json:"platform"`
+	OSVersion  string `json:"os_version"`
+	AppVersion string `json:"app_version"`
+}
+
+// CreateQRTicketResponse 创建二维码票据响应
+type CreateQRTicketResponse struct {
+	Success   bool   `json:"success"`
+	TicketID  string `json:"ticket_id"`  // 渲染为二维码的内容
+	PollToken string `json:"poll_token"` // 轮询凭证，客户端本地保存，不得放入二维码
+	ExpiresAt int64  `json:"expires_at"` // Unix timestamp
+}
+
+// QRTicketActionRequest 扫码/确认/拒绝请求（已登录设备）
+type QRTicketActionRequest struct {
+	TicketID string `json:"ticket_id"`
+}
+
+// CancelQRTicketRequest 新设备取消票据请求
+type CancelQRTicketRequest struct {
+	TicketID  string `json:"ticket_id"`
+	PollToken string `json:"poll_token"`
+}
+
+// ScanQRTicketResponse 扫码响应（展示待登录设备信息供用户确认）
+type ScanQRTicketResponse struct {
+	Success    bool   `json:"success"`
+	TicketID   string `json:"ticket_id"`
+	DeviceName string `json:"device_name"`
+	Platform   string `json:"platform"`
+	ClientIP   string `json:"client_ip"`
+	ExpiresAt  int64  `json:"expires_at"`
+}
+
+// PollQRTicketResponse 轮询响应
+type PollQRTicketResponse struct {
+	Success      bool   `json:"success"`
+	Status       string `json:"status"`
+	ExpiresAt    int64  `json:"expires_at,omitempty"`
+	AccessToken  string `json:"access_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	TokenType    string `json:"token_type,omitempty"`
+	UserID       string `json:"user_id,omitempty"`
+	DeviceID     string `json:"device_id,omitempty"`
+}
+
+// CreateTicket 创建二维码登录票据（新设备调用，无需登录）
+func (h *QRLoginHandler) CreateTicket(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var req CreateQRTicketRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.DeviceID == "" || req.DeviceName == "" || req.Platform == "" {
+		respondError(w, http.StatusBadRequest, "device information is required")
+		return
+	}
+
+	ticket, err := h.qrLoginService.CreateTicket(r.Context(), &qrloginservice.CreateTicketRequest{
+		DeviceName: req.DeviceName,
+		DeviceID:   req.DeviceID,
+		Platform:   req.Platform,
+		OSVersion:  req.OSVersion,
+		AppVersion: req.AppVersion,
+		ClientIP:   getClientIP(r),
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidPlatform) {
+			respondError(w, http.StatusBadRequest, "invalid platform")
+			return
+		}
+		if errors.Is(err, domain.ErrQRTicketRateLimited) {
+			respondError(w, http.StatusTooManyRequests, "too many QR login tickets, please try again later")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "failed to create QR login ticket")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, CreateQRTicketResponse{
+		Success:   true,
+		TicketID:  ticket.ID,
+		PollToken: ticket.PollToken,
+		ExpiresAt: ticket.ExpiresAt.Unix(),
+	})
+}
+
+// PollTicket 长轮询票据状态（新设备调用）
+// Query: ticket_id, last_status（可选）, wait（可选，秒）
+// Header: X-Poll-Token（轮询令牌不放在URL中，避免写入网关和代理的访问日志）
+func (h *QRLoginHandler) PollTicket(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+	ticketID := query.Get("ticket_id")
+	pollToken := r.Header.Get(PollTokenHeader)
+	if ticketID == "" || pollToken == "" {
+		respondError(w, http.StatusBadRequest, "ticket_id and X-Poll-Token header are required")
+		return
+	}
+
+	var wait time.Duration
+	if waitStr := query.Get("wait"); waitStr != "" {
+		seconds, err := strconv.Atoi(waitStr)
+		if err != nil || seconds < 0 {
+			respondError(w, http.StatusBadRequest, "invalid wait")
+			return
+		}
+		wait = time.Duration(seconds) * time.Second
+	}
+
+	result, err := h.qrLoginService.PollTicket(r.Context(), &qrloginservice.PollTicketRequest{
+		TicketID:   ticketID,
+		PollToken:  pollToken,
+		LastStatus: domain.QRLoginStatus(query.Get("last_status")),
+		Wait:       wait,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrQRTicketPollToken) {
+			respondError(w, http.StatusForbidden, "invalid poll token")
+			return
+		}
+		if errors.Is(err, domain.ErrUserInactive) {
+			respondError(w, http.StatusForbidden, "user account is disabled")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "failed to poll QR login ticket")
+		return
+	}
+
+	resp := PollQRTicketResponse{
+		Success: true,
+		Status:  string(result.Status),
+	}
+	if !result.ExpiresAt.IsZero() {
+		resp.ExpiresAt = result.ExpiresAt.Unix()
+	}
+	if result.TokenPair != nil {
+		resp.AccessToken = result.TokenPair.AccessToken
+		resp.RefreshToken = result.TokenPair.RefreshToken
+		resp.ExpiresAt = result.TokenPair.ExpiresAt.Unix()
+		resp.TokenType = "Bearer"
+		resp.UserID = result.UserID
+		resp.DeviceID = result.DeviceID
+		// 确认后的Token只能领取一次，领取即视为登录成功
+		h.stats.Emit(stats.Event{Type: stats.EventLogin, UserID: result.UserID})
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// ScanTicket 扫码（已登录设备调用）
+func (h *QRLoginHandler) ScanTicket(w http.ResponseWriter, r *http.Request) {
+	claims, req, ok := h.parseActionRequest(w, r)
+	if !ok {
+		return
+	}
+
+	ticket, err := h.qrLoginService.ScanTicket(r.Context(), req.TicketID, claims.UserID)
+	if err != nil {
+		respondQRTicketError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, ScanQRTicketResponse{
+		Success:    true,
+		TicketID:   ticket.ID,
+		DeviceName: ticket.DeviceName,
+		Platform:   ticket.Platform,
+		ClientIP:   ticket.ClientIP,
+		ExpiresAt:  ticket.ExpiresAt.Unix(),
+	})
+}
+
+// ConfirmTicket 确认登录（已登录设备调用）
+func (h *QRLoginHandler) ConfirmTicket(w http.ResponseWriter, r *http.Request) {
+	claims, req, ok := h.parseActionRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.qrLoginService.ConfirmTicket(r.Context(), req.TicketID, claims.UserID); err != nil {
+		respondQRTicketError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "login confirmed",
+	})
+}
+
+// CancelTicket 拒绝登录（已登录设备调用，必须是扫码用户）
+func (h *QRLoginHandler) CancelTicket(w http.ResponseWriter, r *http.Request) {
+	claims, req, ok := h.parseActionRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.qrLoginService.CancelTicket(r.Context(), req.TicketID, claims.UserID); err != nil {
+		respondQRTicketError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "login cancelled",
+	})
+}
+
+// CancelTicketByDevice 取消登录（新设备调用，需要轮询令牌）
+func (h *QRLoginHandler) CancelTicketByDevice(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var req CancelQRTicketRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.TicketID == "" || req.PollToken == "" {
+		respondError(w, http.StatusBadRequest, "ticket_id and poll_token are required")
+		return
+	}
+
+	if err := h.qrLoginService.CancelTicketByDevice(r.Context(), req.TicketID, req.PollToken); err != nil {
+		if errors.Is(err, domain.ErrQRTicketPollToken) {
+			respondError(w, http.StatusForbidden, "invalid poll token")
+			return
+		}
+		respondQRTicketError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "login cancelled",
+	})
+}
+
+// parseActionRequest 校验已登录设备的Token并解析请求体
+func (h *QRLoginHandler) parseActionRequest(w http.ResponseWriter, r *http.Request) (*jwtservice.TokenClaims, *QRTicketActionRequest, bool) {
+	if r.Method != http.MethodPost {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return nil, nil, false
+	}
+
+	token := extractToken(r)
+	if token == "" {
+		respondError(w, http.StatusUnauthorized, "missing authorization token")
+		return nil, nil, false
+	}
+
+	claims, err := h.jwtService.ValidateAccessToken(r.Context(), token, "")
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "invalid token")
+		return nil, nil, false
+	}
+
+	var req QRTicketActionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return nil, nil, false
+	}
+
+	if req.TicketID == "" {
+		respondError(w, http.StatusBadRequest, "ticket_id is required")
+		return nil, nil, false
+	}
+
+	return claims, &req, true
+}
+
+// respondQRTicketError 将票据错误映射为HTTP状态码
+func respondQRTicketError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrQRTicketNotFound), errors.Is(err, domain.ErrQRTicketExpired):
+		respondError(w, http.StatusGone, "QR login ticket expired")
+	case errors.Is(err, domain.ErrQRTicketAlreadyScanned), errors.Is(err, domain.ErrQRTicketUserMismatch):
+		respondError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, domain.ErrQRTicketInvalidState):
+		respondError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, domain.ErrMaxDevicesExceeded):
+		respondError(w, http.StatusForbidden, "maximum number of devices exceeded")
+	case errors.Is(err, domain.ErrUserInactive):
+		respondError(w, http.StatusForbidden, "user account is disabled")
+	case errors.Is(err, domain.ErrLoginBlocked):
+		respondError(w, http.StatusForbidden, "login blocked due to suspicious activity")
+	default:
+		respondError(w, http.StatusInternalServerError, "failed to process QR login ticket")
+	}
+}
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/domain"
+	jwtservice "github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/service/jwt"
+	totpservice "github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/service/totp"
+)
+
+// TOTPHandler 身份验证器（TOTP）绑定处理器
+type TOTPHandler struct {
+	totpService totpservice.TOTPService
+	jwtService  jwtservice.JWTService
+}
+
+// NewTOTPHandler 创建TOTP处理器
+func NewTOTPHandler(
+	totpService totpservice.TOTPService,
+	jwtService jwtservice.JWTService,
+) *TOTPHandler {
+	return &TOTPHandler{
+		totpService: totpService,
+		jwtService:  jwtService,
+	}
+}
+
+// TOTPCodeRequest 提交验证码请求
+type TOTPCodeRequest struct {
+	Code string `json:"code"`
+}
+
+// TOTPSetupResponse 生成密钥响应
+type TOTPSetupResponse struct {
+	Success bool   `json:"success"`
+	Secret  string `json:"secret"` // 手动输入到身份验证器
+	URL     string `json:"url"`    // otpauth://链接，客户端渲染为二维码
+}
+
+// TOTPStatusResponse 绑定状态响应
+type TOTPStatusResponse struct {
+	Success bool `json:"success"`
+	Enabled bool `json:"enabled"`
+}
+
+// GetStatus 查询是否已绑定身份验证器
+func (h *TOTPHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.authenticate(w, r, http.MethodGet)
+	if !ok {
+		return
+	}
+
+	enabled, err := h.totpService.IsEnrolled(r.Context(), claims.UserID)
+	if err != nil {
+		respondTOTPError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, TOTPStatusResponse{Success: true, Enabled: enabled})
+}
+
+// Setup 生成待确认的密钥（重复调用会替换未确认的密钥）
+func (h *TOTPHandler) Setup(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.authenticate(w, r, http.MethodPost)
+	if !ok {
+		return
+	}
+
+	enrollment, err := h.totpService.Setup(r.Context(), claims.UserID)
+	if err != nil {
+		respondTOTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	respondJSON(w, http.StatusOK, TOTPSetupResponse{
+		Success: true,
+		Secret:  enrollment.Secret,
+		URL:     enrollment.URL,
+	})
+}
+
+// Confirm 提交身份验证器中的验证码完成绑定
+func (h *TOTPHandler) Confirm(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.authenticate(w, r, http.MethodPost)
+	if !ok {
+		return
+	}
+	code, ok := decodeTOTPCode(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.totpService.Confirm(r.Context(), claims.UserID, code); err != nil {
+		respondTOTPError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, TOTPStatusResponse{Success: true, Enabled: true})
+}
+
+// Disable 解除绑定（需要当前验证码）
+func (h *TOTPHandler) Disable(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.authenticate(w, r, http.MethodDelete)
+	if !ok {
+		return
+	}
+	code, ok := decodeTOTPCode(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.totpService.Disable(r.Context(), claims.UserID, code); err != nil {
+		respondTOTPError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, TOTPStatusResponse{Success: true, Enabled: false})
+}
+
+// authenticate 校验请求方法和Token
+func (h *TOTPHandler) authenticate(w http.ResponseWriter, r *http.Request, method string) (*jwtservice.TokenClaims, bool) {
+	if r.Method != method {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return nil, false
+	}
+
+	token := extractToken(r)
+	if token == "" {
+		respondError(w, http.StatusUnauthorized, "missing authorization token")
+		return nil, false
+	}
+
+	claims, err := h.jwtService.ValidateAccessToken(r.Context(), token, "")
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "invalid token")
+		return nil, false
+	}
+	return claims, true
+}
+
+// decodeTOTPCode 解析请求中的验证码
+func decodeTOTPCode(w http.ResponseWriter, r *http.Request) (string, bool) {
+	var req TOTPCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return "", false
+	}
+	if req.Code == "" {
+		respondError(w, http.StatusBadRequest, "code is required")
+		return "", false
+	}
+	return req.Code, true
+}
+
+// respondTOTPError 将TOTP错误映射为HTTP状态码
+func respondTOTPError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		respondError(w, http.StatusNotFound, "user not found")
+	case errors.Is(err, domain.ErrTOTPNotEnrolled):
+		respondError(w, http.StatusNotFound, "authenticator not enrolled")
+	case errors.Is(err, domain.ErrTOTPAlreadyEnrolled):
+		respondError(w, http.StatusConflict, "authenticator already enrolled")
+	case errors.Is(err, domain.ErrTOTPInvalidCode):
+		respondError(w, http.StatusBadRequest, "invalid verification code")
+	default:
+		respondError(w, http.StatusInternalServerError, "failed to process authenticator request")
+	}
+}
@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/domain"
+)
+
+// AccountDeletionRepository 账号注销请求仓储接口
+type AccountDeletionRepository interface {
+	// Create 创建注销请求（同一用户只能有一个进行中的请求）
+	Create(ctx context.Context, deletion *domain.AccountDeletion) error
+	// GetLatestByUser 获取用户最近一次注销请求
+	GetLatestByUser(ctx context.Context, userID string) (*domain.AccountDeletion, error)
+	// Cancel 撤销冷静期内的注销请求
+	Cancel(ctx context.Context, userID string, cancelledAt time.Time) (*domain.AccountDeletion, error)
+	// ClaimDue 领取到期的注销请求并标记为删除中，lease内其他实例不会重复领取
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.AccountDeletion, error)
+	// Update 更新注销进度
+	Update(ctx context.Context, deletion *domain.AccountDeletion) error
+}
+
+// accountDeletionRepository PostgreSQL账号注销请求仓储实现
+type accountDeletionRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewAccountDeletionRepository 创建账号注销请求仓储
+func NewAccountDeletionRepository(db *pgxpool.Pool) AccountDeletionRepository {
+	return &accountDeletionRepository{db: db}
+}
+
+const accountDeletionColumns = `
+	id, user_id, status, completed_steps, attempts, last_error,
+	requested_at, scheduled_at, next_attempt_at, cancelled_at, completed_at, updated_at
+`
+
+// Create 创建注销请求
+func (r *accountDeletionRepository) Create(ctx context.Context, d *domain.AccountDeletion) error {
+	query := `
+		INSERT INTO account_deletions (` + accountDeletionColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+	_, err := r.db.Exec(ctx, query,
+		d.ID,
+		d.UserID,
+		string(d.Status),
+		d.CompletedSteps,
+		d.Attempts,
+		d.LastError,
+		d.RequestedAt,
+		d.ScheduledAt,
+		d.NextAttemptAt,
+		d.CancelledAt,
+		d.CompletedAt,
+		d.UpdatedAt,
+	)
+	return err
+}
+
+// GetLatestByUser 获取用户最近一次注销请求
+func (r *accountDeletionRepository) GetLatestByUser(ctx context.Context, userID string) (*domain.AccountDeletion, error) {
+	query := `
+		SELECT ` + accountDeletionColumns + `
+		FROM account_deletions
+		WHERE user_id = $1
+		ORDER BY requested_at DESC
+		LIMIT 1
+	`
+
+	deletion, err := scanAccountDeletion(r.db.QueryRow(ctx, query, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrAccountDeletionNotFound
+		}
+		return nil, err
+	}
+	return deletion, nil
+}
+
+// Cancel 撤销冷静期内的注销请求
+// 条件更新保证与ClaimDue并发时，已开始删除的请求不会被撤销
+func (r *accountDeletionRepository) Cancel(ctx context.Context, userID string, cancelledAt time.Time) (*domain.AccountDeletion, error) {
+	query := `
+		UPDATE account_deletions
+		SET status = $2, cancelled_at = $3, updated_at = $3
+		WHERE user_id = $1 AND status = $4
+		RETURNING ` + accountDeletionColumns
+
+	deletion, err := scanAccountDeletion(r.db.QueryRow(ctx, query,
+		userID,
+		string(domain.AccountDeletionStatusCancelled),
+		cancelledAt,
+		string(domain.AccountDeletionStatusPending),
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrAccountDeletionNotCancellable
+		}
+		return nil, err
+	}
+	return deletion, nil
+}
+
+// ClaimDue 领取到期的注销请求
+// 使用FOR UPDATE SKIP LOCKED避免多实例重复领取；领取时把next_attempt_at推后lease，
+// 实例崩溃后请求在lease到期后会被重新领取
+func (r *accountDeletionRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.AccountDeletion, error) {
+	query := `
+		UPDATE account_deletions
+		SET status = $2, next_attempt_at = $3, updated_at = $1
+		WHERE id IN (
+			SELECT id FROM account_deletions
+			WHERE status IN ($4, $2)
+			  AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $5
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + accountDeletionColumns
+
+	rows, err := r.db.Query(ctx, query,
+		now,
+		string(domain.AccountDeletionStatusProcessing),
+		now.Add(lease),
+		string(domain.AccountDeletionStatusPending),
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deletions []*domain.AccountDeletion
+	for rows.Next() {
+		deletion, err := scanAccountDeletion(rows)
+		if err != nil {
+			return nil, err
+		}
+		deletions = append(deletions, deletion)
+	}
+	return deletions, rows.Err()
+}
+
+// Update 更新注销进度
+func (r *accountDeletionRepository) Update(ctx context.Context, d *domain.AccountDeletion) error {
+	query := `
+		UPDATE account_deletions
+		SET status = $2,
+		    completed_steps = $3,
+		    attempts = $4,
+		    last_error = $5,
+		    next_attempt_at = $6,
+		    completed_at = $7,
+		    updated_at = $8
+		WHERE id = $1
+	`
+	tag, err := r.db.Exec(ctx, query,
+		d.ID,
+		string(d.Status),
+		d.CompletedSteps,
+		d.Attempts,
+		d.LastError,
+		d.NextAttemptAt,
+		d.CompletedAt,
+		d.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrAccountDeletionNotFound
+	}
+	return nil
+}
+
+// scanAccountDeletion 扫描一行注销请求
+func scanAccountDeletion(row pgx.Row) (*domain.AccountDeletion, error) {
+	var (
+		d      domain.AccountDeletion
+		status string
+	)
+	err := row.Scan(
+		&d.ID,
+		&d.UserID,
+		&status,
+		&d.CompletedSteps,
+		&d.Attempts,
+		&d.LastError,
+		&d.RequestedAt,
+		&d.ScheduledAt,
+		&d.NextAttemptAt,
+		&d.CancelledAt,
+		&d.CompletedAt,
+		&d.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	d.Status = domain.AccountDeletionStatus(status)
+	return &d, nil
+}
@@ -2,9 +2,10 @@ package repository
 
 import (
 	"context"
-	"database/sql"
+	"errors"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/domain"
 )
@@ -85,7 +86,7 @@ func (r *deviceRepository) GetByID(ctx context.Context, id string) (*domain.Devi
 		&device.CreatedAt,
 	)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrDeviceNotFound
 		}
 		return nil, err
@@ -116,7 +117,7 @@ func (r *deviceRepository) GetByFingerprint(ctx context.Context, userID, fingerp
 		&device.CreatedAt,
 	)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrDeviceNotFound
 		}
 		return nil, err
@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/domain"
+)
+
+// LoginRiskRepository 登录风控决策仓储接口
+type LoginRiskRepository interface {
+	// Create 记录风控决策
+	Create(ctx context.Context, decision *domain.LoginRiskDecision) error
+	// GetByID 根据ID获取风控决策
+	GetByID(ctx context.Context, id string) (*domain.LoginRiskDecision, error)
+	// ListSuccessful 获取用户指定时间后的成功登录（按时间倒序）
+	ListSuccessful(ctx context.Context, userID string, since time.Time, limit int) ([]*domain.LoginRiskDecision, error)
+	// ListByUser 获取用户最近的登录记录（按时间倒序，用于个人数据导出）
+	ListByUser(ctx context.Context, userID string, limit int) ([]*domain.LoginRiskDecision, error)
+	// CountSince 统计用户指定时间后的登录尝试次数、不同IP数，以及clientIP是否已出现过
+	CountSince(ctx context.Context, userID, clientIP string, since time.Time) (attempts, distinctIPs int64, seenIP bool, err error)
+	// UpdateOutcome 更新决策最终结果
+	UpdateOutcome(ctx context.Context, id string, outcome domain.LoginRiskOutcome, resolvedAt time.Time) error
+}
+
+// loginRiskRepository PostgreSQL登录风控决策仓储实现
+type loginRiskRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewLoginRiskRepository 创建登录风控决策仓储
+func NewLoginRiskRepository(db *pgxpool.Pool) LoginRiskRepository {
+	return &loginRiskRepository{db: db}
+}
+
+const loginRiskDecisionColumns = `
+	id, user_id, device_id, fingerprint, client_ip, country, city,
+	latitude, longitude, score, action, reasons, outcome, created_at, resolved_at
+`
+
+// Create 记录风控决策
+func (r *loginRiskRepository) Create(ctx context.Context, d *domain.LoginRiskDecision) error {
+	query := `
+		INSERT INTO login_risk_decisions (` + loginRiskDecisionColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+	`
+	_, err := r.db.Exec(ctx, query,
+		d.ID,
+		d.UserID,
+		d.DeviceID,
+		d.Fingerprint,
+		d.ClientIP,
+		d.Country,
+		d.City,
+		d.Latitude,
+		d.Longitude,
+		d.Score,
+		string(d.Action),
+		d.Reasons,
+		string(d.Outcome),
+		d.CreatedAt,
+		d.ResolvedAt,
+	)
+	return err
+}
+
+// GetByID 根据ID获取风控决策
+func (r *loginRiskRepository) GetByID(ctx context.Context, id string) (*domain.LoginRiskDecision, error) {
+	query := `SELECT ` + loginRiskDecisionColumns + ` FROM login_risk_decisions WHERE id = $1`
+
+	decision, err := scanLoginRiskDecision(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrLoginRiskDecisionNotFound
+		}
+		return nil, err
+	}
+	return decision, nil
+}
+
+// ListSuccessful 获取用户指定时间后的成功登录
+func (r *loginRiskRepository) ListSuccessful(ctx context.Context, userID string, since time.Time, limit int) ([]*domain.LoginRiskDecision, error) {
+	query := `
+		SELECT ` + loginRiskDecisionColumns + `
+		FROM login_risk_decisions
+		WHERE user_id = $1
+		  AND outcome IN ($2, $3)
+		  AND created_at >= $4
+		ORDER BY created_at DESC
+		LIMIT $5
+	`
+
+	rows, err := r.db.Query(ctx, query,
+		userID,
+		string(domain.LoginRiskOutcomeAllowed),
+		string(domain.LoginRiskOutcomeStepUpPassed),
+		since,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var decisions []*domain.LoginRiskDecision
+	for rows.Next() {
+		decision, err := scanLoginRiskDecision(rows)
+		if err != nil {
+			return nil, err
+		}
+		decisions = append(decisions, decision)
+	}
+	return decisions, rows.Err()
+}
+
+// ListByUser 获取用户最近的登录记录
+func (r *loginRiskRepository) ListByUser(ctx context.Context, userID string, limit int) ([]*domain.LoginRiskDecision, error) {
+	query := `
+		SELECT ` + loginRiskDecisionColumns + `
+		FROM login_risk_decisions
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var decisions []*domain.LoginRiskDecision
+	for rows.Next() {
+		decision, err := scanLoginRiskDecision(rows)
+		if err != nil {
+			return nil, err
+		}
+		decisions = append(decisions, decision)
+	}
+	return decisions, rows.Err()
+}
+
+// CountSince 统计用户指定时间后的登录尝试次数、不同IP数，以及clientIP是否已出现过
+func (r *loginRiskRepository) CountSince(ctx context.Context, userID, clientIP string, since time.Time) (int64, int64, bool, error) {
+	query := `
+		SELECT COUNT(*), COUNT(DISTINCT client_ip), COALESCE(BOOL_OR(client_ip = $3), FALSE)
+		FROM login_risk_decisions
+		WHERE user_id = $1 AND created_at >= $2
+	`
+
+	var attempts, distinctIPs int64
+	var seenIP bool
+	if err := r.db.QueryRow(ctx, query, userID, since, clientIP).Scan(&attempts, &distinctIPs, &seenIP); err != nil {
+		return 0, 0, false, err
+	}
+	return attempts, distinctIPs, seenIP, nil
+}
+
+// UpdateOutcome 更新决策最终结果
+func (r *loginRiskRepository) UpdateOutcome(ctx context.Context, id string, outcome domain.LoginRiskOutcome, resolvedAt time.Time) error {
+	query := `
+		UPDATE login_risk_decisions
+		SET outcome = $2, resolved_at = $3
+		WHERE id = $1
+	`
+	tag, err := r.db.Exec(ctx, query, id, string(outcome), resolvedAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrLoginRiskDecisionNotFound
+	}
+	return nil
+}
+
+// scanLoginRiskDecision 扫描一行风控决策
+func scanLoginRiskDecision(row pgx.Row) (*domain.LoginRiskDecision, error) {
+	var (
+		d       domain.LoginRiskDecision
+		action  string
+		outcome string
+	)
+	err := row.Scan(
+		&d.ID,
+		&d.UserID,
+		&d.DeviceID,
+		&d.Fingerprint,
+		&d.ClientIP,
+		&d.Country,
+		&d.City,
+		&d.Latitude,
+		&d.Longitude,
+		&d.Score,
+		&action,
+		&d.Reasons,
+		&outcome,
+		&d.CreatedAt,
+		&d.ResolvedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	d.Action = domain.LoginRiskAction(action)
+	d.Outcome = domain.LoginRiskOutcome(outcome)
+	return &d, nil
+}
@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/domain"
+)
+
+const (
+	// qrTicketKeyPrefix 票据Key前缀: auth:qr:ticket:{ticketID}
+	qrTicketKeyPrefix = "auth:qr:ticket:"
+	// qrTicketChannelSuffix 票据状态变更频道后缀: auth:qr:ticket:{ticketID}:changed
+	qrTicketChannelSuffix = ":changed"
+	// qrCreateCountKeyPrefix 票据创建计数Key前缀: auth:qr:create:{clientIP}
+	qrCreateCountKeyPrefix = "auth:qr:create:"
+	// qrTicketMaxRetries 乐观锁冲突最大重试次数
+	qrTicketMaxRetries = 3
+)
+
+// QRLoginTicketRepository 二维码登录票据仓储接口（Redis）
+type QRLoginTicketRepository interface {
+	// Create 保存新票据（TTL取票据过期时间）
+	Create(ctx context.Context, ticket *domain.QRLoginTicket) error
+	// Get 获取票据
+	Get(ctx context.Context, id string) (*domain.QRLoginTicket, error)
+	// Update 原子更新票据，并通知等待中的轮询方
+	Update(ctx context.Context, id string, fn func(ticket *domain.QRLoginTicket) error) (*domain.QRLoginTicket, error)
+	// Delete 删除票据，返回false表示票据已不存在（并发领取时只有一个请求返回true）
+	Delete(ctx context.Context, id string) (bool, error)
+	// Watch 订阅票据变更，返回通知通道和释放函数
+	Watch(ctx context.Context, id string) (<-chan struct{}, func())
+	// IncrCreateCount 记录一次票据创建，返回窗口内该客户端IP的创建次数
+	IncrCreateCount(ctx context.Context, clientIP string, window time.Duration) (int64, error)
+}
+
+// qrLoginTicketRepository Redis二维码登录票据仓储实现
+type qrLoginTicketRepository struct {
+	client *redis.Client
+}
+
+// NewQRLoginTicketRepository 创建二维码登录票据仓储
+func NewQRLoginTicketRepository(client *redis.Client) QRLoginTicketRepository {
+	return &qrLoginTicketRepository{client: client}
+}
+
+// Create 保存新票据
+func (r *qrLoginTicketRepository) Create(ctx context.Context, ticket *domain.QRLoginTicket) error {
+	data, err := json.Marshal(ticket)
+	if err != nil {
+		return fmt.Errorf("marshal ticket: %w", err)
+	}
+
+	ok, err := r.client.SetNX(ctx, qrTicketKey(ticket.ID), data, time.Until(ticket.ExpiresAt)).Result()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("ticket %s already exists", ticket.ID)
+	}
+	return nil
+}
+
+// Get 获取票据
+func (r *qrLoginTicketRepository) Get(ctx context.Context, id string) (*domain.QRLoginTicket, error) {
+	data, err := r.client.Get(ctx, qrTicketKey(id)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, domain.ErrQRTicketNotFound
+		}
+		return nil, err
+	}
+
+	var ticket domain.QRLoginTicket
+	if err := json.Unmarshal(data, &ticket); err != nil {
+		return nil, fmt.Errorf("unmarshal ticket: %w", err)
+	}
+	return &ticket, nil
+}
+
+// Update 原子更新票据（WATCH/MULTI乐观锁）
+func (r *qrLoginTicketRepository) Update(ctx context.Context, id string, fn func(ticket *domain.QRLoginTicket) error) (*domain.QRLoginTicket, error) {
+	key := qrTicketKey(id)
+	var updated *domain.QRLoginTicket
+
+	txf := func(tx *redis.Tx) error {
+		data, err := tx.Get(ctx, key).Bytes()
+		if err != nil {
+			if errors.Is(err, redis.Nil) {
+				return domain.ErrQRTicketNotFound
+			}
+			return err
+		}
+
+		var ticket domain.QRLoginTicket
+		if err := json.Unmarshal(data, &ticket); err != nil {
+			return fmt.Errorf("unmarshal ticket: %w", err)
+		}
+
+		if err := fn(&ticket); err != nil {
+			return err
+		}
+
+		newData, err := json.Marshal(&ticket)
+		if err != nil {
+			return fmt.Errorf("marshal ticket: %w", err)
+		}
+
+		ttl := time.Until(ticket.ExpiresAt)
+		if ttl <= 0 {
+			ttl = time.Second
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, newData, ttl)
+			pipe.Publish(ctx, qrTicketChannel(id), string(ticket.Status))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		updated = &ticket
+		return nil
+	}
+
+	for i := 0; i < qrTicketMaxRetries; i++ {
+		err := r.client.Watch(ctx, txf, key)
+		if err == nil {
+			return updated, nil
+		}
+		if !errors.Is(err, redis.TxFailedErr) {
+			return nil, err
+		}
+	}
+
+	return nil, fmt.Errorf("update ticket %s: too many concurrent modifications", id)
+}
+
+// Delete 删除票据
+func (r *qrLoginTicketRepository) Delete(ctx context.Context, id string) (bool, error) {
+	deleted, err := r.client.Del(ctx, qrTicketKey(id)).Result()
+	if err != nil {
+		return false, err
+	}
+	return deleted == 1, nil
+}
+
+// Watch 订阅票据变更
+func (r *qrLoginTicketRepository) Watch(ctx context.Context, id string) (<-chan struct{}, func()) {
+	pubsub := r.client.Subscribe(ctx, qrTicketChannel(id))
+	notify := make(chan struct{}, 1)
+
+	// 等待订阅确认，避免订阅建立前的变更通知丢失
+	// 订阅失败时调用方会退化为超时后重新读取
+	_, _ = pubsub.Receive(ctx)
+
+	go func() {
+		for range pubsub.Channel() {
+			select {
+			case notify <- struct{}{}:
+			default:
+			}
+		}
+	}()
+
+	return notify, func() {
+		_ = pubsub.Close()
+	}
+}
+
+// IncrCreateCount 记录一次票据创建（固定窗口计数，与短信防刷共用计数脚本）
+func (r *qrLoginTicketRepository) IncrCreateCount(ctx context.Context, clientIP string, window time.Duration) (int64, error) {
+	return smsLimitIncr.Run(ctx, r.client, []string{qrCreateCountKeyPrefix + clientIP}, window.Milliseconds()).Int64()
+}
+
+// qrTicketKey 票据Key
+func qrTicketKey(id string) string {
+	return qrTicketKeyPrefix + id
+}
+
+// qrTicketChannel 票据变更频道
+func qrTicketChannel(id string) string {
+	return qrTicketKeyPrefix + id + qrTicketChannelSuffix
+}
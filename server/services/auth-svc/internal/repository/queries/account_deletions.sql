@@ -0,0 +1,43 @@
+-- name: CreateAccountDeletion :exec
+INSERT INTO account_deletions (
+    id, user_id, status, completed_steps, attempts, last_error,
+    requested_at, scheduled_at, next_attempt_at, cancelled_at, completed_at, updated_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+);
+
+-- name: GetLatestAccountDeletionByUser :one
+SELECT * FROM account_deletions
+WHERE user_id = $1
+ORDER BY requested_at DESC
+LIMIT 1;
+
+-- name: CancelAccountDeletion :one
+UPDATE account_deletions
+SET status = 'cancelled', cancelled_at = $2, updated_at = $2
+WHERE user_id = $1 AND status = 'pending'
+RETURNING *;
+
+-- name: ClaimDueAccountDeletions :many
+UPDATE account_deletions
+SET status = 'processing', next_attempt_at = $2, updated_at = $1
+WHERE id IN (
+    SELECT id FROM account_deletions
+    WHERE status IN ('pending', 'processing')
+      AND next_attempt_at <= $1
+    ORDER BY next_attempt_at
+    LIMIT $3
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: UpdateAccountDeletion :exec
+UPDATE account_deletions
+SET status = $2,
+    completed_steps = $3,
+    attempts = $4,
+    last_error = $5,
+    next_attempt_at = $6,
+    completed_at = $7,
+    updated_at = $8
+WHERE id = $1;
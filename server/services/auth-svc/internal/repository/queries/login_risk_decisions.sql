@@ -0,0 +1,36 @@
+-- name: CreateLoginRiskDecision :exec
+INSERT INTO login_risk_decisions (
+    id, user_id, device_id, fingerprint, client_ip, country, city,
+    latitude, longitude, score, action, reasons, outcome, created_at, resolved_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
+);
+
+-- name: GetLoginRiskDecisionByID :one
+SELECT * FROM login_risk_decisions
+WHERE id = $1 LIMIT 1;
+
+-- name: ListSuccessfulLoginRiskDecisions :many
+SELECT * FROM login_risk_decisions
+WHERE user_id = $1
+  AND outcome IN ('allowed', 'step_up_passed')
+  AND created_at >= $2
+ORDER BY created_at DESC
+LIMIT $3;
+
+-- name: ListLoginRiskDecisionsByUser :many
+SELECT * FROM login_risk_decisions
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT $2;
+
+-- name: CountLoginRiskDecisionsSince :one
+SELECT COUNT(*) AS attempts, COUNT(DISTINCT client_ip) AS distinct_ips,
+       COALESCE(BOOL_OR(client_ip = $3), FALSE) AS seen_ip
+FROM login_risk_decisions
+WHERE user_id = $1 AND created_at >= $2;
+
+-- name: UpdateLoginRiskDecisionOutcome :exec
+UPDATE login_risk_decisions
+SET outcome = $2, resolved_at = $3
+WHERE id = $1;
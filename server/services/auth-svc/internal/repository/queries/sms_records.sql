@@ -1,8 +1,8 @@
 -- name: CreateSMSRecord :one
 INSERT INTO sms_records (
-    id, phone, provider, success, error_msg, created_at
+    id, phone, provider, success, error_msg, latency_ms, created_at
 ) VALUES (
-    $1, $2, $3, $4, $5, $6
+    $1, $2, $3, $4, $5, $6, $7
 ) RETURNING *;
 
 -- name: GetSMSRecordByID :one
@@ -31,6 +31,19 @@ WHERE success = true AND created_at > $1;
 SELECT COUNT(*) FROM sms_records
 WHERE success = false AND created_at > $1;
 
+-- name: GetSMSProviderHealth :many
+SELECT provider,
+       COUNT(*) AS total,
+       COUNT(*) FILTER (WHERE success) AS success,
+       COALESCE(AVG(latency_ms), 0)::BIGINT AS avg_latency_ms
+FROM sms_records
+WHERE created_at > $1
+GROUP BY provider;
+
 -- name: DeleteOldSMSRecords :exec
 DELETE FROM sms_records
 WHERE created_at < $1;
+
+-- name: DeleteSMSRecordsByPhone :exec
+DELETE FROM sms_records
+WHERE phone = $1;
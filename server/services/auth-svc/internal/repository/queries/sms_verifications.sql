@@ -27,3 +27,7 @@ WHERE expires_at < $1;
 -- name: CountSMSVerificationsByPhone :one
 SELECT COUNT(*) FROM sms_verifications
 WHERE phone = $1 AND created_at > $2;
+
+-- name: DeleteSMSVerificationsByPhone :exec
+DELETE FROM sms_verifications
+WHERE phone = $1;
@@ -0,0 +1,19 @@
+-- name: GetUserTOTP :one
+SELECT * FROM user_totp
+WHERE user_id = $1;
+
+-- name: SavePendingUserTOTP :exec
+INSERT INTO user_totp (user_id, secret, enabled_at, created_at, updated_at)
+VALUES ($1, $2, NULL, $3, $3)
+ON CONFLICT (user_id) DO UPDATE
+SET secret = EXCLUDED.secret, created_at = EXCLUDED.created_at, updated_at = EXCLUDED.updated_at
+WHERE user_totp.enabled_at IS NULL;
+
+-- name: EnableUserTOTP :execrows
+UPDATE user_totp
+SET enabled_at = $2, updated_at = $2
+WHERE user_id = $1 AND enabled_at IS NULL;
+
+-- name: DeleteUserTOTP :exec
+DELETE FROM user_totp
+WHERE user_id = $1;
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// smsLimitKeyPrefix 短信防刷计数Key前缀: auth:sms:limit:{dimension}:{value}
+const smsLimitKeyPrefix = "auth:sms:limit:"
+
+// smsLimitIncr 计数加1，窗口内第一次计数时设置过期时间（INCR与PEXPIRE原子执行）
+var smsLimitIncr = redis.NewScript(`
+local current = redis.call('INCR', KEYS[1])
+if current == 1 then
+  redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return current
+`)
+
+// smsLimitDecr 计数减1，Key已过期时不做处理（避免留下无过期时间的负数计数）
+var smsLimitDecr = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 then
+  return redis.call('DECR', KEYS[1])
+end
+return 0
+`)
+
+// SMSLimitRepository 短信防刷计数仓储接口（Redis固定窗口计数）
+type SMSLimitRepository interface {
+	// Incr 计数加1，返回加1后的计数
+	Incr(ctx context.Context, key string, window time.Duration) (int64, error)
+	// Decr 计数减1（撤销被拒绝请求的计数）
+	Decr(ctx context.Context, key string) error
+}
+
+// smsLimitRepository Redis短信防刷计数仓储实现
+type smsLimitRepository struct {
+	client *redis.Client
+}
+
+// NewSMSLimitRepository 创建短信防刷计数仓储
+func NewSMSLimitRepository(client *redis.Client) SMSLimitRepository {
+	return &smsLimitRepository{client: client}
+}
+
+// Incr 计数加1
+func (r *smsLimitRepository) Incr(ctx context.Context, key string, window time.Duration) (int64, error) {
+	return smsLimitIncr.Run(ctx, r.client, []string{smsLimitKeyPrefix + key}, window.Milliseconds()).Int64()
+}
+
+// Decr 计数减1
+func (r *smsLimitRepository) Decr(ctx context.Context, key string) error {
+	return smsLimitDecr.Run(ctx, r.client, []string{smsLimitKeyPrefix + key}).Err()
+}
@@ -23,6 +23,8 @@ type SMSVerificationRepository interface {
 	DeleteExpired(ctx context.Context, before time.Time) error
 	// CountRecent 统计最近的验证码数量（用于限流）
 	CountRecent(ctx context.Context, phone string, after time.Time) (int64, error)
+	// DeleteByPhone 删除手机号的全部验证码（账号注销）
+	DeleteByPhone(ctx context.Context, phone string) error
 }
 
 // smsVerificationRepository PostgreSQL短信验证仓储实现
@@ -140,6 +142,13 @@ func (r *smsVerificationRepository) CountRecent(ctx context.Context, phone strin
 	return count, err
 }
 
+// DeleteByPhone 删除手机号的全部验证码
+func (r *smsVerificationRepository) DeleteByPhone(ctx context.Context, phone string) error {
+	query := `DELETE FROM sms_verifications WHERE phone = $1`
+	_, err := r.db.Exec(ctx, query, phone)
+	return err
+}
+
 // ========== SMS Record Repository ==========
 
 // SMSRecordRepository 短信记录仓储接口
@@ -158,8 +167,12 @@ type SMSRecordRepository interface {
 	CountSuccess(ctx context.Context, after time.Time) (int64, error)
 	// CountFailed 统计失败发送数量
 	CountFailed(ctx context.Context, after time.Time) (int64, error)
+	// ProviderHealth 按提供商统计发送成功数和平均耗时
+	ProviderHealth(ctx context.Context, after time.Time) ([]*domain.SMSProviderHealth, error)
 	// DeleteOld 删除旧记录
 	DeleteOld(ctx context.Context, before time.Time) error
+	// DeleteByPhone 删除手机号的全部短信记录（账号注销）
+	DeleteByPhone(ctx context.Context, phone string) error
 }
 
 // smsRecordRepository PostgreSQL短信记录仓储实现
@@ -175,8 +188,8 @@ func NewSMSRecordRepository(db *pgxpool.Pool) SMSRecordRepository {
 // Create 创建短信记录
 func (r *smsRecordRepository) Create(ctx context.Context, record *domain.SMSRecord) error {
 	query := `
-		INSERT INTO sms_records (id, phone, provider, success, error_msg, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO sms_records (id, phone, provider, success, error_msg, latency_ms, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 	`
 	_, err := r.db.Exec(ctx, query,
 		record.ID,
@@ -184,6 +197,7 @@ func (r *smsRecordRepository) Create(ctx context.Context, record *domain.SMSReco
 		record.Provider,
 		record.Success,
 		record.ErrorMsg,
+		record.LatencyMs,
 		record.CreatedAt,
 	)
 	return err
@@ -287,9 +301,50 @@ func (r *smsRecordRepository) CountFailed(ctx context.Context, after time.Time)
 	return count, err
 }
 
+// ProviderHealth 按提供商统计发送成功数和平均耗时
+func (r *smsRecordRepository) ProviderHealth(ctx context.Context, after time.Time) ([]*domain.SMSProviderHealth, error) {
+	query := `
+		SELECT provider,
+		       COUNT(*),
+		       COUNT(*) FILTER (WHERE success),
+		       COALESCE(AVG(latency_ms), 0)::BIGINT
+		FROM sms_records
+		WHERE created_at > $1
+		GROUP BY provider
+	`
+
+	rows, err := r.db.Query(ctx, query, after)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*domain.SMSProviderHealth
+	for rows.Next() {
+		var h domain.SMSProviderHealth
+		if err := rows.Scan(&h.Provider, &h.Total, &h.Success, &h.AvgLatencyMs); err != nil {
+			return nil, err
+		}
+		result = append(result, &h)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
 // DeleteOld 删除旧记录
 func (r *smsRecordRepository) DeleteOld(ctx context.Context, before time.Time) error {
 	query := `DELETE FROM sms_records WHERE created_at < $1`
 	_, err := r.db.Exec(ctx, query, before)
 	return err
 }
+
+// DeleteByPhone 删除手机号的全部短信记录
+func (r *smsRecordRepository) DeleteByPhone(ctx context.Context, phone string) error {
+	query := `DELETE FROM sms_records WHERE phone = $1`
+	_, err := r.db.Exec(ctx, query, phone)
+	return err
+}
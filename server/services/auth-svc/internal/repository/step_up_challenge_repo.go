@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/domain"
+)
+
+const (
+	// stepUpChallengeKeyPrefix 二次验证挑战Key前缀: auth:stepup:{challengeID}
+	stepUpChallengeKeyPrefix = "auth:stepup:"
+	// stepUpChallengeMaxRetries 乐观锁冲突最大重试次数
+	stepUpChallengeMaxRetries = 3
+)
+
+// StepUpChallengeRepository 登录二次验证挑战仓储接口（Redis）
+type StepUpChallengeRepository interface {
+	// Create 保存新挑战（TTL取挑战过期时间）
+	Create(ctx context.Context, challenge *domain.StepUpChallenge) error
+	// Get 获取挑战
+	Get(ctx context.Context, id string) (*domain.StepUpChallenge, error)
+	// Update 原子更新挑战
+	// fn返回错误时，若同时返回keep=true则仍然保存修改（如记录失败次数）
+	Update(ctx context.Context, id string, fn func(challenge *domain.StepUpChallenge) (keep bool, err error)) (*domain.StepUpChallenge, error)
+	// Delete 删除挑战，挑战不存在时返回ErrStepUpChallengeNotFound（用于保证挑战只被使用一次）
+	Delete(ctx context.Context, id string) error
+}
+
+// stepUpChallengeRepository Redis二次验证挑战仓储实现
+type stepUpChallengeRepository struct {
+	client *redis.Client
+}
+
+// NewStepUpChallengeRepository 创建二次验证挑战仓储
+func NewStepUpChallengeRepository(client *redis.Client) StepUpChallengeRepository {
+	return &stepUpChallengeRepository{client: client}
+}
+
+// Create 保存新挑战
+func (r *stepUpChallengeRepository) Create(ctx context.Context, challenge *domain.StepUpChallenge) error {
+	data, err := json.Marshal(challenge)
+	if err != nil {
+		return fmt.Errorf("marshal challenge: %w", err)
+	}
+
+	ok, err := r.client.SetNX(ctx, stepUpChallengeKey(challenge.ID), data, time.Until(challenge.ExpiresAt)).Result()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("challenge %s already exists", challenge.ID)
+	}
+	return nil
+}
+
+// Get 获取挑战
+func (r *stepUpChallengeRepository) Get(ctx context.Context, id string) (*domain.StepUpChallenge, error) {
+	data, err := r.client.Get(ctx, stepUpChallengeKey(id)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, domain.ErrStepUpChallengeNotFound
+		}
+		return nil, err
+	}
+
+	var challenge domain.StepUpChallenge
+	if err := json.Unmarshal(data, &challenge); err != nil {
+		return nil, fmt.Errorf("unmarshal challenge: %w", err)
+	}
+	return &challenge, nil
+}
+
+// Update 原子更新挑战（WATCH/MULTI乐观锁）
+func (r *stepUpChallengeRepository) Update(ctx context.Context, id string, fn func(challenge *domain.StepUpChallenge) (bool, error)) (*domain.StepUpChallenge, error) {
+	key := stepUpChallengeKey(id)
+	var updated *domain.StepUpChallenge
+	var fnErr error
+
+	txf := func(tx *redis.Tx) error {
+		fnErr = nil
+
+		data, err := tx.Get(ctx, key).Bytes()
+		if err != nil {
+			if errors.Is(err, redis.Nil) {
+				return domain.ErrStepUpChallengeNotFound
+			}
+			return err
+		}
+
+		var challenge domain.StepUpChallenge
+		if err := json.Unmarshal(data, &challenge); err != nil {
+			return fmt.Errorf("unmarshal challenge: %w", err)
+		}
+
+		keep, err := fn(&challenge)
+		if err != nil {
+			if !keep {
+				return err
+			}
+			fnErr = err
+		}
+
+		newData, err := json.Marshal(&challenge)
+		if err != nil {
+			return fmt.Errorf("marshal challenge: %w", err)
+		}
+
+		// 保持原有过期时间
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.SetArgs(ctx, key, newData, redis.SetArgs{KeepTTL: true})
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		updated = &challenge
+		return nil
+	}
+
+	for i := 0; i < stepUpChallengeMaxRetries; i++ {
+		err := r.client.Watch(ctx, txf, key)
+		if err == nil {
+			return updated, fnErr
+		}
+		if !errors.Is(err, redis.TxFailedErr) {
+			return nil, err
+		}
+	}
+
+	return nil, fmt.Errorf("update challenge %s: too many concurrent modifications", id)
+}
+
+// Delete 删除挑战
+func (r *stepUpChallengeRepository) Delete(ctx context.Context, id string) error {
+	n, err := r.client.Del(ctx, stepUpChallengeKey(id)).Result()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrStepUpChallengeNotFound
+	}
+	return nil
+}
+
+// stepUpChallengeKey 挑战Key
+func stepUpChallengeKey(id string) string {
+	return stepUpChallengeKeyPrefix + id
+}
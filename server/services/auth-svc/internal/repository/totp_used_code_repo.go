@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// totpUsedCodeKeyPrefix 已使用TOTP验证码Key前缀: auth:totp:used:{userID}:{code}
+const totpUsedCodeKeyPrefix = "auth:totp:used:"
+
+// TOTPUsedCodeRepository 已使用TOTP验证码仓储接口（防止验证码在有效期内被重放）
+type TOTPUsedCodeRepository interface {
+	// MarkUsed 标记验证码已使用，验证码之前已被使用过时返回false
+	MarkUsed(ctx context.Context, userID, code string, ttl time.Duration) (bool, error)
+}
+
+// totpUsedCodeRepository Redis已使用TOTP验证码仓储实现
+type totpUsedCodeRepository struct {
+	client *redis.Client
+}
+
+// NewTOTPUsedCodeRepository 创建已使用TOTP验证码仓储
+func NewTOTPUsedCodeRepository(client *redis.Client) TOTPUsedCodeRepository {
+	return &totpUsedCodeRepository{client: client}
+}
+
+// MarkUsed 标记验证码已使用（SETNX，并发使用同一验证码时只有一个成功）
+func (r *totpUsedCodeRepository) MarkUsed(ctx context.Context, userID, code string, ttl time.Duration) (bool, error) {
+	return r.client.SetNX(ctx, totpUsedCodeKeyPrefix+userID+":"+code, 1, ttl).Result()
+}
@@ -2,9 +2,10 @@ package repository
 
 import (
 	"context"
-	"database/sql"
+	"errors"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/domain"
 )
@@ -29,6 +30,10 @@ type UserRepository interface {
 	Count(ctx context.Context) (int64, error)
 	// CountActive 统计激活用户数
 	CountActive(ctx context.Context) (int64, error)
+	// SearchByPhonePrefix 按手机号前缀分页查询用户
+	SearchByPhonePrefix(ctx context.Context, prefix string, limit, offset int) ([]*domain.User, error)
+	// CountByPhonePrefix 统计手机号前缀匹配的用户数
+	CountByPhonePrefix(ctx context.Context, prefix string) (int64, error)
 }
 
 // userRepository PostgreSQL用户仓储实现
@@ -75,7 +80,7 @@ func (r *userRepository) GetByID(ctx context.Context, id string) (*domain.User,
 		&user.UpdatedAt,
 	)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrUserNotFound
 		}
 		return nil, err
@@ -97,7 +102,7 @@ func (r *userRepository) GetByPhone(ctx context.Context, phone string) (*domain.
 		&user.UpdatedAt,
 	)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrUserNotFound
 		}
 		return nil, err
@@ -180,3 +185,51 @@ func (r *userRepository) CountActive(ctx context.Context) (int64, error) {
 	err := r.db.QueryRow(ctx, query).Scan(&count)
 	return count, err
 }
+
+// SearchByPhonePrefix 按手机号前缀分页查询用户（prefix由调用方保证只含数字）
+func (r *userRepository) SearchByPhonePrefix(ctx context.Context, prefix string, limit, offset int) ([]*domain.User, error) {
+	query := `
+		SELECT id, phone, token_version, is_active, created_at, updated_at
+		FROM users
+		WHERE phone LIKE $1 || '%'
+		ORDER BY phone
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(ctx, query, prefix, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*domain.User
+	for rows.Next() {
+		var user domain.User
+		err := rows.Scan(
+			&user.ID,
+			&user.Phone,
+			&user.TokenVersion,
+			&user.IsActive,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, &user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// CountByPhonePrefix 统计手机号前缀匹配的用户数
+func (r *userRepository) CountByPhonePrefix(ctx context.Context, prefix string) (int64, error) {
+	query := `SELECT COUNT(*) FROM users WHERE phone LIKE $1 || '%'`
+	var count int64
+	err := r.db.QueryRow(ctx, query, prefix).Scan(&count)
+	return count, err
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/domain"
+)
+
+// UserTOTPRepository 用户TOTP身份验证器仓储接口
+type UserTOTPRepository interface {
+	// Get 获取用户的TOTP绑定，不存在时返回ErrTOTPNotEnrolled
+	Get(ctx context.Context, userID string) (*domain.UserTOTP, error)
+	// SavePending 保存待确认的密钥（覆盖之前未确认的密钥，已绑定时返回ErrTOTPAlreadyEnrolled）
+	SavePending(ctx context.Context, totp *domain.UserTOTP) error
+	// Enable 确认绑定（仅待确认状态可确认）
+	Enable(ctx context.Context, userID string, enabledAt time.Time) error
+	// Delete 解除绑定
+	Delete(ctx context.Context, userID string) error
+}
+
+// userTOTPRepository PostgreSQL用户TOTP仓储实现
+type userTOTPRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewUserTOTPRepository 创建用户TOTP仓储
+func NewUserTOTPRepository(db *pgxpool.Pool) UserTOTPRepository {
+	return &userTOTPRepository{db: db}
+}
+
+// Get 获取用户的TOTP绑定
+func (r *userTOTPRepository) Get(ctx context.Context, userID string) (*domain.UserTOTP, error) {
+	query := `
+		SELECT user_id, secret, enabled_at, created_at, updated_at
+		FROM user_totp
+		WHERE user_id = $1
+	`
+
+	var t domain.UserTOTP
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&t.UserID,
+		&t.Secret,
+		&t.EnabledAt,
+		&t.CreatedAt,
+		&t.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrTOTPNotEnrolled
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+// SavePending 保存待确认的密钥
+// 条件更新保证已绑定的密钥不会被覆盖
+func (r *userTOTPRepository) SavePending(ctx context.Context, t *domain.UserTOTP) error {
+	query := `
+		INSERT INTO user_totp (user_id, secret, enabled_at, created_at, updated_at)
+		VALUES ($1, $2, NULL, $3, $3)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret, created_at = EXCLUDED.created_at, updated_at = EXCLUDED.updated_at
+		WHERE user_totp.enabled_at IS NULL
+	`
+	tag, err := r.db.Exec(ctx, query, t.UserID, t.Secret, t.CreatedAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrTOTPAlreadyEnrolled
+	}
+	return nil
+}
+
+// Enable 确认绑定
+func (r *userTOTPRepository) Enable(ctx context.Context, userID string, enabledAt time.Time) error {
+	query := `
+		UPDATE user_totp
+		SET enabled_at = $2, updated_at = $2
+		WHERE user_id = $1 AND enabled_at IS NULL
+	`
+	tag, err := r.db.Exec(ctx, query, userID, enabledAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrTOTPNotEnrolled
+	}
+	return nil
+}
+
+// Delete 解除绑定
+func (r *userTOTPRepository) Delete(ctx context.Context, userID string) error {
+	query := `DELETE FROM user_totp WHERE user_id = $1`
+	_, err := r.db.Exec(ctx, query, userID)
+	return err
+}
@@ -0,0 +1,328 @@
+package account
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/domain"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/repository"
+)
+
+// AccountService 账号注销与个人数据导出服务接口
+//
+// 注销流程：
+// 1. 用户申请注销，进入冷静期（默认30天），期间账号可正常使用，可随时撤销
+// 2. 冷静期结束后由后台任务领取请求，按顺序执行各服务的删除步骤（停用账号、用户内容、同步数据、认证数据）
+// 3. 每个步骤可重复执行，完成的步骤会被记录；失败时按指数退避重试，直到全部完成
+type AccountService interface {
+	// RequestDeletion 申请注销（已有进行中的请求时直接返回该请求）
+	RequestDeletion(ctx context.Context, userID string) (*domain.AccountDeletion, error)
+
+	// CancelDeletion 撤销冷静期内的注销申请
+	CancelDeletion(ctx context.Context, userID string) (*domain.AccountDeletion, error)
+
+	// GetDeletion 查询用户最近一次注销申请
+	GetDeletion(ctx context.Context, userID string) (*domain.AccountDeletion, error)
+
+	// ProcessDueDeletions 执行一批到期的注销请求，返回本批完成的数量
+	ProcessDueDeletions(ctx context.Context) (int, error)
+
+	// Run 定期执行到期的注销请求，直到ctx取消
+	Run(ctx context.Context, interval time.Duration)
+
+	// ExportData 收集用户在各服务中的个人数据，返回的归档通过WriteTo流式写出（zip，每个服务一个JSON文件）
+	ExportData(ctx context.Context, userID string) (*DataArchive, error)
+}
+
+// Config 账号服务配置
+type Config struct {
+	// GracePeriod 注销冷静期
+	GracePeriod time.Duration
+	// BatchSize 每次领取的注销请求数量
+	BatchSize int
+	// Lease 领取后的租约时间，实例崩溃后请求在租约到期后会被其他实例重新领取
+	Lease time.Duration
+	// StepTimeout 单个删除步骤/导出来源的超时时间
+	StepTimeout time.Duration
+}
+
+// NewConfig 创建默认配置
+func NewConfig() Config {
+	return Config{
+		GracePeriod: domain.AccountDeletionGracePeriod,
+		BatchSize:   20,
+		Lease:       10 * time.Minute,
+		StepTimeout: 30 * time.Second,
+	}
+}
+
+// DataArchive 个人数据归档
+// 各来源的数据在ExportData中全部取回后才开始写出，写出过程中不再访问下游服务
+type DataArchive struct {
+	Filename    string
+	generatedAt time.Time
+	files       []archiveFile
+}
+
+// archiveFile 归档中的单个文件
+type archiveFile struct {
+	name string
+	data interface{}
+}
+
+// archiveManifest 归档说明文件
+type archiveManifest struct {
+	UserID      string    `json:"user_id"`
+	GeneratedAt time.Time `json:"generated_at"`
+	Files       []string  `json:"files"`
+}
+
+// accountService 账号服务实现
+type accountService struct {
+	config       Config
+	deletionRepo repository.AccountDeletionRepository
+	steps        []ErasureStep
+	sources      []ExportSource
+	now          func() time.Time
+}
+
+// NewAccountService 创建账号服务
+// steps按顺序执行：先停用账号阻止新的写入，最后删除用户记录（删除后无法再定位手机号等数据）
+func NewAccountService(config Config, deletionRepo repository.AccountDeletionRepository, steps []ErasureStep, sources []ExportSource) AccountService {
+	defaults := NewConfig()
+	if config.GracePeriod <= 0 {
+		config.GracePeriod = defaults.GracePeriod
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaults.BatchSize
+	}
+	if config.Lease <= 0 {
+		config.Lease = defaults.Lease
+	}
+	if config.StepTimeout <= 0 {
+		config.StepTimeout = defaults.StepTimeout
+	}
+
+	return &accountService{
+		config:       config,
+		deletionRepo: deletionRepo,
+		steps:        steps,
+		sources:      sources,
+		now:          time.Now,
+	}
+}
+
+// RequestDeletion 申请注销
+func (s *accountService) RequestDeletion(ctx context.Context, userID string) (*domain.AccountDeletion, error) {
+	existing, err := s.deletionRepo.GetLatestByUser(ctx, userID)
+	if err != nil && !errors.Is(err, domain.ErrAccountDeletionNotFound) {
+		return nil, fmt.Errorf("get account deletion: %w", err)
+	}
+	if existing != nil && existing.IsActive() {
+		return existing, nil
+	}
+
+	deletion := domain.NewAccountDeletion(userID, s.config.GracePeriod)
+	if err := s.deletionRepo.Create(ctx, deletion); err != nil {
+		// 并发申请时唯一索引冲突，返回已创建的请求
+		if existing, getErr := s.deletionRepo.GetLatestByUser(ctx, userID); getErr == nil && existing.IsActive() {
+			return existing, nil
+		}
+		return nil, fmt.Errorf("create account deletion: %w", err)
+	}
+	return deletion, nil
+}
+
+// CancelDeletion 撤销注销申请
+func (s *accountService) CancelDeletion(ctx context.Context, userID string) (*domain.AccountDeletion, error) {
+	deletion, err := s.deletionRepo.Cancel(ctx, userID, s.now())
+	if err == nil {
+		return deletion, nil
+	}
+	if !errors.Is(err, domain.ErrAccountDeletionNotCancellable) {
+		return nil, fmt.Errorf("cancel account deletion: %w", err)
+	}
+
+	// 区分"没有申请"和"已开始删除"
+	if _, getErr := s.deletionRepo.GetLatestByUser(ctx, userID); errors.Is(getErr, domain.ErrAccountDeletionNotFound) {
+		return nil, domain.ErrAccountDeletionNotFound
+	}
+	return nil, domain.ErrAccountDeletionNotCancellable
+}
+
+// GetDeletion 查询最近一次注销申请
+func (s *accountService) GetDeletion(ctx context.Context, userID string) (*domain.AccountDeletion, error) {
+	return s.deletionRepo.GetLatestByUser(ctx, userID)
+}
+
+// ProcessDueDeletions 执行一批到期的注销请求
+func (s *accountService) ProcessDueDeletions(ctx context.Context) (int, error) {
+	deletions, err := s.deletionRepo.ClaimDue(ctx, s.now(), s.config.Lease, s.config.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("claim account deletions: %w", err)
+	}
+
+	completed := 0
+	for _, deletion := range deletions {
+		if ctx.Err() != nil {
+			break
+		}
+		if s.process(ctx, deletion) {
+			completed++
+		}
+	}
+	return completed, nil
+}
+
+// process 执行单个注销请求的剩余步骤，返回是否全部完成
+func (s *accountService) process(ctx context.Context, deletion *domain.AccountDeletion) bool {
+	for _, step := range s.steps {
+		if deletion.IsStepDone(step.Name()) {
+			continue
+		}
+
+		stepCtx, cancel := context.WithTimeout(ctx, s.config.StepTimeout)
+		err := step.Erase(stepCtx, deletion.UserID)
+		cancel()
+
+		if err != nil {
+			deletion.RecordFailure(fmt.Errorf("%s: %w", step.Name(), err), s.now())
+			s.saveProgress(ctx, deletion)
+			return false
+		}
+
+		deletion.MarkStepDone(step.Name())
+		// 每完成一步就保存进度，避免重试时重复调用已完成的步骤
+		// 保存失败时停止本轮处理，租约到期后重新领取（已完成的步骤可重复执行）
+		if !s.saveProgress(ctx, deletion) {
+			return false
+		}
+	}
+
+	deletion.Complete(s.now())
+	return s.saveProgress(ctx, deletion)
+}
+
+// saveProgress 保存注销请求进度，返回是否保存成功
+func (s *accountService) saveProgress(ctx context.Context, deletion *domain.AccountDeletion) bool {
+	if err := s.deletionRepo.Update(ctx, deletion); err != nil {
+		log.Printf("Error saving account deletion %s for user %s: %v", deletion.ID, deletion.UserID, err)
+		return false
+	}
+	return true
+}
+
+// Run 定期执行到期的注销请求
+func (s *accountService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.ProcessDueDeletions(ctx); err != nil {
+			log.Printf("Account deletion processing error: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ExportData 收集个人数据
+// 任一来源失败即返回错误，避免用户拿到不完整的数据而不自知
+func (s *accountService) ExportData(ctx context.Context, userID string) (*DataArchive, error) {
+	now := s.now()
+	archive := &DataArchive{
+		Filename:    fmt.Sprintf("listen-stream-data-%s.zip", now.Format("20060102")),
+		generatedAt: now,
+		files:       make([]archiveFile, 0, len(s.sources)+1),
+	}
+	manifest := archiveManifest{
+		UserID:      userID,
+		GeneratedAt: now,
+		Files:       make([]string, 0, len(s.sources)),
+	}
+
+	for _, source := range s.sources {
+		sourceCtx, cancel := context.WithTimeout(ctx, s.config.StepTimeout)
+		data, err := source.Export(sourceCtx, userID)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("export %s data: %w", source.Name(), err)
+		}
+
+		filename := source.Name() + ".json"
+		archive.files = append(archive.files, archiveFile{name: filename, data: data})
+		manifest.Files = append(manifest.Files, filename)
+	}
+	archive.files = append(archive.files, archiveFile{name: "manifest.json", data: manifest})
+
+	return archive, nil
+}
+
+// WriteTo 将归档以zip格式流式写入w（不在内存中缓存整个归档）
+func (a *DataArchive) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	zw := zip.NewWriter(cw)
+
+	for _, file := range a.files {
+		if err := writeJSONFile(zw, file.name, a.generatedAt, file.data); err != nil {
+			return cw.n, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return cw.n, fmt.Errorf("close archive: %w", err)
+	}
+	return cw.n, nil
+}
+
+// countingWriter 统计写入字节数
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// writeJSONFile 向归档写入一个JSON文件
+func writeJSONFile(zw *zip.Writer, name string, modified time.Time, data interface{}) error {
+	w, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: modified,
+	})
+	if err != nil {
+		return fmt.Errorf("create archive file %s: %w", name, err)
+	}
+
+	switch v := data.(type) {
+	case json.RawMessage:
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, v, "", "  "); err != nil {
+			return fmt.Errorf("encode %s: %w", name, err)
+		}
+		if _, err := indented.WriteTo(w); err != nil {
+			return fmt.Errorf("write archive file %s: %w", name, err)
+		}
+	default:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(v); err != nil {
+			return fmt.Errorf("write archive file %s: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,381 @@
+package account
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/domain"
+)
+
+// memoryDeletionRepository 内存注销请求仓储（用于测试）
+type memoryDeletionRepository struct {
+	mu        sync.Mutex
+	deletions map[string]*domain.AccountDeletion
+	updateErr error
+}
+
+func newMemoryDeletionRepository() *memoryDeletionRepository {
+	return &memoryDeletionRepository{deletions: make(map[string]*domain.AccountDeletion)}
+}
+
+func copyDeletion(d *domain.AccountDeletion) *domain.AccountDeletion {
+	copied := *d
+	copied.CompletedSteps = append([]string{}, d.CompletedSteps...)
+	return &copied
+}
+
+func (r *memoryDeletionRepository) Create(ctx context.Context, d *domain.AccountDeletion) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.deletions {
+		if existing.UserID == d.UserID && existing.IsActive() {
+			return errors.New("duplicate active deletion")
+		}
+	}
+	r.deletions[d.ID] = copyDeletion(d)
+	return nil
+}
+
+func (r *memoryDeletionRepository) GetLatestByUser(ctx context.Context, userID string) (*domain.AccountDeletion, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var latest *domain.AccountDeletion
+	for _, d := range r.deletions {
+		if d.UserID == userID && (latest == nil || d.RequestedAt.After(latest.RequestedAt)) {
+			latest = d
+		}
+	}
+	if latest == nil {
+		return nil, domain.ErrAccountDeletionNotFound
+	}
+	return copyDeletion(latest), nil
+}
+
+func (r *memoryDeletionRepository) Cancel(ctx context.Context, userID string, cancelledAt time.Time) (*domain.AccountDeletion, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, d := range r.deletions {
+		if d.UserID == userID && d.CanCancel() {
+			d.Status = domain.AccountDeletionStatusCancelled
+			d.CancelledAt = &cancelledAt
+			d.UpdatedAt = cancelledAt
+			return copyDeletion(d), nil
+		}
+	}
+	return nil, domain.ErrAccountDeletionNotCancellable
+}
+
+func (r *memoryDeletionRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.AccountDeletion, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var due []*domain.AccountDeletion
+	for _, d := range r.deletions {
+		if d.IsActive() && !d.NextAttemptAt.After(now) {
+			due = append(due, d)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].NextAttemptAt.Before(due[j].NextAttemptAt) })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	claimed := make([]*domain.AccountDeletion, 0, len(due))
+	for _, d := range due {
+		d.Status = domain.AccountDeletionStatusProcessing
+		d.NextAttemptAt = now.Add(lease)
+		d.UpdatedAt = now
+		claimed = append(claimed, copyDeletion(d))
+	}
+	return claimed, nil
+}
+
+func (r *memoryDeletionRepository) Update(ctx context.Context, d *domain.AccountDeletion) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.updateErr != nil {
+		return r.updateErr
+	}
+	if _, ok := r.deletions[d.ID]; !ok {
+		return domain.ErrAccountDeletionNotFound
+	}
+	r.deletions[d.ID] = copyDeletion(d)
+	return nil
+}
+
+// fakeStep 可控失败的删除步骤
+type fakeStep struct {
+	name  string
+	err   error
+	calls map[string]int
+}
+
+func newFakeStep(name string) *fakeStep {
+	return &fakeStep{name: name, calls: make(map[string]int)}
+}
+
+func (s *fakeStep) Name() string { return s.name }
+
+func (s *fakeStep) Erase(ctx context.Context, userID string) error {
+	s.calls[userID]++
+	return s.err
+}
+
+// fakeSource 固定数据的导出来源
+type fakeSource struct {
+	name string
+	data interface{}
+	err  error
+}
+
+func (s *fakeSource) Name() string { return s.name }
+
+func (s *fakeSource) Export(ctx context.Context, userID string) (interface{}, error) {
+	return s.data, s.err
+}
+
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time { return c.now }
+
+func newTestService(repo *memoryDeletionRepository, steps []ErasureStep, sources []ExportSource) (*accountService, *clock) {
+	c := &clock{now: time.Now()}
+	svc := NewAccountService(NewConfig(), repo, steps, sources).(*accountService)
+	svc.now = c.Now
+	return svc, c
+}
+
+// forceDue 将注销请求的执行时间提前到当前时间（模拟冷静期结束）
+func forceDue(repo *memoryDeletionRepository, id string, now time.Time) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	repo.deletions[id].NextAttemptAt = now
+}
+
+func TestRequestDeletion_Idempotent(t *testing.T) {
+	repo := newMemoryDeletionRepository()
+	svc, _ := newTestService(repo, nil, nil)
+	ctx := context.Background()
+
+	first, err := svc.RequestDeletion(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.AccountDeletionStatusPending, first.Status)
+	assert.WithinDuration(t, first.RequestedAt.Add(domain.AccountDeletionGracePeriod), first.ScheduledAt, time.Second)
+
+	second, err := svc.RequestDeletion(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, second.ID)
+}
+
+func TestCancelDeletion(t *testing.T) {
+	repo := newMemoryDeletionRepository()
+	svc, _ := newTestService(repo, nil, nil)
+	ctx := context.Background()
+
+	_, err := svc.CancelDeletion(ctx, "user-1")
+	assert.ErrorIs(t, err, domain.ErrAccountDeletionNotFound)
+
+	requested, err := svc.RequestDeletion(ctx, "user-1")
+	require.NoError(t, err)
+
+	cancelled, err := svc.CancelDeletion(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, requested.ID, cancelled.ID)
+	assert.Equal(t, domain.AccountDeletionStatusCancelled, cancelled.Status)
+	assert.NotNil(t, cancelled.CancelledAt)
+
+	// 撤销后可以重新申请
+	again, err := svc.RequestDeletion(ctx, "user-1")
+	require.NoError(t, err)
+	assert.NotEqual(t, requested.ID, again.ID)
+}
+
+func TestCancelDeletion_AfterProcessingStarted(t *testing.T) {
+	repo := newMemoryDeletionRepository()
+	step := newFakeStep("user-content")
+	step.err = errors.New("user-svc unavailable")
+	svc, c := newTestService(repo, []ErasureStep{step}, nil)
+	ctx := context.Background()
+
+	deletion, err := svc.RequestDeletion(ctx, "user-1")
+	require.NoError(t, err)
+	forceDue(repo, deletion.ID, c.now)
+
+	_, err = svc.ProcessDueDeletions(ctx)
+	require.NoError(t, err)
+
+	_, err = svc.CancelDeletion(ctx, "user-1")
+	assert.ErrorIs(t, err, domain.ErrAccountDeletionNotCancellable)
+}
+
+func TestProcessDueDeletions_NotDueDuringGracePeriod(t *testing.T) {
+	repo := newMemoryDeletionRepository()
+	step := newFakeStep("auth-data")
+	svc, _ := newTestService(repo, []ErasureStep{step}, nil)
+	ctx := context.Background()
+
+	_, err := svc.RequestDeletion(ctx, "user-1")
+	require.NoError(t, err)
+
+	completed, err := svc.ProcessDueDeletions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, completed)
+	assert.Equal(t, 0, step.calls["user-1"])
+}
+
+func TestProcessDueDeletions_RetriesFailedStepOnly(t *testing.T) {
+	repo := newMemoryDeletionRepository()
+	revoke := newFakeStep("revoke-access")
+	content := newFakeStep("user-content")
+	authData := newFakeStep("auth-data")
+	content.err = errors.New("user-svc unavailable")
+	svc, c := newTestService(repo, []ErasureStep{revoke, content, authData}, nil)
+	ctx := context.Background()
+
+	deletion, err := svc.RequestDeletion(ctx, "user-1")
+	require.NoError(t, err)
+	forceDue(repo, deletion.ID, c.now)
+
+	completed, err := svc.ProcessDueDeletions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, completed)
+
+	state, err := svc.GetDeletion(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.AccountDeletionStatusProcessing, state.Status)
+	assert.Equal(t, []string{"revoke-access"}, state.CompletedSteps)
+	assert.Equal(t, 1, state.Attempts)
+	assert.Contains(t, state.LastError, "user-content")
+	assert.Equal(t, c.now.Add(time.Minute), state.NextAttemptAt)
+	assert.Equal(t, 0, authData.calls["user-1"])
+
+	// 退避时间内不会重试
+	completed, err = svc.ProcessDueDeletions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, completed)
+	assert.Equal(t, 1, content.calls["user-1"])
+
+	// 恢复后重试，已完成的步骤不再执行
+	content.err = nil
+	c.now = c.now.Add(time.Minute)
+	completed, err = svc.ProcessDueDeletions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, completed)
+
+	assert.Equal(t, 1, revoke.calls["user-1"])
+	assert.Equal(t, 2, content.calls["user-1"])
+	assert.Equal(t, 1, authData.calls["user-1"])
+
+	state, err = svc.GetDeletion(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.AccountDeletionStatusCompleted, state.Status)
+	assert.Equal(t, []string{"revoke-access", "user-content", "auth-data"}, state.CompletedSteps)
+	assert.NotNil(t, state.CompletedAt)
+	assert.Empty(t, state.LastError)
+}
+
+// TestProcessDueDeletions_StopsWhenProgressNotSaved 测试进度保存失败时停止执行后续步骤
+func TestProcessDueDeletions_StopsWhenProgressNotSaved(t *testing.T) {
+	repo := newMemoryDeletionRepository()
+	revoke := newFakeStep("revoke-access")
+	authData := newFakeStep("auth-data")
+	svc, c := newTestService(repo, []ErasureStep{revoke, authData}, nil)
+	ctx := context.Background()
+
+	deletion, err := svc.RequestDeletion(ctx, "user-1")
+	require.NoError(t, err)
+	forceDue(repo, deletion.ID, c.now)
+
+	repo.mu.Lock()
+	repo.updateErr = errors.New("database unavailable")
+	repo.mu.Unlock()
+
+	completed, err := svc.ProcessDueDeletions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, completed)
+	assert.Equal(t, 1, revoke.calls["user-1"])
+	assert.Equal(t, 0, authData.calls["user-1"])
+
+	state, err := svc.GetDeletion(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Empty(t, state.CompletedSteps)
+}
+
+func TestRecordFailure_Backoff(t *testing.T) {
+	now := time.Now()
+	d := domain.NewAccountDeletion("user-1", 0)
+
+	expected := []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute}
+	for _, backoff := range expected {
+		d.RecordFailure(errors.New("boom"), now)
+		assert.Equal(t, now.Add(backoff), d.NextAttemptAt)
+	}
+
+	for i := 0; i < 20; i++ {
+		d.RecordFailure(errors.New("boom"), now)
+	}
+	assert.Equal(t, now.Add(6*time.Hour), d.NextAttemptAt)
+}
+
+func TestExportData_Archive(t *testing.T) {
+	sources := []ExportSource{
+		&fakeSource{name: "account", data: map[string]string{"phone": "13800138000"}},
+		&fakeSource{name: "user-content", data: json.RawMessage(`{"favorites":[]}`)},
+	}
+	svc, _ := newTestService(newMemoryDeletionRepository(), nil, sources)
+
+	archive, err := svc.ExportData(context.Background(), "user-1")
+	require.NoError(t, err)
+	assert.Contains(t, archive.Filename, ".zip")
+
+	var buf bytes.Buffer
+	n, err := archive.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	files := make(map[string][]byte)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		require.NoError(t, err)
+		files[f.Name] = content
+	}
+
+	require.Contains(t, files, "manifest.json")
+	var manifest archiveManifest
+	require.NoError(t, json.Unmarshal(files["manifest.json"], &manifest))
+	assert.Equal(t, "user-1", manifest.UserID)
+	assert.Equal(t, []string{"account.json", "user-content.json"}, manifest.Files)
+
+	assert.JSONEq(t, `{"phone":"13800138000"}`, string(files["account.json"]))
+	assert.JSONEq(t, `{"favorites":[]}`, string(files["user-content.json"]))
+}
+
+func TestExportData_SourceFailure(t *testing.T) {
+	sources := []ExportSource{
+		&fakeSource{name: "account", data: map[string]string{}},
+		&fakeSource{name: "sync-data", err: errors.New("sync-svc unavailable")},
+	}
+	svc, _ := newTestService(newMemoryDeletionRepository(), nil, sources)
+
+	_, err := svc.ExportData(context.Background(), "user-1")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "sync-data")
+}
@@ -0,0 +1,323 @@
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/domain"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/repository"
+	jwtservice "github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/service/jwt"
+	userv1 "github.com/xiaoxiao0301/listen-stream-v2/server/shared/proto/user/v1"
+)
+
+// ErasureStep 注销时的删除步骤
+// Erase必须可重复执行：数据已删除时返回nil
+type ErasureStep interface {
+	// Name 步骤名称（记录在注销进度中，修改会导致已完成的步骤被重新执行）
+	Name() string
+	// Erase 删除用户数据
+	Erase(ctx context.Context, userID string) error
+}
+
+// ExportSource 个人数据导出来源
+type ExportSource interface {
+	// Name 来源名称（作为归档中的文件名）
+	Name() string
+	// Export 导出用户数据，返回值编码为JSON写入归档（json.RawMessage原样写入）
+	Export(ctx context.Context, userID string) (interface{}, error)
+}
+
+// loginHistoryExportLimit 导出的登录记录条数上限
+const loginHistoryExportLimit = 1000
+
+// ========== auth-svc 本地数据 ==========
+
+// revokeAccessStep 停用账号并撤销全部Token，阻止删除过程中产生新数据
+type revokeAccessStep struct {
+	userRepo   repository.UserRepository
+	jwtService jwtservice.JWTService
+}
+
+// NewRevokeAccessStep 创建停用账号步骤（应作为第一个步骤）
+func NewRevokeAccessStep(userRepo repository.UserRepository, jwtService jwtservice.JWTService) ErasureStep {
+	return &revokeAccessStep{userRepo: userRepo, jwtService: jwtService}
+}
+
+// Name 步骤名称
+func (s *revokeAccessStep) Name() string { return "revoke-access" }
+
+// Erase 停用账号并撤销Token
+func (s *revokeAccessStep) Erase(ctx context.Context, userID string) error {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil
+		}
+		return fmt.Errorf("get user: %w", err)
+	}
+	if err := s.userRepo.UpdateActive(ctx, userID, false); err != nil {
+		return fmt.Errorf("deactivate user: %w", err)
+	}
+	if err := s.jwtService.RevokeUserTokens(ctx, userID); err != nil {
+		return fmt.Errorf("revoke tokens: %w", err)
+	}
+	return nil
+}
+
+// authDataStep 删除认证数据（用户记录、设备、登录记录、短信记录）
+type authDataStep struct {
+	userRepo         repository.UserRepository
+	verificationRepo repository.SMSVerificationRepository
+	recordRepo       repository.SMSRecordRepository
+}
+
+// NewAuthDataStep 创建删除认证数据步骤（应作为最后一个步骤）
+// 设备和登录风控记录随用户记录级联删除；短信数据按手机号存储，需要单独删除
+func NewAuthDataStep(userRepo repository.UserRepository, verificationRepo repository.SMSVerificationRepository, recordRepo repository.SMSRecordRepository) ErasureStep {
+	return &authDataStep{
+		userRepo:         userRepo,
+		verificationRepo: verificationRepo,
+		recordRepo:       recordRepo,
+	}
+}
+
+// Name 步骤名称
+func (s *authDataStep) Name() string { return "auth-data" }
+
+// Erase 删除认证数据
+func (s *authDataStep) Erase(ctx context.Context, userID string) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil
+		}
+		return fmt.Errorf("get user: %w", err)
+	}
+
+	if err := s.verificationRepo.DeleteByPhone(ctx, user.Phone); err != nil {
+		return fmt.Errorf("delete sms verifications: %w", err)
+	}
+	if err := s.recordRepo.DeleteByPhone(ctx, user.Phone); err != nil {
+		return fmt.Errorf("delete sms records: %w", err)
+	}
+	if err := s.userRepo.Delete(ctx, userID); err != nil {
+		return fmt.Errorf("delete user: %w", err)
+	}
+	return nil
+}
+
+// authExportSource 导出认证数据
+type authExportSource struct {
+	userRepo   repository.UserRepository
+	deviceRepo repository.DeviceRepository
+	riskRepo   repository.LoginRiskRepository
+}
+
+// NewAuthExportSource 创建认证数据导出来源（riskRepo为nil时不导出登录记录）
+func NewAuthExportSource(userRepo repository.UserRepository, deviceRepo repository.DeviceRepository, riskRepo repository.LoginRiskRepository) ExportSource {
+	return &authExportSource{
+		userRepo:   userRepo,
+		deviceRepo: deviceRepo,
+		riskRepo:   riskRepo,
+	}
+}
+
+// authExport 认证数据导出格式
+type authExport struct {
+	Profile      authProfileExport    `json:"profile"`
+	Devices      []deviceExport       `json:"devices"`
+	LoginHistory []loginHistoryExport `json:"login_history"`
+}
+
+type authProfileExport struct {
+	UserID    string    `json:"user_id"`
+	Phone     string    `json:"phone"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type deviceExport struct {
+	DeviceID    string    `json:"device_id"`
+	DeviceName  string    `json:"device_name"`
+	Platform    string    `json:"platform"`
+	AppVersion  string    `json:"app_version"`
+	LastIP      string    `json:"last_ip"`
+	LastLoginAt time.Time `json:"last_login_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type loginHistoryExport struct {
+	DeviceID  string    `json:"device_id"`
+	ClientIP  string    `json:"client_ip"`
+	Country   string    `json:"country"`
+	City      string    `json:"city"`
+	Action    string    `json:"action"`
+	Outcome   string    `json:"outcome"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Name 来源名称
+func (s *authExportSource) Name() string { return "account" }
+
+// Export 导出认证数据
+func (s *authExportSource) Export(ctx context.Context, userID string) (interface{}, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	devices, err := s.deviceRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list devices: %w", err)
+	}
+
+	export := authExport{
+		Profile: authProfileExport{
+			UserID:    user.ID,
+			Phone:     user.Phone,
+			IsActive:  user.IsActive,
+			CreatedAt: user.CreatedAt,
+			UpdatedAt: user.UpdatedAt,
+		},
+		Devices:      make([]deviceExport, 0, len(devices)),
+		LoginHistory: []loginHistoryExport{},
+	}
+	for _, d := range devices {
+		export.Devices = append(export.Devices, deviceExport{
+			DeviceID:    d.ID,
+			DeviceName:  d.DeviceName,
+			Platform:    d.Platform,
+			AppVersion:  d.AppVersion,
+			LastIP:      d.LastIP,
+			LastLoginAt: d.LastLoginAt,
+			CreatedAt:   d.CreatedAt,
+		})
+	}
+
+	if s.riskRepo != nil {
+		decisions, err := s.riskRepo.ListByUser(ctx, userID, loginHistoryExportLimit)
+		if err != nil {
+			return nil, fmt.Errorf("list login history: %w", err)
+		}
+		for _, d := range decisions {
+			export.LoginHistory = append(export.LoginHistory, loginHistoryExport{
+				DeviceID:  d.DeviceID,
+				ClientIP:  d.ClientIP,
+				Country:   d.Country,
+				City:      d.City,
+				Action:    string(d.Action),
+				Outcome:   string(d.Outcome),
+				CreatedAt: d.CreatedAt,
+			})
+		}
+	}
+
+	return export, nil
+}
+
+// ========== user-svc（收藏、歌单、播放历史） ==========
+
+// userContentParticipant 通过gRPC导出/删除user-svc中的用户内容
+type userContentParticipant struct {
+	client userv1.UserServiceClient
+}
+
+// NewUserContentParticipant 创建user-svc数据步骤，同时实现ErasureStep和ExportSource
+func NewUserContentParticipant(client userv1.UserServiceClient) *userContentParticipant {
+	return &userContentParticipant{client: client}
+}
+
+// Name 步骤名称
+func (p *userContentParticipant) Name() string { return "user-content" }
+
+// Erase 删除用户内容
+func (p *userContentParticipant) Erase(ctx context.Context, userID string) error {
+	_, err := p.client.EraseUserData(ctx, &userv1.EraseUserDataRequest{UserId: userID})
+	return err
+}
+
+// Export 导出用户内容
+func (p *userContentParticipant) Export(ctx context.Context, userID string) (interface{}, error) {
+	resp, err := p.client.ExportUserData(ctx, &userv1.ExportUserDataRequest{UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	data, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(data), nil
+}
+
+// ========== sync-svc（离线消息） ==========
+
+// syncInternalTokenHeader sync-svc内部接口的令牌请求头
+const syncInternalTokenHeader = "X-Internal-Token"
+
+// syncDataParticipant 通过sync-svc内部HTTP接口导出/删除离线消息
+type syncDataParticipant struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+// NewSyncDataParticipant 创建sync-svc数据步骤，同时实现ErasureStep和ExportSource
+func NewSyncDataParticipant(baseURL, token string) *syncDataParticipant {
+	return &syncDataParticipant{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Name 步骤名称
+func (p *syncDataParticipant) Name() string { return "sync-data" }
+
+// Erase 删除离线消息
+func (p *syncDataParticipant) Erase(ctx context.Context, userID string) error {
+	_, err := p.do(ctx, http.MethodDelete, userID)
+	return err
+}
+
+// Export 导出离线消息
+func (p *syncDataParticipant) Export(ctx context.Context, userID string) (interface{}, error) {
+	body, err := p.do(ctx, http.MethodGet, userID)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(body), nil
+}
+
+// do 调用 /internal/v1/users/:user_id/data
+func (p *syncDataParticipant) do(ctx context.Context, method, userID string) ([]byte, error) {
+	endpoint := fmt.Sprintf("%s/internal/v1/users/%s/data", p.baseURL, url.PathEscape(userID))
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create sync request: %w", err)
+	}
+	req.Header.Set(syncInternalTokenHeader, p.token)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("call sync-svc: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64<<20))
+	if err != nil {
+		return nil, fmt.Errorf("read sync response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("sync-svc returned status %d", resp.StatusCode)
+	}
+	return body, nil
+}
@@ -203,8 +203,8 @@ func (s *deviceService) VerifyDevice(ctx context.Context, req *VerifyDeviceReque
 		}, nil
 	}
 
-	// 4. 检查设备指纹是否发生变化（可疑登录）
-	if existingDevice.IsFingerprintChanged(currentFingerprint) {
+	// 4. 检查是否为可疑登录（设备指纹发生变化）
+	if existingDevice.IsSuspiciousLogin(currentFingerprint, req.ClientIP) {
 		return &DeviceVerificationResult{
 			IsValid:            false,
 			IsSuspicious:       true,
@@ -290,7 +290,7 @@ func (s *deviceService) RemoveDevice(ctx context.Context, userID, deviceID strin
 
 	// 2. 验证设备是否属于该用户
 	if device.UserID != userID {
-		return fmt.Errorf("device does not belong to user")
+		return fmt.Errorf("device does not belong to user: %w", domain.ErrDeviceNotFound)
 	}
 
 	// 3. 删除设备
@@ -128,6 +128,19 @@ func (m *MockUserRepository) CountActive(ctx context.Context) (int64, error) {
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockUserRepository) SearchByPhonePrefix(ctx context.Context, prefix string, limit, offset int) ([]*domain.User, error) {
+	args := m.Called(ctx, prefix, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) CountByPhonePrefix(ctx context.Context, prefix string) (int64, error) {
+	args := m.Called(ctx, prefix)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockUserRepository) UpdateActive(ctx context.Context, id string, isActive bool) error {
 	args := m.Called(ctx, id, isActive)
 	return args.Error(0)
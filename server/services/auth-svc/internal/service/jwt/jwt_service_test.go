@@ -82,6 +82,14 @@ func (m *MockUserRepository) CountActive(ctx context.Context) (int64, error) {
 	return count, nil
 }
 
+func (m *MockUserRepository) SearchByPhonePrefix(ctx context.Context, prefix string, limit, offset int) ([]*domain.User, error) {
+	return nil, nil
+}
+
+func (m *MockUserRepository) CountByPhonePrefix(ctx context.Context, prefix string) (int64, error) {
+	return 0, nil
+}
+
 // TestGenerateTokenPair 测试生成Token对
 func TestGenerateTokenPair(t *testing.T) {
 	userRepo := NewMockUserRepository()
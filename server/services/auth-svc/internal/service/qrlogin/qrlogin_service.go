@@ -0,0 +1,384 @@
+package qrlogin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/domain"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/repository"
+	deviceservice "github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/service/device"
+	jwtservice "github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/service/jwt"
+	riskservice "github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/service/risk"
+)
+
+// 长轮询等待时间需小于HTTP服务器的WriteTimeout（15秒）
+const (
+	// DefaultPollWait 默认长轮询等待时间
+	DefaultPollWait = 10 * time.Second
+	// MaxPollWait 最大长轮询等待时间
+	MaxPollWait = 12 * time.Second
+)
+
+// 创建票据无需登录，按客户端IP限制创建频率
+const (
+	// CreateTicketWindow 票据创建计数窗口
+	CreateTicketWindow = time.Minute
+	// MaxTicketsPerIP 计数窗口内单个IP最多创建的票据数
+	MaxTicketsPerIP = 10
+)
+
+// QRLoginService 二维码登录服务接口
+//
+// 流程：
+// 1. 新设备调用CreateTicket获取票据，将票据ID渲染为二维码，轮询令牌留在本地
+// 2. 已登录设备扫码后调用ScanTicket，展示待登录设备信息
+// 3. 已登录设备调用ConfirmTicket确认，服务端对新设备做登录风控后为其注册设备
+// 4. 新设备通过PollTicket长轮询领取Token对（领取时签发，领取后票据即失效；Token不写入Redis）
+type QRLoginService interface {
+	// CreateTicket 创建二维码登录票据（新设备调用，无需登录）
+	CreateTicket(ctx context.Context, req *CreateTicketRequest) (*domain.QRLoginTicket, error)
+
+	// PollTicket 长轮询票据状态（新设备调用，需要轮询令牌）
+	// 状态与LastStatus相同时阻塞等待，直到状态变化或超时
+	PollTicket(ctx context.Context, req *PollTicketRequest) (*PollResult, error)
+
+	// ScanTicket 扫码（已登录设备调用）
+	ScanTicket(ctx context.Context, ticketID, userID string) (*domain.QRLoginTicket, error)
+
+	// ConfirmTicket 确认登录（已登录设备调用）
+	ConfirmTicket(ctx context.Context, ticketID, userID string) error
+
+	// CancelTicket 拒绝登录（已登录设备调用，必须是扫码用户）
+	CancelTicket(ctx context.Context, ticketID, userID string) error
+
+	// CancelTicketByDevice 取消登录（新设备调用，需要轮询令牌）
+	CancelTicketByDevice(ctx context.Context, ticketID, pollToken string) error
+}
+
+// CreateTicketRequest 创建票据请求（新设备信息）
+type CreateTicketRequest struct {
+	DeviceName string
+	DeviceID   string
+	Platform   string
+	OSVersion  string
+	AppVersion string
+	ClientIP   string
+}
+
+// PollTicketRequest 轮询票据请求
+type PollTicketRequest struct {
+	TicketID   string
+	PollToken  string
+	LastStatus domain.QRLoginStatus // 客户端已知状态，为空时视为pending
+	Wait       time.Duration        // 最长等待时间，为0时使用默认值
+}
+
+// PollResult 轮询结果
+type PollResult struct {
+	Status    domain.QRLoginStatus
+	ExpiresAt time.Time
+
+	// 以下字段仅在Status为confirmed时设置
+	UserID    string
+	DeviceID  string
+	TokenPair *jwtservice.TokenPair
+}
+
+// qrLoginService 二维码登录服务实现
+type qrLoginService struct {
+	ticketRepo    repository.QRLoginTicketRepository
+	userRepo      repository.UserRepository
+	deviceService deviceservice.DeviceService
+	jwtService    jwtservice.JWTService
+	riskService   riskservice.RiskService
+}
+
+// NewQRLoginService 创建二维码登录服务
+// riskService为nil时不做登录风控
+func NewQRLoginService(
+	ticketRepo repository.QRLoginTicketRepository,
+	userRepo repository.UserRepository,
+	deviceService deviceservice.DeviceService,
+	jwtService jwtservice.JWTService,
+	riskService riskservice.RiskService,
+) QRLoginService {
+	return &qrLoginService{
+		ticketRepo:    ticketRepo,
+		userRepo:      userRepo,
+		deviceService: deviceService,
+		jwtService:    jwtService,
+		riskService:   riskService,
+	}
+}
+
+// CreateTicket 创建二维码登录票据
+func (s *qrLoginService) CreateTicket(ctx context.Context, req *CreateTicketRequest) (*domain.QRLoginTicket, error) {
+	ticket, err := domain.NewQRLoginTicket(
+		req.DeviceName,
+		req.DeviceID,
+		req.Platform,
+		req.OSVersion,
+		req.AppVersion,
+		req.ClientIP,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ticket.Validate(); err != nil {
+		return nil, err
+	}
+
+	if ip := normalizeIP(req.ClientIP); ip != "" {
+		count, err := s.ticketRepo.IncrCreateCount(ctx, ip, CreateTicketWindow)
+		if err != nil {
+			return nil, fmt.Errorf("count tickets: %w", err)
+		}
+		if count > MaxTicketsPerIP {
+			return nil, domain.ErrQRTicketRateLimited
+		}
+	}
+
+	if err := s.ticketRepo.Create(ctx, ticket); err != nil {
+		return nil, fmt.Errorf("save ticket: %w", err)
+	}
+
+	return ticket, nil
+}
+
+// PollTicket 长轮询票据状态
+func (s *qrLoginService) PollTicket(ctx context.Context, req *PollTicketRequest) (*PollResult, error) {
+	lastStatus := req.LastStatus
+	if lastStatus == "" {
+		lastStatus = domain.QRLoginStatusPending
+	}
+
+	wait := req.Wait
+	if wait <= 0 {
+		wait = DefaultPollWait
+	}
+	if wait > MaxPollWait {
+		wait = MaxPollWait
+	}
+
+	ticket, err := s.getTicketForPoll(ctx, req.TicketID, req.PollToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// 状态未变化时等待通知
+	if ticket.Status == lastStatus && !ticket.IsFinal() {
+		notify, release := s.ticketRepo.Watch(ctx, req.TicketID)
+		defer release()
+
+		// 订阅建立前状态可能已变化，重新读取一次
+		ticket, err = s.getTicketForPoll(ctx, req.TicketID, req.PollToken)
+		if err != nil {
+			return nil, err
+		}
+
+		if ticket.Status == lastStatus && !ticket.IsFinal() {
+			timer := time.NewTimer(wait)
+			defer timer.Stop()
+
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-timer.C:
+			case <-notify:
+			}
+
+			ticket, err = s.getTicketForPoll(ctx, req.TicketID, req.PollToken)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	result := &PollResult{
+		Status:    ticket.Status,
+		ExpiresAt: ticket.ExpiresAt,
+	}
+
+	if ticket.Status == domain.QRLoginStatusConfirmed {
+		// Token只能领取一次：先删除票据，删除成功的请求才签发Token
+		deleted, err := s.ticketRepo.Delete(ctx, ticket.ID)
+		if err != nil {
+			return nil, fmt.Errorf("delete ticket: %w", err)
+		}
+		if !deleted {
+			return &PollResult{Status: domain.QRLoginStatusExpired}, nil
+		}
+
+		tokenPair, err := s.jwtService.GenerateTokenPair(ctx, ticket.UserID, ticket.RegisteredDeviceID, ticket.ClientIP)
+		if err != nil {
+			return nil, fmt.Errorf("generate token pair: %w", err)
+		}
+
+		result.UserID = ticket.UserID
+		result.DeviceID = ticket.RegisteredDeviceID
+		result.TokenPair = tokenPair
+	}
+
+	return result, nil
+}
+
+// getTicketForPoll 获取票据并校验轮询令牌
+// 票据不存在（已过期被Redis淘汰）时返回expired状态的票据
+func (s *qrLoginService) getTicketForPoll(ctx context.Context, ticketID, pollToken string) (*domain.QRLoginTicket, error) {
+	ticket, err := s.ticketRepo.Get(ctx, ticketID)
+	if err != nil {
+		if errors.Is(err, domain.ErrQRTicketNotFound) {
+			return &domain.QRLoginTicket{ID: ticketID, Status: domain.QRLoginStatusExpired}, nil
+		}
+		return nil, fmt.Errorf("get ticket: %w", err)
+	}
+
+	if !ticket.VerifyPollToken(pollToken) {
+		return nil, domain.ErrQRTicketPollToken
+	}
+
+	if ticket.IsExpired() && ticket.Status != domain.QRLoginStatusConfirmed {
+		ticket.Status = domain.QRLoginStatusExpired
+	}
+
+	return ticket, nil
+}
+
+// ScanTicket 扫码
+func (s *qrLoginService) ScanTicket(ctx context.Context, ticketID, userID string) (*domain.QRLoginTicket, error) {
+	if _, err := s.getActiveUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return s.ticketRepo.Update(ctx, ticketID, func(ticket *domain.QRLoginTicket) error {
+		return ticket.MarkScanned(userID)
+	})
+}
+
+// ConfirmTicket 确认登录
+func (s *qrLoginService) ConfirmTicket(ctx context.Context, ticketID, userID string) error {
+	user, err := s.getActiveUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// 1. 原子占用票据（并发确认时只有一个成功，失败的请求不会注册设备）
+	ticket, err := s.ticketRepo.Update(ctx, ticketID, func(t *domain.QRLoginTicket) error {
+		return t.ClaimConfirm(userID)
+	})
+	if err != nil {
+		return err
+	}
+
+	// 2. 登录风控（与短信登录共用策略，按新设备的IP评估）
+	// 扫码登录没有二次验证步骤，需要二次验证的登录直接拒绝，用户可改用短信登录完成二次验证
+	if s.riskService != nil {
+		assessment, err := s.riskService.EvaluateLogin(ctx, &riskservice.EvaluateLoginRequest{
+			User:              user,
+			DeviceName:        ticket.DeviceName,
+			DeviceID:          ticket.DeviceID,
+			Platform:          ticket.Platform,
+			OSVersion:         ticket.OSVersion,
+			AppVersion:        ticket.AppVersion,
+			ClientIP:          ticket.ClientIP,
+			StepUpUnavailable: true,
+		})
+		if err != nil {
+			return s.releaseTicket(ctx, ticketID, userID, fmt.Errorf("evaluate login risk: %w", err))
+		}
+		if assessment.Action() == domain.LoginRiskActionBlock {
+			// 拒绝后票据作废，新设备轮询到cancelled
+			if _, cancelErr := s.ticketRepo.Update(ctx, ticketID, func(t *domain.QRLoginTicket) error {
+				return t.Cancel(userID)
+			}); cancelErr != nil && !errors.Is(cancelErr, domain.ErrQRTicketNotFound) {
+				return fmt.Errorf("%w (cancel ticket: %v)", domain.ErrLoginBlocked, cancelErr)
+			}
+			return domain.ErrLoginBlocked
+		}
+	}
+
+	// 3. 为新设备注册设备（与短信登录共用设备注册逻辑，设备会出现在设备列表中）
+	// 票据需要记录设备ID，只能先注册再完成确认；确认失败时删除本次新建的设备
+	device, err := s.deviceService.RegisterDevice(ctx, &deviceservice.RegisterDeviceRequest{
+		UserID:     userID,
+		DeviceName: ticket.DeviceName,
+		DeviceID:   ticket.DeviceID,
+		Platform:   ticket.Platform,
+		OSVersion:  ticket.OSVersion,
+		AppVersion: ticket.AppVersion,
+		ClientIP:   ticket.ClientIP,
+	})
+	if err != nil {
+		// 释放占用，用户处理后（如移除旧设备）可以重新确认
+		return s.releaseTicket(ctx, ticketID, userID, fmt.Errorf("register device: %w", err))
+	}
+
+	// 4. 完成确认，Token在新设备领取时签发
+	if _, err := s.ticketRepo.Update(ctx, ticketID, func(t *domain.QRLoginTicket) error {
+		return t.Confirm(userID, device.ID)
+	}); err != nil {
+		// 已存在的设备只更新了登录信息，不能删除
+		if device.CreatedAt.Equal(device.LastLoginAt) {
+			if removeErr := s.deviceService.RemoveDevice(ctx, userID, device.ID); removeErr != nil {
+				return fmt.Errorf("%w (remove device: %v)", err, removeErr)
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+// releaseTicket 确认失败时释放票据占用，票据回到已扫码状态，返回原始错误
+func (s *qrLoginService) releaseTicket(ctx context.Context, ticketID, userID string, cause error) error {
+	if _, err := s.ticketRepo.Update(ctx, ticketID, func(t *domain.QRLoginTicket) error {
+		return t.ReleaseConfirm(userID)
+	}); err != nil && !errors.Is(err, domain.ErrQRTicketNotFound) {
+		return fmt.Errorf("%w (release ticket: %v)", cause, err)
+	}
+	return cause
+}
+
+// normalizeIP 取X-Forwarded-For中的第一个地址并去掉端口
+func normalizeIP(clientIP string) string {
+	ip := strings.TrimSpace(strings.Split(clientIP, ",")[0])
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	return ip
+}
+
+// getActiveUser 获取扫码用户并检查是否存在且未被禁用
+func (s *qrLoginService) getActiveUser(ctx context.Context, userID string) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+	if !user.CanLogin() {
+		return nil, domain.ErrUserInactive
+	}
+	return user, nil
+}
+
+// CancelTicket 拒绝登录
+func (s *qrLoginService) CancelTicket(ctx context.Context, ticketID, userID string) error {
+	_, err := s.ticketRepo.Update(ctx, ticketID, func(ticket *domain.QRLoginTicket) error {
+		return ticket.Cancel(userID)
+	})
+	return err
+}
+
+// CancelTicketByDevice 新设备取消登录
+func (s *qrLoginService) CancelTicketByDevice(ctx context.Context, ticketID, pollToken string) error {
+	_, err := s.ticketRepo.Update(ctx, ticketID, func(ticket *domain.QRLoginTicket) error {
+		return ticket.CancelByDevice(pollToken)
+	})
+	return err
+}
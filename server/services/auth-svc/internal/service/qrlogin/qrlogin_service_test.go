@@ -0,0 +1,638 @@
+package qrlogin
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/domain"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/repository"
+	deviceservice "github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/service/device"
+	jwtservice "github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/service/jwt"
+	riskservice "github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/service/risk"
+)
+
+// memoryTicketRepository 内存票据仓储（用于测试）
+type memoryTicketRepository struct {
+	mu           sync.Mutex
+	tickets      map[string][]byte
+	watchers     map[string][]chan struct{}
+	createCounts map[string]int64
+}
+
+func newMemoryTicketRepository() *memoryTicketRepository {
+	return &memoryTicketRepository{
+		tickets:      make(map[string][]byte),
+		watchers:     make(map[string][]chan struct{}),
+		createCounts: make(map[string]int64),
+	}
+}
+
+func (r *memoryTicketRepository) Create(ctx context.Context, ticket *domain.QRLoginTicket) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	data, _ := json.Marshal(ticket)
+	r.tickets[ticket.ID] = data
+	return nil
+}
+
+func (r *memoryTicketRepository) Get(ctx context.Context, id string) (*domain.QRLoginTicket, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	data, ok := r.tickets[id]
+	if !ok {
+		return nil, domain.ErrQRTicketNotFound
+	}
+	var ticket domain.QRLoginTicket
+	_ = json.Unmarshal(data, &ticket)
+	return &ticket, nil
+}
+
+func (r *memoryTicketRepository) Update(ctx context.Context, id string, fn func(ticket *domain.QRLoginTicket) error) (*domain.QRLoginTicket, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	data, ok := r.tickets[id]
+	if !ok {
+		return nil, domain.ErrQRTicketNotFound
+	}
+	var ticket domain.QRLoginTicket
+	_ = json.Unmarshal(data, &ticket)
+	if err := fn(&ticket); err != nil {
+		return nil, err
+	}
+	r.tickets[id], _ = json.Marshal(&ticket)
+	for _, ch := range r.watchers[id] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+	return &ticket, nil
+}
+
+func (r *memoryTicketRepository) Delete(ctx context.Context, id string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.tickets[id]
+	delete(r.tickets, id)
+	return ok, nil
+}
+
+func (r *memoryTicketRepository) Watch(ctx context.Context, id string) (<-chan struct{}, func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ch := make(chan struct{}, 1)
+	r.watchers[id] = append(r.watchers[id], ch)
+	return ch, func() {}
+}
+
+func (r *memoryTicketRepository) IncrCreateCount(ctx context.Context, clientIP string, window time.Duration) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.createCounts[clientIP]++
+	return r.createCounts[clientIP], nil
+}
+
+// stubUserRepository 用户仓储桩（只实现GetByID）
+type stubUserRepository struct {
+	repository.UserRepository
+	users map[string]*domain.User
+}
+
+func (r *stubUserRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, domain.ErrUserNotFound
+	}
+	return user, nil
+}
+
+// MockDeviceService 设备服务Mock
+type MockDeviceService struct {
+	mock.Mock
+}
+
+func (m *MockDeviceService) RegisterDevice(ctx context.Context, req *deviceservice.RegisterDeviceRequest) (*domain.Device, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Device), args.Error(1)
+}
+
+func (m *MockDeviceService) VerifyDevice(ctx context.Context, req *deviceservice.VerifyDeviceRequest) (*deviceservice.DeviceVerificationResult, error) {
+	args := m.Called(ctx, req)
+	return nil, args.Error(1)
+}
+
+func (m *MockDeviceService) ListDevices(ctx context.Context, userID string) ([]*domain.Device, error) {
+	args := m.Called(ctx, userID)
+	return nil, args.Error(1)
+}
+
+func (m *MockDeviceService) RemoveDevice(ctx context.Context, userID, deviceID string) error {
+	args := m.Called(ctx, userID, deviceID)
+	return args.Error(0)
+}
+
+func (m *MockDeviceService) RemoveInactiveDevices(ctx context.Context, days int) (int, error) {
+	args := m.Called(ctx, days)
+	return args.Int(0), args.Error(1)
+}
+
+// MockJWTService JWT服务Mock
+type MockJWTService struct {
+	mock.Mock
+}
+
+func (m *MockJWTService) GenerateTokenPair(ctx context.Context, userID, deviceID, clientIP string) (*jwtservice.TokenPair, error) {
+	args := m.Called(ctx, userID, deviceID, clientIP)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*jwtservice.TokenPair), args.Error(1)
+}
+
+func (m *MockJWTService) ValidateAccessToken(ctx context.Context, token, clientIP string) (*jwtservice.TokenClaims, error) {
+	args := m.Called(ctx, token, clientIP)
+	return nil, args.Error(1)
+}
+
+func (m *MockJWTService) ValidateRefreshToken(ctx context.Context, token string) (*jwtservice.TokenClaims, error) {
+	args := m.Called(ctx, token)
+	return nil, args.Error(1)
+}
+
+func (m *MockJWTService) RefreshAccessToken(ctx context.Context, refreshToken, clientIP string) (*jwtservice.TokenPair, error) {
+	args := m.Called(ctx, refreshToken, clientIP)
+	return nil, args.Error(1)
+}
+
+func (m *MockJWTService) RevokeUserTokens(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockJWTService) GetTokenExpiry() time.Duration {
+	return time.Hour
+}
+
+// MockRiskService 登录风控服务Mock
+type MockRiskService struct {
+	mock.Mock
+}
+
+func (m *MockRiskService) EvaluateLogin(ctx context.Context, req *riskservice.EvaluateLoginRequest) (*riskservice.Assessment, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*riskservice.Assessment), args.Error(1)
+}
+
+func (m *MockRiskService) ResendStepUpCode(ctx context.Context, challengeID string) (*domain.StepUpChallenge, error) {
+	args := m.Called(ctx, challengeID)
+	return nil, args.Error(1)
+}
+
+func (m *MockRiskService) VerifyStepUp(ctx context.Context, challengeID string, method domain.StepUpMethod, code string) (*domain.StepUpChallenge, error) {
+	args := m.Called(ctx, challengeID, method, code)
+	return nil, args.Error(1)
+}
+
+func newTestService() (*qrLoginService, *memoryTicketRepository, *MockDeviceService, *MockJWTService) {
+	repo := newMemoryTicketRepository()
+	userRepo := &stubUserRepository{users: map[string]*domain.User{
+		"user-123": {ID: "user-123", IsActive: true},
+		"user-456": {ID: "user-456", IsActive: true},
+		"user-off": {ID: "user-off", IsActive: false},
+	}}
+	deviceSvc := new(MockDeviceService)
+	jwtSvc := new(MockJWTService)
+	svc := NewQRLoginService(repo, userRepo, deviceSvc, jwtSvc, nil).(*qrLoginService)
+	return svc, repo, deviceSvc, jwtSvc
+}
+
+func createTestTicket(t *testing.T, svc *qrLoginService) *domain.QRLoginTicket {
+	ticket, err := svc.CreateTicket(context.Background(), &CreateTicketRequest{
+		DeviceName: "Living Room TV",
+		DeviceID:   "tv-001",
+		Platform:   "Desktop",
+		OSVersion:  "1.0",
+		AppVersion: "2.0.0",
+		ClientIP:   "10.0.0.8",
+	})
+	require.NoError(t, err)
+	return ticket
+}
+
+// TestCreateTicket_InvalidPlatform 测试无效平台
+func TestCreateTicket_InvalidPlatform(t *testing.T) {
+	svc, _, _, _ := newTestService()
+
+	_, err := svc.CreateTicket(context.Background(), &CreateTicketRequest{
+		DeviceName: "TV",
+		DeviceID:   "tv-001",
+		Platform:   "Toaster",
+	})
+	assert.ErrorIs(t, err, domain.ErrInvalidPlatform)
+}
+
+// TestCreateTicket_RateLimitedPerIP 测试按客户端IP限制票据创建频率
+func TestCreateTicket_RateLimitedPerIP(t *testing.T) {
+	svc, repo, _, _ := newTestService()
+	ctx := context.Background()
+
+	newRequest := func(clientIP string) *CreateTicketRequest {
+		return &CreateTicketRequest{DeviceName: "TV", DeviceID: "tv-001", Platform: "Desktop", ClientIP: clientIP}
+	}
+
+	for i := 0; i < MaxTicketsPerIP; i++ {
+		_, err := svc.CreateTicket(ctx, newRequest("10.0.0.8"))
+		require.NoError(t, err)
+	}
+
+	// 端口和代理链不影响计数
+	_, err := svc.CreateTicket(ctx, newRequest("10.0.0.8:53211"))
+	assert.ErrorIs(t, err, domain.ErrQRTicketRateLimited)
+	_, err = svc.CreateTicket(ctx, newRequest("10.0.0.8, 172.16.0.1"))
+	assert.ErrorIs(t, err, domain.ErrQRTicketRateLimited)
+	assert.Len(t, repo.tickets, MaxTicketsPerIP)
+
+	_, err = svc.CreateTicket(ctx, newRequest("10.0.0.9"))
+	assert.NoError(t, err)
+}
+
+// TestQRLogin_FullFlow 测试扫码、确认、领取Token的完整流程
+func TestQRLogin_FullFlow(t *testing.T) {
+	svc, repo, deviceSvc, jwtSvc := newTestService()
+	ctx := context.Background()
+
+	ticket := createTestTicket(t, svc)
+	assert.Equal(t, domain.QRLoginStatusPending, ticket.Status)
+	assert.NotEmpty(t, ticket.PollToken)
+
+	scanned, err := svc.ScanTicket(ctx, ticket.ID, "user-123")
+	require.NoError(t, err)
+	assert.Equal(t, domain.QRLoginStatusScanned, scanned.Status)
+	assert.Equal(t, "Living Room TV", scanned.DeviceName)
+
+	device := &domain.Device{ID: "device-new", UserID: "user-123"}
+	deviceSvc.On("RegisterDevice", mock.Anything, mock.MatchedBy(func(req *deviceservice.RegisterDeviceRequest) bool {
+		return req.UserID == "user-123" && req.DeviceID == "tv-001" && req.Platform == "Desktop"
+	})).Return(device, nil)
+
+	expiresAt := time.Now().Add(time.Hour)
+	jwtSvc.On("GenerateTokenPair", mock.Anything, "user-123", "device-new", "10.0.0.8").Return(&jwtservice.TokenPair{
+		AccessToken:  "access",
+		RefreshToken: "refresh",
+		ExpiresAt:    expiresAt,
+	}, nil)
+
+	require.NoError(t, svc.ConfirmTicket(ctx, ticket.ID, "user-123"))
+
+	// 确认后票据中只有设备ID，Token在领取时签发
+	jwtSvc.AssertNotCalled(t, "GenerateTokenPair", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	stored := repo.tickets[ticket.ID]
+	assert.NotContains(t, string(stored), "access")
+	assert.NotContains(t, string(stored), "refresh")
+
+	result, err := svc.PollTicket(ctx, &PollTicketRequest{
+		TicketID:   ticket.ID,
+		PollToken:  ticket.PollToken,
+		LastStatus: domain.QRLoginStatusScanned,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.QRLoginStatusConfirmed, result.Status)
+	require.NotNil(t, result.TokenPair)
+	assert.Equal(t, "access", result.TokenPair.AccessToken)
+	assert.Equal(t, "refresh", result.TokenPair.RefreshToken)
+	assert.Equal(t, "user-123", result.UserID)
+	assert.Equal(t, "device-new", result.DeviceID)
+
+	// Token只能领取一次
+	_, err = repo.Get(ctx, ticket.ID)
+	assert.ErrorIs(t, err, domain.ErrQRTicketNotFound)
+	jwtSvc.AssertNumberOfCalls(t, "GenerateTokenPair", 1)
+
+	deviceSvc.AssertExpectations(t)
+	jwtSvc.AssertExpectations(t)
+}
+
+// TestPollTicket_WakesOnChange 测试长轮询在状态变化时立即返回
+func TestPollTicket_WakesOnChange(t *testing.T) {
+	svc, _, _, _ := newTestService()
+	ctx := context.Background()
+	ticket := createTestTicket(t, svc)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		_, _ = svc.ScanTicket(ctx, ticket.ID, "user-123")
+	}()
+
+	start := time.Now()
+	result, err := svc.PollTicket(ctx, &PollTicketRequest{
+		TicketID:  ticket.ID,
+		PollToken: ticket.PollToken,
+		Wait:      5 * time.Second,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.QRLoginStatusScanned, result.Status)
+	assert.Less(t, time.Since(start), 2*time.Second)
+	assert.Nil(t, result.TokenPair)
+}
+
+// TestPollTicket_InvalidPollToken 测试轮询令牌错误
+func TestPollTicket_InvalidPollToken(t *testing.T) {
+	svc, _, _, _ := newTestService()
+	ticket := createTestTicket(t, svc)
+
+	_, err := svc.PollTicket(context.Background(), &PollTicketRequest{
+		TicketID:  ticket.ID,
+		PollToken: "wrong",
+	})
+	assert.ErrorIs(t, err, domain.ErrQRTicketPollToken)
+}
+
+// TestPollTicket_UnknownTicketReportsExpired 测试票据已被淘汰
+func TestPollTicket_UnknownTicketReportsExpired(t *testing.T) {
+	svc, _, _, _ := newTestService()
+
+	result, err := svc.PollTicket(context.Background(), &PollTicketRequest{
+		TicketID:  "missing",
+		PollToken: "whatever",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.QRLoginStatusExpired, result.Status)
+}
+
+// TestScanTicket_OtherUserRejected 测试其他用户不能抢占已扫码的票据
+func TestScanTicket_OtherUserRejected(t *testing.T) {
+	svc, _, _, _ := newTestService()
+	ctx := context.Background()
+	ticket := createTestTicket(t, svc)
+
+	_, err := svc.ScanTicket(ctx, ticket.ID, "user-123")
+	require.NoError(t, err)
+
+	_, err = svc.ScanTicket(ctx, ticket.ID, "user-456")
+	assert.ErrorIs(t, err, domain.ErrQRTicketAlreadyScanned)
+}
+
+// TestConfirmTicket_RequiresScanByCaller 测试确认前必须由同一用户扫码
+func TestConfirmTicket_RequiresScanByCaller(t *testing.T) {
+	svc, _, deviceSvc, _ := newTestService()
+	ctx := context.Background()
+	ticket := createTestTicket(t, svc)
+
+	err := svc.ConfirmTicket(ctx, ticket.ID, "user-123")
+	assert.ErrorIs(t, err, domain.ErrQRTicketInvalidState)
+
+	_, err = svc.ScanTicket(ctx, ticket.ID, "user-123")
+	require.NoError(t, err)
+
+	err = svc.ConfirmTicket(ctx, ticket.ID, "user-456")
+	assert.ErrorIs(t, err, domain.ErrQRTicketUserMismatch)
+
+	deviceSvc.AssertNotCalled(t, "RegisterDevice", mock.Anything, mock.Anything)
+}
+
+// TestConfirmTicket_DeviceLimit 测试设备数量达到上限
+func TestConfirmTicket_DeviceLimit(t *testing.T) {
+	svc, repo, deviceSvc, _ := newTestService()
+	ctx := context.Background()
+	ticket := createTestTicket(t, svc)
+
+	_, err := svc.ScanTicket(ctx, ticket.ID, "user-123")
+	require.NoError(t, err)
+
+	deviceSvc.On("RegisterDevice", mock.Anything, mock.Anything).Return(nil, domain.ErrMaxDevicesExceeded)
+
+	err = svc.ConfirmTicket(ctx, ticket.ID, "user-123")
+	assert.ErrorIs(t, err, domain.ErrMaxDevicesExceeded)
+
+	stored, err := repo.Get(ctx, ticket.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.QRLoginStatusScanned, stored.Status)
+}
+
+// TestCancelTicket 测试拒绝登录
+func TestCancelTicket(t *testing.T) {
+	svc, _, _, _ := newTestService()
+	ctx := context.Background()
+	ticket := createTestTicket(t, svc)
+
+	_, err := svc.ScanTicket(ctx, ticket.ID, "user-123")
+	require.NoError(t, err)
+	require.NoError(t, svc.CancelTicket(ctx, ticket.ID, "user-123"))
+
+	result, err := svc.PollTicket(ctx, &PollTicketRequest{
+		TicketID:   ticket.ID,
+		PollToken:  ticket.PollToken,
+		LastStatus: domain.QRLoginStatusScanned,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.QRLoginStatusCancelled, result.Status)
+}
+
+// TestCancelTicket_OnlyScannerOrPollTokenHolder 测试只有扫码用户或持有轮询令牌的新设备能取消票据
+func TestCancelTicket_OnlyScannerOrPollTokenHolder(t *testing.T) {
+	svc, repo, _, _ := newTestService()
+	ctx := context.Background()
+	ticket := createTestTicket(t, svc)
+
+	// 未扫码的票据不能被任意已登录用户取消
+	err := svc.CancelTicket(ctx, ticket.ID, "user-456")
+	assert.ErrorIs(t, err, domain.ErrQRTicketInvalidState)
+
+	_, err = svc.ScanTicket(ctx, ticket.ID, "user-123")
+	require.NoError(t, err)
+
+	// 其他用户不能拒绝已被扫码的票据
+	err = svc.CancelTicket(ctx, ticket.ID, "user-456")
+	assert.ErrorIs(t, err, domain.ErrQRTicketUserMismatch)
+
+	// 轮询令牌错误
+	err = svc.CancelTicketByDevice(ctx, ticket.ID, "wrong-token")
+	assert.ErrorIs(t, err, domain.ErrQRTicketPollToken)
+
+	stored, err := repo.Get(ctx, ticket.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.QRLoginStatusScanned, stored.Status)
+
+	// 新设备凭轮询令牌取消
+	require.NoError(t, svc.CancelTicketByDevice(ctx, ticket.ID, ticket.PollToken))
+	stored, err = repo.Get(ctx, ticket.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.QRLoginStatusCancelled, stored.Status)
+
+	// 已取消的票据不能再次取消
+	err = svc.CancelTicket(ctx, ticket.ID, "user-123")
+	assert.ErrorIs(t, err, domain.ErrQRTicketInvalidState)
+
+	// 未扫码的票据可由新设备取消
+	pending := createTestTicket(t, svc)
+	require.NoError(t, svc.CancelTicketByDevice(ctx, pending.ID, pending.PollToken))
+	stored, err = repo.Get(ctx, pending.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.QRLoginStatusCancelled, stored.Status)
+}
+
+// TestConfirmTicket_ConcurrentConfirmRejectedBeforeSideEffects 测试并发确认时落败的请求不注册设备
+func TestConfirmTicket_ConcurrentConfirmRejectedBeforeSideEffects(t *testing.T) {
+	svc, repo, deviceSvc, jwtSvc := newTestService()
+	ctx := context.Background()
+	ticket := createTestTicket(t, svc)
+
+	_, err := svc.ScanTicket(ctx, ticket.ID, "user-123")
+	require.NoError(t, err)
+
+	// 另一个确认请求已占用票据
+	_, err = repo.Update(ctx, ticket.ID, func(t *domain.QRLoginTicket) error {
+		return t.ClaimConfirm("user-123")
+	})
+	require.NoError(t, err)
+
+	err = svc.ConfirmTicket(ctx, ticket.ID, "user-123")
+	assert.ErrorIs(t, err, domain.ErrQRTicketInvalidState)
+	deviceSvc.AssertNotCalled(t, "RegisterDevice", mock.Anything, mock.Anything)
+	jwtSvc.AssertNotCalled(t, "GenerateTokenPair", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestPollTicket_SecondPickupGetsNoTokens 测试票据被领取后再次轮询不会再签发Token
+func TestPollTicket_SecondPickupGetsNoTokens(t *testing.T) {
+	svc, _, deviceSvc, jwtSvc := newTestService()
+	ctx := context.Background()
+	ticket := createTestTicket(t, svc)
+
+	_, err := svc.ScanTicket(ctx, ticket.ID, "user-123")
+	require.NoError(t, err)
+	deviceSvc.On("RegisterDevice", mock.Anything, mock.Anything).Return(&domain.Device{ID: "device-new"}, nil)
+	jwtSvc.On("GenerateTokenPair", mock.Anything, "user-123", "device-new", "10.0.0.8").Return(&jwtservice.TokenPair{
+		AccessToken:  "access",
+		RefreshToken: "refresh",
+		ExpiresAt:    time.Now().Add(time.Hour),
+	}, nil)
+	require.NoError(t, svc.ConfirmTicket(ctx, ticket.ID, "user-123"))
+
+	first, err := svc.PollTicket(ctx, &PollTicketRequest{TicketID: ticket.ID, PollToken: ticket.PollToken})
+	require.NoError(t, err)
+	require.NotNil(t, first.TokenPair)
+
+	second, err := svc.PollTicket(ctx, &PollTicketRequest{TicketID: ticket.ID, PollToken: ticket.PollToken})
+	require.NoError(t, err)
+	assert.Equal(t, domain.QRLoginStatusExpired, second.Status)
+	assert.Nil(t, second.TokenPair)
+	jwtSvc.AssertNumberOfCalls(t, "GenerateTokenPair", 1)
+}
+
+// TestQRLogin_InactiveUserRejected 测试被禁用的用户不能扫码或确认
+func TestQRLogin_InactiveUserRejected(t *testing.T) {
+	svc, _, deviceSvc, _ := newTestService()
+	ctx := context.Background()
+	ticket := createTestTicket(t, svc)
+
+	_, err := svc.ScanTicket(ctx, ticket.ID, "user-off")
+	assert.ErrorIs(t, err, domain.ErrUserInactive)
+
+	err = svc.ConfirmTicket(ctx, ticket.ID, "user-off")
+	assert.ErrorIs(t, err, domain.ErrUserInactive)
+	deviceSvc.AssertNotCalled(t, "RegisterDevice", mock.Anything, mock.Anything)
+}
+
+// TestConfirmTicket_RiskBlocked 测试风控拒绝的扫码登录不注册设备，票据作废
+func TestConfirmTicket_RiskBlocked(t *testing.T) {
+	svc, repo, deviceSvc, _ := newTestService()
+	riskSvc := new(MockRiskService)
+	svc.riskService = riskSvc
+	ctx := context.Background()
+	ticket := createTestTicket(t, svc)
+
+	_, err := svc.ScanTicket(ctx, ticket.ID, "user-123")
+	require.NoError(t, err)
+
+	// 扫码登录无法二次验证，风控请求需标记StepUpUnavailable（需要二次验证时直接拒绝）
+	riskSvc.On("EvaluateLogin", mock.Anything, mock.MatchedBy(func(req *riskservice.EvaluateLoginRequest) bool {
+		return req.User.ID == "user-123" && req.DeviceID == "tv-001" && req.ClientIP == "10.0.0.8" && req.StepUpUnavailable
+	})).Return(&riskservice.Assessment{
+		Decision: &domain.LoginRiskDecision{Action: domain.LoginRiskActionBlock},
+	}, nil)
+
+	err = svc.ConfirmTicket(ctx, ticket.ID, "user-123")
+	assert.ErrorIs(t, err, domain.ErrLoginBlocked)
+	deviceSvc.AssertNotCalled(t, "RegisterDevice", mock.Anything, mock.Anything)
+
+	stored, err := repo.Get(ctx, ticket.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.QRLoginStatusCancelled, stored.Status)
+}
+
+// TestConfirmTicket_RiskAllowed 测试风控放行后正常注册设备
+func TestConfirmTicket_RiskAllowed(t *testing.T) {
+	svc, repo, deviceSvc, _ := newTestService()
+	riskSvc := new(MockRiskService)
+	svc.riskService = riskSvc
+	ctx := context.Background()
+	ticket := createTestTicket(t, svc)
+
+	_, err := svc.ScanTicket(ctx, ticket.ID, "user-123")
+	require.NoError(t, err)
+
+	riskSvc.On("EvaluateLogin", mock.Anything, mock.Anything).Return(&riskservice.Assessment{
+		Decision: &domain.LoginRiskDecision{Action: domain.LoginRiskActionAllow},
+	}, nil)
+	deviceSvc.On("RegisterDevice", mock.Anything, mock.Anything).Return(&domain.Device{ID: "device-uuid-1"}, nil)
+
+	require.NoError(t, svc.ConfirmTicket(ctx, ticket.ID, "user-123"))
+
+	stored, err := repo.Get(ctx, ticket.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.QRLoginStatusConfirmed, stored.Status)
+	assert.Equal(t, "device-uuid-1", stored.RegisteredDeviceID)
+}
+
+// TestConfirmTicket_ConfirmFailureRemovesNewDevice 测试确认失败时删除本次新建的设备，不留下无法登录的受信设备
+func TestConfirmTicket_ConfirmFailureRemovesNewDevice(t *testing.T) {
+	svc, repo, deviceSvc, _ := newTestService()
+	ctx := context.Background()
+	ticket := createTestTicket(t, svc)
+
+	_, err := svc.ScanTicket(ctx, ticket.ID, "user-123")
+	require.NoError(t, err)
+
+	// 注册设备期间票据过期被淘汰
+	now := time.Now()
+	deviceSvc.On("RegisterDevice", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		_, _ = repo.Delete(ctx, ticket.ID)
+	}).Return(&domain.Device{ID: "device-new", CreatedAt: now, LastLoginAt: now}, nil)
+	deviceSvc.On("RemoveDevice", mock.Anything, "user-123", "device-new").Return(nil)
+
+	err = svc.ConfirmTicket(ctx, ticket.ID, "user-123")
+	assert.ErrorIs(t, err, domain.ErrQRTicketNotFound)
+	deviceSvc.AssertCalled(t, "RemoveDevice", mock.Anything, "user-123", "device-new")
+}
+
+// TestConfirmTicket_ConfirmFailureKeepsExistingDevice 测试确认失败时不删除已存在的设备
+func TestConfirmTicket_ConfirmFailureKeepsExistingDevice(t *testing.T) {
+	svc, repo, deviceSvc, _ := newTestService()
+	ctx := context.Background()
+	ticket := createTestTicket(t, svc)
+
+	_, err := svc.ScanTicket(ctx, ticket.ID, "user-123")
+	require.NoError(t, err)
+
+	createdAt := time.Now().Add(-30 * 24 * time.Hour)
+	deviceSvc.On("RegisterDevice", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		_, _ = repo.Delete(ctx, ticket.ID)
+	}).Return(&domain.Device{ID: "device-old", CreatedAt: createdAt, LastLoginAt: time.Now()}, nil)
+
+	err = svc.ConfirmTicket(ctx, ticket.ID, "user-123")
+	assert.ErrorIs(t, err, domain.ErrQRTicketNotFound)
+	deviceSvc.AssertNotCalled(t, "RemoveDevice", mock.Anything, mock.Anything, mock.Anything)
+}
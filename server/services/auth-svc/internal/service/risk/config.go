@@ -0,0 +1,78 @@
+package risk
+
+import "time"
+
+// Config 登录风控配置
+// 各策略命中的分数累加，达到StepUpThreshold要求二次验证，达到BlockThreshold拒绝登录
+type Config struct {
+	// StepUpThreshold 二次验证阈值
+	StepUpThreshold int `json:"step_up_threshold"`
+	// BlockThreshold 拒绝登录阈值
+	BlockThreshold int `json:"block_threshold"`
+	// HistoryWindow 登录历史回溯时间
+	HistoryWindow time.Duration `json:"history_window"`
+	// HistoryLimit 登录历史最多条数
+	HistoryLimit int `json:"history_limit"`
+
+	NewDevice        NewDeviceConfig        `json:"new_device"`
+	ImpossibleTravel ImpossibleTravelConfig `json:"impossible_travel"`
+	Velocity         VelocityConfig         `json:"velocity"`
+}
+
+// NewDeviceConfig 新设备策略配置
+type NewDeviceConfig struct {
+	Enabled     bool `json:"enabled"`
+	Score       int  `json:"score"`         // 新设备指纹
+	NewGeoScore int  `json:"new_geo_score"` // 新设备指纹 + 新国家/地区
+}
+
+// ImpossibleTravelConfig 不可能的旅行策略配置
+type ImpossibleTravelConfig struct {
+	Enabled       bool    `json:"enabled"`
+	MaxSpeedKmh   float64 `json:"max_speed_kmh"`   // 允许的最大移动速度
+	MinDistanceKm float64 `json:"min_distance_km"` // 小于该距离不判断（IP库精度）
+	Score         int     `json:"score"`
+}
+
+// VelocityConfig 登录频率策略配置
+type VelocityConfig struct {
+	Enabled        bool          `json:"enabled"`
+	Window         time.Duration `json:"window"`
+	MaxAttempts    int           `json:"max_attempts"`     // 窗口内最大登录次数
+	MaxDistinctIPs int           `json:"max_distinct_ips"` // 窗口内最大不同IP数
+	Score          int           `json:"score"`
+}
+
+// NewConfig 创建默认配置
+//
+// 默认组合：
+//   - 新设备：放行（20分）
+//   - 新设备 + 新国家/地区：二次验证（60分）
+//   - 不可能的旅行：二次验证（70分），叠加新设备新地区则拒绝
+//   - 频率异常：二次验证（50分），叠加新设备新地区则拒绝
+func NewConfig() *Config {
+	return &Config{
+		StepUpThreshold: 50,
+		BlockThreshold:  100,
+		HistoryWindow:   90 * 24 * time.Hour,
+		HistoryLimit:    20,
+		NewDevice: NewDeviceConfig{
+			Enabled:     true,
+			Score:       20,
+			NewGeoScore: 60,
+		},
+		ImpossibleTravel: ImpossibleTravelConfig{
+			Enabled:       true,
+			MaxSpeedKmh:   900,
+			MinDistanceKm: 300,
+			Score:         70,
+		},
+		Velocity: VelocityConfig{
+			Enabled:        true,
+			Window:         time.Hour,
+			MaxAttempts:    10,
+			MaxDistinctIPs: 5,
+			Score:          50,
+		},
+	}
+}
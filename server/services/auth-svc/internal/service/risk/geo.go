@@ -0,0 +1,153 @@
+package risk
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// earthRadiusKm 地球平均半径（公里）
+const earthRadiusKm = 6371.0
+
+// GeoLocation IP地理位置
+type GeoLocation struct {
+	Country   string
+	City      string
+	Latitude  float64
+	Longitude float64
+}
+
+// GeoResolver IP地理位置解析接口
+type GeoResolver interface {
+	// Resolve 解析IP地理位置，无法解析时返回nil
+	Resolve(ip string) *GeoLocation
+}
+
+// noopGeoResolver 不解析地理位置（地理相关策略不会命中）
+type noopGeoResolver struct{}
+
+// NewNoopGeoResolver 创建空地理位置解析器
+func NewNoopGeoResolver() GeoResolver {
+	return noopGeoResolver{}
+}
+
+// Resolve 始终返回nil
+func (noopGeoResolver) Resolve(string) *GeoLocation {
+	return nil
+}
+
+// GeoEntry CIDR地理位置表项
+type GeoEntry struct {
+	CIDR     string
+	Location GeoLocation
+}
+
+// staticGeoResolver 基于CIDR表的地理位置解析器
+type staticGeoResolver struct {
+	networks  []*net.IPNet
+	locations []GeoLocation
+}
+
+// NewStaticGeoResolver 根据CIDR表创建地理位置解析器
+// 按表顺序匹配，更精确的网段应放在前面
+func NewStaticGeoResolver(entries []GeoEntry) (GeoResolver, error) {
+	r := &staticGeoResolver{}
+	for _, entry := range entries {
+		_, network, err := net.ParseCIDR(entry.CIDR)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q: %w", entry.CIDR, err)
+		}
+		r.networks = append(r.networks, network)
+		r.locations = append(r.locations, entry.Location)
+	}
+	return r, nil
+}
+
+// LoadGeoTable 从CSV文件加载CIDR地理位置表
+// 每行格式：cidr,country,city,latitude,longitude（#开头为注释）
+func LoadGeoTable(path string) (GeoResolver, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open geo table: %w", err)
+	}
+	defer f.Close()
+
+	return parseGeoTable(f)
+}
+
+// parseGeoTable 解析CSV格式的CIDR地理位置表
+func parseGeoTable(r io.Reader) (GeoResolver, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 5
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("read geo table: %w", err)
+	}
+
+	entries := make([]GeoEntry, 0, len(records))
+	for i, record := range records {
+		lat, err := strconv.ParseFloat(record[3], 64)
+		if err != nil {
+			return nil, fmt.Errorf("geo table line %d: invalid latitude: %w", i+1, err)
+		}
+		lon, err := strconv.ParseFloat(record[4], 64)
+		if err != nil {
+			return nil, fmt.Errorf("geo table line %d: invalid longitude: %w", i+1, err)
+		}
+		entries = append(entries, GeoEntry{
+			CIDR: record[0],
+			Location: GeoLocation{
+				Country:   record[1],
+				City:      record[2],
+				Latitude:  lat,
+				Longitude: lon,
+			},
+		})
+	}
+
+	return NewStaticGeoResolver(entries)
+}
+
+// Resolve 解析IP地理位置
+func (r *staticGeoResolver) Resolve(ip string) *GeoLocation {
+	parsed := parseClientIP(ip)
+	if parsed == nil {
+		return nil
+	}
+	for i, network := range r.networks {
+		if network.Contains(parsed) {
+			loc := r.locations[i]
+			return &loc
+		}
+	}
+	return nil
+}
+
+// parseClientIP 解析客户端IP
+// 兼容X-Forwarded-For（取第一个）和RemoteAddr（带端口）格式
+func parseClientIP(ip string) net.IP {
+	ip = strings.TrimSpace(strings.Split(ip, ",")[0])
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	return net.ParseIP(ip)
+}
+
+// distanceKm 计算两点间的球面距离（Haversine公式）
+func distanceKm(lat1, lon1, lat2, lon2 float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRad(lat2 - lat1)
+	dLon := toRad(lon2 - lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
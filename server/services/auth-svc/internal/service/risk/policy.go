@@ -0,0 +1,138 @@
+package risk
+
+import (
+	"time"
+
+	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/domain"
+)
+
+// 策略命中原因（记录在风控决策中）
+const (
+	ReasonNewDevice        = "new_device"
+	ReasonNewDeviceNewGeo  = "new_device_new_geo"
+	ReasonImpossibleTravel = "impossible_travel"
+	ReasonVelocity         = "velocity"
+)
+
+// LoginAttempt 待评估的登录尝试
+type LoginAttempt struct {
+	UserID      string
+	Fingerprint string
+	ClientIP    string
+	Location    *GeoLocation // 无法解析时为nil
+	At          time.Time
+}
+
+// LoginHistory 用户登录历史（评估依据）
+type LoginHistory struct {
+	HasDevices   bool                        // 用户是否已有注册设备（首次登录不做设备类判断）
+	KnownDevice  bool                        // 本次设备指纹是否已注册
+	RecentLogins []*domain.LoginRiskDecision // 最近的成功登录（按时间倒序）
+	Attempts     int64                       // 频率窗口内的登录尝试次数（含本次）
+	DistinctIPs  int64                       // 频率窗口内的不同IP数（含本次）
+}
+
+// PolicyResult 策略命中结果
+type PolicyResult struct {
+	Reason string
+	Score  int
+}
+
+// Policy 登录风控策略
+type Policy interface {
+	// Evaluate 评估登录尝试，未命中时返回nil
+	Evaluate(attempt *LoginAttempt, history *LoginHistory) *PolicyResult
+}
+
+// NewPolicies 根据配置创建启用的策略
+func NewPolicies(cfg *Config) []Policy {
+	var policies []Policy
+	if cfg.NewDevice.Enabled {
+		policies = append(policies, &newDevicePolicy{cfg: cfg.NewDevice})
+	}
+	if cfg.ImpossibleTravel.Enabled {
+		policies = append(policies, &impossibleTravelPolicy{cfg: cfg.ImpossibleTravel})
+	}
+	if cfg.Velocity.Enabled {
+		policies = append(policies, &velocityPolicy{cfg: cfg.Velocity})
+	}
+	return policies
+}
+
+// newDevicePolicy 新设备策略
+// 新设备指纹本身风险较低；新设备且来自从未登录过的国家/地区则风险较高
+type newDevicePolicy struct {
+	cfg NewDeviceConfig
+}
+
+// Evaluate 评估新设备
+func (p *newDevicePolicy) Evaluate(attempt *LoginAttempt, history *LoginHistory) *PolicyResult {
+	if !history.HasDevices || history.KnownDevice {
+		return nil
+	}
+
+	if attempt.Location != nil && attempt.Location.Country != "" && !hasCountry(history.RecentLogins, attempt.Location.Country) {
+		return &PolicyResult{Reason: ReasonNewDeviceNewGeo, Score: p.cfg.NewGeoScore}
+	}
+	return &PolicyResult{Reason: ReasonNewDevice, Score: p.cfg.Score}
+}
+
+// hasCountry 历史登录中是否出现过该国家/地区
+func hasCountry(logins []*domain.LoginRiskDecision, country string) bool {
+	for _, login := range logins {
+		if login.Country == country {
+			return true
+		}
+	}
+	return false
+}
+
+// impossibleTravelPolicy 不可能的旅行策略
+// 与上次成功登录相比，移动速度超过阈值（如1小时内从北京到纽约）
+type impossibleTravelPolicy struct {
+	cfg ImpossibleTravelConfig
+}
+
+// Evaluate 评估移动速度
+func (p *impossibleTravelPolicy) Evaluate(attempt *LoginAttempt, history *LoginHistory) *PolicyResult {
+	if attempt.Location == nil {
+		return nil
+	}
+
+	var last *domain.LoginRiskDecision
+	for _, login := range history.RecentLogins {
+		if login.HasLocation() {
+			last = login
+			break
+		}
+	}
+	if last == nil {
+		return nil
+	}
+
+	distance := distanceKm(*last.Latitude, *last.Longitude, attempt.Location.Latitude, attempt.Location.Longitude)
+	// 忽略同城/IP库精度范围内的距离
+	if distance < p.cfg.MinDistanceKm {
+		return nil
+	}
+
+	elapsed := attempt.At.Sub(last.CreatedAt).Hours()
+	if elapsed <= 0 || distance/elapsed > p.cfg.MaxSpeedKmh {
+		return &PolicyResult{Reason: ReasonImpossibleTravel, Score: p.cfg.Score}
+	}
+	return nil
+}
+
+// velocityPolicy 登录频率策略
+// 短时间内登录次数过多或使用过多不同IP（撞库、代理池）
+type velocityPolicy struct {
+	cfg VelocityConfig
+}
+
+// Evaluate 评估登录频率
+func (p *velocityPolicy) Evaluate(_ *LoginAttempt, history *LoginHistory) *PolicyResult {
+	if history.Attempts > int64(p.cfg.MaxAttempts) || history.DistinctIPs > int64(p.cfg.MaxDistinctIPs) {
+		return &PolicyResult{Reason: ReasonVelocity, Score: p.cfg.Score}
+	}
+	return nil
+}
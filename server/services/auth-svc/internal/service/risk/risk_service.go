@@ -0,0 +1,413 @@
+package risk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/domain"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/repository"
+	deviceservice "github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/service/device"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/syncevent"
+)
+
+// RiskService 登录风控服务接口
+//
+// 流程：
+// 1. 登录时调用EvaluateLogin，按策略打分并记录决策
+// 2. 需要二次验证时创建挑战（并发送短信验证码），客户端调用VerifyStepUp完成验证后再签发Token
+// 3. 二次验证或拒绝登录时通过sync-svc通知用户的其他在线设备
+type RiskService interface {
+	// EvaluateLogin 评估登录风险
+	EvaluateLogin(ctx context.Context, req *EvaluateLoginRequest) (*Assessment, error)
+
+	// ResendStepUpCode 重新发送二次验证短信
+	ResendStepUpCode(ctx context.Context, challengeID string) (*domain.StepUpChallenge, error)
+
+	// VerifyStepUp 校验二次验证，通过后返回挑战（含待完成登录的设备信息）
+	VerifyStepUp(ctx context.Context, challengeID string, method domain.StepUpMethod, code string) (*domain.StepUpChallenge, error)
+}
+
+// CodeSender 二次验证短信发送接口（由sms.Service实现）
+type CodeSender interface {
+	SendStepUpCode(ctx context.Context, phone, code string) error
+}
+
+// DeviceVerifier 设备校验接口（由device.DeviceService实现，与Token校验共用设备指纹判断）
+type DeviceVerifier interface {
+	VerifyDevice(ctx context.Context, req *deviceservice.VerifyDeviceRequest) (*deviceservice.DeviceVerificationResult, error)
+}
+
+// TOTPVerifier TOTP校验接口（由totp.TOTPService实现，用户绑定了身份验证器时作为二次验证方式）
+type TOTPVerifier interface {
+	// IsEnrolled 用户是否已绑定TOTP
+	IsEnrolled(ctx context.Context, userID string) (bool, error)
+	// Verify 校验TOTP验证码
+	Verify(ctx context.Context, userID, code string) (bool, error)
+}
+
+// EvaluateLoginRequest 登录风险评估请求
+type EvaluateLoginRequest struct {
+	User       *domain.User
+	DeviceName string
+	DeviceID   string
+	Platform   string
+	OSVersion  string
+	AppVersion string
+	ClientIP   string
+
+	// StepUpUnavailable 登录方式无法完成二次验证（如扫码登录），需要二次验证时直接拒绝
+	StepUpUnavailable bool
+}
+
+// Assessment 登录风险评估结果
+type Assessment struct {
+	Decision  *domain.LoginRiskDecision
+	Challenge *domain.StepUpChallenge // 仅Action为step_up时设置
+}
+
+// Action 决策动作
+func (a *Assessment) Action() domain.LoginRiskAction {
+	return a.Decision.Action
+}
+
+// riskService 登录风控服务实现
+type riskService struct {
+	config        *Config
+	policies      []Policy
+	decisionRepo  repository.LoginRiskRepository
+	challengeRepo repository.StepUpChallengeRepository
+	deviceRepo    repository.DeviceRepository
+	devices       DeviceVerifier
+	geo           GeoResolver
+	codeSender    CodeSender
+	totp          TOTPVerifier
+	notifier      syncevent.Publisher
+	now           func() time.Time
+}
+
+// NewRiskService 创建登录风控服务
+// totp可为nil（仅支持短信二次验证）
+func NewRiskService(
+	config *Config,
+	decisionRepo repository.LoginRiskRepository,
+	challengeRepo repository.StepUpChallengeRepository,
+	deviceRepo repository.DeviceRepository,
+	devices DeviceVerifier,
+	geo GeoResolver,
+	codeSender CodeSender,
+	totp TOTPVerifier,
+	notifier syncevent.Publisher,
+) RiskService {
+	if config == nil {
+		config = NewConfig()
+	}
+	if geo == nil {
+		geo = NewNoopGeoResolver()
+	}
+	if notifier == nil {
+		notifier = syncevent.NoopPublisher{}
+	}
+	return &riskService{
+		config:        config,
+		policies:      NewPolicies(config),
+		decisionRepo:  decisionRepo,
+		challengeRepo: challengeRepo,
+		deviceRepo:    deviceRepo,
+		devices:       devices,
+		geo:           geo,
+		codeSender:    codeSender,
+		totp:          totp,
+		notifier:      notifier,
+		now:           time.Now,
+	}
+}
+
+// EvaluateLogin 评估登录风险
+func (s *riskService) EvaluateLogin(ctx context.Context, req *EvaluateLoginRequest) (*Assessment, error) {
+	now := s.now()
+	fingerprint := domain.GenerateFingerprint(req.DeviceName, req.Platform, req.DeviceID, req.OSVersion)
+
+	// 1. 构建登录历史
+	history, err := s.loadHistory(ctx, req, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 执行策略
+	decision := domain.NewLoginRiskDecision(req.User.ID, req.DeviceID, fingerprint, req.ClientIP)
+	decision.CreatedAt = now
+
+	attempt := &LoginAttempt{
+		UserID:      req.User.ID,
+		Fingerprint: fingerprint,
+		ClientIP:    req.ClientIP,
+		Location:    s.geo.Resolve(req.ClientIP),
+		At:          now,
+	}
+	if attempt.Location != nil {
+		decision.Country = attempt.Location.Country
+		decision.City = attempt.Location.City
+		lat, lon := attempt.Location.Latitude, attempt.Location.Longitude
+		decision.Latitude = &lat
+		decision.Longitude = &lon
+	}
+
+	for _, policy := range s.policies {
+		if result := policy.Evaluate(attempt, history); result != nil {
+			decision.Score += result.Score
+			decision.Reasons = append(decision.Reasons, result.Reason)
+		}
+	}
+
+	// 3. 根据分数决策
+	assessment := &Assessment{Decision: decision}
+	switch {
+	case decision.Score >= s.config.BlockThreshold:
+		decision.Action = domain.LoginRiskActionBlock
+		decision.Outcome = domain.LoginRiskOutcomeBlocked
+		decision.ResolvedAt = &now
+	case decision.Score >= s.config.StepUpThreshold && req.StepUpUnavailable:
+		decision.Action = domain.LoginRiskActionBlock
+		decision.Outcome = domain.LoginRiskOutcomeBlocked
+		decision.ResolvedAt = &now
+	case decision.Score >= s.config.StepUpThreshold:
+		decision.Action = domain.LoginRiskActionStepUp
+		decision.Outcome = domain.LoginRiskOutcomeStepUpPending
+	default:
+		decision.ResolvedAt = &now
+	}
+
+	// 4. 记录决策
+	if err := s.decisionRepo.Create(ctx, decision); err != nil {
+		return nil, fmt.Errorf("save risk decision: %w", err)
+	}
+
+	// 5. 需要二次验证时创建挑战
+	if decision.Action == domain.LoginRiskActionStepUp {
+		challenge, err := s.startStepUp(ctx, req, decision)
+		if err != nil {
+			return nil, err
+		}
+		assessment.Challenge = challenge
+	}
+
+	// 6. 通知其他设备
+	if decision.Action != domain.LoginRiskActionAllow {
+		s.notify(ctx, decision, req.DeviceName, req.Platform, decision.Outcome)
+	}
+
+	return assessment, nil
+}
+
+// loadHistory 构建登录历史
+func (s *riskService) loadHistory(ctx context.Context, req *EvaluateLoginRequest, now time.Time) (*LoginHistory, error) {
+	userID := req.User.ID
+	history := &LoginHistory{}
+
+	deviceCount, err := s.deviceRepo.CountByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("count devices: %w", err)
+	}
+	history.HasDevices = deviceCount > 0
+
+	if history.HasDevices {
+		// 已注册且指纹未变化的设备才算已知设备（指纹不一致返回ErrFingerprintMismatch，按新设备处理）
+		result, err := s.devices.VerifyDevice(ctx, &deviceservice.VerifyDeviceRequest{
+			UserID:     userID,
+			DeviceID:   req.DeviceID,
+			DeviceName: req.DeviceName,
+			Platform:   req.Platform,
+			OSVersion:  req.OSVersion,
+			ClientIP:   req.ClientIP,
+		})
+		if err != nil && !errors.Is(err, domain.ErrFingerprintMismatch) {
+			return nil, fmt.Errorf("verify device: %w", err)
+		}
+		history.KnownDevice = result != nil && result.IsValid
+	}
+
+	history.RecentLogins, err = s.decisionRepo.ListSuccessful(ctx, userID, now.Add(-s.config.HistoryWindow), s.config.HistoryLimit)
+	if err != nil {
+		return nil, fmt.Errorf("list recent logins: %w", err)
+	}
+
+	attempts, distinctIPs, seenIP, err := s.decisionRepo.CountSince(ctx, userID, req.ClientIP, now.Add(-s.config.Velocity.Window))
+	if err != nil {
+		return nil, fmt.Errorf("count recent attempts: %w", err)
+	}
+	// 计入本次尝试，本次IP已在窗口内出现过时不重复计数
+	history.Attempts = attempts + 1
+	history.DistinctIPs = distinctIPs
+	if !seenIP {
+		history.DistinctIPs++
+	}
+
+	return history, nil
+}
+
+// startStepUp 创建二次验证挑战
+func (s *riskService) startStepUp(ctx context.Context, req *EvaluateLoginRequest, decision *domain.LoginRiskDecision) (*domain.StepUpChallenge, error) {
+	methods, err := s.availableMethods(ctx, req.User)
+	if err != nil {
+		return nil, err
+	}
+
+	challenge := domain.NewStepUpChallenge(req.User.ID, decision.ID, req.User.Phone, methods)
+	challenge.DeviceName = req.DeviceName
+	challenge.DeviceID = req.DeviceID
+	challenge.Platform = req.Platform
+	challenge.OSVersion = req.OSVersion
+	challenge.AppVersion = req.AppVersion
+	challenge.ClientIP = req.ClientIP
+
+	var code string
+	if challenge.SupportsMethod(domain.StepUpMethodSMS) {
+		code, err = challenge.IssueSMSCode()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.challengeRepo.Create(ctx, challenge); err != nil {
+		return nil, fmt.Errorf("save step-up challenge: %w", err)
+	}
+
+	if code != "" {
+		if err := s.codeSender.SendStepUpCode(ctx, challenge.Phone, code); err != nil {
+			return nil, err
+		}
+	}
+
+	return challenge, nil
+}
+
+// availableMethods 用户可用的二次验证方式
+// 已绑定TOTP的用户只能用TOTP验证：登录验证码已发送到同一手机号，再发短信不是独立的第二因素
+// 未绑定TOTP时退回短信验证（只能防御验证码被截获后在其他设备使用，不能防御手机号被劫持）
+func (s *riskService) availableMethods(ctx context.Context, user *domain.User) ([]domain.StepUpMethod, error) {
+	if s.totp != nil {
+		enrolled, err := s.totp.IsEnrolled(ctx, user.ID)
+		if err != nil {
+			return nil, fmt.Errorf("check TOTP enrollment: %w", err)
+		}
+		if enrolled {
+			return []domain.StepUpMethod{domain.StepUpMethodTOTP}, nil
+		}
+	}
+
+	var methods []domain.StepUpMethod
+	if s.codeSender != nil && user.Phone != "" {
+		methods = append(methods, domain.StepUpMethodSMS)
+	}
+	if len(methods) == 0 {
+		return nil, domain.ErrStepUpMethodUnavailable
+	}
+	return methods, nil
+}
+
+// ResendStepUpCode 重新发送二次验证短信
+func (s *riskService) ResendStepUpCode(ctx context.Context, challengeID string) (*domain.StepUpChallenge, error) {
+	var code string
+	challenge, err := s.challengeRepo.Update(ctx, challengeID, func(c *domain.StepUpChallenge) (bool, error) {
+		if c.IsExpired() {
+			return false, domain.ErrStepUpChallengeExpired
+		}
+		var err error
+		code, err = c.IssueSMSCode()
+		return false, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.codeSender.SendStepUpCode(ctx, challenge.Phone, code); err != nil {
+		return nil, err
+	}
+	return challenge, nil
+}
+
+// VerifyStepUp 校验二次验证
+func (s *riskService) VerifyStepUp(ctx context.Context, challengeID string, method domain.StepUpMethod, code string) (*domain.StepUpChallenge, error) {
+	challenge, err := s.challengeRepo.Get(ctx, challengeID)
+	if err != nil {
+		return nil, err
+	}
+	if challenge.IsExpired() {
+		return nil, domain.ErrStepUpChallengeExpired
+	}
+	if !challenge.SupportsMethod(method) {
+		return nil, domain.ErrStepUpMethodUnavailable
+	}
+
+	// 1. 校验验证码
+	var valid bool
+	switch method {
+	case domain.StepUpMethodSMS:
+		valid = challenge.VerifySMSCode(code)
+	case domain.StepUpMethodTOTP:
+		valid, err = s.totp.Verify(ctx, challenge.UserID, code)
+		if errors.Is(err, domain.ErrTOTPNotEnrolled) {
+			// 挑战创建后用户解除了绑定
+			return nil, domain.ErrStepUpMethodUnavailable
+		}
+		if err != nil {
+			return nil, fmt.Errorf("verify TOTP: %w", err)
+		}
+	}
+
+	// 2. 校验失败：记录失败次数，耗尽后作废挑战
+	if !valid {
+		_, err := s.challengeRepo.Update(ctx, challengeID, func(c *domain.StepUpChallenge) (bool, error) {
+			return true, c.RecordFailure()
+		})
+		if errors.Is(err, domain.ErrStepUpTooManyAttempts) {
+			_ = s.challengeRepo.Delete(ctx, challengeID)
+			s.resolve(ctx, challenge, domain.LoginRiskOutcomeStepUpFailed)
+			return nil, err
+		}
+		if err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrStepUpInvalidCode
+	}
+
+	// 3. 校验通过：挑战只能使用一次（并发校验时只有一个删除成功）
+	if err := s.challengeRepo.Delete(ctx, challengeID); err != nil {
+		return nil, err
+	}
+	s.resolve(ctx, challenge, domain.LoginRiskOutcomeStepUpPassed)
+
+	return challenge, nil
+}
+
+// resolve 记录二次验证结果并通知其他设备
+func (s *riskService) resolve(ctx context.Context, challenge *domain.StepUpChallenge, outcome domain.LoginRiskOutcome) {
+	// 结果记录失败不影响登录流程（决策仍停留在step_up_pending）
+	_ = s.decisionRepo.UpdateOutcome(ctx, challenge.DecisionID, outcome, s.now())
+
+	decision, err := s.decisionRepo.GetByID(ctx, challenge.DecisionID)
+	if err != nil {
+		return
+	}
+	s.notify(ctx, decision, challenge.DeviceName, challenge.Platform, outcome)
+}
+
+// notify 通过sync-svc通知用户的其他在线设备
+func (s *riskService) notify(ctx context.Context, decision *domain.LoginRiskDecision, deviceName, platform string, outcome domain.LoginRiskOutcome) {
+	data := map[string]interface{}{
+		"decision_id": decision.ID,
+		"action":      string(decision.Action),
+		"outcome":     string(outcome),
+		"reasons":     decision.Reasons,
+		"client_ip":   decision.ClientIP,
+		"country":     decision.Country,
+		"city":        decision.City,
+		"device_name": deviceName,
+		"platform":    platform,
+		"occurred_at": decision.CreatedAt.Unix(),
+	}
+	// 通知是尽力而为的，失败不影响登录结果
+	_ = s.notifier.Publish(ctx, decision.UserID, syncevent.TypeSecurityLoginAlert, data)
+}
@@ -0,0 +1,557 @@
+package risk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/domain"
+	deviceservice "github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/service/device"
+)
+
+// memoryDecisionRepository 内存风控决策仓储（用于测试）
+type memoryDecisionRepository struct {
+	mu        sync.Mutex
+	decisions map[string]*domain.LoginRiskDecision
+}
+
+func newMemoryDecisionRepository() *memoryDecisionRepository {
+	return &memoryDecisionRepository{decisions: make(map[string]*domain.LoginRiskDecision)}
+}
+
+func (r *memoryDecisionRepository) Create(ctx context.Context, d *domain.LoginRiskDecision) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	copied := *d
+	r.decisions[d.ID] = &copied
+	return nil
+}
+
+func (r *memoryDecisionRepository) GetByID(ctx context.Context, id string) (*domain.LoginRiskDecision, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	d, ok := r.decisions[id]
+	if !ok {
+		return nil, domain.ErrLoginRiskDecisionNotFound
+	}
+	copied := *d
+	return &copied, nil
+}
+
+func (r *memoryDecisionRepository) ListSuccessful(ctx context.Context, userID string, since time.Time, limit int) ([]*domain.LoginRiskDecision, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var result []*domain.LoginRiskDecision
+	for _, d := range r.decisions {
+		if d.UserID == userID && d.Outcome.IsSuccessful() && !d.CreatedAt.Before(since) {
+			copied := *d
+			result = append(result, &copied)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.After(result[j].CreatedAt) })
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (r *memoryDecisionRepository) ListByUser(ctx context.Context, userID string, limit int) ([]*domain.LoginRiskDecision, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var result []*domain.LoginRiskDecision
+	for _, d := range r.decisions {
+		if d.UserID == userID {
+			copied := *d
+			result = append(result, &copied)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.After(result[j].CreatedAt) })
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (r *memoryDecisionRepository) CountSince(ctx context.Context, userID, clientIP string, since time.Time) (int64, int64, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var attempts int64
+	ips := make(map[string]struct{})
+	for _, d := range r.decisions {
+		if d.UserID == userID && !d.CreatedAt.Before(since) {
+			attempts++
+			ips[d.ClientIP] = struct{}{}
+		}
+	}
+	_, seen := ips[clientIP]
+	return attempts, int64(len(ips)), seen, nil
+}
+
+func (r *memoryDecisionRepository) UpdateOutcome(ctx context.Context, id string, outcome domain.LoginRiskOutcome, resolvedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	d, ok := r.decisions[id]
+	if !ok {
+		return domain.ErrLoginRiskDecisionNotFound
+	}
+	d.Outcome = outcome
+	d.ResolvedAt = &resolvedAt
+	return nil
+}
+
+// add 直接写入历史登录记录
+func (r *memoryDecisionRepository) add(userID, ip, country string, lat, lon float64, at time.Time) {
+	d := domain.NewLoginRiskDecision(userID, "device-1", "fp", ip)
+	d.Country = country
+	d.Latitude = &lat
+	d.Longitude = &lon
+	d.CreatedAt = at
+	_ = r.Create(context.Background(), d)
+}
+
+// memoryChallengeRepository 内存二次验证挑战仓储（用于测试）
+type memoryChallengeRepository struct {
+	mu         sync.Mutex
+	challenges map[string][]byte
+}
+
+func newMemoryChallengeRepository() *memoryChallengeRepository {
+	return &memoryChallengeRepository{challenges: make(map[string][]byte)}
+}
+
+func (r *memoryChallengeRepository) Create(ctx context.Context, c *domain.StepUpChallenge) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.challenges[c.ID], _ = json.Marshal(c)
+	return nil
+}
+
+func (r *memoryChallengeRepository) Get(ctx context.Context, id string) (*domain.StepUpChallenge, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	data, ok := r.challenges[id]
+	if !ok {
+		return nil, domain.ErrStepUpChallengeNotFound
+	}
+	var c domain.StepUpChallenge
+	_ = json.Unmarshal(data, &c)
+	return &c, nil
+}
+
+func (r *memoryChallengeRepository) Update(ctx context.Context, id string, fn func(c *domain.StepUpChallenge) (bool, error)) (*domain.StepUpChallenge, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	data, ok := r.challenges[id]
+	if !ok {
+		return nil, domain.ErrStepUpChallengeNotFound
+	}
+	var c domain.StepUpChallenge
+	_ = json.Unmarshal(data, &c)
+	keep, err := fn(&c)
+	if err != nil && !keep {
+		return nil, err
+	}
+	r.challenges[id], _ = json.Marshal(&c)
+	return &c, err
+}
+
+func (r *memoryChallengeRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.challenges[id]; !ok {
+		return domain.ErrStepUpChallengeNotFound
+	}
+	delete(r.challenges, id)
+	return nil
+}
+
+// fakeDeviceRepository 仅实现风控（及设备校验）用到的查询
+type fakeDeviceRepository struct {
+	fingerprints map[string]bool
+}
+
+func (r *fakeDeviceRepository) Create(ctx context.Context, device *domain.Device) error { return nil }
+func (r *fakeDeviceRepository) GetByID(ctx context.Context, id string) (*domain.Device, error) {
+	return nil, domain.ErrDeviceNotFound
+}
+func (r *fakeDeviceRepository) GetByFingerprint(ctx context.Context, userID, fingerprint string) (*domain.Device, error) {
+	if r.fingerprints[fingerprint] {
+		return &domain.Device{UserID: userID, Fingerprint: fingerprint}, nil
+	}
+	return nil, domain.ErrDeviceNotFound
+}
+func (r *fakeDeviceRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.Device, error) {
+	return nil, nil
+}
+func (r *fakeDeviceRepository) CountByUserID(ctx context.Context, userID string) (int64, error) {
+	return int64(len(r.fingerprints)), nil
+}
+func (r *fakeDeviceRepository) UpdateLoginInfo(ctx context.Context, id string, ip string, loginAt time.Time) error {
+	return nil
+}
+func (r *fakeDeviceRepository) Delete(ctx context.Context, id string) error             { return nil }
+func (r *fakeDeviceRepository) DeleteByUserID(ctx context.Context, userID string) error { return nil }
+func (r *fakeDeviceRepository) DeleteInactive(ctx context.Context, before time.Time) error {
+	return nil
+}
+
+// fakeCodeSender 记录发送的验证码
+type fakeCodeSender struct {
+	codes []string
+}
+
+func (s *fakeCodeSender) SendStepUpCode(ctx context.Context, phone, code string) error {
+	s.codes = append(s.codes, code)
+	return nil
+}
+
+// fakeTOTPVerifier 已绑定用户的固定验证码
+type fakeTOTPVerifier struct {
+	codes map[string]string
+}
+
+func (v *fakeTOTPVerifier) IsEnrolled(ctx context.Context, userID string) (bool, error) {
+	_, ok := v.codes[userID]
+	return ok, nil
+}
+
+func (v *fakeTOTPVerifier) Verify(ctx context.Context, userID, code string) (bool, error) {
+	expected, ok := v.codes[userID]
+	if !ok {
+		return false, domain.ErrTOTPNotEnrolled
+	}
+	return code == expected, nil
+}
+
+// fakeNotifier 记录发布的事件
+type fakeNotifier struct {
+	events []map[string]interface{}
+}
+
+func (n *fakeNotifier) Publish(ctx context.Context, userID, eventType string, data map[string]interface{}) error {
+	n.events = append(n.events, data)
+	return nil
+}
+
+type testEnv struct {
+	service    *riskService
+	decisions  *memoryDecisionRepository
+	challenges *memoryChallengeRepository
+	devices    *fakeDeviceRepository
+	sender     *fakeCodeSender
+	totp       *fakeTOTPVerifier
+	notifier   *fakeNotifier
+	user       *domain.User
+	now        time.Time
+}
+
+const testGeoTable = `
+# cidr,country,city,latitude,longitude
+1.1.0.0/16, CN, Beijing, 39.90, 116.40
+2.2.0.0/16, CN, Shanghai, 31.23, 121.47
+3.3.0.0/16, US, New York, 40.71, -74.00
+`
+
+func newTestEnv(t *testing.T) *testEnv {
+	geo, err := parseGeoTable(strings.NewReader(testGeoTable))
+	require.NoError(t, err)
+
+	env := &testEnv{
+		decisions:  newMemoryDecisionRepository(),
+		challenges: newMemoryChallengeRepository(),
+		devices:    &fakeDeviceRepository{fingerprints: map[string]bool{}},
+		sender:     &fakeCodeSender{},
+		totp:       &fakeTOTPVerifier{codes: map[string]string{}},
+		notifier:   &fakeNotifier{},
+		user:       domain.NewUser("13800138000"),
+		now:        time.Now(),
+	}
+	env.service = NewRiskService(
+		NewConfig(),
+		env.decisions,
+		env.challenges,
+		env.devices,
+		deviceservice.NewDeviceService(env.devices, nil),
+		geo,
+		env.sender,
+		env.totp,
+		env.notifier,
+	).(*riskService)
+	env.service.now = func() time.Time { return env.now }
+	return env
+}
+
+// knownDevice 注册一个已知设备并返回登录请求
+func (env *testEnv) knownDevice() *EvaluateLoginRequest {
+	req := env.request("known-device", "1.1.1.1")
+	env.devices.fingerprints[domain.GenerateFingerprint(req.DeviceName, req.Platform, req.DeviceID, req.OSVersion)] = true
+	return req
+}
+
+func (env *testEnv) request(deviceID, ip string) *EvaluateLoginRequest {
+	return &EvaluateLoginRequest{
+		User:       env.user,
+		DeviceName: "Phone " + deviceID,
+		DeviceID:   deviceID,
+		Platform:   "ios",
+		OSVersion:  "17.0",
+		AppVersion: "1.0.0",
+		ClientIP:   ip,
+	}
+}
+
+func TestEvaluateLogin_FirstLoginAllowed(t *testing.T) {
+	env := newTestEnv(t)
+
+	assessment, err := env.service.EvaluateLogin(context.Background(), env.request("device-1", "3.3.3.3"))
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.LoginRiskActionAllow, assessment.Action())
+	assert.Empty(t, assessment.Decision.Reasons)
+	assert.Nil(t, assessment.Challenge)
+	assert.Empty(t, env.notifier.events)
+}
+
+func TestEvaluateLogin_NewDeviceSameCountryAllowed(t *testing.T) {
+	env := newTestEnv(t)
+	env.knownDevice()
+	env.decisions.add(env.user.ID, "1.1.1.1", "CN", 39.90, 116.40, env.now.Add(-24*time.Hour))
+
+	assessment, err := env.service.EvaluateLogin(context.Background(), env.request("device-2", "2.2.2.2"))
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.LoginRiskActionAllow, assessment.Action())
+	assert.Equal(t, []string{ReasonNewDevice}, assessment.Decision.Reasons)
+	assert.Equal(t, "CN", assessment.Decision.Country)
+}
+
+func TestEvaluateLogin_NewDeviceNewCountryStepUp(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	env.knownDevice()
+	env.decisions.add(env.user.ID, "1.1.1.1", "CN", 39.90, 116.40, env.now.Add(-48*time.Hour))
+
+	assessment, err := env.service.EvaluateLogin(ctx, env.request("device-2", "3.3.3.3"))
+	require.NoError(t, err)
+
+	require.Equal(t, domain.LoginRiskActionStepUp, assessment.Action())
+	assert.Equal(t, []string{ReasonNewDeviceNewGeo}, assessment.Decision.Reasons)
+	require.NotNil(t, assessment.Challenge)
+	assert.Equal(t, []domain.StepUpMethod{domain.StepUpMethodSMS}, assessment.Challenge.Methods)
+	require.Len(t, env.sender.codes, 1)
+	require.Len(t, env.notifier.events, 1)
+	assert.Equal(t, string(domain.LoginRiskOutcomeStepUpPending), env.notifier.events[0]["outcome"])
+
+	// 错误验证码
+	_, err = env.service.VerifyStepUp(ctx, assessment.Challenge.ID, domain.StepUpMethodSMS, "000000x")
+	assert.ErrorIs(t, err, domain.ErrStepUpInvalidCode)
+
+	// 正确验证码
+	challenge, err := env.service.VerifyStepUp(ctx, assessment.Challenge.ID, domain.StepUpMethodSMS, env.sender.codes[0])
+	require.NoError(t, err)
+	assert.Equal(t, "device-2", challenge.DeviceID)
+
+	decision, err := env.decisions.GetByID(ctx, assessment.Decision.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.LoginRiskOutcomeStepUpPassed, decision.Outcome)
+	assert.NotNil(t, decision.ResolvedAt)
+	assert.Len(t, env.notifier.events, 2)
+
+	// 挑战只能使用一次
+	_, err = env.service.VerifyStepUp(ctx, assessment.Challenge.ID, domain.StepUpMethodSMS, env.sender.codes[0])
+	assert.ErrorIs(t, err, domain.ErrStepUpChallengeNotFound)
+}
+
+func TestEvaluateLogin_ImpossibleTravelWithNewGeoBlocked(t *testing.T) {
+	env := newTestEnv(t)
+	env.knownDevice()
+	// 1小时前在北京登录，现在从纽约的新设备登录
+	env.decisions.add(env.user.ID, "1.1.1.1", "CN", 39.90, 116.40, env.now.Add(-time.Hour))
+
+	assessment, err := env.service.EvaluateLogin(context.Background(), env.request("device-2", "3.3.3.3"))
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.LoginRiskActionBlock, assessment.Action())
+	assert.ElementsMatch(t, []string{ReasonNewDeviceNewGeo, ReasonImpossibleTravel}, assessment.Decision.Reasons)
+	assert.Equal(t, domain.LoginRiskOutcomeBlocked, assessment.Decision.Outcome)
+	assert.Nil(t, assessment.Challenge)
+	require.Len(t, env.notifier.events, 1)
+	assert.Equal(t, "block", env.notifier.events[0]["action"])
+}
+
+func TestEvaluateLogin_ImpossibleTravelKnownDeviceStepUp(t *testing.T) {
+	env := newTestEnv(t)
+	req := env.knownDevice()
+	env.decisions.add(env.user.ID, "1.1.1.1", "CN", 39.90, 116.40, env.now.Add(-time.Hour))
+
+	req.ClientIP = "3.3.3.3"
+	assessment, err := env.service.EvaluateLogin(context.Background(), req)
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.LoginRiskActionStepUp, assessment.Action())
+	assert.Equal(t, []string{ReasonImpossibleTravel}, assessment.Decision.Reasons)
+}
+
+func TestEvaluateLogin_VelocityStepUp(t *testing.T) {
+	env := newTestEnv(t)
+	req := env.knownDevice()
+	for i := 0; i < 10; i++ {
+		env.decisions.add(env.user.ID, "1.1.1.1", "CN", 39.90, 116.40, env.now.Add(-time.Duration(i+1)*time.Minute))
+	}
+
+	assessment, err := env.service.EvaluateLogin(context.Background(), req)
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.LoginRiskActionStepUp, assessment.Action())
+	assert.Equal(t, []string{ReasonVelocity}, assessment.Decision.Reasons)
+}
+
+func TestEvaluateLogin_VelocityKnownIPNotCountedTwice(t *testing.T) {
+	env := newTestEnv(t)
+	req := env.knownDevice()
+	// 窗口内已从5个不同IP登录过（达到上限但未超过），本次从其中一个IP再次登录
+	for i := 1; i <= 5; i++ {
+		env.decisions.add(env.user.ID, fmt.Sprintf("1.1.1.%d", i), "CN", 39.90, 116.40, env.now.Add(-time.Duration(i)*time.Minute))
+	}
+
+	assessment, err := env.service.EvaluateLogin(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, domain.LoginRiskActionAllow, assessment.Action())
+	assert.Empty(t, assessment.Decision.Reasons)
+
+	// 第6个IP超过上限
+	req.ClientIP = "1.1.1.6"
+	assessment, err = env.service.EvaluateLogin(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, domain.LoginRiskActionStepUp, assessment.Action())
+	assert.Equal(t, []string{ReasonVelocity}, assessment.Decision.Reasons)
+}
+
+func TestVerifyStepUp_TooManyAttempts(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	env.knownDevice()
+	env.decisions.add(env.user.ID, "1.1.1.1", "CN", 39.90, 116.40, env.now.Add(-48*time.Hour))
+
+	assessment, err := env.service.EvaluateLogin(ctx, env.request("device-2", "3.3.3.3"))
+	require.NoError(t, err)
+	require.NotNil(t, assessment.Challenge)
+
+	for i := 0; i < domain.StepUpMaxAttempts-1; i++ {
+		_, err = env.service.VerifyStepUp(ctx, assessment.Challenge.ID, domain.StepUpMethodSMS, "wrong")
+		assert.ErrorIs(t, err, domain.ErrStepUpInvalidCode)
+	}
+	_, err = env.service.VerifyStepUp(ctx, assessment.Challenge.ID, domain.StepUpMethodSMS, "wrong")
+	assert.ErrorIs(t, err, domain.ErrStepUpTooManyAttempts)
+
+	decision, err := env.decisions.GetByID(ctx, assessment.Decision.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.LoginRiskOutcomeStepUpFailed, decision.Outcome)
+
+	// 挑战已作废，正确的验证码也无法通过
+	_, err = env.service.VerifyStepUp(ctx, assessment.Challenge.ID, domain.StepUpMethodSMS, env.sender.codes[0])
+	assert.ErrorIs(t, err, domain.ErrStepUpChallengeNotFound)
+}
+
+func TestVerifyStepUp_TOTPUnavailable(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	env.knownDevice()
+	env.decisions.add(env.user.ID, "1.1.1.1", "CN", 39.90, 116.40, env.now.Add(-48*time.Hour))
+
+	assessment, err := env.service.EvaluateLogin(ctx, env.request("device-2", "3.3.3.3"))
+	require.NoError(t, err)
+
+	_, err = env.service.VerifyStepUp(ctx, assessment.Challenge.ID, domain.StepUpMethodTOTP, "123456")
+	assert.ErrorIs(t, err, domain.ErrStepUpMethodUnavailable)
+}
+
+func TestEvaluateLogin_TOTPEnrolledStepUp(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	env.knownDevice()
+	env.decisions.add(env.user.ID, "1.1.1.1", "CN", 39.90, 116.40, env.now.Add(-48*time.Hour))
+	env.totp.codes[env.user.ID] = "654321"
+
+	assessment, err := env.service.EvaluateLogin(ctx, env.request("device-2", "3.3.3.3"))
+	require.NoError(t, err)
+
+	// 已绑定TOTP时只能用TOTP验证，不再向登录手机号发送短信
+	require.NotNil(t, assessment.Challenge)
+	assert.Equal(t, []domain.StepUpMethod{domain.StepUpMethodTOTP}, assessment.Challenge.Methods)
+	assert.Empty(t, env.sender.codes)
+
+	_, err = env.service.VerifyStepUp(ctx, assessment.Challenge.ID, domain.StepUpMethodSMS, "123456")
+	assert.ErrorIs(t, err, domain.ErrStepUpMethodUnavailable)
+	_, err = env.service.ResendStepUpCode(ctx, assessment.Challenge.ID)
+	assert.ErrorIs(t, err, domain.ErrStepUpMethodUnavailable)
+
+	_, err = env.service.VerifyStepUp(ctx, assessment.Challenge.ID, domain.StepUpMethodTOTP, "000000")
+	assert.ErrorIs(t, err, domain.ErrStepUpInvalidCode)
+
+	challenge, err := env.service.VerifyStepUp(ctx, assessment.Challenge.ID, domain.StepUpMethodTOTP, "654321")
+	require.NoError(t, err)
+	assert.Equal(t, "device-2", challenge.DeviceID)
+}
+
+func TestEvaluateLogin_StepUpUnavailableBlocked(t *testing.T) {
+	env := newTestEnv(t)
+	env.knownDevice()
+	env.decisions.add(env.user.ID, "1.1.1.1", "CN", 39.90, 116.40, env.now.Add(-48*time.Hour))
+
+	req := env.request("device-2", "3.3.3.3")
+	req.StepUpUnavailable = true
+	assessment, err := env.service.EvaluateLogin(context.Background(), req)
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.LoginRiskActionBlock, assessment.Action())
+	assert.Equal(t, domain.LoginRiskOutcomeBlocked, assessment.Decision.Outcome)
+	assert.Nil(t, assessment.Challenge)
+	assert.Empty(t, env.sender.codes)
+	require.Len(t, env.notifier.events, 1)
+}
+
+func TestResendStepUpCode_RateLimited(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	env.knownDevice()
+	env.decisions.add(env.user.ID, "1.1.1.1", "CN", 39.90, 116.40, env.now.Add(-48*time.Hour))
+
+	assessment, err := env.service.EvaluateLogin(ctx, env.request("device-2", "3.3.3.3"))
+	require.NoError(t, err)
+
+	_, err = env.service.ResendStepUpCode(ctx, assessment.Challenge.ID)
+	assert.ErrorIs(t, err, domain.ErrSMSTooFrequent)
+	assert.Len(t, env.sender.codes, 1)
+}
+
+func TestStaticGeoResolver(t *testing.T) {
+	geo, err := parseGeoTable(strings.NewReader(testGeoTable))
+	require.NoError(t, err)
+
+	loc := geo.Resolve("3.3.1.1, 10.0.0.1")
+	require.NotNil(t, loc)
+	assert.Equal(t, "US", loc.Country)
+
+	loc = geo.Resolve("1.1.2.2:54321")
+	require.NotNil(t, loc)
+	assert.Equal(t, "Beijing", loc.City)
+
+	assert.Nil(t, geo.Resolve("8.8.8.8"))
+	assert.Nil(t, geo.Resolve("not-an-ip"))
+}
+
+func TestDistanceKm(t *testing.T) {
+	// 北京 - 纽约约11000公里
+	d := distanceKm(39.90, 116.40, 40.71, -74.00)
+	assert.InDelta(t, 11000, d, 200)
+	assert.InDelta(t, 0, distanceKm(39.90, 116.40, 39.90, 116.40), 0.001)
+}
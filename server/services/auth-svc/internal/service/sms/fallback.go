@@ -2,19 +2,41 @@ package sms
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 )
 
+// ErrNoProviderAttempted 所有提供商都被跳过（熔断或超出每日预算），没有实际发送
+var ErrNoProviderAttempted = errors.New("no sms provider attempted")
+
 // FallbackChain SMS Fallback链
 // 按优先级顺序尝试多个提供商，直到成功或全部失败
+// 配置了Router时按国家偏好和提供商健康状态动态排序，并跳过熔断中的提供商（未启用Fallback时仍尝试唯一的提供商）
+// 配置了ProviderGate时跳过被拒绝的提供商（如超出每日预算）
 type FallbackChain struct {
 	providers []Provider
 	enabled   bool
+	router    *Router
+	gate      ProviderGate
+}
+
+// ProviderGate 提供商发送前的准入检查
+type ProviderGate interface {
+	// AllowProvider 是否允许调用该提供商（允许时预占一次额度）
+	AllowProvider(ctx context.Context, provider string) bool
+	// ReleaseProvider 调用失败时归还预占的额度
+	ReleaseProvider(ctx context.Context, provider string)
 }
 
-// NewFallbackChain 创建Fallback链
+// NewFallbackChain 创建Fallback链（按配置顺序尝试）
 func NewFallbackChain(providers []Provider, enabled bool) *FallbackChain {
+	return NewAdaptiveFallbackChain(providers, enabled, nil)
+}
+
+// NewAdaptiveFallbackChain 创建自适应路由的Fallback链（router为nil时按配置顺序尝试）
+func NewAdaptiveFallbackChain(providers []Provider, enabled bool, router *Router) *FallbackChain {
 	// 过滤出可用的提供商
 	available := make([]Provider, 0, len(providers))
 	for _, p := range providers {
@@ -26,9 +48,15 @@ func NewFallbackChain(providers []Provider, enabled bool) *FallbackChain {
 	return &FallbackChain{
 		providers: available,
 		enabled:   enabled,
+		router:    router,
 	}
 }
 
+// SetProviderGate 设置提供商准入检查
+func (c *FallbackChain) SetProviderGate(gate ProviderGate) {
+	c.gate = gate
+}
+
 // SendResult 发送结果
 type SendResult struct {
 	Success      bool          // 是否成功
@@ -36,6 +64,15 @@ type SendResult struct {
 	Attempts     int           // 尝试次数
 	TotalLatency time.Duration // 总延迟
 	Errors       []string      // 所有错误信息
+	AttemptLog   []Attempt     // 每个提供商的调用结果（用于按提供商统计）
+}
+
+// Attempt 单个提供商的调用结果
+type Attempt struct {
+	Provider string
+	Success  bool
+	Latency  time.Duration
+	Error    string
 }
 
 // Send 发送短信（带Fallback）
@@ -52,17 +89,20 @@ func (c *FallbackChain) Send(ctx context.Context, phone, code string) (*SendResu
 
 	startTime := time.Now()
 
-	// 如果未启用Fallback，只尝试第一个提供商
+	// 按国家偏好和健康状态排序
 	providers := c.providers
+	if c.router != nil {
+		providers = c.router.Order(phone, providers)
+	}
+
+	// 如果未启用Fallback，只尝试第一个提供商
 	if !c.enabled {
-		providers = c.providers[:1]
+		providers = providers[:1]
 	}
 
 	// 按顺序尝试每个提供商
 	for i, provider := range providers {
-		result.Attempts++
-
-		// 检查context是否已取消
+		// 检查context是否已取消（在预占预算和熔断名额之前）
 		select {
 		case <-ctx.Done():
 			result.TotalLatency = time.Since(startTime)
@@ -70,11 +110,45 @@ func (c *FallbackChain) Send(ctx context.Context, phone, code string) (*SendResu
 		default:
 		}
 
+		// 先检查预算再检查熔断，避免预算跳过时占用半开状态的熔断名额
+		if c.gate != nil && !c.gate.AllowProvider(ctx, provider.Name()) {
+			result.Errors = append(result.Errors, fmt.Sprintf("[%s] skipped: daily budget exhausted", provider.Name()))
+			continue
+		}
+		// 熔断中的提供商直接跳过，不消耗重试时间
+		// 未启用Fallback时没有后备提供商，跳过只会导致发送失败，仍然尝试
+		checked := c.enabled && c.router != nil
+		if checked && !c.router.Allow(provider.Name()) {
+			if c.gate != nil {
+				c.gate.ReleaseProvider(ctx, provider.Name())
+			}
+			result.Errors = append(result.Errors, fmt.Sprintf("[%s] skipped: circuit open", provider.Name()))
+			continue
+		}
+
+		result.Attempts++
+
 		// 尝试发送
 		providerStart := time.Now()
 		err := provider.Send(ctx, phone, code)
 		providerLatency := time.Since(providerStart)
 
+		// 只有发送成功才计入提供商预算（context已取消时仍需归还）
+		if err != nil && c.gate != nil {
+			c.gate.ReleaseProvider(context.WithoutCancel(ctx), provider.Name())
+		}
+
+		// 检查是否是context错误（不计入提供商健康统计，归还熔断名额）
+		if err == context.Canceled || err == context.DeadlineExceeded {
+			if checked {
+				c.router.Release(provider.Name())
+			}
+			result.TotalLatency = time.Since(startTime)
+			return result, err
+		}
+
+		c.report(result, provider.Name(), err, providerLatency)
+
 		if err == nil {
 			// 发送成功
 			result.Success = true
@@ -83,12 +157,6 @@ func (c *FallbackChain) Send(ctx context.Context, phone, code string) (*SendResu
 			return result, nil
 		}
 
-		// 检查是否是context错误
-		if err == context.Canceled || err == context.DeadlineExceeded {
-			result.TotalLatency = time.Since(startTime)
-			return result, err
-		}
-
 		// 发送失败，记录错误
 		errMsg := fmt.Sprintf("[%s] failed in %v: %v", provider.Name(), providerLatency, err)
 		result.Errors = append(result.Errors, errMsg)
@@ -106,11 +174,43 @@ func (c *FallbackChain) Send(ctx context.Context, phone, code string) (*SendResu
 		}
 	}
 
-	// 所有提供商都失败
 	result.TotalLatency = time.Since(startTime)
+
+	// 所有提供商都被跳过
+	if result.Attempts == 0 {
+		return result, fmt.Errorf("%w: %s", ErrNoProviderAttempted, strings.Join(result.Errors, "; "))
+	}
+
+	// 所有提供商都失败
 	return result, fmt.Errorf("all sms providers failed: tried %d providers", result.Attempts)
 }
 
+// report 记录单个提供商的调用结果
+func (c *FallbackChain) report(result *SendResult, provider string, err error, latency time.Duration) {
+	attempt := Attempt{Provider: provider, Success: err == nil, Latency: latency}
+	if err != nil {
+		attempt.Error = err.Error()
+	}
+	result.AttemptLog = append(result.AttemptLog, attempt)
+
+	if c.router != nil {
+		c.router.Report(provider, err == nil, latency)
+	}
+}
+
+// GetProviderHealth 获取各提供商健康状态（按当前路由顺序，未启用自适应路由时返回nil）
+func (c *FallbackChain) GetProviderHealth() []ProviderHealth {
+	if c.router == nil {
+		return nil
+	}
+	ordered := c.router.Order("", c.providers)
+	health := make([]ProviderHealth, 0, len(ordered))
+	for _, p := range ordered {
+		health = append(health, c.router.Health(p.Name()))
+	}
+	return health
+}
+
 // GetAvailableProviders 获取可用的提供商列表
 func (c *FallbackChain) GetAvailableProviders() []string {
 	names := make([]string, 0, len(c.providers))
@@ -0,0 +1,261 @@
+package sms
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/domain"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/repository"
+)
+
+// LimitRule 单个维度的发送限制（固定窗口）
+type LimitRule struct {
+	// Window 统计窗口
+	Window time.Duration `json:"window"`
+	// ChallengeAfter 窗口内超过该次数后需要人机验证（0表示不要求）
+	ChallengeAfter int64 `json:"challenge_after"`
+	// Max 窗口内最多发送次数（0表示不限制）
+	Max int64 `json:"max"`
+}
+
+// AbuseConfig 短信防刷配置
+type AbuseConfig struct {
+	// Enabled 是否启用防刷限制
+	Enabled bool `json:"enabled"`
+	// IP 按客户端IP限制
+	IP LimitRule `json:"ip"`
+	// Device 按设备指纹限制（未提供指纹时按IP，无IP时按手机号计数）
+	Device LimitRule `json:"device"`
+	// Prefix 按号段限制（防止轮换同一号段的号码）
+	Prefix LimitRule `json:"prefix"`
+	// PrefixLength 号段长度（含国家区号的数字位数，如8613800138000取9位为861380013）
+	PrefixLength int `json:"prefix_length"`
+	// Phone 按手机号限制（60秒重发间隔之外的总量限制）
+	Phone LimitRule `json:"phone"`
+	// StepUp 按手机号限制登录二次验证短信（该流程无法完成人机验证，仅Max生效）
+	StepUp LimitRule `json:"step_up"`
+	// DefaultCountryCode 无国际区号的手机号补全的区号
+	DefaultCountryCode string `json:"default_country_code"`
+	// ProviderDailyBudget 各提供商每日发送上限（按本地日期，0或缺省表示不限制），超出后跳过该提供商
+	ProviderDailyBudget map[string]int64 `json:"provider_daily_budget"`
+}
+
+// NewAbuseConfig 创建默认防刷配置
+func NewAbuseConfig() AbuseConfig {
+	return AbuseConfig{
+		Enabled:            true,
+		IP:                 LimitRule{Window: time.Hour, ChallengeAfter: 5, Max: 20},
+		Device:             LimitRule{Window: time.Hour, ChallengeAfter: 3, Max: 10},
+		Prefix:             LimitRule{Window: time.Hour, ChallengeAfter: 30, Max: 200},
+		PrefixLength:       9,
+		Phone:              LimitRule{Window: 24 * time.Hour, ChallengeAfter: 5, Max: 10},
+		StepUp:             LimitRule{Window: time.Hour, Max: 5},
+		DefaultCountryCode: "86",
+	}
+}
+
+// SendCodeRequest 发送验证码请求
+type SendCodeRequest struct {
+	Phone             string
+	ClientIP          string
+	DeviceFingerprint string
+	CaptchaToken      string
+}
+
+// AbuseGuard 短信防刷
+// 按IP、设备指纹、号段、手机号分层计数：超过ChallengeAfter需要人机验证，超过Max直接拒绝；
+// 同时限制各提供商每日发送总量
+type AbuseGuard struct {
+	config  AbuseConfig
+	repo    repository.SMSLimitRepository
+	captcha CaptchaVerifier
+	now     func() time.Time
+}
+
+// NewAbuseGuard 创建短信防刷
+// captcha为nil时无法完成人机验证，超过ChallengeAfter即拒绝
+func NewAbuseGuard(config AbuseConfig, repo repository.SMSLimitRepository, captcha CaptchaVerifier) *AbuseGuard {
+	return &AbuseGuard{
+		config:  config,
+		repo:    repo,
+		captcha: captcha,
+		now:     time.Now,
+	}
+}
+
+// limitCheck 单个维度的计数
+type limitCheck struct {
+	key  string
+	rule LimitRule
+}
+
+// Admit 检查并记录一次发送请求
+// 被拒绝的请求不计数，避免用户完成人机验证后重试时提前触发上限
+func (g *AbuseGuard) Admit(ctx context.Context, req *SendCodeRequest) error {
+	checks := g.checks(req)
+
+	counted := make([]string, 0, len(checks))
+	release := func() {
+		for _, key := range counted {
+			_ = g.repo.Decr(ctx, key)
+		}
+	}
+
+	challenge := false
+	for _, c := range checks {
+		count, err := g.repo.Incr(ctx, c.key, c.rule.Window)
+		if err != nil {
+			release()
+			return fmt.Errorf("check sms limit: %w", err)
+		}
+		counted = append(counted, c.key)
+
+		if c.rule.Max > 0 && count > c.rule.Max {
+			release()
+			return domain.ErrSMSLimitExceeded
+		}
+		if c.rule.ChallengeAfter > 0 && count > c.rule.ChallengeAfter {
+			challenge = true
+		}
+	}
+
+	if !challenge {
+		return nil
+	}
+
+	if g.captcha == nil {
+		release()
+		return domain.ErrSMSLimitExceeded
+	}
+	if req.CaptchaToken == "" {
+		release()
+		return domain.ErrCaptchaRequired
+	}
+	ok, err := g.captcha.Verify(ctx, req.CaptchaToken, normalizeIP(req.ClientIP))
+	if err != nil {
+		release()
+		return fmt.Errorf("verify captcha: %w", err)
+	}
+	if !ok {
+		release()
+		return domain.ErrCaptchaInvalid
+	}
+	return nil
+}
+
+// AdmitStepUp 检查并记录一次登录二次验证短信
+// 每次登录都会创建新的挑战，单个挑战的重发间隔无法限制总量，需按手机号累计
+func (g *AbuseGuard) AdmitStepUp(ctx context.Context, phone string) error {
+	rule := g.config.StepUp
+	phone = normalizePhone(phone, g.config.DefaultCountryCode)
+	if rule.Window <= 0 || rule.Max <= 0 || phone == "" {
+		return nil
+	}
+
+	key := "stepup:" + phone
+	count, err := g.repo.Incr(ctx, key, rule.Window)
+	if err != nil {
+		return fmt.Errorf("check sms limit: %w", err)
+	}
+	if count > rule.Max {
+		_ = g.repo.Decr(ctx, key)
+		return domain.ErrSMSLimitExceeded
+	}
+	return nil
+}
+
+// AllowProvider 检查提供商当日发送量是否超出预算（放行时预占一次额度，发送失败后由ReleaseProvider归还）
+// 计数失败时放行，预算只用于控制成本，不应因Redis故障导致短信不可用
+func (g *AbuseGuard) AllowProvider(ctx context.Context, provider string) bool {
+	budget := g.config.ProviderDailyBudget[provider]
+	if budget <= 0 {
+		return true
+	}
+
+	key := g.budgetKey(provider)
+	count, err := g.repo.Incr(ctx, key, 25*time.Hour)
+	if err != nil {
+		return true
+	}
+	if count > budget {
+		_ = g.repo.Decr(ctx, key)
+		return false
+	}
+	return true
+}
+
+// ReleaseProvider 归还发送失败时预占的额度（只有发送成功的短信计入预算）
+func (g *AbuseGuard) ReleaseProvider(ctx context.Context, provider string) {
+	if g.config.ProviderDailyBudget[provider] <= 0 {
+		return
+	}
+	_ = g.repo.Decr(ctx, g.budgetKey(provider))
+}
+
+// budgetKey 提供商当日预算计数Key
+func (g *AbuseGuard) budgetKey(provider string) string {
+	return fmt.Sprintf("budget:%s:%s", provider, g.now().Format("20060102"))
+}
+
+// checks 生成请求涉及的计数维度
+func (g *AbuseGuard) checks(req *SendCodeRequest) []limitCheck {
+	phone := normalizePhone(req.Phone, g.config.DefaultCountryCode)
+	checks := make([]limitCheck, 0, 4)
+
+	ip := normalizeIP(req.ClientIP)
+	if ip != "" && g.config.IP.Window > 0 {
+		checks = append(checks, limitCheck{key: "ip:" + ip, rule: g.config.IP})
+	}
+	// 不提供指纹不能绕过设备维度的限制：退回按IP或手机号计数
+	if g.config.Device.Window > 0 {
+		device := req.DeviceFingerprint
+		switch {
+		case device != "":
+		case ip != "":
+			device = "ip:" + ip
+		case phone != "":
+			device = "phone:" + phone
+		}
+		if device != "" {
+			checks = append(checks, limitCheck{key: "device:" + device, rule: g.config.Device})
+		}
+	}
+	if g.config.PrefixLength > 0 && len(phone) > g.config.PrefixLength && g.config.Prefix.Window > 0 {
+		checks = append(checks, limitCheck{key: "prefix:" + phone[:g.config.PrefixLength], rule: g.config.Prefix})
+	}
+	if phone != "" && g.config.Phone.Window > 0 {
+		checks = append(checks, limitCheck{key: "phone:" + phone, rule: g.config.Phone})
+	}
+	return checks
+}
+
+// normalizePhone 转换为带国家区号的纯数字号码（+8613800138000 与 13800138000 视为同一号码）
+func normalizePhone(phone, defaultCountryCode string) string {
+	phone = strings.TrimSpace(phone)
+	international := strings.HasPrefix(phone, "+")
+
+	var b strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	digits := b.String()
+	if digits == "" || international {
+		return digits
+	}
+	return defaultCountryCode + digits
+}
+
+// normalizeIP 取X-Forwarded-For中的第一个地址并去掉端口
+// X-Forwarded-For可被客户端伪造，需由网关覆盖该请求头
+func normalizeIP(clientIP string) string {
+	ip := strings.TrimSpace(strings.Split(clientIP, ",")[0])
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	return ip
+}
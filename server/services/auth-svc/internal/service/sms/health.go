@@ -0,0 +1,131 @@
+package sms
+
+import (
+	"sync"
+	"time"
+
+	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/domain"
+)
+
+// healthBuckets 滑动窗口分桶数
+const healthBuckets = 10
+
+// healthBucket 单个时间桶的发送统计
+type healthBucket struct {
+	start     time.Time
+	attempts  int64
+	successes int64
+	latency   time.Duration // 累计耗时
+}
+
+// HealthTracker 提供商健康统计
+// 本实例的发送结果按滑动窗口统计（实时）；同时保存从sms_records汇总的全局统计，
+// 本地样本不足时（如刚启动、流量低）使用全局统计
+type HealthTracker struct {
+	mu         sync.RWMutex
+	window     time.Duration
+	bucketSize time.Duration
+	local      map[string]*[healthBuckets]healthBucket
+	global     map[string]*domain.SMSProviderHealth
+	now        func() time.Time
+}
+
+// NewHealthTracker 创建健康统计
+func NewHealthTracker(window time.Duration) *HealthTracker {
+	if window <= 0 {
+		window = 5 * time.Minute
+	}
+	return &HealthTracker{
+		window:     window,
+		bucketSize: window / healthBuckets,
+		local:      make(map[string]*[healthBuckets]healthBucket),
+		global:     make(map[string]*domain.SMSProviderHealth),
+		now:        time.Now,
+	}
+}
+
+// Record 记录一次发送结果
+func (t *HealthTracker) Record(provider string, success bool, latency time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	buckets, ok := t.local[provider]
+	if !ok {
+		buckets = &[healthBuckets]healthBucket{}
+		t.local[provider] = buckets
+	}
+
+	now := t.now()
+	start := now.Truncate(t.bucketSize)
+	b := &buckets[int(start.UnixNano()/int64(t.bucketSize))%healthBuckets]
+	if !b.start.Equal(start) {
+		*b = healthBucket{start: start}
+	}
+
+	b.attempts++
+	if success {
+		b.successes++
+	}
+	b.latency += latency
+}
+
+// SetGlobal 更新从数据库汇总的全局统计
+func (t *HealthTracker) SetGlobal(stats []*domain.SMSProviderHealth) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.global = make(map[string]*domain.SMSProviderHealth, len(stats))
+	for _, s := range stats {
+		t.global[s.Provider] = s
+	}
+}
+
+// Sample 提供商健康样本
+type Sample struct {
+	Attempts   int64
+	Successes  int64
+	AvgLatency time.Duration
+	Source     string // local | global | none
+}
+
+// Sample 获取提供商健康样本（本地样本不少于minSamples时使用本地，否则使用全局）
+func (t *HealthTracker) Sample(provider string, minSamples int64) Sample {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var local Sample
+	if buckets, ok := t.local[provider]; ok {
+		cutoff := t.now().Add(-t.window)
+		var latency time.Duration
+		for _, b := range buckets {
+			if b.attempts == 0 || b.start.Before(cutoff) {
+				continue
+			}
+			local.Attempts += b.attempts
+			local.Successes += b.successes
+			latency += b.latency
+		}
+		if local.Attempts > 0 {
+			local.AvgLatency = latency / time.Duration(local.Attempts)
+		}
+	}
+	if local.Attempts >= minSamples {
+		local.Source = "local"
+		return local
+	}
+
+	if g, ok := t.global[provider]; ok && g.Total > 0 {
+		return Sample{
+			Attempts:   g.Total,
+			Successes:  g.Success,
+			AvgLatency: time.Duration(g.AvgLatencyMs) * time.Millisecond,
+			Source:     "global",
+		}
+	}
+
+	if local.Attempts > 0 {
+		local.Source = "local"
+		return local
+	}
+	return Sample{Source: "none"}
+}
@@ -32,6 +32,12 @@ type Config struct {
 	Twilio TwilioConfig `json:"twilio"`
 	// FallbackEnabled 是否启用Fallback（默认true）
 	FallbackEnabled bool `json:"fallback_enabled"`
+	// Routing 自适应路由配置（按健康状态和国家偏好选择提供商）
+	Routing RoutingConfig `json:"routing"`
+	// Abuse 防刷配置（按IP、设备、号段限制发送次数，提供商每日预算）
+	Abuse AbuseConfig `json:"abuse"`
+	// Captcha 人机验证配置（VerifyURL为空时不启用）
+	Captcha CaptchaConfig `json:"captcha"`
 }
 
 // AliyunConfig 阿里云SMS配置
@@ -67,6 +73,8 @@ type TwilioConfig struct {
 func NewConfig() *Config {
 	return &Config{
 		FallbackEnabled: true,
+		Routing:         NewRoutingConfig(),
+		Abuse:           NewAbuseConfig(),
 		Aliyun: AliyunConfig{
 			Endpoint: "dysmsapi.aliyuncs.com",
 			Enabled:  false,
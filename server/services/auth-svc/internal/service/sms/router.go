@@ -0,0 +1,225 @@
+package sms
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/breaker"
+)
+
+// RoutingConfig 自适应路由配置
+type RoutingConfig struct {
+	// Enabled 是否启用自适应路由（关闭时按配置顺序尝试）
+	Enabled bool `json:"enabled"`
+	// Window 健康统计滑动窗口
+	Window time.Duration `json:"window"`
+	// MinSamples 样本数不少于该值才会降级提供商
+	MinSamples int64 `json:"min_samples"`
+	// DemoteBelow 成功率低于该值的提供商被降级到队尾
+	DemoteBelow float64 `json:"demote_below"`
+	// LatencyTarget 期望耗时，超过时扣分（最多扣LatencyWeight）
+	LatencyTarget time.Duration `json:"latency_target"`
+	// LatencyWeight 耗时在评分中的权重（0-1）
+	LatencyWeight float64 `json:"latency_weight"`
+
+	// DefaultCountryCode 无国际区号的手机号视为该国家（默认86）
+	DefaultCountryCode string `json:"default_country_code"`
+	// CountryPreferences 按国家区号的提供商偏好顺序，如 {"86": ["aliyun","tencent"], "1": ["twilio"]}
+	CountryPreferences map[string][]string `json:"country_preferences"`
+
+	// BreakerMaxFailures 连续失败多少次熔断
+	BreakerMaxFailures int `json:"breaker_max_failures"`
+	// BreakerTimeout 熔断后多久进入半开状态
+	BreakerTimeout time.Duration `json:"breaker_timeout"`
+}
+
+// NewRoutingConfig 创建默认路由配置
+func NewRoutingConfig() RoutingConfig {
+	return RoutingConfig{
+		Enabled:            true,
+		Window:             5 * time.Minute,
+		MinSamples:         10,
+		DemoteBelow:        0.8,
+		LatencyTarget:      3 * time.Second,
+		LatencyWeight:      0.2,
+		DefaultCountryCode: "86",
+		CountryPreferences: map[string][]string{
+			"86": {"aliyun", "tencent", "twilio"},
+		},
+		BreakerMaxFailures: 5,
+		BreakerTimeout:     30 * time.Second,
+	}
+}
+
+// ProviderHealth 提供商健康状态
+type ProviderHealth struct {
+	Provider     string        `json:"provider"`
+	Attempts     int64         `json:"attempts"`
+	SuccessRate  float64       `json:"success_rate"` // 0-1
+	AvgLatency   time.Duration `json:"avg_latency"`
+	Score        float64       `json:"score"` // 0-1，越高越优先
+	Demoted      bool          `json:"demoted"`
+	BreakerState string        `json:"breaker_state"`
+	Source       string        `json:"source"` // local | global | none
+}
+
+// Router 提供商自适应路由
+// 排序规则：未降级 > 国家偏好 > 健康评分；熔断中的提供商在发送时跳过
+type Router struct {
+	config   RoutingConfig
+	tracker  *HealthTracker
+	mu       sync.Mutex
+	breakers map[string]*breaker.CircuitBreaker
+}
+
+// NewRouter 创建自适应路由
+func NewRouter(config RoutingConfig) *Router {
+	return &Router{
+		config:   config,
+		tracker:  NewHealthTracker(config.Window),
+		breakers: make(map[string]*breaker.CircuitBreaker),
+	}
+}
+
+// Tracker 返回健康统计
+func (r *Router) Tracker() *HealthTracker {
+	return r.tracker
+}
+
+// Order 按手机号所属国家和提供商健康状态排序
+func (r *Router) Order(phone string, providers []Provider) []Provider {
+	preferences := r.preferencesFor(phone)
+
+	type candidate struct {
+		provider Provider
+		index    int
+		rank     int
+		health   ProviderHealth
+	}
+
+	candidates := make([]candidate, len(providers))
+	for i, p := range providers {
+		rank, ok := preferences[p.Name()]
+		if !ok {
+			rank = len(preferences)
+		}
+		candidates[i] = candidate{provider: p, index: i, rank: rank, health: r.Health(p.Name())}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.health.Demoted != b.health.Demoted {
+			return !a.health.Demoted
+		}
+		if a.rank != b.rank {
+			return a.rank < b.rank
+		}
+		if a.health.Score != b.health.Score {
+			return a.health.Score > b.health.Score
+		}
+		return a.index < b.index
+	})
+
+	ordered := make([]Provider, len(candidates))
+	for i, c := range candidates {
+		ordered[i] = c.provider
+	}
+	return ordered
+}
+
+// Allow 熔断器是否允许请求该提供商
+func (r *Router) Allow(provider string) bool {
+	return r.breaker(provider).Allow()
+}
+
+// Release 归还Allow占用的熔断器名额（未实际发送时调用，不计入统计）
+func (r *Router) Release(provider string) {
+	r.breaker(provider).Release()
+}
+
+// Report 上报发送结果
+func (r *Router) Report(provider string, success bool, latency time.Duration) {
+	r.tracker.Record(provider, success, latency)
+	if success {
+		r.breaker(provider).RecordSuccess()
+	} else {
+		r.breaker(provider).RecordFailure()
+	}
+}
+
+// Health 计算提供商健康状态
+func (r *Router) Health(provider string) ProviderHealth {
+	sample := r.tracker.Sample(provider, r.config.MinSamples)
+
+	// 平滑成功率，避免少量样本导致评分剧烈波动
+	rate := float64(sample.Successes+1) / float64(sample.Attempts+2)
+
+	var latencyPenalty float64
+	if r.config.LatencyTarget > 0 {
+		latencyPenalty = math.Min(float64(sample.AvgLatency)/float64(r.config.LatencyTarget), 1) * r.config.LatencyWeight
+	}
+
+	state := r.breaker(provider).GetState()
+	health := ProviderHealth{
+		Provider:     provider,
+		Attempts:     sample.Attempts,
+		AvgLatency:   sample.AvgLatency,
+		Score:        math.Max(rate-latencyPenalty, 0),
+		BreakerState: state.String(),
+		Source:       sample.Source,
+	}
+	if sample.Attempts > 0 {
+		health.SuccessRate = float64(sample.Successes) / float64(sample.Attempts)
+	}
+	health.Demoted = state == breaker.StateOpen ||
+		(sample.Attempts >= r.config.MinSamples && health.SuccessRate < r.config.DemoteBelow)
+
+	return health
+}
+
+// breaker 获取提供商熔断器
+func (r *Router) breaker(provider string) *breaker.CircuitBreaker {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cb, ok := r.breakers[provider]
+	if !ok {
+		cb = breaker.New(&breaker.Config{
+			Name:        "sms-" + provider,
+			MaxFailures: r.config.BreakerMaxFailures,
+			Timeout:     r.config.BreakerTimeout,
+		})
+		r.breakers[provider] = cb
+	}
+	return cb
+}
+
+// preferencesFor 获取手机号所属国家的提供商偏好（提供商 -> 优先级）
+func (r *Router) preferencesFor(phone string) map[string]int {
+	list := r.config.CountryPreferences[r.countryCode(phone)]
+	prefs := make(map[string]int, len(list))
+	for i, name := range list {
+		prefs[name] = i
+	}
+	return prefs
+}
+
+// countryCode 解析手机号的国际区号
+// +开头的号码按已配置区号做最长前缀匹配，其他号码视为默认国家
+func (r *Router) countryCode(phone string) string {
+	if !strings.HasPrefix(phone, "+") {
+		return r.config.DefaultCountryCode
+	}
+
+	digits := strings.TrimPrefix(phone, "+")
+	best := ""
+	for code := range r.config.CountryPreferences {
+		if strings.HasPrefix(digits, code) && len(code) > len(best) {
+			best = code
+		}
+	}
+	return best
+}
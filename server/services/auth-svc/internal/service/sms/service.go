@@ -12,16 +12,20 @@ import (
 // Service SMS服务（整合所有功能）
 type Service struct {
 	chain              *FallbackChain
+	router             *Router
 	stats              *Stats
+	guard              *AbuseGuard
 	verificationRepo   repository.SMSVerificationRepository
 	config             *Config
 }
 
 // NewService 创建SMS服务
+// limitRepo为nil时不启用防刷限制（仅保留同一号码60秒重发间隔）
 func NewService(
 	config *Config,
 	verificationRepo repository.SMSVerificationRepository,
 	recordRepo repository.SMSRecordRepository,
+	limitRepo repository.SMSLimitRepository,
 ) *Service {
 	// 创建提供商
 	providers := []Provider{
@@ -30,22 +34,48 @@ func NewService(
 		NewTwilioProvider(config.Twilio),
 	}
 
-	// 创建Fallback链
-	chain := NewFallbackChain(providers, config.FallbackEnabled)
+	// 创建Fallback链（启用自适应路由时按提供商健康状态排序）
+	var router *Router
+	if config.Routing.Enabled {
+		router = NewRouter(config.Routing)
+	}
+	chain := NewAdaptiveFallbackChain(providers, config.FallbackEnabled, router)
+
+	// 创建防刷限制（含提供商每日预算）
+	var guard *AbuseGuard
+	if config.Abuse.Enabled && limitRepo != nil {
+		var captcha CaptchaVerifier
+		if config.Captcha.VerifyURL != "" {
+			captcha = NewSiteVerifyCaptcha(config.Captcha)
+		}
+		guard = NewAbuseGuard(config.Abuse, limitRepo, captcha)
+		chain.SetProviderGate(guard)
+	}
 
 	// 创建统计服务
 	stats := NewStats(recordRepo)
 
 	return &Service{
 		chain:            chain,
+		router:           router,
 		stats:            stats,
+		guard:            guard,
 		verificationRepo: verificationRepo,
 		config:           config,
 	}
 }
 
+// SetCaptchaVerifier 替换人机验证实现（需在处理请求前调用）
+func (s *Service) SetCaptchaVerifier(captcha CaptchaVerifier) {
+	if s.guard != nil {
+		s.guard.captcha = captcha
+	}
+}
+
 // SendVerificationCode 发送验证码
-func (s *Service) SendVerificationCode(ctx context.Context, phone string) (*SendCodeResult, error) {
+func (s *Service) SendVerificationCode(ctx context.Context, req *SendCodeRequest) (*SendCodeResult, error) {
+	phone := req.Phone
+
 	// 1. 检查发送频率限制（60秒内不能重复发送）
 	recentCount, err := s.verificationRepo.CountRecent(ctx, phone, time.Now().Add(-domain.SMSCodeRateLimit))
 	if err != nil {
@@ -55,34 +85,26 @@ func (s *Service) SendVerificationCode(ctx context.Context, phone string) (*Send
 		return nil, domain.ErrSMSTooFrequent
 	}
 
-	// 2. 创建验证码
+	// 2. 防刷限制（IP/设备/号段/号码，超过阈值需要人机验证）
+	if s.guard != nil {
+		if err := s.guard.Admit(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+
+	// 3. 创建验证码
 	verification, err := domain.NewSMSVerification(phone)
 	if err != nil {
 		return nil, fmt.Errorf("create verification: %w", err)
 	}
 
-	// 3. 发送短信（带Fallback）
+	// 4. 发送短信（带Fallback）
 	sendResult, err := s.chain.Send(ctx, phone, verification.Code)
 	
-	// 4. 记录发送结果
-	if sendResult != nil {
-		// 记录统计（异步，不阻塞主流程）
-		go func() {
-			recordCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-			defer cancel()
-
-			var errorMsg string
-			if err != nil {
-				errorMsg = err.Error()
-			} else if len(sendResult.Errors) > 0 {
-				errorMsg = fmt.Sprintf("fallback attempts: %v", sendResult.Errors)
-			}
+	// 5. 记录发送结果
+	s.recordAsync(phone, sendResult, err)
 
-			_ = s.stats.Record(recordCtx, phone, sendResult.Provider, sendResult.Success, errorMsg)
-		}()
-	}
-
-	// 5. 处理发送失败
+	// 6. 处理发送失败
 	if err != nil {
 		return &SendCodeResult{
 			Success:      false,
@@ -93,7 +115,7 @@ func (s *Service) SendVerificationCode(ctx context.Context, phone string) (*Send
 		}, err
 	}
 
-	// 6. 保存验证码到数据库
+	// 7. 保存验证码到数据库
 	if err := s.verificationRepo.Create(ctx, verification); err != nil {
 		return nil, fmt.Errorf("save verification: %w", err)
 	}
@@ -107,6 +129,80 @@ func (s *Service) SendVerificationCode(ctx context.Context, phone string) (*Send
 	}, nil
 }
 
+// SendStepUpCode 发送登录二次验证码
+// 验证码由调用方生成并保存（风控挑战中仅保存哈希），不占用登录验证码的发送频率限制，按手机号单独限制总量
+func (s *Service) SendStepUpCode(ctx context.Context, phone, code string) error {
+	if s.guard != nil {
+		if err := s.guard.AdmitStepUp(ctx, phone); err != nil {
+			return err
+		}
+	}
+
+	sendResult, err := s.chain.Send(ctx, phone, code)
+	s.recordAsync(phone, sendResult, err)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrSMSSendFailed, err)
+	}
+	return nil
+}
+
+// recordAsync 记录发送统计（异步，不阻塞主流程）
+// 每次调用提供商单独记录，失败后被Fallback的提供商也会计入其健康统计
+func (s *Service) recordAsync(phone string, sendResult *SendResult, sendErr error) {
+	if sendResult == nil || len(sendResult.AttemptLog) == 0 {
+		return
+	}
+
+	go func() {
+		recordCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		for _, attempt := range sendResult.AttemptLog {
+			_ = s.stats.Record(recordCtx, phone, attempt.Provider, attempt.Success, attempt.Error, attempt.Latency)
+		}
+	}()
+}
+
+// GetProviderHealth 获取各提供商健康状态（按当前路由顺序）
+func (s *Service) GetProviderHealth() []ProviderHealth {
+	return s.chain.GetProviderHealth()
+}
+
+// RefreshProviderHealth 从发送记录汇总全局健康统计（所有实例）
+func (s *Service) RefreshProviderHealth(ctx context.Context) error {
+	if s.router == nil {
+		return nil
+	}
+
+	health, err := s.stats.ProviderHealth(ctx, time.Now().Add(-s.config.Routing.Window))
+	if err != nil {
+		return fmt.Errorf("load provider health: %w", err)
+	}
+	s.router.Tracker().SetGlobal(health)
+	return nil
+}
+
+// RunHealthRefresh 定期刷新全局健康统计，直到ctx取消
+func (s *Service) RunHealthRefresh(ctx context.Context, interval time.Duration) {
+	if s.router == nil {
+		return
+	}
+
+	_ = s.RefreshProviderHealth(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = s.RefreshProviderHealth(ctx)
+		}
+	}
+}
+
 // VerifyCode 验证验证码
 func (s *Service) VerifyCode(ctx context.Context, phone, code string) error {
 	// 1. 获取最新的验证码
@@ -3,8 +3,13 @@ package sms
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
+
+	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/domain"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/repository"
 )
 
 // MockProvider Mock SMS提供商（用于测试）
@@ -275,6 +280,522 @@ func TestFallbackChain_GetAvailableProviders(t *testing.T) {
 	}
 }
 
+// ===== Adaptive Routing Tests =====
+
+// newTestRoutingConfig 测试用路由配置（小样本即可降级）
+func newTestRoutingConfig() RoutingConfig {
+	config := NewRoutingConfig()
+	config.MinSamples = 3
+	config.BreakerMaxFailures = 3
+	config.CountryPreferences = map[string][]string{
+		"86": {"mock1", "mock2"},
+		"1":  {"mock2", "mock1"},
+	}
+	return config
+}
+
+func providerNames(providers []Provider) []string {
+	names := make([]string, 0, len(providers))
+	for _, p := range providers {
+		names = append(names, p.Name())
+	}
+	return names
+}
+
+func TestRouter_CountryPreferences(t *testing.T) {
+	router := NewRouter(newTestRoutingConfig())
+	providers := []Provider{NewMockProvider("mock1", true), NewMockProvider("mock2", true)}
+
+	if got := providerNames(router.Order("13800138000", providers)); got[0] != "mock1" {
+		t.Errorf("expected mock1 first for domestic number, got %v", got)
+	}
+	if got := providerNames(router.Order("+8613800138000", providers)); got[0] != "mock1" {
+		t.Errorf("expected mock1 first for +86 number, got %v", got)
+	}
+	if got := providerNames(router.Order("+14155550100", providers)); got[0] != "mock2" {
+		t.Errorf("expected mock2 first for +1 number, got %v", got)
+	}
+}
+
+func TestRouter_HealthScoreOrdering(t *testing.T) {
+	config := newTestRoutingConfig()
+	config.CountryPreferences = nil
+	router := NewRouter(config)
+	providers := []Provider{NewMockProvider("mock1", true), NewMockProvider("mock2", true)}
+
+	// mock1成功率高但很慢，mock2成功率高且快
+	for i := 0; i < 5; i++ {
+		router.Report("mock1", true, 5*time.Second)
+		router.Report("mock2", true, 100*time.Millisecond)
+	}
+
+	if got := providerNames(router.Order("13800138000", providers)); got[0] != "mock2" {
+		t.Errorf("expected faster provider first, got %v", got)
+	}
+}
+
+func TestRouter_DemotesDegradedProvider(t *testing.T) {
+	router := NewRouter(newTestRoutingConfig())
+	providers := []Provider{NewMockProvider("mock1", true), NewMockProvider("mock2", true)}
+
+	// mock1是首选，但成功率降到50%
+	for i := 0; i < 4; i++ {
+		router.Report("mock1", i%2 == 0, 100*time.Millisecond)
+	}
+
+	health := router.Health("mock1")
+	if !health.Demoted {
+		t.Fatalf("expected mock1 to be demoted, got %+v", health)
+	}
+	if got := providerNames(router.Order("13800138000", providers)); got[0] != "mock2" {
+		t.Errorf("expected demoted provider to move to the end, got %v", got)
+	}
+}
+
+func TestRouter_UsesGlobalStatsWhenLocalSamplesInsufficient(t *testing.T) {
+	router := NewRouter(newTestRoutingConfig())
+	router.Tracker().SetGlobal([]*domain.SMSProviderHealth{
+		{Provider: "mock1", Total: 100, Success: 10, AvgLatencyMs: 200},
+	})
+
+	health := router.Health("mock1")
+	if health.Source != "global" || !health.Demoted {
+		t.Errorf("expected demotion from global stats, got %+v", health)
+	}
+}
+
+func TestAdaptiveFallbackChain_CircuitBreakerSkipsProvider(t *testing.T) {
+	mock1 := NewMockProvider("mock1", true)
+	mock1.SetShouldFail(true)
+	mock2 := NewMockProvider("mock2", true)
+
+	config := newTestRoutingConfig()
+	config.MinSamples = 1000 // 只验证熔断，不触发降级
+	router := NewRouter(config)
+	chain := NewAdaptiveFallbackChain([]Provider{mock1, mock2}, true, router)
+
+	ctx := context.Background()
+	for i := 0; i < config.BreakerMaxFailures; i++ {
+		result, err := chain.Send(ctx, "13800138000", "123456")
+		if err != nil || result.Provider != "mock2" {
+			t.Fatalf("expected fallback to mock2, got %v %v", result, err)
+		}
+	}
+
+	// mock1已熔断：不再调用
+	result, err := chain.Send(ctx, "13800138000", "123456")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result.Attempts != 1 || result.Provider != "mock2" {
+		t.Errorf("expected only mock2 to be attempted, got %d attempts via %s", result.Attempts, result.Provider)
+	}
+	if router.Health("mock1").BreakerState != "open" {
+		t.Errorf("expected mock1 breaker open, got %s", router.Health("mock1").BreakerState)
+	}
+}
+
+func TestAdaptiveFallbackChain_CircuitOpenWithoutFallbackStillTriesPrimary(t *testing.T) {
+	mock1 := NewMockProvider("mock1", true)
+	mock1.SetShouldFail(true)
+
+	config := newTestRoutingConfig()
+	config.MinSamples = 1000
+	router := NewRouter(config)
+	chain := NewAdaptiveFallbackChain([]Provider{mock1}, false, router)
+
+	ctx := context.Background()
+	for i := 0; i < config.BreakerMaxFailures; i++ {
+		_, _ = chain.Send(ctx, "13800138000", "123456")
+	}
+	if router.Health("mock1").BreakerState != "open" {
+		t.Fatalf("expected mock1 breaker open, got %s", router.Health("mock1").BreakerState)
+	}
+
+	// 没有后备提供商：熔断后仍尝试唯一的提供商，恢复后即可发送成功
+	mock1.SetShouldFail(false)
+	result, err := chain.Send(ctx, "13800138000", "123456")
+	if err != nil {
+		t.Fatalf("expected primary to be tried despite open circuit, got %v", err)
+	}
+	if result.Attempts != 1 || result.Provider != "mock1" {
+		t.Errorf("expected mock1 to be attempted once, got %d attempts via %s", result.Attempts, result.Provider)
+	}
+}
+
+func TestAdaptiveFallbackChain_AllCircuitsOpen(t *testing.T) {
+	mock1 := NewMockProvider("mock1", true)
+	mock1.SetShouldFail(true)
+	mock2 := NewMockProvider("mock2", true)
+	mock2.SetShouldFail(true)
+
+	config := newTestRoutingConfig()
+	config.MinSamples = 1000
+	router := NewRouter(config)
+	chain := NewAdaptiveFallbackChain([]Provider{mock1, mock2}, true, router)
+
+	ctx := context.Background()
+	for i := 0; i < config.BreakerMaxFailures; i++ {
+		_, _ = chain.Send(ctx, "13800138000", "123456")
+	}
+
+	result, err := chain.Send(ctx, "13800138000", "123456")
+	if !errors.Is(err, ErrNoProviderAttempted) {
+		t.Fatalf("expected ErrNoProviderAttempted, got %v", err)
+	}
+	if result.Attempts != 0 || len(result.Errors) != 2 {
+		t.Errorf("expected both providers skipped, got %d attempts, errors %v", result.Attempts, result.Errors)
+	}
+}
+
+func TestAdaptiveFallbackChain_CancelledSendReleasesHalfOpenSlot(t *testing.T) {
+	mock1 := NewMockProvider("mock1", true)
+	mock1.SetShouldFail(true)
+
+	config := newTestRoutingConfig()
+	config.MinSamples = 1000
+	config.BreakerTimeout = 10 * time.Millisecond
+	router := NewRouter(config)
+	chain := NewAdaptiveFallbackChain([]Provider{mock1}, true, router)
+
+	ctx := context.Background()
+	for i := 0; i < config.BreakerMaxFailures; i++ {
+		_, _ = chain.Send(ctx, "13800138000", "123456")
+	}
+	if router.Health("mock1").BreakerState != "open" {
+		t.Fatalf("expected mock1 breaker open, got %s", router.Health("mock1").BreakerState)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	// 半开状态下多次取消发送：每次都应归还熔断名额
+	mock1.SetShouldFail(false)
+	mock1.SetDelay(time.Second)
+	for i := 0; i < 5; i++ {
+		cancelCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		_, err := chain.Send(cancelCtx, "13800138000", "123456")
+		cancel()
+		if err != context.DeadlineExceeded {
+			t.Fatalf("expected DeadlineExceeded, got %v", err)
+		}
+	}
+	if router.Health("mock1").BreakerState != "half-open" {
+		t.Fatalf("expected mock1 breaker half-open, got %s", router.Health("mock1").BreakerState)
+	}
+
+	// 名额未泄漏：mock1仍可被尝试
+	mock1.SetDelay(0)
+	result, err := chain.Send(ctx, "13800138000", "123456")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result.Provider != "mock1" || result.Attempts != 1 {
+		t.Errorf("expected half-open mock1 to be attempted, got %s after %d attempts", result.Provider, result.Attempts)
+	}
+}
+
+func TestFallbackChain_AttemptLog(t *testing.T) {
+	mock1 := NewMockProvider("mock1", true)
+	mock1.SetShouldFail(true)
+	mock2 := NewMockProvider("mock2", true)
+
+	chain := NewFallbackChain([]Provider{mock1, mock2}, true)
+	result, err := chain.Send(context.Background(), "13800138000", "123456")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(result.AttemptLog) != 2 {
+		t.Fatalf("expected 2 attempts logged, got %d", len(result.AttemptLog))
+	}
+	if result.AttemptLog[0].Provider != "mock1" || result.AttemptLog[0].Success || result.AttemptLog[0].Error == "" {
+		t.Errorf("unexpected first attempt: %+v", result.AttemptLog[0])
+	}
+	if result.AttemptLog[1].Provider != "mock2" || !result.AttemptLog[1].Success {
+		t.Errorf("unexpected second attempt: %+v", result.AttemptLog[1])
+	}
+}
+
+// ===== Abuse Guard Tests =====
+
+// memoryLimitRepo 内存计数（测试用，不处理窗口过期）
+type memoryLimitRepo struct {
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+func newMemoryLimitRepo() *memoryLimitRepo {
+	return &memoryLimitRepo{counts: make(map[string]int64)}
+}
+
+func (r *memoryLimitRepo) Incr(ctx context.Context, key string, window time.Duration) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.counts[key]++
+	return r.counts[key], nil
+}
+
+func (r *memoryLimitRepo) Decr(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.counts[key]; ok {
+		r.counts[key]--
+	}
+	return nil
+}
+
+// stubCaptcha 固定token通过的人机验证
+type stubCaptcha struct {
+	validToken string
+	calls      int
+}
+
+func (c *stubCaptcha) Verify(ctx context.Context, token, clientIP string) (bool, error) {
+	c.calls++
+	return token == c.validToken, nil
+}
+
+func newTestAbuseConfig() AbuseConfig {
+	config := NewAbuseConfig()
+	config.IP = LimitRule{Window: time.Hour, ChallengeAfter: 2, Max: 4}
+	config.Device = LimitRule{Window: time.Hour, ChallengeAfter: 2, Max: 4}
+	config.Prefix = LimitRule{Window: time.Hour, ChallengeAfter: 3, Max: 5}
+	config.Phone = LimitRule{Window: 24 * time.Hour, Max: 10}
+	return config
+}
+
+func TestAbuseGuard_RotatingPhonesFromOneIP(t *testing.T) {
+	captcha := &stubCaptcha{validToken: "ok"}
+	guard := NewAbuseGuard(newTestAbuseConfig(), newMemoryLimitRepo(), captcha)
+	ctx := context.Background()
+
+	phones := []string{"+8613800000001", "+8613911111112", "+8615022222223", "+8618633333334", "+8617744444445"}
+
+	// 前两次不需要人机验证
+	for _, phone := range phones[:2] {
+		if err := guard.Admit(ctx, &SendCodeRequest{Phone: phone, ClientIP: "1.2.3.4"}); err != nil {
+			t.Fatalf("expected %s to be admitted, got %v", phone, err)
+		}
+	}
+
+	// 超过阈值后需要人机验证，被拒绝的请求不计数
+	err := guard.Admit(ctx, &SendCodeRequest{Phone: phones[2], ClientIP: "1.2.3.4"})
+	if !errors.Is(err, domain.ErrCaptchaRequired) {
+		t.Fatalf("expected ErrCaptchaRequired, got %v", err)
+	}
+	err = guard.Admit(ctx, &SendCodeRequest{Phone: phones[2], ClientIP: "1.2.3.4", CaptchaToken: "bad"})
+	if !errors.Is(err, domain.ErrCaptchaInvalid) {
+		t.Fatalf("expected ErrCaptchaInvalid, got %v", err)
+	}
+
+	for _, phone := range phones[2:4] {
+		if err := guard.Admit(ctx, &SendCodeRequest{Phone: phone, ClientIP: "1.2.3.4", CaptchaToken: "ok"}); err != nil {
+			t.Fatalf("expected %s to be admitted with captcha, got %v", phone, err)
+		}
+	}
+
+	// 超过上限后即使通过人机验证也拒绝
+	err = guard.Admit(ctx, &SendCodeRequest{Phone: phones[4], ClientIP: "1.2.3.4:5678", CaptchaToken: "ok"})
+	if !errors.Is(err, domain.ErrSMSLimitExceeded) {
+		t.Errorf("expected ErrSMSLimitExceeded, got %v", err)
+	}
+
+	// 其他IP不受影响
+	if err := guard.Admit(ctx, &SendCodeRequest{Phone: phones[4], ClientIP: "5.6.7.8"}); err != nil {
+		t.Errorf("expected other IP to be admitted, got %v", err)
+	}
+}
+
+func TestAbuseGuard_PrefixLimit(t *testing.T) {
+	guard := NewAbuseGuard(newTestAbuseConfig(), newMemoryLimitRepo(), nil)
+	ctx := context.Background()
+
+	// 不同IP轮换同一号段的号码；未配置人机验证时超过ChallengeAfter直接拒绝
+	for i := 0; i < 3; i++ {
+		req := &SendCodeRequest{Phone: fmt.Sprintf("1380013800%d", i), ClientIP: fmt.Sprintf("10.0.0.%d", i)}
+		if err := guard.Admit(ctx, req); err != nil {
+			t.Fatalf("expected request %d to be admitted, got %v", i, err)
+		}
+	}
+
+	err := guard.Admit(ctx, &SendCodeRequest{Phone: "+8613800138009", ClientIP: "10.0.0.9"})
+	if !errors.Is(err, domain.ErrSMSLimitExceeded) {
+		t.Errorf("expected ErrSMSLimitExceeded, got %v", err)
+	}
+
+	// 其他号段不受影响
+	if err := guard.Admit(ctx, &SendCodeRequest{Phone: "13900139000", ClientIP: "10.0.0.9"}); err != nil {
+		t.Errorf("expected other prefix to be admitted, got %v", err)
+	}
+}
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		phone string
+		want  string
+	}{
+		{"13800138000", "8613800138000"},
+		{"+8613800138000", "8613800138000"},
+		{"+1 (415) 555-0100", "14155550100"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := normalizePhone(tt.phone, "86"); got != tt.want {
+			t.Errorf("normalizePhone(%q) = %q, want %q", tt.phone, got, tt.want)
+		}
+	}
+}
+
+func TestFallbackChain_ProviderDailyBudget(t *testing.T) {
+	config := newTestAbuseConfig()
+	config.ProviderDailyBudget = map[string]int64{"mock1": 2}
+	guard := NewAbuseGuard(config, newMemoryLimitRepo(), nil)
+
+	mock1 := NewMockProvider("mock1", true)
+	mock2 := NewMockProvider("mock2", true)
+	chain := NewFallbackChain([]Provider{mock1, mock2}, true)
+	chain.SetProviderGate(guard)
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		result, err := chain.Send(ctx, "13800138000", "123456")
+		if err != nil || result.Provider != "mock1" {
+			t.Fatalf("expected mock1 within budget, got %v %v", result, err)
+		}
+	}
+
+	result, err := chain.Send(ctx, "13800138000", "123456")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result.Provider != "mock2" || result.Attempts != 1 {
+		t.Errorf("expected mock1 skipped after budget exhausted, got %s after %d attempts", result.Provider, result.Attempts)
+	}
+}
+
+func TestFallbackChain_ProviderBudgetCountsOnlySuccessfulSends(t *testing.T) {
+	config := newTestAbuseConfig()
+	config.ProviderDailyBudget = map[string]int64{"mock1": 1}
+	guard := NewAbuseGuard(config, newMemoryLimitRepo(), nil)
+
+	mock1 := NewMockProvider("mock1", true)
+	mock1.SetShouldFail(true)
+	chain := NewFallbackChain([]Provider{mock1}, false)
+	chain.SetProviderGate(guard)
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		if _, err := chain.Send(ctx, "13800138000", "123456"); err == nil {
+			t.Fatal("expected failing provider to return error")
+		}
+	}
+
+	// 失败的发送不占用预算
+	mock1.SetShouldFail(false)
+	result, err := chain.Send(ctx, "13800138000", "123456")
+	if err != nil || result.Provider != "mock1" {
+		t.Fatalf("expected mock1 within budget after failed sends, got %v %v", result, err)
+	}
+
+	_, err = chain.Send(ctx, "13800138000", "123456")
+	if !errors.Is(err, ErrNoProviderAttempted) {
+		t.Errorf("expected budget exhausted after one successful send, got %v", err)
+	}
+}
+
+func TestFallbackChain_CancelledSendDoesNotConsumeBudget(t *testing.T) {
+	config := newTestAbuseConfig()
+	config.ProviderDailyBudget = map[string]int64{"mock1": 1}
+	guard := NewAbuseGuard(config, newMemoryLimitRepo(), nil)
+
+	mock1 := NewMockProvider("mock1", true)
+	chain := NewFallbackChain([]Provider{mock1}, true)
+	chain.SetProviderGate(guard)
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	for i := 0; i < 3; i++ {
+		if _, err := chain.Send(cancelled, "13800138000", "123456"); err != context.Canceled {
+			t.Fatalf("expected Canceled, got %v", err)
+		}
+	}
+
+	// 取消的请求不占用预算
+	result, err := chain.Send(context.Background(), "13800138000", "123456")
+	if err != nil || result.Provider != "mock1" {
+		t.Fatalf("expected mock1 within budget after cancelled sends, got %v %v", result, err)
+	}
+}
+
+func TestAbuseGuard_MissingFingerprintFallsBack(t *testing.T) {
+	config := newTestAbuseConfig()
+	config.IP = LimitRule{}
+	config.Prefix = LimitRule{}
+	config.Phone = LimitRule{}
+	guard := NewAbuseGuard(config, newMemoryLimitRepo(), nil)
+	ctx := context.Background()
+
+	// 未提供指纹时按IP计入设备维度
+	for i := 0; i < 2; i++ {
+		req := &SendCodeRequest{Phone: fmt.Sprintf("1380013800%d", i), ClientIP: "1.2.3.4"}
+		if err := guard.Admit(ctx, req); err != nil {
+			t.Fatalf("expected request %d to be admitted, got %v", i, err)
+		}
+	}
+	if err := guard.Admit(ctx, &SendCodeRequest{Phone: "13800138009", ClientIP: "1.2.3.4"}); !errors.Is(err, domain.ErrSMSLimitExceeded) {
+		t.Errorf("expected ErrSMSLimitExceeded without fingerprint, got %v", err)
+	}
+
+	// 既无指纹也无IP时按手机号计数
+	for i := 0; i < 2; i++ {
+		if err := guard.Admit(ctx, &SendCodeRequest{Phone: "13900139000"}); err != nil {
+			t.Fatalf("expected request %d to be admitted, got %v", i, err)
+		}
+	}
+	if err := guard.Admit(ctx, &SendCodeRequest{Phone: "13900139000"}); !errors.Is(err, domain.ErrSMSLimitExceeded) {
+		t.Errorf("expected ErrSMSLimitExceeded without fingerprint and IP, got %v", err)
+	}
+}
+
+// discardRecordRepo 丢弃发送记录（测试用）
+type discardRecordRepo struct {
+	repository.SMSRecordRepository
+}
+
+func (r *discardRecordRepo) Create(ctx context.Context, record *domain.SMSRecord) error {
+	return nil
+}
+
+func TestService_SendStepUpCodeLimitedPerPhone(t *testing.T) {
+	config := newTestAbuseConfig()
+	config.StepUp = LimitRule{Window: time.Hour, Max: 3}
+	mock := NewMockProvider("mock1", true)
+	service := &Service{
+		chain: NewFallbackChain([]Provider{mock}, true),
+		stats: NewStats(&discardRecordRepo{}),
+		guard: NewAbuseGuard(config, newMemoryLimitRepo(), nil),
+	}
+	ctx := context.Background()
+
+	// 每次登录创建新挑战，单个挑战的重发间隔不限制总量
+	for i := 0; i < 3; i++ {
+		if err := service.SendStepUpCode(ctx, "13800138000", "123456"); err != nil {
+			t.Fatalf("expected step-up send %d to succeed, got %v", i, err)
+		}
+	}
+	if err := service.SendStepUpCode(ctx, "+8613800138000", "123456"); !errors.Is(err, domain.ErrSMSLimitExceeded) {
+		t.Errorf("expected ErrSMSLimitExceeded for 4th step-up send, got %v", err)
+	}
+
+	// 其他号码不受影响，登录验证码的限制不计入
+	if err := service.SendStepUpCode(ctx, "13900139000", "123456"); err != nil {
+		t.Errorf("expected other phone to be admitted, got %v", err)
+	}
+	if err := service.guard.Admit(ctx, &SendCodeRequest{Phone: "13800138000", DeviceFingerprint: "fp-1"}); err != nil {
+		t.Errorf("expected login code send to be admitted, got %v", err)
+	}
+}
+
 // ===== Config Tests =====
 
 func TestNewConfig(t *testing.T) {
@@ -37,8 +37,8 @@ func NewStats(repo repository.SMSRecordRepository) *Stats {
 	}
 }
 
-// Record 记录发送结果
-func (s *Stats) Record(ctx context.Context, phone, provider string, success bool, errorMsg string) error {
+// Record 记录发送结果（每次调用提供商记录一条）
+func (s *Stats) Record(ctx context.Context, phone, provider string, success bool, errorMsg string, latency time.Duration) error {
 	// 创建记录
 	var record *domain.SMSRecord
 	if success {
@@ -46,6 +46,7 @@ func (s *Stats) Record(ctx context.Context, phone, provider string, success bool
 	} else {
 		record = domain.NewFailedSMSRecord(phone, provider, errorMsg)
 	}
+	record.LatencyMs = latency.Milliseconds()
 
 	// 验证
 	if err := record.Validate(); err != nil {
@@ -152,6 +153,11 @@ func (s *Stats) refreshStats(ctx context.Context) (*StatsInfo, error) {
 	}, nil
 }
 
+// ProviderHealth 按提供商汇总指定时间后的发送情况（所有实例）
+func (s *Stats) ProviderHealth(ctx context.Context, after time.Time) ([]*domain.SMSProviderHealth, error) {
+	return s.repo.ProviderHealth(ctx, after)
+}
+
 // copyProviderStats 复制提供商统计（避免外部修改）
 func (s *Stats) copyProviderStats(stats map[string]int64) map[string]int64 {
 	copy := make(map[string]int64, len(stats))
@@ -0,0 +1,203 @@
+package totp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+
+	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/domain"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/repository"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/crypto"
+)
+
+const (
+	// DefaultIssuer 身份验证器中显示的发行者名称
+	DefaultIssuer = "Listen Stream"
+	// usedCodeTTL 已使用验证码的记录时间（覆盖前后各一个30秒时间窗口）
+	usedCodeTTL = 90 * time.Second
+)
+
+// TOTPService 用户TOTP身份验证器服务接口
+//
+// 流程：
+// 1. 用户调用Setup获取密钥和otpauth链接，添加到身份验证器App
+// 2. 用户提交身份验证器中的验证码调用Confirm完成绑定
+// 3. 登录风控需要二次验证时，已绑定的用户使用TOTP验证（与登录短信相互独立）
+type TOTPService interface {
+	// Setup 生成待确认的密钥（已绑定时返回ErrTOTPAlreadyEnrolled）
+	Setup(ctx context.Context, userID string) (*Enrollment, error)
+
+	// Confirm 校验验证码并完成绑定
+	Confirm(ctx context.Context, userID, code string) error
+
+	// Disable 解除绑定（需要当前验证码）
+	Disable(ctx context.Context, userID, code string) error
+
+	// IsEnrolled 用户是否已绑定TOTP
+	IsEnrolled(ctx context.Context, userID string) (bool, error)
+
+	// Verify 校验已绑定用户的验证码，每个验证码只能使用一次
+	Verify(ctx context.Context, userID, code string) (bool, error)
+}
+
+// Enrollment 待确认的TOTP绑定信息
+type Enrollment struct {
+	Secret string `json:"secret"` // Base32密钥（手动输入）
+	URL    string `json:"url"`    // otpauth://链接（渲染为二维码）
+}
+
+// totpService TOTP服务实现
+type totpService struct {
+	issuer    string
+	repo      repository.UserTOTPRepository
+	usedCodes repository.TOTPUsedCodeRepository
+	userRepo  repository.UserRepository
+	cipher    *crypto.AESCipher
+	masker    *crypto.DataMasker
+	now       func() time.Time
+}
+
+// NewTOTPService 创建TOTP服务
+// cipher用于加密存储的密钥，issuer为空时使用DefaultIssuer
+func NewTOTPService(
+	issuer string,
+	repo repository.UserTOTPRepository,
+	usedCodes repository.TOTPUsedCodeRepository,
+	userRepo repository.UserRepository,
+	cipher *crypto.AESCipher,
+) TOTPService {
+	if issuer == "" {
+		issuer = DefaultIssuer
+	}
+	return &totpService{
+		issuer:    issuer,
+		repo:      repo,
+		usedCodes: usedCodes,
+		userRepo:  userRepo,
+		cipher:    cipher,
+		masker:    crypto.NewDataMasker(),
+		now:       time.Now,
+	}
+}
+
+// Setup 生成待确认的密钥
+func (s *totpService) Setup(ctx context.Context, userID string) (*Enrollment, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      s.issuer,
+		AccountName: s.masker.MaskPhone(user.Phone),
+		SecretSize:  20, // 160 bits
+	})
+	if err != nil {
+		return nil, fmt.Errorf("generate totp secret: %w", err)
+	}
+
+	encrypted, err := s.cipher.EncryptString(key.Secret())
+	if err != nil {
+		return nil, fmt.Errorf("encrypt totp secret: %w", err)
+	}
+
+	pending := domain.NewUserTOTP(userID, encrypted)
+	pending.CreatedAt = s.now()
+	pending.UpdatedAt = pending.CreatedAt
+	if err := s.repo.SavePending(ctx, pending); err != nil {
+		return nil, err
+	}
+
+	return &Enrollment{Secret: key.Secret(), URL: key.URL()}, nil
+}
+
+// Confirm 校验验证码并完成绑定
+func (s *totpService) Confirm(ctx context.Context, userID, code string) error {
+	record, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if record.IsEnabled() {
+		return domain.ErrTOTPAlreadyEnrolled
+	}
+
+	valid, err := s.check(ctx, record, code)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return domain.ErrTOTPInvalidCode
+	}
+
+	return s.repo.Enable(ctx, userID, s.now())
+}
+
+// Disable 解除绑定
+func (s *totpService) Disable(ctx context.Context, userID, code string) error {
+	valid, err := s.Verify(ctx, userID, code)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return domain.ErrTOTPInvalidCode
+	}
+	return s.repo.Delete(ctx, userID)
+}
+
+// IsEnrolled 用户是否已绑定TOTP
+func (s *totpService) IsEnrolled(ctx context.Context, userID string) (bool, error) {
+	record, err := s.repo.Get(ctx, userID)
+	if errors.Is(err, domain.ErrTOTPNotEnrolled) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return record.IsEnabled(), nil
+}
+
+// Verify 校验已绑定用户的验证码
+func (s *totpService) Verify(ctx context.Context, userID, code string) (bool, error) {
+	record, err := s.repo.Get(ctx, userID)
+	if errors.Is(err, domain.ErrTOTPNotEnrolled) {
+		return false, err
+	}
+	if err != nil {
+		return false, fmt.Errorf("get totp: %w", err)
+	}
+	if !record.IsEnabled() {
+		return false, domain.ErrTOTPNotEnrolled
+	}
+	return s.check(ctx, record, code)
+}
+
+// check 校验验证码（允许前后各一个时间窗口的时钟偏差），通过后记录为已使用
+func (s *totpService) check(ctx context.Context, record *domain.UserTOTP, code string) (bool, error) {
+	secret, err := s.cipher.DecryptString(record.Secret)
+	if err != nil {
+		return false, fmt.Errorf("decrypt totp secret: %w", err)
+	}
+
+	valid, err := totp.ValidateCustom(code, secret, s.now(), totp.ValidateOpts{
+		Period:    30,
+		Skew:      1,
+		Digits:    otp.DigitsSix,
+		Algorithm: otp.AlgorithmSHA1,
+	})
+	if err != nil || !valid {
+		return false, nil
+	}
+
+	fresh, err := s.usedCodes.MarkUsed(ctx, record.UserID, code, usedCodeTTL)
+	if err != nil {
+		return false, fmt.Errorf("record totp code: %w", err)
+	}
+	return fresh, nil
+}
@@ -0,0 +1,234 @@
+package totp
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/domain"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/services/auth-svc/internal/repository"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/crypto"
+)
+
+// memoryTOTPRepository 内存TOTP仓储（用于测试）
+type memoryTOTPRepository struct {
+	mu      sync.Mutex
+	records map[string]domain.UserTOTP
+}
+
+func (r *memoryTOTPRepository) Get(ctx context.Context, userID string) (*domain.UserTOTP, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	record, ok := r.records[userID]
+	if !ok {
+		return nil, domain.ErrTOTPNotEnrolled
+	}
+	return &record, nil
+}
+
+func (r *memoryTOTPRepository) SavePending(ctx context.Context, t *domain.UserTOTP) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.records[t.UserID]; ok && existing.IsEnabled() {
+		return domain.ErrTOTPAlreadyEnrolled
+	}
+	r.records[t.UserID] = *t
+	return nil
+}
+
+func (r *memoryTOTPRepository) Enable(ctx context.Context, userID string, enabledAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	record, ok := r.records[userID]
+	if !ok || record.IsEnabled() {
+		return domain.ErrTOTPNotEnrolled
+	}
+	record.EnabledAt = &enabledAt
+	r.records[userID] = record
+	return nil
+}
+
+func (r *memoryTOTPRepository) Delete(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.records, userID)
+	return nil
+}
+
+// memoryUsedCodeRepository 内存已使用验证码仓储（不处理过期）
+type memoryUsedCodeRepository struct {
+	mu   sync.Mutex
+	used map[string]bool
+}
+
+func (r *memoryUsedCodeRepository) MarkUsed(ctx context.Context, userID, code string, ttl time.Duration) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := userID + ":" + code
+	if r.used[key] {
+		return false, nil
+	}
+	r.used[key] = true
+	return true, nil
+}
+
+// stubUserRepository 只实现GetByID
+type stubUserRepository struct {
+	repository.UserRepository
+}
+
+func (r *stubUserRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	if id != "user-123" {
+		return nil, nil
+	}
+	return &domain.User{ID: id, Phone: "13800138000", IsActive: true}, nil
+}
+
+type testEnv struct {
+	service *totpService
+	repo    *memoryTOTPRepository
+	now     time.Time
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	cipher, err := crypto.NewAES256Cipher(crypto.MustGenerateAES256Key())
+	require.NoError(t, err)
+
+	env := &testEnv{
+		repo: &memoryTOTPRepository{records: make(map[string]domain.UserTOTP)},
+		now:  time.Now(),
+	}
+	env.service = NewTOTPService(
+		"",
+		env.repo,
+		&memoryUsedCodeRepository{used: make(map[string]bool)},
+		&stubUserRepository{},
+		cipher,
+	).(*totpService)
+	env.service.now = func() time.Time { return env.now }
+	return env
+}
+
+// enroll 完成绑定并返回密钥
+func (env *testEnv) enroll(t *testing.T) string {
+	ctx := context.Background()
+	enrollment, err := env.service.Setup(ctx, "user-123")
+	require.NoError(t, err)
+
+	code, err := totp.GenerateCode(enrollment.Secret, env.now)
+	require.NoError(t, err)
+	require.NoError(t, env.service.Confirm(ctx, "user-123", code))
+
+	// 后续验证使用下一个时间窗口，避免与确认时的验证码重复
+	env.now = env.now.Add(30 * time.Second)
+	return enrollment.Secret
+}
+
+// TestSetup_SecretEncrypted 测试密钥加密存储且绑定前不生效
+func TestSetup_SecretEncrypted(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	enrollment, err := env.service.Setup(ctx, "user-123")
+	require.NoError(t, err)
+	assert.Contains(t, enrollment.URL, "otpauth://totp/")
+	assert.Contains(t, enrollment.URL, "issuer=Listen")
+	assert.NotContains(t, enrollment.URL, "13800138000")
+
+	record, err := env.repo.Get(ctx, "user-123")
+	require.NoError(t, err)
+	assert.NotEqual(t, enrollment.Secret, record.Secret)
+
+	enrolled, err := env.service.IsEnrolled(ctx, "user-123")
+	require.NoError(t, err)
+	assert.False(t, enrolled)
+
+	code, err := totp.GenerateCode(enrollment.Secret, env.now)
+	require.NoError(t, err)
+	_, err = env.service.Verify(ctx, "user-123", code)
+	assert.ErrorIs(t, err, domain.ErrTOTPNotEnrolled)
+}
+
+// TestConfirm_InvalidCode 测试错误验证码无法完成绑定
+func TestConfirm_InvalidCode(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	_, err := env.service.Setup(ctx, "user-123")
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, env.service.Confirm(ctx, "user-123", "000000"), domain.ErrTOTPInvalidCode)
+	enrolled, err := env.service.IsEnrolled(ctx, "user-123")
+	require.NoError(t, err)
+	assert.False(t, enrolled)
+}
+
+// TestSetup_AlreadyEnrolled 测试已绑定时不能覆盖密钥
+func TestSetup_AlreadyEnrolled(t *testing.T) {
+	env := newTestEnv(t)
+	env.enroll(t)
+
+	_, err := env.service.Setup(context.Background(), "user-123")
+	assert.ErrorIs(t, err, domain.ErrTOTPAlreadyEnrolled)
+}
+
+// TestVerify_ReplayRejected 测试同一验证码只能使用一次
+func TestVerify_ReplayRejected(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	secret := env.enroll(t)
+
+	code, err := totp.GenerateCode(secret, env.now)
+	require.NoError(t, err)
+
+	valid, err := env.service.Verify(ctx, "user-123", code)
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	valid, err = env.service.Verify(ctx, "user-123", code)
+	require.NoError(t, err)
+	assert.False(t, valid)
+}
+
+// TestVerify_ClockSkew 测试允许前后一个时间窗口的偏差
+func TestVerify_ClockSkew(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	secret := env.enroll(t)
+
+	previous, err := totp.GenerateCode(secret, env.now.Add(-30*time.Second))
+	require.NoError(t, err)
+	stale, err := totp.GenerateCode(secret, env.now.Add(-2*time.Minute))
+	require.NoError(t, err)
+
+	valid, err := env.service.Verify(ctx, "user-123", stale)
+	require.NoError(t, err)
+	assert.False(t, valid)
+
+	// 上一个窗口的验证码在确认时已使用过
+	valid, err = env.service.Verify(ctx, "user-123", previous)
+	require.NoError(t, err)
+	assert.False(t, valid)
+}
+
+// TestDisable 测试解除绑定需要有效验证码
+func TestDisable(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	secret := env.enroll(t)
+
+	assert.ErrorIs(t, env.service.Disable(ctx, "user-123", "000000"), domain.ErrTOTPInvalidCode)
+
+	code, err := totp.GenerateCode(secret, env.now)
+	require.NoError(t, err)
+	require.NoError(t, env.service.Disable(ctx, "user-123", code))
+
+	enrolled, err := env.service.IsEnrolled(ctx, "user-123")
+	require.NoError(t, err)
+	assert.False(t, enrolled)
+}
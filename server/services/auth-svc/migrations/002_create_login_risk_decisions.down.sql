@@ -0,0 +1,4 @@
+-- 002_create_login_risk_decisions.down.sql
+-- 回滚登录风控决策记录表
+
+DROP TABLE IF EXISTS login_risk_decisions;
@@ -0,0 +1,27 @@
+-- 002_create_login_risk_decisions.up.sql
+-- 登录风控决策记录表
+
+CREATE TABLE IF NOT EXISTS login_risk_decisions (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    device_id VARCHAR(100) NOT NULL,
+    fingerprint VARCHAR(64) NOT NULL,
+    client_ip VARCHAR(45) NOT NULL,
+    country VARCHAR(64) NOT NULL DEFAULT '',
+    city VARCHAR(64) NOT NULL DEFAULT '',
+    latitude DOUBLE PRECISION,
+    longitude DOUBLE PRECISION,
+    score INTEGER NOT NULL DEFAULT 0,
+    action VARCHAR(20) NOT NULL,
+    reasons TEXT[] NOT NULL DEFAULT '{}',
+    outcome VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    resolved_at TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- 按用户查询最近登录（登录历史、频率统计）
+CREATE INDEX idx_login_risk_decisions_user_created ON login_risk_decisions(user_id, created_at DESC);
+CREATE INDEX idx_login_risk_decisions_action ON login_risk_decisions(action);
+
+COMMENT ON TABLE login_risk_decisions IS '登录风控决策记录表';
@@ -0,0 +1,5 @@
+-- 003_add_sms_record_latency.down.sql
+-- 回滚短信发送记录耗时字段
+
+DROP INDEX IF EXISTS idx_sms_records_provider_created_at;
+ALTER TABLE sms_records DROP COLUMN IF EXISTS latency_ms;
@@ -0,0 +1,7 @@
+-- 003_add_sms_record_latency.up.sql
+-- 短信发送记录增加耗时（用于提供商健康评分与自适应路由）
+
+ALTER TABLE sms_records ADD COLUMN IF NOT EXISTS latency_ms INTEGER NOT NULL DEFAULT 0;
+
+-- 按提供商统计最近发送情况
+CREATE INDEX IF NOT EXISTS idx_sms_records_provider_created_at ON sms_records(provider, created_at);
@@ -0,0 +1,4 @@
+-- 004_create_account_deletions.down.sql
+-- 回滚账号注销请求表
+
+DROP TABLE IF EXISTS account_deletions;
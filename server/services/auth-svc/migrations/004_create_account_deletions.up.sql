@@ -0,0 +1,29 @@
+-- 004_create_account_deletions.up.sql
+-- 账号注销请求表（冷静期、撤销、分步删除各服务数据）
+
+CREATE TABLE IF NOT EXISTS account_deletions (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    completed_steps TEXT[] NOT NULL DEFAULT '{}',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    requested_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    scheduled_at TIMESTAMP NOT NULL,
+    next_attempt_at TIMESTAMP NOT NULL,
+    cancelled_at TIMESTAMP,
+    completed_at TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- 每个用户同时只能有一个进行中的注销请求
+-- 注意：不对users表建外键，用户删除后仍保留注销记录作为删除凭证
+CREATE UNIQUE INDEX idx_account_deletions_user_active ON account_deletions(user_id)
+    WHERE status IN ('pending', 'processing');
+CREATE INDEX idx_account_deletions_user_requested ON account_deletions(user_id, requested_at DESC);
+
+-- 定时任务按执行时间领取到期请求
+CREATE INDEX idx_account_deletions_due ON account_deletions(next_attempt_at)
+    WHERE status IN ('pending', 'processing');
+
+COMMENT ON TABLE account_deletions IS '账号注销请求表';
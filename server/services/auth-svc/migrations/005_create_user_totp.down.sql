@@ -0,0 +1,4 @@
+-- 005_create_user_totp.down.sql
+-- 回滚用户TOTP身份验证器表
+
+DROP TABLE IF EXISTS user_totp;
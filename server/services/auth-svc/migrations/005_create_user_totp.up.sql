@@ -0,0 +1,13 @@
+-- 005_create_user_totp.up.sql
+-- 用户TOTP身份验证器（登录风控二次验证）
+
+CREATE TABLE IF NOT EXISTS user_totp (
+    user_id VARCHAR(36) PRIMARY KEY,
+    secret TEXT NOT NULL,
+    enabled_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+COMMENT ON TABLE user_totp IS '用户TOTP身份验证器';
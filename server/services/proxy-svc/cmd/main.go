@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	consulapi "github.com/hashicorp/consul/api"
 	"github.com/redis/go-redis/v9"
 	"github.com/xiaoxiao0301/listen-stream-v2/server/services/proxy-svc/internal/cache"
 	"github.com/xiaoxiao0301/listen-stream-v2/server/services/proxy-svc/internal/client"
@@ -17,7 +18,9 @@ import (
 	"github.com/xiaoxiao0301/listen-stream-v2/server/services/proxy-svc/internal/middleware"
 	"github.com/xiaoxiao0301/listen-stream-v2/server/services/proxy-svc/internal/upstream"
 	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/consul"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/featureflag"
 	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/logger"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/stats"
 )
 
 func main() {
@@ -69,6 +72,10 @@ func main() {
 		log.Warn("Failed to create Consul service discovery, using static addresses", logger.String("error", err.Error()))
 	}
 
+	// 功能开关（Consul KV，后台监听变更；Consul不可用时不影响启动）
+	flagClient := newFeatureFlagClient(consulAddr, log)
+	defer flagClient.Close()
+
 	// 初始化gRPC客户端池
 	grpcPool := client.NewClientPool(log)
 	defer grpcPool.Close()
@@ -187,7 +194,14 @@ func main() {
 		}
 	}
 
-	router := setupRouter(fallbackManager, authClient, userClient, cacheLayer, healthChecker, log)
+	// 请求量、失败率和延迟计入admin-svc的运营统计
+	statsConfig := stats.DefaultConfig()
+	statsConfig.OnError = func(err error) {
+		log.Warn("Failed to write request stats", logger.String("error", err.Error()))
+	}
+	statsEmitter := stats.NewRedisEmitter(redisClient, statsConfig)
+
+	router := setupRouter(fallbackManager, fallbackManager, authClient, userClient, cacheLayer, healthChecker, statsEmitter, flagClient, log)
 
 	// 启动HTTP服务器
 	httpAddr := getEnv("HTTP_ADDR", ":8002")
@@ -231,7 +245,8 @@ func main() {
 		log.Error("Failed to shutdown HTTP server gracefully", logger.String("error", err.Error()))
 	}
 
-	// 清理
+	// 清理（先写出缓冲中的统计事件）
+	statsEmitter.Close()
 	if err := redisClient.Close(); err != nil {
 		log.Error("Failed to close Redis client", logger.String("error", err.Error()))
 	}
@@ -242,10 +257,13 @@ func main() {
 // setupRouter 设置路由
 func setupRouter(
 	upstreamClient upstream.ClientInterface,
+	playlistResolver handler.PlaylistResolver,
 	authClient *client.AuthClient,
 	userClient *client.UserClient,
 	cacheLayer *cache.CacheLayer,
 	healthChecker *HealthChecker,
+	statsEmitter stats.Emitter,
+	flagClient *featureflag.Client,
 	log logger.Logger,
 ) *gin.Engine {
 	// 生产模式
@@ -263,6 +281,7 @@ func setupRouter(
 	router.Use(middleware.CORS())                    // 5. 跨域处理
 	rateLimiter := middleware.NewRateLimiter(100, 200, 500, 1000) // IP: 100/s, User: 500/s
 	router.Use(rateLimiter.Limit())                  // 6. 速率限制
+	router.Use(stats.GinMiddleware(statsEmitter, "user_id")) // 7. 请求统计（在c.Next()之后读取认证中间件设置的user_id）
 
 	// 初始化handler (使用FallbackManager，支持多源fallback)
 	h := handler.NewHandler(upstreamClient, log)
@@ -270,7 +289,7 @@ func setupRouter(
 	// 初始化用户handler（如果userClient可用）
 	var userHandler *handler.UserHandler
 	if userClient != nil {
-		userHandler = handler.NewUserHandler(userClient, log)
+		userHandler = handler.NewUserHandler(userClient, playlistResolver, log)
 	}
 
 	// JWT密钥（生产环境应该从配置中心读取）
@@ -351,6 +370,7 @@ func setupRouter(
 			song.GET("/detail", h.GetSongDetail)
 			song.GET("/url", h.GetSongURL) // 播放URL（带Fallback）
 			song.GET("/lyric", h.GetLyric)
+			song.GET("/similar", h.GetSimilarSongs)
 		}
 
 		// 搜索模块
@@ -364,6 +384,15 @@ func setupRouter(
 		}
 	}
 
+	// ===== 可选认证的API（登录后按用户求值） =====
+	bootstrapHandler := handler.NewBootstrapHandler(flagClient)
+	optional := router.Group("/api")
+	optional.Use(middleware.OptionalAuth(jwtSecret, log))
+	{
+		// 客户端启动配置（功能开关）
+		optional.GET("/bootstrap", bootstrapHandler.GetBootstrap)
+	}
+
 	// ===== 需要认证的API =====
 	authenticated := router.Group("/api")
 	authenticated.Use(middleware.RequiredAuth(jwtSecret, log))
@@ -379,6 +408,7 @@ func setupRouter(
 				user.POST("/favorites", userHandler.AddFavorite)
 				user.DELETE("/favorites/:song_id", userHandler.RemoveFavorite)
 				user.GET("/favorites", userHandler.ListFavorites)
+				user.GET("/singers/new-releases", userHandler.ListNewReleases)
 
 				// 播放历史
 				user.POST("/history", userHandler.AddPlayHistory)
@@ -389,11 +419,51 @@ func setupRouter(
 				user.PUT("/playlists/:playlist_id", userHandler.UpdatePlaylist)
 				user.DELETE("/playlists/:playlist_id", userHandler.DeletePlaylist)
 				user.GET("/playlists", userHandler.ListPlaylists)
+				user.POST("/playlists/import", userHandler.ImportPlaylist)
+				user.GET("/playlists/:playlist_id", userHandler.GetPlaylist)
+				user.GET("/playlists/:playlist_id/export", userHandler.ExportPlaylist)
+
+				// 公开歌单：浏览、关注、复制
+				user.GET("/playlists/public", userHandler.ListPublicPlaylists)
+				user.GET("/playlists/public/:playlist_id", userHandler.GetPublicPlaylist)
+				user.GET("/playlists/following", userHandler.ListFollowedPlaylists)
+				user.POST("/playlists/:playlist_id/follow", userHandler.FollowPlaylist)
+				user.DELETE("/playlists/:playlist_id/follow", userHandler.UnfollowPlaylist)
+				user.POST("/playlists/:playlist_id/fork", userHandler.ForkPlaylist)
+
+				// 协作歌单：成员管理和分享链接
+				user.GET("/playlists/shared", userHandler.ListSharedPlaylists)
+				user.GET("/playlists/:playlist_id/members", userHandler.ListPlaylistMembers)
+				user.PUT("/playlists/:playlist_id/members/:member_id", userHandler.InvitePlaylistMember)
+				user.DELETE("/playlists/:playlist_id/members/:member_id", userHandler.RemovePlaylistMember)
+				user.POST("/playlists/:playlist_id/invites", userHandler.CreatePlaylistInvite)
+				user.DELETE("/playlists/:playlist_id/invites", userHandler.RevokePlaylistInvites)
+				user.POST("/playlists/invites/:token/accept", userHandler.AcceptPlaylistInvite)
+
+				// 回收站：最近删除的歌单和收藏
+				user.GET("/trash/playlists", userHandler.ListDeletedPlaylists)
+				user.GET("/trash/favorites", userHandler.ListDeletedFavorites)
+				user.POST("/playlists/:playlist_id/restore", userHandler.RestorePlaylist)
+				user.POST("/favorites/:favorite_id/restore", userHandler.RestoreFavorite)
 
 				// 歌单歌曲管理
 				user.POST("/playlists/:playlist_id/songs", userHandler.AddSongToPlaylist)
 				user.DELETE("/playlists/:playlist_id/songs/:song_id", userHandler.RemoveSongFromPlaylist)
 				user.GET("/playlists/:playlist_id/songs", userHandler.GetPlaylistSongs)
+				user.POST("/playlists/:playlist_id/songs/batch", userHandler.AddSongsToPlaylist)
+				user.POST("/playlists/:playlist_id/songs/batch-remove", userHandler.RemoveSongsFromPlaylist)
+				user.PUT("/playlists/:playlist_id/songs/:song_id/position", userHandler.MovePlaylistSong)
+				user.POST("/playlists/:playlist_id/songs/sort", userHandler.SortPlaylistSongs)
+
+				// 个性化推荐
+				user.GET("/recommendations", userHandler.GetRecommendations)
+
+				// 播放队列和播放进度（多设备同步）
+				user.GET("/playback/queue", userHandler.GetPlayQueue)
+				user.PUT("/playback/queue", userHandler.SavePlayQueue)
+				user.PUT("/playback/queue/current", userHandler.SetPlayQueueIndex)
+				user.GET("/playback/positions", userHandler.GetPlaybackPositions)
+				user.PUT("/playback/positions/:song_id", userHandler.UpdatePlaybackPosition)
 			}
 		}
 	}
@@ -454,6 +524,23 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// newFeatureFlagClient 创建功能开关客户端，失败时返回nil（所有开关使用代码中的默认值）
+func newFeatureFlagClient(consulAddr string, log logger.Logger) *featureflag.Client {
+	consulConfig := consulapi.DefaultConfig()
+	consulConfig.Address = consulAddr
+	consulClient, err := consulapi.NewClient(consulConfig)
+	if err != nil {
+		log.Warn("Failed to create Consul client, feature flags disabled", logger.String("error", err.Error()))
+		return nil
+	}
+
+	flagConfig := featureflag.DefaultConfig()
+	flagConfig.OnError = func(err error) {
+		log.Warn("Failed to load feature flags", logger.String("error", err.Error()))
+	}
+	return featureflag.NewClient(featureflag.NewConsulSource(consulClient, "listen-stream/"), flagConfig)
+}
+
 // getServiceAddr 获取服务地址（优先使用Consul服务发现）
 func getServiceAddr(discovery *consul.ServiceDiscovery, serviceName, fallbackAddr string, log logger.Logger) string {
 	if discovery != nil {
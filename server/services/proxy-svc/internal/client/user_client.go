@@ -150,15 +150,17 @@ func (c *UserClient) ListPlayHistory(ctx context.Context, userID string, page, s
 }
 
 // CreatePlaylist 创建歌单
-func (c *UserClient) CreatePlaylist(ctx context.Context, userID, name, cover string, isPublic bool) (*userv1.CreatePlaylistResponse, error) {
+// smartRules为JSON格式的智能歌单规则，为空时创建普通歌单
+func (c *UserClient) CreatePlaylist(ctx context.Context, userID, name, cover string, isPublic bool, smartRules string) (*userv1.CreatePlaylistResponse, error) {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
 	req := &userv1.CreatePlaylistRequest{
-		UserId:   userID,
-		Name:     name,
-		CoverUrl: cover,
-		IsPublic: isPublic,
+		UserId:     userID,
+		Name:       name,
+		CoverUrl:   cover,
+		IsPublic:   isPublic,
+		SmartRules: smartRules,
 	}
 
 	resp, err := c.client.CreatePlaylist(ctx, req)
@@ -174,7 +176,8 @@ func (c *UserClient) CreatePlaylist(ctx context.Context, userID, name, cover str
 }
 
 // UpdatePlaylist 更新歌单
-func (c *UserClient) UpdatePlaylist(ctx context.Context, userID, playlistID, name, cover string, isPublic *bool) error {
+// smartRules为JSON格式的智能歌单规则，为空时不修改
+func (c *UserClient) UpdatePlaylist(ctx context.Context, userID, playlistID, name, cover string, isPublic *bool, smartRules string) error {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
@@ -183,6 +186,7 @@ func (c *UserClient) UpdatePlaylist(ctx context.Context, userID, playlistID, nam
 		PlaylistId: playlistID,
 		Name:       name,
 		CoverUrl:   cover,
+		SmartRules: smartRules,
 	}
 
 	if isPublic != nil {
@@ -291,19 +295,142 @@ func (c *UserClient) RemoveSongFromPlaylist(ctx context.Context, userID, playlis
 	return nil
 }
 
-// GetPlaylistSongs 获取歌单歌曲列表
-func (c *UserClient) GetPlaylistSongs(ctx context.Context, playlistID string) (*userv1.GetPlaylistSongsResponse, error) {
+// AddSongsToPlaylist 批量添加歌曲到歌单
+func (c *UserClient) AddSongsToPlaylist(ctx context.Context, userID, playlistID string, songs []*userv1.ImportedSong) (*userv1.AddSongsToPlaylistResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req := &userv1.AddSongsToPlaylistRequest{
+		UserId:     userID,
+		PlaylistId: playlistID,
+		Songs:      songs,
+	}
+
+	resp, err := c.client.AddSongsToPlaylist(ctx, req)
+	if err != nil {
+		c.log.WithFields(
+			logger.String("error", err.Error()),
+			logger.String("user_id", userID),
+			logger.String("playlist_id", playlistID),
+		).Error("Failed to add songs to playlist via gRPC")
+		return nil, fmt.Errorf("add songs to playlist failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// RemoveSongsFromPlaylist 批量移除歌单歌曲
+func (c *UserClient) RemoveSongsFromPlaylist(ctx context.Context, userID, playlistID string, songIDs []string) (*userv1.RemoveSongsFromPlaylistResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req := &userv1.RemoveSongsFromPlaylistRequest{
+		UserId:     userID,
+		PlaylistId: playlistID,
+		SongIds:    songIDs,
+	}
+
+	resp, err := c.client.RemoveSongsFromPlaylist(ctx, req)
+	if err != nil {
+		c.log.WithFields(
+			logger.String("error", err.Error()),
+			logger.String("user_id", userID),
+			logger.String("playlist_id", playlistID),
+		).Error("Failed to remove songs from playlist via gRPC")
+		return nil, fmt.Errorf("remove songs from playlist failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// MovePlaylistSong 移动歌单歌曲位置
+func (c *UserClient) MovePlaylistSong(ctx context.Context, userID, playlistID, songID string, position int32) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	req := &userv1.MovePlaylistSongRequest{
+		UserId:     userID,
+		PlaylistId: playlistID,
+		SongId:     songID,
+		Position:   position,
+	}
+
+	_, err := c.client.MovePlaylistSong(ctx, req)
+	if err != nil {
+		c.log.WithFields(
+			logger.String("error", err.Error()),
+			logger.String("user_id", userID),
+			logger.String("playlist_id", playlistID),
+		).Error("Failed to move playlist song via gRPC")
+		return fmt.Errorf("move playlist song failed: %w", err)
+	}
+
+	return nil
+}
+
+// SortPlaylistSongs 重排歌单歌曲（sortBy: name/singer/added_at）
+func (c *UserClient) SortPlaylistSongs(ctx context.Context, userID, playlistID, sortBy string, descending bool) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	req := &userv1.SortPlaylistSongsRequest{
+		UserId:     userID,
+		PlaylistId: playlistID,
+		SortBy:     sortBy,
+		Descending: descending,
+	}
+
+	_, err := c.client.SortPlaylistSongs(ctx, req)
+	if err != nil {
+		c.log.WithFields(
+			logger.String("error", err.Error()),
+			logger.String("user_id", userID),
+			logger.String("playlist_id", playlistID),
+		).Error("Failed to sort playlist songs via gRPC")
+		return fmt.Errorf("sort playlist songs failed: %w", err)
+	}
+
+	return nil
+}
+
+// GetPlaylist 获取歌单详情（私有歌单只有所有者和协作成员可以查看）
+func (c *UserClient) GetPlaylist(ctx context.Context, userID, playlistID string) (*userv1.GetPlaylistResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	req := &userv1.GetPlaylistRequest{
+		UserId:     userID,
+		PlaylistId: playlistID,
+	}
+
+	resp, err := c.client.GetPlaylist(ctx, req)
+	if err != nil {
+		c.log.WithFields(
+			logger.String("error", err.Error()),
+			logger.String("user_id", userID),
+			logger.String("playlist_id", playlistID),
+		).Error("Failed to get playlist via gRPC")
+		return nil, fmt.Errorf("get playlist failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// GetPlaylistSongs 获取歌单歌曲列表（私有歌单只有所有者和协作成员可以查看）
+func (c *UserClient) GetPlaylistSongs(ctx context.Context, userID, playlistID string) (*userv1.GetPlaylistSongsResponse, error) {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
 	req := &userv1.GetPlaylistSongsRequest{
 		PlaylistId: playlistID,
+		UserId:     userID,
 	}
 
 	resp, err := c.client.GetPlaylistSongs(ctx, req)
 	if err != nil {
 		c.log.WithFields(
 			logger.String("error", err.Error()),
+			logger.String("user_id", userID),
 			logger.String("playlist_id", playlistID),
 		).Error("Failed to get playlist songs via gRPC")
 		return nil, fmt.Errorf("get playlist songs failed: %w", err)
@@ -311,3 +438,589 @@ func (c *UserClient) GetPlaylistSongs(ctx context.Context, playlistID string) (*
 
 	return resp, nil
 }
+
+// ListPlaylistMembers 获取歌单成员（包括所有者）
+func (c *UserClient) ListPlaylistMembers(ctx context.Context, userID, playlistID string) (*userv1.ListPlaylistMembersResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	req := &userv1.ListPlaylistMembersRequest{
+		UserId:     userID,
+		PlaylistId: playlistID,
+	}
+
+	resp, err := c.client.ListPlaylistMembers(ctx, req)
+	if err != nil {
+		c.log.WithFields(
+			logger.String("error", err.Error()),
+			logger.String("user_id", userID),
+			logger.String("playlist_id", playlistID),
+		).Error("Failed to list playlist members via gRPC")
+		return nil, fmt.Errorf("list playlist members failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// InvitePlaylistMember 邀请成员或修改成员角色（仅所有者）
+func (c *UserClient) InvitePlaylistMember(ctx context.Context, userID, playlistID, memberID, role string) (*userv1.InvitePlaylistMemberResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	req := &userv1.InvitePlaylistMemberRequest{
+		UserId:     userID,
+		PlaylistId: playlistID,
+		MemberId:   memberID,
+		Role:       role,
+	}
+
+	resp, err := c.client.InvitePlaylistMember(ctx, req)
+	if err != nil {
+		c.log.WithFields(
+			logger.String("error", err.Error()),
+			logger.String("user_id", userID),
+			logger.String("playlist_id", playlistID),
+			logger.String("member_id", memberID),
+		).Error("Failed to invite playlist member via gRPC")
+		return nil, fmt.Errorf("invite playlist member failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// RemovePlaylistMember 移除成员（所有者），或成员退出歌单
+func (c *UserClient) RemovePlaylistMember(ctx context.Context, userID, playlistID, memberID string) (*userv1.RemovePlaylistMemberResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	req := &userv1.RemovePlaylistMemberRequest{
+		UserId:     userID,
+		PlaylistId: playlistID,
+		MemberId:   memberID,
+	}
+
+	resp, err := c.client.RemovePlaylistMember(ctx, req)
+	if err != nil {
+		c.log.WithFields(
+			logger.String("error", err.Error()),
+			logger.String("user_id", userID),
+			logger.String("playlist_id", playlistID),
+			logger.String("member_id", memberID),
+		).Error("Failed to remove playlist member via gRPC")
+		return nil, fmt.Errorf("remove playlist member failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// CreatePlaylistInvite 生成分享链接邀请（仅所有者）
+func (c *UserClient) CreatePlaylistInvite(ctx context.Context, userID, playlistID, role string) (*userv1.CreatePlaylistInviteResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	req := &userv1.CreatePlaylistInviteRequest{
+		UserId:     userID,
+		PlaylistId: playlistID,
+		Role:       role,
+	}
+
+	resp, err := c.client.CreatePlaylistInvite(ctx, req)
+	if err != nil {
+		c.log.WithFields(
+			logger.String("error", err.Error()),
+			logger.String("user_id", userID),
+			logger.String("playlist_id", playlistID),
+		).Error("Failed to create playlist invite via gRPC")
+		return nil, fmt.Errorf("create playlist invite failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// RevokePlaylistInvites 撤销歌单的所有分享链接（仅所有者）
+func (c *UserClient) RevokePlaylistInvites(ctx context.Context, userID, playlistID string) (*userv1.RevokePlaylistInvitesResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	req := &userv1.RevokePlaylistInvitesRequest{
+		UserId:     userID,
+		PlaylistId: playlistID,
+	}
+
+	resp, err := c.client.RevokePlaylistInvites(ctx, req)
+	if err != nil {
+		c.log.WithFields(
+			logger.String("error", err.Error()),
+			logger.String("user_id", userID),
+			logger.String("playlist_id", playlistID),
+		).Error("Failed to revoke playlist invites via gRPC")
+		return nil, fmt.Errorf("revoke playlist invites failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// AcceptPlaylistInvite 通过分享链接加入歌单
+func (c *UserClient) AcceptPlaylistInvite(ctx context.Context, userID, token string) (*userv1.AcceptPlaylistInviteResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	req := &userv1.AcceptPlaylistInviteRequest{
+		UserId: userID,
+		Token:  token,
+	}
+
+	resp, err := c.client.AcceptPlaylistInvite(ctx, req)
+	if err != nil {
+		c.log.WithFields(
+			logger.String("error", err.Error()),
+			logger.String("user_id", userID),
+		).Error("Failed to accept playlist invite via gRPC")
+		return nil, fmt.Errorf("accept playlist invite failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// ListSharedPlaylists 获取用户参与协作的歌单
+func (c *UserClient) ListSharedPlaylists(ctx context.Context, userID string, page, size int32) (*userv1.ListSharedPlaylistsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	req := &userv1.ListSharedPlaylistsRequest{
+		UserId:   userID,
+		Page:     page,
+		PageSize: size,
+	}
+
+	resp, err := c.client.ListSharedPlaylists(ctx, req)
+	if err != nil {
+		c.log.WithFields(
+			logger.String("error", err.Error()),
+			logger.String("user_id", userID),
+		).Error("Failed to list shared playlists via gRPC")
+		return nil, fmt.Errorf("list shared playlists failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// GetRecommendations 获取个性化推荐
+// 离线结果未覆盖的用户需要按需计算，超时时间比普通接口长
+func (c *UserClient) GetRecommendations(ctx context.Context, userID string) (*userv1.GetRecommendationsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req := &userv1.GetRecommendationsRequest{
+		UserId: userID,
+	}
+
+	resp, err := c.client.GetRecommendations(ctx, req)
+	if err != nil {
+		c.log.WithFields(
+			logger.String("error", err.Error()),
+			logger.String("user_id", userID),
+		).Error("Failed to get recommendations via gRPC")
+		return nil, fmt.Errorf("get recommendations failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// ListNewReleases 获取关注歌手的新专辑
+func (c *UserClient) ListNewReleases(ctx context.Context, userID string, page, size int32) (*userv1.ListNewReleasesResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	req := &userv1.ListNewReleasesRequest{
+		UserId:   userID,
+		Page:     page,
+		PageSize: size,
+	}
+
+	resp, err := c.client.ListNewReleases(ctx, req)
+	if err != nil {
+		c.log.WithFields(
+			logger.String("error", err.Error()),
+			logger.String("user_id", userID),
+		).Error("Failed to list new releases via gRPC")
+		return nil, fmt.Errorf("list new releases failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// ImportPlaylist 导入已匹配到曲库的外部歌单
+// 最多一次写入上千首歌曲，超时时间比普通接口长
+func (c *UserClient) ImportPlaylist(ctx context.Context, req *userv1.ImportPlaylistRequest) (*userv1.ImportPlaylistResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	resp, err := c.client.ImportPlaylist(ctx, req)
+	if err != nil {
+		c.log.WithFields(
+			logger.String("error", err.Error()),
+			logger.String("user_id", req.UserId),
+			logger.Int("songs", len(req.Songs)),
+		).Error("Failed to import playlist via gRPC")
+		return nil, fmt.Errorf("import playlist failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// ExportPlaylist 导出歌单文件（m3u8/xspf/json）
+func (c *UserClient) ExportPlaylist(ctx context.Context, userID, playlistID, format string) (*userv1.ExportPlaylistResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	req := &userv1.ExportPlaylistRequest{
+		UserId:     userID,
+		PlaylistId: playlistID,
+		Format:     format,
+	}
+
+	resp, err := c.client.ExportPlaylist(ctx, req)
+	if err != nil {
+		c.log.WithFields(
+			logger.String("error", err.Error()),
+			logger.String("user_id", userID),
+			logger.String("playlist_id", playlistID),
+		).Error("Failed to export playlist via gRPC")
+		return nil, fmt.Errorf("export playlist failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// ListPublicPlaylists 浏览公开歌单（sort: popular/recent）
+func (c *UserClient) ListPublicPlaylists(ctx context.Context, sort string, page, size int32) (*userv1.ListPublicPlaylistsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	req := &userv1.ListPublicPlaylistsRequest{
+		Sort:     sort,
+		Page:     page,
+		PageSize: size,
+	}
+
+	resp, err := c.client.ListPublicPlaylists(ctx, req)
+	if err != nil {
+		c.log.WithFields(
+			logger.String("error", err.Error()),
+			logger.String("sort", sort),
+		).Error("Failed to list public playlists via gRPC")
+		return nil, fmt.Errorf("list public playlists failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// GetPublicPlaylist 获取公开歌单详情
+func (c *UserClient) GetPublicPlaylist(ctx context.Context, userID, playlistID string) (*userv1.GetPublicPlaylistResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	req := &userv1.GetPublicPlaylistRequest{
+		UserId:     userID,
+		PlaylistId: playlistID,
+	}
+
+	resp, err := c.client.GetPublicPlaylist(ctx, req)
+	if err != nil {
+		c.log.WithFields(
+			logger.String("error", err.Error()),
+			logger.String("playlist_id", playlistID),
+		).Error("Failed to get public playlist via gRPC")
+		return nil, fmt.Errorf("get public playlist failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// FollowPlaylist 关注公开歌单
+func (c *UserClient) FollowPlaylist(ctx context.Context, userID, playlistID string) (*userv1.FollowPlaylistResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	req := &userv1.FollowPlaylistRequest{
+		UserId:     userID,
+		PlaylistId: playlistID,
+	}
+
+	resp, err := c.client.FollowPlaylist(ctx, req)
+	if err != nil {
+		c.log.WithFields(
+			logger.String("error", err.Error()),
+			logger.String("user_id", userID),
+			logger.String("playlist_id", playlistID),
+		).Error("Failed to follow playlist via gRPC")
+		return nil, fmt.Errorf("follow playlist failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// UnfollowPlaylist 取消关注歌单
+func (c *UserClient) UnfollowPlaylist(ctx context.Context, userID, playlistID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	req := &userv1.UnfollowPlaylistRequest{
+		UserId:     userID,
+		PlaylistId: playlistID,
+	}
+
+	_, err := c.client.UnfollowPlaylist(ctx, req)
+	if err != nil {
+		c.log.WithFields(
+			logger.String("error", err.Error()),
+			logger.String("user_id", userID),
+			logger.String("playlist_id", playlistID),
+		).Error("Failed to unfollow playlist via gRPC")
+		return fmt.Errorf("unfollow playlist failed: %w", err)
+	}
+
+	return nil
+}
+
+// ListFollowedPlaylists 获取用户关注的歌单
+func (c *UserClient) ListFollowedPlaylists(ctx context.Context, userID string, page, size int32) (*userv1.ListFollowedPlaylistsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	req := &userv1.ListFollowedPlaylistsRequest{
+		UserId:   userID,
+		Page:     page,
+		PageSize: size,
+	}
+
+	resp, err := c.client.ListFollowedPlaylists(ctx, req)
+	if err != nil {
+		c.log.WithFields(
+			logger.String("error", err.Error()),
+			logger.String("user_id", userID),
+		).Error("Failed to list followed playlists via gRPC")
+		return nil, fmt.Errorf("list followed playlists failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// ForkPlaylist 复制歌单到自己的歌单（需要复制全部歌曲，超时时间比普通接口长）
+func (c *UserClient) ForkPlaylist(ctx context.Context, userID, playlistID, name string) (*userv1.ForkPlaylistResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req := &userv1.ForkPlaylistRequest{
+		UserId:     userID,
+		PlaylistId: playlistID,
+		Name:       name,
+	}
+
+	resp, err := c.client.ForkPlaylist(ctx, req)
+	if err != nil {
+		c.log.WithFields(
+			logger.String("error", err.Error()),
+			logger.String("user_id", userID),
+			logger.String("playlist_id", playlistID),
+		).Error("Failed to fork playlist via gRPC")
+		return nil, fmt.Errorf("fork playlist failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// ListDeletedPlaylists 获取回收站中的歌单
+func (c *UserClient) ListDeletedPlaylists(ctx context.Context, userID string, page, size int32) (*userv1.ListDeletedPlaylistsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	req := &userv1.ListDeletedPlaylistsRequest{
+		UserId:   userID,
+		Page:     page,
+		PageSize: size,
+	}
+
+	resp, err := c.client.ListDeletedPlaylists(ctx, req)
+	if err != nil {
+		c.log.WithFields(
+			logger.String("error", err.Error()),
+			logger.String("user_id", userID),
+		).Error("Failed to list deleted playlists via gRPC")
+		return nil, fmt.Errorf("list deleted playlists failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// ListDeletedFavorites 获取回收站中的收藏
+func (c *UserClient) ListDeletedFavorites(ctx context.Context, userID string, page, size int32) (*userv1.ListDeletedFavoritesResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	req := &userv1.ListDeletedFavoritesRequest{
+		UserId:   userID,
+		Page:     page,
+		PageSize: size,
+	}
+
+	resp, err := c.client.ListDeletedFavorites(ctx, req)
+	if err != nil {
+		c.log.WithFields(
+			logger.String("error", err.Error()),
+			logger.String("user_id", userID),
+		).Error("Failed to list deleted favorites via gRPC")
+		return nil, fmt.Errorf("list deleted favorites failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// RestorePlaylist 从回收站恢复歌单
+func (c *UserClient) RestorePlaylist(ctx context.Context, userID, playlistID string) (*userv1.RestorePlaylistResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	req := &userv1.RestorePlaylistRequest{
+		UserId:     userID,
+		PlaylistId: playlistID,
+	}
+
+	resp, err := c.client.RestorePlaylist(ctx, req)
+	if err != nil {
+		c.log.WithFields(
+			logger.String("error", err.Error()),
+			logger.String("user_id", userID),
+			logger.String("playlist_id", playlistID),
+		).Error("Failed to restore playlist via gRPC")
+		return nil, fmt.Errorf("restore playlist failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// RestoreFavorite 从回收站恢复收藏
+func (c *UserClient) RestoreFavorite(ctx context.Context, userID, favoriteID string) (*userv1.RestoreFavoriteResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	req := &userv1.RestoreFavoriteRequest{
+		UserId:     userID,
+		FavoriteId: favoriteID,
+	}
+
+	resp, err := c.client.RestoreFavorite(ctx, req)
+	if err != nil {
+		c.log.WithFields(
+			logger.String("error", err.Error()),
+			logger.String("user_id", userID),
+			logger.String("favorite_id", favoriteID),
+		).Error("Failed to restore favorite via gRPC")
+		return nil, fmt.Errorf("restore favorite failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// GetPlayQueue 获取播放队列
+func (c *UserClient) GetPlayQueue(ctx context.Context, userID string) (*userv1.GetPlayQueueResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	req := &userv1.GetPlayQueueRequest{
+		UserId: userID,
+	}
+
+	resp, err := c.client.GetPlayQueue(ctx, req)
+	if err != nil {
+		c.log.WithFields(
+			logger.String("error", err.Error()),
+			logger.String("user_id", userID),
+		).Error("Failed to get play queue via gRPC")
+		return nil, fmt.Errorf("get play queue failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// SavePlayQueue 覆盖保存播放队列
+func (c *UserClient) SavePlayQueue(ctx context.Context, req *userv1.SavePlayQueueRequest) (*userv1.SavePlayQueueResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	resp, err := c.client.SavePlayQueue(ctx, req)
+	if err != nil {
+		c.log.WithFields(
+			logger.String("error", err.Error()),
+			logger.String("user_id", req.UserId),
+			logger.Int("track_count", len(req.Tracks)),
+		).Error("Failed to save play queue via gRPC")
+		return nil, fmt.Errorf("save play queue failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// SetPlayQueueIndex 切换播放队列中的当前歌曲
+func (c *UserClient) SetPlayQueueIndex(ctx context.Context, userID, deviceID string, index int32) (*userv1.SetPlayQueueIndexResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	req := &userv1.SetPlayQueueIndexRequest{
+		UserId:       userID,
+		DeviceId:     deviceID,
+		CurrentIndex: index,
+	}
+
+	resp, err := c.client.SetPlayQueueIndex(ctx, req)
+	if err != nil {
+		c.log.WithFields(
+			logger.String("error", err.Error()),
+			logger.String("user_id", userID),
+		).Error("Failed to set play queue index via gRPC")
+		return nil, fmt.Errorf("set play queue index failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// UpdatePlaybackPosition 上报播放进度
+func (c *UserClient) UpdatePlaybackPosition(ctx context.Context, req *userv1.UpdatePlaybackPositionRequest) (*userv1.UpdatePlaybackPositionResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	resp, err := c.client.UpdatePlaybackPosition(ctx, req)
+	if err != nil {
+		c.log.WithFields(
+			logger.String("error", err.Error()),
+			logger.String("user_id", req.UserId),
+			logger.String("song_id", req.SongId),
+		).Error("Failed to update playback position via gRPC")
+		return nil, fmt.Errorf("update playback position failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// GetPlaybackPositions 批量获取歌曲的播放进度
+func (c *UserClient) GetPlaybackPositions(ctx context.Context, userID string, songIDs []string) (*userv1.GetPlaybackPositionsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	req := &userv1.GetPlaybackPositionsRequest{
+		UserId:  userID,
+		SongIds: songIDs,
+	}
+
+	resp, err := c.client.GetPlaybackPositions(ctx, req)
+	if err != nil {
+		c.log.WithFields(
+			logger.String("error", err.Error()),
+			logger.String("user_id", userID),
+		).Error("Failed to get playback positions via gRPC")
+		return nil, fmt.Errorf("get playback positions failed: %w", err)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/featureflag"
+)
+
+// BootstrapHandler 客户端启动配置处理器
+type BootstrapHandler struct {
+	flags *featureflag.Client
+}
+
+// NewBootstrapHandler 创建客户端启动配置处理器
+// flags为nil时不返回任何功能开关（客户端使用内置默认值）
+func NewBootstrapHandler(flags *featureflag.Client) *BootstrapHandler {
+	return &BootstrapHandler{
+		flags: flags,
+	}
+}
+
+// GetBootstrap 客户端启动时拉取的配置：对当前用户、平台和版本生效的功能开关
+// GET /api/bootstrap?platform=ios&app_version=2.3.0
+// 平台和版本也可以通过X-Platform、X-App-Version请求头传入；未登录时只命中100%放量的开关
+func (h *BootstrapHandler) GetBootstrap(c *gin.Context) {
+	ec := featureflag.Context{
+		UserID:     c.GetString("user_id"),
+		Platform:   firstNonEmpty(c.Query("platform"), c.GetHeader("X-Platform")),
+		AppVersion: firstNonEmpty(c.Query("app_version"), c.GetHeader("X-App-Version")),
+	}
+
+	// 只返回标记为客户端可见的开关，服务端开关不下发
+	evaluations := h.flags.EvaluateClient(ec)
+	flags := make(map[string]json.RawMessage, len(evaluations))
+	for key, result := range evaluations {
+		flags[key] = result.Value
+	}
+
+	// 结果因用户而异，不允许中间层缓存
+	c.Header("Cache-Control", "private, no-store")
+	Success(c, gin.H{
+		"flags":       flags,
+		"server_time": time.Now().Unix(),
+	})
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
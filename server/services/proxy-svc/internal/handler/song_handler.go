@@ -100,3 +100,29 @@ func (h *Handler) GetLyric(c *gin.Context) {
 
 	Success(c, lyric)
 }
+
+// GetSimilarSongs 获取相似歌曲
+// GET /api/song/similar?song_mid=xxx
+func (h *Handler) GetSimilarSongs(c *gin.Context) {
+	ctx := c.Request.Context()
+	songMid := c.Query("song_mid")
+
+	if songMid == "" {
+		BadRequest(c, "Missing song_mid parameter")
+		return
+	}
+
+	songs, err := h.upstreamClient.GetSimilarSongs(ctx, songMid)
+	if err != nil {
+		h.log.WithFields(
+			logger.String("request_id", getRequestID(c)),
+			logger.String("error", err.Error()),
+			logger.String("song_mid", songMid),
+		).Error("Failed to get similar songs")
+
+		InternalError(c, "Failed to get similar songs")
+		return
+	}
+
+	Success(c, songs)
+}
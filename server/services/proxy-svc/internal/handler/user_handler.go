@@ -1,23 +1,40 @@
 package handler
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"strings"
+
 	"github.com/gin-gonic/gin"
 	"github.com/xiaoxiao0301/listen-stream-v2/server/services/proxy-svc/internal/client"
+	"github.com/xiaoxiao0301/listen-stream-v2/server/services/proxy-svc/internal/upstream"
 	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/logger"
 	userv1 "github.com/xiaoxiao0301/listen-stream-v2/server/shared/proto/user/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// PlaylistResolver 解析外部平台歌单并匹配到曲库（由FallbackManager实现）
+type PlaylistResolver interface {
+	ResolvePlaylist(ctx context.Context, ref *upstream.PlaylistRef) (*upstream.ResolvedPlaylist, error)
+}
+
 // UserHandler 用户相关接口处理器
 type UserHandler struct {
-	userClient *client.UserClient
-	log        logger.Logger
+	userClient       *client.UserClient
+	playlistResolver PlaylistResolver
+	log              logger.Logger
 }
 
 // NewUserHandler 创建用户处理器
-func NewUserHandler(userClient *client.UserClient, log logger.Logger) *UserHandler {
+func NewUserHandler(userClient *client.UserClient, playlistResolver PlaylistResolver, log logger.Logger) *UserHandler {
 	return &UserHandler{
-		userClient: userClient,
-		log:        log,
+		userClient:       userClient,
+		playlistResolver: playlistResolver,
+		log:              log,
 	}
 }
 
@@ -25,15 +42,21 @@ func NewUserHandler(userClient *client.UserClient, log logger.Logger) *UserHandl
 
 // AddFavorite 添加收藏
 // POST /api/user/favorites
-// Body: {"song_id": "xxx", "song_name": "xxx", "artist_name": "xxx"}
+// Body: {"type": "album", "target_id": "xxx", "name": "xxx", "artist_name": "xxx", "cover_url": "xxx", "extra": {"release_date": "xxx"}}
+// type可选song、album、singer、mv、playlist，默认song；歌曲收藏也可以使用song_id、song_name
 func (h *UserHandler) AddFavorite(c *gin.Context) {
 	ctx := c.Request.Context()
 	userID := getUserID(c)
 
 	var req struct {
-		SongID     string `json:"song_id" binding:"required"`
-		SongName   string `json:"song_name" binding:"required"`
-		ArtistName string `json:"artist_name"`
+		Type       string            `json:"type"`
+		TargetID   string            `json:"target_id"`
+		Name       string            `json:"name"`
+		SongID     string            `json:"song_id"`
+		SongName   string            `json:"song_name"`
+		ArtistName string            `json:"artist_name"`
+		CoverURL   string            `json:"cover_url"`
+		Extra      map[string]string `json:"extra"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -41,13 +64,42 @@ func (h *UserHandler) AddFavorite(c *gin.Context) {
 		return
 	}
 
+	favType, ok := parseFavoriteType(req.Type)
+	if !ok {
+		BadRequest(c, "Invalid favorite type: "+req.Type)
+		return
+	}
+	if favType == userv1.FavoriteType_FAVORITE_TYPE_UNSPECIFIED {
+		favType = userv1.FavoriteType_FAVORITE_TYPE_SONG
+	}
+
+	targetID := req.TargetID
+	if targetID == "" {
+		targetID = req.SongID
+	}
+	name := req.Name
+	if name == "" {
+		name = req.SongName
+	}
+	if targetID == "" || name == "" {
+		BadRequest(c, "Missing target_id or name")
+		return
+	}
+
 	metadata := &userv1.FavoriteMetadata{
-		Name:   req.SongName,
-		Artist: req.ArtistName,
+		Name:     name,
+		Artist:   req.ArtistName,
+		CoverUrl: req.CoverURL,
+		Extra:    req.Extra,
 	}
 
-	_, err := h.userClient.AddFavorite(ctx, userID, req.SongID, userv1.FavoriteType_FAVORITE_TYPE_SONG, metadata)
+	resp, err := h.userClient.AddFavorite(ctx, userID, targetID, favType, metadata)
 	if err != nil {
+		if status.Code(err) == codes.AlreadyExists {
+			Error(c, http.StatusConflict, 409, "Already favorited")
+			return
+		}
+
 		h.log.WithFields(
 			logger.String("request_id", getRequestID(c)),
 			logger.String("user_id", userID),
@@ -58,7 +110,10 @@ func (h *UserHandler) AddFavorite(c *gin.Context) {
 		return
 	}
 
-	Success(c, gin.H{"message": "Favorite added successfully"})
+	Success(c, gin.H{
+		"message":     "Favorite added successfully",
+		"favorite_id": resp.FavoriteId,
+	})
 }
 
 // RemoveFavorite 取消收藏
@@ -88,11 +143,18 @@ func (h *UserHandler) RemoveFavorite(c *gin.Context) {
 }
 
 // ListFavorites 获取收藏列表
-// GET /api/user/favorites?page=1&page_size=20
+// GET /api/user/favorites?type=album&page=1&page_size=20
+// 不传type时返回全部类型，type_counts为各类型的收藏数量
 func (h *UserHandler) ListFavorites(c *gin.Context) {
 	ctx := c.Request.Context()
 	userID := getUserID(c)
 
+	favType, ok := parseFavoriteType(c.Query("type"))
+	if !ok {
+		BadRequest(c, "Invalid favorite type: "+c.Query("type"))
+		return
+	}
+
 	page := getIntParam(c, "page", 1)
 	pageSize := getIntParam(c, "page_size", 20)
 
@@ -103,7 +165,7 @@ func (h *UserHandler) ListFavorites(c *gin.Context) {
 		pageSize = 20
 	}
 
-	resp, err := h.userClient.ListFavorites(ctx, userID, userv1.FavoriteType_FAVORITE_TYPE_SONG, int32(page), int32(pageSize))
+	resp, err := h.userClient.ListFavorites(ctx, userID, favType, int32(page), int32(pageSize))
 	if err != nil {
 		h.log.WithFields(
 			logger.String("request_id", getRequestID(c)),
@@ -115,14 +177,70 @@ func (h *UserHandler) ListFavorites(c *gin.Context) {
 		return
 	}
 
+	typeCounts := make(map[string]int64, len(resp.TypeCounts))
+	for _, tc := range resp.TypeCounts {
+		typeCounts[favoriteTypeNames[tc.Type]] = tc.Count
+	}
+
 	Success(c, gin.H{
-		"items":     resp.Favorites,
-		"total":     resp.Total,
-		"page":      page,
-		"page_size": pageSize,
+		"items":       resp.Favorites,
+		"total":       resp.Total,
+		"page":        page,
+		"page_size":   pageSize,
+		"type_counts": typeCounts,
+	})
+}
+
+// ListNewReleases 获取关注歌手（歌手收藏）的新专辑
+// GET /api/user/singers/new-releases?page=1&page_size=20
+func (h *UserHandler) ListNewReleases(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := getUserID(c)
+
+	page := getIntParam(c, "page", 1)
+	pageSize := getIntParam(c, "page_size", 20)
+
+	resp, err := h.userClient.ListNewReleases(ctx, userID, int32(page), int32(pageSize))
+	if err != nil {
+		h.log.WithFields(
+			logger.String("request_id", getRequestID(c)),
+			logger.String("user_id", userID),
+			logger.String("error", err.Error()),
+		).Error("Failed to list new releases")
+
+		InternalError(c, "Failed to list new releases")
+		return
+	}
+
+	Success(c, gin.H{
+		"items": resp.Releases,
+		"total": resp.Total,
+		"page":  page,
 	})
 }
 
+// favoriteTypeNames 收藏类型在HTTP接口中的名称
+var favoriteTypeNames = map[userv1.FavoriteType]string{
+	userv1.FavoriteType_FAVORITE_TYPE_SONG:     "song",
+	userv1.FavoriteType_FAVORITE_TYPE_ALBUM:    "album",
+	userv1.FavoriteType_FAVORITE_TYPE_ARTIST:   "singer",
+	userv1.FavoriteType_FAVORITE_TYPE_MV:       "mv",
+	userv1.FavoriteType_FAVORITE_TYPE_PLAYLIST: "playlist",
+}
+
+// parseFavoriteType 解析收藏类型名称，空字符串返回UNSPECIFIED
+func parseFavoriteType(name string) (userv1.FavoriteType, bool) {
+	if name == "" {
+		return userv1.FavoriteType_FAVORITE_TYPE_UNSPECIFIED, true
+	}
+	for t, n := range favoriteTypeNames {
+		if n == name {
+			return t, true
+		}
+	}
+	return userv1.FavoriteType_FAVORITE_TYPE_UNSPECIFIED, false
+}
+
 // ===== 播放历史 =====
 
 // AddPlayHistory 添加播放历史
@@ -199,15 +317,17 @@ func (h *UserHandler) ListPlayHistory(c *gin.Context) {
 
 // CreatePlaylist 创建歌单
 // POST /api/user/playlists
-// Body: {"name": "xxx", "cover_url": "xxx", "is_public": true}
+// Body: {"name": "xxx", "cover_url": "xxx", "is_public": true, "smart_rules": {...}}
+// 带smart_rules时创建智能歌单，歌曲根据收藏和播放记录自动计算
 func (h *UserHandler) CreatePlaylist(c *gin.Context) {
 	ctx := c.Request.Context()
 	userID := getUserID(c)
 
 	var req struct {
-		Name     string `json:"name" binding:"required"`
-		CoverURL string `json:"cover_url"`
-		IsPublic bool   `json:"is_public"`
+		Name       string          `json:"name" binding:"required"`
+		CoverURL   string          `json:"cover_url"`
+		IsPublic   bool            `json:"is_public"`
+		SmartRules json.RawMessage `json:"smart_rules"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -215,7 +335,7 @@ func (h *UserHandler) CreatePlaylist(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.userClient.CreatePlaylist(ctx, userID, req.Name, req.CoverURL, req.IsPublic)
+	resp, err := h.userClient.CreatePlaylist(ctx, userID, req.Name, req.CoverURL, req.IsPublic, string(req.SmartRules))
 	if err != nil {
 		h.log.WithFields(
 			logger.String("request_id", getRequestID(c)),
@@ -235,7 +355,7 @@ func (h *UserHandler) CreatePlaylist(c *gin.Context) {
 
 // UpdatePlaylist 更新歌单
 // PUT /api/user/playlists/:playlist_id
-// Body: {"name": "xxx", "cover_url": "xxx", "is_public": true}
+// Body: {"name": "xxx", "cover_url": "xxx", "is_public": true, "smart_rules": {...}}
 func (h *UserHandler) UpdatePlaylist(c *gin.Context) {
 	ctx := c.Request.Context()
 	userID := getUserID(c)
@@ -247,9 +367,10 @@ func (h *UserHandler) UpdatePlaylist(c *gin.Context) {
 	}
 
 	var req struct {
-		Name     string `json:"name"`
-		CoverURL string `json:"cover_url"`
-		IsPublic *bool  `json:"is_public"`
+		Name       string          `json:"name"`
+		CoverURL   string          `json:"cover_url"`
+		IsPublic   *bool           `json:"is_public"`
+		SmartRules json.RawMessage `json:"smart_rules"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -257,7 +378,7 @@ func (h *UserHandler) UpdatePlaylist(c *gin.Context) {
 		return
 	}
 
-	if err := h.userClient.UpdatePlaylist(ctx, userID, playlistID, req.Name, req.CoverURL, req.IsPublic); err != nil {
+	if err := h.userClient.UpdatePlaylist(ctx, userID, playlistID, req.Name, req.CoverURL, req.IsPublic, string(req.SmartRules)); err != nil {
 		h.log.WithFields(
 			logger.String("request_id", getRequestID(c)),
 			logger.String("user_id", userID),
@@ -382,10 +503,222 @@ func (h *UserHandler) RemoveSongFromPlaylist(c *gin.Context) {
 	Success(c, gin.H{"message": "Song removed from playlist successfully"})
 }
 
-// GetPlaylistSongs 获取歌单歌曲列表
+// AddSongsToPlaylist 批量添加歌曲到歌单末尾（最多500首，已在歌单中的歌曲会被跳过）
+// POST /api/user/playlists/:playlist_id/songs/batch
+// Body: {"songs": [{"song_id": "xxx", "song_name": "xxx", "singer_name": "xxx"}]}
+func (h *UserHandler) AddSongsToPlaylist(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := getUserID(c)
+	playlistID := c.Param("playlist_id")
+
+	if playlistID == "" {
+		BadRequest(c, "Missing playlist_id parameter")
+		return
+	}
+
+	var req struct {
+		Songs []struct {
+			SongID     string `json:"song_id" binding:"required"`
+			SongName   string `json:"song_name"`
+			SingerName string `json:"singer_name"`
+		} `json:"songs" binding:"required,dive"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	songs := make([]*userv1.ImportedSong, 0, len(req.Songs))
+	for _, song := range req.Songs {
+		songs = append(songs, &userv1.ImportedSong{
+			SongId:     song.SongID,
+			SongName:   song.SongName,
+			SingerName: song.SingerName,
+		})
+	}
+
+	resp, err := h.userClient.AddSongsToPlaylist(ctx, userID, playlistID, songs)
+	if err != nil {
+		h.log.WithFields(
+			logger.String("request_id", getRequestID(c)),
+			logger.String("user_id", userID),
+			logger.String("error", err.Error()),
+		).Error("Failed to add songs to playlist")
+
+		playlistEditError(c, err, "Failed to add songs to playlist")
+		return
+	}
+
+	Success(c, gin.H{
+		"added":      resp.Added,
+		"skipped":    resp.Skipped,
+		"song_count": resp.SongCount,
+	})
+}
+
+// RemoveSongsFromPlaylist 批量移除歌单歌曲（最多500首）
+// POST /api/user/playlists/:playlist_id/songs/batch-remove
+// Body: {"song_ids": ["xxx", "yyy"]}
+func (h *UserHandler) RemoveSongsFromPlaylist(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := getUserID(c)
+	playlistID := c.Param("playlist_id")
+
+	if playlistID == "" {
+		BadRequest(c, "Missing playlist_id parameter")
+		return
+	}
+
+	var req struct {
+		SongIDs []string `json:"song_ids" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	resp, err := h.userClient.RemoveSongsFromPlaylist(ctx, userID, playlistID, req.SongIDs)
+	if err != nil {
+		h.log.WithFields(
+			logger.String("request_id", getRequestID(c)),
+			logger.String("user_id", userID),
+			logger.String("error", err.Error()),
+		).Error("Failed to remove songs from playlist")
+
+		playlistEditError(c, err, "Failed to remove songs from playlist")
+		return
+	}
+
+	Success(c, gin.H{
+		"removed":    resp.Removed,
+		"song_count": resp.SongCount,
+	})
+}
+
+// MovePlaylistSong 移动歌曲到指定位置（从1开始）
+// PUT /api/user/playlists/:playlist_id/songs/:song_id/position
+// Body: {"position": 1}
+func (h *UserHandler) MovePlaylistSong(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := getUserID(c)
+	playlistID := c.Param("playlist_id")
+	songID := c.Param("song_id")
+
+	if playlistID == "" || songID == "" {
+		BadRequest(c, "Missing playlist_id or song_id parameter")
+		return
+	}
+
+	var req struct {
+		Position int32 `json:"position" binding:"required,min=1"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	if err := h.userClient.MovePlaylistSong(ctx, userID, playlistID, songID, req.Position); err != nil {
+		h.log.WithFields(
+			logger.String("request_id", getRequestID(c)),
+			logger.String("user_id", userID),
+			logger.String("error", err.Error()),
+		).Error("Failed to move playlist song")
+
+		playlistEditError(c, err, "Failed to move playlist song")
+		return
+	}
+
+	Success(c, gin.H{"message": "Song moved successfully"})
+}
+
+// SortPlaylistSongs 按歌名、歌手或添加时间重排歌单
+// POST /api/user/playlists/:playlist_id/songs/sort
+// Body: {"sort_by": "name|singer|added_at", "order": "asc|desc"}
+func (h *UserHandler) SortPlaylistSongs(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := getUserID(c)
+	playlistID := c.Param("playlist_id")
+
+	if playlistID == "" {
+		BadRequest(c, "Missing playlist_id parameter")
+		return
+	}
+
+	var req struct {
+		SortBy string `json:"sort_by" binding:"required"`
+		Order  string `json:"order" binding:"omitempty,oneof=asc desc"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	if err := h.userClient.SortPlaylistSongs(ctx, userID, playlistID, req.SortBy, req.Order == "desc"); err != nil {
+		h.log.WithFields(
+			logger.String("request_id", getRequestID(c)),
+			logger.String("user_id", userID),
+			logger.String("error", err.Error()),
+		).Error("Failed to sort playlist songs")
+
+		playlistEditError(c, err, "Failed to sort playlist songs")
+		return
+	}
+
+	Success(c, gin.H{"message": "Playlist sorted successfully"})
+}
+
+// playlistEditError 把歌单歌曲编辑相关的gRPC错误映射为HTTP响应
+func playlistEditError(c *gin.Context, err error, msg string) {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		BadRequest(c, status.Convert(err).Message())
+	case codes.PermissionDenied:
+		Forbidden(c, "Not allowed to edit this playlist")
+	case codes.NotFound:
+		NotFound(c, status.Convert(err).Message())
+	case codes.FailedPrecondition:
+		Error(c, http.StatusConflict, 409, status.Convert(err).Message())
+	default:
+		InternalError(c, msg)
+	}
+}
+
+// GetPlaylist 获取歌单详情（私有歌单只有所有者和协作成员可以查看）
+// GET /api/user/playlists/:playlist_id
+func (h *UserHandler) GetPlaylist(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := getUserID(c)
+	playlistID := c.Param("playlist_id")
+
+	if playlistID == "" {
+		BadRequest(c, "Missing playlist_id parameter")
+		return
+	}
+
+	resp, err := h.userClient.GetPlaylist(ctx, userID, playlistID)
+	if err != nil {
+		h.log.WithFields(
+			logger.String("request_id", getRequestID(c)),
+			logger.String("user_id", userID),
+			logger.String("error", err.Error()),
+		).Error("Failed to get playlist")
+
+		playlistDiscoveryError(c, err, "Failed to get playlist")
+		return
+	}
+
+	Success(c, resp.Playlist)
+}
+
+// GetPlaylistSongs 获取歌单歌曲列表（私有歌单只有所有者和协作成员可以查看）
 // GET /api/user/playlists/:playlist_id/songs
 func (h *UserHandler) GetPlaylistSongs(c *gin.Context) {
 	ctx := c.Request.Context()
+	userID := getUserID(c)
 	playlistID := c.Param("playlist_id")
 
 	if playlistID == "" {
@@ -393,24 +726,1013 @@ func (h *UserHandler) GetPlaylistSongs(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.userClient.GetPlaylistSongs(ctx, playlistID)
+	resp, err := h.userClient.GetPlaylistSongs(ctx, userID, playlistID)
 	if err != nil {
 		h.log.WithFields(
 			logger.String("request_id", getRequestID(c)),
+			logger.String("user_id", userID),
 			logger.String("error", err.Error()),
 		).Error("Failed to get playlist songs")
 
-		InternalError(c, "Failed to get playlist songs")
+		playlistDiscoveryError(c, err, "Failed to get playlist songs")
 		return
 	}
 
 	Success(c, gin.H{"items": resp.Songs})
 }
 
-// getUserID 从JWT中获取用户ID
-func getUserID(c *gin.Context) string {
-	if userID, exists := c.Get("user_id"); exists {
-		return userID.(string)
+// ImportPlaylist 从外部平台导入歌单
+// POST /api/user/playlists/import
+// Body: {"url": "https://music.163.com/#/playlist?id=xxx", "source": "netease", "name": "xxx", "is_public": false}
+// url也可以是纯数字歌单ID（此时必须指定source）；name为空时使用原歌单名称
+// 未能匹配到曲库的歌曲在unmatched中返回
+func (h *UserHandler) ImportPlaylist(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := getUserID(c)
+
+	var req struct {
+		URL      string `json:"url" binding:"required"`
+		Source   string `json:"source"`
+		Name     string `json:"name"`
+		IsPublic bool   `json:"is_public"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	ref, err := upstream.ParsePlaylistRef(req.Source, req.URL)
+	if err != nil {
+		BadRequest(c, err.Error())
+		return
+	}
+
+	resolved, err := h.playlistResolver.ResolvePlaylist(ctx, ref)
+	if err != nil {
+		h.log.WithFields(
+			logger.String("request_id", getRequestID(c)),
+			logger.String("user_id", userID),
+			logger.String("source", ref.Source),
+			logger.String("external_id", ref.ID),
+			logger.String("error", err.Error()),
+		).Error("Failed to resolve external playlist")
+
+		if errors.Is(err, upstream.ErrInvalidPlaylistRef) {
+			BadRequest(c, err.Error())
+			return
+		}
+		Error(c, http.StatusBadGateway, 502, "Failed to fetch playlist from "+ref.Source)
+		return
+	}
+
+	if len(resolved.Songs) == 0 {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "No songs in the playlist could be matched",
+			Data: gin.H{
+				"total_tracks": resolved.TotalTracks,
+				"unmatched":    resolved.Unmatched,
+			},
+			RequestID: getRequestID(c),
+		})
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = resolved.Name
+	}
+	if name == "" {
+		name = "Imported playlist"
+	}
+
+	importReq := &userv1.ImportPlaylistRequest{
+		UserId:      userID,
+		Name:        name,
+		Description: resolved.Description,
+		CoverUrl:    resolved.CoverURL,
+		IsPublic:    req.IsPublic,
+		Songs:       make([]*userv1.ImportedSong, 0, len(resolved.Songs)),
+	}
+	for _, song := range resolved.Songs {
+		importReq.Songs = append(importReq.Songs, &userv1.ImportedSong{
+			SongId:     song.SongMid,
+			SongName:   song.SongName,
+			SingerName: singerNames(song),
+		})
+	}
+
+	resp, err := h.userClient.ImportPlaylist(ctx, importReq)
+	if err != nil {
+		h.log.WithFields(
+			logger.String("request_id", getRequestID(c)),
+			logger.String("user_id", userID),
+			logger.String("error", err.Error()),
+		).Error("Failed to import playlist")
+
+		if status.Code(err) == codes.InvalidArgument {
+			BadRequest(c, status.Convert(err).Message())
+			return
+		}
+		InternalError(c, "Failed to import playlist")
+		return
+	}
+
+	Success(c, gin.H{
+		"playlist_id":  resp.Playlist.Id,
+		"name":         resp.Playlist.Name,
+		"source":       resolved.Source,
+		"total_tracks": resolved.TotalTracks,
+		"imported":     resp.Imported,
+		"duplicates":   resp.Duplicates,
+		"unmatched":    resolved.Unmatched,
+	})
+}
+
+// ExportPlaylist 导出歌单文件
+// GET /api/user/playlists/:playlist_id/export?format=m3u8|xspf|json
+func (h *UserHandler) ExportPlaylist(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := getUserID(c)
+	playlistID := c.Param("playlist_id")
+
+	if playlistID == "" {
+		BadRequest(c, "Missing playlist_id parameter")
+		return
+	}
+
+	resp, err := h.userClient.ExportPlaylist(ctx, userID, playlistID, c.DefaultQuery("format", "m3u8"))
+	if err != nil {
+		h.log.WithFields(
+			logger.String("request_id", getRequestID(c)),
+			logger.String("user_id", userID),
+			logger.String("error", err.Error()),
+		).Error("Failed to export playlist")
+
+		switch status.Code(err) {
+		case codes.InvalidArgument:
+			BadRequest(c, status.Convert(err).Message())
+		case codes.PermissionDenied:
+			Forbidden(c, "Not allowed to export this playlist")
+		case codes.NotFound:
+			NotFound(c, "Playlist not found")
+		default:
+			InternalError(c, "Failed to export playlist")
+		}
+		return
+	}
+
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": resp.Filename}))
+	c.Data(http.StatusOK, resp.ContentType, resp.Content)
+}
+
+// ===== 公开歌单 =====
+
+// ListPublicPlaylists 浏览公开歌单
+// GET /api/user/playlists/public?sort=popular|recent&page=1&page_size=20
+func (h *UserHandler) ListPublicPlaylists(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	page := getIntParam(c, "page", 1)
+	pageSize := getIntParam(c, "page_size", 20)
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	resp, err := h.userClient.ListPublicPlaylists(ctx, c.Query("sort"), int32(page), int32(pageSize))
+	if err != nil {
+		h.log.WithFields(
+			logger.String("request_id", getRequestID(c)),
+			logger.String("error", err.Error()),
+		).Error("Failed to list public playlists")
+
+		if status.Code(err) == codes.InvalidArgument {
+			BadRequest(c, status.Convert(err).Message())
+			return
+		}
+		InternalError(c, "Failed to list public playlists")
+		return
+	}
+
+	Success(c, gin.H{
+		"items":     resp.Playlists,
+		"total":     resp.Total,
+		"page":      page,
+		"page_size": pageSize,
+	})
+}
+
+// GetPublicPlaylist 获取公开歌单详情（包括歌曲和是否已关注）
+// GET /api/user/playlists/public/:playlist_id
+func (h *UserHandler) GetPublicPlaylist(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := getUserID(c)
+	playlistID := c.Param("playlist_id")
+
+	if playlistID == "" {
+		BadRequest(c, "Missing playlist_id parameter")
+		return
+	}
+
+	resp, err := h.userClient.GetPublicPlaylist(ctx, userID, playlistID)
+	if err != nil {
+		h.log.WithFields(
+			logger.String("request_id", getRequestID(c)),
+			logger.String("user_id", userID),
+			logger.String("error", err.Error()),
+		).Error("Failed to get public playlist")
+
+		playlistDiscoveryError(c, err, "Failed to get public playlist")
+		return
+	}
+
+	Success(c, gin.H{
+		"playlist":     resp.Playlist,
+		"songs":        resp.Songs,
+		"is_following": resp.IsFollowing,
+	})
+}
+
+// FollowPlaylist 关注公开歌单
+// POST /api/user/playlists/:playlist_id/follow
+func (h *UserHandler) FollowPlaylist(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := getUserID(c)
+	playlistID := c.Param("playlist_id")
+
+	if playlistID == "" {
+		BadRequest(c, "Missing playlist_id parameter")
+		return
+	}
+
+	resp, err := h.userClient.FollowPlaylist(ctx, userID, playlistID)
+	if err != nil {
+		h.log.WithFields(
+			logger.String("request_id", getRequestID(c)),
+			logger.String("user_id", userID),
+			logger.String("error", err.Error()),
+		).Error("Failed to follow playlist")
+
+		playlistDiscoveryError(c, err, "Failed to follow playlist")
+		return
+	}
+
+	Success(c, gin.H{
+		"playlist_id":    resp.Playlist.Id,
+		"follower_count": resp.Playlist.FollowerCount,
+	})
+}
+
+// UnfollowPlaylist 取消关注歌单
+// DELETE /api/user/playlists/:playlist_id/follow
+func (h *UserHandler) UnfollowPlaylist(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := getUserID(c)
+	playlistID := c.Param("playlist_id")
+
+	if playlistID == "" {
+		BadRequest(c, "Missing playlist_id parameter")
+		return
+	}
+
+	if err := h.userClient.UnfollowPlaylist(ctx, userID, playlistID); err != nil {
+		h.log.WithFields(
+			logger.String("request_id", getRequestID(c)),
+			logger.String("user_id", userID),
+			logger.String("error", err.Error()),
+		).Error("Failed to unfollow playlist")
+
+		InternalError(c, "Failed to unfollow playlist")
+		return
+	}
+
+	Success(c, gin.H{"message": "Playlist unfollowed successfully"})
+}
+
+// ListFollowedPlaylists 获取关注的歌单
+// GET /api/user/playlists/following?page=1&page_size=20
+func (h *UserHandler) ListFollowedPlaylists(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := getUserID(c)
+
+	page := getIntParam(c, "page", 1)
+	pageSize := getIntParam(c, "page_size", 20)
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	resp, err := h.userClient.ListFollowedPlaylists(ctx, userID, int32(page), int32(pageSize))
+	if err != nil {
+		h.log.WithFields(
+			logger.String("request_id", getRequestID(c)),
+			logger.String("user_id", userID),
+			logger.String("error", err.Error()),
+		).Error("Failed to list followed playlists")
+
+		InternalError(c, "Failed to list followed playlists")
+		return
+	}
+
+	Success(c, gin.H{
+		"items":     resp.Playlists,
+		"total":     resp.Total,
+		"page":      page,
+		"page_size": pageSize,
+	})
+}
+
+// ForkPlaylist 复制歌单为自己的私有歌单
+// POST /api/user/playlists/:playlist_id/fork
+// Body（可选）: {"name": "xxx"}，name为空时使用原歌单名称
+func (h *UserHandler) ForkPlaylist(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := getUserID(c)
+	playlistID := c.Param("playlist_id")
+
+	if playlistID == "" {
+		BadRequest(c, "Missing playlist_id parameter")
+		return
+	}
+
+	var req struct {
+		Name string `json:"name"`
+	}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			BadRequest(c, "Invalid request body")
+			return
+		}
+	}
+
+	resp, err := h.userClient.ForkPlaylist(ctx, userID, playlistID, req.Name)
+	if err != nil {
+		h.log.WithFields(
+			logger.String("request_id", getRequestID(c)),
+			logger.String("user_id", userID),
+			logger.String("error", err.Error()),
+		).Error("Failed to fork playlist")
+
+		playlistDiscoveryError(c, err, "Failed to fork playlist")
+		return
+	}
+
+	Success(c, resp.Playlist)
+}
+
+// ===== 协作歌单 =====
+
+// ListPlaylistMembers 获取歌单成员（包括所有者）
+// GET /api/user/playlists/:playlist_id/members
+func (h *UserHandler) ListPlaylistMembers(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := getUserID(c)
+	playlistID := c.Param("playlist_id")
+
+	resp, err := h.userClient.ListPlaylistMembers(ctx, userID, playlistID)
+	if err != nil {
+		h.log.WithFields(
+			logger.String("request_id", getRequestID(c)),
+			logger.String("user_id", userID),
+			logger.String("error", err.Error()),
+		).Error("Failed to list playlist members")
+
+		playlistMemberError(c, err, "Failed to list playlist members")
+		return
+	}
+
+	Success(c, gin.H{"items": resp.Members})
+}
+
+// InvitePlaylistMember 按用户ID邀请成员或修改成员角色（仅所有者）
+// PUT /api/user/playlists/:playlist_id/members/:member_id
+// Body: {"role": "editor"}，role为editor或viewer
+func (h *UserHandler) InvitePlaylistMember(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := getUserID(c)
+	playlistID := c.Param("playlist_id")
+	memberID := c.Param("member_id")
+
+	var req struct {
+		Role string `json:"role" binding:"required,oneof=editor viewer"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	resp, err := h.userClient.InvitePlaylistMember(ctx, userID, playlistID, memberID, req.Role)
+	if err != nil {
+		h.log.WithFields(
+			logger.String("request_id", getRequestID(c)),
+			logger.String("user_id", userID),
+			logger.String("error", err.Error()),
+		).Error("Failed to invite playlist member")
+
+		playlistMemberError(c, err, "Failed to invite playlist member")
+		return
+	}
+
+	Success(c, resp.Member)
+}
+
+// RemovePlaylistMember 移除成员（所有者），或成员退出歌单
+// DELETE /api/user/playlists/:playlist_id/members/:member_id
+func (h *UserHandler) RemovePlaylistMember(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := getUserID(c)
+	playlistID := c.Param("playlist_id")
+	memberID := c.Param("member_id")
+
+	if _, err := h.userClient.RemovePlaylistMember(ctx, userID, playlistID, memberID); err != nil {
+		h.log.WithFields(
+			logger.String("request_id", getRequestID(c)),
+			logger.String("user_id", userID),
+			logger.String("error", err.Error()),
+		).Error("Failed to remove playlist member")
+
+		playlistMemberError(c, err, "Failed to remove playlist member")
+		return
+	}
+
+	Success(c, gin.H{"message": "Member removed successfully"})
+}
+
+// CreatePlaylistInvite 生成分享链接邀请（仅所有者，7天内有效）
+// POST /api/user/playlists/:playlist_id/invites
+// Body: {"role": "viewer"}，role为editor或viewer
+func (h *UserHandler) CreatePlaylistInvite(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := getUserID(c)
+	playlistID := c.Param("playlist_id")
+
+	var req struct {
+		Role string `json:"role" binding:"required,oneof=editor viewer"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	resp, err := h.userClient.CreatePlaylistInvite(ctx, userID, playlistID, req.Role)
+	if err != nil {
+		h.log.WithFields(
+			logger.String("request_id", getRequestID(c)),
+			logger.String("user_id", userID),
+			logger.String("error", err.Error()),
+		).Error("Failed to create playlist invite")
+
+		playlistMemberError(c, err, "Failed to create playlist invite")
+		return
+	}
+
+	Success(c, resp.Invite)
+}
+
+// RevokePlaylistInvites 撤销歌单的所有分享链接（仅所有者）
+// DELETE /api/user/playlists/:playlist_id/invites
+func (h *UserHandler) RevokePlaylistInvites(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := getUserID(c)
+	playlistID := c.Param("playlist_id")
+
+	resp, err := h.userClient.RevokePlaylistInvites(ctx, userID, playlistID)
+	if err != nil {
+		h.log.WithFields(
+			logger.String("request_id", getRequestID(c)),
+			logger.String("user_id", userID),
+			logger.String("error", err.Error()),
+		).Error("Failed to revoke playlist invites")
+
+		playlistMemberError(c, err, "Failed to revoke playlist invites")
+		return
+	}
+
+	Success(c, gin.H{"revoked": resp.Revoked})
+}
+
+// AcceptPlaylistInvite 通过分享链接加入歌单
+// POST /api/user/playlists/invites/:token/accept
+func (h *UserHandler) AcceptPlaylistInvite(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := getUserID(c)
+	token := c.Param("token")
+
+	resp, err := h.userClient.AcceptPlaylistInvite(ctx, userID, token)
+	if err != nil {
+		h.log.WithFields(
+			logger.String("request_id", getRequestID(c)),
+			logger.String("user_id", userID),
+			logger.String("error", err.Error()),
+		).Error("Failed to accept playlist invite")
+
+		playlistMemberError(c, err, "Failed to accept playlist invite")
+		return
+	}
+
+	Success(c, resp.Playlist)
+}
+
+// ListSharedPlaylists 获取参与协作的歌单
+// GET /api/user/playlists/shared?page=1&page_size=20
+func (h *UserHandler) ListSharedPlaylists(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := getUserID(c)
+
+	page := getIntParam(c, "page", 1)
+	pageSize := getIntParam(c, "page_size", 20)
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	resp, err := h.userClient.ListSharedPlaylists(ctx, userID, int32(page), int32(pageSize))
+	if err != nil {
+		h.log.WithFields(
+			logger.String("request_id", getRequestID(c)),
+			logger.String("user_id", userID),
+			logger.String("error", err.Error()),
+		).Error("Failed to list shared playlists")
+
+		InternalError(c, "Failed to list shared playlists")
+		return
+	}
+
+	Success(c, gin.H{
+		"items":     resp.Playlists,
+		"page":      page,
+		"page_size": pageSize,
+	})
+}
+
+// ListDeletedPlaylists 获取最近删除的歌单
+// GET /api/user/trash/playlists?page=1&page_size=20
+func (h *UserHandler) ListDeletedPlaylists(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := getUserID(c)
+
+	page := getIntParam(c, "page", 1)
+	pageSize := getIntParam(c, "page_size", 20)
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	resp, err := h.userClient.ListDeletedPlaylists(ctx, userID, int32(page), int32(pageSize))
+	if err != nil {
+		h.log.WithFields(
+			logger.String("request_id", getRequestID(c)),
+			logger.String("user_id", userID),
+			logger.String("error", err.Error()),
+		).Error("Failed to list deleted playlists")
+
+		InternalError(c, "Failed to list deleted playlists")
+		return
+	}
+
+	Success(c, gin.H{
+		"items":          resp.Playlists,
+		"total":          resp.Total,
+		"page":           page,
+		"page_size":      pageSize,
+		"retention_days": resp.RetentionDays,
+	})
+}
+
+// ListDeletedFavorites 获取最近删除的收藏
+// GET /api/user/trash/favorites?page=1&page_size=20
+func (h *UserHandler) ListDeletedFavorites(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := getUserID(c)
+
+	page := getIntParam(c, "page", 1)
+	pageSize := getIntParam(c, "page_size", 20)
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	resp, err := h.userClient.ListDeletedFavorites(ctx, userID, int32(page), int32(pageSize))
+	if err != nil {
+		h.log.WithFields(
+			logger.String("request_id", getRequestID(c)),
+			logger.String("user_id", userID),
+			logger.String("error", err.Error()),
+		).Error("Failed to list deleted favorites")
+
+		InternalError(c, "Failed to list deleted favorites")
+		return
+	}
+
+	Success(c, gin.H{
+		"items":          resp.Favorites,
+		"total":          resp.Total,
+		"page":           page,
+		"page_size":      pageSize,
+		"retention_days": resp.RetentionDays,
+	})
+}
+
+// RestorePlaylist 从回收站恢复歌单（包括歌曲）
+// POST /api/user/playlists/:playlist_id/restore
+func (h *UserHandler) RestorePlaylist(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := getUserID(c)
+	playlistID := c.Param("playlist_id")
+
+	if playlistID == "" {
+		BadRequest(c, "Missing playlist_id parameter")
+		return
+	}
+
+	resp, err := h.userClient.RestorePlaylist(ctx, userID, playlistID)
+	if err != nil {
+		h.log.WithFields(
+			logger.String("request_id", getRequestID(c)),
+			logger.String("user_id", userID),
+			logger.String("error", err.Error()),
+		).Error("Failed to restore playlist")
+
+		trashRestoreError(c, err, "Failed to restore playlist")
+		return
+	}
+
+	Success(c, resp.Playlist)
+}
+
+// RestoreFavorite 从回收站恢复收藏
+// POST /api/user/favorites/:favorite_id/restore
+func (h *UserHandler) RestoreFavorite(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := getUserID(c)
+	favoriteID := c.Param("favorite_id")
+
+	if favoriteID == "" {
+		BadRequest(c, "Missing favorite_id parameter")
+		return
+	}
+
+	resp, err := h.userClient.RestoreFavorite(ctx, userID, favoriteID)
+	if err != nil {
+		h.log.WithFields(
+			logger.String("request_id", getRequestID(c)),
+			logger.String("user_id", userID),
+			logger.String("error", err.Error()),
+		).Error("Failed to restore favorite")
+
+		trashRestoreError(c, err, "Failed to restore favorite")
+		return
+	}
+
+	Success(c, resp.Favorite)
+}
+
+// trashRestoreError 把回收站恢复的gRPC错误映射为HTTP响应
+func trashRestoreError(c *gin.Context, err error, msg string) {
+	switch status.Code(err) {
+	case codes.NotFound:
+		NotFound(c, "Item not found in trash or already expired")
+	case codes.PermissionDenied:
+		Forbidden(c, "Not allowed to restore this item")
+	case codes.AlreadyExists:
+		Error(c, http.StatusConflict, 409, status.Convert(err).Message())
+	default:
+		InternalError(c, msg)
+	}
+}
+
+// playlistDiscoveryError 把公开歌单相关的gRPC错误映射为HTTP响应
+func playlistDiscoveryError(c *gin.Context, err error, msg string) {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		BadRequest(c, status.Convert(err).Message())
+	case codes.PermissionDenied:
+		Forbidden(c, "Not allowed to access this playlist")
+	case codes.NotFound:
+		NotFound(c, "Playlist not found")
+	default:
+		InternalError(c, msg)
+	}
+}
+
+// playlistMemberError 把协作歌单相关的gRPC错误映射为HTTP响应
+func playlistMemberError(c *gin.Context, err error, msg string) {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		BadRequest(c, status.Convert(err).Message())
+	case codes.PermissionDenied:
+		Forbidden(c, "Not allowed to manage this playlist")
+	case codes.NotFound:
+		NotFound(c, status.Convert(err).Message())
+	case codes.ResourceExhausted:
+		Error(c, http.StatusConflict, 409, status.Convert(err).Message())
+	case codes.FailedPrecondition:
+		Error(c, http.StatusGone, 410, status.Convert(err).Message())
+	default:
+		InternalError(c, msg)
+	}
+}
+
+// singerNames 拼接歌手名
+func singerNames(song upstream.Song) string {
+	if song.SingerName != "" {
+		return song.SingerName
+	}
+	names := make([]string, 0, len(song.Singers))
+	for _, singer := range song.Singers {
+		names = append(names, singer.SingerName)
+	}
+	return strings.Join(names, "/")
+}
+
+// getUserID 从JWT中获取用户ID
+func getUserID(c *gin.Context) string {
+	if userID, exists := c.Get("user_id"); exists {
+		return userID.(string)
+	}
+	return ""
+}
+
+// ===== 个性化推荐 =====
+
+// GetRecommendations 获取个性化推荐（每日推荐 + "因为你喜欢X"）
+// GET /api/user/recommendations
+func (h *UserHandler) GetRecommendations(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := getUserID(c)
+
+	resp, err := h.userClient.GetRecommendations(ctx, userID)
+	if err != nil {
+		h.log.WithFields(
+			logger.String("request_id", getRequestID(c)),
+			logger.String("user_id", userID),
+			logger.String("error", err.Error()),
+		).Error("Failed to get recommendations")
+
+		InternalError(c, "Failed to get recommendations")
+		return
+	}
+
+	Success(c, gin.H{
+		"daily_mix":         resp.DailyMix,
+		"because_you_liked": resp.BecauseYouLiked,
+		"generated_at":      resp.GeneratedAt.AsTime(),
+	})
+}
+
+// ===== 播放队列和播放进度 =====
+
+// GetPlayQueue 获取播放队列（多设备共享）
+// GET /api/user/playback/queue
+func (h *UserHandler) GetPlayQueue(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := getUserID(c)
+
+	resp, err := h.userClient.GetPlayQueue(ctx, userID)
+	if err != nil {
+		h.log.WithFields(
+			logger.String("request_id", getRequestID(c)),
+			logger.String("user_id", userID),
+			logger.String("error", err.Error()),
+		).Error("Failed to get play queue")
+
+		playbackError(c, err, "Failed to get play queue")
+		return
+	}
+
+	Success(c, playQueueResponse(resp.Queue))
+}
+
+// SavePlayQueue 覆盖保存播放队列，变更会推送到用户的其他设备
+// PUT /api/user/playback/queue
+// Body: {"tracks": [{"song_id": "xxx", "song_name": "xxx", "singer_name": "xxx", "duration": 240}], "current_index": 0, "shuffle": false, "repeat_mode": "all"}
+// repeat_mode可选off、all、one，默认off
+func (h *UserHandler) SavePlayQueue(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := getUserID(c)
+
+	var req struct {
+		Tracks []struct {
+			SongID     string `json:"song_id"`
+			SongName   string `json:"song_name"`
+			SingerName string `json:"singer_name"`
+			Duration   int32  `json:"duration"`
+		} `json:"tracks"`
+		CurrentIndex int32  `json:"current_index"`
+		Shuffle      bool   `json:"shuffle"`
+		RepeatMode   string `json:"repeat_mode"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+
+	repeatMode, ok := parseRepeatMode(req.RepeatMode)
+	if !ok {
+		BadRequest(c, "Invalid repeat_mode: "+req.RepeatMode)
+		return
+	}
+
+	tracks := make([]*userv1.QueueTrack, 0, len(req.Tracks))
+	for _, track := range req.Tracks {
+		tracks = append(tracks, &userv1.QueueTrack{
+			SongId:     track.SongID,
+			SongName:   track.SongName,
+			SingerName: track.SingerName,
+			Duration:   track.Duration,
+		})
+	}
+
+	resp, err := h.userClient.SavePlayQueue(ctx, &userv1.SavePlayQueueRequest{
+		UserId:       userID,
+		DeviceId:     c.GetString("device_id"),
+		Tracks:       tracks,
+		CurrentIndex: req.CurrentIndex,
+		Shuffle:      req.Shuffle,
+		RepeatMode:   repeatMode,
+	})
+	if err != nil {
+		h.log.WithFields(
+			logger.String("request_id", getRequestID(c)),
+			logger.String("user_id", userID),
+			logger.String("error", err.Error()),
+		).Error("Failed to save play queue")
+
+		playbackError(c, err, "Failed to save play queue")
+		return
+	}
+
+	Success(c, playQueueResponse(resp.Queue))
+}
+
+// SetPlayQueueIndex 切换当前播放的歌曲
+// PUT /api/user/playback/queue/current
+// Body: {"current_index": 3}
+func (h *UserHandler) SetPlayQueueIndex(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := getUserID(c)
+
+	var req struct {
+		CurrentIndex *int32 `json:"current_index" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+
+	resp, err := h.userClient.SetPlayQueueIndex(ctx, userID, c.GetString("device_id"), *req.CurrentIndex)
+	if err != nil {
+		h.log.WithFields(
+			logger.String("request_id", getRequestID(c)),
+			logger.String("user_id", userID),
+			logger.String("error", err.Error()),
+		).Error("Failed to set play queue index")
+
+		playbackError(c, err, "Failed to set play queue index")
+		return
+	}
+
+	Success(c, playQueueResponse(resp.Queue))
+}
+
+// UpdatePlaybackPosition 上报歌曲的播放进度（客户端可以高频调用）
+// PUT /api/user/playback/positions/:song_id
+// Body: {"position_ms": 90000, "duration_ms": 240000}
+func (h *UserHandler) UpdatePlaybackPosition(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := getUserID(c)
+	songID := c.Param("song_id")
+
+	var req struct {
+		PositionMs int64 `json:"position_ms"`
+		DurationMs int64 `json:"duration_ms"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+
+	resp, err := h.userClient.UpdatePlaybackPosition(ctx, &userv1.UpdatePlaybackPositionRequest{
+		UserId:     userID,
+		DeviceId:   c.GetString("device_id"),
+		SongId:     songID,
+		PositionMs: req.PositionMs,
+		DurationMs: req.DurationMs,
+	})
+	if err != nil {
+		h.log.WithFields(
+			logger.String("request_id", getRequestID(c)),
+			logger.String("user_id", userID),
+			logger.String("song_id", songID),
+			logger.String("error", err.Error()),
+		).Error("Failed to update playback position")
+
+		playbackError(c, err, "Failed to update playback position")
+		return
+	}
+
+	Success(c, resp.Position)
+}
+
+// GetPlaybackPositions 批量获取歌曲的播放进度，从未播放过的歌曲不在结果中
+// GET /api/user/playback/positions?song_ids=a,b,c
+func (h *UserHandler) GetPlaybackPositions(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := getUserID(c)
+
+	var songIDs []string
+	for _, songID := range strings.Split(c.Query("song_ids"), ",") {
+		if songID = strings.TrimSpace(songID); songID != "" {
+			songIDs = append(songIDs, songID)
+		}
+	}
+	if len(songIDs) == 0 {
+		BadRequest(c, "Missing song_ids parameter")
+		return
+	}
+
+	resp, err := h.userClient.GetPlaybackPositions(ctx, userID, songIDs)
+	if err != nil {
+		h.log.WithFields(
+			logger.String("request_id", getRequestID(c)),
+			logger.String("user_id", userID),
+			logger.String("error", err.Error()),
+		).Error("Failed to get playback positions")
+
+		playbackError(c, err, "Failed to get playback positions")
+		return
+	}
+
+	Success(c, gin.H{"items": resp.Positions})
+}
+
+// repeatModeNames 循环模式在HTTP接口中的名称
+var repeatModeNames = map[userv1.RepeatMode]string{
+	userv1.RepeatMode_REPEAT_MODE_OFF: "off",
+	userv1.RepeatMode_REPEAT_MODE_ALL: "all",
+	userv1.RepeatMode_REPEAT_MODE_ONE: "one",
+}
+
+// parseRepeatMode 解析循环模式名称，空字符串返回UNSPECIFIED（按off处理）
+func parseRepeatMode(name string) (userv1.RepeatMode, bool) {
+	if name == "" {
+		return userv1.RepeatMode_REPEAT_MODE_UNSPECIFIED, true
+	}
+	for mode, n := range repeatModeNames {
+		if n == name {
+			return mode, true
+		}
+	}
+	return userv1.RepeatMode_REPEAT_MODE_UNSPECIFIED, false
+}
+
+// playQueueResponse 转换播放队列响应，循环模式使用名称而不是枚举值
+func playQueueResponse(queue *userv1.PlayQueue) gin.H {
+	if queue == nil {
+		queue = &userv1.PlayQueue{}
+	}
+	repeatMode, ok := repeatModeNames[queue.RepeatMode]
+	if !ok {
+		repeatMode = "off"
+	}
+	resp := gin.H{
+		"tracks":        queue.Tracks,
+		"current_index": queue.CurrentIndex,
+		"shuffle":       queue.Shuffle,
+		"repeat_mode":   repeatMode,
+		"device_id":     queue.DeviceId,
+	}
+	if queue.UpdatedAt != nil {
+		resp["updated_at"] = queue.UpdatedAt.AsTime()
+	}
+	return resp
+}
+
+// playbackError 把播放队列和播放进度相关的gRPC错误映射为HTTP响应
+func playbackError(c *gin.Context, err error, msg string) {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		BadRequest(c, status.Convert(err).Message())
+	default:
+		InternalError(c, msg)
 	}
-	return ""
 }
@@ -416,6 +416,13 @@ func (fm *FallbackManager) GetLyric(ctx context.Context, songMid string) (*Lyric
 	return fm.clients[0].GetLyric(ctx, songMid)
 }
 
+func (fm *FallbackManager) GetSimilarSongs(ctx context.Context, songMid string) ([]Song, error) {
+	if len(fm.clients) == 0 {
+		return nil, fmt.Errorf("no clients available")
+	}
+	return fm.clients[0].GetSimilarSongs(ctx, songMid)
+}
+
 func (fm *FallbackManager) GetAlbumDetail(ctx context.Context, albumMid string) (*Album, error) {
 	if len(fm.clients) == 0 {
 		return nil, fmt.Errorf("no clients available")
@@ -155,6 +155,10 @@ func (c *JooxClient) GetLyric(ctx context.Context, songMid string) (*Lyric, erro
 	return nil, fmt.Errorf("not implemented for Joox")
 }
 
+func (c *JooxClient) GetSimilarSongs(ctx context.Context, songMid string) ([]Song, error) {
+	return nil, fmt.Errorf("not implemented for Joox")
+}
+
 func (c *JooxClient) GetAlbumDetail(ctx context.Context, albumMid string) (*Album, error) {
 	return nil, fmt.Errorf("not implemented for Joox")
 }
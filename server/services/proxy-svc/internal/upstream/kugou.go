@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/logger"
@@ -170,8 +171,81 @@ func (c *KugouClient) GetPlaylistsByCategory(ctx context.Context, req GetPlaylis
 	return nil, fmt.Errorf("not implemented for Kugou")
 }
 
+// GetPlaylistDetail gets a public playlist (special) with its tracks (used for playlist import)
 func (c *KugouClient) GetPlaylistDetail(ctx context.Context, dissID string) (*PlaylistDetail, error) {
-	return nil, fmt.Errorf("not implemented for Kugou")
+	reqURL := fmt.Sprintf("%s/plist/list/%s?json=true", c.config.BaseURL, url.PathEscape(dissID))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; ListenStream/1.0)")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Info struct {
+			List struct {
+				SpecialID   int64  `json:"specialid"`
+				SpecialName string `json:"specialname"`
+				Intro       string `json:"intro"`
+				ImgURL      string `json:"imgurl"`
+				SongCount   int    `json:"songcount"`
+				Nickname    string `json:"nickname"`
+			} `json:"list"`
+		} `json:"info"`
+		List struct {
+			List struct {
+				Info []struct {
+					Hash     string `json:"hash"`
+					Filename string `json:"filename"` // "歌手 - 歌名"
+					Duration int    `json:"duration"`
+				} `json:"info"`
+			} `json:"list"`
+		} `json:"list"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if result.Info.List.SpecialName == "" && len(result.List.List.Info) == 0 {
+		return nil, ErrInvalidResponse
+	}
+
+	detail := &PlaylistDetail{
+		Playlist: Playlist{
+			DissID:      dissID,
+			DissName:    result.Info.List.SpecialName,
+			Logo:        strings.ReplaceAll(result.Info.List.ImgURL, "{size}", "400"),
+			SongCount:   result.Info.List.SongCount,
+			Description: result.Info.List.Intro,
+			Creator:     result.Info.List.Nickname,
+		},
+		Songs: make([]Song, 0, len(result.List.List.Info)),
+	}
+
+	for _, track := range result.List.List.Info {
+		artist, name := "", track.Filename
+		if parts := strings.SplitN(track.Filename, " - ", 2); len(parts) == 2 {
+			artist, name = parts[0], parts[1]
+		}
+
+		detail.Songs = append(detail.Songs, Song{
+			ID:       track.Hash,
+			Name:     strings.TrimSpace(name),
+			Artist:   strings.TrimSpace(artist),
+			Duration: track.Duration,
+		})
+	}
+
+	return detail, nil
 }
 
 func (c *KugouClient) GetSongDetail(ctx context.Context, songMid string) (*SongDetail, error) {
@@ -182,6 +256,10 @@ func (c *KugouClient) GetLyric(ctx context.Context, songMid string) (*Lyric, err
 	return nil, fmt.Errorf("not implemented for Kugou")
 }
 
+func (c *KugouClient) GetSimilarSongs(ctx context.Context, songMid string) ([]Song, error) {
+	return nil, fmt.Errorf("not implemented for Kugou")
+}
+
 func (c *KugouClient) GetAlbumDetail(ctx context.Context, albumMid string) (*Album, error) {
 	return nil, fmt.Errorf("not implemented for Kugou")
 }
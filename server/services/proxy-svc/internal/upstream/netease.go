@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/logger"
@@ -193,8 +194,95 @@ func (c *NetEaseClient) GetPlaylistsByCategory(ctx context.Context, req GetPlayl
 	return nil, fmt.Errorf("not implemented for NetEase")
 }
 
+// GetPlaylistDetail gets a public playlist with its tracks (used for playlist import)
 func (c *NetEaseClient) GetPlaylistDetail(ctx context.Context, dissID string) (*PlaylistDetail, error) {
-	return nil, fmt.Errorf("not implemented for NetEase")
+	reqURL := fmt.Sprintf("%s/v6/playlist/detail", c.config.BaseURL)
+	params := url.Values{}
+	params.Add("id", dissID)
+	params.Add("n", "1000")
+	reqURL += "?" + params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; ListenStream/1.0)")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Referer", "https://music.163.com")
+	if c.config.Cookie != "" {
+		req.Header.Set("Cookie", c.config.Cookie)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Code     int `json:"code"`
+		Playlist struct {
+			ID          int64  `json:"id"`
+			Name        string `json:"name"`
+			Description string `json:"description"`
+			CoverImgURL string `json:"coverImgUrl"`
+			TrackCount  int    `json:"trackCount"`
+			Creator     struct {
+				Nickname string `json:"nickname"`
+			} `json:"creator"`
+			Tracks []struct {
+				ID   int64  `json:"id"`
+				Name string `json:"name"`
+				Ar   []struct {
+					Name string `json:"name"`
+				} `json:"ar"`
+				Al struct {
+					Name   string `json:"name"`
+					PicURL string `json:"picUrl"`
+				} `json:"al"`
+				Dt int `json:"dt"` // milliseconds
+			} `json:"tracks"`
+		} `json:"playlist"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if result.Code != 200 {
+		return nil, fmt.Errorf("API error code: %d", result.Code)
+	}
+
+	detail := &PlaylistDetail{
+		Playlist: Playlist{
+			DissID:      fmt.Sprintf("%d", result.Playlist.ID),
+			DissName:    result.Playlist.Name,
+			Logo:        result.Playlist.CoverImgURL,
+			SongCount:   result.Playlist.TrackCount,
+			Description: result.Playlist.Description,
+			Creator:     result.Playlist.Creator.Nickname,
+		},
+		Songs: make([]Song, 0, len(result.Playlist.Tracks)),
+	}
+
+	for _, track := range result.Playlist.Tracks {
+		artists := make([]string, 0, len(track.Ar))
+		for _, ar := range track.Ar {
+			artists = append(artists, ar.Name)
+		}
+
+		detail.Songs = append(detail.Songs, Song{
+			ID:       fmt.Sprintf("%d", track.ID),
+			Name:     track.Name,
+			Artist:   strings.Join(artists, "/"),
+			Album:    track.Al.Name,
+			CoverURL: track.Al.PicURL,
+			Duration: track.Dt / 1000,
+		})
+	}
+
+	return detail, nil
 }
 
 func (c *NetEaseClient) GetSongDetail(ctx context.Context, songMid string) (*SongDetail, error) {
@@ -205,6 +293,10 @@ func (c *NetEaseClient) GetLyric(ctx context.Context, songMid string) (*Lyric, e
 	return nil, fmt.Errorf("not implemented for NetEase")
 }
 
+func (c *NetEaseClient) GetSimilarSongs(ctx context.Context, songMid string) ([]Song, error) {
+	return nil, fmt.Errorf("not implemented for NetEase")
+}
+
 func (c *NetEaseClient) GetAlbumDetail(ctx context.Context, albumMid string) (*Album, error) {
 	return nil, fmt.Errorf("not implemented for NetEase")
 }
@@ -0,0 +1,379 @@
+package upstream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/xiaoxiao0301/listen-stream-v2/server/shared/pkg/logger"
+)
+
+// 支持导入的歌单来源（与FallbackManager中的源名称一致）
+const (
+	ImportSourceQQMusic = "qq-music"
+	ImportSourceNetEase = "netease"
+	ImportSourceKugou   = "kugou"
+)
+
+const (
+	// MaxImportTracks 单次导入的最大歌曲数
+	MaxImportTracks = 1000
+	// importResolveWorkers 并发匹配歌曲的协程数（受QQ音乐限流约束）
+	importResolveWorkers = 5
+	// importSearchSize 每首歌搜索的候选数
+	importSearchSize = 5
+)
+
+// ErrInvalidPlaylistRef 无法识别的歌单链接或ID
+var ErrInvalidPlaylistRef = errors.New("invalid playlist url or id")
+
+var (
+	digitsPattern      = regexp.MustCompile(`^\d+$`)
+	qqPathPattern      = regexp.MustCompile(`/playlist/(\d+)`)
+	kugouPathPattern   = regexp.MustCompile(`/(?:special/single|plist/list)/(\d+)`)
+	bracketPattern     = regexp.MustCompile(`[(（\[【].*?[)）\]】]`)
+	artistSplitPattern = regexp.MustCompile(`\s*(?:/|、|&|,|，|;| feat\.? | ft\.? )\s*`)
+)
+
+// PlaylistRef 外部歌单引用
+type PlaylistRef struct {
+	Source string `json:"source"`
+	ID     string `json:"id"`
+}
+
+// UnmatchedTrack 导入时未能匹配到曲库的歌曲
+type UnmatchedTrack struct {
+	Position int    `json:"position"` // 在原歌单中的位置（从1开始）
+	Name     string `json:"name"`
+	Artist   string `json:"artist"`
+	Reason   string `json:"reason"`
+}
+
+// ResolvedPlaylist 解析并匹配后的外部歌单，Songs为曲库（QQ音乐）中的歌曲，顺序与原歌单一致
+type ResolvedPlaylist struct {
+	Source      string           `json:"source"`
+	ID          string           `json:"id"`
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	CoverURL    string           `json:"cover_url"`
+	TotalTracks int              `json:"total_tracks"`
+	Songs       []Song           `json:"songs"`
+	Unmatched   []UnmatchedTrack `json:"unmatched"`
+}
+
+// ParsePlaylistRef 解析歌单链接或ID
+// source为空时根据链接域名识别来源；input为纯数字ID时必须指定source
+func ParsePlaylistRef(source, input string) (*PlaylistRef, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, ErrInvalidPlaylistRef
+	}
+
+	if digitsPattern.MatchString(input) {
+		switch source {
+		case ImportSourceQQMusic, ImportSourceNetEase, ImportSourceKugou:
+			return &PlaylistRef{Source: source, ID: input}, nil
+		default:
+			return nil, fmt.Errorf("%w: source is required for a bare id", ErrInvalidPlaylistRef)
+		}
+	}
+
+	u, err := url.Parse(input)
+	if err != nil || u.Host == "" {
+		return nil, ErrInvalidPlaylistRef
+	}
+
+	host := strings.ToLower(u.Hostname())
+	detected := ""
+	switch {
+	case strings.HasSuffix(host, "qq.com"):
+		detected = ImportSourceQQMusic
+	case strings.HasSuffix(host, "163.com") || strings.HasSuffix(host, "163cn.tv"):
+		detected = ImportSourceNetEase
+	case strings.HasSuffix(host, "kugou.com"):
+		detected = ImportSourceKugou
+	default:
+		return nil, fmt.Errorf("%w: unsupported host %s", ErrInvalidPlaylistRef, host)
+	}
+	if source != "" && source != detected {
+		return nil, fmt.Errorf("%w: url does not belong to %s", ErrInvalidPlaylistRef, source)
+	}
+
+	// 网易云的链接常见形式为 https://music.163.com/#/playlist?id=xxx，id在fragment中
+	query := u.Query()
+	if i := strings.Index(u.Fragment, "?"); i >= 0 {
+		if fragmentQuery, err := url.ParseQuery(u.Fragment[i+1:]); err == nil {
+			for k, v := range fragmentQuery {
+				query[k] = v
+			}
+		}
+	}
+
+	var id string
+	switch detected {
+	case ImportSourceQQMusic:
+		id = firstDigits(query.Get("id"), query.Get("disstid"), query.Get("dissid"))
+		if id == "" {
+			if m := qqPathPattern.FindStringSubmatch(u.Path); m != nil {
+				id = m[1]
+			}
+		}
+	case ImportSourceNetEase:
+		id = firstDigits(query.Get("id"))
+	case ImportSourceKugou:
+		id = firstDigits(query.Get("specialid"), query.Get("id"))
+		if id == "" {
+			if m := kugouPathPattern.FindStringSubmatch(u.Path); m != nil {
+				id = m[1]
+			}
+		}
+	}
+	if id == "" {
+		return nil, fmt.Errorf("%w: playlist id not found in url", ErrInvalidPlaylistRef)
+	}
+
+	return &PlaylistRef{Source: detected, ID: id}, nil
+}
+
+func firstDigits(values ...string) string {
+	for _, v := range values {
+		if digitsPattern.MatchString(v) {
+			return v
+		}
+	}
+	return ""
+}
+
+// ResolvePlaylist 获取外部歌单并把歌曲匹配到曲库（QQ音乐）
+// QQ音乐歌单直接使用原歌曲；其他来源按"歌名+歌手"在QQ音乐中搜索匹配，匹配失败的歌曲记入Unmatched
+func (fm *FallbackManager) ResolvePlaylist(ctx context.Context, ref *PlaylistRef) (*ResolvedPlaylist, error) {
+	fm.mu.RLock()
+	defer fm.mu.RUnlock()
+
+	if len(fm.clients) == 0 {
+		return nil, fmt.Errorf("no clients available")
+	}
+
+	source := fm.clientByName(ref.Source)
+	if source == nil {
+		return nil, fmt.Errorf("%w: unknown source %s", ErrInvalidPlaylistRef, ref.Source)
+	}
+
+	detail, err := source.GetPlaylistDetail(ctx, ref.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get %s playlist %s: %w", ref.Source, ref.ID, err)
+	}
+
+	tracks := detail.Songs
+	resolved := &ResolvedPlaylist{
+		Source:      ref.Source,
+		ID:          ref.ID,
+		Name:        detail.DissName,
+		Description: detail.Description,
+		CoverURL:    detail.Logo,
+		TotalTracks: len(tracks),
+		Songs:       []Song{},
+		Unmatched:   []UnmatchedTrack{},
+	}
+
+	if len(tracks) > MaxImportTracks {
+		for i := MaxImportTracks; i < len(tracks); i++ {
+			resolved.Unmatched = append(resolved.Unmatched, UnmatchedTrack{
+				Position: i + 1,
+				Name:     trackName(tracks[i]),
+				Artist:   trackArtist(tracks[i]),
+				Reason:   "too_many_tracks",
+			})
+		}
+		tracks = tracks[:MaxImportTracks]
+	}
+
+	matches := make([]*Song, len(tracks))
+	reasons := make([]string, len(tracks))
+
+	if ref.Source == ImportSourceQQMusic {
+		for i := range tracks {
+			if tracks[i].SongMid != "" {
+				matches[i] = &tracks[i]
+			} else {
+				reasons[i] = "unavailable"
+			}
+		}
+	} else {
+		fm.matchTracks(ctx, fm.clients[0], tracks, matches, reasons)
+	}
+
+	for i, track := range tracks {
+		if matches[i] == nil {
+			resolved.Unmatched = append(resolved.Unmatched, UnmatchedTrack{
+				Position: i + 1,
+				Name:     trackName(track),
+				Artist:   trackArtist(track),
+				Reason:   reasons[i],
+			})
+			continue
+		}
+		resolved.Songs = append(resolved.Songs, *matches[i])
+	}
+
+	// 超出上限的歌曲先加入了Unmatched，按原位置重新排序
+	sort.SliceStable(resolved.Unmatched, func(i, j int) bool {
+		return resolved.Unmatched[i].Position < resolved.Unmatched[j].Position
+	})
+
+	fm.logger.Info("Resolved external playlist",
+		logger.String("source", ref.Source),
+		logger.String("playlist_id", ref.ID),
+		logger.Int("total", resolved.TotalTracks),
+		logger.Int("matched", len(resolved.Songs)),
+		logger.Int("unmatched", len(resolved.Unmatched)),
+	)
+
+	return resolved, nil
+}
+
+// clientByName 按源名称获取客户端
+func (fm *FallbackManager) clientByName(name string) ClientInterface {
+	for i, n := range fm.names {
+		if n == name {
+			return fm.clients[i]
+		}
+	}
+	return nil
+}
+
+// matchTracks 并发在曲库中搜索匹配歌曲
+func (fm *FallbackManager) matchTracks(ctx context.Context, catalog ClientInterface, tracks []Song, matches []*Song, reasons []string) {
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < importResolveWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				song, reason := matchTrack(ctx, catalog, tracks[i])
+				matches[i] = song
+				reasons[i] = reason
+			}
+		}()
+	}
+
+	for i := range tracks {
+		if ctx.Err() != nil {
+			reasons[i] = "canceled"
+			continue
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// matchTrack 搜索单首歌曲，歌名一致且歌手有交集才视为匹配
+func matchTrack(ctx context.Context, catalog ClientInterface, track Song) (*Song, string) {
+	name, artist := trackName(track), trackArtist(track)
+	if name == "" {
+		return nil, "missing_name"
+	}
+
+	page, err := catalog.SearchSongs(ctx, strings.TrimSpace(name+" "+artist), 1, importSearchSize)
+	if err != nil {
+		return nil, "search_failed"
+	}
+
+	candidates, err := decodeSongs(page.Data)
+	if err != nil || len(candidates) == 0 {
+		return nil, "not_found"
+	}
+
+	wantName := normalizeTitle(name)
+	wantArtists := splitArtists(artist)
+	for i := range candidates {
+		candidate := &candidates[i]
+		if candidate.SongMid == "" || normalizeTitle(trackName(*candidate)) != wantName {
+			continue
+		}
+		if len(wantArtists) == 0 || artistsOverlap(wantArtists, splitArtists(trackArtist(*candidate))) {
+			return candidate, ""
+		}
+	}
+	return nil, "not_found"
+}
+
+// decodeSongs PageResponse.Data经过JSON解码后是[]interface{}，重新编码为[]Song
+func decodeSongs(data interface{}) ([]Song, error) {
+	if songs, ok := data.([]Song); ok {
+		return songs, nil
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var songs []Song
+	if err := json.Unmarshal(raw, &songs); err != nil {
+		return nil, err
+	}
+	return songs, nil
+}
+
+func trackName(s Song) string {
+	if s.SongName != "" {
+		return s.SongName
+	}
+	return s.Name
+}
+
+func trackArtist(s Song) string {
+	if s.SingerName != "" {
+		return s.SingerName
+	}
+	if len(s.Singers) > 0 {
+		names := make([]string, 0, len(s.Singers))
+		for _, singer := range s.Singers {
+			names = append(names, singer.SingerName)
+		}
+		return strings.Join(names, "/")
+	}
+	return s.Artist
+}
+
+// normalizeTitle 歌名归一化：去掉括号内的版本说明、空白和标点，统一小写
+func normalizeTitle(name string) string {
+	name = bracketPattern.ReplaceAllString(name, "")
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func splitArtists(artist string) []string {
+	var artists []string
+	for _, a := range artistSplitPattern.Split(strings.ToLower(artist), -1) {
+		if a = strings.TrimSpace(a); a != "" {
+			artists = append(artists, a)
+		}
+	}
+	return artists
+}
+
+func artistsOverlap(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
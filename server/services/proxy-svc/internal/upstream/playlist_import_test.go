@@ -0,0 +1,165 @@
+package upstream
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// fakeClient 只实现歌单导入用到的方法，其余方法调用会panic
+type fakeClient struct {
+	ClientInterface
+	playlists map[string]*PlaylistDetail
+	search    map[string][]Song
+}
+
+func (f *fakeClient) GetPlaylistDetail(ctx context.Context, dissID string) (*PlaylistDetail, error) {
+	if detail, ok := f.playlists[dissID]; ok {
+		return detail, nil
+	}
+	return nil, ErrInvalidResponse
+}
+
+func (f *fakeClient) SearchSongs(ctx context.Context, keyword string, page, size int) (*PageResponse, error) {
+	songs, ok := f.search[keyword]
+	if !ok {
+		return nil, errors.New("search failed")
+	}
+	// 模拟经过缓存JSON往返后的数据
+	data := make([]interface{}, 0, len(songs))
+	for _, s := range songs {
+		data = append(data, map[string]interface{}{
+			"song_mid":    s.SongMid,
+			"song_name":   s.SongName,
+			"singer_name": s.SingerName,
+		})
+	}
+	return &PageResponse{Total: len(songs), Page: page, Size: size, Data: data}, nil
+}
+
+func TestParsePlaylistRef(t *testing.T) {
+	tests := []struct {
+		source string
+		input  string
+		want   PlaylistRef
+	}{
+		{"", "https://music.163.com/#/playlist?id=2829883282", PlaylistRef{ImportSourceNetEase, "2829883282"}},
+		{"", "https://music.163.com/playlist?id=123&userid=9", PlaylistRef{ImportSourceNetEase, "123"}},
+		{"", "https://y.qq.com/n/ryqq/playlist/7256912512", PlaylistRef{ImportSourceQQMusic, "7256912512"}},
+		{"", "https://i.y.qq.com/n2/m/share/details/taoge.html?id=7256912512", PlaylistRef{ImportSourceQQMusic, "7256912512"}},
+		{"", "https://www.kugou.com/yy/special/single/546903.html", PlaylistRef{ImportSourceKugou, "546903"}},
+		{"kugou", "https://m.kugou.com/plist/list/546903?json=true", PlaylistRef{ImportSourceKugou, "546903"}},
+		{"netease", " 123456 ", PlaylistRef{ImportSourceNetEase, "123456"}},
+	}
+
+	for _, tt := range tests {
+		got, err := ParsePlaylistRef(tt.source, tt.input)
+		if err != nil {
+			t.Errorf("ParsePlaylistRef(%q, %q) error: %v", tt.source, tt.input, err)
+			continue
+		}
+		if *got != tt.want {
+			t.Errorf("ParsePlaylistRef(%q, %q) = %+v, want %+v", tt.source, tt.input, *got, tt.want)
+		}
+	}
+
+	invalid := []struct{ source, input string }{
+		{"", ""},
+		{"", "123456"},
+		{"spotify", "123456"},
+		{"", "https://open.spotify.com/playlist/abc"},
+		{"qq-music", "https://music.163.com/#/playlist?id=1"},
+		{"", "https://music.163.com/#/discover"},
+	}
+	for _, tt := range invalid {
+		if _, err := ParsePlaylistRef(tt.source, tt.input); !errors.Is(err, ErrInvalidPlaylistRef) {
+			t.Errorf("ParsePlaylistRef(%q, %q) error = %v, want ErrInvalidPlaylistRef", tt.source, tt.input, err)
+		}
+	}
+}
+
+func TestResolvePlaylist_MatchesToCatalog(t *testing.T) {
+	qq := &fakeClient{search: map[string][]Song{
+		"晴天 周杰伦": {
+			{SongMid: "live", SongName: "晴天 (Live)", SingerName: "某翻唱"},
+			{SongMid: "mid-qingtian", SongName: "晴天", SingerName: "周杰伦"},
+		},
+		"Stay The Kid LAROI/Justin Bieber": {
+			{SongMid: "mid-stay", SongName: "STAY", SingerName: "Justin Bieber/The Kid LAROI"},
+		},
+		"不存在的歌 无名": {},
+	}}
+	netease := &fakeClient{playlists: map[string]*PlaylistDetail{
+		"42": {
+			Playlist: Playlist{DissName: "我的歌单", Description: "desc", Logo: "cover.jpg"},
+			Songs: []Song{
+				{ID: "1", Name: "晴天", Artist: "周杰伦"},
+				{ID: "2", Name: "不存在的歌", Artist: "无名"},
+				{ID: "3", Name: "Stay", Artist: "The Kid LAROI/Justin Bieber"},
+				{ID: "4", Name: "", Artist: "无名"},
+				{ID: "5", Name: "搜索失败", Artist: "无名"},
+			},
+		},
+	}}
+	fm := NewFallbackManager([]ClientInterface{qq, netease}, []string{ImportSourceQQMusic, ImportSourceNetEase}, &mockLogger{})
+
+	resolved, err := fm.ResolvePlaylist(context.Background(), &PlaylistRef{Source: ImportSourceNetEase, ID: "42"})
+	if err != nil {
+		t.Fatalf("ResolvePlaylist error: %v", err)
+	}
+
+	if resolved.Name != "我的歌单" || resolved.CoverURL != "cover.jpg" || resolved.TotalTracks != 5 {
+		t.Errorf("unexpected playlist meta: %+v", resolved)
+	}
+
+	// 匹配结果保持原歌单顺序
+	if len(resolved.Songs) != 2 || resolved.Songs[0].SongMid != "mid-qingtian" || resolved.Songs[1].SongMid != "mid-stay" {
+		t.Fatalf("unexpected matched songs: %+v", resolved.Songs)
+	}
+
+	wantUnmatched := []UnmatchedTrack{
+		{Position: 2, Name: "不存在的歌", Artist: "无名", Reason: "not_found"},
+		{Position: 4, Name: "", Artist: "无名", Reason: "missing_name"},
+		{Position: 5, Name: "搜索失败", Artist: "无名", Reason: "search_failed"},
+	}
+	if len(resolved.Unmatched) != len(wantUnmatched) {
+		t.Fatalf("unmatched = %+v, want %+v", resolved.Unmatched, wantUnmatched)
+	}
+	for i, want := range wantUnmatched {
+		if resolved.Unmatched[i] != want {
+			t.Errorf("unmatched[%d] = %+v, want %+v", i, resolved.Unmatched[i], want)
+		}
+	}
+}
+
+func TestResolvePlaylist_QQMusicAndErrors(t *testing.T) {
+	qq := &fakeClient{playlists: map[string]*PlaylistDetail{
+		"7": {
+			Playlist: Playlist{DissName: "QQ歌单"},
+			Songs: []Song{
+				{SongMid: "a", SongName: "A", SingerName: "X"},
+				{SongName: "下架歌曲", SingerName: "Y"},
+			},
+		},
+	}}
+	fm := NewFallbackManager([]ClientInterface{qq}, []string{ImportSourceQQMusic}, &mockLogger{})
+	ctx := context.Background()
+
+	resolved, err := fm.ResolvePlaylist(ctx, &PlaylistRef{Source: ImportSourceQQMusic, ID: "7"})
+	if err != nil {
+		t.Fatalf("ResolvePlaylist error: %v", err)
+	}
+	if len(resolved.Songs) != 1 || resolved.Songs[0].SongMid != "a" {
+		t.Errorf("unexpected songs: %+v", resolved.Songs)
+	}
+	if len(resolved.Unmatched) != 1 || resolved.Unmatched[0].Reason != "unavailable" {
+		t.Errorf("unexpected unmatched: %+v", resolved.Unmatched)
+	}
+
+	if _, err := fm.ResolvePlaylist(ctx, &PlaylistRef{Source: ImportSourceKugou, ID: "7"}); !errors.Is(err, ErrInvalidPlaylistRef) {
+		t.Errorf("unknown source error = %v, want ErrInvalidPlaylistRef", err)
+	}
+	if _, err := fm.ResolvePlaylist(ctx, &PlaylistRef{Source: ImportSourceQQMusic, ID: "404"}); !errors.Is(err, ErrInvalidResponse) {
+		t.Errorf("missing playlist error = %v, want ErrInvalidResponse", err)
+	}
+}
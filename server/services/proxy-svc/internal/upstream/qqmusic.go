@@ -931,6 +931,32 @@ func (c *QQMusicClient) GetLyric(ctx context.Context, songMid string) (*Lyric, e
 	return &response.Data, nil
 }
 
+// GetSimilarSongs 获取相似歌曲
+func (c *QQMusicClient) GetSimilarSongs(ctx context.Context, songMid string) ([]Song, error) {
+	path := fmt.Sprintf("/song/similar?id=%s", url.QueryEscape(songMid))
+
+	data, err := c.Get(ctx, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get similar songs failed: %w", err)
+	}
+
+	var response struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    []Song `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("parse response failed: %w", err)
+	}
+
+	if response.Code != 1 {
+		return nil, fmt.Errorf("api error: code=%d, msg=%s", response.Code, response.Message)
+	}
+
+	return response.Data, nil
+}
+
 // HealthCheck 健康检查
 func (c *QQMusicClient) HealthCheck(ctx context.Context) error {
 	// 使用轻量级接口进行健康检查
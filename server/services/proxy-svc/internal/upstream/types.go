@@ -303,6 +303,7 @@ type ClientInterface interface {
 	// Song模块
 	GetSongDetail(ctx context.Context, songMid string) (*SongDetail, error)
 	GetLyric(ctx context.Context, songMid string) (*Lyric, error)
+	GetSimilarSongs(ctx context.Context, songMid string) ([]Song, error)
 
 	// Album模块
 	GetAlbumDetail(ctx context.Context, albumMid string) (*Album, error)
@@ -75,8 +75,13 @@ func main() {
 	defer cancel()
 	go wsManager.Start(managerCtx)
 
+	internalToken := os.Getenv("INTERNAL_API_TOKEN")
+	if internalToken == "" {
+		log.Println("Warning: INTERNAL_API_TOKEN not set, internal user data API is disabled")
+	}
+
 	// 创建HTTP服务器
-	server := startHTTPServer(port, jwtSecret, wsManager)
+	server := startHTTPServer(port, jwtSecret, internalToken, wsManager)
 
 	// 等待中断信号
 	quit := make(chan os.Signal, 1)
@@ -104,7 +109,7 @@ func main() {
 	log.Println("sync-svc stopped")
 }
 
-func startHTTPServer(port, jwtSecret string, wsManager *ws.Manager) *http.Server {
+func startHTTPServer(port, jwtSecret, internalToken string, wsManager *ws.Manager) *http.Server {
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.New() // 使用gin.New()而不是gin.Default()
 
@@ -154,6 +159,14 @@ func startHTTPServer(port, jwtSecret string, wsManager *ws.Manager) *http.Server
 		api.GET("/stats/pubsub", eventHandler.GetPubSubStats)
 	}
 	
+	// 用户数据API（服务间调用，需要内部令牌：账号数据下载、注销账号）
+	internalAPI := router.Group("/internal/v1/users/:user_id")
+	internalAPI.Use(middleware.InternalAuth(internalToken))
+	{
+		internalAPI.GET("/data", wsHandler.ExportUserData)
+		internalAPI.DELETE("/data", wsHandler.EraseUserData)
+	}
+
 	// 事件API（需要JWT认证 + 限流 + 请求验证）
 	eventAPI := router.Group("/api/v1/events")
 	eventAPI.Use(
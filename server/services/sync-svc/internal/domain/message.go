@@ -14,6 +14,9 @@ const (
 	MessageTypePlaylistSongAdded MessageType = "playlist.song.added"
 	MessageTypePlaylistSongRemoved MessageType = "playlist.song.removed"
 	MessageTypeHistoryAdded      MessageType = "history.added"
+	MessageTypeSecurityLoginAlert MessageType = "security.login_alert" // 可疑登录提醒（由auth-svc发布）
+	MessageTypePlaybackQueueUpdated    MessageType = "playback.queue_updated"    // 播放队列变更（由user-svc发布）
+	MessageTypePlaybackPositionUpdated MessageType = "playback.position_updated" // 播放进度变更（由user-svc发布）
 	MessageTypePing              MessageType = "ping"
 	MessageTypePong              MessageType = "pong"
 )
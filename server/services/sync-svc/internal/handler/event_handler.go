@@ -253,6 +253,8 @@ func isValidMessageType(msgType domain.MessageType) bool {
 		domain.MessageTypePlaylistSongAdded,
 		domain.MessageTypePlaylistSongRemoved,
 		domain.MessageTypeHistoryAdded,
+		domain.MessageTypePlaybackQueueUpdated,
+		domain.MessageTypePlaybackPositionUpdated,
 	}
 
 	for _, valid := range validTypes {
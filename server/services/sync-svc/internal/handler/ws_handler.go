@@ -279,3 +279,45 @@ func (h *WSHandler) GetOfflineStats(c *gin.Context) {
 	c.JSON(http.StatusOK, stats)
 }
 
+// ExportUserData 导出用户数据（内部调用，账号数据下载）
+func (h *WSHandler) ExportUserData(c *gin.Context) {
+	userID := c.Param("user_id")
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id required"})
+		return
+	}
+
+	messages, err := h.manager.ExportUserData(c.Request.Context(), userID)
+	if err != nil {
+		log.Printf("Failed to export user data: user=%s, error=%v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to export user data"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user_id":          userID,
+		"offline_messages": messages,
+	})
+}
+
+// EraseUserData 删除用户数据（内部调用，注销账号）
+func (h *WSHandler) EraseUserData(c *gin.Context) {
+	userID := c.Param("user_id")
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id required"})
+		return
+	}
+
+	deleted, err := h.manager.EraseUserData(c.Request.Context(), userID)
+	if err != nil {
+		log.Printf("Failed to erase user data: user=%s, error=%v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to erase user data"})
+		return
+	}
+
+	log.Printf("Erased user data: user=%s, offline_messages=%d", userID, deleted)
+	c.JSON(http.StatusOK, gin.H{
+		"user_id":                  userID,
+		"offline_messages_deleted": deleted,
+	})
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InternalTokenHeader 服务间调用的令牌请求头
+const InternalTokenHeader = "X-Internal-Token"
+
+// InternalAuth 服务间调用认证中间件
+// token为空时拒绝所有请求，避免未配置时内部接口对外暴露
+func InternalAuth(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		provided := c.GetHeader(InternalTokenHeader)
+		if token == "" || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid internal token"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -447,6 +447,38 @@ func (m *Manager) GetOfflineMessageCount(ctx context.Context, userID string) (in
 	return m.offline.Count(ctx, userID)
 }
 
+// ExportUserData 导出用户待投递的离线消息（账号数据下载）
+func (m *Manager) ExportUserData(ctx context.Context, userID string) ([]*offline.OfflineMessage, error) {
+	messages, err := m.offline.Pull(ctx, userID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// ACK令牌仅用于投递确认，不属于用户数据
+	for _, msg := range messages {
+		msg.AckToken = ""
+	}
+	return messages, nil
+}
+
+// EraseUserData 删除用户的离线消息并断开本实例上的连接（注销账号）
+// 可重复调用；其他实例上的连接在Token失效后无法重连
+func (m *Manager) EraseUserData(ctx context.Context, userID string) (int64, error) {
+	count, err := m.offline.Count(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	if err := m.offline.Clear(ctx, userID); err != nil {
+		return 0, err
+	}
+
+	for _, conn := range m.room.GetUserConnections(userID) {
+		conn.Close("account deleted")
+	}
+
+	return count, nil
+}
+
 // shutdown 关闭所有连接
 func (m *Manager) shutdown() {
 	log.Println("Shutting down WebSocket manager...")
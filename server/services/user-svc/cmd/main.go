@@ -8,18 +8,24 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
 	"user-svc/internal/cron"
+	"user-svc/internal/domain"
 	"user-svc/internal/grpc"
 	"user-svc/internal/handler"
+	"user-svc/internal/listener"
 	"user-svc/internal/middleware"
 	"user-svc/internal/repository"
 	"user-svc/internal/service"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+	"github.com/listen-stream/server/shared/pkg/stats"
+	"github.com/listen-stream/server/shared/pkg/syncevent"
 	userv1 "github.com/listen-stream/server/shared/proto/user/v1"
 	grpc_server "google.golang.org/grpc"
 	"google.golang.org/grpc/health"
@@ -40,16 +46,40 @@ func main() {
 	}
 	defer db.Close()
 
-	favoriteService, historyService, playlistService, cleanupService := initServices(db)
-
-	cronManager := cron.NewCronManager(cleanupService)
+	redisClient, err := initRedis()
+	if err != nil {
+		log.Fatalf("Failed to initialize redis: %v", err)
+	}
+	defer redisClient.Close()
+
+	// 播放、收藏和建歌单计入admin-svc的运营统计，停机时写出缓冲中的事件
+	metrics := stats.NewRedisEmitter(redisClient, stats.DefaultConfig())
+	defer metrics.Close()
+
+	favoriteService, historyService, playlistService, transferService, memberService, discoveryService, trashService, cleanupService, accountService, statsService, recsService, releaseService, playbackService := initServices(db, redisClient, metrics)
+
+	cronManager := cron.NewCronManager(cron.CronDeps{
+		Cleanup:         cleanupService,
+		Stats:           statsService,
+		Recommendations: recsService,
+		Trash:           trashService,
+		Releases:        releaseService,
+		Playback:        playbackService,
+	})
 	if err := cronManager.Start(); err != nil {
 		log.Fatalf("Failed to start cron manager: %v", err)
 	}
 	defer cronManager.Stop()
 
-	httpServer := startHTTPServer(favoriteService, historyService, playlistService)
-	grpcServer := startGRPCServer(favoriteService, historyService, playlistService)
+	// 收藏和播放事件触发智能歌单重新计算
+	syncListener := listener.NewSyncListener(redisClient, playlistService)
+	if err := syncListener.Start(context.Background()); err != nil {
+		log.Fatalf("Failed to start sync listener: %v", err)
+	}
+	defer syncListener.Stop()
+
+	httpServer := startHTTPServer(favoriteService, historyService, playlistService, transferService, memberService, discoveryService, trashService, statsService, recsService, releaseService, playbackService)
+	grpcServer := startGRPCServer(favoriteService, historyService, playlistService, transferService, memberService, discoveryService, trashService, accountService, statsService, recsService, releaseService, playbackService)
 
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -64,6 +94,13 @@ func main() {
 	}
 
 	grpcServer.GracefulStop()
+
+	// 停机前回写Redis中尚未落库的播放队列和播放进度
+	flushCtx, flushCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer flushCancel()
+	if err := cronManager.RunPlaybackFlushNow(flushCtx); err != nil {
+		log.Printf("Failed to flush playback state on shutdown: %v", err)
+	}
 	log.Println("user-svc stopped")
 }
 
@@ -95,26 +132,124 @@ func initDatabase() (*pgxpool.Pool, error) {
 	return pool, nil
 }
 
-func initServices(db *pgxpool.Pool) (*service.FavoriteService, *service.PlayHistoryService, *service.PlaylistService, *service.CleanupService) {
+func initRedis() (*redis.Client, error) {
+	addr := os.Getenv("REDIS_ADDR")
+	if addr == "" {
+		addr = "localhost:6379"
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Password: os.Getenv("REDIS_PASSWORD"),
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("failed to ping redis: %w", err)
+	}
+
+	log.Println("Redis connected successfully")
+	return client, nil
+}
+
+func initServices(db *pgxpool.Pool, redisClient *redis.Client, metrics stats.Emitter) (*service.FavoriteService, *service.PlayHistoryService, *service.PlaylistService, *service.PlaylistTransferService, *service.PlaylistMemberService, *service.PlaylistDiscoveryService, *service.TrashService, *service.CleanupService, *service.AccountDataService, *service.ListeningStatsService, *service.RecommendationService, *service.SingerReleaseService, *service.PlaybackService) {
 	// 初始化仓储层
 	favoriteRepo := repository.NewFavoriteRepository(db)
 	historyRepo := repository.NewPlayHistoryRepository(db)
 	playlistRepo := repository.NewPlaylistRepository(db)
 	playlistSongRepo := repository.NewPlaylistSongRepository(db)
+	playlistMemberRepo := repository.NewPlaylistMemberRepository(db)
+	playlistFollowRepo := repository.NewPlaylistFollowRepository(db)
+	statsRepo := repository.NewListeningStatsRepository(db)
+	recsRepo := repository.NewRecommendationRepository(db)
+	recsCache := repository.NewRecommendationCache(redisClient)
+	smartPlaylistRepo := repository.NewSmartPlaylistRepository(db)
+	smartPlaylistCache := repository.NewSmartPlaylistCache(redisClient)
+	releaseRepo := repository.NewSingerReleaseRepository(db)
+	playbackRepo := repository.NewPlaybackRepository(db)
+	playbackCache := repository.NewPlaybackCache(redisClient)
 
 	// 初始化服务层
-	favoriteService := service.NewFavoriteService(favoriteRepo)
-	historyService := service.NewPlayHistoryService(historyRepo)
-	playlistService := service.NewPlaylistService(playlistRepo, playlistSongRepo)
+	favoriteService := service.NewFavoriteService(favoriteRepo, smartPlaylistCache, metrics)
+	historyService := service.NewPlayHistoryService(historyRepo, smartPlaylistCache, metrics)
+	// 协作歌单的变更经sync-svc推送给所有成员
+	syncPublisher := syncevent.NewRedisPublisher(redisClient, "user-svc")
+	playlistService := service.NewPlaylistService(playlistRepo, playlistSongRepo, playlistMemberRepo, smartPlaylistRepo, smartPlaylistCache, syncPublisher, metrics)
+	transferService := service.NewPlaylistTransferService(playlistService, playlistRepo, playlistSongRepo, os.Getenv("PUBLIC_API_URL"))
+	memberService := service.NewPlaylistMemberService(playlistService, playlistRepo, playlistMemberRepo)
+	discoveryService := service.NewPlaylistDiscoveryService(playlistService, playlistRepo, playlistSongRepo, playlistFollowRepo)
+	trashService := service.NewTrashService(playlistService, playlistRepo, favoriteRepo, trashRetention())
 	cleanupService := service.NewCleanupService(historyRepo)
+	accountService := service.NewAccountDataService(favoriteRepo, historyRepo, playlistRepo, playlistSongRepo, playlistMemberRepo, playlistFollowRepo, statsRepo, recsCache, playbackRepo, playbackCache)
+	statsService := service.NewListeningStatsService(statsRepo, statsLocation())
+	recsService := service.NewRecommendationService(recsRepo, recsCache, similarSongSource())
+	releaseService := service.NewSingerReleaseService(releaseRepo, singerReleaseSource())
+	playbackService := service.NewPlaybackService(playbackRepo, playbackCache, syncPublisher)
 
-	return favoriteService, historyService, playlistService, cleanupService
+	return favoriteService, historyService, playlistService, transferService, memberService, discoveryService, trashService, cleanupService, accountService, statsService, recsService, releaseService, playbackService
+}
+
+// similarSongSource 上游相似歌曲数据源（经proxy-svc），未配置PROXY_SVC_URL时只使用共现推荐
+func similarSongSource() service.SimilarSongSource {
+	baseURL := os.Getenv("PROXY_SVC_URL")
+	if baseURL == "" {
+		log.Println("PROXY_SVC_URL not set, recommendations use co-occurrence only")
+		return nil
+	}
+	return service.NewProxySimilarSongSource(baseURL)
+}
+
+// singerReleaseSource 上游歌手专辑数据源（经proxy-svc），未配置PROXY_SVC_URL时不轮询关注歌手的新专辑
+func singerReleaseSource() service.SingerReleaseSource {
+	baseURL := os.Getenv("PROXY_SVC_URL")
+	if baseURL == "" {
+		log.Println("PROXY_SVC_URL not set, followed singer releases are not polled")
+		return nil
+	}
+	return service.NewProxySingerReleaseSource(baseURL)
+}
+
+// statsLocation 听歌统计时区（按天/月划分和每小时分布），默认使用服务器本地时区
+func statsLocation() *time.Location {
+	name := os.Getenv("STATS_TIMEZONE")
+	if name == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		log.Printf("Invalid STATS_TIMEZONE %q, using local timezone: %v", name, err)
+		return time.Local
+	}
+	return loc
+}
+
+// trashRetention 回收站保留天数（TRASH_RETENTION_DAYS），未配置或无效时使用默认30天
+func trashRetention() time.Duration {
+	value := os.Getenv("TRASH_RETENTION_DAYS")
+	if value == "" {
+		return domain.DefaultTrashRetention
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil || days <= 0 {
+		log.Printf("Invalid TRASH_RETENTION_DAYS %q, using default retention", value)
+		return domain.DefaultTrashRetention
+	}
+	return time.Duration(days) * 24 * time.Hour
 }
 
 func startHTTPServer(
 favoriteService *service.FavoriteService,
 historyService *service.PlayHistoryService,
 playlistService *service.PlaylistService,
+transferService *service.PlaylistTransferService,
+memberService *service.PlaylistMemberService,
+discoveryService *service.PlaylistDiscoveryService,
+trashService *service.TrashService,
+statsService *service.ListeningStatsService,
+recsService *service.RecommendationService,
+releaseService *service.SingerReleaseService,
+playbackService *service.PlaybackService,
 ) *http.Server {
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.Default()
@@ -145,10 +280,59 @@ c.JSON(http.StatusOK, gin.H{"status": "ok"})
 		api.GET("/playlists", playlistHandler.ListUserPlaylists)
 		api.GET("/playlists/:id", playlistHandler.GetPlaylist)
 		api.PUT("/playlists/:id", playlistHandler.UpdatePlaylist)
+		api.PUT("/playlists/:id/smart-rules", playlistHandler.UpdateSmartRules)
 		api.DELETE("/playlists/:id", playlistHandler.DeletePlaylist)
 		api.POST("/playlists/:id/songs", playlistHandler.AddSongToPlaylist)
 		api.GET("/playlists/:id/songs", playlistHandler.ListPlaylistSongs)
 		api.DELETE("/playlists/:id/songs/:song_id", playlistHandler.RemoveSongFromPlaylist)
+		api.POST("/playlists/:id/songs/batch", playlistHandler.AddSongsToPlaylist)
+		api.POST("/playlists/:id/songs/batch-remove", playlistHandler.RemoveSongsFromPlaylist)
+		api.PUT("/playlists/:id/songs/:song_id/position", playlistHandler.MoveSong)
+		api.POST("/playlists/:id/songs/sort", playlistHandler.SortSongs)
+
+		transferHandler := handler.NewPlaylistTransferHandler(transferService)
+		api.POST("/playlists/import", transferHandler.ImportPlaylist)
+		api.GET("/playlists/:id/export", transferHandler.ExportPlaylist)
+
+		memberHandler := handler.NewPlaylistMemberHandler(memberService, playlistService)
+		api.GET("/playlists/shared", memberHandler.ListSharedPlaylists)
+		api.POST("/playlists/invites/:token/accept", memberHandler.AcceptInvite)
+		api.GET("/playlists/:id/members", memberHandler.ListMembers)
+		api.PUT("/playlists/:id/members/:user_id", memberHandler.PutMember)
+		api.DELETE("/playlists/:id/members/:user_id", memberHandler.RemoveMember)
+		api.POST("/playlists/:id/invites", memberHandler.CreateInvite)
+		api.DELETE("/playlists/:id/invites", memberHandler.RevokeInvites)
+
+		discoveryHandler := handler.NewPlaylistDiscoveryHandler(discoveryService)
+		api.GET("/playlists/public", discoveryHandler.ListPublicPlaylists)
+		api.GET("/playlists/public/:id", discoveryHandler.GetPublicPlaylist)
+		api.GET("/playlists/following", discoveryHandler.ListFollowedPlaylists)
+		api.POST("/playlists/:id/follow", discoveryHandler.FollowPlaylist)
+		api.DELETE("/playlists/:id/follow", discoveryHandler.UnfollowPlaylist)
+		api.POST("/playlists/:id/fork", discoveryHandler.ForkPlaylist)
+
+		trashHandler := handler.NewTrashHandler(trashService)
+		api.GET("/trash/playlists", trashHandler.ListDeletedPlaylists)
+		api.GET("/trash/favorites", trashHandler.ListDeletedFavorites)
+		api.POST("/playlists/:id/restore", trashHandler.RestorePlaylist)
+		api.POST("/favorites/:id/restore", trashHandler.RestoreFavorite)
+
+		statsHandler := handler.NewStatsHandler(statsService)
+		api.GET("/stats/listening", statsHandler.GetListeningStats)
+		api.GET("/stats/year-in-review", statsHandler.GetYearInReview)
+
+		recommendationHandler := handler.NewRecommendationHandler(recsService)
+		api.GET("/recommendations", recommendationHandler.GetRecommendations)
+
+		releaseHandler := handler.NewSingerReleaseHandler(releaseService)
+		api.GET("/singers/new-releases", releaseHandler.ListNewReleases)
+
+		playbackHandler := handler.NewPlaybackHandler(playbackService)
+		api.GET("/playback/queue", playbackHandler.GetQueue)
+		api.PUT("/playback/queue", playbackHandler.SaveQueue)
+		api.PUT("/playback/queue/current", playbackHandler.SetCurrentIndex)
+		api.GET("/playback/positions", playbackHandler.GetPositions)
+		api.PUT("/playback/positions/:song_id", playbackHandler.UpdatePosition)
 	}
 
 	server := &http.Server{
@@ -170,6 +354,15 @@ func startGRPCServer(
 favoriteService *service.FavoriteService,
 historyService *service.PlayHistoryService,
 playlistService *service.PlaylistService,
+transferService *service.PlaylistTransferService,
+memberService *service.PlaylistMemberService,
+discoveryService *service.PlaylistDiscoveryService,
+trashService *service.TrashService,
+accountService *service.AccountDataService,
+statsService *service.ListeningStatsService,
+recsService *service.RecommendationService,
+releaseService *service.SingerReleaseService,
+playbackService *service.PlaybackService,
 ) *grpc_server.Server {
 	lis, err := net.Listen("tcp", grpcPort)
 	if err != nil {
@@ -178,7 +371,7 @@ playlistService *service.PlaylistService,
 
 	grpcServer := grpc_server.NewServer()
 
-	userServer := grpc.NewUserServer(favoriteService, historyService, playlistService)
+	userServer := grpc.NewUserServer(favoriteService, historyService, playlistService, memberService, transferService, discoveryService, trashService, accountService, statsService, recsService, releaseService, playbackService)
 	userv1.RegisterUserServiceServer(grpcServer, userServer)
 
 	healthServer := health.NewServer()
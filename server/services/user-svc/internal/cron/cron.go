@@ -9,49 +9,149 @@ import (
 	"github.com/robfig/cron/v3"
 )
 
+// CronDeps 定时任务依赖的服务，为nil的服务对应的定时任务不注册
+type CronDeps struct {
+	Cleanup         *service.CleanupService        // 每天02:00清理播放历史
+	Stats           *service.ListeningStatsService // 每小时汇总听歌统计，清理前执行夜间汇总
+	Recommendations *service.RecommendationService // 每天04:00离线计算推荐
+	Trash           *service.TrashService          // 每天03:00清理回收站
+	Releases        *service.SingerReleaseService  // 每6小时轮询关注歌手的新专辑
+	Playback        *service.PlaybackService       // 每分钟回写播放队列和播放进度
+}
+
 // CronManager 定时任务管理器
 type CronManager struct {
-	cron           *cron.Cron
-	cleanupService *service.CleanupService
+	cron            *cron.Cron
+	cleanupService  *service.CleanupService
+	statsService    *service.ListeningStatsService
+	recsService     *service.RecommendationService
+	trashService    *service.TrashService
+	releaseService  *service.SingerReleaseService
+	playbackService *service.PlaybackService
 }
 
 // NewCronManager 创建定时任务管理器
-func NewCronManager(cleanupService *service.CleanupService) *CronManager {
+func NewCronManager(deps CronDeps) *CronManager {
 	// 创建带秒级支持的cron（可选）
 	// 或使用标准的分钟级: cron.New()
 	return &CronManager{
-		cron:           cron.New(cron.WithLocation(time.Local)),
-		cleanupService: cleanupService,
+		cron:            cron.New(cron.WithLocation(time.Local)),
+		cleanupService:  deps.Cleanup,
+		statsService:    deps.Stats,
+		recsService:     deps.Recommendations,
+		trashService:    deps.Trash,
+		releaseService:  deps.Releases,
+		playbackService: deps.Playback,
 	}
 }
 
-// Start 启动定时任务
+// Start 注册依赖齐全的定时任务并启动
 func (m *CronManager) Start() error {
+	// 每小时第5分钟汇总听歌统计，使统计数据保持在1小时内更新
+	if m.statsService != nil {
+		_, err := m.cron.AddFunc("5 * * * *", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Minute)
+			defer cancel()
+
+			rolledUp, err := m.statsService.RollupPendingPlays(ctx)
+			if err != nil {
+				log.Printf("Listening stats rollup failed: %v", err)
+				return
+			}
+			log.Printf("Listening stats rollup completed: rolled_up=%d", rolledUp)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	// 每天凌晨2点执行清理任务
 	// Cron格式: 分 时 日 月 周
 	// "0 2 * * *" = 每天02:00:00
-	_, err := m.cron.AddFunc("0 2 * * *", func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
-		defer cancel()
-
-		log.Println("=== Starting scheduled cleanup job ===")
-		startTime := time.Now()
-
-		if err := m.cleanupService.CleanupAllUsers(ctx); err != nil {
-			log.Printf("Cleanup job failed: %v", err)
-		} else {
-			duration := time.Since(startTime)
-			log.Printf("Cleanup job completed successfully in %v", duration)
+	if m.cleanupService != nil {
+		_, err := m.cron.AddFunc("0 2 * * *", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+			defer cancel()
+
+			log.Println("=== Starting scheduled cleanup job ===")
+			startTime := time.Now()
+
+			if err := m.runCleanup(ctx); err != nil {
+				log.Printf("Cleanup job failed: %v", err)
+			} else {
+				duration := time.Since(startTime)
+				log.Printf("Cleanup job completed successfully in %v", duration)
+			}
+
+			log.Println("=== Cleanup job finished ===")
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// 每天凌晨3点永久删除回收站中超过保留期的歌单和收藏
+	if m.trashService != nil {
+		_, err := m.cron.AddFunc("0 3 * * *", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+			defer cancel()
+
+			if err := m.runTrashPurge(ctx); err != nil {
+				log.Printf("Trash purge failed: %v", err)
+			}
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// 每天凌晨4点（清理和夜间汇总之后）离线计算推荐
+	if m.recsService != nil {
+		_, err := m.cron.AddFunc("0 4 * * *", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+			defer cancel()
+
+			if _, err := m.recsService.RebuildAll(ctx); err != nil {
+				log.Printf("Recommendation rebuild failed: %v", err)
+			}
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// 每分钟把Redis中的播放队列和播放进度回写到数据库
+	if m.playbackService != nil {
+		_, err := m.cron.AddFunc("* * * * *", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Second)
+			defer cancel()
+
+			if err := m.runPlaybackFlush(ctx); err != nil {
+				log.Printf("Playback state flush failed: %v", err)
+			}
+		})
+		if err != nil {
+			return err
 		}
+	}
+
+	// 每6小时（第30分钟）轮询关注歌手的专辑，发现新发行
+	if m.releaseService != nil {
+		_, err := m.cron.AddFunc("30 */6 * * *", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+			defer cancel()
 
-		log.Println("=== Cleanup job finished ===")
-	})
-	if err != nil {
-		return err
+			if err := m.runReleasePoll(ctx); err != nil {
+				log.Printf("Singer release poll failed: %v", err)
+			}
+		})
+		if err != nil {
+			return err
+		}
 	}
 
 	m.cron.Start()
-	log.Println("Cron manager started - scheduled cleanup at 02:00 daily")
+	log.Printf("Cron manager started: %d jobs scheduled", len(m.cron.Entries()))
 	return nil
 }
 
@@ -64,6 +164,76 @@ func (m *CronManager) Stop() {
 
 // RunCleanupNow 立即执行清理任务（用于测试或手动触发）
 func (m *CronManager) RunCleanupNow(ctx context.Context) error {
+	if m.cleanupService == nil {
+		return nil
+	}
 	log.Println("Running cleanup job immediately...")
+	return m.runCleanup(ctx)
+}
+
+// runCleanup 先汇总听歌统计再清理播放历史
+// 清理只删除已汇总的记录，汇总失败时未汇总的记录会保留到下次
+func (m *CronManager) runCleanup(ctx context.Context) error {
+	if m.statsService != nil {
+		if err := m.statsService.RunNightlyRollup(ctx); err != nil {
+			log.Printf("Listening stats rollup failed before cleanup: %v", err)
+		}
+	}
 	return m.cleanupService.CleanupAllUsers(ctx)
 }
+
+// RunTrashPurgeNow 立即清理回收站（用于测试或手动触发）
+func (m *CronManager) RunTrashPurgeNow(ctx context.Context) error {
+	if m.trashService == nil {
+		return nil
+	}
+	log.Println("Running trash purge immediately...")
+	return m.runTrashPurge(ctx)
+}
+
+// runTrashPurge 永久删除超过保留期的回收站记录
+func (m *CronManager) runTrashPurge(ctx context.Context) error {
+	result, err := m.trashService.PurgeExpired(ctx)
+	if result != nil {
+		log.Printf("Trash purge completed: retention=%v playlists=%d favorites=%d",
+			m.trashService.Retention(), result.PlaylistsPurged, result.FavoritesPurged)
+	}
+	return err
+}
+
+// RunReleasePollNow 立即轮询关注歌手的新专辑（用于测试或手动触发）
+func (m *CronManager) RunReleasePollNow(ctx context.Context) error {
+	if m.releaseService == nil {
+		return nil
+	}
+	log.Println("Running singer release poll immediately...")
+	return m.runReleasePoll(ctx)
+}
+
+// runReleasePoll 轮询所有被关注歌手的专辑
+func (m *CronManager) runReleasePoll(ctx context.Context) error {
+	result, err := m.releaseService.PollAll(ctx)
+	if result != nil {
+		log.Printf("Singer release poll completed: singers=%d failed=%d new_releases=%d",
+			result.SingersPolled, result.SingersFailed, result.NewReleases)
+	}
+	return err
+}
+
+// RunPlaybackFlushNow 立即回写播放队列和播放进度（用于停机前或手动触发）
+func (m *CronManager) RunPlaybackFlushNow(ctx context.Context) error {
+	if m.playbackService == nil {
+		return nil
+	}
+	return m.runPlaybackFlush(ctx)
+}
+
+// runPlaybackFlush 回写播放队列和播放进度，没有数据时不输出日志
+func (m *CronManager) runPlaybackFlush(ctx context.Context) error {
+	result, err := m.playbackService.FlushDirty(ctx)
+	if result != nil && (result.QueuesFlushed > 0 || result.PositionsFlushed > 0) {
+		log.Printf("Playback state flush completed: queues=%d positions=%d",
+			result.QueuesFlushed, result.PositionsFlushed)
+	}
+	return err
+}
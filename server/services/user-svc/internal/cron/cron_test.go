@@ -48,14 +48,9 @@ func (m *MockPlayHistoryRepository) Delete(ctx context.Context, id string) error
 	return args.Error(0)
 }
 
-func (m *MockPlayHistoryRepository) DeleteOldest(ctx context.Context, userID string, count int) error {
-	args := m.Called(ctx, userID, count)
-	return args.Error(0)
-}
-
-func (m *MockPlayHistoryRepository) Cleanup(ctx context.Context, userID string, keepCount int) error {
-	args := m.Called(ctx, userID, keepCount)
-	return args.Error(0)
+func (m *MockPlayHistoryRepository) Cleanup(ctx context.Context, userID string, keepCount, hardLimit int) (int64, error) {
+	args := m.Called(ctx, userID, keepCount, hardLimit)
+	return args.Get(0).(int64), args.Error(1)
 }
 
 func (m *MockPlayHistoryRepository) GetAllUserIDs(ctx context.Context) ([]string, error) {
@@ -66,10 +61,15 @@ func (m *MockPlayHistoryRepository) GetAllUserIDs(ctx context.Context) ([]string
 	return args.Get(0).([]string), args.Error(1)
 }
 
+func (m *MockPlayHistoryRepository) DeleteAllByUser(ctx context.Context, userID string) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func TestCronManager_Start(t *testing.T) {
 	mockRepo := new(MockPlayHistoryRepository)
 	cleanupService := service.NewCleanupService(mockRepo)
-	cronManager := NewCronManager(cleanupService)
+	cronManager := NewCronManager(CronDeps{Cleanup: cleanupService})
 
 	err := cronManager.Start()
 	assert.NoError(t, err)
@@ -78,6 +78,42 @@ func TestCronManager_Start(t *testing.T) {
 	cronManager.Stop()
 }
 
+func TestCronManager_RegistersJobsForProvidedDeps(t *testing.T) {
+	tests := []struct {
+		name string
+		deps CronDeps
+		jobs int
+	}{
+		{"no deps", CronDeps{}, 0},
+		{"cleanup only", CronDeps{Cleanup: service.NewCleanupService(new(MockPlayHistoryRepository))}, 1},
+		{"trash and playback", CronDeps{Trash: &service.TrashService{}, Playback: &service.PlaybackService{}}, 2},
+		{"all deps", CronDeps{
+			Cleanup:         service.NewCleanupService(new(MockPlayHistoryRepository)),
+			Stats:           &service.ListeningStatsService{},
+			Recommendations: &service.RecommendationService{},
+			Trash:           &service.TrashService{},
+			Releases:        &service.SingerReleaseService{},
+			Playback:        &service.PlaybackService{},
+		}, 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cronManager := NewCronManager(tt.deps)
+			assert.NoError(t, cronManager.Start())
+			defer cronManager.Stop()
+			assert.Len(t, cronManager.cron.Entries(), tt.jobs)
+		})
+	}
+
+	// 没有依赖时手动触发不执行任何操作
+	cronManager := NewCronManager(CronDeps{})
+	ctx := context.Background()
+	assert.NoError(t, cronManager.RunCleanupNow(ctx))
+	assert.NoError(t, cronManager.RunTrashPurgeNow(ctx))
+	assert.NoError(t, cronManager.RunReleasePollNow(ctx))
+	assert.NoError(t, cronManager.RunPlaybackFlushNow(ctx))
+}
+
 func TestCronManager_RunCleanupNow(t *testing.T) {
 	mockRepo := new(MockPlayHistoryRepository)
 	
@@ -87,17 +123,17 @@ func TestCronManager_RunCleanupNow(t *testing.T) {
 	
 	// user1: 600条记录，需要清理
 	mockRepo.On("Count", mock.Anything, "user1").Return(int64(600), nil)
-	mockRepo.On("Cleanup", mock.Anything, "user1", 500).Return(nil)
+	mockRepo.On("Cleanup", mock.Anything, "user1", 500, 2000).Return(int64(100), nil)
 	
 	// user2: 400条记录，不需要清理
 	mockRepo.On("Count", mock.Anything, "user2").Return(int64(400), nil)
 	
 	// user3: 1000条记录，需要清理
 	mockRepo.On("Count", mock.Anything, "user3").Return(int64(1000), nil)
-	mockRepo.On("Cleanup", mock.Anything, "user3", 500).Return(nil)
+	mockRepo.On("Cleanup", mock.Anything, "user3", 500, 2000).Return(int64(500), nil)
 
 	cleanupService := service.NewCleanupService(mockRepo)
-	cronManager := NewCronManager(cleanupService)
+	cronManager := NewCronManager(CronDeps{Cleanup: cleanupService})
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -118,7 +154,7 @@ func TestCleanupService_CleanupAllUserHistories(t *testing.T) {
 	
 	// user1: 600条记录
 	mockRepo.On("Count", mock.Anything, "user1").Return(int64(600), nil)
-	mockRepo.On("Cleanup", mock.Anything, "user1", 500).Return(nil)
+	mockRepo.On("Cleanup", mock.Anything, "user1", 500, 2000).Return(int64(100), nil)
 	
 	// user2: 300条记录
 	mockRepo.On("Count", mock.Anything, "user2").Return(int64(300), nil)
@@ -132,3 +168,19 @@ func TestCleanupService_CleanupAllUserHistories(t *testing.T) {
 	// 验证mock被调用
 	mockRepo.AssertExpectations(t)
 }
+
+func TestCleanupService_CleanupAllUserHistories_PendingRollup(t *testing.T) {
+	mockRepo := new(MockPlayHistoryRepository)
+	mockRepo.On("GetAllUserIDs", mock.Anything).Return([]string{"user1"}, nil)
+
+	// 900条记录中只有100条已汇总，只删除了这100条，未汇总的保留到硬上限以内
+	mockRepo.On("Count", mock.Anything, "user1").Return(int64(900), nil)
+	mockRepo.On("Cleanup", mock.Anything, "user1", service.MaxHistoryCount, service.MaxHistoryHardLimit).Return(int64(100), nil)
+
+	cleanupService := service.NewCleanupService(mockRepo)
+
+	err := cleanupService.CleanupAllUserHistories(context.Background())
+	assert.NoError(t, err)
+
+	mockRepo.AssertExpectations(t)
+}
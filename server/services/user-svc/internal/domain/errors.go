@@ -11,6 +11,7 @@ var (
 	// 收藏相关错误
 	ErrFavoriteNotFound      = errors.New("favorite not found")
 	ErrFavoriteAlreadyExists = errors.New("favorite already exists")
+	ErrInvalidFavoriteType   = errors.New("invalid favorite type")
 	
 	// 播放历史相关错误
 	ErrHistoryNotFound   = errors.New("history not found")
@@ -26,6 +27,41 @@ var (
 	ErrInvalidPosition            = errors.New("invalid position")
 	ErrSongAlreadyInPlaylist      = errors.New("song already in playlist")
 	ErrSongNotInPlaylist          = errors.New("song not in playlist")
+	ErrInvalidSmartRules          = errors.New("invalid smart playlist rules")
+	ErrSmartPlaylistReadOnly      = errors.New("smart playlist songs are read-only")
+	ErrNotSmartPlaylist           = errors.New("not a smart playlist")
+	ErrInvalidExportFormat        = errors.New("invalid playlist export format")
+	ErrTooManyImportSongs         = errors.New("too many songs to import")
+	ErrTooManyBatchSongs          = errors.New("too many songs in one batch")
+	ErrInvalidPlaylistSongSort    = errors.New("invalid playlist song sort")
+	
+	// 协作歌单相关错误
+	ErrInvalidMemberRole = errors.New("invalid playlist member role")
+	ErrMemberNotFound    = errors.New("playlist member not found")
+	ErrCannotInviteOwner = errors.New("playlist owner cannot be invited")
+	ErrTooManyMembers    = errors.New("too many playlist members")
+	ErrInviteNotFound    = errors.New("playlist invite not found")
+	ErrInviteExpired     = errors.New("playlist invite expired")
+	
+	// 公开歌单发现相关错误
+	ErrPlaylistNotPublic       = errors.New("playlist is not public")
+	ErrCannotFollowOwnPlaylist = errors.New("cannot follow own playlist")
+	ErrInvalidPlaylistSort     = errors.New("invalid public playlist sort")
+	
+	// 听歌统计相关错误
+	ErrInvalidStatsRange = errors.New("invalid stats range")
+	ErrInvalidStatsYear  = errors.New("invalid stats year")
+	ErrRollupConflict    = errors.New("play histories already rolled up")
+	
+	// 推荐相关错误
+	ErrRecommendationsNotFound = errors.New("recommendations not found")
+	
+	// 播放队列和播放进度相关错误
+	ErrPlayQueueNotFound   = errors.New("play queue not found")
+	ErrPlayQueueTooLarge   = errors.New("play queue too large")
+	ErrInvalidQueueIndex   = errors.New("invalid play queue index")
+	ErrInvalidRepeatMode   = errors.New("invalid repeat mode")
+	ErrInvalidPlayPosition = errors.New("invalid playback position")
 	
 	// 权限相关错误
 	ErrUnauthorized = errors.New("unauthorized")
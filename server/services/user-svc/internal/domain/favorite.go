@@ -2,15 +2,44 @@ package domain
 
 import "time"
 
+// 收藏类型
+const (
+	FavoriteTypeSong     = "song"     // 歌曲
+	FavoriteTypeAlbum    = "album"    // 专辑
+	FavoriteTypeSinger   = "singer"   // 歌手（即关注歌手）
+	FavoriteTypeMV       = "mv"       // MV
+	FavoriteTypePlaylist = "playlist" // 上游平台歌单
+)
+
+// FavoriteTypes 全部收藏类型（按展示顺序）
+var FavoriteTypes = []string{FavoriteTypeSong, FavoriteTypeAlbum, FavoriteTypeSinger, FavoriteTypeMV, FavoriteTypePlaylist}
+
 // Favorite 收藏实体
+// 历史原因目标ID、名称和歌手沿用song_id、song_name、singer_name字段，非歌曲收藏同样使用这三个字段
 type Favorite struct {
-	ID        string    `json:"id"`         // UUID
-	UserID    string    `json:"user_id"`    // 用户ID
-	SongID    string    `json:"song_id"`    // 歌曲ID
-	SongName  string    `json:"song_name"`  // 歌名（冗余存储，支持离线显示）
-	SingerName string   `json:"singer_name"` // 歌手名（冗余存储）
-	DeletedAt *time.Time `json:"deleted_at,omitempty"` // 软删除时间
-	CreatedAt time.Time `json:"created_at"` // 创建时间
+	ID         string            `json:"id"`                   // UUID
+	UserID     string            `json:"user_id"`              // 用户ID
+	Type       string            `json:"type"`                 // 收藏类型
+	SongID     string            `json:"song_id"`              // 目标ID（歌曲/专辑/歌手/MV/歌单的第三方平台ID）
+	SongName   string            `json:"song_name"`            // 名称（冗余存储，支持离线显示）
+	SingerName string            `json:"singer_name"`          // 歌手名（冗余存储，歌手收藏为空）
+	CoverURL   string            `json:"cover_url,omitempty"`  // 封面
+	Extra      map[string]string `json:"extra,omitempty"`      // 按类型不同的额外信息，如专辑发行时间、MV时长、歌单创建者
+	DeletedAt  *time.Time        `json:"deleted_at,omitempty"` // 软删除时间
+	CreatedAt  time.Time         `json:"created_at"`           // 创建时间
+}
+
+// ValidateFavoriteType 验证收藏类型，空值视为歌曲
+func ValidateFavoriteType(favoriteType string) (string, error) {
+	if favoriteType == "" {
+		return FavoriteTypeSong, nil
+	}
+	for _, t := range FavoriteTypes {
+		if t == favoriteType {
+			return favoriteType, nil
+		}
+	}
+	return "", ErrInvalidFavoriteType
 }
 
 // IsDeleted 判断是否已删除
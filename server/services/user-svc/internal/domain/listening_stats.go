@@ -0,0 +1,255 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	// HoursPerDay 每日按小时统计的桶数
+	HoursPerDay = 24
+	// HeatmapSize 听歌时段热力图大小（星期×小时，下标为 weekday*24+hour，周日为0）
+	HeatmapSize = 7 * HoursPerDay
+)
+
+// DailyListening 用户单日听歌汇总
+type DailyListening struct {
+	UserID          string    `json:"user_id"`
+	Day             time.Time `json:"day"`              // 日期（统计时区的零点）
+	PlayCount       int64     `json:"play_count"`       // 播放次数
+	SecondsListened int64     `json:"seconds_listened"` // 收听时长（秒）
+	HourPlays       []int64   `json:"hour_plays"`       // 每小时播放次数（长度24）
+}
+
+// MonthlyListening 用户单月听歌汇总
+type MonthlyListening struct {
+	UserID          string    `json:"user_id"`
+	Month           time.Time `json:"month"`            // 月份（当月1日）
+	PlayCount       int64     `json:"play_count"`       // 播放次数
+	SecondsListened int64     `json:"seconds_listened"` // 收听时长（秒）
+	Heatmap         []int64   `json:"heatmap"`          // 星期×小时播放次数（长度168）
+}
+
+// SongListening 歌曲收听汇总（按日或按月）
+type SongListening struct {
+	UserID          string    `json:"user_id"`
+	Period          time.Time `json:"period"` // 日期或月份
+	SongID          string    `json:"song_id"`
+	SongName        string    `json:"song_name"`
+	SingerName      string    `json:"singer_name"`
+	AlbumCover      string    `json:"album_cover"`
+	PlayCount       int64     `json:"play_count"`
+	SecondsListened int64     `json:"seconds_listened"`
+}
+
+// SingerListening 歌手收听汇总（按日或按月）
+type SingerListening struct {
+	UserID          string    `json:"user_id"`
+	Period          time.Time `json:"period"` // 日期或月份
+	SingerName      string    `json:"singer_name"`
+	PlayCount       int64     `json:"play_count"`
+	SecondsListened int64     `json:"seconds_listened"`
+}
+
+// ListeningRollup 一批播放记录的聚合结果（增量累加到汇总表）
+type ListeningRollup struct {
+	Daily          []*DailyListening
+	DailySongs     []*SongListening
+	DailySingers   []*SingerListening
+	Monthly        []*MonthlyListening
+	MonthlySongs   []*SongListening
+	MonthlySingers []*SingerListening
+}
+
+// IsEmpty 是否没有任何聚合数据
+func (r *ListeningRollup) IsEmpty() bool {
+	return len(r.Daily) == 0
+}
+
+// BuildListeningRollup 按统计时区将播放记录聚合为日/月汇总
+func BuildListeningRollup(plays []*PlayHistory, loc *time.Location) *ListeningRollup {
+	type songKey struct {
+		userID string
+		period time.Time
+		songID string
+	}
+	type singerKey struct {
+		userID string
+		period time.Time
+		singer string
+	}
+	type periodKey struct {
+		userID string
+		period time.Time
+	}
+
+	daily := make(map[periodKey]*DailyListening)
+	monthly := make(map[periodKey]*MonthlyListening)
+	dailySongs := make(map[songKey]*SongListening)
+	monthlySongs := make(map[songKey]*SongListening)
+	dailySingers := make(map[singerKey]*SingerListening)
+	monthlySingers := make(map[singerKey]*SingerListening)
+
+	addSong := func(m map[songKey]*SongListening, p *PlayHistory, period time.Time, seconds int64) {
+		key := songKey{p.UserID, period, p.SongID}
+		s, ok := m[key]
+		if !ok {
+			s = &SongListening{UserID: p.UserID, Period: period, SongID: p.SongID}
+			m[key] = s
+		}
+		// 冗余信息以最近一次播放为准
+		s.SongName, s.SingerName, s.AlbumCover = p.SongName, p.SingerName, p.AlbumCover
+		s.PlayCount++
+		s.SecondsListened += seconds
+	}
+	addSinger := func(m map[singerKey]*SingerListening, p *PlayHistory, period time.Time, seconds int64) {
+		key := singerKey{p.UserID, period, p.SingerName}
+		s, ok := m[key]
+		if !ok {
+			s = &SingerListening{UserID: p.UserID, Period: period, SingerName: p.SingerName}
+			m[key] = s
+		}
+		s.PlayCount++
+		s.SecondsListened += seconds
+	}
+
+	// 按播放时间排序，保证冗余信息取最近一次
+	sorted := make([]*PlayHistory, len(plays))
+	copy(sorted, plays)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].PlayedAt.Before(sorted[j].PlayedAt) })
+
+	for _, p := range sorted {
+		local := p.PlayedAt.In(loc)
+		day := StartOfDay(local)
+		month := StartOfMonth(local)
+		seconds := int64(p.Duration)
+		if seconds < 0 {
+			seconds = 0
+		}
+
+		d, ok := daily[periodKey{p.UserID, day}]
+		if !ok {
+			d = &DailyListening{UserID: p.UserID, Day: day, HourPlays: make([]int64, HoursPerDay)}
+			daily[periodKey{p.UserID, day}] = d
+		}
+		d.PlayCount++
+		d.SecondsListened += seconds
+		d.HourPlays[local.Hour()]++
+
+		m, ok := monthly[periodKey{p.UserID, month}]
+		if !ok {
+			m = &MonthlyListening{UserID: p.UserID, Month: month, Heatmap: make([]int64, HeatmapSize)}
+			monthly[periodKey{p.UserID, month}] = m
+		}
+		m.PlayCount++
+		m.SecondsListened += seconds
+		m.Heatmap[HeatmapIndex(local.Weekday(), local.Hour())]++
+
+		addSong(dailySongs, p, day, seconds)
+		addSong(monthlySongs, p, month, seconds)
+		if p.SingerName != "" {
+			addSinger(dailySingers, p, day, seconds)
+			addSinger(monthlySingers, p, month, seconds)
+		}
+	}
+
+	rollup := &ListeningRollup{}
+	for _, d := range daily {
+		rollup.Daily = append(rollup.Daily, d)
+	}
+	for _, m := range monthly {
+		rollup.Monthly = append(rollup.Monthly, m)
+	}
+	for _, s := range dailySongs {
+		rollup.DailySongs = append(rollup.DailySongs, s)
+	}
+	for _, s := range monthlySongs {
+		rollup.MonthlySongs = append(rollup.MonthlySongs, s)
+	}
+	for _, s := range dailySingers {
+		rollup.DailySingers = append(rollup.DailySingers, s)
+	}
+	for _, s := range monthlySingers {
+		rollup.MonthlySingers = append(rollup.MonthlySingers, s)
+	}
+	return rollup
+}
+
+// HeatmapIndex 热力图下标
+func HeatmapIndex(weekday time.Weekday, hour int) int {
+	return int(weekday)*HoursPerDay + hour
+}
+
+// StartOfDay 当天零点（保持时区）
+func StartOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// StartOfMonth 当月1日零点（保持时区）
+func StartOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+// ListeningSummary 时间范围内的听歌统计
+type ListeningSummary struct {
+	UserID          string             `json:"user_id"`
+	From            time.Time          `json:"from"`
+	To              time.Time          `json:"to"` // 包含当天
+	PlayCount       int64              `json:"play_count"`
+	SecondsListened int64              `json:"seconds_listened"`
+	MinutesListened int64              `json:"minutes_listened"`
+	TopSongs        []*SongListening   `json:"top_songs"`
+	TopSingers      []*SingerListening `json:"top_singers"`
+	Heatmap         []int64            `json:"heatmap"` // 星期×小时播放次数（长度168）
+	Daily           []*DailyListening  `json:"daily"`
+}
+
+// YearInReview 年度听歌报告
+type YearInReview struct {
+	UserID          string              `json:"user_id"`
+	Year            int                 `json:"year"`
+	PlayCount       int64               `json:"play_count"`
+	SecondsListened int64               `json:"seconds_listened"`
+	MinutesListened int64               `json:"minutes_listened"`
+	ListeningDays   int64               `json:"listening_days"`   // 有听歌的天数
+	DistinctSongs   int64               `json:"distinct_songs"`   // 听过的不同歌曲数
+	DistinctSingers int64               `json:"distinct_singers"` // 听过的不同歌手数
+	TopSongs        []*SongListening    `json:"top_songs"`
+	TopSingers      []*SingerListening  `json:"top_singers"`
+	Months          []*MonthlyListening `json:"months"`
+	TopMonth        *time.Time          `json:"top_month,omitempty"` // 收听时长最长的月份
+	PeakHour        int                 `json:"peak_hour"`           // 播放最多的小时（0-23，无数据时为-1）
+	PeakWeekday     int                 `json:"peak_weekday"`        // 播放最多的星期（0为周日，无数据时为-1）
+	Heatmap         []int64             `json:"heatmap"`             // 星期×小时播放次数（长度168）
+}
+
+// Peaks 从热力图计算播放最多的小时和星期（无数据时返回-1）
+func Peaks(heatmap []int64) (peakHour, peakWeekday int) {
+	var hours [HoursPerDay]int64
+	var weekdays [7]int64
+	var total int64
+	for i, v := range heatmap {
+		if i >= HeatmapSize {
+			break
+		}
+		hours[i%HoursPerDay] += v
+		weekdays[i/HoursPerDay] += v
+		total += v
+	}
+	if total == 0 {
+		return -1, -1
+	}
+
+	peakHour, peakWeekday = 0, 0
+	for h := range hours {
+		if hours[h] > hours[peakHour] {
+			peakHour = h
+		}
+	}
+	for w := range weekdays {
+		if weekdays[w] > weekdays[peakWeekday] {
+			peakWeekday = w
+		}
+	}
+	return peakHour, peakWeekday
+}
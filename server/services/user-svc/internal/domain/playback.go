@@ -0,0 +1,82 @@
+package domain
+
+import "time"
+
+// 播放队列循环模式
+const (
+	RepeatModeOff = "off" // 不循环
+	RepeatModeAll = "all" // 列表循环
+	RepeatModeOne = "one" // 单曲循环
+)
+
+// MaxPlayQueueTracks 播放队列最多包含的歌曲数
+const MaxPlayQueueTracks = 1000
+
+// PlayQueue 用户的播放队列（每个用户一个，跨设备共享）
+type PlayQueue struct {
+	UserID       string        `json:"user_id"`
+	Tracks       []*QueueTrack `json:"tracks"`        // 按播放顺序排列（随机播放时为打乱后的顺序）
+	CurrentIndex int           `json:"current_index"` // 当前播放歌曲在Tracks中的下标，队列为空时为0
+	Shuffle      bool          `json:"shuffle"`       // 是否随机播放
+	RepeatMode   string        `json:"repeat_mode"`   // 循环模式
+	DeviceID     string        `json:"device_id"`     // 最后修改队列的设备
+	UpdatedAt    time.Time     `json:"updated_at"`
+}
+
+// QueueTrack 播放队列中的歌曲
+type QueueTrack struct {
+	SongID     string `json:"song_id"`
+	SongName   string `json:"song_name"`
+	SingerName string `json:"singer_name"`
+	Duration   int    `json:"duration"` // 歌曲时长（秒）
+}
+
+// Validate 验证播放队列，空的循环模式视为不循环
+func (q *PlayQueue) Validate() error {
+	if len(q.Tracks) > MaxPlayQueueTracks {
+		return ErrPlayQueueTooLarge
+	}
+	for _, track := range q.Tracks {
+		if track == nil || track.SongID == "" {
+			return ErrInvalidSongID
+		}
+	}
+	if q.CurrentIndex < 0 || (len(q.Tracks) > 0 && q.CurrentIndex >= len(q.Tracks)) || (len(q.Tracks) == 0 && q.CurrentIndex != 0) {
+		return ErrInvalidQueueIndex
+	}
+	switch q.RepeatMode {
+	case "":
+		q.RepeatMode = RepeatModeOff
+	case RepeatModeOff, RepeatModeAll, RepeatModeOne:
+	default:
+		return ErrInvalidRepeatMode
+	}
+	return nil
+}
+
+// PlaybackPosition 歌曲的播放进度（用于长音频断点续播）
+type PlaybackPosition struct {
+	UserID     string    `json:"user_id"`
+	SongID     string    `json:"song_id"`
+	PositionMs int64     `json:"position_ms"` // 播放到的位置（毫秒）
+	DurationMs int64     `json:"duration_ms"` // 歌曲时长（毫秒），未知时为0
+	DeviceID   string    `json:"device_id"`   // 上报进度的设备
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Validate 验证播放进度
+func (p *PlaybackPosition) Validate() error {
+	if p.SongID == "" {
+		return ErrInvalidSongID
+	}
+	if p.PositionMs < 0 || p.DurationMs < 0 || (p.DurationMs > 0 && p.PositionMs > p.DurationMs) {
+		return ErrInvalidPlayPosition
+	}
+	return nil
+}
+
+// PlaybackFlushResult 一次回写的结果
+type PlaybackFlushResult struct {
+	QueuesFlushed    int `json:"queues_flushed"`
+	PositionsFlushed int `json:"positions_flushed"`
+}
@@ -4,16 +4,19 @@ import "time"
 
 // UserPlaylist 用户歌单实体
 type UserPlaylist struct {
-	ID          string     `json:"id"`
-	UserID      string     `json:"user_id"`
-	Name        string     `json:"name"`
-	Description string     `json:"description"`
-	CoverURL    string     `json:"cover_url"`
-	SongCount   int        `json:"song_count"`
-	IsPublic    bool       `json:"is_public"`
-	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
+	ID            string              `json:"id"`
+	UserID        string              `json:"user_id"`
+	Name          string              `json:"name"`
+	Description   string              `json:"description"`
+	CoverURL      string              `json:"cover_url"`
+	SongCount     int                 `json:"song_count"`
+	IsPublic      bool                `json:"is_public"`
+	SmartRules    *SmartPlaylistRules `json:"smart_rules,omitempty"`
+	FollowerCount int                 `json:"follower_count"`        // 关注数（冗余存储）
+	ForkedFrom    *string             `json:"forked_from,omitempty"` // 复制来源歌单ID
+	DeletedAt     *time.Time          `json:"deleted_at,omitempty"`
+	CreatedAt     time.Time           `json:"created_at"`
+	UpdatedAt     time.Time           `json:"updated_at"`
 }
 
 // Validate 验证歌单数据
@@ -33,6 +36,11 @@ func (p *UserPlaylist) Validate() error {
 	return nil
 }
 
+// IsSmart 判断是否为智能歌单
+func (p *UserPlaylist) IsSmart() bool {
+	return p.SmartRules != nil
+}
+
 // IsDeleted 判断是否已删除
 func (p *UserPlaylist) IsDeleted() bool {
 	return p.DeletedAt != nil
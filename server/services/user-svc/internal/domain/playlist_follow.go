@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+// 公开歌单排序方式
+const (
+	PublicPlaylistSortPopular = "popular" // 按关注数排序
+	PublicPlaylistSortRecent  = "recent"  // 按更新时间排序
+)
+
+// PlaylistFollow 用户关注的公开歌单
+type PlaylistFollow struct {
+	PlaylistID string    `json:"playlist_id"`
+	UserID     string    `json:"user_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ValidatePublicPlaylistSort 验证公开歌单排序方式，空值视为按关注数排序
+func ValidatePublicPlaylistSort(sortBy string) (string, error) {
+	switch sortBy {
+	case "":
+		return PublicPlaylistSortPopular, nil
+	case PublicPlaylistSortPopular, PublicPlaylistSortRecent:
+		return sortBy, nil
+	default:
+		return "", ErrInvalidPlaylistSort
+	}
+}
+
+// PublicPlaylistDetail 公开歌单详情
+type PublicPlaylistDetail struct {
+	Playlist    *UserPlaylist   `json:"playlist"`
+	Songs       []*PlaylistSong `json:"songs"`
+	IsFollowing bool            `json:"is_following"`
+}
@@ -0,0 +1,63 @@
+package domain
+
+import "time"
+
+// 歌单成员角色
+// 所有者不存储在成员表中，由歌单的UserID决定
+const (
+	PlaylistRoleOwner  = "owner"  // 所有者：管理成员、编辑、删除歌单
+	PlaylistRoleEditor = "editor" // 编辑者：添加/移除歌曲
+	PlaylistRoleViewer = "viewer" // 查看者：查看私有歌单
+)
+
+const (
+	// MaxPlaylistMembers 单个歌单的最大成员数（不含所有者）
+	MaxPlaylistMembers = 50
+	// PlaylistInviteTTL 分享链接邀请的有效期
+	PlaylistInviteTTL = 7 * 24 * time.Hour
+)
+
+// playlistRoleRanks 角色权限等级，高等级包含低等级的全部权限
+var playlistRoleRanks = map[string]int{
+	PlaylistRoleViewer: 1,
+	PlaylistRoleEditor: 2,
+	PlaylistRoleOwner:  3,
+}
+
+// PlaylistMember 歌单协作成员
+type PlaylistMember struct {
+	PlaylistID string    `json:"playlist_id"`
+	UserID     string    `json:"user_id"`
+	Role       string    `json:"role"`
+	InvitedBy  string    `json:"invited_by"`
+	JoinedAt   time.Time `json:"joined_at"`
+}
+
+// PlaylistInvite 歌单分享链接邀请，持有token的用户接受后以Role加入歌单
+type PlaylistInvite struct {
+	Token      string    `json:"token"`
+	PlaylistID string    `json:"playlist_id"`
+	Role       string    `json:"role"`
+	CreatedBy  string    `json:"created_by"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ValidateMemberRole 验证可授予成员的角色（不能授予所有者）
+func ValidateMemberRole(role string) error {
+	if role != PlaylistRoleEditor && role != PlaylistRoleViewer {
+		return ErrInvalidMemberRole
+	}
+	return nil
+}
+
+// RoleAllows 判断角色是否具备required角色的权限
+func RoleAllows(role, required string) bool {
+	rank, ok := playlistRoleRanks[role]
+	return ok && rank >= playlistRoleRanks[required]
+}
+
+// IsExpired 判断邀请是否过期
+func (i *PlaylistInvite) IsExpired(now time.Time) bool {
+	return !now.Before(i.ExpiresAt)
+}
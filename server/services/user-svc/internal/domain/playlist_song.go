@@ -2,6 +2,16 @@ package domain
 
 import "time"
 
+// 歌单歌曲排序字段
+const (
+	PlaylistSongSortName    = "name"     // 按歌名
+	PlaylistSongSortSinger  = "singer"   // 按歌手名
+	PlaylistSongSortAddedAt = "added_at" // 按添加时间
+)
+
+// MaxBatchPlaylistSongs 单次批量添加/移除的最大歌曲数
+const MaxBatchPlaylistSongs = 500
+
 // PlaylistSong 歌单-歌曲关联实体
 type PlaylistSong struct {
 	PlaylistID string    `json:"playlist_id"` // 歌单ID
@@ -9,6 +19,7 @@ type PlaylistSong struct {
 	SongName   string    `json:"song_name"`   // 歌名（冗余存储）
 	SingerName string    `json:"singer_name"` // 歌手名（冗余存储）
 	Position   int       `json:"position"`    // 排序位置
+	AddedBy    string    `json:"added_by"`    // 添加者用户ID（协作歌单中区分成员）
 	AddedAt    time.Time `json:"added_at"`    // 添加时间
 }
 
@@ -25,3 +36,13 @@ func (ps *PlaylistSong) Validate() error {
 	}
 	return nil
 }
+
+// ValidatePlaylistSongSort 验证歌单歌曲排序字段
+func ValidatePlaylistSongSort(sortBy string) error {
+	switch sortBy {
+	case PlaylistSongSortName, PlaylistSongSortSinger, PlaylistSongSortAddedAt:
+		return nil
+	default:
+		return ErrInvalidPlaylistSongSort
+	}
+}
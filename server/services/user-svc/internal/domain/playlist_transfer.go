@@ -0,0 +1,35 @@
+package domain
+
+// 歌单导出格式
+const (
+	ExportFormatM3U8 = "m3u8"
+	ExportFormatXSPF = "xspf"
+	ExportFormatJSON = "json"
+)
+
+// MaxImportSongs 单次导入歌单的最大歌曲数
+const MaxImportSongs = 1000
+
+// ValidateExportFormat 验证导出格式
+func ValidateExportFormat(format string) error {
+	switch format {
+	case ExportFormatM3U8, ExportFormatXSPF, ExportFormatJSON:
+		return nil
+	default:
+		return ErrInvalidExportFormat
+	}
+}
+
+// PlaylistImportResult 歌单导入结果
+type PlaylistImportResult struct {
+	Playlist   *UserPlaylist `json:"playlist"`
+	Imported   int           `json:"imported"`   // 导入的歌曲数
+	Duplicates int           `json:"duplicates"` // 重复而被跳过的歌曲数
+}
+
+// PlaylistFile 导出的歌单文件
+type PlaylistFile struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Content     []byte `json:"-"`
+}
@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+// 推荐来源
+const (
+	RecommendationSourceCoOccurrence = "co_occurrence" // 其他用户的收藏/歌单/播放共现
+	RecommendationSourceSimilar      = "similar"       // 上游相似歌曲
+)
+
+// SongSignal 用户对一首歌的偏好信号（收藏、加入歌单、播放次数加权汇总）
+type SongSignal struct {
+	SongID     string  `json:"song_id"`
+	SongName   string  `json:"song_name"`
+	SingerName string  `json:"singer_name"`
+	Weight     float64 `json:"weight"`
+	Favorited  bool    `json:"favorited"`
+}
+
+// RecommendedSong 推荐歌曲
+type RecommendedSong struct {
+	SongID     string  `json:"song_id"`
+	SongName   string  `json:"song_name"`
+	SingerName string  `json:"singer_name"`
+	Score      float64 `json:"score"`
+	Source     string  `json:"source"`
+}
+
+// RecommendationRow "因为你喜欢X"推荐行
+type RecommendationRow struct {
+	SeedSongID     string             `json:"seed_song_id"`
+	SeedSongName   string             `json:"seed_song_name"`
+	SeedSingerName string             `json:"seed_singer_name"`
+	Songs          []*RecommendedSong `json:"songs"`
+}
+
+// UserRecommendations 用户的个性化推荐（离线计算后缓存）
+type UserRecommendations struct {
+	UserID          string               `json:"user_id"`
+	DailyMix        []*RecommendedSong   `json:"daily_mix"`
+	BecauseYouLiked []*RecommendationRow `json:"because_you_liked"`
+	GeneratedAt     time.Time            `json:"generated_at"`
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// SingerRelease 歌手发行的专辑（由定时任务轮询上游歌手专辑列表发现）
+type SingerRelease struct {
+	SingerID     string    `json:"singer_id"`     // 歌手的第三方平台ID
+	SingerName   string    `json:"singer_name"`   // 歌手名
+	AlbumID      string    `json:"album_id"`      // 专辑的第三方平台ID
+	AlbumName    string    `json:"album_name"`    // 专辑名
+	CoverURL     string    `json:"cover_url"`     // 专辑封面
+	ReleaseDate  string    `json:"release_date"`  // 上游返回的发行日期，如2026-10-01
+	Baseline     bool      `json:"-"`             // 第一次拉取到的已有专辑，不作为新发行
+	DiscoveredAt time.Time `json:"discovered_at"` // 发现时间
+}
+
+// SingerReleasePollResult 一次轮询的结果
+type SingerReleasePollResult struct {
+	SingersPolled int   `json:"singers_polled"`
+	SingersFailed int   `json:"singers_failed"`
+	NewReleases   int64 `json:"new_releases"`
+}
@@ -0,0 +1,306 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// 智能歌单规则的组合方式
+const (
+	SmartMatchAll = "all" // 满足全部规则
+	SmartMatchAny = "any" // 满足任一规则
+)
+
+// 智能歌单规则字段
+const (
+	SmartFieldFavorited    = "favorited"      // 是否收藏
+	SmartFieldFavoritedAt  = "favorited_at"   // 收藏时间
+	SmartFieldPlayCount    = "play_count"     // 累计播放次数
+	SmartFieldLastPlayedAt = "last_played_at" // 最后播放时间
+	SmartFieldSinger       = "singer"         // 歌手
+	SmartFieldSongName     = "song_name"      // 歌名
+)
+
+// 智能歌单规则操作符
+const (
+	SmartOpIs            = "is"               // 布尔：是
+	SmartOpIsNot         = "is_not"           // 布尔：否
+	SmartOpInLastDays    = "in_last_days"     // 时间：最近N天内
+	SmartOpNotInLastDays = "not_in_last_days" // 时间：N天内没有（从未发生也算）
+	SmartOpEq            = "eq"
+	SmartOpGt            = "gt"
+	SmartOpGte           = "gte"
+	SmartOpLt            = "lt"
+	SmartOpLte           = "lte"
+	SmartOpIn            = "in"           // 文本：等于其中之一（忽略大小写）
+	SmartOpNotIn         = "not_in"       // 文本：不等于其中任何一个
+	SmartOpContains      = "contains"     // 文本：包含其中之一
+	SmartOpNotContains   = "not_contains" // 文本：不包含其中任何一个
+)
+
+// 智能歌单排序方式
+const (
+	SmartSortPlayCount    = "play_count"     // 播放次数从多到少（默认）
+	SmartSortLastPlayedAt = "last_played_at" // 最近播放在前
+	SmartSortFavoritedAt  = "favorited_at"   // 最近收藏在前
+	SmartSortSongName     = "song_name"      // 歌名
+)
+
+const (
+	// MaxSmartRules 单个智能歌单的最大规则数
+	MaxSmartRules = 20
+	// DefaultSmartPlaylistLimit 智能歌单默认最多歌曲数
+	DefaultSmartPlaylistLimit = 100
+	// MaxSmartPlaylistLimit 智能歌单最多歌曲数上限
+	MaxSmartPlaylistLimit = 500
+	// MaxSmartRuleDays 时间规则的最大天数（约100年，换算为time.Duration时不会溢出）
+	MaxSmartRuleDays = 36500
+)
+
+// smartFieldOps 每个字段支持的操作符
+var smartFieldOps = map[string][]string{
+	SmartFieldFavorited:    {SmartOpIs, SmartOpIsNot},
+	SmartFieldFavoritedAt:  {SmartOpInLastDays, SmartOpNotInLastDays},
+	SmartFieldPlayCount:    {SmartOpEq, SmartOpGt, SmartOpGte, SmartOpLt, SmartOpLte},
+	SmartFieldLastPlayedAt: {SmartOpInLastDays, SmartOpNotInLastDays},
+	SmartFieldSinger:       {SmartOpIn, SmartOpNotIn, SmartOpContains, SmartOpNotContains},
+	SmartFieldSongName:     {SmartOpContains, SmartOpNotContains},
+}
+
+// SmartPlaylistRules 智能歌单规则表达式（以JSON存储在歌单上）
+// 例：{"match":"all","rules":[{"field":"favorited_at","op":"in_last_days","value":30},
+// {"field":"singer","op":"in","values":["周杰伦"]}],"sort_by":"play_count","limit":50}
+type SmartPlaylistRules struct {
+	Match  string      `json:"match"`
+	Rules  []SmartRule `json:"rules"`
+	SortBy string      `json:"sort_by,omitempty"`
+	Limit  int         `json:"limit,omitempty"`
+}
+
+// SmartRule 单条规则
+// 数值和天数类规则使用Value，文本类规则使用Values，布尔类规则不需要值
+type SmartRule struct {
+	Field  string   `json:"field"`
+	Op     string   `json:"op"`
+	Value  float64  `json:"value,omitempty"`
+	Values []string `json:"values,omitempty"`
+}
+
+// LibrarySong 用户曲库中的一首歌（收藏或播放过），用于计算智能歌单
+type LibrarySong struct {
+	SongID       string     `json:"song_id"`
+	SongName     string     `json:"song_name"`
+	SingerName   string     `json:"singer_name"`
+	FavoritedAt  *time.Time `json:"favorited_at,omitempty"`
+	PlayCount    int64      `json:"play_count"`
+	LastPlayedAt *time.Time `json:"last_played_at,omitempty"`
+}
+
+// Normalize 填充默认值
+func (r *SmartPlaylistRules) Normalize() {
+	if r.Match == "" {
+		r.Match = SmartMatchAll
+	}
+	if r.SortBy == "" {
+		r.SortBy = SmartSortPlayCount
+	}
+	if r.Limit <= 0 {
+		r.Limit = DefaultSmartPlaylistLimit
+	}
+}
+
+// Validate 验证规则表达式
+func (r *SmartPlaylistRules) Validate() error {
+	if r.Match != SmartMatchAll && r.Match != SmartMatchAny {
+		return fmt.Errorf("%w: unknown match %q", ErrInvalidSmartRules, r.Match)
+	}
+	if len(r.Rules) == 0 {
+		return fmt.Errorf("%w: at least one rule is required", ErrInvalidSmartRules)
+	}
+	if len(r.Rules) > MaxSmartRules {
+		return fmt.Errorf("%w: at most %d rules", ErrInvalidSmartRules, MaxSmartRules)
+	}
+	switch r.SortBy {
+	case SmartSortPlayCount, SmartSortLastPlayedAt, SmartSortFavoritedAt, SmartSortSongName:
+	default:
+		return fmt.Errorf("%w: unknown sort_by %q", ErrInvalidSmartRules, r.SortBy)
+	}
+	if r.Limit > MaxSmartPlaylistLimit {
+		return fmt.Errorf("%w: limit exceeds %d", ErrInvalidSmartRules, MaxSmartPlaylistLimit)
+	}
+
+	for i, rule := range r.Rules {
+		ops, ok := smartFieldOps[rule.Field]
+		if !ok {
+			return fmt.Errorf("%w: rule %d: unknown field %q", ErrInvalidSmartRules, i, rule.Field)
+		}
+		if !containsString(ops, rule.Op) {
+			return fmt.Errorf("%w: rule %d: field %q does not support op %q", ErrInvalidSmartRules, i, rule.Field, rule.Op)
+		}
+		switch rule.Field {
+		case SmartFieldFavoritedAt, SmartFieldLastPlayedAt:
+			if rule.Value <= 0 {
+				return fmt.Errorf("%w: rule %d: days must be positive", ErrInvalidSmartRules, i)
+			}
+			if rule.Value > MaxSmartRuleDays {
+				return fmt.Errorf("%w: rule %d: days exceeds %d", ErrInvalidSmartRules, i, MaxSmartRuleDays)
+			}
+		case SmartFieldPlayCount:
+			if rule.Value < 0 {
+				return fmt.Errorf("%w: rule %d: play count must not be negative", ErrInvalidSmartRules, i)
+			}
+		case SmartFieldSinger, SmartFieldSongName:
+			if len(rule.Values) == 0 {
+				return fmt.Errorf("%w: rule %d: values are required", ErrInvalidSmartRules, i)
+			}
+		}
+	}
+	return nil
+}
+
+// Evaluate 对曲库计算智能歌单，按排序方式返回最多Limit首
+func (r *SmartPlaylistRules) Evaluate(library []*LibrarySong, now time.Time) []*LibrarySong {
+	var matched []*LibrarySong
+	for _, song := range library {
+		if r.matches(song, now) {
+			matched = append(matched, song)
+		}
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		a, b := matched[i], matched[j]
+		switch r.SortBy {
+		case SmartSortLastPlayedAt:
+			if !timeEqual(a.LastPlayedAt, b.LastPlayedAt) {
+				return timeAfter(a.LastPlayedAt, b.LastPlayedAt)
+			}
+		case SmartSortFavoritedAt:
+			if !timeEqual(a.FavoritedAt, b.FavoritedAt) {
+				return timeAfter(a.FavoritedAt, b.FavoritedAt)
+			}
+		case SmartSortSongName:
+			if a.SongName != b.SongName {
+				return a.SongName < b.SongName
+			}
+		default:
+			if a.PlayCount != b.PlayCount {
+				return a.PlayCount > b.PlayCount
+			}
+		}
+		return a.SongID < b.SongID
+	})
+
+	limit := r.Limit
+	if limit <= 0 {
+		limit = DefaultSmartPlaylistLimit
+	}
+	if len(matched) > limit {
+		matched = matched[:limit]
+	}
+	return matched
+}
+
+func (r *SmartPlaylistRules) matches(song *LibrarySong, now time.Time) bool {
+	for _, rule := range r.Rules {
+		ok := rule.matches(song, now)
+		if r.Match == SmartMatchAny && ok {
+			return true
+		}
+		if r.Match != SmartMatchAny && !ok {
+			return false
+		}
+	}
+	return r.Match != SmartMatchAny
+}
+
+func (rule SmartRule) matches(song *LibrarySong, now time.Time) bool {
+	switch rule.Field {
+	case SmartFieldFavorited:
+		return (song.FavoritedAt != nil) == (rule.Op == SmartOpIs)
+	case SmartFieldFavoritedAt:
+		return rule.matchesDays(song.FavoritedAt, now)
+	case SmartFieldLastPlayedAt:
+		return rule.matchesDays(song.LastPlayedAt, now)
+	case SmartFieldPlayCount:
+		count := float64(song.PlayCount)
+		switch rule.Op {
+		case SmartOpEq:
+			return count == rule.Value
+		case SmartOpGt:
+			return count > rule.Value
+		case SmartOpGte:
+			return count >= rule.Value
+		case SmartOpLt:
+			return count < rule.Value
+		case SmartOpLte:
+			return count <= rule.Value
+		}
+	case SmartFieldSinger:
+		return rule.matchesText(song.SingerName)
+	case SmartFieldSongName:
+		return rule.matchesText(song.SongName)
+	}
+	return false
+}
+
+// matchesDays 时间规则，t为nil表示从未发生
+// 天数限制在MaxSmartRuleDays以内，校验前保存的规则也不会因换算溢出而反转结果
+func (rule SmartRule) matchesDays(t *time.Time, now time.Time) bool {
+	days := rule.Value
+	if days > MaxSmartRuleDays {
+		days = MaxSmartRuleDays
+	}
+	cutoff := now.Add(-time.Duration(days * float64(24*time.Hour)))
+	within := t != nil && !t.Before(cutoff)
+	if rule.Op == SmartOpInLastDays {
+		return within
+	}
+	return !within
+}
+
+func (rule SmartRule) matchesText(value string) bool {
+	value = strings.ToLower(value)
+	for _, candidate := range rule.Values {
+		candidate = strings.ToLower(candidate)
+		switch rule.Op {
+		case SmartOpIn, SmartOpNotIn:
+			if value == candidate {
+				return rule.Op == SmartOpIn
+			}
+		case SmartOpContains, SmartOpNotContains:
+			if strings.Contains(value, candidate) {
+				return rule.Op == SmartOpContains
+			}
+		}
+	}
+	return rule.Op == SmartOpNotIn || rule.Op == SmartOpNotContains
+}
+
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
+
+func timeEqual(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Equal(*b)
+}
+
+// timeAfter 比较可空时间，nil视为最早
+func timeAfter(a, b *time.Time) bool {
+	if a == nil {
+		return false
+	}
+	if b == nil {
+		return true
+	}
+	return a.After(*b)
+}
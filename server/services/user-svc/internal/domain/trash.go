@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// DefaultTrashRetention 回收站默认保留时长，超过后由定时任务硬删除
+const DefaultTrashRetention = 30 * 24 * time.Hour
+
+// TrashedPlaylist 回收站中的歌单
+type TrashedPlaylist struct {
+	*UserPlaylist
+	ExpiresAt time.Time `json:"expires_at"` // 超过该时间后将被永久删除
+}
+
+// TrashedFavorite 回收站中的收藏
+type TrashedFavorite struct {
+	*Favorite
+	ExpiresAt time.Time `json:"expires_at"` // 超过该时间后将被永久删除
+}
+
+// TrashPurgeResult 回收站清理结果
+type TrashPurgeResult struct {
+	PlaylistsPurged int64 `json:"playlists_purged"`
+	FavoritesPurged int64 `json:"favorites_purged"`
+}
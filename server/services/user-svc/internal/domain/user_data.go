@@ -0,0 +1,33 @@
+package domain
+
+// UserDataExport 用户数据导出（账号数据下载）
+// 推荐结果由以下数据计算得出，可重新生成，不导出
+type UserDataExport struct {
+	UserID            string              `json:"user_id"`
+	Favorites         []*Favorite         `json:"favorites"`
+	Playlists         []*PlaylistExport   `json:"playlists"`
+	Histories         []*PlayHistory      `json:"histories"`
+	DailyListening    []*DailyListening   `json:"daily_listening"`    // 仍在明细保留期内的每日汇总
+	MonthlyListening  []*MonthlyListening `json:"monthly_listening"`  // 每月汇总
+	PlayQueue         *PlayQueue          `json:"play_queue"`         // 未保存过播放队列时为nil
+	PlaybackPositions []*PlaybackPosition `json:"playback_positions"` // 包括尚未回写数据库的进度
+	Memberships       []*PlaylistMember   `json:"memberships"`        // 参与的协作歌单成员关系
+	FollowedPlaylists []*UserPlaylist     `json:"followed_playlists"` // 关注的公开歌单
+}
+
+// PlaylistExport 歌单及其歌曲
+type PlaylistExport struct {
+	Playlist *UserPlaylist   `json:"playlist"`
+	Songs    []*PlaylistSong `json:"songs"`
+}
+
+// ErasureResult 用户数据删除结果
+type ErasureResult struct {
+	FavoritesDeleted   int64 `json:"favorites_deleted"`
+	PlaylistsDeleted   int64 `json:"playlists_deleted"`
+	MembershipsDeleted int64 `json:"memberships_deleted"` // 参与的协作歌单成员关系
+	FollowsDeleted     int64 `json:"follows_deleted"`     // 关注的公开歌单
+	HistoryDeleted     int64 `json:"history_deleted"`
+	StatsDeleted       int64 `json:"stats_deleted"`    // 听歌统计汇总行数
+	PlaybackDeleted    int64 `json:"playback_deleted"` // 播放队列和播放进度行数
+}
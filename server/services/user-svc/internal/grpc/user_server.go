@@ -2,6 +2,9 @@ package grpc
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"time"
 
 	"user-svc/internal/domain"
 	"user-svc/internal/service"
@@ -15,9 +18,18 @@ import (
 // UserServer 用户服务gRPC实现
 type UserServer struct {
 	userv1.UnimplementedUserServiceServer
-	favoriteService *service.FavoriteService
-	historyService  *service.PlayHistoryService
-	playlistService *service.PlaylistService
+	favoriteService  *service.FavoriteService
+	historyService   *service.PlayHistoryService
+	playlistService  *service.PlaylistService
+	memberService    *service.PlaylistMemberService
+	transferService  *service.PlaylistTransferService
+	discoveryService *service.PlaylistDiscoveryService
+	trashService     *service.TrashService
+	accountService   *service.AccountDataService
+	statsService     *service.ListeningStatsService
+	recsService      *service.RecommendationService
+	releaseService   *service.SingerReleaseService
+	playbackService  *service.PlaybackService
 }
 
 // NewUserServer 创建用户服务gRPC服务器
@@ -25,32 +37,53 @@ func NewUserServer(
 	favoriteService *service.FavoriteService,
 	historyService *service.PlayHistoryService,
 	playlistService *service.PlaylistService,
+	memberService *service.PlaylistMemberService,
+	transferService *service.PlaylistTransferService,
+	discoveryService *service.PlaylistDiscoveryService,
+	trashService *service.TrashService,
+	accountService *service.AccountDataService,
+	statsService *service.ListeningStatsService,
+	recsService *service.RecommendationService,
+	releaseService *service.SingerReleaseService,
+	playbackService *service.PlaybackService,
 ) *UserServer {
 	return &UserServer{
-		favoriteService: favoriteService,
-		historyService:  historyService,
-		playlistService: playlistService,
+		favoriteService:  favoriteService,
+		historyService:   historyService,
+		playlistService:  playlistService,
+		memberService:    memberService,
+		transferService:  transferService,
+		discoveryService: discoveryService,
+		trashService:     trashService,
+		accountService:   accountService,
+		statsService:     statsService,
+		recsService:      recsService,
+		releaseService:   releaseService,
+		playbackService:  playbackService,
 	}
 }
 
-// AddFavorite 添加收藏
-// 注意：当前实现仅支持歌曲收藏(FAVORITE_TYPE_SONG)，未来需扩展支持album/artist/mv
+// AddFavorite 添加收藏（歌曲、专辑、歌手、MV或上游歌单）
 func (s *UserServer) AddFavorite(ctx context.Context, req *userv1.AddFavoriteRequest) (*userv1.AddFavoriteResponse, error) {
-	// 验证类型（当前仅支持歌曲）
-	if req.Type != userv1.FavoriteType_FAVORITE_TYPE_SONG {
-		return nil, status.Errorf(codes.Unimplemented, "only song favorites are currently supported")
+	favoriteType, ok := favoriteTypeFromProto(req.Type)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid favorite type: %v", req.Type)
 	}
 
 	// 提取元数据
-	songName := ""
-	singerName := ""
+	favorite := &domain.Favorite{
+		Type:   favoriteType,
+		SongID: req.TargetId,
+	}
 	if req.Metadata != nil {
-		songName = req.Metadata.Name
-		singerName = req.Metadata.Artist
+		favorite.SongName = req.Metadata.Name
+		favorite.SingerName = req.Metadata.Artist
+		favorite.CoverURL = req.Metadata.CoverUrl
+		favorite.Extra = req.Metadata.Extra
 	}
 
 	// 调用服务层
-	favorite, err := s.favoriteService.AddFavorite(ctx, req.UserId, req.TargetId, songName, singerName)
+	favorite, err := s.favoriteService.AddFavorite(ctx, req.UserId, favorite)
 	if err != nil {
 		if err == domain.ErrFavoriteAlreadyExists {
 			return nil, status.Errorf(codes.AlreadyExists, "already favorited")
@@ -82,16 +115,14 @@ func (s *UserServer) RemoveFavorite(ctx context.Context, req *userv1.RemoveFavor
 	}, nil
 }
 
-// ListFavorites 获取收藏列表
+// ListFavorites 获取收藏列表，可按类型过滤，同时返回各类型的收藏数量
 func (s *UserServer) ListFavorites(ctx context.Context, req *userv1.ListFavoritesRequest) (*userv1.ListFavoritesResponse, error) {
-	// 验证类型过滤（当前仅支持SONG或不过滤）
-	if req.Type != userv1.FavoriteType_FAVORITE_TYPE_UNSPECIFIED && req.Type != userv1.FavoriteType_FAVORITE_TYPE_SONG {
-		return &userv1.ListFavoritesResponse{
-			Favorites: []*userv1.Favorite{},
-			Total:     0,
-			Page:      req.Page,
-			PageSize:  req.PageSize,
-		}, nil
+	favoriteType := ""
+	if req.Type != userv1.FavoriteType_FAVORITE_TYPE_UNSPECIFIED {
+		var ok bool
+		if favoriteType, ok = favoriteTypeFromProto(req.Type); !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid favorite type: %v", req.Type)
+		}
 	}
 
 	page := req.Page
@@ -106,32 +137,35 @@ func (s *UserServer) ListFavorites(ctx context.Context, req *userv1.ListFavorite
 		pageSize = 100
 	}
 
-	favorites, total, err := s.favoriteService.GetFavorites(ctx, req.UserId, int(page), int(pageSize))
+	favorites, total, err := s.favoriteService.GetFavorites(ctx, req.UserId, favoriteType, int(page), int(pageSize))
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to list favorites: %v", err)
 	}
 
+	counts, err := s.favoriteService.CountByType(ctx, req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to count favorites: %v", err)
+	}
+
 	// 转换为proto消息
 	pbFavorites := make([]*userv1.Favorite, 0, len(favorites))
 	for _, f := range favorites {
-		pbFavorites = append(pbFavorites, &userv1.Favorite{
-			Id:       f.ID,
-			UserId:   f.UserID,
-			Type:     userv1.FavoriteType_FAVORITE_TYPE_SONG,
-			TargetId: f.SongID,
-			Metadata: &userv1.FavoriteMetadata{
-				Name:   f.SongName,
-				Artist: f.SingerName,
-			},
-			CreatedAt: timestamppb.New(f.CreatedAt),
+		pbFavorites = append(pbFavorites, domainFavoriteToProto(f))
+	}
+	typeCounts := make([]*userv1.FavoriteTypeCount, 0, len(domain.FavoriteTypes))
+	for _, t := range domain.FavoriteTypes {
+		typeCounts = append(typeCounts, &userv1.FavoriteTypeCount{
+			Type:  favoriteTypeToProto(t),
+			Count: counts[t],
 		})
 	}
 
 	return &userv1.ListFavoritesResponse{
-		Favorites: pbFavorites,
-		Total:     int32(total),
-		Page:      page,
-		PageSize:  pageSize,
+		Favorites:  pbFavorites,
+		Total:      int32(total),
+		Page:       page,
+		PageSize:   pageSize,
+		TypeCounts: typeCounts,
 	}, nil
 }
 
@@ -200,17 +234,32 @@ func (s *UserServer) ListPlayHistory(ctx context.Context, req *userv1.ListPlayHi
 	}, nil
 }
 
-// CreatePlaylist 创建歌单
+// CreatePlaylist 创建歌单（带smart_rules时创建智能歌单）
 func (s *UserServer) CreatePlaylist(ctx context.Context, req *userv1.CreatePlaylistRequest) (*userv1.CreatePlaylistResponse, error) {
-	playlist, err := s.playlistService.CreatePlaylist(
-		ctx,
-		req.UserId,
-		req.Name,
-		"",          // description - proto中没有此字段
-		req.CoverUrl,
-		req.IsPublic,
+	var (
+		playlist *domain.UserPlaylist
+		err      error
 	)
+	if req.SmartRules != "" {
+		rules, parseErr := parseSmartRules(req.SmartRules)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		playlist, err = s.playlistService.CreateSmartPlaylist(ctx, req.UserId, req.Name, "", req.CoverUrl, req.IsPublic, rules)
+	} else {
+		playlist, err = s.playlistService.CreatePlaylist(
+			ctx,
+			req.UserId,
+			req.Name,
+			"",          // description - proto中没有此字段
+			req.CoverUrl,
+			req.IsPublic,
+		)
+	}
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidSmartRules) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, status.Errorf(codes.Internal, "failed to create playlist: %v", err)
 	}
 
@@ -226,6 +275,16 @@ func (s *UserServer) UpdatePlaylist(ctx context.Context, req *userv1.UpdatePlayl
 		isPublic = *req.IsPublic
 	}
 
+	if req.SmartRules != "" {
+		rules, err := parseSmartRules(req.SmartRules)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := s.playlistService.UpdateSmartRules(ctx, req.PlaylistId, req.UserId, rules); err != nil {
+			return nil, smartPlaylistError(err)
+		}
+	}
+
 	playlist, err := s.playlistService.UpdatePlaylist(
 		ctx,
 		req.PlaylistId,
@@ -299,9 +358,19 @@ func (s *UserServer) AddSongToPlaylist(ctx context.Context, req *userv1.AddSongT
 		if err == domain.ErrSongAlreadyInPlaylist {
 			return nil, status.Errorf(codes.AlreadyExists, "song already in playlist")
 		}
+		if err == domain.ErrSmartPlaylistReadOnly {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
 		return nil, status.Errorf(codes.Internal, "failed to add song to playlist: %v", err)
 	}
 
+	// 指定了位置时把新添加的歌曲移动到该位置（添加时总是追加到末尾）
+	if req.Position > 0 {
+		if err := s.playlistService.MoveSong(ctx, req.PlaylistId, req.UserId, req.SongId, int(req.Position)); err != nil {
+			return nil, playlistSongError(err, "failed to move song")
+		}
+	}
+
 	// 获取更新后的歌单以返回song_count
 	playlist, err := s.playlistService.GetPlaylist(ctx, req.PlaylistId)
 	if err != nil {
@@ -324,6 +393,12 @@ func (s *UserServer) RemoveSongFromPlaylist(ctx context.Context, req *userv1.Rem
 		if err == domain.ErrUnauthorized {
 			return nil, status.Errorf(codes.PermissionDenied, "not authorized")
 		}
+		if err == domain.ErrSmartPlaylistReadOnly {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		if err == domain.ErrSongNotInPlaylist {
+			return nil, status.Errorf(codes.NotFound, "song not in playlist")
+		}
 		return nil, status.Errorf(codes.Internal, "failed to remove song from playlist: %v", err)
 	}
 
@@ -342,8 +417,112 @@ func (s *UserServer) RemoveSongFromPlaylist(ctx context.Context, req *userv1.Rem
 	}, nil
 }
 
-// GetPlaylistSongs 获取歌单歌曲列表
+// AddSongsToPlaylist 批量添加歌曲到歌单
+func (s *UserServer) AddSongsToPlaylist(ctx context.Context, req *userv1.AddSongsToPlaylistRequest) (*userv1.AddSongsToPlaylistResponse, error) {
+	songs := make([]*domain.PlaylistSong, 0, len(req.Songs))
+	for _, song := range req.Songs {
+		songs = append(songs, &domain.PlaylistSong{
+			SongID:     song.SongId,
+			SongName:   song.SongName,
+			SingerName: song.SingerName,
+		})
+	}
+
+	added, err := s.playlistService.AddSongsToPlaylist(ctx, req.PlaylistId, req.UserId, songs)
+	if err != nil {
+		return nil, playlistSongError(err, "failed to add songs to playlist")
+	}
+
+	return &userv1.AddSongsToPlaylistResponse{
+		Added:     int32(added),
+		Skipped:   int32(len(songs) - added),
+		SongCount: s.songCount(ctx, req.PlaylistId),
+	}, nil
+}
+
+// RemoveSongsFromPlaylist 批量移除歌单歌曲
+func (s *UserServer) RemoveSongsFromPlaylist(ctx context.Context, req *userv1.RemoveSongsFromPlaylistRequest) (*userv1.RemoveSongsFromPlaylistResponse, error) {
+	removed, err := s.playlistService.RemoveSongsFromPlaylist(ctx, req.PlaylistId, req.UserId, req.SongIds)
+	if err != nil {
+		return nil, playlistSongError(err, "failed to remove songs from playlist")
+	}
+
+	return &userv1.RemoveSongsFromPlaylistResponse{
+		Removed:   int32(removed),
+		SongCount: s.songCount(ctx, req.PlaylistId),
+	}, nil
+}
+
+// MovePlaylistSong 移动歌单歌曲位置
+func (s *UserServer) MovePlaylistSong(ctx context.Context, req *userv1.MovePlaylistSongRequest) (*userv1.MovePlaylistSongResponse, error) {
+	if err := s.playlistService.MoveSong(ctx, req.PlaylistId, req.UserId, req.SongId, int(req.Position)); err != nil {
+		return nil, playlistSongError(err, "failed to move song")
+	}
+
+	return &userv1.MovePlaylistSongResponse{
+		Success: true,
+	}, nil
+}
+
+// SortPlaylistSongs 重排歌单歌曲
+func (s *UserServer) SortPlaylistSongs(ctx context.Context, req *userv1.SortPlaylistSongsRequest) (*userv1.SortPlaylistSongsResponse, error) {
+	if err := s.playlistService.SortSongs(ctx, req.PlaylistId, req.UserId, req.SortBy, req.Descending); err != nil {
+		return nil, playlistSongError(err, "failed to sort playlist songs")
+	}
+
+	return &userv1.SortPlaylistSongsResponse{
+		Success: true,
+	}, nil
+}
+
+// songCount 获取歌单当前的歌曲数，获取失败时返回0（操作本身已成功）
+func (s *UserServer) songCount(ctx context.Context, playlistID string) int32 {
+	playlist, err := s.playlistService.GetPlaylist(ctx, playlistID)
+	if err != nil {
+		return 0
+	}
+	return int32(playlist.SongCount)
+}
+
+// playlistSongError 把歌单歌曲编辑相关的domain错误映射为gRPC状态码
+func playlistSongError(err error, msg string) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidSongID),
+		errors.Is(err, domain.ErrInvalidPosition),
+		errors.Is(err, domain.ErrInvalidPlaylistSongSort),
+		errors.Is(err, domain.ErrTooManyBatchSongs):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrPlaylistNotFound):
+		return status.Errorf(codes.NotFound, "playlist not found")
+	case errors.Is(err, domain.ErrSongNotInPlaylist):
+		return status.Errorf(codes.NotFound, "song not in playlist")
+	case errors.Is(err, domain.ErrUnauthorized):
+		return status.Errorf(codes.PermissionDenied, "not authorized")
+	case errors.Is(err, domain.ErrSmartPlaylistReadOnly):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", msg, err)
+	}
+}
+
+// GetPlaylist 获取歌单详情（私有歌单只有所有者和协作成员可以查看）
+func (s *UserServer) GetPlaylist(ctx context.Context, req *userv1.GetPlaylistRequest) (*userv1.GetPlaylistResponse, error) {
+	playlist, err := s.playlistService.GetPlaylistForUser(ctx, req.PlaylistId, req.UserId)
+	if err != nil {
+		return nil, playlistSongError(err, "failed to get playlist")
+	}
+
+	return &userv1.GetPlaylistResponse{
+		Playlist: domainPlaylistToProto(playlist),
+	}, nil
+}
+
+// GetPlaylistSongs 获取歌单歌曲列表（私有歌单只有所有者和协作成员可以查看）
 func (s *UserServer) GetPlaylistSongs(ctx context.Context, req *userv1.GetPlaylistSongsRequest) (*userv1.GetPlaylistSongsResponse, error) {
+	if _, err := s.playlistService.GetPlaylistForUser(ctx, req.PlaylistId, req.UserId); err != nil {
+		return nil, playlistSongError(err, "failed to get playlist songs")
+	}
+
 	songs, err := s.playlistService.GetPlaylistSongs(ctx, req.PlaylistId)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get playlist songs: %v", err)
@@ -352,12 +531,7 @@ func (s *UserServer) GetPlaylistSongs(ctx context.Context, req *userv1.GetPlayli
 	// 转换为proto消息
 	pbSongs := make([]*userv1.PlaylistSong, 0, len(songs))
 	for _, s := range songs {
-		pbSongs = append(pbSongs, &userv1.PlaylistSong{
-			PlaylistId: s.PlaylistID,
-			SongId:     s.SongID,
-			Position:   int32(s.Position),
-			AddedAt:    timestamppb.New(s.AddedAt),
-		})
+		pbSongs = append(pbSongs, domainPlaylistSongToProto(s))
 	}
 
 	return &userv1.GetPlaylistSongsResponse{
@@ -365,16 +539,933 @@ func (s *UserServer) GetPlaylistSongs(ctx context.Context, req *userv1.GetPlayli
 	}, nil
 }
 
+// ImportPlaylist 导入外部歌单（歌曲已由proxy-svc匹配到曲库）
+func (s *UserServer) ImportPlaylist(ctx context.Context, req *userv1.ImportPlaylistRequest) (*userv1.ImportPlaylistResponse, error) {
+	songs := make([]*domain.PlaylistSong, 0, len(req.Songs))
+	for _, song := range req.Songs {
+		songs = append(songs, &domain.PlaylistSong{
+			SongID:     song.SongId,
+			SongName:   song.SongName,
+			SingerName: song.SingerName,
+		})
+	}
+
+	result, err := s.transferService.ImportPlaylist(ctx, req.UserId, req.Name, req.Description, req.CoverUrl, req.IsPublic, songs)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidUserID),
+			errors.Is(err, domain.ErrInvalidSongID),
+			errors.Is(err, domain.ErrInvalidPlaylistName),
+			errors.Is(err, domain.ErrPlaylistNameTooLong),
+			errors.Is(err, domain.ErrTooManyImportSongs):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		default:
+			return nil, status.Errorf(codes.Internal, "failed to import playlist: %v", err)
+		}
+	}
+
+	return &userv1.ImportPlaylistResponse{
+		Playlist:   domainPlaylistToProto(result.Playlist),
+		Imported:   int32(result.Imported),
+		Duplicates: int32(result.Duplicates),
+	}, nil
+}
+
+// ExportPlaylist 导出歌单文件
+func (s *UserServer) ExportPlaylist(ctx context.Context, req *userv1.ExportPlaylistRequest) (*userv1.ExportPlaylistResponse, error) {
+	file, err := s.transferService.ExportPlaylist(ctx, req.PlaylistId, req.UserId, req.Format)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidExportFormat):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, domain.ErrPlaylistNotFound):
+			return nil, status.Errorf(codes.NotFound, "playlist not found")
+		case errors.Is(err, domain.ErrUnauthorized):
+			return nil, status.Errorf(codes.PermissionDenied, "not authorized")
+		default:
+			return nil, status.Errorf(codes.Internal, "failed to export playlist: %v", err)
+		}
+	}
+
+	return &userv1.ExportPlaylistResponse{
+		Filename:    file.Filename,
+		ContentType: file.ContentType,
+		Content:     file.Content,
+	}, nil
+}
+
+// ListPublicPlaylists 浏览公开歌单
+func (s *UserServer) ListPublicPlaylists(ctx context.Context, req *userv1.ListPublicPlaylistsRequest) (*userv1.ListPublicPlaylistsResponse, error) {
+	page, pageSize := normalizePage(req.Page, req.PageSize)
+
+	playlists, total, err := s.discoveryService.ListPublicPlaylists(ctx, req.Sort, page, pageSize)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidPlaylistSort) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to list public playlists: %v", err)
+	}
+
+	return &userv1.ListPublicPlaylistsResponse{
+		Playlists: domainPlaylistsToProto(playlists),
+		Total:     total,
+	}, nil
+}
+
+// GetPublicPlaylist 获取公开歌单详情
+func (s *UserServer) GetPublicPlaylist(ctx context.Context, req *userv1.GetPublicPlaylistRequest) (*userv1.GetPublicPlaylistResponse, error) {
+	detail, err := s.discoveryService.GetPublicPlaylist(ctx, req.PlaylistId, req.UserId)
+	if err != nil {
+		return nil, discoveryError(err, "failed to get public playlist")
+	}
+
+	songs := make([]*userv1.PlaylistSong, 0, len(detail.Songs))
+	for _, song := range detail.Songs {
+		songs = append(songs, domainPlaylistSongToProto(song))
+	}
+
+	return &userv1.GetPublicPlaylistResponse{
+		Playlist:    domainPlaylistToProto(detail.Playlist),
+		Songs:       songs,
+		IsFollowing: detail.IsFollowing,
+	}, nil
+}
+
+// FollowPlaylist 关注公开歌单
+func (s *UserServer) FollowPlaylist(ctx context.Context, req *userv1.FollowPlaylistRequest) (*userv1.FollowPlaylistResponse, error) {
+	playlist, err := s.discoveryService.FollowPlaylist(ctx, req.PlaylistId, req.UserId)
+	if err != nil {
+		return nil, discoveryError(err, "failed to follow playlist")
+	}
+
+	return &userv1.FollowPlaylistResponse{
+		Playlist: domainPlaylistToProto(playlist),
+	}, nil
+}
+
+// UnfollowPlaylist 取消关注歌单
+func (s *UserServer) UnfollowPlaylist(ctx context.Context, req *userv1.UnfollowPlaylistRequest) (*userv1.UnfollowPlaylistResponse, error) {
+	if err := s.discoveryService.UnfollowPlaylist(ctx, req.PlaylistId, req.UserId); err != nil {
+		return nil, discoveryError(err, "failed to unfollow playlist")
+	}
+
+	return &userv1.UnfollowPlaylistResponse{
+		Success: true,
+	}, nil
+}
+
+// ListFollowedPlaylists 获取用户关注的歌单
+func (s *UserServer) ListFollowedPlaylists(ctx context.Context, req *userv1.ListFollowedPlaylistsRequest) (*userv1.ListFollowedPlaylistsResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "user_id is required")
+	}
+	page, pageSize := normalizePage(req.Page, req.PageSize)
+
+	playlists, total, err := s.discoveryService.GetFollowedPlaylists(ctx, req.UserId, page, pageSize)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list followed playlists: %v", err)
+	}
+
+	return &userv1.ListFollowedPlaylistsResponse{
+		Playlists: domainPlaylistsToProto(playlists),
+		Total:     total,
+	}, nil
+}
+
+// ForkPlaylist 复制歌单为自己的私有歌单
+func (s *UserServer) ForkPlaylist(ctx context.Context, req *userv1.ForkPlaylistRequest) (*userv1.ForkPlaylistResponse, error) {
+	playlist, err := s.discoveryService.ForkPlaylist(ctx, req.PlaylistId, req.UserId, req.Name)
+	if err != nil {
+		return nil, discoveryError(err, "failed to fork playlist")
+	}
+
+	return &userv1.ForkPlaylistResponse{
+		Playlist: domainPlaylistToProto(playlist),
+	}, nil
+}
+
+// discoveryError 把公开歌单相关的domain错误映射为gRPC状态码
+func discoveryError(err error, msg string) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidUserID),
+		errors.Is(err, domain.ErrInvalidPlaylistName),
+		errors.Is(err, domain.ErrPlaylistNameTooLong),
+		errors.Is(err, domain.ErrCannotFollowOwnPlaylist):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrPlaylistNotFound):
+		return status.Errorf(codes.NotFound, "playlist not found")
+	case errors.Is(err, domain.ErrPlaylistNotPublic),
+		errors.Is(err, domain.ErrUnauthorized):
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", msg, err)
+	}
+}
+
+// ListPlaylistMembers 获取歌单成员（包括所有者）
+func (s *UserServer) ListPlaylistMembers(ctx context.Context, req *userv1.ListPlaylistMembersRequest) (*userv1.ListPlaylistMembersResponse, error) {
+	members, err := s.memberService.ListMembers(ctx, req.PlaylistId, req.UserId)
+	if err != nil {
+		return nil, memberError(err, "failed to list playlist members")
+	}
+
+	pbMembers := make([]*userv1.PlaylistMember, 0, len(members))
+	for _, member := range members {
+		pbMembers = append(pbMembers, playlistMemberToProto(member))
+	}
+
+	return &userv1.ListPlaylistMembersResponse{
+		Members: pbMembers,
+	}, nil
+}
+
+// InvitePlaylistMember 按用户ID邀请成员或修改成员角色（仅所有者）
+func (s *UserServer) InvitePlaylistMember(ctx context.Context, req *userv1.InvitePlaylistMemberRequest) (*userv1.InvitePlaylistMemberResponse, error) {
+	member, err := s.memberService.InviteMember(ctx, req.PlaylistId, req.UserId, req.MemberId, req.Role)
+	if err != nil {
+		return nil, memberError(err, "failed to invite playlist member")
+	}
+
+	return &userv1.InvitePlaylistMemberResponse{
+		Member: playlistMemberToProto(member),
+	}, nil
+}
+
+// RemovePlaylistMember 移除成员（所有者），或成员退出歌单
+func (s *UserServer) RemovePlaylistMember(ctx context.Context, req *userv1.RemovePlaylistMemberRequest) (*userv1.RemovePlaylistMemberResponse, error) {
+	if err := s.memberService.RemoveMember(ctx, req.PlaylistId, req.UserId, req.MemberId); err != nil {
+		return nil, memberError(err, "failed to remove playlist member")
+	}
+
+	return &userv1.RemovePlaylistMemberResponse{
+		Success: true,
+	}, nil
+}
+
+// CreatePlaylistInvite 生成分享链接邀请（仅所有者）
+func (s *UserServer) CreatePlaylistInvite(ctx context.Context, req *userv1.CreatePlaylistInviteRequest) (*userv1.CreatePlaylistInviteResponse, error) {
+	invite, err := s.memberService.CreateInviteLink(ctx, req.PlaylistId, req.UserId, req.Role)
+	if err != nil {
+		return nil, memberError(err, "failed to create playlist invite")
+	}
+
+	return &userv1.CreatePlaylistInviteResponse{
+		Invite: &userv1.PlaylistInvite{
+			Token:      invite.Token,
+			PlaylistId: invite.PlaylistID,
+			Role:       invite.Role,
+			CreatedBy:  invite.CreatedBy,
+			ExpiresAt:  timestamppb.New(invite.ExpiresAt),
+			CreatedAt:  timestamppb.New(invite.CreatedAt),
+		},
+	}, nil
+}
+
+// RevokePlaylistInvites 撤销歌单的所有分享链接（仅所有者）
+func (s *UserServer) RevokePlaylistInvites(ctx context.Context, req *userv1.RevokePlaylistInvitesRequest) (*userv1.RevokePlaylistInvitesResponse, error) {
+	revoked, err := s.memberService.RevokeInviteLinks(ctx, req.PlaylistId, req.UserId)
+	if err != nil {
+		return nil, memberError(err, "failed to revoke playlist invites")
+	}
+
+	return &userv1.RevokePlaylistInvitesResponse{
+		Revoked: revoked,
+	}, nil
+}
+
+// AcceptPlaylistInvite 通过分享链接加入歌单
+func (s *UserServer) AcceptPlaylistInvite(ctx context.Context, req *userv1.AcceptPlaylistInviteRequest) (*userv1.AcceptPlaylistInviteResponse, error) {
+	playlist, err := s.memberService.AcceptInvite(ctx, req.Token, req.UserId)
+	if err != nil {
+		return nil, memberError(err, "failed to accept playlist invite")
+	}
+
+	return &userv1.AcceptPlaylistInviteResponse{
+		Playlist: domainPlaylistToProto(playlist),
+	}, nil
+}
+
+// ListSharedPlaylists 获取用户参与协作的歌单列表
+func (s *UserServer) ListSharedPlaylists(ctx context.Context, req *userv1.ListSharedPlaylistsRequest) (*userv1.ListSharedPlaylistsResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "user_id is required")
+	}
+	page, pageSize := normalizePage(req.Page, req.PageSize)
+
+	playlists, err := s.playlistService.GetSharedPlaylists(ctx, req.UserId, page, pageSize)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list shared playlists: %v", err)
+	}
+
+	return &userv1.ListSharedPlaylistsResponse{
+		Playlists: domainPlaylistsToProto(playlists),
+	}, nil
+}
+
+// memberError 把协作歌单成员相关的domain错误映射为gRPC状态码
+func memberError(err error, msg string) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidUserID),
+		errors.Is(err, domain.ErrInvalidMemberRole),
+		errors.Is(err, domain.ErrCannotInviteOwner):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrPlaylistNotFound),
+		errors.Is(err, domain.ErrMemberNotFound),
+		errors.Is(err, domain.ErrInviteNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrUnauthorized):
+		return status.Errorf(codes.PermissionDenied, "not authorized")
+	case errors.Is(err, domain.ErrTooManyMembers):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, domain.ErrInviteExpired):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", msg, err)
+	}
+}
+
+// playlistMemberToProto 转换歌单成员
+func playlistMemberToProto(m *domain.PlaylistMember) *userv1.PlaylistMember {
+	return &userv1.PlaylistMember{
+		PlaylistId: m.PlaylistID,
+		UserId:     m.UserID,
+		Role:       m.Role,
+		InvitedBy:  m.InvitedBy,
+		JoinedAt:   timestamppb.New(m.JoinedAt),
+	}
+}
+
+// ListDeletedPlaylists 获取回收站中的歌单
+func (s *UserServer) ListDeletedPlaylists(ctx context.Context, req *userv1.ListDeletedPlaylistsRequest) (*userv1.ListDeletedPlaylistsResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "user_id is required")
+	}
+	page, pageSize := normalizePage(req.Page, req.PageSize)
+
+	playlists, total, err := s.trashService.ListDeletedPlaylists(ctx, req.UserId, page, pageSize)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list deleted playlists: %v", err)
+	}
+
+	pbPlaylists := make([]*userv1.DeletedPlaylist, 0, len(playlists))
+	for _, p := range playlists {
+		pbPlaylists = append(pbPlaylists, &userv1.DeletedPlaylist{
+			Playlist:  domainPlaylistToProto(p.UserPlaylist),
+			DeletedAt: timestamppb.New(*p.DeletedAt),
+			ExpiresAt: timestamppb.New(p.ExpiresAt),
+		})
+	}
+
+	return &userv1.ListDeletedPlaylistsResponse{
+		Playlists:     pbPlaylists,
+		Total:         total,
+		RetentionDays: s.trashRetentionDays(),
+	}, nil
+}
+
+// ListDeletedFavorites 获取回收站中的收藏
+func (s *UserServer) ListDeletedFavorites(ctx context.Context, req *userv1.ListDeletedFavoritesRequest) (*userv1.ListDeletedFavoritesResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "user_id is required")
+	}
+	page, pageSize := normalizePage(req.Page, req.PageSize)
+
+	favorites, total, err := s.trashService.ListDeletedFavorites(ctx, req.UserId, page, pageSize)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list deleted favorites: %v", err)
+	}
+
+	pbFavorites := make([]*userv1.DeletedFavorite, 0, len(favorites))
+	for _, f := range favorites {
+		pbFavorites = append(pbFavorites, &userv1.DeletedFavorite{
+			Favorite:  domainFavoriteToProto(f.Favorite),
+			DeletedAt: timestamppb.New(*f.DeletedAt),
+			ExpiresAt: timestamppb.New(f.ExpiresAt),
+		})
+	}
+
+	return &userv1.ListDeletedFavoritesResponse{
+		Favorites:     pbFavorites,
+		Total:         total,
+		RetentionDays: s.trashRetentionDays(),
+	}, nil
+}
+
+// RestorePlaylist 从回收站恢复歌单（包括歌曲）
+func (s *UserServer) RestorePlaylist(ctx context.Context, req *userv1.RestorePlaylistRequest) (*userv1.RestorePlaylistResponse, error) {
+	playlist, err := s.trashService.RestorePlaylist(ctx, req.PlaylistId, req.UserId)
+	if err != nil {
+		return nil, trashError(err, "failed to restore playlist")
+	}
+
+	return &userv1.RestorePlaylistResponse{
+		Playlist: domainPlaylistToProto(playlist),
+	}, nil
+}
+
+// RestoreFavorite 从回收站恢复收藏
+func (s *UserServer) RestoreFavorite(ctx context.Context, req *userv1.RestoreFavoriteRequest) (*userv1.RestoreFavoriteResponse, error) {
+	favorite, err := s.trashService.RestoreFavorite(ctx, req.FavoriteId, req.UserId)
+	if err != nil {
+		return nil, trashError(err, "failed to restore favorite")
+	}
+
+	return &userv1.RestoreFavoriteResponse{
+		Favorite: domainFavoriteToProto(favorite),
+	}, nil
+}
+
+// trashRetentionDays 回收站保留天数
+func (s *UserServer) trashRetentionDays() int32 {
+	return int32(s.trashService.Retention() / (24 * time.Hour))
+}
+
+// trashError 把回收站相关的domain错误映射为gRPC状态码
+func trashError(err error, msg string) error {
+	switch {
+	case errors.Is(err, domain.ErrPlaylistNotFound),
+		errors.Is(err, domain.ErrFavoriteNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrFavoriteAlreadyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, domain.ErrUnauthorized):
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", msg, err)
+	}
+}
+
+// ExportUserData 导出用户全部数据
+func (s *UserServer) ExportUserData(ctx context.Context, req *userv1.ExportUserDataRequest) (*userv1.ExportUserDataResponse, error) {
+	export, err := s.accountService.ExportUserData(ctx, req.UserId)
+	if err != nil {
+		if err == domain.ErrInvalidUserID {
+			return nil, status.Errorf(codes.InvalidArgument, "user_id is required")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to export user data: %v", err)
+	}
+
+	resp := &userv1.ExportUserDataResponse{
+		Favorites: make([]*userv1.Favorite, 0, len(export.Favorites)),
+		Playlists: make([]*userv1.PlaylistExport, 0, len(export.Playlists)),
+		History:   make([]*userv1.PlayHistory, 0, len(export.Histories)),
+	}
+	for _, f := range export.Favorites {
+		resp.Favorites = append(resp.Favorites, domainFavoriteToProto(f))
+	}
+	for _, p := range export.Playlists {
+		songs := make([]*userv1.PlaylistSong, 0, len(p.Songs))
+		for _, song := range p.Songs {
+			songs = append(songs, domainPlaylistSongToProto(song))
+		}
+		resp.Playlists = append(resp.Playlists, &userv1.PlaylistExport{
+			Playlist: domainPlaylistToProto(p.Playlist),
+			Songs:    songs,
+		})
+	}
+	for _, h := range export.Histories {
+		resp.History = append(resp.History, &userv1.PlayHistory{
+			Id:         h.ID,
+			UserId:     h.UserID,
+			SongId:     h.SongID,
+			SongName:   h.SongName,
+			ArtistName: h.SingerName,
+			Duration:   int32(h.Duration),
+			PlayedAt:   timestamppb.New(h.PlayedAt),
+		})
+	}
+	for _, d := range export.DailyListening {
+		resp.DailyListening = append(resp.DailyListening, &userv1.DailyListening{
+			Date:            d.Day.Format(time.DateOnly),
+			PlayCount:       d.PlayCount,
+			SecondsListened: d.SecondsListened,
+		})
+	}
+	for _, m := range export.MonthlyListening {
+		resp.MonthlyListening = append(resp.MonthlyListening, &userv1.MonthlyListening{
+			Month:           m.Month.Format("2006-01"),
+			PlayCount:       m.PlayCount,
+			SecondsListened: m.SecondsListened,
+		})
+	}
+	if export.PlayQueue != nil {
+		resp.PlayQueue = playQueueToProto(export.PlayQueue)
+	}
+	for _, p := range export.PlaybackPositions {
+		resp.PlaybackPositions = append(resp.PlaybackPositions, playbackPositionToProto(p))
+	}
+	for _, m := range export.Memberships {
+		resp.PlaylistMemberships = append(resp.PlaylistMemberships, playlistMemberToProto(m))
+	}
+	resp.FollowedPlaylists = domainPlaylistsToProto(export.FollowedPlaylists)
+
+	return resp, nil
+}
+
+// EraseUserData 永久删除用户全部数据（注销账号）
+func (s *UserServer) EraseUserData(ctx context.Context, req *userv1.EraseUserDataRequest) (*userv1.EraseUserDataResponse, error) {
+	result, err := s.accountService.EraseUserData(ctx, req.UserId)
+	if err != nil {
+		if err == domain.ErrInvalidUserID {
+			return nil, status.Errorf(codes.InvalidArgument, "user_id is required")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to erase user data: %v", err)
+	}
+
+	return &userv1.EraseUserDataResponse{
+		FavoritesDeleted:   result.FavoritesDeleted,
+		PlaylistsDeleted:   result.PlaylistsDeleted,
+		HistoryDeleted:     result.HistoryDeleted,
+		StatsDeleted:       result.StatsDeleted,
+		MembershipsDeleted: result.MembershipsDeleted,
+		FollowsDeleted:     result.FollowsDeleted,
+		PlaybackDeleted:    result.PlaybackDeleted,
+	}, nil
+}
+
 // domainPlaylistToProto 将domain歌单转换为proto消息
 func domainPlaylistToProto(p *domain.UserPlaylist) *userv1.Playlist {
-	return &userv1.Playlist{
-		Id:        p.ID,
-		UserId:    p.UserID,
-		Name:      p.Name,
-		CoverUrl:  p.CoverURL,
-		IsPublic:  p.IsPublic,
-		SongCount: int32(p.SongCount),
-		CreatedAt: timestamppb.New(p.CreatedAt),
-		UpdatedAt: timestamppb.New(p.UpdatedAt),
+	pb := &userv1.Playlist{
+		Id:            p.ID,
+		UserId:        p.UserID,
+		Name:          p.Name,
+		Description:   p.Description,
+		CoverUrl:      p.CoverURL,
+		IsPublic:      p.IsPublic,
+		SongCount:     int32(p.SongCount),
+		FollowerCount: int32(p.FollowerCount),
+		CreatedAt:     timestamppb.New(p.CreatedAt),
+		UpdatedAt:     timestamppb.New(p.UpdatedAt),
+	}
+	if p.ForkedFrom != nil {
+		pb.ForkedFrom = *p.ForkedFrom
+	}
+	if p.SmartRules != nil {
+		if data, err := json.Marshal(p.SmartRules); err == nil {
+			pb.SmartRules = string(data)
+		}
+	}
+	return pb
+}
+
+// domainFavoriteToProto 转换收藏（歌曲、专辑、歌手、MV或上游歌单）
+func domainFavoriteToProto(f *domain.Favorite) *userv1.Favorite {
+	return &userv1.Favorite{
+		Id:       f.ID,
+		UserId:   f.UserID,
+		Type:     favoriteTypeToProto(f.Type),
+		TargetId: f.SongID,
+		Metadata: &userv1.FavoriteMetadata{
+			Name:     f.SongName,
+			Artist:   f.SingerName,
+			CoverUrl: f.CoverURL,
+			Extra:    f.Extra,
+		},
+		CreatedAt: timestamppb.New(f.CreatedAt),
+	}
+}
+
+// favoriteTypeProto 收藏类型与proto枚举的对应关系（歌手在proto中为ARTIST）
+var favoriteTypeProto = map[string]userv1.FavoriteType{
+	domain.FavoriteTypeSong:     userv1.FavoriteType_FAVORITE_TYPE_SONG,
+	domain.FavoriteTypeAlbum:    userv1.FavoriteType_FAVORITE_TYPE_ALBUM,
+	domain.FavoriteTypeSinger:   userv1.FavoriteType_FAVORITE_TYPE_ARTIST,
+	domain.FavoriteTypeMV:       userv1.FavoriteType_FAVORITE_TYPE_MV,
+	domain.FavoriteTypePlaylist: userv1.FavoriteType_FAVORITE_TYPE_PLAYLIST,
+}
+
+// favoriteTypeToProto 将收藏类型转换为proto枚举，历史数据没有类型时视为歌曲
+func favoriteTypeToProto(favoriteType string) userv1.FavoriteType {
+	if t, ok := favoriteTypeProto[favoriteType]; ok {
+		return t
+	}
+	return userv1.FavoriteType_FAVORITE_TYPE_SONG
+}
+
+// favoriteTypeFromProto 将proto枚举转换为收藏类型，UNSPECIFIED或未知值返回false
+func favoriteTypeFromProto(t userv1.FavoriteType) (string, bool) {
+	for favoriteType, pt := range favoriteTypeProto {
+		if pt == t {
+			return favoriteType, true
+		}
+	}
+	return "", false
+}
+
+// domainPlaylistsToProto 批量转换歌单
+func domainPlaylistsToProto(playlists []*domain.UserPlaylist) []*userv1.Playlist {
+	result := make([]*userv1.Playlist, 0, len(playlists))
+	for _, p := range playlists {
+		result = append(result, domainPlaylistToProto(p))
+	}
+	return result
+}
+
+// normalizePage 规范化分页参数（默认每页20条，最多100条）
+func normalizePage(page, pageSize int32) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+	return int(page), int(pageSize)
+}
+
+// parseSmartRules 解析JSON格式的智能歌单规则
+func parseSmartRules(raw string) (*domain.SmartPlaylistRules, error) {
+	var rules domain.SmartPlaylistRules
+	if err := json.Unmarshal([]byte(raw), &rules); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid smart_rules: %v", err)
+	}
+	return &rules, nil
+}
+
+// smartPlaylistError 将智能歌单错误映射为gRPC状态码
+func smartPlaylistError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrUnauthorized):
+		return status.Errorf(codes.PermissionDenied, "not authorized")
+	case errors.Is(err, domain.ErrInvalidSmartRules),
+		errors.Is(err, domain.ErrNotSmartPlaylist):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "failed to update smart rules: %v", err)
+	}
+}
+
+// domainPlaylistSongToProto 将domain歌单歌曲转换为proto消息
+func domainPlaylistSongToProto(s *domain.PlaylistSong) *userv1.PlaylistSong {
+	return &userv1.PlaylistSong{
+		PlaylistId: s.PlaylistID,
+		SongId:     s.SongID,
+		Position:   int32(s.Position),
+		AddedAt:    timestamppb.New(s.AddedAt),
+		SongName:   s.SongName,
+		ArtistName: s.SingerName,
+		AddedBy:    s.AddedBy,
+	}
+}
+
+// GetListeningStats 获取日期范围内的听歌统计
+func (s *UserServer) GetListeningStats(ctx context.Context, req *userv1.GetListeningStatsRequest) (*userv1.GetListeningStatsResponse, error) {
+	from, err := s.statsService.ParseDate(req.FromDate)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid from_date: %v", err)
+	}
+	to, err := s.statsService.ParseDate(req.ToDate)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid to_date: %v", err)
+	}
+
+	summary, err := s.statsService.GetListeningStats(ctx, req.UserId, from, to, int(req.TopLimit))
+	if err != nil {
+		return nil, statsError(err)
+	}
+
+	daily := make([]*userv1.DailyListening, 0, len(summary.Daily))
+	for _, d := range summary.Daily {
+		daily = append(daily, &userv1.DailyListening{
+			Date:            d.Day.Format(time.DateOnly),
+			PlayCount:       d.PlayCount,
+			SecondsListened: d.SecondsListened,
+		})
+	}
+
+	return &userv1.GetListeningStatsResponse{
+		Summary: listeningSummaryToProto(summary.PlayCount, summary.SecondsListened, summary.TopSongs, summary.TopSingers, summary.Heatmap),
+		Daily:   daily,
+	}, nil
+}
+
+// GetYearInReview 获取年度听歌报告
+func (s *UserServer) GetYearInReview(ctx context.Context, req *userv1.GetYearInReviewRequest) (*userv1.GetYearInReviewResponse, error) {
+	review, err := s.statsService.GetYearInReview(ctx, req.UserId, int(req.Year), int(req.TopLimit))
+	if err != nil {
+		return nil, statsError(err)
+	}
+
+	months := make([]*userv1.MonthlyListening, 0, len(review.Months))
+	for _, m := range review.Months {
+		months = append(months, &userv1.MonthlyListening{
+			Month:           m.Month.Format("2006-01"),
+			PlayCount:       m.PlayCount,
+			SecondsListened: m.SecondsListened,
+		})
+	}
+
+	resp := &userv1.GetYearInReviewResponse{
+		Year:            int32(review.Year),
+		Summary:         listeningSummaryToProto(review.PlayCount, review.SecondsListened, review.TopSongs, review.TopSingers, review.Heatmap),
+		ListeningDays:   review.ListeningDays,
+		DistinctSongs:   review.DistinctSongs,
+		DistinctSingers: review.DistinctSingers,
+		PeakHour:        int32(review.PeakHour),
+		PeakWeekday:     int32(review.PeakWeekday),
+		Months:          months,
+	}
+	if review.TopMonth != nil {
+		resp.TopMonth = review.TopMonth.Format("2006-01")
+	}
+	return resp, nil
+}
+
+// GetRecommendations 获取个性化推荐
+func (s *UserServer) GetRecommendations(ctx context.Context, req *userv1.GetRecommendationsRequest) (*userv1.GetRecommendationsResponse, error) {
+	recs, err := s.recsService.GetRecommendations(ctx, req.UserId)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidUserID) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get recommendations: %v", err)
+	}
+
+	rows := make([]*userv1.RecommendationRow, 0, len(recs.BecauseYouLiked))
+	for _, row := range recs.BecauseYouLiked {
+		rows = append(rows, &userv1.RecommendationRow{
+			SeedSongId:     row.SeedSongID,
+			SeedSongName:   row.SeedSongName,
+			SeedSingerName: row.SeedSingerName,
+			Songs:          recommendedSongsToProto(row.Songs),
+		})
+	}
+
+	return &userv1.GetRecommendationsResponse{
+		DailyMix:        recommendedSongsToProto(recs.DailyMix),
+		BecauseYouLiked: rows,
+		GeneratedAt:     timestamppb.New(recs.GeneratedAt),
+	}, nil
+}
+
+// recommendedSongsToProto 将推荐歌曲转换为proto消息
+func recommendedSongsToProto(songs []*domain.RecommendedSong) []*userv1.RecommendedSong {
+	result := make([]*userv1.RecommendedSong, 0, len(songs))
+	for _, song := range songs {
+		result = append(result, &userv1.RecommendedSong{
+			SongId:     song.SongID,
+			SongName:   song.SongName,
+			SingerName: song.SingerName,
+			Score:      song.Score,
+			Source:     song.Source,
+		})
+	}
+	return result
+}
+
+// listeningSummaryToProto 将听歌统计转换为proto消息
+func listeningSummaryToProto(plays, seconds int64, songs []*domain.SongListening, singers []*domain.SingerListening, heatmap []int64) *userv1.ListeningSummary {
+	summary := &userv1.ListeningSummary{
+		PlayCount:       plays,
+		SecondsListened: seconds,
+		MinutesListened: seconds / 60,
+		TopSongs:        make([]*userv1.TopSong, 0, len(songs)),
+		TopSingers:      make([]*userv1.TopSinger, 0, len(singers)),
+		Heatmap:         heatmap,
+	}
+	for _, song := range songs {
+		summary.TopSongs = append(summary.TopSongs, &userv1.TopSong{
+			SongId:          song.SongID,
+			SongName:        song.SongName,
+			SingerName:      song.SingerName,
+			AlbumCover:      song.AlbumCover,
+			PlayCount:       song.PlayCount,
+			SecondsListened: song.SecondsListened,
+		})
+	}
+	for _, singer := range singers {
+		summary.TopSingers = append(summary.TopSingers, &userv1.TopSinger{
+			SingerName:      singer.SingerName,
+			PlayCount:       singer.PlayCount,
+			SecondsListened: singer.SecondsListened,
+		})
+	}
+	return summary
+}
+
+// statsError 将听歌统计错误映射为gRPC状态码
+func statsError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidUserID),
+		errors.Is(err, domain.ErrInvalidStatsRange),
+		errors.Is(err, domain.ErrInvalidStatsYear):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "failed to get listening stats: %v", err)
+	}
+}
+
+// ListNewReleases 获取关注歌手的新专辑
+func (s *UserServer) ListNewReleases(ctx context.Context, req *userv1.ListNewReleasesRequest) (*userv1.ListNewReleasesResponse, error) {
+	page, pageSize := normalizePage(req.Page, req.PageSize)
+	releases, total, err := s.releaseService.ListNewReleases(ctx, req.UserId, page, pageSize)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list new releases: %v", err)
+	}
+
+	result := make([]*userv1.NewRelease, 0, len(releases))
+	for _, release := range releases {
+		result = append(result, &userv1.NewRelease{
+			SingerId:     release.SingerID,
+			SingerName:   release.SingerName,
+			AlbumId:      release.AlbumID,
+			AlbumName:    release.AlbumName,
+			CoverUrl:     release.CoverURL,
+			ReleaseDate:  release.ReleaseDate,
+			DiscoveredAt: timestamppb.New(release.DiscoveredAt),
+		})
+	}
+
+	return &userv1.ListNewReleasesResponse{
+		Releases: result,
+		Total:    total,
+	}, nil
+}
+
+// GetPlayQueue 获取播放队列
+func (s *UserServer) GetPlayQueue(ctx context.Context, req *userv1.GetPlayQueueRequest) (*userv1.GetPlayQueueResponse, error) {
+	queue, err := s.playbackService.GetQueue(ctx, req.UserId)
+	if err != nil {
+		return nil, playbackError(err, "failed to get play queue")
+	}
+	return &userv1.GetPlayQueueResponse{Queue: playQueueToProto(queue)}, nil
+}
+
+// SavePlayQueue 替换播放队列
+func (s *UserServer) SavePlayQueue(ctx context.Context, req *userv1.SavePlayQueueRequest) (*userv1.SavePlayQueueResponse, error) {
+	tracks := make([]*domain.QueueTrack, 0, len(req.Tracks))
+	for _, t := range req.Tracks {
+		tracks = append(tracks, &domain.QueueTrack{
+			SongID:     t.SongId,
+			SongName:   t.SongName,
+			SingerName: t.SingerName,
+			Duration:   int(t.Duration),
+		})
+	}
+
+	queue, err := s.playbackService.SaveQueue(ctx, req.UserId, req.DeviceId, &domain.PlayQueue{
+		Tracks:       tracks,
+		CurrentIndex: int(req.CurrentIndex),
+		Shuffle:      req.Shuffle,
+		RepeatMode:   repeatModeFromProto(req.RepeatMode),
+	})
+	if err != nil {
+		return nil, playbackError(err, "failed to save play queue")
+	}
+	return &userv1.SavePlayQueueResponse{Queue: playQueueToProto(queue)}, nil
+}
+
+// SetPlayQueueIndex 切换当前播放的歌曲
+func (s *UserServer) SetPlayQueueIndex(ctx context.Context, req *userv1.SetPlayQueueIndexRequest) (*userv1.SetPlayQueueIndexResponse, error) {
+	queue, err := s.playbackService.SetCurrentIndex(ctx, req.UserId, req.DeviceId, int(req.CurrentIndex))
+	if err != nil {
+		return nil, playbackError(err, "failed to set play queue index")
+	}
+	return &userv1.SetPlayQueueIndexResponse{Queue: playQueueToProto(queue)}, nil
+}
+
+// UpdatePlaybackPosition 上报播放进度
+func (s *UserServer) UpdatePlaybackPosition(ctx context.Context, req *userv1.UpdatePlaybackPositionRequest) (*userv1.UpdatePlaybackPositionResponse, error) {
+	position, err := s.playbackService.UpdatePosition(ctx, req.UserId, req.DeviceId, &domain.PlaybackPosition{
+		SongID:     req.SongId,
+		PositionMs: req.PositionMs,
+		DurationMs: req.DurationMs,
+	})
+	if err != nil {
+		return nil, playbackError(err, "failed to update playback position")
+	}
+	return &userv1.UpdatePlaybackPositionResponse{Position: playbackPositionToProto(position)}, nil
+}
+
+// GetPlaybackPositions 批量获取播放进度
+func (s *UserServer) GetPlaybackPositions(ctx context.Context, req *userv1.GetPlaybackPositionsRequest) (*userv1.GetPlaybackPositionsResponse, error) {
+	positions, err := s.playbackService.GetPositions(ctx, req.UserId, req.SongIds)
+	if err != nil {
+		return nil, playbackError(err, "failed to get playback positions")
+	}
+
+	result := make([]*userv1.PlaybackPosition, 0, len(positions))
+	for _, position := range positions {
+		result = append(result, playbackPositionToProto(position))
+	}
+	return &userv1.GetPlaybackPositionsResponse{Positions: result}, nil
+}
+
+// playbackError 把播放队列和播放进度相关的domain错误映射为gRPC状态码
+func playbackError(err error, msg string) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidUserID),
+		errors.Is(err, domain.ErrInvalidSongID),
+		errors.Is(err, domain.ErrPlayQueueTooLarge),
+		errors.Is(err, domain.ErrInvalidQueueIndex),
+		errors.Is(err, domain.ErrInvalidRepeatMode),
+		errors.Is(err, domain.ErrInvalidPlayPosition):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", msg, err)
+	}
+}
+
+// playQueueToProto 将播放队列转换为proto消息
+func playQueueToProto(queue *domain.PlayQueue) *userv1.PlayQueue {
+	tracks := make([]*userv1.QueueTrack, 0, len(queue.Tracks))
+	for _, t := range queue.Tracks {
+		tracks = append(tracks, &userv1.QueueTrack{
+			SongId:     t.SongID,
+			SongName:   t.SongName,
+			SingerName: t.SingerName,
+			Duration:   int32(t.Duration),
+		})
+	}
+
+	pb := &userv1.PlayQueue{
+		Tracks:       tracks,
+		CurrentIndex: int32(queue.CurrentIndex),
+		Shuffle:      queue.Shuffle,
+		RepeatMode:   repeatModeToProto(queue.RepeatMode),
+		DeviceId:     queue.DeviceID,
+	}
+	if !queue.UpdatedAt.IsZero() {
+		pb.UpdatedAt = timestamppb.New(queue.UpdatedAt)
+	}
+	return pb
+}
+
+// playbackPositionToProto 将播放进度转换为proto消息
+func playbackPositionToProto(position *domain.PlaybackPosition) *userv1.PlaybackPosition {
+	return &userv1.PlaybackPosition{
+		SongId:     position.SongID,
+		PositionMs: position.PositionMs,
+		DurationMs: position.DurationMs,
+		DeviceId:   position.DeviceID,
+		UpdatedAt:  timestamppb.New(position.UpdatedAt),
+	}
+}
+
+// repeatModeToProto 将循环模式转换为proto枚举
+func repeatModeToProto(mode string) userv1.RepeatMode {
+	switch mode {
+	case domain.RepeatModeAll:
+		return userv1.RepeatMode_REPEAT_MODE_ALL
+	case domain.RepeatModeOne:
+		return userv1.RepeatMode_REPEAT_MODE_ONE
+	default:
+		return userv1.RepeatMode_REPEAT_MODE_OFF
+	}
+}
+
+// repeatModeFromProto 将proto枚举转换为循环模式，未知值交给领域层校验
+func repeatModeFromProto(mode userv1.RepeatMode) string {
+	switch mode {
+	case userv1.RepeatMode_REPEAT_MODE_UNSPECIFIED, userv1.RepeatMode_REPEAT_MODE_OFF:
+		return domain.RepeatModeOff
+	case userv1.RepeatMode_REPEAT_MODE_ALL:
+		return domain.RepeatModeAll
+	case userv1.RepeatMode_REPEAT_MODE_ONE:
+		return domain.RepeatModeOne
+	default:
+		return mode.String()
 	}
 }
@@ -16,30 +16,56 @@ func handleError(c *gin.Context, err error) {
 	case errors.Is(err, domain.ErrFavoriteNotFound),
 		errors.Is(err, domain.ErrHistoryNotFound),
 		errors.Is(err, domain.ErrPlaylistNotFound),
-		errors.Is(err, domain.ErrSongNotInPlaylist):
+		errors.Is(err, domain.ErrSongNotInPlaylist),
+		errors.Is(err, domain.ErrMemberNotFound),
+		errors.Is(err, domain.ErrInviteNotFound):
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 
 	// 409 Conflict
 	case errors.Is(err, domain.ErrFavoriteAlreadyExists),
 		errors.Is(err, domain.ErrPlaylistAlreadyExists),
-		errors.Is(err, domain.ErrSongAlreadyInPlaylist):
+		errors.Is(err, domain.ErrSongAlreadyInPlaylist),
+		errors.Is(err, domain.ErrSmartPlaylistReadOnly),
+		errors.Is(err, domain.ErrTooManyMembers):
 		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 
+	// 410 Gone
+	case errors.Is(err, domain.ErrInviteExpired):
+		c.JSON(http.StatusGone, gin.H{"error": err.Error()})
+
 	// 400 Bad Request
 	case errors.Is(err, domain.ErrInvalidUserID),
 		errors.Is(err, domain.ErrInvalidSongID),
 		errors.Is(err, domain.ErrInvalidSongName),
+		errors.Is(err, domain.ErrInvalidFavoriteType),
 		errors.Is(err, domain.ErrInvalidDuration),
 		errors.Is(err, domain.ErrInvalidPlaylistID),
 		errors.Is(err, domain.ErrInvalidPlaylistName),
 		errors.Is(err, domain.ErrPlaylistNameTooLong),
 		errors.Is(err, domain.ErrPlaylistDescriptionTooLong),
-		errors.Is(err, domain.ErrInvalidPosition):
+		errors.Is(err, domain.ErrInvalidPosition),
+		errors.Is(err, domain.ErrInvalidSmartRules),
+		errors.Is(err, domain.ErrNotSmartPlaylist),
+		errors.Is(err, domain.ErrInvalidExportFormat),
+		errors.Is(err, domain.ErrTooManyImportSongs),
+		errors.Is(err, domain.ErrTooManyBatchSongs),
+		errors.Is(err, domain.ErrInvalidPlaylistSongSort),
+		errors.Is(err, domain.ErrInvalidMemberRole),
+		errors.Is(err, domain.ErrCannotInviteOwner),
+		errors.Is(err, domain.ErrCannotFollowOwnPlaylist),
+		errors.Is(err, domain.ErrInvalidPlaylistSort),
+		errors.Is(err, domain.ErrInvalidStatsRange),
+		errors.Is(err, domain.ErrInvalidStatsYear),
+		errors.Is(err, domain.ErrPlayQueueTooLarge),
+		errors.Is(err, domain.ErrInvalidQueueIndex),
+		errors.Is(err, domain.ErrInvalidRepeatMode),
+		errors.Is(err, domain.ErrInvalidPlayPosition):
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 
 	// 403 Forbidden
 	case errors.Is(err, domain.ErrUnauthorized),
-		errors.Is(err, domain.ErrForbidden):
+		errors.Is(err, domain.ErrForbidden),
+		errors.Is(err, domain.ErrPlaylistNotPublic):
 		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 
 	// 500 Internal Server Error (默认)
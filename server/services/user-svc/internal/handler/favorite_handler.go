@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"user-svc/internal/domain"
 	"user-svc/internal/service"
 
 	"github.com/gin-gonic/gin"
@@ -25,10 +26,14 @@ func NewFavoriteHandler(service *service.FavoriteService) *FavoriteHandler {
 func (h *FavoriteHandler) AddFavorite(c *gin.Context) {
 	userID := c.GetString("user_id")
 
+	// type为空时视为歌曲；非歌曲收藏同样使用song_id/song_name/singer_name传目标ID、名称和歌手
 	var req struct {
-		SongID     string `json:"song_id" binding:"required"`
-		SongName   string `json:"song_name" binding:"required"`
-		SingerName string `json:"singer_name" binding:"required"`
+		Type       string            `json:"type"`
+		SongID     string            `json:"song_id" binding:"required"`
+		SongName   string            `json:"song_name" binding:"required"`
+		SingerName string            `json:"singer_name"`
+		CoverURL   string            `json:"cover_url"`
+		Extra      map[string]string `json:"extra"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -36,7 +41,14 @@ func (h *FavoriteHandler) AddFavorite(c *gin.Context) {
 		return
 	}
 
-	favorite, err := h.service.AddFavorite(c.Request.Context(), userID, req.SongID, req.SongName, req.SingerName)
+	favorite, err := h.service.AddFavorite(c.Request.Context(), userID, &domain.Favorite{
+		Type:       req.Type,
+		SongID:     req.SongID,
+		SongName:   req.SongName,
+		SingerName: req.SingerName,
+		CoverURL:   req.CoverURL,
+		Extra:      req.Extra,
+	})
 	if err != nil {
 		handleError(c, err)
 		return
@@ -58,22 +70,30 @@ func (h *FavoriteHandler) RemoveFavorite(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "removed successfully"})
 }
 
-// GetFavorites 获取收藏列表
+// GetFavorites 获取收藏列表，可通过type按类型过滤，type_counts为各类型的收藏数量
 func (h *FavoriteHandler) GetFavorites(c *gin.Context) {
 	userID := c.GetString("user_id")
+	favoriteType := c.Query("type")
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
 
-	favorites, total, err := h.service.GetFavorites(c.Request.Context(), userID, page, pageSize)
+	favorites, total, err := h.service.GetFavorites(c.Request.Context(), userID, favoriteType, page, pageSize)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	typeCounts, err := h.service.CountByType(c.Request.Context(), userID)
 	if err != nil {
 		handleError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"data":  favorites,
-		"total": total,
-		"page":  page,
+		"data":        favorites,
+		"total":       total,
+		"page":        page,
+		"type_counts": typeCounts,
 	})
 }
 
@@ -87,7 +107,7 @@ func (h *FavoriteHandler) IsFavorite(c *gin.Context) {
 		return
 	}
 
-	exists, err := h.service.IsFavorite(c.Request.Context(), userID, songID)
+	exists, err := h.service.IsFavorite(c.Request.Context(), userID, c.Query("type"), songID)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"user-svc/internal/domain"
+	"user-svc/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PlaybackHandler 播放队列和播放进度处理器
+// 设备ID通过X-Device-ID请求头传递，推送时其他设备据此区分变更来源
+type PlaybackHandler struct {
+	service *service.PlaybackService
+}
+
+// NewPlaybackHandler 创建播放队列和播放进度处理器
+func NewPlaybackHandler(service *service.PlaybackService) *PlaybackHandler {
+	return &PlaybackHandler{service: service}
+}
+
+// GetQueue 获取播放队列
+func (h *PlaybackHandler) GetQueue(c *gin.Context) {
+	userID := c.GetString("user_id")
+
+	queue, err := h.service.GetQueue(c.Request.Context(), userID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, queue)
+}
+
+// SaveQueue 替换播放队列
+func (h *PlaybackHandler) SaveQueue(c *gin.Context) {
+	userID := c.GetString("user_id")
+
+	var req struct {
+		Tracks       []*domain.QueueTrack `json:"tracks"`
+		CurrentIndex int                  `json:"current_index"`
+		Shuffle      bool                 `json:"shuffle"`
+		RepeatMode   string               `json:"repeat_mode"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	queue, err := h.service.SaveQueue(c.Request.Context(), userID, c.GetHeader("X-Device-ID"), &domain.PlayQueue{
+		Tracks:       req.Tracks,
+		CurrentIndex: req.CurrentIndex,
+		Shuffle:      req.Shuffle,
+		RepeatMode:   req.RepeatMode,
+	})
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, queue)
+}
+
+// SetCurrentIndex 切换当前播放的歌曲
+func (h *PlaybackHandler) SetCurrentIndex(c *gin.Context) {
+	userID := c.GetString("user_id")
+
+	var req struct {
+		CurrentIndex *int `json:"current_index" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	queue, err := h.service.SetCurrentIndex(c.Request.Context(), userID, c.GetHeader("X-Device-ID"), *req.CurrentIndex)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, queue)
+}
+
+// UpdatePosition 上报歌曲播放进度
+func (h *PlaybackHandler) UpdatePosition(c *gin.Context) {
+	userID := c.GetString("user_id")
+
+	var req struct {
+		PositionMs int64 `json:"position_ms"`
+		DurationMs int64 `json:"duration_ms"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	position, err := h.service.UpdatePosition(c.Request.Context(), userID, c.GetHeader("X-Device-ID"), &domain.PlaybackPosition{
+		SongID:     c.Param("song_id"),
+		PositionMs: req.PositionMs,
+		DurationMs: req.DurationMs,
+	})
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, position)
+}
+
+// GetPositions 获取歌曲播放进度，song_ids为逗号分隔的歌曲ID
+func (h *PlaybackHandler) GetPositions(c *gin.Context) {
+	userID := c.GetString("user_id")
+
+	var songIDs []string
+	for _, songID := range strings.Split(c.Query("song_ids"), ",") {
+		if songID = strings.TrimSpace(songID); songID != "" {
+			songIDs = append(songIDs, songID)
+		}
+	}
+
+	positions, err := h.service.GetPositions(c.Request.Context(), userID, songIDs)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": positions})
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"user-svc/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PlaylistDiscoveryHandler 公开歌单发现处理器
+type PlaylistDiscoveryHandler struct {
+	service *service.PlaylistDiscoveryService
+}
+
+// NewPlaylistDiscoveryHandler 创建公开歌单发现处理器
+func NewPlaylistDiscoveryHandler(service *service.PlaylistDiscoveryService) *PlaylistDiscoveryHandler {
+	return &PlaylistDiscoveryHandler{service: service}
+}
+
+// ListPublicPlaylists 浏览公开歌单（sort=popular|recent）
+func (h *PlaylistDiscoveryHandler) ListPublicPlaylists(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	sortBy := c.Query("sort")
+
+	playlists, total, err := h.service.ListPublicPlaylists(c.Request.Context(), sortBy, page, pageSize)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  playlists,
+		"total": total,
+		"page":  page,
+	})
+}
+
+// GetPublicPlaylist 获取公开歌单详情
+func (h *PlaylistDiscoveryHandler) GetPublicPlaylist(c *gin.Context) {
+	userID := c.GetString("user_id")
+	playlistID := c.Param("id")
+
+	detail, err := h.service.GetPublicPlaylist(c.Request.Context(), playlistID, userID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, detail)
+}
+
+// FollowPlaylist 关注公开歌单
+func (h *PlaylistDiscoveryHandler) FollowPlaylist(c *gin.Context) {
+	userID := c.GetString("user_id")
+	playlistID := c.Param("id")
+
+	playlist, err := h.service.FollowPlaylist(c.Request.Context(), playlistID, userID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, playlist)
+}
+
+// UnfollowPlaylist 取消关注歌单
+func (h *PlaylistDiscoveryHandler) UnfollowPlaylist(c *gin.Context) {
+	userID := c.GetString("user_id")
+	playlistID := c.Param("id")
+
+	if err := h.service.UnfollowPlaylist(c.Request.Context(), playlistID, userID); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "unfollowed successfully"})
+}
+
+// ListFollowedPlaylists 获取用户关注的歌单
+func (h *PlaylistDiscoveryHandler) ListFollowedPlaylists(c *gin.Context) {
+	userID := c.GetString("user_id")
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	playlists, total, err := h.service.GetFollowedPlaylists(c.Request.Context(), userID, page, pageSize)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  playlists,
+		"total": total,
+		"page":  page,
+	})
+}
+
+// ForkPlaylist 复制歌单到自己的歌单
+func (h *PlaylistDiscoveryHandler) ForkPlaylist(c *gin.Context) {
+	userID := c.GetString("user_id")
+	playlistID := c.Param("id")
+
+	var req struct {
+		Name string `json:"name"`
+	}
+	// 请求体可选，name为空时沿用来源歌单名称
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	playlist, err := h.service.ForkPlaylist(c.Request.Context(), playlistID, userID, req.Name)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, playlist)
+}
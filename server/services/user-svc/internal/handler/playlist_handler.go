@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"user-svc/internal/domain"
 	"user-svc/internal/service"
 
 	"github.com/gin-gonic/gin"
@@ -21,15 +22,16 @@ func NewPlaylistHandler(service *service.PlaylistService) *PlaylistHandler {
 	}
 }
 
-// CreatePlaylist 创建歌单
+// CreatePlaylist 创建歌单（带smart_rules时创建智能歌单）
 func (h *PlaylistHandler) CreatePlaylist(c *gin.Context) {
 	userID := c.GetString("user_id")
 
 	var req struct {
-		Name        string `json:"name" binding:"required"`
-		Description string `json:"description"`
-		CoverURL    string `json:"cover_url"`
-		IsPublic    bool   `json:"is_public"`
+		Name        string                     `json:"name" binding:"required"`
+		Description string                     `json:"description"`
+		CoverURL    string                     `json:"cover_url"`
+		IsPublic    bool                       `json:"is_public"`
+		SmartRules  *domain.SmartPlaylistRules `json:"smart_rules"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -37,7 +39,15 @@ func (h *PlaylistHandler) CreatePlaylist(c *gin.Context) {
 		return
 	}
 
-	playlist, err := h.service.CreatePlaylist(c.Request.Context(), userID, req.Name, req.Description, req.CoverURL, req.IsPublic)
+	var (
+		playlist *domain.UserPlaylist
+		err      error
+	)
+	if req.SmartRules != nil {
+		playlist, err = h.service.CreateSmartPlaylist(c.Request.Context(), userID, req.Name, req.Description, req.CoverURL, req.IsPublic, req.SmartRules)
+	} else {
+		playlist, err = h.service.CreatePlaylist(c.Request.Context(), userID, req.Name, req.Description, req.CoverURL, req.IsPublic)
+	}
 	if err != nil {
 		handleError(c, err)
 		return
@@ -46,11 +56,12 @@ func (h *PlaylistHandler) CreatePlaylist(c *gin.Context) {
 	c.JSON(http.StatusOK, playlist)
 }
 
-// GetPlaylist 获取歌单详情
+// GetPlaylist 获取歌单详情（私有歌单只有所有者和协作成员可以查看）
 func (h *PlaylistHandler) GetPlaylist(c *gin.Context) {
+	userID := c.GetString("user_id")
 	playlistID := c.Param("id")
 
-	playlist, err := h.service.GetPlaylist(c.Request.Context(), playlistID)
+	playlist, err := h.service.GetPlaylistForUser(c.Request.Context(), playlistID, userID)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -104,6 +115,26 @@ func (h *PlaylistHandler) UpdatePlaylist(c *gin.Context) {
 	c.JSON(http.StatusOK, playlist)
 }
 
+// UpdateSmartRules 更新智能歌单规则
+func (h *PlaylistHandler) UpdateSmartRules(c *gin.Context) {
+	userID := c.GetString("user_id")
+	playlistID := c.Param("id")
+
+	var rules domain.SmartPlaylistRules
+	if err := c.ShouldBindJSON(&rules); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	playlist, err := h.service.UpdateSmartRules(c.Request.Context(), playlistID, userID, &rules)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, playlist)
+}
+
 // DeletePlaylist 删除歌单
 func (h *PlaylistHandler) DeletePlaylist(c *gin.Context) {
 	userID := c.GetString("user_id")
@@ -144,7 +175,7 @@ func (h *PlaylistHandler) AddSongToPlaylist(c *gin.Context) {
 // RemoveSongFromPlaylist 从歌单移除歌曲
 func (h *PlaylistHandler) RemoveSongFromPlaylist(c *gin.Context) {
 	userID := c.GetString("user_id")
-	playlistID := c.Param("playlist_id")
+	playlistID := c.Param("id")
 	songID := c.Param("song_id")
 
 	if err := h.service.RemoveSongFromPlaylist(c.Request.Context(), playlistID, userID, songID); err != nil {
@@ -155,10 +186,124 @@ func (h *PlaylistHandler) RemoveSongFromPlaylist(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "removed successfully"})
 }
 
-// GetPlaylistSongs 获取歌单的歌曲列表
+// AddSongsToPlaylist 批量添加歌曲到歌单末尾，已在歌单中的歌曲会被跳过
+func (h *PlaylistHandler) AddSongsToPlaylist(c *gin.Context) {
+	userID := c.GetString("user_id")
+	playlistID := c.Param("id")
+
+	var req struct {
+		Songs []struct {
+			SongID     string `json:"song_id" binding:"required"`
+			SongName   string `json:"song_name"`
+			SingerName string `json:"singer_name"`
+		} `json:"songs" binding:"required,dive"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	songs := make([]*domain.PlaylistSong, 0, len(req.Songs))
+	for _, song := range req.Songs {
+		songs = append(songs, &domain.PlaylistSong{
+			SongID:     song.SongID,
+			SongName:   song.SongName,
+			SingerName: song.SingerName,
+		})
+	}
+
+	added, err := h.service.AddSongsToPlaylist(c.Request.Context(), playlistID, userID, songs)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"added":   added,
+		"skipped": len(songs) - added,
+	})
+}
+
+// RemoveSongsFromPlaylist 批量移除歌曲，不在歌单中的歌曲会被忽略
+func (h *PlaylistHandler) RemoveSongsFromPlaylist(c *gin.Context) {
+	userID := c.GetString("user_id")
+	playlistID := c.Param("id")
+
+	var req struct {
+		SongIDs []string `json:"song_ids" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	removed, err := h.service.RemoveSongsFromPlaylist(c.Request.Context(), playlistID, userID, req.SongIDs)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"removed": removed})
+}
+
+// MoveSong 把歌曲移动到指定位置（从1开始）
+func (h *PlaylistHandler) MoveSong(c *gin.Context) {
+	userID := c.GetString("user_id")
+	playlistID := c.Param("id")
+	songID := c.Param("song_id")
+
+	var req struct {
+		Position int `json:"position" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.MoveSong(c.Request.Context(), playlistID, userID, songID, req.Position); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "moved successfully"})
+}
+
+// SortSongs 按歌名、歌手或添加时间重排歌单
+func (h *PlaylistHandler) SortSongs(c *gin.Context) {
+	userID := c.GetString("user_id")
+	playlistID := c.Param("id")
+
+	var req struct {
+		SortBy string `json:"sort_by" binding:"required"`
+		Order  string `json:"order" binding:"omitempty,oneof=asc desc"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.SortSongs(c.Request.Context(), playlistID, userID, req.SortBy, req.Order == "desc"); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "sorted successfully"})
+}
+
+// GetPlaylistSongs 获取歌单的歌曲列表（私有歌单只有所有者和协作成员可以查看）
 func (h *PlaylistHandler) GetPlaylistSongs(c *gin.Context) {
+	userID := c.GetString("user_id")
 	playlistID := c.Param("id")
 
+	if _, err := h.service.GetPlaylistForUser(c.Request.Context(), playlistID, userID); err != nil {
+		handleError(c, err)
+		return
+	}
+
 	songs, err := h.service.GetPlaylistSongs(c.Request.Context(), playlistID)
 	if err != nil {
 		handleError(c, err)
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"user-svc/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PlaylistMemberHandler 协作歌单成员处理器
+type PlaylistMemberHandler struct {
+	service         *service.PlaylistMemberService
+	playlistService *service.PlaylistService
+}
+
+// NewPlaylistMemberHandler 创建协作歌单成员处理器
+func NewPlaylistMemberHandler(service *service.PlaylistMemberService, playlistService *service.PlaylistService) *PlaylistMemberHandler {
+	return &PlaylistMemberHandler{
+		service:         service,
+		playlistService: playlistService,
+	}
+}
+
+// ListMembers 获取歌单成员（包括所有者）
+func (h *PlaylistMemberHandler) ListMembers(c *gin.Context) {
+	userID := c.GetString("user_id")
+	playlistID := c.Param("id")
+
+	members, err := h.service.ListMembers(c.Request.Context(), playlistID, userID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": members,
+	})
+}
+
+// PutMember 按用户ID邀请成员或修改成员角色（仅所有者）
+func (h *PlaylistMemberHandler) PutMember(c *gin.Context) {
+	userID := c.GetString("user_id")
+	playlistID := c.Param("id")
+	memberID := c.Param("user_id")
+
+	var req struct {
+		Role string `json:"role" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	member, err := h.service.InviteMember(c.Request.Context(), playlistID, userID, memberID, req.Role)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, member)
+}
+
+// RemoveMember 移除成员（所有者），或成员退出歌单
+func (h *PlaylistMemberHandler) RemoveMember(c *gin.Context) {
+	userID := c.GetString("user_id")
+	playlistID := c.Param("id")
+	memberID := c.Param("user_id")
+
+	if err := h.service.RemoveMember(c.Request.Context(), playlistID, userID, memberID); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "removed successfully"})
+}
+
+// CreateInvite 生成分享链接邀请（仅所有者）
+func (h *PlaylistMemberHandler) CreateInvite(c *gin.Context) {
+	userID := c.GetString("user_id")
+	playlistID := c.Param("id")
+
+	var req struct {
+		Role string `json:"role" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	invite, err := h.service.CreateInviteLink(c.Request.Context(), playlistID, userID, req.Role)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invite)
+}
+
+// RevokeInvites 撤销歌单的所有分享链接（仅所有者）
+func (h *PlaylistMemberHandler) RevokeInvites(c *gin.Context) {
+	userID := c.GetString("user_id")
+	playlistID := c.Param("id")
+
+	revoked, err := h.service.RevokeInviteLinks(c.Request.Context(), playlistID, userID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"revoked": revoked})
+}
+
+// AcceptInvite 通过分享链接加入歌单
+func (h *PlaylistMemberHandler) AcceptInvite(c *gin.Context) {
+	userID := c.GetString("user_id")
+	token := c.Param("token")
+
+	playlist, err := h.service.AcceptInvite(c.Request.Context(), token, userID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, playlist)
+}
+
+// ListSharedPlaylists 获取用户参与协作的歌单列表
+func (h *PlaylistMemberHandler) ListSharedPlaylists(c *gin.Context) {
+	userID := c.GetString("user_id")
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	playlists, err := h.playlistService.GetSharedPlaylists(c.Request.Context(), userID, page, pageSize)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": playlists,
+		"page": page,
+	})
+}
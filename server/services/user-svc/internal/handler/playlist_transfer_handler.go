@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"mime"
+	"net/http"
+
+	"user-svc/internal/domain"
+	"user-svc/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PlaylistTransferHandler 歌单导入导出处理器
+type PlaylistTransferHandler struct {
+	service *service.PlaylistTransferService
+}
+
+// NewPlaylistTransferHandler 创建歌单导入导出处理器
+func NewPlaylistTransferHandler(service *service.PlaylistTransferService) *PlaylistTransferHandler {
+	return &PlaylistTransferHandler{
+		service: service,
+	}
+}
+
+// ImportPlaylist 导入歌单（歌曲需已匹配到曲库，例如本服务导出的JSON文件）
+func (h *PlaylistTransferHandler) ImportPlaylist(c *gin.Context) {
+	userID := c.GetString("user_id")
+
+	var req struct {
+		Name        string `json:"name" binding:"required"`
+		Description string `json:"description"`
+		CoverURL    string `json:"cover_url"`
+		IsPublic    bool   `json:"is_public"`
+		Songs       []struct {
+			SongID     string `json:"song_id" binding:"required"`
+			SongName   string `json:"song_name"`
+			SingerName string `json:"singer_name"`
+		} `json:"songs" binding:"dive"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	songs := make([]*domain.PlaylistSong, 0, len(req.Songs))
+	for _, song := range req.Songs {
+		songs = append(songs, &domain.PlaylistSong{
+			SongID:     song.SongID,
+			SongName:   song.SongName,
+			SingerName: song.SingerName,
+		})
+	}
+
+	result, err := h.service.ImportPlaylist(c.Request.Context(), userID, req.Name, req.Description, req.CoverURL, req.IsPublic, songs)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ExportPlaylist 导出歌单为文件，format为m3u8、xspf或json（默认m3u8）
+func (h *PlaylistTransferHandler) ExportPlaylist(c *gin.Context) {
+	userID := c.GetString("user_id")
+	playlistID := c.Param("id")
+	format := c.DefaultQuery("format", domain.ExportFormatM3U8)
+
+	file, err := h.service.ExportPlaylist(c.Request.Context(), playlistID, userID, format)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": file.Filename}))
+	c.Data(http.StatusOK, file.ContentType, file.Content)
+}
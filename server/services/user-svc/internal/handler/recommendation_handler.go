@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"net/http"
+
+	"user-svc/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RecommendationHandler 个性化推荐处理器
+type RecommendationHandler struct {
+	service *service.RecommendationService
+}
+
+// NewRecommendationHandler 创建个性化推荐处理器
+func NewRecommendationHandler(service *service.RecommendationService) *RecommendationHandler {
+	return &RecommendationHandler{
+		service: service,
+	}
+}
+
+// GetRecommendations 获取每日推荐和"因为你喜欢X"推荐行
+func (h *RecommendationHandler) GetRecommendations(c *gin.Context) {
+	userID := c.GetString("user_id")
+
+	recs, err := h.service.GetRecommendations(c.Request.Context(), userID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, recs)
+}
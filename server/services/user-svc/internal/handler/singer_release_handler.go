@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"user-svc/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SingerReleaseHandler 关注歌手新发行处理器
+type SingerReleaseHandler struct {
+	service *service.SingerReleaseService
+}
+
+// NewSingerReleaseHandler 创建关注歌手新发行处理器
+func NewSingerReleaseHandler(service *service.SingerReleaseService) *SingerReleaseHandler {
+	return &SingerReleaseHandler{service: service}
+}
+
+// ListNewReleases 获取关注歌手的新专辑
+func (h *SingerReleaseHandler) ListNewReleases(c *gin.Context) {
+	userID := c.GetString("user_id")
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	releases, total, err := h.service.ListNewReleases(c.Request.Context(), userID, page, pageSize)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  releases,
+		"total": total,
+		"page":  page,
+	})
+}
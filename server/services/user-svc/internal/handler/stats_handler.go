@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"user-svc/internal/domain"
+	"user-svc/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultStatsRangeDays 未指定日期范围时统计最近30天
+const defaultStatsRangeDays = 30
+
+// StatsHandler 听歌统计处理器
+type StatsHandler struct {
+	service *service.ListeningStatsService
+}
+
+// NewStatsHandler 创建听歌统计处理器
+func NewStatsHandler(service *service.ListeningStatsService) *StatsHandler {
+	return &StatsHandler{
+		service: service,
+	}
+}
+
+// GetListeningStats 获取日期范围内的听歌统计
+// 查询参数: from、to（YYYY-MM-DD，包含当天，默认最近30天）、top（排行数量）
+func (h *StatsHandler) GetListeningStats(c *gin.Context) {
+	userID := c.GetString("user_id")
+	top, _ := strconv.Atoi(c.DefaultQuery("top", "10"))
+
+	to := h.service.Today()
+	if value := c.Query("to"); value != "" {
+		parsed, err := h.service.ParseDate(value)
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 0, -(defaultStatsRangeDays - 1))
+	if value := c.Query("from"); value != "" {
+		parsed, err := h.service.ParseDate(value)
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+		from = parsed
+	}
+
+	summary, err := h.service.GetListeningStats(c.Request.Context(), userID, from, to, top)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+// GetYearInReview 获取年度听歌报告
+// 查询参数: year（默认今年）、top（排行数量）
+func (h *StatsHandler) GetYearInReview(c *gin.Context) {
+	userID := c.GetString("user_id")
+	top, _ := strconv.Atoi(c.DefaultQuery("top", "10"))
+
+	year := h.service.Today().Year()
+	if value := c.Query("year"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			handleError(c, domain.ErrInvalidStatsYear)
+			return
+		}
+		year = parsed
+	}
+
+	review, err := h.service.GetYearInReview(c.Request.Context(), userID, year, top)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"user-svc/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TrashHandler 回收站处理器
+type TrashHandler struct {
+	service *service.TrashService
+}
+
+// NewTrashHandler 创建回收站处理器
+func NewTrashHandler(service *service.TrashService) *TrashHandler {
+	return &TrashHandler{service: service}
+}
+
+// ListDeletedPlaylists 获取最近删除的歌单
+func (h *TrashHandler) ListDeletedPlaylists(c *gin.Context) {
+	userID := c.GetString("user_id")
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	playlists, total, err := h.service.ListDeletedPlaylists(c.Request.Context(), userID, page, pageSize)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":           playlists,
+		"total":          total,
+		"page":           page,
+		"retention_days": int(h.service.Retention().Hours() / 24),
+	})
+}
+
+// ListDeletedFavorites 获取最近删除的收藏
+func (h *TrashHandler) ListDeletedFavorites(c *gin.Context) {
+	userID := c.GetString("user_id")
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	favorites, total, err := h.service.ListDeletedFavorites(c.Request.Context(), userID, page, pageSize)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":           favorites,
+		"total":          total,
+		"page":           page,
+		"retention_days": int(h.service.Retention().Hours() / 24),
+	})
+}
+
+// RestorePlaylist 恢复已删除的歌单（包括歌曲）
+func (h *TrashHandler) RestorePlaylist(c *gin.Context) {
+	userID := c.GetString("user_id")
+	playlistID := c.Param("id")
+
+	playlist, err := h.service.RestorePlaylist(c.Request.Context(), playlistID, userID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, playlist)
+}
+
+// RestoreFavorite 恢复已删除的收藏
+func (h *TrashHandler) RestoreFavorite(c *gin.Context) {
+	userID := c.GetString("user_id")
+	favoriteID := c.Param("id")
+
+	favorite, err := h.service.RestoreFavorite(c.Request.Context(), favoriteID, userID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, favorite)
+}
@@ -0,0 +1,96 @@
+package listener
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+
+	"github.com/listen-stream/server/shared/pkg/syncevent"
+	"github.com/redis/go-redis/v9"
+)
+
+// SmartPlaylistInvalidator 智能歌单缓存失效接口
+type SmartPlaylistInvalidator interface {
+	InvalidateSmartPlaylists(ctx context.Context, userID string)
+}
+
+// SyncListener 订阅sync-svc的用户事件，收藏和播放记录变化时使该用户的智能歌单缓存失效
+// 使用go-redis的PubSub通道，断线后由客户端自动重连并重新订阅
+type SyncListener struct {
+	redis       *redis.Client
+	invalidator SmartPlaylistInvalidator
+	pubsub      *redis.PubSub
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup
+}
+
+// NewSyncListener 创建同步事件监听器
+func NewSyncListener(redisClient *redis.Client, invalidator SmartPlaylistInvalidator) *SyncListener {
+	return &SyncListener{
+		redis:       redisClient,
+		invalidator: invalidator,
+	}
+}
+
+// Start 开始监听
+func (l *SyncListener) Start(ctx context.Context) error {
+	pattern := syncevent.UserChannelPrefix + "*"
+	pubsub := l.redis.PSubscribe(ctx, pattern)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	l.pubsub = pubsub
+	l.cancel = cancel
+
+	l.wg.Add(1)
+	go l.loop(ctx, pubsub.Channel())
+
+	log.Printf("Sync listener started with pattern: %s", pattern)
+	return nil
+}
+
+// Stop 停止监听
+func (l *SyncListener) Stop() {
+	if l.cancel == nil {
+		return
+	}
+	l.cancel()
+	l.pubsub.Close()
+	l.wg.Wait()
+	log.Println("Sync listener stopped")
+}
+
+func (l *SyncListener) loop(ctx context.Context, ch <-chan *redis.Message) {
+	defer l.wg.Done()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			l.handle(ctx, msg.Payload)
+		}
+	}
+}
+
+// handle 处理单条事件，只关心影响智能歌单的收藏和播放事件
+func (l *SyncListener) handle(ctx context.Context, payload string) {
+	var msg syncevent.Message
+	if err := json.Unmarshal([]byte(payload), &msg); err != nil {
+		log.Printf("Failed to decode sync message: %v", err)
+		return
+	}
+
+	switch msg.Type {
+	case syncevent.TypeFavoriteAdded, syncevent.TypeFavoriteRemoved, syncevent.TypeHistoryAdded:
+		if msg.UserID != "" {
+			l.invalidator.InvalidateSmartPlaylists(ctx, msg.UserID)
+		}
+	}
+}
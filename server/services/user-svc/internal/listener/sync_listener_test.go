@@ -0,0 +1,31 @@
+package listener
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingInvalidator struct {
+	userIDs []string
+}
+
+func (r *recordingInvalidator) InvalidateSmartPlaylists(ctx context.Context, userID string) {
+	r.userIDs = append(r.userIDs, userID)
+}
+
+func TestSyncListener_Handle(t *testing.T) {
+	invalidator := &recordingInvalidator{}
+	l := NewSyncListener(nil, invalidator)
+	ctx := context.Background()
+
+	l.handle(ctx, `{"type":"favorite.added","user_id":"u1"}`)
+	l.handle(ctx, `{"type":"history.added","user_id":"u2"}`)
+	l.handle(ctx, `{"type":"favorite.removed","user_id":"u1"}`)
+	l.handle(ctx, `{"type":"playlist.created","user_id":"u3"}`)
+	l.handle(ctx, `{"type":"history.added"}`)
+	l.handle(ctx, `not json`)
+
+	assert.Equal(t, []string{"u1", "u2", "u1"}, invalidator.userIDs)
+}
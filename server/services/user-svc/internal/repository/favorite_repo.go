@@ -2,13 +2,18 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"user-svc/internal/domain"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// favoriteColumns 收藏查询列，顺序与scanFavorite一致
+const favoriteColumns = `id, user_id, target_type, song_id, song_name, singer_name, cover_url, metadata, deleted_at, created_at`
+
 // FavoriteRepositoryImpl 收藏仓储实现
 type FavoriteRepositoryImpl struct {
 	db *pgxpool.Pool
@@ -22,15 +27,18 @@ func NewFavoriteRepository(db *pgxpool.Pool) FavoriteRepository {
 // Create 创建收藏
 func (r *FavoriteRepositoryImpl) Create(ctx context.Context, favorite *domain.Favorite) error {
 	query := `
-		INSERT INTO favorites (id, user_id, song_id, song_name, singer_name, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO favorites (id, user_id, target_type, song_id, song_name, singer_name, cover_url, metadata, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
 	_, err := r.db.Exec(ctx, query,
 		favorite.ID,
 		favorite.UserID,
+		favorite.Type,
 		favorite.SongID,
 		favorite.SongName,
 		favorite.SingerName,
+		favorite.CoverURL,
+		favorite.Extra,
 		favorite.CreatedAt,
 	)
 	return err
@@ -38,91 +46,70 @@ func (r *FavoriteRepositoryImpl) Create(ctx context.Context, favorite *domain.Fa
 
 // GetByID 根据ID获取收藏
 func (r *FavoriteRepositoryImpl) GetByID(ctx context.Context, id string) (*domain.Favorite, error) {
+	query := `SELECT ` + favoriteColumns + ` FROM favorites WHERE id = $1 AND deleted_at IS NULL`
+	return scanFavorite(r.db.QueryRow(ctx, query, id))
+}
+
+// GetByUserAndTarget 根据用户、收藏类型和目标ID获取收藏
+func (r *FavoriteRepositoryImpl) GetByUserAndTarget(ctx context.Context, userID, favoriteType, targetID string) (*domain.Favorite, error) {
 	query := `
-		SELECT id, user_id, song_id, song_name, singer_name, deleted_at, created_at
+		SELECT ` + favoriteColumns + `
 		FROM favorites
-		WHERE id = $1 AND deleted_at IS NULL
+		WHERE user_id = $1 AND target_type = $2 AND song_id = $3 AND deleted_at IS NULL
 	`
-	var favorite domain.Favorite
-	err := r.db.QueryRow(ctx, query, id).Scan(
-		&favorite.ID,
-		&favorite.UserID,
-		&favorite.SongID,
-		&favorite.SongName,
-		&favorite.SingerName,
-		&favorite.DeletedAt,
-		&favorite.CreatedAt,
-	)
-	if err != nil {
-		return nil, err
-	}
-	return &favorite, nil
+	return scanFavorite(r.db.QueryRow(ctx, query, userID, favoriteType, targetID))
 }
 
-// GetByUserAndSong 根据用户和歌曲ID获取收藏
-func (r *FavoriteRepositoryImpl) GetByUserAndSong(ctx context.Context, userID, songID string) (*domain.Favorite, error) {
+// ListByUser 获取用户的收藏列表，favoriteType为空时返回全部类型
+func (r *FavoriteRepositoryImpl) ListByUser(ctx context.Context, userID, favoriteType string, limit, offset int) ([]*domain.Favorite, error) {
 	query := `
-		SELECT id, user_id, song_id, song_name, singer_name, deleted_at, created_at
+		SELECT ` + favoriteColumns + `
 		FROM favorites
-		WHERE user_id = $1 AND song_id = $2 AND deleted_at IS NULL
+		WHERE user_id = $1 AND deleted_at IS NULL AND ($2::TEXT = '' OR target_type = $2)
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4
 	`
-	var favorite domain.Favorite
-	err := r.db.QueryRow(ctx, query, userID, songID).Scan(
-		&favorite.ID,
-		&favorite.UserID,
-		&favorite.SongID,
-		&favorite.SongName,
-		&favorite.SingerName,
-		&favorite.DeletedAt,
-		&favorite.CreatedAt,
-	)
+	rows, err := r.db.Query(ctx, query, userID, favoriteType, limit, offset)
 	if err != nil {
 		return nil, err
 	}
-	return &favorite, nil
+	return scanFavorites(rows)
 }
 
-// ListByUser 获取用户的收藏列表
-func (r *FavoriteRepositoryImpl) ListByUser(ctx context.Context, userID string, limit, offset int) ([]*domain.Favorite, error) {
+// Count 统计用户的收藏数量，favoriteType为空时统计全部类型
+func (r *FavoriteRepositoryImpl) Count(ctx context.Context, userID, favoriteType string) (int64, error) {
+	query := `SELECT COUNT(*) FROM favorites WHERE user_id = $1 AND deleted_at IS NULL AND ($2::TEXT = '' OR target_type = $2)`
+	var count int64
+	err := r.db.QueryRow(ctx, query, userID, favoriteType).Scan(&count)
+	return count, err
+}
+
+// CountByType 按收藏类型统计用户的收藏数量，没有收藏的类型不在结果中
+func (r *FavoriteRepositoryImpl) CountByType(ctx context.Context, userID string) (map[string]int64, error) {
 	query := `
-		SELECT id, user_id, song_id, song_name, singer_name, deleted_at, created_at
+		SELECT target_type, COUNT(*)
 		FROM favorites
 		WHERE user_id = $1 AND deleted_at IS NULL
-		ORDER BY created_at DESC
-		LIMIT $2 OFFSET $3
+		GROUP BY target_type
 	`
-	rows, err := r.db.Query(ctx, query, userID, limit, offset)
+	rows, err := r.db.Query(ctx, query, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var favorites []*domain.Favorite
+	counts := make(map[string]int64)
 	for rows.Next() {
-		var favorite domain.Favorite
-		err := rows.Scan(
-			&favorite.ID,
-			&favorite.UserID,
-			&favorite.SongID,
-			&favorite.SongName,
-			&favorite.SingerName,
-			&favorite.DeletedAt,
-			&favorite.CreatedAt,
+		var (
+			favoriteType string
+			count        int64
 		)
-		if err != nil {
+		if err := rows.Scan(&favoriteType, &count); err != nil {
 			return nil, err
 		}
-		favorites = append(favorites, &favorite)
+		counts[favoriteType] = count
 	}
-	return favorites, rows.Err()
-}
-
-// Count 统计用户的收藏数量
-func (r *FavoriteRepositoryImpl) Count(ctx context.Context, userID string) (int64, error) {
-	query := `SELECT COUNT(*) FROM favorites WHERE user_id = $1 AND deleted_at IS NULL`
-	var count int64
-	err := r.db.QueryRow(ctx, query, userID).Scan(&count)
-	return count, err
+	return counts, rows.Err()
 }
 
 // SoftDelete 软删除收藏
@@ -132,11 +119,61 @@ func (r *FavoriteRepositoryImpl) SoftDelete(ctx context.Context, id string) erro
 	return err
 }
 
-// Restore 恢复收藏
+// Restore 恢复收藏，收藏不在回收站中时返回ErrFavoriteNotFound
 func (r *FavoriteRepositoryImpl) Restore(ctx context.Context, id string) error {
 	query := `UPDATE favorites SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL`
-	_, err := r.db.Exec(ctx, query, id)
-	return err
+	tag, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrFavoriteNotFound
+	}
+	return nil
+}
+
+// GetDeleted 获取回收站中的收藏，不存在或未删除时返回ErrFavoriteNotFound
+func (r *FavoriteRepositoryImpl) GetDeleted(ctx context.Context, id string) (*domain.Favorite, error) {
+	query := `SELECT ` + favoriteColumns + ` FROM favorites WHERE id = $1 AND deleted_at IS NOT NULL`
+	favorite, err := scanFavorite(r.db.QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrFavoriteNotFound
+	}
+	return favorite, err
+}
+
+// ListDeletedByUser 获取用户在deletedSince之后删除的收藏，最近删除的在前
+func (r *FavoriteRepositoryImpl) ListDeletedByUser(ctx context.Context, userID string, deletedSince time.Time, limit, offset int) ([]*domain.Favorite, error) {
+	query := `
+		SELECT ` + favoriteColumns + `
+		FROM favorites
+		WHERE user_id = $1 AND deleted_at > $2
+		ORDER BY deleted_at DESC
+		LIMIT $3 OFFSET $4
+	`
+	rows, err := r.db.Query(ctx, query, userID, deletedSince, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return scanFavorites(rows)
+}
+
+// CountDeletedByUser 统计用户在deletedSince之后删除的收藏数量
+func (r *FavoriteRepositoryImpl) CountDeletedByUser(ctx context.Context, userID string, deletedSince time.Time) (int64, error) {
+	query := `SELECT COUNT(*) FROM favorites WHERE user_id = $1 AND deleted_at > $2`
+	var count int64
+	err := r.db.QueryRow(ctx, query, userID, deletedSince).Scan(&count)
+	return count, err
+}
+
+// PurgeDeleted 硬删除deletedBefore之前软删除的收藏，返回删除数量
+func (r *FavoriteRepositoryImpl) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	query := `DELETE FROM favorites WHERE deleted_at IS NOT NULL AND deleted_at <= $1`
+	tag, err := r.db.Exec(ctx, query, deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
 }
 
 // HardDelete 硬删除收藏
@@ -146,15 +183,76 @@ func (r *FavoriteRepositoryImpl) HardDelete(ctx context.Context, id string) erro
 	return err
 }
 
-// Exists 检查收藏是否存在
-func (r *FavoriteRepositoryImpl) Exists(ctx context.Context, userID, songID string) (bool, error) {
+// Exists 检查指定类型的目标是否已收藏
+func (r *FavoriteRepositoryImpl) Exists(ctx context.Context, userID, favoriteType, targetID string) (bool, error) {
 	query := `
 		SELECT EXISTS(
 			SELECT 1 FROM favorites
-			WHERE user_id = $1 AND song_id = $2 AND deleted_at IS NULL
+			WHERE user_id = $1 AND target_type = $2 AND song_id = $3 AND deleted_at IS NULL
 		)
 	`
 	var exists bool
-	err := r.db.QueryRow(ctx, query, userID, songID).Scan(&exists)
+	err := r.db.QueryRow(ctx, query, userID, favoriteType, targetID).Scan(&exists)
 	return exists, err
 }
+
+// ListAllByUser 获取用户的全部收藏（包括已软删除的，用于数据导出）
+func (r *FavoriteRepositoryImpl) ListAllByUser(ctx context.Context, userID string) ([]*domain.Favorite, error) {
+	query := `
+		SELECT ` + favoriteColumns + `
+		FROM favorites
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	return scanFavorites(rows)
+}
+
+// DeleteAllByUser 硬删除用户的全部收藏（包括已软删除的，用于注销账号）
+func (r *FavoriteRepositoryImpl) DeleteAllByUser(ctx context.Context, userID string) (int64, error) {
+	query := `DELETE FROM favorites WHERE user_id = $1`
+	tag, err := r.db.Exec(ctx, query, userID)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// scanFavorite 扫描一行收藏（列顺序见favoriteColumns）
+func scanFavorite(row pgx.Row) (*domain.Favorite, error) {
+	var favorite domain.Favorite
+	err := row.Scan(
+		&favorite.ID,
+		&favorite.UserID,
+		&favorite.Type,
+		&favorite.SongID,
+		&favorite.SongName,
+		&favorite.SingerName,
+		&favorite.CoverURL,
+		&favorite.Extra,
+		&favorite.DeletedAt,
+		&favorite.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &favorite, nil
+}
+
+// scanFavorites 扫描多行收藏并关闭rows
+func scanFavorites(rows pgx.Rows) ([]*domain.Favorite, error) {
+	defer rows.Close()
+
+	var favorites []*domain.Favorite
+	for rows.Next() {
+		favorite, err := scanFavorite(rows)
+		if err != nil {
+			return nil, err
+		}
+		favorites = append(favorites, favorite)
+	}
+	return favorites, rows.Err()
+}
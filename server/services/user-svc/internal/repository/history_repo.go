@@ -115,23 +115,10 @@ func (r *PlayHistoryRepositoryImpl) Delete(ctx context.Context, id string) error
 	return err
 }
 
-// DeleteOldest 删除最早的N条记录
-func (r *PlayHistoryRepositoryImpl) DeleteOldest(ctx context.Context, userID string, count int) error {
-	query := `
-		DELETE FROM play_histories
-		WHERE id IN (
-			SELECT id FROM play_histories
-			WHERE user_id = $1
-			ORDER BY played_at ASC
-			LIMIT $2
-		)
-	`
-	_, err := r.db.Exec(ctx, query, userID, count)
-	return err
-}
-
-// Cleanup 清理用户历史记录，保留最新的keepCount条
-func (r *PlayHistoryRepositoryImpl) Cleanup(ctx context.Context, userID string, keepCount int) error {
+// Cleanup 清理用户历史记录，保留最新的keepCount条，返回删除的条数
+// 超出keepCount的记录只删除已汇总到听歌统计的，未汇总的等下次汇总后再删除；
+// 汇总长期失败时，超出hardLimit的记录不论是否已汇总都删除，避免无限增长
+func (r *PlayHistoryRepositoryImpl) Cleanup(ctx context.Context, userID string, keepCount, hardLimit int) (int64, error) {
 	query := `
 		DELETE FROM play_histories
 		WHERE user_id = $1
@@ -141,9 +128,21 @@ func (r *PlayHistoryRepositoryImpl) Cleanup(ctx context.Context, userID string,
 			ORDER BY played_at DESC
 			LIMIT $2
 		)
+		AND (
+			rolled_up_at IS NOT NULL
+			OR id NOT IN (
+				SELECT id FROM play_histories
+				WHERE user_id = $1
+				ORDER BY played_at DESC
+				LIMIT $3
+			)
+		)
 	`
-	_, err := r.db.Exec(ctx, query, userID, keepCount)
-	return err
+	tag, err := r.db.Exec(ctx, query, userID, keepCount, hardLimit)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
 }
 
 // GetAllUserIDs 获取所有有播放历史的用户ID列表
@@ -165,3 +164,13 @@ func (r *PlayHistoryRepositoryImpl) GetAllUserIDs(ctx context.Context) ([]string
 	}
 	return userIDs, rows.Err()
 }
+
+// DeleteAllByUser 删除用户的全部播放历史（用于注销账号）
+func (r *PlayHistoryRepositoryImpl) DeleteAllByUser(ctx context.Context, userID string) (int64, error) {
+	query := `DELETE FROM play_histories WHERE user_id = $1`
+	tag, err := r.db.Exec(ctx, query, userID)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,356 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"user-svc/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ListeningStatsRepositoryImpl 听歌统计仓储实现
+type ListeningStatsRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewListeningStatsRepository 创建听歌统计仓储
+func NewListeningStatsRepository(db *pgxpool.Pool) ListeningStatsRepository {
+	return &ListeningStatsRepositoryImpl{db: db}
+}
+
+const (
+	upsertDailyStatsQuery = `
+		INSERT INTO listening_daily_stats (user_id, day, play_count, seconds_listened, hour_plays, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id, day) DO UPDATE SET
+			play_count = listening_daily_stats.play_count + EXCLUDED.play_count,
+			seconds_listened = listening_daily_stats.seconds_listened + EXCLUDED.seconds_listened,
+			hour_plays = listening_array_add(listening_daily_stats.hour_plays, EXCLUDED.hour_plays),
+			updated_at = EXCLUDED.updated_at
+	`
+	upsertMonthlyStatsQuery = `
+		INSERT INTO listening_monthly_stats (user_id, month, play_count, seconds_listened, heatmap, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id, month) DO UPDATE SET
+			play_count = listening_monthly_stats.play_count + EXCLUDED.play_count,
+			seconds_listened = listening_monthly_stats.seconds_listened + EXCLUDED.seconds_listened,
+			heatmap = listening_array_add(listening_monthly_stats.heatmap, EXCLUDED.heatmap),
+			updated_at = EXCLUDED.updated_at
+	`
+	// %[1]s 表名，%[2]s 周期列（day/month）
+	upsertSongsQuery = `
+		INSERT INTO %[1]s (user_id, %[2]s, song_id, song_name, singer_name, album_cover, play_count, seconds_listened)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (user_id, %[2]s, song_id) DO UPDATE SET
+			song_name = EXCLUDED.song_name,
+			singer_name = EXCLUDED.singer_name,
+			album_cover = EXCLUDED.album_cover,
+			play_count = %[1]s.play_count + EXCLUDED.play_count,
+			seconds_listened = %[1]s.seconds_listened + EXCLUDED.seconds_listened
+	`
+	upsertSingersQuery = `
+		INSERT INTO %[1]s (user_id, %[2]s, singer_name, play_count, seconds_listened)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, %[2]s, singer_name) DO UPDATE SET
+			play_count = %[1]s.play_count + EXCLUDED.play_count,
+			seconds_listened = %[1]s.seconds_listened + EXCLUDED.seconds_listened
+	`
+)
+
+// ListPendingPlays 获取尚未汇总的播放记录（按播放时间升序）
+func (r *ListeningStatsRepositoryImpl) ListPendingPlays(ctx context.Context, limit int) ([]*domain.PlayHistory, error) {
+	query := `
+		SELECT id, user_id, song_id, song_name, singer_name, COALESCE(album_cover, ''), duration, played_at, created_at
+		FROM play_histories
+		WHERE rolled_up_at IS NULL
+		ORDER BY played_at ASC
+		LIMIT $1
+	`
+	rows, err := r.db.Query(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var histories []*domain.PlayHistory
+	for rows.Next() {
+		var history domain.PlayHistory
+		err := rows.Scan(
+			&history.ID,
+			&history.UserID,
+			&history.SongID,
+			&history.SongName,
+			&history.SingerName,
+			&history.AlbumCover,
+			&history.Duration,
+			&history.PlayedAt,
+			&history.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		histories = append(histories, &history)
+	}
+	return histories, rows.Err()
+}
+
+// ApplyRollup 在同一事务中标记播放记录已汇总并累加汇总数据
+// 先标记再累加：并发执行时后到的事务会等待行锁，标记行数不足即回滚，保证每条记录只累加一次
+func (r *ListeningStatsRepositoryImpl) ApplyRollup(ctx context.Context, rollup *domain.ListeningRollup, historyIDs []string, rolledUpAt time.Time) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx,
+		`UPDATE play_histories SET rolled_up_at = $2 WHERE id = ANY($1::uuid[]) AND rolled_up_at IS NULL`,
+		historyIDs, rolledUpAt,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() != int64(len(historyIDs)) {
+		return domain.ErrRollupConflict
+	}
+
+	batch := &pgx.Batch{}
+	for _, d := range rollup.Daily {
+		batch.Queue(upsertDailyStatsQuery, d.UserID, d.Day, d.PlayCount, d.SecondsListened, d.HourPlays, rolledUpAt)
+	}
+	for _, m := range rollup.Monthly {
+		batch.Queue(upsertMonthlyStatsQuery, m.UserID, m.Month, m.PlayCount, m.SecondsListened, m.Heatmap, rolledUpAt)
+	}
+	queueSongs(batch, "listening_daily_songs", "day", rollup.DailySongs)
+	queueSongs(batch, "listening_monthly_songs", "month", rollup.MonthlySongs)
+	queueSingers(batch, "listening_daily_singers", "day", rollup.DailySingers)
+	queueSingers(batch, "listening_monthly_singers", "month", rollup.MonthlySingers)
+
+	if batch.Len() > 0 {
+		results := tx.SendBatch(ctx, batch)
+		for i := 0; i < batch.Len(); i++ {
+			if _, err := results.Exec(); err != nil {
+				results.Close()
+				return err
+			}
+		}
+		if err := results.Close(); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// queueSongs 加入歌曲汇总upsert
+func queueSongs(batch *pgx.Batch, table, periodColumn string, songs []*domain.SongListening) {
+	query := fmt.Sprintf(upsertSongsQuery, table, periodColumn)
+	for _, s := range songs {
+		batch.Queue(query, s.UserID, s.Period, s.SongID, s.SongName, s.SingerName, s.AlbumCover, s.PlayCount, s.SecondsListened)
+	}
+}
+
+// queueSingers 加入歌手汇总upsert
+func queueSingers(batch *pgx.Batch, table, periodColumn string, singers []*domain.SingerListening) {
+	query := fmt.Sprintf(upsertSingersQuery, table, periodColumn)
+	for _, s := range singers {
+		batch.Queue(query, s.UserID, s.Period, s.SingerName, s.PlayCount, s.SecondsListened)
+	}
+}
+
+// ListDaily 获取日期范围内的每日汇总（包含to当天）
+func (r *ListeningStatsRepositoryImpl) ListDaily(ctx context.Context, userID string, from, to time.Time) ([]*domain.DailyListening, error) {
+	query := `
+		SELECT user_id, day, play_count, seconds_listened, hour_plays
+		FROM listening_daily_stats
+		WHERE user_id = $1 AND day >= $2 AND day <= $3
+		ORDER BY day ASC
+	`
+	rows, err := r.db.Query(ctx, query, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var days []*domain.DailyListening
+	for rows.Next() {
+		var d domain.DailyListening
+		if err := rows.Scan(&d.UserID, &d.Day, &d.PlayCount, &d.SecondsListened, &d.HourPlays); err != nil {
+			return nil, err
+		}
+		days = append(days, &d)
+	}
+	return days, rows.Err()
+}
+
+// ListMonthly 获取月份范围内的每月汇总（包含to当月）
+func (r *ListeningStatsRepositoryImpl) ListMonthly(ctx context.Context, userID string, from, to time.Time) ([]*domain.MonthlyListening, error) {
+	query := `
+		SELECT user_id, month, play_count, seconds_listened, heatmap
+		FROM listening_monthly_stats
+		WHERE user_id = $1 AND month >= $2 AND month <= $3
+		ORDER BY month ASC
+	`
+	rows, err := r.db.Query(ctx, query, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var months []*domain.MonthlyListening
+	for rows.Next() {
+		var m domain.MonthlyListening
+		if err := rows.Scan(&m.UserID, &m.Month, &m.PlayCount, &m.SecondsListened, &m.Heatmap); err != nil {
+			return nil, err
+		}
+		months = append(months, &m)
+	}
+	return months, rows.Err()
+}
+
+// TopSongsByDay 日期范围内播放最多的歌曲
+func (r *ListeningStatsRepositoryImpl) TopSongsByDay(ctx context.Context, userID string, from, to time.Time, limit int) ([]*domain.SongListening, error) {
+	return r.topSongs(ctx, "listening_daily_songs", "day", userID, from, to, limit)
+}
+
+// TopSingersByDay 日期范围内播放最多的歌手
+func (r *ListeningStatsRepositoryImpl) TopSingersByDay(ctx context.Context, userID string, from, to time.Time, limit int) ([]*domain.SingerListening, error) {
+	return r.topSingers(ctx, "listening_daily_singers", "day", userID, from, to, limit)
+}
+
+// TopSongsByMonth 月份范围内播放最多的歌曲
+func (r *ListeningStatsRepositoryImpl) TopSongsByMonth(ctx context.Context, userID string, from, to time.Time, limit int) ([]*domain.SongListening, error) {
+	return r.topSongs(ctx, "listening_monthly_songs", "month", userID, from, to, limit)
+}
+
+// TopSingersByMonth 月份范围内播放最多的歌手
+func (r *ListeningStatsRepositoryImpl) TopSingersByMonth(ctx context.Context, userID string, from, to time.Time, limit int) ([]*domain.SingerListening, error) {
+	return r.topSingers(ctx, "listening_monthly_singers", "month", userID, from, to, limit)
+}
+
+// topSongs 按播放次数排序的歌曲排行（歌名等冗余信息取最近一期）
+func (r *ListeningStatsRepositoryImpl) topSongs(ctx context.Context, table, periodColumn, userID string, from, to time.Time, limit int) ([]*domain.SongListening, error) {
+	query := fmt.Sprintf(`
+		SELECT song_id,
+			(array_agg(song_name ORDER BY %[2]s DESC))[1],
+			(array_agg(singer_name ORDER BY %[2]s DESC))[1],
+			(array_agg(COALESCE(album_cover, '') ORDER BY %[2]s DESC))[1],
+			SUM(play_count)::BIGINT AS plays,
+			SUM(seconds_listened)::BIGINT AS seconds
+		FROM %[1]s
+		WHERE user_id = $1 AND %[2]s >= $2 AND %[2]s <= $3
+		GROUP BY song_id
+		ORDER BY plays DESC, seconds DESC, song_id ASC
+		LIMIT $4
+	`, table, periodColumn)
+
+	rows, err := r.db.Query(ctx, query, userID, from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var songs []*domain.SongListening
+	for rows.Next() {
+		s := domain.SongListening{UserID: userID}
+		if err := rows.Scan(&s.SongID, &s.SongName, &s.SingerName, &s.AlbumCover, &s.PlayCount, &s.SecondsListened); err != nil {
+			return nil, err
+		}
+		songs = append(songs, &s)
+	}
+	return songs, rows.Err()
+}
+
+// topSingers 按播放次数排序的歌手排行
+func (r *ListeningStatsRepositoryImpl) topSingers(ctx context.Context, table, periodColumn, userID string, from, to time.Time, limit int) ([]*domain.SingerListening, error) {
+	query := fmt.Sprintf(`
+		SELECT singer_name,
+			SUM(play_count)::BIGINT AS plays,
+			SUM(seconds_listened)::BIGINT AS seconds
+		FROM %[1]s
+		WHERE user_id = $1 AND %[2]s >= $2 AND %[2]s <= $3
+		GROUP BY singer_name
+		ORDER BY plays DESC, seconds DESC, singer_name ASC
+		LIMIT $4
+	`, table, periodColumn)
+
+	rows, err := r.db.Query(ctx, query, userID, from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var singers []*domain.SingerListening
+	for rows.Next() {
+		s := domain.SingerListening{UserID: userID}
+		if err := rows.Scan(&s.SingerName, &s.PlayCount, &s.SecondsListened); err != nil {
+			return nil, err
+		}
+		singers = append(singers, &s)
+	}
+	return singers, rows.Err()
+}
+
+// CountDistinctByMonth 统计月份范围内听过的不同歌曲数和歌手数
+func (r *ListeningStatsRepositoryImpl) CountDistinctByMonth(ctx context.Context, userID string, from, to time.Time) (int64, int64, error) {
+	query := `
+		SELECT
+			(SELECT COUNT(DISTINCT song_id) FROM listening_monthly_songs
+			 WHERE user_id = $1 AND month >= $2 AND month <= $3),
+			(SELECT COUNT(DISTINCT singer_name) FROM listening_monthly_singers
+			 WHERE user_id = $1 AND month >= $2 AND month <= $3)
+	`
+	var songs, singers int64
+	if err := r.db.QueryRow(ctx, query, userID, from, to).Scan(&songs, &singers); err != nil {
+		return 0, 0, err
+	}
+	return songs, singers, nil
+}
+
+// PruneDailyDetails 删除指定日期之前的每日歌曲/歌手明细（每日汇总和每月数据长期保留）
+func (r *ListeningStatsRepositoryImpl) PruneDailyDetails(ctx context.Context, before time.Time) (int64, error) {
+	var deleted int64
+	for _, table := range []string{"listening_daily_songs", "listening_daily_singers"} {
+		tag, err := r.db.Exec(ctx, fmt.Sprintf(`DELETE FROM %s WHERE day < $1`, table), before)
+		if err != nil {
+			return deleted, err
+		}
+		deleted += tag.RowsAffected()
+	}
+	return deleted, nil
+}
+
+// DeleteAllByUser 删除用户的全部听歌统计（用于注销账号）
+func (r *ListeningStatsRepositoryImpl) DeleteAllByUser(ctx context.Context, userID string) (int64, error) {
+	tables := []string{
+		"listening_daily_stats",
+		"listening_daily_songs",
+		"listening_daily_singers",
+		"listening_monthly_stats",
+		"listening_monthly_songs",
+		"listening_monthly_singers",
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var deleted int64
+	for _, table := range tables {
+		tag, err := tx.Exec(ctx, fmt.Sprintf(`DELETE FROM %s WHERE user_id = $1`, table), userID)
+		if err != nil {
+			return 0, err
+		}
+		deleted += tag.RowsAffected()
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
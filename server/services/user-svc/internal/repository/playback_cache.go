@@ -0,0 +1,237 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"user-svc/internal/domain"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	playQueueKeyPrefix     = "playback:queue:" // 每个用户一个String，值为JSON格式的播放队列
+	playPositionsKeyPrefix = "playback:pos:"   // 每个用户一个Hash，field为歌曲ID
+	dirtyQueuesKey         = "playback:dirty:queues"
+	dirtyPositionsKey      = "playback:dirty:positions" // 成员格式为 user_id|song_id
+
+	// playbackCacheTTL 缓存过期时间，每次写入时刷新；过期后从数据库读取
+	playbackCacheTTL = 7 * 24 * time.Hour
+)
+
+// PlaybackCacheImpl 基于Redis的播放队列和播放进度缓存
+// 写入时把用户（或用户和歌曲）加入待回写集合，回写时用SPOP取出，取出后再次写入会重新加入集合
+type PlaybackCacheImpl struct {
+	client *redis.Client
+}
+
+// NewPlaybackCache 创建播放队列和播放进度缓存
+func NewPlaybackCache(client *redis.Client) PlaybackCache {
+	return &PlaybackCacheImpl{client: client}
+}
+
+// SaveQueue 缓存播放队列，dirty为true时标记为待回写
+func (c *PlaybackCacheImpl) SaveQueue(ctx context.Context, queue *domain.PlayQueue, dirty bool) error {
+	data, err := json.Marshal(queue)
+	if err != nil {
+		return fmt.Errorf("marshal play queue: %w", err)
+	}
+
+	pipe := c.client.TxPipeline()
+	pipe.Set(ctx, playQueueKeyPrefix+queue.UserID, data, playbackCacheTTL)
+	if dirty {
+		pipe.SAdd(ctx, dirtyQueuesKey, queue.UserID)
+	}
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// GetQueue 获取缓存的播放队列，第二个返回值表示是否命中
+func (c *PlaybackCacheImpl) GetQueue(ctx context.Context, userID string) (*domain.PlayQueue, bool, error) {
+	data, err := c.client.Get(ctx, playQueueKeyPrefix+userID).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	var queue domain.PlayQueue
+	if err := json.Unmarshal(data, &queue); err != nil {
+		return nil, false, fmt.Errorf("unmarshal play queue: %w", err)
+	}
+	return &queue, true, nil
+}
+
+// SavePosition 缓存播放进度并标记为待回写
+func (c *PlaybackCacheImpl) SavePosition(ctx context.Context, position *domain.PlaybackPosition) error {
+	data, err := json.Marshal(position)
+	if err != nil {
+		return fmt.Errorf("marshal playback position: %w", err)
+	}
+
+	key := playPositionsKeyPrefix + position.UserID
+	pipe := c.client.TxPipeline()
+	pipe.HSet(ctx, key, position.SongID, data)
+	pipe.Expire(ctx, key, playbackCacheTTL)
+	pipe.SAdd(ctx, dirtyPositionsKey, positionMember(position.UserID, position.SongID))
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// GetPositions 获取缓存的播放进度，未缓存的歌曲不在结果中
+func (c *PlaybackCacheImpl) GetPositions(ctx context.Context, userID string, songIDs []string) (map[string]*domain.PlaybackPosition, error) {
+	positions := make(map[string]*domain.PlaybackPosition, len(songIDs))
+	if len(songIDs) == 0 {
+		return positions, nil
+	}
+
+	values, err := c.client.HMGet(ctx, playPositionsKeyPrefix+userID, songIDs...).Result()
+	if err != nil {
+		return nil, err
+	}
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			continue
+		}
+		var position domain.PlaybackPosition
+		if err := json.Unmarshal([]byte(data), &position); err != nil {
+			return nil, fmt.Errorf("unmarshal playback position: %w", err)
+		}
+		positions[songIDs[i]] = &position
+	}
+	return positions, nil
+}
+
+// ListPositions 获取用户缓存的全部播放进度（包括尚未回写的）
+func (c *PlaybackCacheImpl) ListPositions(ctx context.Context, userID string) ([]*domain.PlaybackPosition, error) {
+	values, err := c.client.HGetAll(ctx, playPositionsKeyPrefix+userID).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	positions := make([]*domain.PlaybackPosition, 0, len(values))
+	for _, data := range values {
+		var position domain.PlaybackPosition
+		if err := json.Unmarshal([]byte(data), &position); err != nil {
+			return nil, fmt.Errorf("unmarshal playback position: %w", err)
+		}
+		positions = append(positions, &position)
+	}
+	return positions, nil
+}
+
+// PopDirtyQueues 取出最多count个待回写的播放队列（缓存已过期或已删除的跳过）
+func (c *PlaybackCacheImpl) PopDirtyQueues(ctx context.Context, count int) ([]*domain.PlayQueue, error) {
+	userIDs, err := c.client.SPopN(ctx, dirtyQueuesKey, int64(count)).Result()
+	if err != nil || len(userIDs) == 0 {
+		return nil, err
+	}
+
+	pipe := c.client.Pipeline()
+	cmds := make([]*redis.StringCmd, len(userIDs))
+	for i, userID := range userIDs {
+		cmds[i] = pipe.Get(ctx, playQueueKeyPrefix+userID)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		c.client.SAdd(ctx, dirtyQueuesKey, toInterfaces(userIDs)...)
+		return nil, err
+	}
+
+	queues := make([]*domain.PlayQueue, 0, len(cmds))
+	for _, cmd := range cmds {
+		data, err := cmd.Bytes()
+		if err != nil {
+			continue
+		}
+		var queue domain.PlayQueue
+		if err := json.Unmarshal(data, &queue); err != nil {
+			continue
+		}
+		queues = append(queues, &queue)
+	}
+	return queues, nil
+}
+
+// PopDirtyPositions 取出最多count个待回写的播放进度（缓存已过期或已删除的跳过）
+func (c *PlaybackCacheImpl) PopDirtyPositions(ctx context.Context, count int) ([]*domain.PlaybackPosition, error) {
+	members, err := c.client.SPopN(ctx, dirtyPositionsKey, int64(count)).Result()
+	if err != nil || len(members) == 0 {
+		return nil, err
+	}
+
+	pipe := c.client.Pipeline()
+	cmds := make([]*redis.StringCmd, 0, len(members))
+	for _, member := range members {
+		userID, songID, ok := strings.Cut(member, "|")
+		if !ok {
+			continue
+		}
+		cmds = append(cmds, pipe.HGet(ctx, playPositionsKeyPrefix+userID, songID))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		c.client.SAdd(ctx, dirtyPositionsKey, toInterfaces(members)...)
+		return nil, err
+	}
+
+	positions := make([]*domain.PlaybackPosition, 0, len(cmds))
+	for _, cmd := range cmds {
+		data, err := cmd.Bytes()
+		if err != nil {
+			continue
+		}
+		var position domain.PlaybackPosition
+		if err := json.Unmarshal(data, &position); err != nil {
+			continue
+		}
+		positions = append(positions, &position)
+	}
+	return positions, nil
+}
+
+// MarkQueuesDirty 重新标记播放队列为待回写（回写失败时使用）
+func (c *PlaybackCacheImpl) MarkQueuesDirty(ctx context.Context, userIDs []string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	return c.client.SAdd(ctx, dirtyQueuesKey, toInterfaces(userIDs)...).Err()
+}
+
+// MarkPositionsDirty 重新标记播放进度为待回写（回写失败时使用）
+func (c *PlaybackCacheImpl) MarkPositionsDirty(ctx context.Context, positions []*domain.PlaybackPosition) error {
+	if len(positions) == 0 {
+		return nil
+	}
+	members := make([]interface{}, 0, len(positions))
+	for _, position := range positions {
+		members = append(members, positionMember(position.UserID, position.SongID))
+	}
+	return c.client.SAdd(ctx, dirtyPositionsKey, members...).Err()
+}
+
+// DeleteUser 删除用户的缓存，待回写集合中残留的成员在回写时因缓存不存在而跳过
+func (c *PlaybackCacheImpl) DeleteUser(ctx context.Context, userID string) error {
+	pipe := c.client.TxPipeline()
+	pipe.Del(ctx, playQueueKeyPrefix+userID, playPositionsKeyPrefix+userID)
+	pipe.SRem(ctx, dirtyQueuesKey, userID)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// positionMember 待回写播放进度集合的成员
+func positionMember(userID, songID string) string {
+	return userID + "|" + songID
+}
+
+func toInterfaces(values []string) []interface{} {
+	result := make([]interface{}, len(values))
+	for i, v := range values {
+		result[i] = v
+	}
+	return result
+}
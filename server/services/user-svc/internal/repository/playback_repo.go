@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"user-svc/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PlaybackRepositoryImpl 播放队列和播放进度仓储实现
+// 数据由Redis批量回写，upsert时只接受updated_at更新的数据
+type PlaybackRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewPlaybackRepository 创建播放队列和播放进度仓储
+func NewPlaybackRepository(db *pgxpool.Pool) PlaybackRepository {
+	return &PlaybackRepositoryImpl{db: db}
+}
+
+// GetQueue 获取用户的播放队列，不存在时返回ErrPlayQueueNotFound
+func (r *PlaybackRepositoryImpl) GetQueue(ctx context.Context, userID string) (*domain.PlayQueue, error) {
+	query := `
+		SELECT user_id, tracks, current_index, shuffle, repeat_mode, device_id, updated_at
+		FROM play_queues
+		WHERE user_id = $1
+	`
+	var (
+		queue  domain.PlayQueue
+		tracks []byte
+	)
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&queue.UserID,
+		&tracks,
+		&queue.CurrentIndex,
+		&queue.Shuffle,
+		&queue.RepeatMode,
+		&queue.DeviceID,
+		&queue.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrPlayQueueNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(tracks, &queue.Tracks); err != nil {
+		return nil, fmt.Errorf("unmarshal play queue tracks: %w", err)
+	}
+	return &queue, nil
+}
+
+// UpsertQueues 批量保存播放队列，已保存的数据比传入的更新时跳过
+func (r *PlaybackRepositoryImpl) UpsertQueues(ctx context.Context, queues []*domain.PlayQueue) error {
+	if len(queues) == 0 {
+		return nil
+	}
+
+	batch := &pgx.Batch{}
+	for _, queue := range queues {
+		tracks, err := json.Marshal(queue.Tracks)
+		if err != nil {
+			return fmt.Errorf("marshal play queue tracks: %w", err)
+		}
+		batch.Queue(`
+			INSERT INTO play_queues (user_id, tracks, current_index, shuffle, repeat_mode, device_id, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (user_id) DO UPDATE SET
+				tracks = EXCLUDED.tracks,
+				current_index = EXCLUDED.current_index,
+				shuffle = EXCLUDED.shuffle,
+				repeat_mode = EXCLUDED.repeat_mode,
+				device_id = EXCLUDED.device_id,
+				updated_at = EXCLUDED.updated_at
+			WHERE play_queues.updated_at < EXCLUDED.updated_at
+		`,
+			queue.UserID,
+			tracks,
+			queue.CurrentIndex,
+			queue.Shuffle,
+			queue.RepeatMode,
+			queue.DeviceID,
+			queue.UpdatedAt,
+		)
+	}
+	return r.db.SendBatch(ctx, batch).Close()
+}
+
+// GetPositions 获取用户指定歌曲的播放进度，没有进度的歌曲不在结果中
+func (r *PlaybackRepositoryImpl) GetPositions(ctx context.Context, userID string, songIDs []string) ([]*domain.PlaybackPosition, error) {
+	query := `
+		SELECT user_id, song_id, position_ms, duration_ms, device_id, updated_at
+		FROM playback_positions
+		WHERE user_id = $1 AND song_id = ANY($2)
+	`
+	rows, err := r.db.Query(ctx, query, userID, songIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var positions []*domain.PlaybackPosition
+	for rows.Next() {
+		var position domain.PlaybackPosition
+		if err := rows.Scan(
+			&position.UserID,
+			&position.SongID,
+			&position.PositionMs,
+			&position.DurationMs,
+			&position.DeviceID,
+			&position.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		positions = append(positions, &position)
+	}
+	return positions, rows.Err()
+}
+
+// ListPositions 获取用户的全部播放进度（用于数据导出）
+func (r *PlaybackRepositoryImpl) ListPositions(ctx context.Context, userID string) ([]*domain.PlaybackPosition, error) {
+	query := `
+		SELECT user_id, song_id, position_ms, duration_ms, device_id, updated_at
+		FROM playback_positions
+		WHERE user_id = $1
+		ORDER BY updated_at DESC
+	`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var positions []*domain.PlaybackPosition
+	for rows.Next() {
+		var position domain.PlaybackPosition
+		if err := rows.Scan(
+			&position.UserID,
+			&position.SongID,
+			&position.PositionMs,
+			&position.DurationMs,
+			&position.DeviceID,
+			&position.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		positions = append(positions, &position)
+	}
+	return positions, rows.Err()
+}
+
+// UpsertPositions 批量保存播放进度，已保存的数据比传入的更新时跳过
+func (r *PlaybackRepositoryImpl) UpsertPositions(ctx context.Context, positions []*domain.PlaybackPosition) error {
+	if len(positions) == 0 {
+		return nil
+	}
+
+	batch := &pgx.Batch{}
+	for _, position := range positions {
+		batch.Queue(`
+			INSERT INTO playback_positions (user_id, song_id, position_ms, duration_ms, device_id, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (user_id, song_id) DO UPDATE SET
+				position_ms = EXCLUDED.position_ms,
+				duration_ms = EXCLUDED.duration_ms,
+				device_id = EXCLUDED.device_id,
+				updated_at = EXCLUDED.updated_at
+			WHERE playback_positions.updated_at < EXCLUDED.updated_at
+		`,
+			position.UserID,
+			position.SongID,
+			position.PositionMs,
+			position.DurationMs,
+			position.DeviceID,
+			position.UpdatedAt,
+		)
+	}
+	return r.db.SendBatch(ctx, batch).Close()
+}
+
+// DeleteAllByUser 删除用户的播放队列和全部播放进度（用于注销账号），返回删除行数
+func (r *PlaybackRepositoryImpl) DeleteAllByUser(ctx context.Context, userID string) (int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	queueTag, err := tx.Exec(ctx, `DELETE FROM play_queues WHERE user_id = $1`, userID)
+	if err != nil {
+		return 0, err
+	}
+	positionTag, err := tx.Exec(ctx, `DELETE FROM playback_positions WHERE user_id = $1`, userID)
+	if err != nil {
+		return 0, err
+	}
+	return queueTag.RowsAffected() + positionTag.RowsAffected(), tx.Commit(ctx)
+}
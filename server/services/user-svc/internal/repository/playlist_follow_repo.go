@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"user-svc/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PlaylistFollowRepositoryImpl 歌单关注仓储实现
+// user_playlists.follower_count与playlist_follows在同一事务中维护
+type PlaylistFollowRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewPlaylistFollowRepository 创建歌单关注仓储
+func NewPlaylistFollowRepository(db *pgxpool.Pool) PlaylistFollowRepository {
+	return &PlaylistFollowRepositoryImpl{db: db}
+}
+
+// Follow 关注歌单，已关注时不做修改；返回是否新增了关注
+func (r *PlaylistFollowRepositoryImpl) Follow(ctx context.Context, playlistID, userID string, followedAt time.Time) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `
+		INSERT INTO playlist_follows (playlist_id, user_id, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (playlist_id, user_id) DO NOTHING
+	`, playlistID, userID, followedAt)
+	if err != nil {
+		return false, err
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	if _, err := tx.Exec(ctx,
+		`UPDATE user_playlists SET follower_count = follower_count + 1 WHERE id = $1`,
+		playlistID,
+	); err != nil {
+		return false, err
+	}
+	return true, tx.Commit(ctx)
+}
+
+// Unfollow 取消关注歌单，未关注时不做修改；返回是否删除了关注
+func (r *PlaylistFollowRepositoryImpl) Unfollow(ctx context.Context, playlistID, userID string) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx,
+		`DELETE FROM playlist_follows WHERE playlist_id = $1 AND user_id = $2`,
+		playlistID, userID,
+	)
+	if err != nil {
+		return false, err
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	if _, err := tx.Exec(ctx,
+		`UPDATE user_playlists SET follower_count = GREATEST(follower_count - 1, 0) WHERE id = $1`,
+		playlistID,
+	); err != nil {
+		return false, err
+	}
+	return true, tx.Commit(ctx)
+}
+
+// IsFollowing 检查用户是否关注了歌单
+func (r *PlaylistFollowRepositoryImpl) IsFollowing(ctx context.Context, playlistID, userID string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM playlist_follows WHERE playlist_id = $1 AND user_id = $2)`
+	var exists bool
+	err := r.db.QueryRow(ctx, query, playlistID, userID).Scan(&exists)
+	return exists, err
+}
+
+// ListFollowed 获取用户关注的歌单列表（按关注时间倒序）
+// 歌单被设为私有或删除后不再返回，重新公开后自动恢复
+func (r *PlaylistFollowRepositoryImpl) ListFollowed(ctx context.Context, userID string, limit, offset int) ([]*domain.UserPlaylist, error) {
+	query := `
+		SELECT p.id, p.user_id, p.name, p.description, p.cover_url, p.song_count, p.is_public, p.smart_rules, p.follower_count, p.forked_from, p.deleted_at, p.created_at, p.updated_at
+		FROM user_playlists p
+		JOIN playlist_follows f ON f.playlist_id = p.id
+		WHERE f.user_id = $1 AND p.is_public = TRUE AND p.deleted_at IS NULL
+		ORDER BY f.created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.Query(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var playlists []*domain.UserPlaylist
+	for rows.Next() {
+		var playlist domain.UserPlaylist
+		err := rows.Scan(
+			&playlist.ID,
+			&playlist.UserID,
+			&playlist.Name,
+			&playlist.Description,
+			&playlist.CoverURL,
+			&playlist.SongCount,
+			&playlist.IsPublic,
+			&playlist.SmartRules,
+			&playlist.FollowerCount,
+			&playlist.ForkedFrom,
+			&playlist.DeletedAt,
+			&playlist.CreatedAt,
+			&playlist.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		playlists = append(playlists, &playlist)
+	}
+	return playlists, rows.Err()
+}
+
+// CountFollowed 统计用户关注的公开歌单数量
+func (r *PlaylistFollowRepositoryImpl) CountFollowed(ctx context.Context, userID string) (int64, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM playlist_follows f
+		JOIN user_playlists p ON p.id = f.playlist_id
+		WHERE f.user_id = $1 AND p.is_public = TRUE AND p.deleted_at IS NULL
+	`
+	var count int64
+	err := r.db.QueryRow(ctx, query, userID).Scan(&count)
+	return count, err
+}
+
+// DeleteAllByUser 删除用户的所有关注并扣减对应歌单的关注数（注销账号时使用）
+func (r *PlaylistFollowRepositoryImpl) DeleteAllByUser(ctx context.Context, userID string) (int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE user_playlists p
+		SET follower_count = GREATEST(p.follower_count - 1, 0)
+		FROM playlist_follows f
+		WHERE f.playlist_id = p.id AND f.user_id = $1
+	`, userID); err != nil {
+		return 0, err
+	}
+
+	tag, err := tx.Exec(ctx, `DELETE FROM playlist_follows WHERE user_id = $1`, userID)
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"user-svc/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PlaylistMemberRepositoryImpl 歌单协作成员仓储实现
+type PlaylistMemberRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewPlaylistMemberRepository 创建歌单协作成员仓储
+func NewPlaylistMemberRepository(db *pgxpool.Pool) PlaylistMemberRepository {
+	return &PlaylistMemberRepositoryImpl{db: db}
+}
+
+// Upsert 添加成员，成员已存在时更新角色
+func (r *PlaylistMemberRepositoryImpl) Upsert(ctx context.Context, member *domain.PlaylistMember) error {
+	query := `
+		INSERT INTO playlist_members (playlist_id, user_id, role, invited_by, joined_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (playlist_id, user_id) DO UPDATE SET role = EXCLUDED.role
+	`
+	_, err := r.db.Exec(ctx, query,
+		member.PlaylistID,
+		member.UserID,
+		member.Role,
+		member.InvitedBy,
+		member.JoinedAt,
+	)
+	return err
+}
+
+// Get 获取成员
+func (r *PlaylistMemberRepositoryImpl) Get(ctx context.Context, playlistID, userID string) (*domain.PlaylistMember, error) {
+	query := `
+		SELECT playlist_id, user_id, role, invited_by, joined_at
+		FROM playlist_members
+		WHERE playlist_id = $1 AND user_id = $2
+	`
+	var member domain.PlaylistMember
+	err := r.db.QueryRow(ctx, query, playlistID, userID).Scan(
+		&member.PlaylistID,
+		&member.UserID,
+		&member.Role,
+		&member.InvitedBy,
+		&member.JoinedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrMemberNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// List 获取歌单的所有成员
+func (r *PlaylistMemberRepositoryImpl) List(ctx context.Context, playlistID string) ([]*domain.PlaylistMember, error) {
+	query := `
+		SELECT playlist_id, user_id, role, invited_by, joined_at
+		FROM playlist_members
+		WHERE playlist_id = $1
+		ORDER BY joined_at ASC
+	`
+	rows, err := r.db.Query(ctx, query, playlistID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []*domain.PlaylistMember
+	for rows.Next() {
+		var member domain.PlaylistMember
+		err := rows.Scan(
+			&member.PlaylistID,
+			&member.UserID,
+			&member.Role,
+			&member.InvitedBy,
+			&member.JoinedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, &member)
+	}
+	return members, rows.Err()
+}
+
+// ListByUser 获取用户参与的所有歌单成员关系（包括自己歌单的owner记录）
+func (r *PlaylistMemberRepositoryImpl) ListByUser(ctx context.Context, userID string) ([]*domain.PlaylistMember, error) {
+	query := `
+		SELECT playlist_id, user_id, role, invited_by, joined_at
+		FROM playlist_members
+		WHERE user_id = $1
+		ORDER BY joined_at ASC
+	`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []*domain.PlaylistMember
+	for rows.Next() {
+		var member domain.PlaylistMember
+		err := rows.Scan(
+			&member.PlaylistID,
+			&member.UserID,
+			&member.Role,
+			&member.InvitedBy,
+			&member.JoinedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, &member)
+	}
+	return members, rows.Err()
+}
+
+// Count 统计歌单的成员数量
+func (r *PlaylistMemberRepositoryImpl) Count(ctx context.Context, playlistID string) (int64, error) {
+	query := `SELECT COUNT(*) FROM playlist_members WHERE playlist_id = $1`
+	var count int64
+	err := r.db.QueryRow(ctx, query, playlistID).Scan(&count)
+	return count, err
+}
+
+// Remove 移除成员
+func (r *PlaylistMemberRepositoryImpl) Remove(ctx context.Context, playlistID, userID string) error {
+	query := `DELETE FROM playlist_members WHERE playlist_id = $1 AND user_id = $2`
+	tag, err := r.db.Exec(ctx, query, playlistID, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrMemberNotFound
+	}
+	return nil
+}
+
+// DeleteAllByUser 删除用户参与的所有协作关系（注销账号时使用）
+func (r *PlaylistMemberRepositoryImpl) DeleteAllByUser(ctx context.Context, userID string) (int64, error) {
+	query := `DELETE FROM playlist_members WHERE user_id = $1`
+	tag, err := r.db.Exec(ctx, query, userID)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// CreateInvite 创建分享链接邀请
+func (r *PlaylistMemberRepositoryImpl) CreateInvite(ctx context.Context, invite *domain.PlaylistInvite) error {
+	query := `
+		INSERT INTO playlist_invites (token, playlist_id, role, created_by, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err := r.db.Exec(ctx, query,
+		invite.Token,
+		invite.PlaylistID,
+		invite.Role,
+		invite.CreatedBy,
+		invite.ExpiresAt,
+		invite.CreatedAt,
+	)
+	return err
+}
+
+// GetInvite 根据token获取邀请
+func (r *PlaylistMemberRepositoryImpl) GetInvite(ctx context.Context, token string) (*domain.PlaylistInvite, error) {
+	query := `
+		SELECT token, playlist_id, role, created_by, expires_at, created_at
+		FROM playlist_invites
+		WHERE token = $1
+	`
+	var invite domain.PlaylistInvite
+	err := r.db.QueryRow(ctx, query, token).Scan(
+		&invite.Token,
+		&invite.PlaylistID,
+		&invite.Role,
+		&invite.CreatedBy,
+		&invite.ExpiresAt,
+		&invite.CreatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrInviteNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// DeleteInvites 撤销歌单的所有分享链接
+func (r *PlaylistMemberRepositoryImpl) DeleteInvites(ctx context.Context, playlistID string) (int64, error) {
+	query := `DELETE FROM playlist_invites WHERE playlist_id = $1`
+	tag, err := r.db.Exec(ctx, query, playlistID)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -2,10 +2,13 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"user-svc/internal/domain"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -21,11 +24,40 @@ func NewPlaylistRepository(db *pgxpool.Pool) PlaylistRepository {
 
 // Create 创建歌单
 func (r *PlaylistRepositoryImpl) Create(ctx context.Context, playlist *domain.UserPlaylist) error {
+	return insertPlaylist(ctx, r.db, playlist)
+}
+
+// CreateWithSongs 在同一事务中创建歌单并写入歌曲（用于导入和复制歌单），任一步失败都不会留下歌单
+func (r *PlaylistRepositoryImpl) CreateWithSongs(ctx context.Context, playlist *domain.UserPlaylist, songs []*domain.PlaylistSong) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := insertPlaylist(ctx, tx, playlist); err != nil {
+		return err
+	}
+	if len(songs) > 0 {
+		if err := copyPlaylistSongs(ctx, tx, songs); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// execer 可执行SQL的连接池或事务
+type execer interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+}
+
+// insertPlaylist 写入歌单行
+func insertPlaylist(ctx context.Context, db execer, playlist *domain.UserPlaylist) error {
 	query := `
-		INSERT INTO user_playlists (id, user_id, name, description, cover_url, song_count, is_public, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO user_playlists (id, user_id, name, description, cover_url, song_count, is_public, smart_rules, forked_from, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 	`
-	_, err := r.db.Exec(ctx, query,
+	_, err := db.Exec(ctx, query,
 		playlist.ID,
 		playlist.UserID,
 		playlist.Name,
@@ -33,6 +65,8 @@ func (r *PlaylistRepositoryImpl) Create(ctx context.Context, playlist *domain.Us
 		playlist.CoverURL,
 		playlist.SongCount,
 		playlist.IsPublic,
+		playlist.SmartRules,
+		playlist.ForkedFrom,
 		playlist.CreatedAt,
 		playlist.UpdatedAt,
 	)
@@ -42,7 +76,7 @@ func (r *PlaylistRepositoryImpl) Create(ctx context.Context, playlist *domain.Us
 // GetByID 根据ID获取歌单
 func (r *PlaylistRepositoryImpl) GetByID(ctx context.Context, id string) (*domain.UserPlaylist, error) {
 	query := `
-		SELECT id, user_id, name, description, cover_url, song_count, is_public, deleted_at, created_at, updated_at
+		SELECT id, user_id, name, description, cover_url, song_count, is_public, smart_rules, follower_count, forked_from, deleted_at, created_at, updated_at
 		FROM user_playlists
 		WHERE id = $1 AND deleted_at IS NULL
 	`
@@ -55,6 +89,9 @@ func (r *PlaylistRepositoryImpl) GetByID(ctx context.Context, id string) (*domai
 		&playlist.CoverURL,
 		&playlist.SongCount,
 		&playlist.IsPublic,
+		&playlist.SmartRules,
+		&playlist.FollowerCount,
+		&playlist.ForkedFrom,
 		&playlist.DeletedAt,
 		&playlist.CreatedAt,
 		&playlist.UpdatedAt,
@@ -68,7 +105,7 @@ func (r *PlaylistRepositoryImpl) GetByID(ctx context.Context, id string) (*domai
 // ListByUser 获取用户的歌单列表
 func (r *PlaylistRepositoryImpl) ListByUser(ctx context.Context, userID string, limit, offset int) ([]*domain.UserPlaylist, error) {
 	query := `
-		SELECT id, user_id, name, description, cover_url, song_count, is_public, deleted_at, created_at, updated_at
+		SELECT id, user_id, name, description, cover_url, song_count, is_public, smart_rules, follower_count, forked_from, deleted_at, created_at, updated_at
 		FROM user_playlists
 		WHERE user_id = $1 AND deleted_at IS NULL
 		ORDER BY updated_at DESC
@@ -91,6 +128,9 @@ func (r *PlaylistRepositoryImpl) ListByUser(ctx context.Context, userID string,
 			&playlist.CoverURL,
 			&playlist.SongCount,
 			&playlist.IsPublic,
+			&playlist.SmartRules,
+			&playlist.FollowerCount,
+			&playlist.ForkedFrom,
 			&playlist.DeletedAt,
 			&playlist.CreatedAt,
 			&playlist.UpdatedAt,
@@ -104,12 +144,17 @@ func (r *PlaylistRepositoryImpl) ListByUser(ctx context.Context, userID string,
 }
 
 // ListPublic 获取公开歌单列表
-func (r *PlaylistRepositoryImpl) ListPublic(ctx context.Context, limit, offset int) ([]*domain.UserPlaylist, error) {
+// sortBy为domain.PublicPlaylistSortPopular时按关注数排序，否则按更新时间排序
+func (r *PlaylistRepositoryImpl) ListPublic(ctx context.Context, sortBy string, limit, offset int) ([]*domain.UserPlaylist, error) {
+	orderBy := "updated_at DESC"
+	if sortBy == domain.PublicPlaylistSortPopular {
+		orderBy = "follower_count DESC, updated_at DESC"
+	}
 	query := `
-		SELECT id, user_id, name, description, cover_url, song_count, is_public, deleted_at, created_at, updated_at
+		SELECT id, user_id, name, description, cover_url, song_count, is_public, smart_rules, follower_count, forked_from, deleted_at, created_at, updated_at
 		FROM user_playlists
 		WHERE is_public = TRUE AND deleted_at IS NULL
-		ORDER BY updated_at DESC
+		ORDER BY ` + orderBy + `, id
 		LIMIT $1 OFFSET $2
 	`
 	rows, err := r.db.Query(ctx, query, limit, offset)
@@ -129,6 +174,59 @@ func (r *PlaylistRepositoryImpl) ListPublic(ctx context.Context, limit, offset i
 			&playlist.CoverURL,
 			&playlist.SongCount,
 			&playlist.IsPublic,
+			&playlist.SmartRules,
+			&playlist.FollowerCount,
+			&playlist.ForkedFrom,
+			&playlist.DeletedAt,
+			&playlist.CreatedAt,
+			&playlist.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		playlists = append(playlists, &playlist)
+	}
+	return playlists, rows.Err()
+}
+
+// CountPublic 统计公开歌单数量
+func (r *PlaylistRepositoryImpl) CountPublic(ctx context.Context) (int64, error) {
+	query := `SELECT COUNT(*) FROM user_playlists WHERE is_public = TRUE AND deleted_at IS NULL`
+	var count int64
+	err := r.db.QueryRow(ctx, query).Scan(&count)
+	return count, err
+}
+
+// ListSharedWithUser 获取用户作为协作成员加入的歌单列表
+func (r *PlaylistRepositoryImpl) ListSharedWithUser(ctx context.Context, userID string, limit, offset int) ([]*domain.UserPlaylist, error) {
+	query := `
+		SELECT p.id, p.user_id, p.name, p.description, p.cover_url, p.song_count, p.is_public, p.smart_rules, p.follower_count, p.forked_from, p.deleted_at, p.created_at, p.updated_at
+		FROM user_playlists p
+		JOIN playlist_members m ON m.playlist_id = p.id
+		WHERE m.user_id = $1 AND p.deleted_at IS NULL
+		ORDER BY p.updated_at DESC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.Query(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var playlists []*domain.UserPlaylist
+	for rows.Next() {
+		var playlist domain.UserPlaylist
+		err := rows.Scan(
+			&playlist.ID,
+			&playlist.UserID,
+			&playlist.Name,
+			&playlist.Description,
+			&playlist.CoverURL,
+			&playlist.SongCount,
+			&playlist.IsPublic,
+			&playlist.SmartRules,
+			&playlist.FollowerCount,
+			&playlist.ForkedFrom,
 			&playlist.DeletedAt,
 			&playlist.CreatedAt,
 			&playlist.UpdatedAt,
@@ -153,7 +251,7 @@ func (r *PlaylistRepositoryImpl) Count(ctx context.Context, userID string) (int6
 func (r *PlaylistRepositoryImpl) Update(ctx context.Context, playlist *domain.UserPlaylist) error {
 	query := `
 		UPDATE user_playlists
-		SET name = $2, description = $3, cover_url = $4, is_public = $5, updated_at = $6
+		SET name = $2, description = $3, cover_url = $4, is_public = $5, smart_rules = $6, updated_at = $7
 		WHERE id = $1 AND deleted_at IS NULL
 	`
 	_, err := r.db.Exec(ctx, query,
@@ -162,11 +260,23 @@ func (r *PlaylistRepositoryImpl) Update(ctx context.Context, playlist *domain.Us
 		playlist.Description,
 		playlist.CoverURL,
 		playlist.IsPublic,
+		playlist.SmartRules,
 		playlist.UpdatedAt,
 	)
 	return err
 }
 
+// SetSongCount 设置歌曲数量（智能歌单计算后回写）
+func (r *PlaylistRepositoryImpl) SetSongCount(ctx context.Context, playlistID string, count int) error {
+	query := `
+		UPDATE user_playlists
+		SET song_count = $2
+		WHERE id = $1 AND song_count <> $2
+	`
+	_, err := r.db.Exec(ctx, query, playlistID, count)
+	return err
+}
+
 // IncrementSongCount 增加歌曲数量
 func (r *PlaylistRepositoryImpl) IncrementSongCount(ctx context.Context, playlistID string) error {
 	query := `
@@ -196,11 +306,110 @@ func (r *PlaylistRepositoryImpl) SoftDelete(ctx context.Context, id string) erro
 	return err
 }
 
-// Restore 恢复歌单
+// Restore 恢复歌单，歌单不在回收站中时返回ErrPlaylistNotFound
+// 软删除不会删除歌单歌曲、成员和关注，恢复后原样可用
 func (r *PlaylistRepositoryImpl) Restore(ctx context.Context, id string) error {
 	query := `UPDATE user_playlists SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL`
-	_, err := r.db.Exec(ctx, query, id)
-	return err
+	tag, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrPlaylistNotFound
+	}
+	return nil
+}
+
+// GetDeleted 获取回收站中的歌单，不存在或未删除时返回ErrPlaylistNotFound
+func (r *PlaylistRepositoryImpl) GetDeleted(ctx context.Context, id string) (*domain.UserPlaylist, error) {
+	query := `
+		SELECT id, user_id, name, description, cover_url, song_count, is_public, smart_rules, follower_count, forked_from, deleted_at, created_at, updated_at
+		FROM user_playlists
+		WHERE id = $1 AND deleted_at IS NOT NULL
+	`
+	var playlist domain.UserPlaylist
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&playlist.ID,
+		&playlist.UserID,
+		&playlist.Name,
+		&playlist.Description,
+		&playlist.CoverURL,
+		&playlist.SongCount,
+		&playlist.IsPublic,
+		&playlist.SmartRules,
+		&playlist.FollowerCount,
+		&playlist.ForkedFrom,
+		&playlist.DeletedAt,
+		&playlist.CreatedAt,
+		&playlist.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrPlaylistNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &playlist, nil
+}
+
+// ListDeletedByUser 获取用户在deletedSince之后删除的歌单，最近删除的在前
+func (r *PlaylistRepositoryImpl) ListDeletedByUser(ctx context.Context, userID string, deletedSince time.Time, limit, offset int) ([]*domain.UserPlaylist, error) {
+	query := `
+		SELECT id, user_id, name, description, cover_url, song_count, is_public, smart_rules, follower_count, forked_from, deleted_at, created_at, updated_at
+		FROM user_playlists
+		WHERE user_id = $1 AND deleted_at > $2
+		ORDER BY deleted_at DESC
+		LIMIT $3 OFFSET $4
+	`
+	rows, err := r.db.Query(ctx, query, userID, deletedSince, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var playlists []*domain.UserPlaylist
+	for rows.Next() {
+		var playlist domain.UserPlaylist
+		err := rows.Scan(
+			&playlist.ID,
+			&playlist.UserID,
+			&playlist.Name,
+			&playlist.Description,
+			&playlist.CoverURL,
+			&playlist.SongCount,
+			&playlist.IsPublic,
+			&playlist.SmartRules,
+			&playlist.FollowerCount,
+			&playlist.ForkedFrom,
+			&playlist.DeletedAt,
+			&playlist.CreatedAt,
+			&playlist.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		playlists = append(playlists, &playlist)
+	}
+	return playlists, rows.Err()
+}
+
+// CountDeletedByUser 统计用户在deletedSince之后删除的歌单数量
+func (r *PlaylistRepositoryImpl) CountDeletedByUser(ctx context.Context, userID string, deletedSince time.Time) (int64, error) {
+	query := `SELECT COUNT(*) FROM user_playlists WHERE user_id = $1 AND deleted_at > $2`
+	var count int64
+	err := r.db.QueryRow(ctx, query, userID, deletedSince).Scan(&count)
+	return count, err
+}
+
+// PurgeDeleted 硬删除deletedBefore之前软删除的歌单，返回删除数量
+// 歌曲、成员、邀请和关注通过外键级联删除，复制出的歌单forked_from置为NULL
+func (r *PlaylistRepositoryImpl) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	query := `DELETE FROM user_playlists WHERE deleted_at IS NOT NULL AND deleted_at <= $1`
+	tag, err := r.db.Exec(ctx, query, deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
 }
 
 // HardDelete 硬删除歌单
@@ -209,3 +418,53 @@ func (r *PlaylistRepositoryImpl) HardDelete(ctx context.Context, id string) erro
 	_, err := r.db.Exec(ctx, query, id)
 	return err
 }
+
+// ListAllByUser 获取用户的全部歌单（包括已软删除的，用于数据导出）
+func (r *PlaylistRepositoryImpl) ListAllByUser(ctx context.Context, userID string) ([]*domain.UserPlaylist, error) {
+	query := `
+		SELECT id, user_id, name, description, cover_url, song_count, is_public, smart_rules, follower_count, forked_from, deleted_at, created_at, updated_at
+		FROM user_playlists
+		WHERE user_id = $1
+		ORDER BY created_at ASC
+	`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var playlists []*domain.UserPlaylist
+	for rows.Next() {
+		var playlist domain.UserPlaylist
+		err := rows.Scan(
+			&playlist.ID,
+			&playlist.UserID,
+			&playlist.Name,
+			&playlist.Description,
+			&playlist.CoverURL,
+			&playlist.SongCount,
+			&playlist.IsPublic,
+			&playlist.SmartRules,
+			&playlist.FollowerCount,
+			&playlist.ForkedFrom,
+			&playlist.DeletedAt,
+			&playlist.CreatedAt,
+			&playlist.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		playlists = append(playlists, &playlist)
+	}
+	return playlists, rows.Err()
+}
+
+// DeleteAllByUser 硬删除用户的全部歌单（包括已软删除的，歌单歌曲级联删除，用于注销账号）
+func (r *PlaylistRepositoryImpl) DeleteAllByUser(ctx context.Context, userID string) (int64, error) {
+	query := `DELETE FROM user_playlists WHERE user_id = $1`
+	tag, err := r.db.Exec(ctx, query, userID)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
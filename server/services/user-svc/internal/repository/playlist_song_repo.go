@@ -2,12 +2,41 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"user-svc/internal/domain"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// syncSongCountQuery 按实际歌曲数重新计算歌单的song_count
+const syncSongCountQuery = `
+	UPDATE user_playlists
+	SET song_count = (SELECT COUNT(*) FROM playlist_songs WHERE playlist_id = $1)
+	WHERE id = $1
+`
+
+// renumberQuery 按指定顺序把歌单歌曲的位置重排为1..N，只更新位置变化的行
+const renumberQuery = `
+	UPDATE playlist_songs ps
+	SET position = r.rn
+	FROM (
+		SELECT song_id, ROW_NUMBER() OVER (ORDER BY %s) AS rn
+		FROM playlist_songs
+		WHERE playlist_id = $1
+	) r
+	WHERE ps.playlist_id = $1 AND ps.song_id = r.song_id AND ps.position <> r.rn
+`
+
+// playlistSongOrders 排序字段对应的ORDER BY子句，%s为ASC/DESC
+var playlistSongOrders = map[string]string{
+	domain.PlaylistSongSortName:    "song_name %s, position, song_id",
+	domain.PlaylistSongSortSinger:  "singer_name %s, song_name, position, song_id",
+	domain.PlaylistSongSortAddedAt: "added_at %s, position, song_id",
+}
+
 // PlaylistSongRepositoryImpl 歌单歌曲仓储实现
 type PlaylistSongRepositoryImpl struct {
 	db *pgxpool.Pool
@@ -21,8 +50,8 @@ func NewPlaylistSongRepository(db *pgxpool.Pool) PlaylistSongRepository {
 // Add 添加歌曲到歌单
 func (r *PlaylistSongRepositoryImpl) Add(ctx context.Context, ps *domain.PlaylistSong) error {
 	query := `
-		INSERT INTO playlist_songs (playlist_id, song_id, song_name, singer_name, position, added_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO playlist_songs (playlist_id, song_id, song_name, singer_name, position, added_by, added_at)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7)
 	`
 	_, err := r.db.Exec(ctx, query,
 		ps.PlaylistID,
@@ -30,15 +59,34 @@ func (r *PlaylistSongRepositoryImpl) Add(ctx context.Context, ps *domain.Playlis
 		ps.SongName,
 		ps.SingerName,
 		ps.Position,
+		ps.AddedBy,
 		ps.AddedAt,
 	)
 	return err
 }
 
+// copyPlaylistSongs 在事务中批量写入歌曲（使用COPY，用于歌单导入和复制）
+func copyPlaylistSongs(ctx context.Context, tx pgx.Tx, songs []*domain.PlaylistSong) error {
+	rows := make([][]interface{}, 0, len(songs))
+	for _, ps := range songs {
+		var addedBy interface{}
+		if ps.AddedBy != "" {
+			addedBy = ps.AddedBy
+		}
+		rows = append(rows, []interface{}{ps.PlaylistID, ps.SongID, ps.SongName, ps.SingerName, ps.Position, addedBy, ps.AddedAt})
+	}
+	_, err := tx.CopyFrom(ctx,
+		pgx.Identifier{"playlist_songs"},
+		[]string{"playlist_id", "song_id", "song_name", "singer_name", "position", "added_by", "added_at"},
+		pgx.CopyFromRows(rows),
+	)
+	return err
+}
+
 // Get 获取歌单中的歌曲
 func (r *PlaylistSongRepositoryImpl) Get(ctx context.Context, playlistID, songID string) (*domain.PlaylistSong, error) {
 	query := `
-		SELECT playlist_id, song_id, song_name, singer_name, position, added_at
+		SELECT playlist_id, song_id, song_name, singer_name, position, COALESCE(added_by, ''), added_at
 		FROM playlist_songs
 		WHERE playlist_id = $1 AND song_id = $2
 	`
@@ -49,6 +97,7 @@ func (r *PlaylistSongRepositoryImpl) Get(ctx context.Context, playlistID, songID
 		&ps.SongName,
 		&ps.SingerName,
 		&ps.Position,
+		&ps.AddedBy,
 		&ps.AddedAt,
 	)
 	if err != nil {
@@ -60,7 +109,7 @@ func (r *PlaylistSongRepositoryImpl) Get(ctx context.Context, playlistID, songID
 // List 获取歌单的所有歌曲
 func (r *PlaylistSongRepositoryImpl) List(ctx context.Context, playlistID string) ([]*domain.PlaylistSong, error) {
 	query := `
-		SELECT playlist_id, song_id, song_name, singer_name, position, added_at
+		SELECT playlist_id, song_id, song_name, singer_name, position, COALESCE(added_by, ''), added_at
 		FROM playlist_songs
 		WHERE playlist_id = $1
 		ORDER BY position ASC
@@ -80,6 +129,7 @@ func (r *PlaylistSongRepositoryImpl) List(ctx context.Context, playlistID string
 			&ps.SongName,
 			&ps.SingerName,
 			&ps.Position,
+			&ps.AddedBy,
 			&ps.AddedAt,
 		)
 		if err != nil {
@@ -139,3 +189,202 @@ func (r *PlaylistSongRepositoryImpl) DeleteAll(ctx context.Context, playlistID s
 	_, err := r.db.Exec(ctx, query, playlistID)
 	return err
 }
+
+// AddSongs 在同一事务中批量追加歌曲到歌单末尾
+// 已在歌单中的歌曲和批次内重复的歌曲会被跳过；锁定歌单行，保证并发添加时位置连续、song_count准确
+func (r *PlaylistSongRepositoryImpl) AddSongs(ctx context.Context, playlistID string, songs []*domain.PlaylistSong) (int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockPlaylist(ctx, tx, playlistID); err != nil {
+		return 0, err
+	}
+
+	songIDs := make([]string, 0, len(songs))
+	for _, song := range songs {
+		songIDs = append(songIDs, song.SongID)
+	}
+	rows, err := tx.Query(ctx,
+		`SELECT song_id FROM playlist_songs WHERE playlist_id = $1 AND song_id = ANY($2)`,
+		playlistID, songIDs,
+	)
+	if err != nil {
+		return 0, err
+	}
+	seen := make(map[string]bool, len(songs))
+	for rows.Next() {
+		var songID string
+		if err := rows.Scan(&songID); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		seen[songID] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	var maxPos int
+	if err := tx.QueryRow(ctx,
+		`SELECT COALESCE(MAX(position), 0) FROM playlist_songs WHERE playlist_id = $1`,
+		playlistID,
+	).Scan(&maxPos); err != nil {
+		return 0, err
+	}
+
+	copyRows := make([][]interface{}, 0, len(songs))
+	for _, ps := range songs {
+		if seen[ps.SongID] {
+			continue
+		}
+		seen[ps.SongID] = true
+		var addedBy interface{}
+		if ps.AddedBy != "" {
+			addedBy = ps.AddedBy
+		}
+		ps.PlaylistID = playlistID
+		ps.Position = maxPos + len(copyRows) + 1
+		copyRows = append(copyRows, []interface{}{ps.PlaylistID, ps.SongID, ps.SongName, ps.SingerName, ps.Position, addedBy, ps.AddedAt})
+	}
+	if len(copyRows) == 0 {
+		return 0, nil
+	}
+
+	added, err := tx.CopyFrom(ctx,
+		pgx.Identifier{"playlist_songs"},
+		[]string{"playlist_id", "song_id", "song_name", "singer_name", "position", "added_by", "added_at"},
+		pgx.CopyFromRows(copyRows),
+	)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec(ctx, syncSongCountQuery, playlistID); err != nil {
+		return 0, err
+	}
+	return added, tx.Commit(ctx)
+}
+
+// RemoveSongs 在同一事务中批量移除歌曲，并把剩余歌曲的位置重排为连续的1..N
+func (r *PlaylistSongRepositoryImpl) RemoveSongs(ctx context.Context, playlistID string, songIDs []string) (int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockPlaylist(ctx, tx, playlistID); err != nil {
+		return 0, err
+	}
+
+	tag, err := tx.Exec(ctx,
+		`DELETE FROM playlist_songs WHERE playlist_id = $1 AND song_id = ANY($2)`,
+		playlistID, songIDs,
+	)
+	if err != nil {
+		return 0, err
+	}
+	if tag.RowsAffected() == 0 {
+		return 0, nil
+	}
+
+	if _, err := tx.Exec(ctx, fmt.Sprintf(renumberQuery, "position, added_at, song_id"), playlistID); err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec(ctx, syncSongCountQuery, playlistID); err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), tx.Commit(ctx)
+}
+
+// Move 把歌曲移动到指定位置（从1开始，超出范围时移动到末尾），其他歌曲依次前移或后移
+func (r *PlaylistSongRepositoryImpl) Move(ctx context.Context, playlistID, songID string, position int) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockPlaylist(ctx, tx, playlistID); err != nil {
+		return err
+	}
+
+	// 先规范化位置，历史数据的位置可能从0开始或不连续
+	if _, err := tx.Exec(ctx, fmt.Sprintf(renumberQuery, "position, added_at, song_id"), playlistID); err != nil {
+		return err
+	}
+
+	var current, count int
+	err = tx.QueryRow(ctx, `
+		SELECT position, (SELECT COUNT(*) FROM playlist_songs WHERE playlist_id = $1)
+		FROM playlist_songs
+		WHERE playlist_id = $1 AND song_id = $2
+	`, playlistID, songID).Scan(&current, &count)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrSongNotInPlaylist
+	}
+	if err != nil {
+		return err
+	}
+	if position > count {
+		position = count
+	}
+	if position == current {
+		return tx.Commit(ctx)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE playlist_songs
+		SET position = CASE
+			WHEN song_id = $2 THEN $4
+			WHEN $3 < $4 THEN position - 1
+			ELSE position + 1
+		END
+		WHERE playlist_id = $1 AND position BETWEEN LEAST($3::int, $4::int) AND GREATEST($3::int, $4::int)
+	`, playlistID, songID, current, position); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// Sort 按歌名、歌手或添加时间重排歌单歌曲的位置
+func (r *PlaylistSongRepositoryImpl) Sort(ctx context.Context, playlistID, sortBy string, descending bool) error {
+	order, ok := playlistSongOrders[sortBy]
+	if !ok {
+		return domain.ErrInvalidPlaylistSongSort
+	}
+	direction := "ASC"
+	if descending {
+		direction = "DESC"
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockPlaylist(ctx, tx, playlistID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, fmt.Sprintf(renumberQuery, fmt.Sprintf(order, direction)), playlistID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// lockPlaylist 锁定歌单行，串行化同一歌单的歌曲增删和排序
+func lockPlaylist(ctx context.Context, tx pgx.Tx, playlistID string) error {
+	var id string
+	err := tx.QueryRow(ctx,
+		`SELECT id FROM user_playlists WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`,
+		playlistID,
+	).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrPlaylistNotFound
+	}
+	return err
+}
@@ -1,34 +1,40 @@
 -- name: CreateFavorite :one
 INSERT INTO favorites (
-    id, user_id, song_id, song_name, singer_name, created_at
+    id, user_id, target_type, song_id, song_name, singer_name, cover_url, metadata, created_at
 ) VALUES (
-    $1, $2, $3, $4, $5, $6
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
 ) RETURNING *;
 
 -- name: GetFavorite :one
 SELECT * FROM favorites
 WHERE id = $1 AND deleted_at IS NULL;
 
--- name: GetFavoriteByUserAndSong :one
+-- name: GetFavoriteByUserAndTarget :one
 SELECT * FROM favorites
-WHERE user_id = $1 AND song_id = $2 AND deleted_at IS NULL;
+WHERE user_id = $1 AND target_type = $2 AND song_id = $3 AND deleted_at IS NULL;
 
 -- name: ListFavoritesByUser :many
+-- $2为空时返回全部类型
 SELECT * FROM favorites
-WHERE user_id = $1 AND deleted_at IS NULL
+WHERE user_id = $1 AND deleted_at IS NULL AND ($2::TEXT = '' OR target_type = $2)
 ORDER BY created_at DESC
-LIMIT $2 OFFSET $3;
+LIMIT $3 OFFSET $4;
 
 -- name: CountFavoritesByUser :one
 SELECT COUNT(*) FROM favorites
-WHERE user_id = $1 AND deleted_at IS NULL;
+WHERE user_id = $1 AND deleted_at IS NULL AND ($2::TEXT = '' OR target_type = $2);
+
+-- name: CountFavoritesByType :many
+SELECT target_type, COUNT(*) FROM favorites
+WHERE user_id = $1 AND deleted_at IS NULL
+GROUP BY target_type;
 
 -- name: SoftDeleteFavorite :exec
 UPDATE favorites
 SET deleted_at = $2
 WHERE id = $1 AND deleted_at IS NULL;
 
--- name: RestoreFavorite :exec
+-- name: RestoreFavorite :execrows
 UPDATE favorites
 SET deleted_at = NULL
 WHERE id = $1 AND deleted_at IS NOT NULL;
@@ -36,8 +42,34 @@ WHERE id = $1 AND deleted_at IS NOT NULL;
 -- name: HardDeleteFavorite :exec
 DELETE FROM favorites WHERE id = $1;
 
+-- name: GetDeletedFavorite :one
+SELECT * FROM favorites
+WHERE id = $1 AND deleted_at IS NOT NULL;
+
+-- name: ListDeletedFavoritesByUser :many
+SELECT * FROM favorites
+WHERE user_id = $1 AND deleted_at > $2
+ORDER BY deleted_at DESC
+LIMIT $3 OFFSET $4;
+
+-- name: CountDeletedFavoritesByUser :one
+SELECT COUNT(*) FROM favorites
+WHERE user_id = $1 AND deleted_at > $2;
+
+-- name: PurgeDeletedFavorites :execrows
+DELETE FROM favorites
+WHERE deleted_at IS NOT NULL AND deleted_at <= $1;
+
 -- name: CheckFavoriteExists :one
 SELECT EXISTS(
     SELECT 1 FROM favorites
-    WHERE user_id = $1 AND song_id = $2 AND deleted_at IS NULL
+    WHERE user_id = $1 AND target_type = $2 AND song_id = $3 AND deleted_at IS NULL
 );
+
+-- name: ListAllFavoritesByUser :many
+SELECT * FROM favorites
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: DeleteAllFavoritesByUser :execrows
+DELETE FROM favorites WHERE user_id = $1;
@@ -21,16 +21,7 @@ WHERE user_id = $1;
 -- name: DeletePlayHistory :exec
 DELETE FROM play_histories WHERE id = $1;
 
--- name: DeleteOldestPlayHistories :exec
-DELETE FROM play_histories
-WHERE id IN (
-    SELECT id FROM play_histories
-    WHERE user_id = $1
-    ORDER BY played_at ASC
-    LIMIT $2
-);
-
--- name: CleanupOldPlayHistories :exec
+-- name: CleanupOldPlayHistories :execrows
 DELETE FROM play_histories
 WHERE user_id = $1
 AND id NOT IN (
@@ -38,4 +29,16 @@ AND id NOT IN (
     WHERE user_id = $1
     ORDER BY played_at DESC
     LIMIT $2
+)
+AND (
+    rolled_up_at IS NOT NULL
+    OR id NOT IN (
+        SELECT id FROM play_histories
+        WHERE user_id = $1
+        ORDER BY played_at DESC
+        LIMIT $3
+    )
 );
+
+-- name: DeleteAllPlayHistoriesByUser :execrows
+DELETE FROM play_histories WHERE user_id = $1;
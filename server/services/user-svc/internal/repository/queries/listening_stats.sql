@@ -0,0 +1,131 @@
+-- name: ListPendingRollupPlays :many
+SELECT * FROM play_histories
+WHERE rolled_up_at IS NULL
+ORDER BY played_at ASC
+LIMIT $1;
+
+-- name: MarkPlayHistoriesRolledUp :execrows
+UPDATE play_histories
+SET rolled_up_at = $2
+WHERE id = ANY($1::uuid[]) AND rolled_up_at IS NULL;
+
+-- name: UpsertListeningDailyStats :exec
+INSERT INTO listening_daily_stats (user_id, day, play_count, seconds_listened, hour_plays, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (user_id, day) DO UPDATE SET
+    play_count = listening_daily_stats.play_count + EXCLUDED.play_count,
+    seconds_listened = listening_daily_stats.seconds_listened + EXCLUDED.seconds_listened,
+    hour_plays = listening_array_add(listening_daily_stats.hour_plays, EXCLUDED.hour_plays),
+    updated_at = EXCLUDED.updated_at;
+
+-- name: UpsertListeningMonthlyStats :exec
+INSERT INTO listening_monthly_stats (user_id, month, play_count, seconds_listened, heatmap, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (user_id, month) DO UPDATE SET
+    play_count = listening_monthly_stats.play_count + EXCLUDED.play_count,
+    seconds_listened = listening_monthly_stats.seconds_listened + EXCLUDED.seconds_listened,
+    heatmap = listening_array_add(listening_monthly_stats.heatmap, EXCLUDED.heatmap),
+    updated_at = EXCLUDED.updated_at;
+
+-- name: UpsertListeningDailySong :exec
+INSERT INTO listening_daily_songs (user_id, day, song_id, song_name, singer_name, album_cover, play_count, seconds_listened)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (user_id, day, song_id) DO UPDATE SET
+    song_name = EXCLUDED.song_name,
+    singer_name = EXCLUDED.singer_name,
+    album_cover = EXCLUDED.album_cover,
+    play_count = listening_daily_songs.play_count + EXCLUDED.play_count,
+    seconds_listened = listening_daily_songs.seconds_listened + EXCLUDED.seconds_listened;
+
+-- name: UpsertListeningMonthlySong :exec
+INSERT INTO listening_monthly_songs (user_id, month, song_id, song_name, singer_name, album_cover, play_count, seconds_listened)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (user_id, month, song_id) DO UPDATE SET
+    song_name = EXCLUDED.song_name,
+    singer_name = EXCLUDED.singer_name,
+    album_cover = EXCLUDED.album_cover,
+    play_count = listening_monthly_songs.play_count + EXCLUDED.play_count,
+    seconds_listened = listening_monthly_songs.seconds_listened + EXCLUDED.seconds_listened;
+
+-- name: UpsertListeningDailySinger :exec
+INSERT INTO listening_daily_singers (user_id, day, singer_name, play_count, seconds_listened)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (user_id, day, singer_name) DO UPDATE SET
+    play_count = listening_daily_singers.play_count + EXCLUDED.play_count,
+    seconds_listened = listening_daily_singers.seconds_listened + EXCLUDED.seconds_listened;
+
+-- name: UpsertListeningMonthlySinger :exec
+INSERT INTO listening_monthly_singers (user_id, month, singer_name, play_count, seconds_listened)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (user_id, month, singer_name) DO UPDATE SET
+    play_count = listening_monthly_singers.play_count + EXCLUDED.play_count,
+    seconds_listened = listening_monthly_singers.seconds_listened + EXCLUDED.seconds_listened;
+
+-- name: ListListeningDailyStats :many
+SELECT * FROM listening_daily_stats
+WHERE user_id = $1 AND day >= $2 AND day <= $3
+ORDER BY day ASC;
+
+-- name: ListListeningMonthlyStats :many
+SELECT * FROM listening_monthly_stats
+WHERE user_id = $1 AND month >= $2 AND month <= $3
+ORDER BY month ASC;
+
+-- name: TopListeningSongsByDay :many
+SELECT song_id,
+    (array_agg(song_name ORDER BY day DESC))[1] AS song_name,
+    (array_agg(singer_name ORDER BY day DESC))[1] AS singer_name,
+    (array_agg(COALESCE(album_cover, '') ORDER BY day DESC))[1] AS album_cover,
+    SUM(play_count)::BIGINT AS plays,
+    SUM(seconds_listened)::BIGINT AS seconds
+FROM listening_daily_songs
+WHERE user_id = $1 AND day >= $2 AND day <= $3
+GROUP BY song_id
+ORDER BY plays DESC, seconds DESC, song_id ASC
+LIMIT $4;
+
+-- name: TopListeningSingersByDay :many
+SELECT singer_name,
+    SUM(play_count)::BIGINT AS plays,
+    SUM(seconds_listened)::BIGINT AS seconds
+FROM listening_daily_singers
+WHERE user_id = $1 AND day >= $2 AND day <= $3
+GROUP BY singer_name
+ORDER BY plays DESC, seconds DESC, singer_name ASC
+LIMIT $4;
+
+-- name: TopListeningSongsByMonth :many
+SELECT song_id,
+    (array_agg(song_name ORDER BY month DESC))[1] AS song_name,
+    (array_agg(singer_name ORDER BY month DESC))[1] AS singer_name,
+    (array_agg(COALESCE(album_cover, '') ORDER BY month DESC))[1] AS album_cover,
+    SUM(play_count)::BIGINT AS plays,
+    SUM(seconds_listened)::BIGINT AS seconds
+FROM listening_monthly_songs
+WHERE user_id = $1 AND month >= $2 AND month <= $3
+GROUP BY song_id
+ORDER BY plays DESC, seconds DESC, song_id ASC
+LIMIT $4;
+
+-- name: TopListeningSingersByMonth :many
+SELECT singer_name,
+    SUM(play_count)::BIGINT AS plays,
+    SUM(seconds_listened)::BIGINT AS seconds
+FROM listening_monthly_singers
+WHERE user_id = $1 AND month >= $2 AND month <= $3
+GROUP BY singer_name
+ORDER BY plays DESC, seconds DESC, singer_name ASC
+LIMIT $4;
+
+-- name: CountDistinctListeningByMonth :one
+SELECT
+    (SELECT COUNT(DISTINCT song_id) FROM listening_monthly_songs s
+     WHERE s.user_id = $1 AND s.month >= $2 AND s.month <= $3) AS songs,
+    (SELECT COUNT(DISTINCT singer_name) FROM listening_monthly_singers g
+     WHERE g.user_id = $1 AND g.month >= $2 AND g.month <= $3) AS singers;
+
+-- name: PruneListeningDailySongs :execrows
+DELETE FROM listening_daily_songs WHERE day < $1;
+
+-- name: PruneListeningDailySingers :execrows
+DELETE FROM listening_daily_singers WHERE day < $1;
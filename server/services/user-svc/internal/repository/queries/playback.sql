@@ -0,0 +1,49 @@
+-- name: GetPlayQueue :one
+SELECT * FROM play_queues
+WHERE user_id = $1;
+
+-- name: UpsertPlayQueue :exec
+-- 只接受更新的数据，避免延迟的回写批次覆盖新数据
+INSERT INTO play_queues (
+    user_id, tracks, current_index, shuffle, repeat_mode, device_id, updated_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT (user_id) DO UPDATE SET
+    tracks = EXCLUDED.tracks,
+    current_index = EXCLUDED.current_index,
+    shuffle = EXCLUDED.shuffle,
+    repeat_mode = EXCLUDED.repeat_mode,
+    device_id = EXCLUDED.device_id,
+    updated_at = EXCLUDED.updated_at
+WHERE play_queues.updated_at < EXCLUDED.updated_at;
+
+-- name: GetPlaybackPositions :many
+SELECT * FROM playback_positions
+WHERE user_id = $1 AND song_id = ANY($2::VARCHAR[]);
+
+-- name: ListPlaybackPositionsByUser :many
+SELECT * FROM playback_positions
+WHERE user_id = $1
+ORDER BY updated_at DESC;
+
+-- name: UpsertPlaybackPosition :exec
+INSERT INTO playback_positions (
+    user_id, song_id, position_ms, duration_ms, device_id, updated_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (user_id, song_id) DO UPDATE SET
+    position_ms = EXCLUDED.position_ms,
+    duration_ms = EXCLUDED.duration_ms,
+    device_id = EXCLUDED.device_id,
+    updated_at = EXCLUDED.updated_at
+WHERE playback_positions.updated_at < EXCLUDED.updated_at;
+
+-- name: DeletePlayQueueByUser :execrows
+DELETE FROM play_queues
+WHERE user_id = $1;
+
+-- name: DeletePlaybackPositionsByUser :execrows
+DELETE FROM playback_positions
+WHERE user_id = $1;
@@ -1,8 +1,8 @@
 -- name: CreateUserPlaylist :one
 INSERT INTO user_playlists (
-    id, user_id, name, description, cover_url, song_count, is_public, created_at, updated_at
+    id, user_id, name, description, cover_url, song_count, is_public, smart_rules, forked_from, created_at, updated_at
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
 ) RETURNING *;
 
 -- name: GetUserPlaylist :one
@@ -15,21 +15,43 @@ WHERE user_id = $1 AND deleted_at IS NULL
 ORDER BY updated_at DESC
 LIMIT $2 OFFSET $3;
 
--- name: ListPublicPlaylists :many
+-- name: ListPublicPlaylistsByRecent :many
 SELECT * FROM user_playlists
 WHERE is_public = TRUE AND deleted_at IS NULL
-ORDER BY updated_at DESC
+ORDER BY updated_at DESC, id
+LIMIT $1 OFFSET $2;
+
+-- name: ListPublicPlaylistsByPopular :many
+SELECT * FROM user_playlists
+WHERE is_public = TRUE AND deleted_at IS NULL
+ORDER BY follower_count DESC, updated_at DESC, id
 LIMIT $1 OFFSET $2;
 
+-- name: CountPublicPlaylists :one
+SELECT COUNT(*) FROM user_playlists
+WHERE is_public = TRUE AND deleted_at IS NULL;
+
+-- name: ListPlaylistsSharedWithUser :many
+SELECT p.* FROM user_playlists p
+JOIN playlist_members m ON m.playlist_id = p.id
+WHERE m.user_id = $1 AND p.deleted_at IS NULL
+ORDER BY p.updated_at DESC
+LIMIT $2 OFFSET $3;
+
 -- name: CountUserPlaylistsByUser :one
 SELECT COUNT(*) FROM user_playlists
 WHERE user_id = $1 AND deleted_at IS NULL;
 
 -- name: UpdateUserPlaylist :exec
 UPDATE user_playlists
-SET name = $2, description = $3, cover_url = $4, is_public = $5, updated_at = $6
+SET name = $2, description = $3, cover_url = $4, is_public = $5, smart_rules = $6, updated_at = $7
 WHERE id = $1 AND deleted_at IS NULL;
 
+-- name: SetPlaylistSongCount :exec
+UPDATE user_playlists
+SET song_count = $2
+WHERE id = $1 AND song_count <> $2;
+
 -- name: IncrementPlaylistSongCount :exec
 UPDATE user_playlists
 SET song_count = song_count + 1, updated_at = $2
@@ -45,10 +67,36 @@ UPDATE user_playlists
 SET deleted_at = $2
 WHERE id = $1 AND deleted_at IS NULL;
 
--- name: RestoreUserPlaylist :exec
+-- name: RestoreUserPlaylist :execrows
 UPDATE user_playlists
 SET deleted_at = NULL
 WHERE id = $1 AND deleted_at IS NOT NULL;
 
 -- name: HardDeleteUserPlaylist :exec
 DELETE FROM user_playlists WHERE id = $1;
+
+-- name: GetDeletedUserPlaylist :one
+SELECT * FROM user_playlists
+WHERE id = $1 AND deleted_at IS NOT NULL;
+
+-- name: ListDeletedUserPlaylistsByUser :many
+SELECT * FROM user_playlists
+WHERE user_id = $1 AND deleted_at > $2
+ORDER BY deleted_at DESC
+LIMIT $3 OFFSET $4;
+
+-- name: CountDeletedUserPlaylistsByUser :one
+SELECT COUNT(*) FROM user_playlists
+WHERE user_id = $1 AND deleted_at > $2;
+
+-- name: PurgeDeletedUserPlaylists :execrows
+DELETE FROM user_playlists
+WHERE deleted_at IS NOT NULL AND deleted_at <= $1;
+
+-- name: ListAllUserPlaylistsByUser :many
+SELECT * FROM user_playlists
+WHERE user_id = $1
+ORDER BY created_at ASC;
+
+-- name: DeleteAllUserPlaylistsByUser :execrows
+DELETE FROM user_playlists WHERE user_id = $1;
@@ -0,0 +1,49 @@
+-- name: FollowPlaylist :execrows
+INSERT INTO playlist_follows (
+    playlist_id, user_id, created_at
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (playlist_id, user_id) DO NOTHING;
+
+-- name: IncrementPlaylistFollowerCount :exec
+UPDATE user_playlists
+SET follower_count = follower_count + 1
+WHERE id = $1;
+
+-- name: UnfollowPlaylist :execrows
+DELETE FROM playlist_follows
+WHERE playlist_id = $1 AND user_id = $2;
+
+-- name: DecrementPlaylistFollowerCount :exec
+UPDATE user_playlists
+SET follower_count = GREATEST(follower_count - 1, 0)
+WHERE id = $1;
+
+-- name: IsFollowingPlaylist :one
+SELECT EXISTS(
+    SELECT 1 FROM playlist_follows
+    WHERE playlist_id = $1 AND user_id = $2
+);
+
+-- name: ListFollowedPlaylists :many
+SELECT p.* FROM user_playlists p
+JOIN playlist_follows f ON f.playlist_id = p.id
+WHERE f.user_id = $1 AND p.is_public = TRUE AND p.deleted_at IS NULL
+ORDER BY f.created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CountFollowedPlaylists :one
+SELECT COUNT(*) FROM playlist_follows f
+JOIN user_playlists p ON p.id = f.playlist_id
+WHERE f.user_id = $1 AND p.is_public = TRUE AND p.deleted_at IS NULL;
+
+-- name: DecrementFollowerCountsByUser :exec
+UPDATE user_playlists p
+SET follower_count = GREATEST(p.follower_count - 1, 0)
+FROM playlist_follows f
+WHERE f.playlist_id = p.id AND f.user_id = $1;
+
+-- name: DeleteAllPlaylistFollowsByUser :execrows
+DELETE FROM playlist_follows
+WHERE user_id = $1;
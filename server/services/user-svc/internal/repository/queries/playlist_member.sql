@@ -0,0 +1,48 @@
+-- name: UpsertPlaylistMember :exec
+INSERT INTO playlist_members (
+    playlist_id, user_id, role, invited_by, joined_at
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (playlist_id, user_id) DO UPDATE SET role = EXCLUDED.role;
+
+-- name: GetPlaylistMember :one
+SELECT * FROM playlist_members
+WHERE playlist_id = $1 AND user_id = $2;
+
+-- name: ListPlaylistMembers :many
+SELECT * FROM playlist_members
+WHERE playlist_id = $1
+ORDER BY joined_at ASC;
+
+-- name: ListPlaylistMembershipsByUser :many
+SELECT * FROM playlist_members
+WHERE user_id = $1
+ORDER BY joined_at ASC;
+
+-- name: CountPlaylistMembers :one
+SELECT COUNT(*) FROM playlist_members
+WHERE playlist_id = $1;
+
+-- name: RemovePlaylistMember :execrows
+DELETE FROM playlist_members
+WHERE playlist_id = $1 AND user_id = $2;
+
+-- name: DeleteAllPlaylistMembershipsByUser :execrows
+DELETE FROM playlist_members
+WHERE user_id = $1;
+
+-- name: CreatePlaylistInvite :exec
+INSERT INTO playlist_invites (
+    token, playlist_id, role, created_by, expires_at, created_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+);
+
+-- name: GetPlaylistInvite :one
+SELECT * FROM playlist_invites
+WHERE token = $1;
+
+-- name: DeletePlaylistInvites :execrows
+DELETE FROM playlist_invites
+WHERE playlist_id = $1;
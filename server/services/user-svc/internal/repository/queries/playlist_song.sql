@@ -1,10 +1,17 @@
 -- name: AddSongToPlaylist :one
 INSERT INTO playlist_songs (
-    playlist_id, song_id, song_name, singer_name, position, added_at
+    playlist_id, song_id, song_name, singer_name, position, added_by, added_at
 ) VALUES (
-    $1, $2, $3, $4, $5, $6
+    $1, $2, $3, $4, $5, $6, $7
 ) RETURNING *;
 
+-- name: AddSongsToPlaylist :copyfrom
+INSERT INTO playlist_songs (
+    playlist_id, song_id, song_name, singer_name, position, added_by, added_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+);
+
 -- name: GetPlaylistSong :one
 SELECT * FROM playlist_songs
 WHERE playlist_id = $1 AND song_id = $2;
@@ -39,3 +46,51 @@ WHERE playlist_id = $1;
 
 -- name: DeletePlaylistSongs :exec
 DELETE FROM playlist_songs WHERE playlist_id = $1;
+
+-- name: LockPlaylist :one
+SELECT id FROM user_playlists
+WHERE id = $1 AND deleted_at IS NULL
+FOR UPDATE;
+
+-- name: ListExistingPlaylistSongIDs :many
+SELECT song_id FROM playlist_songs
+WHERE playlist_id = $1 AND song_id = ANY(@song_ids::text[]);
+
+-- name: GetMaxPlaylistSongPosition :one
+SELECT COALESCE(MAX(position), 0) FROM playlist_songs
+WHERE playlist_id = $1;
+
+-- name: RemoveSongsFromPlaylist :execrows
+DELETE FROM playlist_songs
+WHERE playlist_id = $1 AND song_id = ANY(@song_ids::text[]);
+
+-- name: RenumberPlaylistSongs :exec
+-- 排序字段在代码中拼接（position/song_name/singer_name/added_at），这里给出默认顺序
+UPDATE playlist_songs ps
+SET position = r.rn
+FROM (
+    SELECT song_id, ROW_NUMBER() OVER (ORDER BY position, added_at, song_id) AS rn
+    FROM playlist_songs
+    WHERE playlist_id = $1
+) r
+WHERE ps.playlist_id = $1 AND ps.song_id = r.song_id AND ps.position <> r.rn;
+
+-- name: GetPlaylistSongPosition :one
+SELECT position, (SELECT COUNT(*) FROM playlist_songs c WHERE c.playlist_id = $1) AS song_count
+FROM playlist_songs
+WHERE playlist_songs.playlist_id = $1 AND song_id = $2;
+
+-- name: MovePlaylistSong :exec
+UPDATE playlist_songs
+SET position = CASE
+    WHEN song_id = @song_id THEN @to_position::int
+    WHEN @from_position::int < @to_position::int THEN position - 1
+    ELSE position + 1
+END
+WHERE playlist_id = $1
+  AND position BETWEEN LEAST(@from_position::int, @to_position::int) AND GREATEST(@from_position::int, @to_position::int);
+
+-- name: SyncPlaylistSongCount :exec
+UPDATE user_playlists
+SET song_count = (SELECT COUNT(*) FROM playlist_songs WHERE playlist_id = $1)
+WHERE id = $1;
@@ -0,0 +1,41 @@
+-- name: ListRecommendationSignalUserIDs :many
+SELECT user_id FROM (
+    SELECT user_id FROM favorites WHERE deleted_at IS NULL AND target_type = 'song'
+    UNION
+    SELECT user_id FROM user_playlists WHERE deleted_at IS NULL AND song_count > 0
+    UNION
+    SELECT user_id FROM listening_daily_songs WHERE day >= $1
+) u
+WHERE user_id > $2
+ORDER BY user_id
+LIMIT $3;
+
+-- name: ListUserSongSignals :many
+-- 收藏、歌单、播放（次数取对数）加权汇总为每首歌的偏好权重
+SELECT song_id,
+    (array_agg(song_name ORDER BY weight DESC))[1] AS song_name,
+    (array_agg(singer_name ORDER BY weight DESC))[1] AS singer_name,
+    SUM(weight) AS total_weight,
+    BOOL_OR(favorited) AS favorited
+FROM (
+    SELECT song_id, song_name, singer_name, $2::FLOAT8 AS weight, TRUE AS favorited
+    FROM favorites
+    WHERE user_id = $1 AND deleted_at IS NULL AND target_type = 'song'
+    UNION ALL
+    SELECT ps.song_id, ps.song_name, ps.singer_name, $3::FLOAT8, FALSE
+    FROM playlist_songs ps
+    JOIN user_playlists p ON p.id = ps.playlist_id
+    WHERE p.user_id = $1 AND p.deleted_at IS NULL
+    UNION ALL
+    SELECT song_id,
+        (array_agg(song_name ORDER BY day DESC))[1],
+        (array_agg(singer_name ORDER BY day DESC))[1],
+        $4::FLOAT8 * LN(1 + SUM(play_count)),
+        FALSE
+    FROM listening_daily_songs
+    WHERE user_id = $1 AND day >= $5
+    GROUP BY song_id
+) s
+GROUP BY song_id
+ORDER BY total_weight DESC, song_id ASC
+LIMIT $6;
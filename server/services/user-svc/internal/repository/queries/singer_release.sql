@@ -0,0 +1,31 @@
+-- name: ListFollowedSingerIDs :many
+SELECT DISTINCT song_id
+FROM favorites
+WHERE target_type = 'singer' AND deleted_at IS NULL
+ORDER BY song_id;
+
+-- name: HasSingerReleases :one
+SELECT EXISTS(SELECT 1 FROM singer_releases WHERE singer_id = $1);
+
+-- name: CreateSingerRelease :execrows
+INSERT INTO singer_releases (
+    singer_id, album_id, album_name, singer_name, cover_url, release_date, baseline, discovered_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+ON CONFLICT (singer_id, album_id) DO NOTHING;
+
+-- name: ListSingerReleasesForUser :many
+-- 只返回关注之后发现的非基线专辑
+SELECT r.* FROM singer_releases r
+JOIN favorites f ON f.song_id = r.singer_id
+WHERE f.user_id = $1 AND f.target_type = 'singer' AND f.deleted_at IS NULL
+    AND r.baseline = FALSE AND r.discovered_at > f.created_at
+ORDER BY r.discovered_at DESC, r.album_id
+LIMIT $2 OFFSET $3;
+
+-- name: CountSingerReleasesForUser :one
+SELECT COUNT(*) FROM singer_releases r
+JOIN favorites f ON f.song_id = r.singer_id
+WHERE f.user_id = $1 AND f.target_type = 'singer' AND f.deleted_at IS NULL
+    AND r.baseline = FALSE AND r.discovered_at > f.created_at;
@@ -0,0 +1,29 @@
+-- name: ListUserLibrary :many
+SELECT song_id,
+    (array_agg(song_name ORDER BY priority))[1]::VARCHAR AS song_name,
+    (array_agg(singer_name ORDER BY priority))[1]::VARCHAR AS singer_name,
+    MAX(favorited_at)::TIMESTAMP AS favorited_at,
+    SUM(play_count)::BIGINT AS play_count,
+    MAX(last_played_at)::TIMESTAMP AS last_played_at
+FROM (
+    SELECT song_id, song_name, singer_name, 1 AS priority,
+        created_at AS favorited_at, 0::BIGINT AS play_count, NULL::TIMESTAMP AS last_played_at
+    FROM favorites
+    WHERE favorites.user_id = $1 AND deleted_at IS NULL AND target_type = 'song'
+    UNION ALL
+    SELECT song_id, song_name, singer_name, 2,
+        NULL, CASE WHEN rolled_up_at IS NULL THEN 1 ELSE 0 END, played_at
+    FROM play_histories
+    WHERE play_histories.user_id = $1
+    UNION ALL
+    SELECT song_id, song_name, singer_name, 3,
+        NULL, 0, day::TIMESTAMP
+    FROM listening_daily_songs
+    WHERE listening_daily_songs.user_id = $1
+    UNION ALL
+    SELECT song_id, song_name, singer_name, 4,
+        NULL, play_count, month::TIMESTAMP
+    FROM listening_monthly_songs
+    WHERE listening_monthly_songs.user_id = $1
+) s
+GROUP BY song_id;
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"user-svc/internal/domain"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	recommendationKeyPrefix = "recs:user:"
+	similarSongsKeyPrefix   = "recs:similar:"
+)
+
+// RecommendationCacheImpl 基于Redis的推荐结果缓存
+type RecommendationCacheImpl struct {
+	client *redis.Client
+}
+
+// NewRecommendationCache 创建推荐结果缓存
+func NewRecommendationCache(client *redis.Client) RecommendationCache {
+	return &RecommendationCacheImpl{client: client}
+}
+
+// SaveRecommendations 保存用户推荐结果
+func (c *RecommendationCacheImpl) SaveRecommendations(ctx context.Context, recs *domain.UserRecommendations, ttl time.Duration) error {
+	data, err := json.Marshal(recs)
+	if err != nil {
+		return fmt.Errorf("marshal recommendations: %w", err)
+	}
+	return c.client.Set(ctx, recommendationKeyPrefix+recs.UserID, data, ttl).Err()
+}
+
+// GetRecommendations 获取用户推荐结果，未缓存时返回ErrRecommendationsNotFound
+func (c *RecommendationCacheImpl) GetRecommendations(ctx context.Context, userID string) (*domain.UserRecommendations, error) {
+	data, err := c.client.Get(ctx, recommendationKeyPrefix+userID).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, domain.ErrRecommendationsNotFound
+		}
+		return nil, err
+	}
+
+	var recs domain.UserRecommendations
+	if err := json.Unmarshal(data, &recs); err != nil {
+		return nil, fmt.Errorf("unmarshal recommendations: %w", err)
+	}
+	return &recs, nil
+}
+
+// DeleteRecommendations 删除用户推荐结果
+func (c *RecommendationCacheImpl) DeleteRecommendations(ctx context.Context, userID string) error {
+	return c.client.Del(ctx, recommendationKeyPrefix+userID).Err()
+}
+
+// SaveSimilarSongs 缓存上游相似歌曲（空列表也缓存，避免重复请求）
+func (c *RecommendationCacheImpl) SaveSimilarSongs(ctx context.Context, songID string, songs []*domain.RecommendedSong, ttl time.Duration) error {
+	if songs == nil {
+		songs = []*domain.RecommendedSong{}
+	}
+	data, err := json.Marshal(songs)
+	if err != nil {
+		return fmt.Errorf("marshal similar songs: %w", err)
+	}
+	return c.client.Set(ctx, similarSongsKeyPrefix+songID, data, ttl).Err()
+}
+
+// GetSimilarSongs 获取缓存的上游相似歌曲，第二个返回值表示是否命中
+func (c *RecommendationCacheImpl) GetSimilarSongs(ctx context.Context, songID string) ([]*domain.RecommendedSong, bool, error) {
+	data, err := c.client.Get(ctx, similarSongsKeyPrefix+songID).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	var songs []*domain.RecommendedSong
+	if err := json.Unmarshal(data, &songs); err != nil {
+		return nil, false, fmt.Errorf("unmarshal similar songs: %w", err)
+	}
+	return songs, true, nil
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"user-svc/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// 偏好信号权重：收藏 > 加入歌单 > 播放（播放次数取对数，避免单曲循环主导结果）
+const (
+	favoriteSignalWeight = 3.0
+	playlistSignalWeight = 2.0
+	playSignalWeight     = 1.0
+)
+
+// RecommendationRepositoryImpl 推荐信号仓储实现
+type RecommendationRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewRecommendationRepository 创建推荐信号仓储
+func NewRecommendationRepository(db *pgxpool.Pool) RecommendationRepository {
+	return &RecommendationRepositoryImpl{db: db}
+}
+
+// ListSignalUserIDs 按用户ID分页列出有偏好信号的用户
+func (r *RecommendationRepositoryImpl) ListSignalUserIDs(ctx context.Context, since time.Time, afterUserID string, limit int) ([]string, error) {
+	query := `
+		SELECT user_id FROM (
+			SELECT user_id FROM favorites WHERE deleted_at IS NULL AND target_type = 'song'
+			UNION
+			SELECT user_id FROM user_playlists WHERE deleted_at IS NULL AND song_count > 0
+			UNION
+			SELECT user_id FROM listening_daily_songs WHERE day >= $1
+		) u
+		WHERE user_id > $2
+		ORDER BY user_id
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(ctx, query, since, afterUserID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var userIDs []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, rows.Err()
+}
+
+// ListUserSignals 汇总用户对每首歌的偏好信号，按权重从高到低返回
+// 播放信号来自听歌统计的每日歌曲明细（since之后）
+func (r *RecommendationRepositoryImpl) ListUserSignals(ctx context.Context, userID string, since time.Time, limit int) ([]*domain.SongSignal, error) {
+	query := `
+		SELECT song_id,
+			(array_agg(song_name ORDER BY weight DESC))[1],
+			(array_agg(singer_name ORDER BY weight DESC))[1],
+			SUM(weight) AS total_weight,
+			BOOL_OR(favorited)
+		FROM (
+			SELECT song_id, song_name, singer_name, $2::FLOAT8 AS weight, TRUE AS favorited
+			FROM favorites
+			WHERE user_id = $1 AND deleted_at IS NULL AND target_type = 'song'
+			UNION ALL
+			SELECT ps.song_id, ps.song_name, ps.singer_name, $3::FLOAT8, FALSE
+			FROM playlist_songs ps
+			JOIN user_playlists p ON p.id = ps.playlist_id
+			WHERE p.user_id = $1 AND p.deleted_at IS NULL
+			UNION ALL
+			SELECT song_id,
+				(array_agg(song_name ORDER BY day DESC))[1],
+				(array_agg(singer_name ORDER BY day DESC))[1],
+				$4::FLOAT8 * LN(1 + SUM(play_count)),
+				FALSE
+			FROM listening_daily_songs
+			WHERE user_id = $1 AND day >= $5
+			GROUP BY song_id
+		) s
+		GROUP BY song_id
+		ORDER BY total_weight DESC, song_id ASC
+		LIMIT $6
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, favoriteSignalWeight, playlistSignalWeight, playSignalWeight, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var signals []*domain.SongSignal
+	for rows.Next() {
+		var s domain.SongSignal
+		if err := rows.Scan(&s.SongID, &s.SongName, &s.SingerName, &s.Weight, &s.Favorited); err != nil {
+			return nil, err
+		}
+		signals = append(signals, &s)
+	}
+
+	return signals, rows.Err()
+}
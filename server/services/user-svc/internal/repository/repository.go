@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"user-svc/internal/domain"
 )
@@ -10,13 +11,20 @@ import (
 type FavoriteRepository interface {
 	Create(ctx context.Context, favorite *domain.Favorite) error
 	GetByID(ctx context.Context, id string) (*domain.Favorite, error)
-	GetByUserAndSong(ctx context.Context, userID, songID string) (*domain.Favorite, error)
-	ListByUser(ctx context.Context, userID string, limit, offset int) ([]*domain.Favorite, error)
-	Count(ctx context.Context, userID string) (int64, error)
+	GetByUserAndTarget(ctx context.Context, userID, favoriteType, targetID string) (*domain.Favorite, error)
+	ListByUser(ctx context.Context, userID, favoriteType string, limit, offset int) ([]*domain.Favorite, error)
+	Count(ctx context.Context, userID, favoriteType string) (int64, error)
+	CountByType(ctx context.Context, userID string) (map[string]int64, error)
 	SoftDelete(ctx context.Context, id string) error
 	Restore(ctx context.Context, id string) error
 	HardDelete(ctx context.Context, id string) error
-	Exists(ctx context.Context, userID, songID string) (bool, error)
+	Exists(ctx context.Context, userID, favoriteType, targetID string) (bool, error)
+	GetDeleted(ctx context.Context, id string) (*domain.Favorite, error)
+	ListDeletedByUser(ctx context.Context, userID string, deletedSince time.Time, limit, offset int) ([]*domain.Favorite, error)
+	CountDeletedByUser(ctx context.Context, userID string, deletedSince time.Time) (int64, error)
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error)
+	ListAllByUser(ctx context.Context, userID string) ([]*domain.Favorite, error)
+	DeleteAllByUser(ctx context.Context, userID string) (int64, error)
 }
 
 // PlayHistoryRepository 播放历史仓储接口
@@ -26,24 +34,34 @@ type PlayHistoryRepository interface {
 	ListByUser(ctx context.Context, userID string, limit, offset int) ([]*domain.PlayHistory, error)
 	Count(ctx context.Context, userID string) (int64, error)
 	Delete(ctx context.Context, id string) error
-	DeleteOldest(ctx context.Context, userID string, count int) error
-	Cleanup(ctx context.Context, userID string, keepCount int) error
+	Cleanup(ctx context.Context, userID string, keepCount, hardLimit int) (int64, error)
 	GetAllUserIDs(ctx context.Context) ([]string, error)
+	DeleteAllByUser(ctx context.Context, userID string) (int64, error)
 }
 
 // PlaylistRepository 歌单仓储接口
 type PlaylistRepository interface {
 	Create(ctx context.Context, playlist *domain.UserPlaylist) error
+	CreateWithSongs(ctx context.Context, playlist *domain.UserPlaylist, songs []*domain.PlaylistSong) error
 	GetByID(ctx context.Context, id string) (*domain.UserPlaylist, error)
 	ListByUser(ctx context.Context, userID string, limit, offset int) ([]*domain.UserPlaylist, error)
-	ListPublic(ctx context.Context, limit, offset int) ([]*domain.UserPlaylist, error)
+	ListPublic(ctx context.Context, sortBy string, limit, offset int) ([]*domain.UserPlaylist, error)
+	CountPublic(ctx context.Context) (int64, error)
+	ListSharedWithUser(ctx context.Context, userID string, limit, offset int) ([]*domain.UserPlaylist, error)
 	Count(ctx context.Context, userID string) (int64, error)
 	Update(ctx context.Context, playlist *domain.UserPlaylist) error
+	SetSongCount(ctx context.Context, playlistID string, count int) error
 	IncrementSongCount(ctx context.Context, playlistID string) error
 	DecrementSongCount(ctx context.Context, playlistID string) error
 	SoftDelete(ctx context.Context, id string) error
 	Restore(ctx context.Context, id string) error
 	HardDelete(ctx context.Context, id string) error
+	GetDeleted(ctx context.Context, id string) (*domain.UserPlaylist, error)
+	ListDeletedByUser(ctx context.Context, userID string, deletedSince time.Time, limit, offset int) ([]*domain.UserPlaylist, error)
+	CountDeletedByUser(ctx context.Context, userID string, deletedSince time.Time) (int64, error)
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error)
+	ListAllByUser(ctx context.Context, userID string) ([]*domain.UserPlaylist, error)
+	DeleteAllByUser(ctx context.Context, userID string) (int64, error)
 }
 
 // PlaylistSongRepository 歌单歌曲仓储接口
@@ -57,4 +75,108 @@ type PlaylistSongRepository interface {
 	Exists(ctx context.Context, playlistID, songID string) (bool, error)
 	GetMaxPosition(ctx context.Context, playlistID string) (int, error)
 	DeleteAll(ctx context.Context, playlistID string) error
+	AddSongs(ctx context.Context, playlistID string, songs []*domain.PlaylistSong) (int64, error)
+	RemoveSongs(ctx context.Context, playlistID string, songIDs []string) (int64, error)
+	Move(ctx context.Context, playlistID, songID string, position int) error
+	Sort(ctx context.Context, playlistID, sortBy string, descending bool) error
+}
+
+// PlaylistMemberRepository 歌单协作成员仓储接口
+type PlaylistMemberRepository interface {
+	Upsert(ctx context.Context, member *domain.PlaylistMember) error
+	Get(ctx context.Context, playlistID, userID string) (*domain.PlaylistMember, error)
+	List(ctx context.Context, playlistID string) ([]*domain.PlaylistMember, error)
+	ListByUser(ctx context.Context, userID string) ([]*domain.PlaylistMember, error)
+	Count(ctx context.Context, playlistID string) (int64, error)
+	Remove(ctx context.Context, playlistID, userID string) error
+	DeleteAllByUser(ctx context.Context, userID string) (int64, error)
+	CreateInvite(ctx context.Context, invite *domain.PlaylistInvite) error
+	GetInvite(ctx context.Context, token string) (*domain.PlaylistInvite, error)
+	DeleteInvites(ctx context.Context, playlistID string) (int64, error)
+}
+
+// PlaylistFollowRepository 歌单关注仓储接口
+type PlaylistFollowRepository interface {
+	Follow(ctx context.Context, playlistID, userID string, followedAt time.Time) (bool, error)
+	Unfollow(ctx context.Context, playlistID, userID string) (bool, error)
+	IsFollowing(ctx context.Context, playlistID, userID string) (bool, error)
+	ListFollowed(ctx context.Context, userID string, limit, offset int) ([]*domain.UserPlaylist, error)
+	CountFollowed(ctx context.Context, userID string) (int64, error)
+	DeleteAllByUser(ctx context.Context, userID string) (int64, error)
+}
+
+// SmartPlaylistRepository 智能歌单仓储接口
+type SmartPlaylistRepository interface {
+	ListLibrary(ctx context.Context, userID string) ([]*domain.LibrarySong, error)
+}
+
+// SmartPlaylistCache 智能歌单计算结果缓存接口
+type SmartPlaylistCache interface {
+	GetSongs(ctx context.Context, userID, playlistID string) ([]*domain.PlaylistSong, bool, error)
+	SaveSongs(ctx context.Context, userID, playlistID string, songs []*domain.PlaylistSong, ttl time.Duration) error
+	Invalidate(ctx context.Context, userID string) error
+}
+
+// ListeningStatsRepository 听歌统计仓储接口
+type ListeningStatsRepository interface {
+	ListPendingPlays(ctx context.Context, limit int) ([]*domain.PlayHistory, error)
+	ApplyRollup(ctx context.Context, rollup *domain.ListeningRollup, historyIDs []string, rolledUpAt time.Time) error
+	ListDaily(ctx context.Context, userID string, from, to time.Time) ([]*domain.DailyListening, error)
+	ListMonthly(ctx context.Context, userID string, from, to time.Time) ([]*domain.MonthlyListening, error)
+	TopSongsByDay(ctx context.Context, userID string, from, to time.Time, limit int) ([]*domain.SongListening, error)
+	TopSingersByDay(ctx context.Context, userID string, from, to time.Time, limit int) ([]*domain.SingerListening, error)
+	TopSongsByMonth(ctx context.Context, userID string, from, to time.Time, limit int) ([]*domain.SongListening, error)
+	TopSingersByMonth(ctx context.Context, userID string, from, to time.Time, limit int) ([]*domain.SingerListening, error)
+	CountDistinctByMonth(ctx context.Context, userID string, from, to time.Time) (songs, singers int64, err error)
+	PruneDailyDetails(ctx context.Context, before time.Time) (int64, error)
+	DeleteAllByUser(ctx context.Context, userID string) (int64, error)
+}
+
+// RecommendationRepository 推荐信号仓储接口
+type RecommendationRepository interface {
+	ListSignalUserIDs(ctx context.Context, since time.Time, afterUserID string, limit int) ([]string, error)
+	ListUserSignals(ctx context.Context, userID string, since time.Time, limit int) ([]*domain.SongSignal, error)
+}
+
+// RecommendationCache 推荐结果缓存接口
+type RecommendationCache interface {
+	SaveRecommendations(ctx context.Context, recs *domain.UserRecommendations, ttl time.Duration) error
+	GetRecommendations(ctx context.Context, userID string) (*domain.UserRecommendations, error)
+	DeleteRecommendations(ctx context.Context, userID string) error
+	SaveSimilarSongs(ctx context.Context, songID string, songs []*domain.RecommendedSong, ttl time.Duration) error
+	GetSimilarSongs(ctx context.Context, songID string) ([]*domain.RecommendedSong, bool, error)
+}
+
+// SingerReleaseRepository 关注歌手新发行仓储接口
+type SingerReleaseRepository interface {
+	ListFollowedSingerIDs(ctx context.Context) ([]string, error)
+	HasReleases(ctx context.Context, singerID string) (bool, error)
+	SaveReleases(ctx context.Context, releases []*domain.SingerRelease) (int64, error)
+	ListForUser(ctx context.Context, userID string, limit, offset int) ([]*domain.SingerRelease, error)
+	CountForUser(ctx context.Context, userID string) (int64, error)
+}
+
+// PlaybackRepository 播放队列和播放进度仓储接口（Redis回写的持久化存储）
+type PlaybackRepository interface {
+	GetQueue(ctx context.Context, userID string) (*domain.PlayQueue, error)
+	UpsertQueues(ctx context.Context, queues []*domain.PlayQueue) error
+	GetPositions(ctx context.Context, userID string, songIDs []string) ([]*domain.PlaybackPosition, error)
+	ListPositions(ctx context.Context, userID string) ([]*domain.PlaybackPosition, error)
+	UpsertPositions(ctx context.Context, positions []*domain.PlaybackPosition) error
+	DeleteAllByUser(ctx context.Context, userID string) (int64, error)
+}
+
+// PlaybackCache 播放队列和播放进度缓存接口
+// 客户端的写入只进入缓存并标记为待回写，由定时任务批量回写到PlaybackRepository
+type PlaybackCache interface {
+	SaveQueue(ctx context.Context, queue *domain.PlayQueue, dirty bool) error
+	GetQueue(ctx context.Context, userID string) (*domain.PlayQueue, bool, error)
+	SavePosition(ctx context.Context, position *domain.PlaybackPosition) error
+	GetPositions(ctx context.Context, userID string, songIDs []string) (map[string]*domain.PlaybackPosition, error)
+	ListPositions(ctx context.Context, userID string) ([]*domain.PlaybackPosition, error)
+	PopDirtyQueues(ctx context.Context, count int) ([]*domain.PlayQueue, error)
+	PopDirtyPositions(ctx context.Context, count int) ([]*domain.PlaybackPosition, error)
+	MarkQueuesDirty(ctx context.Context, userIDs []string) error
+	MarkPositionsDirty(ctx context.Context, positions []*domain.PlaybackPosition) error
+	DeleteUser(ctx context.Context, userID string) error
 }
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+
+	"user-svc/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SingerReleaseRepositoryImpl 关注歌手新发行仓储实现
+// 关注歌手即target_type为singer的收藏，song_id为歌手ID
+type SingerReleaseRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewSingerReleaseRepository 创建关注歌手新发行仓储
+func NewSingerReleaseRepository(db *pgxpool.Pool) SingerReleaseRepository {
+	return &SingerReleaseRepositoryImpl{db: db}
+}
+
+// ListFollowedSingerIDs 获取至少被一个用户关注的歌手ID
+func (r *SingerReleaseRepositoryImpl) ListFollowedSingerIDs(ctx context.Context) ([]string, error) {
+	query := `
+		SELECT DISTINCT song_id
+		FROM favorites
+		WHERE target_type = 'singer' AND deleted_at IS NULL
+		ORDER BY song_id
+	`
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var singerIDs []string
+	for rows.Next() {
+		var singerID string
+		if err := rows.Scan(&singerID); err != nil {
+			return nil, err
+		}
+		singerIDs = append(singerIDs, singerID)
+	}
+	return singerIDs, rows.Err()
+}
+
+// HasReleases 检查是否已拉取过歌手的专辑（包括基线）
+func (r *SingerReleaseRepositoryImpl) HasReleases(ctx context.Context, singerID string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM singer_releases WHERE singer_id = $1)`
+	var exists bool
+	err := r.db.QueryRow(ctx, query, singerID).Scan(&exists)
+	return exists, err
+}
+
+// SaveReleases 保存拉取到的专辑，已存在的专辑跳过；返回新增数量
+func (r *SingerReleaseRepositoryImpl) SaveReleases(ctx context.Context, releases []*domain.SingerRelease) (int64, error) {
+	if len(releases) == 0 {
+		return 0, nil
+	}
+
+	batch := &pgx.Batch{}
+	for _, release := range releases {
+		batch.Queue(`
+			INSERT INTO singer_releases (singer_id, album_id, album_name, singer_name, cover_url, release_date, baseline, discovered_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			ON CONFLICT (singer_id, album_id) DO NOTHING
+		`,
+			release.SingerID,
+			release.AlbumID,
+			release.AlbumName,
+			release.SingerName,
+			release.CoverURL,
+			release.ReleaseDate,
+			release.Baseline,
+			release.DiscoveredAt,
+		)
+	}
+
+	results := r.db.SendBatch(ctx, batch)
+	defer results.Close()
+
+	var inserted int64
+	for range releases {
+		tag, err := results.Exec()
+		if err != nil {
+			return inserted, err
+		}
+		inserted += tag.RowsAffected()
+	}
+	return inserted, nil
+}
+
+// ListForUser 获取用户关注的歌手在关注之后发现的新专辑，最近发现的在前
+func (r *SingerReleaseRepositoryImpl) ListForUser(ctx context.Context, userID string, limit, offset int) ([]*domain.SingerRelease, error) {
+	query := `
+		SELECT r.singer_id, r.singer_name, r.album_id, r.album_name, r.cover_url, r.release_date, r.baseline, r.discovered_at
+		FROM singer_releases r
+		JOIN favorites f ON f.song_id = r.singer_id
+		WHERE f.user_id = $1 AND f.target_type = 'singer' AND f.deleted_at IS NULL
+			AND r.baseline = FALSE AND r.discovered_at > f.created_at
+		ORDER BY r.discovered_at DESC, r.album_id
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.Query(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var releases []*domain.SingerRelease
+	for rows.Next() {
+		var release domain.SingerRelease
+		if err := rows.Scan(
+			&release.SingerID,
+			&release.SingerName,
+			&release.AlbumID,
+			&release.AlbumName,
+			&release.CoverURL,
+			&release.ReleaseDate,
+			&release.Baseline,
+			&release.DiscoveredAt,
+		); err != nil {
+			return nil, err
+		}
+		releases = append(releases, &release)
+	}
+	return releases, rows.Err()
+}
+
+// CountForUser 统计用户关注的歌手在关注之后发现的新专辑数量
+func (r *SingerReleaseRepositoryImpl) CountForUser(ctx context.Context, userID string) (int64, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM singer_releases r
+		JOIN favorites f ON f.song_id = r.singer_id
+		WHERE f.user_id = $1 AND f.target_type = 'singer' AND f.deleted_at IS NULL
+			AND r.baseline = FALSE AND r.discovered_at > f.created_at
+	`
+	var count int64
+	err := r.db.QueryRow(ctx, query, userID).Scan(&count)
+	return count, err
+}
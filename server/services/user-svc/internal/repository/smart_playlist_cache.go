@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"user-svc/internal/domain"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// smartPlaylistKeyPrefix 每个用户一个Hash，field为歌单ID，失效时整体删除
+const smartPlaylistKeyPrefix = "smartpl:user:"
+
+// SmartPlaylistCacheImpl 基于Redis的智能歌单计算结果缓存
+type SmartPlaylistCacheImpl struct {
+	client *redis.Client
+}
+
+// NewSmartPlaylistCache 创建智能歌单缓存
+func NewSmartPlaylistCache(client *redis.Client) SmartPlaylistCache {
+	return &SmartPlaylistCacheImpl{client: client}
+}
+
+// cachedSmartPlaylist Hash field不能单独设置过期时间，过期时间随数据一起存储
+type cachedSmartPlaylist struct {
+	Songs     []*domain.PlaylistSong `json:"songs"`
+	ExpiresAt time.Time              `json:"expires_at"`
+}
+
+// GetSongs 获取缓存的智能歌单歌曲，第二个返回值表示是否命中
+func (c *SmartPlaylistCacheImpl) GetSongs(ctx context.Context, userID, playlistID string) ([]*domain.PlaylistSong, bool, error) {
+	data, err := c.client.HGet(ctx, smartPlaylistKeyPrefix+userID, playlistID).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	var cached cachedSmartPlaylist
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, false, fmt.Errorf("unmarshal smart playlist: %w", err)
+	}
+	if time.Now().After(cached.ExpiresAt) {
+		return nil, false, nil
+	}
+	return cached.Songs, true, nil
+}
+
+// SaveSongs 缓存智能歌单歌曲
+func (c *SmartPlaylistCacheImpl) SaveSongs(ctx context.Context, userID, playlistID string, songs []*domain.PlaylistSong, ttl time.Duration) error {
+	if songs == nil {
+		songs = []*domain.PlaylistSong{}
+	}
+	data, err := json.Marshal(cachedSmartPlaylist{Songs: songs, ExpiresAt: time.Now().Add(ttl)})
+	if err != nil {
+		return fmt.Errorf("marshal smart playlist: %w", err)
+	}
+
+	key := smartPlaylistKeyPrefix + userID
+	pipe := c.client.TxPipeline()
+	pipe.HSet(ctx, key, playlistID, data)
+	pipe.Expire(ctx, key, ttl)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// Invalidate 删除用户全部智能歌单缓存（收藏或播放数据变化时调用）
+func (c *SmartPlaylistCacheImpl) Invalidate(ctx context.Context, userID string) error {
+	return c.client.Del(ctx, smartPlaylistKeyPrefix+userID).Err()
+}
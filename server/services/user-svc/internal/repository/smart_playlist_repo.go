@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+
+	"user-svc/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SmartPlaylistRepositoryImpl 智能歌单仓储实现
+type SmartPlaylistRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewSmartPlaylistRepository 创建智能歌单仓储
+func NewSmartPlaylistRepository(db *pgxpool.Pool) SmartPlaylistRepository {
+	return &SmartPlaylistRepositoryImpl{db: db}
+}
+
+// ListLibrary 汇总用户曲库（收藏过或播放过的歌曲）
+// 播放次数 = 月汇总 + 尚未汇总的播放记录；最后播放时间取播放记录、每日明细、月汇总中最新的一条，
+// 只剩月汇总时按当月1日估算
+func (r *SmartPlaylistRepositoryImpl) ListLibrary(ctx context.Context, userID string) ([]*domain.LibrarySong, error) {
+	query := `
+		SELECT song_id,
+			(array_agg(song_name ORDER BY priority))[1],
+			(array_agg(singer_name ORDER BY priority))[1],
+			MAX(favorited_at),
+			SUM(play_count)::BIGINT,
+			MAX(last_played_at)
+		FROM (
+			SELECT song_id, song_name, singer_name, 1 AS priority,
+				created_at AS favorited_at, 0::BIGINT AS play_count, NULL::TIMESTAMP AS last_played_at
+			FROM favorites
+			WHERE user_id = $1 AND deleted_at IS NULL AND target_type = 'song'
+			UNION ALL
+			SELECT song_id, song_name, singer_name, 2,
+				NULL, CASE WHEN rolled_up_at IS NULL THEN 1 ELSE 0 END, played_at
+			FROM play_histories
+			WHERE user_id = $1
+			UNION ALL
+			SELECT song_id, song_name, singer_name, 3,
+				NULL, 0, day::TIMESTAMP
+			FROM listening_daily_songs
+			WHERE user_id = $1
+			UNION ALL
+			SELECT song_id, song_name, singer_name, 4,
+				NULL, play_count, month::TIMESTAMP
+			FROM listening_monthly_songs
+			WHERE user_id = $1
+		) s
+		GROUP BY song_id
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var library []*domain.LibrarySong
+	for rows.Next() {
+		var song domain.LibrarySong
+		if err := rows.Scan(
+			&song.SongID,
+			&song.SongName,
+			&song.SingerName,
+			&song.FavoritedAt,
+			&song.PlayCount,
+			&song.LastPlayedAt,
+		); err != nil {
+			return nil, err
+		}
+		library = append(library, &song)
+	}
+
+	return library, rows.Err()
+}
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"user-svc/internal/domain"
+	"user-svc/internal/repository"
+)
+
+// exportPageSize 导出分页读取的每页条数
+const exportPageSize = 100
+
+// AccountDataService 账号数据服务（数据导出、注销账号时删除数据）
+type AccountDataService struct {
+	favoriteRepo     repository.FavoriteRepository
+	historyRepo      repository.PlayHistoryRepository
+	playlistRepo     repository.PlaylistRepository
+	playlistSongRepo repository.PlaylistSongRepository
+	memberRepo       repository.PlaylistMemberRepository
+	followRepo       repository.PlaylistFollowRepository
+	statsRepo        repository.ListeningStatsRepository
+	recsCache        repository.RecommendationCache
+	playbackRepo     repository.PlaybackRepository
+	playbackCache    repository.PlaybackCache
+}
+
+// NewAccountDataService 创建账号数据服务
+func NewAccountDataService(
+	favoriteRepo repository.FavoriteRepository,
+	historyRepo repository.PlayHistoryRepository,
+	playlistRepo repository.PlaylistRepository,
+	playlistSongRepo repository.PlaylistSongRepository,
+	memberRepo repository.PlaylistMemberRepository,
+	followRepo repository.PlaylistFollowRepository,
+	statsRepo repository.ListeningStatsRepository,
+	recsCache repository.RecommendationCache,
+	playbackRepo repository.PlaybackRepository,
+	playbackCache repository.PlaybackCache,
+) *AccountDataService {
+	return &AccountDataService{
+		favoriteRepo:     favoriteRepo,
+		historyRepo:      historyRepo,
+		playlistRepo:     playlistRepo,
+		playlistSongRepo: playlistSongRepo,
+		memberRepo:       memberRepo,
+		followRepo:       followRepo,
+		statsRepo:        statsRepo,
+		recsCache:        recsCache,
+		playbackRepo:     playbackRepo,
+		playbackCache:    playbackCache,
+	}
+}
+
+// ExportUserData 导出用户的全部数据（包括已软删除但尚未清理的收藏和歌单）
+func (s *AccountDataService) ExportUserData(ctx context.Context, userID string) (*domain.UserDataExport, error) {
+	if userID == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	favorites, err := s.favoriteRepo.ListAllByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list favorites: %w", err)
+	}
+
+	playlists, err := s.playlistRepo.ListAllByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list playlists: %w", err)
+	}
+
+	playlistExports := make([]*domain.PlaylistExport, 0, len(playlists))
+	for _, playlist := range playlists {
+		songs, err := s.playlistSongRepo.List(ctx, playlist.ID)
+		if err != nil {
+			return nil, fmt.Errorf("list songs of playlist %s: %w", playlist.ID, err)
+		}
+		playlistExports = append(playlistExports, &domain.PlaylistExport{
+			Playlist: playlist,
+			Songs:    songs,
+		})
+	}
+
+	// 播放历史每用户最多保留MaxHistoryCount条
+	histories, err := s.historyRepo.ListByUser(ctx, userID, MaxHistoryCount, 0)
+	if err != nil {
+		return nil, fmt.Errorf("list play histories: %w", err)
+	}
+
+	now := time.Now()
+	daily, err := s.statsRepo.ListDaily(ctx, userID, time.Time{}, now)
+	if err != nil {
+		return nil, fmt.Errorf("list daily listening stats: %w", err)
+	}
+	monthly, err := s.statsRepo.ListMonthly(ctx, userID, time.Time{}, now)
+	if err != nil {
+		return nil, fmt.Errorf("list monthly listening stats: %w", err)
+	}
+
+	queue, positions, err := s.exportPlayback(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	memberships, err := s.memberRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list playlist memberships: %w", err)
+	}
+
+	var followed []*domain.UserPlaylist
+	for offset := 0; ; offset += exportPageSize {
+		page, err := s.followRepo.ListFollowed(ctx, userID, exportPageSize, offset)
+		if err != nil {
+			return nil, fmt.Errorf("list followed playlists: %w", err)
+		}
+		followed = append(followed, page...)
+		if len(page) < exportPageSize {
+			break
+		}
+	}
+
+	return &domain.UserDataExport{
+		UserID:            userID,
+		Favorites:         favorites,
+		Playlists:         playlistExports,
+		Histories:         histories,
+		DailyListening:    daily,
+		MonthlyListening:  monthly,
+		PlayQueue:         queue,
+		PlaybackPositions: positions,
+		Memberships:       memberships,
+		FollowedPlaylists: followed,
+	}, nil
+}
+
+// exportPlayback 导出播放队列和播放进度
+// 缓存中的数据可能尚未回写数据库，与数据库中的数据合并，同一首歌取更新的进度
+func (s *AccountDataService) exportPlayback(ctx context.Context, userID string) (*domain.PlayQueue, []*domain.PlaybackPosition, error) {
+	queue, ok, err := s.playbackCache.GetQueue(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get cached play queue: %w", err)
+	}
+	if !ok {
+		queue, err = s.playbackRepo.GetQueue(ctx, userID)
+		if errors.Is(err, domain.ErrPlayQueueNotFound) {
+			queue, err = nil, nil
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("get play queue: %w", err)
+		}
+	}
+
+	stored, err := s.playbackRepo.ListPositions(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list playback positions: %w", err)
+	}
+	cached, err := s.playbackCache.ListPositions(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list cached playback positions: %w", err)
+	}
+
+	bySong := make(map[string]*domain.PlaybackPosition, len(stored)+len(cached))
+	for _, position := range append(stored, cached...) {
+		if existing, ok := bySong[position.SongID]; !ok || position.UpdatedAt.After(existing.UpdatedAt) {
+			bySong[position.SongID] = position
+		}
+	}
+	positions := make([]*domain.PlaybackPosition, 0, len(bySong))
+	for _, position := range bySong {
+		positions = append(positions, position)
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		return positions[i].UpdatedAt.After(positions[j].UpdatedAt)
+	})
+	return queue, positions, nil
+}
+
+// EraseUserData 永久删除用户的全部数据
+// 可重复调用：数据已删除时返回零计数
+func (s *AccountDataService) EraseUserData(ctx context.Context, userID string) (*domain.ErasureResult, error) {
+	if userID == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	result := &domain.ErasureResult{}
+	var err error
+
+	if result.FavoritesDeleted, err = s.favoriteRepo.DeleteAllByUser(ctx, userID); err != nil {
+		return nil, fmt.Errorf("delete favorites: %w", err)
+	}
+	if result.PlaylistsDeleted, err = s.playlistRepo.DeleteAllByUser(ctx, userID); err != nil {
+		return nil, fmt.Errorf("delete playlists: %w", err)
+	}
+	// 退出用户参与的他人协作歌单（自己的歌单删除时成员关系级联删除）
+	if result.MembershipsDeleted, err = s.memberRepo.DeleteAllByUser(ctx, userID); err != nil {
+		return nil, fmt.Errorf("delete playlist memberships: %w", err)
+	}
+	// 取消对他人公开歌单的关注，同时扣减关注数
+	if result.FollowsDeleted, err = s.followRepo.DeleteAllByUser(ctx, userID); err != nil {
+		return nil, fmt.Errorf("delete playlist follows: %w", err)
+	}
+	if result.HistoryDeleted, err = s.historyRepo.DeleteAllByUser(ctx, userID); err != nil {
+		return nil, fmt.Errorf("delete play histories: %w", err)
+	}
+	if result.StatsDeleted, err = s.statsRepo.DeleteAllByUser(ctx, userID); err != nil {
+		return nil, fmt.Errorf("delete listening stats: %w", err)
+	}
+	if err := s.recsCache.DeleteRecommendations(ctx, userID); err != nil {
+		return nil, fmt.Errorf("delete recommendations: %w", err)
+	}
+	// 先删缓存再删数据库，避免待回写的数据在删除后又被写回
+	if err := s.playbackCache.DeleteUser(ctx, userID); err != nil {
+		return nil, fmt.Errorf("delete cached playback state: %w", err)
+	}
+	if result.PlaybackDeleted, err = s.playbackRepo.DeleteAllByUser(ctx, userID); err != nil {
+		return nil, fmt.Errorf("delete playback state: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"user-svc/internal/domain"
+	"user-svc/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// exportFavoriteRepository 只实现导出用到的方法（用于测试）
+type exportFavoriteRepository struct {
+	repository.FavoriteRepository
+	favorites []*domain.Favorite
+}
+
+func (r *exportFavoriteRepository) ListAllByUser(ctx context.Context, userID string) ([]*domain.Favorite, error) {
+	return r.favorites, nil
+}
+
+// exportPlaylistRepository 只实现导出用到的方法（用于测试）
+type exportPlaylistRepository struct {
+	repository.PlaylistRepository
+}
+
+func (r *exportPlaylistRepository) ListAllByUser(ctx context.Context, userID string) ([]*domain.UserPlaylist, error) {
+	return nil, nil
+}
+
+// exportHistoryRepository 只实现导出用到的方法（用于测试）
+type exportHistoryRepository struct {
+	repository.PlayHistoryRepository
+}
+
+func (r *exportHistoryRepository) ListByUser(ctx context.Context, userID string, limit, offset int) ([]*domain.PlayHistory, error) {
+	return nil, nil
+}
+
+// exportFollowRepository 按分页返回关注的歌单（用于测试）
+type exportFollowRepository struct {
+	repository.PlaylistFollowRepository
+	followed []*domain.UserPlaylist
+	calls    int
+}
+
+func (r *exportFollowRepository) ListFollowed(ctx context.Context, userID string, limit, offset int) ([]*domain.UserPlaylist, error) {
+	r.calls++
+	if offset >= len(r.followed) {
+		return nil, nil
+	}
+	end := offset + limit
+	if end > len(r.followed) {
+		end = len(r.followed)
+	}
+	return r.followed[offset:end], nil
+}
+
+func TestAccountDataService_ExportUserData(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	userID := "user-1"
+
+	stats := newMemoryStatsRepository()
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	stats.daily[day] = &domain.DailyListening{UserID: userID, Day: day, PlayCount: 3}
+	stats.monthly[day] = &domain.MonthlyListening{UserID: userID, Month: day, PlayCount: 3}
+
+	members := newMemoryPlaylistMemberRepository()
+	require.NoError(t, members.Upsert(ctx, &domain.PlaylistMember{PlaylistID: "pl-other", UserID: userID, Role: "editor", JoinedAt: now}))
+	require.NoError(t, members.Upsert(ctx, &domain.PlaylistMember{PlaylistID: "pl-other", UserID: "user-2", Role: "editor", JoinedAt: now}))
+
+	follows := &exportFollowRepository{}
+	for i := 0; i < exportPageSize+1; i++ {
+		follows.followed = append(follows.followed, &domain.UserPlaylist{ID: fmt.Sprintf("pl-%d", i)})
+	}
+
+	// 数据库中已回写的队列和进度
+	repo := &memoryPlaybackRepository{store: newMemoryPlaybackStore()}
+	require.NoError(t, repo.UpsertQueues(ctx, []*domain.PlayQueue{{UserID: userID, Tracks: []*domain.QueueTrack{{SongID: "song-1"}}}}))
+	require.NoError(t, repo.UpsertPositions(ctx, []*domain.PlaybackPosition{
+		{UserID: userID, SongID: "song-1", PositionMs: 1000, UpdatedAt: now.Add(-time.Hour)},
+		{UserID: userID, SongID: "song-2", PositionMs: 2000, UpdatedAt: now.Add(-2 * time.Hour)},
+	}))
+	// 缓存中尚未回写的进度
+	cache := newMemoryPlaybackStore()
+	require.NoError(t, cache.SavePosition(ctx, &domain.PlaybackPosition{UserID: userID, SongID: "song-1", PositionMs: 5000, UpdatedAt: now}))
+	require.NoError(t, cache.SavePosition(ctx, &domain.PlaybackPosition{UserID: userID, SongID: "song-3", PositionMs: 3000, UpdatedAt: now.Add(-time.Minute)}))
+
+	svc := NewAccountDataService(
+		&exportFavoriteRepository{favorites: []*domain.Favorite{{ID: "fav-1", UserID: userID}}},
+		&exportHistoryRepository{},
+		&exportPlaylistRepository{},
+		nil,
+		members,
+		follows,
+		stats,
+		nil,
+		repo,
+		cache,
+	)
+
+	export, err := svc.ExportUserData(ctx, userID)
+	require.NoError(t, err)
+
+	assert.Len(t, export.Favorites, 1)
+	require.Len(t, export.DailyListening, 1)
+	assert.Equal(t, int64(3), export.DailyListening[0].PlayCount)
+	require.Len(t, export.MonthlyListening, 1)
+
+	// 缓存中没有队列时读取数据库
+	require.NotNil(t, export.PlayQueue)
+	assert.Equal(t, "song-1", export.PlayQueue.Tracks[0].SongID)
+
+	// 同一首歌取更新的进度，按更新时间倒序
+	require.Len(t, export.PlaybackPositions, 3)
+	assert.Equal(t, "song-1", export.PlaybackPositions[0].SongID)
+	assert.Equal(t, int64(5000), export.PlaybackPositions[0].PositionMs)
+	assert.Equal(t, "song-3", export.PlaybackPositions[1].SongID)
+	assert.Equal(t, "song-2", export.PlaybackPositions[2].SongID)
+
+	require.Len(t, export.Memberships, 1)
+	assert.Equal(t, "pl-other", export.Memberships[0].PlaylistID)
+
+	assert.Len(t, export.FollowedPlaylists, exportPageSize+1)
+	assert.Equal(t, 2, follows.calls)
+}
+
+func TestAccountDataService_ExportUserData_NoPlayback(t *testing.T) {
+	svc := NewAccountDataService(
+		&exportFavoriteRepository{},
+		&exportHistoryRepository{},
+		&exportPlaylistRepository{},
+		nil,
+		newMemoryPlaylistMemberRepository(),
+		&exportFollowRepository{},
+		newMemoryStatsRepository(),
+		nil,
+		&memoryPlaybackRepository{store: newMemoryPlaybackStore()},
+		newMemoryPlaybackStore(),
+	)
+
+	export, err := svc.ExportUserData(context.Background(), "user-1")
+	require.NoError(t, err)
+	assert.Nil(t, export.PlayQueue)
+	assert.Empty(t, export.PlaybackPositions)
+	assert.Empty(t, export.FollowedPlaylists)
+}
@@ -11,6 +11,8 @@ import (
 const (
 	// MaxHistoryCount 每个用户保留的最大历史记录数
 	MaxHistoryCount = 500
+	// MaxHistoryHardLimit 未汇总的记录也会被删除的上限，防止听歌统计汇总长期失败时历史无限增长
+	MaxHistoryHardLimit = MaxHistoryCount * 4
 )
 
 // CleanupService 清理服务
@@ -32,6 +34,7 @@ type CleanupStats struct {
 	TotalUsers     int
 	CleanedUsers   int
 	FailedUsers    int
+	PendingUsers   int // 因记录未汇总而超出保留条数的用户数
 	TotalRecords   int64
 	DeletedRecords int64
 	Errors         []string
@@ -71,17 +74,23 @@ func (s *CleanupService) CleanupAllUserHistories(ctx context.Context) error {
 
 		// 如果超过500条，执行清理
 		if count > MaxHistoryCount {
-			if err := s.historyRepo.Cleanup(ctx, userID, MaxHistoryCount); err != nil {
+			deleted, err := s.historyRepo.Cleanup(ctx, userID, MaxHistoryCount, MaxHistoryHardLimit)
+			if err != nil {
 				log.Printf("Failed to cleanup histories for user %s: %v", userID, err)
 				stats.FailedUsers++
 				stats.Errors = append(stats.Errors, err.Error())
 				continue
 			}
 
-			deleted := count - MaxHistoryCount
 			stats.DeletedRecords += deleted
 			stats.CleanedUsers++
 			log.Printf("Cleaned up %d records for user %s (kept %d)", deleted, userID, MaxHistoryCount)
+
+			// 未汇总的记录不会被删除，剩余条数超出保留条数说明汇总落后
+			if remaining := count - deleted; remaining > MaxHistoryCount {
+				stats.PendingUsers++
+				log.Printf("WARNING: user %s keeps %d histories (limit %d, hard limit %d): records not rolled up into listening stats", userID, remaining, MaxHistoryCount, MaxHistoryHardLimit)
+			}
 		}
 	}
 
@@ -94,6 +103,7 @@ func (s *CleanupService) CleanupAllUserHistories(ctx context.Context) error {
 	log.Printf("  - Total users: %d", stats.TotalUsers)
 	log.Printf("  - Cleaned users: %d", stats.CleanedUsers)
 	log.Printf("  - Failed users: %d", stats.FailedUsers)
+	log.Printf("  - Users over limit pending rollup: %d", stats.PendingUsers)
 	log.Printf("  - Total records: %d", stats.TotalRecords)
 	log.Printf("  - Deleted records: %d", stats.DeletedRecords)
 
@@ -118,7 +128,8 @@ func (s *CleanupService) CleanupAllUserHistories(ctx context.Context) error {
 
 // CleanupUserHistory 清理指定用户的历史记录
 func (s *CleanupService) CleanupUserHistory(ctx context.Context, userID string, keepCount int) error {
-	return s.historyRepo.Cleanup(ctx, userID, keepCount)
+	_, err := s.historyRepo.Cleanup(ctx, userID, keepCount, MaxHistoryHardLimit)
+	return err
 }
 
 // CleanupAllUsers 清理所有用户的历史记录（别名方法）
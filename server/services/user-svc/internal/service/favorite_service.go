@@ -8,24 +8,39 @@ import (
 	"user-svc/internal/repository"
 
 	"github.com/google/uuid"
+	"github.com/listen-stream/server/shared/pkg/stats"
 )
 
 // FavoriteService 收藏服务
 type FavoriteService struct {
-	repo repository.FavoriteRepository
+	repo       repository.FavoriteRepository
+	smartCache repository.SmartPlaylistCache
+	metrics    stats.Emitter
 }
 
 // NewFavoriteService 创建收藏服务
-func NewFavoriteService(repo repository.FavoriteRepository) *FavoriteService {
+// smartCache为收藏变化时需要失效的智能歌单缓存，为nil时不处理；metrics用于上报运营统计，为nil时不上报
+func NewFavoriteService(repo repository.FavoriteRepository, smartCache repository.SmartPlaylistCache, metrics stats.Emitter) *FavoriteService {
+	if metrics == nil {
+		metrics = stats.NoopEmitter{}
+	}
 	return &FavoriteService{
-		repo: repo,
+		repo:       repo,
+		smartCache: smartCache,
+		metrics:    metrics,
 	}
 }
 
 // AddFavorite 添加收藏
-func (s *FavoriteService) AddFavorite(ctx context.Context, userID, songID, songName, singerName string) (*domain.Favorite, error) {
+// favorite需填写Type、SongID（目标ID）和名称等元数据，Type为空时视为歌曲
+func (s *FavoriteService) AddFavorite(ctx context.Context, userID string, favorite *domain.Favorite) (*domain.Favorite, error) {
+	favoriteType, err := domain.ValidateFavoriteType(favorite.Type)
+	if err != nil {
+		return nil, err
+	}
+
 	// 检查是否已存在
-	exists, err := s.repo.Exists(ctx, userID, songID)
+	exists, err := s.repo.Exists(ctx, userID, favoriteType, favorite.SongID)
 	if err != nil {
 		return nil, err
 	}
@@ -34,20 +49,25 @@ func (s *FavoriteService) AddFavorite(ctx context.Context, userID, songID, songN
 	}
 
 	// 创建收藏
-	favorite := &domain.Favorite{
+	created := &domain.Favorite{
 		ID:         uuid.New().String(),
 		UserID:     userID,
-		SongID:     songID,
-		SongName:   songName,
-		SingerName: singerName,
+		Type:       favoriteType,
+		SongID:     favorite.SongID,
+		SongName:   favorite.SongName,
+		SingerName: favorite.SingerName,
+		CoverURL:   favorite.CoverURL,
+		Extra:      favorite.Extra,
 		CreatedAt:  time.Now(),
 	}
 
-	if err := s.repo.Create(ctx, favorite); err != nil {
+	if err := s.repo.Create(ctx, created); err != nil {
 		return nil, err
 	}
+	invalidateSmartPlaylists(ctx, s.smartCache, userID)
+	s.metrics.Emit(stats.Event{Type: stats.EventFavorite, UserID: userID})
 
-	return favorite, nil
+	return created, nil
 }
 
 // RemoveFavorite 移除收藏（软删除）
@@ -67,7 +87,11 @@ func (s *FavoriteService) RemoveFavorite(ctx context.Context, userID, favoriteID
 	}
 
 	// 软删除
-	return s.repo.SoftDelete(ctx, favoriteID)
+	if err := s.repo.SoftDelete(ctx, favoriteID); err != nil {
+		return err
+	}
+	invalidateSmartPlaylists(ctx, s.smartCache, userID)
+	return nil
 }
 
 // GetFavorite 获取收藏详情
@@ -88,16 +112,22 @@ func (s *FavoriteService) GetFavorite(ctx context.Context, userID, favoriteID st
 	return favorite, nil
 }
 
-// ListFavorites 获取用户收藏列表
-func (s *FavoriteService) ListFavorites(ctx context.Context, userID string, limit, offset int) ([]*domain.Favorite, int64, error) {
+// ListFavorites 获取用户收藏列表，favoriteType为空时返回全部类型
+func (s *FavoriteService) ListFavorites(ctx context.Context, userID, favoriteType string, limit, offset int) ([]*domain.Favorite, int64, error) {
+	if favoriteType != "" {
+		if _, err := domain.ValidateFavoriteType(favoriteType); err != nil {
+			return nil, 0, err
+		}
+	}
+
 	// 获取列表
-	favorites, err := s.repo.ListByUser(ctx, userID, limit, offset)
+	favorites, err := s.repo.ListByUser(ctx, userID, favoriteType, limit, offset)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	// 获取总数
-	total, err := s.repo.Count(ctx, userID)
+	total, err := s.repo.Count(ctx, userID, favoriteType)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -105,18 +135,36 @@ func (s *FavoriteService) ListFavorites(ctx context.Context, userID string, limi
 	return favorites, total, nil
 }
 
-// CheckFavorite 检查歌曲是否已收藏
-func (s *FavoriteService) CheckFavorite(ctx context.Context, userID, songID string) (bool, error) {
-	return s.repo.Exists(ctx, userID, songID)
+// CountByType 按类型统计用户的收藏数量，每种类型都会出现在结果中
+func (s *FavoriteService) CountByType(ctx context.Context, userID string) (map[string]int64, error) {
+	counts, err := s.repo.CountByType(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range domain.FavoriteTypes {
+		if _, ok := counts[t]; !ok {
+			counts[t] = 0
+		}
+	}
+	return counts, nil
+}
+
+// CheckFavorite 检查目标是否已收藏，favoriteType为空时视为歌曲
+func (s *FavoriteService) CheckFavorite(ctx context.Context, userID, favoriteType, targetID string) (bool, error) {
+	favoriteType, err := domain.ValidateFavoriteType(favoriteType)
+	if err != nil {
+		return false, err
+	}
+	return s.repo.Exists(ctx, userID, favoriteType, targetID)
 }
 
 // GetFavorites 获取用户收藏列表（别名方法）
-func (s *FavoriteService) GetFavorites(ctx context.Context, userID string, page, pageSize int) ([]*domain.Favorite, int64, error) {
+func (s *FavoriteService) GetFavorites(ctx context.Context, userID, favoriteType string, page, pageSize int) ([]*domain.Favorite, int64, error) {
 	offset := (page - 1) * pageSize
-	return s.ListFavorites(ctx, userID, pageSize, offset)
+	return s.ListFavorites(ctx, userID, favoriteType, pageSize, offset)
 }
 
-// IsFavorite 检查歌曲是否已收藏（别名方法）
-func (s *FavoriteService) IsFavorite(ctx context.Context, userID, songID string) (bool, error) {
-	return s.CheckFavorite(ctx, userID, songID)
+// IsFavorite 检查目标是否已收藏（别名方法）
+func (s *FavoriteService) IsFavorite(ctx context.Context, userID, favoriteType, targetID string) (bool, error) {
+	return s.CheckFavorite(ctx, userID, favoriteType, targetID)
 }
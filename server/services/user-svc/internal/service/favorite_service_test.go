@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"user-svc/internal/domain"
+	"user-svc/internal/repository"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Create 收藏仓储的其他方法见trash_service_test.go
+func (r *memoryFavoriteRepository) Create(ctx context.Context, favorite *domain.Favorite) error {
+	r.favorites[favorite.ID] = favorite
+	return nil
+}
+
+// memoryHistoryRepository 内存播放历史仓储（用于测试，只实现用到的方法）
+type memoryHistoryRepository struct {
+	repository.PlayHistoryRepository
+	histories []*domain.PlayHistory
+}
+
+func (r *memoryHistoryRepository) Create(ctx context.Context, history *domain.PlayHistory) error {
+	r.histories = append(r.histories, history)
+	return nil
+}
+
+func (r *memoryHistoryRepository) Count(ctx context.Context, userID string) (int64, error) {
+	return int64(len(r.histories)), nil
+}
+
+// TestUserActivity_InvalidatesSmartPlaylists 测试收藏和播放后智能歌单缓存立即失效
+func TestUserActivity_InvalidatesSmartPlaylists(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+	cache := repository.NewSmartPlaylistCache(client)
+	ctx := context.Background()
+
+	cached := func() bool {
+		_, ok, err := cache.GetSongs(ctx, "u1", "smart-1")
+		require.NoError(t, err)
+		return ok
+	}
+
+	favorites := NewFavoriteService(&memoryFavoriteRepository{favorites: map[string]*domain.Favorite{}}, cache, nil)
+	require.NoError(t, cache.SaveSongs(ctx, "u1", "smart-1", nil, SmartPlaylistTTL))
+	_, err := favorites.AddFavorite(ctx, "u1", &domain.Favorite{SongID: "s1", SongName: "晴天"})
+	require.NoError(t, err)
+	assert.False(t, cached())
+
+	history := NewPlayHistoryService(&memoryHistoryRepository{}, cache, nil)
+	require.NoError(t, cache.SaveSongs(ctx, "u1", "smart-1", nil, SmartPlaylistTTL))
+	_, err = history.AddPlayHistory(ctx, "u1", "s1", "晴天", "周杰伦", "", 269)
+	require.NoError(t, err)
+	assert.False(t, cached())
+}
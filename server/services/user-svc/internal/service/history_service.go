@@ -8,6 +8,7 @@ import (
 	"user-svc/internal/repository"
 
 	"github.com/google/uuid"
+	"github.com/listen-stream/server/shared/pkg/stats"
 )
 
 const (
@@ -17,13 +18,21 @@ const (
 
 // PlayHistoryService 播放历史服务
 type PlayHistoryService struct {
-	repo repository.PlayHistoryRepository
+	repo       repository.PlayHistoryRepository
+	smartCache repository.SmartPlaylistCache
+	metrics    stats.Emitter
 }
 
 // NewPlayHistoryService 创建播放历史服务
-func NewPlayHistoryService(repo repository.PlayHistoryRepository) *PlayHistoryService {
+// smartCache为播放记录变化时需要失效的智能歌单缓存，为nil时不处理；metrics用于上报运营统计，为nil时不上报
+func NewPlayHistoryService(repo repository.PlayHistoryRepository, smartCache repository.SmartPlaylistCache, metrics stats.Emitter) *PlayHistoryService {
+	if metrics == nil {
+		metrics = stats.NoopEmitter{}
+	}
 	return &PlayHistoryService{
-		repo: repo,
+		repo:       repo,
+		smartCache: smartCache,
+		metrics:    metrics,
 	}
 }
 
@@ -50,6 +59,8 @@ func (s *PlayHistoryService) AddPlayHistory(ctx context.Context, userID, songID,
 	if err := s.repo.Create(ctx, history); err != nil {
 		return nil, err
 	}
+	invalidateSmartPlaylists(ctx, s.smartCache, userID)
+	s.metrics.Emit(stats.Event{Type: stats.EventPlay, UserID: userID})
 
 	// 检查是否超出限制，如果超出则清理旧记录
 	count, err := s.repo.Count(ctx, userID)
@@ -62,8 +73,7 @@ func (s *PlayHistoryService) AddPlayHistory(ctx context.Context, userID, songID,
 		// 异步清理，不阻塞主流程
 		go func() {
 			cleanCtx := context.Background()
-			deleteCount := int(count - MaxHistoryPerUser)
-			_ = s.repo.DeleteOldest(cleanCtx, userID, deleteCount)
+			_, _ = s.repo.Cleanup(cleanCtx, userID, MaxHistoryPerUser, MaxHistoryHardLimit)
 		}()
 	}
 
@@ -122,12 +132,17 @@ func (s *PlayHistoryService) DeletePlayHistory(ctx context.Context, userID, hist
 	}
 
 	// 删除
-	return s.repo.Delete(ctx, historyID)
+	if err := s.repo.Delete(ctx, historyID); err != nil {
+		return err
+	}
+	invalidateSmartPlaylists(ctx, s.smartCache, userID)
+	return nil
 }
 
 // CleanupUserHistory 清理用户历史记录（保留最新的N条）
 func (s *PlayHistoryService) CleanupUserHistory(ctx context.Context, userID string, keepCount int) error {
-	return s.repo.Cleanup(ctx, userID, keepCount)
+	_, err := s.repo.Cleanup(ctx, userID, keepCount, MaxHistoryHardLimit)
+	return err
 }
 
 // AddHistory 添加播放历史（别名方法）
@@ -0,0 +1,291 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"user-svc/internal/domain"
+	"user-svc/internal/repository"
+)
+
+const (
+	// RollupBatchSize 每批汇总的播放记录数
+	RollupBatchSize = 1000
+	// DailyDetailRetentionDays 每日歌曲/歌手明细保留天数（每日汇总和每月数据长期保留）
+	DailyDetailRetentionDays = 400
+	// MaxStatsRangeDays 单次统计查询的最大天数
+	MaxStatsRangeDays = 366
+	// DefaultTopLimit 默认排行数量
+	DefaultTopLimit = 10
+	// MaxTopLimit 最大排行数量
+	MaxTopLimit = 100
+	// maxRollupConflicts 汇总冲突（其他实例已汇总同一批记录）的最大重试次数
+	maxRollupConflicts = 3
+)
+
+// ListeningStatsService 听歌统计服务
+// 播放记录定期汇总到日/月汇总表，统计查询只读汇总表，因此最近一次汇总之后的播放不会立即体现
+type ListeningStatsService struct {
+	repo repository.ListeningStatsRepository
+	loc  *time.Location
+	now  func() time.Time
+}
+
+// NewListeningStatsService 创建听歌统计服务
+// loc为统计时区，决定按天/月划分和每小时分布，为nil时使用服务器本地时区
+func NewListeningStatsService(repo repository.ListeningStatsRepository, loc *time.Location) *ListeningStatsService {
+	if loc == nil {
+		loc = time.Local
+	}
+	return &ListeningStatsService{
+		repo: repo,
+		loc:  loc,
+		now:  time.Now,
+	}
+}
+
+// ParseDate 按统计时区解析日期（YYYY-MM-DD）
+func (s *ListeningStatsService) ParseDate(value string) (time.Time, error) {
+	t, err := time.ParseInLocation(time.DateOnly, value, s.loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %v", domain.ErrInvalidStatsRange, err)
+	}
+	return t, nil
+}
+
+// Today 统计时区的当天零点
+func (s *ListeningStatsService) Today() time.Time {
+	return domain.StartOfDay(s.now().In(s.loc))
+}
+
+// RollupPendingPlays 将尚未汇总的播放记录累加到汇总表，返回汇总的记录数
+// 多实例同时执行时，同一条记录只会被汇总一次
+func (s *ListeningStatsService) RollupPendingPlays(ctx context.Context) (int, error) {
+	total := 0
+	conflicts := 0
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+
+		plays, err := s.repo.ListPendingPlays(ctx, RollupBatchSize)
+		if err != nil {
+			return total, fmt.Errorf("list pending plays: %w", err)
+		}
+		if len(plays) == 0 {
+			return total, nil
+		}
+
+		historyIDs := make([]string, 0, len(plays))
+		for _, p := range plays {
+			historyIDs = append(historyIDs, p.ID)
+		}
+
+		rollup := domain.BuildListeningRollup(plays, s.loc)
+		if err := s.repo.ApplyRollup(ctx, rollup, historyIDs, s.now()); err != nil {
+			// 其他实例已汇总或用户删除了其中的记录，重新获取
+			if errors.Is(err, domain.ErrRollupConflict) && conflicts < maxRollupConflicts {
+				conflicts++
+				continue
+			}
+			return total, fmt.Errorf("apply rollup: %w", err)
+		}
+
+		total += len(plays)
+		if len(plays) < RollupBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// PruneDailyDetails 删除超过保留期的每日歌曲/歌手明细
+func (s *ListeningStatsService) PruneDailyDetails(ctx context.Context) (int64, error) {
+	before := domain.StartOfDay(s.now().In(s.loc)).AddDate(0, 0, -DailyDetailRetentionDays)
+	return s.repo.PruneDailyDetails(ctx, before)
+}
+
+// RunNightlyRollup 汇总全部未汇总记录并清理过期明细（在播放历史清理之前执行）
+func (s *ListeningStatsService) RunNightlyRollup(ctx context.Context) error {
+	rolledUp, err := s.RollupPendingPlays(ctx)
+	if err != nil {
+		return err
+	}
+
+	pruned, err := s.PruneDailyDetails(ctx)
+	if err != nil {
+		return fmt.Errorf("prune daily details: %w", err)
+	}
+
+	log.Printf("Listening stats rollup completed: rolled_up=%d, pruned_daily_details=%d", rolledUp, pruned)
+	return nil
+}
+
+// GetListeningStats 获取日期范围内的听歌统计（包含from和to当天）
+// 歌曲/歌手排行依赖每日明细，from需在明细保留期内
+func (s *ListeningStatsService) GetListeningStats(ctx context.Context, userID string, from, to time.Time, topLimit int) (*domain.ListeningSummary, error) {
+	if userID == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	from = domain.StartOfDay(from.In(s.loc))
+	to = domain.StartOfDay(to.In(s.loc))
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: from must not be after to", domain.ErrInvalidStatsRange)
+	}
+	if to.Sub(from) >= MaxStatsRangeDays*24*time.Hour {
+		return nil, fmt.Errorf("%w: range must not exceed %d days", domain.ErrInvalidStatsRange, MaxStatsRangeDays)
+	}
+	retentionStart := domain.StartOfDay(s.now().In(s.loc)).AddDate(0, 0, -DailyDetailRetentionDays)
+	if from.Before(retentionStart) {
+		return nil, fmt.Errorf("%w: from must be within the last %d days", domain.ErrInvalidStatsRange, DailyDetailRetentionDays)
+	}
+	topLimit = normalizeTopLimit(topLimit)
+
+	daily, err := s.repo.ListDaily(ctx, userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("list daily stats: %w", err)
+	}
+	topSongs, err := s.repo.TopSongsByDay(ctx, userID, from, to, topLimit)
+	if err != nil {
+		return nil, fmt.Errorf("list top songs: %w", err)
+	}
+	topSingers, err := s.repo.TopSingersByDay(ctx, userID, from, to, topLimit)
+	if err != nil {
+		return nil, fmt.Errorf("list top singers: %w", err)
+	}
+
+	summary := &domain.ListeningSummary{
+		UserID:     userID,
+		From:       from,
+		To:         to,
+		TopSongs:   nonNilSongs(topSongs),
+		TopSingers: nonNilSingers(topSingers),
+		Heatmap:    make([]int64, domain.HeatmapSize),
+		Daily:      make([]*domain.DailyListening, 0, len(daily)),
+	}
+	for _, d := range daily {
+		d.Day = s.dateInLoc(d.Day)
+		summary.PlayCount += d.PlayCount
+		summary.SecondsListened += d.SecondsListened
+		weekday := d.Day.Weekday()
+		for hour, plays := range d.HourPlays {
+			if hour < domain.HoursPerDay {
+				summary.Heatmap[domain.HeatmapIndex(weekday, hour)] += plays
+			}
+		}
+		summary.Daily = append(summary.Daily, d)
+	}
+	summary.MinutesListened = summary.SecondsListened / 60
+
+	return summary, nil
+}
+
+// GetYearInReview 获取年度听歌报告
+func (s *ListeningStatsService) GetYearInReview(ctx context.Context, userID string, year, topLimit int) (*domain.YearInReview, error) {
+	if userID == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+	if year < 2000 || year > s.now().In(s.loc).Year() {
+		return nil, domain.ErrInvalidStatsYear
+	}
+	topLimit = normalizeTopLimit(topLimit)
+
+	firstMonth := time.Date(year, time.January, 1, 0, 0, 0, 0, s.loc)
+	lastMonth := time.Date(year, time.December, 1, 0, 0, 0, 0, s.loc)
+	lastDay := time.Date(year, time.December, 31, 0, 0, 0, 0, s.loc)
+
+	months, err := s.repo.ListMonthly(ctx, userID, firstMonth, lastMonth)
+	if err != nil {
+		return nil, fmt.Errorf("list monthly stats: %w", err)
+	}
+	daily, err := s.repo.ListDaily(ctx, userID, firstMonth, lastDay)
+	if err != nil {
+		return nil, fmt.Errorf("list daily stats: %w", err)
+	}
+	topSongs, err := s.repo.TopSongsByMonth(ctx, userID, firstMonth, lastMonth, topLimit)
+	if err != nil {
+		return nil, fmt.Errorf("list top songs: %w", err)
+	}
+	topSingers, err := s.repo.TopSingersByMonth(ctx, userID, firstMonth, lastMonth, topLimit)
+	if err != nil {
+		return nil, fmt.Errorf("list top singers: %w", err)
+	}
+	distinctSongs, distinctSingers, err := s.repo.CountDistinctByMonth(ctx, userID, firstMonth, lastMonth)
+	if err != nil {
+		return nil, fmt.Errorf("count distinct songs: %w", err)
+	}
+
+	review := &domain.YearInReview{
+		UserID:          userID,
+		Year:            year,
+		DistinctSongs:   distinctSongs,
+		DistinctSingers: distinctSingers,
+		TopSongs:        nonNilSongs(topSongs),
+		TopSingers:      nonNilSingers(topSingers),
+		Months:          make([]*domain.MonthlyListening, 0, len(months)),
+		Heatmap:         make([]int64, domain.HeatmapSize),
+	}
+
+	var topMonth *domain.MonthlyListening
+	for _, m := range months {
+		m.Month = s.dateInLoc(m.Month)
+		review.PlayCount += m.PlayCount
+		review.SecondsListened += m.SecondsListened
+		for i, plays := range m.Heatmap {
+			if i < domain.HeatmapSize {
+				review.Heatmap[i] += plays
+			}
+		}
+		if topMonth == nil || m.SecondsListened > topMonth.SecondsListened {
+			topMonth = m
+		}
+		review.Months = append(review.Months, m)
+	}
+	if topMonth != nil {
+		month := topMonth.Month
+		review.TopMonth = &month
+	}
+	for _, d := range daily {
+		if d.PlayCount > 0 {
+			review.ListeningDays++
+		}
+	}
+	review.MinutesListened = review.SecondsListened / 60
+	review.PeakHour, review.PeakWeekday = domain.Peaks(review.Heatmap)
+
+	return review, nil
+}
+
+// dateInLoc 数据库DATE列读出为UTC零点，转换为统计时区的同一日期
+func (s *ListeningStatsService) dateInLoc(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, s.loc)
+}
+
+// normalizeTopLimit 规范化排行数量
+func normalizeTopLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultTopLimit
+	}
+	if limit > MaxTopLimit {
+		return MaxTopLimit
+	}
+	return limit
+}
+
+func nonNilSongs(songs []*domain.SongListening) []*domain.SongListening {
+	if songs == nil {
+		return []*domain.SongListening{}
+	}
+	return songs
+}
+
+func nonNilSingers(singers []*domain.SingerListening) []*domain.SingerListening {
+	if singers == nil {
+		return []*domain.SingerListening{}
+	}
+	return singers
+}
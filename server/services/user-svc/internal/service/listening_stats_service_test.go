@@ -0,0 +1,319 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"user-svc/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStatsRepository 内存听歌统计仓储（用于测试）
+type memoryStatsRepository struct {
+	pending   []*domain.PlayHistory
+	conflicts int // ApplyRollup返回冲突的次数
+	daily     map[time.Time]*domain.DailyListening
+	monthly   map[time.Time]*domain.MonthlyListening
+	songs     map[string]*domain.SongListening // 按月
+	singers   map[string]*domain.SingerListening
+	dailySong map[string]*domain.SongListening
+}
+
+func newMemoryStatsRepository() *memoryStatsRepository {
+	return &memoryStatsRepository{
+		daily:     make(map[time.Time]*domain.DailyListening),
+		monthly:   make(map[time.Time]*domain.MonthlyListening),
+		songs:     make(map[string]*domain.SongListening),
+		singers:   make(map[string]*domain.SingerListening),
+		dailySong: make(map[string]*domain.SongListening),
+	}
+}
+
+func (r *memoryStatsRepository) ListPendingPlays(ctx context.Context, limit int) ([]*domain.PlayHistory, error) {
+	if len(r.pending) > limit {
+		return r.pending[:limit], nil
+	}
+	return r.pending, nil
+}
+
+func (r *memoryStatsRepository) ApplyRollup(ctx context.Context, rollup *domain.ListeningRollup, historyIDs []string, rolledUpAt time.Time) error {
+	if r.conflicts > 0 {
+		r.conflicts--
+		return domain.ErrRollupConflict
+	}
+
+	rolled := make(map[string]bool, len(historyIDs))
+	for _, id := range historyIDs {
+		rolled[id] = true
+	}
+	remaining := r.pending[:0]
+	for _, p := range r.pending {
+		if !rolled[p.ID] {
+			remaining = append(remaining, p)
+		}
+	}
+	r.pending = remaining
+
+	for _, d := range rollup.Daily {
+		existing, ok := r.daily[d.Day]
+		if !ok {
+			r.daily[d.Day] = d
+			continue
+		}
+		existing.PlayCount += d.PlayCount
+		existing.SecondsListened += d.SecondsListened
+		for i := range d.HourPlays {
+			existing.HourPlays[i] += d.HourPlays[i]
+		}
+	}
+	for _, m := range rollup.Monthly {
+		existing, ok := r.monthly[m.Month]
+		if !ok {
+			r.monthly[m.Month] = m
+			continue
+		}
+		existing.PlayCount += m.PlayCount
+		existing.SecondsListened += m.SecondsListened
+		for i := range m.Heatmap {
+			existing.Heatmap[i] += m.Heatmap[i]
+		}
+	}
+	for _, s := range rollup.MonthlySongs {
+		if existing, ok := r.songs[s.SongID]; ok {
+			existing.PlayCount += s.PlayCount
+			existing.SecondsListened += s.SecondsListened
+		} else {
+			r.songs[s.SongID] = s
+		}
+	}
+	for _, s := range rollup.DailySongs {
+		if existing, ok := r.dailySong[s.SongID]; ok {
+			existing.PlayCount += s.PlayCount
+			existing.SecondsListened += s.SecondsListened
+		} else {
+			r.dailySong[s.SongID] = s
+		}
+	}
+	for _, s := range rollup.MonthlySingers {
+		if existing, ok := r.singers[s.SingerName]; ok {
+			existing.PlayCount += s.PlayCount
+			existing.SecondsListened += s.SecondsListened
+		} else {
+			r.singers[s.SingerName] = s
+		}
+	}
+	return nil
+}
+
+func (r *memoryStatsRepository) ListDaily(ctx context.Context, userID string, from, to time.Time) ([]*domain.DailyListening, error) {
+	var result []*domain.DailyListening
+	for day, d := range r.daily {
+		if d.UserID == userID && !day.Before(from) && !day.After(to) {
+			copied := *d
+			result = append(result, &copied)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Day.Before(result[j].Day) })
+	return result, nil
+}
+
+func (r *memoryStatsRepository) ListMonthly(ctx context.Context, userID string, from, to time.Time) ([]*domain.MonthlyListening, error) {
+	var result []*domain.MonthlyListening
+	for month, m := range r.monthly {
+		if m.UserID == userID && !month.Before(from) && !month.After(to) {
+			copied := *m
+			result = append(result, &copied)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Month.Before(result[j].Month) })
+	return result, nil
+}
+
+func topSongs(songs map[string]*domain.SongListening, limit int) []*domain.SongListening {
+	var result []*domain.SongListening
+	for _, s := range songs {
+		result = append(result, s)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].PlayCount > result[j].PlayCount })
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
+func (r *memoryStatsRepository) TopSongsByDay(ctx context.Context, userID string, from, to time.Time, limit int) ([]*domain.SongListening, error) {
+	return topSongs(r.dailySong, limit), nil
+}
+
+func (r *memoryStatsRepository) TopSingersByDay(ctx context.Context, userID string, from, to time.Time, limit int) ([]*domain.SingerListening, error) {
+	return nil, nil
+}
+
+func (r *memoryStatsRepository) TopSongsByMonth(ctx context.Context, userID string, from, to time.Time, limit int) ([]*domain.SongListening, error) {
+	return topSongs(r.songs, limit), nil
+}
+
+func (r *memoryStatsRepository) TopSingersByMonth(ctx context.Context, userID string, from, to time.Time, limit int) ([]*domain.SingerListening, error) {
+	var result []*domain.SingerListening
+	for _, s := range r.singers {
+		result = append(result, s)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].PlayCount > result[j].PlayCount })
+	return result, nil
+}
+
+func (r *memoryStatsRepository) CountDistinctByMonth(ctx context.Context, userID string, from, to time.Time) (int64, int64, error) {
+	return int64(len(r.songs)), int64(len(r.singers)), nil
+}
+
+func (r *memoryStatsRepository) PruneDailyDetails(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (r *memoryStatsRepository) DeleteAllByUser(ctx context.Context, userID string) (int64, error) {
+	return 0, nil
+}
+
+func play(id, songID, singer string, playedAt time.Time, duration int) *domain.PlayHistory {
+	return &domain.PlayHistory{
+		ID:         id,
+		UserID:     "user-1",
+		SongID:     songID,
+		SongName:   "song " + songID,
+		SingerName: singer,
+		Duration:   duration,
+		PlayedAt:   playedAt,
+	}
+}
+
+func TestBuildListeningRollup(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*3600)
+	// 2026-03-01 23:30 UTC 是本地时间 2026-03-02 07:30（周一）
+	plays := []*domain.PlayHistory{
+		play("1", "s1", "Singer A", time.Date(2026, 3, 1, 23, 30, 0, 0, time.UTC), 200),
+		play("2", "s1", "Singer A", time.Date(2026, 3, 2, 0, 10, 0, 0, time.UTC), 180),
+		play("3", "s2", "Singer B", time.Date(2026, 2, 28, 10, 0, 0, 0, time.UTC), 240),
+	}
+
+	rollup := domain.BuildListeningRollup(plays, loc)
+
+	require.Len(t, rollup.Daily, 2)
+	require.Len(t, rollup.Monthly, 2)
+	assert.Len(t, rollup.DailySongs, 2)
+	assert.Len(t, rollup.MonthlySingers, 2)
+
+	var march2 *domain.DailyListening
+	for _, d := range rollup.Daily {
+		if d.Day.Equal(time.Date(2026, 3, 2, 0, 0, 0, 0, loc)) {
+			march2 = d
+		}
+	}
+	require.NotNil(t, march2)
+	assert.Equal(t, int64(2), march2.PlayCount)
+	assert.Equal(t, int64(380), march2.SecondsListened)
+	assert.Equal(t, int64(1), march2.HourPlays[7])
+	assert.Equal(t, int64(1), march2.HourPlays[8])
+
+	for _, m := range rollup.Monthly {
+		if m.Month.Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, loc)) {
+			assert.Equal(t, int64(2), m.PlayCount)
+			assert.Equal(t, int64(1), m.Heatmap[domain.HeatmapIndex(time.Monday, 7)])
+		}
+	}
+}
+
+func TestRollupPendingPlays_RetriesConflict(t *testing.T) {
+	repo := newMemoryStatsRepository()
+	repo.conflicts = 1
+	now := time.Now()
+	repo.pending = []*domain.PlayHistory{
+		play("1", "s1", "Singer A", now.Add(-2*time.Hour), 200),
+		play("2", "s2", "Singer B", now.Add(-time.Hour), 100),
+	}
+	svc := NewListeningStatsService(repo, time.UTC)
+
+	rolledUp, err := svc.RollupPendingPlays(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, rolledUp)
+	assert.Empty(t, repo.pending)
+}
+
+func TestGetListeningStats(t *testing.T) {
+	repo := newMemoryStatsRepository()
+	svc := NewListeningStatsService(repo, time.UTC)
+	svc.now = func() time.Time { return time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC) }
+
+	// 2026-03-02 是周一
+	repo.pending = []*domain.PlayHistory{
+		play("1", "s1", "Singer A", time.Date(2026, 3, 2, 21, 0, 0, 0, time.UTC), 200),
+		play("2", "s1", "Singer A", time.Date(2026, 3, 2, 21, 30, 0, 0, time.UTC), 200),
+		play("3", "s2", "Singer B", time.Date(2026, 3, 3, 9, 0, 0, 0, time.UTC), 100),
+	}
+	_, err := svc.RollupPendingPlays(context.Background())
+	require.NoError(t, err)
+
+	from, _ := svc.ParseDate("2026-03-01")
+	to, _ := svc.ParseDate("2026-03-10")
+	summary, err := svc.GetListeningStats(context.Background(), "user-1", from, to, 0)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(3), summary.PlayCount)
+	assert.Equal(t, int64(500), summary.SecondsListened)
+	assert.Equal(t, int64(8), summary.MinutesListened)
+	assert.Len(t, summary.Daily, 2)
+	assert.Equal(t, int64(2), summary.Heatmap[domain.HeatmapIndex(time.Monday, 21)])
+	assert.Equal(t, int64(1), summary.Heatmap[domain.HeatmapIndex(time.Tuesday, 9)])
+	require.NotEmpty(t, summary.TopSongs)
+	assert.Equal(t, "s1", summary.TopSongs[0].SongID)
+}
+
+func TestGetListeningStats_InvalidRange(t *testing.T) {
+	svc := NewListeningStatsService(newMemoryStatsRepository(), time.UTC)
+	svc.now = func() time.Time { return time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC) }
+	ctx := context.Background()
+
+	_, err := svc.GetListeningStats(ctx, "user-1", time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), 10)
+	assert.ErrorIs(t, err, domain.ErrInvalidStatsRange)
+
+	_, err = svc.GetListeningStats(ctx, "user-1", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), 10)
+	assert.ErrorIs(t, err, domain.ErrInvalidStatsRange)
+
+	_, err = svc.GetListeningStats(ctx, "user-1", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), 10)
+	assert.ErrorIs(t, err, domain.ErrInvalidStatsRange)
+}
+
+func TestGetYearInReview(t *testing.T) {
+	repo := newMemoryStatsRepository()
+	svc := NewListeningStatsService(repo, time.UTC)
+	svc.now = func() time.Time { return time.Date(2026, 12, 31, 12, 0, 0, 0, time.UTC) }
+
+	repo.pending = []*domain.PlayHistory{
+		play("1", "s1", "Singer A", time.Date(2026, 1, 5, 22, 0, 0, 0, time.UTC), 300),
+		play("2", "s1", "Singer A", time.Date(2026, 6, 1, 22, 0, 0, 0, time.UTC), 300),
+		play("3", "s2", "Singer B", time.Date(2026, 6, 2, 22, 0, 0, 0, time.UTC), 300),
+		play("4", "s3", "Singer B", time.Date(2026, 6, 2, 8, 0, 0, 0, time.UTC), 60),
+	}
+	_, err := svc.RollupPendingPlays(context.Background())
+	require.NoError(t, err)
+
+	review, err := svc.GetYearInReview(context.Background(), "user-1", 2026, 5)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(4), review.PlayCount)
+	assert.Equal(t, int64(16), review.MinutesListened)
+	assert.Equal(t, int64(3), review.ListeningDays)
+	assert.Equal(t, int64(3), review.DistinctSongs)
+	assert.Equal(t, int64(2), review.DistinctSingers)
+	require.NotNil(t, review.TopMonth)
+	assert.Equal(t, time.June, review.TopMonth.Month())
+	assert.Equal(t, 22, review.PeakHour)
+	assert.Len(t, review.Months, 2)
+	assert.Equal(t, "s1", review.TopSongs[0].SongID)
+
+	_, err = svc.GetYearInReview(context.Background(), "user-1", 2027, 5)
+	assert.ErrorIs(t, err, domain.ErrInvalidStatsYear)
+}
@@ -0,0 +1,261 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"user-svc/internal/domain"
+	"user-svc/internal/repository"
+
+	"github.com/listen-stream/server/shared/pkg/syncevent"
+)
+
+const (
+	// MaxPlaybackPositionQuery 一次最多查询的歌曲播放进度数
+	MaxPlaybackPositionQuery = 100
+
+	// playbackFlushBatchSize 每批回写的播放队列/播放进度数
+	playbackFlushBatchSize = 500
+)
+
+// PlaybackService 播放队列和播放进度服务
+// 客户端高频上报的写入只进入Redis（write-behind），由定时任务批量回写到数据库；
+// 每次变更经sync-svc推送给用户的其他设备
+type PlaybackService struct {
+	repo     repository.PlaybackRepository
+	cache    repository.PlaybackCache
+	notifier syncevent.Publisher
+	now      func() time.Time
+}
+
+// NewPlaybackService 创建播放队列和播放进度服务，notifier为nil时不推送
+func NewPlaybackService(repo repository.PlaybackRepository, cache repository.PlaybackCache, notifier syncevent.Publisher) *PlaybackService {
+	if notifier == nil {
+		notifier = syncevent.NoopPublisher{}
+	}
+	return &PlaybackService{
+		repo:     repo,
+		cache:    cache,
+		notifier: notifier,
+		now:      time.Now,
+	}
+}
+
+// GetQueue 获取用户的播放队列，从未保存过时返回空队列
+func (s *PlaybackService) GetQueue(ctx context.Context, userID string) (*domain.PlayQueue, error) {
+	if userID == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	queue, ok, err := s.cache.GetQueue(ctx, userID)
+	if err != nil {
+		log.Printf("Failed to read cached play queue for user %s: %v", userID, err)
+	}
+	if ok {
+		return queue, nil
+	}
+
+	queue, err = s.repo.GetQueue(ctx, userID)
+	if errors.Is(err, domain.ErrPlayQueueNotFound) {
+		return &domain.PlayQueue{
+			UserID:     userID,
+			Tracks:     []*domain.QueueTrack{},
+			RepeatMode: domain.RepeatModeOff,
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.cache.SaveQueue(ctx, queue, false); err != nil {
+		log.Printf("Failed to cache play queue for user %s: %v", userID, err)
+	}
+	return queue, nil
+}
+
+// SaveQueue 替换用户的播放队列
+func (s *PlaybackService) SaveQueue(ctx context.Context, userID, deviceID string, queue *domain.PlayQueue) (*domain.PlayQueue, error) {
+	if userID == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+	if err := queue.Validate(); err != nil {
+		return nil, err
+	}
+	if queue.Tracks == nil {
+		queue.Tracks = []*domain.QueueTrack{}
+	}
+	queue.UserID = userID
+	queue.DeviceID = deviceID
+	queue.UpdatedAt = s.now()
+
+	if err := s.cache.SaveQueue(ctx, queue, true); err != nil {
+		return nil, err
+	}
+
+	s.publish(ctx, userID, syncevent.TypePlaybackQueueUpdated, map[string]interface{}{
+		"action":    "replaced",
+		"device_id": deviceID,
+		"queue":     queue,
+	})
+	return queue, nil
+}
+
+// SetCurrentIndex 切换当前播放的歌曲（不修改队列内容，推送时只发送下标）
+func (s *PlaybackService) SetCurrentIndex(ctx context.Context, userID, deviceID string, index int) (*domain.PlayQueue, error) {
+	queue, err := s.GetQueue(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if index < 0 || index >= len(queue.Tracks) {
+		return nil, domain.ErrInvalidQueueIndex
+	}
+
+	queue.CurrentIndex = index
+	queue.DeviceID = deviceID
+	queue.UpdatedAt = s.now()
+	if err := s.cache.SaveQueue(ctx, queue, true); err != nil {
+		return nil, err
+	}
+
+	s.publish(ctx, userID, syncevent.TypePlaybackQueueUpdated, map[string]interface{}{
+		"action":        "current_changed",
+		"device_id":     deviceID,
+		"current_index": index,
+		"song_id":       queue.Tracks[index].SongID,
+		"updated_at":    queue.UpdatedAt,
+	})
+	return queue, nil
+}
+
+// UpdatePosition 上报歌曲的播放进度
+func (s *PlaybackService) UpdatePosition(ctx context.Context, userID, deviceID string, position *domain.PlaybackPosition) (*domain.PlaybackPosition, error) {
+	if userID == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+	if err := position.Validate(); err != nil {
+		return nil, err
+	}
+	position.UserID = userID
+	position.DeviceID = deviceID
+	position.UpdatedAt = s.now()
+
+	if err := s.cache.SavePosition(ctx, position); err != nil {
+		return nil, err
+	}
+
+	s.publish(ctx, userID, syncevent.TypePlaybackPositionUpdated, map[string]interface{}{
+		"device_id":   deviceID,
+		"song_id":     position.SongID,
+		"position_ms": position.PositionMs,
+		"duration_ms": position.DurationMs,
+		"updated_at":  position.UpdatedAt,
+	})
+	return position, nil
+}
+
+// GetPositions 获取歌曲的播放进度，没有进度的歌曲不在结果中；结果顺序与songIDs一致
+func (s *PlaybackService) GetPositions(ctx context.Context, userID string, songIDs []string) ([]*domain.PlaybackPosition, error) {
+	if userID == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+	if len(songIDs) == 0 || len(songIDs) > MaxPlaybackPositionQuery {
+		return nil, domain.ErrInvalidSongID
+	}
+
+	positions, err := s.cache.GetPositions(ctx, userID, songIDs)
+	if err != nil {
+		log.Printf("Failed to read cached playback positions for user %s: %v", userID, err)
+		positions = make(map[string]*domain.PlaybackPosition, len(songIDs))
+	}
+
+	var missing []string
+	for _, songID := range songIDs {
+		if _, ok := positions[songID]; !ok {
+			missing = append(missing, songID)
+		}
+	}
+	if len(missing) > 0 {
+		stored, err := s.repo.GetPositions(ctx, userID, missing)
+		if err != nil {
+			return nil, err
+		}
+		for _, position := range stored {
+			positions[position.SongID] = position
+		}
+	}
+
+	result := make([]*domain.PlaybackPosition, 0, len(positions))
+	seen := make(map[string]bool, len(songIDs))
+	for _, songID := range songIDs {
+		if position, ok := positions[songID]; ok && !seen[songID] {
+			seen[songID] = true
+			result = append(result, position)
+		}
+	}
+	return result, nil
+}
+
+// FlushDirty 把Redis中待回写的播放队列和播放进度批量写入数据库
+// 写入失败的批次重新标记为待回写，下次继续
+func (s *PlaybackService) FlushDirty(ctx context.Context) (*domain.PlaybackFlushResult, error) {
+	result := &domain.PlaybackFlushResult{}
+
+	for {
+		queues, err := s.cache.PopDirtyQueues(ctx, playbackFlushBatchSize)
+		if err != nil {
+			return result, err
+		}
+		if len(queues) == 0 {
+			break
+		}
+		if err := s.repo.UpsertQueues(ctx, queues); err != nil {
+			s.remarkQueues(ctx, queues)
+			return result, err
+		}
+		result.QueuesFlushed += len(queues)
+	}
+
+	for {
+		positions, err := s.cache.PopDirtyPositions(ctx, playbackFlushBatchSize)
+		if err != nil {
+			return result, err
+		}
+		if len(positions) == 0 {
+			break
+		}
+		if err := s.repo.UpsertPositions(ctx, positions); err != nil {
+			s.remarkPositions(ctx, positions)
+			return result, err
+		}
+		result.PositionsFlushed += len(positions)
+	}
+
+	return result, nil
+}
+
+// remarkQueues 回写失败时重新标记播放队列为待回写
+func (s *PlaybackService) remarkQueues(ctx context.Context, queues []*domain.PlayQueue) {
+	userIDs := make([]string, 0, len(queues))
+	for _, queue := range queues {
+		userIDs = append(userIDs, queue.UserID)
+	}
+	if err := s.cache.MarkQueuesDirty(ctx, userIDs); err != nil {
+		log.Printf("Failed to re-mark %d play queues as dirty: %v", len(userIDs), err)
+	}
+}
+
+// remarkPositions 回写失败时重新标记播放进度为待回写
+func (s *PlaybackService) remarkPositions(ctx context.Context, positions []*domain.PlaybackPosition) {
+	if err := s.cache.MarkPositionsDirty(ctx, positions); err != nil {
+		log.Printf("Failed to re-mark %d playback positions as dirty: %v", len(positions), err)
+	}
+}
+
+// publish 推送播放状态变更到用户的其他设备（发起变更的设备根据device_id忽略）
+func (s *PlaybackService) publish(ctx context.Context, userID, eventType string, data map[string]interface{}) {
+	if err := s.notifier.Publish(ctx, userID, eventType, data); err != nil {
+		log.Printf("Failed to publish %s event to user %s: %v", eventType, userID, err)
+	}
+}
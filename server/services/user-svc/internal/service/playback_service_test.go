@@ -0,0 +1,285 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"user-svc/internal/domain"
+
+	"github.com/listen-stream/server/shared/pkg/syncevent"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryPlaybackStore 内存播放状态存储，同时实现PlaybackRepository和PlaybackCache（用于测试）
+type memoryPlaybackStore struct {
+	queues         map[string]*domain.PlayQueue
+	positions      map[string]*domain.PlaybackPosition
+	dirtyQueues    map[string]bool
+	dirtyPositions map[string]bool
+}
+
+func newMemoryPlaybackStore() *memoryPlaybackStore {
+	return &memoryPlaybackStore{
+		queues:         map[string]*domain.PlayQueue{},
+		positions:      map[string]*domain.PlaybackPosition{},
+		dirtyQueues:    map[string]bool{},
+		dirtyPositions: map[string]bool{},
+	}
+}
+
+func (m *memoryPlaybackStore) SaveQueue(ctx context.Context, queue *domain.PlayQueue, dirty bool) error {
+	copied := *queue
+	m.queues[queue.UserID] = &copied
+	if dirty {
+		m.dirtyQueues[queue.UserID] = true
+	}
+	return nil
+}
+
+func (m *memoryPlaybackStore) GetQueue(ctx context.Context, userID string) (*domain.PlayQueue, bool, error) {
+	queue, ok := m.queues[userID]
+	if !ok {
+		return nil, false, nil
+	}
+	copied := *queue
+	return &copied, true, nil
+}
+
+func (m *memoryPlaybackStore) SavePosition(ctx context.Context, position *domain.PlaybackPosition) error {
+	copied := *position
+	key := position.UserID + "|" + position.SongID
+	m.positions[key] = &copied
+	m.dirtyPositions[key] = true
+	return nil
+}
+
+func (m *memoryPlaybackStore) GetPositions(ctx context.Context, userID string, songIDs []string) (map[string]*domain.PlaybackPosition, error) {
+	result := map[string]*domain.PlaybackPosition{}
+	for _, songID := range songIDs {
+		if position, ok := m.positions[userID+"|"+songID]; ok {
+			result[songID] = position
+		}
+	}
+	return result, nil
+}
+
+func (m *memoryPlaybackStore) ListPositions(ctx context.Context, userID string) ([]*domain.PlaybackPosition, error) {
+	var result []*domain.PlaybackPosition
+	for _, position := range m.positions {
+		if position.UserID == userID {
+			result = append(result, position)
+		}
+	}
+	return result, nil
+}
+
+func (m *memoryPlaybackStore) PopDirtyQueues(ctx context.Context, count int) ([]*domain.PlayQueue, error) {
+	var queues []*domain.PlayQueue
+	for userID := range m.dirtyQueues {
+		if len(queues) == count {
+			break
+		}
+		delete(m.dirtyQueues, userID)
+		queues = append(queues, m.queues[userID])
+	}
+	return queues, nil
+}
+
+func (m *memoryPlaybackStore) PopDirtyPositions(ctx context.Context, count int) ([]*domain.PlaybackPosition, error) {
+	var positions []*domain.PlaybackPosition
+	for key := range m.dirtyPositions {
+		if len(positions) == count {
+			break
+		}
+		delete(m.dirtyPositions, key)
+		positions = append(positions, m.positions[key])
+	}
+	return positions, nil
+}
+
+func (m *memoryPlaybackStore) MarkQueuesDirty(ctx context.Context, userIDs []string) error {
+	for _, userID := range userIDs {
+		m.dirtyQueues[userID] = true
+	}
+	return nil
+}
+
+func (m *memoryPlaybackStore) MarkPositionsDirty(ctx context.Context, positions []*domain.PlaybackPosition) error {
+	for _, position := range positions {
+		m.dirtyPositions[position.UserID+"|"+position.SongID] = true
+	}
+	return nil
+}
+
+func (m *memoryPlaybackStore) DeleteUser(ctx context.Context, userID string) error {
+	return nil
+}
+
+// memoryPlaybackRepository 内存播放状态仓储（用于测试）
+type memoryPlaybackRepository struct {
+	store     *memoryPlaybackStore
+	upsertErr error
+}
+
+func (r *memoryPlaybackRepository) GetQueue(ctx context.Context, userID string) (*domain.PlayQueue, error) {
+	queue, ok, _ := r.store.GetQueue(ctx, userID)
+	if !ok {
+		return nil, domain.ErrPlayQueueNotFound
+	}
+	return queue, nil
+}
+
+func (r *memoryPlaybackRepository) UpsertQueues(ctx context.Context, queues []*domain.PlayQueue) error {
+	if r.upsertErr != nil {
+		return r.upsertErr
+	}
+	for _, queue := range queues {
+		r.store.SaveQueue(ctx, queue, false)
+	}
+	return nil
+}
+
+func (r *memoryPlaybackRepository) GetPositions(ctx context.Context, userID string, songIDs []string) ([]*domain.PlaybackPosition, error) {
+	positions, _ := r.store.GetPositions(ctx, userID, songIDs)
+	var result []*domain.PlaybackPosition
+	for _, position := range positions {
+		result = append(result, position)
+	}
+	return result, nil
+}
+
+func (r *memoryPlaybackRepository) ListPositions(ctx context.Context, userID string) ([]*domain.PlaybackPosition, error) {
+	return r.store.ListPositions(ctx, userID)
+}
+
+func (r *memoryPlaybackRepository) UpsertPositions(ctx context.Context, positions []*domain.PlaybackPosition) error {
+	if r.upsertErr != nil {
+		return r.upsertErr
+	}
+	for _, position := range positions {
+		copied := *position
+		r.store.positions[position.UserID+"|"+position.SongID] = &copied
+	}
+	return nil
+}
+
+func (r *memoryPlaybackRepository) DeleteAllByUser(ctx context.Context, userID string) (int64, error) {
+	return 0, nil
+}
+
+type playbackFixture struct {
+	svc      *PlaybackService
+	cache    *memoryPlaybackStore
+	repo     *memoryPlaybackRepository
+	notifier *recordingPublisher
+}
+
+func newPlaybackFixture() *playbackFixture {
+	cache := newMemoryPlaybackStore()
+	repo := &memoryPlaybackRepository{store: newMemoryPlaybackStore()}
+	notifier := &recordingPublisher{}
+	svc := NewPlaybackService(repo, cache, notifier)
+	svc.now = func() time.Time { return time.Date(2026, 10, 18, 20, 0, 0, 0, time.UTC) }
+	return &playbackFixture{svc: svc, cache: cache, repo: repo, notifier: notifier}
+}
+
+func TestPlayback_SaveQueue(t *testing.T) {
+	ctx := context.Background()
+	f := newPlaybackFixture()
+
+	// 从未保存过时返回空队列
+	queue, err := f.svc.GetQueue(ctx, "u1")
+	require.NoError(t, err)
+	assert.Empty(t, queue.Tracks)
+	assert.Equal(t, domain.RepeatModeOff, queue.RepeatMode)
+
+	_, err = f.svc.SaveQueue(ctx, "u1", "phone", &domain.PlayQueue{
+		Tracks:       []*domain.QueueTrack{{SongID: "a"}},
+		CurrentIndex: 1,
+	})
+	assert.ErrorIs(t, err, domain.ErrInvalidQueueIndex)
+	_, err = f.svc.SaveQueue(ctx, "u1", "phone", &domain.PlayQueue{RepeatMode: "shuffle"})
+	assert.ErrorIs(t, err, domain.ErrInvalidRepeatMode)
+
+	saved, err := f.svc.SaveQueue(ctx, "u1", "phone", &domain.PlayQueue{
+		Tracks:       []*domain.QueueTrack{{SongID: "a"}, {SongID: "b"}},
+		CurrentIndex: 1,
+		Shuffle:      true,
+		RepeatMode:   domain.RepeatModeAll,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "phone", saved.DeviceID)
+	assert.True(t, f.cache.dirtyQueues["u1"])
+
+	require.Len(t, f.notifier.events, 1)
+	assert.Equal(t, syncevent.TypePlaybackQueueUpdated, f.notifier.events[0].Type)
+	assert.Equal(t, "replaced", f.notifier.events[0].Data["action"])
+
+	// 另一台设备切歌只推送下标
+	queue, err = f.svc.SetCurrentIndex(ctx, "u1", "desktop", 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, queue.CurrentIndex)
+	assert.Equal(t, "desktop", queue.DeviceID)
+	assert.Equal(t, "a", f.notifier.events[1].Data["song_id"])
+	_, err = f.svc.SetCurrentIndex(ctx, "u1", "desktop", 2)
+	assert.ErrorIs(t, err, domain.ErrInvalidQueueIndex)
+}
+
+func TestPlayback_FlushDirty(t *testing.T) {
+	ctx := context.Background()
+	f := newPlaybackFixture()
+
+	_, err := f.svc.SaveQueue(ctx, "u1", "phone", &domain.PlayQueue{Tracks: []*domain.QueueTrack{{SongID: "a"}}})
+	require.NoError(t, err)
+	_, err = f.svc.UpdatePosition(ctx, "u1", "phone", &domain.PlaybackPosition{SongID: "a", PositionMs: 90000, DurationMs: 3600000})
+	require.NoError(t, err)
+
+	// 回写失败时重新标记，下次继续
+	f.repo.upsertErr = errors.New("db down")
+	_, err = f.svc.FlushDirty(ctx)
+	require.Error(t, err)
+	assert.True(t, f.cache.dirtyQueues["u1"])
+	assert.Empty(t, f.repo.store.queues)
+
+	f.repo.upsertErr = nil
+	result, err := f.svc.FlushDirty(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.QueuesFlushed)
+	assert.Equal(t, 1, result.PositionsFlushed)
+	assert.Empty(t, f.cache.dirtyQueues)
+	assert.Empty(t, f.cache.dirtyPositions)
+	assert.Equal(t, "a", f.repo.store.queues["u1"].Tracks[0].SongID)
+	assert.Equal(t, int64(90000), f.repo.store.positions["u1|a"].PositionMs)
+
+	// 缓存过期后从数据库读取
+	delete(f.cache.queues, "u1")
+	queue, err := f.svc.GetQueue(ctx, "u1")
+	require.NoError(t, err)
+	require.Len(t, queue.Tracks, 1)
+	assert.Contains(t, f.cache.queues, "u1")
+	assert.False(t, f.cache.dirtyQueues["u1"])
+}
+
+func TestPlayback_GetPositions(t *testing.T) {
+	ctx := context.Background()
+	f := newPlaybackFixture()
+
+	f.repo.store.positions["u1|old"] = &domain.PlaybackPosition{UserID: "u1", SongID: "old", PositionMs: 1000}
+	_, err := f.svc.UpdatePosition(ctx, "u1", "phone", &domain.PlaybackPosition{SongID: "new", PositionMs: 2000})
+	require.NoError(t, err)
+
+	_, err = f.svc.UpdatePosition(ctx, "u1", "phone", &domain.PlaybackPosition{SongID: "x", PositionMs: 5000, DurationMs: 4000})
+	assert.ErrorIs(t, err, domain.ErrInvalidPlayPosition)
+
+	positions, err := f.svc.GetPositions(ctx, "u1", []string{"new", "missing", "old"})
+	require.NoError(t, err)
+	require.Len(t, positions, 2)
+	assert.Equal(t, "new", positions[0].SongID)
+	assert.Equal(t, int64(1000), positions[1].PositionMs)
+
+	_, err = f.svc.GetPositions(ctx, "u1", nil)
+	assert.ErrorIs(t, err, domain.ErrInvalidSongID)
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"user-svc/internal/domain"
+	"user-svc/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// PlaylistDiscoveryService 公开歌单发现服务
+// 浏览其他用户的公开歌单、关注/取消关注，以及把歌单复制（fork）为自己的私有歌单
+type PlaylistDiscoveryService struct {
+	playlistService  *PlaylistService
+	playlistRepo     repository.PlaylistRepository
+	playlistSongRepo repository.PlaylistSongRepository
+	followRepo       repository.PlaylistFollowRepository
+	now              func() time.Time
+}
+
+// NewPlaylistDiscoveryService 创建公开歌单发现服务
+func NewPlaylistDiscoveryService(playlistService *PlaylistService, playlistRepo repository.PlaylistRepository, playlistSongRepo repository.PlaylistSongRepository, followRepo repository.PlaylistFollowRepository) *PlaylistDiscoveryService {
+	return &PlaylistDiscoveryService{
+		playlistService:  playlistService,
+		playlistRepo:     playlistRepo,
+		playlistSongRepo: playlistSongRepo,
+		followRepo:       followRepo,
+		now:              time.Now,
+	}
+}
+
+// ListPublicPlaylists 浏览公开歌单，sortBy为popular（按关注数）或recent（按更新时间）
+func (s *PlaylistDiscoveryService) ListPublicPlaylists(ctx context.Context, sortBy string, page, pageSize int) ([]*domain.UserPlaylist, int64, error) {
+	sortBy, err := domain.ValidatePublicPlaylistSort(sortBy)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	playlists, err := s.playlistRepo.ListPublic(ctx, sortBy, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.playlistRepo.CountPublic(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return playlists, total, nil
+}
+
+// GetPublicPlaylist 获取公开歌单详情（包括歌曲和当前用户是否已关注）
+func (s *PlaylistDiscoveryService) GetPublicPlaylist(ctx context.Context, playlistID, userID string) (*domain.PublicPlaylistDetail, error) {
+	playlist, err := s.publicPlaylist(ctx, playlistID)
+	if err != nil {
+		return nil, err
+	}
+
+	songs, err := s.playlistService.GetPlaylistSongs(ctx, playlist.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	following := false
+	if userID != "" {
+		following, err = s.followRepo.IsFollowing(ctx, playlist.ID, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &domain.PublicPlaylistDetail{
+		Playlist:    playlist,
+		Songs:       songs,
+		IsFollowing: following,
+	}, nil
+}
+
+// FollowPlaylist 关注公开歌单，重复关注不报错；返回关注后的歌单
+func (s *PlaylistDiscoveryService) FollowPlaylist(ctx context.Context, playlistID, userID string) (*domain.UserPlaylist, error) {
+	if userID == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	playlist, err := s.publicPlaylist(ctx, playlistID)
+	if err != nil {
+		return nil, err
+	}
+	if playlist.UserID == userID {
+		return nil, domain.ErrCannotFollowOwnPlaylist
+	}
+
+	followed, err := s.followRepo.Follow(ctx, playlist.ID, userID, s.now())
+	if err != nil {
+		return nil, err
+	}
+	if followed {
+		playlist.FollowerCount++
+	}
+	return playlist, nil
+}
+
+// UnfollowPlaylist 取消关注歌单，未关注时不报错
+// 歌单被设为私有后仍然可以取消关注
+func (s *PlaylistDiscoveryService) UnfollowPlaylist(ctx context.Context, playlistID, userID string) error {
+	if userID == "" {
+		return domain.ErrInvalidUserID
+	}
+	_, err := s.followRepo.Unfollow(ctx, playlistID, userID)
+	return err
+}
+
+// GetFollowedPlaylists 获取用户关注的公开歌单
+func (s *PlaylistDiscoveryService) GetFollowedPlaylists(ctx context.Context, userID string, page, pageSize int) ([]*domain.UserPlaylist, int64, error) {
+	offset := (page - 1) * pageSize
+	playlists, err := s.followRepo.ListFollowed(ctx, userID, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.followRepo.CountFollowed(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return playlists, total, nil
+}
+
+// ForkPlaylist 把有权查看的歌单复制为自己的私有歌单
+// 复制的是当前歌曲的快照（智能歌单按规则计算后的结果），之后与来源歌单互不影响；name为空时沿用来源歌单名称
+func (s *PlaylistDiscoveryService) ForkPlaylist(ctx context.Context, playlistID, userID, name string) (*domain.UserPlaylist, error) {
+	if userID == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	source, err := s.playlistService.GetPlaylistForUser(ctx, playlistID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = source.Name
+	}
+	if err := domain.ValidatePlaylistName(name); err != nil {
+		return nil, err
+	}
+
+	songs, err := s.playlistService.GetPlaylistSongs(ctx, source.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	forkedFrom := source.ID
+	playlist := &domain.UserPlaylist{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		Name:        name,
+		Description: source.Description,
+		CoverURL:    source.CoverURL,
+		IsPublic:    false,
+		ForkedFrom:  &forkedFrom,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if _, err := createPlaylistWithSongs(ctx, s.playlistRepo, playlist, songs); err != nil {
+		return nil, err
+	}
+	s.playlistService.recordCreated(userID)
+	return playlist, nil
+}
+
+// publicPlaylist 获取公开歌单，私有歌单返回ErrPlaylistNotPublic
+func (s *PlaylistDiscoveryService) publicPlaylist(ctx context.Context, playlistID string) (*domain.UserPlaylist, error) {
+	playlist, err := s.playlistRepo.GetByID(ctx, playlistID)
+	if err != nil {
+		return nil, err
+	}
+	if !playlist.IsPublic {
+		return nil, domain.ErrPlaylistNotPublic
+	}
+	return playlist, nil
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"user-svc/internal/domain"
+	"user-svc/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryPlaylistFollowRepository 内存歌单关注仓储（用于测试），关注数直接写回内存歌单仓储
+type memoryPlaylistFollowRepository struct {
+	repository.PlaylistFollowRepository
+	playlists *memoryPlaylistRepository
+	follows   map[string]map[string]bool
+}
+
+func (r *memoryPlaylistFollowRepository) Follow(ctx context.Context, playlistID, userID string, followedAt time.Time) (bool, error) {
+	if r.follows[playlistID] == nil {
+		r.follows[playlistID] = map[string]bool{}
+	}
+	if r.follows[playlistID][userID] {
+		return false, nil
+	}
+	r.follows[playlistID][userID] = true
+	r.playlists.playlists[playlistID].FollowerCount++
+	return true, nil
+}
+
+func (r *memoryPlaylistFollowRepository) Unfollow(ctx context.Context, playlistID, userID string) (bool, error) {
+	if !r.follows[playlistID][userID] {
+		return false, nil
+	}
+	delete(r.follows[playlistID], userID)
+	r.playlists.playlists[playlistID].FollowerCount--
+	return true, nil
+}
+
+func (r *memoryPlaylistFollowRepository) IsFollowing(ctx context.Context, playlistID, userID string) (bool, error) {
+	return r.follows[playlistID][userID], nil
+}
+
+type discoveryFixture struct {
+	discovery    *PlaylistDiscoveryService
+	playlistRepo *memoryPlaylistRepository
+	songRepo     *memoryPlaylistSongRepository
+	followRepo   *memoryPlaylistFollowRepository
+}
+
+func newDiscoveryFixture() *discoveryFixture {
+	playlistRepo := &memoryPlaylistRepository{playlists: map[string]*domain.UserPlaylist{
+		"public":  {ID: "public", UserID: "owner", Name: "Chill", Description: "desc", CoverURL: "cover.jpg", IsPublic: true},
+		"private": {ID: "private", UserID: "owner", Name: "Secret"},
+	}}
+	songRepo := &memoryPlaylistSongRepository{songs: map[string][]*domain.PlaylistSong{
+		"public": {
+			{PlaylistID: "public", SongID: "a", SongName: "A", Position: 1, AddedBy: "owner"},
+			{PlaylistID: "public", SongID: "b", SongName: "B", Position: 2, AddedBy: "owner"},
+		},
+	}}
+	playlistRepo.songRepo = songRepo
+	followRepo := &memoryPlaylistFollowRepository{playlists: playlistRepo, follows: map[string]map[string]bool{}}
+	playlistService := NewPlaylistService(playlistRepo, songRepo, nil, nil, nil, nil, nil)
+
+	f := &discoveryFixture{
+		discovery:    NewPlaylistDiscoveryService(playlistService, playlistRepo, songRepo, followRepo),
+		playlistRepo: playlistRepo,
+		songRepo:     songRepo,
+		followRepo:   followRepo,
+	}
+	f.discovery.now = func() time.Time { return time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC) }
+	return f
+}
+
+func TestPlaylistDiscovery_Follow(t *testing.T) {
+	f := newDiscoveryFixture()
+	ctx := context.Background()
+
+	playlist, err := f.discovery.FollowPlaylist(ctx, "public", "u1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, playlist.FollowerCount)
+
+	// 重复关注不重复计数
+	playlist, err = f.discovery.FollowPlaylist(ctx, "public", "u1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, playlist.FollowerCount)
+
+	detail, err := f.discovery.GetPublicPlaylist(ctx, "public", "u1")
+	require.NoError(t, err)
+	assert.True(t, detail.IsFollowing)
+	assert.Len(t, detail.Songs, 2)
+
+	_, err = f.discovery.FollowPlaylist(ctx, "public", "owner")
+	assert.ErrorIs(t, err, domain.ErrCannotFollowOwnPlaylist)
+	_, err = f.discovery.FollowPlaylist(ctx, "private", "u1")
+	assert.ErrorIs(t, err, domain.ErrPlaylistNotPublic)
+	_, err = f.discovery.GetPublicPlaylist(ctx, "private", "u1")
+	assert.ErrorIs(t, err, domain.ErrPlaylistNotPublic)
+
+	require.NoError(t, f.discovery.UnfollowPlaylist(ctx, "public", "u1"))
+	require.NoError(t, f.discovery.UnfollowPlaylist(ctx, "public", "u1"))
+	assert.Equal(t, 0, f.playlistRepo.playlists["public"].FollowerCount)
+
+	_, _, err = f.discovery.ListPublicPlaylists(ctx, "oldest", 1, 20)
+	assert.ErrorIs(t, err, domain.ErrInvalidPlaylistSort)
+}
+
+func TestPlaylistDiscovery_Fork(t *testing.T) {
+	f := newDiscoveryFixture()
+	ctx := context.Background()
+
+	fork, err := f.discovery.ForkPlaylist(ctx, "public", "u1", "")
+	require.NoError(t, err)
+	assert.Equal(t, "u1", fork.UserID)
+	assert.Equal(t, "Chill", fork.Name)
+	assert.Equal(t, "desc", fork.Description)
+	assert.False(t, fork.IsPublic)
+	require.NotNil(t, fork.ForkedFrom)
+	assert.Equal(t, "public", *fork.ForkedFrom)
+	assert.Equal(t, 2, fork.SongCount)
+
+	// 复制的是快照：歌曲属于新歌单，添加者为空（视为所有者添加）
+	songs := f.songRepo.songs[fork.ID]
+	assert.Equal(t, []string{"a", "b"}, playlistSongIDs(songs))
+	for _, song := range songs {
+		assert.Equal(t, fork.ID, song.PlaylistID)
+		assert.Empty(t, song.AddedBy)
+	}
+	f.songRepo.songs["public"] = nil
+	assert.Len(t, f.songRepo.songs[fork.ID], 2)
+
+	named, err := f.discovery.ForkPlaylist(ctx, "public", "u1", "My Chill")
+	require.NoError(t, err)
+	assert.Equal(t, "My Chill", named.Name)
+
+	// 私有歌单只有所有者和成员可以复制
+	_, err = f.discovery.ForkPlaylist(ctx, "private", "u1", "")
+	assert.ErrorIs(t, err, domain.ErrUnauthorized)
+	own, err := f.discovery.ForkPlaylist(ctx, "private", "owner", "")
+	require.NoError(t, err)
+	assert.Equal(t, "Secret", own.Name)
+}
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"user-svc/internal/domain"
+	"user-svc/internal/repository"
+)
+
+// PlaylistMemberService 协作歌单成员服务
+// 所有者可以按用户ID直接邀请成员，也可以生成分享链接由对方接受；成员变更同样推送给所有协作者
+type PlaylistMemberService struct {
+	playlistService *PlaylistService
+	playlistRepo    repository.PlaylistRepository
+	memberRepo      repository.PlaylistMemberRepository
+	now             func() time.Time
+}
+
+// NewPlaylistMemberService 创建协作歌单成员服务
+func NewPlaylistMemberService(playlistService *PlaylistService, playlistRepo repository.PlaylistRepository, memberRepo repository.PlaylistMemberRepository) *PlaylistMemberService {
+	return &PlaylistMemberService{
+		playlistService: playlistService,
+		playlistRepo:    playlistRepo,
+		memberRepo:      memberRepo,
+		now:             time.Now,
+	}
+}
+
+// InviteMember 所有者按用户ID邀请成员，成员已存在时修改其角色
+func (s *PlaylistMemberService) InviteMember(ctx context.Context, playlistID, ownerID, memberID, role string) (*domain.PlaylistMember, error) {
+	if memberID == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+	if err := domain.ValidateMemberRole(role); err != nil {
+		return nil, err
+	}
+
+	playlist, err := s.ownedPlaylist(ctx, playlistID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if memberID == playlist.UserID {
+		return nil, domain.ErrCannotInviteOwner
+	}
+
+	member, err := s.memberRepo.Get(ctx, playlistID, memberID)
+	switch {
+	case errors.Is(err, domain.ErrMemberNotFound):
+		if err := s.checkCapacity(ctx, playlistID); err != nil {
+			return nil, err
+		}
+		member = &domain.PlaylistMember{
+			PlaylistID: playlistID,
+			UserID:     memberID,
+			InvitedBy:  ownerID,
+			JoinedAt:   s.now(),
+		}
+	case err != nil:
+		return nil, err
+	}
+	member.Role = role
+
+	if err := s.memberRepo.Upsert(ctx, member); err != nil {
+		return nil, err
+	}
+
+	s.playlistService.notifyCollaborators(ctx, playlist, ownerID, "member_updated", map[string]interface{}{
+		"member_id": memberID,
+		"role":      role,
+	})
+	return member, nil
+}
+
+// RemoveMember 移除成员：所有者可以移除任何成员，成员可以移除自己（退出歌单）
+func (s *PlaylistMemberService) RemoveMember(ctx context.Context, playlistID, userID, memberID string) error {
+	playlist, err := s.playlistRepo.GetByID(ctx, playlistID)
+	if err != nil {
+		return err
+	}
+	if playlist.UserID != userID && memberID != userID {
+		return domain.ErrUnauthorized
+	}
+
+	// 先取协作者列表，被移除的成员也需要收到通知
+	recipients := s.playlistService.collaborators(ctx, playlist)
+	if err := s.memberRepo.Remove(ctx, playlistID, memberID); err != nil {
+		return err
+	}
+
+	s.playlistService.publish(ctx, recipients, playlistID, userID, "member_removed", map[string]interface{}{
+		"member_id": memberID,
+	})
+	return nil
+}
+
+// ListMembers 获取歌单的所有者和成员，所有者排在第一位
+func (s *PlaylistMemberService) ListMembers(ctx context.Context, playlistID, userID string) ([]*domain.PlaylistMember, error) {
+	playlist, err := s.playlistRepo.GetByID(ctx, playlistID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.playlistService.CanRead(ctx, playlist, userID); err != nil {
+		return nil, err
+	}
+
+	members, err := s.memberRepo.List(ctx, playlistID)
+	if err != nil {
+		return nil, err
+	}
+
+	owner := &domain.PlaylistMember{
+		PlaylistID: playlist.ID,
+		UserID:     playlist.UserID,
+		Role:       domain.PlaylistRoleOwner,
+		InvitedBy:  playlist.UserID,
+		JoinedAt:   playlist.CreatedAt,
+	}
+	return append([]*domain.PlaylistMember{owner}, members...), nil
+}
+
+// CreateInviteLink 所有者生成分享链接邀请，持有token的用户在有效期内可以加入歌单
+func (s *PlaylistMemberService) CreateInviteLink(ctx context.Context, playlistID, ownerID, role string) (*domain.PlaylistInvite, error) {
+	if err := domain.ValidateMemberRole(role); err != nil {
+		return nil, err
+	}
+	if _, err := s.ownedPlaylist(ctx, playlistID, ownerID); err != nil {
+		return nil, err
+	}
+
+	token, err := newInviteToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	invite := &domain.PlaylistInvite{
+		Token:      token,
+		PlaylistID: playlistID,
+		Role:       role,
+		CreatedBy:  ownerID,
+		ExpiresAt:  now.Add(domain.PlaylistInviteTTL),
+		CreatedAt:  now,
+	}
+	if err := s.memberRepo.CreateInvite(ctx, invite); err != nil {
+		return nil, err
+	}
+	return invite, nil
+}
+
+// RevokeInviteLinks 撤销歌单的所有分享链接，已加入的成员不受影响
+func (s *PlaylistMemberService) RevokeInviteLinks(ctx context.Context, playlistID, ownerID string) (int64, error) {
+	if _, err := s.ownedPlaylist(ctx, playlistID, ownerID); err != nil {
+		return 0, err
+	}
+	return s.memberRepo.DeleteInvites(ctx, playlistID)
+}
+
+// AcceptInvite 通过分享链接加入歌单
+// 所有者接受自己的链接不做任何修改；已经是成员时只会升级角色，不会降级
+func (s *PlaylistMemberService) AcceptInvite(ctx context.Context, token, userID string) (*domain.UserPlaylist, error) {
+	if userID == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	invite, err := s.memberRepo.GetInvite(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if invite.IsExpired(s.now()) {
+		return nil, domain.ErrInviteExpired
+	}
+
+	playlist, err := s.playlistRepo.GetByID(ctx, invite.PlaylistID)
+	if err != nil {
+		return nil, err
+	}
+	if playlist.UserID == userID {
+		return playlist, nil
+	}
+
+	member, err := s.memberRepo.Get(ctx, playlist.ID, userID)
+	switch {
+	case err == nil:
+		if domain.RoleAllows(member.Role, invite.Role) {
+			return playlist, nil
+		}
+	case errors.Is(err, domain.ErrMemberNotFound):
+		if err := s.checkCapacity(ctx, playlist.ID); err != nil {
+			return nil, err
+		}
+		member = &domain.PlaylistMember{
+			PlaylistID: playlist.ID,
+			UserID:     userID,
+			InvitedBy:  invite.CreatedBy,
+			JoinedAt:   s.now(),
+		}
+	default:
+		return nil, err
+	}
+	member.Role = invite.Role
+
+	if err := s.memberRepo.Upsert(ctx, member); err != nil {
+		return nil, err
+	}
+
+	s.playlistService.notifyCollaborators(ctx, playlist, userID, "member_joined", map[string]interface{}{
+		"member_id": userID,
+		"role":      member.Role,
+	})
+	return playlist, nil
+}
+
+// ownedPlaylist 获取歌单并检查调用者是否为所有者
+func (s *PlaylistMemberService) ownedPlaylist(ctx context.Context, playlistID, ownerID string) (*domain.UserPlaylist, error) {
+	playlist, err := s.playlistRepo.GetByID(ctx, playlistID)
+	if err != nil {
+		return nil, err
+	}
+	if playlist.UserID != ownerID {
+		return nil, domain.ErrUnauthorized
+	}
+	return playlist, nil
+}
+
+// checkCapacity 检查歌单成员数是否已达上限
+func (s *PlaylistMemberService) checkCapacity(ctx context.Context, playlistID string) error {
+	count, err := s.memberRepo.Count(ctx, playlistID)
+	if err != nil {
+		return err
+	}
+	if count >= domain.MaxPlaylistMembers {
+		return domain.ErrTooManyMembers
+	}
+	return nil
+}
+
+// newInviteToken 生成分享链接token（128位随机数）
+func newInviteToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate invite token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"user-svc/internal/domain"
+
+	"github.com/listen-stream/server/shared/pkg/syncevent"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryPlaylistMemberRepository 内存歌单成员仓储（用于测试）
+type memoryPlaylistMemberRepository struct {
+	members map[string]map[string]*domain.PlaylistMember
+	invites map[string]*domain.PlaylistInvite
+}
+
+func newMemoryPlaylistMemberRepository() *memoryPlaylistMemberRepository {
+	return &memoryPlaylistMemberRepository{
+		members: map[string]map[string]*domain.PlaylistMember{},
+		invites: map[string]*domain.PlaylistInvite{},
+	}
+}
+
+func (r *memoryPlaylistMemberRepository) Upsert(ctx context.Context, member *domain.PlaylistMember) error {
+	if r.members[member.PlaylistID] == nil {
+		r.members[member.PlaylistID] = map[string]*domain.PlaylistMember{}
+	}
+	copied := *member
+	r.members[member.PlaylistID][member.UserID] = &copied
+	return nil
+}
+
+func (r *memoryPlaylistMemberRepository) Get(ctx context.Context, playlistID, userID string) (*domain.PlaylistMember, error) {
+	member, ok := r.members[playlistID][userID]
+	if !ok {
+		return nil, domain.ErrMemberNotFound
+	}
+	copied := *member
+	return &copied, nil
+}
+
+func (r *memoryPlaylistMemberRepository) List(ctx context.Context, playlistID string) ([]*domain.PlaylistMember, error) {
+	var members []*domain.PlaylistMember
+	for _, member := range r.members[playlistID] {
+		members = append(members, member)
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].UserID < members[j].UserID })
+	return members, nil
+}
+
+func (r *memoryPlaylistMemberRepository) ListByUser(ctx context.Context, userID string) ([]*domain.PlaylistMember, error) {
+	var members []*domain.PlaylistMember
+	for _, playlistMembers := range r.members {
+		if member, ok := playlistMembers[userID]; ok {
+			members = append(members, member)
+		}
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].PlaylistID < members[j].PlaylistID })
+	return members, nil
+}
+
+func (r *memoryPlaylistMemberRepository) Count(ctx context.Context, playlistID string) (int64, error) {
+	return int64(len(r.members[playlistID])), nil
+}
+
+func (r *memoryPlaylistMemberRepository) Remove(ctx context.Context, playlistID, userID string) error {
+	if _, ok := r.members[playlistID][userID]; !ok {
+		return domain.ErrMemberNotFound
+	}
+	delete(r.members[playlistID], userID)
+	return nil
+}
+
+func (r *memoryPlaylistMemberRepository) DeleteAllByUser(ctx context.Context, userID string) (int64, error) {
+	var deleted int64
+	for _, members := range r.members {
+		if _, ok := members[userID]; ok {
+			delete(members, userID)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (r *memoryPlaylistMemberRepository) CreateInvite(ctx context.Context, invite *domain.PlaylistInvite) error {
+	r.invites[invite.Token] = invite
+	return nil
+}
+
+func (r *memoryPlaylistMemberRepository) GetInvite(ctx context.Context, token string) (*domain.PlaylistInvite, error) {
+	invite, ok := r.invites[token]
+	if !ok {
+		return nil, domain.ErrInviteNotFound
+	}
+	return invite, nil
+}
+
+func (r *memoryPlaylistMemberRepository) DeleteInvites(ctx context.Context, playlistID string) (int64, error) {
+	var deleted int64
+	for token, invite := range r.invites {
+		if invite.PlaylistID == playlistID {
+			delete(r.invites, token)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// recordingPublisher 记录推送的同步事件（用于测试）
+type recordingPublisher struct {
+	events []syncevent.Message
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, userID, eventType string, data map[string]interface{}) error {
+	p.events = append(p.events, syncevent.Message{UserID: userID, Type: eventType, Data: data})
+	return nil
+}
+
+func (p *recordingPublisher) recipients(action string) []string {
+	var userIDs []string
+	for _, event := range p.events {
+		if event.Data["action"] == action {
+			userIDs = append(userIDs, event.UserID)
+		}
+	}
+	sort.Strings(userIDs)
+	return userIDs
+}
+
+type collaborationFixture struct {
+	playlists *PlaylistService
+	members   *PlaylistMemberService
+	songRepo  *memoryPlaylistSongRepository
+	notifier  *recordingPublisher
+	now       time.Time
+}
+
+func newCollaborationFixture() *collaborationFixture {
+	playlistRepo := &memoryPlaylistRepository{playlists: map[string]*domain.UserPlaylist{
+		"p1": {ID: "p1", UserID: "owner", Name: "Road Trip"},
+	}}
+	songRepo := &memoryPlaylistSongRepository{songs: map[string][]*domain.PlaylistSong{}}
+	memberRepo := newMemoryPlaylistMemberRepository()
+	notifier := &recordingPublisher{}
+
+	f := &collaborationFixture{
+		playlists: NewPlaylistService(playlistRepo, songRepo, memberRepo, nil, nil, notifier, nil),
+		songRepo:  songRepo,
+		notifier:  notifier,
+		now:       time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+	}
+	f.members = NewPlaylistMemberService(f.playlists, playlistRepo, memberRepo)
+	f.members.now = func() time.Time { return f.now }
+	return f
+}
+
+func TestCollaborativePlaylist_Permissions(t *testing.T) {
+	f := newCollaborationFixture()
+	ctx := context.Background()
+
+	// 非成员不能编辑
+	err := f.playlists.AddSongToPlaylist(ctx, "p1", "editor", "s1", "Song 1", "X")
+	assert.ErrorIs(t, err, domain.ErrUnauthorized)
+
+	_, err = f.members.InviteMember(ctx, "p1", "owner", "editor", domain.PlaylistRoleEditor)
+	require.NoError(t, err)
+	_, err = f.members.InviteMember(ctx, "p1", "owner", "viewer", domain.PlaylistRoleViewer)
+	require.NoError(t, err)
+
+	// 只有所有者可以管理成员
+	_, err = f.members.InviteMember(ctx, "p1", "editor", "someone", domain.PlaylistRoleEditor)
+	assert.ErrorIs(t, err, domain.ErrUnauthorized)
+	_, err = f.members.InviteMember(ctx, "p1", "owner", "owner", domain.PlaylistRoleEditor)
+	assert.ErrorIs(t, err, domain.ErrCannotInviteOwner)
+	_, err = f.members.InviteMember(ctx, "p1", "owner", "someone", domain.PlaylistRoleOwner)
+	assert.ErrorIs(t, err, domain.ErrInvalidMemberRole)
+
+	// 编辑者可以增删歌曲，并记录添加者
+	require.NoError(t, f.playlists.AddSongToPlaylist(ctx, "p1", "editor", "s1", "Song 1", "X"))
+	require.Len(t, f.songRepo.songs["p1"], 1)
+	assert.Equal(t, "editor", f.songRepo.songs["p1"][0].AddedBy)
+	assert.Equal(t, []string{"editor", "owner", "viewer"}, f.notifier.recipients("song_added"))
+
+	// 查看者只能看，不能改
+	err = f.playlists.AddSongToPlaylist(ctx, "p1", "viewer", "s2", "Song 2", "Y")
+	assert.ErrorIs(t, err, domain.ErrUnauthorized)
+	_, err = f.playlists.GetPlaylistForUser(ctx, "p1", "viewer")
+	assert.NoError(t, err)
+	_, err = f.playlists.GetPlaylistForUser(ctx, "p1", "stranger")
+	assert.ErrorIs(t, err, domain.ErrUnauthorized)
+
+	require.NoError(t, f.playlists.RemoveSongFromPlaylist(ctx, "p1", "editor", "s1"))
+	assert.Empty(t, f.songRepo.songs["p1"])
+
+	members, err := f.members.ListMembers(ctx, "p1", "viewer")
+	require.NoError(t, err)
+	require.Len(t, members, 3)
+	assert.Equal(t, domain.PlaylistRoleOwner, members[0].Role)
+
+	// 成员可以退出，被移除的成员也会收到通知
+	require.NoError(t, f.members.RemoveMember(ctx, "p1", "viewer", "viewer"))
+	assert.Equal(t, []string{"editor", "owner", "viewer"}, f.notifier.recipients("member_removed"))
+	err = f.members.RemoveMember(ctx, "p1", "editor", "viewer")
+	assert.ErrorIs(t, err, domain.ErrUnauthorized)
+}
+
+func TestCollaborativePlaylist_InviteLinks(t *testing.T) {
+	f := newCollaborationFixture()
+	ctx := context.Background()
+
+	_, err := f.members.CreateInviteLink(ctx, "p1", "stranger", domain.PlaylistRoleEditor)
+	assert.ErrorIs(t, err, domain.ErrUnauthorized)
+
+	viewerLink, err := f.members.CreateInviteLink(ctx, "p1", "owner", domain.PlaylistRoleViewer)
+	require.NoError(t, err)
+	assert.Len(t, viewerLink.Token, 32)
+	editorLink, err := f.members.CreateInviteLink(ctx, "p1", "owner", domain.PlaylistRoleEditor)
+	require.NoError(t, err)
+
+	playlist, err := f.members.AcceptInvite(ctx, editorLink.Token, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, "p1", playlist.ID)
+
+	// 已是编辑者时接受查看者链接不会降级
+	_, err = f.members.AcceptInvite(ctx, viewerLink.Token, "u1")
+	require.NoError(t, err)
+	require.NoError(t, f.playlists.AddSongToPlaylist(ctx, "p1", "u1", "s1", "Song 1", "X"))
+
+	// 所有者接受自己的链接不会成为成员
+	_, err = f.members.AcceptInvite(ctx, viewerLink.Token, "owner")
+	require.NoError(t, err)
+	members, err := f.members.ListMembers(ctx, "p1", "owner")
+	require.NoError(t, err)
+	assert.Len(t, members, 2)
+
+	_, err = f.members.AcceptInvite(ctx, "unknown", "u2")
+	assert.ErrorIs(t, err, domain.ErrInviteNotFound)
+
+	f.now = f.now.Add(domain.PlaylistInviteTTL)
+	_, err = f.members.AcceptInvite(ctx, viewerLink.Token, "u2")
+	assert.ErrorIs(t, err, domain.ErrInviteExpired)
+
+	revoked, err := f.members.RevokeInviteLinks(ctx, "p1", "owner")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), revoked)
+}
@@ -2,25 +2,61 @@ package service
 
 import (
 	"context"
+	"errors"
+	"log"
 	"time"
 
 	"user-svc/internal/domain"
 	"user-svc/internal/repository"
 
 	"github.com/google/uuid"
+	"github.com/listen-stream/server/shared/pkg/stats"
+	"github.com/listen-stream/server/shared/pkg/syncevent"
 )
 
+// SmartPlaylistTTL 智能歌单计算结果的缓存时间
+// 收藏和播放变化时会主动失效，TTL只用于兜底"N天内"这类随时间变化的规则
+const SmartPlaylistTTL = 10 * time.Minute
+
 // PlaylistService 歌单服务
 type PlaylistService struct {
 	playlistRepo     repository.PlaylistRepository
 	playlistSongRepo repository.PlaylistSongRepository
+	memberRepo       repository.PlaylistMemberRepository
+	smartRepo        repository.SmartPlaylistRepository
+	smartCache       repository.SmartPlaylistCache
+	notifier         syncevent.Publisher
+	metrics          stats.Emitter
+	now              func() time.Time
 }
 
 // NewPlaylistService 创建歌单服务
-func NewPlaylistService(playlistRepo repository.PlaylistRepository, playlistSongRepo repository.PlaylistSongRepository) *PlaylistService {
+// memberRepo为nil时只有所有者可以访问私有歌单；smartCache为nil时智能歌单每次读取都重新计算；
+// notifier用于把歌单变更推送给所有协作者的在线设备，为nil时不推送；metrics为nil时不上报运营统计
+func NewPlaylistService(
+	playlistRepo repository.PlaylistRepository,
+	playlistSongRepo repository.PlaylistSongRepository,
+	memberRepo repository.PlaylistMemberRepository,
+	smartRepo repository.SmartPlaylistRepository,
+	smartCache repository.SmartPlaylistCache,
+	notifier syncevent.Publisher,
+	metrics stats.Emitter,
+) *PlaylistService {
+	if notifier == nil {
+		notifier = syncevent.NoopPublisher{}
+	}
+	if metrics == nil {
+		metrics = stats.NoopEmitter{}
+	}
 	return &PlaylistService{
 		playlistRepo:     playlistRepo,
 		playlistSongRepo: playlistSongRepo,
+		memberRepo:       memberRepo,
+		smartRepo:        smartRepo,
+		smartCache:       smartCache,
+		notifier:         notifier,
+		metrics:          metrics,
+		now:              time.Now,
 	}
 }
 
@@ -45,15 +81,109 @@ func (s *PlaylistService) CreatePlaylist(ctx context.Context, userID, name, desc
 	if err := s.playlistRepo.Create(ctx, playlist); err != nil {
 		return nil, err
 	}
+	s.recordCreated(userID)
+
+	return playlist, nil
+}
+
+// CreateSmartPlaylist 创建智能歌单
+func (s *PlaylistService) CreateSmartPlaylist(ctx context.Context, userID, name, description, coverURL string, isPublic bool, rules *domain.SmartPlaylistRules) (*domain.UserPlaylist, error) {
+	if err := domain.ValidatePlaylistName(name); err != nil {
+		return nil, err
+	}
+	if rules == nil {
+		return nil, domain.ErrInvalidSmartRules
+	}
+	rules.Normalize()
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+
+	playlist := &domain.UserPlaylist{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		Name:        name,
+		Description: description,
+		CoverURL:    coverURL,
+		IsPublic:    isPublic,
+		SmartRules:  rules,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	if err := s.playlistRepo.Create(ctx, playlist); err != nil {
+		return nil, err
+	}
+	s.recordCreated(userID)
+
+	return playlist, nil
+}
+
+// UpdateSmartRules 更新智能歌单规则
+func (s *PlaylistService) UpdateSmartRules(ctx context.Context, playlistID, userID string, rules *domain.SmartPlaylistRules) (*domain.UserPlaylist, error) {
+	playlist, err := s.playlistRepo.GetByID(ctx, playlistID)
+	if err != nil {
+		return nil, err
+	}
+
+	if playlist.UserID != userID {
+		return nil, domain.ErrUnauthorized
+	}
+	if !playlist.IsSmart() {
+		return nil, domain.ErrNotSmartPlaylist
+	}
+	if rules == nil {
+		return nil, domain.ErrInvalidSmartRules
+	}
+	rules.Normalize()
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+
+	playlist.SmartRules = rules
+	playlist.UpdatedAt = time.Now()
+
+	if err := s.playlistRepo.Update(ctx, playlist); err != nil {
+		return nil, err
+	}
 
+	s.InvalidateSmartPlaylists(ctx, userID)
+	s.notifyCollaborators(ctx, playlist, userID, "updated", nil)
 	return playlist, nil
 }
 
+// InvalidateSmartPlaylists 使用户的智能歌单缓存失效，下次读取时重新计算
+func (s *PlaylistService) InvalidateSmartPlaylists(ctx context.Context, userID string) {
+	invalidateSmartPlaylists(ctx, s.smartCache, userID)
+}
+
+// invalidateSmartPlaylists 收藏、播放记录或规则变化时使智能歌单缓存失效，失败只记录日志（缓存会按TTL过期）
+func invalidateSmartPlaylists(ctx context.Context, cache repository.SmartPlaylistCache, userID string) {
+	if cache == nil {
+		return
+	}
+	if err := cache.Invalidate(ctx, userID); err != nil {
+		log.Printf("Failed to invalidate smart playlists for user %s: %v", userID, err)
+	}
+}
+
 // GetPlaylist 获取歌单详情
 func (s *PlaylistService) GetPlaylist(ctx context.Context, playlistID string) (*domain.UserPlaylist, error) {
 	return s.playlistRepo.GetByID(ctx, playlistID)
 }
 
+// GetPlaylistForUser 获取用户有权查看的歌单（公开歌单、自己的歌单或参与协作的歌单）
+func (s *PlaylistService) GetPlaylistForUser(ctx context.Context, playlistID, userID string) (*domain.UserPlaylist, error) {
+	playlist, err := s.playlistRepo.GetByID(ctx, playlistID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.CanRead(ctx, playlist, userID); err != nil {
+		return nil, err
+	}
+	return playlist, nil
+}
+
 // GetUserPlaylists 获取用户的歌单列表
 func (s *PlaylistService) GetUserPlaylists(ctx context.Context, userID string, page, pageSize int) ([]*domain.UserPlaylist, int64, error) {
 	offset := (page - 1) * pageSize
@@ -103,6 +233,7 @@ func (s *PlaylistService) UpdatePlaylist(ctx context.Context, playlistID, userID
 		return nil, err
 	}
 
+	s.notifyCollaborators(ctx, playlist, userID, "updated", nil)
 	return playlist, nil
 }
 
@@ -117,72 +248,349 @@ func (s *PlaylistService) DeletePlaylist(ctx context.Context, playlistID, userID
 		return domain.ErrUnauthorized
 	}
 
-	return s.playlistRepo.SoftDelete(ctx, playlistID)
+	// 先取成员列表，删除后仍需通知协作者
+	recipients := s.collaborators(ctx, playlist)
+	if err := s.playlistRepo.SoftDelete(ctx, playlistID); err != nil {
+		return err
+	}
+
+	s.publish(ctx, recipients, playlist.ID, userID, "deleted", nil)
+	return nil
 }
 
-// AddSongToPlaylist 添加歌曲到歌单
+// AddSongToPlaylist 添加歌曲到歌单末尾（所有者和编辑者）
 func (s *PlaylistService) AddSongToPlaylist(ctx context.Context, playlistID, userID, songID, songName, singerName string) error {
-	playlist, err := s.playlistRepo.GetByID(ctx, playlistID)
+	if songID == "" {
+		return domain.ErrInvalidSongID
+	}
+	playlist, err := s.editablePlaylist(ctx, playlistID, userID)
 	if err != nil {
 		return err
 	}
 
-	if playlist.UserID != userID {
-		return domain.ErrUnauthorized
+	added, err := s.playlistSongRepo.AddSongs(ctx, playlistID, []*domain.PlaylistSong{{
+		SongID:     songID,
+		SongName:   songName,
+		SingerName: singerName,
+		AddedBy:    userID,
+		AddedAt:    time.Now(),
+	}})
+	if err != nil {
+		return err
+	}
+	if added == 0 {
+		return domain.ErrSongAlreadyInPlaylist
+	}
+
+	s.notifyCollaborators(ctx, playlist, userID, "song_added", map[string]interface{}{
+		"song_id":   songID,
+		"song_name": songName,
+	})
+	return nil
+}
+
+// AddSongsToPlaylist 批量添加歌曲到歌单末尾（所有者和编辑者）
+// 歌曲按传入顺序排列，已在歌单中的歌曲和重复的歌曲会被跳过；返回实际添加的歌曲数
+func (s *PlaylistService) AddSongsToPlaylist(ctx context.Context, playlistID, userID string, songs []*domain.PlaylistSong) (int, error) {
+	if len(songs) > domain.MaxBatchPlaylistSongs {
+		return 0, domain.ErrTooManyBatchSongs
+	}
+	for _, song := range songs {
+		if song.SongID == "" {
+			return 0, domain.ErrInvalidSongID
+		}
 	}
+	playlist, err := s.editablePlaylist(ctx, playlistID, userID)
+	if err != nil {
+		return 0, err
+	}
+	if len(songs) == 0 {
+		return 0, nil
+	}
+
+	now := time.Now()
+	toAdd := make([]*domain.PlaylistSong, 0, len(songs))
+	for _, song := range songs {
+		toAdd = append(toAdd, &domain.PlaylistSong{
+			SongID:     song.SongID,
+			SongName:   song.SongName,
+			SingerName: song.SingerName,
+			AddedBy:    userID,
+			AddedAt:    now,
+		})
+	}
+
+	added, err := s.playlistSongRepo.AddSongs(ctx, playlistID, toAdd)
+	if err != nil {
+		return 0, err
+	}
+
+	if added > 0 {
+		s.notifyCollaborators(ctx, playlist, userID, "songs_added", map[string]interface{}{
+			"count": added,
+		})
+	}
+	return int(added), nil
+}
 
-	// 检查歌曲是否已在歌单中
-	exists, err := s.playlistSongRepo.Exists(ctx, playlistID, songID)
+// RemoveSongFromPlaylist 从歌单移除歌曲（所有者和编辑者）
+func (s *PlaylistService) RemoveSongFromPlaylist(ctx context.Context, playlistID, userID, songID string) error {
+	playlist, err := s.editablePlaylist(ctx, playlistID, userID)
 	if err != nil {
 		return err
 	}
-	if exists {
-		return domain.ErrSongAlreadyInPlaylist
+
+	removed, err := s.playlistSongRepo.RemoveSongs(ctx, playlistID, []string{songID})
+	if err != nil {
+		return err
 	}
+	if removed == 0 {
+		return domain.ErrSongNotInPlaylist
+	}
+
+	s.notifyCollaborators(ctx, playlist, userID, "song_removed", map[string]interface{}{
+		"song_id": songID,
+	})
+	return nil
+}
 
-	// 获取当前最大位置
-	maxPos, err := s.playlistSongRepo.GetMaxPosition(ctx, playlistID)
+// RemoveSongsFromPlaylist 批量移除歌曲（所有者和编辑者），不在歌单中的歌曲会被忽略；返回实际移除的歌曲数
+func (s *PlaylistService) RemoveSongsFromPlaylist(ctx context.Context, playlistID, userID string, songIDs []string) (int, error) {
+	if len(songIDs) > domain.MaxBatchPlaylistSongs {
+		return 0, domain.ErrTooManyBatchSongs
+	}
+	playlist, err := s.editablePlaylist(ctx, playlistID, userID)
+	if err != nil {
+		return 0, err
+	}
+	if len(songIDs) == 0 {
+		return 0, nil
+	}
+
+	removed, err := s.playlistSongRepo.RemoveSongs(ctx, playlistID, songIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	if removed > 0 {
+		s.notifyCollaborators(ctx, playlist, userID, "songs_removed", map[string]interface{}{
+			"song_ids": songIDs,
+		})
+	}
+	return int(removed), nil
+}
+
+// MoveSong 把歌曲移动到指定位置（从1开始，超出歌曲数时移动到末尾）
+func (s *PlaylistService) MoveSong(ctx context.Context, playlistID, userID, songID string, position int) error {
+	if position < 1 {
+		return domain.ErrInvalidPosition
+	}
+	playlist, err := s.editablePlaylist(ctx, playlistID, userID)
 	if err != nil {
 		return err
 	}
 
-	playlistSong := &domain.PlaylistSong{
-		PlaylistID: playlistID,
-		SongID:     songID,
-		SongName:   songName,
-		SingerName: singerName,
-		Position:   maxPos + 1,
-		AddedAt:    time.Now(),
+	if err := s.playlistSongRepo.Move(ctx, playlistID, songID, position); err != nil {
+		return err
 	}
 
-	if err := s.playlistSongRepo.Add(ctx, playlistSong); err != nil {
+	s.notifyCollaborators(ctx, playlist, userID, "song_moved", map[string]interface{}{
+		"song_id":  songID,
+		"position": position,
+	})
+	return nil
+}
+
+// SortSongs 按歌名、歌手或添加时间重排歌单（会覆盖手动调整的顺序）
+func (s *PlaylistService) SortSongs(ctx context.Context, playlistID, userID, sortBy string, descending bool) error {
+	if err := domain.ValidatePlaylistSongSort(sortBy); err != nil {
+		return err
+	}
+	playlist, err := s.editablePlaylist(ctx, playlistID, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.playlistSongRepo.Sort(ctx, playlistID, sortBy, descending); err != nil {
 		return err
 	}
 
-	// 增加歌单歌曲数量
-	return s.playlistRepo.IncrementSongCount(ctx, playlistID)
+	s.notifyCollaborators(ctx, playlist, userID, "songs_sorted", map[string]interface{}{
+		"sort_by":    sortBy,
+		"descending": descending,
+	})
+	return nil
 }
 
-// RemoveSongFromPlaylist 从歌单移除歌曲
-func (s *PlaylistService) RemoveSongFromPlaylist(ctx context.Context, playlistID, userID, songID string) error {
+// editablePlaylist 获取用户可以编辑歌曲的歌单：需要编辑者以上权限，智能歌单的歌曲只读
+func (s *PlaylistService) editablePlaylist(ctx context.Context, playlistID, userID string) (*domain.UserPlaylist, error) {
 	playlist, err := s.playlistRepo.GetByID(ctx, playlistID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if err := s.Authorize(ctx, playlist, userID, domain.PlaylistRoleEditor); err != nil {
+		return nil, err
 	}
+	if playlist.IsSmart() {
+		return nil, domain.ErrSmartPlaylistReadOnly
+	}
+	return playlist, nil
+}
 
-	if playlist.UserID != userID {
-		return domain.ErrUnauthorized
+// Role 获取用户在歌单中的角色，不是所有者也不是成员时返回空字符串
+func (s *PlaylistService) Role(ctx context.Context, playlist *domain.UserPlaylist, userID string) (string, error) {
+	if userID == "" {
+		return "", nil
+	}
+	if playlist.UserID == userID {
+		return domain.PlaylistRoleOwner, nil
 	}
+	if s.memberRepo == nil {
+		return "", nil
+	}
+
+	member, err := s.memberRepo.Get(ctx, playlist.ID, userID)
+	if errors.Is(err, domain.ErrMemberNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return member.Role, nil
+}
 
-	if err := s.playlistSongRepo.Remove(ctx, playlistID, songID); err != nil {
+// Authorize 检查用户在歌单中是否具备required角色的权限
+func (s *PlaylistService) Authorize(ctx context.Context, playlist *domain.UserPlaylist, userID, required string) error {
+	role, err := s.Role(ctx, playlist, userID)
+	if err != nil {
 		return err
 	}
+	if !domain.RoleAllows(role, required) {
+		return domain.ErrUnauthorized
+	}
+	return nil
+}
+
+// CanRead 判断用户能否查看歌单：公开歌单任何人可看，私有歌单需要是所有者或成员
+func (s *PlaylistService) CanRead(ctx context.Context, playlist *domain.UserPlaylist, userID string) error {
+	if playlist.IsPublic {
+		return nil
+	}
+	return s.Authorize(ctx, playlist, userID, domain.PlaylistRoleViewer)
+}
+
+// GetSharedPlaylists 获取用户作为协作成员加入的歌单
+func (s *PlaylistService) GetSharedPlaylists(ctx context.Context, userID string, page, pageSize int) ([]*domain.UserPlaylist, error) {
+	offset := (page - 1) * pageSize
+	return s.playlistRepo.ListSharedWithUser(ctx, userID, pageSize, offset)
+}
+
+// collaborators 歌单的所有者和全部成员
+func (s *PlaylistService) collaborators(ctx context.Context, playlist *domain.UserPlaylist) []string {
+	recipients := []string{playlist.UserID}
+	if s.memberRepo == nil {
+		return recipients
+	}
 
-	// 减少歌单歌曲数量
-	return s.playlistRepo.DecrementSongCount(ctx, playlistID)
+	members, err := s.memberRepo.List(ctx, playlist.ID)
+	if err != nil {
+		log.Printf("Failed to list members of playlist %s: %v", playlist.ID, err)
+		return recipients
+	}
+	for _, member := range members {
+		recipients = append(recipients, member.UserID)
+	}
+	return recipients
+}
+
+// notifyCollaborators 把歌单变更推送给所有者和全部成员（包括操作者自己的其他设备）
+func (s *PlaylistService) notifyCollaborators(ctx context.Context, playlist *domain.UserPlaylist, actorID, action string, extra map[string]interface{}) {
+	s.publish(ctx, s.collaborators(ctx, playlist), playlist.ID, actorID, action, extra)
+}
+
+// publish 推送歌单变更事件，推送失败不影响操作结果
+func (s *PlaylistService) publish(ctx context.Context, recipients []string, playlistID, actorID, action string, extra map[string]interface{}) {
+	for _, userID := range recipients {
+		data := map[string]interface{}{
+			"playlist_id": playlistID,
+			"action":      action,
+			"actor_id":    actorID,
+		}
+		for k, v := range extra {
+			data[k] = v
+		}
+		if err := s.notifier.Publish(ctx, userID, syncevent.TypePlaylistUpdated, data); err != nil {
+			log.Printf("Failed to publish playlist %s %s event to user %s: %v", playlistID, action, userID, err)
+		}
+	}
 }
 
 // GetPlaylistSongs 获取歌单的所有歌曲
+// 智能歌单的歌曲在读取时根据规则计算
 func (s *PlaylistService) GetPlaylistSongs(ctx context.Context, playlistID string) ([]*domain.PlaylistSong, error) {
+	playlist, err := s.playlistRepo.GetByID(ctx, playlistID)
+	if err != nil {
+		return nil, err
+	}
+	if playlist.IsSmart() {
+		return s.evaluateSmartPlaylist(ctx, playlist)
+	}
 	return s.playlistSongRepo.List(ctx, playlistID)
 }
+
+// evaluateSmartPlaylist 计算智能歌单歌曲，优先使用缓存
+func (s *PlaylistService) evaluateSmartPlaylist(ctx context.Context, playlist *domain.UserPlaylist) ([]*domain.PlaylistSong, error) {
+	if s.smartCache != nil {
+		songs, ok, err := s.smartCache.GetSongs(ctx, playlist.UserID, playlist.ID)
+		if err != nil {
+			log.Printf("Failed to read cached smart playlist %s: %v", playlist.ID, err)
+		} else if ok {
+			return songs, nil
+		}
+	}
+
+	library, err := s.smartRepo.ListLibrary(ctx, playlist.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	matched := playlist.SmartRules.Evaluate(library, now)
+	songs := make([]*domain.PlaylistSong, 0, len(matched))
+	for i, song := range matched {
+		addedAt := now
+		if song.FavoritedAt != nil {
+			addedAt = *song.FavoritedAt
+		} else if song.LastPlayedAt != nil {
+			addedAt = *song.LastPlayedAt
+		}
+		songs = append(songs, &domain.PlaylistSong{
+			PlaylistID: playlist.ID,
+			SongID:     song.SongID,
+			SongName:   song.SongName,
+			SingerName: song.SingerName,
+			Position:   i + 1,
+			AddedAt:    addedAt,
+		})
+	}
+
+	if s.smartCache != nil {
+		if err := s.smartCache.SaveSongs(ctx, playlist.UserID, playlist.ID, songs, SmartPlaylistTTL); err != nil {
+			log.Printf("Failed to cache smart playlist %s: %v", playlist.ID, err)
+		}
+	}
+
+	// 回写歌曲数量，使歌单列表显示的数量与内容一致
+	if playlist.SongCount != len(songs) {
+		if err := s.playlistRepo.SetSongCount(ctx, playlist.ID, len(songs)); err != nil {
+			log.Printf("Failed to update song count of smart playlist %s: %v", playlist.ID, err)
+		}
+	}
+
+	return songs, nil
+}
+
+// recordCreated 上报一次歌单创建，导入和复制歌单同样计入
+func (s *PlaylistService) recordCreated(userID string) {
+	s.metrics.Emit(stats.Event{Type: stats.EventPlaylistCreated, UserID: userID})
+}
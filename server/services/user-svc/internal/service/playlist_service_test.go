@@ -0,0 +1,344 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"user-svc/internal/domain"
+	"user-svc/internal/repository"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/listen-stream/server/shared/pkg/stats"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryPlaylistRepository 内存歌单仓储（用于测试，只实现用到的方法）
+type memoryPlaylistRepository struct {
+	repository.PlaylistRepository
+	playlists map[string]*domain.UserPlaylist
+	songRepo  *memoryPlaylistSongRepository // CreateWithSongs写入的歌曲，为nil时丢弃
+	createErr error                         // CreateWithSongs模拟事务失败，不写入任何数据
+}
+
+func (r *memoryPlaylistRepository) Create(ctx context.Context, playlist *domain.UserPlaylist) error {
+	r.playlists[playlist.ID] = playlist
+	return nil
+}
+
+func (r *memoryPlaylistRepository) CreateWithSongs(ctx context.Context, playlist *domain.UserPlaylist, songs []*domain.PlaylistSong) error {
+	if r.createErr != nil {
+		return r.createErr
+	}
+	r.playlists[playlist.ID] = playlist
+	if r.songRepo != nil {
+		r.songRepo.songs[playlist.ID] = append(r.songRepo.songs[playlist.ID], songs...)
+	}
+	return nil
+}
+
+func (r *memoryPlaylistRepository) GetByID(ctx context.Context, id string) (*domain.UserPlaylist, error) {
+	playlist, ok := r.playlists[id]
+	if !ok || playlist.DeletedAt != nil {
+		return nil, domain.ErrPlaylistNotFound
+	}
+	copied := *playlist
+	return &copied, nil
+}
+
+func (r *memoryPlaylistRepository) Update(ctx context.Context, playlist *domain.UserPlaylist) error {
+	r.playlists[playlist.ID] = playlist
+	return nil
+}
+
+func (r *memoryPlaylistRepository) IncrementSongCount(ctx context.Context, playlistID string) error {
+	r.playlists[playlistID].SongCount++
+	return nil
+}
+
+func (r *memoryPlaylistRepository) DecrementSongCount(ctx context.Context, playlistID string) error {
+	r.playlists[playlistID].SongCount--
+	return nil
+}
+
+func (r *memoryPlaylistRepository) HardDelete(ctx context.Context, id string) error {
+	delete(r.playlists, id)
+	return nil
+}
+
+func (r *memoryPlaylistRepository) SetSongCount(ctx context.Context, playlistID string, count int) error {
+	r.playlists[playlistID].SongCount = count
+	return nil
+}
+
+// memoryLibraryRepository 内存曲库（用于测试）
+type memoryLibraryRepository struct {
+	library map[string][]*domain.LibrarySong
+	calls   int
+}
+
+func (r *memoryLibraryRepository) ListLibrary(ctx context.Context, userID string) ([]*domain.LibrarySong, error) {
+	r.calls++
+	return r.library[userID], nil
+}
+
+func daysAgo(now time.Time, days int) *time.Time {
+	t := now.AddDate(0, 0, -days)
+	return &t
+}
+
+func playlistSongIDs(songs []*domain.PlaylistSong) []string {
+	ids := make([]string, 0, len(songs))
+	for _, song := range songs {
+		ids = append(ids, song.SongID)
+	}
+	return ids
+}
+
+func newTestPlaylistService(t *testing.T, library *memoryLibraryRepository) (*PlaylistService, *memoryPlaylistRepository) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	playlistRepo := &memoryPlaylistRepository{playlists: map[string]*domain.UserPlaylist{}}
+	return NewPlaylistService(playlistRepo, nil, nil, library, repository.NewSmartPlaylistCache(client), nil, nil), playlistRepo
+}
+
+func TestSmartPlaylistRules_Evaluate(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	library := []*domain.LibrarySong{
+		{SongID: "fav-recent", SingerName: "周杰伦", FavoritedAt: daysAgo(now, 3), PlayCount: 2, LastPlayedAt: daysAgo(now, 1)},
+		{SongID: "fav-old", SingerName: "Jay Chou", FavoritedAt: daysAgo(now, 90), PlayCount: 30, LastPlayedAt: daysAgo(now, 200)},
+		{SongID: "played", SingerName: "林俊杰", PlayCount: 12, LastPlayedAt: daysAgo(now, 2)},
+		{SongID: "never-played", SingerName: "林俊杰", FavoritedAt: daysAgo(now, 400)},
+	}
+
+	tests := []struct {
+		name  string
+		rules domain.SmartPlaylistRules
+		want  []string
+	}{
+		{
+			name:  "favorited in last 30 days",
+			rules: domain.SmartPlaylistRules{Rules: []domain.SmartRule{{Field: domain.SmartFieldFavoritedAt, Op: domain.SmartOpInLastDays, Value: 30}}},
+			want:  []string{"fav-recent"},
+		},
+		{
+			name:  "played more than 10 times",
+			rules: domain.SmartPlaylistRules{Rules: []domain.SmartRule{{Field: domain.SmartFieldPlayCount, Op: domain.SmartOpGt, Value: 10}}},
+			want:  []string{"fav-old", "played"},
+		},
+		{
+			name:  "singer in list ignores case",
+			rules: domain.SmartPlaylistRules{Rules: []domain.SmartRule{{Field: domain.SmartFieldSinger, Op: domain.SmartOpIn, Values: []string{"周杰伦", "jay chou"}}}},
+			want:  []string{"fav-old", "fav-recent"},
+		},
+		{
+			name:  "not played for 6 months includes never played",
+			rules: domain.SmartPlaylistRules{Rules: []domain.SmartRule{{Field: domain.SmartFieldLastPlayedAt, Op: domain.SmartOpNotInLastDays, Value: 180}}},
+			want:  []string{"fav-old", "never-played"},
+		},
+		{
+			name: "match any",
+			rules: domain.SmartPlaylistRules{Match: domain.SmartMatchAny, Rules: []domain.SmartRule{
+				{Field: domain.SmartFieldFavorited, Op: domain.SmartOpIsNot},
+				{Field: domain.SmartFieldFavoritedAt, Op: domain.SmartOpInLastDays, Value: 30},
+			}},
+			want: []string{"played", "fav-recent"},
+		},
+		{
+			name: "limit and sort",
+			rules: domain.SmartPlaylistRules{SortBy: domain.SmartSortLastPlayedAt, Limit: 2, Rules: []domain.SmartRule{
+				{Field: domain.SmartFieldPlayCount, Op: domain.SmartOpGte, Value: 0},
+			}},
+			want: []string{"fav-recent", "played"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := tt.rules
+			rules.Normalize()
+			require.NoError(t, rules.Validate())
+
+			var got []string
+			for _, song := range rules.Evaluate(library, now) {
+				got = append(got, song.SongID)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSmartPlaylistRules_Validate(t *testing.T) {
+	invalid := []domain.SmartPlaylistRules{
+		{},
+		{Rules: []domain.SmartRule{{Field: "album", Op: domain.SmartOpIs}}},
+		{Rules: []domain.SmartRule{{Field: domain.SmartFieldPlayCount, Op: domain.SmartOpContains}}},
+		{Rules: []domain.SmartRule{{Field: domain.SmartFieldFavoritedAt, Op: domain.SmartOpInLastDays}}},
+		{Rules: []domain.SmartRule{{Field: domain.SmartFieldSinger, Op: domain.SmartOpIn}}},
+		{Limit: domain.MaxSmartPlaylistLimit + 1, Rules: []domain.SmartRule{{Field: domain.SmartFieldFavorited, Op: domain.SmartOpIs}}},
+		{Rules: []domain.SmartRule{{Field: domain.SmartFieldLastPlayedAt, Op: domain.SmartOpInLastDays, Value: 1e6}}},
+	}
+
+	for _, rules := range invalid {
+		rules.Normalize()
+		assert.ErrorIs(t, rules.Validate(), domain.ErrInvalidSmartRules)
+	}
+}
+
+func TestSmartPlaylist_LazyEvaluationAndInvalidation(t *testing.T) {
+	now := time.Now()
+	library := &memoryLibraryRepository{library: map[string][]*domain.LibrarySong{
+		"u1": {
+			{SongID: "a", PlayCount: 20, LastPlayedAt: daysAgo(now, 1)},
+			{SongID: "b", PlayCount: 11, LastPlayedAt: daysAgo(now, 1)},
+			{SongID: "c", PlayCount: 3, LastPlayedAt: daysAgo(now, 1)},
+		},
+	}}
+	svc, playlistRepo := newTestPlaylistService(t, library)
+	ctx := context.Background()
+
+	playlist, err := svc.CreateSmartPlaylist(ctx, "u1", "On repeat", "", "", false, &domain.SmartPlaylistRules{
+		Rules: []domain.SmartRule{{Field: domain.SmartFieldPlayCount, Op: domain.SmartOpGt, Value: 10}},
+	})
+	require.NoError(t, err)
+	assert.True(t, playlist.IsSmart())
+	assert.Equal(t, domain.SmartMatchAll, playlist.SmartRules.Match)
+
+	songs, err := svc.GetPlaylistSongs(ctx, playlist.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, playlistSongIDs(songs))
+	assert.Equal(t, 2, songs[1].Position)
+	assert.Equal(t, 2, playlistRepo.playlists[playlist.ID].SongCount)
+
+	// 第二次读取命中缓存
+	_, err = svc.GetPlaylistSongs(ctx, playlist.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, library.calls)
+
+	// 播放事件使缓存失效后重新计算
+	library.library["u1"][2].PlayCount = 15
+	svc.InvalidateSmartPlaylists(ctx, "u1")
+	songs, err = svc.GetPlaylistSongs(ctx, playlist.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "c", "b"}, playlistSongIDs(songs))
+	assert.Equal(t, 2, library.calls)
+
+	// 智能歌单不能手动增删歌曲
+	assert.ErrorIs(t, svc.AddSongToPlaylist(ctx, playlist.ID, "u1", "d", "d", "X"), domain.ErrSmartPlaylistReadOnly)
+	assert.ErrorIs(t, svc.RemoveSongFromPlaylist(ctx, playlist.ID, "u1", "a"), domain.ErrSmartPlaylistReadOnly)
+
+	// 修改规则后立即生效
+	_, err = svc.UpdateSmartRules(ctx, playlist.ID, "u1", &domain.SmartPlaylistRules{
+		Rules: []domain.SmartRule{{Field: domain.SmartFieldPlayCount, Op: domain.SmartOpLt, Value: 16}},
+	})
+	require.NoError(t, err)
+	songs, err = svc.GetPlaylistSongs(ctx, playlist.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c", "b"}, playlistSongIDs(songs))
+
+	_, err = svc.UpdateSmartRules(ctx, playlist.ID, "u2", &domain.SmartPlaylistRules{
+		Rules: []domain.SmartRule{{Field: domain.SmartFieldFavorited, Op: domain.SmartOpIs}},
+	})
+	assert.ErrorIs(t, err, domain.ErrUnauthorized)
+}
+
+func TestUpdateSmartRules_ManualPlaylist(t *testing.T) {
+	svc, _ := newTestPlaylistService(t, &memoryLibraryRepository{})
+	ctx := context.Background()
+
+	playlist, err := svc.CreatePlaylist(ctx, "u1", "Manual", "", "", false)
+	require.NoError(t, err)
+
+	_, err = svc.UpdateSmartRules(ctx, playlist.ID, "u1", &domain.SmartPlaylistRules{
+		Rules: []domain.SmartRule{{Field: domain.SmartFieldFavorited, Op: domain.SmartOpIs}},
+	})
+	assert.ErrorIs(t, err, domain.ErrNotSmartPlaylist)
+}
+
+func TestPlaylistSongs_BatchMoveSort(t *testing.T) {
+	playlistRepo := &memoryPlaylistRepository{playlists: map[string]*domain.UserPlaylist{
+		"p1": {ID: "p1", UserID: "u1", Name: "Mix"},
+	}}
+	songRepo := &memoryPlaylistSongRepository{songs: map[string][]*domain.PlaylistSong{}}
+	svc := NewPlaylistService(playlistRepo, songRepo, nil, nil, nil, nil, nil)
+	ctx := context.Background()
+
+	added, err := svc.AddSongsToPlaylist(ctx, "p1", "u1", []*domain.PlaylistSong{
+		{SongID: "c", SongName: "Cherry", SingerName: "Z"},
+		{SongID: "a", SongName: "Apple", SingerName: "Y"},
+		{SongID: "c", SongName: "Cherry", SingerName: "Z"},
+		{SongID: "b", SongName: "Banana", SingerName: "X"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, added)
+
+	// 已在歌单中的歌曲被跳过
+	added, err = svc.AddSongsToPlaylist(ctx, "p1", "u1", []*domain.PlaylistSong{{SongID: "a"}, {SongID: "d", SongName: "Date", SingerName: "W"}})
+	require.NoError(t, err)
+	assert.Equal(t, 1, added)
+	assert.Equal(t, []string{"c", "a", "b", "d"}, playlistSongIDs(songRepo.songs["p1"]))
+	assert.ErrorIs(t, svc.AddSongToPlaylist(ctx, "p1", "u1", "a", "Apple", "Y"), domain.ErrSongAlreadyInPlaylist)
+
+	_, err = svc.AddSongsToPlaylist(ctx, "p1", "u1", make([]*domain.PlaylistSong, domain.MaxBatchPlaylistSongs+1))
+	assert.ErrorIs(t, err, domain.ErrTooManyBatchSongs)
+	_, err = svc.AddSongsToPlaylist(ctx, "p1", "u2", []*domain.PlaylistSong{{SongID: "e"}})
+	assert.ErrorIs(t, err, domain.ErrUnauthorized)
+
+	require.NoError(t, svc.MoveSong(ctx, "p1", "u1", "d", 1))
+	assert.Equal(t, []string{"d", "c", "a", "b"}, playlistSongIDs(songRepo.songs["p1"]))
+	require.NoError(t, svc.MoveSong(ctx, "p1", "u1", "d", 99))
+	assert.Equal(t, []string{"c", "a", "b", "d"}, playlistSongIDs(songRepo.songs["p1"]))
+	assert.ErrorIs(t, svc.MoveSong(ctx, "p1", "u1", "d", 0), domain.ErrInvalidPosition)
+	assert.ErrorIs(t, svc.MoveSong(ctx, "p1", "u1", "zz", 1), domain.ErrSongNotInPlaylist)
+
+	require.NoError(t, svc.SortSongs(ctx, "p1", "u1", domain.PlaylistSongSortName, false))
+	assert.Equal(t, []string{"a", "b", "c", "d"}, playlistSongIDs(songRepo.songs["p1"]))
+	require.NoError(t, svc.SortSongs(ctx, "p1", "u1", domain.PlaylistSongSortSinger, true))
+	assert.Equal(t, []string{"c", "a", "b", "d"}, playlistSongIDs(songRepo.songs["p1"]))
+	assert.ErrorIs(t, svc.SortSongs(ctx, "p1", "u1", "duration", false), domain.ErrInvalidPlaylistSongSort)
+
+	removed, err := svc.RemoveSongsFromPlaylist(ctx, "p1", "u1", []string{"a", "zz", "c"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed)
+	songs := songRepo.songs["p1"]
+	assert.Equal(t, []string{"b", "d"}, playlistSongIDs(songs))
+	assert.Equal(t, 1, songs[0].Position)
+	assert.Equal(t, 2, songs[1].Position)
+	assert.ErrorIs(t, svc.RemoveSongFromPlaylist(ctx, "p1", "u1", "a"), domain.ErrSongNotInPlaylist)
+}
+
+// recordingEmitter 记录上报的统计事件
+type recordingEmitter struct {
+	events []stats.Event
+}
+
+func (e *recordingEmitter) Emit(event stats.Event) {
+	e.events = append(e.events, event)
+}
+
+func TestPlaylistCreation_RecordsMetrics(t *testing.T) {
+	playlistRepo := &memoryPlaylistRepository{playlists: map[string]*domain.UserPlaylist{}}
+	songRepo := &memoryPlaylistSongRepository{songs: map[string][]*domain.PlaylistSong{}}
+	metrics := &recordingEmitter{}
+	svc := NewPlaylistService(playlistRepo, songRepo, nil, nil, nil, nil, metrics)
+	transfer := NewPlaylistTransferService(svc, playlistRepo, songRepo, "")
+	ctx := context.Background()
+
+	_, err := svc.CreatePlaylist(ctx, "u1", "Mix", "", "", false)
+	require.NoError(t, err)
+	_, err = transfer.ImportPlaylist(ctx, "u2", "Imported", "", "", false, []*domain.PlaylistSong{{SongID: "a"}})
+	require.NoError(t, err)
+
+	// 创建失败的歌单不计入
+	_, err = svc.CreatePlaylist(ctx, "u1", "", "", "", false)
+	require.Error(t, err)
+
+	require.Len(t, metrics.events, 2)
+	assert.Equal(t, stats.EventPlaylistCreated, metrics.events[0].Type)
+	assert.Equal(t, "u1", metrics.events[0].UserID)
+	assert.Equal(t, "u2", metrics.events[1].UserID)
+}
@@ -0,0 +1,291 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"user-svc/internal/domain"
+	"user-svc/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// PlaylistTransferService 歌单导入导出服务
+// 导入：proxy-svc解析外部平台（QQ音乐/网易云/酷狗）歌单并匹配到曲库后，在此创建歌单并批量写入歌曲
+// 导出：把任意歌单（包括智能歌单）渲染为M3U8、XSPF或JSON文件
+type PlaylistTransferService struct {
+	playlistService  *PlaylistService
+	playlistRepo     repository.PlaylistRepository
+	playlistSongRepo repository.PlaylistSongRepository
+	songURLBase      string
+	now              func() time.Time
+}
+
+// NewPlaylistTransferService 创建歌单导入导出服务
+// songURLBase为导出文件中歌曲播放地址的前缀（对外网关地址），为空时使用相对地址
+func NewPlaylistTransferService(playlistService *PlaylistService, playlistRepo repository.PlaylistRepository, playlistSongRepo repository.PlaylistSongRepository, songURLBase string) *PlaylistTransferService {
+	return &PlaylistTransferService{
+		playlistService:  playlistService,
+		playlistRepo:     playlistRepo,
+		playlistSongRepo: playlistSongRepo,
+		songURLBase:      strings.TrimRight(songURLBase, "/"),
+		now:              time.Now,
+	}
+}
+
+// ImportPlaylist 创建歌单并导入歌曲，歌曲按传入顺序排列，重复的歌曲只保留第一次出现
+func (s *PlaylistTransferService) ImportPlaylist(ctx context.Context, userID, name, description, coverURL string, isPublic bool, songs []*domain.PlaylistSong) (*domain.PlaylistImportResult, error) {
+	if userID == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+	if err := domain.ValidatePlaylistName(name); err != nil {
+		return nil, err
+	}
+	if len(songs) > domain.MaxImportSongs {
+		return nil, domain.ErrTooManyImportSongs
+	}
+	// 外部平台的简介可能超长，截断而不是拒绝导入
+	description = truncateUTF8(description, 500)
+
+	now := s.now()
+	playlist := &domain.UserPlaylist{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		Name:        name,
+		Description: description,
+		CoverURL:    coverURL,
+		IsPublic:    isPublic,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	imported, err := createPlaylistWithSongs(ctx, s.playlistRepo, playlist, songs)
+	if err != nil {
+		return nil, err
+	}
+	s.playlistService.recordCreated(userID)
+
+	return &domain.PlaylistImportResult{
+		Playlist:   playlist,
+		Imported:   imported,
+		Duplicates: len(songs) - imported,
+	}, nil
+}
+
+// createPlaylistWithSongs 创建歌单并批量写入歌曲，歌曲按传入顺序排列，重复的歌曲只保留第一次出现
+// 歌单和歌曲在同一事务中写入，失败时不会留下空歌单；返回实际写入的歌曲数
+func createPlaylistWithSongs(ctx context.Context, playlistRepo repository.PlaylistRepository, playlist *domain.UserPlaylist, songs []*domain.PlaylistSong) (int, error) {
+	seen := make(map[string]bool, len(songs))
+	toInsert := make([]*domain.PlaylistSong, 0, len(songs))
+	for _, song := range songs {
+		if song.SongID == "" {
+			return 0, domain.ErrInvalidSongID
+		}
+		if seen[song.SongID] {
+			continue
+		}
+		seen[song.SongID] = true
+		toInsert = append(toInsert, &domain.PlaylistSong{
+			PlaylistID: playlist.ID,
+			SongID:     song.SongID,
+			SongName:   song.SongName,
+			SingerName: song.SingerName,
+			Position:   len(toInsert) + 1,
+			AddedAt:    playlist.CreatedAt,
+		})
+	}
+	playlist.SongCount = len(toInsert)
+
+	if err := playlistRepo.CreateWithSongs(ctx, playlist, toInsert); err != nil {
+		return 0, fmt.Errorf("create playlist with songs: %w", err)
+	}
+	return len(toInsert), nil
+}
+
+// ExportPlaylist 导出歌单，可以导出公开歌单和自己所有或参与协作的歌单
+func (s *PlaylistTransferService) ExportPlaylist(ctx context.Context, playlistID, userID, format string) (*domain.PlaylistFile, error) {
+	format = strings.ToLower(format)
+	if err := domain.ValidateExportFormat(format); err != nil {
+		return nil, err
+	}
+
+	playlist, err := s.playlistRepo.GetByID(ctx, playlistID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.playlistService.CanRead(ctx, playlist, userID); err != nil {
+		return nil, err
+	}
+
+	songs, err := s.playlistService.GetPlaylistSongs(ctx, playlistID)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		content     []byte
+		contentType string
+	)
+	switch format {
+	case domain.ExportFormatM3U8:
+		content, contentType = s.renderM3U8(playlist, songs), "audio/x-mpegurl; charset=utf-8"
+	case domain.ExportFormatXSPF:
+		content, err = s.renderXSPF(playlist, songs)
+		contentType = "application/xspf+xml"
+	case domain.ExportFormatJSON:
+		content, err = s.renderJSON(playlist, songs)
+		contentType = "application/json"
+	}
+	if err != nil {
+		return nil, fmt.Errorf("render %s playlist: %w", format, err)
+	}
+
+	return &domain.PlaylistFile{
+		Filename:    exportFilename(playlist.Name, format),
+		ContentType: contentType,
+		Content:     content,
+	}, nil
+}
+
+// songLocation 歌曲播放地址（经proxy-svc获取播放URL）
+func (s *PlaylistTransferService) songLocation(songID string) string {
+	return s.songURLBase + "/api/song/url?song_mid=" + url.QueryEscape(songID)
+}
+
+// renderM3U8 渲染扩展M3U（UTF-8），时长未知时按规范写-1
+func (s *PlaylistTransferService) renderM3U8(playlist *domain.UserPlaylist, songs []*domain.PlaylistSong) []byte {
+	var b bytes.Buffer
+	b.WriteString("#EXTM3U\n")
+	fmt.Fprintf(&b, "#PLAYLIST:%s\n", m3uEscape(playlist.Name))
+	for _, song := range songs {
+		title := m3uEscape(song.SongName)
+		if song.SingerName != "" {
+			title = m3uEscape(song.SingerName) + " - " + title
+		}
+		fmt.Fprintf(&b, "#EXTINF:-1,%s\n", title)
+		b.WriteString(s.songLocation(song.SongID))
+		b.WriteString("\n")
+	}
+	return b.Bytes()
+}
+
+// xspfPlaylist XSPF 1.0 (http://xspf.org/xspf-v1.html)
+type xspfPlaylist struct {
+	XMLName    xml.Name    `xml:"playlist"`
+	Version    string      `xml:"version,attr"`
+	Xmlns      string      `xml:"xmlns,attr"`
+	Title      string      `xml:"title"`
+	Annotation string      `xml:"annotation,omitempty"`
+	Image      string      `xml:"image,omitempty"`
+	Date       string      `xml:"date"`
+	Tracks     []xspfTrack `xml:"trackList>track"`
+}
+
+type xspfTrack struct {
+	Location   string `xml:"location"`
+	Identifier string `xml:"identifier"`
+	Title      string `xml:"title"`
+	Creator    string `xml:"creator,omitempty"`
+	TrackNum   int    `xml:"trackNum"`
+}
+
+func (s *PlaylistTransferService) renderXSPF(playlist *domain.UserPlaylist, songs []*domain.PlaylistSong) ([]byte, error) {
+	doc := xspfPlaylist{
+		Version:    "1",
+		Xmlns:      "http://xspf.org/ns/0/",
+		Title:      playlist.Name,
+		Annotation: playlist.Description,
+		Image:      playlist.CoverURL,
+		Date:       s.now().UTC().Format(time.RFC3339),
+		Tracks:     make([]xspfTrack, 0, len(songs)),
+	}
+	for i, song := range songs {
+		doc.Tracks = append(doc.Tracks, xspfTrack{
+			Location:   s.songLocation(song.SongID),
+			Identifier: "listen-stream:song:" + song.SongID,
+			Title:      song.SongName,
+			Creator:    song.SingerName,
+			TrackNum:   i + 1,
+		})
+	}
+
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(data, '\n')...), nil
+}
+
+// playlistJSONFormat JSON导出格式标识，导入方可据此识别版本
+const playlistJSONFormat = "listen-stream.playlist.v1"
+
+func (s *PlaylistTransferService) renderJSON(playlist *domain.UserPlaylist, songs []*domain.PlaylistSong) ([]byte, error) {
+	type exportedSong struct {
+		Position   int    `json:"position"`
+		SongID     string `json:"song_id"`
+		SongName   string `json:"song_name"`
+		SingerName string `json:"singer_name"`
+		Location   string `json:"location"`
+	}
+
+	exported := make([]exportedSong, 0, len(songs))
+	for i, song := range songs {
+		exported = append(exported, exportedSong{
+			Position:   i + 1,
+			SongID:     song.SongID,
+			SongName:   song.SongName,
+			SingerName: song.SingerName,
+			Location:   s.songLocation(song.SongID),
+		})
+	}
+
+	return json.MarshalIndent(map[string]interface{}{
+		"format":      playlistJSONFormat,
+		"exported_at": s.now().UTC().Format(time.RFC3339),
+		"playlist": map[string]interface{}{
+			"name":        playlist.Name,
+			"description": playlist.Description,
+			"cover_url":   playlist.CoverURL,
+			"smart_rules": playlist.SmartRules,
+		},
+		"songs": exported,
+	}, "", "  ")
+}
+
+// m3uEscape M3U是按行解析的，去掉标题中的换行
+func m3uEscape(s string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(s)
+}
+
+var unsafeFilenameChars = regexp.MustCompile(`[\\/:*?"<>|\x00-\x1f]+`)
+
+// exportFilename 生成导出文件名
+func exportFilename(name, format string) string {
+	base := strings.TrimSpace(unsafeFilenameChars.ReplaceAllString(name, "_"))
+	if base == "" {
+		base = "playlist"
+	}
+	return base + "." + format
+}
+
+// truncateUTF8 按字节截断字符串，不截断在多字节字符中间
+func truncateUTF8(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	cut := 0
+	for i := range s {
+		if i > max {
+			break
+		}
+		cut = i
+	}
+	return s[:cut]
+}
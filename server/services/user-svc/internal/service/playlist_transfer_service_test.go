@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"user-svc/internal/domain"
+	"user-svc/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryPlaylistSongRepository 内存歌单歌曲仓储（用于测试，只实现导入导出和增删歌曲用到的方法）
+type memoryPlaylistSongRepository struct {
+	repository.PlaylistSongRepository
+	songs map[string][]*domain.PlaylistSong
+}
+
+func (r *memoryPlaylistSongRepository) List(ctx context.Context, playlistID string) ([]*domain.PlaylistSong, error) {
+	return r.songs[playlistID], nil
+}
+
+func (r *memoryPlaylistSongRepository) AddSongs(ctx context.Context, playlistID string, songs []*domain.PlaylistSong) (int64, error) {
+	seen := map[string]bool{}
+	for _, song := range r.songs[playlistID] {
+		seen[song.SongID] = true
+	}
+	var added int64
+	for _, song := range songs {
+		if seen[song.SongID] {
+			continue
+		}
+		seen[song.SongID] = true
+		song.PlaylistID = playlistID
+		song.Position = len(r.songs[playlistID]) + 1
+		r.songs[playlistID] = append(r.songs[playlistID], song)
+		added++
+	}
+	return added, nil
+}
+
+func (r *memoryPlaylistSongRepository) RemoveSongs(ctx context.Context, playlistID string, songIDs []string) (int64, error) {
+	remove := map[string]bool{}
+	for _, songID := range songIDs {
+		remove[songID] = true
+	}
+	songs := r.songs[playlistID][:0]
+	for _, song := range r.songs[playlistID] {
+		if !remove[song.SongID] {
+			songs = append(songs, song)
+		}
+	}
+	removed := int64(len(r.songs[playlistID]) - len(songs))
+	r.songs[playlistID] = songs
+	r.renumber(playlistID)
+	return removed, nil
+}
+
+func (r *memoryPlaylistSongRepository) Move(ctx context.Context, playlistID, songID string, position int) error {
+	songs := r.songs[playlistID]
+	from := -1
+	for i, song := range songs {
+		if song.SongID == songID {
+			from = i
+		}
+	}
+	if from < 0 {
+		return domain.ErrSongNotInPlaylist
+	}
+	if position > len(songs) {
+		position = len(songs)
+	}
+	moved := songs[from]
+	songs = append(songs[:from], songs[from+1:]...)
+	songs = append(songs[:position-1], append([]*domain.PlaylistSong{moved}, songs[position-1:]...)...)
+	r.songs[playlistID] = songs
+	r.renumber(playlistID)
+	return nil
+}
+
+func (r *memoryPlaylistSongRepository) Sort(ctx context.Context, playlistID, sortBy string, descending bool) error {
+	songs := r.songs[playlistID]
+	sort.SliceStable(songs, func(i, j int) bool {
+		a, b := songs[i].SongName, songs[j].SongName
+		if sortBy == domain.PlaylistSongSortSinger {
+			a, b = songs[i].SingerName, songs[j].SingerName
+		}
+		if descending {
+			return a > b
+		}
+		return a < b
+	})
+	r.renumber(playlistID)
+	return nil
+}
+
+func (r *memoryPlaylistSongRepository) renumber(playlistID string) {
+	for i, song := range r.songs[playlistID] {
+		song.Position = i + 1
+	}
+}
+
+func newTestTransferService() (*PlaylistTransferService, *memoryPlaylistRepository, *memoryPlaylistSongRepository) {
+	songRepo := &memoryPlaylistSongRepository{songs: map[string][]*domain.PlaylistSong{}}
+	playlistRepo := &memoryPlaylistRepository{playlists: map[string]*domain.UserPlaylist{}, songRepo: songRepo}
+	playlistService := NewPlaylistService(playlistRepo, songRepo, nil, nil, nil, nil, nil)
+
+	svc := NewPlaylistTransferService(playlistService, playlistRepo, songRepo, "https://api.example.com/")
+	svc.now = func() time.Time { return time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC) }
+	return svc, playlistRepo, songRepo
+}
+
+func TestImportPlaylist(t *testing.T) {
+	svc, playlistRepo, songRepo := newTestTransferService()
+	ctx := context.Background()
+
+	result, err := svc.ImportPlaylist(ctx, "u1", "Imported", strings.Repeat("长", 300), "cover.jpg", true, []*domain.PlaylistSong{
+		{SongID: "a", SongName: "A", SingerName: "X"},
+		{SongID: "b", SongName: "B", SingerName: "Y"},
+		{SongID: "a", SongName: "A", SingerName: "X"},
+		{SongID: "c", SongName: "C", SingerName: "Z"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Imported)
+	assert.Equal(t, 1, result.Duplicates)
+	assert.Equal(t, 3, result.Playlist.SongCount)
+	assert.LessOrEqual(t, len(result.Playlist.Description), 500)
+
+	songs := songRepo.songs[result.Playlist.ID]
+	assert.Equal(t, []string{"a", "b", "c"}, playlistSongIDs(songs))
+	for i, song := range songs {
+		assert.Equal(t, i+1, song.Position)
+	}
+	assert.Contains(t, playlistRepo.playlists, result.Playlist.ID)
+}
+
+func TestImportPlaylist_Errors(t *testing.T) {
+	svc, playlistRepo, songRepo := newTestTransferService()
+	ctx := context.Background()
+
+	_, err := svc.ImportPlaylist(ctx, "u1", "", "", "", false, nil)
+	assert.ErrorIs(t, err, domain.ErrInvalidPlaylistName)
+
+	_, err = svc.ImportPlaylist(ctx, "u1", "name", "", "", false, []*domain.PlaylistSong{{SongName: "no id"}})
+	assert.ErrorIs(t, err, domain.ErrInvalidSongID)
+
+	tooMany := make([]*domain.PlaylistSong, domain.MaxImportSongs+1)
+	_, err = svc.ImportPlaylist(ctx, "u1", "name", "", "", false, tooMany)
+	assert.ErrorIs(t, err, domain.ErrTooManyImportSongs)
+
+	// 写入歌曲失败时事务回滚，不留下空歌单
+	playlistRepo.createErr = errors.New("copy failed")
+	_, err = svc.ImportPlaylist(ctx, "u1", "name", "", "", false, []*domain.PlaylistSong{{SongID: "a"}})
+	assert.Error(t, err)
+	assert.Empty(t, playlistRepo.playlists)
+	assert.Empty(t, songRepo.songs)
+}
+
+func TestExportPlaylist(t *testing.T) {
+	svc, playlistRepo, songRepo := newTestTransferService()
+	ctx := context.Background()
+
+	playlistRepo.playlists["p1"] = &domain.UserPlaylist{ID: "p1", UserID: "u1", Name: "Road/Trip", Description: "desc"}
+	songRepo.songs["p1"] = []*domain.PlaylistSong{
+		{PlaylistID: "p1", SongID: "mid 1", SongName: "晴天", SingerName: "周杰伦", Position: 1},
+		{PlaylistID: "p1", SongID: "mid2", SongName: "Line\nBreak", Position: 2},
+	}
+
+	file, err := svc.ExportPlaylist(ctx, "p1", "u1", "M3U8")
+	require.NoError(t, err)
+	assert.Equal(t, "Road_Trip.m3u8", file.Filename)
+	assert.Equal(t, "#EXTM3U\n"+
+		"#PLAYLIST:Road/Trip\n"+
+		"#EXTINF:-1,周杰伦 - 晴天\n"+
+		"https://api.example.com/api/song/url?song_mid=mid+1\n"+
+		"#EXTINF:-1,Line Break\n"+
+		"https://api.example.com/api/song/url?song_mid=mid2\n", string(file.Content))
+
+	file, err = svc.ExportPlaylist(ctx, "p1", "u1", domain.ExportFormatXSPF)
+	require.NoError(t, err)
+	assert.Equal(t, "application/xspf+xml", file.ContentType)
+	content := string(file.Content)
+	assert.True(t, strings.HasPrefix(content, "<?xml"))
+	assert.Contains(t, content, `<playlist version="1" xmlns="http://xspf.org/ns/0/">`)
+	assert.Contains(t, content, "<identifier>listen-stream:song:mid2</identifier>")
+	assert.Contains(t, content, "<creator>周杰伦</creator>")
+
+	file, err = svc.ExportPlaylist(ctx, "p1", "u1", domain.ExportFormatJSON)
+	require.NoError(t, err)
+	var doc struct {
+		Format string `json:"format"`
+		Songs  []struct {
+			Position int    `json:"position"`
+			SongID   string `json:"song_id"`
+		} `json:"songs"`
+	}
+	require.NoError(t, json.Unmarshal(file.Content, &doc))
+	assert.Equal(t, playlistJSONFormat, doc.Format)
+	require.Len(t, doc.Songs, 2)
+	assert.Equal(t, "mid2", doc.Songs[1].SongID)
+	assert.Equal(t, 2, doc.Songs[1].Position)
+
+	_, err = svc.ExportPlaylist(ctx, "p1", "u1", "wpl")
+	assert.ErrorIs(t, err, domain.ErrInvalidExportFormat)
+
+	// 私有歌单只有所有者和成员可以导出，公开歌单任何人都可以
+	_, err = svc.ExportPlaylist(ctx, "p1", "u2", domain.ExportFormatM3U8)
+	assert.ErrorIs(t, err, domain.ErrUnauthorized)
+	playlistRepo.playlists["p1"].IsPublic = true
+	_, err = svc.ExportPlaylist(ctx, "p1", "u2", domain.ExportFormatM3U8)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,459 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"user-svc/internal/domain"
+	"user-svc/internal/repository"
+)
+
+const (
+	// RecommendationSignalWindowDays 播放信号的统计窗口（天）
+	RecommendationSignalWindowDays = 90
+	// RecommendationSignalsPerUser 每个用户参与共现计算的歌曲数（按偏好权重取前N首）
+	// 共现计算的内存占用约为 用户数 × N² / 2，调大前需评估
+	RecommendationSignalsPerUser = 30
+	// RecommendationNeighborsPerSong 每首歌保留的相似歌曲数
+	RecommendationNeighborsPerSong = 50
+	// MinCoOccurrence 歌曲对最少共同出现的用户数，低于该值视为噪声
+	MinCoOccurrence = 2
+	// DailyMixSize 每日推荐歌曲数
+	DailyMixSize = 30
+	// MaxSongsPerSingerInMix 每日推荐中同一歌手的最多歌曲数
+	MaxSongsPerSingerInMix = 3
+	// BecauseYouLikedRows "因为你喜欢X"的行数
+	BecauseYouLikedRows = 3
+	// BecauseYouLikedSize 每行的推荐歌曲数
+	BecauseYouLikedSize = 10
+	// UpstreamSimilarSeeds 每个用户用于查询上游相似歌曲的种子歌曲数
+	UpstreamSimilarSeeds = 5
+	// RecommendationTTL 离线推荐结果的缓存时间（覆盖两次离线计算的间隔）
+	RecommendationTTL = 48 * time.Hour
+	// OnDemandRecommendationTTL 按需计算的推荐结果缓存时间
+	OnDemandRecommendationTTL = time.Hour
+	// SimilarSongsTTL 上游相似歌曲的缓存时间
+	SimilarSongsTTL = 7 * 24 * time.Hour
+
+	// upstreamSimilarWeight 上游相似歌曲相对于共现相似度的权重
+	upstreamSimilarWeight = 0.5
+	// dailyMixJitter 每日推荐按日期扰动得分的幅度，使每天的结果有变化
+	dailyMixJitter = 0.2
+	// becauseYouLikedSeedPool "因为你喜欢X"的候选种子数，每天轮换其中的BecauseYouLikedRows首
+	becauseYouLikedSeedPool    = 10
+	recommendationUserPageSize = 500
+)
+
+// RecommendationService 个性化推荐服务
+// 离线任务根据所有用户的收藏、歌单和播放记录计算歌曲共现相似度，结合上游相似歌曲
+// 为每个用户生成每日推荐和"因为你喜欢X"推荐行，结果缓存在Redis中
+type RecommendationService struct {
+	repo    repository.RecommendationRepository
+	cache   repository.RecommendationCache
+	similar SimilarSongSource
+	now     func() time.Time
+}
+
+// NewRecommendationService 创建推荐服务
+// similar为nil时只使用共现相似度
+func NewRecommendationService(repo repository.RecommendationRepository, cache repository.RecommendationCache, similar SimilarSongSource) *RecommendationService {
+	return &RecommendationService{
+		repo:    repo,
+		cache:   cache,
+		similar: similar,
+		now:     time.Now,
+	}
+}
+
+// RecommendationRebuildStats 离线推荐计算统计
+type RecommendationRebuildStats struct {
+	Users       int           // 有偏好信号的用户数
+	Songs       int           // 相似度图中的歌曲数
+	Recommended int           // 写入缓存的用户数
+	Failed      int           // 失败的用户数
+	Duration    time.Duration // 执行时长
+}
+
+// scoredSong 带相似度的歌曲
+type scoredSong struct {
+	songID string
+	score  float64
+}
+
+// songGraph 歌曲相似度图（共现余弦相似度），每首歌只保留最相似的若干首
+type songGraph struct {
+	neighbors map[string][]scoredSong
+	meta      map[string]*domain.SongSignal // 歌名、歌手
+}
+
+// buildSongGraph 根据用户偏好信号构建歌曲相似度图
+// 相似度 = 同时喜欢两首歌的用户数 / sqrt(喜欢A的用户数 × 喜欢B的用户数)
+func buildSongGraph(signals map[string][]*domain.SongSignal) *songGraph {
+	graph := &songGraph{
+		neighbors: make(map[string][]scoredSong),
+		meta:      make(map[string]*domain.SongSignal),
+	}
+
+	counts := make(map[string]int)
+	pairs := make(map[string]map[string]int)
+	addPair := func(a, b string) {
+		row, ok := pairs[a]
+		if !ok {
+			row = make(map[string]int)
+			pairs[a] = row
+		}
+		row[b]++
+	}
+
+	for _, userSignals := range signals {
+		for i, a := range userSignals {
+			counts[a.SongID]++
+			if _, ok := graph.meta[a.SongID]; !ok {
+				graph.meta[a.SongID] = a
+			}
+			for _, b := range userSignals[i+1:] {
+				addPair(a.SongID, b.SongID)
+				addPair(b.SongID, a.SongID)
+			}
+		}
+	}
+
+	for a, row := range pairs {
+		var neighbors []scoredSong
+		for b, together := range row {
+			if together < MinCoOccurrence {
+				continue
+			}
+			score := float64(together) / math.Sqrt(float64(counts[a])*float64(counts[b]))
+			neighbors = append(neighbors, scoredSong{songID: b, score: score})
+		}
+		if len(neighbors) == 0 {
+			continue
+		}
+		sortScored(neighbors)
+		if len(neighbors) > RecommendationNeighborsPerSong {
+			neighbors = neighbors[:RecommendationNeighborsPerSong]
+		}
+		graph.neighbors[a] = neighbors
+	}
+
+	return graph
+}
+
+func sortScored(songs []scoredSong) {
+	sort.Slice(songs, func(i, j int) bool {
+		if songs[i].score != songs[j].score {
+			return songs[i].score > songs[j].score
+		}
+		return songs[i].songID < songs[j].songID
+	})
+}
+
+// RebuildAll 离线计算所有用户的推荐结果并写入缓存
+// 单个用户失败不影响其他用户
+func (s *RecommendationService) RebuildAll(ctx context.Context) (*RecommendationRebuildStats, error) {
+	start := s.now()
+	since := start.AddDate(0, 0, -RecommendationSignalWindowDays)
+	stats := &RecommendationRebuildStats{}
+
+	signals := make(map[string][]*domain.SongSignal)
+	afterUserID := ""
+	for {
+		if err := ctx.Err(); err != nil {
+			return stats, err
+		}
+
+		userIDs, err := s.repo.ListSignalUserIDs(ctx, since, afterUserID, recommendationUserPageSize)
+		if err != nil {
+			return stats, fmt.Errorf("list signal users: %w", err)
+		}
+
+		for _, userID := range userIDs {
+			userSignals, err := s.repo.ListUserSignals(ctx, userID, since, RecommendationSignalsPerUser)
+			if err != nil {
+				log.Printf("Failed to load recommendation signals for user %s: %v", userID, err)
+				stats.Failed++
+				continue
+			}
+			if len(userSignals) > 0 {
+				signals[userID] = userSignals
+			}
+		}
+
+		if len(userIDs) < recommendationUserPageSize {
+			break
+		}
+		afterUserID = userIDs[len(userIDs)-1]
+	}
+	stats.Users = len(signals)
+
+	graph := buildSongGraph(signals)
+	stats.Songs = len(graph.meta)
+
+	for userID, userSignals := range signals {
+		if err := ctx.Err(); err != nil {
+			return stats, err
+		}
+
+		recs := s.recommend(ctx, userID, userSignals, graph)
+		if err := s.cache.SaveRecommendations(ctx, recs, RecommendationTTL); err != nil {
+			log.Printf("Failed to cache recommendations for user %s: %v", userID, err)
+			stats.Failed++
+			continue
+		}
+		stats.Recommended++
+	}
+
+	stats.Duration = time.Since(start)
+	log.Printf("Recommendations rebuilt: users=%d songs=%d recommended=%d failed=%d duration=%v",
+		stats.Users, stats.Songs, stats.Recommended, stats.Failed, stats.Duration)
+	return stats, nil
+}
+
+// GetRecommendations 获取用户的推荐结果
+// 离线结果未覆盖的用户（如新用户）按需计算，此时只能使用上游相似歌曲
+func (s *RecommendationService) GetRecommendations(ctx context.Context, userID string) (*domain.UserRecommendations, error) {
+	if userID == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	recs, err := s.cache.GetRecommendations(ctx, userID)
+	if err == nil {
+		return recs, nil
+	}
+	if !errors.Is(err, domain.ErrRecommendationsNotFound) {
+		return nil, fmt.Errorf("get cached recommendations: %w", err)
+	}
+
+	since := s.now().AddDate(0, 0, -RecommendationSignalWindowDays)
+	userSignals, err := s.repo.ListUserSignals(ctx, userID, since, RecommendationSignalsPerUser)
+	if err != nil {
+		return nil, fmt.Errorf("list recommendation signals: %w", err)
+	}
+
+	recs = s.recommend(ctx, userID, userSignals, &songGraph{})
+	// 没有任何信号时不缓存，用户产生收藏/播放后即可得到推荐
+	if len(userSignals) > 0 {
+		if err := s.cache.SaveRecommendations(ctx, recs, OnDemandRecommendationTTL); err != nil {
+			log.Printf("Failed to cache on-demand recommendations for user %s: %v", userID, err)
+		}
+	}
+	return recs, nil
+}
+
+// recommend 为单个用户生成推荐，userSignals按权重从高到低排列
+func (s *RecommendationService) recommend(ctx context.Context, userID string, userSignals []*domain.SongSignal, graph *songGraph) *domain.UserRecommendations {
+	now := s.now()
+	day := now.Format(time.DateOnly)
+
+	known := make(map[string]bool, len(userSignals))
+	for _, signal := range userSignals {
+		known[signal.SongID] = true
+	}
+
+	candidates := newCandidateSet(known)
+	for _, seed := range userSignals {
+		for _, neighbor := range graph.neighbors[seed.SongID] {
+			candidates.add(graph.songOf(neighbor.songID), seed.Weight*neighbor.score)
+		}
+	}
+	for _, seed := range userSignals[:min(UpstreamSimilarSeeds, len(userSignals))] {
+		similar := s.similarSongs(ctx, seed.SongID)
+		for rank, song := range similar {
+			candidates.add(song, seed.Weight*upstreamSimilarWeight*rankScore(rank, len(similar)))
+		}
+	}
+
+	return &domain.UserRecommendations{
+		UserID:          userID,
+		DailyMix:        pickDailyMix(candidates.list(), userID, day),
+		BecauseYouLiked: s.becauseYouLiked(ctx, userID, day, userSignals, known, graph),
+		GeneratedAt:     now,
+	}
+}
+
+// becauseYouLiked 以用户收藏的歌曲（没有收藏时用偏好最高的歌曲）为种子生成推荐行，种子每天轮换
+func (s *RecommendationService) becauseYouLiked(ctx context.Context, userID, day string, userSignals []*domain.SongSignal, known map[string]bool, graph *songGraph) []*domain.RecommendationRow {
+	var pool []*domain.SongSignal
+	for _, signal := range userSignals {
+		if signal.Favorited {
+			pool = append(pool, signal)
+		}
+	}
+	if len(pool) == 0 {
+		pool = userSignals
+	}
+	if len(pool) > becauseYouLikedSeedPool {
+		pool = pool[:becauseYouLikedSeedPool]
+	}
+	if len(pool) == 0 {
+		return []*domain.RecommendationRow{}
+	}
+
+	offset := int(dailyHash(userID, day, "") % uint64(len(pool)))
+	rows := make([]*domain.RecommendationRow, 0, BecauseYouLikedRows)
+	for i := 0; i < min(BecauseYouLikedRows, len(pool)); i++ {
+		seed := pool[(offset+i)%len(pool)]
+
+		candidates := newCandidateSet(known)
+		for _, neighbor := range graph.neighbors[seed.SongID] {
+			candidates.add(graph.songOf(neighbor.songID), neighbor.score)
+		}
+		similar := s.similarSongs(ctx, seed.SongID)
+		for rank, song := range similar {
+			candidates.add(song, upstreamSimilarWeight*rankScore(rank, len(similar)))
+		}
+
+		songs := candidates.list()
+		if len(songs) == 0 {
+			continue
+		}
+		if len(songs) > BecauseYouLikedSize {
+			songs = songs[:BecauseYouLikedSize]
+		}
+		rows = append(rows, &domain.RecommendationRow{
+			SeedSongID:     seed.SongID,
+			SeedSongName:   seed.SongName,
+			SeedSingerName: seed.SingerName,
+			Songs:          songs,
+		})
+	}
+	return rows
+}
+
+// similarSongs 获取上游相似歌曲（先查缓存），失败时返回空，不影响共现推荐
+func (s *RecommendationService) similarSongs(ctx context.Context, songID string) []*domain.RecommendedSong {
+	if s.similar == nil {
+		return nil
+	}
+
+	songs, ok, err := s.cache.GetSimilarSongs(ctx, songID)
+	if err != nil {
+		log.Printf("Failed to read cached similar songs for %s: %v", songID, err)
+	}
+	if ok {
+		return songs
+	}
+
+	songs, err = s.similar.GetSimilarSongs(ctx, songID)
+	if err != nil {
+		log.Printf("Failed to fetch similar songs for %s: %v", songID, err)
+		return nil
+	}
+	if err := s.cache.SaveSimilarSongs(ctx, songID, songs, SimilarSongsTTL); err != nil {
+		log.Printf("Failed to cache similar songs for %s: %v", songID, err)
+	}
+	return songs
+}
+
+// songOf 相似度图中歌曲的推荐信息
+func (g *songGraph) songOf(songID string) *domain.RecommendedSong {
+	song := &domain.RecommendedSong{
+		SongID: songID,
+		Source: domain.RecommendationSourceCoOccurrence,
+	}
+	if meta, ok := g.meta[songID]; ok {
+		song.SongName = meta.SongName
+		song.SingerName = meta.SingerName
+	}
+	return song
+}
+
+// candidateSet 候选歌曲集合，同一首歌的得分累加，排除用户已知的歌曲
+type candidateSet struct {
+	known map[string]bool
+	songs map[string]*domain.RecommendedSong
+}
+
+func newCandidateSet(known map[string]bool) *candidateSet {
+	return &candidateSet{
+		known: known,
+		songs: make(map[string]*domain.RecommendedSong),
+	}
+}
+
+func (c *candidateSet) add(song *domain.RecommendedSong, score float64) {
+	if song.SongID == "" || c.known[song.SongID] || score <= 0 {
+		return
+	}
+	if existing, ok := c.songs[song.SongID]; ok {
+		existing.Score += score
+		if existing.SongName == "" {
+			existing.SongName = song.SongName
+			existing.SingerName = song.SingerName
+		}
+		return
+	}
+	c.songs[song.SongID] = &domain.RecommendedSong{
+		SongID:     song.SongID,
+		SongName:   song.SongName,
+		SingerName: song.SingerName,
+		Score:      score,
+		Source:     song.Source,
+	}
+}
+
+// list 按得分从高到低返回候选歌曲
+func (c *candidateSet) list() []*domain.RecommendedSong {
+	songs := make([]*domain.RecommendedSong, 0, len(c.songs))
+	for _, song := range c.songs {
+		songs = append(songs, song)
+	}
+	sort.Slice(songs, func(i, j int) bool {
+		if songs[i].Score != songs[j].Score {
+			return songs[i].Score > songs[j].Score
+		}
+		return songs[i].SongID < songs[j].SongID
+	})
+	return songs
+}
+
+// pickDailyMix 从候选歌曲中挑选每日推荐
+// 得分按用户和日期做小幅扰动使每天的结果有变化，同一歌手最多MaxSongsPerSingerInMix首
+func pickDailyMix(candidates []*domain.RecommendedSong, userID, day string) []*domain.RecommendedSong {
+	adjusted := make(map[string]float64, len(candidates))
+	for _, song := range candidates {
+		jitter := float64(dailyHash(userID, day, song.SongID)%1000) / 1000
+		adjusted[song.SongID] = song.Score * (1 + dailyMixJitter*jitter)
+	}
+	sorted := append([]*domain.RecommendedSong(nil), candidates...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return adjusted[sorted[i].SongID] > adjusted[sorted[j].SongID]
+	})
+
+	mix := make([]*domain.RecommendedSong, 0, min(DailyMixSize, len(sorted)))
+	perSinger := make(map[string]int)
+	for _, song := range sorted {
+		if len(mix) >= DailyMixSize {
+			break
+		}
+		if song.SingerName != "" && perSinger[song.SingerName] >= MaxSongsPerSingerInMix {
+			continue
+		}
+		perSinger[song.SingerName]++
+		mix = append(mix, song)
+	}
+	return mix
+}
+
+// rankScore 上游相似歌曲按排名递减的得分（第一名为1）
+func rankScore(rank, total int) float64 {
+	return 1 - float64(rank)/float64(total)
+}
+
+// dailyHash 用户+日期(+歌曲)的稳定哈希，同一天内结果不变
+func dailyHash(userID, day, songID string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(userID))
+	h.Write([]byte{0})
+	h.Write([]byte(day))
+	h.Write([]byte{0})
+	h.Write([]byte(songID))
+	return h.Sum64()
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"user-svc/internal/domain"
+	"user-svc/internal/repository"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryRecommendationRepository 内存推荐信号仓储（用于测试）
+type memoryRecommendationRepository struct {
+	signals map[string][]*domain.SongSignal
+}
+
+func (r *memoryRecommendationRepository) ListSignalUserIDs(ctx context.Context, since time.Time, afterUserID string, limit int) ([]string, error) {
+	var userIDs []string
+	for userID := range r.signals {
+		if userID > afterUserID {
+			userIDs = append(userIDs, userID)
+		}
+	}
+	return userIDs, nil
+}
+
+func (r *memoryRecommendationRepository) ListUserSignals(ctx context.Context, userID string, since time.Time, limit int) ([]*domain.SongSignal, error) {
+	return r.signals[userID], nil
+}
+
+// fakeSimilarSongSource 上游相似歌曲（用于测试）
+type fakeSimilarSongSource struct {
+	songs map[string][]*domain.RecommendedSong
+	calls int
+	err   error
+}
+
+func (f *fakeSimilarSongSource) GetSimilarSongs(ctx context.Context, songID string) ([]*domain.RecommendedSong, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.songs[songID], nil
+}
+
+func signal(songID, singer string, weight float64, favorited bool) *domain.SongSignal {
+	return &domain.SongSignal{
+		SongID:     songID,
+		SongName:   "song " + songID,
+		SingerName: singer,
+		Weight:     weight,
+		Favorited:  favorited,
+	}
+}
+
+func newTestRecommendationCache(t *testing.T) repository.RecommendationCache {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return repository.NewRecommendationCache(client)
+}
+
+func songIDs(songs []*domain.RecommendedSong) []string {
+	ids := make([]string, 0, len(songs))
+	for _, song := range songs {
+		ids = append(ids, song.SongID)
+	}
+	return ids
+}
+
+func TestBuildSongGraph(t *testing.T) {
+	graph := buildSongGraph(map[string][]*domain.SongSignal{
+		"u1": {signal("a", "X", 3, true), signal("b", "X", 2, false), signal("c", "Y", 1, false)},
+		"u2": {signal("a", "X", 3, true), signal("b", "X", 1, false)},
+		"u3": {signal("a", "X", 1, false), signal("d", "Y", 1, false)},
+	})
+
+	// a-b 共现2次，a-c 只有1次低于MinCoOccurrence
+	require.Len(t, graph.neighbors["a"], 1)
+	assert.Equal(t, "b", graph.neighbors["a"][0].songID)
+	assert.InDelta(t, 2/math.Sqrt(3*2), graph.neighbors["a"][0].score, 1e-9)
+	assert.Empty(t, graph.neighbors["c"])
+	assert.Len(t, graph.meta, 4)
+}
+
+func TestRebuildAll(t *testing.T) {
+	repo := &memoryRecommendationRepository{signals: map[string][]*domain.SongSignal{
+		"u1": {signal("a", "X", 3, true), signal("b", "X", 2, false), signal("c", "Y", 2, false)},
+		"u2": {signal("a", "X", 3, true), signal("b", "X", 2, false), signal("c", "Y", 1, false)},
+		"u3": {signal("a", "X", 3, true)},
+	}}
+	similar := &fakeSimilarSongSource{songs: map[string][]*domain.RecommendedSong{
+		"a": {
+			{SongID: "s1", SongName: "similar 1", SingerName: "Z", Source: domain.RecommendationSourceSimilar},
+			{SongID: "b", SongName: "song b", SingerName: "X", Source: domain.RecommendationSourceSimilar},
+		},
+	}}
+	cache := newTestRecommendationCache(t)
+	svc := NewRecommendationService(repo, cache, similar)
+	ctx := context.Background()
+
+	stats, err := svc.RebuildAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats.Users)
+	assert.Equal(t, 3, stats.Recommended)
+	assert.Equal(t, 0, stats.Failed)
+
+	// u3只收藏了a：共现推荐b、c，上游推荐s1和b
+	recs, err := svc.GetRecommendations(ctx, "u3")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"b", "c", "s1"}, songIDs(recs.DailyMix))
+	require.Len(t, recs.BecauseYouLiked, 1)
+	assert.Equal(t, "a", recs.BecauseYouLiked[0].SeedSongID)
+	assert.Equal(t, "b", recs.BecauseYouLiked[0].Songs[0].SongID)
+
+	// 已知歌曲不会被推荐
+	recs, err = svc.GetRecommendations(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"s1"}, songIDs(recs.DailyMix))
+
+	// 上游相似歌曲按歌曲缓存，每首种子歌曲只请求一次
+	assert.Equal(t, 3, similar.calls)
+	_, err = svc.RebuildAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, similar.calls)
+}
+
+func TestGetRecommendations_OnDemand(t *testing.T) {
+	repo := &memoryRecommendationRepository{signals: map[string][]*domain.SongSignal{
+		"new-user": {signal("a", "X", 3, true)},
+	}}
+	similar := &fakeSimilarSongSource{songs: map[string][]*domain.RecommendedSong{
+		"a": {{SongID: "s1", SongName: "similar 1", SingerName: "Z", Source: domain.RecommendationSourceSimilar}},
+	}}
+	cache := newTestRecommendationCache(t)
+	svc := NewRecommendationService(repo, cache, similar)
+	ctx := context.Background()
+
+	recs, err := svc.GetRecommendations(ctx, "new-user")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"s1"}, songIDs(recs.DailyMix))
+
+	cached, err := cache.GetRecommendations(ctx, "new-user")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"s1"}, songIDs(cached.DailyMix))
+
+	// 没有信号的用户返回空推荐且不缓存
+	recs, err = svc.GetRecommendations(ctx, "nobody")
+	require.NoError(t, err)
+	assert.Empty(t, recs.DailyMix)
+	assert.Empty(t, recs.BecauseYouLiked)
+	_, err = cache.GetRecommendations(ctx, "nobody")
+	assert.ErrorIs(t, err, domain.ErrRecommendationsNotFound)
+
+	_, err = svc.GetRecommendations(ctx, "")
+	assert.ErrorIs(t, err, domain.ErrInvalidUserID)
+}
+
+func TestGetRecommendations_UpstreamFailure(t *testing.T) {
+	repo := &memoryRecommendationRepository{signals: map[string][]*domain.SongSignal{
+		"u1": {signal("a", "X", 3, true)},
+	}}
+	similar := &fakeSimilarSongSource{err: errors.New("upstream down")}
+	svc := NewRecommendationService(repo, newTestRecommendationCache(t), similar)
+
+	recs, err := svc.GetRecommendations(context.Background(), "u1")
+	require.NoError(t, err)
+	assert.Empty(t, recs.DailyMix)
+}
+
+func TestPickDailyMix_SingerCap(t *testing.T) {
+	var candidates []*domain.RecommendedSong
+	for i, id := range []string{"a", "b", "c", "d", "e"} {
+		candidates = append(candidates, &domain.RecommendedSong{SongID: id, SingerName: "X", Score: float64(10 - i)})
+	}
+	candidates = append(candidates, &domain.RecommendedSong{SongID: "f", SingerName: "Y", Score: 1})
+
+	mix := pickDailyMix(candidates, "u1", "2026-03-01")
+	assert.Len(t, mix, MaxSongsPerSingerInMix+1)
+	assert.Contains(t, songIDs(mix), "f")
+
+	// 同一天结果稳定
+	assert.Equal(t, songIDs(mix), songIDs(pickDailyMix(candidates, "u1", "2026-03-01")))
+}
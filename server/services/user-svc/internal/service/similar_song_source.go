@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"user-svc/internal/domain"
+)
+
+// SimilarSongSource 上游相似歌曲数据源
+type SimilarSongSource interface {
+	GetSimilarSongs(ctx context.Context, songID string) ([]*domain.RecommendedSong, error)
+}
+
+// ProxySimilarSongSource 通过proxy-svc获取上游相似歌曲
+type ProxySimilarSongSource struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewProxySimilarSongSource 创建proxy-svc相似歌曲数据源
+func NewProxySimilarSongSource(baseURL string) *ProxySimilarSongSource {
+	return &ProxySimilarSongSource{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// GetSimilarSongs 获取相似歌曲，返回顺序即上游的相似度排序
+func (s *ProxySimilarSongSource) GetSimilarSongs(ctx context.Context, songID string) ([]*domain.RecommendedSong, error) {
+	endpoint := s.baseURL + "/api/song/similar?song_mid=" + url.QueryEscape(songID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request similar songs: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request similar songs: unexpected status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    []struct {
+			SongMid    string `json:"song_mid"`
+			SongName   string `json:"song_name"`
+			SingerName string `json:"singer_name"`
+			ID         string `json:"id"`
+			Name       string `json:"name"`
+			Artist     string `json:"artist"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decode similar songs: %w", err)
+	}
+	if body.Code != 1 {
+		return nil, fmt.Errorf("similar songs error: code=%d, msg=%s", body.Code, body.Message)
+	}
+
+	songs := make([]*domain.RecommendedSong, 0, len(body.Data))
+	for _, item := range body.Data {
+		song := &domain.RecommendedSong{
+			SongID:     firstNonEmpty(item.SongMid, item.ID),
+			SongName:   firstNonEmpty(item.SongName, item.Name),
+			SingerName: firstNonEmpty(item.SingerName, item.Artist),
+			Source:     domain.RecommendationSourceSimilar,
+		}
+		if song.SongID == "" {
+			continue
+		}
+		songs = append(songs, song)
+	}
+	return songs, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
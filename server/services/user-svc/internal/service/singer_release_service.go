@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"user-svc/internal/domain"
+	"user-svc/internal/repository"
+)
+
+// SingerReleaseService 关注歌手新发行服务
+// 定时轮询被关注歌手的上游专辑列表，把新出现的专辑推送到关注者的"新发行"列表
+type SingerReleaseService struct {
+	repo   repository.SingerReleaseRepository
+	source SingerReleaseSource
+	now    func() time.Time
+}
+
+// NewSingerReleaseService 创建关注歌手新发行服务，source为nil时不轮询上游
+func NewSingerReleaseService(repo repository.SingerReleaseRepository, source SingerReleaseSource) *SingerReleaseService {
+	return &SingerReleaseService{
+		repo:   repo,
+		source: source,
+		now:    time.Now,
+	}
+}
+
+// PollAll 轮询所有被关注歌手的专辑
+// 歌手第一次被轮询时拉取到的专辑只作为基线保存，避免把旧专辑当作新发行；单个歌手失败不影响其他歌手
+func (s *SingerReleaseService) PollAll(ctx context.Context) (*domain.SingerReleasePollResult, error) {
+	result := &domain.SingerReleasePollResult{}
+	if s.source == nil {
+		return result, nil
+	}
+
+	singerIDs, err := s.repo.ListFollowedSingerIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, singerID := range singerIDs {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		inserted, err := s.pollSinger(ctx, singerID)
+		if err != nil {
+			log.Printf("Failed to poll releases for singer %s: %v", singerID, err)
+			result.SingersFailed++
+			continue
+		}
+		result.SingersPolled++
+		result.NewReleases += inserted
+	}
+	return result, nil
+}
+
+// pollSinger 拉取并保存一个歌手的专辑，返回新发行数量（基线不计入）
+func (s *SingerReleaseService) pollSinger(ctx context.Context, singerID string) (int64, error) {
+	polled, err := s.repo.HasReleases(ctx, singerID)
+	if err != nil {
+		return 0, err
+	}
+
+	releases, err := s.source.GetSingerAlbums(ctx, singerID)
+	if err != nil {
+		return 0, err
+	}
+
+	discoveredAt := s.now()
+	for _, release := range releases {
+		release.Baseline = !polled
+		release.DiscoveredAt = discoveredAt
+	}
+
+	inserted, err := s.repo.SaveReleases(ctx, releases)
+	if err != nil {
+		return 0, err
+	}
+	if !polled {
+		return 0, nil
+	}
+	return inserted, nil
+}
+
+// ListNewReleases 获取用户关注的歌手在关注之后发行的新专辑，最近发现的在前
+func (s *SingerReleaseService) ListNewReleases(ctx context.Context, userID string, page, pageSize int) ([]*domain.SingerRelease, int64, error) {
+	offset := (page - 1) * pageSize
+	releases, err := s.repo.ListForUser(ctx, userID, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.repo.CountForUser(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return releases, total, nil
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"user-svc/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memorySingerReleaseRepository 内存关注歌手新发行仓储（用于测试）
+type memorySingerReleaseRepository struct {
+	singerIDs []string
+	releases  map[string]*domain.SingerRelease
+}
+
+func (r *memorySingerReleaseRepository) ListFollowedSingerIDs(ctx context.Context) ([]string, error) {
+	return r.singerIDs, nil
+}
+
+func (r *memorySingerReleaseRepository) HasReleases(ctx context.Context, singerID string) (bool, error) {
+	for _, release := range r.releases {
+		if release.SingerID == singerID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *memorySingerReleaseRepository) SaveReleases(ctx context.Context, releases []*domain.SingerRelease) (int64, error) {
+	var inserted int64
+	for _, release := range releases {
+		key := release.SingerID + "/" + release.AlbumID
+		if _, ok := r.releases[key]; ok {
+			continue
+		}
+		r.releases[key] = release
+		inserted++
+	}
+	return inserted, nil
+}
+
+func (r *memorySingerReleaseRepository) ListForUser(ctx context.Context, userID string, limit, offset int) ([]*domain.SingerRelease, error) {
+	return nil, nil
+}
+
+func (r *memorySingerReleaseRepository) CountForUser(ctx context.Context, userID string) (int64, error) {
+	return 0, nil
+}
+
+type fakeSingerReleaseSource struct {
+	albums map[string][]string
+	failed map[string]bool
+}
+
+func (f *fakeSingerReleaseSource) GetSingerAlbums(ctx context.Context, singerID string) ([]*domain.SingerRelease, error) {
+	if f.failed[singerID] {
+		return nil, errors.New("upstream down")
+	}
+	var releases []*domain.SingerRelease
+	for _, albumID := range f.albums[singerID] {
+		releases = append(releases, &domain.SingerRelease{SingerID: singerID, AlbumID: albumID, AlbumName: "album " + albumID})
+	}
+	return releases, nil
+}
+
+func TestSingerRelease_PollAllBaseline(t *testing.T) {
+	ctx := context.Background()
+	first := time.Date(2026, 10, 1, 0, 30, 0, 0, time.UTC)
+	repo := &memorySingerReleaseRepository{singerIDs: []string{"s1", "s2"}, releases: map[string]*domain.SingerRelease{}}
+	source := &fakeSingerReleaseSource{
+		albums: map[string][]string{"s1": {"a1", "a2"}, "s2": {"b1"}},
+		failed: map[string]bool{},
+	}
+	svc := NewSingerReleaseService(repo, source)
+	svc.now = func() time.Time { return first }
+
+	// 第一次轮询只保存基线
+	result, err := svc.PollAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.SingersPolled)
+	assert.Equal(t, int64(0), result.NewReleases)
+	assert.True(t, repo.releases["s1/a1"].Baseline)
+	assert.True(t, repo.releases["s2/b1"].Baseline)
+
+	// 之后出现的专辑才是新发行，失败的歌手不影响其他歌手
+	second := first.Add(6 * time.Hour)
+	svc.now = func() time.Time { return second }
+	source.albums["s1"] = []string{"a3", "a1", "a2"}
+	source.failed["s2"] = true
+
+	result, err = svc.PollAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.SingersPolled)
+	assert.Equal(t, 1, result.SingersFailed)
+	assert.Equal(t, int64(1), result.NewReleases)
+	require.Contains(t, repo.releases, "s1/a3")
+	assert.False(t, repo.releases["s1/a3"].Baseline)
+	assert.Equal(t, second, repo.releases["s1/a3"].DiscoveredAt)
+	assert.Equal(t, first, repo.releases["s1/a1"].DiscoveredAt)
+}
+
+func TestSingerRelease_PollAllWithoutSource(t *testing.T) {
+	svc := NewSingerReleaseService(&memorySingerReleaseRepository{singerIDs: []string{"s1"}}, nil)
+
+	result, err := svc.PollAll(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.SingersPolled)
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"user-svc/internal/domain"
+)
+
+// SingerReleaseSource 上游歌手专辑数据源
+type SingerReleaseSource interface {
+	GetSingerAlbums(ctx context.Context, singerID string) ([]*domain.SingerRelease, error)
+}
+
+// singerAlbumPageSize 每次拉取的歌手专辑数量（上游按发行时间倒序，新专辑总在第一页）
+const singerAlbumPageSize = 20
+
+// ProxySingerReleaseSource 通过proxy-svc获取上游歌手专辑列表
+type ProxySingerReleaseSource struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewProxySingerReleaseSource 创建proxy-svc歌手专辑数据源
+func NewProxySingerReleaseSource(baseURL string) *ProxySingerReleaseSource {
+	return &ProxySingerReleaseSource{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// GetSingerAlbums 获取歌手最近发行的专辑
+func (s *ProxySingerReleaseSource) GetSingerAlbums(ctx context.Context, singerID string) ([]*domain.SingerRelease, error) {
+	endpoint := fmt.Sprintf("%s/api/singer/albums?singer_mid=%s&page=1&size=%d", s.baseURL, url.QueryEscape(singerID), singerAlbumPageSize)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request singer albums: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request singer albums: unexpected status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    struct {
+			Data []struct {
+				AlbumMid   string `json:"album_mid"`
+				AlbumName  string `json:"album_name"`
+				AlbumPic   string `json:"album_pic"`
+				PublicTime string `json:"public_time"`
+				SingerMid  string `json:"singer_mid"`
+				SingerName string `json:"singer_name"`
+			} `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decode singer albums: %w", err)
+	}
+	if body.Code != 1 {
+		return nil, fmt.Errorf("singer albums error: code=%d, msg=%s", body.Code, body.Message)
+	}
+
+	releases := make([]*domain.SingerRelease, 0, len(body.Data.Data))
+	for _, item := range body.Data.Data {
+		if item.AlbumMid == "" {
+			continue
+		}
+		releases = append(releases, &domain.SingerRelease{
+			SingerID:    singerID,
+			SingerName:  item.SingerName,
+			AlbumID:     item.AlbumMid,
+			AlbumName:   item.AlbumName,
+			CoverURL:    item.AlbumPic,
+			ReleaseDate: item.PublicTime,
+		})
+	}
+	return releases, nil
+}
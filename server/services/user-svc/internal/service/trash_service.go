@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"user-svc/internal/domain"
+	"user-svc/internal/repository"
+)
+
+// TrashService 回收站服务
+// 删除的歌单和收藏在保留期内可以恢复，过期后由定时任务永久删除
+type TrashService struct {
+	playlistService *PlaylistService
+	playlistRepo    repository.PlaylistRepository
+	favoriteRepo    repository.FavoriteRepository
+	retention       time.Duration
+	now             func() time.Time
+}
+
+// NewTrashService 创建回收站服务，retention不大于0时使用默认保留时长
+func NewTrashService(playlistService *PlaylistService, playlistRepo repository.PlaylistRepository, favoriteRepo repository.FavoriteRepository, retention time.Duration) *TrashService {
+	if retention <= 0 {
+		retention = domain.DefaultTrashRetention
+	}
+	return &TrashService{
+		playlistService: playlistService,
+		playlistRepo:    playlistRepo,
+		favoriteRepo:    favoriteRepo,
+		retention:       retention,
+		now:             time.Now,
+	}
+}
+
+// Retention 回收站保留时长
+func (s *TrashService) Retention() time.Duration {
+	return s.retention
+}
+
+// ListDeletedPlaylists 获取保留期内删除的歌单，最近删除的在前
+func (s *TrashService) ListDeletedPlaylists(ctx context.Context, userID string, page, pageSize int) ([]*domain.TrashedPlaylist, int64, error) {
+	since := s.cutoff()
+	offset := (page - 1) * pageSize
+	playlists, err := s.playlistRepo.ListDeletedByUser(ctx, userID, since, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.playlistRepo.CountDeletedByUser(ctx, userID, since)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	trashed := make([]*domain.TrashedPlaylist, 0, len(playlists))
+	for _, playlist := range playlists {
+		trashed = append(trashed, &domain.TrashedPlaylist{
+			UserPlaylist: playlist,
+			ExpiresAt:    s.expiresAt(playlist.DeletedAt),
+		})
+	}
+	return trashed, total, nil
+}
+
+// ListDeletedFavorites 获取保留期内删除的收藏，最近删除的在前
+func (s *TrashService) ListDeletedFavorites(ctx context.Context, userID string, page, pageSize int) ([]*domain.TrashedFavorite, int64, error) {
+	since := s.cutoff()
+	offset := (page - 1) * pageSize
+	favorites, err := s.favoriteRepo.ListDeletedByUser(ctx, userID, since, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.favoriteRepo.CountDeletedByUser(ctx, userID, since)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	trashed := make([]*domain.TrashedFavorite, 0, len(favorites))
+	for _, favorite := range favorites {
+		trashed = append(trashed, &domain.TrashedFavorite{
+			Favorite:  favorite,
+			ExpiresAt: s.expiresAt(favorite.DeletedAt),
+		})
+	}
+	return trashed, total, nil
+}
+
+// RestorePlaylist 恢复已删除的歌单（仅所有者）
+// 软删除保留了歌曲、协作成员和关注，恢复后歌单和删除前完全一致
+func (s *TrashService) RestorePlaylist(ctx context.Context, playlistID, userID string) (*domain.UserPlaylist, error) {
+	playlist, err := s.playlistRepo.GetDeleted(ctx, playlistID)
+	if err != nil {
+		return nil, err
+	}
+	if playlist.UserID != userID {
+		return nil, domain.ErrUnauthorized
+	}
+	if s.expired(playlist.DeletedAt) {
+		return nil, domain.ErrPlaylistNotFound
+	}
+
+	if err := s.playlistRepo.Restore(ctx, playlistID); err != nil {
+		return nil, err
+	}
+
+	restored, err := s.playlistRepo.GetByID(ctx, playlistID)
+	if err != nil {
+		return nil, err
+	}
+	s.playlistService.notifyCollaborators(ctx, restored, userID, "restored", nil)
+	return restored, nil
+}
+
+// RestoreFavorite 恢复已删除的收藏
+// 删除后又重新收藏了同一目标时返回ErrFavoriteAlreadyExists，避免出现重复收藏
+func (s *TrashService) RestoreFavorite(ctx context.Context, favoriteID, userID string) (*domain.Favorite, error) {
+	favorite, err := s.favoriteRepo.GetDeleted(ctx, favoriteID)
+	if err != nil {
+		return nil, err
+	}
+	if favorite.UserID != userID {
+		return nil, domain.ErrUnauthorized
+	}
+	if s.expired(favorite.DeletedAt) {
+		return nil, domain.ErrFavoriteNotFound
+	}
+
+	exists, err := s.favoriteRepo.Exists(ctx, userID, favorite.Type, favorite.SongID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, domain.ErrFavoriteAlreadyExists
+	}
+
+	if err := s.favoriteRepo.Restore(ctx, favoriteID); err != nil {
+		return nil, err
+	}
+	if s.playlistService != nil {
+		s.playlistService.InvalidateSmartPlaylists(ctx, userID)
+	}
+	favorite.DeletedAt = nil
+	return favorite, nil
+}
+
+// PurgeExpired 永久删除超过保留期的歌单和收藏
+// 歌单清理失败时仍继续清理收藏，返回遇到的第一个错误
+func (s *TrashService) PurgeExpired(ctx context.Context) (*domain.TrashPurgeResult, error) {
+	before := s.cutoff()
+	result := &domain.TrashPurgeResult{}
+
+	var firstErr error
+	purged, err := s.playlistRepo.PurgeDeleted(ctx, before)
+	if err != nil {
+		log.Printf("Failed to purge deleted playlists: %v", err)
+		firstErr = err
+	}
+	result.PlaylistsPurged = purged
+
+	purged, err = s.favoriteRepo.PurgeDeleted(ctx, before)
+	if err != nil {
+		log.Printf("Failed to purge deleted favorites: %v", err)
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	result.FavoritesPurged = purged
+
+	return result, firstErr
+}
+
+// cutoff 保留期的起点，早于该时间删除的记录视为过期
+func (s *TrashService) cutoff() time.Time {
+	return s.now().Add(-s.retention)
+}
+
+// expired 判断删除时间是否已超过保留期（定时清理之前也不允许恢复）
+func (s *TrashService) expired(deletedAt *time.Time) bool {
+	return deletedAt == nil || !deletedAt.After(s.cutoff())
+}
+
+// expiresAt 计算回收站记录的过期时间
+func (s *TrashService) expiresAt(deletedAt *time.Time) time.Time {
+	if deletedAt == nil {
+		return s.now()
+	}
+	return deletedAt.Add(s.retention)
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"user-svc/internal/domain"
+	"user-svc/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (r *memoryPlaylistRepository) SoftDelete(ctx context.Context, id string) error {
+	now := time.Now()
+	r.playlists[id].DeletedAt = &now
+	return nil
+}
+
+func (r *memoryPlaylistRepository) GetDeleted(ctx context.Context, id string) (*domain.UserPlaylist, error) {
+	playlist, ok := r.playlists[id]
+	if !ok || playlist.DeletedAt == nil {
+		return nil, domain.ErrPlaylistNotFound
+	}
+	copied := *playlist
+	return &copied, nil
+}
+
+func (r *memoryPlaylistRepository) Restore(ctx context.Context, id string) error {
+	playlist, ok := r.playlists[id]
+	if !ok || playlist.DeletedAt == nil {
+		return domain.ErrPlaylistNotFound
+	}
+	playlist.DeletedAt = nil
+	return nil
+}
+
+func (r *memoryPlaylistRepository) ListDeletedByUser(ctx context.Context, userID string, deletedSince time.Time, limit, offset int) ([]*domain.UserPlaylist, error) {
+	var playlists []*domain.UserPlaylist
+	for _, playlist := range r.playlists {
+		if playlist.UserID == userID && playlist.DeletedAt != nil && playlist.DeletedAt.After(deletedSince) {
+			playlists = append(playlists, playlist)
+		}
+	}
+	sort.Slice(playlists, func(i, j int) bool { return playlists[i].DeletedAt.After(*playlists[j].DeletedAt) })
+	return playlists, nil
+}
+
+func (r *memoryPlaylistRepository) CountDeletedByUser(ctx context.Context, userID string, deletedSince time.Time) (int64, error) {
+	playlists, _ := r.ListDeletedByUser(ctx, userID, deletedSince, 0, 0)
+	return int64(len(playlists)), nil
+}
+
+func (r *memoryPlaylistRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	var purged int64
+	for id, playlist := range r.playlists {
+		if playlist.DeletedAt != nil && !playlist.DeletedAt.After(deletedBefore) {
+			delete(r.playlists, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// memoryFavoriteRepository 内存收藏仓储（用于测试，只实现回收站用到的方法）
+type memoryFavoriteRepository struct {
+	repository.FavoriteRepository
+	favorites map[string]*domain.Favorite
+}
+
+func (r *memoryFavoriteRepository) Exists(ctx context.Context, userID, favoriteType, targetID string) (bool, error) {
+	for _, favorite := range r.favorites {
+		if favorite.UserID == userID && favorite.Type == favoriteType && favorite.SongID == targetID && favorite.DeletedAt == nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *memoryFavoriteRepository) GetDeleted(ctx context.Context, id string) (*domain.Favorite, error) {
+	favorite, ok := r.favorites[id]
+	if !ok || favorite.DeletedAt == nil {
+		return nil, domain.ErrFavoriteNotFound
+	}
+	copied := *favorite
+	return &copied, nil
+}
+
+func (r *memoryFavoriteRepository) Restore(ctx context.Context, id string) error {
+	r.favorites[id].DeletedAt = nil
+	return nil
+}
+
+func (r *memoryFavoriteRepository) ListDeletedByUser(ctx context.Context, userID string, deletedSince time.Time, limit, offset int) ([]*domain.Favorite, error) {
+	var favorites []*domain.Favorite
+	for _, favorite := range r.favorites {
+		if favorite.UserID == userID && favorite.DeletedAt != nil && favorite.DeletedAt.After(deletedSince) {
+			favorites = append(favorites, favorite)
+		}
+	}
+	return favorites, nil
+}
+
+func (r *memoryFavoriteRepository) CountDeletedByUser(ctx context.Context, userID string, deletedSince time.Time) (int64, error) {
+	favorites, _ := r.ListDeletedByUser(ctx, userID, deletedSince, 0, 0)
+	return int64(len(favorites)), nil
+}
+
+func (r *memoryFavoriteRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	var purged int64
+	for id, favorite := range r.favorites {
+		if favorite.DeletedAt != nil && !favorite.DeletedAt.After(deletedBefore) {
+			delete(r.favorites, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+func TestTrash_RestorePlaylist(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	playlistRepo := &memoryPlaylistRepository{playlists: map[string]*domain.UserPlaylist{
+		"p1":  {ID: "p1", UserID: "u1", Name: "Road Trip", SongCount: 2},
+		"old": {ID: "old", UserID: "u1", Name: "Old", DeletedAt: daysAgo(now, 31)},
+	}}
+	songRepo := &memoryPlaylistSongRepository{songs: map[string][]*domain.PlaylistSong{
+		"p1": {{PlaylistID: "p1", SongID: "a", Position: 1}, {PlaylistID: "p1", SongID: "b", Position: 2}},
+	}}
+	notifier := &recordingPublisher{}
+	playlists := NewPlaylistService(playlistRepo, songRepo, nil, nil, nil, notifier, nil)
+	svc := NewTrashService(playlists, playlistRepo, &memoryFavoriteRepository{}, 0)
+
+	require.NoError(t, playlists.DeletePlaylist(ctx, "p1", "u1"))
+	_, err := playlists.GetPlaylistForUser(ctx, "p1", "u1")
+	assert.ErrorIs(t, err, domain.ErrPlaylistNotFound)
+
+	// 超过保留期的歌单不在回收站中显示，也不能恢复
+	trashed, total, err := svc.ListDeletedPlaylists(ctx, "u1", 1, 20)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, trashed, 1)
+	assert.Equal(t, "p1", trashed[0].ID)
+	assert.Equal(t, trashed[0].DeletedAt.Add(domain.DefaultTrashRetention), trashed[0].ExpiresAt)
+
+	_, err = svc.RestorePlaylist(ctx, "old", "u1")
+	assert.ErrorIs(t, err, domain.ErrPlaylistNotFound)
+	_, err = svc.RestorePlaylist(ctx, "p1", "u2")
+	assert.ErrorIs(t, err, domain.ErrUnauthorized)
+
+	// 恢复后歌曲原样保留
+	restored, err := svc.RestorePlaylist(ctx, "p1", "u1")
+	require.NoError(t, err)
+	assert.Nil(t, restored.DeletedAt)
+	assert.Equal(t, 2, restored.SongCount)
+	songs, err := playlists.GetPlaylistSongs(ctx, "p1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, playlistSongIDs(songs))
+	assert.Equal(t, []string{"u1"}, notifier.recipients("restored"))
+
+	_, err = svc.RestorePlaylist(ctx, "p1", "u1")
+	assert.ErrorIs(t, err, domain.ErrPlaylistNotFound)
+}
+
+func TestTrash_RestoreFavorite(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	favoriteRepo := &memoryFavoriteRepository{favorites: map[string]*domain.Favorite{
+		"f1": {ID: "f1", UserID: "u1", Type: domain.FavoriteTypeSong, SongID: "a", DeletedAt: daysAgo(now, 1)},
+		"f2": {ID: "f2", UserID: "u1", Type: domain.FavoriteTypeSong, SongID: "b", DeletedAt: daysAgo(now, 2)},
+		"f3": {ID: "f3", UserID: "u1", Type: domain.FavoriteTypeSong, SongID: "b"},
+	}}
+	svc := NewTrashService(nil, &memoryPlaylistRepository{}, favoriteRepo, 0)
+
+	favorite, err := svc.RestoreFavorite(ctx, "f1", "u1")
+	require.NoError(t, err)
+	assert.Nil(t, favorite.DeletedAt)
+	assert.Nil(t, favoriteRepo.favorites["f1"].DeletedAt)
+
+	// 删除后又重新收藏了同一首歌
+	_, err = svc.RestoreFavorite(ctx, "f2", "u1")
+	assert.ErrorIs(t, err, domain.ErrFavoriteAlreadyExists)
+	_, err = svc.RestoreFavorite(ctx, "f3", "u1")
+	assert.ErrorIs(t, err, domain.ErrFavoriteNotFound)
+	_, err = svc.RestoreFavorite(ctx, "f2", "u2")
+	assert.ErrorIs(t, err, domain.ErrUnauthorized)
+}
+
+func TestTrash_PurgeExpired(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 3, 0, 0, 0, time.UTC)
+	playlistRepo := &memoryPlaylistRepository{playlists: map[string]*domain.UserPlaylist{
+		"kept":    {ID: "kept", UserID: "u1", DeletedAt: daysAgo(now, 6)},
+		"expired": {ID: "expired", UserID: "u1", DeletedAt: daysAgo(now, 8)},
+		"active":  {ID: "active", UserID: "u1"},
+	}}
+	favoriteRepo := &memoryFavoriteRepository{favorites: map[string]*domain.Favorite{
+		"f1": {ID: "f1", UserID: "u1", DeletedAt: daysAgo(now, 7)},
+		"f2": {ID: "f2", UserID: "u1"},
+	}}
+	svc := NewTrashService(nil, playlistRepo, favoriteRepo, 7*24*time.Hour)
+	svc.now = func() time.Time { return now }
+
+	result, err := svc.PurgeExpired(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.PlaylistsPurged)
+	assert.Equal(t, int64(1), result.FavoritesPurged)
+	assert.Contains(t, playlistRepo.playlists, "kept")
+	assert.Contains(t, playlistRepo.playlists, "active")
+	assert.NotContains(t, playlistRepo.playlists, "expired")
+	assert.Contains(t, favoriteRepo.favorites, "f2")
+}
@@ -0,0 +1,14 @@
+-- 删除函数
+DROP FUNCTION IF EXISTS listening_array_add(BIGINT[], BIGINT[]);
+
+-- 删除表
+DROP TABLE IF EXISTS listening_monthly_singers;
+DROP TABLE IF EXISTS listening_monthly_songs;
+DROP TABLE IF EXISTS listening_monthly_stats;
+DROP TABLE IF EXISTS listening_daily_singers;
+DROP TABLE IF EXISTS listening_daily_songs;
+DROP TABLE IF EXISTS listening_daily_stats;
+
+-- 删除播放历史汇总标记
+DROP INDEX IF EXISTS idx_play_histories_pending_rollup;
+ALTER TABLE play_histories DROP COLUMN IF EXISTS rolled_up_at;
@@ -0,0 +1,83 @@
+-- 听歌统计汇总表
+-- play_histories每用户只保留最新500条，汇总任务在清理前把播放记录累加到日/月汇总表，
+-- 已汇总的记录通过rolled_up_at标记，清理任务只删除已汇总的记录
+
+ALTER TABLE play_histories ADD COLUMN IF NOT EXISTS rolled_up_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_play_histories_pending_rollup ON play_histories(played_at) WHERE rolled_up_at IS NULL;
+
+-- 每日汇总（长期保留，用于日历/趋势和年度听歌天数）
+CREATE TABLE IF NOT EXISTS listening_daily_stats (
+    user_id VARCHAR(255) NOT NULL,
+    day DATE NOT NULL,
+    play_count BIGINT NOT NULL DEFAULT 0,
+    seconds_listened BIGINT NOT NULL DEFAULT 0,
+    hour_plays BIGINT[] NOT NULL, -- 每小时播放次数（24个元素）
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, day)
+);
+
+-- 每日歌曲/歌手明细（保留有限天数，用于任意时间范围的排行）
+CREATE TABLE IF NOT EXISTS listening_daily_songs (
+    user_id VARCHAR(255) NOT NULL,
+    day DATE NOT NULL,
+    song_id VARCHAR(255) NOT NULL,
+    song_name VARCHAR(500) NOT NULL,
+    singer_name VARCHAR(500) NOT NULL,
+    album_cover VARCHAR(1000),
+    play_count BIGINT NOT NULL DEFAULT 0,
+    seconds_listened BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, day, song_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_listening_daily_songs_day ON listening_daily_songs(day);
+
+CREATE TABLE IF NOT EXISTS listening_daily_singers (
+    user_id VARCHAR(255) NOT NULL,
+    day DATE NOT NULL,
+    singer_name VARCHAR(500) NOT NULL,
+    play_count BIGINT NOT NULL DEFAULT 0,
+    seconds_listened BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, day, singer_name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_listening_daily_singers_day ON listening_daily_singers(day);
+
+-- 每月汇总（长期保留，用于年度报告）
+CREATE TABLE IF NOT EXISTS listening_monthly_stats (
+    user_id VARCHAR(255) NOT NULL,
+    month DATE NOT NULL, -- 当月1日
+    play_count BIGINT NOT NULL DEFAULT 0,
+    seconds_listened BIGINT NOT NULL DEFAULT 0,
+    heatmap BIGINT[] NOT NULL, -- 星期×小时播放次数（168个元素，下标 weekday*24+hour）
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, month)
+);
+
+CREATE TABLE IF NOT EXISTS listening_monthly_songs (
+    user_id VARCHAR(255) NOT NULL,
+    month DATE NOT NULL,
+    song_id VARCHAR(255) NOT NULL,
+    song_name VARCHAR(500) NOT NULL,
+    singer_name VARCHAR(500) NOT NULL,
+    album_cover VARCHAR(1000),
+    play_count BIGINT NOT NULL DEFAULT 0,
+    seconds_listened BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, month, song_id)
+);
+
+CREATE TABLE IF NOT EXISTS listening_monthly_singers (
+    user_id VARCHAR(255) NOT NULL,
+    month DATE NOT NULL,
+    singer_name VARCHAR(500) NOT NULL,
+    play_count BIGINT NOT NULL DEFAULT 0,
+    seconds_listened BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, month, singer_name)
+);
+
+-- 数组按元素相加（用于累加每小时/热力图计数）
+CREATE OR REPLACE FUNCTION listening_array_add(a BIGINT[], b BIGINT[])
+RETURNS BIGINT[] AS $$
+    SELECT array_agg(COALESCE(x, 0) + COALESCE(y, 0) ORDER BY i)
+    FROM unnest(a, b) WITH ORDINALITY AS t(x, y, i);
+$$ LANGUAGE sql IMMUTABLE;
@@ -0,0 +1,6 @@
+-- 删除索引
+DROP INDEX IF EXISTS idx_listening_daily_songs_user_song;
+DROP INDEX IF EXISTS idx_user_playlists_smart;
+
+-- 删除智能歌单规则
+ALTER TABLE user_playlists DROP COLUMN IF EXISTS smart_rules;
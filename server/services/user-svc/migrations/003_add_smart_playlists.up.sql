@@ -0,0 +1,10 @@
+-- 智能歌单
+-- smart_rules为规则表达式（JSON），NULL表示普通歌单；
+-- 智能歌单不在playlist_songs中存储歌曲，读取时根据收藏和播放数据实时计算
+
+ALTER TABLE user_playlists ADD COLUMN IF NOT EXISTS smart_rules JSONB;
+
+CREATE INDEX IF NOT EXISTS idx_user_playlists_smart ON user_playlists(user_id) WHERE smart_rules IS NOT NULL AND deleted_at IS NULL;
+
+-- 计算"最后播放时间"时按歌曲查询每日明细
+CREATE INDEX IF NOT EXISTS idx_listening_daily_songs_user_song ON listening_daily_songs(user_id, song_id, day DESC);
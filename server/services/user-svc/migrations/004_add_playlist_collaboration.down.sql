@@ -0,0 +1,6 @@
+-- 删除歌曲添加者
+ALTER TABLE playlist_songs DROP COLUMN IF EXISTS added_by;
+
+-- 删除协作表
+DROP TABLE IF EXISTS playlist_invites;
+DROP TABLE IF EXISTS playlist_members;
@@ -0,0 +1,29 @@
+-- 协作歌单
+-- 所有者仍由user_playlists.user_id决定，playlist_members只存储被邀请的编辑者和查看者
+
+CREATE TABLE IF NOT EXISTS playlist_members (
+    playlist_id UUID NOT NULL REFERENCES user_playlists(id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL,
+    invited_by VARCHAR(255) NOT NULL,
+    joined_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (playlist_id, user_id),
+    CONSTRAINT chk_playlist_members_role CHECK (role IN ('editor', 'viewer'))
+);
+CREATE INDEX IF NOT EXISTS idx_playlist_members_user_id ON playlist_members(user_id);
+
+-- 分享链接邀请
+CREATE TABLE IF NOT EXISTS playlist_invites (
+    token VARCHAR(64) PRIMARY KEY,
+    playlist_id UUID NOT NULL REFERENCES user_playlists(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL,
+    created_by VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_playlist_invites_role CHECK (role IN ('editor', 'viewer'))
+);
+CREATE INDEX IF NOT EXISTS idx_playlist_invites_playlist_id ON playlist_invites(playlist_id);
+
+-- 记录每首歌由谁添加，历史数据视为所有者添加
+ALTER TABLE playlist_songs ADD COLUMN IF NOT EXISTS added_by VARCHAR(255);
+UPDATE playlist_songs ps SET added_by = p.user_id FROM user_playlists p WHERE p.id = ps.playlist_id AND ps.added_by IS NULL;
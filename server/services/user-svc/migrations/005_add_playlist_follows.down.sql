@@ -0,0 +1,10 @@
+-- 删除索引
+DROP INDEX IF EXISTS idx_user_playlists_public_recent;
+DROP INDEX IF EXISTS idx_user_playlists_public_popular;
+
+-- 删除关注表
+DROP TABLE IF EXISTS playlist_follows;
+
+-- 删除冗余字段
+ALTER TABLE user_playlists DROP COLUMN IF EXISTS forked_from;
+ALTER TABLE user_playlists DROP COLUMN IF EXISTS follower_count;
@@ -0,0 +1,18 @@
+-- 公开歌单发现：关注和复制
+-- follower_count冗余存储关注数，关注/取消关注时在同一事务中更新；
+-- forked_from记录复制来源，来源歌单被删除后置为NULL
+
+ALTER TABLE user_playlists ADD COLUMN IF NOT EXISTS follower_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE user_playlists ADD COLUMN IF NOT EXISTS forked_from UUID REFERENCES user_playlists(id) ON DELETE SET NULL;
+
+CREATE TABLE IF NOT EXISTS playlist_follows (
+    playlist_id UUID NOT NULL REFERENCES user_playlists(id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (playlist_id, user_id)
+);
+CREATE INDEX IF NOT EXISTS idx_playlist_follows_user_id ON playlist_follows(user_id, created_at DESC);
+
+-- 公开歌单按关注数/更新时间浏览
+CREATE INDEX IF NOT EXISTS idx_user_playlists_public_popular ON user_playlists(follower_count DESC, updated_at DESC) WHERE is_public = TRUE AND deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_user_playlists_public_recent ON user_playlists(updated_at DESC) WHERE is_public = TRUE AND deleted_at IS NULL;
@@ -0,0 +1,3 @@
+-- 删除回收站索引
+DROP INDEX IF EXISTS idx_favorites_trash;
+DROP INDEX IF EXISTS idx_user_playlists_trash;
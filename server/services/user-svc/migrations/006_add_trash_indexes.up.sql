@@ -0,0 +1,3 @@
+-- 回收站：按用户列出最近删除的歌单和收藏，定时任务按删除时间清理过期记录
+CREATE INDEX IF NOT EXISTS idx_user_playlists_trash ON user_playlists(user_id, deleted_at DESC) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_favorites_trash ON favorites(user_id, deleted_at DESC) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,13 @@
+-- 删除歌手新专辑表
+DROP TABLE IF EXISTS singer_releases;
+
+-- 删除索引
+DROP INDEX IF EXISTS idx_favorites_singer;
+DROP INDEX IF EXISTS idx_favorites_user_type;
+
+-- 删除收藏类型字段（非歌曲收藏一并删除）
+DELETE FROM favorites WHERE target_type <> 'song';
+ALTER TABLE favorites DROP CONSTRAINT IF EXISTS chk_favorites_target_type;
+ALTER TABLE favorites DROP COLUMN IF EXISTS metadata;
+ALTER TABLE favorites DROP COLUMN IF EXISTS cover_url;
+ALTER TABLE favorites DROP COLUMN IF EXISTS target_type;
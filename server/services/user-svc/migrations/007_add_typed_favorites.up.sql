@@ -0,0 +1,32 @@
+-- 收藏支持专辑、歌手、MV和上游歌单
+-- song_id/song_name/singer_name沿用为目标ID、名称和歌手，历史数据都是歌曲收藏
+ALTER TABLE favorites ADD COLUMN IF NOT EXISTS target_type VARCHAR(20) NOT NULL DEFAULT 'song';
+ALTER TABLE favorites ADD COLUMN IF NOT EXISTS cover_url VARCHAR(1000) NOT NULL DEFAULT '';
+ALTER TABLE favorites ADD COLUMN IF NOT EXISTS metadata JSONB;
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'chk_favorites_target_type' AND conrelid = 'favorites'::regclass) THEN
+        ALTER TABLE favorites ADD CONSTRAINT chk_favorites_target_type CHECK (target_type IN ('song', 'album', 'singer', 'mv', 'playlist'));
+    END IF;
+END
+$$;
+
+-- 按类型筛选和统计
+CREATE INDEX IF NOT EXISTS idx_favorites_user_type ON favorites(user_id, target_type, created_at DESC) WHERE deleted_at IS NULL;
+-- 定时任务按歌手汇总关注
+CREATE INDEX IF NOT EXISTS idx_favorites_singer ON favorites(song_id) WHERE target_type = 'singer' AND deleted_at IS NULL;
+
+-- 关注歌手的新专辑
+-- 每个歌手第一次拉取到的专辑作为基线（baseline），不会作为新发行推送给用户
+CREATE TABLE IF NOT EXISTS singer_releases (
+    singer_id VARCHAR(255) NOT NULL,
+    album_id VARCHAR(255) NOT NULL,
+    album_name VARCHAR(500) NOT NULL,
+    singer_name VARCHAR(500) NOT NULL DEFAULT '',
+    cover_url VARCHAR(1000) NOT NULL DEFAULT '',
+    release_date VARCHAR(20) NOT NULL DEFAULT '',
+    baseline BOOLEAN NOT NULL DEFAULT FALSE,
+    discovered_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (singer_id, album_id)
+);
+CREATE INDEX IF NOT EXISTS idx_singer_releases_discovered ON singer_releases(singer_id, discovered_at DESC) WHERE baseline = FALSE;
@@ -0,0 +1,6 @@
+-- 删除索引
+DROP INDEX IF EXISTS idx_playback_positions_user_updated;
+
+-- 删除播放进度和播放队列表
+DROP TABLE IF EXISTS playback_positions;
+DROP TABLE IF EXISTS play_queues;
@@ -0,0 +1,25 @@
+-- 播放队列和播放进度（跨设备续播）
+-- 客户端高频上报先写入Redis，由定时任务批量回写到这两张表；
+-- 回写时只接受更新的数据（updated_at更大），避免延迟的批次覆盖新数据
+
+CREATE TABLE IF NOT EXISTS play_queues (
+    user_id VARCHAR(255) PRIMARY KEY,
+    tracks JSONB NOT NULL DEFAULT '[]',
+    current_index INTEGER NOT NULL DEFAULT 0,
+    shuffle BOOLEAN NOT NULL DEFAULT FALSE,
+    repeat_mode VARCHAR(10) NOT NULL DEFAULT 'off',
+    device_id VARCHAR(255) NOT NULL DEFAULT '',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_play_queues_repeat_mode CHECK (repeat_mode IN ('off', 'all', 'one'))
+);
+
+CREATE TABLE IF NOT EXISTS playback_positions (
+    user_id VARCHAR(255) NOT NULL,
+    song_id VARCHAR(255) NOT NULL,
+    position_ms BIGINT NOT NULL DEFAULT 0,
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    device_id VARCHAR(255) NOT NULL DEFAULT '',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, song_id)
+);
+CREATE INDEX IF NOT EXISTS idx_playback_positions_user_updated ON playback_positions(user_id, updated_at DESC);
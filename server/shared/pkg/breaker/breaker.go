@@ -118,6 +118,17 @@ func (cb *CircuitBreaker) Allow() bool {
 	}
 }
 
+// Release returns a slot taken by Allow without recording an outcome,
+// e.g. when the caller gave up before executing the request.
+func (cb *CircuitBreaker) Release() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	
+	if cb.state == StateHalfOpen && cb.halfOpenRequests > 0 {
+		cb.halfOpenRequests--
+	}
+}
+
 // RecordSuccess records a successful execution.
 func (cb *CircuitBreaker) RecordSuccess() {
 	cb.mu.Lock()
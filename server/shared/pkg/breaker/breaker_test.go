@@ -216,3 +216,34 @@ func TestCircuitBreaker_DefaultConfig(t *testing.T) {
 		t.Errorf("Default state = %v, want %v", stats.State, StateClosed)
 	}
 }
+
+func TestCircuitBreaker_ReleaseHalfOpenSlot(t *testing.T) {
+	cb := New(&Config{
+		Name:            "test",
+		MaxFailures:     1,
+		Timeout:         10 * time.Millisecond,
+		HalfOpenMaxReqs: 1,
+	})
+	
+	cb.RecordFailure()
+	time.Sleep(20 * time.Millisecond)
+	
+	// First Allow moves the breaker to half-open
+	if !cb.Allow() {
+		t.Fatal("Allow() after timeout = false, want true")
+	}
+	if !cb.Allow() {
+		t.Fatal("Allow() in half-open = false, want true")
+	}
+	if cb.Allow() {
+		t.Fatal("Allow() beyond half-open limit = true, want false")
+	}
+	
+	cb.Release()
+	if !cb.Allow() {
+		t.Error("Allow() after Release = false, want true")
+	}
+	if cb.GetState() != StateHalfOpen {
+		t.Errorf("State = %v, want %v", cb.GetState(), StateHalfOpen)
+	}
+}
@@ -0,0 +1,239 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ValueKind describes how a business config value is encoded in Consul KV.
+type ValueKind int
+
+const (
+	// KindString is a free-form string.
+	KindString ValueKind = iota
+	// KindInt is a decimal integer.
+	KindInt
+	// KindBool is "true" or "false".
+	KindBool
+	// KindURL is an absolute http(s) URL.
+	KindURL
+)
+
+// KeySpec describes a business config key.
+type KeySpec struct {
+	Kind   ValueKind
+	Secret bool // credentials that must not be displayed in full
+}
+
+// businessNamespaces are the top-level KV directories that make up BusinessConfig.
+// Keys under these directories must be listed in businessKeys.
+var businessNamespaces = []string{"common/", "api/", "sms/", "features/"}
+
+// businessKeys lists every key read into BusinessConfig, relative to the KV prefix.
+var businessKeys = map[string]KeySpec{
+	"common/jwt_secret":     {Kind: KindString, Secret: true},
+	"common/jwt_version":    {Kind: KindInt},
+	"common/aes_key":        {Kind: KindString, Secret: true},
+	"common/jwt_expiry":     {Kind: KindInt},
+	"common/refresh_expiry": {Kind: KindInt},
+
+	"sms/aliyun/access_key_id":     {Kind: KindString, Secret: true},
+	"sms/aliyun/access_key_secret": {Kind: KindString, Secret: true},
+	"sms/aliyun/sign_name":         {Kind: KindString},
+	"sms/aliyun/template_code":     {Kind: KindString},
+	"sms/aliyun/enabled":           {Kind: KindBool},
+
+	"sms/tencent/secret_id":   {Kind: KindString, Secret: true},
+	"sms/tencent/secret_key":  {Kind: KindString, Secret: true},
+	"sms/tencent/app_id":      {Kind: KindString},
+	"sms/tencent/sign_name":   {Kind: KindString},
+	"sms/tencent/template_id": {Kind: KindString},
+	"sms/tencent/enabled":     {Kind: KindBool},
+
+	"sms/twilio/account_sid": {Kind: KindString, Secret: true},
+	"sms/twilio/auth_token":  {Kind: KindString, Secret: true},
+	"sms/twilio/from_number": {Kind: KindString},
+	"sms/twilio/enabled":     {Kind: KindBool},
+
+	"features/token_ip_binding":   {Kind: KindBool},
+	"features/device_fingerprint": {Kind: KindBool},
+	"features/two_factor_auth":    {Kind: KindBool},
+	"features/rate_limit_enabled": {Kind: KindBool},
+	"features/cache_warmup":       {Kind: KindBool},
+	"features/open_telemetry":     {Kind: KindBool},
+}
+
+// apiProviders are the third-party music APIs under "api/".
+var apiProviders = []string{"qq_music", "joox", "netease", "kugou"}
+
+func init() {
+	for _, provider := range apiProviders {
+		prefix := "api/" + provider + "/"
+		businessKeys[prefix+"base_url"] = KeySpec{Kind: KindURL}
+		businessKeys[prefix+"api_key"] = KeySpec{Kind: KindString, Secret: true}
+		businessKeys[prefix+"rate_limit"] = KeySpec{Kind: KindInt}
+		businessKeys[prefix+"timeout"] = KeySpec{Kind: KindInt}
+		businessKeys[prefix+"enabled"] = KeySpec{Kind: KindBool}
+	}
+}
+
+// IsBusinessKey reports whether key (relative to the KV prefix) lies in one of
+// the directories read into BusinessConfig, whether or not the key is known.
+func IsBusinessKey(key string) bool {
+	for _, ns := range businessNamespaces {
+		if strings.HasPrefix(key, ns) {
+			return true
+		}
+	}
+	return false
+}
+
+// LookupBusinessKey returns the spec of a known business config key.
+func LookupBusinessKey(key string) (KeySpec, bool) {
+	spec, ok := businessKeys[key]
+	return spec, ok
+}
+
+// ValidateBusinessKey checks that a single value is well-formed for its key.
+// Keys outside the business directories are not checked; unknown keys inside
+// them are rejected so that a misspelled key is not silently ignored.
+func ValidateBusinessKey(key, value string) error {
+	if !IsBusinessKey(key) {
+		return nil
+	}
+	spec, ok := businessKeys[key]
+	if !ok {
+		return fmt.Errorf("unknown business config key %s", key)
+	}
+
+	switch spec.Kind {
+	case KindInt:
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("%s must be an integer", key)
+		}
+	case KindBool:
+		if value != "true" && value != "false" {
+			return fmt.Errorf("%s must be true or false", key)
+		}
+	case KindURL:
+		if err := validateBaseURL(value); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// ParseBusinessConfig builds a BusinessConfig from KV values keyed by their
+// path relative to the KV prefix (e.g. "api/qq_music/base_url").
+// Missing keys keep their zero value, except jwt_expiry and refresh_expiry
+// which default to 1 hour and 7 days. The result is not validated.
+func ParseBusinessConfig(values map[string]string) (*BusinessConfig, error) {
+	p := &kvParser{values: values}
+	cfg := &BusinessConfig{}
+
+	cfg.Common = CommonConfig{
+		JWTSecret:     values["common/jwt_secret"],
+		JWTVersion:    p.int("common/jwt_version", 0),
+		AESKey:        values["common/aes_key"],
+		JWTExpiry:     p.int("common/jwt_expiry", 3600),
+		RefreshExpiry: p.int("common/refresh_expiry", 604800),
+	}
+
+	cfg.API.QQMusic = QQMusicConfig(p.endpoint("api/qq_music/"))
+	cfg.API.Joox = JooxConfig(p.endpoint("api/joox/"))
+	cfg.API.NetEase = NetEaseConfig(p.endpoint("api/netease/"))
+	cfg.API.Kugou = KugouConfig(p.endpoint("api/kugou/"))
+
+	cfg.SMS.Aliyun = AliyunSMSConfig{
+		AccessKeyID:     values["sms/aliyun/access_key_id"],
+		AccessKeySecret: values["sms/aliyun/access_key_secret"],
+		SignName:        values["sms/aliyun/sign_name"],
+		TemplateCode:    values["sms/aliyun/template_code"],
+		Enabled:         values["sms/aliyun/enabled"] == "true",
+	}
+	cfg.SMS.Tencent = TencentSMSConfig{
+		SecretID:   values["sms/tencent/secret_id"],
+		SecretKey:  values["sms/tencent/secret_key"],
+		AppID:      values["sms/tencent/app_id"],
+		SignName:   values["sms/tencent/sign_name"],
+		TemplateID: values["sms/tencent/template_id"],
+		Enabled:    values["sms/tencent/enabled"] == "true",
+	}
+	cfg.SMS.Twilio = TwilioSMSConfig{
+		AccountSID: values["sms/twilio/account_sid"],
+		AuthToken:  values["sms/twilio/auth_token"],
+		FromNumber: values["sms/twilio/from_number"],
+		Enabled:    values["sms/twilio/enabled"] == "true",
+	}
+
+	cfg.Features = FeatureFlags{
+		TokenIPBinding:    values["features/token_ip_binding"] == "true",
+		DeviceFingerprint: values["features/device_fingerprint"] == "true",
+		TwoFactorAuth:     values["features/two_factor_auth"] == "true",
+		RateLimitEnabled:  values["features/rate_limit_enabled"] == "true",
+		CacheWarmup:       values["features/cache_warmup"] == "true",
+		OpenTelemetry:     values["features/open_telemetry"] == "true",
+	}
+
+	if p.err != nil {
+		return nil, p.err
+	}
+	return cfg, nil
+}
+
+// endpointConfig has the same layout as every third-party API config.
+type endpointConfig struct {
+	BaseURL   string `json:"base_url"`
+	APIKey    string `json:"api_key"`
+	RateLimit int    `json:"rate_limit"`
+	Timeout   int    `json:"timeout"`
+	Enabled   bool   `json:"enabled"`
+}
+
+// kvParser converts KV values and keeps the first conversion error.
+type kvParser struct {
+	values map[string]string
+	err    error
+}
+
+func (p *kvParser) int(key string, def int) int {
+	raw, ok := p.values[key]
+	if !ok || raw == "" {
+		return def
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil {
+		if p.err == nil {
+			p.err = fmt.Errorf("invalid %s: %w", key, err)
+		}
+		return def
+	}
+	return n
+}
+
+func (p *kvParser) endpoint(prefix string) endpointConfig {
+	return endpointConfig{
+		BaseURL:   p.values[prefix+"base_url"],
+		APIKey:    p.values[prefix+"api_key"],
+		RateLimit: p.int(prefix+"rate_limit", 0),
+		Timeout:   p.int(prefix+"timeout", 0),
+		Enabled:   p.values[prefix+"enabled"] == "true",
+	}
+}
+
+// validateBaseURL requires an absolute http or https URL with a host.
+func validateBaseURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid base_url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("base_url must use http or https")
+	}
+	if u.Host == "" {
+		return fmt.Errorf("base_url must include a host")
+	}
+	return nil
+}
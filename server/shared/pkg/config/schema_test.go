@@ -0,0 +1,96 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBusinessConfig(t *testing.T) {
+	values := map[string]string{
+		"common/jwt_secret":          "0123456789abcdef0123456789abcdef",
+		"common/jwt_version":         "2",
+		"api/qq_music/base_url":      "https://api.qqmusic.com",
+		"api/qq_music/rate_limit":    "100",
+		"api/qq_music/enabled":       "true",
+		"api/kugou/timeout":          "5",
+		"sms/aliyun/access_key_id":   "id",
+		"sms/aliyun/enabled":         "true",
+		"features/two_factor_auth":   "true",
+		"features/cache_warmup":      "false",
+		"unrelated/service/settings": "ignored",
+	}
+
+	cfg, err := ParseBusinessConfig(values)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, cfg.Common.JWTVersion)
+	assert.Equal(t, 3600, cfg.Common.JWTExpiry)
+	assert.Equal(t, 604800, cfg.Common.RefreshExpiry)
+	assert.Equal(t, "https://api.qqmusic.com", cfg.API.QQMusic.BaseURL)
+	assert.Equal(t, 100, cfg.API.QQMusic.RateLimit)
+	assert.True(t, cfg.API.QQMusic.Enabled)
+	assert.Equal(t, 5, cfg.API.Kugou.Timeout)
+	assert.False(t, cfg.API.Joox.Enabled)
+	assert.Equal(t, "id", cfg.SMS.Aliyun.AccessKeyID)
+	assert.True(t, cfg.SMS.Aliyun.Enabled)
+	assert.True(t, cfg.Features.TwoFactorAuth)
+	assert.False(t, cfg.Features.CacheWarmup)
+}
+
+func TestParseBusinessConfig_InvalidInt(t *testing.T) {
+	_, err := ParseBusinessConfig(map[string]string{"common/jwt_version": "two"})
+	assert.ErrorContains(t, err, "common/jwt_version")
+}
+
+func TestValidateBusinessKey(t *testing.T) {
+	tests := []struct {
+		key     string
+		value   string
+		wantErr bool
+	}{
+		{"api/qq_music/base_url", "https://api.qqmusic.com", false},
+		{"api/qq_music/base_url", "htps://api.qqmusic.com", true},
+		{"api/qq_music/base_url", "api.qqmusic.com", true},
+		{"api/qq_music/base_url", "https://", true},
+		{"api/qq_music/baseurl", "https://api.qqmusic.com", true},
+		{"api/joox/rate_limit", "10", false},
+		{"api/joox/rate_limit", "10/s", true},
+		{"sms/twilio/enabled", "true", false},
+		{"sms/twilio/enabled", "yes", true},
+		{"common/jwt_secret", "anything", false},
+		{"other/free_form", "anything", false},
+	}
+
+	for _, tt := range tests {
+		err := ValidateBusinessKey(tt.key, tt.value)
+		if tt.wantErr {
+			assert.Error(t, err, "%s=%s", tt.key, tt.value)
+		} else {
+			assert.NoError(t, err, "%s=%s", tt.key, tt.value)
+		}
+	}
+}
+
+func TestLookupBusinessKey(t *testing.T) {
+	spec, ok := LookupBusinessKey("api/netease/api_key")
+	require.True(t, ok)
+	assert.True(t, spec.Secret)
+
+	spec, ok = LookupBusinessKey("api/netease/base_url")
+	require.True(t, ok)
+	assert.Equal(t, KindURL, spec.Kind)
+	assert.False(t, spec.Secret)
+
+	_, ok = LookupBusinessKey("features/unknown")
+	assert.False(t, ok)
+	assert.True(t, IsBusinessKey("features/unknown"))
+}
+
+func TestValidateAPIEndpoint_RequiresAbsoluteURL(t *testing.T) {
+	v := NewValidator()
+	assert.NoError(t, v.ValidateAPIEndpoint("qq_music", "", false))
+	assert.NoError(t, v.ValidateAPIEndpoint("qq_music", "http://10.0.0.1:8080/v1", true))
+	assert.Error(t, v.ValidateAPIEndpoint("qq_music", "https//api.qqmusic.com", true))
+}
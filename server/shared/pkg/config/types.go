@@ -192,7 +192,9 @@ type TwilioSMSConfig struct {
 	Enabled    bool   `json:"enabled"`
 }
 
-// FeatureFlags holds feature toggle settings.
+// FeatureFlags holds the global on/off switches under "features/".
+// New flags, and anything that needs a gradual rollout or user targeting,
+// belong in pkg/featureflag instead.
 type FeatureFlags struct {
 	TokenIPBinding     bool `json:"token_ip_binding"`
 	DeviceFingerprint  bool `json:"device_fingerprint"`
@@ -2,7 +2,6 @@ package config
 
 import (
 	"fmt"
-	"net/url"
 	"time"
 )
 
@@ -232,8 +231,8 @@ func (v *Validator) ValidateAPIEndpoint(name, baseURL string, enabled bool) erro
 		return fmt.Errorf("%s base_url is required when enabled", name)
 	}
 	
-	if _, err := url.Parse(baseURL); err != nil {
-		return fmt.Errorf("%s invalid base_url: %w", name, err)
+	if err := validateBaseURL(baseURL); err != nil {
+		return fmt.Errorf("%s %w", name, err)
 	}
 	
 	return nil
@@ -0,0 +1,308 @@
+package featureflag
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/consul/api"
+)
+
+// Source loads all flags.
+type Source interface {
+	// Load returns all flags and an index that changes whenever any flag
+	// changes. When waitIndex is non-zero, Load may block until the index
+	// differs from waitIndex or its wait time elapses.
+	Load(ctx context.Context, waitIndex uint64) (map[string]*Flag, uint64, error)
+}
+
+// ConsulSource loads flags from Consul KV using blocking queries.
+type ConsulSource struct {
+	client   *api.Client
+	prefix   string
+	waitTime time.Duration
+}
+
+// NewConsulSource creates a source reading "<kvPrefix>flags/". kvPrefix is the
+// configuration prefix shared by all services, e.g. "listen-stream/".
+func NewConsulSource(client *api.Client, kvPrefix string) *ConsulSource {
+	return &ConsulSource{
+		client:   client,
+		prefix:   strings.TrimSuffix(kvPrefix, "/") + "/" + KeyPrefix,
+		waitTime: 5 * time.Minute,
+	}
+}
+
+// Load implements Source. Malformed flags are skipped and reported in the
+// returned error together with the flags that could be parsed.
+func (s *ConsulSource) Load(ctx context.Context, waitIndex uint64) (map[string]*Flag, uint64, error) {
+	opts := (&api.QueryOptions{WaitIndex: waitIndex, WaitTime: s.waitTime}).WithContext(ctx)
+	pairs, meta, err := s.client.KV().List(s.prefix, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("featureflag: failed to list %s: %w", s.prefix, err)
+	}
+
+	flags := make(map[string]*Flag, len(pairs))
+	var bad []string
+	for _, pair := range pairs {
+		key := strings.TrimPrefix(pair.Key, s.prefix)
+		if key == "" || strings.HasSuffix(key, "/") {
+			continue
+		}
+		flag, err := DecodeFlag(key, pair.Value, pair.ModifyIndex)
+		if err != nil {
+			bad = append(bad, key)
+			continue
+		}
+		flags[key] = flag
+	}
+	if len(bad) > 0 {
+		return flags, meta.LastIndex, fmt.Errorf("featureflag: skipped malformed flags: %s", strings.Join(bad, ", "))
+	}
+	return flags, meta.LastIndex, nil
+}
+
+// DecodeFlag parses a flag stored under key. The stored key and version are
+// replaced by the KV key and its ModifyIndex.
+func DecodeFlag(key string, data []byte, modifyIndex uint64) (*Flag, error) {
+	var flag Flag
+	if err := json.Unmarshal(data, &flag); err != nil {
+		return nil, err
+	}
+	flag.Key = key
+	flag.Version = modifyIndex
+	flag.Normalize()
+	if err := flag.Validate(); err != nil {
+		return nil, err
+	}
+	return &flag, nil
+}
+
+// Config configures a Client.
+type Config struct {
+	// InitTimeout bounds the initial load in NewClient
+	InitTimeout time.Duration
+
+	// RetryInterval is the delay before reloading after a failed load
+	RetryInterval time.Duration
+
+	// OnError is called when flags cannot be loaded (optional)
+	OnError func(err error)
+}
+
+// DefaultConfig returns the default client configuration.
+func DefaultConfig() *Config {
+	return &Config{
+		InitTimeout:   5 * time.Second,
+		RetryInterval: 5 * time.Second,
+	}
+}
+
+// Client evaluates flags against an in-memory snapshot that is refreshed in
+// the background. Evaluation never performs I/O; until the first successful
+// load, or when a flag does not exist, the typed getters return the fallback.
+// A nil Client evaluates every flag as not found.
+type Client struct {
+	source Source
+	config *Config
+	flags  atomic.Pointer[map[string]*Flag]
+
+	cancel    context.CancelFunc
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// NewClient loads the flags once (waiting at most config.InitTimeout) and
+// starts watching the source for changes. A failed initial load is reported
+// through config.OnError and retried in the background, so a service can
+// start while Consul is unavailable. Call Close on shutdown.
+func NewClient(source Source, config *Config) *Client {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &Client{source: source, config: config, cancel: cancel}
+
+	initCtx, initCancel := context.WithTimeout(ctx, config.InitTimeout)
+	index, err := c.load(initCtx, 0)
+	initCancel()
+	if err != nil {
+		c.reportError(err)
+	}
+
+	c.wg.Add(1)
+	go c.watch(ctx, index)
+	return c
+}
+
+// NewStaticClient returns a client serving a fixed set of flags, for tests
+// and local development.
+func NewStaticClient(flags ...*Flag) *Client {
+	c := &Client{}
+	c.Replace(flags...)
+	return c
+}
+
+// Replace swaps the snapshot for the given flags.
+func (c *Client) Replace(flags ...*Flag) {
+	snapshot := make(map[string]*Flag, len(flags))
+	for _, f := range flags {
+		snapshot[f.Key] = f
+	}
+	c.flags.Store(&snapshot)
+}
+
+// Close stops watching the source.
+func (c *Client) Close() {
+	if c == nil || c.cancel == nil {
+		return
+	}
+	c.closeOnce.Do(func() {
+		c.cancel()
+		c.wg.Wait()
+	})
+}
+
+// Flag returns a flag definition, or nil if it does not exist.
+func (c *Client) Flag(key string) *Flag {
+	if c == nil {
+		return nil
+	}
+	snapshot := c.flags.Load()
+	if snapshot == nil {
+		return nil
+	}
+	return (*snapshot)[key]
+}
+
+// Flags returns all flags sorted by key.
+func (c *Client) Flags() []*Flag {
+	if c == nil {
+		return nil
+	}
+	snapshot := c.flags.Load()
+	if snapshot == nil {
+		return nil
+	}
+	flags := make([]*Flag, 0, len(*snapshot))
+	for _, f := range *snapshot {
+		flags = append(flags, f)
+	}
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Key < flags[j].Key })
+	return flags
+}
+
+// Evaluate evaluates a flag for a caller.
+func (c *Client) Evaluate(key string, ec Context) Evaluation {
+	result := Evaluate(c.Flag(key), ec)
+	result.Key = key
+	return result
+}
+
+// EvaluateClient evaluates all flags marked Client for an app, keyed by flag key.
+func (c *Client) EvaluateClient(ec Context) map[string]Evaluation {
+	result := make(map[string]Evaluation)
+	for _, f := range c.Flags() {
+		if f.Client {
+			result[f.Key] = Evaluate(f, ec)
+		}
+	}
+	return result
+}
+
+// Bool returns the value of a bool flag, or fallback if the flag does not
+// exist or has another type.
+func (c *Client) Bool(key string, ec Context, fallback bool) bool {
+	var v bool
+	if !c.decode(key, TypeBool, ec, &v) {
+		return fallback
+	}
+	return v
+}
+
+// String returns the value of a string flag, or fallback.
+func (c *Client) String(key string, ec Context, fallback string) string {
+	var v string
+	if !c.decode(key, TypeString, ec, &v) {
+		return fallback
+	}
+	return v
+}
+
+// Number returns the value of a number flag, or fallback.
+func (c *Client) Number(key string, ec Context, fallback float64) float64 {
+	var v float64
+	if !c.decode(key, TypeNumber, ec, &v) {
+		return fallback
+	}
+	return v
+}
+
+// JSON decodes the value of a json flag into out and reports whether it did.
+// It returns false if the flag does not exist, has another type or does not
+// decode into out.
+func (c *Client) JSON(key string, ec Context, out interface{}) bool {
+	return c.decode(key, TypeJSON, ec, out)
+}
+
+func (c *Client) decode(key string, typ Type, ec Context, out interface{}) bool {
+	f := c.Flag(key)
+	if f == nil || f.Type != typ {
+		return false
+	}
+	return json.Unmarshal(Evaluate(f, ec).Value, out) == nil
+}
+
+// watch reloads the flags whenever the source reports a change.
+func (c *Client) watch(ctx context.Context, index uint64) {
+	defer c.wg.Done()
+
+	for {
+		next, err := c.load(ctx, index)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			c.reportError(err)
+		}
+
+		switch {
+		case next == 0:
+			// Nothing loaded; wait so a failing source is not polled in a tight loop
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(c.config.RetryInterval):
+			}
+		case next < index:
+			// The index went backwards (e.g. Consul snapshot restore); start over
+			index = 0
+		default:
+			index = next
+		}
+	}
+}
+
+// load replaces the snapshot when the source returns flags and returns the
+// new index (0 when nothing could be loaded).
+func (c *Client) load(ctx context.Context, waitIndex uint64) (uint64, error) {
+	flags, index, err := c.source.Load(ctx, waitIndex)
+	if flags == nil {
+		return 0, err
+	}
+	if index != waitIndex || c.flags.Load() == nil {
+		c.flags.Store(&flags)
+	}
+	return index, err
+}
+
+func (c *Client) reportError(err error) {
+	if c.config.OnError != nil {
+		c.config.OnError(err)
+	}
+}
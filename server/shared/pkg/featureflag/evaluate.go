@@ -0,0 +1,155 @@
+package featureflag
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Context describes the caller a flag is evaluated for.
+type Context struct {
+	UserID     string // empty for anonymous callers, who only get Value at 100% rollout
+	Platform   string // ios, android, web, ...
+	AppVersion string // e.g. "2.3.1"
+}
+
+// Reasons explain an evaluation result.
+const (
+	ReasonNotFound        = "not_found" // the flag does not exist
+	ReasonDisabled        = "disabled"  // kill switch is off
+	ReasonDenied          = "denied"    // user is in DenyUsers
+	ReasonAllowed         = "allowed"   // user is in AllowUsers
+	ReasonPlatformMissed  = "platform_mismatch"
+	ReasonVersionMissed   = "version_mismatch" // app version is outside the range or unknown
+	ReasonRollout         = "rollout"          // user is inside the rollout percentage
+	ReasonRolloutExcluded = "rollout_excluded" // user is outside the rollout percentage
+)
+
+// Evaluation is the result of evaluating a flag.
+type Evaluation struct {
+	Key    string          `json:"key"`
+	Type   Type            `json:"type,omitempty"`
+	Value  json.RawMessage `json:"value"` // nil when the flag does not exist
+	On     bool            `json:"on"`    // whether Value (rather than OffValue) was served
+	Reason string          `json:"reason"`
+}
+
+// Evaluate evaluates a flag for a caller. A nil flag evaluates to ReasonNotFound.
+func Evaluate(f *Flag, ec Context) Evaluation {
+	if f == nil {
+		return Evaluation{Reason: ReasonNotFound}
+	}
+
+	on, reason := f.match(ec)
+	result := Evaluation{Key: f.Key, Type: f.Type, On: on, Reason: reason}
+	switch {
+	case on:
+		result.Value = f.Value
+	case len(f.OffValue) > 0:
+		result.Value = f.OffValue
+	default:
+		result.Value = f.Type.zero()
+	}
+	return result
+}
+
+func (f *Flag) match(ec Context) (bool, string) {
+	if !f.Enabled {
+		return false, ReasonDisabled
+	}
+	if ec.UserID != "" {
+		if contains(f.DenyUsers, ec.UserID) {
+			return false, ReasonDenied
+		}
+		if contains(f.AllowUsers, ec.UserID) {
+			return true, ReasonAllowed
+		}
+	}
+	if len(f.Platforms) > 0 && !contains(f.Platforms, strings.ToLower(ec.Platform)) {
+		return false, ReasonPlatformMissed
+	}
+	if !f.versionMatches(ec.AppVersion) {
+		return false, ReasonVersionMissed
+	}
+	if f.Rollout >= 100 || (ec.UserID != "" && Bucket(f.Key, ec.UserID) < int(math.Round(f.Rollout*100))) {
+		return true, ReasonRollout
+	}
+	return false, ReasonRolloutExcluded
+}
+
+// versionMatches checks the app version range. Callers with an unknown or
+// unparseable version never match a bounded range.
+func (f *Flag) versionMatches(appVersion string) bool {
+	if f.MinAppVersion == "" && f.MaxAppVersion == "" {
+		return true
+	}
+	v, err := parseVersion(appVersion)
+	if err != nil {
+		return false
+	}
+	if f.MinAppVersion != "" {
+		if min, err := parseVersion(f.MinAppVersion); err != nil || v.compare(min) < 0 {
+			return false
+		}
+	}
+	if f.MaxAppVersion != "" {
+		if max, err := parseVersion(f.MaxAppVersion); err != nil || v.compare(max) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Bucket returns the rollout bucket (0-9999) of a user for a flag. A user is
+// inside a rollout of p percent when Bucket < p*100.
+func Bucket(key, userID string) int {
+	sum := sha256.Sum256([]byte(key + ":" + userID))
+	return int(binary.BigEndian.Uint32(sum[:4]) % 10000)
+}
+
+// version is a dotted numeric version; pre-release and build suffixes are ignored.
+type version []int
+
+func parseVersion(s string) (version, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		s = s[:i]
+	}
+	if s == "" {
+		return nil, fmt.Errorf("empty version")
+	}
+	parts := strings.Split(s, ".")
+	v := make(version, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid version %q", s)
+		}
+		v[i] = n
+	}
+	return v, nil
+}
+
+// compare returns -1, 0 or 1; missing components count as 0 ("2.1" == "2.1.0").
+func (v version) compare(other version) int {
+	for i := 0; i < len(v) || i < len(other); i++ {
+		var a, b int
+		if i < len(v) {
+			a = v[i]
+		}
+		if i < len(other) {
+			b = other[i]
+		}
+		if a != b {
+			if a < b {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,299 @@
+package featureflag
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func boolFlag(key string) *Flag {
+	return &Flag{Key: key, Type: TypeBool, Enabled: true, Value: json.RawMessage("true"), Rollout: 100}
+}
+
+func TestEvaluate_TargetingOrder(t *testing.T) {
+	f := boolFlag("player.lossless")
+	f.AllowUsers = []string{"vip"}
+	f.DenyUsers = []string{"blocked"}
+	f.Platforms = []string{"ios"}
+	f.MinAppVersion = "2.0"
+	f.Rollout = 0
+
+	tests := []struct {
+		name   string
+		mutate func(*Flag)
+		ec     Context
+		on     bool
+		reason string
+	}{
+		{"disabled", func(f *Flag) { f.Enabled = false }, Context{UserID: "vip"}, false, ReasonDisabled},
+		{"denied", nil, Context{UserID: "blocked", Platform: "ios", AppVersion: "3.0"}, false, ReasonDenied},
+		{"allowed bypasses targeting", nil, Context{UserID: "vip", Platform: "android"}, true, ReasonAllowed},
+		{"platform", nil, Context{UserID: "u1", Platform: "android", AppVersion: "3.0"}, false, ReasonPlatformMissed},
+		{"platform is case-insensitive", func(f *Flag) { f.Rollout = 100 }, Context{UserID: "u1", Platform: "iOS", AppVersion: "3.0"}, true, ReasonRollout},
+		{"old version", nil, Context{UserID: "u1", Platform: "ios", AppVersion: "1.9.9"}, false, ReasonVersionMissed},
+		{"unknown version", nil, Context{UserID: "u1", Platform: "ios"}, false, ReasonVersionMissed},
+		{"outside rollout", nil, Context{UserID: "u1", Platform: "ios", AppVersion: "2.0.0"}, false, ReasonRolloutExcluded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flag := *f
+			if tt.mutate != nil {
+				tt.mutate(&flag)
+			}
+			result := Evaluate(&flag, tt.ec)
+			assert.Equal(t, tt.on, result.On)
+			assert.Equal(t, tt.reason, result.Reason)
+			if tt.on {
+				assert.JSONEq(t, "true", string(result.Value))
+			} else {
+				assert.JSONEq(t, "false", string(result.Value))
+			}
+		})
+	}
+}
+
+func TestEvaluate_OffValue(t *testing.T) {
+	f := &Flag{
+		Key: "search.engine", Type: TypeString, Enabled: false,
+		Value: json.RawMessage(`"v2"`), OffValue: json.RawMessage(`"v1"`),
+	}
+	assert.JSONEq(t, `"v1"`, string(Evaluate(f, Context{}).Value))
+
+	assert.Equal(t, ReasonNotFound, Evaluate(nil, Context{}).Reason)
+	assert.Nil(t, Evaluate(nil, Context{}).Value)
+}
+
+func TestEvaluate_AnonymousOnlyAtFullRollout(t *testing.T) {
+	f := boolFlag("home.new_layout")
+	f.Rollout = 99.99
+	assert.False(t, Evaluate(f, Context{}).On)
+
+	f.Rollout = 100
+	assert.True(t, Evaluate(f, Context{}).On)
+}
+
+func TestRollout_PercentageAndStability(t *testing.T) {
+	f := boolFlag("player.lossless")
+	f.Rollout = 5
+
+	const users = 20000
+	var inFive []string
+	for i := 0; i < users; i++ {
+		userID := fmt.Sprintf("user-%d", i)
+		if Evaluate(f, Context{UserID: userID}).On {
+			inFive = append(inFive, userID)
+		}
+	}
+	assert.InDelta(t, 0.05, float64(len(inFive))/users, 0.01)
+
+	// Growing the rollout keeps everyone who already had the feature
+	f.Rollout = 20
+	for _, userID := range inFive {
+		assert.True(t, Evaluate(f, Context{UserID: userID}).On, userID)
+	}
+
+	// Different flags sample different users
+	other := boolFlag("home.new_layout")
+	other.Rollout = 5
+	overlap := 0
+	for _, userID := range inFive {
+		if Evaluate(other, Context{UserID: userID}).On {
+			overlap++
+		}
+	}
+	assert.Less(t, overlap, len(inFive)/4)
+}
+
+func TestVersionCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"2.1", "2.1.0", 0},
+		{"v2.10.0", "2.9.9", 1},
+		{"2.3.1-beta.1", "2.3.1", 0},
+		{"1.0.0", "1.0.1", -1},
+	}
+	for _, tt := range tests {
+		a, err := parseVersion(tt.a)
+		require.NoError(t, err)
+		b, err := parseVersion(tt.b)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, a.compare(b), "%s vs %s", tt.a, tt.b)
+	}
+
+	_, err := parseVersion("latest")
+	assert.Error(t, err)
+}
+
+func TestFlag_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*Flag)
+		wantErr bool
+	}{
+		{"valid", func(*Flag) {}, false},
+		{"bad key", func(f *Flag) { f.Key = "Player/Lossless" }, true},
+		{"bad type", func(f *Flag) { f.Type = "list" }, true},
+		{"missing value", func(f *Flag) { f.Value = nil }, true},
+		{"value type mismatch", func(f *Flag) { f.Value = json.RawMessage(`"yes"`) }, true},
+		{"off value type mismatch", func(f *Flag) { f.OffValue = json.RawMessage("0") }, true},
+		{"rollout out of range", func(f *Flag) { f.Rollout = 101 }, true},
+		{"allow and deny", func(f *Flag) { f.AllowUsers = []string{"u1"}; f.DenyUsers = []string{"u1"} }, true},
+		{"bad version", func(f *Flag) { f.MinAppVersion = "two" }, true},
+		{"inverted versions", func(f *Flag) { f.MinAppVersion = "3.0"; f.MaxAppVersion = "2.9" }, true},
+		{"json value", func(f *Flag) { f.Type = TypeJSON; f.Value = json.RawMessage(`{"bitrate":320}`) }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := boolFlag("player.lossless")
+			tt.mutate(f)
+			if tt.wantErr {
+				assert.Error(t, f.Validate())
+			} else {
+				assert.NoError(t, f.Validate())
+			}
+		})
+	}
+}
+
+func TestFlag_Normalize(t *testing.T) {
+	f := &Flag{Key: " a.b ", Type: " Bool ", Platforms: []string{"iOS", "ios", " "}, AllowUsers: []string{"u1", "u1"}}
+	f.Normalize()
+	assert.Equal(t, "a.b", f.Key)
+	assert.Equal(t, TypeBool, f.Type)
+	assert.Equal(t, []string{"ios"}, f.Platforms)
+	assert.Equal(t, []string{"u1"}, f.AllowUsers)
+}
+
+func TestClient_TypedGetters(t *testing.T) {
+	limit := &Flag{Key: "queue.limit", Type: TypeNumber, Enabled: true, Value: json.RawMessage("500"), Rollout: 100}
+	quality := &Flag{Key: "player.quality", Type: TypeJSON, Enabled: true, Value: json.RawMessage(`{"bitrate":320}`), Rollout: 100}
+	c := NewStaticClient(boolFlag("player.lossless"), limit, quality)
+	ec := Context{UserID: "u1"}
+
+	assert.True(t, c.Bool("player.lossless", ec, false))
+	assert.True(t, c.Bool("missing", ec, true))
+	assert.Equal(t, "fallback", c.String("player.lossless", ec, "fallback"), "type mismatch returns fallback")
+	assert.Equal(t, 500.0, c.Number("queue.limit", ec, 100))
+
+	var q struct{ Bitrate int }
+	assert.True(t, c.JSON("player.quality", ec, &q))
+	assert.Equal(t, 320, q.Bitrate)
+
+	var nilClient *Client
+	assert.False(t, nilClient.Bool("player.lossless", ec, false))
+	assert.Equal(t, ReasonNotFound, nilClient.Evaluate("player.lossless", ec).Reason)
+}
+
+func TestClient_EvaluateClientOnlyExposesClientFlags(t *testing.T) {
+	clientFlag := boolFlag("home.new_layout")
+	clientFlag.Client = true
+	c := NewStaticClient(clientFlag, boolFlag("server.secret_rollout"))
+
+	result := c.EvaluateClient(Context{UserID: "u1"})
+	assert.Len(t, result, 1)
+	assert.True(t, result["home.new_layout"].On)
+}
+
+type fakeSource struct {
+	mu      sync.Mutex
+	flags   map[string]*Flag
+	index   uint64
+	err     error
+	changed chan struct{}
+}
+
+func newFakeSource() *fakeSource {
+	return &fakeSource{flags: map[string]*Flag{}, index: 1, changed: make(chan struct{}, 1)}
+}
+
+func (s *fakeSource) set(flags ...*Flag) {
+	s.mu.Lock()
+	s.flags = map[string]*Flag{}
+	for _, f := range flags {
+		s.flags[f.Key] = f
+	}
+	s.index++
+	s.err = nil
+	s.mu.Unlock()
+	select {
+	case s.changed <- struct{}{}:
+	default:
+	}
+}
+
+func (s *fakeSource) Load(ctx context.Context, waitIndex uint64) (map[string]*Flag, uint64, error) {
+	s.mu.Lock()
+	current := s.index
+	s.mu.Unlock()
+	if waitIndex == current {
+		select {
+		case <-ctx.Done():
+			return nil, 0, ctx.Err()
+		case <-s.changed:
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return nil, 0, s.err
+	}
+	flags := make(map[string]*Flag, len(s.flags))
+	for k, v := range s.flags {
+		flags[k] = v
+	}
+	return flags, s.index, nil
+}
+
+func TestClient_WatchesSource(t *testing.T) {
+	source := newFakeSource()
+	source.err = fmt.Errorf("consul unavailable")
+
+	var errs []error
+	var mu sync.Mutex
+	c := NewClient(source, &Config{
+		InitTimeout:   time.Second,
+		RetryInterval: 10 * time.Millisecond,
+		OnError: func(err error) {
+			mu.Lock()
+			errs = append(errs, err)
+			mu.Unlock()
+		},
+	})
+	defer c.Close()
+
+	mu.Lock()
+	assert.NotEmpty(t, errs, "initial load error is reported")
+	mu.Unlock()
+	assert.False(t, c.Bool("player.lossless", Context{UserID: "u1"}, false))
+
+	source.set(boolFlag("player.lossless"))
+	assert.Eventually(t, func() bool {
+		return c.Bool("player.lossless", Context{UserID: "u1"}, false)
+	}, time.Second, 5*time.Millisecond)
+
+	disabled := boolFlag("player.lossless")
+	disabled.Enabled = false
+	source.set(disabled)
+	assert.Eventually(t, func() bool {
+		return !c.Bool("player.lossless", Context{UserID: "u1"}, true)
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestDecodeFlag(t *testing.T) {
+	f, err := DecodeFlag("player.lossless", []byte(`{"key":"other","type":"bool","enabled":true,"value":true,"rollout":5,"version":1}`), 42)
+	require.NoError(t, err)
+	assert.Equal(t, "player.lossless", f.Key)
+	assert.Equal(t, uint64(42), f.Version)
+
+	_, err = DecodeFlag("player.lossless", []byte(`{"type":"bool","value":"yes"}`), 1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,206 @@
+// Package featureflag evaluates feature flags stored in Consul KV.
+//
+// Each flag is a JSON document under "<kv prefix>flags/<key>" (written by
+// admin-svc). A flag has a type and two values: Value is served to users the
+// flag targets, OffValue to everyone else. Targeting is evaluated in order:
+//
+//  1. a disabled flag serves OffValue to everyone (kill switch)
+//  2. users in DenyUsers get OffValue, users in AllowUsers get Value
+//  3. Platforms and the app version range must match the caller
+//  4. Rollout percent of users get Value, bucketed by a hash of the flag key
+//     and user ID, so a user keeps the same result while the percentage
+//     only grows, and different flags sample different users
+//
+// Services embed a Client, which keeps an in-memory snapshot of all flags
+// refreshed through Consul blocking queries, and evaluate flags locally.
+package featureflag
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// KeyPrefix is the directory of flags relative to the Consul KV prefix.
+const KeyPrefix = "flags/"
+
+// Type is the type of a flag's values.
+type Type string
+
+// Flag types.
+const (
+	TypeBool   Type = "bool"
+	TypeString Type = "string"
+	TypeNumber Type = "number"
+	TypeJSON   Type = "json"
+)
+
+// Flag is a feature flag definition.
+type Flag struct {
+	Key         string `json:"key"`
+	Description string `json:"description,omitempty"`
+	Type        Type   `json:"type"`
+
+	// Enabled is the kill switch; a disabled flag serves OffValue to everyone
+	Enabled bool `json:"enabled"`
+
+	// Value is served to targeted users, OffValue to everyone else.
+	// An empty OffValue means the zero value of the type.
+	Value    json.RawMessage `json:"value"`
+	OffValue json.RawMessage `json:"off_value,omitempty"`
+
+	// Rollout is the percentage (0-100, two decimals) of targeted users that get Value
+	Rollout float64 `json:"rollout"`
+
+	AllowUsers []string `json:"allow_users,omitempty"` // always get Value while enabled
+	DenyUsers  []string `json:"deny_users,omitempty"`  // never get Value
+
+	// Platforms restricts the flag to these client platforms (ios, android, web, ...); empty means all
+	Platforms []string `json:"platforms,omitempty"`
+
+	// MinAppVersion and MaxAppVersion bound the client version (inclusive); empty means unbounded
+	MinAppVersion string `json:"min_app_version,omitempty"`
+	MaxAppVersion string `json:"max_app_version,omitempty"`
+
+	// Client exposes the flag to apps through the bootstrap API; server-only flags are never sent to clients
+	Client bool `json:"client"`
+
+	UpdatedBy string    `json:"updated_by,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Version is the Consul ModifyIndex of the flag, used for compare-and-set updates
+	Version uint64 `json:"version,omitempty"`
+}
+
+// keyPattern allows lowercase keys with dots, underscores and hyphens, e.g. "player.lossless_audio".
+var keyPattern = regexp.MustCompile(`^[a-z][a-z0-9._-]{1,63}$`)
+
+// ValidKey reports whether key is a valid flag key.
+func ValidKey(key string) bool {
+	return keyPattern.MatchString(key)
+}
+
+// Normalize trims and lowercases targeting fields and removes duplicates.
+func (f *Flag) Normalize() {
+	f.Key = strings.TrimSpace(f.Key)
+	f.Type = Type(strings.ToLower(strings.TrimSpace(string(f.Type))))
+	f.AllowUsers = normalizeList(f.AllowUsers, false)
+	f.DenyUsers = normalizeList(f.DenyUsers, false)
+	f.Platforms = normalizeList(f.Platforms, true)
+	f.MinAppVersion = strings.TrimSpace(f.MinAppVersion)
+	f.MaxAppVersion = strings.TrimSpace(f.MaxAppVersion)
+}
+
+// Validate checks the flag definition.
+func (f *Flag) Validate() error {
+	if !ValidKey(f.Key) {
+		return fmt.Errorf("invalid key %q: must be 2-64 lowercase letters, digits, '.', '_' or '-' starting with a letter", f.Key)
+	}
+	switch f.Type {
+	case TypeBool, TypeString, TypeNumber, TypeJSON:
+	default:
+		return fmt.Errorf("invalid type %q", f.Type)
+	}
+	if len(f.Value) == 0 {
+		return fmt.Errorf("value is required")
+	}
+	if err := f.Type.check(f.Value); err != nil {
+		return fmt.Errorf("value: %w", err)
+	}
+	if len(f.OffValue) > 0 {
+		if err := f.Type.check(f.OffValue); err != nil {
+			return fmt.Errorf("off_value: %w", err)
+		}
+	}
+	if f.Rollout < 0 || f.Rollout > 100 {
+		return fmt.Errorf("rollout must be between 0 and 100")
+	}
+	for _, user := range f.AllowUsers {
+		if contains(f.DenyUsers, user) {
+			return fmt.Errorf("user %s is in both allow_users and deny_users", user)
+		}
+	}
+
+	var minVersion, maxVersion version
+	var err error
+	if f.MinAppVersion != "" {
+		if minVersion, err = parseVersion(f.MinAppVersion); err != nil {
+			return fmt.Errorf("min_app_version: %w", err)
+		}
+	}
+	if f.MaxAppVersion != "" {
+		if maxVersion, err = parseVersion(f.MaxAppVersion); err != nil {
+			return fmt.Errorf("max_app_version: %w", err)
+		}
+	}
+	if minVersion != nil && maxVersion != nil && minVersion.compare(maxVersion) > 0 {
+		return fmt.Errorf("min_app_version is greater than max_app_version")
+	}
+	return nil
+}
+
+// check verifies that a raw JSON value has the type.
+func (t Type) check(raw json.RawMessage) error {
+	var err error
+	switch t {
+	case TypeBool:
+		var v bool
+		err = json.Unmarshal(raw, &v)
+	case TypeString:
+		var v string
+		err = json.Unmarshal(raw, &v)
+	case TypeNumber:
+		var v float64
+		err = json.Unmarshal(raw, &v)
+	case TypeJSON:
+		if !json.Valid(raw) {
+			err = fmt.Errorf("invalid JSON")
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("not a %s value", t)
+	}
+	return nil
+}
+
+// zero returns the zero value of the type, served when OffValue is empty.
+func (t Type) zero() json.RawMessage {
+	switch t {
+	case TypeBool:
+		return json.RawMessage("false")
+	case TypeString:
+		return json.RawMessage(`""`)
+	case TypeNumber:
+		return json.RawMessage("0")
+	default:
+		return json.RawMessage("null")
+	}
+}
+
+func normalizeList(items []string, lower bool) []string {
+	if len(items) == 0 {
+		return nil
+	}
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if lower {
+			item = strings.ToLower(item)
+		}
+		if item != "" && !contains(result, item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func contains(items []string, item string) bool {
+	for _, v := range items {
+		if v == item {
+			return true
+		}
+	}
+	return false
+}
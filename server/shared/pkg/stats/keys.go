@@ -0,0 +1,61 @@
+package stats
+
+import (
+	"fmt"
+	"time"
+)
+
+// Metric names used in daily and per-minute counter keys.
+const (
+	MetricLogins          = "logins"
+	MetricNewUsers        = "new_users"
+	MetricPlays           = "total_plays"
+	MetricFavorites       = "total_favorites"
+	MetricPlaylists       = "total_playlists"
+	MetricRequests        = "total_requests"
+	MetricSuccessRequests = "success_requests"
+	MetricFailedRequests  = "failed_requests"
+	MetricResponseTimeSum = "response_time_sum" // milliseconds
+	MetricActiveUsers     = "active_users_hll"
+)
+
+// TotalUsersKey holds the running number of registered users.
+//
+// auth-svc seeds it from the users table on startup and every new-user
+// event increments it, so it never expires.
+const TotalUsersKey = "stats:users:total"
+
+// Key retention. Daily keys outlive the 7 days admin-svc reads from Redis so
+// the nightly persist job can always see the previous day.
+const (
+	DailyRetention   = 8 * 24 * time.Hour
+	WeeklyRetention  = 15 * 24 * time.Hour
+	MonthlyRetention = 40 * 24 * time.Hour
+	MinuteRetention  = 10 * time.Minute
+)
+
+// DailyKey returns the counter key of a metric for the day containing t.
+func DailyKey(t time.Time, metric string) string {
+	return fmt.Sprintf("daily:%s:%s", t.Format("2006-01-02"), metric)
+}
+
+// DailyActiveUsersKey returns the HyperLogLog of users active on the day containing t.
+func DailyActiveUsersKey(t time.Time) string {
+	return DailyKey(t, MetricActiveUsers)
+}
+
+// WeeklyActiveUsersKey returns the HyperLogLog of users active in the ISO week containing t.
+func WeeklyActiveUsersKey(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("weekly:%d-W%02d:%s", year, week, MetricActiveUsers)
+}
+
+// MonthlyActiveUsersKey returns the HyperLogLog of users active in the calendar month containing t.
+func MonthlyActiveUsersKey(t time.Time) string {
+	return fmt.Sprintf("monthly:%s:%s", t.Format("2006-01"), MetricActiveUsers)
+}
+
+// MinuteKey returns the realtime key of a metric for the minute containing t.
+func MinuteKey(t time.Time, metric string) string {
+	return fmt.Sprintf("stats:minute:%s:%s", t.Format("200601021504"), metric)
+}
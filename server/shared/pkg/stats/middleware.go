@@ -0,0 +1,57 @@
+package stats
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+)
+
+// GinMiddleware records the outcome and latency of every HTTP request.
+//
+// userIDKey is the gin context key set by the auth middleware; requests
+// with a user ID also count that user as active. Only 5xx responses count
+// as failures, client errors are the caller's problem.
+func GinMiddleware(emitter Emitter, userIDKey string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		emitter.Emit(Event{
+			Type:    EventRequest,
+			UserID:  c.GetString(userIDKey),
+			Success: c.Writer.Status() < http.StatusInternalServerError,
+			Latency: time.Since(start),
+			Time:    start,
+		})
+	}
+}
+
+// userScoped is implemented by every request message with a user_id field.
+type userScoped interface {
+	GetUserId() string
+}
+
+// UnaryServerInterceptor emits an event for every successful call to one of
+// the given methods. events maps full method names
+// ("/user.v1.UserService/AddFavorite") to event types; the user ID is read
+// from the request's user_id field.
+func UnaryServerInterceptor(emitter Emitter, events map[string]string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+
+		if eventType, ok := events[info.FullMethod]; ok {
+			event := Event{Type: eventType}
+			if r, ok := req.(userScoped); ok {
+				event.UserID = r.GetUserId()
+			}
+			emitter.Emit(event)
+		}
+		return resp, nil
+	}
+}
@@ -0,0 +1,279 @@
+// Package stats records business metrics for the admin dashboards.
+//
+// Services emit events (logins, new users, plays, favorites, playlists and
+// request outcomes) through an Emitter. RedisEmitter batches them in memory
+// and writes Redis counters and HyperLogLogs under the keys defined in
+// keys.go; admin-svc reads the same keys to build its realtime and daily
+// statistics.
+package stats
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Event types.
+const (
+	EventLogin           = "login"
+	EventNewUser         = "new_user"
+	EventPlay            = "play"
+	EventFavorite        = "favorite"
+	EventPlaylistCreated = "playlist_created"
+	EventRequest         = "request"
+)
+
+// Event is a single business event.
+type Event struct {
+	Type   string
+	UserID string // optional; counts the user as active
+
+	// Request outcome, only used by EventRequest
+	Success bool
+	Latency time.Duration
+
+	// Time defaults to the moment the event is emitted
+	Time time.Time
+}
+
+// Emitter records business events. Emit must never block the caller.
+type Emitter interface {
+	Emit(event Event)
+}
+
+// NoopEmitter discards all events.
+type NoopEmitter struct{}
+
+// Emit implements Emitter.
+func (NoopEmitter) Emit(Event) {}
+
+// Config configures a RedisEmitter.
+type Config struct {
+	// BufferSize is the number of pending events; events are dropped when full
+	BufferSize int
+
+	// BatchSize is the maximum number of events written in one pipeline
+	BatchSize int
+
+	// FlushInterval is how often pending events are written
+	FlushInterval time.Duration
+
+	// OnError is called when a batch cannot be written (optional)
+	OnError func(err error)
+}
+
+// DefaultConfig returns the default emitter configuration.
+func DefaultConfig() *Config {
+	return &Config{
+		BufferSize:    10000,
+		BatchSize:     500,
+		FlushInterval: time.Second,
+	}
+}
+
+// RedisEmitter writes events to Redis asynchronously in batches.
+type RedisEmitter struct {
+	client  redis.UniversalClient
+	config  *Config
+	events  chan Event
+	dropped atomic.Int64
+
+	done      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// NewRedisEmitter creates an emitter and starts its background writer.
+// Call Close on shutdown to flush pending events.
+func NewRedisEmitter(client redis.UniversalClient, config *Config) *RedisEmitter {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	e := &RedisEmitter{
+		client: client,
+		config: config,
+		events: make(chan Event, config.BufferSize),
+		done:   make(chan struct{}),
+	}
+	e.wg.Add(1)
+	go e.run()
+	return e
+}
+
+// Emit queues an event. It drops the event if the buffer is full.
+func (e *RedisEmitter) Emit(event Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	select {
+	case e.events <- event:
+	default:
+		e.dropped.Add(1)
+	}
+}
+
+// Dropped returns the number of events dropped because the buffer was full.
+func (e *RedisEmitter) Dropped() int64 {
+	return e.dropped.Load()
+}
+
+// Close stops the background writer after flushing pending events.
+func (e *RedisEmitter) Close() {
+	e.closeOnce.Do(func() {
+		close(e.done)
+		e.wg.Wait()
+	})
+}
+
+func (e *RedisEmitter) run() {
+	defer e.wg.Done()
+
+	ticker := time.NewTicker(e.config.FlushInterval)
+	defer ticker.Stop()
+
+	pending := make([]Event, 0, e.config.BatchSize)
+	flush := func() {
+		if len(pending) == 0 {
+			return
+		}
+		e.write(pending)
+		pending = pending[:0]
+	}
+
+	for {
+		select {
+		case event := <-e.events:
+			pending = append(pending, event)
+			if len(pending) >= e.config.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-e.done:
+			// Drain whatever is still buffered
+			for {
+				select {
+				case event := <-e.events:
+					pending = append(pending, event)
+					if len(pending) >= e.config.BatchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (e *RedisEmitter) write(events []Event) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	pipe := e.client.Pipeline()
+	newBatch(events).apply(ctx, pipe)
+	if _, err := pipe.Exec(ctx); err != nil && e.config.OnError != nil {
+		e.config.OnError(err)
+	}
+}
+
+// SetTotalUsers seeds the running number of registered users.
+func SetTotalUsers(ctx context.Context, client redis.UniversalClient, total int64) error {
+	return client.Set(ctx, TotalUsersKey, total, 0).Err()
+}
+
+// batch merges events into per-key increments and HyperLogLog members so a
+// whole batch costs a handful of Redis commands.
+type batch struct {
+	counters map[string]int64
+	uniques  map[string]map[string]struct{}
+	ttls     map[string]time.Duration
+}
+
+func newBatch(events []Event) *batch {
+	b := &batch{
+		counters: make(map[string]int64),
+		uniques:  make(map[string]map[string]struct{}),
+		ttls:     make(map[string]time.Duration),
+	}
+	for _, event := range events {
+		b.add(event)
+	}
+	return b
+}
+
+func (b *batch) add(event Event) {
+	t := event.Time
+
+	switch event.Type {
+	case EventLogin:
+		b.incr(DailyKey(t, MetricLogins), 1, DailyRetention)
+	case EventNewUser:
+		b.incr(DailyKey(t, MetricNewUsers), 1, DailyRetention)
+		b.incr(TotalUsersKey, 1, 0)
+	case EventPlay:
+		b.incr(DailyKey(t, MetricPlays), 1, DailyRetention)
+	case EventFavorite:
+		b.incr(DailyKey(t, MetricFavorites), 1, DailyRetention)
+	case EventPlaylistCreated:
+		b.incr(DailyKey(t, MetricPlaylists), 1, DailyRetention)
+	case EventRequest:
+		latency := event.Latency.Milliseconds()
+		b.incr(DailyKey(t, MetricRequests), 1, DailyRetention)
+		b.incr(DailyKey(t, MetricResponseTimeSum), latency, DailyRetention)
+		b.incr(MinuteKey(t, MetricRequests), 1, MinuteRetention)
+		b.incr(MinuteKey(t, MetricResponseTimeSum), latency, MinuteRetention)
+		if event.Success {
+			b.incr(DailyKey(t, MetricSuccessRequests), 1, DailyRetention)
+		} else {
+			b.incr(DailyKey(t, MetricFailedRequests), 1, DailyRetention)
+			b.incr(MinuteKey(t, MetricFailedRequests), 1, MinuteRetention)
+		}
+	default:
+		return
+	}
+
+	if event.UserID != "" {
+		b.unique(DailyActiveUsersKey(t), event.UserID, DailyRetention)
+		b.unique(WeeklyActiveUsersKey(t), event.UserID, WeeklyRetention)
+		b.unique(MonthlyActiveUsersKey(t), event.UserID, MonthlyRetention)
+		b.unique(MinuteKey(t, MetricActiveUsers), event.UserID, MinuteRetention)
+	}
+}
+
+func (b *batch) incr(key string, n int64, ttl time.Duration) {
+	b.counters[key] += n
+	b.ttls[key] = ttl
+}
+
+func (b *batch) unique(key, member string, ttl time.Duration) {
+	members, ok := b.uniques[key]
+	if !ok {
+		members = make(map[string]struct{})
+		b.uniques[key] = members
+	}
+	members[member] = struct{}{}
+	b.ttls[key] = ttl
+}
+
+func (b *batch) apply(ctx context.Context, pipe redis.Pipeliner) {
+	for key, n := range b.counters {
+		pipe.IncrBy(ctx, key, n)
+	}
+	for key, members := range b.uniques {
+		values := make([]interface{}, 0, len(members))
+		for member := range members {
+			values = append(values, member)
+		}
+		pipe.PFAdd(ctx, key, values...)
+	}
+	for key, ttl := range b.ttls {
+		if ttl > 0 {
+			pipe.Expire(ctx, key, ttl)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+type recordingEmitter struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (r *recordingEmitter) Emit(event Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func TestKeys(t *testing.T) {
+	ts := time.Date(2026, 1, 1, 9, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, "daily:2026-01-01:total_plays", DailyKey(ts, MetricPlays))
+	assert.Equal(t, "daily:2026-01-01:active_users_hll", DailyActiveUsersKey(ts))
+	// 2026-01-01 belongs to ISO week 1 of 2026
+	assert.Equal(t, "weekly:2026-W01:active_users_hll", WeeklyActiveUsersKey(ts))
+	assert.Equal(t, "monthly:2026-01:active_users_hll", MonthlyActiveUsersKey(ts))
+	assert.Equal(t, "stats:minute:202601010930:total_requests", MinuteKey(ts, MetricRequests))
+}
+
+func TestBatch_MergesEvents(t *testing.T) {
+	ts := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	b := newBatch([]Event{
+		{Type: EventPlay, UserID: "u1", Time: ts},
+		{Type: EventPlay, UserID: "u1", Time: ts},
+		{Type: EventNewUser, UserID: "u2", Time: ts},
+		{Type: EventRequest, UserID: "u2", Success: true, Latency: 20 * time.Millisecond, Time: ts},
+		{Type: EventRequest, Success: false, Latency: 100 * time.Millisecond, Time: ts},
+		{Type: "unknown", UserID: "u3", Time: ts},
+	})
+
+	assert.Equal(t, int64(2), b.counters[DailyKey(ts, MetricPlays)])
+	assert.Equal(t, int64(1), b.counters[DailyKey(ts, MetricNewUsers)])
+	assert.Equal(t, int64(1), b.counters[TotalUsersKey])
+	assert.Equal(t, int64(2), b.counters[DailyKey(ts, MetricRequests)])
+	assert.Equal(t, int64(1), b.counters[DailyKey(ts, MetricSuccessRequests)])
+	assert.Equal(t, int64(1), b.counters[DailyKey(ts, MetricFailedRequests)])
+	assert.Equal(t, int64(120), b.counters[DailyKey(ts, MetricResponseTimeSum)])
+	assert.Equal(t, int64(1), b.counters[MinuteKey(ts, MetricFailedRequests)])
+
+	assert.Len(t, b.uniques[DailyActiveUsersKey(ts)], 2)
+	assert.Len(t, b.uniques[WeeklyActiveUsersKey(ts)], 2)
+	assert.Len(t, b.uniques[MonthlyActiveUsersKey(ts)], 2)
+
+	assert.Equal(t, DailyRetention, b.ttls[DailyKey(ts, MetricPlays)])
+	assert.Equal(t, time.Duration(0), b.ttls[TotalUsersKey])
+}
+
+func TestGinMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := &recordingEmitter{}
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) { c.Set("user_id", "u1") })
+	router.Use(GinMiddleware(rec, "user_id"))
+	router.GET("/ok", func(c *gin.Context) { c.Status(http.StatusNotFound) })
+	router.GET("/fail", func(c *gin.Context) { c.Status(http.StatusBadGateway) })
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ok", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/fail", nil))
+
+	require.Len(t, rec.events, 2)
+	assert.Equal(t, EventRequest, rec.events[0].Type)
+	assert.Equal(t, "u1", rec.events[0].UserID)
+	assert.True(t, rec.events[0].Success)
+	assert.False(t, rec.events[1].Success)
+}
+
+type fakeRequest struct{ userID string }
+
+func (r *fakeRequest) GetUserId() string { return r.userID }
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	rec := &recordingEmitter{}
+	interceptor := UnaryServerInterceptor(rec, map[string]string{
+		"/user.v1.UserService/AddFavorite": EventFavorite,
+	})
+
+	ok := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	fail := func(ctx context.Context, req interface{}) (interface{}, error) { return nil, errors.New("boom") }
+
+	_, _ = interceptor(context.Background(), &fakeRequest{"u1"}, &grpc.UnaryServerInfo{FullMethod: "/user.v1.UserService/AddFavorite"}, ok)
+	_, _ = interceptor(context.Background(), &fakeRequest{"u1"}, &grpc.UnaryServerInfo{FullMethod: "/user.v1.UserService/AddFavorite"}, fail)
+	_, _ = interceptor(context.Background(), &fakeRequest{"u1"}, &grpc.UnaryServerInfo{FullMethod: "/user.v1.UserService/ListFavorites"}, ok)
+
+	require.Len(t, rec.events, 1)
+	assert.Equal(t, EventFavorite, rec.events[0].Type)
+	assert.Equal(t, "u1", rec.events[0].UserID)
+}
+
+func TestRedisEmitter_DropsWhenFull(t *testing.T) {
+	e := &RedisEmitter{config: DefaultConfig(), events: make(chan Event, 1)}
+
+	e.Emit(Event{Type: EventPlay})
+	e.Emit(Event{Type: EventPlay})
+
+	assert.Equal(t, int64(1), e.Dropped())
+	assert.False(t, (<-e.events).Time.IsZero())
+}
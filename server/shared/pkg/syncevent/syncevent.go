@@ -0,0 +1,103 @@
+// Package syncevent publishes user-scoped events to sync-svc.
+//
+// sync-svc subscribes to the Redis pattern "sync:user:*" and fans every
+// message out to the user's online WebSocket connections. Other services use
+// this package so they do not have to depend on sync-svc internals.
+package syncevent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// UserChannelPrefix is the Redis channel prefix consumed by sync-svc.
+const UserChannelPrefix = "sync:user:"
+
+// Event types understood by sync-svc clients.
+const (
+	TypeFavoriteAdded      = "favorite.added"
+	TypeFavoriteRemoved    = "favorite.removed"
+	TypeHistoryAdded       = "history.added"
+	TypePlaylistUpdated    = "playlist.updated"
+	TypeSecurityLoginAlert = "security.login_alert"
+
+	TypePlaybackQueueUpdated    = "playback.queue_updated"
+	TypePlaybackPositionUpdated = "playback.position_updated"
+)
+
+// Message mirrors sync-svc's SyncMessage wire format.
+type Message struct {
+	ID         string                 `json:"id"`
+	Type       string                 `json:"type"`
+	UserID     string                 `json:"user_id"`
+	Data       map[string]interface{} `json:"data"`
+	Timestamp  time.Time              `json:"timestamp"`
+	InstanceID string                 `json:"instance_id,omitempty"`
+}
+
+// Publisher publishes events to a user's connected devices.
+type Publisher interface {
+	Publish(ctx context.Context, userID, eventType string, data map[string]interface{}) error
+}
+
+// redisPublisher is the subset of the go-redis client used by RedisPublisher.
+type redisPublisher interface {
+	Publish(ctx context.Context, channel string, message interface{}) *redis.IntCmd
+}
+
+// RedisPublisher publishes events over Redis Pub/Sub.
+type RedisPublisher struct {
+	client redisPublisher
+	source string
+}
+
+// NewRedisPublisher creates a publisher. source identifies the publishing
+// service and is sent as instance_id so sync-svc never mistakes it for one
+// of its own instances.
+func NewRedisPublisher(client redis.UniversalClient, source string) *RedisPublisher {
+	return &RedisPublisher{client: client, source: source}
+}
+
+// Publish sends an event to all of the user's devices.
+func (p *RedisPublisher) Publish(ctx context.Context, userID, eventType string, data map[string]interface{}) error {
+	if userID == "" {
+		return fmt.Errorf("syncevent: user ID is required")
+	}
+
+	msg := Message{
+		ID:         uuid.New().String(),
+		Type:       eventType,
+		UserID:     userID,
+		Data:       data,
+		Timestamp:  time.Now(),
+		InstanceID: p.source,
+	}
+
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("syncevent: failed to marshal message: %w", err)
+	}
+
+	if err := p.client.Publish(ctx, UserChannel(userID), payload).Err(); err != nil {
+		return fmt.Errorf("syncevent: failed to publish %s: %w", eventType, err)
+	}
+	return nil
+}
+
+// UserChannel returns the sync-svc channel for a user.
+func UserChannel(userID string) string {
+	return UserChannelPrefix + userID
+}
+
+// NoopPublisher discards all events.
+type NoopPublisher struct{}
+
+// Publish implements Publisher.
+func (NoopPublisher) Publish(context.Context, string, string, map[string]interface{}) error {
+	return nil
+}
@@ -0,0 +1,56 @@
+package syncevent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRedis struct {
+	channel string
+	payload []byte
+	err     error
+}
+
+func (f *fakeRedis) Publish(ctx context.Context, channel string, message interface{}) *redis.IntCmd {
+	f.channel = channel
+	f.payload, _ = message.([]byte)
+	cmd := redis.NewIntCmd(ctx)
+	if f.err != nil {
+		cmd.SetErr(f.err)
+	} else {
+		cmd.SetVal(1)
+	}
+	return cmd
+}
+
+func TestRedisPublisher_Publish(t *testing.T) {
+	fake := &fakeRedis{}
+	p := &RedisPublisher{client: fake, source: "auth-svc"}
+
+	err := p.Publish(context.Background(), "user-1", TypeSecurityLoginAlert, map[string]interface{}{"action": "block"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "sync:user:user-1", fake.channel)
+
+	var msg Message
+	require.NoError(t, json.Unmarshal(fake.payload, &msg))
+	assert.NotEmpty(t, msg.ID)
+	assert.Equal(t, TypeSecurityLoginAlert, msg.Type)
+	assert.Equal(t, "user-1", msg.UserID)
+	assert.Equal(t, "auth-svc", msg.InstanceID)
+	assert.Equal(t, "block", msg.Data["action"])
+	assert.False(t, msg.Timestamp.IsZero())
+}
+
+func TestRedisPublisher_PublishErrors(t *testing.T) {
+	p := &RedisPublisher{client: &fakeRedis{err: errors.New("down")}, source: "auth-svc"}
+
+	assert.Error(t, p.Publish(context.Background(), "", TypeSecurityLoginAlert, nil))
+	assert.Error(t, p.Publish(context.Background(), "user-1", TypeSecurityLoginAlert, nil))
+}
@@ -36,6 +36,8 @@ const (
 	FavoriteType_FAVORITE_TYPE_ARTIST FavoriteType = 3
 	// Music video
 	FavoriteType_FAVORITE_TYPE_MV FavoriteType = 4
+	// Playlist on the upstream music service
+	FavoriteType_FAVORITE_TYPE_PLAYLIST FavoriteType = 5
 )
 
 // Enum value maps for FavoriteType.
@@ -46,6 +48,7 @@ var (
 		2: "FAVORITE_TYPE_ALBUM",
 		3: "FAVORITE_TYPE_ARTIST",
 		4: "FAVORITE_TYPE_MV",
+		5: "FAVORITE_TYPE_PLAYLIST",
 	}
 	FavoriteType_value = map[string]int32{
 		"FAVORITE_TYPE_UNSPECIFIED": 0,
@@ -53,6 +56,7 @@ var (
 		"FAVORITE_TYPE_ALBUM":       2,
 		"FAVORITE_TYPE_ARTIST":      3,
 		"FAVORITE_TYPE_MV":          4,
+		"FAVORITE_TYPE_PLAYLIST":    5,
 	}
 )
 
@@ -83,6 +87,63 @@ func (FavoriteType) EnumDescriptor() ([]byte, []int) {
 	return file_user_v1_user_proto_rawDescGZIP(), []int{0}
 }
 
+// RepeatMode is the play queue repeat mode.
+type RepeatMode int32
+
+const (
+	// Not set (treated as OFF)
+	RepeatMode_REPEAT_MODE_UNSPECIFIED RepeatMode = 0
+	// No repeat
+	RepeatMode_REPEAT_MODE_OFF RepeatMode = 1
+	// Repeat the whole queue
+	RepeatMode_REPEAT_MODE_ALL RepeatMode = 2
+	// Repeat the current track
+	RepeatMode_REPEAT_MODE_ONE RepeatMode = 3
+)
+
+// Enum value maps for RepeatMode.
+var (
+	RepeatMode_name = map[int32]string{
+		0: "REPEAT_MODE_UNSPECIFIED",
+		1: "REPEAT_MODE_OFF",
+		2: "REPEAT_MODE_ALL",
+		3: "REPEAT_MODE_ONE",
+	}
+	RepeatMode_value = map[string]int32{
+		"REPEAT_MODE_UNSPECIFIED": 0,
+		"REPEAT_MODE_OFF":         1,
+		"REPEAT_MODE_ALL":         2,
+		"REPEAT_MODE_ONE":         3,
+	}
+)
+
+func (x RepeatMode) Enum() *RepeatMode {
+	p := new(RepeatMode)
+	*p = x
+	return p
+}
+
+func (x RepeatMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RepeatMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_user_v1_user_proto_enumTypes[1].Descriptor()
+}
+
+func (RepeatMode) Type() protoreflect.EnumType {
+	return &file_user_v1_user_proto_enumTypes[1]
+}
+
+func (x RepeatMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RepeatMode.Descriptor instead.
+func (RepeatMode) EnumDescriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{1}
+}
+
 // AddFavoriteRequest specifies the item to favorite.
 type AddFavoriteRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -395,7 +456,9 @@ type ListFavoritesResponse struct {
 	// Current page
 	Page int32 `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
 	// Page size
-	PageSize      int32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageSize int32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Favorite counts of every type, regardless of the type filter
+	TypeCounts    []*FavoriteTypeCount `protobuf:"bytes,5,rep,name=type_counts,json=typeCounts,proto3" json:"type_counts,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -458,6 +521,68 @@ func (x *ListFavoritesResponse) GetPageSize() int32 {
 	return 0
 }
 
+func (x *ListFavoritesResponse) GetTypeCounts() []*FavoriteTypeCount {
+	if x != nil {
+		return x.TypeCounts
+	}
+	return nil
+}
+
+// FavoriteTypeCount is the number of favorites of one type.
+type FavoriteTypeCount struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Favorite type
+	Type FavoriteType `protobuf:"varint,1,opt,name=type,proto3,enum=user.v1.FavoriteType" json:"type,omitempty"`
+	// Number of favorites
+	Count         int64 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FavoriteTypeCount) Reset() {
+	*x = FavoriteTypeCount{}
+	mi := &file_user_v1_user_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FavoriteTypeCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FavoriteTypeCount) ProtoMessage() {}
+
+func (x *FavoriteTypeCount) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FavoriteTypeCount.ProtoReflect.Descriptor instead.
+func (*FavoriteTypeCount) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *FavoriteTypeCount) GetType() FavoriteType {
+	if x != nil {
+		return x.Type
+	}
+	return FavoriteType_FAVORITE_TYPE_UNSPECIFIED
+}
+
+func (x *FavoriteTypeCount) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
 // AddPlayHistoryRequest records a play event.
 type AddPlayHistoryRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -479,7 +604,7 @@ type AddPlayHistoryRequest struct {
 
 func (x *AddPlayHistoryRequest) Reset() {
 	*x = AddPlayHistoryRequest{}
-	mi := &file_user_v1_user_proto_msgTypes[6]
+	mi := &file_user_v1_user_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -491,7 +616,7 @@ func (x *AddPlayHistoryRequest) String() string {
 func (*AddPlayHistoryRequest) ProtoMessage() {}
 
 func (x *AddPlayHistoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_v1_user_proto_msgTypes[6]
+	mi := &file_user_v1_user_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -504,7 +629,7 @@ func (x *AddPlayHistoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddPlayHistoryRequest.ProtoReflect.Descriptor instead.
 func (*AddPlayHistoryRequest) Descriptor() ([]byte, []int) {
-	return file_user_v1_user_proto_rawDescGZIP(), []int{6}
+	return file_user_v1_user_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *AddPlayHistoryRequest) GetUserId() string {
@@ -564,7 +689,7 @@ type AddPlayHistoryResponse struct {
 
 func (x *AddPlayHistoryResponse) Reset() {
 	*x = AddPlayHistoryResponse{}
-	mi := &file_user_v1_user_proto_msgTypes[7]
+	mi := &file_user_v1_user_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -576,7 +701,7 @@ func (x *AddPlayHistoryResponse) String() string {
 func (*AddPlayHistoryResponse) ProtoMessage() {}
 
 func (x *AddPlayHistoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_v1_user_proto_msgTypes[7]
+	mi := &file_user_v1_user_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -589,7 +714,7 @@ func (x *AddPlayHistoryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddPlayHistoryResponse.ProtoReflect.Descriptor instead.
 func (*AddPlayHistoryResponse) Descriptor() ([]byte, []int) {
-	return file_user_v1_user_proto_rawDescGZIP(), []int{7}
+	return file_user_v1_user_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *AddPlayHistoryResponse) GetHistoryId() string {
@@ -628,7 +753,7 @@ type ListPlayHistoryRequest struct {
 
 func (x *ListPlayHistoryRequest) Reset() {
 	*x = ListPlayHistoryRequest{}
-	mi := &file_user_v1_user_proto_msgTypes[8]
+	mi := &file_user_v1_user_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -640,7 +765,7 @@ func (x *ListPlayHistoryRequest) String() string {
 func (*ListPlayHistoryRequest) ProtoMessage() {}
 
 func (x *ListPlayHistoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_v1_user_proto_msgTypes[8]
+	mi := &file_user_v1_user_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -653,7 +778,7 @@ func (x *ListPlayHistoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPlayHistoryRequest.ProtoReflect.Descriptor instead.
 func (*ListPlayHistoryRequest) Descriptor() ([]byte, []int) {
-	return file_user_v1_user_proto_rawDescGZIP(), []int{8}
+	return file_user_v1_user_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ListPlayHistoryRequest) GetUserId() string {
@@ -694,7 +819,7 @@ type ListPlayHistoryResponse struct {
 
 func (x *ListPlayHistoryResponse) Reset() {
 	*x = ListPlayHistoryResponse{}
-	mi := &file_user_v1_user_proto_msgTypes[9]
+	mi := &file_user_v1_user_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -706,7 +831,7 @@ func (x *ListPlayHistoryResponse) String() string {
 func (*ListPlayHistoryResponse) ProtoMessage() {}
 
 func (x *ListPlayHistoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_v1_user_proto_msgTypes[9]
+	mi := &file_user_v1_user_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -719,7 +844,7 @@ func (x *ListPlayHistoryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPlayHistoryResponse.ProtoReflect.Descriptor instead.
 func (*ListPlayHistoryResponse) Descriptor() ([]byte, []int) {
-	return file_user_v1_user_proto_rawDescGZIP(), []int{9}
+	return file_user_v1_user_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ListPlayHistoryResponse) GetHistory() []*PlayHistory {
@@ -760,14 +885,19 @@ type CreatePlaylistRequest struct {
 	// Optional cover image URL
 	CoverUrl string `protobuf:"bytes,3,opt,name=cover_url,json=coverUrl,proto3" json:"cover_url,omitempty"`
 	// Whether the playlist is public
-	IsPublic      bool `protobuf:"varint,4,opt,name=is_public,json=isPublic,proto3" json:"is_public,omitempty"`
+	IsPublic bool `protobuf:"varint,4,opt,name=is_public,json=isPublic,proto3" json:"is_public,omitempty"`
+	// Optional smart playlist rules as JSON, e.g.
+	// {"match":"all","rules":[{"field":"play_count","op":"gt","value":10}]}.
+	// When set, songs are computed from the user's favorites and play history
+	// instead of being added manually.
+	SmartRules    string `protobuf:"bytes,5,opt,name=smart_rules,json=smartRules,proto3" json:"smart_rules,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreatePlaylistRequest) Reset() {
 	*x = CreatePlaylistRequest{}
-	mi := &file_user_v1_user_proto_msgTypes[10]
+	mi := &file_user_v1_user_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -779,7 +909,7 @@ func (x *CreatePlaylistRequest) String() string {
 func (*CreatePlaylistRequest) ProtoMessage() {}
 
 func (x *CreatePlaylistRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_v1_user_proto_msgTypes[10]
+	mi := &file_user_v1_user_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -792,7 +922,7 @@ func (x *CreatePlaylistRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreatePlaylistRequest.ProtoReflect.Descriptor instead.
 func (*CreatePlaylistRequest) Descriptor() ([]byte, []int) {
-	return file_user_v1_user_proto_rawDescGZIP(), []int{10}
+	return file_user_v1_user_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *CreatePlaylistRequest) GetUserId() string {
@@ -823,6 +953,13 @@ func (x *CreatePlaylistRequest) GetIsPublic() bool {
 	return false
 }
 
+func (x *CreatePlaylistRequest) GetSmartRules() string {
+	if x != nil {
+		return x.SmartRules
+	}
+	return ""
+}
+
 // CreatePlaylistResponse contains the created playlist.
 type CreatePlaylistResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -834,7 +971,7 @@ type CreatePlaylistResponse struct {
 
 func (x *CreatePlaylistResponse) Reset() {
 	*x = CreatePlaylistResponse{}
-	mi := &file_user_v1_user_proto_msgTypes[11]
+	mi := &file_user_v1_user_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -846,7 +983,7 @@ func (x *CreatePlaylistResponse) String() string {
 func (*CreatePlaylistResponse) ProtoMessage() {}
 
 func (x *CreatePlaylistResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_v1_user_proto_msgTypes[11]
+	mi := &file_user_v1_user_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -859,7 +996,7 @@ func (x *CreatePlaylistResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreatePlaylistResponse.ProtoReflect.Descriptor instead.
 func (*CreatePlaylistResponse) Descriptor() ([]byte, []int) {
-	return file_user_v1_user_proto_rawDescGZIP(), []int{11}
+	return file_user_v1_user_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *CreatePlaylistResponse) GetPlaylist() *Playlist {
@@ -881,14 +1018,17 @@ type UpdatePlaylistRequest struct {
 	// New cover URL (optional, empty = no change)
 	CoverUrl string `protobuf:"bytes,4,opt,name=cover_url,json=coverUrl,proto3" json:"cover_url,omitempty"`
 	// New visibility (optional)
-	IsPublic      *bool `protobuf:"varint,5,opt,name=is_public,json=isPublic,proto3,oneof" json:"is_public,omitempty"`
+	IsPublic *bool `protobuf:"varint,5,opt,name=is_public,json=isPublic,proto3,oneof" json:"is_public,omitempty"`
+	// New smart playlist rules as JSON (optional, empty = no change).
+	// Only valid for smart playlists.
+	SmartRules    string `protobuf:"bytes,6,opt,name=smart_rules,json=smartRules,proto3" json:"smart_rules,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdatePlaylistRequest) Reset() {
 	*x = UpdatePlaylistRequest{}
-	mi := &file_user_v1_user_proto_msgTypes[12]
+	mi := &file_user_v1_user_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -900,7 +1040,7 @@ func (x *UpdatePlaylistRequest) String() string {
 func (*UpdatePlaylistRequest) ProtoMessage() {}
 
 func (x *UpdatePlaylistRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_v1_user_proto_msgTypes[12]
+	mi := &file_user_v1_user_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -913,7 +1053,7 @@ func (x *UpdatePlaylistRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdatePlaylistRequest.ProtoReflect.Descriptor instead.
 func (*UpdatePlaylistRequest) Descriptor() ([]byte, []int) {
-	return file_user_v1_user_proto_rawDescGZIP(), []int{12}
+	return file_user_v1_user_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *UpdatePlaylistRequest) GetUserId() string {
@@ -951,6 +1091,13 @@ func (x *UpdatePlaylistRequest) GetIsPublic() bool {
 	return false
 }
 
+func (x *UpdatePlaylistRequest) GetSmartRules() string {
+	if x != nil {
+		return x.SmartRules
+	}
+	return ""
+}
+
 // UpdatePlaylistResponse contains the updated playlist.
 type UpdatePlaylistResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -962,7 +1109,7 @@ type UpdatePlaylistResponse struct {
 
 func (x *UpdatePlaylistResponse) Reset() {
 	*x = UpdatePlaylistResponse{}
-	mi := &file_user_v1_user_proto_msgTypes[13]
+	mi := &file_user_v1_user_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -974,7 +1121,7 @@ func (x *UpdatePlaylistResponse) String() string {
 func (*UpdatePlaylistResponse) ProtoMessage() {}
 
 func (x *UpdatePlaylistResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_v1_user_proto_msgTypes[13]
+	mi := &file_user_v1_user_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -987,7 +1134,7 @@ func (x *UpdatePlaylistResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdatePlaylistResponse.ProtoReflect.Descriptor instead.
 func (*UpdatePlaylistResponse) Descriptor() ([]byte, []int) {
-	return file_user_v1_user_proto_rawDescGZIP(), []int{13}
+	return file_user_v1_user_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *UpdatePlaylistResponse) GetPlaylist() *Playlist {
@@ -1010,7 +1157,7 @@ type DeletePlaylistRequest struct {
 
 func (x *DeletePlaylistRequest) Reset() {
 	*x = DeletePlaylistRequest{}
-	mi := &file_user_v1_user_proto_msgTypes[14]
+	mi := &file_user_v1_user_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1022,7 +1169,7 @@ func (x *DeletePlaylistRequest) String() string {
 func (*DeletePlaylistRequest) ProtoMessage() {}
 
 func (x *DeletePlaylistRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_v1_user_proto_msgTypes[14]
+	mi := &file_user_v1_user_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1035,7 +1182,7 @@ func (x *DeletePlaylistRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeletePlaylistRequest.ProtoReflect.Descriptor instead.
 func (*DeletePlaylistRequest) Descriptor() ([]byte, []int) {
-	return file_user_v1_user_proto_rawDescGZIP(), []int{14}
+	return file_user_v1_user_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *DeletePlaylistRequest) GetUserId() string {
@@ -1063,7 +1210,7 @@ type DeletePlaylistResponse struct {
 
 func (x *DeletePlaylistResponse) Reset() {
 	*x = DeletePlaylistResponse{}
-	mi := &file_user_v1_user_proto_msgTypes[15]
+	mi := &file_user_v1_user_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1075,7 +1222,7 @@ func (x *DeletePlaylistResponse) String() string {
 func (*DeletePlaylistResponse) ProtoMessage() {}
 
 func (x *DeletePlaylistResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_v1_user_proto_msgTypes[15]
+	mi := &file_user_v1_user_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1088,7 +1235,7 @@ func (x *DeletePlaylistResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeletePlaylistResponse.ProtoReflect.Descriptor instead.
 func (*DeletePlaylistResponse) Descriptor() ([]byte, []int) {
-	return file_user_v1_user_proto_rawDescGZIP(), []int{15}
+	return file_user_v1_user_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *DeletePlaylistResponse) GetSuccess() bool {
@@ -1109,7 +1256,7 @@ type ListPlaylistsRequest struct {
 
 func (x *ListPlaylistsRequest) Reset() {
 	*x = ListPlaylistsRequest{}
-	mi := &file_user_v1_user_proto_msgTypes[16]
+	mi := &file_user_v1_user_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1121,7 +1268,7 @@ func (x *ListPlaylistsRequest) String() string {
 func (*ListPlaylistsRequest) ProtoMessage() {}
 
 func (x *ListPlaylistsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_v1_user_proto_msgTypes[16]
+	mi := &file_user_v1_user_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1134,7 +1281,7 @@ func (x *ListPlaylistsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPlaylistsRequest.ProtoReflect.Descriptor instead.
 func (*ListPlaylistsRequest) Descriptor() ([]byte, []int) {
-	return file_user_v1_user_proto_rawDescGZIP(), []int{16}
+	return file_user_v1_user_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *ListPlaylistsRequest) GetUserId() string {
@@ -1155,7 +1302,7 @@ type ListPlaylistsResponse struct {
 
 func (x *ListPlaylistsResponse) Reset() {
 	*x = ListPlaylistsResponse{}
-	mi := &file_user_v1_user_proto_msgTypes[17]
+	mi := &file_user_v1_user_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1167,7 +1314,7 @@ func (x *ListPlaylistsResponse) String() string {
 func (*ListPlaylistsResponse) ProtoMessage() {}
 
 func (x *ListPlaylistsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_v1_user_proto_msgTypes[17]
+	mi := &file_user_v1_user_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1180,7 +1327,7 @@ func (x *ListPlaylistsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPlaylistsResponse.ProtoReflect.Descriptor instead.
 func (*ListPlaylistsResponse) Descriptor() ([]byte, []int) {
-	return file_user_v1_user_proto_rawDescGZIP(), []int{17}
+	return file_user_v1_user_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *ListPlaylistsResponse) GetPlaylists() []*Playlist {
@@ -1207,7 +1354,7 @@ type AddSongToPlaylistRequest struct {
 
 func (x *AddSongToPlaylistRequest) Reset() {
 	*x = AddSongToPlaylistRequest{}
-	mi := &file_user_v1_user_proto_msgTypes[18]
+	mi := &file_user_v1_user_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1219,7 +1366,7 @@ func (x *AddSongToPlaylistRequest) String() string {
 func (*AddSongToPlaylistRequest) ProtoMessage() {}
 
 func (x *AddSongToPlaylistRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_v1_user_proto_msgTypes[18]
+	mi := &file_user_v1_user_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1232,7 +1379,7 @@ func (x *AddSongToPlaylistRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddSongToPlaylistRequest.ProtoReflect.Descriptor instead.
 func (*AddSongToPlaylistRequest) Descriptor() ([]byte, []int) {
-	return file_user_v1_user_proto_rawDescGZIP(), []int{18}
+	return file_user_v1_user_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *AddSongToPlaylistRequest) GetUserId() string {
@@ -1276,7 +1423,7 @@ type AddSongToPlaylistResponse struct {
 
 func (x *AddSongToPlaylistResponse) Reset() {
 	*x = AddSongToPlaylistResponse{}
-	mi := &file_user_v1_user_proto_msgTypes[19]
+	mi := &file_user_v1_user_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1288,7 +1435,7 @@ func (x *AddSongToPlaylistResponse) String() string {
 func (*AddSongToPlaylistResponse) ProtoMessage() {}
 
 func (x *AddSongToPlaylistResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_v1_user_proto_msgTypes[19]
+	mi := &file_user_v1_user_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1301,7 +1448,7 @@ func (x *AddSongToPlaylistResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddSongToPlaylistResponse.ProtoReflect.Descriptor instead.
 func (*AddSongToPlaylistResponse) Descriptor() ([]byte, []int) {
-	return file_user_v1_user_proto_rawDescGZIP(), []int{19}
+	return file_user_v1_user_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *AddSongToPlaylistResponse) GetSuccess() bool {
@@ -1333,7 +1480,7 @@ type RemoveSongFromPlaylistRequest struct {
 
 func (x *RemoveSongFromPlaylistRequest) Reset() {
 	*x = RemoveSongFromPlaylistRequest{}
-	mi := &file_user_v1_user_proto_msgTypes[20]
+	mi := &file_user_v1_user_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1345,7 +1492,7 @@ func (x *RemoveSongFromPlaylistRequest) String() string {
 func (*RemoveSongFromPlaylistRequest) ProtoMessage() {}
 
 func (x *RemoveSongFromPlaylistRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_v1_user_proto_msgTypes[20]
+	mi := &file_user_v1_user_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1358,7 +1505,7 @@ func (x *RemoveSongFromPlaylistRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveSongFromPlaylistRequest.ProtoReflect.Descriptor instead.
 func (*RemoveSongFromPlaylistRequest) Descriptor() ([]byte, []int) {
-	return file_user_v1_user_proto_rawDescGZIP(), []int{20}
+	return file_user_v1_user_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *RemoveSongFromPlaylistRequest) GetUserId() string {
@@ -1395,7 +1542,7 @@ type RemoveSongFromPlaylistResponse struct {
 
 func (x *RemoveSongFromPlaylistResponse) Reset() {
 	*x = RemoveSongFromPlaylistResponse{}
-	mi := &file_user_v1_user_proto_msgTypes[21]
+	mi := &file_user_v1_user_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1407,7 +1554,7 @@ func (x *RemoveSongFromPlaylistResponse) String() string {
 func (*RemoveSongFromPlaylistResponse) ProtoMessage() {}
 
 func (x *RemoveSongFromPlaylistResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_v1_user_proto_msgTypes[21]
+	mi := &file_user_v1_user_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1420,7 +1567,7 @@ func (x *RemoveSongFromPlaylistResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveSongFromPlaylistResponse.ProtoReflect.Descriptor instead.
 func (*RemoveSongFromPlaylistResponse) Descriptor() ([]byte, []int) {
-	return file_user_v1_user_proto_rawDescGZIP(), []int{21}
+	return file_user_v1_user_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *RemoveSongFromPlaylistResponse) GetSuccess() bool {
@@ -1437,30 +1584,34 @@ func (x *RemoveSongFromPlaylistResponse) GetSongCount() int32 {
 	return 0
 }
 
-// GetPlaylistSongsRequest fetches songs in a playlist.
-type GetPlaylistSongsRequest struct {
+// AddSongsToPlaylistRequest adds songs in bulk.
+type AddSongsToPlaylistRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID (for authorization)
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	// Playlist ID
-	PlaylistId    string `protobuf:"bytes,1,opt,name=playlist_id,json=playlistId,proto3" json:"playlist_id,omitempty"`
+	PlaylistId string `protobuf:"bytes,2,opt,name=playlist_id,json=playlistId,proto3" json:"playlist_id,omitempty"`
+	// Songs to append (at most 500)
+	Songs         []*ImportedSong `protobuf:"bytes,3,rep,name=songs,proto3" json:"songs,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPlaylistSongsRequest) Reset() {
-	*x = GetPlaylistSongsRequest{}
-	mi := &file_user_v1_user_proto_msgTypes[22]
+func (x *AddSongsToPlaylistRequest) Reset() {
+	*x = AddSongsToPlaylistRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPlaylistSongsRequest) String() string {
+func (x *AddSongsToPlaylistRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPlaylistSongsRequest) ProtoMessage() {}
+func (*AddSongsToPlaylistRequest) ProtoMessage() {}
 
-func (x *GetPlaylistSongsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_v1_user_proto_msgTypes[22]
+func (x *AddSongsToPlaylistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1471,97 +1622,59 @@ func (x *GetPlaylistSongsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPlaylistSongsRequest.ProtoReflect.Descriptor instead.
-func (*GetPlaylistSongsRequest) Descriptor() ([]byte, []int) {
-	return file_user_v1_user_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use AddSongsToPlaylistRequest.ProtoReflect.Descriptor instead.
+func (*AddSongsToPlaylistRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *GetPlaylistSongsRequest) GetPlaylistId() string {
+func (x *AddSongsToPlaylistRequest) GetUserId() string {
 	if x != nil {
-		return x.PlaylistId
+		return x.UserId
 	}
 	return ""
 }
 
-// GetPlaylistSongsResponse contains playlist songs.
-type GetPlaylistSongsResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// List of songs (ordered by position)
-	Songs         []*PlaylistSong `protobuf:"bytes,1,rep,name=songs,proto3" json:"songs,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetPlaylistSongsResponse) Reset() {
-	*x = GetPlaylistSongsResponse{}
-	mi := &file_user_v1_user_proto_msgTypes[23]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetPlaylistSongsResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetPlaylistSongsResponse) ProtoMessage() {}
-
-func (x *GetPlaylistSongsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_v1_user_proto_msgTypes[23]
+func (x *AddSongsToPlaylistRequest) GetPlaylistId() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.PlaylistId
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetPlaylistSongsResponse.ProtoReflect.Descriptor instead.
-func (*GetPlaylistSongsResponse) Descriptor() ([]byte, []int) {
-	return file_user_v1_user_proto_rawDescGZIP(), []int{23}
+	return ""
 }
 
-func (x *GetPlaylistSongsResponse) GetSongs() []*PlaylistSong {
+func (x *AddSongsToPlaylistRequest) GetSongs() []*ImportedSong {
 	if x != nil {
 		return x.Songs
 	}
 	return nil
 }
 
-// Favorite represents a favorited item.
-type Favorite struct {
+// AddSongsToPlaylistResponse reports the result of a bulk add.
+type AddSongsToPlaylistResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Unique favorite ID
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	// User ID
-	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	// Type of content
-	Type FavoriteType `protobuf:"varint,3,opt,name=type,proto3,enum=user.v1.FavoriteType" json:"type,omitempty"`
-	// Target content ID
-	TargetId string `protobuf:"bytes,4,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
-	// Optional metadata
-	Metadata *FavoriteMetadata `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	// Creation timestamp
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Number of songs added
+	Added int32 `protobuf:"varint,1,opt,name=added,proto3" json:"added,omitempty"`
+	// Number of songs skipped because they were already in the playlist or duplicated
+	Skipped int32 `protobuf:"varint,2,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	// New song count in playlist
+	SongCount     int32 `protobuf:"varint,3,opt,name=song_count,json=songCount,proto3" json:"song_count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Favorite) Reset() {
-	*x = Favorite{}
+func (x *AddSongsToPlaylistResponse) Reset() {
+	*x = AddSongsToPlaylistResponse{}
 	mi := &file_user_v1_user_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Favorite) String() string {
+func (x *AddSongsToPlaylistResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Favorite) ProtoMessage() {}
+func (*AddSongsToPlaylistResponse) ProtoMessage() {}
 
-func (x *Favorite) ProtoReflect() protoreflect.Message {
+func (x *AddSongsToPlaylistResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_user_v1_user_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1573,9 +1686,5342 @@ func (x *Favorite) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
+// Deprecated: Use AddSongsToPlaylistResponse.ProtoReflect.Descriptor instead.
+func (*AddSongsToPlaylistResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AddSongsToPlaylistResponse) GetAdded() int32 {
+	if x != nil {
+		return x.Added
+	}
+	return 0
+}
+
+func (x *AddSongsToPlaylistResponse) GetSkipped() int32 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+func (x *AddSongsToPlaylistResponse) GetSongCount() int32 {
+	if x != nil {
+		return x.SongCount
+	}
+	return 0
+}
+
+// RemoveSongsFromPlaylistRequest removes songs in bulk.
+type RemoveSongsFromPlaylistRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID (for authorization)
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Playlist ID
+	PlaylistId string `protobuf:"bytes,2,opt,name=playlist_id,json=playlistId,proto3" json:"playlist_id,omitempty"`
+	// Song IDs to remove (at most 500)
+	SongIds       []string `protobuf:"bytes,3,rep,name=song_ids,json=songIds,proto3" json:"song_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveSongsFromPlaylistRequest) Reset() {
+	*x = RemoveSongsFromPlaylistRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveSongsFromPlaylistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveSongsFromPlaylistRequest) ProtoMessage() {}
+
+func (x *RemoveSongsFromPlaylistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveSongsFromPlaylistRequest.ProtoReflect.Descriptor instead.
+func (*RemoveSongsFromPlaylistRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RemoveSongsFromPlaylistRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RemoveSongsFromPlaylistRequest) GetPlaylistId() string {
+	if x != nil {
+		return x.PlaylistId
+	}
+	return ""
+}
+
+func (x *RemoveSongsFromPlaylistRequest) GetSongIds() []string {
+	if x != nil {
+		return x.SongIds
+	}
+	return nil
+}
+
+// RemoveSongsFromPlaylistResponse reports the result of a bulk removal.
+type RemoveSongsFromPlaylistResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of songs removed
+	Removed int32 `protobuf:"varint,1,opt,name=removed,proto3" json:"removed,omitempty"`
+	// New song count in playlist
+	SongCount     int32 `protobuf:"varint,2,opt,name=song_count,json=songCount,proto3" json:"song_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveSongsFromPlaylistResponse) Reset() {
+	*x = RemoveSongsFromPlaylistResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveSongsFromPlaylistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveSongsFromPlaylistResponse) ProtoMessage() {}
+
+func (x *RemoveSongsFromPlaylistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveSongsFromPlaylistResponse.ProtoReflect.Descriptor instead.
+func (*RemoveSongsFromPlaylistResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RemoveSongsFromPlaylistResponse) GetRemoved() int32 {
+	if x != nil {
+		return x.Removed
+	}
+	return 0
+}
+
+func (x *RemoveSongsFromPlaylistResponse) GetSongCount() int32 {
+	if x != nil {
+		return x.SongCount
+	}
+	return 0
+}
+
+// MovePlaylistSongRequest moves a song within a playlist.
+type MovePlaylistSongRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID (for authorization)
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Playlist ID
+	PlaylistId string `protobuf:"bytes,2,opt,name=playlist_id,json=playlistId,proto3" json:"playlist_id,omitempty"`
+	// Song ID to move
+	SongId string `protobuf:"bytes,3,opt,name=song_id,json=songId,proto3" json:"song_id,omitempty"`
+	// Target position (1-based; positions past the end move the song to the end)
+	Position      int32 `protobuf:"varint,4,opt,name=position,proto3" json:"position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MovePlaylistSongRequest) Reset() {
+	*x = MovePlaylistSongRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MovePlaylistSongRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MovePlaylistSongRequest) ProtoMessage() {}
+
+func (x *MovePlaylistSongRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MovePlaylistSongRequest.ProtoReflect.Descriptor instead.
+func (*MovePlaylistSongRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *MovePlaylistSongRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MovePlaylistSongRequest) GetPlaylistId() string {
+	if x != nil {
+		return x.PlaylistId
+	}
+	return ""
+}
+
+func (x *MovePlaylistSongRequest) GetSongId() string {
+	if x != nil {
+		return x.SongId
+	}
+	return ""
+}
+
+func (x *MovePlaylistSongRequest) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+// MovePlaylistSongResponse confirms the move.
+type MovePlaylistSongResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether the move was successful
+	Success       bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MovePlaylistSongResponse) Reset() {
+	*x = MovePlaylistSongResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MovePlaylistSongResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MovePlaylistSongResponse) ProtoMessage() {}
+
+func (x *MovePlaylistSongResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MovePlaylistSongResponse.ProtoReflect.Descriptor instead.
+func (*MovePlaylistSongResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *MovePlaylistSongResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// SortPlaylistSongsRequest reorders a playlist.
+type SortPlaylistSongsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID (for authorization)
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Playlist ID
+	PlaylistId string `protobuf:"bytes,2,opt,name=playlist_id,json=playlistId,proto3" json:"playlist_id,omitempty"`
+	// Sort field: "name", "singer" or "added_at"
+	SortBy string `protobuf:"bytes,3,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	// Sort descending instead of ascending
+	Descending    bool `protobuf:"varint,4,opt,name=descending,proto3" json:"descending,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SortPlaylistSongsRequest) Reset() {
+	*x = SortPlaylistSongsRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SortPlaylistSongsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SortPlaylistSongsRequest) ProtoMessage() {}
+
+func (x *SortPlaylistSongsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SortPlaylistSongsRequest.ProtoReflect.Descriptor instead.
+func (*SortPlaylistSongsRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SortPlaylistSongsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SortPlaylistSongsRequest) GetPlaylistId() string {
+	if x != nil {
+		return x.PlaylistId
+	}
+	return ""
+}
+
+func (x *SortPlaylistSongsRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *SortPlaylistSongsRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+// SortPlaylistSongsResponse confirms the sort.
+type SortPlaylistSongsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether the sort was successful
+	Success       bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SortPlaylistSongsResponse) Reset() {
+	*x = SortPlaylistSongsResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SortPlaylistSongsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SortPlaylistSongsResponse) ProtoMessage() {}
+
+func (x *SortPlaylistSongsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SortPlaylistSongsResponse.ProtoReflect.Descriptor instead.
+func (*SortPlaylistSongsResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *SortPlaylistSongsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// GetPlaylistRequest fetches a playlist.
+type GetPlaylistRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID of the caller, used for the access check
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Playlist ID
+	PlaylistId    string `protobuf:"bytes,2,opt,name=playlist_id,json=playlistId,proto3" json:"playlist_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlaylistRequest) Reset() {
+	*x = GetPlaylistRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlaylistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlaylistRequest) ProtoMessage() {}
+
+func (x *GetPlaylistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlaylistRequest.ProtoReflect.Descriptor instead.
+func (*GetPlaylistRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetPlaylistRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetPlaylistRequest) GetPlaylistId() string {
+	if x != nil {
+		return x.PlaylistId
+	}
+	return ""
+}
+
+// GetPlaylistResponse contains the playlist.
+type GetPlaylistResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The playlist
+	Playlist      *Playlist `protobuf:"bytes,1,opt,name=playlist,proto3" json:"playlist,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlaylistResponse) Reset() {
+	*x = GetPlaylistResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlaylistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlaylistResponse) ProtoMessage() {}
+
+func (x *GetPlaylistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlaylistResponse.ProtoReflect.Descriptor instead.
+func (*GetPlaylistResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetPlaylistResponse) GetPlaylist() *Playlist {
+	if x != nil {
+		return x.Playlist
+	}
+	return nil
+}
+
+// GetPlaylistSongsRequest fetches songs in a playlist.
+type GetPlaylistSongsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Playlist ID
+	PlaylistId string `protobuf:"bytes,1,opt,name=playlist_id,json=playlistId,proto3" json:"playlist_id,omitempty"`
+	// User ID of the caller, used for the access check (private playlists need owner or member)
+	UserId        string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlaylistSongsRequest) Reset() {
+	*x = GetPlaylistSongsRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlaylistSongsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlaylistSongsRequest) ProtoMessage() {}
+
+func (x *GetPlaylistSongsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlaylistSongsRequest.ProtoReflect.Descriptor instead.
+func (*GetPlaylistSongsRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetPlaylistSongsRequest) GetPlaylistId() string {
+	if x != nil {
+		return x.PlaylistId
+	}
+	return ""
+}
+
+func (x *GetPlaylistSongsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// GetPlaylistSongsResponse contains playlist songs.
+type GetPlaylistSongsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// List of songs (ordered by position)
+	Songs         []*PlaylistSong `protobuf:"bytes,1,rep,name=songs,proto3" json:"songs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlaylistSongsResponse) Reset() {
+	*x = GetPlaylistSongsResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlaylistSongsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlaylistSongsResponse) ProtoMessage() {}
+
+func (x *GetPlaylistSongsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlaylistSongsResponse.ProtoReflect.Descriptor instead.
+func (*GetPlaylistSongsResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetPlaylistSongsResponse) GetSongs() []*PlaylistSong {
+	if x != nil {
+		return x.Songs
+	}
+	return nil
+}
+
+// ListPlaylistMembersRequest lists the collaborators of a playlist.
+type ListPlaylistMembersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID of the caller
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Playlist ID
+	PlaylistId    string `protobuf:"bytes,2,opt,name=playlist_id,json=playlistId,proto3" json:"playlist_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPlaylistMembersRequest) Reset() {
+	*x = ListPlaylistMembersRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPlaylistMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPlaylistMembersRequest) ProtoMessage() {}
+
+func (x *ListPlaylistMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPlaylistMembersRequest.ProtoReflect.Descriptor instead.
+func (*ListPlaylistMembersRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListPlaylistMembersRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListPlaylistMembersRequest) GetPlaylistId() string {
+	if x != nil {
+		return x.PlaylistId
+	}
+	return ""
+}
+
+// ListPlaylistMembersResponse contains the owner followed by the members.
+type ListPlaylistMembersResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Collaborators, the owner first
+	Members       []*PlaylistMember `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPlaylistMembersResponse) Reset() {
+	*x = ListPlaylistMembersResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPlaylistMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPlaylistMembersResponse) ProtoMessage() {}
+
+func (x *ListPlaylistMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPlaylistMembersResponse.ProtoReflect.Descriptor instead.
+func (*ListPlaylistMembersResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListPlaylistMembersResponse) GetMembers() []*PlaylistMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+// InvitePlaylistMemberRequest adds a member or changes a member's role.
+type InvitePlaylistMemberRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID of the caller (must be the owner)
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Playlist ID
+	PlaylistId string `protobuf:"bytes,2,opt,name=playlist_id,json=playlistId,proto3" json:"playlist_id,omitempty"`
+	// User ID of the member to invite
+	MemberId string `protobuf:"bytes,3,opt,name=member_id,json=memberId,proto3" json:"member_id,omitempty"`
+	// Role to grant: "editor" or "viewer"
+	Role          string `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvitePlaylistMemberRequest) Reset() {
+	*x = InvitePlaylistMemberRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvitePlaylistMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvitePlaylistMemberRequest) ProtoMessage() {}
+
+func (x *InvitePlaylistMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvitePlaylistMemberRequest.ProtoReflect.Descriptor instead.
+func (*InvitePlaylistMemberRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *InvitePlaylistMemberRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *InvitePlaylistMemberRequest) GetPlaylistId() string {
+	if x != nil {
+		return x.PlaylistId
+	}
+	return ""
+}
+
+func (x *InvitePlaylistMemberRequest) GetMemberId() string {
+	if x != nil {
+		return x.MemberId
+	}
+	return ""
+}
+
+func (x *InvitePlaylistMemberRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+// InvitePlaylistMemberResponse contains the member.
+type InvitePlaylistMemberResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The added or updated member
+	Member        *PlaylistMember `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvitePlaylistMemberResponse) Reset() {
+	*x = InvitePlaylistMemberResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvitePlaylistMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvitePlaylistMemberResponse) ProtoMessage() {}
+
+func (x *InvitePlaylistMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvitePlaylistMemberResponse.ProtoReflect.Descriptor instead.
+func (*InvitePlaylistMemberResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *InvitePlaylistMemberResponse) GetMember() *PlaylistMember {
+	if x != nil {
+		return x.Member
+	}
+	return nil
+}
+
+// RemovePlaylistMemberRequest removes a member from a playlist.
+type RemovePlaylistMemberRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID of the caller (the owner, or the member leaving)
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Playlist ID
+	PlaylistId string `protobuf:"bytes,2,opt,name=playlist_id,json=playlistId,proto3" json:"playlist_id,omitempty"`
+	// User ID of the member to remove
+	MemberId      string `protobuf:"bytes,3,opt,name=member_id,json=memberId,proto3" json:"member_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemovePlaylistMemberRequest) Reset() {
+	*x = RemovePlaylistMemberRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemovePlaylistMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemovePlaylistMemberRequest) ProtoMessage() {}
+
+func (x *RemovePlaylistMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemovePlaylistMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemovePlaylistMemberRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *RemovePlaylistMemberRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RemovePlaylistMemberRequest) GetPlaylistId() string {
+	if x != nil {
+		return x.PlaylistId
+	}
+	return ""
+}
+
+func (x *RemovePlaylistMemberRequest) GetMemberId() string {
+	if x != nil {
+		return x.MemberId
+	}
+	return ""
+}
+
+// RemovePlaylistMemberResponse indicates success.
+type RemovePlaylistMemberResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether the member was removed
+	Success       bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemovePlaylistMemberResponse) Reset() {
+	*x = RemovePlaylistMemberResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemovePlaylistMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemovePlaylistMemberResponse) ProtoMessage() {}
+
+func (x *RemovePlaylistMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemovePlaylistMemberResponse.ProtoReflect.Descriptor instead.
+func (*RemovePlaylistMemberResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *RemovePlaylistMemberResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// CreatePlaylistInviteRequest creates a share link.
+type CreatePlaylistInviteRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID of the caller (must be the owner)
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Playlist ID
+	PlaylistId string `protobuf:"bytes,2,opt,name=playlist_id,json=playlistId,proto3" json:"playlist_id,omitempty"`
+	// Role granted to whoever accepts: "editor" or "viewer"
+	Role          string `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePlaylistInviteRequest) Reset() {
+	*x = CreatePlaylistInviteRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePlaylistInviteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePlaylistInviteRequest) ProtoMessage() {}
+
+func (x *CreatePlaylistInviteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePlaylistInviteRequest.ProtoReflect.Descriptor instead.
+func (*CreatePlaylistInviteRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *CreatePlaylistInviteRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreatePlaylistInviteRequest) GetPlaylistId() string {
+	if x != nil {
+		return x.PlaylistId
+	}
+	return ""
+}
+
+func (x *CreatePlaylistInviteRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+// CreatePlaylistInviteResponse contains the share link.
+type CreatePlaylistInviteResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The created invite
+	Invite        *PlaylistInvite `protobuf:"bytes,1,opt,name=invite,proto3" json:"invite,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePlaylistInviteResponse) Reset() {
+	*x = CreatePlaylistInviteResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePlaylistInviteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePlaylistInviteResponse) ProtoMessage() {}
+
+func (x *CreatePlaylistInviteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePlaylistInviteResponse.ProtoReflect.Descriptor instead.
+func (*CreatePlaylistInviteResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *CreatePlaylistInviteResponse) GetInvite() *PlaylistInvite {
+	if x != nil {
+		return x.Invite
+	}
+	return nil
+}
+
+// RevokePlaylistInvitesRequest revokes all share links of a playlist.
+type RevokePlaylistInvitesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID of the caller (must be the owner)
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Playlist ID
+	PlaylistId    string `protobuf:"bytes,2,opt,name=playlist_id,json=playlistId,proto3" json:"playlist_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokePlaylistInvitesRequest) Reset() {
+	*x = RevokePlaylistInvitesRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokePlaylistInvitesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokePlaylistInvitesRequest) ProtoMessage() {}
+
+func (x *RevokePlaylistInvitesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokePlaylistInvitesRequest.ProtoReflect.Descriptor instead.
+func (*RevokePlaylistInvitesRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *RevokePlaylistInvitesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RevokePlaylistInvitesRequest) GetPlaylistId() string {
+	if x != nil {
+		return x.PlaylistId
+	}
+	return ""
+}
+
+// RevokePlaylistInvitesResponse contains the number of revoked links.
+type RevokePlaylistInvitesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of revoked links
+	Revoked       int64 `protobuf:"varint,1,opt,name=revoked,proto3" json:"revoked,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokePlaylistInvitesResponse) Reset() {
+	*x = RevokePlaylistInvitesResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokePlaylistInvitesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokePlaylistInvitesResponse) ProtoMessage() {}
+
+func (x *RevokePlaylistInvitesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokePlaylistInvitesResponse.ProtoReflect.Descriptor instead.
+func (*RevokePlaylistInvitesResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *RevokePlaylistInvitesResponse) GetRevoked() int64 {
+	if x != nil {
+		return x.Revoked
+	}
+	return 0
+}
+
+// AcceptPlaylistInviteRequest joins a playlist through a share link.
+type AcceptPlaylistInviteRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID of the caller
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Share link token
+	Token         string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptPlaylistInviteRequest) Reset() {
+	*x = AcceptPlaylistInviteRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptPlaylistInviteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptPlaylistInviteRequest) ProtoMessage() {}
+
+func (x *AcceptPlaylistInviteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptPlaylistInviteRequest.ProtoReflect.Descriptor instead.
+func (*AcceptPlaylistInviteRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *AcceptPlaylistInviteRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AcceptPlaylistInviteRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// AcceptPlaylistInviteResponse contains the joined playlist.
+type AcceptPlaylistInviteResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The joined playlist
+	Playlist      *Playlist `protobuf:"bytes,1,opt,name=playlist,proto3" json:"playlist,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptPlaylistInviteResponse) Reset() {
+	*x = AcceptPlaylistInviteResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptPlaylistInviteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptPlaylistInviteResponse) ProtoMessage() {}
+
+func (x *AcceptPlaylistInviteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptPlaylistInviteResponse.ProtoReflect.Descriptor instead.
+func (*AcceptPlaylistInviteResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *AcceptPlaylistInviteResponse) GetPlaylist() *Playlist {
+	if x != nil {
+		return x.Playlist
+	}
+	return nil
+}
+
+// ListSharedPlaylistsRequest lists the playlists shared with a user.
+type ListSharedPlaylistsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Page number (1-based)
+	Page int32 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	// Page size (max 100)
+	PageSize      int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSharedPlaylistsRequest) Reset() {
+	*x = ListSharedPlaylistsRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSharedPlaylistsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSharedPlaylistsRequest) ProtoMessage() {}
+
+func (x *ListSharedPlaylistsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSharedPlaylistsRequest.ProtoReflect.Descriptor instead.
+func (*ListSharedPlaylistsRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ListSharedPlaylistsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListSharedPlaylistsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListSharedPlaylistsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// ListSharedPlaylistsResponse contains a page of shared playlists.
+type ListSharedPlaylistsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Shared playlists, most recently joined first
+	Playlists     []*Playlist `protobuf:"bytes,1,rep,name=playlists,proto3" json:"playlists,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSharedPlaylistsResponse) Reset() {
+	*x = ListSharedPlaylistsResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSharedPlaylistsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSharedPlaylistsResponse) ProtoMessage() {}
+
+func (x *ListSharedPlaylistsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSharedPlaylistsResponse.ProtoReflect.Descriptor instead.
+func (*ListSharedPlaylistsResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ListSharedPlaylistsResponse) GetPlaylists() []*Playlist {
+	if x != nil {
+		return x.Playlists
+	}
+	return nil
+}
+
+// ImportedSong is a song to add to an imported playlist.
+type ImportedSong struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Catalogue song ID
+	SongId string `protobuf:"bytes,1,opt,name=song_id,json=songId,proto3" json:"song_id,omitempty"`
+	// Song name
+	SongName string `protobuf:"bytes,2,opt,name=song_name,json=songName,proto3" json:"song_name,omitempty"`
+	// Singer name
+	SingerName    string `protobuf:"bytes,3,opt,name=singer_name,json=singerName,proto3" json:"singer_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportedSong) Reset() {
+	*x = ImportedSong{}
+	mi := &file_user_v1_user_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportedSong) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportedSong) ProtoMessage() {}
+
+func (x *ImportedSong) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportedSong.ProtoReflect.Descriptor instead.
+func (*ImportedSong) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ImportedSong) GetSongId() string {
+	if x != nil {
+		return x.SongId
+	}
+	return ""
+}
+
+func (x *ImportedSong) GetSongName() string {
+	if x != nil {
+		return x.SongName
+	}
+	return ""
+}
+
+func (x *ImportedSong) GetSingerName() string {
+	if x != nil {
+		return x.SingerName
+	}
+	return ""
+}
+
+// ImportPlaylistRequest creates a playlist from an external playlist.
+type ImportPlaylistRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Playlist name
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Playlist description (truncated to 500 bytes)
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Cover image URL
+	CoverUrl string `protobuf:"bytes,4,opt,name=cover_url,json=coverUrl,proto3" json:"cover_url,omitempty"`
+	// Whether the playlist is public
+	IsPublic bool `protobuf:"varint,5,opt,name=is_public,json=isPublic,proto3" json:"is_public,omitempty"`
+	// Songs in playlist order (at most 1000)
+	Songs         []*ImportedSong `protobuf:"bytes,6,rep,name=songs,proto3" json:"songs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportPlaylistRequest) Reset() {
+	*x = ImportPlaylistRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportPlaylistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportPlaylistRequest) ProtoMessage() {}
+
+func (x *ImportPlaylistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportPlaylistRequest.ProtoReflect.Descriptor instead.
+func (*ImportPlaylistRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ImportPlaylistRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ImportPlaylistRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ImportPlaylistRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ImportPlaylistRequest) GetCoverUrl() string {
+	if x != nil {
+		return x.CoverUrl
+	}
+	return ""
+}
+
+func (x *ImportPlaylistRequest) GetIsPublic() bool {
+	if x != nil {
+		return x.IsPublic
+	}
+	return false
+}
+
+func (x *ImportPlaylistRequest) GetSongs() []*ImportedSong {
+	if x != nil {
+		return x.Songs
+	}
+	return nil
+}
+
+// ImportPlaylistResponse contains the created playlist.
+type ImportPlaylistResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The created playlist
+	Playlist *Playlist `protobuf:"bytes,1,opt,name=playlist,proto3" json:"playlist,omitempty"`
+	// Number of songs imported
+	Imported int32 `protobuf:"varint,2,opt,name=imported,proto3" json:"imported,omitempty"`
+	// Number of duplicate songs skipped
+	Duplicates    int32 `protobuf:"varint,3,opt,name=duplicates,proto3" json:"duplicates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportPlaylistResponse) Reset() {
+	*x = ImportPlaylistResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportPlaylistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportPlaylistResponse) ProtoMessage() {}
+
+func (x *ImportPlaylistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportPlaylistResponse.ProtoReflect.Descriptor instead.
+func (*ImportPlaylistResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ImportPlaylistResponse) GetPlaylist() *Playlist {
+	if x != nil {
+		return x.Playlist
+	}
+	return nil
+}
+
+func (x *ImportPlaylistResponse) GetImported() int32 {
+	if x != nil {
+		return x.Imported
+	}
+	return 0
+}
+
+func (x *ImportPlaylistResponse) GetDuplicates() int32 {
+	if x != nil {
+		return x.Duplicates
+	}
+	return 0
+}
+
+// ExportPlaylistRequest specifies which playlist to export and how.
+type ExportPlaylistRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID (for authorization)
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Playlist ID to export
+	PlaylistId string `protobuf:"bytes,2,opt,name=playlist_id,json=playlistId,proto3" json:"playlist_id,omitempty"`
+	// File format: "m3u8", "xspf" or "json"
+	Format        string `protobuf:"bytes,3,opt,name=format,proto3" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportPlaylistRequest) Reset() {
+	*x = ExportPlaylistRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportPlaylistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportPlaylistRequest) ProtoMessage() {}
+
+func (x *ExportPlaylistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportPlaylistRequest.ProtoReflect.Descriptor instead.
+func (*ExportPlaylistRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ExportPlaylistRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ExportPlaylistRequest) GetPlaylistId() string {
+	if x != nil {
+		return x.PlaylistId
+	}
+	return ""
+}
+
+func (x *ExportPlaylistRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+// ExportPlaylistResponse contains the rendered file.
+type ExportPlaylistResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Suggested file name, e.g. "My Playlist.m3u8"
+	Filename string `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	// MIME type of the content
+	ContentType string `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// File content
+	Content       []byte `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportPlaylistResponse) Reset() {
+	*x = ExportPlaylistResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportPlaylistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportPlaylistResponse) ProtoMessage() {}
+
+func (x *ExportPlaylistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportPlaylistResponse.ProtoReflect.Descriptor instead.
+func (*ExportPlaylistResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ExportPlaylistResponse) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *ExportPlaylistResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ExportPlaylistResponse) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+// ListPublicPlaylistsRequest pages through public playlists.
+type ListPublicPlaylistsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Sort order: "popular" (by follower count, default) or "recent" (by last update)
+	Sort string `protobuf:"bytes,1,opt,name=sort,proto3" json:"sort,omitempty"`
+	// Page number (1-based)
+	Page int32 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	// Page size (max 100)
+	PageSize      int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPublicPlaylistsRequest) Reset() {
+	*x = ListPublicPlaylistsRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPublicPlaylistsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPublicPlaylistsRequest) ProtoMessage() {}
+
+func (x *ListPublicPlaylistsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPublicPlaylistsRequest.ProtoReflect.Descriptor instead.
+func (*ListPublicPlaylistsRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ListPublicPlaylistsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListPublicPlaylistsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListPublicPlaylistsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// ListPublicPlaylistsResponse contains a page of public playlists.
+type ListPublicPlaylistsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Public playlists
+	Playlists []*Playlist `protobuf:"bytes,1,rep,name=playlists,proto3" json:"playlists,omitempty"`
+	// Total number of public playlists
+	Total         int64 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPublicPlaylistsResponse) Reset() {
+	*x = ListPublicPlaylistsResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPublicPlaylistsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPublicPlaylistsResponse) ProtoMessage() {}
+
+func (x *ListPublicPlaylistsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPublicPlaylistsResponse.ProtoReflect.Descriptor instead.
+func (*ListPublicPlaylistsResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ListPublicPlaylistsResponse) GetPlaylists() []*Playlist {
+	if x != nil {
+		return x.Playlists
+	}
+	return nil
+}
+
+func (x *ListPublicPlaylistsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// GetPublicPlaylistRequest fetches a public playlist.
+type GetPublicPlaylistRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Calling user ID (optional, used for is_following)
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Playlist ID
+	PlaylistId    string `protobuf:"bytes,2,opt,name=playlist_id,json=playlistId,proto3" json:"playlist_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPublicPlaylistRequest) Reset() {
+	*x = GetPublicPlaylistRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPublicPlaylistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPublicPlaylistRequest) ProtoMessage() {}
+
+func (x *GetPublicPlaylistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPublicPlaylistRequest.ProtoReflect.Descriptor instead.
+func (*GetPublicPlaylistRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *GetPublicPlaylistRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetPublicPlaylistRequest) GetPlaylistId() string {
+	if x != nil {
+		return x.PlaylistId
+	}
+	return ""
+}
+
+// GetPublicPlaylistResponse contains the playlist and its songs.
+type GetPublicPlaylistResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Playlist metadata
+	Playlist *Playlist `protobuf:"bytes,1,opt,name=playlist,proto3" json:"playlist,omitempty"`
+	// Songs (ordered by position)
+	Songs []*PlaylistSong `protobuf:"bytes,2,rep,name=songs,proto3" json:"songs,omitempty"`
+	// Whether the calling user follows the playlist
+	IsFollowing   bool `protobuf:"varint,3,opt,name=is_following,json=isFollowing,proto3" json:"is_following,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPublicPlaylistResponse) Reset() {
+	*x = GetPublicPlaylistResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPublicPlaylistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPublicPlaylistResponse) ProtoMessage() {}
+
+func (x *GetPublicPlaylistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPublicPlaylistResponse.ProtoReflect.Descriptor instead.
+func (*GetPublicPlaylistResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *GetPublicPlaylistResponse) GetPlaylist() *Playlist {
+	if x != nil {
+		return x.Playlist
+	}
+	return nil
+}
+
+func (x *GetPublicPlaylistResponse) GetSongs() []*PlaylistSong {
+	if x != nil {
+		return x.Songs
+	}
+	return nil
+}
+
+func (x *GetPublicPlaylistResponse) GetIsFollowing() bool {
+	if x != nil {
+		return x.IsFollowing
+	}
+	return false
+}
+
+// FollowPlaylistRequest follows a public playlist.
+type FollowPlaylistRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Playlist ID
+	PlaylistId    string `protobuf:"bytes,2,opt,name=playlist_id,json=playlistId,proto3" json:"playlist_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FollowPlaylistRequest) Reset() {
+	*x = FollowPlaylistRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FollowPlaylistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FollowPlaylistRequest) ProtoMessage() {}
+
+func (x *FollowPlaylistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FollowPlaylistRequest.ProtoReflect.Descriptor instead.
+func (*FollowPlaylistRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *FollowPlaylistRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *FollowPlaylistRequest) GetPlaylistId() string {
+	if x != nil {
+		return x.PlaylistId
+	}
+	return ""
+}
+
+// FollowPlaylistResponse contains the followed playlist.
+type FollowPlaylistResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The playlist with its updated follower count
+	Playlist      *Playlist `protobuf:"bytes,1,opt,name=playlist,proto3" json:"playlist,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FollowPlaylistResponse) Reset() {
+	*x = FollowPlaylistResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FollowPlaylistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FollowPlaylistResponse) ProtoMessage() {}
+
+func (x *FollowPlaylistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FollowPlaylistResponse.ProtoReflect.Descriptor instead.
+func (*FollowPlaylistResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *FollowPlaylistResponse) GetPlaylist() *Playlist {
+	if x != nil {
+		return x.Playlist
+	}
+	return nil
+}
+
+// UnfollowPlaylistRequest stops following a playlist.
+type UnfollowPlaylistRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Playlist ID
+	PlaylistId    string `protobuf:"bytes,2,opt,name=playlist_id,json=playlistId,proto3" json:"playlist_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnfollowPlaylistRequest) Reset() {
+	*x = UnfollowPlaylistRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnfollowPlaylistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnfollowPlaylistRequest) ProtoMessage() {}
+
+func (x *UnfollowPlaylistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnfollowPlaylistRequest.ProtoReflect.Descriptor instead.
+func (*UnfollowPlaylistRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *UnfollowPlaylistRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UnfollowPlaylistRequest) GetPlaylistId() string {
+	if x != nil {
+		return x.PlaylistId
+	}
+	return ""
+}
+
+// UnfollowPlaylistResponse confirms the unfollow.
+type UnfollowPlaylistResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether the unfollow was successful
+	Success       bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnfollowPlaylistResponse) Reset() {
+	*x = UnfollowPlaylistResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnfollowPlaylistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnfollowPlaylistResponse) ProtoMessage() {}
+
+func (x *UnfollowPlaylistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnfollowPlaylistResponse.ProtoReflect.Descriptor instead.
+func (*UnfollowPlaylistResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *UnfollowPlaylistResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// ListFollowedPlaylistsRequest pages through followed playlists.
+type ListFollowedPlaylistsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Page number (1-based)
+	Page int32 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	// Page size (max 100)
+	PageSize      int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFollowedPlaylistsRequest) Reset() {
+	*x = ListFollowedPlaylistsRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFollowedPlaylistsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFollowedPlaylistsRequest) ProtoMessage() {}
+
+func (x *ListFollowedPlaylistsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFollowedPlaylistsRequest.ProtoReflect.Descriptor instead.
+func (*ListFollowedPlaylistsRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ListFollowedPlaylistsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListFollowedPlaylistsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListFollowedPlaylistsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// ListFollowedPlaylistsResponse contains a page of followed playlists.
+type ListFollowedPlaylistsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Followed playlists
+	Playlists []*Playlist `protobuf:"bytes,1,rep,name=playlists,proto3" json:"playlists,omitempty"`
+	// Total number of followed playlists
+	Total         int64 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFollowedPlaylistsResponse) Reset() {
+	*x = ListFollowedPlaylistsResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFollowedPlaylistsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFollowedPlaylistsResponse) ProtoMessage() {}
+
+func (x *ListFollowedPlaylistsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFollowedPlaylistsResponse.ProtoReflect.Descriptor instead.
+func (*ListFollowedPlaylistsResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ListFollowedPlaylistsResponse) GetPlaylists() []*Playlist {
+	if x != nil {
+		return x.Playlists
+	}
+	return nil
+}
+
+func (x *ListFollowedPlaylistsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// ForkPlaylistRequest copies a playlist.
+type ForkPlaylistRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID of the new owner
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Playlist ID to copy
+	PlaylistId string `protobuf:"bytes,2,opt,name=playlist_id,json=playlistId,proto3" json:"playlist_id,omitempty"`
+	// Name of the copy (defaults to the source playlist name)
+	Name          string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForkPlaylistRequest) Reset() {
+	*x = ForkPlaylistRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForkPlaylistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForkPlaylistRequest) ProtoMessage() {}
+
+func (x *ForkPlaylistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForkPlaylistRequest.ProtoReflect.Descriptor instead.
+func (*ForkPlaylistRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ForkPlaylistRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ForkPlaylistRequest) GetPlaylistId() string {
+	if x != nil {
+		return x.PlaylistId
+	}
+	return ""
+}
+
+func (x *ForkPlaylistRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// ForkPlaylistResponse contains the created copy.
+type ForkPlaylistResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The new private playlist
+	Playlist      *Playlist `protobuf:"bytes,1,opt,name=playlist,proto3" json:"playlist,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForkPlaylistResponse) Reset() {
+	*x = ForkPlaylistResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForkPlaylistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForkPlaylistResponse) ProtoMessage() {}
+
+func (x *ForkPlaylistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForkPlaylistResponse.ProtoReflect.Descriptor instead.
+func (*ForkPlaylistResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ForkPlaylistResponse) GetPlaylist() *Playlist {
+	if x != nil {
+		return x.Playlist
+	}
+	return nil
+}
+
+// ListDeletedPlaylistsRequest pages through the playlist trash.
+type ListDeletedPlaylistsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Page number (1-based)
+	Page int32 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	// Page size (max 100)
+	PageSize      int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedPlaylistsRequest) Reset() {
+	*x = ListDeletedPlaylistsRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedPlaylistsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedPlaylistsRequest) ProtoMessage() {}
+
+func (x *ListDeletedPlaylistsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedPlaylistsRequest.ProtoReflect.Descriptor instead.
+func (*ListDeletedPlaylistsRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ListDeletedPlaylistsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListDeletedPlaylistsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListDeletedPlaylistsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// ListDeletedPlaylistsResponse contains a page of deleted playlists.
+type ListDeletedPlaylistsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deleted playlists
+	Playlists []*DeletedPlaylist `protobuf:"bytes,1,rep,name=playlists,proto3" json:"playlists,omitempty"`
+	// Total number of restorable playlists
+	Total int64 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	// Days a deleted item stays in the trash before it is purged
+	RetentionDays int32 `protobuf:"varint,3,opt,name=retention_days,json=retentionDays,proto3" json:"retention_days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedPlaylistsResponse) Reset() {
+	*x = ListDeletedPlaylistsResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedPlaylistsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedPlaylistsResponse) ProtoMessage() {}
+
+func (x *ListDeletedPlaylistsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedPlaylistsResponse.ProtoReflect.Descriptor instead.
+func (*ListDeletedPlaylistsResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ListDeletedPlaylistsResponse) GetPlaylists() []*DeletedPlaylist {
+	if x != nil {
+		return x.Playlists
+	}
+	return nil
+}
+
+func (x *ListDeletedPlaylistsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListDeletedPlaylistsResponse) GetRetentionDays() int32 {
+	if x != nil {
+		return x.RetentionDays
+	}
+	return 0
+}
+
+// DeletedPlaylist is a playlist in the trash.
+type DeletedPlaylist struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Playlist metadata
+	Playlist *Playlist `protobuf:"bytes,1,opt,name=playlist,proto3" json:"playlist,omitempty"`
+	// Deletion timestamp
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// Time after which the playlist is permanently deleted
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletedPlaylist) Reset() {
+	*x = DeletedPlaylist{}
+	mi := &file_user_v1_user_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletedPlaylist) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletedPlaylist) ProtoMessage() {}
+
+func (x *DeletedPlaylist) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletedPlaylist.ProtoReflect.Descriptor instead.
+func (*DeletedPlaylist) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *DeletedPlaylist) GetPlaylist() *Playlist {
+	if x != nil {
+		return x.Playlist
+	}
+	return nil
+}
+
+func (x *DeletedPlaylist) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *DeletedPlaylist) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// ListDeletedFavoritesRequest pages through the favorite trash.
+type ListDeletedFavoritesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Page number (1-based)
+	Page int32 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	// Page size (max 100)
+	PageSize      int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedFavoritesRequest) Reset() {
+	*x = ListDeletedFavoritesRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedFavoritesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedFavoritesRequest) ProtoMessage() {}
+
+func (x *ListDeletedFavoritesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedFavoritesRequest.ProtoReflect.Descriptor instead.
+func (*ListDeletedFavoritesRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *ListDeletedFavoritesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListDeletedFavoritesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListDeletedFavoritesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// ListDeletedFavoritesResponse contains a page of deleted favorites.
+type ListDeletedFavoritesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deleted favorites
+	Favorites []*DeletedFavorite `protobuf:"bytes,1,rep,name=favorites,proto3" json:"favorites,omitempty"`
+	// Total number of restorable favorites
+	Total int64 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	// Days a deleted item stays in the trash before it is purged
+	RetentionDays int32 `protobuf:"varint,3,opt,name=retention_days,json=retentionDays,proto3" json:"retention_days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedFavoritesResponse) Reset() {
+	*x = ListDeletedFavoritesResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedFavoritesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedFavoritesResponse) ProtoMessage() {}
+
+func (x *ListDeletedFavoritesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedFavoritesResponse.ProtoReflect.Descriptor instead.
+func (*ListDeletedFavoritesResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *ListDeletedFavoritesResponse) GetFavorites() []*DeletedFavorite {
+	if x != nil {
+		return x.Favorites
+	}
+	return nil
+}
+
+func (x *ListDeletedFavoritesResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListDeletedFavoritesResponse) GetRetentionDays() int32 {
+	if x != nil {
+		return x.RetentionDays
+	}
+	return 0
+}
+
+// DeletedFavorite is a favorite in the trash.
+type DeletedFavorite struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Favorite metadata
+	Favorite *Favorite `protobuf:"bytes,1,opt,name=favorite,proto3" json:"favorite,omitempty"`
+	// Deletion timestamp
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// Time after which the favorite is permanently deleted
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletedFavorite) Reset() {
+	*x = DeletedFavorite{}
+	mi := &file_user_v1_user_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletedFavorite) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletedFavorite) ProtoMessage() {}
+
+func (x *DeletedFavorite) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletedFavorite.ProtoReflect.Descriptor instead.
+func (*DeletedFavorite) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *DeletedFavorite) GetFavorite() *Favorite {
+	if x != nil {
+		return x.Favorite
+	}
+	return nil
+}
+
+func (x *DeletedFavorite) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *DeletedFavorite) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// RestorePlaylistRequest restores a deleted playlist.
+type RestorePlaylistRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID (must be the owner)
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Playlist ID
+	PlaylistId    string `protobuf:"bytes,2,opt,name=playlist_id,json=playlistId,proto3" json:"playlist_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestorePlaylistRequest) Reset() {
+	*x = RestorePlaylistRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestorePlaylistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestorePlaylistRequest) ProtoMessage() {}
+
+func (x *RestorePlaylistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestorePlaylistRequest.ProtoReflect.Descriptor instead.
+func (*RestorePlaylistRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *RestorePlaylistRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RestorePlaylistRequest) GetPlaylistId() string {
+	if x != nil {
+		return x.PlaylistId
+	}
+	return ""
+}
+
+// RestorePlaylistResponse contains the restored playlist.
+type RestorePlaylistResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The restored playlist
+	Playlist      *Playlist `protobuf:"bytes,1,opt,name=playlist,proto3" json:"playlist,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestorePlaylistResponse) Reset() {
+	*x = RestorePlaylistResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestorePlaylistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestorePlaylistResponse) ProtoMessage() {}
+
+func (x *RestorePlaylistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestorePlaylistResponse.ProtoReflect.Descriptor instead.
+func (*RestorePlaylistResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *RestorePlaylistResponse) GetPlaylist() *Playlist {
+	if x != nil {
+		return x.Playlist
+	}
+	return nil
+}
+
+// RestoreFavoriteRequest restores a deleted favorite.
+type RestoreFavoriteRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Favorite ID
+	FavoriteId    string `protobuf:"bytes,2,opt,name=favorite_id,json=favoriteId,proto3" json:"favorite_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreFavoriteRequest) Reset() {
+	*x = RestoreFavoriteRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreFavoriteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreFavoriteRequest) ProtoMessage() {}
+
+func (x *RestoreFavoriteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreFavoriteRequest.ProtoReflect.Descriptor instead.
+func (*RestoreFavoriteRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *RestoreFavoriteRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RestoreFavoriteRequest) GetFavoriteId() string {
+	if x != nil {
+		return x.FavoriteId
+	}
+	return ""
+}
+
+// RestoreFavoriteResponse contains the restored favorite.
+type RestoreFavoriteResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The restored favorite
+	Favorite      *Favorite `protobuf:"bytes,1,opt,name=favorite,proto3" json:"favorite,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreFavoriteResponse) Reset() {
+	*x = RestoreFavoriteResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreFavoriteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreFavoriteResponse) ProtoMessage() {}
+
+func (x *RestoreFavoriteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreFavoriteResponse.ProtoReflect.Descriptor instead.
+func (*RestoreFavoriteResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *RestoreFavoriteResponse) GetFavorite() *Favorite {
+	if x != nil {
+		return x.Favorite
+	}
+	return nil
+}
+
+// ExportUserDataRequest specifies whose data to export.
+type ExportUserDataRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID
+	UserId        string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataRequest) Reset() {
+	*x = ExportUserDataRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataRequest) ProtoMessage() {}
+
+func (x *ExportUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *ExportUserDataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// ExportUserDataResponse contains all user content.
+//
+// Recommendations are not exported: they are derived from the data below and
+// are regenerated from it.
+type ExportUserDataResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// All favorites
+	Favorites []*Favorite `protobuf:"bytes,1,rep,name=favorites,proto3" json:"favorites,omitempty"`
+	// All playlists with their songs
+	Playlists []*PlaylistExport `protobuf:"bytes,2,rep,name=playlists,proto3" json:"playlists,omitempty"`
+	// Play history (newest first)
+	History []*PlayHistory `protobuf:"bytes,3,rep,name=history,proto3" json:"history,omitempty"`
+	// Per-day listening totals (only days still within the daily detail retention)
+	DailyListening []*DailyListening `protobuf:"bytes,4,rep,name=daily_listening,json=dailyListening,proto3" json:"daily_listening,omitempty"`
+	// Per-month listening totals
+	MonthlyListening []*MonthlyListening `protobuf:"bytes,5,rep,name=monthly_listening,json=monthlyListening,proto3" json:"monthly_listening,omitempty"`
+	// Play queue, unset if the user never saved one
+	PlayQueue *PlayQueue `protobuf:"bytes,6,opt,name=play_queue,json=playQueue,proto3" json:"play_queue,omitempty"`
+	// Playback positions of partially played tracks
+	PlaybackPositions []*PlaybackPosition `protobuf:"bytes,7,rep,name=playback_positions,json=playbackPositions,proto3" json:"playback_positions,omitempty"`
+	// Memberships in collaborative playlists, including the user's own playlists
+	PlaylistMemberships []*PlaylistMember `protobuf:"bytes,8,rep,name=playlist_memberships,json=playlistMemberships,proto3" json:"playlist_memberships,omitempty"`
+	// Public playlists the user follows
+	FollowedPlaylists []*Playlist `protobuf:"bytes,9,rep,name=followed_playlists,json=followedPlaylists,proto3" json:"followed_playlists,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ExportUserDataResponse) Reset() {
+	*x = ExportUserDataResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataResponse) ProtoMessage() {}
+
+func (x *ExportUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataResponse.ProtoReflect.Descriptor instead.
+func (*ExportUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *ExportUserDataResponse) GetFavorites() []*Favorite {
+	if x != nil {
+		return x.Favorites
+	}
+	return nil
+}
+
+func (x *ExportUserDataResponse) GetPlaylists() []*PlaylistExport {
+	if x != nil {
+		return x.Playlists
+	}
+	return nil
+}
+
+func (x *ExportUserDataResponse) GetHistory() []*PlayHistory {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *ExportUserDataResponse) GetDailyListening() []*DailyListening {
+	if x != nil {
+		return x.DailyListening
+	}
+	return nil
+}
+
+func (x *ExportUserDataResponse) GetMonthlyListening() []*MonthlyListening {
+	if x != nil {
+		return x.MonthlyListening
+	}
+	return nil
+}
+
+func (x *ExportUserDataResponse) GetPlayQueue() *PlayQueue {
+	if x != nil {
+		return x.PlayQueue
+	}
+	return nil
+}
+
+func (x *ExportUserDataResponse) GetPlaybackPositions() []*PlaybackPosition {
+	if x != nil {
+		return x.PlaybackPositions
+	}
+	return nil
+}
+
+func (x *ExportUserDataResponse) GetPlaylistMemberships() []*PlaylistMember {
+	if x != nil {
+		return x.PlaylistMemberships
+	}
+	return nil
+}
+
+func (x *ExportUserDataResponse) GetFollowedPlaylists() []*Playlist {
+	if x != nil {
+		return x.FollowedPlaylists
+	}
+	return nil
+}
+
+// PlaylistExport is a playlist together with its songs.
+type PlaylistExport struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Playlist metadata
+	Playlist *Playlist `protobuf:"bytes,1,opt,name=playlist,proto3" json:"playlist,omitempty"`
+	// Songs (ordered by position)
+	Songs         []*PlaylistSong `protobuf:"bytes,2,rep,name=songs,proto3" json:"songs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaylistExport) Reset() {
+	*x = PlaylistExport{}
+	mi := &file_user_v1_user_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaylistExport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaylistExport) ProtoMessage() {}
+
+func (x *PlaylistExport) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaylistExport.ProtoReflect.Descriptor instead.
+func (*PlaylistExport) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *PlaylistExport) GetPlaylist() *Playlist {
+	if x != nil {
+		return x.Playlist
+	}
+	return nil
+}
+
+func (x *PlaylistExport) GetSongs() []*PlaylistSong {
+	if x != nil {
+		return x.Songs
+	}
+	return nil
+}
+
+// EraseUserDataRequest specifies whose data to erase.
+type EraseUserDataRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID
+	UserId        string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EraseUserDataRequest) Reset() {
+	*x = EraseUserDataRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EraseUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseUserDataRequest) ProtoMessage() {}
+
+func (x *EraseUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseUserDataRequest.ProtoReflect.Descriptor instead.
+func (*EraseUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *EraseUserDataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// EraseUserDataResponse reports how many rows were deleted.
+type EraseUserDataResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deleted favorites
+	FavoritesDeleted int64 `protobuf:"varint,1,opt,name=favorites_deleted,json=favoritesDeleted,proto3" json:"favorites_deleted,omitempty"`
+	// Deleted playlists (songs are deleted with their playlist)
+	PlaylistsDeleted int64 `protobuf:"varint,2,opt,name=playlists_deleted,json=playlistsDeleted,proto3" json:"playlists_deleted,omitempty"`
+	// Deleted play history records
+	HistoryDeleted int64 `protobuf:"varint,3,opt,name=history_deleted,json=historyDeleted,proto3" json:"history_deleted,omitempty"`
+	// Deleted listening statistics rows (daily/monthly rollups)
+	StatsDeleted int64 `protobuf:"varint,4,opt,name=stats_deleted,json=statsDeleted,proto3" json:"stats_deleted,omitempty"`
+	// Removed memberships in collaborative playlists
+	MembershipsDeleted int64 `protobuf:"varint,5,opt,name=memberships_deleted,json=membershipsDeleted,proto3" json:"memberships_deleted,omitempty"`
+	// Removed follows of public playlists
+	FollowsDeleted int64 `protobuf:"varint,6,opt,name=follows_deleted,json=followsDeleted,proto3" json:"follows_deleted,omitempty"`
+	// Deleted play queue and playback position rows
+	PlaybackDeleted int64 `protobuf:"varint,7,opt,name=playback_deleted,json=playbackDeleted,proto3" json:"playback_deleted,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *EraseUserDataResponse) Reset() {
+	*x = EraseUserDataResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EraseUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseUserDataResponse) ProtoMessage() {}
+
+func (x *EraseUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseUserDataResponse.ProtoReflect.Descriptor instead.
+func (*EraseUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *EraseUserDataResponse) GetFavoritesDeleted() int64 {
+	if x != nil {
+		return x.FavoritesDeleted
+	}
+	return 0
+}
+
+func (x *EraseUserDataResponse) GetPlaylistsDeleted() int64 {
+	if x != nil {
+		return x.PlaylistsDeleted
+	}
+	return 0
+}
+
+func (x *EraseUserDataResponse) GetHistoryDeleted() int64 {
+	if x != nil {
+		return x.HistoryDeleted
+	}
+	return 0
+}
+
+func (x *EraseUserDataResponse) GetStatsDeleted() int64 {
+	if x != nil {
+		return x.StatsDeleted
+	}
+	return 0
+}
+
+func (x *EraseUserDataResponse) GetMembershipsDeleted() int64 {
+	if x != nil {
+		return x.MembershipsDeleted
+	}
+	return 0
+}
+
+func (x *EraseUserDataResponse) GetFollowsDeleted() int64 {
+	if x != nil {
+		return x.FollowsDeleted
+	}
+	return 0
+}
+
+func (x *EraseUserDataResponse) GetPlaybackDeleted() int64 {
+	if x != nil {
+		return x.PlaybackDeleted
+	}
+	return 0
+}
+
+// GetListeningStatsRequest specifies the user and date range.
+type GetListeningStatsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// First day (inclusive), format YYYY-MM-DD
+	FromDate string `protobuf:"bytes,2,opt,name=from_date,json=fromDate,proto3" json:"from_date,omitempty"`
+	// Last day (inclusive), format YYYY-MM-DD
+	ToDate string `protobuf:"bytes,3,opt,name=to_date,json=toDate,proto3" json:"to_date,omitempty"`
+	// Number of top songs/singers to return (default 10, max 100)
+	TopLimit      int32 `protobuf:"varint,4,opt,name=top_limit,json=topLimit,proto3" json:"top_limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetListeningStatsRequest) Reset() {
+	*x = GetListeningStatsRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetListeningStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetListeningStatsRequest) ProtoMessage() {}
+
+func (x *GetListeningStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetListeningStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetListeningStatsRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *GetListeningStatsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetListeningStatsRequest) GetFromDate() string {
+	if x != nil {
+		return x.FromDate
+	}
+	return ""
+}
+
+func (x *GetListeningStatsRequest) GetToDate() string {
+	if x != nil {
+		return x.ToDate
+	}
+	return ""
+}
+
+func (x *GetListeningStatsRequest) GetTopLimit() int32 {
+	if x != nil {
+		return x.TopLimit
+	}
+	return 0
+}
+
+// GetListeningStatsResponse contains statistics for the range.
+type GetListeningStatsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Totals, top lists and heatmap for the whole range
+	Summary *ListeningSummary `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"`
+	// Per-day totals (days without plays are omitted)
+	Daily         []*DailyListening `protobuf:"bytes,2,rep,name=daily,proto3" json:"daily,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetListeningStatsResponse) Reset() {
+	*x = GetListeningStatsResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetListeningStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetListeningStatsResponse) ProtoMessage() {}
+
+func (x *GetListeningStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetListeningStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetListeningStatsResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *GetListeningStatsResponse) GetSummary() *ListeningSummary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+func (x *GetListeningStatsResponse) GetDaily() []*DailyListening {
+	if x != nil {
+		return x.Daily
+	}
+	return nil
+}
+
+// GetYearInReviewRequest specifies the user and year.
+type GetYearInReviewRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Calendar year (in the stats timezone)
+	Year int32 `protobuf:"varint,2,opt,name=year,proto3" json:"year,omitempty"`
+	// Number of top songs/singers to return (default 10, max 100)
+	TopLimit      int32 `protobuf:"varint,3,opt,name=top_limit,json=topLimit,proto3" json:"top_limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetYearInReviewRequest) Reset() {
+	*x = GetYearInReviewRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetYearInReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetYearInReviewRequest) ProtoMessage() {}
+
+func (x *GetYearInReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetYearInReviewRequest.ProtoReflect.Descriptor instead.
+func (*GetYearInReviewRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *GetYearInReviewRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetYearInReviewRequest) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *GetYearInReviewRequest) GetTopLimit() int32 {
+	if x != nil {
+		return x.TopLimit
+	}
+	return 0
+}
+
+// GetYearInReviewResponse is the yearly recap.
+type GetYearInReviewResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Year
+	Year int32 `protobuf:"varint,1,opt,name=year,proto3" json:"year,omitempty"`
+	// Totals, top lists and heatmap for the year
+	Summary *ListeningSummary `protobuf:"bytes,2,opt,name=summary,proto3" json:"summary,omitempty"`
+	// Number of days with at least one play
+	ListeningDays int64 `protobuf:"varint,3,opt,name=listening_days,json=listeningDays,proto3" json:"listening_days,omitempty"`
+	// Number of distinct songs played
+	DistinctSongs int64 `protobuf:"varint,4,opt,name=distinct_songs,json=distinctSongs,proto3" json:"distinct_songs,omitempty"`
+	// Number of distinct singers played
+	DistinctSingers int64 `protobuf:"varint,5,opt,name=distinct_singers,json=distinctSingers,proto3" json:"distinct_singers,omitempty"`
+	// Month with the most listening time, format YYYY-MM (empty without plays)
+	TopMonth string `protobuf:"bytes,6,opt,name=top_month,json=topMonth,proto3" json:"top_month,omitempty"`
+	// Hour of day with the most plays (0-23, -1 without plays)
+	PeakHour int32 `protobuf:"varint,7,opt,name=peak_hour,json=peakHour,proto3" json:"peak_hour,omitempty"`
+	// Weekday with the most plays (0 = Sunday, -1 without plays)
+	PeakWeekday int32 `protobuf:"varint,8,opt,name=peak_weekday,json=peakWeekday,proto3" json:"peak_weekday,omitempty"`
+	// Per-month totals (months without plays are omitted)
+	Months        []*MonthlyListening `protobuf:"bytes,9,rep,name=months,proto3" json:"months,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetYearInReviewResponse) Reset() {
+	*x = GetYearInReviewResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetYearInReviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetYearInReviewResponse) ProtoMessage() {}
+
+func (x *GetYearInReviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetYearInReviewResponse.ProtoReflect.Descriptor instead.
+func (*GetYearInReviewResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *GetYearInReviewResponse) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *GetYearInReviewResponse) GetSummary() *ListeningSummary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+func (x *GetYearInReviewResponse) GetListeningDays() int64 {
+	if x != nil {
+		return x.ListeningDays
+	}
+	return 0
+}
+
+func (x *GetYearInReviewResponse) GetDistinctSongs() int64 {
+	if x != nil {
+		return x.DistinctSongs
+	}
+	return 0
+}
+
+func (x *GetYearInReviewResponse) GetDistinctSingers() int64 {
+	if x != nil {
+		return x.DistinctSingers
+	}
+	return 0
+}
+
+func (x *GetYearInReviewResponse) GetTopMonth() string {
+	if x != nil {
+		return x.TopMonth
+	}
+	return ""
+}
+
+func (x *GetYearInReviewResponse) GetPeakHour() int32 {
+	if x != nil {
+		return x.PeakHour
+	}
+	return 0
+}
+
+func (x *GetYearInReviewResponse) GetPeakWeekday() int32 {
+	if x != nil {
+		return x.PeakWeekday
+	}
+	return 0
+}
+
+func (x *GetYearInReviewResponse) GetMonths() []*MonthlyListening {
+	if x != nil {
+		return x.Months
+	}
+	return nil
+}
+
+// ListeningSummary aggregates plays over a period.
+type ListeningSummary struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of plays
+	PlayCount int64 `protobuf:"varint,1,opt,name=play_count,json=playCount,proto3" json:"play_count,omitempty"`
+	// Total listening time in seconds
+	SecondsListened int64 `protobuf:"varint,2,opt,name=seconds_listened,json=secondsListened,proto3" json:"seconds_listened,omitempty"`
+	// Total listening time in minutes (rounded down)
+	MinutesListened int64 `protobuf:"varint,3,opt,name=minutes_listened,json=minutesListened,proto3" json:"minutes_listened,omitempty"`
+	// Most played songs
+	TopSongs []*TopSong `protobuf:"bytes,4,rep,name=top_songs,json=topSongs,proto3" json:"top_songs,omitempty"`
+	// Most played singers
+	TopSingers []*TopSinger `protobuf:"bytes,5,rep,name=top_singers,json=topSingers,proto3" json:"top_singers,omitempty"`
+	// Plays by weekday and hour: 168 values, index = weekday * 24 + hour (weekday 0 = Sunday)
+	Heatmap       []int64 `protobuf:"varint,6,rep,packed,name=heatmap,proto3" json:"heatmap,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListeningSummary) Reset() {
+	*x = ListeningSummary{}
+	mi := &file_user_v1_user_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListeningSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListeningSummary) ProtoMessage() {}
+
+func (x *ListeningSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListeningSummary.ProtoReflect.Descriptor instead.
+func (*ListeningSummary) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *ListeningSummary) GetPlayCount() int64 {
+	if x != nil {
+		return x.PlayCount
+	}
+	return 0
+}
+
+func (x *ListeningSummary) GetSecondsListened() int64 {
+	if x != nil {
+		return x.SecondsListened
+	}
+	return 0
+}
+
+func (x *ListeningSummary) GetMinutesListened() int64 {
+	if x != nil {
+		return x.MinutesListened
+	}
+	return 0
+}
+
+func (x *ListeningSummary) GetTopSongs() []*TopSong {
+	if x != nil {
+		return x.TopSongs
+	}
+	return nil
+}
+
+func (x *ListeningSummary) GetTopSingers() []*TopSinger {
+	if x != nil {
+		return x.TopSingers
+	}
+	return nil
+}
+
+func (x *ListeningSummary) GetHeatmap() []int64 {
+	if x != nil {
+		return x.Heatmap
+	}
+	return nil
+}
+
+// TopSong is a song ranked by play count.
+type TopSong struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Song ID
+	SongId string `protobuf:"bytes,1,opt,name=song_id,json=songId,proto3" json:"song_id,omitempty"`
+	// Song name (latest seen)
+	SongName string `protobuf:"bytes,2,opt,name=song_name,json=songName,proto3" json:"song_name,omitempty"`
+	// Singer name (latest seen)
+	SingerName string `protobuf:"bytes,3,opt,name=singer_name,json=singerName,proto3" json:"singer_name,omitempty"`
+	// Album cover URL (latest seen)
+	AlbumCover string `protobuf:"bytes,4,opt,name=album_cover,json=albumCover,proto3" json:"album_cover,omitempty"`
+	// Number of plays
+	PlayCount int64 `protobuf:"varint,5,opt,name=play_count,json=playCount,proto3" json:"play_count,omitempty"`
+	// Listening time in seconds
+	SecondsListened int64 `protobuf:"varint,6,opt,name=seconds_listened,json=secondsListened,proto3" json:"seconds_listened,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TopSong) Reset() {
+	*x = TopSong{}
+	mi := &file_user_v1_user_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopSong) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopSong) ProtoMessage() {}
+
+func (x *TopSong) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopSong.ProtoReflect.Descriptor instead.
+func (*TopSong) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *TopSong) GetSongId() string {
+	if x != nil {
+		return x.SongId
+	}
+	return ""
+}
+
+func (x *TopSong) GetSongName() string {
+	if x != nil {
+		return x.SongName
+	}
+	return ""
+}
+
+func (x *TopSong) GetSingerName() string {
+	if x != nil {
+		return x.SingerName
+	}
+	return ""
+}
+
+func (x *TopSong) GetAlbumCover() string {
+	if x != nil {
+		return x.AlbumCover
+	}
+	return ""
+}
+
+func (x *TopSong) GetPlayCount() int64 {
+	if x != nil {
+		return x.PlayCount
+	}
+	return 0
+}
+
+func (x *TopSong) GetSecondsListened() int64 {
+	if x != nil {
+		return x.SecondsListened
+	}
+	return 0
+}
+
+// TopSinger is a singer ranked by play count.
+type TopSinger struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Singer name
+	SingerName string `protobuf:"bytes,1,opt,name=singer_name,json=singerName,proto3" json:"singer_name,omitempty"`
+	// Number of plays
+	PlayCount int64 `protobuf:"varint,2,opt,name=play_count,json=playCount,proto3" json:"play_count,omitempty"`
+	// Listening time in seconds
+	SecondsListened int64 `protobuf:"varint,3,opt,name=seconds_listened,json=secondsListened,proto3" json:"seconds_listened,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TopSinger) Reset() {
+	*x = TopSinger{}
+	mi := &file_user_v1_user_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopSinger) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopSinger) ProtoMessage() {}
+
+func (x *TopSinger) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopSinger.ProtoReflect.Descriptor instead.
+func (*TopSinger) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *TopSinger) GetSingerName() string {
+	if x != nil {
+		return x.SingerName
+	}
+	return ""
+}
+
+func (x *TopSinger) GetPlayCount() int64 {
+	if x != nil {
+		return x.PlayCount
+	}
+	return 0
+}
+
+func (x *TopSinger) GetSecondsListened() int64 {
+	if x != nil {
+		return x.SecondsListened
+	}
+	return 0
+}
+
+// DailyListening is the listening total of one day.
+type DailyListening struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Day, format YYYY-MM-DD
+	Date string `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	// Number of plays
+	PlayCount int64 `protobuf:"varint,2,opt,name=play_count,json=playCount,proto3" json:"play_count,omitempty"`
+	// Listening time in seconds
+	SecondsListened int64 `protobuf:"varint,3,opt,name=seconds_listened,json=secondsListened,proto3" json:"seconds_listened,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DailyListening) Reset() {
+	*x = DailyListening{}
+	mi := &file_user_v1_user_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DailyListening) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DailyListening) ProtoMessage() {}
+
+func (x *DailyListening) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DailyListening.ProtoReflect.Descriptor instead.
+func (*DailyListening) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *DailyListening) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *DailyListening) GetPlayCount() int64 {
+	if x != nil {
+		return x.PlayCount
+	}
+	return 0
+}
+
+func (x *DailyListening) GetSecondsListened() int64 {
+	if x != nil {
+		return x.SecondsListened
+	}
+	return 0
+}
+
+// MonthlyListening is the listening total of one month.
+type MonthlyListening struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Month, format YYYY-MM
+	Month string `protobuf:"bytes,1,opt,name=month,proto3" json:"month,omitempty"`
+	// Number of plays
+	PlayCount int64 `protobuf:"varint,2,opt,name=play_count,json=playCount,proto3" json:"play_count,omitempty"`
+	// Listening time in seconds
+	SecondsListened int64 `protobuf:"varint,3,opt,name=seconds_listened,json=secondsListened,proto3" json:"seconds_listened,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *MonthlyListening) Reset() {
+	*x = MonthlyListening{}
+	mi := &file_user_v1_user_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MonthlyListening) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MonthlyListening) ProtoMessage() {}
+
+func (x *MonthlyListening) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MonthlyListening.ProtoReflect.Descriptor instead.
+func (*MonthlyListening) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *MonthlyListening) GetMonth() string {
+	if x != nil {
+		return x.Month
+	}
+	return ""
+}
+
+func (x *MonthlyListening) GetPlayCount() int64 {
+	if x != nil {
+		return x.PlayCount
+	}
+	return 0
+}
+
+func (x *MonthlyListening) GetSecondsListened() int64 {
+	if x != nil {
+		return x.SecondsListened
+	}
+	return 0
+}
+
+// GetRecommendationsRequest asks for a user's recommendations.
+type GetRecommendationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID
+	UserId        string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRecommendationsRequest) Reset() {
+	*x = GetRecommendationsRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecommendationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecommendationsRequest) ProtoMessage() {}
+
+func (x *GetRecommendationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecommendationsRequest.ProtoReflect.Descriptor instead.
+func (*GetRecommendationsRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *GetRecommendationsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// GetRecommendationsResponse contains the personalized recommendation rows.
+type GetRecommendationsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Songs for today's mix, best first
+	DailyMix []*RecommendedSong `protobuf:"bytes,1,rep,name=daily_mix,json=dailyMix,proto3" json:"daily_mix,omitempty"`
+	// "Because you liked X" rows (seed songs rotate daily)
+	BecauseYouLiked []*RecommendationRow `protobuf:"bytes,2,rep,name=because_you_liked,json=becauseYouLiked,proto3" json:"because_you_liked,omitempty"`
+	// When the recommendations were computed
+	GeneratedAt   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=generated_at,json=generatedAt,proto3" json:"generated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRecommendationsResponse) Reset() {
+	*x = GetRecommendationsResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecommendationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecommendationsResponse) ProtoMessage() {}
+
+func (x *GetRecommendationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecommendationsResponse.ProtoReflect.Descriptor instead.
+func (*GetRecommendationsResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *GetRecommendationsResponse) GetDailyMix() []*RecommendedSong {
+	if x != nil {
+		return x.DailyMix
+	}
+	return nil
+}
+
+func (x *GetRecommendationsResponse) GetBecauseYouLiked() []*RecommendationRow {
+	if x != nil {
+		return x.BecauseYouLiked
+	}
+	return nil
+}
+
+func (x *GetRecommendationsResponse) GetGeneratedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.GeneratedAt
+	}
+	return nil
+}
+
+// RecommendedSong is a recommended song.
+type RecommendedSong struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Song ID
+	SongId string `protobuf:"bytes,1,opt,name=song_id,json=songId,proto3" json:"song_id,omitempty"`
+	// Song name
+	SongName string `protobuf:"bytes,2,opt,name=song_name,json=songName,proto3" json:"song_name,omitempty"`
+	// Singer name
+	SingerName string `protobuf:"bytes,3,opt,name=singer_name,json=singerName,proto3" json:"singer_name,omitempty"`
+	// Relevance score (only meaningful for ordering within one response)
+	Score float64 `protobuf:"fixed64,4,opt,name=score,proto3" json:"score,omitempty"`
+	// Where the recommendation came from: "co_occurrence" or "similar"
+	Source        string `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecommendedSong) Reset() {
+	*x = RecommendedSong{}
+	mi := &file_user_v1_user_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecommendedSong) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecommendedSong) ProtoMessage() {}
+
+func (x *RecommendedSong) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecommendedSong.ProtoReflect.Descriptor instead.
+func (*RecommendedSong) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *RecommendedSong) GetSongId() string {
+	if x != nil {
+		return x.SongId
+	}
+	return ""
+}
+
+func (x *RecommendedSong) GetSongName() string {
+	if x != nil {
+		return x.SongName
+	}
+	return ""
+}
+
+func (x *RecommendedSong) GetSingerName() string {
+	if x != nil {
+		return x.SingerName
+	}
+	return ""
+}
+
+func (x *RecommendedSong) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *RecommendedSong) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+// RecommendationRow is a "because you liked X" row.
+type RecommendationRow struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Seed song ID
+	SeedSongId string `protobuf:"bytes,1,opt,name=seed_song_id,json=seedSongId,proto3" json:"seed_song_id,omitempty"`
+	// Seed song name
+	SeedSongName string `protobuf:"bytes,2,opt,name=seed_song_name,json=seedSongName,proto3" json:"seed_song_name,omitempty"`
+	// Seed singer name
+	SeedSingerName string `protobuf:"bytes,3,opt,name=seed_singer_name,json=seedSingerName,proto3" json:"seed_singer_name,omitempty"`
+	// Recommended songs, best first
+	Songs         []*RecommendedSong `protobuf:"bytes,4,rep,name=songs,proto3" json:"songs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecommendationRow) Reset() {
+	*x = RecommendationRow{}
+	mi := &file_user_v1_user_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecommendationRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecommendationRow) ProtoMessage() {}
+
+func (x *RecommendationRow) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecommendationRow.ProtoReflect.Descriptor instead.
+func (*RecommendationRow) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *RecommendationRow) GetSeedSongId() string {
+	if x != nil {
+		return x.SeedSongId
+	}
+	return ""
+}
+
+func (x *RecommendationRow) GetSeedSongName() string {
+	if x != nil {
+		return x.SeedSongName
+	}
+	return ""
+}
+
+func (x *RecommendationRow) GetSeedSingerName() string {
+	if x != nil {
+		return x.SeedSingerName
+	}
+	return ""
+}
+
+func (x *RecommendationRow) GetSongs() []*RecommendedSong {
+	if x != nil {
+		return x.Songs
+	}
+	return nil
+}
+
+// ListNewReleasesRequest pages through new releases of followed singers.
+type ListNewReleasesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Page number (1-based)
+	Page int32 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	// Page size (max 100)
+	PageSize      int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNewReleasesRequest) Reset() {
+	*x = ListNewReleasesRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNewReleasesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNewReleasesRequest) ProtoMessage() {}
+
+func (x *ListNewReleasesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNewReleasesRequest.ProtoReflect.Descriptor instead.
+func (*ListNewReleasesRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *ListNewReleasesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListNewReleasesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListNewReleasesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// ListNewReleasesResponse contains a page of new releases.
+type ListNewReleasesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// New releases, most recently discovered first
+	Releases []*NewRelease `protobuf:"bytes,1,rep,name=releases,proto3" json:"releases,omitempty"`
+	// Total number of new releases
+	Total         int64 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNewReleasesResponse) Reset() {
+	*x = ListNewReleasesResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNewReleasesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNewReleasesResponse) ProtoMessage() {}
+
+func (x *ListNewReleasesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNewReleasesResponse.ProtoReflect.Descriptor instead.
+func (*ListNewReleasesResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *ListNewReleasesResponse) GetReleases() []*NewRelease {
+	if x != nil {
+		return x.Releases
+	}
+	return nil
+}
+
+func (x *ListNewReleasesResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// NewRelease is an album released by a followed singer.
+type NewRelease struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Singer ID on the upstream service
+	SingerId string `protobuf:"bytes,1,opt,name=singer_id,json=singerId,proto3" json:"singer_id,omitempty"`
+	// Singer name
+	SingerName string `protobuf:"bytes,2,opt,name=singer_name,json=singerName,proto3" json:"singer_name,omitempty"`
+	// Album ID on the upstream service
+	AlbumId string `protobuf:"bytes,3,opt,name=album_id,json=albumId,proto3" json:"album_id,omitempty"`
+	// Album name
+	AlbumName string `protobuf:"bytes,4,opt,name=album_name,json=albumName,proto3" json:"album_name,omitempty"`
+	// Album cover URL
+	CoverUrl string `protobuf:"bytes,5,opt,name=cover_url,json=coverUrl,proto3" json:"cover_url,omitempty"`
+	// Release date as reported upstream (e.g. "2026-10-01")
+	ReleaseDate string `protobuf:"bytes,6,opt,name=release_date,json=releaseDate,proto3" json:"release_date,omitempty"`
+	// When the release was discovered
+	DiscoveredAt  *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=discovered_at,json=discoveredAt,proto3" json:"discovered_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NewRelease) Reset() {
+	*x = NewRelease{}
+	mi := &file_user_v1_user_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NewRelease) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewRelease) ProtoMessage() {}
+
+func (x *NewRelease) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewRelease.ProtoReflect.Descriptor instead.
+func (*NewRelease) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *NewRelease) GetSingerId() string {
+	if x != nil {
+		return x.SingerId
+	}
+	return ""
+}
+
+func (x *NewRelease) GetSingerName() string {
+	if x != nil {
+		return x.SingerName
+	}
+	return ""
+}
+
+func (x *NewRelease) GetAlbumId() string {
+	if x != nil {
+		return x.AlbumId
+	}
+	return ""
+}
+
+func (x *NewRelease) GetAlbumName() string {
+	if x != nil {
+		return x.AlbumName
+	}
+	return ""
+}
+
+func (x *NewRelease) GetCoverUrl() string {
+	if x != nil {
+		return x.CoverUrl
+	}
+	return ""
+}
+
+func (x *NewRelease) GetReleaseDate() string {
+	if x != nil {
+		return x.ReleaseDate
+	}
+	return ""
+}
+
+func (x *NewRelease) GetDiscoveredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DiscoveredAt
+	}
+	return nil
+}
+
+// GetPlayQueueRequest specifies the user.
+type GetPlayQueueRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID
+	UserId        string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlayQueueRequest) Reset() {
+	*x = GetPlayQueueRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlayQueueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlayQueueRequest) ProtoMessage() {}
+
+func (x *GetPlayQueueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlayQueueRequest.ProtoReflect.Descriptor instead.
+func (*GetPlayQueueRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *GetPlayQueueRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// GetPlayQueueResponse contains the play queue.
+type GetPlayQueueResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Play queue
+	Queue         *PlayQueue `protobuf:"bytes,1,opt,name=queue,proto3" json:"queue,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlayQueueResponse) Reset() {
+	*x = GetPlayQueueResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlayQueueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlayQueueResponse) ProtoMessage() {}
+
+func (x *GetPlayQueueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlayQueueResponse.ProtoReflect.Descriptor instead.
+func (*GetPlayQueueResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *GetPlayQueueResponse) GetQueue() *PlayQueue {
+	if x != nil {
+		return x.Queue
+	}
+	return nil
+}
+
+// SavePlayQueueRequest replaces the play queue.
+type SavePlayQueueRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Device making the change (echoed in the sync event so the device can ignore it)
+	DeviceId string `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	// Tracks in play order (max 1000)
+	Tracks []*QueueTrack `protobuf:"bytes,3,rep,name=tracks,proto3" json:"tracks,omitempty"`
+	// Index of the current track (0 for an empty queue)
+	CurrentIndex int32 `protobuf:"varint,4,opt,name=current_index,json=currentIndex,proto3" json:"current_index,omitempty"`
+	// Shuffle enabled
+	Shuffle bool `protobuf:"varint,5,opt,name=shuffle,proto3" json:"shuffle,omitempty"`
+	// Repeat mode (UNSPECIFIED means OFF)
+	RepeatMode    RepeatMode `protobuf:"varint,6,opt,name=repeat_mode,json=repeatMode,proto3,enum=user.v1.RepeatMode" json:"repeat_mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SavePlayQueueRequest) Reset() {
+	*x = SavePlayQueueRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SavePlayQueueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SavePlayQueueRequest) ProtoMessage() {}
+
+func (x *SavePlayQueueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SavePlayQueueRequest.ProtoReflect.Descriptor instead.
+func (*SavePlayQueueRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *SavePlayQueueRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SavePlayQueueRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *SavePlayQueueRequest) GetTracks() []*QueueTrack {
+	if x != nil {
+		return x.Tracks
+	}
+	return nil
+}
+
+func (x *SavePlayQueueRequest) GetCurrentIndex() int32 {
+	if x != nil {
+		return x.CurrentIndex
+	}
+	return 0
+}
+
+func (x *SavePlayQueueRequest) GetShuffle() bool {
+	if x != nil {
+		return x.Shuffle
+	}
+	return false
+}
+
+func (x *SavePlayQueueRequest) GetRepeatMode() RepeatMode {
+	if x != nil {
+		return x.RepeatMode
+	}
+	return RepeatMode_REPEAT_MODE_UNSPECIFIED
+}
+
+// SavePlayQueueResponse contains the saved play queue.
+type SavePlayQueueResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Play queue
+	Queue         *PlayQueue `protobuf:"bytes,1,opt,name=queue,proto3" json:"queue,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SavePlayQueueResponse) Reset() {
+	*x = SavePlayQueueResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SavePlayQueueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SavePlayQueueResponse) ProtoMessage() {}
+
+func (x *SavePlayQueueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SavePlayQueueResponse.ProtoReflect.Descriptor instead.
+func (*SavePlayQueueResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *SavePlayQueueResponse) GetQueue() *PlayQueue {
+	if x != nil {
+		return x.Queue
+	}
+	return nil
+}
+
+// SetPlayQueueIndexRequest changes the current track.
+type SetPlayQueueIndexRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Device making the change
+	DeviceId string `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	// Index of the new current track
+	CurrentIndex  int32 `protobuf:"varint,3,opt,name=current_index,json=currentIndex,proto3" json:"current_index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPlayQueueIndexRequest) Reset() {
+	*x = SetPlayQueueIndexRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPlayQueueIndexRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPlayQueueIndexRequest) ProtoMessage() {}
+
+func (x *SetPlayQueueIndexRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPlayQueueIndexRequest.ProtoReflect.Descriptor instead.
+func (*SetPlayQueueIndexRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *SetPlayQueueIndexRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetPlayQueueIndexRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *SetPlayQueueIndexRequest) GetCurrentIndex() int32 {
+	if x != nil {
+		return x.CurrentIndex
+	}
+	return 0
+}
+
+// SetPlayQueueIndexResponse contains the updated play queue.
+type SetPlayQueueIndexResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Play queue
+	Queue         *PlayQueue `protobuf:"bytes,1,opt,name=queue,proto3" json:"queue,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPlayQueueIndexResponse) Reset() {
+	*x = SetPlayQueueIndexResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPlayQueueIndexResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPlayQueueIndexResponse) ProtoMessage() {}
+
+func (x *SetPlayQueueIndexResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPlayQueueIndexResponse.ProtoReflect.Descriptor instead.
+func (*SetPlayQueueIndexResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *SetPlayQueueIndexResponse) GetQueue() *PlayQueue {
+	if x != nil {
+		return x.Queue
+	}
+	return nil
+}
+
+// UpdatePlaybackPositionRequest records a playback position.
+type UpdatePlaybackPositionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Device reporting the position
+	DeviceId string `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	// Song ID
+	SongId string `protobuf:"bytes,3,opt,name=song_id,json=songId,proto3" json:"song_id,omitempty"`
+	// Position in milliseconds
+	PositionMs int64 `protobuf:"varint,4,opt,name=position_ms,json=positionMs,proto3" json:"position_ms,omitempty"`
+	// Track duration in milliseconds (0 if unknown)
+	DurationMs    int64 `protobuf:"varint,5,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePlaybackPositionRequest) Reset() {
+	*x = UpdatePlaybackPositionRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePlaybackPositionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePlaybackPositionRequest) ProtoMessage() {}
+
+func (x *UpdatePlaybackPositionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[103]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePlaybackPositionRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePlaybackPositionRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *UpdatePlaybackPositionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdatePlaybackPositionRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *UpdatePlaybackPositionRequest) GetSongId() string {
+	if x != nil {
+		return x.SongId
+	}
+	return ""
+}
+
+func (x *UpdatePlaybackPositionRequest) GetPositionMs() int64 {
+	if x != nil {
+		return x.PositionMs
+	}
+	return 0
+}
+
+func (x *UpdatePlaybackPositionRequest) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+// UpdatePlaybackPositionResponse contains the recorded position.
+type UpdatePlaybackPositionResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Recorded position
+	Position      *PlaybackPosition `protobuf:"bytes,1,opt,name=position,proto3" json:"position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePlaybackPositionResponse) Reset() {
+	*x = UpdatePlaybackPositionResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePlaybackPositionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePlaybackPositionResponse) ProtoMessage() {}
+
+func (x *UpdatePlaybackPositionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[104]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePlaybackPositionResponse.ProtoReflect.Descriptor instead.
+func (*UpdatePlaybackPositionResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *UpdatePlaybackPositionResponse) GetPosition() *PlaybackPosition {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+// GetPlaybackPositionsRequest specifies the tracks.
+type GetPlaybackPositionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Song IDs (1-100)
+	SongIds       []string `protobuf:"bytes,2,rep,name=song_ids,json=songIds,proto3" json:"song_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlaybackPositionsRequest) Reset() {
+	*x = GetPlaybackPositionsRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[105]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlaybackPositionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlaybackPositionsRequest) ProtoMessage() {}
+
+func (x *GetPlaybackPositionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[105]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlaybackPositionsRequest.ProtoReflect.Descriptor instead.
+func (*GetPlaybackPositionsRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{105}
+}
+
+func (x *GetPlaybackPositionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetPlaybackPositionsRequest) GetSongIds() []string {
+	if x != nil {
+		return x.SongIds
+	}
+	return nil
+}
+
+// GetPlaybackPositionsResponse contains the known positions.
+type GetPlaybackPositionsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Positions in request order; tracks never played are omitted
+	Positions     []*PlaybackPosition `protobuf:"bytes,1,rep,name=positions,proto3" json:"positions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlaybackPositionsResponse) Reset() {
+	*x = GetPlaybackPositionsResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[106]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlaybackPositionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlaybackPositionsResponse) ProtoMessage() {}
+
+func (x *GetPlaybackPositionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[106]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlaybackPositionsResponse.ProtoReflect.Descriptor instead.
+func (*GetPlaybackPositionsResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{106}
+}
+
+func (x *GetPlaybackPositionsResponse) GetPositions() []*PlaybackPosition {
+	if x != nil {
+		return x.Positions
+	}
+	return nil
+}
+
+// PlayQueue is a user's play queue.
+type PlayQueue struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Tracks in play order (shuffled order when shuffle is on)
+	Tracks []*QueueTrack `protobuf:"bytes,1,rep,name=tracks,proto3" json:"tracks,omitempty"`
+	// Index of the current track
+	CurrentIndex int32 `protobuf:"varint,2,opt,name=current_index,json=currentIndex,proto3" json:"current_index,omitempty"`
+	// Shuffle enabled
+	Shuffle bool `protobuf:"varint,3,opt,name=shuffle,proto3" json:"shuffle,omitempty"`
+	// Repeat mode
+	RepeatMode RepeatMode `protobuf:"varint,4,opt,name=repeat_mode,json=repeatMode,proto3,enum=user.v1.RepeatMode" json:"repeat_mode,omitempty"`
+	// Device that last changed the queue
+	DeviceId string `protobuf:"bytes,5,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	// Last change time
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayQueue) Reset() {
+	*x = PlayQueue{}
+	mi := &file_user_v1_user_proto_msgTypes[107]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayQueue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayQueue) ProtoMessage() {}
+
+func (x *PlayQueue) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[107]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayQueue.ProtoReflect.Descriptor instead.
+func (*PlayQueue) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{107}
+}
+
+func (x *PlayQueue) GetTracks() []*QueueTrack {
+	if x != nil {
+		return x.Tracks
+	}
+	return nil
+}
+
+func (x *PlayQueue) GetCurrentIndex() int32 {
+	if x != nil {
+		return x.CurrentIndex
+	}
+	return 0
+}
+
+func (x *PlayQueue) GetShuffle() bool {
+	if x != nil {
+		return x.Shuffle
+	}
+	return false
+}
+
+func (x *PlayQueue) GetRepeatMode() RepeatMode {
+	if x != nil {
+		return x.RepeatMode
+	}
+	return RepeatMode_REPEAT_MODE_UNSPECIFIED
+}
+
+func (x *PlayQueue) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *PlayQueue) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// QueueTrack is a track in the play queue.
+type QueueTrack struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Song ID
+	SongId string `protobuf:"bytes,1,opt,name=song_id,json=songId,proto3" json:"song_id,omitempty"`
+	// Song name
+	SongName string `protobuf:"bytes,2,opt,name=song_name,json=songName,proto3" json:"song_name,omitempty"`
+	// Singer name
+	SingerName string `protobuf:"bytes,3,opt,name=singer_name,json=singerName,proto3" json:"singer_name,omitempty"`
+	// Duration in seconds
+	Duration      int32 `protobuf:"varint,4,opt,name=duration,proto3" json:"duration,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueueTrack) Reset() {
+	*x = QueueTrack{}
+	mi := &file_user_v1_user_proto_msgTypes[108]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueueTrack) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueueTrack) ProtoMessage() {}
+
+func (x *QueueTrack) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[108]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueueTrack.ProtoReflect.Descriptor instead.
+func (*QueueTrack) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{108}
+}
+
+func (x *QueueTrack) GetSongId() string {
+	if x != nil {
+		return x.SongId
+	}
+	return ""
+}
+
+func (x *QueueTrack) GetSongName() string {
+	if x != nil {
+		return x.SongName
+	}
+	return ""
+}
+
+func (x *QueueTrack) GetSingerName() string {
+	if x != nil {
+		return x.SingerName
+	}
+	return ""
+}
+
+func (x *QueueTrack) GetDuration() int32 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+// PlaybackPosition is the last playback position of a track.
+type PlaybackPosition struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Song ID
+	SongId string `protobuf:"bytes,1,opt,name=song_id,json=songId,proto3" json:"song_id,omitempty"`
+	// Position in milliseconds
+	PositionMs int64 `protobuf:"varint,2,opt,name=position_ms,json=positionMs,proto3" json:"position_ms,omitempty"`
+	// Track duration in milliseconds (0 if unknown)
+	DurationMs int64 `protobuf:"varint,3,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	// Device that reported the position
+	DeviceId string `protobuf:"bytes,4,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	// Report time
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaybackPosition) Reset() {
+	*x = PlaybackPosition{}
+	mi := &file_user_v1_user_proto_msgTypes[109]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaybackPosition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaybackPosition) ProtoMessage() {}
+
+func (x *PlaybackPosition) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[109]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaybackPosition.ProtoReflect.Descriptor instead.
+func (*PlaybackPosition) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{109}
+}
+
+func (x *PlaybackPosition) GetSongId() string {
+	if x != nil {
+		return x.SongId
+	}
+	return ""
+}
+
+func (x *PlaybackPosition) GetPositionMs() int64 {
+	if x != nil {
+		return x.PositionMs
+	}
+	return 0
+}
+
+func (x *PlaybackPosition) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *PlaybackPosition) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *PlaybackPosition) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Favorite represents a favorited item.
+type Favorite struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unique favorite ID
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// User ID
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Type of content
+	Type FavoriteType `protobuf:"varint,3,opt,name=type,proto3,enum=user.v1.FavoriteType" json:"type,omitempty"`
+	// Target content ID
+	TargetId string `protobuf:"bytes,4,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	// Optional metadata
+	Metadata *FavoriteMetadata `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// Creation timestamp
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Favorite) Reset() {
+	*x = Favorite{}
+	mi := &file_user_v1_user_proto_msgTypes[110]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Favorite) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Favorite) ProtoMessage() {}
+
+func (x *Favorite) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[110]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
 // Deprecated: Use Favorite.ProtoReflect.Descriptor instead.
 func (*Favorite) Descriptor() ([]byte, []int) {
-	return file_user_v1_user_proto_rawDescGZIP(), []int{24}
+	return file_user_v1_user_proto_rawDescGZIP(), []int{110}
 }
 
 func (x *Favorite) GetId() string {
@@ -1628,14 +7074,16 @@ type FavoriteMetadata struct {
 	// Artist name (for songs/albums)
 	Artist string `protobuf:"bytes,2,opt,name=artist,proto3" json:"artist,omitempty"`
 	// Cover image URL
-	CoverUrl      string `protobuf:"bytes,3,opt,name=cover_url,json=coverUrl,proto3" json:"cover_url,omitempty"`
+	CoverUrl string `protobuf:"bytes,3,opt,name=cover_url,json=coverUrl,proto3" json:"cover_url,omitempty"`
+	// Type-specific details, e.g. album release date, MV duration or playlist creator
+	Extra         map[string]string `protobuf:"bytes,4,rep,name=extra,proto3" json:"extra,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *FavoriteMetadata) Reset() {
 	*x = FavoriteMetadata{}
-	mi := &file_user_v1_user_proto_msgTypes[25]
+	mi := &file_user_v1_user_proto_msgTypes[111]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1647,7 +7095,7 @@ func (x *FavoriteMetadata) String() string {
 func (*FavoriteMetadata) ProtoMessage() {}
 
 func (x *FavoriteMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_user_v1_user_proto_msgTypes[25]
+	mi := &file_user_v1_user_proto_msgTypes[111]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1660,7 +7108,7 @@ func (x *FavoriteMetadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FavoriteMetadata.ProtoReflect.Descriptor instead.
 func (*FavoriteMetadata) Descriptor() ([]byte, []int) {
-	return file_user_v1_user_proto_rawDescGZIP(), []int{25}
+	return file_user_v1_user_proto_rawDescGZIP(), []int{111}
 }
 
 func (x *FavoriteMetadata) GetName() string {
@@ -1684,6 +7132,13 @@ func (x *FavoriteMetadata) GetCoverUrl() string {
 	return ""
 }
 
+func (x *FavoriteMetadata) GetExtra() map[string]string {
+	if x != nil {
+		return x.Extra
+	}
+	return nil
+}
+
 // PlayHistory represents a play event.
 type PlayHistory struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1709,7 +7164,7 @@ type PlayHistory struct {
 
 func (x *PlayHistory) Reset() {
 	*x = PlayHistory{}
-	mi := &file_user_v1_user_proto_msgTypes[26]
+	mi := &file_user_v1_user_proto_msgTypes[112]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1721,7 +7176,7 @@ func (x *PlayHistory) String() string {
 func (*PlayHistory) ProtoMessage() {}
 
 func (x *PlayHistory) ProtoReflect() protoreflect.Message {
-	mi := &file_user_v1_user_proto_msgTypes[26]
+	mi := &file_user_v1_user_proto_msgTypes[112]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1734,7 +7189,7 @@ func (x *PlayHistory) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PlayHistory.ProtoReflect.Descriptor instead.
 func (*PlayHistory) Descriptor() ([]byte, []int) {
-	return file_user_v1_user_proto_rawDescGZIP(), []int{26}
+	return file_user_v1_user_proto_rawDescGZIP(), []int{112}
 }
 
 func (x *PlayHistory) GetId() string {
@@ -1811,14 +7266,22 @@ type Playlist struct {
 	// Creation timestamp
 	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	// Last update timestamp
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Playlist description
+	Description string `protobuf:"bytes,9,opt,name=description,proto3" json:"description,omitempty"`
+	// Smart playlist rules as JSON, empty for manual playlists
+	SmartRules string `protobuf:"bytes,10,opt,name=smart_rules,json=smartRules,proto3" json:"smart_rules,omitempty"`
+	// Number of users following the playlist
+	FollowerCount int32 `protobuf:"varint,11,opt,name=follower_count,json=followerCount,proto3" json:"follower_count,omitempty"`
+	// ID of the playlist this one was forked from, empty if not a fork
+	ForkedFrom    string `protobuf:"bytes,12,opt,name=forked_from,json=forkedFrom,proto3" json:"forked_from,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Playlist) Reset() {
 	*x = Playlist{}
-	mi := &file_user_v1_user_proto_msgTypes[27]
+	mi := &file_user_v1_user_proto_msgTypes[113]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1830,7 +7293,7 @@ func (x *Playlist) String() string {
 func (*Playlist) ProtoMessage() {}
 
 func (x *Playlist) ProtoReflect() protoreflect.Message {
-	mi := &file_user_v1_user_proto_msgTypes[27]
+	mi := &file_user_v1_user_proto_msgTypes[113]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1843,7 +7306,7 @@ func (x *Playlist) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Playlist.ProtoReflect.Descriptor instead.
 func (*Playlist) Descriptor() ([]byte, []int) {
-	return file_user_v1_user_proto_rawDescGZIP(), []int{27}
+	return file_user_v1_user_proto_rawDescGZIP(), []int{113}
 }
 
 func (x *Playlist) GetId() string {
@@ -1902,6 +7365,207 @@ func (x *Playlist) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Playlist) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Playlist) GetSmartRules() string {
+	if x != nil {
+		return x.SmartRules
+	}
+	return ""
+}
+
+func (x *Playlist) GetFollowerCount() int32 {
+	if x != nil {
+		return x.FollowerCount
+	}
+	return 0
+}
+
+func (x *Playlist) GetForkedFrom() string {
+	if x != nil {
+		return x.ForkedFrom
+	}
+	return ""
+}
+
+// PlaylistMember is a collaborator of a playlist.
+type PlaylistMember struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Playlist ID
+	PlaylistId string `protobuf:"bytes,1,opt,name=playlist_id,json=playlistId,proto3" json:"playlist_id,omitempty"`
+	// Member user ID
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Role: "owner", "editor" or "viewer"
+	Role string `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	// User ID of whoever invited the member, empty for the owner
+	InvitedBy string `protobuf:"bytes,4,opt,name=invited_by,json=invitedBy,proto3" json:"invited_by,omitempty"`
+	// When the member joined
+	JoinedAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=joined_at,json=joinedAt,proto3" json:"joined_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaylistMember) Reset() {
+	*x = PlaylistMember{}
+	mi := &file_user_v1_user_proto_msgTypes[114]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaylistMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaylistMember) ProtoMessage() {}
+
+func (x *PlaylistMember) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[114]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaylistMember.ProtoReflect.Descriptor instead.
+func (*PlaylistMember) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{114}
+}
+
+func (x *PlaylistMember) GetPlaylistId() string {
+	if x != nil {
+		return x.PlaylistId
+	}
+	return ""
+}
+
+func (x *PlaylistMember) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PlaylistMember) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *PlaylistMember) GetInvitedBy() string {
+	if x != nil {
+		return x.InvitedBy
+	}
+	return ""
+}
+
+func (x *PlaylistMember) GetJoinedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.JoinedAt
+	}
+	return nil
+}
+
+// PlaylistInvite is a share link that grants a role on a playlist.
+type PlaylistInvite struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Share link token
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// Playlist ID
+	PlaylistId string `protobuf:"bytes,2,opt,name=playlist_id,json=playlistId,proto3" json:"playlist_id,omitempty"`
+	// Role granted on acceptance
+	Role string `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	// Owner who created the link
+	CreatedBy string `protobuf:"bytes,4,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	// Expiry time
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Creation time
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaylistInvite) Reset() {
+	*x = PlaylistInvite{}
+	mi := &file_user_v1_user_proto_msgTypes[115]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaylistInvite) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaylistInvite) ProtoMessage() {}
+
+func (x *PlaylistInvite) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[115]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaylistInvite.ProtoReflect.Descriptor instead.
+func (*PlaylistInvite) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{115}
+}
+
+func (x *PlaylistInvite) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *PlaylistInvite) GetPlaylistId() string {
+	if x != nil {
+		return x.PlaylistId
+	}
+	return ""
+}
+
+func (x *PlaylistInvite) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *PlaylistInvite) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *PlaylistInvite) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *PlaylistInvite) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
 // PlaylistSong represents a song in a playlist.
 type PlaylistSong struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1912,14 +7576,20 @@ type PlaylistSong struct {
 	// Position in playlist (1-based)
 	Position int32 `protobuf:"varint,3,opt,name=position,proto3" json:"position,omitempty"`
 	// When the song was added
-	AddedAt       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=added_at,json=addedAt,proto3" json:"added_at,omitempty"`
+	AddedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=added_at,json=addedAt,proto3" json:"added_at,omitempty"`
+	// Song name (redundant storage)
+	SongName string `protobuf:"bytes,5,opt,name=song_name,json=songName,proto3" json:"song_name,omitempty"`
+	// Artist name (redundant storage)
+	ArtistName string `protobuf:"bytes,6,opt,name=artist_name,json=artistName,proto3" json:"artist_name,omitempty"`
+	// User who added the song (differs from the owner on collaborative playlists)
+	AddedBy       string `protobuf:"bytes,7,opt,name=added_by,json=addedBy,proto3" json:"added_by,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PlaylistSong) Reset() {
 	*x = PlaylistSong{}
-	mi := &file_user_v1_user_proto_msgTypes[28]
+	mi := &file_user_v1_user_proto_msgTypes[116]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1931,7 +7601,7 @@ func (x *PlaylistSong) String() string {
 func (*PlaylistSong) ProtoMessage() {}
 
 func (x *PlaylistSong) ProtoReflect() protoreflect.Message {
-	mi := &file_user_v1_user_proto_msgTypes[28]
+	mi := &file_user_v1_user_proto_msgTypes[116]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1944,7 +7614,7 @@ func (x *PlaylistSong) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PlaylistSong.ProtoReflect.Descriptor instead.
 func (*PlaylistSong) Descriptor() ([]byte, []int) {
-	return file_user_v1_user_proto_rawDescGZIP(), []int{28}
+	return file_user_v1_user_proto_rawDescGZIP(), []int{116}
 }
 
 func (x *PlaylistSong) GetPlaylistId() string {
@@ -1975,6 +7645,27 @@ func (x *PlaylistSong) GetAddedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *PlaylistSong) GetSongName() string {
+	if x != nil {
+		return x.SongName
+	}
+	return ""
+}
+
+func (x *PlaylistSong) GetArtistName() string {
+	if x != nil {
+		return x.ArtistName
+	}
+	return ""
+}
+
+func (x *PlaylistSong) GetAddedBy() string {
+	if x != nil {
+		return x.AddedBy
+	}
+	return ""
+}
+
 var File_user_v1_user_proto protoreflect.FileDescriptor
 
 const file_user_v1_user_proto_rawDesc = "" +
@@ -2000,12 +7691,17 @@ const file_user_v1_user_proto_rawDesc = "" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12)\n" +
 	"\x04type\x18\x02 \x01(\x0e2\x15.user.v1.FavoriteTypeR\x04type\x12\x12\n" +
 	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\x8f\x01\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\xcc\x01\n" +
 	"\x15ListFavoritesResponse\x12/\n" +
 	"\tfavorites\x18\x01 \x03(\v2\x11.user.v1.FavoriteR\tfavorites\x12\x14\n" +
 	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
 	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\xc2\x01\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12;\n" +
+	"\vtype_counts\x18\x05 \x03(\v2\x1a.user.v1.FavoriteTypeCountR\n" +
+	"typeCounts\"T\n" +
+	"\x11FavoriteTypeCount\x12)\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x15.user.v1.FavoriteTypeR\x04type\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"\xc2\x01\n" +
 	"\x15AddPlayHistoryRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
 	"\asong_id\x18\x02 \x01(\tR\x06songId\x12\x1b\n" +
@@ -2028,21 +7724,25 @@ const file_user_v1_user_proto_rawDesc = "" +
 	"\ahistory\x18\x01 \x03(\v2\x14.user.v1.PlayHistoryR\ahistory\x12\x14\n" +
 	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
 	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"~\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\x9f\x01\n" +
 	"\x15CreatePlaylistRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
 	"\tcover_url\x18\x03 \x01(\tR\bcoverUrl\x12\x1b\n" +
-	"\tis_public\x18\x04 \x01(\bR\bisPublic\"G\n" +
+	"\tis_public\x18\x04 \x01(\bR\bisPublic\x12\x1f\n" +
+	"\vsmart_rules\x18\x05 \x01(\tR\n" +
+	"smartRules\"G\n" +
 	"\x16CreatePlaylistResponse\x12-\n" +
-	"\bplaylist\x18\x01 \x01(\v2\x11.user.v1.PlaylistR\bplaylist\"\xb2\x01\n" +
+	"\bplaylist\x18\x01 \x01(\v2\x11.user.v1.PlaylistR\bplaylist\"\xd3\x01\n" +
 	"\x15UpdatePlaylistRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
 	"\vplaylist_id\x18\x02 \x01(\tR\n" +
 	"playlistId\x12\x12\n" +
 	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1b\n" +
 	"\tcover_url\x18\x04 \x01(\tR\bcoverUrl\x12 \n" +
-	"\tis_public\x18\x05 \x01(\bH\x00R\bisPublic\x88\x01\x01B\f\n" +
+	"\tis_public\x18\x05 \x01(\bH\x00R\bisPublic\x88\x01\x01\x12\x1f\n" +
+	"\vsmart_rules\x18\x06 \x01(\tR\n" +
+	"smartRulesB\f\n" +
 	"\n" +
 	"_is_public\"G\n" +
 	"\x16UpdatePlaylistResponse\x12-\n" +
@@ -2075,12 +7775,389 @@ const file_user_v1_user_proto_rawDesc = "" +
 	"\x1eRemoveSongFromPlaylistResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1d\n" +
 	"\n" +
-	"song_count\x18\x02 \x01(\x05R\tsongCount\":\n" +
+	"song_count\x18\x02 \x01(\x05R\tsongCount\"\x82\x01\n" +
+	"\x19AddSongsToPlaylistRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vplaylist_id\x18\x02 \x01(\tR\n" +
+	"playlistId\x12+\n" +
+	"\x05songs\x18\x03 \x03(\v2\x15.user.v1.ImportedSongR\x05songs\"k\n" +
+	"\x1aAddSongsToPlaylistResponse\x12\x14\n" +
+	"\x05added\x18\x01 \x01(\x05R\x05added\x12\x18\n" +
+	"\askipped\x18\x02 \x01(\x05R\askipped\x12\x1d\n" +
+	"\n" +
+	"song_count\x18\x03 \x01(\x05R\tsongCount\"u\n" +
+	"\x1eRemoveSongsFromPlaylistRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vplaylist_id\x18\x02 \x01(\tR\n" +
+	"playlistId\x12\x19\n" +
+	"\bsong_ids\x18\x03 \x03(\tR\asongIds\"Z\n" +
+	"\x1fRemoveSongsFromPlaylistResponse\x12\x18\n" +
+	"\aremoved\x18\x01 \x01(\x05R\aremoved\x12\x1d\n" +
+	"\n" +
+	"song_count\x18\x02 \x01(\x05R\tsongCount\"\x88\x01\n" +
+	"\x17MovePlaylistSongRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vplaylist_id\x18\x02 \x01(\tR\n" +
+	"playlistId\x12\x17\n" +
+	"\asong_id\x18\x03 \x01(\tR\x06songId\x12\x1a\n" +
+	"\bposition\x18\x04 \x01(\x05R\bposition\"4\n" +
+	"\x18MovePlaylistSongResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x8d\x01\n" +
+	"\x18SortPlaylistSongsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vplaylist_id\x18\x02 \x01(\tR\n" +
+	"playlistId\x12\x17\n" +
+	"\asort_by\x18\x03 \x01(\tR\x06sortBy\x12\x1e\n" +
+	"\n" +
+	"descending\x18\x04 \x01(\bR\n" +
+	"descending\"5\n" +
+	"\x19SortPlaylistSongsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"N\n" +
+	"\x12GetPlaylistRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vplaylist_id\x18\x02 \x01(\tR\n" +
+	"playlistId\"D\n" +
+	"\x13GetPlaylistResponse\x12-\n" +
+	"\bplaylist\x18\x01 \x01(\v2\x11.user.v1.PlaylistR\bplaylist\"S\n" +
 	"\x17GetPlaylistSongsRequest\x12\x1f\n" +
 	"\vplaylist_id\x18\x01 \x01(\tR\n" +
-	"playlistId\"G\n" +
+	"playlistId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"G\n" +
 	"\x18GetPlaylistSongsResponse\x12+\n" +
-	"\x05songs\x18\x01 \x03(\v2\x15.user.v1.PlaylistSongR\x05songs\"\xed\x01\n" +
+	"\x05songs\x18\x01 \x03(\v2\x15.user.v1.PlaylistSongR\x05songs\"V\n" +
+	"\x1aListPlaylistMembersRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vplaylist_id\x18\x02 \x01(\tR\n" +
+	"playlistId\"P\n" +
+	"\x1bListPlaylistMembersResponse\x121\n" +
+	"\amembers\x18\x01 \x03(\v2\x17.user.v1.PlaylistMemberR\amembers\"\x88\x01\n" +
+	"\x1bInvitePlaylistMemberRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vplaylist_id\x18\x02 \x01(\tR\n" +
+	"playlistId\x12\x1b\n" +
+	"\tmember_id\x18\x03 \x01(\tR\bmemberId\x12\x12\n" +
+	"\x04role\x18\x04 \x01(\tR\x04role\"O\n" +
+	"\x1cInvitePlaylistMemberResponse\x12/\n" +
+	"\x06member\x18\x01 \x01(\v2\x17.user.v1.PlaylistMemberR\x06member\"t\n" +
+	"\x1bRemovePlaylistMemberRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vplaylist_id\x18\x02 \x01(\tR\n" +
+	"playlistId\x12\x1b\n" +
+	"\tmember_id\x18\x03 \x01(\tR\bmemberId\"8\n" +
+	"\x1cRemovePlaylistMemberResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"k\n" +
+	"\x1bCreatePlaylistInviteRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vplaylist_id\x18\x02 \x01(\tR\n" +
+	"playlistId\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\"O\n" +
+	"\x1cCreatePlaylistInviteResponse\x12/\n" +
+	"\x06invite\x18\x01 \x01(\v2\x17.user.v1.PlaylistInviteR\x06invite\"X\n" +
+	"\x1cRevokePlaylistInvitesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vplaylist_id\x18\x02 \x01(\tR\n" +
+	"playlistId\"9\n" +
+	"\x1dRevokePlaylistInvitesResponse\x12\x18\n" +
+	"\arevoked\x18\x01 \x01(\x03R\arevoked\"L\n" +
+	"\x1bAcceptPlaylistInviteRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"M\n" +
+	"\x1cAcceptPlaylistInviteResponse\x12-\n" +
+	"\bplaylist\x18\x01 \x01(\v2\x11.user.v1.PlaylistR\bplaylist\"f\n" +
+	"\x1aListSharedPlaylistsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"N\n" +
+	"\x1bListSharedPlaylistsResponse\x12/\n" +
+	"\tplaylists\x18\x01 \x03(\v2\x11.user.v1.PlaylistR\tplaylists\"e\n" +
+	"\fImportedSong\x12\x17\n" +
+	"\asong_id\x18\x01 \x01(\tR\x06songId\x12\x1b\n" +
+	"\tsong_name\x18\x02 \x01(\tR\bsongName\x12\x1f\n" +
+	"\vsinger_name\x18\x03 \x01(\tR\n" +
+	"singerName\"\xcd\x01\n" +
+	"\x15ImportPlaylistRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1b\n" +
+	"\tcover_url\x18\x04 \x01(\tR\bcoverUrl\x12\x1b\n" +
+	"\tis_public\x18\x05 \x01(\bR\bisPublic\x12+\n" +
+	"\x05songs\x18\x06 \x03(\v2\x15.user.v1.ImportedSongR\x05songs\"\x83\x01\n" +
+	"\x16ImportPlaylistResponse\x12-\n" +
+	"\bplaylist\x18\x01 \x01(\v2\x11.user.v1.PlaylistR\bplaylist\x12\x1a\n" +
+	"\bimported\x18\x02 \x01(\x05R\bimported\x12\x1e\n" +
+	"\n" +
+	"duplicates\x18\x03 \x01(\x05R\n" +
+	"duplicates\"i\n" +
+	"\x15ExportPlaylistRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vplaylist_id\x18\x02 \x01(\tR\n" +
+	"playlistId\x12\x16\n" +
+	"\x06format\x18\x03 \x01(\tR\x06format\"q\n" +
+	"\x16ExportPlaylistResponse\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\fR\acontent\"a\n" +
+	"\x1aListPublicPlaylistsRequest\x12\x12\n" +
+	"\x04sort\x18\x01 \x01(\tR\x04sort\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"d\n" +
+	"\x1bListPublicPlaylistsResponse\x12/\n" +
+	"\tplaylists\x18\x01 \x03(\v2\x11.user.v1.PlaylistR\tplaylists\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"T\n" +
+	"\x18GetPublicPlaylistRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vplaylist_id\x18\x02 \x01(\tR\n" +
+	"playlistId\"\x9a\x01\n" +
+	"\x19GetPublicPlaylistResponse\x12-\n" +
+	"\bplaylist\x18\x01 \x01(\v2\x11.user.v1.PlaylistR\bplaylist\x12+\n" +
+	"\x05songs\x18\x02 \x03(\v2\x15.user.v1.PlaylistSongR\x05songs\x12!\n" +
+	"\fis_following\x18\x03 \x01(\bR\visFollowing\"Q\n" +
+	"\x15FollowPlaylistRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vplaylist_id\x18\x02 \x01(\tR\n" +
+	"playlistId\"G\n" +
+	"\x16FollowPlaylistResponse\x12-\n" +
+	"\bplaylist\x18\x01 \x01(\v2\x11.user.v1.PlaylistR\bplaylist\"S\n" +
+	"\x17UnfollowPlaylistRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vplaylist_id\x18\x02 \x01(\tR\n" +
+	"playlistId\"4\n" +
+	"\x18UnfollowPlaylistResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"h\n" +
+	"\x1cListFollowedPlaylistsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"f\n" +
+	"\x1dListFollowedPlaylistsResponse\x12/\n" +
+	"\tplaylists\x18\x01 \x03(\v2\x11.user.v1.PlaylistR\tplaylists\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"c\n" +
+	"\x13ForkPlaylistRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vplaylist_id\x18\x02 \x01(\tR\n" +
+	"playlistId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\"E\n" +
+	"\x14ForkPlaylistResponse\x12-\n" +
+	"\bplaylist\x18\x01 \x01(\v2\x11.user.v1.PlaylistR\bplaylist\"g\n" +
+	"\x1bListDeletedPlaylistsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"\x93\x01\n" +
+	"\x1cListDeletedPlaylistsResponse\x126\n" +
+	"\tplaylists\x18\x01 \x03(\v2\x18.user.v1.DeletedPlaylistR\tplaylists\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12%\n" +
+	"\x0eretention_days\x18\x03 \x01(\x05R\rretentionDays\"\xb6\x01\n" +
+	"\x0fDeletedPlaylist\x12-\n" +
+	"\bplaylist\x18\x01 \x01(\v2\x11.user.v1.PlaylistR\bplaylist\x129\n" +
+	"\n" +
+	"deleted_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"g\n" +
+	"\x1bListDeletedFavoritesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"\x93\x01\n" +
+	"\x1cListDeletedFavoritesResponse\x126\n" +
+	"\tfavorites\x18\x01 \x03(\v2\x18.user.v1.DeletedFavoriteR\tfavorites\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12%\n" +
+	"\x0eretention_days\x18\x03 \x01(\x05R\rretentionDays\"\xb6\x01\n" +
+	"\x0fDeletedFavorite\x12-\n" +
+	"\bfavorite\x18\x01 \x01(\v2\x11.user.v1.FavoriteR\bfavorite\x129\n" +
+	"\n" +
+	"deleted_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"R\n" +
+	"\x16RestorePlaylistRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vplaylist_id\x18\x02 \x01(\tR\n" +
+	"playlistId\"H\n" +
+	"\x17RestorePlaylistResponse\x12-\n" +
+	"\bplaylist\x18\x01 \x01(\v2\x11.user.v1.PlaylistR\bplaylist\"R\n" +
+	"\x16RestoreFavoriteRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vfavorite_id\x18\x02 \x01(\tR\n" +
+	"favoriteId\"H\n" +
+	"\x17RestoreFavoriteResponse\x12-\n" +
+	"\bfavorite\x18\x01 \x01(\v2\x11.user.v1.FavoriteR\bfavorite\"0\n" +
+	"\x15ExportUserDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xc5\x04\n" +
+	"\x16ExportUserDataResponse\x12/\n" +
+	"\tfavorites\x18\x01 \x03(\v2\x11.user.v1.FavoriteR\tfavorites\x125\n" +
+	"\tplaylists\x18\x02 \x03(\v2\x17.user.v1.PlaylistExportR\tplaylists\x12.\n" +
+	"\ahistory\x18\x03 \x03(\v2\x14.user.v1.PlayHistoryR\ahistory\x12@\n" +
+	"\x0fdaily_listening\x18\x04 \x03(\v2\x17.user.v1.DailyListeningR\x0edailyListening\x12F\n" +
+	"\x11monthly_listening\x18\x05 \x03(\v2\x19.user.v1.MonthlyListeningR\x10monthlyListening\x121\n" +
+	"\n" +
+	"play_queue\x18\x06 \x01(\v2\x12.user.v1.PlayQueueR\tplayQueue\x12H\n" +
+	"\x12playback_positions\x18\a \x03(\v2\x19.user.v1.PlaybackPositionR\x11playbackPositions\x12J\n" +
+	"\x14playlist_memberships\x18\b \x03(\v2\x17.user.v1.PlaylistMemberR\x13playlistMemberships\x12@\n" +
+	"\x12followed_playlists\x18\t \x03(\v2\x11.user.v1.PlaylistR\x11followedPlaylists\"l\n" +
+	"\x0ePlaylistExport\x12-\n" +
+	"\bplaylist\x18\x01 \x01(\v2\x11.user.v1.PlaylistR\bplaylist\x12+\n" +
+	"\x05songs\x18\x02 \x03(\v2\x15.user.v1.PlaylistSongR\x05songs\"/\n" +
+	"\x14EraseUserDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xc4\x02\n" +
+	"\x15EraseUserDataResponse\x12+\n" +
+	"\x11favorites_deleted\x18\x01 \x01(\x03R\x10favoritesDeleted\x12+\n" +
+	"\x11playlists_deleted\x18\x02 \x01(\x03R\x10playlistsDeleted\x12'\n" +
+	"\x0fhistory_deleted\x18\x03 \x01(\x03R\x0ehistoryDeleted\x12#\n" +
+	"\rstats_deleted\x18\x04 \x01(\x03R\fstatsDeleted\x12/\n" +
+	"\x13memberships_deleted\x18\x05 \x01(\x03R\x12membershipsDeleted\x12'\n" +
+	"\x0ffollows_deleted\x18\x06 \x01(\x03R\x0efollowsDeleted\x12)\n" +
+	"\x10playback_deleted\x18\a \x01(\x03R\x0fplaybackDeleted\"\x86\x01\n" +
+	"\x18GetListeningStatsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tfrom_date\x18\x02 \x01(\tR\bfromDate\x12\x17\n" +
+	"\ato_date\x18\x03 \x01(\tR\x06toDate\x12\x1b\n" +
+	"\ttop_limit\x18\x04 \x01(\x05R\btopLimit\"\x7f\n" +
+	"\x19GetListeningStatsResponse\x123\n" +
+	"\asummary\x18\x01 \x01(\v2\x19.user.v1.ListeningSummaryR\asummary\x12-\n" +
+	"\x05daily\x18\x02 \x03(\v2\x17.user.v1.DailyListeningR\x05daily\"b\n" +
+	"\x16GetYearInReviewRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04year\x18\x02 \x01(\x05R\x04year\x12\x1b\n" +
+	"\ttop_limit\x18\x03 \x01(\x05R\btopLimit\"\xeb\x02\n" +
+	"\x17GetYearInReviewResponse\x12\x12\n" +
+	"\x04year\x18\x01 \x01(\x05R\x04year\x123\n" +
+	"\asummary\x18\x02 \x01(\v2\x19.user.v1.ListeningSummaryR\asummary\x12%\n" +
+	"\x0elistening_days\x18\x03 \x01(\x03R\rlisteningDays\x12%\n" +
+	"\x0edistinct_songs\x18\x04 \x01(\x03R\rdistinctSongs\x12)\n" +
+	"\x10distinct_singers\x18\x05 \x01(\x03R\x0fdistinctSingers\x12\x1b\n" +
+	"\ttop_month\x18\x06 \x01(\tR\btopMonth\x12\x1b\n" +
+	"\tpeak_hour\x18\a \x01(\x05R\bpeakHour\x12!\n" +
+	"\fpeak_weekday\x18\b \x01(\x05R\vpeakWeekday\x121\n" +
+	"\x06months\x18\t \x03(\v2\x19.user.v1.MonthlyListeningR\x06months\"\x85\x02\n" +
+	"\x10ListeningSummary\x12\x1d\n" +
+	"\n" +
+	"play_count\x18\x01 \x01(\x03R\tplayCount\x12)\n" +
+	"\x10seconds_listened\x18\x02 \x01(\x03R\x0fsecondsListened\x12)\n" +
+	"\x10minutes_listened\x18\x03 \x01(\x03R\x0fminutesListened\x12-\n" +
+	"\ttop_songs\x18\x04 \x03(\v2\x10.user.v1.TopSongR\btopSongs\x123\n" +
+	"\vtop_singers\x18\x05 \x03(\v2\x12.user.v1.TopSingerR\n" +
+	"topSingers\x12\x18\n" +
+	"\aheatmap\x18\x06 \x03(\x03R\aheatmap\"\xcb\x01\n" +
+	"\aTopSong\x12\x17\n" +
+	"\asong_id\x18\x01 \x01(\tR\x06songId\x12\x1b\n" +
+	"\tsong_name\x18\x02 \x01(\tR\bsongName\x12\x1f\n" +
+	"\vsinger_name\x18\x03 \x01(\tR\n" +
+	"singerName\x12\x1f\n" +
+	"\valbum_cover\x18\x04 \x01(\tR\n" +
+	"albumCover\x12\x1d\n" +
+	"\n" +
+	"play_count\x18\x05 \x01(\x03R\tplayCount\x12)\n" +
+	"\x10seconds_listened\x18\x06 \x01(\x03R\x0fsecondsListened\"v\n" +
+	"\tTopSinger\x12\x1f\n" +
+	"\vsinger_name\x18\x01 \x01(\tR\n" +
+	"singerName\x12\x1d\n" +
+	"\n" +
+	"play_count\x18\x02 \x01(\x03R\tplayCount\x12)\n" +
+	"\x10seconds_listened\x18\x03 \x01(\x03R\x0fsecondsListened\"n\n" +
+	"\x0eDailyListening\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\x1d\n" +
+	"\n" +
+	"play_count\x18\x02 \x01(\x03R\tplayCount\x12)\n" +
+	"\x10seconds_listened\x18\x03 \x01(\x03R\x0fsecondsListened\"r\n" +
+	"\x10MonthlyListening\x12\x14\n" +
+	"\x05month\x18\x01 \x01(\tR\x05month\x12\x1d\n" +
+	"\n" +
+	"play_count\x18\x02 \x01(\x03R\tplayCount\x12)\n" +
+	"\x10seconds_listened\x18\x03 \x01(\x03R\x0fsecondsListened\"4\n" +
+	"\x19GetRecommendationsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xda\x01\n" +
+	"\x1aGetRecommendationsResponse\x125\n" +
+	"\tdaily_mix\x18\x01 \x03(\v2\x18.user.v1.RecommendedSongR\bdailyMix\x12F\n" +
+	"\x11because_you_liked\x18\x02 \x03(\v2\x1a.user.v1.RecommendationRowR\x0fbecauseYouLiked\x12=\n" +
+	"\fgenerated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vgeneratedAt\"\x96\x01\n" +
+	"\x0fRecommendedSong\x12\x17\n" +
+	"\asong_id\x18\x01 \x01(\tR\x06songId\x12\x1b\n" +
+	"\tsong_name\x18\x02 \x01(\tR\bsongName\x12\x1f\n" +
+	"\vsinger_name\x18\x03 \x01(\tR\n" +
+	"singerName\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x01R\x05score\x12\x16\n" +
+	"\x06source\x18\x05 \x01(\tR\x06source\"\xb5\x01\n" +
+	"\x11RecommendationRow\x12 \n" +
+	"\fseed_song_id\x18\x01 \x01(\tR\n" +
+	"seedSongId\x12$\n" +
+	"\x0eseed_song_name\x18\x02 \x01(\tR\fseedSongName\x12(\n" +
+	"\x10seed_singer_name\x18\x03 \x01(\tR\x0eseedSingerName\x12.\n" +
+	"\x05songs\x18\x04 \x03(\v2\x18.user.v1.RecommendedSongR\x05songs\"b\n" +
+	"\x16ListNewReleasesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"`\n" +
+	"\x17ListNewReleasesResponse\x12/\n" +
+	"\breleases\x18\x01 \x03(\v2\x13.user.v1.NewReleaseR\breleases\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"\x85\x02\n" +
+	"\n" +
+	"NewRelease\x12\x1b\n" +
+	"\tsinger_id\x18\x01 \x01(\tR\bsingerId\x12\x1f\n" +
+	"\vsinger_name\x18\x02 \x01(\tR\n" +
+	"singerName\x12\x19\n" +
+	"\balbum_id\x18\x03 \x01(\tR\aalbumId\x12\x1d\n" +
+	"\n" +
+	"album_name\x18\x04 \x01(\tR\talbumName\x12\x1b\n" +
+	"\tcover_url\x18\x05 \x01(\tR\bcoverUrl\x12!\n" +
+	"\frelease_date\x18\x06 \x01(\tR\vreleaseDate\x12?\n" +
+	"\rdiscovered_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\fdiscoveredAt\".\n" +
+	"\x13GetPlayQueueRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"@\n" +
+	"\x14GetPlayQueueResponse\x12(\n" +
+	"\x05queue\x18\x01 \x01(\v2\x12.user.v1.PlayQueueR\x05queue\"\xee\x01\n" +
+	"\x14SavePlayQueueRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tdevice_id\x18\x02 \x01(\tR\bdeviceId\x12+\n" +
+	"\x06tracks\x18\x03 \x03(\v2\x13.user.v1.QueueTrackR\x06tracks\x12#\n" +
+	"\rcurrent_index\x18\x04 \x01(\x05R\fcurrentIndex\x12\x18\n" +
+	"\ashuffle\x18\x05 \x01(\bR\ashuffle\x124\n" +
+	"\vrepeat_mode\x18\x06 \x01(\x0e2\x13.user.v1.RepeatModeR\n" +
+	"repeatMode\"A\n" +
+	"\x15SavePlayQueueResponse\x12(\n" +
+	"\x05queue\x18\x01 \x01(\v2\x12.user.v1.PlayQueueR\x05queue\"u\n" +
+	"\x18SetPlayQueueIndexRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tdevice_id\x18\x02 \x01(\tR\bdeviceId\x12#\n" +
+	"\rcurrent_index\x18\x03 \x01(\x05R\fcurrentIndex\"E\n" +
+	"\x19SetPlayQueueIndexResponse\x12(\n" +
+	"\x05queue\x18\x01 \x01(\v2\x12.user.v1.PlayQueueR\x05queue\"\xb0\x01\n" +
+	"\x1dUpdatePlaybackPositionRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tdevice_id\x18\x02 \x01(\tR\bdeviceId\x12\x17\n" +
+	"\asong_id\x18\x03 \x01(\tR\x06songId\x12\x1f\n" +
+	"\vposition_ms\x18\x04 \x01(\x03R\n" +
+	"positionMs\x12\x1f\n" +
+	"\vduration_ms\x18\x05 \x01(\x03R\n" +
+	"durationMs\"W\n" +
+	"\x1eUpdatePlaybackPositionResponse\x125\n" +
+	"\bposition\x18\x01 \x01(\v2\x19.user.v1.PlaybackPositionR\bposition\"Q\n" +
+	"\x1bGetPlaybackPositionsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x19\n" +
+	"\bsong_ids\x18\x02 \x03(\tR\asongIds\"W\n" +
+	"\x1cGetPlaybackPositionsResponse\x127\n" +
+	"\tpositions\x18\x01 \x03(\v2\x19.user.v1.PlaybackPositionR\tpositions\"\x85\x02\n" +
+	"\tPlayQueue\x12+\n" +
+	"\x06tracks\x18\x01 \x03(\v2\x13.user.v1.QueueTrackR\x06tracks\x12#\n" +
+	"\rcurrent_index\x18\x02 \x01(\x05R\fcurrentIndex\x12\x18\n" +
+	"\ashuffle\x18\x03 \x01(\bR\ashuffle\x124\n" +
+	"\vrepeat_mode\x18\x04 \x01(\x0e2\x13.user.v1.RepeatModeR\n" +
+	"repeatMode\x12\x1b\n" +
+	"\tdevice_id\x18\x05 \x01(\tR\bdeviceId\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x7f\n" +
+	"\n" +
+	"QueueTrack\x12\x17\n" +
+	"\asong_id\x18\x01 \x01(\tR\x06songId\x12\x1b\n" +
+	"\tsong_name\x18\x02 \x01(\tR\bsongName\x12\x1f\n" +
+	"\vsinger_name\x18\x03 \x01(\tR\n" +
+	"singerName\x12\x1a\n" +
+	"\bduration\x18\x04 \x01(\x05R\bduration\"\xc5\x01\n" +
+	"\x10PlaybackPosition\x12\x17\n" +
+	"\asong_id\x18\x01 \x01(\tR\x06songId\x12\x1f\n" +
+	"\vposition_ms\x18\x02 \x01(\x03R\n" +
+	"positionMs\x12\x1f\n" +
+	"\vduration_ms\x18\x03 \x01(\x03R\n" +
+	"durationMs\x12\x1b\n" +
+	"\tdevice_id\x18\x04 \x01(\tR\bdeviceId\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xed\x01\n" +
 	"\bFavorite\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12)\n" +
@@ -2088,11 +8165,16 @@ const file_user_v1_user_proto_rawDesc = "" +
 	"\ttarget_id\x18\x04 \x01(\tR\btargetId\x125\n" +
 	"\bmetadata\x18\x05 \x01(\v2\x19.user.v1.FavoriteMetadataR\bmetadata\x129\n" +
 	"\n" +
-	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"[\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xd1\x01\n" +
 	"\x10FavoriteMetadata\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
 	"\x06artist\x18\x02 \x01(\tR\x06artist\x12\x1b\n" +
-	"\tcover_url\x18\x03 \x01(\tR\bcoverUrl\"\x81\x02\n" +
+	"\tcover_url\x18\x03 \x01(\tR\bcoverUrl\x12:\n" +
+	"\x05extra\x18\x04 \x03(\v2$.user.v1.FavoriteMetadata.ExtraEntryR\x05extra\x1a8\n" +
+	"\n" +
+	"ExtraEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x81\x02\n" +
 	"\vPlayHistory\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x17\n" +
@@ -2103,7 +8185,7 @@ const file_user_v1_user_proto_rawDesc = "" +
 	"\n" +
 	"album_name\x18\x06 \x01(\tR\talbumName\x12\x1a\n" +
 	"\bduration\x18\a \x01(\x05R\bduration\x127\n" +
-	"\tplayed_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\bplayedAt\"\x96\x02\n" +
+	"\tplayed_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\bplayedAt\"\xa1\x03\n" +
 	"\bPlaylist\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
@@ -2115,19 +8197,56 @@ const file_user_v1_user_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x9b\x01\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12 \n" +
+	"\vdescription\x18\t \x01(\tR\vdescription\x12\x1f\n" +
+	"\vsmart_rules\x18\n" +
+	" \x01(\tR\n" +
+	"smartRules\x12%\n" +
+	"\x0efollower_count\x18\v \x01(\x05R\rfollowerCount\x12\x1f\n" +
+	"\vforked_from\x18\f \x01(\tR\n" +
+	"forkedFrom\"\xb6\x01\n" +
+	"\x0ePlaylistMember\x12\x1f\n" +
+	"\vplaylist_id\x18\x01 \x01(\tR\n" +
+	"playlistId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\x12\x1d\n" +
+	"\n" +
+	"invited_by\x18\x04 \x01(\tR\tinvitedBy\x127\n" +
+	"\tjoined_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bjoinedAt\"\xf0\x01\n" +
+	"\x0ePlaylistInvite\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1f\n" +
+	"\vplaylist_id\x18\x02 \x01(\tR\n" +
+	"playlistId\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x04 \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xf4\x01\n" +
 	"\fPlaylistSong\x12\x1f\n" +
 	"\vplaylist_id\x18\x01 \x01(\tR\n" +
 	"playlistId\x12\x17\n" +
 	"\asong_id\x18\x02 \x01(\tR\x06songId\x12\x1a\n" +
 	"\bposition\x18\x03 \x01(\x05R\bposition\x125\n" +
-	"\badded_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aaddedAt*\x8e\x01\n" +
+	"\badded_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aaddedAt\x12\x1b\n" +
+	"\tsong_name\x18\x05 \x01(\tR\bsongName\x12\x1f\n" +
+	"\vartist_name\x18\x06 \x01(\tR\n" +
+	"artistName\x12\x19\n" +
+	"\badded_by\x18\a \x01(\tR\aaddedBy*\xaa\x01\n" +
 	"\fFavoriteType\x12\x1d\n" +
 	"\x19FAVORITE_TYPE_UNSPECIFIED\x10\x00\x12\x16\n" +
 	"\x12FAVORITE_TYPE_SONG\x10\x01\x12\x17\n" +
 	"\x13FAVORITE_TYPE_ALBUM\x10\x02\x12\x18\n" +
 	"\x14FAVORITE_TYPE_ARTIST\x10\x03\x12\x14\n" +
-	"\x10FAVORITE_TYPE_MV\x10\x042\x8c\b\n" +
+	"\x10FAVORITE_TYPE_MV\x10\x04\x12\x1a\n" +
+	"\x16FAVORITE_TYPE_PLAYLIST\x10\x05*h\n" +
+	"\n" +
+	"RepeatMode\x12\x1b\n" +
+	"\x17REPEAT_MODE_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fREPEAT_MODE_OFF\x10\x01\x12\x13\n" +
+	"\x0fREPEAT_MODE_ALL\x10\x02\x12\x13\n" +
+	"\x0fREPEAT_MODE_ONE\x10\x032\xa6!\n" +
 	"\vUserService\x12H\n" +
 	"\vAddFavorite\x12\x1b.user.v1.AddFavoriteRequest\x1a\x1c.user.v1.AddFavoriteResponse\x12Q\n" +
 	"\x0eRemoveFavorite\x12\x1e.user.v1.RemoveFavoriteRequest\x1a\x1f.user.v1.RemoveFavoriteResponse\x12N\n" +
@@ -2139,8 +8258,43 @@ const file_user_v1_user_proto_rawDesc = "" +
 	"\x0eDeletePlaylist\x12\x1e.user.v1.DeletePlaylistRequest\x1a\x1f.user.v1.DeletePlaylistResponse\x12N\n" +
 	"\rListPlaylists\x12\x1d.user.v1.ListPlaylistsRequest\x1a\x1e.user.v1.ListPlaylistsResponse\x12Z\n" +
 	"\x11AddSongToPlaylist\x12!.user.v1.AddSongToPlaylistRequest\x1a\".user.v1.AddSongToPlaylistResponse\x12i\n" +
-	"\x16RemoveSongFromPlaylist\x12&.user.v1.RemoveSongFromPlaylistRequest\x1a'.user.v1.RemoveSongFromPlaylistResponse\x12W\n" +
-	"\x10GetPlaylistSongs\x12 .user.v1.GetPlaylistSongsRequest\x1a!.user.v1.GetPlaylistSongsResponseB=Z;github.com/xiaoxiao0301/listen-stream-v2/server/shared/proto/user/v1;userv1b\x06proto3"
+	"\x16RemoveSongFromPlaylist\x12&.user.v1.RemoveSongFromPlaylistRequest\x1a'.user.v1.RemoveSongFromPlaylistResponse\x12]\n" +
+	"\x12AddSongsToPlaylist\x12\".user.v1.AddSongsToPlaylistRequest\x1a#.user.v1.AddSongsToPlaylistResponse\x12l\n" +
+	"\x17RemoveSongsFromPlaylist\x12'.user.v1.RemoveSongsFromPlaylistRequest\x1a(.user.v1.RemoveSongsFromPlaylistResponse\x12W\n" +
+	"\x10MovePlaylistSong\x12 .user.v1.MovePlaylistSongRequest\x1a!.user.v1.MovePlaylistSongResponse\x12Z\n" +
+	"\x11SortPlaylistSongs\x12!.user.v1.SortPlaylistSongsRequest\x1a\".user.v1.SortPlaylistSongsResponse\x12H\n" +
+	"\vGetPlaylist\x12\x1b.user.v1.GetPlaylistRequest\x1a\x1c.user.v1.GetPlaylistResponse\x12W\n" +
+	"\x10GetPlaylistSongs\x12 .user.v1.GetPlaylistSongsRequest\x1a!.user.v1.GetPlaylistSongsResponse\x12`\n" +
+	"\x13ListPlaylistMembers\x12#.user.v1.ListPlaylistMembersRequest\x1a$.user.v1.ListPlaylistMembersResponse\x12c\n" +
+	"\x14InvitePlaylistMember\x12$.user.v1.InvitePlaylistMemberRequest\x1a%.user.v1.InvitePlaylistMemberResponse\x12c\n" +
+	"\x14RemovePlaylistMember\x12$.user.v1.RemovePlaylistMemberRequest\x1a%.user.v1.RemovePlaylistMemberResponse\x12c\n" +
+	"\x14CreatePlaylistInvite\x12$.user.v1.CreatePlaylistInviteRequest\x1a%.user.v1.CreatePlaylistInviteResponse\x12f\n" +
+	"\x15RevokePlaylistInvites\x12%.user.v1.RevokePlaylistInvitesRequest\x1a&.user.v1.RevokePlaylistInvitesResponse\x12c\n" +
+	"\x14AcceptPlaylistInvite\x12$.user.v1.AcceptPlaylistInviteRequest\x1a%.user.v1.AcceptPlaylistInviteResponse\x12`\n" +
+	"\x13ListSharedPlaylists\x12#.user.v1.ListSharedPlaylistsRequest\x1a$.user.v1.ListSharedPlaylistsResponse\x12Q\n" +
+	"\x0eImportPlaylist\x12\x1e.user.v1.ImportPlaylistRequest\x1a\x1f.user.v1.ImportPlaylistResponse\x12Q\n" +
+	"\x0eExportPlaylist\x12\x1e.user.v1.ExportPlaylistRequest\x1a\x1f.user.v1.ExportPlaylistResponse\x12`\n" +
+	"\x13ListPublicPlaylists\x12#.user.v1.ListPublicPlaylistsRequest\x1a$.user.v1.ListPublicPlaylistsResponse\x12Z\n" +
+	"\x11GetPublicPlaylist\x12!.user.v1.GetPublicPlaylistRequest\x1a\".user.v1.GetPublicPlaylistResponse\x12Q\n" +
+	"\x0eFollowPlaylist\x12\x1e.user.v1.FollowPlaylistRequest\x1a\x1f.user.v1.FollowPlaylistResponse\x12W\n" +
+	"\x10UnfollowPlaylist\x12 .user.v1.UnfollowPlaylistRequest\x1a!.user.v1.UnfollowPlaylistResponse\x12f\n" +
+	"\x15ListFollowedPlaylists\x12%.user.v1.ListFollowedPlaylistsRequest\x1a&.user.v1.ListFollowedPlaylistsResponse\x12K\n" +
+	"\fForkPlaylist\x12\x1c.user.v1.ForkPlaylistRequest\x1a\x1d.user.v1.ForkPlaylistResponse\x12c\n" +
+	"\x14ListDeletedPlaylists\x12$.user.v1.ListDeletedPlaylistsRequest\x1a%.user.v1.ListDeletedPlaylistsResponse\x12c\n" +
+	"\x14ListDeletedFavorites\x12$.user.v1.ListDeletedFavoritesRequest\x1a%.user.v1.ListDeletedFavoritesResponse\x12T\n" +
+	"\x0fRestorePlaylist\x12\x1f.user.v1.RestorePlaylistRequest\x1a .user.v1.RestorePlaylistResponse\x12T\n" +
+	"\x0fRestoreFavorite\x12\x1f.user.v1.RestoreFavoriteRequest\x1a .user.v1.RestoreFavoriteResponse\x12Q\n" +
+	"\x0eExportUserData\x12\x1e.user.v1.ExportUserDataRequest\x1a\x1f.user.v1.ExportUserDataResponse\x12N\n" +
+	"\rEraseUserData\x12\x1d.user.v1.EraseUserDataRequest\x1a\x1e.user.v1.EraseUserDataResponse\x12Z\n" +
+	"\x11GetListeningStats\x12!.user.v1.GetListeningStatsRequest\x1a\".user.v1.GetListeningStatsResponse\x12T\n" +
+	"\x0fGetYearInReview\x12\x1f.user.v1.GetYearInReviewRequest\x1a .user.v1.GetYearInReviewResponse\x12]\n" +
+	"\x12GetRecommendations\x12\".user.v1.GetRecommendationsRequest\x1a#.user.v1.GetRecommendationsResponse\x12T\n" +
+	"\x0fListNewReleases\x12\x1f.user.v1.ListNewReleasesRequest\x1a .user.v1.ListNewReleasesResponse\x12K\n" +
+	"\fGetPlayQueue\x12\x1c.user.v1.GetPlayQueueRequest\x1a\x1d.user.v1.GetPlayQueueResponse\x12N\n" +
+	"\rSavePlayQueue\x12\x1d.user.v1.SavePlayQueueRequest\x1a\x1e.user.v1.SavePlayQueueResponse\x12Z\n" +
+	"\x11SetPlayQueueIndex\x12!.user.v1.SetPlayQueueIndexRequest\x1a\".user.v1.SetPlayQueueIndexResponse\x12i\n" +
+	"\x16UpdatePlaybackPosition\x12&.user.v1.UpdatePlaybackPositionRequest\x1a'.user.v1.UpdatePlaybackPositionResponse\x12c\n" +
+	"\x14GetPlaybackPositions\x12$.user.v1.GetPlaybackPositionsRequest\x1a%.user.v1.GetPlaybackPositionsResponseBMZKgithub.com/xiaoxiao0301/listen-stream-v2/server/shared/proto/user/v1;userv1b\x06proto3"
 
 var (
 	file_user_v1_user_proto_rawDescOnce sync.Once
@@ -2154,89 +8308,314 @@ func file_user_v1_user_proto_rawDescGZIP() []byte {
 	return file_user_v1_user_proto_rawDescData
 }
 
-var file_user_v1_user_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_user_v1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
+var file_user_v1_user_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_user_v1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 118)
 var file_user_v1_user_proto_goTypes = []any{
-	(FavoriteType)(0),                      // 0: user.v1.FavoriteType
-	(*AddFavoriteRequest)(nil),             // 1: user.v1.AddFavoriteRequest
-	(*AddFavoriteResponse)(nil),            // 2: user.v1.AddFavoriteResponse
-	(*RemoveFavoriteRequest)(nil),          // 3: user.v1.RemoveFavoriteRequest
-	(*RemoveFavoriteResponse)(nil),         // 4: user.v1.RemoveFavoriteResponse
-	(*ListFavoritesRequest)(nil),           // 5: user.v1.ListFavoritesRequest
-	(*ListFavoritesResponse)(nil),          // 6: user.v1.ListFavoritesResponse
-	(*AddPlayHistoryRequest)(nil),          // 7: user.v1.AddPlayHistoryRequest
-	(*AddPlayHistoryResponse)(nil),         // 8: user.v1.AddPlayHistoryResponse
-	(*ListPlayHistoryRequest)(nil),         // 9: user.v1.ListPlayHistoryRequest
-	(*ListPlayHistoryResponse)(nil),        // 10: user.v1.ListPlayHistoryResponse
-	(*CreatePlaylistRequest)(nil),          // 11: user.v1.CreatePlaylistRequest
-	(*CreatePlaylistResponse)(nil),         // 12: user.v1.CreatePlaylistResponse
-	(*UpdatePlaylistRequest)(nil),          // 13: user.v1.UpdatePlaylistRequest
-	(*UpdatePlaylistResponse)(nil),         // 14: user.v1.UpdatePlaylistResponse
-	(*DeletePlaylistRequest)(nil),          // 15: user.v1.DeletePlaylistRequest
-	(*DeletePlaylistResponse)(nil),         // 16: user.v1.DeletePlaylistResponse
-	(*ListPlaylistsRequest)(nil),           // 17: user.v1.ListPlaylistsRequest
-	(*ListPlaylistsResponse)(nil),          // 18: user.v1.ListPlaylistsResponse
-	(*AddSongToPlaylistRequest)(nil),       // 19: user.v1.AddSongToPlaylistRequest
-	(*AddSongToPlaylistResponse)(nil),      // 20: user.v1.AddSongToPlaylistResponse
-	(*RemoveSongFromPlaylistRequest)(nil),  // 21: user.v1.RemoveSongFromPlaylistRequest
-	(*RemoveSongFromPlaylistResponse)(nil), // 22: user.v1.RemoveSongFromPlaylistResponse
-	(*GetPlaylistSongsRequest)(nil),        // 23: user.v1.GetPlaylistSongsRequest
-	(*GetPlaylistSongsResponse)(nil),       // 24: user.v1.GetPlaylistSongsResponse
-	(*Favorite)(nil),                       // 25: user.v1.Favorite
-	(*FavoriteMetadata)(nil),               // 26: user.v1.FavoriteMetadata
-	(*PlayHistory)(nil),                    // 27: user.v1.PlayHistory
-	(*Playlist)(nil),                       // 28: user.v1.Playlist
-	(*PlaylistSong)(nil),                   // 29: user.v1.PlaylistSong
-	(*timestamppb.Timestamp)(nil),          // 30: google.protobuf.Timestamp
+	(FavoriteType)(0),                       // 0: user.v1.FavoriteType
+	(RepeatMode)(0),                         // 1: user.v1.RepeatMode
+	(*AddFavoriteRequest)(nil),              // 2: user.v1.AddFavoriteRequest
+	(*AddFavoriteResponse)(nil),             // 3: user.v1.AddFavoriteResponse
+	(*RemoveFavoriteRequest)(nil),           // 4: user.v1.RemoveFavoriteRequest
+	(*RemoveFavoriteResponse)(nil),          // 5: user.v1.RemoveFavoriteResponse
+	(*ListFavoritesRequest)(nil),            // 6: user.v1.ListFavoritesRequest
+	(*ListFavoritesResponse)(nil),           // 7: user.v1.ListFavoritesResponse
+	(*FavoriteTypeCount)(nil),               // 8: user.v1.FavoriteTypeCount
+	(*AddPlayHistoryRequest)(nil),           // 9: user.v1.AddPlayHistoryRequest
+	(*AddPlayHistoryResponse)(nil),          // 10: user.v1.AddPlayHistoryResponse
+	(*ListPlayHistoryRequest)(nil),          // 11: user.v1.ListPlayHistoryRequest
+	(*ListPlayHistoryResponse)(nil),         // 12: user.v1.ListPlayHistoryResponse
+	(*CreatePlaylistRequest)(nil),           // 13: user.v1.CreatePlaylistRequest
+	(*CreatePlaylistResponse)(nil),          // 14: user.v1.CreatePlaylistResponse
+	(*UpdatePlaylistRequest)(nil),           // 15: user.v1.UpdatePlaylistRequest
+	(*UpdatePlaylistResponse)(nil),          // 16: user.v1.UpdatePlaylistResponse
+	(*DeletePlaylistRequest)(nil),           // 17: user.v1.DeletePlaylistRequest
+	(*DeletePlaylistResponse)(nil),          // 18: user.v1.DeletePlaylistResponse
+	(*ListPlaylistsRequest)(nil),            // 19: user.v1.ListPlaylistsRequest
+	(*ListPlaylistsResponse)(nil),           // 20: user.v1.ListPlaylistsResponse
+	(*AddSongToPlaylistRequest)(nil),        // 21: user.v1.AddSongToPlaylistRequest
+	(*AddSongToPlaylistResponse)(nil),       // 22: user.v1.AddSongToPlaylistResponse
+	(*RemoveSongFromPlaylistRequest)(nil),   // 23: user.v1.RemoveSongFromPlaylistRequest
+	(*RemoveSongFromPlaylistResponse)(nil),  // 24: user.v1.RemoveSongFromPlaylistResponse
+	(*AddSongsToPlaylistRequest)(nil),       // 25: user.v1.AddSongsToPlaylistRequest
+	(*AddSongsToPlaylistResponse)(nil),      // 26: user.v1.AddSongsToPlaylistResponse
+	(*RemoveSongsFromPlaylistRequest)(nil),  // 27: user.v1.RemoveSongsFromPlaylistRequest
+	(*RemoveSongsFromPlaylistResponse)(nil), // 28: user.v1.RemoveSongsFromPlaylistResponse
+	(*MovePlaylistSongRequest)(nil),         // 29: user.v1.MovePlaylistSongRequest
+	(*MovePlaylistSongResponse)(nil),        // 30: user.v1.MovePlaylistSongResponse
+	(*SortPlaylistSongsRequest)(nil),        // 31: user.v1.SortPlaylistSongsRequest
+	(*SortPlaylistSongsResponse)(nil),       // 32: user.v1.SortPlaylistSongsResponse
+	(*GetPlaylistRequest)(nil),              // 33: user.v1.GetPlaylistRequest
+	(*GetPlaylistResponse)(nil),             // 34: user.v1.GetPlaylistResponse
+	(*GetPlaylistSongsRequest)(nil),         // 35: user.v1.GetPlaylistSongsRequest
+	(*GetPlaylistSongsResponse)(nil),        // 36: user.v1.GetPlaylistSongsResponse
+	(*ListPlaylistMembersRequest)(nil),      // 37: user.v1.ListPlaylistMembersRequest
+	(*ListPlaylistMembersResponse)(nil),     // 38: user.v1.ListPlaylistMembersResponse
+	(*InvitePlaylistMemberRequest)(nil),     // 39: user.v1.InvitePlaylistMemberRequest
+	(*InvitePlaylistMemberResponse)(nil),    // 40: user.v1.InvitePlaylistMemberResponse
+	(*RemovePlaylistMemberRequest)(nil),     // 41: user.v1.RemovePlaylistMemberRequest
+	(*RemovePlaylistMemberResponse)(nil),    // 42: user.v1.RemovePlaylistMemberResponse
+	(*CreatePlaylistInviteRequest)(nil),     // 43: user.v1.CreatePlaylistInviteRequest
+	(*CreatePlaylistInviteResponse)(nil),    // 44: user.v1.CreatePlaylistInviteResponse
+	(*RevokePlaylistInvitesRequest)(nil),    // 45: user.v1.RevokePlaylistInvitesRequest
+	(*RevokePlaylistInvitesResponse)(nil),   // 46: user.v1.RevokePlaylistInvitesResponse
+	(*AcceptPlaylistInviteRequest)(nil),     // 47: user.v1.AcceptPlaylistInviteRequest
+	(*AcceptPlaylistInviteResponse)(nil),    // 48: user.v1.AcceptPlaylistInviteResponse
+	(*ListSharedPlaylistsRequest)(nil),      // 49: user.v1.ListSharedPlaylistsRequest
+	(*ListSharedPlaylistsResponse)(nil),     // 50: user.v1.ListSharedPlaylistsResponse
+	(*ImportedSong)(nil),                    // 51: user.v1.ImportedSong
+	(*ImportPlaylistRequest)(nil),           // 52: user.v1.ImportPlaylistRequest
+	(*ImportPlaylistResponse)(nil),          // 53: user.v1.ImportPlaylistResponse
+	(*ExportPlaylistRequest)(nil),           // 54: user.v1.ExportPlaylistRequest
+	(*ExportPlaylistResponse)(nil),          // 55: user.v1.ExportPlaylistResponse
+	(*ListPublicPlaylistsRequest)(nil),      // 56: user.v1.ListPublicPlaylistsRequest
+	(*ListPublicPlaylistsResponse)(nil),     // 57: user.v1.ListPublicPlaylistsResponse
+	(*GetPublicPlaylistRequest)(nil),        // 58: user.v1.GetPublicPlaylistRequest
+	(*GetPublicPlaylistResponse)(nil),       // 59: user.v1.GetPublicPlaylistResponse
+	(*FollowPlaylistRequest)(nil),           // 60: user.v1.FollowPlaylistRequest
+	(*FollowPlaylistResponse)(nil),          // 61: user.v1.FollowPlaylistResponse
+	(*UnfollowPlaylistRequest)(nil),         // 62: user.v1.UnfollowPlaylistRequest
+	(*UnfollowPlaylistResponse)(nil),        // 63: user.v1.UnfollowPlaylistResponse
+	(*ListFollowedPlaylistsRequest)(nil),    // 64: user.v1.ListFollowedPlaylistsRequest
+	(*ListFollowedPlaylistsResponse)(nil),   // 65: user.v1.ListFollowedPlaylistsResponse
+	(*ForkPlaylistRequest)(nil),             // 66: user.v1.ForkPlaylistRequest
+	(*ForkPlaylistResponse)(nil),            // 67: user.v1.ForkPlaylistResponse
+	(*ListDeletedPlaylistsRequest)(nil),     // 68: user.v1.ListDeletedPlaylistsRequest
+	(*ListDeletedPlaylistsResponse)(nil),    // 69: user.v1.ListDeletedPlaylistsResponse
+	(*DeletedPlaylist)(nil),                 // 70: user.v1.DeletedPlaylist
+	(*ListDeletedFavoritesRequest)(nil),     // 71: user.v1.ListDeletedFavoritesRequest
+	(*ListDeletedFavoritesResponse)(nil),    // 72: user.v1.ListDeletedFavoritesResponse
+	(*DeletedFavorite)(nil),                 // 73: user.v1.DeletedFavorite
+	(*RestorePlaylistRequest)(nil),          // 74: user.v1.RestorePlaylistRequest
+	(*RestorePlaylistResponse)(nil),         // 75: user.v1.RestorePlaylistResponse
+	(*RestoreFavoriteRequest)(nil),          // 76: user.v1.RestoreFavoriteRequest
+	(*RestoreFavoriteResponse)(nil),         // 77: user.v1.RestoreFavoriteResponse
+	(*ExportUserDataRequest)(nil),           // 78: user.v1.ExportUserDataRequest
+	(*ExportUserDataResponse)(nil),          // 79: user.v1.ExportUserDataResponse
+	(*PlaylistExport)(nil),                  // 80: user.v1.PlaylistExport
+	(*EraseUserDataRequest)(nil),            // 81: user.v1.EraseUserDataRequest
+	(*EraseUserDataResponse)(nil),           // 82: user.v1.EraseUserDataResponse
+	(*GetListeningStatsRequest)(nil),        // 83: user.v1.GetListeningStatsRequest
+	(*GetListeningStatsResponse)(nil),       // 84: user.v1.GetListeningStatsResponse
+	(*GetYearInReviewRequest)(nil),          // 85: user.v1.GetYearInReviewRequest
+	(*GetYearInReviewResponse)(nil),         // 86: user.v1.GetYearInReviewResponse
+	(*ListeningSummary)(nil),                // 87: user.v1.ListeningSummary
+	(*TopSong)(nil),                         // 88: user.v1.TopSong
+	(*TopSinger)(nil),                       // 89: user.v1.TopSinger
+	(*DailyListening)(nil),                  // 90: user.v1.DailyListening
+	(*MonthlyListening)(nil),                // 91: user.v1.MonthlyListening
+	(*GetRecommendationsRequest)(nil),       // 92: user.v1.GetRecommendationsRequest
+	(*GetRecommendationsResponse)(nil),      // 93: user.v1.GetRecommendationsResponse
+	(*RecommendedSong)(nil),                 // 94: user.v1.RecommendedSong
+	(*RecommendationRow)(nil),               // 95: user.v1.RecommendationRow
+	(*ListNewReleasesRequest)(nil),          // 96: user.v1.ListNewReleasesRequest
+	(*ListNewReleasesResponse)(nil),         // 97: user.v1.ListNewReleasesResponse
+	(*NewRelease)(nil),                      // 98: user.v1.NewRelease
+	(*GetPlayQueueRequest)(nil),             // 99: user.v1.GetPlayQueueRequest
+	(*GetPlayQueueResponse)(nil),            // 100: user.v1.GetPlayQueueResponse
+	(*SavePlayQueueRequest)(nil),            // 101: user.v1.SavePlayQueueRequest
+	(*SavePlayQueueResponse)(nil),           // 102: user.v1.SavePlayQueueResponse
+	(*SetPlayQueueIndexRequest)(nil),        // 103: user.v1.SetPlayQueueIndexRequest
+	(*SetPlayQueueIndexResponse)(nil),       // 104: user.v1.SetPlayQueueIndexResponse
+	(*UpdatePlaybackPositionRequest)(nil),   // 105: user.v1.UpdatePlaybackPositionRequest
+	(*UpdatePlaybackPositionResponse)(nil),  // 106: user.v1.UpdatePlaybackPositionResponse
+	(*GetPlaybackPositionsRequest)(nil),     // 107: user.v1.GetPlaybackPositionsRequest
+	(*GetPlaybackPositionsResponse)(nil),    // 108: user.v1.GetPlaybackPositionsResponse
+	(*PlayQueue)(nil),                       // 109: user.v1.PlayQueue
+	(*QueueTrack)(nil),                      // 110: user.v1.QueueTrack
+	(*PlaybackPosition)(nil),                // 111: user.v1.PlaybackPosition
+	(*Favorite)(nil),                        // 112: user.v1.Favorite
+	(*FavoriteMetadata)(nil),                // 113: user.v1.FavoriteMetadata
+	(*PlayHistory)(nil),                     // 114: user.v1.PlayHistory
+	(*Playlist)(nil),                        // 115: user.v1.Playlist
+	(*PlaylistMember)(nil),                  // 116: user.v1.PlaylistMember
+	(*PlaylistInvite)(nil),                  // 117: user.v1.PlaylistInvite
+	(*PlaylistSong)(nil),                    // 118: user.v1.PlaylistSong
+	nil,                                     // 119: user.v1.FavoriteMetadata.ExtraEntry
+	(*timestamppb.Timestamp)(nil),           // 120: google.protobuf.Timestamp
 }
 var file_user_v1_user_proto_depIdxs = []int32{
-	0,  // 0: user.v1.AddFavoriteRequest.type:type_name -> user.v1.FavoriteType
-	26, // 1: user.v1.AddFavoriteRequest.metadata:type_name -> user.v1.FavoriteMetadata
-	30, // 2: user.v1.AddFavoriteResponse.created_at:type_name -> google.protobuf.Timestamp
-	0,  // 3: user.v1.ListFavoritesRequest.type:type_name -> user.v1.FavoriteType
-	25, // 4: user.v1.ListFavoritesResponse.favorites:type_name -> user.v1.Favorite
-	30, // 5: user.v1.AddPlayHistoryResponse.played_at:type_name -> google.protobuf.Timestamp
-	27, // 6: user.v1.ListPlayHistoryResponse.history:type_name -> user.v1.PlayHistory
-	28, // 7: user.v1.CreatePlaylistResponse.playlist:type_name -> user.v1.Playlist
-	28, // 8: user.v1.UpdatePlaylistResponse.playlist:type_name -> user.v1.Playlist
-	28, // 9: user.v1.ListPlaylistsResponse.playlists:type_name -> user.v1.Playlist
-	29, // 10: user.v1.GetPlaylistSongsResponse.songs:type_name -> user.v1.PlaylistSong
-	0,  // 11: user.v1.Favorite.type:type_name -> user.v1.FavoriteType
-	26, // 12: user.v1.Favorite.metadata:type_name -> user.v1.FavoriteMetadata
-	30, // 13: user.v1.Favorite.created_at:type_name -> google.protobuf.Timestamp
-	30, // 14: user.v1.PlayHistory.played_at:type_name -> google.protobuf.Timestamp
-	30, // 15: user.v1.Playlist.created_at:type_name -> google.protobuf.Timestamp
-	30, // 16: user.v1.Playlist.updated_at:type_name -> google.protobuf.Timestamp
-	30, // 17: user.v1.PlaylistSong.added_at:type_name -> google.protobuf.Timestamp
-	1,  // 18: user.v1.UserService.AddFavorite:input_type -> user.v1.AddFavoriteRequest
-	3,  // 19: user.v1.UserService.RemoveFavorite:input_type -> user.v1.RemoveFavoriteRequest
-	5,  // 20: user.v1.UserService.ListFavorites:input_type -> user.v1.ListFavoritesRequest
-	7,  // 21: user.v1.UserService.AddPlayHistory:input_type -> user.v1.AddPlayHistoryRequest
-	9,  // 22: user.v1.UserService.ListPlayHistory:input_type -> user.v1.ListPlayHistoryRequest
-	11, // 23: user.v1.UserService.CreatePlaylist:input_type -> user.v1.CreatePlaylistRequest
-	13, // 24: user.v1.UserService.UpdatePlaylist:input_type -> user.v1.UpdatePlaylistRequest
-	15, // 25: user.v1.UserService.DeletePlaylist:input_type -> user.v1.DeletePlaylistRequest
-	17, // 26: user.v1.UserService.ListPlaylists:input_type -> user.v1.ListPlaylistsRequest
-	19, // 27: user.v1.UserService.AddSongToPlaylist:input_type -> user.v1.AddSongToPlaylistRequest
-	21, // 28: user.v1.UserService.RemoveSongFromPlaylist:input_type -> user.v1.RemoveSongFromPlaylistRequest
-	23, // 29: user.v1.UserService.GetPlaylistSongs:input_type -> user.v1.GetPlaylistSongsRequest
-	2,  // 30: user.v1.UserService.AddFavorite:output_type -> user.v1.AddFavoriteResponse
-	4,  // 31: user.v1.UserService.RemoveFavorite:output_type -> user.v1.RemoveFavoriteResponse
-	6,  // 32: user.v1.UserService.ListFavorites:output_type -> user.v1.ListFavoritesResponse
-	8,  // 33: user.v1.UserService.AddPlayHistory:output_type -> user.v1.AddPlayHistoryResponse
-	10, // 34: user.v1.UserService.ListPlayHistory:output_type -> user.v1.ListPlayHistoryResponse
-	12, // 35: user.v1.UserService.CreatePlaylist:output_type -> user.v1.CreatePlaylistResponse
-	14, // 36: user.v1.UserService.UpdatePlaylist:output_type -> user.v1.UpdatePlaylistResponse
-	16, // 37: user.v1.UserService.DeletePlaylist:output_type -> user.v1.DeletePlaylistResponse
-	18, // 38: user.v1.UserService.ListPlaylists:output_type -> user.v1.ListPlaylistsResponse
-	20, // 39: user.v1.UserService.AddSongToPlaylist:output_type -> user.v1.AddSongToPlaylistResponse
-	22, // 40: user.v1.UserService.RemoveSongFromPlaylist:output_type -> user.v1.RemoveSongFromPlaylistResponse
-	24, // 41: user.v1.UserService.GetPlaylistSongs:output_type -> user.v1.GetPlaylistSongsResponse
-	30, // [30:42] is the sub-list for method output_type
-	18, // [18:30] is the sub-list for method input_type
-	18, // [18:18] is the sub-list for extension type_name
-	18, // [18:18] is the sub-list for extension extendee
-	0,  // [0:18] is the sub-list for field type_name
+	0,   // 0: user.v1.AddFavoriteRequest.type:type_name -> user.v1.FavoriteType
+	113, // 1: user.v1.AddFavoriteRequest.metadata:type_name -> user.v1.FavoriteMetadata
+	120, // 2: user.v1.AddFavoriteResponse.created_at:type_name -> google.protobuf.Timestamp
+	0,   // 3: user.v1.ListFavoritesRequest.type:type_name -> user.v1.FavoriteType
+	112, // 4: user.v1.ListFavoritesResponse.favorites:type_name -> user.v1.Favorite
+	8,   // 5: user.v1.ListFavoritesResponse.type_counts:type_name -> user.v1.FavoriteTypeCount
+	0,   // 6: user.v1.FavoriteTypeCount.type:type_name -> user.v1.FavoriteType
+	120, // 7: user.v1.AddPlayHistoryResponse.played_at:type_name -> google.protobuf.Timestamp
+	114, // 8: user.v1.ListPlayHistoryResponse.history:type_name -> user.v1.PlayHistory
+	115, // 9: user.v1.CreatePlaylistResponse.playlist:type_name -> user.v1.Playlist
+	115, // 10: user.v1.UpdatePlaylistResponse.playlist:type_name -> user.v1.Playlist
+	115, // 11: user.v1.ListPlaylistsResponse.playlists:type_name -> user.v1.Playlist
+	51,  // 12: user.v1.AddSongsToPlaylistRequest.songs:type_name -> user.v1.ImportedSong
+	115, // 13: user.v1.GetPlaylistResponse.playlist:type_name -> user.v1.Playlist
+	118, // 14: user.v1.GetPlaylistSongsResponse.songs:type_name -> user.v1.PlaylistSong
+	116, // 15: user.v1.ListPlaylistMembersResponse.members:type_name -> user.v1.PlaylistMember
+	116, // 16: user.v1.InvitePlaylistMemberResponse.member:type_name -> user.v1.PlaylistMember
+	117, // 17: user.v1.CreatePlaylistInviteResponse.invite:type_name -> user.v1.PlaylistInvite
+	115, // 18: user.v1.AcceptPlaylistInviteResponse.playlist:type_name -> user.v1.Playlist
+	115, // 19: user.v1.ListSharedPlaylistsResponse.playlists:type_name -> user.v1.Playlist
+	51,  // 20: user.v1.ImportPlaylistRequest.songs:type_name -> user.v1.ImportedSong
+	115, // 21: user.v1.ImportPlaylistResponse.playlist:type_name -> user.v1.Playlist
+	115, // 22: user.v1.ListPublicPlaylistsResponse.playlists:type_name -> user.v1.Playlist
+	115, // 23: user.v1.GetPublicPlaylistResponse.playlist:type_name -> user.v1.Playlist
+	118, // 24: user.v1.GetPublicPlaylistResponse.songs:type_name -> user.v1.PlaylistSong
+	115, // 25: user.v1.FollowPlaylistResponse.playlist:type_name -> user.v1.Playlist
+	115, // 26: user.v1.ListFollowedPlaylistsResponse.playlists:type_name -> user.v1.Playlist
+	115, // 27: user.v1.ForkPlaylistResponse.playlist:type_name -> user.v1.Playlist
+	70,  // 28: user.v1.ListDeletedPlaylistsResponse.playlists:type_name -> user.v1.DeletedPlaylist
+	115, // 29: user.v1.DeletedPlaylist.playlist:type_name -> user.v1.Playlist
+	120, // 30: user.v1.DeletedPlaylist.deleted_at:type_name -> google.protobuf.Timestamp
+	120, // 31: user.v1.DeletedPlaylist.expires_at:type_name -> google.protobuf.Timestamp
+	73,  // 32: user.v1.ListDeletedFavoritesResponse.favorites:type_name -> user.v1.DeletedFavorite
+	112, // 33: user.v1.DeletedFavorite.favorite:type_name -> user.v1.Favorite
+	120, // 34: user.v1.DeletedFavorite.deleted_at:type_name -> google.protobuf.Timestamp
+	120, // 35: user.v1.DeletedFavorite.expires_at:type_name -> google.protobuf.Timestamp
+	115, // 36: user.v1.RestorePlaylistResponse.playlist:type_name -> user.v1.Playlist
+	112, // 37: user.v1.RestoreFavoriteResponse.favorite:type_name -> user.v1.Favorite
+	112, // 38: user.v1.ExportUserDataResponse.favorites:type_name -> user.v1.Favorite
+	80,  // 39: user.v1.ExportUserDataResponse.playlists:type_name -> user.v1.PlaylistExport
+	114, // 40: user.v1.ExportUserDataResponse.history:type_name -> user.v1.PlayHistory
+	90,  // 41: user.v1.ExportUserDataResponse.daily_listening:type_name -> user.v1.DailyListening
+	91,  // 42: user.v1.ExportUserDataResponse.monthly_listening:type_name -> user.v1.MonthlyListening
+	109, // 43: user.v1.ExportUserDataResponse.play_queue:type_name -> user.v1.PlayQueue
+	111, // 44: user.v1.ExportUserDataResponse.playback_positions:type_name -> user.v1.PlaybackPosition
+	116, // 45: user.v1.ExportUserDataResponse.playlist_memberships:type_name -> user.v1.PlaylistMember
+	115, // 46: user.v1.ExportUserDataResponse.followed_playlists:type_name -> user.v1.Playlist
+	115, // 47: user.v1.PlaylistExport.playlist:type_name -> user.v1.Playlist
+	118, // 48: user.v1.PlaylistExport.songs:type_name -> user.v1.PlaylistSong
+	87,  // 49: user.v1.GetListeningStatsResponse.summary:type_name -> user.v1.ListeningSummary
+	90,  // 50: user.v1.GetListeningStatsResponse.daily:type_name -> user.v1.DailyListening
+	87,  // 51: user.v1.GetYearInReviewResponse.summary:type_name -> user.v1.ListeningSummary
+	91,  // 52: user.v1.GetYearInReviewResponse.months:type_name -> user.v1.MonthlyListening
+	88,  // 53: user.v1.ListeningSummary.top_songs:type_name -> user.v1.TopSong
+	89,  // 54: user.v1.ListeningSummary.top_singers:type_name -> user.v1.TopSinger
+	94,  // 55: user.v1.GetRecommendationsResponse.daily_mix:type_name -> user.v1.RecommendedSong
+	95,  // 56: user.v1.GetRecommendationsResponse.because_you_liked:type_name -> user.v1.RecommendationRow
+	120, // 57: user.v1.GetRecommendationsResponse.generated_at:type_name -> google.protobuf.Timestamp
+	94,  // 58: user.v1.RecommendationRow.songs:type_name -> user.v1.RecommendedSong
+	98,  // 59: user.v1.ListNewReleasesResponse.releases:type_name -> user.v1.NewRelease
+	120, // 60: user.v1.NewRelease.discovered_at:type_name -> google.protobuf.Timestamp
+	109, // 61: user.v1.GetPlayQueueResponse.queue:type_name -> user.v1.PlayQueue
+	110, // 62: user.v1.SavePlayQueueRequest.tracks:type_name -> user.v1.QueueTrack
+	1,   // 63: user.v1.SavePlayQueueRequest.repeat_mode:type_name -> user.v1.RepeatMode
+	109, // 64: user.v1.SavePlayQueueResponse.queue:type_name -> user.v1.PlayQueue
+	109, // 65: user.v1.SetPlayQueueIndexResponse.queue:type_name -> user.v1.PlayQueue
+	111, // 66: user.v1.UpdatePlaybackPositionResponse.position:type_name -> user.v1.PlaybackPosition
+	111, // 67: user.v1.GetPlaybackPositionsResponse.positions:type_name -> user.v1.PlaybackPosition
+	110, // 68: user.v1.PlayQueue.tracks:type_name -> user.v1.QueueTrack
+	1,   // 69: user.v1.PlayQueue.repeat_mode:type_name -> user.v1.RepeatMode
+	120, // 70: user.v1.PlayQueue.updated_at:type_name -> google.protobuf.Timestamp
+	120, // 71: user.v1.PlaybackPosition.updated_at:type_name -> google.protobuf.Timestamp
+	0,   // 72: user.v1.Favorite.type:type_name -> user.v1.FavoriteType
+	113, // 73: user.v1.Favorite.metadata:type_name -> user.v1.FavoriteMetadata
+	120, // 74: user.v1.Favorite.created_at:type_name -> google.protobuf.Timestamp
+	119, // 75: user.v1.FavoriteMetadata.extra:type_name -> user.v1.FavoriteMetadata.ExtraEntry
+	120, // 76: user.v1.PlayHistory.played_at:type_name -> google.protobuf.Timestamp
+	120, // 77: user.v1.Playlist.created_at:type_name -> google.protobuf.Timestamp
+	120, // 78: user.v1.Playlist.updated_at:type_name -> google.protobuf.Timestamp
+	120, // 79: user.v1.PlaylistMember.joined_at:type_name -> google.protobuf.Timestamp
+	120, // 80: user.v1.PlaylistInvite.expires_at:type_name -> google.protobuf.Timestamp
+	120, // 81: user.v1.PlaylistInvite.created_at:type_name -> google.protobuf.Timestamp
+	120, // 82: user.v1.PlaylistSong.added_at:type_name -> google.protobuf.Timestamp
+	2,   // 83: user.v1.UserService.AddFavorite:input_type -> user.v1.AddFavoriteRequest
+	4,   // 84: user.v1.UserService.RemoveFavorite:input_type -> user.v1.RemoveFavoriteRequest
+	6,   // 85: user.v1.UserService.ListFavorites:input_type -> user.v1.ListFavoritesRequest
+	9,   // 86: user.v1.UserService.AddPlayHistory:input_type -> user.v1.AddPlayHistoryRequest
+	11,  // 87: user.v1.UserService.ListPlayHistory:input_type -> user.v1.ListPlayHistoryRequest
+	13,  // 88: user.v1.UserService.CreatePlaylist:input_type -> user.v1.CreatePlaylistRequest
+	15,  // 89: user.v1.UserService.UpdatePlaylist:input_type -> user.v1.UpdatePlaylistRequest
+	17,  // 90: user.v1.UserService.DeletePlaylist:input_type -> user.v1.DeletePlaylistRequest
+	19,  // 91: user.v1.UserService.ListPlaylists:input_type -> user.v1.ListPlaylistsRequest
+	21,  // 92: user.v1.UserService.AddSongToPlaylist:input_type -> user.v1.AddSongToPlaylistRequest
+	23,  // 93: user.v1.UserService.RemoveSongFromPlaylist:input_type -> user.v1.RemoveSongFromPlaylistRequest
+	25,  // 94: user.v1.UserService.AddSongsToPlaylist:input_type -> user.v1.AddSongsToPlaylistRequest
+	27,  // 95: user.v1.UserService.RemoveSongsFromPlaylist:input_type -> user.v1.RemoveSongsFromPlaylistRequest
+	29,  // 96: user.v1.UserService.MovePlaylistSong:input_type -> user.v1.MovePlaylistSongRequest
+	31,  // 97: user.v1.UserService.SortPlaylistSongs:input_type -> user.v1.SortPlaylistSongsRequest
+	33,  // 98: user.v1.UserService.GetPlaylist:input_type -> user.v1.GetPlaylistRequest
+	35,  // 99: user.v1.UserService.GetPlaylistSongs:input_type -> user.v1.GetPlaylistSongsRequest
+	37,  // 100: user.v1.UserService.ListPlaylistMembers:input_type -> user.v1.ListPlaylistMembersRequest
+	39,  // 101: user.v1.UserService.InvitePlaylistMember:input_type -> user.v1.InvitePlaylistMemberRequest
+	41,  // 102: user.v1.UserService.RemovePlaylistMember:input_type -> user.v1.RemovePlaylistMemberRequest
+	43,  // 103: user.v1.UserService.CreatePlaylistInvite:input_type -> user.v1.CreatePlaylistInviteRequest
+	45,  // 104: user.v1.UserService.RevokePlaylistInvites:input_type -> user.v1.RevokePlaylistInvitesRequest
+	47,  // 105: user.v1.UserService.AcceptPlaylistInvite:input_type -> user.v1.AcceptPlaylistInviteRequest
+	49,  // 106: user.v1.UserService.ListSharedPlaylists:input_type -> user.v1.ListSharedPlaylistsRequest
+	52,  // 107: user.v1.UserService.ImportPlaylist:input_type -> user.v1.ImportPlaylistRequest
+	54,  // 108: user.v1.UserService.ExportPlaylist:input_type -> user.v1.ExportPlaylistRequest
+	56,  // 109: user.v1.UserService.ListPublicPlaylists:input_type -> user.v1.ListPublicPlaylistsRequest
+	58,  // 110: user.v1.UserService.GetPublicPlaylist:input_type -> user.v1.GetPublicPlaylistRequest
+	60,  // 111: user.v1.UserService.FollowPlaylist:input_type -> user.v1.FollowPlaylistRequest
+	62,  // 112: user.v1.UserService.UnfollowPlaylist:input_type -> user.v1.UnfollowPlaylistRequest
+	64,  // 113: user.v1.UserService.ListFollowedPlaylists:input_type -> user.v1.ListFollowedPlaylistsRequest
+	66,  // 114: user.v1.UserService.ForkPlaylist:input_type -> user.v1.ForkPlaylistRequest
+	68,  // 115: user.v1.UserService.ListDeletedPlaylists:input_type -> user.v1.ListDeletedPlaylistsRequest
+	71,  // 116: user.v1.UserService.ListDeletedFavorites:input_type -> user.v1.ListDeletedFavoritesRequest
+	74,  // 117: user.v1.UserService.RestorePlaylist:input_type -> user.v1.RestorePlaylistRequest
+	76,  // 118: user.v1.UserService.RestoreFavorite:input_type -> user.v1.RestoreFavoriteRequest
+	78,  // 119: user.v1.UserService.ExportUserData:input_type -> user.v1.ExportUserDataRequest
+	81,  // 120: user.v1.UserService.EraseUserData:input_type -> user.v1.EraseUserDataRequest
+	83,  // 121: user.v1.UserService.GetListeningStats:input_type -> user.v1.GetListeningStatsRequest
+	85,  // 122: user.v1.UserService.GetYearInReview:input_type -> user.v1.GetYearInReviewRequest
+	92,  // 123: user.v1.UserService.GetRecommendations:input_type -> user.v1.GetRecommendationsRequest
+	96,  // 124: user.v1.UserService.ListNewReleases:input_type -> user.v1.ListNewReleasesRequest
+	99,  // 125: user.v1.UserService.GetPlayQueue:input_type -> user.v1.GetPlayQueueRequest
+	101, // 126: user.v1.UserService.SavePlayQueue:input_type -> user.v1.SavePlayQueueRequest
+	103, // 127: user.v1.UserService.SetPlayQueueIndex:input_type -> user.v1.SetPlayQueueIndexRequest
+	105, // 128: user.v1.UserService.UpdatePlaybackPosition:input_type -> user.v1.UpdatePlaybackPositionRequest
+	107, // 129: user.v1.UserService.GetPlaybackPositions:input_type -> user.v1.GetPlaybackPositionsRequest
+	3,   // 130: user.v1.UserService.AddFavorite:output_type -> user.v1.AddFavoriteResponse
+	5,   // 131: user.v1.UserService.RemoveFavorite:output_type -> user.v1.RemoveFavoriteResponse
+	7,   // 132: user.v1.UserService.ListFavorites:output_type -> user.v1.ListFavoritesResponse
+	10,  // 133: user.v1.UserService.AddPlayHistory:output_type -> user.v1.AddPlayHistoryResponse
+	12,  // 134: user.v1.UserService.ListPlayHistory:output_type -> user.v1.ListPlayHistoryResponse
+	14,  // 135: user.v1.UserService.CreatePlaylist:output_type -> user.v1.CreatePlaylistResponse
+	16,  // 136: user.v1.UserService.UpdatePlaylist:output_type -> user.v1.UpdatePlaylistResponse
+	18,  // 137: user.v1.UserService.DeletePlaylist:output_type -> user.v1.DeletePlaylistResponse
+	20,  // 138: user.v1.UserService.ListPlaylists:output_type -> user.v1.ListPlaylistsResponse
+	22,  // 139: user.v1.UserService.AddSongToPlaylist:output_type -> user.v1.AddSongToPlaylistResponse
+	24,  // 140: user.v1.UserService.RemoveSongFromPlaylist:output_type -> user.v1.RemoveSongFromPlaylistResponse
+	26,  // 141: user.v1.UserService.AddSongsToPlaylist:output_type -> user.v1.AddSongsToPlaylistResponse
+	28,  // 142: user.v1.UserService.RemoveSongsFromPlaylist:output_type -> user.v1.RemoveSongsFromPlaylistResponse
+	30,  // 143: user.v1.UserService.MovePlaylistSong:output_type -> user.v1.MovePlaylistSongResponse
+	32,  // 144: user.v1.UserService.SortPlaylistSongs:output_type -> user.v1.SortPlaylistSongsResponse
+	34,  // 145: user.v1.UserService.GetPlaylist:output_type -> user.v1.GetPlaylistResponse
+	36,  // 146: user.v1.UserService.GetPlaylistSongs:output_type -> user.v1.GetPlaylistSongsResponse
+	38,  // 147: user.v1.UserService.ListPlaylistMembers:output_type -> user.v1.ListPlaylistMembersResponse
+	40,  // 148: user.v1.UserService.InvitePlaylistMember:output_type -> user.v1.InvitePlaylistMemberResponse
+	42,  // 149: user.v1.UserService.RemovePlaylistMember:output_type -> user.v1.RemovePlaylistMemberResponse
+	44,  // 150: user.v1.UserService.CreatePlaylistInvite:output_type -> user.v1.CreatePlaylistInviteResponse
+	46,  // 151: user.v1.UserService.RevokePlaylistInvites:output_type -> user.v1.RevokePlaylistInvitesResponse
+	48,  // 152: user.v1.UserService.AcceptPlaylistInvite:output_type -> user.v1.AcceptPlaylistInviteResponse
+	50,  // 153: user.v1.UserService.ListSharedPlaylists:output_type -> user.v1.ListSharedPlaylistsResponse
+	53,  // 154: user.v1.UserService.ImportPlaylist:output_type -> user.v1.ImportPlaylistResponse
+	55,  // 155: user.v1.UserService.ExportPlaylist:output_type -> user.v1.ExportPlaylistResponse
+	57,  // 156: user.v1.UserService.ListPublicPlaylists:output_type -> user.v1.ListPublicPlaylistsResponse
+	59,  // 157: user.v1.UserService.GetPublicPlaylist:output_type -> user.v1.GetPublicPlaylistResponse
+	61,  // 158: user.v1.UserService.FollowPlaylist:output_type -> user.v1.FollowPlaylistResponse
+	63,  // 159: user.v1.UserService.UnfollowPlaylist:output_type -> user.v1.UnfollowPlaylistResponse
+	65,  // 160: user.v1.UserService.ListFollowedPlaylists:output_type -> user.v1.ListFollowedPlaylistsResponse
+	67,  // 161: user.v1.UserService.ForkPlaylist:output_type -> user.v1.ForkPlaylistResponse
+	69,  // 162: user.v1.UserService.ListDeletedPlaylists:output_type -> user.v1.ListDeletedPlaylistsResponse
+	72,  // 163: user.v1.UserService.ListDeletedFavorites:output_type -> user.v1.ListDeletedFavoritesResponse
+	75,  // 164: user.v1.UserService.RestorePlaylist:output_type -> user.v1.RestorePlaylistResponse
+	77,  // 165: user.v1.UserService.RestoreFavorite:output_type -> user.v1.RestoreFavoriteResponse
+	79,  // 166: user.v1.UserService.ExportUserData:output_type -> user.v1.ExportUserDataResponse
+	82,  // 167: user.v1.UserService.EraseUserData:output_type -> user.v1.EraseUserDataResponse
+	84,  // 168: user.v1.UserService.GetListeningStats:output_type -> user.v1.GetListeningStatsResponse
+	86,  // 169: user.v1.UserService.GetYearInReview:output_type -> user.v1.GetYearInReviewResponse
+	93,  // 170: user.v1.UserService.GetRecommendations:output_type -> user.v1.GetRecommendationsResponse
+	97,  // 171: user.v1.UserService.ListNewReleases:output_type -> user.v1.ListNewReleasesResponse
+	100, // 172: user.v1.UserService.GetPlayQueue:output_type -> user.v1.GetPlayQueueResponse
+	102, // 173: user.v1.UserService.SavePlayQueue:output_type -> user.v1.SavePlayQueueResponse
+	104, // 174: user.v1.UserService.SetPlayQueueIndex:output_type -> user.v1.SetPlayQueueIndexResponse
+	106, // 175: user.v1.UserService.UpdatePlaybackPosition:output_type -> user.v1.UpdatePlaybackPositionResponse
+	108, // 176: user.v1.UserService.GetPlaybackPositions:output_type -> user.v1.GetPlaybackPositionsResponse
+	130, // [130:177] is the sub-list for method output_type
+	83,  // [83:130] is the sub-list for method input_type
+	83,  // [83:83] is the sub-list for extension type_name
+	83,  // [83:83] is the sub-list for extension extendee
+	0,   // [0:83] is the sub-list for field type_name
 }
 
 func init() { file_user_v1_user_proto_init() }
@@ -2244,14 +8623,14 @@ func file_user_v1_user_proto_init() {
 	if File_user_v1_user_proto != nil {
 		return
 	}
-	file_user_v1_user_proto_msgTypes[12].OneofWrappers = []any{}
+	file_user_v1_user_proto_msgTypes[13].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_user_v1_user_proto_rawDesc), len(file_user_v1_user_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   29,
+			NumEnums:      2,
+			NumMessages:   118,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
-// - protoc-gen-go-grpc v1.6.1
+// - protoc-gen-go-grpc v1.6.2
 // - protoc             v6.33.4
 // source: user/v1/user.proto
 
@@ -19,18 +19,53 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	UserService_AddFavorite_FullMethodName            = "/user.v1.UserService/AddFavorite"
-	UserService_RemoveFavorite_FullMethodName         = "/user.v1.UserService/RemoveFavorite"
-	UserService_ListFavorites_FullMethodName          = "/user.v1.UserService/ListFavorites"
-	UserService_AddPlayHistory_FullMethodName         = "/user.v1.UserService/AddPlayHistory"
-	UserService_ListPlayHistory_FullMethodName        = "/user.v1.UserService/ListPlayHistory"
-	UserService_CreatePlaylist_FullMethodName         = "/user.v1.UserService/CreatePlaylist"
-	UserService_UpdatePlaylist_FullMethodName         = "/user.v1.UserService/UpdatePlaylist"
-	UserService_DeletePlaylist_FullMethodName         = "/user.v1.UserService/DeletePlaylist"
-	UserService_ListPlaylists_FullMethodName          = "/user.v1.UserService/ListPlaylists"
-	UserService_AddSongToPlaylist_FullMethodName      = "/user.v1.UserService/AddSongToPlaylist"
-	UserService_RemoveSongFromPlaylist_FullMethodName = "/user.v1.UserService/RemoveSongFromPlaylist"
-	UserService_GetPlaylistSongs_FullMethodName       = "/user.v1.UserService/GetPlaylistSongs"
+	UserService_AddFavorite_FullMethodName             = "/user.v1.UserService/AddFavorite"
+	UserService_RemoveFavorite_FullMethodName          = "/user.v1.UserService/RemoveFavorite"
+	UserService_ListFavorites_FullMethodName           = "/user.v1.UserService/ListFavorites"
+	UserService_AddPlayHistory_FullMethodName          = "/user.v1.UserService/AddPlayHistory"
+	UserService_ListPlayHistory_FullMethodName         = "/user.v1.UserService/ListPlayHistory"
+	UserService_CreatePlaylist_FullMethodName          = "/user.v1.UserService/CreatePlaylist"
+	UserService_UpdatePlaylist_FullMethodName          = "/user.v1.UserService/UpdatePlaylist"
+	UserService_DeletePlaylist_FullMethodName          = "/user.v1.UserService/DeletePlaylist"
+	UserService_ListPlaylists_FullMethodName           = "/user.v1.UserService/ListPlaylists"
+	UserService_AddSongToPlaylist_FullMethodName       = "/user.v1.UserService/AddSongToPlaylist"
+	UserService_RemoveSongFromPlaylist_FullMethodName  = "/user.v1.UserService/RemoveSongFromPlaylist"
+	UserService_AddSongsToPlaylist_FullMethodName      = "/user.v1.UserService/AddSongsToPlaylist"
+	UserService_RemoveSongsFromPlaylist_FullMethodName = "/user.v1.UserService/RemoveSongsFromPlaylist"
+	UserService_MovePlaylistSong_FullMethodName        = "/user.v1.UserService/MovePlaylistSong"
+	UserService_SortPlaylistSongs_FullMethodName       = "/user.v1.UserService/SortPlaylistSongs"
+	UserService_GetPlaylist_FullMethodName             = "/user.v1.UserService/GetPlaylist"
+	UserService_GetPlaylistSongs_FullMethodName        = "/user.v1.UserService/GetPlaylistSongs"
+	UserService_ListPlaylistMembers_FullMethodName     = "/user.v1.UserService/ListPlaylistMembers"
+	UserService_InvitePlaylistMember_FullMethodName    = "/user.v1.UserService/InvitePlaylistMember"
+	UserService_RemovePlaylistMember_FullMethodName    = "/user.v1.UserService/RemovePlaylistMember"
+	UserService_CreatePlaylistInvite_FullMethodName    = "/user.v1.UserService/CreatePlaylistInvite"
+	UserService_RevokePlaylistInvites_FullMethodName   = "/user.v1.UserService/RevokePlaylistInvites"
+	UserService_AcceptPlaylistInvite_FullMethodName    = "/user.v1.UserService/AcceptPlaylistInvite"
+	UserService_ListSharedPlaylists_FullMethodName     = "/user.v1.UserService/ListSharedPlaylists"
+	UserService_ImportPlaylist_FullMethodName          = "/user.v1.UserService/ImportPlaylist"
+	UserService_ExportPlaylist_FullMethodName          = "/user.v1.UserService/ExportPlaylist"
+	UserService_ListPublicPlaylists_FullMethodName     = "/user.v1.UserService/ListPublicPlaylists"
+	UserService_GetPublicPlaylist_FullMethodName       = "/user.v1.UserService/GetPublicPlaylist"
+	UserService_FollowPlaylist_FullMethodName          = "/user.v1.UserService/FollowPlaylist"
+	UserService_UnfollowPlaylist_FullMethodName        = "/user.v1.UserService/UnfollowPlaylist"
+	UserService_ListFollowedPlaylists_FullMethodName   = "/user.v1.UserService/ListFollowedPlaylists"
+	UserService_ForkPlaylist_FullMethodName            = "/user.v1.UserService/ForkPlaylist"
+	UserService_ListDeletedPlaylists_FullMethodName    = "/user.v1.UserService/ListDeletedPlaylists"
+	UserService_ListDeletedFavorites_FullMethodName    = "/user.v1.UserService/ListDeletedFavorites"
+	UserService_RestorePlaylist_FullMethodName         = "/user.v1.UserService/RestorePlaylist"
+	UserService_RestoreFavorite_FullMethodName         = "/user.v1.UserService/RestoreFavorite"
+	UserService_ExportUserData_FullMethodName          = "/user.v1.UserService/ExportUserData"
+	UserService_EraseUserData_FullMethodName           = "/user.v1.UserService/EraseUserData"
+	UserService_GetListeningStats_FullMethodName       = "/user.v1.UserService/GetListeningStats"
+	UserService_GetYearInReview_FullMethodName         = "/user.v1.UserService/GetYearInReview"
+	UserService_GetRecommendations_FullMethodName      = "/user.v1.UserService/GetRecommendations"
+	UserService_ListNewReleases_FullMethodName         = "/user.v1.UserService/ListNewReleases"
+	UserService_GetPlayQueue_FullMethodName            = "/user.v1.UserService/GetPlayQueue"
+	UserService_SavePlayQueue_FullMethodName           = "/user.v1.UserService/SavePlayQueue"
+	UserService_SetPlayQueueIndex_FullMethodName       = "/user.v1.UserService/SetPlayQueueIndex"
+	UserService_UpdatePlaybackPosition_FullMethodName  = "/user.v1.UserService/UpdatePlaybackPosition"
+	UserService_GetPlaybackPositions_FullMethodName    = "/user.v1.UserService/GetPlaybackPositions"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -65,8 +100,122 @@ type UserServiceClient interface {
 	AddSongToPlaylist(ctx context.Context, in *AddSongToPlaylistRequest, opts ...grpc.CallOption) (*AddSongToPlaylistResponse, error)
 	// RemoveSongFromPlaylist removes a song from a playlist.
 	RemoveSongFromPlaylist(ctx context.Context, in *RemoveSongFromPlaylistRequest, opts ...grpc.CallOption) (*RemoveSongFromPlaylistResponse, error)
-	// GetPlaylistSongs returns all songs in a playlist.
+	// AddSongsToPlaylist appends up to 500 songs in one transaction.
+	//
+	// Songs keep the given order; songs already in the playlist and duplicates in the request are skipped.
+	AddSongsToPlaylist(ctx context.Context, in *AddSongsToPlaylistRequest, opts ...grpc.CallOption) (*AddSongsToPlaylistResponse, error)
+	// RemoveSongsFromPlaylist removes up to 500 songs in one transaction. Unknown song IDs are ignored.
+	RemoveSongsFromPlaylist(ctx context.Context, in *RemoveSongsFromPlaylistRequest, opts ...grpc.CallOption) (*RemoveSongsFromPlaylistResponse, error)
+	// MovePlaylistSong moves a song to a 1-based position, shifting the songs in between.
+	MovePlaylistSong(ctx context.Context, in *MovePlaylistSongRequest, opts ...grpc.CallOption) (*MovePlaylistSongResponse, error)
+	// SortPlaylistSongs reorders a playlist by song name, singer or date added.
+	SortPlaylistSongs(ctx context.Context, in *SortPlaylistSongsRequest, opts ...grpc.CallOption) (*SortPlaylistSongsResponse, error)
+	// GetPlaylist returns a playlist the caller can read: public, owned or shared with them.
+	GetPlaylist(ctx context.Context, in *GetPlaylistRequest, opts ...grpc.CallOption) (*GetPlaylistResponse, error)
+	// GetPlaylistSongs returns all songs in a playlist the caller can read.
 	GetPlaylistSongs(ctx context.Context, in *GetPlaylistSongsRequest, opts ...grpc.CallOption) (*GetPlaylistSongsResponse, error)
+	// ListPlaylistMembers returns the owner and members of a playlist. Any collaborator may list them.
+	ListPlaylistMembers(ctx context.Context, in *ListPlaylistMembersRequest, opts ...grpc.CallOption) (*ListPlaylistMembersResponse, error)
+	// InvitePlaylistMember adds a user to a playlist as editor or viewer, or changes their role.
+	//
+	// Only the owner may invite members.
+	InvitePlaylistMember(ctx context.Context, in *InvitePlaylistMemberRequest, opts ...grpc.CallOption) (*InvitePlaylistMemberResponse, error)
+	// RemovePlaylistMember removes a member. The owner may remove anyone; members may remove themselves.
+	RemovePlaylistMember(ctx context.Context, in *RemovePlaylistMemberRequest, opts ...grpc.CallOption) (*RemovePlaylistMemberResponse, error)
+	// CreatePlaylistInvite creates a share link token that grants a role to whoever accepts it.
+	//
+	// Only the owner may create links; links expire after 7 days.
+	CreatePlaylistInvite(ctx context.Context, in *CreatePlaylistInviteRequest, opts ...grpc.CallOption) (*CreatePlaylistInviteResponse, error)
+	// RevokePlaylistInvites revokes all outstanding share links of a playlist. Owner only.
+	RevokePlaylistInvites(ctx context.Context, in *RevokePlaylistInvitesRequest, opts ...grpc.CallOption) (*RevokePlaylistInvitesResponse, error)
+	// AcceptPlaylistInvite joins a playlist through a share link token.
+	//
+	// Existing members are only ever upgraded, never downgraded.
+	AcceptPlaylistInvite(ctx context.Context, in *AcceptPlaylistInviteRequest, opts ...grpc.CallOption) (*AcceptPlaylistInviteResponse, error)
+	// ListSharedPlaylists returns the playlists a user collaborates on as editor or viewer.
+	ListSharedPlaylists(ctx context.Context, in *ListSharedPlaylistsRequest, opts ...grpc.CallOption) (*ListSharedPlaylistsResponse, error)
+	// ImportPlaylist creates a playlist filled with songs that were already resolved
+	// to catalogue song IDs (e.g. by proxy-svc from a QQ Music/NetEase/Kugou playlist).
+	//
+	// Songs keep the given order; duplicates are skipped and counted.
+	ImportPlaylist(ctx context.Context, in *ImportPlaylistRequest, opts ...grpc.CallOption) (*ImportPlaylistResponse, error)
+	// ExportPlaylist renders a playlist as an M3U8, XSPF or JSON file.
+	//
+	// Users can export their own playlists and any public playlist.
+	ExportPlaylist(ctx context.Context, in *ExportPlaylistRequest, opts ...grpc.CallOption) (*ExportPlaylistResponse, error)
+	// ListPublicPlaylists browses other users' public playlists, sorted by follower count or recency.
+	ListPublicPlaylists(ctx context.Context, in *ListPublicPlaylistsRequest, opts ...grpc.CallOption) (*ListPublicPlaylistsResponse, error)
+	// GetPublicPlaylist returns a public playlist with its songs and whether the caller follows it.
+	GetPublicPlaylist(ctx context.Context, in *GetPublicPlaylistRequest, opts ...grpc.CallOption) (*GetPublicPlaylistResponse, error)
+	// FollowPlaylist follows a public playlist. Idempotent; users cannot follow their own playlists.
+	FollowPlaylist(ctx context.Context, in *FollowPlaylistRequest, opts ...grpc.CallOption) (*FollowPlaylistResponse, error)
+	// UnfollowPlaylist stops following a playlist. Idempotent.
+	UnfollowPlaylist(ctx context.Context, in *UnfollowPlaylistRequest, opts ...grpc.CallOption) (*UnfollowPlaylistResponse, error)
+	// ListFollowedPlaylists returns the public playlists a user follows, most recently followed first.
+	//
+	// Playlists that were made private or deleted are hidden.
+	ListFollowedPlaylists(ctx context.Context, in *ListFollowedPlaylistsRequest, opts ...grpc.CallOption) (*ListFollowedPlaylistsResponse, error)
+	// ForkPlaylist copies a readable playlist into the user's library as a private playlist.
+	//
+	// The copy is a snapshot of the current songs; later changes to either playlist are independent.
+	ForkPlaylist(ctx context.Context, in *ForkPlaylistRequest, opts ...grpc.CallOption) (*ForkPlaylistResponse, error)
+	// ListDeletedPlaylists returns the user's recently deleted playlists, most recently deleted first.
+	//
+	// Only playlists still within the trash retention period are listed.
+	ListDeletedPlaylists(ctx context.Context, in *ListDeletedPlaylistsRequest, opts ...grpc.CallOption) (*ListDeletedPlaylistsResponse, error)
+	// ListDeletedFavorites returns the user's recently deleted favorites, most recently deleted first.
+	ListDeletedFavorites(ctx context.Context, in *ListDeletedFavoritesRequest, opts ...grpc.CallOption) (*ListDeletedFavoritesResponse, error)
+	// RestorePlaylist restores a deleted playlist together with its songs, members and followers.
+	//
+	// Only the owner can restore; expired playlists return NOT_FOUND.
+	RestorePlaylist(ctx context.Context, in *RestorePlaylistRequest, opts ...grpc.CallOption) (*RestorePlaylistResponse, error)
+	// RestoreFavorite restores a deleted favorite.
+	//
+	// Returns ALREADY_EXISTS if the same target was favorited again after the deletion.
+	RestoreFavorite(ctx context.Context, in *RestoreFavoriteRequest, opts ...grpc.CallOption) (*RestoreFavoriteResponse, error)
+	// ExportUserData returns everything stored for a user (favorites, playlists with songs, play history).
+	//
+	// Called by auth-svc to build the "download my data" archive.
+	ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (*ExportUserDataResponse, error)
+	// EraseUserData permanently deletes everything stored for a user, including soft-deleted rows.
+	//
+	// Idempotent: erasing a user without data succeeds. Called by auth-svc's account deletion workflow.
+	EraseUserData(ctx context.Context, in *EraseUserDataRequest, opts ...grpc.CallOption) (*EraseUserDataResponse, error)
+	// GetListeningStats returns aggregated listening statistics for a date range.
+	//
+	// Served from daily rollups of play history, so the most recent plays appear after the next
+	// rollup (hourly). The range is at most 366 days and must start within the daily detail retention.
+	GetListeningStats(ctx context.Context, in *GetListeningStatsRequest, opts ...grpc.CallOption) (*GetListeningStatsResponse, error)
+	// GetYearInReview returns the yearly listening recap built from monthly rollups.
+	GetYearInReview(ctx context.Context, in *GetYearInReviewRequest, opts ...grpc.CallOption) (*GetYearInReviewResponse, error)
+	// GetRecommendations returns the user's personalized "daily mix" and "because you liked X" rows.
+	//
+	// Computed offline from favorites, playlists and play history across users plus upstream
+	// similar-song data, then cached. Users not covered by the last offline run get results
+	// computed on demand from upstream similar songs only.
+	GetRecommendations(ctx context.Context, in *GetRecommendationsRequest, opts ...grpc.CallOption) (*GetRecommendationsResponse, error)
+	// ListNewReleases returns new albums from singers the user follows (singer favorites).
+	//
+	// Releases are discovered by periodically polling the upstream singer album lists. Only albums
+	// discovered after the user started following the singer are returned, newest first.
+	ListNewReleases(ctx context.Context, in *ListNewReleasesRequest, opts ...grpc.CallOption) (*ListNewReleasesResponse, error)
+	// GetPlayQueue returns the user's play queue shared across devices.
+	//
+	// Users who never saved a queue get an empty queue.
+	GetPlayQueue(ctx context.Context, in *GetPlayQueueRequest, opts ...grpc.CallOption) (*GetPlayQueueResponse, error)
+	// SavePlayQueue replaces the user's play queue and pushes it to the user's other devices.
+	//
+	// Writes go to Redis and are flushed to Postgres in the background.
+	SavePlayQueue(ctx context.Context, in *SavePlayQueueRequest, opts ...grpc.CallOption) (*SavePlayQueueResponse, error)
+	// SetPlayQueueIndex changes the current track without resending the whole queue.
+	SetPlayQueueIndex(ctx context.Context, in *SetPlayQueueIndexRequest, opts ...grpc.CallOption) (*SetPlayQueueIndexResponse, error)
+	// UpdatePlaybackPosition records the last playback position of a track.
+	//
+	// Designed to be called every few seconds while playing; writes go to Redis and are
+	// flushed to Postgres in the background.
+	UpdatePlaybackPosition(ctx context.Context, in *UpdatePlaybackPositionRequest, opts ...grpc.CallOption) (*UpdatePlaybackPositionResponse, error)
+	// GetPlaybackPositions returns the last playback positions of up to 100 tracks.
+	GetPlaybackPositions(ctx context.Context, in *GetPlaybackPositionsRequest, opts ...grpc.CallOption) (*GetPlaybackPositionsResponse, error)
 }
 
 type userServiceClient struct {
@@ -187,6 +336,56 @@ func (c *userServiceClient) RemoveSongFromPlaylist(ctx context.Context, in *Remo
 	return out, nil
 }
 
+func (c *userServiceClient) AddSongsToPlaylist(ctx context.Context, in *AddSongsToPlaylistRequest, opts ...grpc.CallOption) (*AddSongsToPlaylistResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddSongsToPlaylistResponse)
+	err := c.cc.Invoke(ctx, UserService_AddSongsToPlaylist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RemoveSongsFromPlaylist(ctx context.Context, in *RemoveSongsFromPlaylistRequest, opts ...grpc.CallOption) (*RemoveSongsFromPlaylistResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveSongsFromPlaylistResponse)
+	err := c.cc.Invoke(ctx, UserService_RemoveSongsFromPlaylist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) MovePlaylistSong(ctx context.Context, in *MovePlaylistSongRequest, opts ...grpc.CallOption) (*MovePlaylistSongResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MovePlaylistSongResponse)
+	err := c.cc.Invoke(ctx, UserService_MovePlaylistSong_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SortPlaylistSongs(ctx context.Context, in *SortPlaylistSongsRequest, opts ...grpc.CallOption) (*SortPlaylistSongsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SortPlaylistSongsResponse)
+	err := c.cc.Invoke(ctx, UserService_SortPlaylistSongs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetPlaylist(ctx context.Context, in *GetPlaylistRequest, opts ...grpc.CallOption) (*GetPlaylistResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPlaylistResponse)
+	err := c.cc.Invoke(ctx, UserService_GetPlaylist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) GetPlaylistSongs(ctx context.Context, in *GetPlaylistSongsRequest, opts ...grpc.CallOption) (*GetPlaylistSongsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetPlaylistSongsResponse)
@@ -197,319 +396,1468 @@ func (c *userServiceClient) GetPlaylistSongs(ctx context.Context, in *GetPlaylis
 	return out, nil
 }
 
-// UserServiceServer is the server API for UserService service.
-// All implementations must embed UnimplementedUserServiceServer
-// for forward compatibility.
-//
-// UserService manages user-generated content including favorites, playlists, and play history.
-//
-// All RPCs require valid authentication and are called by proxy-svc.
-type UserServiceServer interface {
-	// AddFavorite adds a song/album/artist/mv to user's favorites.
-	AddFavorite(context.Context, *AddFavoriteRequest) (*AddFavoriteResponse, error)
-	// RemoveFavorite removes an item from favorites (soft delete).
-	RemoveFavorite(context.Context, *RemoveFavoriteRequest) (*RemoveFavoriteResponse, error)
-	// ListFavorites returns paginated favorites for a user.
-	ListFavorites(context.Context, *ListFavoritesRequest) (*ListFavoritesResponse, error)
-	// AddPlayHistory records a song play event.
-	//
-	// Automatically enforces 500-item limit per user with FIFO eviction.
-	AddPlayHistory(context.Context, *AddPlayHistoryRequest) (*AddPlayHistoryResponse, error)
-	// ListPlayHistory returns paginated play history.
-	ListPlayHistory(context.Context, *ListPlayHistoryRequest) (*ListPlayHistoryResponse, error)
-	// CreatePlaylist creates a new user playlist.
-	CreatePlaylist(context.Context, *CreatePlaylistRequest) (*CreatePlaylistResponse, error)
-	// UpdatePlaylist modifies playlist metadata (name, cover, visibility).
-	UpdatePlaylist(context.Context, *UpdatePlaylistRequest) (*UpdatePlaylistResponse, error)
-	// DeletePlaylist soft-deletes a playlist.
-	DeletePlaylist(context.Context, *DeletePlaylistRequest) (*DeletePlaylistResponse, error)
-	// ListPlaylists returns all playlists for a user.
-	ListPlaylists(context.Context, *ListPlaylistsRequest) (*ListPlaylistsResponse, error)
-	// AddSongToPlaylist adds a song to a playlist.
-	AddSongToPlaylist(context.Context, *AddSongToPlaylistRequest) (*AddSongToPlaylistResponse, error)
-	// RemoveSongFromPlaylist removes a song from a playlist.
-	RemoveSongFromPlaylist(context.Context, *RemoveSongFromPlaylistRequest) (*RemoveSongFromPlaylistResponse, error)
-	// GetPlaylistSongs returns all songs in a playlist.
-	GetPlaylistSongs(context.Context, *GetPlaylistSongsRequest) (*GetPlaylistSongsResponse, error)
-	mustEmbedUnimplementedUserServiceServer()
+func (c *userServiceClient) ListPlaylistMembers(ctx context.Context, in *ListPlaylistMembersRequest, opts ...grpc.CallOption) (*ListPlaylistMembersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPlaylistMembersResponse)
+	err := c.cc.Invoke(ctx, UserService_ListPlaylistMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-// UnimplementedUserServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedUserServiceServer struct{}
+func (c *userServiceClient) InvitePlaylistMember(ctx context.Context, in *InvitePlaylistMemberRequest, opts ...grpc.CallOption) (*InvitePlaylistMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InvitePlaylistMemberResponse)
+	err := c.cc.Invoke(ctx, UserService_InvitePlaylistMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
-func (UnimplementedUserServiceServer) AddFavorite(context.Context, *AddFavoriteRequest) (*AddFavoriteResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method AddFavorite not implemented")
+func (c *userServiceClient) RemovePlaylistMember(ctx context.Context, in *RemovePlaylistMemberRequest, opts ...grpc.CallOption) (*RemovePlaylistMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemovePlaylistMemberResponse)
+	err := c.cc.Invoke(ctx, UserService_RemovePlaylistMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedUserServiceServer) RemoveFavorite(context.Context, *RemoveFavoriteRequest) (*RemoveFavoriteResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method RemoveFavorite not implemented")
+
+func (c *userServiceClient) CreatePlaylistInvite(ctx context.Context, in *CreatePlaylistInviteRequest, opts ...grpc.CallOption) (*CreatePlaylistInviteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreatePlaylistInviteResponse)
+	err := c.cc.Invoke(ctx, UserService_CreatePlaylistInvite_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedUserServiceServer) ListFavorites(context.Context, *ListFavoritesRequest) (*ListFavoritesResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ListFavorites not implemented")
+
+func (c *userServiceClient) RevokePlaylistInvites(ctx context.Context, in *RevokePlaylistInvitesRequest, opts ...grpc.CallOption) (*RevokePlaylistInvitesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokePlaylistInvitesResponse)
+	err := c.cc.Invoke(ctx, UserService_RevokePlaylistInvites_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedUserServiceServer) AddPlayHistory(context.Context, *AddPlayHistoryRequest) (*AddPlayHistoryResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method AddPlayHistory not implemented")
+
+func (c *userServiceClient) AcceptPlaylistInvite(ctx context.Context, in *AcceptPlaylistInviteRequest, opts ...grpc.CallOption) (*AcceptPlaylistInviteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AcceptPlaylistInviteResponse)
+	err := c.cc.Invoke(ctx, UserService_AcceptPlaylistInvite_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedUserServiceServer) ListPlayHistory(context.Context, *ListPlayHistoryRequest) (*ListPlayHistoryResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ListPlayHistory not implemented")
+
+func (c *userServiceClient) ListSharedPlaylists(ctx context.Context, in *ListSharedPlaylistsRequest, opts ...grpc.CallOption) (*ListSharedPlaylistsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSharedPlaylistsResponse)
+	err := c.cc.Invoke(ctx, UserService_ListSharedPlaylists_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedUserServiceServer) CreatePlaylist(context.Context, *CreatePlaylistRequest) (*CreatePlaylistResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method CreatePlaylist not implemented")
+
+func (c *userServiceClient) ImportPlaylist(ctx context.Context, in *ImportPlaylistRequest, opts ...grpc.CallOption) (*ImportPlaylistResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportPlaylistResponse)
+	err := c.cc.Invoke(ctx, UserService_ImportPlaylist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ExportPlaylist(ctx context.Context, in *ExportPlaylistRequest, opts ...grpc.CallOption) (*ExportPlaylistResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportPlaylistResponse)
+	err := c.cc.Invoke(ctx, UserService_ExportPlaylist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListPublicPlaylists(ctx context.Context, in *ListPublicPlaylistsRequest, opts ...grpc.CallOption) (*ListPublicPlaylistsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPublicPlaylistsResponse)
+	err := c.cc.Invoke(ctx, UserService_ListPublicPlaylists_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetPublicPlaylist(ctx context.Context, in *GetPublicPlaylistRequest, opts ...grpc.CallOption) (*GetPublicPlaylistResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPublicPlaylistResponse)
+	err := c.cc.Invoke(ctx, UserService_GetPublicPlaylist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) FollowPlaylist(ctx context.Context, in *FollowPlaylistRequest, opts ...grpc.CallOption) (*FollowPlaylistResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FollowPlaylistResponse)
+	err := c.cc.Invoke(ctx, UserService_FollowPlaylist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UnfollowPlaylist(ctx context.Context, in *UnfollowPlaylistRequest, opts ...grpc.CallOption) (*UnfollowPlaylistResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnfollowPlaylistResponse)
+	err := c.cc.Invoke(ctx, UserService_UnfollowPlaylist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListFollowedPlaylists(ctx context.Context, in *ListFollowedPlaylistsRequest, opts ...grpc.CallOption) (*ListFollowedPlaylistsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFollowedPlaylistsResponse)
+	err := c.cc.Invoke(ctx, UserService_ListFollowedPlaylists_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ForkPlaylist(ctx context.Context, in *ForkPlaylistRequest, opts ...grpc.CallOption) (*ForkPlaylistResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ForkPlaylistResponse)
+	err := c.cc.Invoke(ctx, UserService_ForkPlaylist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListDeletedPlaylists(ctx context.Context, in *ListDeletedPlaylistsRequest, opts ...grpc.CallOption) (*ListDeletedPlaylistsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeletedPlaylistsResponse)
+	err := c.cc.Invoke(ctx, UserService_ListDeletedPlaylists_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListDeletedFavorites(ctx context.Context, in *ListDeletedFavoritesRequest, opts ...grpc.CallOption) (*ListDeletedFavoritesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeletedFavoritesResponse)
+	err := c.cc.Invoke(ctx, UserService_ListDeletedFavorites_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RestorePlaylist(ctx context.Context, in *RestorePlaylistRequest, opts ...grpc.CallOption) (*RestorePlaylistResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestorePlaylistResponse)
+	err := c.cc.Invoke(ctx, UserService_RestorePlaylist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RestoreFavorite(ctx context.Context, in *RestoreFavoriteRequest, opts ...grpc.CallOption) (*RestoreFavoriteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreFavoriteResponse)
+	err := c.cc.Invoke(ctx, UserService_RestoreFavorite_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (*ExportUserDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportUserDataResponse)
+	err := c.cc.Invoke(ctx, UserService_ExportUserData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) EraseUserData(ctx context.Context, in *EraseUserDataRequest, opts ...grpc.CallOption) (*EraseUserDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EraseUserDataResponse)
+	err := c.cc.Invoke(ctx, UserService_EraseUserData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetListeningStats(ctx context.Context, in *GetListeningStatsRequest, opts ...grpc.CallOption) (*GetListeningStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetListeningStatsResponse)
+	err := c.cc.Invoke(ctx, UserService_GetListeningStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetYearInReview(ctx context.Context, in *GetYearInReviewRequest, opts ...grpc.CallOption) (*GetYearInReviewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetYearInReviewResponse)
+	err := c.cc.Invoke(ctx, UserService_GetYearInReview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetRecommendations(ctx context.Context, in *GetRecommendationsRequest, opts ...grpc.CallOption) (*GetRecommendationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRecommendationsResponse)
+	err := c.cc.Invoke(ctx, UserService_GetRecommendations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListNewReleases(ctx context.Context, in *ListNewReleasesRequest, opts ...grpc.CallOption) (*ListNewReleasesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListNewReleasesResponse)
+	err := c.cc.Invoke(ctx, UserService_ListNewReleases_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetPlayQueue(ctx context.Context, in *GetPlayQueueRequest, opts ...grpc.CallOption) (*GetPlayQueueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPlayQueueResponse)
+	err := c.cc.Invoke(ctx, UserService_GetPlayQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SavePlayQueue(ctx context.Context, in *SavePlayQueueRequest, opts ...grpc.CallOption) (*SavePlayQueueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SavePlayQueueResponse)
+	err := c.cc.Invoke(ctx, UserService_SavePlayQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SetPlayQueueIndex(ctx context.Context, in *SetPlayQueueIndexRequest, opts ...grpc.CallOption) (*SetPlayQueueIndexResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetPlayQueueIndexResponse)
+	err := c.cc.Invoke(ctx, UserService_SetPlayQueueIndex_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UpdatePlaybackPosition(ctx context.Context, in *UpdatePlaybackPositionRequest, opts ...grpc.CallOption) (*UpdatePlaybackPositionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdatePlaybackPositionResponse)
+	err := c.cc.Invoke(ctx, UserService_UpdatePlaybackPosition_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetPlaybackPositions(ctx context.Context, in *GetPlaybackPositionsRequest, opts ...grpc.CallOption) (*GetPlaybackPositionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPlaybackPositionsResponse)
+	err := c.cc.Invoke(ctx, UserService_GetPlaybackPositions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UserServiceServer is the server API for UserService service.
+// All implementations must embed UnimplementedUserServiceServer
+// for forward compatibility.
+//
+// UserService manages user-generated content including favorites, playlists, and play history.
+//
+// All RPCs require valid authentication and are called by proxy-svc.
+type UserServiceServer interface {
+	// AddFavorite adds a song/album/artist/mv to user's favorites.
+	AddFavorite(context.Context, *AddFavoriteRequest) (*AddFavoriteResponse, error)
+	// RemoveFavorite removes an item from favorites (soft delete).
+	RemoveFavorite(context.Context, *RemoveFavoriteRequest) (*RemoveFavoriteResponse, error)
+	// ListFavorites returns paginated favorites for a user.
+	ListFavorites(context.Context, *ListFavoritesRequest) (*ListFavoritesResponse, error)
+	// AddPlayHistory records a song play event.
+	//
+	// Automatically enforces 500-item limit per user with FIFO eviction.
+	AddPlayHistory(context.Context, *AddPlayHistoryRequest) (*AddPlayHistoryResponse, error)
+	// ListPlayHistory returns paginated play history.
+	ListPlayHistory(context.Context, *ListPlayHistoryRequest) (*ListPlayHistoryResponse, error)
+	// CreatePlaylist creates a new user playlist.
+	CreatePlaylist(context.Context, *CreatePlaylistRequest) (*CreatePlaylistResponse, error)
+	// UpdatePlaylist modifies playlist metadata (name, cover, visibility).
+	UpdatePlaylist(context.Context, *UpdatePlaylistRequest) (*UpdatePlaylistResponse, error)
+	// DeletePlaylist soft-deletes a playlist.
+	DeletePlaylist(context.Context, *DeletePlaylistRequest) (*DeletePlaylistResponse, error)
+	// ListPlaylists returns all playlists for a user.
+	ListPlaylists(context.Context, *ListPlaylistsRequest) (*ListPlaylistsResponse, error)
+	// AddSongToPlaylist adds a song to a playlist.
+	AddSongToPlaylist(context.Context, *AddSongToPlaylistRequest) (*AddSongToPlaylistResponse, error)
+	// RemoveSongFromPlaylist removes a song from a playlist.
+	RemoveSongFromPlaylist(context.Context, *RemoveSongFromPlaylistRequest) (*RemoveSongFromPlaylistResponse, error)
+	// AddSongsToPlaylist appends up to 500 songs in one transaction.
+	//
+	// Songs keep the given order; songs already in the playlist and duplicates in the request are skipped.
+	AddSongsToPlaylist(context.Context, *AddSongsToPlaylistRequest) (*AddSongsToPlaylistResponse, error)
+	// RemoveSongsFromPlaylist removes up to 500 songs in one transaction. Unknown song IDs are ignored.
+	RemoveSongsFromPlaylist(context.Context, *RemoveSongsFromPlaylistRequest) (*RemoveSongsFromPlaylistResponse, error)
+	// MovePlaylistSong moves a song to a 1-based position, shifting the songs in between.
+	MovePlaylistSong(context.Context, *MovePlaylistSongRequest) (*MovePlaylistSongResponse, error)
+	// SortPlaylistSongs reorders a playlist by song name, singer or date added.
+	SortPlaylistSongs(context.Context, *SortPlaylistSongsRequest) (*SortPlaylistSongsResponse, error)
+	// GetPlaylist returns a playlist the caller can read: public, owned or shared with them.
+	GetPlaylist(context.Context, *GetPlaylistRequest) (*GetPlaylistResponse, error)
+	// GetPlaylistSongs returns all songs in a playlist the caller can read.
+	GetPlaylistSongs(context.Context, *GetPlaylistSongsRequest) (*GetPlaylistSongsResponse, error)
+	// ListPlaylistMembers returns the owner and members of a playlist. Any collaborator may list them.
+	ListPlaylistMembers(context.Context, *ListPlaylistMembersRequest) (*ListPlaylistMembersResponse, error)
+	// InvitePlaylistMember adds a user to a playlist as editor or viewer, or changes their role.
+	//
+	// Only the owner may invite members.
+	InvitePlaylistMember(context.Context, *InvitePlaylistMemberRequest) (*InvitePlaylistMemberResponse, error)
+	// RemovePlaylistMember removes a member. The owner may remove anyone; members may remove themselves.
+	RemovePlaylistMember(context.Context, *RemovePlaylistMemberRequest) (*RemovePlaylistMemberResponse, error)
+	// CreatePlaylistInvite creates a share link token that grants a role to whoever accepts it.
+	//
+	// Only the owner may create links; links expire after 7 days.
+	CreatePlaylistInvite(context.Context, *CreatePlaylistInviteRequest) (*CreatePlaylistInviteResponse, error)
+	// RevokePlaylistInvites revokes all outstanding share links of a playlist. Owner only.
+	RevokePlaylistInvites(context.Context, *RevokePlaylistInvitesRequest) (*RevokePlaylistInvitesResponse, error)
+	// AcceptPlaylistInvite joins a playlist through a share link token.
+	//
+	// Existing members are only ever upgraded, never downgraded.
+	AcceptPlaylistInvite(context.Context, *AcceptPlaylistInviteRequest) (*AcceptPlaylistInviteResponse, error)
+	// ListSharedPlaylists returns the playlists a user collaborates on as editor or viewer.
+	ListSharedPlaylists(context.Context, *ListSharedPlaylistsRequest) (*ListSharedPlaylistsResponse, error)
+	// ImportPlaylist creates a playlist filled with songs that were already resolved
+	// to catalogue song IDs (e.g. by proxy-svc from a QQ Music/NetEase/Kugou playlist).
+	//
+	// Songs keep the given order; duplicates are skipped and counted.
+	ImportPlaylist(context.Context, *ImportPlaylistRequest) (*ImportPlaylistResponse, error)
+	// ExportPlaylist renders a playlist as an M3U8, XSPF or JSON file.
+	//
+	// Users can export their own playlists and any public playlist.
+	ExportPlaylist(context.Context, *ExportPlaylistRequest) (*ExportPlaylistResponse, error)
+	// ListPublicPlaylists browses other users' public playlists, sorted by follower count or recency.
+	ListPublicPlaylists(context.Context, *ListPublicPlaylistsRequest) (*ListPublicPlaylistsResponse, error)
+	// GetPublicPlaylist returns a public playlist with its songs and whether the caller follows it.
+	GetPublicPlaylist(context.Context, *GetPublicPlaylistRequest) (*GetPublicPlaylistResponse, error)
+	// FollowPlaylist follows a public playlist. Idempotent; users cannot follow their own playlists.
+	FollowPlaylist(context.Context, *FollowPlaylistRequest) (*FollowPlaylistResponse, error)
+	// UnfollowPlaylist stops following a playlist. Idempotent.
+	UnfollowPlaylist(context.Context, *UnfollowPlaylistRequest) (*UnfollowPlaylistResponse, error)
+	// ListFollowedPlaylists returns the public playlists a user follows, most recently followed first.
+	//
+	// Playlists that were made private or deleted are hidden.
+	ListFollowedPlaylists(context.Context, *ListFollowedPlaylistsRequest) (*ListFollowedPlaylistsResponse, error)
+	// ForkPlaylist copies a readable playlist into the user's library as a private playlist.
+	//
+	// The copy is a snapshot of the current songs; later changes to either playlist are independent.
+	ForkPlaylist(context.Context, *ForkPlaylistRequest) (*ForkPlaylistResponse, error)
+	// ListDeletedPlaylists returns the user's recently deleted playlists, most recently deleted first.
+	//
+	// Only playlists still within the trash retention period are listed.
+	ListDeletedPlaylists(context.Context, *ListDeletedPlaylistsRequest) (*ListDeletedPlaylistsResponse, error)
+	// ListDeletedFavorites returns the user's recently deleted favorites, most recently deleted first.
+	ListDeletedFavorites(context.Context, *ListDeletedFavoritesRequest) (*ListDeletedFavoritesResponse, error)
+	// RestorePlaylist restores a deleted playlist together with its songs, members and followers.
+	//
+	// Only the owner can restore; expired playlists return NOT_FOUND.
+	RestorePlaylist(context.Context, *RestorePlaylistRequest) (*RestorePlaylistResponse, error)
+	// RestoreFavorite restores a deleted favorite.
+	//
+	// Returns ALREADY_EXISTS if the same target was favorited again after the deletion.
+	RestoreFavorite(context.Context, *RestoreFavoriteRequest) (*RestoreFavoriteResponse, error)
+	// ExportUserData returns everything stored for a user (favorites, playlists with songs, play history).
+	//
+	// Called by auth-svc to build the "download my data" archive.
+	ExportUserData(context.Context, *ExportUserDataRequest) (*ExportUserDataResponse, error)
+	// EraseUserData permanently deletes everything stored for a user, including soft-deleted rows.
+	//
+	// Idempotent: erasing a user without data succeeds. Called by auth-svc's account deletion workflow.
+	EraseUserData(context.Context, *EraseUserDataRequest) (*EraseUserDataResponse, error)
+	// GetListeningStats returns aggregated listening statistics for a date range.
+	//
+	// Served from daily rollups of play history, so the most recent plays appear after the next
+	// rollup (hourly). The range is at most 366 days and must start within the daily detail retention.
+	GetListeningStats(context.Context, *GetListeningStatsRequest) (*GetListeningStatsResponse, error)
+	// GetYearInReview returns the yearly listening recap built from monthly rollups.
+	GetYearInReview(context.Context, *GetYearInReviewRequest) (*GetYearInReviewResponse, error)
+	// GetRecommendations returns the user's personalized "daily mix" and "because you liked X" rows.
+	//
+	// Computed offline from favorites, playlists and play history across users plus upstream
+	// similar-song data, then cached. Users not covered by the last offline run get results
+	// computed on demand from upstream similar songs only.
+	GetRecommendations(context.Context, *GetRecommendationsRequest) (*GetRecommendationsResponse, error)
+	// ListNewReleases returns new albums from singers the user follows (singer favorites).
+	//
+	// Releases are discovered by periodically polling the upstream singer album lists. Only albums
+	// discovered after the user started following the singer are returned, newest first.
+	ListNewReleases(context.Context, *ListNewReleasesRequest) (*ListNewReleasesResponse, error)
+	// GetPlayQueue returns the user's play queue shared across devices.
+	//
+	// Users who never saved a queue get an empty queue.
+	GetPlayQueue(context.Context, *GetPlayQueueRequest) (*GetPlayQueueResponse, error)
+	// SavePlayQueue replaces the user's play queue and pushes it to the user's other devices.
+	//
+	// Writes go to Redis and are flushed to Postgres in the background.
+	SavePlayQueue(context.Context, *SavePlayQueueRequest) (*SavePlayQueueResponse, error)
+	// SetPlayQueueIndex changes the current track without resending the whole queue.
+	SetPlayQueueIndex(context.Context, *SetPlayQueueIndexRequest) (*SetPlayQueueIndexResponse, error)
+	// UpdatePlaybackPosition records the last playback position of a track.
+	//
+	// Designed to be called every few seconds while playing; writes go to Redis and are
+	// flushed to Postgres in the background.
+	UpdatePlaybackPosition(context.Context, *UpdatePlaybackPositionRequest) (*UpdatePlaybackPositionResponse, error)
+	// GetPlaybackPositions returns the last playback positions of up to 100 tracks.
+	GetPlaybackPositions(context.Context, *GetPlaybackPositionsRequest) (*GetPlaybackPositionsResponse, error)
+	mustEmbedUnimplementedUserServiceServer()
+}
+
+// UnimplementedUserServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedUserServiceServer struct{}
+
+func (UnimplementedUserServiceServer) AddFavorite(context.Context, *AddFavoriteRequest) (*AddFavoriteResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddFavorite not implemented")
+}
+func (UnimplementedUserServiceServer) RemoveFavorite(context.Context, *RemoveFavoriteRequest) (*RemoveFavoriteResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveFavorite not implemented")
+}
+func (UnimplementedUserServiceServer) ListFavorites(context.Context, *ListFavoritesRequest) (*ListFavoritesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListFavorites not implemented")
+}
+func (UnimplementedUserServiceServer) AddPlayHistory(context.Context, *AddPlayHistoryRequest) (*AddPlayHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddPlayHistory not implemented")
+}
+func (UnimplementedUserServiceServer) ListPlayHistory(context.Context, *ListPlayHistoryRequest) (*ListPlayHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPlayHistory not implemented")
+}
+func (UnimplementedUserServiceServer) CreatePlaylist(context.Context, *CreatePlaylistRequest) (*CreatePlaylistResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreatePlaylist not implemented")
+}
+func (UnimplementedUserServiceServer) UpdatePlaylist(context.Context, *UpdatePlaylistRequest) (*UpdatePlaylistResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdatePlaylist not implemented")
+}
+func (UnimplementedUserServiceServer) DeletePlaylist(context.Context, *DeletePlaylistRequest) (*DeletePlaylistResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeletePlaylist not implemented")
+}
+func (UnimplementedUserServiceServer) ListPlaylists(context.Context, *ListPlaylistsRequest) (*ListPlaylistsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPlaylists not implemented")
+}
+func (UnimplementedUserServiceServer) AddSongToPlaylist(context.Context, *AddSongToPlaylistRequest) (*AddSongToPlaylistResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddSongToPlaylist not implemented")
+}
+func (UnimplementedUserServiceServer) RemoveSongFromPlaylist(context.Context, *RemoveSongFromPlaylistRequest) (*RemoveSongFromPlaylistResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveSongFromPlaylist not implemented")
+}
+func (UnimplementedUserServiceServer) AddSongsToPlaylist(context.Context, *AddSongsToPlaylistRequest) (*AddSongsToPlaylistResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddSongsToPlaylist not implemented")
+}
+func (UnimplementedUserServiceServer) RemoveSongsFromPlaylist(context.Context, *RemoveSongsFromPlaylistRequest) (*RemoveSongsFromPlaylistResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveSongsFromPlaylist not implemented")
+}
+func (UnimplementedUserServiceServer) MovePlaylistSong(context.Context, *MovePlaylistSongRequest) (*MovePlaylistSongResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MovePlaylistSong not implemented")
+}
+func (UnimplementedUserServiceServer) SortPlaylistSongs(context.Context, *SortPlaylistSongsRequest) (*SortPlaylistSongsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SortPlaylistSongs not implemented")
+}
+func (UnimplementedUserServiceServer) GetPlaylist(context.Context, *GetPlaylistRequest) (*GetPlaylistResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPlaylist not implemented")
+}
+func (UnimplementedUserServiceServer) GetPlaylistSongs(context.Context, *GetPlaylistSongsRequest) (*GetPlaylistSongsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPlaylistSongs not implemented")
+}
+func (UnimplementedUserServiceServer) ListPlaylistMembers(context.Context, *ListPlaylistMembersRequest) (*ListPlaylistMembersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPlaylistMembers not implemented")
+}
+func (UnimplementedUserServiceServer) InvitePlaylistMember(context.Context, *InvitePlaylistMemberRequest) (*InvitePlaylistMemberResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method InvitePlaylistMember not implemented")
+}
+func (UnimplementedUserServiceServer) RemovePlaylistMember(context.Context, *RemovePlaylistMemberRequest) (*RemovePlaylistMemberResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemovePlaylistMember not implemented")
+}
+func (UnimplementedUserServiceServer) CreatePlaylistInvite(context.Context, *CreatePlaylistInviteRequest) (*CreatePlaylistInviteResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreatePlaylistInvite not implemented")
+}
+func (UnimplementedUserServiceServer) RevokePlaylistInvites(context.Context, *RevokePlaylistInvitesRequest) (*RevokePlaylistInvitesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RevokePlaylistInvites not implemented")
+}
+func (UnimplementedUserServiceServer) AcceptPlaylistInvite(context.Context, *AcceptPlaylistInviteRequest) (*AcceptPlaylistInviteResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AcceptPlaylistInvite not implemented")
+}
+func (UnimplementedUserServiceServer) ListSharedPlaylists(context.Context, *ListSharedPlaylistsRequest) (*ListSharedPlaylistsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListSharedPlaylists not implemented")
+}
+func (UnimplementedUserServiceServer) ImportPlaylist(context.Context, *ImportPlaylistRequest) (*ImportPlaylistResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ImportPlaylist not implemented")
+}
+func (UnimplementedUserServiceServer) ExportPlaylist(context.Context, *ExportPlaylistRequest) (*ExportPlaylistResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExportPlaylist not implemented")
+}
+func (UnimplementedUserServiceServer) ListPublicPlaylists(context.Context, *ListPublicPlaylistsRequest) (*ListPublicPlaylistsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPublicPlaylists not implemented")
+}
+func (UnimplementedUserServiceServer) GetPublicPlaylist(context.Context, *GetPublicPlaylistRequest) (*GetPublicPlaylistResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPublicPlaylist not implemented")
+}
+func (UnimplementedUserServiceServer) FollowPlaylist(context.Context, *FollowPlaylistRequest) (*FollowPlaylistResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method FollowPlaylist not implemented")
+}
+func (UnimplementedUserServiceServer) UnfollowPlaylist(context.Context, *UnfollowPlaylistRequest) (*UnfollowPlaylistResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UnfollowPlaylist not implemented")
+}
+func (UnimplementedUserServiceServer) ListFollowedPlaylists(context.Context, *ListFollowedPlaylistsRequest) (*ListFollowedPlaylistsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListFollowedPlaylists not implemented")
+}
+func (UnimplementedUserServiceServer) ForkPlaylist(context.Context, *ForkPlaylistRequest) (*ForkPlaylistResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ForkPlaylist not implemented")
+}
+func (UnimplementedUserServiceServer) ListDeletedPlaylists(context.Context, *ListDeletedPlaylistsRequest) (*ListDeletedPlaylistsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDeletedPlaylists not implemented")
+}
+func (UnimplementedUserServiceServer) ListDeletedFavorites(context.Context, *ListDeletedFavoritesRequest) (*ListDeletedFavoritesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDeletedFavorites not implemented")
+}
+func (UnimplementedUserServiceServer) RestorePlaylist(context.Context, *RestorePlaylistRequest) (*RestorePlaylistResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RestorePlaylist not implemented")
+}
+func (UnimplementedUserServiceServer) RestoreFavorite(context.Context, *RestoreFavoriteRequest) (*RestoreFavoriteResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RestoreFavorite not implemented")
+}
+func (UnimplementedUserServiceServer) ExportUserData(context.Context, *ExportUserDataRequest) (*ExportUserDataResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExportUserData not implemented")
+}
+func (UnimplementedUserServiceServer) EraseUserData(context.Context, *EraseUserDataRequest) (*EraseUserDataResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method EraseUserData not implemented")
+}
+func (UnimplementedUserServiceServer) GetListeningStats(context.Context, *GetListeningStatsRequest) (*GetListeningStatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetListeningStats not implemented")
+}
+func (UnimplementedUserServiceServer) GetYearInReview(context.Context, *GetYearInReviewRequest) (*GetYearInReviewResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetYearInReview not implemented")
+}
+func (UnimplementedUserServiceServer) GetRecommendations(context.Context, *GetRecommendationsRequest) (*GetRecommendationsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRecommendations not implemented")
+}
+func (UnimplementedUserServiceServer) ListNewReleases(context.Context, *ListNewReleasesRequest) (*ListNewReleasesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListNewReleases not implemented")
+}
+func (UnimplementedUserServiceServer) GetPlayQueue(context.Context, *GetPlayQueueRequest) (*GetPlayQueueResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPlayQueue not implemented")
+}
+func (UnimplementedUserServiceServer) SavePlayQueue(context.Context, *SavePlayQueueRequest) (*SavePlayQueueResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SavePlayQueue not implemented")
+}
+func (UnimplementedUserServiceServer) SetPlayQueueIndex(context.Context, *SetPlayQueueIndexRequest) (*SetPlayQueueIndexResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetPlayQueueIndex not implemented")
+}
+func (UnimplementedUserServiceServer) UpdatePlaybackPosition(context.Context, *UpdatePlaybackPositionRequest) (*UpdatePlaybackPositionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdatePlaybackPosition not implemented")
+}
+func (UnimplementedUserServiceServer) GetPlaybackPositions(context.Context, *GetPlaybackPositionsRequest) (*GetPlaybackPositionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPlaybackPositions not implemented")
+}
+func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
+func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UserServiceServer will
+// result in compilation errors.
+type UnsafeUserServiceServer interface {
+	mustEmbedUnimplementedUserServiceServer()
+}
+
+func RegisterUserServiceServer(s grpc.ServiceRegistrar, srv UserServiceServer) {
+	// If the following call panics, it indicates UnimplementedUserServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&UserService_ServiceDesc, srv)
+}
+
+func _UserService_AddFavorite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddFavoriteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AddFavorite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_AddFavorite_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AddFavorite(ctx, req.(*AddFavoriteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RemoveFavorite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveFavoriteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RemoveFavorite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RemoveFavorite_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RemoveFavorite(ctx, req.(*RemoveFavoriteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListFavorites_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFavoritesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListFavorites(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListFavorites_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListFavorites(ctx, req.(*ListFavoritesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_AddPlayHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddPlayHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AddPlayHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_AddPlayHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AddPlayHistory(ctx, req.(*AddPlayHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListPlayHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPlayHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListPlayHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListPlayHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListPlayHistory(ctx, req.(*ListPlayHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CreatePlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePlaylistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CreatePlaylist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CreatePlaylist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CreatePlaylist(ctx, req.(*CreatePlaylistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UpdatePlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePlaylistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdatePlaylist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UpdatePlaylist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdatePlaylist(ctx, req.(*UpdatePlaylistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DeletePlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePlaylistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).DeletePlaylist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_DeletePlaylist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DeletePlaylist(ctx, req.(*DeletePlaylistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListPlaylists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPlaylistsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListPlaylists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListPlaylists_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListPlaylists(ctx, req.(*ListPlaylistsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_AddSongToPlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddSongToPlaylistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AddSongToPlaylist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_AddSongToPlaylist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AddSongToPlaylist(ctx, req.(*AddSongToPlaylistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RemoveSongFromPlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveSongFromPlaylistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RemoveSongFromPlaylist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RemoveSongFromPlaylist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RemoveSongFromPlaylist(ctx, req.(*RemoveSongFromPlaylistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_AddSongsToPlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddSongsToPlaylistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AddSongsToPlaylist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_AddSongsToPlaylist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AddSongsToPlaylist(ctx, req.(*AddSongsToPlaylistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RemoveSongsFromPlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveSongsFromPlaylistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RemoveSongsFromPlaylist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RemoveSongsFromPlaylist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RemoveSongsFromPlaylist(ctx, req.(*RemoveSongsFromPlaylistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_MovePlaylistSong_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MovePlaylistSongRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).MovePlaylistSong(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_MovePlaylistSong_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).MovePlaylistSong(ctx, req.(*MovePlaylistSongRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SortPlaylistSongs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SortPlaylistSongsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SortPlaylistSongs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SortPlaylistSongs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SortPlaylistSongs(ctx, req.(*SortPlaylistSongsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetPlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPlaylistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetPlaylist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetPlaylist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetPlaylist(ctx, req.(*GetPlaylistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetPlaylistSongs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPlaylistSongsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetPlaylistSongs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetPlaylistSongs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetPlaylistSongs(ctx, req.(*GetPlaylistSongsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListPlaylistMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPlaylistMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListPlaylistMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListPlaylistMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListPlaylistMembers(ctx, req.(*ListPlaylistMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_InvitePlaylistMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvitePlaylistMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).InvitePlaylistMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_InvitePlaylistMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).InvitePlaylistMember(ctx, req.(*InvitePlaylistMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RemovePlaylistMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemovePlaylistMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RemovePlaylistMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RemovePlaylistMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RemovePlaylistMember(ctx, req.(*RemovePlaylistMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CreatePlaylistInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePlaylistInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CreatePlaylistInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CreatePlaylistInvite_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CreatePlaylistInvite(ctx, req.(*CreatePlaylistInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RevokePlaylistInvites_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokePlaylistInvitesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RevokePlaylistInvites(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RevokePlaylistInvites_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RevokePlaylistInvites(ctx, req.(*RevokePlaylistInvitesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_AcceptPlaylistInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptPlaylistInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AcceptPlaylistInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_AcceptPlaylistInvite_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AcceptPlaylistInvite(ctx, req.(*AcceptPlaylistInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListSharedPlaylists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSharedPlaylistsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListSharedPlaylists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListSharedPlaylists_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListSharedPlaylists(ctx, req.(*ListSharedPlaylistsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ImportPlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportPlaylistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ImportPlaylist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ImportPlaylist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ImportPlaylist(ctx, req.(*ImportPlaylistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ExportPlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportPlaylistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ExportPlaylist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ExportPlaylist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ExportPlaylist(ctx, req.(*ExportPlaylistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListPublicPlaylists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPublicPlaylistsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListPublicPlaylists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListPublicPlaylists_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListPublicPlaylists(ctx, req.(*ListPublicPlaylistsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedUserServiceServer) UpdatePlaylist(context.Context, *UpdatePlaylistRequest) (*UpdatePlaylistResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method UpdatePlaylist not implemented")
+
+func _UserService_GetPublicPlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPublicPlaylistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetPublicPlaylist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetPublicPlaylist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetPublicPlaylist(ctx, req.(*GetPublicPlaylistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedUserServiceServer) DeletePlaylist(context.Context, *DeletePlaylistRequest) (*DeletePlaylistResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method DeletePlaylist not implemented")
+
+func _UserService_FollowPlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FollowPlaylistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).FollowPlaylist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_FollowPlaylist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).FollowPlaylist(ctx, req.(*FollowPlaylistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedUserServiceServer) ListPlaylists(context.Context, *ListPlaylistsRequest) (*ListPlaylistsResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ListPlaylists not implemented")
+
+func _UserService_UnfollowPlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnfollowPlaylistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UnfollowPlaylist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UnfollowPlaylist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UnfollowPlaylist(ctx, req.(*UnfollowPlaylistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedUserServiceServer) AddSongToPlaylist(context.Context, *AddSongToPlaylistRequest) (*AddSongToPlaylistResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method AddSongToPlaylist not implemented")
+
+func _UserService_ListFollowedPlaylists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFollowedPlaylistsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListFollowedPlaylists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListFollowedPlaylists_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListFollowedPlaylists(ctx, req.(*ListFollowedPlaylistsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedUserServiceServer) RemoveSongFromPlaylist(context.Context, *RemoveSongFromPlaylistRequest) (*RemoveSongFromPlaylistResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method RemoveSongFromPlaylist not implemented")
+
+func _UserService_ForkPlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForkPlaylistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ForkPlaylist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ForkPlaylist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ForkPlaylist(ctx, req.(*ForkPlaylistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedUserServiceServer) GetPlaylistSongs(context.Context, *GetPlaylistSongsRequest) (*GetPlaylistSongsResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method GetPlaylistSongs not implemented")
+
+func _UserService_ListDeletedPlaylists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeletedPlaylistsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListDeletedPlaylists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListDeletedPlaylists_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListDeletedPlaylists(ctx, req.(*ListDeletedPlaylistsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
-func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
 
-// UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to UserServiceServer will
-// result in compilation errors.
-type UnsafeUserServiceServer interface {
-	mustEmbedUnimplementedUserServiceServer()
+func _UserService_ListDeletedFavorites_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeletedFavoritesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListDeletedFavorites(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListDeletedFavorites_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListDeletedFavorites(ctx, req.(*ListDeletedFavoritesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterUserServiceServer(s grpc.ServiceRegistrar, srv UserServiceServer) {
-	// If the following call panics, it indicates UnimplementedUserServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _UserService_RestorePlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestorePlaylistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&UserService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(UserServiceServer).RestorePlaylist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RestorePlaylist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RestorePlaylist(ctx, req.(*RestorePlaylistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_AddFavorite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddFavoriteRequest)
+func _UserService_RestoreFavorite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreFavoriteRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).AddFavorite(ctx, in)
+		return srv.(UserServiceServer).RestoreFavorite(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_AddFavorite_FullMethodName,
+		FullMethod: UserService_RestoreFavorite_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).AddFavorite(ctx, req.(*AddFavoriteRequest))
+		return srv.(UserServiceServer).RestoreFavorite(ctx, req.(*RestoreFavoriteRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_RemoveFavorite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RemoveFavoriteRequest)
+func _UserService_ExportUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportUserDataRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).RemoveFavorite(ctx, in)
+		return srv.(UserServiceServer).ExportUserData(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_RemoveFavorite_FullMethodName,
+		FullMethod: UserService_ExportUserData_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).RemoveFavorite(ctx, req.(*RemoveFavoriteRequest))
+		return srv.(UserServiceServer).ExportUserData(ctx, req.(*ExportUserDataRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_ListFavorites_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListFavoritesRequest)
+func _UserService_EraseUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EraseUserDataRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).ListFavorites(ctx, in)
+		return srv.(UserServiceServer).EraseUserData(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_ListFavorites_FullMethodName,
+		FullMethod: UserService_EraseUserData_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).ListFavorites(ctx, req.(*ListFavoritesRequest))
+		return srv.(UserServiceServer).EraseUserData(ctx, req.(*EraseUserDataRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_AddPlayHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddPlayHistoryRequest)
+func _UserService_GetListeningStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetListeningStatsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).AddPlayHistory(ctx, in)
+		return srv.(UserServiceServer).GetListeningStats(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_AddPlayHistory_FullMethodName,
+		FullMethod: UserService_GetListeningStats_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).AddPlayHistory(ctx, req.(*AddPlayHistoryRequest))
+		return srv.(UserServiceServer).GetListeningStats(ctx, req.(*GetListeningStatsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_ListPlayHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListPlayHistoryRequest)
+func _UserService_GetYearInReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetYearInReviewRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).ListPlayHistory(ctx, in)
+		return srv.(UserServiceServer).GetYearInReview(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_ListPlayHistory_FullMethodName,
+		FullMethod: UserService_GetYearInReview_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).ListPlayHistory(ctx, req.(*ListPlayHistoryRequest))
+		return srv.(UserServiceServer).GetYearInReview(ctx, req.(*GetYearInReviewRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_CreatePlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreatePlaylistRequest)
+func _UserService_GetRecommendations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRecommendationsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).CreatePlaylist(ctx, in)
+		return srv.(UserServiceServer).GetRecommendations(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_CreatePlaylist_FullMethodName,
+		FullMethod: UserService_GetRecommendations_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).CreatePlaylist(ctx, req.(*CreatePlaylistRequest))
+		return srv.(UserServiceServer).GetRecommendations(ctx, req.(*GetRecommendationsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_UpdatePlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdatePlaylistRequest)
+func _UserService_ListNewReleases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNewReleasesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).UpdatePlaylist(ctx, in)
+		return srv.(UserServiceServer).ListNewReleases(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_UpdatePlaylist_FullMethodName,
+		FullMethod: UserService_ListNewReleases_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).UpdatePlaylist(ctx, req.(*UpdatePlaylistRequest))
+		return srv.(UserServiceServer).ListNewReleases(ctx, req.(*ListNewReleasesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_DeletePlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeletePlaylistRequest)
+func _UserService_GetPlayQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPlayQueueRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).DeletePlaylist(ctx, in)
+		return srv.(UserServiceServer).GetPlayQueue(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_DeletePlaylist_FullMethodName,
+		FullMethod: UserService_GetPlayQueue_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).DeletePlaylist(ctx, req.(*DeletePlaylistRequest))
+		return srv.(UserServiceServer).GetPlayQueue(ctx, req.(*GetPlayQueueRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_ListPlaylists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListPlaylistsRequest)
+func _UserService_SavePlayQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SavePlayQueueRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).ListPlaylists(ctx, in)
+		return srv.(UserServiceServer).SavePlayQueue(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_ListPlaylists_FullMethodName,
+		FullMethod: UserService_SavePlayQueue_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).ListPlaylists(ctx, req.(*ListPlaylistsRequest))
+		return srv.(UserServiceServer).SavePlayQueue(ctx, req.(*SavePlayQueueRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_AddSongToPlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddSongToPlaylistRequest)
+func _UserService_SetPlayQueueIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPlayQueueIndexRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).AddSongToPlaylist(ctx, in)
+		return srv.(UserServiceServer).SetPlayQueueIndex(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_AddSongToPlaylist_FullMethodName,
+		FullMethod: UserService_SetPlayQueueIndex_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).AddSongToPlaylist(ctx, req.(*AddSongToPlaylistRequest))
+		return srv.(UserServiceServer).SetPlayQueueIndex(ctx, req.(*SetPlayQueueIndexRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_RemoveSongFromPlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RemoveSongFromPlaylistRequest)
+func _UserService_UpdatePlaybackPosition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePlaybackPositionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).RemoveSongFromPlaylist(ctx, in)
+		return srv.(UserServiceServer).UpdatePlaybackPosition(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_RemoveSongFromPlaylist_FullMethodName,
+		FullMethod: UserService_UpdatePlaybackPosition_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).RemoveSongFromPlaylist(ctx, req.(*RemoveSongFromPlaylistRequest))
+		return srv.(UserServiceServer).UpdatePlaybackPosition(ctx, req.(*UpdatePlaybackPositionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_GetPlaylistSongs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetPlaylistSongsRequest)
+func _UserService_GetPlaybackPositions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPlaybackPositionsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).GetPlaylistSongs(ctx, in)
+		return srv.(UserServiceServer).GetPlaybackPositions(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_GetPlaylistSongs_FullMethodName,
+		FullMethod: UserService_GetPlaybackPositions_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).GetPlaylistSongs(ctx, req.(*GetPlaylistSongsRequest))
+		return srv.(UserServiceServer).GetPlaybackPositions(ctx, req.(*GetPlaybackPositionsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -565,10 +1913,150 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveSongFromPlaylist",
 			Handler:    _UserService_RemoveSongFromPlaylist_Handler,
 		},
+		{
+			MethodName: "AddSongsToPlaylist",
+			Handler:    _UserService_AddSongsToPlaylist_Handler,
+		},
+		{
+			MethodName: "RemoveSongsFromPlaylist",
+			Handler:    _UserService_RemoveSongsFromPlaylist_Handler,
+		},
+		{
+			MethodName: "MovePlaylistSong",
+			Handler:    _UserService_MovePlaylistSong_Handler,
+		},
+		{
+			MethodName: "SortPlaylistSongs",
+			Handler:    _UserService_SortPlaylistSongs_Handler,
+		},
+		{
+			MethodName: "GetPlaylist",
+			Handler:    _UserService_GetPlaylist_Handler,
+		},
 		{
 			MethodName: "GetPlaylistSongs",
 			Handler:    _UserService_GetPlaylistSongs_Handler,
 		},
+		{
+			MethodName: "ListPlaylistMembers",
+			Handler:    _UserService_ListPlaylistMembers_Handler,
+		},
+		{
+			MethodName: "InvitePlaylistMember",
+			Handler:    _UserService_InvitePlaylistMember_Handler,
+		},
+		{
+			MethodName: "RemovePlaylistMember",
+			Handler:    _UserService_RemovePlaylistMember_Handler,
+		},
+		{
+			MethodName: "CreatePlaylistInvite",
+			Handler:    _UserService_CreatePlaylistInvite_Handler,
+		},
+		{
+			MethodName: "RevokePlaylistInvites",
+			Handler:    _UserService_RevokePlaylistInvites_Handler,
+		},
+		{
+			MethodName: "AcceptPlaylistInvite",
+			Handler:    _UserService_AcceptPlaylistInvite_Handler,
+		},
+		{
+			MethodName: "ListSharedPlaylists",
+			Handler:    _UserService_ListSharedPlaylists_Handler,
+		},
+		{
+			MethodName: "ImportPlaylist",
+			Handler:    _UserService_ImportPlaylist_Handler,
+		},
+		{
+			MethodName: "ExportPlaylist",
+			Handler:    _UserService_ExportPlaylist_Handler,
+		},
+		{
+			MethodName: "ListPublicPlaylists",
+			Handler:    _UserService_ListPublicPlaylists_Handler,
+		},
+		{
+			MethodName: "GetPublicPlaylist",
+			Handler:    _UserService_GetPublicPlaylist_Handler,
+		},
+		{
+			MethodName: "FollowPlaylist",
+			Handler:    _UserService_FollowPlaylist_Handler,
+		},
+		{
+			MethodName: "UnfollowPlaylist",
+			Handler:    _UserService_UnfollowPlaylist_Handler,
+		},
+		{
+			MethodName: "ListFollowedPlaylists",
+			Handler:    _UserService_ListFollowedPlaylists_Handler,
+		},
+		{
+			MethodName: "ForkPlaylist",
+			Handler:    _UserService_ForkPlaylist_Handler,
+		},
+		{
+			MethodName: "ListDeletedPlaylists",
+			Handler:    _UserService_ListDeletedPlaylists_Handler,
+		},
+		{
+			MethodName: "ListDeletedFavorites",
+			Handler:    _UserService_ListDeletedFavorites_Handler,
+		},
+		{
+			MethodName: "RestorePlaylist",
+			Handler:    _UserService_RestorePlaylist_Handler,
+		},
+		{
+			MethodName: "RestoreFavorite",
+			Handler:    _UserService_RestoreFavorite_Handler,
+		},
+		{
+			MethodName: "ExportUserData",
+			Handler:    _UserService_ExportUserData_Handler,
+		},
+		{
+			MethodName: "EraseUserData",
+			Handler:    _UserService_EraseUserData_Handler,
+		},
+		{
+			MethodName: "GetListeningStats",
+			Handler:    _UserService_GetListeningStats_Handler,
+		},
+		{
+			MethodName: "GetYearInReview",
+			Handler:    _UserService_GetYearInReview_Handler,
+		},
+		{
+			MethodName: "GetRecommendations",
+			Handler:    _UserService_GetRecommendations_Handler,
+		},
+		{
+			MethodName: "ListNewReleases",
+			Handler:    _UserService_ListNewReleases_Handler,
+		},
+		{
+			MethodName: "GetPlayQueue",
+			Handler:    _UserService_GetPlayQueue_Handler,
+		},
+		{
+			MethodName: "SavePlayQueue",
+			Handler:    _UserService_SavePlayQueue_Handler,
+		},
+		{
+			MethodName: "SetPlayQueueIndex",
+			Handler:    _UserService_SetPlayQueueIndex_Handler,
+		},
+		{
+			MethodName: "UpdatePlaybackPosition",
+			Handler:    _UserService_UpdatePlaybackPosition_Handler,
+		},
+		{
+			MethodName: "GetPlaybackPositions",
+			Handler:    _UserService_GetPlaybackPositions_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "user/v1/user.proto",